@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+
+	domainEVV "caregiver/src/domain/evv"
+
+	"github.com/spf13/cobra"
+)
+
+// newResendFailedCommand requeues EVV submissions that ran out of retries.
+// They are the service's only outbound deliveries that can fail for good.
+func newResendFailedCommand() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "resend-failed",
+		Short: "Requeue EVV submissions that could not be delivered",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			failed, err := app.EVVUseCase.GetSubmissions(domainEVV.SubmissionFilter{Status: domainEVV.StatusFailed})
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			var requeued, errored int
+			for _, submission := range *failed {
+				if dryRun {
+					fmt.Fprintf(out, "would requeue %s (visit %s)\n", submission.ID, submission.ScheduleID)
+					continue
+				}
+				if _, err := app.EVVUseCase.Resubmit(submission.ID); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", submission.ID, err)
+					errored++
+					continue
+				}
+				fmt.Fprintf(out, "requeued %s (visit %s)\n", submission.ID, submission.ScheduleID)
+				requeued++
+			}
+			fmt.Fprintf(out, "%d failed, %d requeued\n", len(*failed), requeued)
+			if errored > 0 {
+				return fmt.Errorf("%d submissions could not be requeued", errored)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "list the submissions without requeueing them")
+	return cmd
+}
@@ -0,0 +1,53 @@
+// Command caregiverctl runs operational tasks against the caregiver database
+// through the same use cases as the API, so fixes made from the command line
+// are validated, logged and announced to observers like any other change.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"caregiver/src/infrastructure/di"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// app is set up before any command runs.
+var app *di.ApplicationContext
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:          "caregiverctl",
+		Short:        "Operational tasks for the caregiver service",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			_ = godotenv.Load()
+			// Logs go to stderr so command output can be piped.
+			zapLogger, err := zap.NewProduction()
+			if err != nil {
+				return fmt.Errorf("error initializing logger: %w", err)
+			}
+			app, err = di.SetupDependencies(&logger.Logger{Log: zapLogger})
+			if err != nil {
+				return fmt.Errorf("error setting up dependencies: %w", err)
+			}
+			return nil
+		},
+	}
+	root.AddCommand(
+		newReissueTokensCommand(),
+		newResendFailedCommand(),
+		newTimesheetsCommand(),
+		newCloseStuckVisitsCommand(),
+	)
+	return root
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+const dateLayout = "2006-01-02"
+
+// timesheet is one caregiver's worked time and pay in a period.
+type timesheet struct {
+	CaregiverUserID uuid.UUID
+	Visits          int
+	Hours           float64
+	BaseAmount      float64
+	Amount          float64
+}
+
+// newTimesheetsCommand recomputes caregivers' hours and pay for a period from
+// their checked-in time and the pay rates and differentials in force now, e.g.
+// after a rate was corrected.
+func newTimesheetsCommand() *cobra.Command {
+	var from, to, caregiver string
+	cmd := &cobra.Command{
+		Use:   "timesheets --from YYYY-MM-DD --to YYYY-MM-DD",
+		Short: "Recompute caregiver hours and pay for a period",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, err := time.Parse(dateLayout, from)
+			if err != nil {
+				return errors.New("--from must be YYYY-MM-DD")
+			}
+			end, err := time.Parse(dateLayout, to)
+			if err != nil {
+				return errors.New("--to must be YYYY-MM-DD")
+			}
+			// The period includes the whole of its last day.
+			end = end.AddDate(0, 0, 1)
+			if !end.After(start) {
+				return errors.New("--to must not be before --from")
+			}
+			query := domainSchedule.SearchQuery{
+				Statuses: []string{"completed", "partially_completed"},
+				From:     &start,
+				To:       &end,
+				PageSize: 100,
+			}
+			if caregiver != "" {
+				caregiverID, err := uuid.Parse(caregiver)
+				if err != nil {
+					return errors.New("--caregiver must be a user ID")
+				}
+				query.AssignedUserIDs = []uuid.UUID{caregiverID}
+			}
+
+			sheets := make(map[uuid.UUID]*timesheet)
+			var skipped int
+			for query.Page = 1; ; query.Page++ {
+				result, _, err := app.ScheduleUseCase.SearchSchedules(query)
+				if err != nil {
+					return err
+				}
+				for _, schedule := range *result.Data {
+					pay, err := app.DifferentialUseCase.VisitPay(schedule.ID)
+					if err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "skipping visit %s: %v\n", schedule.ID, err)
+						skipped++
+						continue
+					}
+					sheet, ok := sheets[pay.CaregiverUserID]
+					if !ok {
+						sheet = &timesheet{CaregiverUserID: pay.CaregiverUserID}
+						sheets[pay.CaregiverUserID] = sheet
+					}
+					sheet.Visits++
+					sheet.Hours += pay.Hours
+					sheet.BaseAmount += pay.BaseAmount
+					sheet.Amount += pay.Amount
+				}
+				if query.Page >= result.TotalPages {
+					break
+				}
+			}
+
+			res := make([]*timesheet, 0, len(sheets))
+			for _, sheet := range sheets {
+				res = append(res, sheet)
+			}
+			sort.Slice(res, func(i, j int) bool {
+				return res[i].CaregiverUserID.String() < res[j].CaregiverUserID.String()
+			})
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', tabwriter.AlignRight)
+			fmt.Fprintln(w, "CAREGIVER\tVISITS\tHOURS\tBASE\tPAY\t")
+			for _, sheet := range res {
+				fmt.Fprintf(w, "%s\t%d\t%.2f\t%.2f\t%.2f\t\n", sheet.CaregiverUserID, sheet.Visits, sheet.Hours, sheet.BaseAmount, sheet.Amount)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if skipped > 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%d visits skipped\n", skipped)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "first day of the period (required)")
+	cmd.Flags().StringVar(&to, "to", "", "last day of the period (required)")
+	cmd.Flags().StringVar(&caregiver, "caregiver", "", "only this caregiver's user ID")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
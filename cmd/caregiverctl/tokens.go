@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func newReissueTokensCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reissue-tokens <user-id|email>",
+		Short: "Issue a fresh access and refresh token for a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := uuid.Parse(args[0])
+			if err != nil {
+				user, err := app.UserUseCase.GetByEmail(args[0])
+				if err != nil {
+					return err
+				}
+				if user.ID == uuid.Nil {
+					return fmt.Errorf("no user with email %s", args[0])
+				}
+				userID = user.ID
+			}
+			user, tokens, err := app.AuthUseCase.IssueTokens(userID)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "User:          %s (%s)\n", user.ID, user.Email)
+			fmt.Fprintf(out, "Access token:  %s\n", tokens.AccessToken)
+			fmt.Fprintf(out, "  expires      %s\n", tokens.ExpirationAccessDateTime.Format(time.RFC3339))
+			fmt.Fprintf(out, "Refresh token: %s\n", tokens.RefreshToken)
+			fmt.Fprintf(out, "  expires      %s\n", tokens.ExpirationRefreshDateTime.Format(time.RFC3339))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newCloseStuckVisitsCommand() *cobra.Command {
+	var olderThan time.Duration
+	cmd := &cobra.Command{
+		Use:   "close-stuck-visits",
+		Short: "Check out visits left in progress long after their slot ended",
+		Long: "Checks out every visit still in progress whose slot ended more than\n" +
+			"--older-than ago, at the time the slot ended, as if the caregiver had\n" +
+			"checked out on time.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if olderThan < 0 {
+				return fmt.Errorf("--older-than cannot be negative")
+			}
+			closed, err := app.ScheduleUseCase.CloseStuckVisits(time.Now().Add(-olderThan))
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			for _, schedule := range *closed {
+				fmt.Fprintf(out, "closed %s (caregiver %s, slot ended %s)\n", schedule.ID, schedule.AssignedUserID, schedule.ScheduledSlot.To.Format(time.RFC3339))
+			}
+			fmt.Fprintf(out, "%d visits closed\n", len(*closed))
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&olderThan, "older-than", 12*time.Hour, "how long after the slot ended a visit counts as stuck")
+	return cmd
+}
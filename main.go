@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	"caregiver/src/infrastructure/analytics"
 	"caregiver/src/infrastructure/di"
+	"caregiver/src/infrastructure/jobs"
 	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/repository/psql/shadow"
 	"caregiver/src/infrastructure/rest/middlewares"
 	"caregiver/src/infrastructure/rest/routes"
 
@@ -64,6 +68,10 @@ func main() {
 		loggerInstance.Panic("Error initializing application context", zap.Error(err))
 	}
 
+	// Start the periodic background jobs
+	jobs.Start(backgroundJobs(appContext), loggerInstance)
+	startShadowBackfill(appContext, loggerInstance)
+
 	// Setup router
 	router := setupRouter(appContext, loggerInstance)
 
@@ -97,10 +105,22 @@ func setupRouter(appContext *di.ApplicationContext, logger *logger.Logger) *gin.
 	router.Use(middlewares.ErrorHandler())
 	router.Use(middlewares.GinBodyLogMiddleware)
 	router.Use(middlewares.CommonHeaders)
+	router.Use(middlewares.DeprecationTelemetry(appContext.DeprecationUseCase))
+	router.Use(middlewares.ClientErrorTelemetry(appContext.ClientErrorUseCase))
+	router.Use(middlewares.FeatureUsage(analytics.NewQueue(analytics.NewSinkFromEnv(), 1000, logger), featureFlags()))
 
 	// Add logger middleware
 	router.Use(logger.GinZapLogger())
 
+	// Refuse caregiver app builds older than their platform's minimum version
+	router.Use(middlewares.AppVersion(appContext.AppVersionUseCase))
+
+	// Identify callers presenting a bearer token
+	router.Use(middlewares.IdentifyCaller(appContext.JWTService, appContext.UserRepository))
+
+	// Meter calls made with an API key against the key's monthly quota
+	router.Use(middlewares.APIQuota(appContext.QuotaUseCase))
+
 	// Setup routes
 	routes.ApplicationRouter(router, appContext)
 	return router
@@ -116,6 +136,95 @@ func setupServer(router *gin.Engine, port string) *http.Server {
 	}
 }
 
+// backgroundJobs are the periodic jobs the server runs. Each interval is a Go
+// duration read from its environment variable; "0" disables the job.
+func backgroundJobs(appContext *di.ApplicationContext) []jobs.Job {
+	return []jobs.Job{
+		// Raise missed-visit alerts and run due escalations.
+		{Name: "alert sweep", IntervalEnv: "ALERT_SWEEP_INTERVAL", DefaultInterval: time.Minute, Run: func(now time.Time) error {
+			_, err := appContext.AlertUseCase.Sweep(now)
+			return err
+		}},
+		// Move visits whose slot ended without a check-in to "missed".
+		{Name: "missed visits", IntervalEnv: "MISSED_VISIT_INTERVAL", DefaultInterval: time.Minute, Run: func(now time.Time) error {
+			_, err := appContext.ScheduleUseCase.MarkMissedVisits(now)
+			return err
+		}},
+		// Raise alerts for clients whose care plan visit frequency will not be
+		// met this week.
+		{Name: "visit frequency monitor", IntervalEnv: "VISIT_FREQUENCY_INTERVAL", DefaultInterval: time.Hour, Run: func(now time.Time) error {
+			_, err := appContext.CarePlanUseCase.MonitorVisitFrequency(now)
+			return err
+		}},
+		// Send due visit reminders.
+		{Name: "reminder sweep", IntervalEnv: "REMINDER_SWEEP_INTERVAL", DefaultInterval: time.Minute, Run: func(now time.Time) error {
+			_, err := appContext.ReminderUseCase.Sweep(now)
+			return err
+		}},
+		// Expire arrival confirmations the client did not answer in time.
+		{Name: "attestation sweep", IntervalEnv: "ATTESTATION_SWEEP_INTERVAL", DefaultInterval: time.Minute, Run: func(now time.Time) error {
+			_, err := appContext.AttestationUseCase.Sweep(now)
+			return err
+		}},
+		// Send queued visits to their state EVV aggregators.
+		{Name: "EVV submission", IntervalEnv: "EVV_SUBMIT_INTERVAL", DefaultInterval: 5 * time.Minute, Run: func(now time.Time) error {
+			_, err := appContext.EVVUseCase.Sweep(now)
+			return err
+		}},
+		// Remind coordinators of equipment coming due for maintenance.
+		{Name: "equipment sweep", IntervalEnv: "EQUIPMENT_SWEEP_INTERVAL", DefaultInterval: time.Hour, Run: func(now time.Time) error {
+			_, err := appContext.EquipmentUseCase.Sweep(now)
+			return err
+		}},
+		// Collect the results of pending background checks.
+		{Name: "screening sweep", IntervalEnv: "SCREENING_SWEEP_INTERVAL", DefaultInterval: 15 * time.Minute, Run: func(now time.Time) error {
+			_, err := appContext.ScreeningUseCase.Sweep(now)
+			return err
+		}},
+		// Email the saved reports that are due.
+		{Name: "saved report delivery", IntervalEnv: "REPORT_SWEEP_INTERVAL", DefaultInterval: 5 * time.Minute, Run: func(now time.Time) error {
+			_, err := appContext.ReportUseCase.Sweep(now)
+			return err
+		}},
+		// Send queued email, text and push notifications.
+		{Name: "notification delivery", IntervalEnv: "NOTIFICATION_DELIVERY_INTERVAL", DefaultInterval: 5 * time.Second, Run: func(now time.Time) error {
+			_, err := appContext.NotificationQueue.Deliver()
+			return err
+		}},
+		// Send queued webhook deliveries and retry failed ones.
+		{Name: "webhook delivery", IntervalEnv: "WEBHOOK_DELIVERY_INTERVAL", DefaultInterval: 15 * time.Second, Run: func(now time.Time) error {
+			_, err := appContext.WebhookUseCase.Sweep(now)
+			return err
+		}},
+	}
+}
+
+// startShadowBackfill copies existing users into the shadow tables turned on
+// by SHADOW_WRITES, so comparisons cover records written before the switch.
+func startShadowBackfill(appContext *di.ApplicationContext, loggerInstance *logger.Logger) {
+	backfiller, ok := appContext.UserRepository.(shadow.Backfiller)
+	if !ok {
+		return
+	}
+	go func() {
+		if _, err := backfiller.Backfill(); err != nil {
+			loggerInstance.Error("Shadow backfill failed", zap.Error(err))
+		}
+	}()
+}
+
+// featureFlags lists the features switched on for this deployment, named in
+// the comma-separated FEATURE_FLAGS, for analytics events.
+func featureFlags() []string {
+	flags := []string{}
+	for _, flag := range strings.Split(os.Getenv("FEATURE_FLAGS"), ",") {
+		if flag = strings.TrimSpace(flag); flag != "" {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
 // Helper function
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -0,0 +1,81 @@
+package access
+
+import (
+	"errors"
+	"fmt"
+
+	domainAccess "caregiver/src/domain/access"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTeam "caregiver/src/domain/team"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IAccessUseCase interface {
+	Explain(kind domainAccess.Kind, entityID, userID uuid.UUID) (*domainAccess.Explanation, error)
+}
+
+type AccessUseCase struct {
+	userRepository     domainUser.IUserRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	teamRepository     domainTeam.ITeamRepository
+	rules              []domainAccess.Rule
+	Logger             *logger.Logger
+}
+
+func NewAccessUseCase(userRepository domainUser.IUserRepository, scheduleRepository domainSchedule.IScheduleRepository, teamRepository domainTeam.ITeamRepository, loggerInstance *logger.Logger) IAccessUseCase {
+	return &AccessUseCase{
+		userRepository:     userRepository,
+		scheduleRepository: scheduleRepository,
+		teamRepository:     teamRepository,
+		rules:              domainAccess.Policy,
+		Logger:             loggerInstance,
+	}
+}
+
+// Explain walks the access policy for a user looking at a record and reports
+// which rule let them in or kept them out.
+func (u *AccessUseCase) Explain(kind domainAccess.Kind, entityID, userID uuid.UUID) (*domainAccess.Explanation, error) {
+	u.Logger.Info("Explaining access", zap.String("kind", string(kind)), zap.String("entityID", entityID.String()), zap.String("userID", userID.String()))
+	if !kind.IsValid() {
+		return nil, domainErrors.NewAppError(fmt.Errorf("type must be one of %v", domainAccess.Kinds), domainErrors.ValidationError)
+	}
+	viewer, err := u.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("user not found"), domainErrors.NotFound)
+	}
+	facts := domainAccess.Facts{Viewer: *viewer, Kind: kind, EntityID: entityID}
+
+	switch kind {
+	case domainAccess.KindUser:
+		subject, err := u.userRepository.GetByID(entityID)
+		if err != nil {
+			return nil, err
+		}
+		facts.SubjectRole = subject.Role
+		facts.OwnerIDs = []uuid.UUID{subject.ID}
+	case domainAccess.KindSchedule:
+		schedule, err := u.scheduleRepository.GetScheduleByID(entityID)
+		if err != nil {
+			return nil, err
+		}
+		facts.OwnerIDs = []uuid.UUID{schedule.ClientUserID, schedule.AssignedUserID}
+	}
+
+	teams, err := u.teamRepository.GetBySupervisor(userID)
+	if err != nil {
+		return nil, err
+	}
+	teamIDs := make([]uuid.UUID, len(*teams))
+	for i, team := range *teams {
+		teamIDs[i] = team.ID
+	}
+	if facts.SupervisedIDs, err = u.teamRepository.GetMemberIDs(teamIDs); err != nil {
+		return nil, err
+	}
+	return domainAccess.Explain(u.rules, facts), nil
+}
@@ -0,0 +1,136 @@
+package access
+
+import (
+	"errors"
+	"testing"
+
+	domainAccess "caregiver/src/domain/access"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTeam "caregiver/src/domain/team"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return user, nil
+}
+
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedule *domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	if m.schedule.ID != id {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return m.schedule, nil
+}
+
+// mockTeamRepository holds a single team
+type mockTeamRepository struct {
+	domainTeam.ITeamRepository
+	team domainTeam.Team
+}
+
+func (m *mockTeamRepository) GetBySupervisor(supervisorUserID uuid.UUID) (*[]domainTeam.Team, error) {
+	teams := []domainTeam.Team{}
+	if m.team.SupervisorUserID == supervisorUserID {
+		teams = append(teams, m.team)
+	}
+	return &teams, nil
+}
+
+func (m *mockTeamRepository) GetMemberIDs(teamIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(teamIDs) == 0 {
+		return nil, nil
+	}
+	return m.team.MemberUserIDs, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func decisiveRule(e *domainAccess.Explanation) string {
+	for _, step := range e.Steps {
+		if step.Decisive {
+			return step.Rule
+		}
+	}
+	return ""
+}
+
+func TestExplain(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	admin, supervisor, caregiver, other, client, inactive := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{
+		admin:      {ID: admin, Role: domainUser.RoleAdmin, Status: true},
+		supervisor: {ID: supervisor, Role: domainUser.RoleCaregiver, Status: true},
+		caregiver:  {ID: caregiver, Role: domainUser.RoleCaregiver, Status: true},
+		other:      {ID: other, Role: domainUser.RoleCaregiver, Status: true},
+		client:     {ID: client, Role: domainUser.RoleClient, Status: true},
+		inactive:   {ID: inactive, Role: domainUser.RoleAdmin, Status: false},
+	}}
+	visit := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: client, AssignedUserID: caregiver}
+	teams := &mockTeamRepository{team: domainTeam.Team{ID: uuid.New(), SupervisorUserID: supervisor, MemberUserIDs: []uuid.UUID{caregiver}}}
+	useCase := NewAccessUseCase(users, &mockScheduleRepository{schedule: visit}, teams, loggerInstance)
+
+	for _, tc := range []struct {
+		name    string
+		kind    domainAccess.Kind
+		entity  uuid.UUID
+		viewer  uuid.UUID
+		allowed bool
+		level   string
+		rule    string
+	}{
+		{"Coordinators see every visit", domainAccess.KindSchedule, visit.ID, admin, true, domainAccess.LevelFull, "role:admin"},
+		{"Deactivated accounts see nothing", domainAccess.KindSchedule, visit.ID, inactive, false, domainAccess.LevelNone, "account:active"},
+		{"Assigned caregiver sees the visit", domainAccess.KindSchedule, visit.ID, caregiver, true, domainAccess.LevelFull, "ownership"},
+		{"Supervisor sees the member's visit", domainAccess.KindSchedule, visit.ID, supervisor, true, domainAccess.LevelFull, "team:supervisor"},
+		{"Other caregivers do not see the visit", domainAccess.KindSchedule, visit.ID, other, false, domainAccess.LevelNone, "default"},
+		{"Caregivers see clients in part", domainAccess.KindUser, client, other, true, domainAccess.LevelLimited, "directory:caregiver"},
+		{"Clients see caregivers in part", domainAccess.KindUser, caregiver, client, true, domainAccess.LevelLimited, "directory:basic"},
+		{"Users see themselves in full", domainAccess.KindUser, client, client, true, domainAccess.LevelFull, "ownership"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			explanation, err := useCase.Explain(tc.kind, tc.entity, tc.viewer)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if explanation.Allowed != tc.allowed || explanation.Level != tc.level || decisiveRule(explanation) != tc.rule {
+				t.Errorf("expected allowed=%v level=%s by %s, got %+v", tc.allowed, tc.level, tc.rule, explanation)
+			}
+			if len(explanation.Steps) != len(domainAccess.Policy) {
+				t.Errorf("expected every rule to be listed, got %d steps", len(explanation.Steps))
+			}
+		})
+	}
+
+	if _, err := useCase.Explain("invoices", visit.ID, admin); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected validation error for an unknown type, got %v", err)
+	}
+	if _, err := useCase.Explain(domainAccess.KindSchedule, uuid.New(), admin); errorType(err) != domainErrors.NotFound {
+		t.Errorf("expected not found for a missing visit, got %v", err)
+	}
+}
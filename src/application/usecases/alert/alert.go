@@ -0,0 +1,453 @@
+package alert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainAlert "caregiver/src/domain/alert"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTeam "caregiver/src/domain/team"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MissedVisitGrace is how long after a visit's scheduled start it may remain
+// without a check-in before a missed-visit alert is raised.
+const MissedVisitGrace = 15 * time.Minute
+
+// SweepResult counts the work done by one sweep.
+type SweepResult struct {
+	Raised    int
+	Escalated int
+}
+
+type IAlertUseCase interface {
+	CreatePolicy(policy *domainAlert.Policy) (*domainAlert.Policy, error)
+	GetPolicyByID(id uuid.UUID) (*domainAlert.Policy, error)
+	GetPolicies() (*[]domainAlert.Policy, error)
+	UpdatePolicy(policy *domainAlert.Policy) (*domainAlert.Policy, error)
+	DeletePolicy(id uuid.UUID) error
+	RaiseIncident(scheduleID uuid.UUID, message string) (*domainAlert.Alert, error)
+	RaiseEmergency(scheduleID uuid.UUID, location domainSchedule.Location, note string, now time.Time) (*domainAlert.Alert, error)
+	// RaiseUnderService opens an under-service alert for a client unless one
+	// was already raised since weekStart; raised reports which happened.
+	RaiseUnderService(clientUserID uuid.UUID, weekStart time.Time, message string, now time.Time) (alert *domainAlert.Alert, raised bool, err error)
+	GetAlerts(status string) (*[]domainAlert.Alert, error)
+	GetAlertByID(id uuid.UUID) (*domainAlert.Alert, error)
+	Acknowledge(alertID, userID uuid.UUID) (*domainAlert.Alert, error)
+	Sweep(now time.Time) (*SweepResult, error)
+}
+
+type AlertUseCase struct {
+	alertRepository    domainAlert.IAlertRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	teamRepository     domainTeam.ITeamRepository
+	notifier           notification.INotifier
+	Logger             *logger.Logger
+}
+
+func NewAlertUseCase(alertRepository domainAlert.IAlertRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, teamRepository domainTeam.ITeamRepository, notifier notification.INotifier, loggerInstance *logger.Logger) IAlertUseCase {
+	return &AlertUseCase{
+		alertRepository:    alertRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		teamRepository:     teamRepository,
+		notifier:           notifier,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *AlertUseCase) CreatePolicy(policy *domainAlert.Policy) (*domainAlert.Policy, error) {
+	u.Logger.Info("Creating escalation policy", zap.String("name", policy.Name), zap.String("alertType", policy.AlertType))
+	policy.ID = uuid.New()
+	policy.Active = true
+	if err := u.validatePolicy(policy); err != nil {
+		return nil, err
+	}
+	return u.alertRepository.CreatePolicy(policy)
+}
+
+func (u *AlertUseCase) GetPolicyByID(id uuid.UUID) (*domainAlert.Policy, error) {
+	u.Logger.Info("Getting escalation policy by ID", zap.String("id", id.String()))
+	return u.alertRepository.GetPolicyByID(id)
+}
+
+func (u *AlertUseCase) GetPolicies() (*[]domainAlert.Policy, error) {
+	u.Logger.Info("Getting escalation policies")
+	return u.alertRepository.GetPolicies()
+}
+
+// UpdatePolicy replaces a policy. Open alerts pick up the new steps at their
+// next escalation.
+func (u *AlertUseCase) UpdatePolicy(policy *domainAlert.Policy) (*domainAlert.Policy, error) {
+	u.Logger.Info("Updating escalation policy", zap.String("id", policy.ID.String()))
+	if _, err := u.alertRepository.GetPolicyByID(policy.ID); err != nil {
+		return nil, err
+	}
+	if err := u.validatePolicy(policy); err != nil {
+		return nil, err
+	}
+	return u.alertRepository.UpdatePolicy(policy)
+}
+
+func (u *AlertUseCase) DeletePolicy(id uuid.UUID) error {
+	u.Logger.Info("Deleting escalation policy", zap.String("id", id.String()))
+	return u.alertRepository.DeletePolicy(id)
+}
+
+// RaiseIncident opens an incident alert for a visit and notifies the first
+// step of the incident policy.
+func (u *AlertUseCase) RaiseIncident(scheduleID uuid.UUID, message string) (*domainAlert.Alert, error) {
+	u.Logger.Info("Raising incident alert", zap.String("scheduleID", scheduleID.String()))
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, domainErrors.NewAppError(errors.New("message is required"), domainErrors.ValidationError)
+	}
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	return u.raise(newAlert(domainAlert.TypeIncident, schedule, message), time.Now().UTC())
+}
+
+// RaiseEmergency opens an incident from a caregiver's SOS during a visit.
+// Every step of the incident policy is notified straight away rather than
+// waiting out the step delays.
+func (u *AlertUseCase) RaiseEmergency(scheduleID uuid.UUID, location domainSchedule.Location, note string, now time.Time) (*domainAlert.Alert, error) {
+	u.Logger.Warn("Raising emergency alert", zap.String("scheduleID", scheduleID.String()))
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.CheckinTime == nil || schedule.CheckoutTime != nil {
+		return nil, domainErrors.NewAppError(errors.New("an emergency can only be raised during a visit"), domainErrors.ValidationError)
+	}
+	if (location.Lat == nil) != (location.Long == nil) {
+		return nil, domainErrors.NewAppError(errors.New("location needs both latitude and longitude"), domainErrors.ValidationError)
+	}
+
+	message := "Emergency SOS raised during the " + schedule.ServiceName + " visit"
+	if location.Lat != nil {
+		message += fmt.Sprintf(" at %.6f,%.6f", *location.Lat, *location.Long)
+	}
+	message += "."
+	if note = strings.TrimSpace(note); note != "" {
+		message += " " + note
+	}
+	alert := newAlert(domainAlert.TypeIncident, schedule, message)
+	alert.Emergency = true
+	alert.Location = location
+	return u.raise(alert, now)
+}
+
+func (u *AlertUseCase) RaiseUnderService(clientUserID uuid.UUID, weekStart time.Time, message string, now time.Time) (*domainAlert.Alert, bool, error) {
+	existing, err := u.alertRepository.GetAlertByClientSince(clientUserID, domainAlert.TypeUnderService, weekStart)
+	if err == nil {
+		return existing, false, nil
+	}
+	var appErr *domainErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Type != domainErrors.NotFound {
+		return nil, false, err
+	}
+	u.Logger.Info("Raising under-service alert", zap.String("clientUserID", clientUserID.String()))
+	alert, err := u.raise(&domainAlert.Alert{
+		ID:           uuid.New(),
+		Type:         domainAlert.TypeUnderService,
+		ClientUserID: &clientUserID,
+		Message:      message,
+		Status:       domainAlert.StatusOpen,
+	}, now)
+	if err != nil {
+		return nil, false, err
+	}
+	return alert, true, nil
+}
+
+func (u *AlertUseCase) GetAlerts(status string) (*[]domainAlert.Alert, error) {
+	u.Logger.Info("Getting alerts", zap.String("status", status))
+	return u.alertRepository.GetAlerts(status)
+}
+
+func (u *AlertUseCase) GetAlertByID(id uuid.UUID) (*domainAlert.Alert, error) {
+	u.Logger.Info("Getting alert by ID", zap.String("id", id.String()))
+	return u.alertRepository.GetAlertByID(id)
+}
+
+// Acknowledge stops further escalation. Anyone already notified may
+// acknowledge, as may any coordinator.
+func (u *AlertUseCase) Acknowledge(alertID, userID uuid.UUID) (*domainAlert.Alert, error) {
+	u.Logger.Info("Acknowledging alert", zap.String("alertID", alertID.String()), zap.String("userID", userID.String()))
+
+	alert, err := u.alertRepository.GetAlertByID(alertID)
+	if err != nil {
+		return nil, err
+	}
+	if alert.Status == domainAlert.StatusAcknowledged {
+		return nil, domainErrors.NewAppError(errors.New("alert is already acknowledged"), domainErrors.Conflict)
+	}
+	if !alert.WasNotified(userID) {
+		user, err := u.userRepository.GetByID(userID)
+		if err != nil || user.Role != domainUser.RoleAdmin {
+			return nil, domainErrors.NewAppError(errors.New("only notified users or coordinators can acknowledge an alert"), domainErrors.NotAuthorized)
+		}
+	}
+
+	now := time.Now().UTC()
+	alert.Status = domainAlert.StatusAcknowledged
+	alert.AcknowledgedBy = &userID
+	alert.AcknowledgedAt = &now
+	alert.NextEscalationAt = nil
+	return u.alertRepository.SaveAlert(alert)
+}
+
+// Sweep raises alerts for visits that have not started within the grace
+// period and escalates open alerts whose next step is due.
+func (u *AlertUseCase) Sweep(now time.Time) (*SweepResult, error) {
+	result := &SweepResult{}
+
+	overdue, err := u.scheduleRepository.GetUnstartedSchedulesBefore(now.Add(-MissedVisitGrace))
+	if err != nil {
+		return nil, err
+	}
+	for i := range *overdue {
+		schedule := &(*overdue)[i]
+		_, err := u.alertRepository.GetAlertBySchedule(schedule.ID, domainAlert.TypeMissedVisit)
+		if err == nil {
+			continue
+		}
+		var appErr *domainErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Type != domainErrors.NotFound {
+			return nil, err
+		}
+		message := fmt.Sprintf("%s visit scheduled for %s has not started.", schedule.ServiceName, schedule.ScheduledSlot.From.Format(time.RFC3339))
+		if _, err := u.raise(newAlert(domainAlert.TypeMissedVisit, schedule, message), now); err != nil {
+			return nil, err
+		}
+		result.Raised++
+	}
+
+	due, err := u.alertRepository.GetDueAlerts(now)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *due {
+		alert := &(*due)[i]
+		policy, err := u.policyFor(alert)
+		if err != nil {
+			return nil, err
+		}
+		if policy == nil {
+			alert.NextEscalationAt = nil
+		} else {
+			u.advance(alert, policy, now)
+		}
+		if _, err := u.alertRepository.SaveAlert(alert); err != nil {
+			return nil, err
+		}
+		result.Escalated++
+	}
+
+	if result.Raised > 0 || result.Escalated > 0 {
+		u.Logger.Info("Alert sweep completed", zap.Int("raised", result.Raised), zap.Int("escalated", result.Escalated))
+	}
+	return result, nil
+}
+
+func newAlert(alertType string, schedule *domainSchedule.Schedule, message string) *domainAlert.Alert {
+	return &domainAlert.Alert{
+		ID:              uuid.New(),
+		Type:            alertType,
+		ScheduleID:      &schedule.ID,
+		CaregiverUserID: &schedule.AssignedUserID,
+		ClientUserID:    &schedule.ClientUserID,
+		Message:         message,
+		Status:          domainAlert.StatusOpen,
+	}
+}
+
+// raise records a new alert and notifies any steps already due, or every
+// step for an emergency. Without an active policy for the type, coordinators
+// are notified once.
+func (u *AlertUseCase) raise(alert *domainAlert.Alert, now time.Time) (*domainAlert.Alert, error) {
+	policy, err := u.alertRepository.GetActivePolicy(alert.Type)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Type != domainErrors.NotFound {
+			return nil, err
+		}
+		u.Logger.Warn("No escalation policy for alert type, notifying coordinators", zap.String("alertType", alert.Type))
+		u.notify(alert, notification.Message{Role: domainUser.RoleAdmin})
+		return u.alertRepository.CreateAlert(alert)
+	}
+
+	alert.PolicyID = &policy.ID
+	if alert.Emergency {
+		for alert.NextStep < len(policy.Steps) {
+			u.notifyStep(alert, policy.Steps[alert.NextStep], now)
+			alert.NextStep++
+		}
+		return u.alertRepository.CreateAlert(alert)
+	}
+	first := now.Add(time.Duration(policy.Steps[0].DelayMinutes) * time.Minute)
+	alert.NextEscalationAt = &first
+	u.advance(alert, policy, now)
+	return u.alertRepository.CreateAlert(alert)
+}
+
+// advance notifies every step that is due by now. Step delays run from the
+// time the previous step was due, so a late sweep catches up rather than
+// stretching the chain.
+func (u *AlertUseCase) advance(alert *domainAlert.Alert, policy *domainAlert.Policy, now time.Time) {
+	for alert.NextEscalationAt != nil && !now.Before(*alert.NextEscalationAt) {
+		if alert.NextStep >= len(policy.Steps) {
+			alert.NextEscalationAt = nil
+			return
+		}
+		u.notifyStep(alert, policy.Steps[alert.NextStep], now)
+
+		alert.NextStep++
+		if alert.NextStep >= len(policy.Steps) {
+			alert.NextEscalationAt = nil
+			return
+		}
+		next := alert.NextEscalationAt.Add(time.Duration(policy.Steps[alert.NextStep].DelayMinutes) * time.Minute)
+		alert.NextEscalationAt = &next
+	}
+}
+
+// notifyStep notifies the recipient of the alert's next step, skipping the
+// step if it has none.
+func (u *AlertUseCase) notifyStep(alert *domainAlert.Alert, step domainAlert.Step, now time.Time) {
+	recipient, err := u.recipient(step, alert)
+	if err != nil {
+		u.Logger.Warn("Escalation step has no recipient, skipping",
+			zap.Error(err), zap.String("alertID", alert.ID.String()), zap.String("target", step.Target))
+		return
+	}
+	u.notify(alert, notification.Message{UserID: &recipient})
+	alert.Notifications = append(alert.Notifications, domainAlert.Notification{
+		Step:   alert.NextStep,
+		Target: step.Target,
+		UserID: recipient,
+		SentAt: now,
+	})
+}
+
+func (u *AlertUseCase) recipient(step domainAlert.Step, alert *domainAlert.Alert) (uuid.UUID, error) {
+	switch step.Target {
+	case domainAlert.TargetCaregiver:
+		if alert.CaregiverUserID == nil {
+			return uuid.Nil, errors.New("alert has no caregiver")
+		}
+		return *alert.CaregiverUserID, nil
+	case domainAlert.TargetSupervisor:
+		if alert.CaregiverUserID == nil {
+			return uuid.Nil, errors.New("alert has no caregiver")
+		}
+		team, err := u.teamRepository.GetByMember(*alert.CaregiverUserID)
+		if err != nil {
+			return uuid.Nil, errors.New("caregiver is not on a team")
+		}
+		return team.SupervisorUserID, nil
+	case domainAlert.TargetOnCall:
+		if step.UserID == nil {
+			return uuid.Nil, errors.New("on-call step has no user")
+		}
+		return *step.UserID, nil
+	}
+	return uuid.Nil, fmt.Errorf("unknown target %q", step.Target)
+}
+
+func (u *AlertUseCase) notify(alert *domainAlert.Alert, message notification.Message) {
+	message.Subject = alertSubjects[alert.Type]
+	message.Body = alert.Message
+	message.Data = map[string]interface{}{
+		"alertID":    alert.ID,
+		"scheduleID": alert.ScheduleID,
+	}
+	if alert.Emergency {
+		message.Subject = "Emergency SOS"
+		message.Data["emergency"] = true
+		if alert.Location.Lat != nil {
+			message.Data["latitude"] = *alert.Location.Lat
+			message.Data["longitude"] = *alert.Location.Long
+		}
+	}
+	if err := u.notifier.Notify(message); err != nil {
+		u.Logger.Error("Error sending alert notification", zap.Error(err), zap.String("alertID", alert.ID.String()))
+	}
+}
+
+// policyFor returns the alert's policy, or nil if it has since been deleted.
+func (u *AlertUseCase) policyFor(alert *domainAlert.Alert) (*domainAlert.Policy, error) {
+	if alert.PolicyID == nil {
+		return nil, nil
+	}
+	policy, err := u.alertRepository.GetPolicyByID(*alert.PolicyID)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+var alertSubjects = map[string]string{
+	domainAlert.TypeMissedVisit:  "Missed visit",
+	domainAlert.TypeIncident:     "Visit incident reported",
+	domainAlert.TypeUnderService: "Client under-served",
+}
+
+func (u *AlertUseCase) validatePolicy(policy *domainAlert.Policy) error {
+	policy.Name = strings.TrimSpace(policy.Name)
+	if policy.Name == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	if _, ok := alertSubjects[policy.AlertType]; !ok {
+		return domainErrors.NewAppError(errors.New("alert type must be 'missed_visit', 'incident' or 'under_service'"), domainErrors.ValidationError)
+	}
+	if len(policy.Steps) == 0 {
+		return domainErrors.NewAppError(errors.New("at least one step is required"), domainErrors.ValidationError)
+	}
+	for i, step := range policy.Steps {
+		if step.DelayMinutes < 0 {
+			return domainErrors.NewAppError(fmt.Errorf("step %d: delay cannot be negative", i+1), domainErrors.ValidationError)
+		}
+		switch step.Target {
+		case domainAlert.TargetCaregiver, domainAlert.TargetSupervisor:
+			policy.Steps[i].UserID = nil
+		case domainAlert.TargetOnCall:
+			if step.UserID == nil {
+				return domainErrors.NewAppError(fmt.Errorf("step %d: on-call steps need a user", i+1), domainErrors.ValidationError)
+			}
+			if _, err := u.userRepository.GetByID(*step.UserID); err != nil {
+				return domainErrors.NewAppError(fmt.Errorf("step %d: on-call user not found", i+1), domainErrors.ValidationError)
+			}
+		default:
+			return domainErrors.NewAppError(fmt.Errorf("step %d: target must be 'caregiver', 'supervisor' or 'on_call'", i+1), domainErrors.ValidationError)
+		}
+	}
+
+	if policy.Active {
+		policies, err := u.alertRepository.GetPolicies()
+		if err != nil {
+			return err
+		}
+		for _, other := range *policies {
+			if other.ID != policy.ID && other.Active && other.AlertType == policy.AlertType {
+				return domainErrors.NewAppError(errors.New("another active policy already covers this alert type"), domainErrors.Conflict)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package alert
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainAlert "caregiver/src/domain/alert"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTeam "caregiver/src/domain/team"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+type mockAlertRepository struct {
+	policies []domainAlert.Policy
+	alerts   map[uuid.UUID]domainAlert.Alert
+}
+
+func (m *mockAlertRepository) CreatePolicy(policy *domainAlert.Policy) (*domainAlert.Policy, error) {
+	m.policies = append(m.policies, *policy)
+	return policy, nil
+}
+
+func (m *mockAlertRepository) GetPolicyByID(id uuid.UUID) (*domainAlert.Policy, error) {
+	for _, p := range m.policies {
+		if p.ID == id {
+			return &p, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockAlertRepository) GetPolicies() (*[]domainAlert.Policy, error) {
+	return &m.policies, nil
+}
+
+func (m *mockAlertRepository) GetActivePolicy(alertType string) (*domainAlert.Policy, error) {
+	for _, p := range m.policies {
+		if p.Active && p.AlertType == alertType {
+			return &p, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockAlertRepository) UpdatePolicy(policy *domainAlert.Policy) (*domainAlert.Policy, error) {
+	return policy, nil
+}
+
+func (m *mockAlertRepository) DeletePolicy(id uuid.UUID) error {
+	return nil
+}
+
+func (m *mockAlertRepository) CreateAlert(alert *domainAlert.Alert) (*domainAlert.Alert, error) {
+	if alert.CreatedAt.IsZero() {
+		alert.CreatedAt = time.Now().UTC()
+	}
+	m.alerts[alert.ID] = *alert
+	return alert, nil
+}
+
+func (m *mockAlertRepository) GetAlertByID(id uuid.UUID) (*domainAlert.Alert, error) {
+	alert, ok := m.alerts[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &alert, nil
+}
+
+func (m *mockAlertRepository) GetAlerts(status string) (*[]domainAlert.Alert, error) {
+	alerts := []domainAlert.Alert{}
+	for _, alert := range m.alerts {
+		alerts = append(alerts, alert)
+	}
+	return &alerts, nil
+}
+
+func (m *mockAlertRepository) GetDueAlerts(now time.Time) (*[]domainAlert.Alert, error) {
+	due := []domainAlert.Alert{}
+	for _, alert := range m.alerts {
+		if alert.Status == domainAlert.StatusOpen && alert.NextEscalationAt != nil && !alert.NextEscalationAt.After(now) {
+			due = append(due, alert)
+		}
+	}
+	return &due, nil
+}
+
+func (m *mockAlertRepository) GetAlertBySchedule(scheduleID uuid.UUID, alertType string) (*domainAlert.Alert, error) {
+	for _, alert := range m.alerts {
+		if alert.ScheduleID != nil && *alert.ScheduleID == scheduleID && alert.Type == alertType {
+			return &alert, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockAlertRepository) GetAlertByClientSince(clientUserID uuid.UUID, alertType string, since time.Time) (*domainAlert.Alert, error) {
+	for _, alert := range m.alerts {
+		if alert.ClientUserID != nil && *alert.ClientUserID == clientUserID && alert.Type == alertType && !alert.CreatedAt.Before(since) {
+			return &alert, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockAlertRepository) SaveAlert(alert *domainAlert.Alert) (*domainAlert.Alert, error) {
+	m.alerts[alert.ID] = *alert
+	return alert, nil
+}
+
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	unstarted []domainSchedule.Schedule
+	visits    map[uuid.UUID]domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	visit, ok := m.visits[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &visit, nil
+}
+
+func (m *mockScheduleRepository) GetUnstartedSchedulesBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.unstarted {
+		if s.ScheduledSlot.From.Before(before) {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &user, nil
+}
+
+type mockTeamRepository struct {
+	domainTeam.ITeamRepository
+	team domainTeam.Team
+}
+
+func (m *mockTeamRepository) GetByMember(userID uuid.UUID) (*domainTeam.Team, error) {
+	if !m.team.HasMember(userID) {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &m.team, nil
+}
+
+type recordingNotifier struct {
+	messages []notification.Message
+}
+
+func (n *recordingNotifier) Notify(message notification.Message) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func (n *recordingNotifier) recipients() []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, m := range n.messages {
+		if m.UserID != nil {
+			ids = append(ids, *m.UserID)
+		}
+	}
+	return ids
+}
+
+func TestEscalationChain(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	supervisor := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	onCall := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	slot := time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+	visit := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiver.ID, ClientUserID: uuid.New(), ServiceName: "Morning care", VisitStatus: "upcoming"}
+	visit.ScheduledSlot.From = slot
+
+	alertRepo := &mockAlertRepository{alerts: make(map[uuid.UUID]domainAlert.Alert)}
+	notifier := &recordingNotifier{}
+	uc := NewAlertUseCase(alertRepo,
+		&mockScheduleRepository{unstarted: []domainSchedule.Schedule{visit}},
+		&mockUserRepository{users: map[uuid.UUID]domainUser.User{caregiver.ID: caregiver, supervisor.ID: supervisor, onCall.ID: onCall}},
+		&mockTeamRepository{team: domainTeam.Team{ID: uuid.New(), SupervisorUserID: supervisor.ID, MemberUserIDs: []uuid.UUID{caregiver.ID}}},
+		notifier, loggerInstance)
+
+	_, err = uc.CreatePolicy(&domainAlert.Policy{
+		Name:      "Missed visits",
+		AlertType: domainAlert.TypeMissedVisit,
+		Steps: []domainAlert.Step{
+			{Target: domainAlert.TargetCaregiver},
+			{Target: domainAlert.TargetSupervisor, DelayMinutes: 10},
+			{Target: domainAlert.TargetOnCall, DelayMinutes: 20, UserID: &onCall.ID},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.CreatePolicy(&domainAlert.Policy{Name: "Duplicate", AlertType: domainAlert.TypeMissedVisit, Steps: []domainAlert.Step{{Target: domainAlert.TargetCaregiver}}}); err == nil {
+		t.Error("expected conflict for a second active policy")
+	}
+
+	raisedAt := slot.Add(MissedVisitGrace + time.Minute)
+	result, err := uc.Sweep(slot.Add(MissedVisitGrace - time.Minute))
+	if err != nil || result.Raised != 0 {
+		t.Fatalf("expected nothing within the grace period, got %+v, %v", result, err)
+	}
+	if result, _ = uc.Sweep(raisedAt); result.Raised != 1 {
+		t.Fatalf("expected a missed-visit alert, got %+v", result)
+	}
+	if got := notifier.recipients(); len(got) != 1 || got[0] != caregiver.ID {
+		t.Fatalf("expected caregiver to be notified first, got %v", got)
+	}
+	if result, _ = uc.Sweep(raisedAt.Add(time.Minute)); result.Raised != 0 || result.Escalated != 0 {
+		t.Errorf("expected no duplicate alert or early escalation, got %+v", result)
+	}
+
+	if _, err = uc.Sweep(raisedAt.Add(10 * time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := notifier.recipients(); len(got) != 2 || got[1] != supervisor.ID {
+		t.Fatalf("expected supervisor to be notified second, got %v", got)
+	}
+
+	var alertID uuid.UUID
+	for id := range alertRepo.alerts {
+		alertID = id
+	}
+	acknowledged, err := uc.Acknowledge(alertID, supervisor.ID)
+	if err != nil || acknowledged.Status != domainAlert.StatusAcknowledged || acknowledged.NextEscalationAt != nil {
+		t.Fatalf("expected acknowledged alert, got %+v, %v", acknowledged, err)
+	}
+	var appErr *domainErrors.AppError
+	if _, err := uc.Acknowledge(alertID, onCall.ID); !errors.As(err, &appErr) || appErr.Type != domainErrors.Conflict {
+		t.Errorf("expected conflict for second acknowledgment, got %v", err)
+	}
+
+	if _, err = uc.Sweep(raisedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := notifier.recipients(); len(got) != 2 {
+		t.Errorf("expected escalation to stop after acknowledgment, got %v", got)
+	}
+}
+
+func TestAcknowledgeRequiresNotifiedUserOrCoordinator(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	stranger := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	alertRepo := &mockAlertRepository{alerts: make(map[uuid.UUID]domainAlert.Alert)}
+	alert := domainAlert.Alert{ID: uuid.New(), Status: domainAlert.StatusOpen}
+	alertRepo.alerts[alert.ID] = alert
+	uc := NewAlertUseCase(alertRepo, &mockScheduleRepository{},
+		&mockUserRepository{users: map[uuid.UUID]domainUser.User{stranger.ID: stranger}},
+		&mockTeamRepository{}, &recordingNotifier{}, loggerInstance)
+
+	var appErr *domainErrors.AppError
+	if _, err := uc.Acknowledge(alert.ID, stranger.ID); !errors.As(err, &appErr) || appErr.Type != domainErrors.NotAuthorized {
+		t.Errorf("expected not authorized, got %v", err)
+	}
+}
+
+func TestEmergencyNotifiesWholeChain(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	supervisor := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	onCall := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	checkin := time.Date(2025, 7, 16, 9, 5, 0, 0, time.UTC)
+	visit := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiver.ID, ClientUserID: uuid.New(), ServiceName: "Morning care", VisitStatus: "in_progress", CheckinTime: &checkin}
+	upcoming := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiver.ID, VisitStatus: "upcoming"}
+
+	alertRepo := &mockAlertRepository{alerts: make(map[uuid.UUID]domainAlert.Alert)}
+	notifier := &recordingNotifier{}
+	uc := NewAlertUseCase(alertRepo,
+		&mockScheduleRepository{visits: map[uuid.UUID]domainSchedule.Schedule{visit.ID: visit, upcoming.ID: upcoming}},
+		&mockUserRepository{users: map[uuid.UUID]domainUser.User{caregiver.ID: caregiver, supervisor.ID: supervisor, onCall.ID: onCall}},
+		&mockTeamRepository{team: domainTeam.Team{ID: uuid.New(), SupervisorUserID: supervisor.ID, MemberUserIDs: []uuid.UUID{caregiver.ID}}},
+		notifier, loggerInstance)
+
+	_, err = uc.CreatePolicy(&domainAlert.Policy{
+		Name:      "Incidents",
+		AlertType: domainAlert.TypeIncident,
+		Steps: []domainAlert.Step{
+			{Target: domainAlert.TargetSupervisor, DelayMinutes: 5},
+			{Target: domainAlert.TargetOnCall, DelayMinutes: 30, UserID: &onCall.ID},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := uc.RaiseEmergency(upcoming.ID, domainSchedule.Location{}, "", checkin); err == nil {
+		t.Error("expected an SOS outside a visit to be rejected")
+	}
+
+	lat, long := 40.7128, -74.006
+	alert, err := uc.RaiseEmergency(visit.ID, domainSchedule.Location{Lat: &lat, Long: &long}, "Client fell in the bathroom", checkin.Add(20*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if alert.Type != domainAlert.TypeIncident || !alert.Emergency || alert.Status != domainAlert.StatusOpen || *alert.Location.Lat != lat {
+		t.Errorf("expected an open emergency incident with the location, got %+v", alert)
+	}
+	if got := notifier.recipients(); len(got) != 2 || got[0] != supervisor.ID || got[1] != onCall.ID {
+		t.Errorf("expected the whole chain notified at once, got %v", got)
+	}
+	if alert.NextEscalationAt != nil || len(alert.Notifications) != 2 {
+		t.Errorf("expected nothing left to escalate, got %+v", alert)
+	}
+	if notifier.messages[0].Subject != "Emergency SOS" || notifier.messages[0].Data["latitude"] != lat {
+		t.Errorf("expected an SOS notification with the location, got %+v", notifier.messages[0])
+	}
+}
+
+func TestUnderServiceRaisedOncePerWeek(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	clientID := uuid.New()
+	alertRepo := &mockAlertRepository{alerts: make(map[uuid.UUID]domainAlert.Alert)}
+	notifier := &recordingNotifier{}
+	uc := NewAlertUseCase(alertRepo, &mockScheduleRepository{}, &mockUserRepository{}, &mockTeamRepository{}, notifier, loggerInstance)
+
+	now := time.Now().UTC()
+	weekStart := now.AddDate(0, 0, -1)
+	alert, raised, err := uc.RaiseUnderService(clientID, weekStart, "Needs 3 visits.", now)
+	if err != nil || !raised {
+		t.Fatalf("expected an alert to be raised, got %v (%v)", raised, err)
+	}
+	if alert.Type != domainAlert.TypeUnderService || *alert.ClientUserID != clientID || alert.ScheduleID != nil {
+		t.Errorf("expected an under-service alert for the client, got %+v", alert)
+	}
+	if len(notifier.messages) != 1 || notifier.messages[0].Role != domainUser.RoleAdmin || notifier.messages[0].Subject != "Client under-served" {
+		t.Errorf("expected coordinators told without a policy, got %+v", notifier.messages)
+	}
+
+	again, raised, err := uc.RaiseUnderService(clientID, weekStart, "Needs 3 visits.", now.Add(time.Hour))
+	if err != nil || raised || again.ID != alert.ID {
+		t.Errorf("expected the week's alert to be reused, got %+v raised=%v (%v)", again, raised, err)
+	}
+	if _, raised, _ := uc.RaiseUnderService(clientID, now.Add(time.Minute), "Needs 3 visits.", now.Add(time.Minute)); !raised {
+		t.Error("expected a new alert in the next week")
+	}
+}
@@ -0,0 +1,225 @@
+package announcement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainAnnouncement "caregiver/src/domain/announcement"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTeam "caregiver/src/domain/team"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	maxSubjectLength = 200
+	maxBodyLength    = 5000
+)
+
+type IAnnouncementUseCase interface {
+	Send(subject, body string, audience domainAnnouncement.Audience, callerID *uuid.UUID, now time.Time) (*domainAnnouncement.Announcement, error)
+	GetAll() (*[]domainAnnouncement.Announcement, error)
+	GetByID(id uuid.UUID) (*domainAnnouncement.Announcement, error)
+	GetDeliveries(id uuid.UUID) (*[]domainAnnouncement.Delivery, error)
+}
+
+type AnnouncementUseCase struct {
+	announcementRepository domainAnnouncement.IAnnouncementRepository
+	userRepository         domainUser.IUserRepository
+	teamRepository         domainTeam.ITeamRepository
+	scheduleRepository     domainSchedule.IScheduleRepository
+	notifier               notification.INotifier
+	Logger                 *logger.Logger
+}
+
+func NewAnnouncementUseCase(announcementRepository domainAnnouncement.IAnnouncementRepository, userRepository domainUser.IUserRepository, teamRepository domainTeam.ITeamRepository, scheduleRepository domainSchedule.IScheduleRepository, notifier notification.INotifier, loggerInstance *logger.Logger) IAnnouncementUseCase {
+	return &AnnouncementUseCase{
+		announcementRepository: announcementRepository,
+		userRepository:         userRepository,
+		teamRepository:         teamRepository,
+		scheduleRepository:     scheduleRepository,
+		notifier:               notifier,
+		Logger:                 loggerInstance,
+	}
+}
+
+// Send queues the announcement for every caregiver in the audience on the
+// channels they prefer, and archives it with a queued delivery for each of
+// them. The outcome on each channel replaces it as the notifier reports it;
+// a caregiver no channel could reach gets an unreachable delivery.
+func (u *AnnouncementUseCase) Send(subject, body string, audience domainAnnouncement.Audience, callerID *uuid.UUID, now time.Time) (*domainAnnouncement.Announcement, error) {
+	subject, body = strings.TrimSpace(subject), strings.TrimSpace(body)
+	if err := validate(subject, body, audience); err != nil {
+		return nil, err
+	}
+	caregivers, err := u.caregivers(audience)
+	if err != nil {
+		return nil, err
+	}
+	if len(caregivers) == 0 {
+		return nil, domainErrors.NewAppError(errors.New("no caregivers match the audience"), domainErrors.ValidationError)
+	}
+	if len(caregivers) > domainAnnouncement.MaxRecipients {
+		return nil, domainErrors.NewAppError(fmt.Errorf("an announcement reaches at most %d caregivers", domainAnnouncement.MaxRecipients), domainErrors.ValidationError)
+	}
+
+	announcement := &domainAnnouncement.Announcement{
+		ID:           uuid.New(),
+		Subject:      subject,
+		Body:         body,
+		Audience:     audience,
+		SentByUserID: callerID,
+		SentAt:       now,
+		Recipients:   len(caregivers),
+	}
+	u.Logger.Info("Sending announcement", zap.String("announcementID", announcement.ID.String()), zap.Int("recipients", len(caregivers)))
+
+	deliveries := make([]domainAnnouncement.Delivery, len(caregivers))
+	for i, caregiverID := range caregivers {
+		deliveries[i] = domainAnnouncement.Delivery{UserID: caregiverID, Status: domainAnnouncement.DeliveryQueued}
+	}
+	created, err := u.announcementRepository.Create(announcement, deliveries)
+	if err != nil {
+		return nil, err
+	}
+	for _, caregiverID := range caregivers {
+		userID := caregiverID
+		message := notification.Message{
+			UserID:  &userID,
+			Subject: subject,
+			Body:    body,
+			Data:    map[string]interface{}{"announcementID": announcement.ID.String()},
+			Report:  u.recordDelivery(announcement.ID),
+		}
+		if err := u.notifier.Notify(message); err != nil {
+			u.Logger.Warn("Announcement not queued", zap.Error(err), zap.String("userID", userID.String()))
+		}
+	}
+	return created, nil
+}
+
+// recordDelivery saves each attempt to deliver the announcement as the
+// notifier reports it.
+func (u *AnnouncementUseCase) recordDelivery(announcementID uuid.UUID) func(userID uuid.UUID, channel string, err error) {
+	return func(userID uuid.UUID, channel string, err error) {
+		delivery := domainAnnouncement.Delivery{AnnouncementID: announcementID, UserID: userID, Channel: channel, Status: domainAnnouncement.DeliverySent}
+		switch {
+		case errors.Is(err, notification.ErrNoAddress):
+			delivery.Channel, delivery.Status = "", domainAnnouncement.DeliveryUnreachable
+		case err != nil:
+			delivery.Status, delivery.Error = domainAnnouncement.DeliveryFailed, err.Error()
+		}
+		if err := u.announcementRepository.RecordDelivery(delivery); err != nil {
+			u.Logger.Error("Error recording announcement delivery", zap.Error(err), zap.String("announcementID", announcementID.String()),
+				zap.String("userID", userID.String()))
+		}
+	}
+}
+
+func (u *AnnouncementUseCase) GetAll() (*[]domainAnnouncement.Announcement, error) {
+	return u.announcementRepository.GetAll()
+}
+
+func (u *AnnouncementUseCase) GetByID(id uuid.UUID) (*domainAnnouncement.Announcement, error) {
+	return u.announcementRepository.GetByID(id)
+}
+
+func (u *AnnouncementUseCase) GetDeliveries(id uuid.UUID) (*[]domainAnnouncement.Delivery, error) {
+	if _, err := u.announcementRepository.GetByID(id); err != nil {
+		return nil, err
+	}
+	return u.announcementRepository.GetDeliveries(id)
+}
+
+func validate(subject, body string, audience domainAnnouncement.Audience) error {
+	switch {
+	case subject == "" || body == "":
+		return domainErrors.NewAppError(errors.New("subject and body are required"), domainErrors.ValidationError)
+	case utf8.RuneCountInString(subject) > maxSubjectLength:
+		return domainErrors.NewAppError(fmt.Errorf("subject is longer than %d characters", maxSubjectLength), domainErrors.ValidationError)
+	case utf8.RuneCountInString(body) > maxBodyLength:
+		return domainErrors.NewAppError(fmt.Errorf("body is longer than %d characters", maxBodyLength), domainErrors.ValidationError)
+	case (audience.UpcomingFrom == nil) != (audience.UpcomingTo == nil):
+		return domainErrors.NewAppError(errors.New("upcoming_from and upcoming_to go together"), domainErrors.ValidationError)
+	case audience.UpcomingFrom != nil && !audience.UpcomingTo.After(*audience.UpcomingFrom):
+		return domainErrors.NewAppError(errors.New("upcoming_to must be after upcoming_from"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+// caregivers returns the active caregivers the audience picks, in the order
+// the user repository lists them.
+func (u *AnnouncementUseCase) caregivers(audience domainAnnouncement.Audience) ([]uuid.UUID, error) {
+	users, err := u.userRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var members map[uuid.UUID]bool
+	if len(audience.TeamIDs) > 0 {
+		ids, err := u.teamRepository.GetMemberIDs(audience.TeamIDs)
+		if err != nil {
+			return nil, err
+		}
+		members = toSet(ids)
+	}
+
+	var candidates []uuid.UUID
+	for _, user := range *users {
+		if user.Role != domainUser.RoleCaregiver || !user.Status {
+			continue
+		}
+		if members != nil && !members[user.ID] {
+			continue
+		}
+		if len(audience.Regions) > 0 && !inRegions(user.Location, audience.Regions) {
+			continue
+		}
+		candidates = append(candidates, user.ID)
+	}
+	if audience.UpcomingFrom == nil || len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	schedules, err := u.scheduleRepository.GetActiveSchedulesBetween(*audience.UpcomingFrom, *audience.UpcomingTo, candidates)
+	if err != nil {
+		return nil, err
+	}
+	scheduled := map[uuid.UUID]bool{}
+	for _, schedule := range *schedules {
+		if !schedule.Draft() {
+			scheduled[schedule.AssignedUserID] = true
+		}
+	}
+	var caregivers []uuid.UUID
+	for _, id := range candidates {
+		if scheduled[id] {
+			caregivers = append(caregivers, id)
+		}
+	}
+	return caregivers, nil
+}
+
+func inRegions(location domainUser.Location, regions []string) bool {
+	for _, region := range regions {
+		if strings.EqualFold(location.City, region) || strings.EqualFold(location.State, region) {
+			return true
+		}
+	}
+	return false
+}
+
+func toSet(ids []uuid.UUID) map[uuid.UUID]bool {
+	set := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
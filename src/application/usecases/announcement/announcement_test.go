@@ -0,0 +1,211 @@
+package announcement
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainAnnouncement "caregiver/src/domain/announcement"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTeam "caregiver/src/domain/team"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+type mockAnnouncementRepository struct {
+	domainAnnouncement.IAnnouncementRepository
+	created    *domainAnnouncement.Announcement
+	deliveries []domainAnnouncement.Delivery
+}
+
+func (m *mockAnnouncementRepository) Create(announcement *domainAnnouncement.Announcement, deliveries []domainAnnouncement.Delivery) (*domainAnnouncement.Announcement, error) {
+	m.created, m.deliveries = announcement, deliveries
+	return announcement, nil
+}
+
+func (m *mockAnnouncementRepository) RecordDelivery(delivery domainAnnouncement.Delivery) error {
+	var kept []domainAnnouncement.Delivery
+	reached := false
+	for _, d := range m.deliveries {
+		switch {
+		case d.UserID != delivery.UserID:
+		case d.Status == domainAnnouncement.DeliveryQueued && delivery.Status == domainAnnouncement.DeliveryUnreachable:
+			d.Status = domainAnnouncement.DeliveryUnreachable
+		case delivery.Status == domainAnnouncement.DeliveryUnreachable:
+		case d.Status == domainAnnouncement.DeliveryQueued || d.Status == domainAnnouncement.DeliveryUnreachable:
+			continue
+		case d.Status == domainAnnouncement.DeliverySent:
+			reached = true
+		}
+		kept = append(kept, d)
+	}
+	if delivery.Status != domainAnnouncement.DeliveryUnreachable {
+		kept = append(kept, delivery)
+		if delivery.Status == domainAnnouncement.DeliverySent && !reached {
+			m.created.Reached++
+		}
+	}
+	m.deliveries = kept
+	return nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users []domainUser.User
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) {
+	return &m.users, nil
+}
+
+type mockTeamRepository struct {
+	domainTeam.ITeamRepository
+	members map[uuid.UUID][]uuid.UUID
+}
+
+func (m *mockTeamRepository) GetMemberIDs(teamIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, teamID := range teamIDs {
+		ids = append(ids, m.members[teamID]...)
+	}
+	return ids, nil
+}
+
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetActiveSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, schedule := range m.schedules {
+		if schedule.ScheduledSlot.From.Before(to) && schedule.ScheduledSlot.To.After(from) {
+			res = append(res, schedule)
+		}
+	}
+	return &res, nil
+}
+
+// channelNotifier reports a text sent to everyone in sent, a failure for
+// everyone in failing and no address for anyone else.
+type channelNotifier struct {
+	sent    map[uuid.UUID]bool
+	failing map[uuid.UUID]bool
+	to      []uuid.UUID
+}
+
+func (n *channelNotifier) Notify(message notification.Message) error {
+	userID := *message.UserID
+	n.to = append(n.to, userID)
+	switch {
+	case n.sent[userID]:
+		message.Report(userID, "sms", nil)
+		message.Report(userID, "push", notification.ErrNoAddress)
+	case n.failing[userID]:
+		message.Report(userID, "email", errors.New("mailbox full"))
+	default:
+		message.Report(userID, "sms", notification.ErrNoAddress)
+	}
+	return nil
+}
+
+func TestSend(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	north := uuid.New()
+	caregiver := func(city string) domainUser.User {
+		return domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true, Location: domainUser.Location{City: city, State: "IL"}}
+	}
+	ana, ben, cam, dee := caregiver("Springfield"), caregiver("Chicago"), caregiver("springfield"), caregiver("Springfield")
+	inactive := caregiver("Springfield")
+	inactive.Status = false
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Status: true, Location: domainUser.Location{City: "Springfield"}}
+	users := &mockUserRepository{users: []domainUser.User{ana, ben, cam, dee, inactive, client}}
+	teams := &mockTeamRepository{members: map[uuid.UUID][]uuid.UUID{north: {ana.ID, ben.ID, cam.ID, inactive.ID}}}
+
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	visit := func(userID uuid.UUID, status string) domainSchedule.Schedule {
+		return domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: userID, VisitStatus: status, ScheduledSlot: domainSchedule.ScheduledSlot{From: monday, To: monday.Add(time.Hour)}}
+	}
+	schedules := &mockScheduleRepository{schedules: []domainSchedule.Schedule{visit(ana.ID, "upcoming"), visit(cam.ID, "upcoming"), visit(ben.ID, domainSchedule.StatusDraft)}}
+
+	repo := &mockAnnouncementRepository{}
+	notifier := &channelNotifier{sent: map[uuid.UUID]bool{ana.ID: true}, failing: map[uuid.UUID]bool{cam.ID: true}}
+	uc := NewAnnouncementUseCase(repo, users, teams, schedules, notifier, loggerInstance)
+
+	from, to := monday.Add(-time.Hour), monday.Add(24*time.Hour)
+	audience := domainAnnouncement.Audience{TeamIDs: []uuid.UUID{north}, Regions: []string{"Springfield"}, UpcomingFrom: &from, UpcomingTo: &to}
+	sender := uuid.New()
+	announcement, err := uc.Send(" Flu shots ", "Clinic on Friday.", audience, &sender, monday)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.to) != 2 || notifier.to[0] != ana.ID || notifier.to[1] != cam.ID {
+		t.Errorf("expected the Springfield team members with a visit, got %v", notifier.to)
+	}
+	if announcement.Subject != "Flu shots" || announcement.Recipients != 2 || announcement.Reached != 1 || *announcement.SentByUserID != sender {
+		t.Errorf("unexpected announcement %+v", announcement)
+	}
+	if len(repo.deliveries) != 2 {
+		t.Fatalf("expected a delivery per attempted channel, got %+v", repo.deliveries)
+	}
+	if d := repo.deliveries[0]; d.UserID != ana.ID || d.Channel != "sms" || d.Status != domainAnnouncement.DeliverySent {
+		t.Errorf("expected the text to Ana recorded as sent, got %+v", d)
+	}
+	if d := repo.deliveries[1]; d.UserID != cam.ID || d.Status != domainAnnouncement.DeliveryFailed || d.Error != "mailbox full" {
+		t.Errorf("expected the email to Cam recorded as failed, got %+v", d)
+	}
+
+	if _, err := uc.Send("Office closed", "Friday.", domainAnnouncement.Audience{}, nil, monday); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.created.Recipients != 4 {
+		t.Errorf("expected every active caregiver without filters, got %d", repo.created.Recipients)
+	}
+	unreachable := 0
+	for _, delivery := range repo.deliveries {
+		if delivery.Status == domainAnnouncement.DeliveryUnreachable {
+			unreachable++
+		}
+	}
+	if unreachable != 2 {
+		t.Errorf("expected Ben and Dee unreachable, got %+v", repo.deliveries)
+	}
+}
+
+func TestSendValidation(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	users := &mockUserRepository{users: []domainUser.User{{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true, Location: domainUser.Location{City: "Chicago"}}}}
+	uc := NewAnnouncementUseCase(&mockAnnouncementRepository{}, users, &mockTeamRepository{}, &mockScheduleRepository{}, &channelNotifier{}, loggerInstance)
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		subject  string
+		audience domainAnnouncement.Audience
+	}{
+		{"blank subject", "  ", domainAnnouncement.Audience{}},
+		{"half a window", "Notice", domainAnnouncement.Audience{UpcomingFrom: &now}},
+		{"backwards window", "Notice", domainAnnouncement.Audience{UpcomingFrom: &now, UpcomingTo: &earlier}},
+		{"nobody in the region", "Notice", domainAnnouncement.Audience{Regions: []string{"Springfield"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Send(tt.subject, "Body", tt.audience, nil, now)
+			var appErr *domainErrors.AppError
+			if !errors.As(err, &appErr) || appErr.Type != domainErrors.ValidationError {
+				t.Errorf("expected ValidationError, got %v", err)
+			}
+		})
+	}
+}
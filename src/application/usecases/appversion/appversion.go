@@ -0,0 +1,137 @@
+package appversion
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	domainAppVersion "caregiver/src/domain/appversion"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"go.uber.org/zap"
+)
+
+// requirementsTTL is how long Check reuses the requirements it read. Saving
+// or deleting a requirement takes effect at once on this instance.
+const requirementsTTL = time.Minute
+
+type IAppVersionUseCase interface {
+	// Check returns an UpgradeRequired error when the app version is older
+	// than the platform's minimum. Platforms without a minimum pass.
+	Check(platform, version string) error
+	GetRequirements() (*[]domainAppVersion.Requirement, error)
+	SaveRequirement(requirement *domainAppVersion.Requirement) (*domainAppVersion.Requirement, error)
+	DeleteRequirement(platform string) error
+}
+
+type AppVersionUseCase struct {
+	appVersionRepository domainAppVersion.IAppVersionRepository
+	Logger               *logger.Logger
+
+	mu       sync.Mutex
+	minimums map[string]domainAppVersion.Requirement
+	loadedAt time.Time
+}
+
+func NewAppVersionUseCase(appVersionRepository domainAppVersion.IAppVersionRepository, loggerInstance *logger.Logger) IAppVersionUseCase {
+	return &AppVersionUseCase{
+		appVersionRepository: appVersionRepository,
+		Logger:               loggerInstance,
+	}
+}
+
+func (u *AppVersionUseCase) Check(platform, version string) error {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	current, err := domainAppVersion.ParseVersion(version)
+	if err != nil {
+		return domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
+	requirement, ok, err := u.requirement(platform)
+	if err != nil || !ok {
+		return err
+	}
+	minimum, err := domainAppVersion.ParseVersion(requirement.MinimumVersion)
+	if err != nil || current.Compare(minimum) >= 0 {
+		return nil
+	}
+	message := requirement.Message
+	if message == "" {
+		message = fmt.Sprintf("Version %s of the app is no longer supported. Please update to %s or later.", version, requirement.MinimumVersion)
+	}
+	return domainErrors.NewAppError(&domainAppVersion.UpgradeRequiredError{
+		Platform:       platform,
+		Version:        version,
+		MinimumVersion: requirement.MinimumVersion,
+		StoreURL:       requirement.StoreURL,
+		Message:        message,
+		ForceUpgrade:   true,
+	}, domainErrors.UpgradeRequired)
+}
+
+func (u *AppVersionUseCase) GetRequirements() (*[]domainAppVersion.Requirement, error) {
+	return u.appVersionRepository.GetRequirements()
+}
+
+func (u *AppVersionUseCase) SaveRequirement(requirement *domainAppVersion.Requirement) (*domainAppVersion.Requirement, error) {
+	requirement.Platform = strings.ToLower(strings.TrimSpace(requirement.Platform))
+	requirement.MinimumVersion = strings.TrimSpace(requirement.MinimumVersion)
+	requirement.Message = strings.TrimSpace(requirement.Message)
+	if !domainAppVersion.IsPlatform(requirement.Platform) {
+		return nil, domainErrors.NewAppError(fmt.Errorf("platform must be one of: %s", strings.Join(domainAppVersion.Platforms, ", ")), domainErrors.ValidationError)
+	}
+	if _, err := domainAppVersion.ParseVersion(requirement.MinimumVersion); err != nil {
+		return nil, domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
+	if requirement.StoreURL != "" {
+		if parsed, err := url.Parse(requirement.StoreURL); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, domainErrors.NewAppError(errors.New("store URL must be an absolute URL"), domainErrors.ValidationError)
+		}
+	}
+	u.Logger.Info("Saving minimum app version", zap.String("platform", requirement.Platform), zap.String("minimumVersion", requirement.MinimumVersion))
+	saved, err := u.appVersionRepository.SaveRequirement(requirement)
+	if err != nil {
+		return nil, err
+	}
+	u.invalidate()
+	return saved, nil
+}
+
+func (u *AppVersionUseCase) DeleteRequirement(platform string) error {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	u.Logger.Info("Deleting minimum app version", zap.String("platform", platform))
+	if err := u.appVersionRepository.DeleteRequirement(platform); err != nil {
+		return err
+	}
+	u.invalidate()
+	return nil
+}
+
+// requirement returns the platform's minimum version, reading the
+// requirements again once requirementsTTL has passed.
+func (u *AppVersionUseCase) requirement(platform string) (domainAppVersion.Requirement, bool, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.minimums == nil || time.Since(u.loadedAt) > requirementsTTL {
+		requirements, err := u.appVersionRepository.GetRequirements()
+		if err != nil {
+			return domainAppVersion.Requirement{}, false, err
+		}
+		u.minimums = make(map[string]domainAppVersion.Requirement, len(*requirements))
+		for _, requirement := range *requirements {
+			u.minimums[requirement.Platform] = requirement
+		}
+		u.loadedAt = time.Now()
+	}
+	requirement, ok := u.minimums[platform]
+	return requirement, ok, nil
+}
+
+func (u *AppVersionUseCase) invalidate() {
+	u.mu.Lock()
+	u.minimums = nil
+	u.mu.Unlock()
+}
@@ -0,0 +1,117 @@
+package appversion
+
+import (
+	"errors"
+	"testing"
+
+	domainAppVersion "caregiver/src/domain/appversion"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+)
+
+type mockAppVersionRepository struct {
+	requirements map[string]domainAppVersion.Requirement
+	reads        int
+}
+
+func (m *mockAppVersionRepository) GetRequirements() (*[]domainAppVersion.Requirement, error) {
+	m.reads++
+	res := []domainAppVersion.Requirement{}
+	for _, requirement := range m.requirements {
+		res = append(res, requirement)
+	}
+	return &res, nil
+}
+
+func (m *mockAppVersionRepository) SaveRequirement(requirement *domainAppVersion.Requirement) (*domainAppVersion.Requirement, error) {
+	m.requirements[requirement.Platform] = *requirement
+	return requirement, nil
+}
+
+func (m *mockAppVersionRepository) DeleteRequirement(platform string) error {
+	delete(m.requirements, platform)
+	return nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestParseAndCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.10.0", "2.9.3", 1},
+		{"2.1", "2.1.0", 0},
+		{"v3.0.0-beta+12", "3", 0},
+		{"1.9.9", "2.0.0", -1},
+	}
+	for _, tt := range tests {
+		a, err := domainAppVersion.ParseVersion(tt.a)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", tt.a, err)
+		}
+		b, _ := domainAppVersion.ParseVersion(tt.b)
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "latest", "1.2.3.4.5", "1.-2"} {
+		if _, err := domainAppVersion.ParseVersion(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	repo := &mockAppVersionRepository{requirements: map[string]domainAppVersion.Requirement{}}
+	uc := NewAppVersionUseCase(repo, loggerInstance)
+
+	if _, err := uc.SaveRequirement(&domainAppVersion.Requirement{Platform: "windows", MinimumVersion: "1.0"}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an unknown platform to be rejected, got %v", err)
+	}
+	if _, err := uc.SaveRequirement(&domainAppVersion.Requirement{Platform: "ios", MinimumVersion: "2.4", StoreURL: "apps.apple.com"}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a relative store URL to be rejected, got %v", err)
+	}
+
+	if err := uc.Check("ios", "1.0.0"); err != nil {
+		t.Errorf("expected no gating without a minimum, got %v", err)
+	}
+	if _, err := uc.SaveRequirement(&domainAppVersion.Requirement{Platform: " iOS ", MinimumVersion: "2.4.0", StoreURL: "https://apps.apple.com/app/id1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = uc.Check("ios", "2.3.9")
+	if errorType(err) != domainErrors.UpgradeRequired {
+		t.Fatalf("expected an outdated app to need an upgrade, got %v", err)
+	}
+	var upgrade *domainAppVersion.UpgradeRequiredError
+	if !errors.As(err, &upgrade) || !upgrade.ForceUpgrade || upgrade.MinimumVersion != "2.4.0" || upgrade.StoreURL == "" {
+		t.Errorf("expected forced upgrade details, got %+v", upgrade)
+	}
+	if err := uc.Check("ios", "2.4.0"); err != nil {
+		t.Errorf("expected the minimum version to pass, got %v", err)
+	}
+	if err := uc.Check("android", "0.1"); err != nil {
+		t.Errorf("expected another platform to pass, got %v", err)
+	}
+	if repo.reads != 2 {
+		t.Errorf("expected requirements read once per save, got %d reads", repo.reads)
+	}
+
+	if err := uc.DeleteRequirement("ios"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.Check("ios", "2.3.9"); err != nil {
+		t.Errorf("expected a deleted minimum to stop gating, got %v", err)
+	}
+}
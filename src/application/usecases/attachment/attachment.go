@@ -0,0 +1,196 @@
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	domainAttachment "caregiver/src/domain/attachment"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxAttachmentSize caps uploads at 10 MiB.
+const MaxAttachmentSize = 10 << 20
+
+var allowedContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/heic":      true,
+	"image/webp":      true,
+}
+
+type IAttachmentUseCase interface {
+	Upload(newAttachment *domainAttachment.Attachment, content io.Reader) (*domainAttachment.Attachment, error)
+	GetBySchedule(scheduleID uuid.UUID) (*[]domainAttachment.Attachment, *[]domainAttachment.View, error)
+	Open(scheduleID uuid.UUID, attachmentID uuid.UUID, viewerUserID uuid.UUID) (*domainAttachment.Attachment, io.ReadCloser, error)
+	Delete(scheduleID uuid.UUID, attachmentID uuid.UUID) error
+	BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error
+}
+
+type AttachmentUseCase struct {
+	attachmentRepository domainAttachment.IAttachmentRepository
+	scheduleRepository   domainSchedule.IScheduleRepository
+	storage              storage.IFileStorage
+	Logger               *logger.Logger
+}
+
+func NewAttachmentUseCase(attachmentRepository domainAttachment.IAttachmentRepository, scheduleRepository domainSchedule.IScheduleRepository, fileStorage storage.IFileStorage, loggerInstance *logger.Logger) IAttachmentUseCase {
+	return &AttachmentUseCase{
+		attachmentRepository: attachmentRepository,
+		scheduleRepository:   scheduleRepository,
+		storage:              fileStorage,
+		Logger:               loggerInstance,
+	}
+}
+
+// Upload stores the file and its metadata. An attachment scoped to a task
+// must name one of the visit's tasks.
+func (u *AttachmentUseCase) Upload(newAttachment *domainAttachment.Attachment, content io.Reader) (*domainAttachment.Attachment, error) {
+	u.Logger.Info("Uploading attachment", zap.String("scheduleID", newAttachment.ScheduleID.String()), zap.String("fileName", newAttachment.FileName))
+
+	schedule, err := u.scheduleRepository.GetScheduleByID(newAttachment.ScheduleID)
+	if err != nil {
+		u.Logger.Error("Schedule not found for attachment", zap.Error(err), zap.String("scheduleID", newAttachment.ScheduleID.String()))
+		return nil, err
+	}
+	if newAttachment.TaskID != nil && !hasTask(schedule, *newAttachment.TaskID) {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	if !allowedContentTypes[newAttachment.ContentType] {
+		return nil, domainErrors.NewAppError(fmt.Errorf("content type '%s' is not allowed", newAttachment.ContentType), domainErrors.ValidationError)
+	}
+	if newAttachment.Size <= 0 || newAttachment.Size > MaxAttachmentSize {
+		return nil, domainErrors.NewAppError(errors.New("attachment must be between 1 byte and 10 MiB"), domainErrors.ValidationError)
+	}
+
+	newAttachment.ID = uuid.New()
+	newAttachment.FileName = filepath.Base(newAttachment.FileName)
+	newAttachment.StorageKey = fmt.Sprintf("attachments/%s/%s%s", newAttachment.ScheduleID, newAttachment.ID, strings.ToLower(filepath.Ext(newAttachment.FileName)))
+
+	if err := u.storage.Save(newAttachment.StorageKey, io.LimitReader(content, MaxAttachmentSize)); err != nil {
+		u.Logger.Error("Error storing attachment content", zap.Error(err), zap.String("scheduleID", newAttachment.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	created, err := u.attachmentRepository.Create(newAttachment)
+	if err != nil {
+		_ = u.storage.Delete(newAttachment.StorageKey)
+		return nil, err
+	}
+	return created, nil
+}
+
+func (u *AttachmentUseCase) GetBySchedule(scheduleID uuid.UUID) (*[]domainAttachment.Attachment, *[]domainAttachment.View, error) {
+	u.Logger.Info("Getting attachments for schedule", zap.String("scheduleID", scheduleID.String()))
+
+	attachments, err := u.attachmentRepository.GetBySchedule(scheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	views, err := u.attachmentRepository.GetViewsBySchedule(scheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachments, views, nil
+}
+
+// Open returns the attachment content. When a viewer is given the open is
+// recorded against the visit so check-in can verify it happened.
+func (u *AttachmentUseCase) Open(scheduleID uuid.UUID, attachmentID uuid.UUID, viewerUserID uuid.UUID) (*domainAttachment.Attachment, io.ReadCloser, error) {
+	attachment, err := u.getForSchedule(scheduleID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := u.storage.Open(attachment.StorageKey)
+	if err != nil {
+		u.Logger.Error("Error opening attachment content", zap.Error(err), zap.String("attachmentID", attachmentID.String()))
+		return nil, nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	if viewerUserID != uuid.Nil {
+		view := &domainAttachment.View{
+			AttachmentID: attachment.ID,
+			ScheduleID:   attachment.ScheduleID,
+			UserID:       viewerUserID,
+			ViewedAt:     time.Now().UTC(),
+		}
+		if _, err := u.attachmentRepository.RecordView(view); err != nil {
+			content.Close()
+			return nil, nil, err
+		}
+		u.Logger.Info("Attachment viewed", zap.String("attachmentID", attachmentID.String()), zap.String("userID", viewerUserID.String()))
+	}
+	return attachment, content, nil
+}
+
+func (u *AttachmentUseCase) Delete(scheduleID uuid.UUID, attachmentID uuid.UUID) error {
+	attachment, err := u.getForSchedule(scheduleID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := u.attachmentRepository.Delete(attachment.ID); err != nil {
+		return err
+	}
+	if err := u.storage.Delete(attachment.StorageKey); err != nil {
+		u.Logger.Warn("Error removing attachment content", zap.Error(err), zap.String("attachmentID", attachmentID.String()))
+	}
+	return nil
+}
+
+// BeforeCheckin blocks a check-in until the assigned caregiver has opened
+// every attachment flagged as required for the visit.
+func (u *AttachmentUseCase) BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error {
+	attachments, views, err := u.GetBySchedule(schedule.ID)
+	if err != nil {
+		return err
+	}
+
+	viewed := make(map[uuid.UUID]bool)
+	for _, view := range *views {
+		if view.UserID == schedule.AssignedUserID {
+			viewed[view.AttachmentID] = true
+		}
+	}
+
+	var pending []string
+	for _, attachment := range *attachments {
+		if attachment.RequiredBeforeCheckin && !viewed[attachment.ID] {
+			pending = append(pending, attachment.FileName)
+		}
+	}
+	if len(pending) > 0 {
+		return domainErrors.NewAppError(fmt.Errorf("required attachments must be opened before check-in: %s", strings.Join(pending, ", ")), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func hasTask(schedule *domainSchedule.Schedule, taskID uuid.UUID) bool {
+	for _, task := range schedule.Tasks {
+		if task.ID == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *AttachmentUseCase) getForSchedule(scheduleID uuid.UUID, attachmentID uuid.UUID) (*domainAttachment.Attachment, error) {
+	attachment, err := u.attachmentRepository.GetByID(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.ScheduleID != scheduleID {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return attachment, nil
+}
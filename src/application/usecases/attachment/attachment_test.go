@@ -0,0 +1,236 @@
+package attachment
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	domainAttachment "caregiver/src/domain/attachment"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockAttachmentRepository is an in-memory implementation of IAttachmentRepository
+type mockAttachmentRepository struct {
+	attachments []domainAttachment.Attachment
+	views       []domainAttachment.View
+}
+
+func (m *mockAttachmentRepository) Create(newAttachment *domainAttachment.Attachment) (*domainAttachment.Attachment, error) {
+	m.attachments = append(m.attachments, *newAttachment)
+	return newAttachment, nil
+}
+
+func (m *mockAttachmentRepository) GetByID(id uuid.UUID) (*domainAttachment.Attachment, error) {
+	for i := range m.attachments {
+		if m.attachments[i].ID == id {
+			return &m.attachments[i], nil
+		}
+	}
+	return nil, errors.New("attachment not found")
+}
+
+func (m *mockAttachmentRepository) GetBySchedule(scheduleID uuid.UUID) (*[]domainAttachment.Attachment, error) {
+	res := []domainAttachment.Attachment{}
+	for _, a := range m.attachments {
+		if a.ScheduleID == scheduleID {
+			res = append(res, a)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockAttachmentRepository) Delete(id uuid.UUID) error {
+	return nil
+}
+
+func (m *mockAttachmentRepository) RecordView(view *domainAttachment.View) (*domainAttachment.View, error) {
+	m.views = append(m.views, *view)
+	return view, nil
+}
+
+func (m *mockAttachmentRepository) GetViewsBySchedule(scheduleID uuid.UUID) (*[]domainAttachment.View, error) {
+	return &m.views, nil
+}
+
+// mockScheduleRepository only implements the lookups the attachment use case needs
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	getScheduleByIDFn func(id uuid.UUID) (*domainSchedule.Schedule, error)
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	return m.getScheduleByIDFn(id)
+}
+
+// mockStorage keeps file contents in memory
+type mockStorage struct {
+	files map[string][]byte
+}
+
+func (m *mockStorage) Save(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	m.files[key] = data
+	return nil
+}
+
+func (m *mockStorage) Open(key string) (io.ReadCloser, error) {
+	data, ok := m.files[key]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *mockStorage) Delete(key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+func setupTestAttachmentUseCase(t *testing.T) (IAttachmentUseCase, *mockAttachmentRepository, *mockStorage) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	attachmentRepo := &mockAttachmentRepository{}
+	scheduleRepo := &mockScheduleRepository{getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+		return &domainSchedule.Schedule{ID: id}, nil
+	}}
+	fileStorage := &mockStorage{files: map[string][]byte{}}
+	return NewAttachmentUseCase(attachmentRepo, scheduleRepo, fileStorage, loggerInstance), attachmentRepo, fileStorage
+}
+
+func TestUpload(t *testing.T) {
+	useCase, _, fileStorage := setupTestAttachmentUseCase(t)
+	scheduleID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		created, err := useCase.Upload(&domainAttachment.Attachment{
+			ScheduleID:  scheduleID,
+			FileName:    "../orders.PDF",
+			ContentType: "application/pdf",
+			Size:        4,
+		}, strings.NewReader("%PDF"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created.FileName != "orders.PDF" {
+			t.Errorf("expected sanitized file name, got %s", created.FileName)
+		}
+		if !strings.HasSuffix(created.StorageKey, ".pdf") || string(fileStorage.files[created.StorageKey]) != "%PDF" {
+			t.Errorf("expected content stored under %s", created.StorageKey)
+		}
+	})
+
+	t.Run("Rejects content type", func(t *testing.T) {
+		_, err := useCase.Upload(&domainAttachment.Attachment{
+			ScheduleID:  scheduleID,
+			FileName:    "run.sh",
+			ContentType: "application/x-sh",
+			Size:        4,
+		}, strings.NewReader("echo"))
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("Scoped to a task", func(t *testing.T) {
+		taskID := uuid.New()
+		scheduleRepo := &mockScheduleRepository{getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return &domainSchedule.Schedule{ID: id, Tasks: []domainSchedule.Task{{ID: taskID}}}, nil
+		}}
+		loggerInstance, _ := logger.NewLogger()
+		taskUseCase := NewAttachmentUseCase(&mockAttachmentRepository{}, scheduleRepo, &mockStorage{files: map[string][]byte{}}, loggerInstance)
+
+		created, err := taskUseCase.Upload(&domainAttachment.Attachment{
+			ScheduleID:  scheduleID,
+			TaskID:      &taskID,
+			FileName:    "wound.heic",
+			ContentType: "image/heic",
+			Size:        4,
+		}, strings.NewReader("heic"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created.TaskID == nil || *created.TaskID != taskID {
+			t.Errorf("expected the attachment kept on the task, got %v", created.TaskID)
+		}
+
+		otherTaskID := uuid.New()
+		_, err = taskUseCase.Upload(&domainAttachment.Attachment{
+			ScheduleID:  scheduleID,
+			TaskID:      &otherTaskID,
+			FileName:    "receipt.jpg",
+			ContentType: "image/jpeg",
+			Size:        4,
+		}, strings.NewReader("jpeg"))
+		if err == nil {
+			t.Error("expected an error for a task of another visit")
+		}
+	})
+}
+
+func TestBeforeCheckin(t *testing.T) {
+	useCase, attachmentRepo, _ := setupTestAttachmentUseCase(t)
+	caregiverID := uuid.New()
+	schedule := &domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiverID}
+
+	created, err := useCase.Upload(&domainAttachment.Attachment{
+		ScheduleID:            schedule.ID,
+		FileName:              "care-plan.pdf",
+		ContentType:           "application/pdf",
+		Size:                  4,
+		RequiredBeforeCheckin: true,
+	}, strings.NewReader("%PDF"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := useCase.BeforeCheckin(schedule, schedule.ScheduledSlot.From, &domainSchedule.Verification{}); err == nil {
+		t.Fatal("expected check-in to be blocked until the attachment is opened")
+	}
+
+	// A coordinator preview is not recorded.
+	_, content, err := useCase.Open(schedule.ID, created.ID, uuid.Nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content.Close()
+	if len(attachmentRepo.views) != 0 {
+		t.Errorf("expected no recorded views, got %d", len(attachmentRepo.views))
+	}
+
+	_, content, err = useCase.Open(schedule.ID, created.ID, caregiverID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content.Close()
+
+	if err := useCase.BeforeCheckin(schedule, schedule.ScheduledSlot.From, &domainSchedule.Verification{}); err != nil {
+		t.Errorf("expected check-in to be allowed, got %v", err)
+	}
+}
+
+func TestOpenWrongSchedule(t *testing.T) {
+	useCase, _, _ := setupTestAttachmentUseCase(t)
+	created, err := useCase.Upload(&domainAttachment.Attachment{
+		ScheduleID:  uuid.New(),
+		FileName:    "photo.png",
+		ContentType: "image/png",
+		Size:        3,
+	}, strings.NewReader("png"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, _, err := useCase.Open(uuid.New(), created.ID, uuid.Nil); err == nil {
+		t.Error("expected not found for attachment on another schedule")
+	}
+}
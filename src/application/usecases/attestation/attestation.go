@@ -0,0 +1,193 @@
+package attestation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	domainAttestation "caregiver/src/domain/attestation"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxWindowMinutes caps the confirmation window at four hours.
+const MaxWindowMinutes = 240
+
+type IAttestationUseCase interface {
+	GetSettings() (*domainAttestation.Settings, error)
+	SetSettings(required bool, windowMinutes int) (*domainAttestation.Settings, error)
+	Respond(scheduleID uuid.UUID, clientUserID uuid.UUID, confirmed bool, deviceID string, now time.Time) (*domainSchedule.Schedule, error)
+	Sweep(now time.Time) (int, error)
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+type AttestationUseCase struct {
+	attestationRepository domainAttestation.IAttestationRepository
+	scheduleRepository    domainSchedule.IScheduleRepository
+	notifier              notification.INotifier
+	Logger                *logger.Logger
+}
+
+func NewAttestationUseCase(attestationRepository domainAttestation.IAttestationRepository, scheduleRepository domainSchedule.IScheduleRepository, notifier notification.INotifier, loggerInstance *logger.Logger) IAttestationUseCase {
+	return &AttestationUseCase{
+		attestationRepository: attestationRepository,
+		scheduleRepository:    scheduleRepository,
+		notifier:              notifier,
+		Logger:                loggerInstance,
+	}
+}
+
+// GetSettings returns the organization's settings. Attestation is off until
+// a coordinator turns it on.
+func (u *AttestationUseCase) GetSettings() (*domainAttestation.Settings, error) {
+	settings, err := u.attestationRepository.GetSettings()
+	if err != nil {
+		if isNotFound(err) {
+			return &domainAttestation.Settings{WindowMinutes: domainAttestation.DefaultWindowMinutes}, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (u *AttestationUseCase) SetSettings(required bool, windowMinutes int) (*domainAttestation.Settings, error) {
+	u.Logger.Info("Setting attestation settings", zap.Bool("required", required), zap.Int("windowMinutes", windowMinutes))
+	if windowMinutes < 1 || windowMinutes > MaxWindowMinutes {
+		return nil, domainErrors.NewAppError(fmt.Errorf("window must be between 1 and %d minutes", MaxWindowMinutes), domainErrors.ValidationError)
+	}
+	settings, err := u.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	settings.Required = required
+	settings.WindowMinutes = windowMinutes
+	return u.attestationRepository.SaveSettings(settings)
+}
+
+// OnScheduleEvent asks the client to confirm the caregiver's arrival when a
+// visit is checked in and attestation is required. The started schedule is
+// updated in place so the check-in response shows the pending request.
+func (u *AttestationUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	if event.Type != domainSchedule.EventStarted || event.Schedule.CheckinTime == nil {
+		return
+	}
+	settings, err := u.GetSettings()
+	if err != nil {
+		u.Logger.Error("Error loading attestation settings", zap.Error(err))
+		return
+	}
+	if !settings.Required {
+		return
+	}
+
+	schedule := event.Schedule
+	dueAt := schedule.CheckinTime.Add(time.Duration(settings.WindowMinutes) * time.Minute)
+	attestation := domainSchedule.Attestation{Status: domainSchedule.AttestationPending, DueAt: &dueAt}
+	if _, err := u.scheduleRepository.UpdateSchedule(schedule.ID, map[string]interface{}{
+		"attestation_status":       attestation.Status,
+		"attestation_due_at":       dueAt,
+		"attestation_responded_at": nil,
+		"attestation_device_id":    "",
+	}); err != nil {
+		u.Logger.Error("Error requesting attestation", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		return
+	}
+	schedule.Attestation = attestation
+
+	clientUserID := schedule.ClientUserID
+	if err := u.notifier.Notify(notification.Message{
+		UserID:  &clientUserID,
+		Subject: "Please confirm your caregiver has arrived",
+		Body:    fmt.Sprintf("Your caregiver checked in for %s. Please confirm their arrival by %s.", schedule.ServiceName, dueAt.Format(time.RFC3339)),
+		Data: map[string]interface{}{
+			"scheduleID": schedule.ID.String(),
+			"dueAt":      dueAt,
+		},
+	}); err != nil {
+		u.Logger.Error("Error notifying client for attestation", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+	}
+}
+
+// Respond records the client's answer. Confirming after the window has closed
+// is refused and the request is marked expired.
+func (u *AttestationUseCase) Respond(scheduleID uuid.UUID, clientUserID uuid.UUID, confirmed bool, deviceID string, now time.Time) (*domainSchedule.Schedule, error) {
+	u.Logger.Info("Recording client attestation", zap.String("scheduleID", scheduleID.String()), zap.Bool("confirmed", confirmed))
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.ClientUserID != clientUserID {
+		return nil, domainErrors.NewAppError(errors.New("only the visit's client can confirm arrival"), domainErrors.NotAuthorized)
+	}
+	if schedule.Attestation.Status != domainSchedule.AttestationPending {
+		return nil, domainErrors.NewAppError(errors.New("no arrival confirmation is pending for this visit"), domainErrors.ValidationError)
+	}
+	if schedule.Attestation.DueAt != nil && now.After(*schedule.Attestation.DueAt) {
+		if _, err := u.expire(schedule); err != nil {
+			return nil, err
+		}
+		return nil, domainErrors.NewAppError(errors.New("the confirmation window has closed"), domainErrors.ValidationError)
+	}
+
+	status := domainSchedule.AttestationConfirmed
+	if !confirmed {
+		status = domainSchedule.AttestationDisputed
+	}
+	updated, err := u.scheduleRepository.UpdateSchedule(scheduleID, map[string]interface{}{
+		"attestation_status":       status,
+		"attestation_responded_at": now,
+		"attestation_device_id":    deviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == domainSchedule.AttestationDisputed {
+		u.Logger.Warn("Client disputed caregiver arrival", zap.String("scheduleID", scheduleID.String()))
+		if err := u.notifier.Notify(notification.Message{
+			Role:    domainUser.RoleAdmin,
+			Subject: "Client disputed caregiver arrival",
+			Body:    fmt.Sprintf("The client reported that the caregiver for %s has not arrived despite checking in.", schedule.ServiceName),
+			Data:    map[string]interface{}{"scheduleID": scheduleID.String()},
+		}); err != nil {
+			u.Logger.Error("Error notifying coordinators of dispute", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		}
+	}
+	return updated, nil
+}
+
+// Sweep marks requests that were not answered in time as expired.
+func (u *AttestationUseCase) Sweep(now time.Time) (int, error) {
+	schedules, err := u.scheduleRepository.GetPendingAttestationsDueBefore(now)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	for i := range *schedules {
+		if _, err := u.expire(&(*schedules)[i]); err != nil {
+			u.Logger.Error("Error expiring attestation", zap.Error(err), zap.String("scheduleID", (*schedules)[i].ID.String()))
+			continue
+		}
+		expired++
+	}
+	if expired > 0 {
+		u.Logger.Info("Attestation sweep completed", zap.Int("expired", expired))
+	}
+	return expired, nil
+}
+
+func (u *AttestationUseCase) expire(schedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error) {
+	return u.scheduleRepository.UpdateSchedule(schedule.ID, map[string]interface{}{
+		"attestation_status": domainSchedule.AttestationExpired,
+	})
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
@@ -0,0 +1,203 @@
+package attestation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainAttestation "caregiver/src/domain/attestation"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+// mockAttestationRepository keeps the settings in memory
+type mockAttestationRepository struct {
+	settings *domainAttestation.Settings
+}
+
+func (m *mockAttestationRepository) GetSettings() (*domainAttestation.Settings, error) {
+	if m.settings == nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return m.settings, nil
+}
+
+func (m *mockAttestationRepository) SaveSettings(settings *domainAttestation.Settings) (*domainAttestation.Settings, error) {
+	m.settings = settings
+	return settings, nil
+}
+
+// mockScheduleRepository applies attestation updates to a fixed set of schedules
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules map[uuid.UUID]*domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	s, ok := m.schedules[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *s
+	return &copied, nil
+}
+
+func (m *mockScheduleRepository) UpdateSchedule(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+	s := m.schedules[id]
+	if v, ok := updates["attestation_status"].(string); ok {
+		s.Attestation.Status = v
+	}
+	if v, ok := updates["attestation_due_at"].(time.Time); ok {
+		s.Attestation.DueAt = &v
+	}
+	if v, ok := updates["attestation_responded_at"].(time.Time); ok {
+		s.Attestation.RespondedAt = &v
+	}
+	if v, ok := updates["attestation_device_id"].(string); ok {
+		s.Attestation.DeviceID = v
+	}
+	return m.GetScheduleByID(id)
+}
+
+func (m *mockScheduleRepository) GetPendingAttestationsDueBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		if s.Attestation.Status == domainSchedule.AttestationPending && s.Attestation.DueAt.Before(before) {
+			res = append(res, *s)
+		}
+	}
+	return &res, nil
+}
+
+// mockNotifier records sent messages
+type mockNotifier struct {
+	messages []notification.Message
+}
+
+func (m *mockNotifier) Notify(message notification.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+var checkinTime = time.Date(2025, 7, 16, 9, 2, 0, 0, time.UTC)
+
+func setupTestAttestationUseCase(t *testing.T, required bool) (IAttestationUseCase, *domainSchedule.Schedule, *mockNotifier) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	checkin := checkinTime
+	visit := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: uuid.New(), VisitStatus: "in_progress", CheckinTime: &checkin}
+	scheduleRepo := &mockScheduleRepository{schedules: map[uuid.UUID]*domainSchedule.Schedule{visit.ID: visit}}
+	notifier := &mockNotifier{}
+	useCase := NewAttestationUseCase(&mockAttestationRepository{}, scheduleRepo, notifier, loggerInstance)
+	if required {
+		if _, err := useCase.SetSettings(true, 10); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return useCase, visit, notifier
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestOnScheduleEvent(t *testing.T) {
+	t.Run("Not required", func(t *testing.T) {
+		useCase, visit, notifier := setupTestAttestationUseCase(t, false)
+		useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit})
+		if visit.Attestation.Status != "" || len(notifier.messages) != 0 {
+			t.Errorf("expected no attestation request, got %q", visit.Attestation.Status)
+		}
+	})
+
+	t.Run("Requests confirmation from the client", func(t *testing.T) {
+		useCase, visit, notifier := setupTestAttestationUseCase(t, true)
+		started := *visit
+		useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: &started})
+		if started.Attestation.Status != domainSchedule.AttestationPending || !started.Attestation.DueAt.Equal(checkinTime.Add(10*time.Minute)) {
+			t.Errorf("expected pending attestation due 10 minutes after check-in, got %+v", started.Attestation)
+		}
+		if visit.Attestation.Status != domainSchedule.AttestationPending {
+			t.Error("expected the request to be stored on the visit")
+		}
+		if len(notifier.messages) != 1 || *notifier.messages[0].UserID != visit.ClientUserID {
+			t.Errorf("expected the client to be notified")
+		}
+	})
+}
+
+func TestRespond(t *testing.T) {
+	t.Run("Confirms within the window", func(t *testing.T) {
+		useCase, visit, _ := setupTestAttestationUseCase(t, true)
+		useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit})
+		updated, err := useCase.Respond(visit.ID, visit.ClientUserID, true, "phone-1", checkinTime.Add(5*time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Attestation.Status != domainSchedule.AttestationConfirmed || updated.Attestation.DeviceID != "phone-1" {
+			t.Errorf("expected confirmed attestation, got %+v", updated.Attestation)
+		}
+		if _, err := useCase.Respond(visit.ID, visit.ClientUserID, true, "phone-1", checkinTime.Add(6*time.Minute)); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected a second answer to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Dispute alerts coordinators", func(t *testing.T) {
+		useCase, visit, notifier := setupTestAttestationUseCase(t, true)
+		useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit})
+		updated, err := useCase.Respond(visit.ID, visit.ClientUserID, false, "", checkinTime.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Attestation.Status != domainSchedule.AttestationDisputed {
+			t.Errorf("expected disputed, got %s", updated.Attestation.Status)
+		}
+		if len(notifier.messages) != 2 || notifier.messages[1].Role == "" {
+			t.Errorf("expected a coordinator notification")
+		}
+	})
+
+	t.Run("Only the client can answer", func(t *testing.T) {
+		useCase, visit, _ := setupTestAttestationUseCase(t, true)
+		useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit})
+		if _, err := useCase.Respond(visit.ID, uuid.New(), true, "", checkinTime.Add(time.Minute)); errorType(err) != domainErrors.NotAuthorized {
+			t.Errorf("expected not authorized, got %v", err)
+		}
+	})
+
+	t.Run("Late answer expires", func(t *testing.T) {
+		useCase, visit, _ := setupTestAttestationUseCase(t, true)
+		useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit})
+		if _, err := useCase.Respond(visit.ID, visit.ClientUserID, true, "", checkinTime.Add(11*time.Minute)); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+		if visit.Attestation.Status != domainSchedule.AttestationExpired {
+			t.Errorf("expected expired, got %s", visit.Attestation.Status)
+		}
+	})
+}
+
+func TestSweep(t *testing.T) {
+	useCase, visit, _ := setupTestAttestationUseCase(t, true)
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit})
+
+	if expired, err := useCase.Sweep(checkinTime.Add(5 * time.Minute)); err != nil || expired != 0 {
+		t.Fatalf("expected nothing to expire yet, got %d (%v)", expired, err)
+	}
+	if expired, err := useCase.Sweep(checkinTime.Add(11 * time.Minute)); err != nil || expired != 1 {
+		t.Fatalf("expected one expiry, got %d (%v)", expired, err)
+	}
+	if visit.Attestation.Status != domainSchedule.AttestationExpired {
+		t.Errorf("expected expired, got %s", visit.Attestation.Status)
+	}
+}
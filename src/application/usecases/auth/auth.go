@@ -17,19 +17,33 @@ import (
 type IAuthUseCase interface {
 	Login(email, password string) (*domainUser.User, *AuthTokens, error)
 	AccessTokenByRefreshToken(refreshToken string) (*domainUser.User, *AuthTokens, error)
+	// IssueTokens issues a fresh access and refresh token for a user without
+	// their password, for operators helping someone who is locked out.
+	IssueTokens(userID uuid.UUID) (*domainUser.User, *AuthTokens, error)
+	// SetPassword sets the password of the user a password token was issued
+	// to, using the token up.
+	SetPassword(token, password string) error
+	ChangePassword(userID uuid.UUID, currentPassword, newPassword string) error
+	// ResetPassword lets an admin clear a user's password and issue a token
+	// the user sets a new one with. It also issues a new user's first token.
+	ResetPassword(adminID, userID uuid.UUID) (*PasswordReset, error)
 }
 
 type AuthUseCase struct {
-	UserRepository user.UserRepositoryInterface
-	JWTService     security.IJWTService
-	Logger         *logger.Logger
+	UserRepository     user.UserRepositoryInterface
+	PasswordRepository domainUser.IPasswordRepository
+	JWTService         security.IJWTService
+	PasswordService    security.IPasswordService
+	Logger             *logger.Logger
 }
 
-func NewAuthUseCase(userRepository user.UserRepositoryInterface, jwtService security.IJWTService, loggerInstance *logger.Logger) IAuthUseCase {
+func NewAuthUseCase(userRepository user.UserRepositoryInterface, passwordRepository domainUser.IPasswordRepository, jwtService security.IJWTService, passwordService security.IPasswordService, loggerInstance *logger.Logger) IAuthUseCase {
 	return &AuthUseCase{
-		UserRepository: userRepository,
-		JWTService:     jwtService,
-		Logger:         loggerInstance,
+		UserRepository:     userRepository,
+		PasswordRepository: passwordRepository,
+		JWTService:         jwtService,
+		PasswordService:    passwordService,
+		Logger:             loggerInstance,
 	}
 }
 
@@ -52,31 +66,16 @@ func (s *AuthUseCase) Login(email, password string) (*domainUser.User, *AuthToke
 		return nil, nil, domainErrors.NewAppError(errors.New("email or password does not match"), domainErrors.NotAuthenticated)
 	}
 
-	// Password checking removed as authentication is disabled.
-	// isAuthenticated := checkPasswordHash(password, user.HashPassword)
-	// if !isAuthenticated {
-	// 	s.Logger.Warn("Login failed: invalid password", zap.String("email", email))
-	// 	return nil, nil, domainErrors.NewAppError(errors.New("email or password does not match"), domainErrors.NotAuthenticated)
-	// }
-
-	accessTokenClaims, err := s.JWTService.GenerateJWTToken(user.ID.String(), "access")
-	if err != nil {
-		s.Logger.Error("Error generating access token", zap.Error(err), zap.String("userID", user.ID.String()))
-		return nil, nil, err
+	if !s.PasswordService.CheckPassword(user.HashPassword, password) {
+		s.Logger.Warn("Login failed: invalid password", zap.String("email", email))
+		return nil, nil, domainErrors.NewAppError(errors.New("email or password does not match"), domainErrors.NotAuthenticated)
 	}
-	refreshTokenClaims, err := s.JWTService.GenerateJWTToken(user.ID.String(), "refresh")
+
+	authTokens, err := s.generateTokens(user)
 	if err != nil {
-		s.Logger.Error("Error generating refresh token", zap.Error(err), zap.String("userID", user.ID.String()))
 		return nil, nil, err
 	}
 
-	authTokens := &AuthTokens{
-		AccessToken:               accessTokenClaims.Token,
-		RefreshToken:              refreshTokenClaims.Token,
-		ExpirationAccessDateTime:  accessTokenClaims.ExpirationTime,
-		ExpirationRefreshDateTime: refreshTokenClaims.ExpirationTime,
-	}
-
 	s.Logger.Info("User login successful", zap.String("email", email), zap.String("userID", user.ID.String()))
 	return user, authTokens, nil
 }
@@ -118,3 +117,37 @@ func (s *AuthUseCase) AccessTokenByRefreshToken(refreshToken string) (*domainUse
 	s.Logger.Info("Access token refreshed successfully", zap.String("userID", user.ID.String()))
 	return user, authTokens, nil
 }
+
+func (s *AuthUseCase) IssueTokens(userID uuid.UUID) (*domainUser.User, *AuthTokens, error) {
+	s.Logger.Info("Issuing tokens", zap.String("userID", userID.String()))
+	user, err := s.UserRepository.GetByID(userID)
+	if err != nil {
+		s.Logger.Error("Error getting user for token issue", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, nil, err
+	}
+	authTokens, err := s.generateTokens(user)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.Logger.Info("Tokens issued", zap.String("userID", user.ID.String()))
+	return user, authTokens, nil
+}
+
+func (s *AuthUseCase) generateTokens(user *domainUser.User) (*AuthTokens, error) {
+	accessTokenClaims, err := s.JWTService.GenerateJWTToken(user.ID.String(), "access")
+	if err != nil {
+		s.Logger.Error("Error generating access token", zap.Error(err), zap.String("userID", user.ID.String()))
+		return nil, err
+	}
+	refreshTokenClaims, err := s.JWTService.GenerateJWTToken(user.ID.String(), "refresh")
+	if err != nil {
+		s.Logger.Error("Error generating refresh token", zap.Error(err), zap.String("userID", user.ID.String()))
+		return nil, err
+	}
+	return &AuthTokens{
+		AccessToken:               accessTokenClaims.Token,
+		RefreshToken:              refreshTokenClaims.Token,
+		ExpirationAccessDateTime:  accessTokenClaims.ExpirationTime,
+		ExpirationRefreshDateTime: refreshTokenClaims.ExpirationTime,
+	}, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type mockUserService struct {
@@ -25,6 +26,9 @@ type mockUserService struct {
 func (m *mockUserService) GetAll() (*[]domainUser.User, error) {
 	return nil, nil
 }
+func (m *mockUserService) Stream(fn func(*domainUser.User) error) error {
+	return nil
+}
 func (m *mockUserService) GetByID(id uuid.UUID) (*domainUser.User, error) {
 	m.callGetByIDCalled = true
 	return m.getByIDFn(id)
@@ -39,13 +43,19 @@ func (m *mockUserService) Create(newUser *domainUser.User) (*domainUser.User, er
 func (m *mockUserService) Delete(id uuid.UUID) error {
 	return nil
 }
+func (m *mockUserService) DeleteBy(id uuid.UUID, deletedBy *uuid.UUID) error {
+	return nil
+}
+func (m *mockUserService) Restore(id uuid.UUID) (*domainUser.User, error) {
+	return nil, nil
+}
 func (m *mockUserService) Update(id uuid.UUID, userMap map[string]interface{}) (*domainUser.User, error) {
 	return nil, nil
 }
 func (m *mockUserService) SearchPaginated(filters domain.DataFilters) (*domainUser.SearchResultUser, error) {
 	return nil, nil
 }
-func (m *mockUserService) SearchByProperty(property string, searchText string) (*[]string, error) {
+func (m *mockUserService) SearchByProperty(search domainUser.PropertySearch) (*domainUser.SearchResultProperty, error) {
 	return nil, nil
 }
 
@@ -70,7 +80,20 @@ func setupLogger(t *testing.T) *logger.Logger {
 	return loggerInstance
 }
 
+func testPasswordService() security.IPasswordService {
+	return security.NewPasswordServiceWithCost(bcrypt.MinCost)
+}
+
+func mustHash(t *testing.T, password string) string {
+	hash, err := testPasswordService().HashPassword(password)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	return hash
+}
+
 func TestAuthUseCase_Login(t *testing.T) {
+	hash := mustHash(t, "mySecretPass")
 	tests := []struct {
 		name                   string
 		mockGetByEmailFn       func(string) (*domainUser.User, error)
@@ -110,21 +133,50 @@ func TestAuthUseCase_Login(t *testing.T) {
 		{
 			name: "Access token generation fails",
 			mockGetByEmailFn: func(email string) (*domainUser.User, error) {
-				return &domainUser.User{ID: uuid.New()}, nil
+				return &domainUser.User{ID: uuid.New(), HashPassword: hash}, nil
 			},
 			mockGenerateTokenFn: func(userID string, tokenType string) (*security.AppToken, error) {
 				return nil, errors.New("token generation failed")
 			},
 			inputEmail:    "test@example.com",
-			inputPassword: "somePass",
+			inputPassword: "mySecretPass",
 			wantErr:       true,
 		},
+		{
+			name: "Wrong password",
+			mockGetByEmailFn: func(email string) (*domainUser.User, error) {
+				return &domainUser.User{ID: uuid.New(), HashPassword: hash}, nil
+			},
+			mockGenerateTokenFn: func(userID string, tokenType string) (*security.AppToken, error) {
+				return &security.AppToken{Token: "test_token"}, nil
+			},
+			inputEmail:        "test@example.com",
+			inputPassword:     "notMyPass",
+			wantErr:           true,
+			wantErrType:       domainErrors.NotAuthenticated,
+			wantEmptySecurity: true,
+		},
+		{
+			name: "No password set yet",
+			mockGetByEmailFn: func(email string) (*domainUser.User, error) {
+				return &domainUser.User{ID: uuid.New()}, nil
+			},
+			mockGenerateTokenFn: func(userID string, tokenType string) (*security.AppToken, error) {
+				return &security.AppToken{Token: "test_token"}, nil
+			},
+			inputEmail:        "test@example.com",
+			inputPassword:     "",
+			wantErr:           true,
+			wantErrType:       domainErrors.NotAuthenticated,
+			wantEmptySecurity: true,
+		},
 		{
 			name: "OK - everything correct",
 			mockGetByEmailFn: func(email string) (*domainUser.User, error) {
 				return &domainUser.User{
-					ID:    uuid.New(),
-					Email: "test@example.com",
+					ID:           uuid.New(),
+					Email:        "test@example.com",
+					HashPassword: hash,
 				}, nil
 			},
 			mockGenerateTokenFn: func(userID string, tokenType string) (*security.AppToken, error) {
@@ -151,7 +203,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 			}
 
 			logger := setupLogger(t)
-			uc := NewAuthUseCase(userRepoMock, jwtMock, logger)
+			uc := NewAuthUseCase(userRepoMock, nil, jwtMock, testPasswordService(), logger)
 
 			user, authTokens, err := uc.Login(tt.inputEmail, tt.inputPassword)
 			if (err != nil) != tt.wantErr {
@@ -292,7 +344,7 @@ func TestAuthUseCase_AccessTokenByRefreshToken(t *testing.T) {
 			}
 
 			logger := setupLogger(t)
-			uc := NewAuthUseCase(userRepoMock, jwtMock, logger)
+			uc := NewAuthUseCase(userRepoMock, nil, jwtMock, testPasswordService(), logger)
 
 			user, authTokens, err := uc.AccessTokenByRefreshToken(tt.inputRefreshToken)
 			if (err != nil) != tt.wantErr {
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+	"caregiver/src/infrastructure/security"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// passwordTokenTTL is how long a user has to set their password.
+const passwordTokenTTL = 72 * time.Hour
+
+const passwordTokenPrefix = "pw_"
+
+// PasswordReset is a password token to hand to the user. The token itself is
+// only ever returned here.
+type PasswordReset struct {
+	UserID    uuid.UUID
+	Token     string
+	ExpiresAt time.Time
+}
+
+func (s *AuthUseCase) SetPassword(token, password string) error {
+	hash, err := s.hashPassword(password)
+	if err != nil {
+		return err
+	}
+	userID, err := s.PasswordRepository.SetPasswordWithToken(hashPasswordToken(token), hash, time.Now().UTC())
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+			return domainErrors.NewAppError(errors.New("the password link is invalid or has expired"), domainErrors.NotAuthenticated)
+		}
+		return err
+	}
+	s.Logger.Info("Password set with token", zap.String("userID", userID.String()))
+	return nil
+}
+
+func (s *AuthUseCase) ChangePassword(userID uuid.UUID, currentPassword, newPassword string) error {
+	user, err := s.UserRepository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if !s.PasswordService.CheckPassword(user.HashPassword, currentPassword) {
+		s.Logger.Warn("Password change refused: current password does not match", zap.String("userID", userID.String()))
+		return domainErrors.NewAppError(errors.New("current password does not match"), domainErrors.NotAuthenticated)
+	}
+	hash, err := s.hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.PasswordRepository.SetPassword(userID, hash); err != nil {
+		return err
+	}
+	s.Logger.Info("Password changed", zap.String("userID", userID.String()))
+	return nil
+}
+
+func (s *AuthUseCase) ResetPassword(adminID, userID uuid.UUID) (*PasswordReset, error) {
+	admin, err := s.UserRepository.GetByID(adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.Role != domainUser.RoleAdmin {
+		return nil, domainErrors.NewAppError(errors.New("only an admin can reset passwords"), domainErrors.NotAuthorized)
+	}
+	if _, err := s.UserRepository.GetByID(userID); err != nil {
+		return nil, err
+	}
+	if err := s.PasswordRepository.SetPassword(userID, ""); err != nil {
+		return nil, err
+	}
+	token, err := generatePasswordToken()
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.PasswordRepository.CreatePasswordToken(&domainUser.PasswordToken{
+		UserID:    userID,
+		TokenHash: hashPasswordToken(token),
+		ExpiresAt: time.Now().UTC().Add(passwordTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.Logger.Info("Password reset", zap.String("userID", userID.String()), zap.String("adminID", adminID.String()))
+	return &PasswordReset{UserID: userID, Token: token, ExpiresAt: stored.ExpiresAt}, nil
+}
+
+func (s *AuthUseCase) hashPassword(password string) (string, error) {
+	hash, err := s.PasswordService.HashPassword(password)
+	if errors.Is(err, security.ErrPasswordTooShort) || errors.Is(err, security.ErrPasswordTooLong) {
+		return "", domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
+	return hash, err
+}
+
+func generatePasswordToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return passwordTokenPrefix + hex.EncodeToString(buf), nil
+}
+
+func hashPasswordToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+type mockPasswordRepository struct {
+	hashes map[uuid.UUID]string
+	tokens []domainUser.PasswordToken
+}
+
+func newMockPasswordRepository() *mockPasswordRepository {
+	return &mockPasswordRepository{hashes: map[uuid.UUID]string{}}
+}
+
+func (m *mockPasswordRepository) SetPassword(userID uuid.UUID, hash string) error {
+	m.hashes[userID] = hash
+	return nil
+}
+
+func (m *mockPasswordRepository) CreatePasswordToken(token *domainUser.PasswordToken) (*domainUser.PasswordToken, error) {
+	m.tokens = append(m.tokens, *token)
+	return token, nil
+}
+
+func (m *mockPasswordRepository) SetPasswordWithToken(tokenHash, passwordHash string, now time.Time) (uuid.UUID, error) {
+	for i, token := range m.tokens {
+		if token.TokenHash == tokenHash && token.UsedAt == nil && now.Before(token.ExpiresAt) {
+			m.tokens[i].UsedAt = &now
+			m.hashes[token.UserID] = passwordHash
+			return token.UserID, nil
+		}
+	}
+	return uuid.Nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestAuthUseCase_ResetAndSetPassword(t *testing.T) {
+	adminID, caregiverID := uuid.New(), uuid.New()
+	users := map[uuid.UUID]*domainUser.User{
+		adminID:     {ID: adminID, Role: domainUser.RoleAdmin},
+		caregiverID: {ID: caregiverID, Role: domainUser.RoleCaregiver, HashPassword: mustHash(t, "oldPassword")},
+	}
+	userRepoMock := &mockUserService{getByIDFn: func(id uuid.UUID) (*domainUser.User, error) {
+		if user, ok := users[id]; ok {
+			return user, nil
+		}
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}}
+	passwordRepo := newMockPasswordRepository()
+	uc := NewAuthUseCase(userRepoMock, passwordRepo, &mockJWTService{}, testPasswordService(), setupLogger(t))
+
+	if _, err := uc.ResetPassword(caregiverID, adminID); errorType(err) != domainErrors.NotAuthorized {
+		t.Fatalf("expected a caregiver's reset to be NotAuthorized, got %v", err)
+	}
+
+	reset, err := uc.ResetPassword(adminID, caregiverID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(reset.Token, passwordTokenPrefix) {
+		t.Errorf("expected token prefix %q, got %q", passwordTokenPrefix, reset.Token)
+	}
+	if hash, ok := passwordRepo.hashes[caregiverID]; !ok || hash != "" {
+		t.Errorf("expected the old password to be cleared, got %q", hash)
+	}
+	if passwordRepo.tokens[0].TokenHash == reset.Token {
+		t.Error("expected only a hash of the token to be stored")
+	}
+
+	if err := uc.SetPassword(reset.Token, "short"); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a short password to be a ValidationError, got %v", err)
+	}
+	if err := uc.SetPassword(reset.Token, "newPassword"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !testPasswordService().CheckPassword(passwordRepo.hashes[caregiverID], "newPassword") {
+		t.Error("expected the new password to be stored")
+	}
+	if err := uc.SetPassword(reset.Token, "anotherPassword"); errorType(err) != domainErrors.NotAuthenticated {
+		t.Errorf("expected a used token to be refused, got %v", err)
+	}
+}
+
+func TestAuthUseCase_ChangePassword(t *testing.T) {
+	userID := uuid.New()
+	userRepoMock := &mockUserService{getByIDFn: func(id uuid.UUID) (*domainUser.User, error) {
+		return &domainUser.User{ID: id, HashPassword: mustHash(t, "currentPassword")}, nil
+	}}
+	passwordRepo := newMockPasswordRepository()
+	uc := NewAuthUseCase(userRepoMock, passwordRepo, &mockJWTService{}, testPasswordService(), setupLogger(t))
+
+	if err := uc.ChangePassword(userID, "wrongPassword", "newPassword"); errorType(err) != domainErrors.NotAuthenticated {
+		t.Errorf("expected a wrong current password to be NotAuthenticated, got %v", err)
+	}
+	if _, changed := passwordRepo.hashes[userID]; changed {
+		t.Error("expected the password to be unchanged")
+	}
+	if err := uc.ChangePassword(userID, "currentPassword", "newPassword"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !testPasswordService().CheckPassword(passwordRepo.hashes[userID], "newPassword") {
+		t.Error("expected the new password to be stored")
+	}
+}
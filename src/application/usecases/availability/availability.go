@@ -0,0 +1,92 @@
+package availability
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLeave "caregiver/src/domain/leave"
+	domainPlanner "caregiver/src/domain/planner"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxCalendarDays bounds the period a calendar covers.
+const MaxCalendarDays = 92
+
+// Calendar is when a caregiver can be booked over a period: their weekly
+// windows and the time off, approved or pending, overlapping the period.
+type Calendar struct {
+	CaregiverUserID uuid.UUID
+	From            time.Time
+	To              time.Time
+	Windows         []domainPlanner.Availability
+	TimeOff         []domainLeave.Request
+}
+
+type IAvailabilityUseCase interface {
+	GetCalendar(caregiverUserID uuid.UUID, from, to time.Time) (*Calendar, error)
+	// ValidateSchedule rejects assigning a caregiver outside their weekly
+	// availability. Caregivers who have not set any windows are taken to be
+	// available at any time; approved time off is checked by the leave
+	// validator.
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+}
+
+type AvailabilityUseCase struct {
+	plannerRepository domainPlanner.IPlannerRepository
+	leaveRepository   domainLeave.ILeaveRepository
+	Logger            *logger.Logger
+}
+
+func NewAvailabilityUseCase(plannerRepository domainPlanner.IPlannerRepository, leaveRepository domainLeave.ILeaveRepository, loggerInstance *logger.Logger) IAvailabilityUseCase {
+	return &AvailabilityUseCase{
+		plannerRepository: plannerRepository,
+		leaveRepository:   leaveRepository,
+		Logger:            loggerInstance,
+	}
+}
+
+func (u *AvailabilityUseCase) GetCalendar(caregiverUserID uuid.UUID, from, to time.Time) (*Calendar, error) {
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+	if to.Sub(from) > MaxCalendarDays*24*time.Hour {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the period can be at most %d days", MaxCalendarDays), domainErrors.ValidationError)
+	}
+	windows, err := u.plannerRepository.GetAvailability([]uuid.UUID{caregiverUserID})
+	if err != nil {
+		return nil, err
+	}
+	requests, err := u.leaveRepository.GetRequests(domainLeave.RequestFilter{
+		CaregiverUserID: &caregiverUserID,
+		Statuses:        []string{domainLeave.StatusApproved, domainLeave.StatusPending},
+		From:            &from,
+		To:              &to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Calendar{CaregiverUserID: caregiverUserID, From: from, To: to, Windows: *windows, TimeOff: *requests}, nil
+}
+
+func (u *AvailabilityUseCase) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.VisitStatus == "cancelled" || schedule.AssignedUserID == uuid.Nil {
+		return nil, nil
+	}
+	windows, err := u.plannerRepository.GetAvailability([]uuid.UUID{schedule.AssignedUserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(*windows) == 0 || domainPlanner.CoveredBy(*windows, schedule.ScheduledSlot.From, schedule.ScheduledSlot.To) {
+		return nil, nil
+	}
+	u.Logger.Warn("Schedule falls outside caregiver availability",
+		zap.String("assignedUserID", schedule.AssignedUserID.String()),
+		zap.Time("from", schedule.ScheduledSlot.From))
+	return nil, domainErrors.NewAppError(errors.New("the visit falls outside the caregiver's weekly availability"), domainErrors.Conflict)
+}
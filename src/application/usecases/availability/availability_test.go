@@ -0,0 +1,124 @@
+package availability
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLeave "caregiver/src/domain/leave"
+	domainPlanner "caregiver/src/domain/planner"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockPlannerRepository serves fixed availability windows
+type mockPlannerRepository struct {
+	domainPlanner.IPlannerRepository
+	windows []domainPlanner.Availability
+}
+
+func (m *mockPlannerRepository) GetAvailability(caregiverUserIDs []uuid.UUID) (*[]domainPlanner.Availability, error) {
+	res := []domainPlanner.Availability{}
+	for _, w := range m.windows {
+		if w.CaregiverUserID == caregiverUserIDs[0] {
+			res = append(res, w)
+		}
+	}
+	return &res, nil
+}
+
+// mockLeaveRepository serves fixed leave requests
+type mockLeaveRepository struct {
+	domainLeave.ILeaveRepository
+	requests []domainLeave.Request
+}
+
+func (m *mockLeaveRepository) GetRequests(filter domainLeave.RequestFilter) (*[]domainLeave.Request, error) {
+	res := []domainLeave.Request{}
+	for _, r := range m.requests {
+		if r.CaregiverUserID == *filter.CaregiverUserID && r.Overlaps(*filter.From, *filter.To) {
+			res = append(res, r)
+		}
+	}
+	return &res, nil
+}
+
+func TestValidateSchedule(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	ana, ben := uuid.New(), uuid.New()
+	planner := &mockPlannerRepository{windows: []domainPlanner.Availability{
+		{CaregiverUserID: ana, Weekday: int(time.Monday), StartMinute: 8 * 60, EndMinute: 12 * 60, Timezone: "America/New_York"},
+		{CaregiverUserID: ana, Weekday: int(time.Monday), StartMinute: 12 * 60, EndMinute: 24 * 60, Timezone: "America/New_York"},
+		{CaregiverUserID: ana, Weekday: int(time.Tuesday), StartMinute: 0, EndMinute: 2 * 60, Timezone: "America/New_York"},
+	}}
+	useCase := NewAvailabilityUseCase(planner, &mockLeaveRepository{}, loggerInstance)
+
+	newYork, _ := time.LoadLocation("America/New_York")
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, newYork)
+	}
+	tests := []struct {
+		name      string
+		assignee  uuid.UUID
+		from, to  time.Time
+		status    string
+		wantError bool
+	}{
+		{"Inside one window", ana, monday(9, 0), monday(11, 0), "upcoming", false},
+		{"Across back to back windows", ana, monday(11, 0), monday(13, 0), "upcoming", false},
+		{"Across midnight", ana, monday(23, 0), monday(25, 30), "upcoming", false},
+		{"Starts too early", ana, monday(7, 30), monday(9, 0), "upcoming", true},
+		{"Runs past the last window", ana, monday(25, 0), monday(26, 30), "upcoming", true},
+		{"Other weekday", ana, monday(9, 0).AddDate(0, 0, 2), monday(10, 0).AddDate(0, 0, 2), "upcoming", true},
+		{"Cancelled visits are not checked", ana, monday(6, 0), monday(7, 0), "cancelled", false},
+		{"No windows set", ben, monday(3, 0), monday(4, 0), "upcoming", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.ValidateSchedule(&domainSchedule.Schedule{
+				AssignedUserID: tt.assignee,
+				VisitStatus:    tt.status,
+				ScheduledSlot:  domainSchedule.ScheduledSlot{From: tt.from.UTC(), To: tt.to.UTC()},
+			})
+			var appErr *domainErrors.AppError
+			if tt.wantError && (!errors.As(err, &appErr) || appErr.Type != domainErrors.Conflict) {
+				t.Errorf("expected a conflict, got %v", err)
+			}
+			if !tt.wantError && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetCalendar(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	ana := uuid.New()
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	leave := &mockLeaveRepository{requests: []domainLeave.Request{
+		{ID: uuid.New(), CaregiverUserID: ana, Status: domainLeave.StatusApproved, From: from.AddDate(0, 0, 3), To: from.AddDate(0, 0, 5)},
+		{ID: uuid.New(), CaregiverUserID: ana, Status: domainLeave.StatusApproved, From: from.AddDate(0, 0, 30), To: from.AddDate(0, 0, 31)},
+	}}
+	planner := &mockPlannerRepository{windows: []domainPlanner.Availability{{CaregiverUserID: ana, Weekday: 1, StartMinute: 480, EndMinute: 960}}}
+	useCase := NewAvailabilityUseCase(planner, leave, loggerInstance)
+
+	calendar, err := useCase.GetCalendar(ana, from, from.AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calendar.Windows) != 1 || len(calendar.TimeOff) != 1 {
+		t.Errorf("expected one window and the time off in the period, got %+v", calendar)
+	}
+	if _, err := useCase.GetCalendar(ana, from, from.AddDate(1, 0, 0)); err == nil {
+		t.Error("expected a year-long period to be refused")
+	}
+}
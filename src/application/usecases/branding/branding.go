@@ -0,0 +1,261 @@
+package branding
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	domainBranding "caregiver/src/domain/branding"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	mailer "caregiver/src/infrastructure/mail"
+	"caregiver/src/infrastructure/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxLogoSize caps logo uploads at 1 MiB.
+const MaxLogoSize = 1 << 20
+
+// logoExtensions are the logo formats PDFs can embed.
+var logoExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+}
+
+type IBrandingUseCase interface {
+	Get() (*domainBranding.Branding, error)
+	Set(branding *domainBranding.Branding) (*domainBranding.Branding, error)
+	UploadLogo(contentType string, size int64, content io.Reader) (*domainBranding.Branding, error)
+	OpenLogo() (*domainBranding.Branding, io.ReadCloser, error)
+	DeleteLogo() (*domainBranding.Branding, error)
+	LogoImage() ([]byte, error)
+	Mailer(inner mailer.IMailer) mailer.IMailer
+}
+
+type BrandingUseCase struct {
+	brandingRepository domainBranding.IBrandingRepository
+	storage            storage.IFileStorage
+	Logger             *logger.Logger
+}
+
+func NewBrandingUseCase(brandingRepository domainBranding.IBrandingRepository, fileStorage storage.IFileStorage, loggerInstance *logger.Logger) IBrandingUseCase {
+	return &BrandingUseCase{
+		brandingRepository: brandingRepository,
+		storage:            fileStorage,
+		Logger:             loggerInstance,
+	}
+}
+
+// Get returns the agency branding, empty when none has been set up.
+func (u *BrandingUseCase) Get() (*domainBranding.Branding, error) {
+	branding, err := u.brandingRepository.Get()
+	if isNotFound(err) {
+		return &domainBranding.Branding{}, nil
+	}
+	return branding, err
+}
+
+// Set replaces the branding. The uploaded logo is kept while LogoURL still
+// points at it; linking another logo drops the upload.
+func (u *BrandingUseCase) Set(branding *domainBranding.Branding) (*domainBranding.Branding, error) {
+	u.Logger.Info("Setting agency branding")
+	branding.DisplayName = strings.TrimSpace(branding.DisplayName)
+	branding.SenderName = strings.TrimSpace(branding.SenderName)
+	branding.ReplyTo = strings.TrimSpace(branding.ReplyTo)
+	if err := validate(branding); err != nil {
+		return nil, err
+	}
+	existing, err := u.Get()
+	if err != nil {
+		return nil, err
+	}
+	branding.ID = existing.ID
+	staleKey := ""
+	if existing.HasLogo() {
+		if branding.LogoURL == domainBranding.LogoPath {
+			branding.LogoKey = existing.LogoKey
+			branding.LogoContentType = existing.LogoContentType
+		} else {
+			staleKey = existing.LogoKey
+		}
+	} else if branding.LogoURL == domainBranding.LogoPath {
+		return nil, domainErrors.NewAppError(errors.New("no logo has been uploaded"), domainErrors.ValidationError)
+	}
+	saved, err := u.brandingRepository.Save(branding)
+	if err != nil {
+		return nil, err
+	}
+	u.removeLogo(staleKey)
+	return saved, nil
+}
+
+// UploadLogo stores a PNG or JPEG logo and points LogoURL at it, replacing
+// any earlier logo.
+func (u *BrandingUseCase) UploadLogo(contentType string, size int64, content io.Reader) (*domainBranding.Branding, error) {
+	u.Logger.Info("Uploading agency logo", zap.String("contentType", contentType), zap.Int64("size", size))
+	extension, ok := logoExtensions[contentType]
+	if !ok {
+		return nil, domainErrors.NewAppError(fmt.Errorf("content type '%s' is not allowed; use PNG or JPEG", contentType), domainErrors.ValidationError)
+	}
+	if size <= 0 || size > MaxLogoSize {
+		return nil, domainErrors.NewAppError(errors.New("logo must be between 1 byte and 1 MiB"), domainErrors.ValidationError)
+	}
+	data, err := io.ReadAll(io.LimitReader(content, MaxLogoSize+1))
+	if err != nil {
+		return nil, domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
+	if len(data) > MaxLogoSize {
+		return nil, domainErrors.NewAppError(errors.New("logo must be between 1 byte and 1 MiB"), domainErrors.ValidationError)
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return nil, domainErrors.NewAppError(errors.New("logo is not a readable image"), domainErrors.ValidationError)
+	}
+
+	branding, err := u.Get()
+	if err != nil {
+		return nil, err
+	}
+	staleKey := branding.LogoKey
+	branding.LogoKey = fmt.Sprintf("branding/logo-%s%s", uuid.New(), extension)
+	branding.LogoContentType = contentType
+	branding.LogoURL = domainBranding.LogoPath
+	if err := u.storage.Save(branding.LogoKey, bytes.NewReader(data)); err != nil {
+		u.Logger.Error("Error storing agency logo", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	saved, err := u.brandingRepository.Save(branding)
+	if err != nil {
+		u.removeLogo(branding.LogoKey)
+		return nil, err
+	}
+	u.removeLogo(staleKey)
+	return saved, nil
+}
+
+func (u *BrandingUseCase) OpenLogo() (*domainBranding.Branding, io.ReadCloser, error) {
+	branding, err := u.Get()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !branding.HasLogo() {
+		return nil, nil, domainErrors.NewAppError(errors.New("no logo has been uploaded"), domainErrors.NotFound)
+	}
+	content, err := u.storage.Open(branding.LogoKey)
+	if err != nil {
+		u.Logger.Error("Error opening agency logo", zap.Error(err))
+		return nil, nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return branding, content, nil
+}
+
+func (u *BrandingUseCase) DeleteLogo() (*domainBranding.Branding, error) {
+	u.Logger.Info("Deleting agency logo")
+	branding, err := u.Get()
+	if err != nil {
+		return nil, err
+	}
+	if !branding.HasLogo() {
+		return nil, domainErrors.NewAppError(errors.New("no logo has been uploaded"), domainErrors.NotFound)
+	}
+	staleKey := branding.LogoKey
+	branding.LogoKey, branding.LogoContentType, branding.LogoURL = "", "", ""
+	saved, err := u.brandingRepository.Save(branding)
+	if err != nil {
+		return nil, err
+	}
+	u.removeLogo(staleKey)
+	return saved, nil
+}
+
+// LogoImage returns the uploaded logo for embedding in documents, or nil
+// when there is none.
+func (u *BrandingUseCase) LogoImage() ([]byte, error) {
+	_, content, err := u.OpenLogo()
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return io.ReadAll(io.LimitReader(content, MaxLogoSize))
+}
+
+// Mailer wraps a mailer so every message goes out under the agency's sender
+// name, else its display name, and reply-to address, unless the message sets
+// its own.
+func (u *BrandingUseCase) Mailer(inner mailer.IMailer) mailer.IMailer {
+	return &brandedMailer{inner: inner, branding: u}
+}
+
+type brandedMailer struct {
+	inner    mailer.IMailer
+	branding *BrandingUseCase
+}
+
+func (m *brandedMailer) Send(ctx context.Context, message mailer.Message) error {
+	branding, err := m.branding.Get()
+	if err != nil {
+		m.branding.Logger.Warn("Sending email without branding", zap.Error(err))
+		return m.inner.Send(ctx, message)
+	}
+	if message.FromName == "" {
+		message.FromName = branding.SenderName
+	}
+	if message.FromName == "" {
+		message.FromName = branding.DisplayName
+	}
+	if message.ReplyTo == "" {
+		message.ReplyTo = branding.ReplyTo
+	}
+	return m.inner.Send(ctx, message)
+}
+
+func (u *BrandingUseCase) removeLogo(key string) {
+	if key == "" {
+		return
+	}
+	if err := u.storage.Delete(key); err != nil {
+		u.Logger.Warn("Error removing agency logo", zap.Error(err), zap.String("key", key))
+	}
+}
+
+func validate(b *domainBranding.Branding) error {
+	for _, color := range []struct{ name, value string }{{"primary color", b.PrimaryColor}, {"accent color", b.AccentColor}} {
+		if _, _, _, ok := domainBranding.ParseColor(color.value); color.value != "" && !ok {
+			return domainErrors.NewAppError(fmt.Errorf("%s must be written as #RRGGBB", color.name), domainErrors.ValidationError)
+		}
+	}
+	if b.LogoURL != "" && b.LogoURL != domainBranding.LogoPath {
+		parsed, err := url.Parse(b.LogoURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return domainErrors.NewAppError(errors.New("logo url must be an absolute http or https url"), domainErrors.ValidationError)
+		}
+	}
+	if strings.ContainsAny(b.SenderName, "\r\n") || len(b.SenderName) > 100 {
+		return domainErrors.NewAppError(errors.New("sender name must be a single line of at most 100 characters"), domainErrors.ValidationError)
+	}
+	if b.ReplyTo != "" {
+		address, err := mail.ParseAddress(b.ReplyTo)
+		if err != nil {
+			return domainErrors.NewAppError(errors.New("reply-to must be an email address"), domainErrors.ValidationError)
+		}
+		b.ReplyTo = address.Address
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
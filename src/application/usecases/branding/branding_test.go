@@ -0,0 +1,201 @@
+package branding
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"testing"
+
+	domainBranding "caregiver/src/domain/branding"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	mailer "caregiver/src/infrastructure/mail"
+)
+
+// mockBrandingRepository keeps the single branding row in memory
+type mockBrandingRepository struct {
+	branding *domainBranding.Branding
+}
+
+func (m *mockBrandingRepository) Get() (*domainBranding.Branding, error) {
+	if m.branding == nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *m.branding
+	return &copied, nil
+}
+
+func (m *mockBrandingRepository) Save(branding *domainBranding.Branding) (*domainBranding.Branding, error) {
+	copied := *branding
+	m.branding = &copied
+	return m.Get()
+}
+
+// mockStorage keeps uploaded files in memory
+type mockStorage struct {
+	files map[string][]byte
+}
+
+func (m *mockStorage) Save(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	m.files[key] = data
+	return nil
+}
+
+func (m *mockStorage) Open(key string) (io.ReadCloser, error) {
+	data, ok := m.files[key]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *mockStorage) Delete(key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+// mockMailer records the messages it is asked to send
+type mockMailer struct {
+	sent []mailer.Message
+}
+
+func (m *mockMailer) Send(ctx context.Context, message mailer.Message) error {
+	m.sent = append(m.sent, message)
+	return nil
+}
+
+func setupTestBrandingUseCase(t *testing.T) (IBrandingUseCase, *mockStorage) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	files := &mockStorage{files: make(map[string][]byte)}
+	return NewBrandingUseCase(&mockBrandingRepository{}, files, loggerInstance), files
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func logoPNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSet(t *testing.T) {
+	useCase, _ := setupTestBrandingUseCase(t)
+	branding, err := useCase.Get()
+	if err != nil || branding.DisplayName != "" {
+		t.Fatalf("expected empty branding before setup, got %+v, %v", branding, err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		branding domainBranding.Branding
+	}{
+		{"Color must be hex", domainBranding.Branding{PrimaryColor: "navy"}},
+		{"Logo URL must be absolute", domainBranding.Branding{LogoURL: "logo.png"}},
+		{"Logo URL must be web", domainBranding.Branding{LogoURL: "javascript:alert(1)"}},
+		{"Reply-to must be an address", domainBranding.Branding{ReplyTo: "office"}},
+		{"Sender name must be one line", domainBranding.Branding{SenderName: "Care\r\nBcc: all@example.com"}},
+		{"Uploaded logo must exist", domainBranding.Branding{LogoURL: domainBranding.LogoPath}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			branding := tc.branding
+			if _, err := useCase.Set(&branding); errorType(err) != domainErrors.ValidationError {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+
+	branding, err = useCase.Set(&domainBranding.Branding{DisplayName: " Sunrise Home Care ", PrimaryColor: "#003366", ReplyTo: "Office <office@sunrise.example>"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if branding.DisplayName != "Sunrise Home Care" || branding.ReplyTo != "office@sunrise.example" {
+		t.Errorf("expected trimmed name and bare reply-to address, got %+v", branding)
+	}
+}
+
+func TestUploadLogo(t *testing.T) {
+	useCase, files := setupTestBrandingUseCase(t)
+	logo := logoPNG(t)
+
+	if _, err := useCase.UploadLogo("image/gif", int64(len(logo)), bytes.NewReader(logo)); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected validation error for a GIF, got %v", err)
+	}
+	if _, err := useCase.UploadLogo("image/png", 8, bytes.NewReader([]byte("not png!"))); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected validation error for unreadable data, got %v", err)
+	}
+
+	first, err := useCase.UploadLogo("image/png", int64(len(logo)), bytes.NewReader(logo))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.LogoURL != domainBranding.LogoPath || !first.HasLogo() {
+		t.Fatalf("expected the logo to be served from the API, got %+v", first)
+	}
+	second, err := useCase.UploadLogo("image/png", int64(len(logo)), bytes.NewReader(logo))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := files.files[first.LogoKey]; ok || len(files.files) != 1 {
+		t.Errorf("expected the replaced logo to be removed, got %d files", len(files.files))
+	}
+
+	data, err := useCase.LogoImage()
+	if err != nil || !bytes.Equal(data, logo) {
+		t.Errorf("expected the uploaded logo, got %d bytes, %v", len(data), err)
+	}
+
+	kept, err := useCase.Set(&domainBranding.Branding{DisplayName: "Sunrise", LogoURL: domainBranding.LogoPath})
+	if err != nil || kept.LogoKey != second.LogoKey {
+		t.Fatalf("expected saving the served URL to keep the upload, got %+v, %v", kept, err)
+	}
+	linked, err := useCase.Set(&domainBranding.Branding{DisplayName: "Sunrise", LogoURL: "https://cdn.example.com/logo.png"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if linked.HasLogo() || len(files.files) != 0 {
+		t.Errorf("expected linking another logo to drop the upload, got %+v", linked)
+	}
+	if data, err := useCase.LogoImage(); data != nil || err != nil {
+		t.Errorf("expected no logo to embed, got %d bytes, %v", len(data), err)
+	}
+}
+
+func TestMailer(t *testing.T) {
+	useCase, _ := setupTestBrandingUseCase(t)
+	inner := &mockMailer{}
+	branded := useCase.Mailer(inner)
+
+	if _, err := useCase.Set(&domainBranding.Branding{DisplayName: "Sunrise Home Care", ReplyTo: "office@sunrise.example"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := branded.Send(context.Background(), mailer.Message{To: []string{"family@example.com"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := branded.Send(context.Background(), mailer.Message{To: []string{"family@example.com"}, FromName: "Billing"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := inner.sent[0]; got.FromName != "Sunrise Home Care" || got.ReplyTo != "office@sunrise.example" {
+		t.Errorf("expected the agency sender, got %+v", got)
+	}
+	if got := inner.sent[1]; got.FromName != "Billing" {
+		t.Errorf("expected the message's own sender to win, got %+v", got)
+	}
+}
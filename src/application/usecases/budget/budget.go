@@ -0,0 +1,233 @@
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	domainBudget "caregiver/src/domain/budget"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultWarningThreshold = 0.8
+
+type IBudgetUseCase interface {
+	Create(newBudget *domainBudget.Budget) (*domainBudget.Budget, error)
+	GetByID(id uuid.UUID) (*domainBudget.Budget, error)
+	GetAll() (*[]domainBudget.Budget, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*domainBudget.Budget, error)
+	Delete(id uuid.UUID) error
+	Report(at time.Time) (*[]domainBudget.Usage, error)
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+type BudgetUseCase struct {
+	budgetRepository domainBudget.IBudgetRepository
+	userRepository   domainUser.IUserRepository
+	Logger           *logger.Logger
+}
+
+func NewBudgetUseCase(budgetRepository domainBudget.IBudgetRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) IBudgetUseCase {
+	return &BudgetUseCase{
+		budgetRepository: budgetRepository,
+		userRepository:   userRepository,
+		Logger:           loggerInstance,
+	}
+}
+
+func (u *BudgetUseCase) Create(newBudget *domainBudget.Budget) (*domainBudget.Budget, error) {
+	u.Logger.Info("Creating budget", zap.String("clientUserID", newBudget.ClientUserID.String()))
+
+	if _, err := u.userRepository.GetByID(newBudget.ClientUserID); err != nil {
+		u.Logger.Error("Client user not found for budget", zap.Error(err), zap.String("clientUserID", newBudget.ClientUserID.String()))
+		return nil, domainErrors.NewAppError(errors.New("client user not found"), domainErrors.NotFound)
+	}
+	if newBudget.WarningThreshold == 0 {
+		newBudget.WarningThreshold = defaultWarningThreshold
+	}
+	if err := validateBudget(newBudget); err != nil {
+		return nil, err
+	}
+
+	newBudget.ID = uuid.New()
+	newBudget.Active = true
+	return u.budgetRepository.Create(newBudget)
+}
+
+func (u *BudgetUseCase) GetByID(id uuid.UUID) (*domainBudget.Budget, error) {
+	u.Logger.Info("Getting budget by ID", zap.String("id", id.String()))
+	return u.budgetRepository.GetByID(id)
+}
+
+func (u *BudgetUseCase) GetAll() (*[]domainBudget.Budget, error) {
+	u.Logger.Info("Getting all budgets")
+	return u.budgetRepository.GetAll()
+}
+
+func (u *BudgetUseCase) Update(id uuid.UUID, updates map[string]interface{}) (*domainBudget.Budget, error) {
+	u.Logger.Info("Updating budget", zap.String("id", id.String()))
+
+	existing, err := u.budgetRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["period"].(string); ok {
+		candidate.Period = v
+	}
+	if v, ok := updates["unit"].(string); ok {
+		candidate.Unit = v
+	}
+	if v, ok := updates["limit_amount"].(float64); ok {
+		candidate.Limit = v
+	}
+	if v, ok := updates["hourly_rate"].(float64); ok {
+		candidate.HourlyRate = &v
+	}
+	if v, ok := updates["warning_threshold"].(float64); ok {
+		candidate.WarningThreshold = v
+	}
+	if err := validateBudget(&candidate); err != nil {
+		return nil, err
+	}
+	return u.budgetRepository.Update(id, updates)
+}
+
+func (u *BudgetUseCase) Delete(id uuid.UUID) error {
+	u.Logger.Info("Deleting budget", zap.String("id", id.String()))
+	return u.budgetRepository.Delete(id)
+}
+
+// Report returns spend versus budget for the period containing at, for every
+// active budget.
+func (u *BudgetUseCase) Report(at time.Time) (*[]domainBudget.Usage, error) {
+	u.Logger.Info("Building budget report", zap.Time("at", at))
+
+	budgets, err := u.budgetRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]domainBudget.Usage, 0, len(*budgets))
+	for _, b := range *budgets {
+		if !b.Active {
+			continue
+		}
+		start, end := b.PeriodBounds(at)
+		spent, err := u.budgetRepository.SumEntries(b.ID, start, uuid.Nil)
+		if err != nil {
+			return nil, err
+		}
+		usage := domainBudget.Usage{
+			Budget:      b,
+			PeriodStart: start,
+			PeriodEnd:   end,
+			Spent:       spent,
+			Remaining:   b.Limit - spent,
+		}
+		if b.Limit > 0 {
+			usage.PercentUsed = spent / b.Limit * 100
+		}
+		report = append(report, usage)
+	}
+	return &report, nil
+}
+
+// ValidateSchedule projects the schedule against the client's active budgets.
+// Crossing the warning threshold yields a warning; exceeding a hard cap
+// rejects the schedule.
+func (u *BudgetUseCase) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.VisitStatus == "cancelled" {
+		return nil, nil
+	}
+	budgets, err := u.budgetRepository.GetActiveByClientUserID(schedule.ClientUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, b := range *budgets {
+		periodStart, _ := b.PeriodBounds(schedule.ScheduledSlot.From)
+		spent, err := u.budgetRepository.SumEntries(b.ID, periodStart, schedule.ID)
+		if err != nil {
+			return nil, err
+		}
+		projected := spent + b.AmountFor(schedule.ScheduledDuration())
+
+		switch {
+		case projected > b.Limit && b.HardCap:
+			u.Logger.Warn("Schedule exceeds client budget cap",
+				zap.String("budgetID", b.ID.String()),
+				zap.Float64("projected", projected),
+				zap.Float64("limit", b.Limit))
+			return nil, domainErrors.NewAppError(
+				fmt.Errorf("schedule exceeds the client's %s budget (%.2f of %.2f %s)", b.Period, projected, b.Limit, b.Unit),
+				domainErrors.ValidationError)
+		case projected > b.Limit:
+			warnings = append(warnings, fmt.Sprintf("client %s budget exceeded: %.2f of %.2f %s", b.Period, projected, b.Limit, b.Unit))
+		case projected >= b.Limit*b.WarningThreshold:
+			warnings = append(warnings, fmt.Sprintf("client %s budget nearly used: %.2f of %.2f %s", b.Period, projected, b.Limit, b.Unit))
+		}
+	}
+	return warnings, nil
+}
+
+// OnScheduleEvent keeps the running tally in sync with the schedule: creation
+// and edits record the schedule's consumption, cancellation releases it.
+func (u *BudgetUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	schedule := event.Schedule
+	switch event.Type {
+	case domainSchedule.EventCancelled, domainSchedule.EventDeleted:
+		if err := u.budgetRepository.DeleteEntriesBySchedule(schedule.ID); err != nil {
+			u.Logger.Error("Error releasing budget for cancelled schedule", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		}
+	case domainSchedule.EventCreated, domainSchedule.EventUpdated:
+		if schedule.VisitStatus == "cancelled" {
+			return
+		}
+		budgets, err := u.budgetRepository.GetActiveByClientUserID(schedule.ClientUserID)
+		if err != nil {
+			u.Logger.Error("Error loading budgets for schedule", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+			return
+		}
+		entries := make([]domainBudget.Entry, 0, len(*budgets))
+		for _, b := range *budgets {
+			periodStart, _ := b.PeriodBounds(schedule.ScheduledSlot.From)
+			entries = append(entries, domainBudget.Entry{
+				BudgetID:    b.ID,
+				ScheduleID:  schedule.ID,
+				PeriodStart: periodStart,
+				Amount:      b.AmountFor(schedule.ScheduledDuration()),
+			})
+		}
+		if err := u.budgetRepository.ReplaceEntries(schedule.ID, entries); err != nil {
+			u.Logger.Error("Error recording budget spend", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		}
+	}
+}
+
+func validateBudget(b *domainBudget.Budget) error {
+	if b.Period != domainBudget.PeriodWeekly && b.Period != domainBudget.PeriodMonthly {
+		return domainErrors.NewAppError(errors.New("period must be 'weekly' or 'monthly'"), domainErrors.ValidationError)
+	}
+	if b.Unit != domainBudget.UnitHours && b.Unit != domainBudget.UnitCurrency {
+		return domainErrors.NewAppError(errors.New("unit must be 'hours' or 'currency'"), domainErrors.ValidationError)
+	}
+	if b.Unit == domainBudget.UnitCurrency && (b.HourlyRate == nil || *b.HourlyRate <= 0) {
+		return domainErrors.NewAppError(errors.New("currency budgets require a positive hourly rate"), domainErrors.ValidationError)
+	}
+	if b.Limit <= 0 {
+		return domainErrors.NewAppError(errors.New("limit must be greater than zero"), domainErrors.ValidationError)
+	}
+	if b.WarningThreshold <= 0 || b.WarningThreshold > 1 {
+		return domainErrors.NewAppError(errors.New("warning threshold must be between 0 and 1"), domainErrors.ValidationError)
+	}
+	return nil
+}
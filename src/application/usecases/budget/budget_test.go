@@ -0,0 +1,207 @@
+package budget
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"caregiver/src/domain"
+	domainBudget "caregiver/src/domain/budget"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockBudgetRepository is a mock implementation of the IBudgetRepository interface
+type mockBudgetRepository struct {
+	budgets         []domainBudget.Budget
+	spent           float64
+	replacedEntries []domainBudget.Entry
+	deletedFor      uuid.UUID
+}
+
+func (m *mockBudgetRepository) Create(newBudget *domainBudget.Budget) (*domainBudget.Budget, error) {
+	m.budgets = append(m.budgets, *newBudget)
+	return newBudget, nil
+}
+
+func (m *mockBudgetRepository) GetByID(id uuid.UUID) (*domainBudget.Budget, error) {
+	for i := range m.budgets {
+		if m.budgets[i].ID == id {
+			return &m.budgets[i], nil
+		}
+	}
+	return nil, errors.New("budget not found")
+}
+
+func (m *mockBudgetRepository) GetAll() (*[]domainBudget.Budget, error) {
+	return &m.budgets, nil
+}
+
+func (m *mockBudgetRepository) GetActiveByClientUserID(clientUserID uuid.UUID) (*[]domainBudget.Budget, error) {
+	res := []domainBudget.Budget{}
+	for _, b := range m.budgets {
+		if b.ClientUserID == clientUserID && b.Active {
+			res = append(res, b)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockBudgetRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainBudget.Budget, error) {
+	return m.GetByID(id)
+}
+
+func (m *mockBudgetRepository) Delete(id uuid.UUID) error {
+	return nil
+}
+
+func (m *mockBudgetRepository) SumEntries(budgetID uuid.UUID, periodStart time.Time, excludeScheduleID uuid.UUID) (float64, error) {
+	return m.spent, nil
+}
+
+func (m *mockBudgetRepository) ReplaceEntries(scheduleID uuid.UUID, entries []domainBudget.Entry) error {
+	m.replacedEntries = entries
+	return nil
+}
+
+func (m *mockBudgetRepository) DeleteEntriesBySchedule(scheduleID uuid.UUID) error {
+	m.deletedFor = scheduleID
+	return nil
+}
+
+// mockUserRepository is a mock implementation of the IUserRepository interface
+type mockUserRepository struct {
+	getByIDFn func(id uuid.UUID) (*domainUser.User, error)
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) { return nil, nil }
+func (m *mockUserRepository) Create(userDomain *domainUser.User) (*domainUser.User, error) {
+	return nil, nil
+}
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) { return m.getByIDFn(id) }
+func (m *mockUserRepository) GetByEmail(email string) (*domainUser.User, error) {
+	return nil, nil
+}
+func (m *mockUserRepository) Update(id uuid.UUID, userMap map[string]interface{}) (*domainUser.User, error) {
+	return nil, nil
+}
+func (m *mockUserRepository) Delete(id uuid.UUID) error { return nil }
+func (m *mockUserRepository) SearchPaginated(filters domain.DataFilters) (*domainUser.SearchResultUser, error) {
+	return nil, nil
+}
+func (m *mockUserRepository) SearchByProperty(search domainUser.PropertySearch) (*domainUser.SearchResultProperty, error) {
+	return nil, nil
+}
+
+func setupTestBudgetUseCase(t *testing.T) (IBudgetUseCase, *mockBudgetRepository, *mockUserRepository) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	budgetRepo := &mockBudgetRepository{}
+	userRepo := &mockUserRepository{getByIDFn: func(id uuid.UUID) (*domainUser.User, error) {
+		return &domainUser.User{ID: id}, nil
+	}}
+	return NewBudgetUseCase(budgetRepo, userRepo, loggerInstance), budgetRepo, userRepo
+}
+
+func createTestSchedule(clientUserID uuid.UUID, hours int) *domainSchedule.Schedule {
+	from := time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+	return &domainSchedule.Schedule{
+		ID:            uuid.New(),
+		ClientUserID:  clientUserID,
+		VisitStatus:   "upcoming",
+		ScheduledSlot: domainSchedule.ScheduledSlot{From: from, To: from.Add(time.Duration(hours) * time.Hour)},
+	}
+}
+
+func TestCreateBudget(t *testing.T) {
+	useCase, _, userRepo := setupTestBudgetUseCase(t)
+
+	t.Run("Defaults warning threshold", func(t *testing.T) {
+		created, err := useCase.Create(&domainBudget.Budget{ClientUserID: uuid.New(), Period: "weekly", Unit: "hours", Limit: 20})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created.WarningThreshold != defaultWarningThreshold || !created.Active {
+			t.Errorf("expected active budget with default threshold, got %+v", created)
+		}
+	})
+
+	t.Run("Currency budget requires rate", func(t *testing.T) {
+		_, err := useCase.Create(&domainBudget.Budget{ClientUserID: uuid.New(), Period: "monthly", Unit: "currency", Limit: 500})
+		if err == nil {
+			t.Error("expected validation error, got nil")
+		}
+	})
+
+	t.Run("Client not found", func(t *testing.T) {
+		userRepo.getByIDFn = func(id uuid.UUID) (*domainUser.User, error) {
+			return nil, errors.New("user not found")
+		}
+		_, err := useCase.Create(&domainBudget.Budget{ClientUserID: uuid.New(), Period: "weekly", Unit: "hours", Limit: 20})
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestValidateSchedule(t *testing.T) {
+	useCase, budgetRepo, _ := setupTestBudgetUseCase(t)
+	clientID := uuid.New()
+	budgetRepo.budgets = []domainBudget.Budget{
+		{ID: uuid.New(), ClientUserID: clientID, Period: "weekly", Unit: "hours", Limit: 10, WarningThreshold: 0.8, Active: true},
+	}
+
+	t.Run("Under threshold", func(t *testing.T) {
+		budgetRepo.spent = 2
+		warnings, err := useCase.ValidateSchedule(createTestSchedule(clientID, 2))
+		if err != nil || len(warnings) != 0 {
+			t.Errorf("expected no warnings, got %v (err %v)", warnings, err)
+		}
+	})
+
+	t.Run("Near cap warns", func(t *testing.T) {
+		budgetRepo.spent = 6
+		warnings, err := useCase.ValidateSchedule(createTestSchedule(clientID, 2))
+		if err != nil || len(warnings) != 1 {
+			t.Errorf("expected one warning, got %v (err %v)", warnings, err)
+		}
+	})
+
+	t.Run("Hard cap rejects", func(t *testing.T) {
+		budgetRepo.budgets[0].HardCap = true
+		budgetRepo.spent = 9
+		_, err := useCase.ValidateSchedule(createTestSchedule(clientID, 2))
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestOnScheduleEvent(t *testing.T) {
+	useCase, budgetRepo, _ := setupTestBudgetUseCase(t)
+	clientID := uuid.New()
+	rate := 25.0
+	budgetRepo.budgets = []domainBudget.Budget{
+		{ID: uuid.New(), ClientUserID: clientID, Period: "monthly", Unit: "currency", Limit: 1000, HourlyRate: &rate, Active: true},
+	}
+
+	schedule := createTestSchedule(clientID, 3)
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCreated, Schedule: schedule})
+	if len(budgetRepo.replacedEntries) != 1 || budgetRepo.replacedEntries[0].Amount != 75 {
+		t.Errorf("expected one entry of 75, got %+v", budgetRepo.replacedEntries)
+	}
+	expectedStart := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	if !budgetRepo.replacedEntries[0].PeriodStart.Equal(expectedStart) {
+		t.Errorf("expected period start %v, got %v", expectedStart, budgetRepo.replacedEntries[0].PeriodStart)
+	}
+
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCancelled, Schedule: schedule})
+	if budgetRepo.deletedFor != schedule.ID {
+		t.Error("expected cancelled schedule to release its budget entries")
+	}
+}
@@ -0,0 +1,384 @@
+package careplan
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	domainAlert "caregiver/src/domain/alert"
+	domainCarePlan "caregiver/src/domain/careplan"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ICarePlanUseCase interface {
+	CreatePlan(newPlan *domainCarePlan.Plan) (*domainCarePlan.Plan, error)
+	GetPlanByID(id uuid.UUID) (*domainCarePlan.Plan, error)
+	GetPlans(clientUserID *uuid.UUID) (*[]domainCarePlan.Plan, error)
+	UpdatePlan(id uuid.UUID, updates map[string]interface{}) (*domainCarePlan.Plan, error)
+	DeletePlan(id uuid.UUID) error
+	CreateGoal(newGoal *domainCarePlan.Goal) (*domainCarePlan.Goal, error)
+	GetGoalByID(id uuid.UUID) (*domainCarePlan.Goal, error)
+	GetGoals(planID uuid.UUID) (*[]domainCarePlan.Goal, error)
+	UpdateGoal(id uuid.UUID, updates map[string]interface{}) (*domainCarePlan.Goal, error)
+	DeleteGoal(id uuid.UUID) error
+	GetVisitGoals(scheduleID uuid.UUID) (*[]domainCarePlan.Goal, error)
+	LogProgress(newEntry *domainCarePlan.Entry) (*domainCarePlan.Entry, error)
+	GetVisitEntries(scheduleID uuid.UUID) (*[]domainCarePlan.Entry, error)
+	DeleteEntry(id uuid.UUID) error
+	Chart(goalID uuid.UUID, from, to time.Time) (*domainCarePlan.Chart, error)
+	// FrequencyReport compares every client's visits in the week containing
+	// weekOf with their plan's required visit frequency.
+	FrequencyReport(weekOf time.Time) (*domainCarePlan.FrequencyReport, error)
+	// MonitorVisitFrequency raises an under-service alert for each client
+	// whose required frequency will not be met this week, once per week,
+	// and returns how many were raised.
+	MonitorVisitFrequency(now time.Time) (int, error)
+}
+
+// UnderServiceAlerter raises an alert for a client whose required visit
+// frequency will not be met, at most once per week.
+type UnderServiceAlerter interface {
+	RaiseUnderService(clientUserID uuid.UUID, weekStart time.Time, message string, now time.Time) (*domainAlert.Alert, bool, error)
+}
+
+type CarePlanUseCase struct {
+	carePlanRepository domainCarePlan.ICarePlanRepository
+	userRepository     domainUser.IUserRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	alerter            UnderServiceAlerter
+	Logger             *logger.Logger
+}
+
+type Option func(*CarePlanUseCase)
+
+// WithUnderServiceAlerts enables alerts from MonitorVisitFrequency.
+func WithUnderServiceAlerts(alerter UnderServiceAlerter) Option {
+	return func(u *CarePlanUseCase) {
+		u.alerter = alerter
+	}
+}
+
+func NewCarePlanUseCase(carePlanRepository domainCarePlan.ICarePlanRepository, userRepository domainUser.IUserRepository, scheduleRepository domainSchedule.IScheduleRepository, loggerInstance *logger.Logger, opts ...Option) ICarePlanUseCase {
+	useCase := &CarePlanUseCase{
+		carePlanRepository: carePlanRepository,
+		userRepository:     userRepository,
+		scheduleRepository: scheduleRepository,
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+func (u *CarePlanUseCase) CreatePlan(newPlan *domainCarePlan.Plan) (*domainCarePlan.Plan, error) {
+	u.Logger.Info("Creating care plan", zap.String("clientUserID", newPlan.ClientUserID.String()))
+	client, err := u.userRepository.GetByID(newPlan.ClientUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("client not found"), domainErrors.NotFound)
+	}
+	if client.Role != domainUser.RoleClient {
+		return nil, domainErrors.NewAppError(errors.New("care plans belong to clients"), domainErrors.ValidationError)
+	}
+	if err := validatePlan(newPlan); err != nil {
+		return nil, err
+	}
+	newPlan.ID = uuid.New()
+	newPlan.Active = true
+	return u.carePlanRepository.CreatePlan(newPlan)
+}
+
+func (u *CarePlanUseCase) GetPlanByID(id uuid.UUID) (*domainCarePlan.Plan, error) {
+	return u.carePlanRepository.GetPlanByID(id)
+}
+
+func (u *CarePlanUseCase) GetPlans(clientUserID *uuid.UUID) (*[]domainCarePlan.Plan, error) {
+	return u.carePlanRepository.GetPlans(clientUserID)
+}
+
+func (u *CarePlanUseCase) UpdatePlan(id uuid.UUID, updates map[string]interface{}) (*domainCarePlan.Plan, error) {
+	u.Logger.Info("Updating care plan", zap.String("id", id.String()))
+	existing, err := u.carePlanRepository.GetPlanByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["title"].(string); ok {
+		candidate.Title = v
+	}
+	if v, ok := updates["start_date"].(time.Time); ok {
+		candidate.StartDate = v
+	}
+	if v, ok := updates["review_date"].(time.Time); ok {
+		candidate.ReviewDate = &v
+	}
+	if v, ok := updates["visits_per_week"].(int); ok {
+		candidate.VisitsPerWeek = v
+	}
+	if err := validatePlan(&candidate); err != nil {
+		return nil, err
+	}
+	return u.carePlanRepository.UpdatePlan(id, updates)
+}
+
+func (u *CarePlanUseCase) DeletePlan(id uuid.UUID) error {
+	u.Logger.Info("Deleting care plan", zap.String("id", id.String()))
+	return u.carePlanRepository.DeletePlan(id)
+}
+
+func (u *CarePlanUseCase) CreateGoal(newGoal *domainCarePlan.Goal) (*domainCarePlan.Goal, error) {
+	u.Logger.Info("Creating care plan goal", zap.String("planID", newGoal.PlanID.String()))
+	if _, err := u.carePlanRepository.GetPlanByID(newGoal.PlanID); err != nil {
+		return nil, err
+	}
+	newGoal.Status = domainCarePlan.GoalStatusActive
+	if err := validateGoal(newGoal); err != nil {
+		return nil, err
+	}
+	newGoal.ID = uuid.New()
+	return u.carePlanRepository.CreateGoal(newGoal)
+}
+
+func (u *CarePlanUseCase) GetGoalByID(id uuid.UUID) (*domainCarePlan.Goal, error) {
+	return u.carePlanRepository.GetGoalByID(id)
+}
+
+func (u *CarePlanUseCase) GetGoals(planID uuid.UUID) (*[]domainCarePlan.Goal, error) {
+	if _, err := u.carePlanRepository.GetPlanByID(planID); err != nil {
+		return nil, err
+	}
+	return u.carePlanRepository.GetGoals(planID)
+}
+
+func (u *CarePlanUseCase) UpdateGoal(id uuid.UUID, updates map[string]interface{}) (*domainCarePlan.Goal, error) {
+	u.Logger.Info("Updating care plan goal", zap.String("id", id.String()))
+	existing, err := u.carePlanRepository.GetGoalByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["description"].(string); ok {
+		candidate.Description = v
+	}
+	if v, ok := updates["metric"].(string); ok {
+		candidate.Metric = v
+	}
+	if v, ok := updates["baseline"].(float64); ok {
+		candidate.Baseline = v
+	}
+	if v, ok := updates["target"].(float64); ok {
+		candidate.Target = v
+	}
+	if v, ok := updates["cadence"].(string); ok {
+		candidate.Cadence = v
+	}
+	if v, ok := updates["status"].(string); ok {
+		candidate.Status = v
+	}
+	if err := validateGoal(&candidate); err != nil {
+		return nil, err
+	}
+	return u.carePlanRepository.UpdateGoal(id, updates)
+}
+
+func (u *CarePlanUseCase) DeleteGoal(id uuid.UUID) error {
+	u.Logger.Info("Deleting care plan goal", zap.String("id", id.String()))
+	return u.carePlanRepository.DeleteGoal(id)
+}
+
+// GetVisitGoals lists the active goals on the client's active care plans that
+// a caregiver can measure during the visit.
+func (u *CarePlanUseCase) GetVisitGoals(scheduleID uuid.UUID) (*[]domainCarePlan.Goal, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := u.carePlanRepository.GetPlans(&schedule.ClientUserID)
+	if err != nil {
+		return nil, err
+	}
+	res := []domainCarePlan.Goal{}
+	for _, plan := range *plans {
+		if !plan.Active {
+			continue
+		}
+		goals, err := u.carePlanRepository.GetGoals(plan.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, goal := range *goals {
+			if goal.Status == domainCarePlan.GoalStatusActive {
+				res = append(res, goal)
+			}
+		}
+	}
+	return &res, nil
+}
+
+// LogProgress records a measurement of a goal during a visit to the goal's
+// client. It is attributed to the visit's caregiver and dated at check-in
+// unless given a time.
+func (u *CarePlanUseCase) LogProgress(newEntry *domainCarePlan.Entry) (*domainCarePlan.Entry, error) {
+	u.Logger.Info("Logging care plan goal progress", zap.String("scheduleID", newEntry.ScheduleID.String()), zap.String("goalID", newEntry.GoalID.String()))
+	schedule, err := u.scheduleRepository.GetScheduleByID(newEntry.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.CheckinTime == nil {
+		return nil, domainErrors.NewAppError(errors.New("progress can only be logged once the visit has started"), domainErrors.ValidationError)
+	}
+	goal, err := u.carePlanRepository.GetGoalByID(newEntry.GoalID)
+	if err != nil {
+		return nil, err
+	}
+	if goal.Status != domainCarePlan.GoalStatusActive {
+		return nil, domainErrors.NewAppError(errors.New("the goal is no longer active"), domainErrors.ValidationError)
+	}
+	plan, err := u.carePlanRepository.GetPlanByID(goal.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	if plan.ClientUserID != schedule.ClientUserID {
+		return nil, domainErrors.NewAppError(errors.New("the goal is not on this client's care plan"), domainErrors.ValidationError)
+	}
+
+	newEntry.ID = uuid.New()
+	newEntry.RecordedByUserID = schedule.AssignedUserID
+	if newEntry.RecordedAt.IsZero() {
+		newEntry.RecordedAt = *schedule.CheckinTime
+	}
+	return u.carePlanRepository.CreateEntry(newEntry)
+}
+
+func (u *CarePlanUseCase) GetVisitEntries(scheduleID uuid.UUID) (*[]domainCarePlan.Entry, error) {
+	return u.carePlanRepository.GetEntriesBySchedule(scheduleID)
+}
+
+func (u *CarePlanUseCase) DeleteEntry(id uuid.UUID) error {
+	u.Logger.Info("Deleting care plan goal entry", zap.String("id", id.String()))
+	return u.carePlanRepository.DeleteEntry(id)
+}
+
+// Chart plots a goal's measurements in [from, to) against its baseline and
+// target, one point per entry for per-visit goals and one per period
+// otherwise.
+func (u *CarePlanUseCase) Chart(goalID uuid.UUID, from, to time.Time) (*domainCarePlan.Chart, error) {
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+	goal, err := u.carePlanRepository.GetGoalByID(goalID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := u.carePlanRepository.GetEntries(goalID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	chart := &domainCarePlan.Chart{Goal: *goal, From: from, To: to, Points: []domainCarePlan.ChartPoint{}}
+	for _, entry := range *entries {
+		at := entry.RecordedAt
+		if goal.Cadence != domainCarePlan.CadencePerVisit {
+			at = periodStart(goal.Cadence, at)
+		}
+		last := len(chart.Points) - 1
+		if goal.Cadence != domainCarePlan.CadencePerVisit && last >= 0 && chart.Points[last].At.Equal(at) {
+			point := &chart.Points[last]
+			point.Value = (point.Value*float64(point.Count) + entry.Value) / float64(point.Count+1)
+			point.Count++
+			continue
+		}
+		chart.Points = append(chart.Points, domainCarePlan.ChartPoint{At: at, Value: entry.Value, Count: 1})
+	}
+	for i := range chart.Points {
+		chart.Points[i].Progress = goal.Progress(chart.Points[i].Value)
+	}
+	if n := len(*entries); n > 0 {
+		latest := (*entries)[n-1].Value
+		chart.Latest = &latest
+		chart.Progress = goal.Progress(latest)
+		chart.Achieved = chart.Progress >= 1
+	}
+
+	if goal.Cadence != domainCarePlan.CadencePerVisit {
+		start := from
+		if goal.CreatedAt.After(start) {
+			start = goal.CreatedAt
+		}
+		measured := make(map[time.Time]bool, len(chart.Points))
+		for _, point := range chart.Points {
+			measured[point.At] = true
+		}
+		for period := periodStart(goal.Cadence, start); !nextPeriod(goal.Cadence, period).After(to); period = nextPeriod(goal.Cadence, period) {
+			if !measured[period] {
+				chart.MissedPeriods++
+			}
+		}
+	}
+	return chart, nil
+}
+
+// periodStart truncates at to the start of its day, ISO week or month in UTC.
+func periodStart(cadence string, at time.Time) time.Time {
+	at = at.UTC()
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	switch cadence {
+	case domainCarePlan.CadenceWeekly:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case domainCarePlan.CadenceMonthly:
+		return time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+func nextPeriod(cadence string, start time.Time) time.Time {
+	switch cadence {
+	case domainCarePlan.CadenceWeekly:
+		return start.AddDate(0, 0, 7)
+	case domainCarePlan.CadenceMonthly:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+func validatePlan(p *domainCarePlan.Plan) error {
+	if strings.TrimSpace(p.Title) == "" {
+		return domainErrors.NewAppError(errors.New("title is required"), domainErrors.ValidationError)
+	}
+	if p.StartDate.IsZero() {
+		return domainErrors.NewAppError(errors.New("start date is required"), domainErrors.ValidationError)
+	}
+	if p.ReviewDate != nil && !p.ReviewDate.After(p.StartDate) {
+		return domainErrors.NewAppError(errors.New("the review date must be after the start date"), domainErrors.ValidationError)
+	}
+	if p.VisitsPerWeek < 0 {
+		return domainErrors.NewAppError(errors.New("visits per week cannot be negative"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func validateGoal(g *domainCarePlan.Goal) error {
+	if strings.TrimSpace(g.Description) == "" || strings.TrimSpace(g.Metric) == "" {
+		return domainErrors.NewAppError(errors.New("description and metric are required"), domainErrors.ValidationError)
+	}
+	if g.Baseline == g.Target {
+		return domainErrors.NewAppError(errors.New("the target must differ from the baseline"), domainErrors.ValidationError)
+	}
+	switch g.Cadence {
+	case domainCarePlan.CadencePerVisit, domainCarePlan.CadenceDaily, domainCarePlan.CadenceWeekly, domainCarePlan.CadenceMonthly:
+	default:
+		return domainErrors.NewAppError(errors.New("cadence must be 'per_visit', 'daily', 'weekly' or 'monthly'"), domainErrors.ValidationError)
+	}
+	switch g.Status {
+	case domainCarePlan.GoalStatusActive, domainCarePlan.GoalStatusAchieved, domainCarePlan.GoalStatusDiscontinued:
+	default:
+		return domainErrors.NewAppError(errors.New("status must be 'active', 'achieved' or 'discontinued'"), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package careplan
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainCarePlan "caregiver/src/domain/careplan"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockCarePlanRepository keeps plans, goals and entries in memory
+type mockCarePlanRepository struct {
+	domainCarePlan.ICarePlanRepository
+	plans   []domainCarePlan.Plan
+	goals   []domainCarePlan.Goal
+	entries []domainCarePlan.Entry
+}
+
+func (m *mockCarePlanRepository) CreatePlan(newPlan *domainCarePlan.Plan) (*domainCarePlan.Plan, error) {
+	m.plans = append(m.plans, *newPlan)
+	return newPlan, nil
+}
+
+func (m *mockCarePlanRepository) GetPlanByID(id uuid.UUID) (*domainCarePlan.Plan, error) {
+	for _, plan := range m.plans {
+		if plan.ID == id {
+			return &plan, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockCarePlanRepository) GetPlans(clientUserID *uuid.UUID) (*[]domainCarePlan.Plan, error) {
+	res := []domainCarePlan.Plan{}
+	for _, plan := range m.plans {
+		if clientUserID == nil || plan.ClientUserID == *clientUserID {
+			res = append(res, plan)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockCarePlanRepository) CreateGoal(newGoal *domainCarePlan.Goal) (*domainCarePlan.Goal, error) {
+	m.goals = append(m.goals, *newGoal)
+	return newGoal, nil
+}
+
+func (m *mockCarePlanRepository) GetGoalByID(id uuid.UUID) (*domainCarePlan.Goal, error) {
+	for _, goal := range m.goals {
+		if goal.ID == id {
+			return &goal, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockCarePlanRepository) GetGoals(planID uuid.UUID) (*[]domainCarePlan.Goal, error) {
+	res := []domainCarePlan.Goal{}
+	for _, goal := range m.goals {
+		if goal.PlanID == planID {
+			res = append(res, goal)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockCarePlanRepository) CreateEntry(newEntry *domainCarePlan.Entry) (*domainCarePlan.Entry, error) {
+	m.entries = append(m.entries, *newEntry)
+	return newEntry, nil
+}
+
+func (m *mockCarePlanRepository) GetEntries(goalID uuid.UUID, from, to time.Time) (*[]domainCarePlan.Entry, error) {
+	res := []domainCarePlan.Entry{}
+	for _, entry := range m.entries {
+		if entry.GoalID == goalID && !entry.RecordedAt.Before(from) && entry.RecordedAt.Before(to) {
+			res = append(res, entry)
+		}
+	}
+	return &res, nil
+}
+
+// mockUserRepository returns users by ID
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockScheduleRepository returns schedules by ID
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules map[uuid.UUID]*domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	if schedule, ok := m.schedules[id]; ok {
+		return schedule, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func setupTestCarePlanUseCase(t *testing.T) (ICarePlanUseCase, *mockCarePlanRepository, *mockScheduleRepository, uuid.UUID, uuid.UUID) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	clientID := uuid.New()
+	caregiverID := uuid.New()
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{
+		clientID:    {ID: clientID, Role: domainUser.RoleClient},
+		caregiverID: {ID: caregiverID, Role: domainUser.RoleCaregiver},
+	}}
+	plans := &mockCarePlanRepository{}
+	schedules := &mockScheduleRepository{schedules: make(map[uuid.UUID]*domainSchedule.Schedule)}
+	return NewCarePlanUseCase(plans, users, schedules, loggerInstance), plans, schedules, clientID, caregiverID
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func (m *mockScheduleRepository) visit(clientID, caregiverID uuid.UUID, checkin *time.Time) uuid.UUID {
+	id := uuid.New()
+	m.schedules[id] = &domainSchedule.Schedule{ID: id, ClientUserID: clientID, AssignedUserID: caregiverID, CheckinTime: checkin}
+	return id
+}
+
+func TestLogProgress(t *testing.T) {
+	useCase, _, schedules, clientID, caregiverID := setupTestCarePlanUseCase(t)
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	if _, err := useCase.CreatePlan(&domainCarePlan.Plan{ClientUserID: caregiverID, Title: "Mobility", StartDate: start}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a plan for a caregiver to be rejected, got %v", err)
+	}
+	plan, err := useCase.CreatePlan(&domainCarePlan.Plan{ClientUserID: clientID, Title: "Mobility", StartDate: start})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.CreateGoal(&domainCarePlan.Goal{PlanID: plan.ID, Description: "Walk further", Metric: "distance", Baseline: 20, Target: 20, Cadence: domainCarePlan.CadenceWeekly}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a goal without a change to be rejected, got %v", err)
+	}
+	goal, err := useCase.CreateGoal(&domainCarePlan.Goal{PlanID: plan.ID, Description: "Walk further", Metric: "distance", Unit: "m", Baseline: 20, Target: 100, Cadence: domainCarePlan.CadenceWeekly})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	notStarted := schedules.visit(clientID, caregiverID, nil)
+	if _, err := useCase.LogProgress(&domainCarePlan.Entry{GoalID: goal.ID, ScheduleID: notStarted, Value: 30}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected progress before check-in to be rejected, got %v", err)
+	}
+	checkin := start.Add(9 * time.Hour)
+	otherClient := schedules.visit(uuid.New(), caregiverID, &checkin)
+	if _, err := useCase.LogProgress(&domainCarePlan.Entry{GoalID: goal.ID, ScheduleID: otherClient, Value: 30}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected progress for another client's goal to be rejected, got %v", err)
+	}
+
+	visitID := schedules.visit(clientID, caregiverID, &checkin)
+	goals, _ := useCase.GetVisitGoals(visitID)
+	if len(*goals) != 1 || (*goals)[0].ID != goal.ID {
+		t.Errorf("expected the visit to list the client's goal, got %+v", goals)
+	}
+	entry, err := useCase.LogProgress(&domainCarePlan.Entry{GoalID: goal.ID, ScheduleID: visitID, Value: 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.RecordedByUserID != caregiverID || !entry.RecordedAt.Equal(checkin) {
+		t.Errorf("expected the entry attributed to the caregiver at check-in, got %+v", entry)
+	}
+}
+
+func TestChart(t *testing.T) {
+	useCase, plans, _, clientID, _ := setupTestCarePlanUseCase(t)
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	plan, _ := useCase.CreatePlan(&domainCarePlan.Plan{ClientUserID: clientID, Title: "Diabetes", StartDate: monday})
+	goal, _ := useCase.CreateGoal(&domainCarePlan.Goal{PlanID: plan.ID, Description: "Lower fasting glucose", Metric: "glucose", Unit: "mg/dL", Baseline: 180, Target: 120, Cadence: domainCarePlan.CadenceWeekly})
+	plans.goals[0].CreatedAt = monday
+
+	for _, entry := range []struct {
+		at    time.Time
+		value float64
+	}{
+		{monday.Add(10 * time.Hour), 170},
+		{monday.AddDate(0, 0, 3), 160},
+		{monday.AddDate(0, 0, 15), 130},
+		{monday.AddDate(0, 0, 22), 115},
+	} {
+		plans.entries = append(plans.entries, domainCarePlan.Entry{ID: uuid.New(), GoalID: goal.ID, RecordedAt: entry.at, Value: entry.value})
+	}
+
+	chart, err := useCase.Chart(goal.ID, monday, monday.AddDate(0, 0, 28))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chart.Points) != 3 || chart.Points[0].Count != 2 || chart.Points[0].Value != 165 || !chart.Points[1].At.Equal(monday.AddDate(0, 0, 14)) {
+		t.Fatalf("expected weekly averages, got %+v", chart.Points)
+	}
+	if chart.MissedPeriods != 1 {
+		t.Errorf("expected the second week to be missed, got %d", chart.MissedPeriods)
+	}
+	if chart.Latest == nil || *chart.Latest != 115 || !chart.Achieved {
+		t.Errorf("expected the latest reading to meet the lower target, got %+v", chart)
+	}
+	if chart.Points[1].Progress != 0.8333333333333334 {
+		t.Errorf("expected progress measured towards a lower target, got %v", chart.Points[1].Progress)
+	}
+}
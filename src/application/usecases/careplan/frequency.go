@@ -0,0 +1,152 @@
+package careplan
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domainCarePlan "caregiver/src/domain/careplan"
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// frequencyPageSize is how many visits are read per query when counting a
+// week's visits.
+const frequencyPageSize = 500
+
+// frequencyStatuses are the visit states counted towards a week: everything
+// but cancelled visits.
+var frequencyStatuses = []string{"upcoming", "in_progress", "partially_completed", "completed", "missed"}
+
+func (u *CarePlanUseCase) FrequencyReport(weekOf time.Time) (*domainCarePlan.FrequencyReport, error) {
+	weekStart := periodStart(domainCarePlan.CadenceWeekly, weekOf)
+	report := &domainCarePlan.FrequencyReport{
+		WeekStart: weekStart,
+		WeekEnd:   nextPeriod(domainCarePlan.CadenceWeekly, weekStart),
+		Clients:   []domainCarePlan.VisitFrequency{},
+	}
+	plans, err := u.carePlanRepository.GetFrequencyPlans()
+	if err != nil {
+		return nil, err
+	}
+	if len(*plans) == 0 {
+		return report, nil
+	}
+
+	clientIDs := make([]uuid.UUID, 0, len(*plans))
+	for _, plan := range *plans {
+		clientIDs = append(clientIDs, plan.ClientUserID)
+	}
+	visits, err := u.weekVisits(clientIDs, report.WeekStart, report.WeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// A client with more than one plan is held to the highest frequency.
+	byClient := make(map[uuid.UUID]int)
+	for _, plan := range *plans {
+		if i, seen := byClient[plan.ClientUserID]; seen {
+			if plan.VisitsPerWeek > report.Clients[i].Required {
+				report.Clients[i].PlanID = plan.ID
+				report.Clients[i].Required = plan.VisitsPerWeek
+			}
+			continue
+		}
+		byClient[plan.ClientUserID] = len(report.Clients)
+		report.Clients = append(report.Clients, domainCarePlan.VisitFrequency{
+			PlanID:       plan.ID,
+			ClientUserID: plan.ClientUserID,
+			Required:     plan.VisitsPerWeek,
+		})
+	}
+	for _, visit := range visits {
+		// A visit across midnight on Sunday counts towards the week it starts in.
+		if visit.ScheduledSlot.From.Before(report.WeekStart) {
+			continue
+		}
+		entry := &report.Clients[byClient[visit.ClientUserID]]
+		entry.Scheduled++
+		switch visit.VisitStatus {
+		case "completed", "partially_completed":
+			entry.Delivered++
+		case "missed":
+			entry.Missed++
+		default:
+			entry.Upcoming++
+		}
+	}
+	for i := range report.Clients {
+		entry := &report.Clients[i]
+		if short := entry.Required - entry.Delivered - entry.Upcoming; short > 0 {
+			entry.Shortfall = short
+			entry.UnderServed = true
+			report.UnderServed++
+		}
+	}
+	return report, nil
+}
+
+func (u *CarePlanUseCase) MonitorVisitFrequency(now time.Time) (int, error) {
+	if u.alerter == nil {
+		return 0, nil
+	}
+	report, err := u.FrequencyReport(now)
+	if err != nil {
+		return 0, err
+	}
+	raised := 0
+	for _, entry := range report.Clients {
+		if !entry.UnderServed {
+			continue
+		}
+		message := fmt.Sprintf("%s needs %d visits in the week of %s but only %d are delivered or still scheduled.",
+			u.clientName(entry.ClientUserID), entry.Required, report.WeekStart.Format("2006-01-02"), entry.Delivered+entry.Upcoming)
+		_, created, err := u.alerter.RaiseUnderService(entry.ClientUserID, report.WeekStart, message, now)
+		if err != nil {
+			return raised, err
+		}
+		if created {
+			raised++
+		}
+	}
+	if raised > 0 {
+		u.Logger.Info("Under-service alerts raised", zap.Int("raised", raised), zap.Time("weekStart", report.WeekStart))
+	}
+	return raised, nil
+}
+
+// weekVisits returns the clients' visits whose slot overlaps [from, to),
+// except cancelled ones.
+func (u *CarePlanUseCase) weekVisits(clientIDs []uuid.UUID, from, to time.Time) ([]domainSchedule.Schedule, error) {
+	var visits []domainSchedule.Schedule
+	for page := 1; ; page++ {
+		result, err := u.scheduleRepository.Search(domainSchedule.SearchQuery{
+			Statuses:      frequencyStatuses,
+			ClientUserIDs: clientIDs,
+			From:          &from,
+			To:            &to,
+			Page:          page,
+			PageSize:      frequencyPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		visits = append(visits, *result.Data...)
+		if page >= result.TotalPages {
+			return visits, nil
+		}
+	}
+}
+
+func (u *CarePlanUseCase) clientName(clientUserID uuid.UUID) string {
+	client, err := u.userRepository.GetByID(clientUserID)
+	if err != nil {
+		return "Client " + clientUserID.String()
+	}
+	if name := strings.TrimSpace(client.FirstName + " " + client.LastName); name != "" {
+		return name
+	}
+	return "Client " + clientUserID.String()
+}
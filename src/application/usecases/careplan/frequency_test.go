@@ -0,0 +1,117 @@
+package careplan
+
+import (
+	"testing"
+	"time"
+
+	domainAlert "caregiver/src/domain/alert"
+	domainCarePlan "caregiver/src/domain/careplan"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+func (m *mockCarePlanRepository) GetFrequencyPlans() (*[]domainCarePlan.Plan, error) {
+	res := []domainCarePlan.Plan{}
+	for _, plan := range m.plans {
+		if plan.Active && plan.VisitsPerWeek > 0 {
+			res = append(res, plan)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockScheduleRepository) Search(q domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		if s.ScheduledSlot.To.After(*q.From) && s.ScheduledSlot.From.Before(*q.To) && contains(q.Statuses, s.VisitStatus) {
+			res = append(res, *s)
+		}
+	}
+	return &domainSchedule.SearchResultSchedule{Data: &res, Total: int64(len(res)), Page: 1, PageSize: q.PageSize, TotalPages: 1}, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// recordingAlerter raises at most one alert per client and week
+type recordingAlerter struct {
+	raised map[uuid.UUID]time.Time
+}
+
+func (a *recordingAlerter) RaiseUnderService(clientUserID uuid.UUID, weekStart time.Time, message string, now time.Time) (*domainAlert.Alert, bool, error) {
+	if last, ok := a.raised[clientUserID]; ok && !last.Before(weekStart) {
+		return &domainAlert.Alert{}, false, nil
+	}
+	a.raised[clientUserID] = now
+	return &domainAlert.Alert{Message: message}, true, nil
+}
+
+func TestVisitFrequency(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	wellServed, underServed, unmonitored := uuid.New(), uuid.New(), uuid.New()
+	plans := &mockCarePlanRepository{plans: []domainCarePlan.Plan{
+		{ID: uuid.New(), ClientUserID: wellServed, VisitsPerWeek: 2, Active: true},
+		{ID: uuid.New(), ClientUserID: underServed, VisitsPerWeek: 3, Active: true},
+		{ID: uuid.New(), ClientUserID: unmonitored, Active: true},
+	}}
+	schedules := &mockScheduleRepository{schedules: make(map[uuid.UUID]*domainSchedule.Schedule)}
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	visit := func(clientID uuid.UUID, day int, status string) {
+		id := uuid.New()
+		from := monday.AddDate(0, 0, day).Add(9 * time.Hour)
+		schedules.schedules[id] = &domainSchedule.Schedule{
+			ID: id, ClientUserID: clientID, VisitStatus: status,
+			ScheduledSlot: domainSchedule.ScheduledSlot{From: from, To: from.Add(2 * time.Hour)},
+		}
+	}
+	visit(wellServed, 0, "completed")
+	visit(wellServed, 4, "upcoming")
+	visit(underServed, 0, "completed")
+	visit(underServed, 1, "missed")
+	visit(underServed, 3, "cancelled")
+	visit(underServed, -1, "completed")
+	alerter := &recordingAlerter{raised: make(map[uuid.UUID]time.Time)}
+	useCase := NewCarePlanUseCase(plans, &mockUserRepository{}, schedules, loggerInstance, WithUnderServiceAlerts(alerter))
+
+	report, err := useCase.FrequencyReport(monday.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.WeekStart.Equal(monday) || len(report.Clients) != 2 || report.UnderServed != 1 {
+		t.Fatalf("expected one of two monitored clients under-served in the week of %v, got %+v", monday, report)
+	}
+	for _, entry := range report.Clients {
+		switch entry.ClientUserID {
+		case wellServed:
+			if entry.UnderServed || entry.Delivered != 1 || entry.Upcoming != 1 {
+				t.Errorf("expected a delivered and an upcoming visit to meet twice a week, got %+v", entry)
+			}
+		case underServed:
+			if !entry.UnderServed || entry.Scheduled != 2 || entry.Missed != 1 || entry.Shortfall != 2 {
+				t.Errorf("expected a shortfall of two visits, got %+v", entry)
+			}
+		}
+	}
+
+	now := monday.AddDate(0, 0, 2)
+	if raised, err := useCase.MonitorVisitFrequency(now); err != nil || raised != 1 {
+		t.Fatalf("expected one alert, got %d (%v)", raised, err)
+	}
+	if raised, _ := useCase.MonitorVisitFrequency(now.Add(time.Hour)); raised != 0 {
+		t.Errorf("expected no second alert in the same week, got %d", raised)
+	}
+	if _, ok := alerter.raised[underServed]; !ok {
+		t.Error("expected the alert to be for the under-served client")
+	}
+}
@@ -0,0 +1,965 @@
+package claim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	domainClaim "caregiver/src/domain/claim"
+	domainConsent "caregiver/src/domain/consent"
+	domainDifferential "caregiver/src/domain/differential"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/x12"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var npiPattern = regexp.MustCompile(`^\d{10}$`)
+
+type IClaimUseCase interface {
+	CreatePayer(newPayer *domainClaim.Payer) (*domainClaim.Payer, error)
+	GetPayers() (*[]domainClaim.Payer, error)
+	GetPayerByID(id uuid.UUID) (*domainClaim.Payer, error)
+	UpdatePayer(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Payer, error)
+	DeletePayer(id uuid.UUID) error
+	CreateCoverage(newCoverage *domainClaim.Coverage) (*domainClaim.Coverage, error)
+	GetCoverages(filter domainClaim.CoverageFilter) (*[]domainClaim.Coverage, error)
+	UpdateCoverage(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Coverage, error)
+	DeleteCoverage(id uuid.UUID) error
+	CreateRate(newRate *domainClaim.Rate) (*domainClaim.Rate, error)
+	GetRates(payerID uuid.UUID) (*[]domainClaim.Rate, error)
+	UpdateRate(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Rate, error)
+	DeleteRate(id uuid.UUID) error
+	GetProviderSettings() (*domainClaim.ProviderSettings, error)
+	SetProviderSettings(settings *domainClaim.ProviderSettings) (*domainClaim.ProviderSettings, error)
+	GenerateBatch(payerID uuid.UUID, from, to time.Time) (*domainClaim.Batch, error)
+	GetBatches(payerID *uuid.UUID) (*[]domainClaim.Batch, error)
+	GetBatchByID(id uuid.UUID) (*domainClaim.Batch, error)
+	GetClaims(filter domainClaim.ClaimFilter) (*[]domainClaim.Claim, error)
+	GetClaimByID(id uuid.UUID) (*domainClaim.Claim, error)
+	UpdateClaimStatus(id uuid.UUID, status string, reason string, now time.Time) (*domainClaim.Claim, error)
+	IngestRemittance(content string, now time.Time) (*domainClaim.Remittance, *[]domainClaim.RemittanceLine, error)
+	GetRemittances() (*[]domainClaim.Remittance, error)
+	GetRemittance(id uuid.UUID) (*domainClaim.Remittance, *[]domainClaim.RemittanceLine, error)
+	GetDenialReasons() []domainClaim.DenialReason
+	GetDenials(filter domainClaim.DenialFilter) (*[]domainClaim.Denial, error)
+	GetDenialByID(id uuid.UUID) (*domainClaim.Denial, error)
+	UpdateDenial(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Denial, error)
+	RebillDenial(id uuid.UUID, now time.Time) (*domainClaim.Denial, *domainClaim.Batch, error)
+	WriteOffDenial(id uuid.UUID, note string, now time.Time) (*domainClaim.Denial, error)
+	DenialAging(now time.Time, payerID *uuid.UUID) (*domainClaim.DenialAgingReport, error)
+}
+
+// DifferentialSource supplies the shift differentials that raise the charge
+// for visits worked at nights, weekends and other premium times.
+type DifferentialSource interface {
+	ActiveRules(appliesTo string) ([]domainDifferential.Rule, error)
+}
+
+// ConsentSource tells which clients have agreed to their billing data being
+// shared with a payer.
+type ConsentSource interface {
+	ConsentedClients(payerID uuid.UUID, scope string, at time.Time) (map[uuid.UUID]bool, error)
+}
+
+type ClaimUseCase struct {
+	claimRepository    domainClaim.IClaimRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	differentials      DifferentialSource
+	consents           ConsentSource
+	Logger             *logger.Logger
+}
+
+type Option func(*ClaimUseCase)
+
+// WithDifferentials prices claims with the billing shift differentials.
+func WithDifferentials(source DifferentialSource) Option {
+	return func(u *ClaimUseCase) {
+		u.differentials = source
+	}
+}
+
+// WithConsents only exports clients who have consented to sharing their
+// billing data with the payer.
+func WithConsents(source ConsentSource) Option {
+	return func(u *ClaimUseCase) {
+		u.consents = source
+	}
+}
+
+func NewClaimUseCase(claimRepository domainClaim.IClaimRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger, opts ...Option) IClaimUseCase {
+	useCase := &ClaimUseCase{
+		claimRepository:    claimRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+func (u *ClaimUseCase) CreatePayer(newPayer *domainClaim.Payer) (*domainClaim.Payer, error) {
+	u.Logger.Info("Creating payer", zap.String("name", newPayer.Name))
+	if strings.TrimSpace(newPayer.Name) == "" || strings.TrimSpace(newPayer.PayerCode) == "" {
+		return nil, domainErrors.NewAppError(errors.New("name and payer code are required"), domainErrors.ValidationError)
+	}
+	if newPayer.FilingIndicator == "" {
+		newPayer.FilingIndicator = domainClaim.DefaultFilingIndicator
+	}
+	newPayer.Active = true
+	return u.claimRepository.CreatePayer(newPayer)
+}
+
+func (u *ClaimUseCase) GetPayers() (*[]domainClaim.Payer, error) {
+	return u.claimRepository.GetPayers()
+}
+
+func (u *ClaimUseCase) GetPayerByID(id uuid.UUID) (*domainClaim.Payer, error) {
+	return u.claimRepository.GetPayerByID(id)
+}
+
+func (u *ClaimUseCase) UpdatePayer(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Payer, error) {
+	u.Logger.Info("Updating payer", zap.String("id", id.String()))
+	for _, key := range []string{"name", "payer_code"} {
+		if v, ok := updates[key].(string); ok && strings.TrimSpace(v) == "" {
+			return nil, domainErrors.NewAppError(errors.New("name and payer code cannot be empty"), domainErrors.ValidationError)
+		}
+	}
+	if _, err := u.claimRepository.GetPayerByID(id); err != nil {
+		return nil, err
+	}
+	return u.claimRepository.UpdatePayer(id, updates)
+}
+
+func (u *ClaimUseCase) DeletePayer(id uuid.UUID) error {
+	u.Logger.Info("Deleting payer", zap.String("id", id.String()))
+	return u.claimRepository.DeletePayer(id)
+}
+
+func (u *ClaimUseCase) CreateCoverage(newCoverage *domainClaim.Coverage) (*domainClaim.Coverage, error) {
+	u.Logger.Info("Creating coverage", zap.String("clientUserID", newCoverage.ClientUserID.String()), zap.String("payerID", newCoverage.PayerID.String()))
+	client, err := u.userRepository.GetByID(newCoverage.ClientUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("client user not found"), domainErrors.NotFound)
+	}
+	if client.Role != domainUser.RoleClient {
+		return nil, domainErrors.NewAppError(errors.New("coverage can only be added for clients"), domainErrors.ValidationError)
+	}
+	if _, err := u.claimRepository.GetPayerByID(newCoverage.PayerID); err != nil {
+		return nil, err
+	}
+	if err := validateCoverage(newCoverage); err != nil {
+		return nil, err
+	}
+	newCoverage.Active = true
+	return u.claimRepository.CreateCoverage(newCoverage)
+}
+
+func (u *ClaimUseCase) GetCoverages(filter domainClaim.CoverageFilter) (*[]domainClaim.Coverage, error) {
+	return u.claimRepository.GetCoverages(filter)
+}
+
+func (u *ClaimUseCase) UpdateCoverage(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Coverage, error) {
+	u.Logger.Info("Updating coverage", zap.String("id", id.String()))
+	existing, err := u.claimRepository.GetCoverageByID(id)
+	if err != nil {
+		return nil, err
+	}
+	merged := *existing
+	if v, ok := updates["member_id"].(string); ok {
+		merged.MemberID = v
+	}
+	if v, ok := updates["birth_date"].(time.Time); ok {
+		merged.BirthDate = v
+	}
+	if v, ok := updates["gender"].(string); ok {
+		merged.Gender = v
+	}
+	if v, ok := updates["diagnosis_code"].(string); ok {
+		merged.DiagnosisCode = v
+	}
+	if err := validateCoverage(&merged); err != nil {
+		return nil, err
+	}
+	return u.claimRepository.UpdateCoverage(id, updates)
+}
+
+func (u *ClaimUseCase) DeleteCoverage(id uuid.UUID) error {
+	u.Logger.Info("Deleting coverage", zap.String("id", id.String()))
+	return u.claimRepository.DeleteCoverage(id)
+}
+
+func validateCoverage(c *domainClaim.Coverage) error {
+	if strings.TrimSpace(c.MemberID) == "" {
+		return domainErrors.NewAppError(errors.New("member id is required"), domainErrors.ValidationError)
+	}
+	if c.BirthDate.IsZero() {
+		return domainErrors.NewAppError(errors.New("birth date is required"), domainErrors.ValidationError)
+	}
+	if strings.TrimSpace(c.DiagnosisCode) == "" {
+		return domainErrors.NewAppError(errors.New("diagnosis code is required"), domainErrors.ValidationError)
+	}
+	switch strings.ToUpper(c.Gender) {
+	case "", "M", "F", "U":
+	default:
+		return domainErrors.NewAppError(errors.New("gender must be M, F or U"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func (u *ClaimUseCase) CreateRate(newRate *domainClaim.Rate) (*domainClaim.Rate, error) {
+	u.Logger.Info("Creating payer rate", zap.String("payerID", newRate.PayerID.String()), zap.String("serviceName", newRate.ServiceName))
+	if _, err := u.claimRepository.GetPayerByID(newRate.PayerID); err != nil {
+		return nil, err
+	}
+	if err := validateRate(newRate); err != nil {
+		return nil, err
+	}
+	if err := u.checkRateOverlap(newRate); err != nil {
+		return nil, err
+	}
+	return u.claimRepository.CreateRate(newRate)
+}
+
+func (u *ClaimUseCase) GetRates(payerID uuid.UUID) (*[]domainClaim.Rate, error) {
+	return u.claimRepository.GetRates(payerID)
+}
+
+func (u *ClaimUseCase) UpdateRate(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Rate, error) {
+	u.Logger.Info("Updating payer rate", zap.String("id", id.String()))
+	existing, err := u.claimRepository.GetRateByID(id)
+	if err != nil {
+		return nil, err
+	}
+	merged := *existing
+	if v, ok := updates["service_name"].(string); ok {
+		merged.ServiceName = v
+	}
+	if v, ok := updates["procedure_code"].(string); ok {
+		merged.ProcedureCode = v
+	}
+	if v, ok := updates["unit_minutes"].(int); ok {
+		merged.UnitMinutes = v
+	}
+	if v, ok := updates["unit_rate"].(float64); ok {
+		merged.UnitRate = v
+	}
+	if v, ok := updates["effective_from"].(time.Time); ok {
+		merged.EffectiveFrom = v
+	}
+	if v, ok := updates["effective_to"]; ok {
+		merged.EffectiveTo = nil
+		if t, ok := v.(time.Time); ok {
+			merged.EffectiveTo = &t
+		}
+	}
+	if err := validateRate(&merged); err != nil {
+		return nil, err
+	}
+	if err := u.checkRateOverlap(&merged); err != nil {
+		return nil, err
+	}
+	return u.claimRepository.UpdateRate(id, updates)
+}
+
+func (u *ClaimUseCase) DeleteRate(id uuid.UUID) error {
+	u.Logger.Info("Deleting payer rate", zap.String("id", id.String()))
+	return u.claimRepository.DeleteRate(id)
+}
+
+func validateRate(r *domainClaim.Rate) error {
+	if strings.TrimSpace(r.ServiceName) == "" || strings.TrimSpace(r.ProcedureCode) == "" {
+		return domainErrors.NewAppError(errors.New("service name and procedure code are required"), domainErrors.ValidationError)
+	}
+	if r.UnitMinutes <= 0 || r.UnitRate <= 0 {
+		return domainErrors.NewAppError(errors.New("unit minutes and unit rate must be positive"), domainErrors.ValidationError)
+	}
+	if r.EffectiveTo != nil && r.EffectiveTo.Before(r.EffectiveFrom) {
+		return domainErrors.NewAppError(errors.New("effective to cannot be before effective from"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+// checkRateOverlap keeps at most one rate per payer and service in effect on
+// any day, so a visit date always resolves to a single price.
+func (u *ClaimUseCase) checkRateOverlap(rate *domainClaim.Rate) error {
+	rates, err := u.claimRepository.GetRates(rate.PayerID)
+	if err != nil {
+		return err
+	}
+	for i := range *rates {
+		other := &(*rates)[i]
+		if other.ID != rate.ID && strings.EqualFold(other.ServiceName, rate.ServiceName) && other.Overlaps(rate) {
+			return domainErrors.NewAppError(fmt.Errorf("the rate overlaps the %q rate effective from %s", other.ServiceName, other.EffectiveFrom.Format("2006-01-02")), domainErrors.Conflict)
+		}
+	}
+	return nil
+}
+
+func (u *ClaimUseCase) GetProviderSettings() (*domainClaim.ProviderSettings, error) {
+	return u.claimRepository.GetProviderSettings()
+}
+
+func (u *ClaimUseCase) SetProviderSettings(settings *domainClaim.ProviderSettings) (*domainClaim.ProviderSettings, error) {
+	u.Logger.Info("Setting claim provider settings")
+	if strings.TrimSpace(settings.Name) == "" || strings.TrimSpace(settings.TaxID) == "" || strings.TrimSpace(settings.SubmitterID) == "" {
+		return nil, domainErrors.NewAppError(errors.New("name, tax id and submitter id are required"), domainErrors.ValidationError)
+	}
+	if !npiPattern.MatchString(settings.NPI) {
+		return nil, domainErrors.NewAppError(errors.New("npi must be 10 digits"), domainErrors.ValidationError)
+	}
+	existing, err := u.claimRepository.GetProviderSettings()
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if existing != nil {
+		settings.ID = existing.ID
+	}
+	return u.claimRepository.SaveProviderSettings(settings)
+}
+
+// GenerateBatch bills the payer for every completed visit checked out within
+// [from, to) whose client has active coverage with the payer and whose
+// service has a rate in effect on the visit date. Visits already on a claim are skipped, so overlapping
+// periods never bill a visit twice; denied visits are billed again through
+// RebillDenial. When consents are enforced, clients who have not consented to
+// sharing billing data with the payer are left out.
+func (u *ClaimUseCase) GenerateBatch(payerID uuid.UUID, from, to time.Time) (*domainClaim.Batch, error) {
+	u.Logger.Info("Generating claim batch", zap.String("payerID", payerID.String()), zap.Time("from", from), zap.Time("to", to))
+	if !to.After(from) {
+		return nil, domainErrors.NewAppError(errors.New("to must be after from"), domainErrors.ValidationError)
+	}
+	provider, err := u.claimRepository.GetProviderSettings()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, domainErrors.NewAppError(errors.New("billing provider settings are not configured"), domainErrors.ValidationError)
+		}
+		return nil, err
+	}
+	payer, err := u.claimRepository.GetPayerByID(payerID)
+	if err != nil {
+		return nil, err
+	}
+	if !payer.Active {
+		return nil, domainErrors.NewAppError(errors.New("payer is inactive"), domainErrors.ValidationError)
+	}
+
+	coverages, err := u.claimRepository.GetCoverages(domainClaim.CoverageFilter{PayerID: &payerID})
+	if err != nil {
+		return nil, err
+	}
+	consented, err := u.consentedClients(payerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	coverageByClient := make(map[uuid.UUID]domainClaim.Coverage)
+	clientIDs := []uuid.UUID{}
+	for _, coverage := range *coverages {
+		if !coverage.Active {
+			continue
+		}
+		if consented != nil && !consented[coverage.ClientUserID] {
+			u.Logger.Warn("Skipping client without billing consent for payer", zap.String("clientUserID", coverage.ClientUserID.String()), zap.String("payerID", payerID.String()))
+			continue
+		}
+		coverageByClient[coverage.ClientUserID] = coverage
+		clientIDs = append(clientIDs, coverage.ClientUserID)
+	}
+	rates, err := u.claimRepository.GetRates(payerID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := u.scheduleRepository.GetCompletedSchedulesBetween(from, to, clientIDs)
+	if err != nil {
+		return nil, err
+	}
+	scheduleIDs := make([]uuid.UUID, len(*schedules))
+	for i, s := range *schedules {
+		scheduleIDs[i] = s.ID
+	}
+	billed, err := u.claimRepository.GetBilledScheduleIDs(scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	differentials, err := u.billingDifferentials()
+	if err != nil {
+		return nil, err
+	}
+
+	var claims []domainClaim.Claim
+	var lines []x12.ProfessionalClaim
+	clients := make(map[uuid.UUID]*domainUser.User)
+	for i := range *schedules {
+		schedule := &(*schedules)[i]
+		if billed[schedule.ID] {
+			continue
+		}
+		rate, ok := domainClaim.EffectiveRate(*rates, schedule.ServiceName, serviceDate(schedule))
+		if !ok {
+			u.Logger.Warn("No payer rate in effect for service on the visit date, visit not billed", zap.String("scheduleID", schedule.ID.String()), zap.String("serviceName", schedule.ServiceName))
+			continue
+		}
+		client, ok := clients[schedule.ClientUserID]
+		if !ok {
+			client, err = u.userRepository.GetByID(schedule.ClientUserID)
+			if err != nil {
+				return nil, err
+			}
+			clients[schedule.ClientUserID] = client
+		}
+		coverage := coverageByClient[schedule.ClientUserID]
+		claim, line, ok := buildClaim(schedule, client, &coverage, rate, differentials)
+		if !ok {
+			continue
+		}
+		claims = append(claims, claim)
+		lines = append(lines, line)
+	}
+	if len(claims) == 0 {
+		return nil, domainErrors.NewAppError(errors.New("no billable visits in the period"), domainErrors.ValidationError)
+	}
+	return u.createBatch(provider, payer, from, to, claims, lines)
+}
+
+// buildClaim prices a visit and builds its claim and 837P line. The units
+// are charged at the rate raised by the differentials the worked time falls
+// under. It reports false when the worked time rounds to zero units.
+func buildClaim(schedule *domainSchedule.Schedule, client *domainUser.User, coverage *domainClaim.Coverage, rate *domainClaim.Rate, differentials []domainDifferential.Rule) (domainClaim.Claim, x12.ProfessionalClaim, bool) {
+	units := int(math.Round(schedule.WorkedDuration().Minutes() / float64(rate.UnitMinutes)))
+	if units <= 0 {
+		return domainClaim.Claim{}, x12.ProfessionalClaim{}, false
+	}
+	breakdown := domainDifferential.Price(differentials, schedule.WorkedPeriods(), roundCents(float64(units)*rate.UnitRate))
+	id := uuid.New()
+	claim := domainClaim.Claim{
+		ID:              id,
+		PayerID:         coverage.PayerID,
+		ClientUserID:    schedule.ClientUserID,
+		ScheduleID:      schedule.ID,
+		ControlNumber:   controlNumber(id),
+		ServiceDate:     serviceDate(schedule),
+		ProcedureCode:   rate.ProcedureCode,
+		Modifier:        rate.Modifier,
+		Units:           units,
+		Charge:          breakdown.Amount,
+		ChargeBreakdown: breakdown.Lines,
+		Status:          domainClaim.StatusSubmitted,
+	}
+	line := x12.ProfessionalClaim{
+		ControlNumber: claim.ControlNumber,
+		Subscriber: x12.Subscriber{
+			FirstName: client.FirstName,
+			LastName:  client.LastName,
+			MemberID:  coverage.MemberID,
+			BirthDate: coverage.BirthDate,
+			Gender:    coverage.Gender,
+			Address: x12.Address{
+				Street: strings.TrimSpace(client.Location.HouseNumber + " " + client.Location.Street),
+				City:   client.Location.City,
+				State:  client.Location.State,
+				Zip:    client.Location.Pincode,
+			},
+		},
+		DiagnosisCode: coverage.DiagnosisCode,
+		ProcedureCode: claim.ProcedureCode,
+		Modifier:      claim.Modifier,
+		Units:         claim.Units,
+		Charge:        claim.Charge,
+		ServiceDate:   claim.ServiceDate,
+	}
+	return claim, line, true
+}
+
+// createBatch numbers a new batch, renders its 837P file and stores it with
+// its claims.
+func (u *ClaimUseCase) createBatch(provider *domainClaim.ProviderSettings, payer *domainClaim.Payer, from, to time.Time, claims []domainClaim.Claim, lines []x12.ProfessionalClaim) (*domainClaim.Batch, error) {
+	last, err := u.claimRepository.GetLastBatchControlNumber()
+	if err != nil {
+		return nil, err
+	}
+	total := 0.0
+	for _, claim := range claims {
+		total += claim.Charge
+	}
+	batch := &domainClaim.Batch{
+		PayerID:       payer.ID,
+		ControlNumber: last + 1,
+		From:          from,
+		To:            to,
+		ClaimCount:    len(claims),
+		TotalCharge:   roundCents(total),
+	}
+	batch.Content = x12.Build837P(x12.ClaimFile{
+		ControlNumber: batch.ControlNumber,
+		CreatedAt:     time.Now().UTC(),
+		Provider: x12.BillingProvider{
+			Name:         provider.Name,
+			NPI:          provider.NPI,
+			TaxID:        provider.TaxID,
+			Address:      x12.Address{Street: provider.Street, City: provider.City, State: provider.State, Zip: provider.Zip},
+			SubmitterID:  provider.SubmitterID,
+			ContactName:  provider.ContactName,
+			ContactPhone: provider.ContactPhone,
+		},
+		PayerName:       payer.Name,
+		PayerCode:       payer.PayerCode,
+		FilingIndicator: payer.FilingIndicator,
+		Claims:          lines,
+	})
+	return u.claimRepository.CreateBatch(batch, claims)
+}
+
+func (u *ClaimUseCase) GetBatches(payerID *uuid.UUID) (*[]domainClaim.Batch, error) {
+	return u.claimRepository.GetBatches(payerID)
+}
+
+func (u *ClaimUseCase) GetBatchByID(id uuid.UUID) (*domainClaim.Batch, error) {
+	return u.claimRepository.GetBatchByID(id)
+}
+
+func (u *ClaimUseCase) GetClaims(filter domainClaim.ClaimFilter) (*[]domainClaim.Claim, error) {
+	return u.claimRepository.GetClaims(filter)
+}
+
+func (u *ClaimUseCase) GetClaimByID(id uuid.UUID) (*domainClaim.Claim, error) {
+	return u.claimRepository.GetClaimByID(id)
+}
+
+// UpdateClaimStatus records an acknowledgement received outside an 835, such
+// as a payer portal or a 277CA acceptance report. Payments are only recorded
+// through remittances.
+func (u *ClaimUseCase) UpdateClaimStatus(id uuid.UUID, status string, reason string, now time.Time) (*domainClaim.Claim, error) {
+	u.Logger.Info("Updating claim status", zap.String("id", id.String()), zap.String("status", status))
+	if status != domainClaim.StatusAccepted && status != domainClaim.StatusDenied {
+		return nil, domainErrors.NewAppError(errors.New("status must be accepted or denied"), domainErrors.ValidationError)
+	}
+	claim, err := u.claimRepository.GetClaimByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status != domainClaim.StatusSubmitted && claim.Status != domainClaim.StatusAccepted {
+		return nil, domainErrors.NewAppError(fmt.Errorf("a %s claim cannot change status", claim.Status), domainErrors.Conflict)
+	}
+	updated, err := u.claimRepository.UpdateClaim(id, map[string]interface{}{
+		"status":         status,
+		"status_reason":  reason,
+		"adjudicated_at": now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == domainClaim.StatusDenied {
+		if err := u.openDenial(updated, splitReasonCodes(reason), now); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+// IngestRemittance reads an 835 file and applies each claim payment to the
+// claim with the matching control number. Payments for unknown control
+// numbers are kept as unmatched lines for manual follow-up.
+func (u *ClaimUseCase) IngestRemittance(content string, now time.Time) (*domainClaim.Remittance, *[]domainClaim.RemittanceLine, error) {
+	u.Logger.Info("Ingesting remittance")
+	parsed, err := x12.Parse835(content)
+	if err != nil {
+		return nil, nil, domainErrors.NewAppError(fmt.Errorf("invalid 835 file: %w", err), domainErrors.ValidationError)
+	}
+
+	remittance := &domainClaim.Remittance{
+		ID:          uuid.New(),
+		PayerName:   parsed.PayerName,
+		TraceNumber: parsed.TraceNumber,
+		PaymentDate: parsed.PaymentDate,
+		TotalPaid:   parsed.TotalPaid,
+		Content:     content,
+	}
+	if parsed.PayerID != "" {
+		payers, err := u.claimRepository.GetPayers()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, payer := range *payers {
+			if strings.EqualFold(payer.PayerCode, parsed.PayerID) {
+				payerID := payer.ID
+				remittance.PayerID = &payerID
+				break
+			}
+		}
+	}
+
+	lines := make([]domainClaim.RemittanceLine, len(parsed.Claims))
+	for i, payment := range parsed.Claims {
+		adjustments := make([]string, len(payment.Adjustments))
+		for j, a := range payment.Adjustments {
+			adjustments[j] = a.String()
+		}
+		lines[i] = domainClaim.RemittanceLine{
+			ControlNumber: payment.ControlNumber,
+			StatusCode:    payment.StatusCode,
+			Charge:        payment.Charge,
+			Paid:          payment.Paid,
+			Adjustments:   strings.Join(adjustments, ","),
+		}
+
+		claim, err := u.claimRepository.GetClaimByControlNumber(payment.ControlNumber)
+		if err != nil {
+			if !isNotFound(err) {
+				return nil, nil, err
+			}
+			u.Logger.Warn("Remittance line does not match a claim", zap.String("controlNumber", payment.ControlNumber))
+			remittance.Unmatched++
+			continue
+		}
+		claimID := claim.ID
+		lines[i].ClaimID = &claimID
+		remittance.Matched++
+
+		status := domainClaim.StatusPaid
+		paid := payment.Paid
+		switch {
+		case payment.StatusCode == x12.ClaimStatusReversal:
+			status = domainClaim.StatusSubmitted
+			paid = 0
+		case payment.StatusCode == x12.ClaimStatusDenied || payment.Paid <= 0:
+			status = domainClaim.StatusDenied
+		}
+		updated, err := u.claimRepository.UpdateClaim(claim.ID, map[string]interface{}{
+			"status":         status,
+			"status_reason":  lines[i].Adjustments,
+			"paid_amount":    paid,
+			"remittance_id":  remittance.ID,
+			"adjudicated_at": now,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if status == domainClaim.StatusDenied {
+			if err := u.openDenial(updated, adjustments, now); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	created, err := u.claimRepository.CreateRemittance(remittance, lines)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.Logger.Info("Remittance ingested", zap.String("remittanceID", created.ID.String()), zap.Int("matched", created.Matched), zap.Int("unmatched", created.Unmatched))
+	return created, &lines, nil
+}
+
+func (u *ClaimUseCase) GetRemittances() (*[]domainClaim.Remittance, error) {
+	return u.claimRepository.GetRemittances()
+}
+
+func (u *ClaimUseCase) GetRemittance(id uuid.UUID) (*domainClaim.Remittance, *[]domainClaim.RemittanceLine, error) {
+	remittance, err := u.claimRepository.GetRemittanceByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	lines, err := u.claimRepository.GetRemittanceLines(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return remittance, lines, nil
+}
+
+func (u *ClaimUseCase) GetDenialReasons() []domainClaim.DenialReason {
+	return domainClaim.DenialReasons
+}
+
+func (u *ClaimUseCase) GetDenials(filter domainClaim.DenialFilter) (*[]domainClaim.Denial, error) {
+	return u.claimRepository.GetDenials(filter)
+}
+
+func (u *ClaimUseCase) GetDenialByID(id uuid.UUID) (*domainClaim.Denial, error) {
+	return u.claimRepository.GetDenialByID(id)
+}
+
+// UpdateDenial edits the note or the billing staff member working an open
+// denial.
+func (u *ClaimUseCase) UpdateDenial(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Denial, error) {
+	u.Logger.Info("Updating claim denial", zap.String("id", id.String()))
+	denial, err := u.openDenialByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if assignee, ok := updates["assigned_user_id"].(uuid.UUID); ok {
+		user, err := u.userRepository.GetByID(assignee)
+		if err != nil {
+			return nil, domainErrors.NewAppError(errors.New("assigned user not found"), domainErrors.NotFound)
+		}
+		if user.Role != domainUser.RoleAdmin {
+			return nil, domainErrors.NewAppError(errors.New("denials can only be assigned to admins"), domainErrors.ValidationError)
+		}
+	}
+	return u.claimRepository.UpdateDenial(denial.ID, updates)
+}
+
+// RebillDenial bills the denied visit again in a new single-claim batch using
+// the current coverage and the rate in effect on the visit date, so
+// corrections made while working the denial are picked up. The denied claim is kept, marked rebilled.
+func (u *ClaimUseCase) RebillDenial(id uuid.UUID, now time.Time) (*domainClaim.Denial, *domainClaim.Batch, error) {
+	u.Logger.Info("Rebilling claim denial", zap.String("id", id.String()))
+	denial, err := u.openDenialByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	denied, err := u.claimRepository.GetClaimByID(denial.ClaimID)
+	if err != nil {
+		return nil, nil, err
+	}
+	provider, err := u.claimRepository.GetProviderSettings()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil, domainErrors.NewAppError(errors.New("billing provider settings are not configured"), domainErrors.ValidationError)
+		}
+		return nil, nil, err
+	}
+	payer, err := u.claimRepository.GetPayerByID(denied.PayerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !payer.Active {
+		return nil, nil, domainErrors.NewAppError(errors.New("payer is inactive"), domainErrors.ValidationError)
+	}
+	schedule, err := u.scheduleRepository.GetScheduleByID(denied.ScheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := u.userRepository.GetByID(denied.ClientUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	coverages, err := u.claimRepository.GetCoverages(domainClaim.CoverageFilter{ClientUserID: &denied.ClientUserID, PayerID: &denied.PayerID})
+	if err != nil {
+		return nil, nil, err
+	}
+	var coverage *domainClaim.Coverage
+	for i := range *coverages {
+		if (*coverages)[i].Active {
+			coverage = &(*coverages)[i]
+			break
+		}
+	}
+	if coverage == nil {
+		return nil, nil, domainErrors.NewAppError(errors.New("client has no active coverage with the payer"), domainErrors.ValidationError)
+	}
+	consented, err := u.consentedClients(denied.PayerID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if consented != nil && !consented[denied.ClientUserID] {
+		return nil, nil, domainErrors.NewAppError(errors.New("the client has not consented to sharing billing data with the payer"), domainErrors.NotAuthorized)
+	}
+	rates, err := u.claimRepository.GetRates(denied.PayerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rate, ok := domainClaim.EffectiveRate(*rates, schedule.ServiceName, serviceDate(schedule))
+	if !ok {
+		return nil, nil, domainErrors.NewAppError(fmt.Errorf("payer has no rate for %q in effect on the visit date", schedule.ServiceName), domainErrors.ValidationError)
+	}
+
+	differentials, err := u.billingDifferentials()
+	if err != nil {
+		return nil, nil, err
+	}
+	claim, line, ok := buildClaim(schedule, client, coverage, rate, differentials)
+	if !ok {
+		return nil, nil, domainErrors.NewAppError(errors.New("visit has no billable time"), domainErrors.ValidationError)
+	}
+	claim.OriginalClaimID = &denied.ID
+	batch, err := u.createBatch(provider, payer, denied.ServiceDate, denied.ServiceDate.AddDate(0, 0, 1), []domainClaim.Claim{claim}, []x12.ProfessionalClaim{line})
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := u.claimRepository.UpdateClaim(denied.ID, map[string]interface{}{"status": domainClaim.StatusRebilled}); err != nil {
+		return nil, nil, err
+	}
+	resolved, err := u.claimRepository.UpdateDenial(denial.ID, map[string]interface{}{
+		"status":          domainClaim.DenialRebilled,
+		"rebill_claim_id": claim.ID,
+		"resolved_at":     now,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resolved, batch, nil
+}
+
+// WriteOffDenial closes a denial the agency will not pursue.
+func (u *ClaimUseCase) WriteOffDenial(id uuid.UUID, note string, now time.Time) (*domainClaim.Denial, error) {
+	u.Logger.Info("Writing off claim denial", zap.String("id", id.String()))
+	if strings.TrimSpace(note) == "" {
+		return nil, domainErrors.NewAppError(errors.New("a note is required to write off a denial"), domainErrors.ValidationError)
+	}
+	denial, err := u.openDenialByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.claimRepository.UpdateClaim(denial.ClaimID, map[string]interface{}{"status": domainClaim.StatusWrittenOff}); err != nil {
+		return nil, err
+	}
+	return u.claimRepository.UpdateDenial(denial.ID, map[string]interface{}{
+		"status":      domainClaim.DenialWrittenOff,
+		"note":        note,
+		"resolved_at": now,
+	})
+}
+
+// DenialAging groups the open denials by days since the denial and by
+// reason code.
+func (u *ClaimUseCase) DenialAging(now time.Time, payerID *uuid.UUID) (*domainClaim.DenialAgingReport, error) {
+	denials, err := u.claimRepository.GetDenials(domainClaim.DenialFilter{Status: domainClaim.DenialOpen, PayerID: payerID})
+	if err != nil {
+		return nil, err
+	}
+	report := &domainClaim.DenialAgingReport{
+		AsOf: now,
+		Buckets: []domainClaim.DenialAgingBucket{
+			{Label: "0-30", MinDays: 0, MaxDays: 30},
+			{Label: "31-60", MinDays: 31, MaxDays: 60},
+			{Label: "61-90", MinDays: 61, MaxDays: 90},
+			{Label: "90+", MinDays: 91},
+		},
+	}
+	byReason := make(map[string]*domainClaim.DenialReasonSummary)
+	var codes []string
+	for _, denial := range *denials {
+		report.Total++
+		report.TotalAmount += denial.Amount
+		days := int(now.Sub(denial.DeniedAt).Hours() / 24)
+		for i := range report.Buckets {
+			bucket := &report.Buckets[i]
+			if days >= bucket.MinDays && (bucket.MaxDays == 0 || days <= bucket.MaxDays) {
+				bucket.Count++
+				bucket.Amount = roundCents(bucket.Amount + denial.Amount)
+				break
+			}
+		}
+		for _, code := range denial.ReasonCodes {
+			summary, ok := byReason[code]
+			if !ok {
+				summary = &domainClaim.DenialReasonSummary{Code: code}
+				if reason, found := domainClaim.LookupDenialReason(code); found {
+					summary.Description = reason.Description
+				}
+				byReason[code] = summary
+				codes = append(codes, code)
+			}
+			summary.Count++
+			summary.Amount = roundCents(summary.Amount + denial.Amount)
+		}
+	}
+	report.TotalAmount = roundCents(report.TotalAmount)
+	report.ByReason = make([]domainClaim.DenialReasonSummary, len(codes))
+	for i, code := range codes {
+		report.ByReason[i] = *byReason[code]
+	}
+	sort.SliceStable(report.ByReason, func(i, j int) bool {
+		return report.ByReason[i].Count > report.ByReason[j].Count
+	})
+	return report, nil
+}
+
+// openDenial opens the correction task for a denied claim, or refreshes the
+// reason codes of the one already open.
+func (u *ClaimUseCase) openDenial(claim *domainClaim.Claim, reasonCodes []string, now time.Time) error {
+	existing, err := u.claimRepository.GetOpenDenialByClaim(claim.ID)
+	if err == nil {
+		_, err = u.claimRepository.UpdateDenial(existing.ID, map[string]interface{}{"reason_codes": reasonCodes})
+		return err
+	}
+	if !isNotFound(err) {
+		return err
+	}
+	_, err = u.claimRepository.CreateDenial(&domainClaim.Denial{
+		ClaimID:      claim.ID,
+		ScheduleID:   claim.ScheduleID,
+		ClientUserID: claim.ClientUserID,
+		PayerID:      claim.PayerID,
+		Amount:       claim.Charge,
+		ReasonCodes:  reasonCodes,
+		Status:       domainClaim.DenialOpen,
+		DeniedAt:     now,
+	})
+	return err
+}
+
+func (u *ClaimUseCase) openDenialByID(id uuid.UUID) (*domainClaim.Denial, error) {
+	denial, err := u.claimRepository.GetDenialByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if denial.Status != domainClaim.DenialOpen {
+		return nil, domainErrors.NewAppError(fmt.Errorf("denial is already %s", denial.Status), domainErrors.Conflict)
+	}
+	return denial, nil
+}
+
+func splitReasonCodes(reason string) []string {
+	codes := []string{}
+	for _, code := range strings.Split(reason, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// controlNumber derives the 20-character patient control number from the
+// claim ID, short enough for every payer's CLM01 limit.
+func controlNumber(id uuid.UUID) string {
+	return strings.ToUpper(strings.ReplaceAll(id.String(), "-", ""))[:20]
+}
+
+// billingDifferentials returns the active billing differentials, none when
+// differentials are not configured.
+func (u *ClaimUseCase) billingDifferentials() ([]domainDifferential.Rule, error) {
+	if u.differentials == nil {
+		return nil, nil
+	}
+	return u.differentials.ActiveRules(domainDifferential.AppliesToBilling)
+}
+
+// consentedClients returns the clients allowed in exports to the payer, or nil
+// when consents are not enforced.
+func (u *ClaimUseCase) consentedClients(payerID uuid.UUID, at time.Time) (map[uuid.UUID]bool, error) {
+	if u.consents == nil {
+		return nil, nil
+	}
+	return u.consents.ConsentedClients(payerID, domainConsent.ScopeBilling, at)
+}
+
+func serviceDate(schedule *domainSchedule.Schedule) time.Time {
+	t := schedule.ScheduledSlot.From
+	if schedule.CheckinTime != nil {
+		t = *schedule.CheckinTime
+	}
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
@@ -0,0 +1,636 @@
+package claim
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domainClaim "caregiver/src/domain/claim"
+	domainDifferential "caregiver/src/domain/differential"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockClaimRepository keeps payer setup, batches, claims and remittances in
+// memory
+type mockClaimRepository struct {
+	domainClaim.IClaimRepository
+	provider    *domainClaim.ProviderSettings
+	payers      []domainClaim.Payer
+	coverages   []domainClaim.Coverage
+	rates       []domainClaim.Rate
+	batches     []domainClaim.Batch
+	claims      map[uuid.UUID]*domainClaim.Claim
+	remittances []domainClaim.Remittance
+	lines       []domainClaim.RemittanceLine
+	denials     map[uuid.UUID]*domainClaim.Denial
+}
+
+func (m *mockClaimRepository) GetProviderSettings() (*domainClaim.ProviderSettings, error) {
+	if m.provider == nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return m.provider, nil
+}
+
+func (m *mockClaimRepository) GetPayerByID(id uuid.UUID) (*domainClaim.Payer, error) {
+	for i := range m.payers {
+		if m.payers[i].ID == id {
+			return &m.payers[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockClaimRepository) GetPayers() (*[]domainClaim.Payer, error) {
+	return &m.payers, nil
+}
+
+func (m *mockClaimRepository) GetCoverages(filter domainClaim.CoverageFilter) (*[]domainClaim.Coverage, error) {
+	res := []domainClaim.Coverage{}
+	for _, c := range m.coverages {
+		if (filter.PayerID == nil || c.PayerID == *filter.PayerID) && (filter.ClientUserID == nil || c.ClientUserID == *filter.ClientUserID) {
+			res = append(res, c)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockClaimRepository) GetRates(payerID uuid.UUID) (*[]domainClaim.Rate, error) {
+	res := []domainClaim.Rate{}
+	for _, r := range m.rates {
+		if r.PayerID == payerID {
+			res = append(res, r)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockClaimRepository) CreateRate(newRate *domainClaim.Rate) (*domainClaim.Rate, error) {
+	newRate.ID = uuid.New()
+	m.rates = append(m.rates, *newRate)
+	return newRate, nil
+}
+
+func (m *mockClaimRepository) GetBilledScheduleIDs(scheduleIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	billed := make(map[uuid.UUID]bool)
+	for _, c := range m.claims {
+		billed[c.ScheduleID] = true
+	}
+	return billed, nil
+}
+
+func (m *mockClaimRepository) GetLastBatchControlNumber() (int, error) {
+	return len(m.batches), nil
+}
+
+func (m *mockClaimRepository) CreateBatch(batch *domainClaim.Batch, claims []domainClaim.Claim) (*domainClaim.Batch, error) {
+	batch.ID = uuid.New()
+	m.batches = append(m.batches, *batch)
+	for i := range claims {
+		claims[i].BatchID = batch.ID
+		claim := claims[i]
+		m.claims[claim.ID] = &claim
+	}
+	return batch, nil
+}
+
+func (m *mockClaimRepository) GetClaimByID(id uuid.UUID) (*domainClaim.Claim, error) {
+	c, ok := m.claims[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *c
+	return &copied, nil
+}
+
+func (m *mockClaimRepository) GetClaimByControlNumber(controlNumber string) (*domainClaim.Claim, error) {
+	for _, c := range m.claims {
+		if c.ControlNumber == controlNumber {
+			copied := *c
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockClaimRepository) UpdateClaim(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Claim, error) {
+	c := m.claims[id]
+	for key, value := range updates {
+		switch key {
+		case "status":
+			c.Status = value.(string)
+		case "status_reason":
+			c.StatusReason = value.(string)
+		case "paid_amount":
+			c.PaidAmount = value.(float64)
+		case "remittance_id":
+			v := value.(uuid.UUID)
+			c.RemittanceID = &v
+		case "adjudicated_at":
+			v := value.(time.Time)
+			c.AdjudicatedAt = &v
+		}
+	}
+	return m.GetClaimByID(id)
+}
+
+func (m *mockClaimRepository) CreateRemittance(remittance *domainClaim.Remittance, lines []domainClaim.RemittanceLine) (*domainClaim.Remittance, error) {
+	m.remittances = append(m.remittances, *remittance)
+	m.lines = append(m.lines, lines...)
+	return remittance, nil
+}
+
+func (m *mockClaimRepository) CreateDenial(newDenial *domainClaim.Denial) (*domainClaim.Denial, error) {
+	newDenial.ID = uuid.New()
+	m.denials[newDenial.ID] = newDenial
+	return newDenial, nil
+}
+
+func (m *mockClaimRepository) GetDenialByID(id uuid.UUID) (*domainClaim.Denial, error) {
+	d, ok := m.denials[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (m *mockClaimRepository) GetOpenDenialByClaim(claimID uuid.UUID) (*domainClaim.Denial, error) {
+	for _, d := range m.denials {
+		if d.ClaimID == claimID && d.Status == domainClaim.DenialOpen {
+			return m.GetDenialByID(d.ID)
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockClaimRepository) GetDenials(filter domainClaim.DenialFilter) (*[]domainClaim.Denial, error) {
+	res := []domainClaim.Denial{}
+	for _, d := range m.denials {
+		if filter.Status == "" || d.Status == filter.Status {
+			res = append(res, *d)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockClaimRepository) UpdateDenial(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Denial, error) {
+	d := m.denials[id]
+	for key, value := range updates {
+		switch key {
+		case "status":
+			d.Status = value.(string)
+		case "note":
+			d.Note = value.(string)
+		case "reason_codes":
+			d.ReasonCodes = value.([]string)
+		case "assigned_user_id":
+			v := value.(uuid.UUID)
+			d.AssignedUserID = &v
+		case "rebill_claim_id":
+			v := value.(uuid.UUID)
+			d.RebillClaimID = &v
+		case "resolved_at":
+			v := value.(time.Time)
+			d.ResolvedAt = &v
+		}
+	}
+	return m.GetDenialByID(id)
+}
+
+// mockScheduleRepository returns the completed schedules of the listed
+// clients
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetCompletedSchedulesBetween(from, to time.Time, clientUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		for _, id := range clientUserIDs {
+			if s.ClientUserID == id && !s.CheckoutTime.Before(from) && s.CheckoutTime.Before(to) {
+				res = append(res, s)
+			}
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockUserRepository returns a fixed set of users
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	u, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return u, nil
+}
+
+// mockDifferentials serves a fixed list of billing differentials
+type mockDifferentials struct {
+	rules []domainDifferential.Rule
+}
+
+func (m *mockDifferentials) ActiveRules(appliesTo string) ([]domainDifferential.Rule, error) {
+	return m.rules, nil
+}
+
+// mockConsents lists the clients who consented to sharing with each payer
+type mockConsents struct {
+	clients map[uuid.UUID]bool
+}
+
+func (m *mockConsents) ConsentedClients(payerID uuid.UUID, scope string, at time.Time) (map[uuid.UUID]bool, error) {
+	return m.clients, nil
+}
+
+var (
+	periodFrom = time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	periodTo   = time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	now        = time.Date(2025, 8, 5, 12, 0, 0, 0, time.UTC)
+)
+
+type testFixture struct {
+	useCase       IClaimUseCase
+	claimRepo     *mockClaimRepository
+	schedules     *mockScheduleRepository
+	differentials *mockDifferentials
+	payer         domainClaim.Payer
+	client        *domainUser.User
+}
+
+func setupTestClaimUseCase(t *testing.T) *testFixture {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	client := &domainUser.User{ID: uuid.New(), FirstName: "Ana", LastName: "Ruiz", Role: domainUser.RoleClient}
+	payer := domainClaim.Payer{ID: uuid.New(), Name: "Texas Medicaid", PayerCode: "TXMCD", FilingIndicator: "MC", Active: true}
+	claimRepo := &mockClaimRepository{
+		provider: &domainClaim.ProviderSettings{Name: "Sunrise Home Care", NPI: "1234567893", TaxID: "123456789", SubmitterID: "SUNRISE"},
+		payers:   []domainClaim.Payer{payer},
+		coverages: []domainClaim.Coverage{{
+			ID: uuid.New(), ClientUserID: client.ID, PayerID: payer.ID, MemberID: "M1",
+			BirthDate: time.Date(1940, 1, 2, 0, 0, 0, 0, time.UTC), DiagnosisCode: "Z74.1", Active: true,
+		}},
+		rates:   []domainClaim.Rate{{ID: uuid.New(), PayerID: payer.ID, ServiceName: "Personal care", ProcedureCode: "T1019", UnitMinutes: 15, UnitRate: 5.25}},
+		claims:  map[uuid.UUID]*domainClaim.Claim{},
+		denials: map[uuid.UUID]*domainClaim.Denial{},
+	}
+	schedules := &mockScheduleRepository{}
+	differentials := &mockDifferentials{}
+	useCase := NewClaimUseCase(
+		claimRepo,
+		schedules,
+		&mockUserRepository{users: map[uuid.UUID]*domainUser.User{client.ID: client}},
+		loggerInstance,
+		WithDifferentials(differentials),
+	)
+	return &testFixture{useCase: useCase, claimRepo: claimRepo, schedules: schedules, differentials: differentials, payer: payer, client: client}
+}
+
+// addVisit records a completed visit of the given length for the client
+func (f *testFixture) addVisit(serviceName string, checkin time.Time, worked time.Duration) uuid.UUID {
+	checkout := checkin.Add(worked)
+	visit := domainSchedule.Schedule{
+		ID:           uuid.New(),
+		ClientUserID: f.client.ID,
+		ServiceName:  serviceName,
+		VisitStatus:  "completed",
+		CheckinTime:  &checkin,
+		CheckoutTime: &checkout,
+	}
+	f.schedules.schedules = append(f.schedules.schedules, visit)
+	return visit.ID
+}
+
+func (f *testFixture) claimFor(scheduleID uuid.UUID) *domainClaim.Claim {
+	for _, c := range f.claimRepo.claims {
+		if c.ScheduleID == scheduleID {
+			return c
+		}
+	}
+	return nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestGenerateBatch(t *testing.T) {
+	t.Run("Bills completed visits by unit", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		visitID := f.addVisit("personal care", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), 2*time.Hour+5*time.Minute)
+		f.addVisit("Companionship", time.Date(2025, 7, 17, 9, 0, 0, 0, time.UTC), time.Hour)
+
+		batch, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if batch.ControlNumber != 1 || batch.ClaimCount != 1 || batch.TotalCharge != 42 {
+			t.Errorf("unexpected batch %+v", batch)
+		}
+		claim := f.claimFor(visitID)
+		if claim == nil || claim.Units != 8 || claim.ProcedureCode != "T1019" || claim.Status != domainClaim.StatusSubmitted {
+			t.Fatalf("unexpected claim %+v", claim)
+		}
+		if len(claim.ControlNumber) != 20 || !strings.Contains(batch.Content, "CLM*"+claim.ControlNumber+"*42*") {
+			t.Errorf("expected the claim in the 837P, got %q", batch.Content)
+		}
+	})
+
+	t.Run("Night differential raises the charge", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		f.differentials.rules = []domainDifferential.Rule{{
+			ID: uuid.New(), Name: "Nights", AppliesTo: domainDifferential.AppliesToBilling,
+			StartMinute: 22 * 60, EndMinute: 6 * 60, Timezone: "UTC", Multiplier: 1.5, Active: true,
+		}}
+		visitID := f.addVisit("Personal care", time.Date(2025, 7, 16, 21, 0, 0, 0, time.UTC), 2*time.Hour)
+
+		if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		claim := f.claimFor(visitID)
+		if claim.Units != 8 || claim.Charge != 52.5 {
+			t.Fatalf("expected 8 units charged 52.50, got %+v", claim)
+		}
+		if len(claim.ChargeBreakdown) != 2 || claim.ChargeBreakdown[0].Amount != 21 || claim.ChargeBreakdown[1].Name != "Nights" || claim.ChargeBreakdown[1].Amount != 31.5 {
+			t.Errorf("unexpected breakdown %+v", claim.ChargeBreakdown)
+		}
+	})
+
+	t.Run("Visits are billed once", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		f.addVisit("Personal care", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), time.Hour)
+		if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected no billable visits, got %v", err)
+		}
+	})
+
+	t.Run("Provider settings are required", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		f.claimRepo.provider = nil
+		f.addVisit("Personal care", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), time.Hour)
+		if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("Inactive coverage is not billed", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		f.claimRepo.coverages[0].Active = false
+		f.addVisit("Personal care", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), time.Hour)
+		if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected no billable visits, got %v", err)
+		}
+	})
+
+	t.Run("Clients without billing consent are not exported", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		consents := &mockConsents{clients: map[uuid.UUID]bool{}}
+		WithConsents(consents)(f.useCase.(*ClaimUseCase))
+		visitID := f.addVisit("Personal care", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), time.Hour)
+		if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected no billable visits without consent, got %v", err)
+		}
+
+		consents.clients[f.client.ID] = true
+		if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if f.claimFor(visitID) == nil {
+			t.Error("expected the consented client's visit to be billed")
+		}
+	})
+}
+
+func TestEffectiveDatedRates(t *testing.T) {
+	f := setupTestClaimUseCase(t)
+	julyEnd := time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)
+	f.claimRepo.rates[0].EffectiveTo = &julyEnd
+
+	raise := &domainClaim.Rate{PayerID: f.payer.ID, ServiceName: "personal care", ProcedureCode: "T1019", UnitMinutes: 15, UnitRate: 6, EffectiveFrom: julyEnd}
+	if _, err := f.useCase.CreateRate(raise); errorType(err) != domainErrors.Conflict {
+		t.Fatalf("expected overlapping rates to conflict, got %v", err)
+	}
+	raise.EffectiveFrom = julyEnd.AddDate(0, 0, 1)
+	if _, err := f.useCase.CreateRate(raise); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	before := f.addVisit("Personal care", time.Date(2025, 7, 15, 9, 0, 0, 0, time.UTC), time.Hour)
+	after := f.addVisit("Personal care", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), time.Hour)
+	if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if charge := f.claimFor(before).Charge; charge != 21 {
+		t.Errorf("expected the old rate on the last day it applies, got %v", charge)
+	}
+	if charge := f.claimFor(after).Charge; charge != 24 {
+		t.Errorf("expected the new rate from its effective date, got %v", charge)
+	}
+}
+
+func TestUpdateClaimStatus(t *testing.T) {
+	f := setupTestClaimUseCase(t)
+	visitID := f.addVisit("Personal care", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), time.Hour)
+	if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claimID := f.claimFor(visitID).ID
+
+	if _, err := f.useCase.UpdateClaimStatus(claimID, domainClaim.StatusPaid, "", now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected paid to be rejected, got %v", err)
+	}
+	accepted, err := f.useCase.UpdateClaimStatus(claimID, domainClaim.StatusAccepted, "", now)
+	if err != nil || accepted.Status != domainClaim.StatusAccepted || accepted.AdjudicatedAt == nil {
+		t.Fatalf("expected accepted claim, got %+v (%v)", accepted, err)
+	}
+	if _, err := f.useCase.UpdateClaimStatus(claimID, domainClaim.StatusDenied, "CO-16", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.useCase.UpdateClaimStatus(claimID, domainClaim.StatusAccepted, "", now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected conflict for a denied claim, got %v", err)
+	}
+	denial, err := f.claimRepo.GetOpenDenialByClaim(claimID)
+	if err != nil || len(denial.ReasonCodes) != 1 || denial.ReasonCodes[0] != "CO-16" {
+		t.Errorf("expected an open denial for the claim, got %+v (%v)", denial, err)
+	}
+}
+
+func TestIngestRemittance(t *testing.T) {
+	f := setupTestClaimUseCase(t)
+	paidID := f.addVisit("Personal care", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), 2*time.Hour)
+	deniedID := f.addVisit("Personal care", time.Date(2025, 7, 17, 9, 0, 0, 0, time.UTC), time.Hour)
+	if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	paid, denied := f.claimFor(paidID), f.claimFor(deniedID)
+
+	content := "ISA*00*          *00*          *ZZ*TXMCD          *ZZ*SUNRISE        *250801*1200*^*00501*000000007*0*P*:~" +
+		"GS*HP*TXMCD*SUNRISE*20250801*1200*7*X*005010X221A1~" +
+		"ST*835*0001~" +
+		"BPR*I*40*C*ACH*CCP*01*999999999*DA*123456*1512345678**01*999999999*DA*654321*20250801~" +
+		"TRN*1*EFT0001*1512345678~" +
+		"N1*PR*TEXAS MEDICAID*XV*TXMCD~" +
+		"CLP*" + paid.ControlNumber + "*1*42*40**MC*PAYER1~" +
+		"CAS*CO*45*2~" +
+		"CLP*" + denied.ControlNumber + "*4*21*0**MC*PAYER2~" +
+		"CAS*CO*16*21~" +
+		"CLP*UNKNOWN*1*10*10**MC*PAYER3~" +
+		"SE*11*0001~GE*1*7~IEA*1*000000007~"
+
+	remittance, lines, err := f.useCase.IngestRemittance(content, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remittance.Matched != 2 || remittance.Unmatched != 1 || remittance.PayerID == nil || *remittance.PayerID != f.payer.ID {
+		t.Errorf("unexpected remittance %+v", remittance)
+	}
+	if len(*lines) != 3 || (*lines)[2].ClaimID != nil {
+		t.Errorf("expected the unknown line to stay unmatched, got %+v", *lines)
+	}
+	if paid.Status != domainClaim.StatusPaid || paid.PaidAmount != 40 || paid.StatusReason != "CO-45" {
+		t.Errorf("unexpected paid claim %+v", paid)
+	}
+	if denied.Status != domainClaim.StatusDenied || denied.StatusReason != "CO-16" || denied.RemittanceID == nil {
+		t.Errorf("unexpected denied claim %+v", denied)
+	}
+
+	if _, _, err := f.useCase.IngestRemittance("not an 835", now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected validation error, got %v", err)
+	}
+	if len(f.claimRepo.denials) != 1 {
+		t.Fatalf("expected one denial, got %d", len(f.claimRepo.denials))
+	}
+	if _, err := f.claimRepo.GetOpenDenialByClaim(denied.ID); err != nil {
+		t.Errorf("expected an open denial for the denied claim: %v", err)
+	}
+}
+
+// deniedVisit bills a visit and denies its claim, returning the open denial
+func (f *testFixture) deniedVisit(t *testing.T, checkin time.Time, reason string, deniedAt time.Time) *domainClaim.Denial {
+	visitID := f.addVisit("Personal care", checkin, time.Hour)
+	if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claim := f.claimFor(visitID)
+	if _, err := f.useCase.UpdateClaimStatus(claim.ID, domainClaim.StatusDenied, reason, deniedAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	denial, err := f.claimRepo.GetOpenDenialByClaim(claim.ID)
+	if err != nil {
+		t.Fatalf("expected an open denial: %v", err)
+	}
+	return denial
+}
+
+func TestDenials(t *testing.T) {
+	t.Run("Rebill after correcting the coverage", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		denial := f.deniedVisit(t, time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), "CO-31", now)
+		f.claimRepo.coverages[0].MemberID = "M2"
+
+		resolved, batch, err := f.useCase.RebillDenial(denial.ID, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resolved.Status != domainClaim.DenialRebilled || resolved.RebillClaimID == nil || resolved.ResolvedAt == nil {
+			t.Fatalf("unexpected denial %+v", resolved)
+		}
+		rebill := f.claimRepo.claims[*resolved.RebillClaimID]
+		if rebill.OriginalClaimID == nil || *rebill.OriginalClaimID != denial.ClaimID || rebill.BatchID != batch.ID || rebill.ScheduleID != denial.ScheduleID {
+			t.Errorf("expected the rebill to link back to the denied claim and visit, got %+v", rebill)
+		}
+		if !strings.Contains(batch.Content, "*MI*M2~") {
+			t.Errorf("expected the corrected member id in the 837P")
+		}
+		if f.claimRepo.claims[denial.ClaimID].Status != domainClaim.StatusRebilled {
+			t.Errorf("expected the denied claim to be marked rebilled")
+		}
+		if _, _, err := f.useCase.RebillDenial(denial.ID, now); errorType(err) != domainErrors.Conflict {
+			t.Errorf("expected conflict for a resolved denial, got %v", err)
+		}
+	})
+
+	t.Run("Denied visits are not billed again by a batch", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		f.deniedVisit(t, time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), "CO-16", now)
+		if _, err := f.useCase.GenerateBatch(f.payer.ID, periodFrom, periodTo); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected no billable visits, got %v", err)
+		}
+	})
+
+	t.Run("Write off", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		denial := f.deniedVisit(t, time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), "CO-29", now)
+		if _, err := f.useCase.WriteOffDenial(denial.ID, "", now); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected a note to be required, got %v", err)
+		}
+		resolved, err := f.useCase.WriteOffDenial(denial.ID, "past timely filing", now)
+		if err != nil || resolved.Status != domainClaim.DenialWrittenOff {
+			t.Fatalf("expected written off denial, got %+v (%v)", resolved, err)
+		}
+		if f.claimRepo.claims[denial.ClaimID].Status != domainClaim.StatusWrittenOff {
+			t.Errorf("expected the claim to be written off")
+		}
+	})
+
+	t.Run("Only admins are assigned", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		denial := f.deniedVisit(t, time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), "CO-16", now)
+		if _, err := f.useCase.UpdateDenial(denial.ID, map[string]interface{}{"assigned_user_id": f.client.ID}); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("Aging report", func(t *testing.T) {
+		f := setupTestClaimUseCase(t)
+		f.deniedVisit(t, time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC), "CO-16,CO-197", now.AddDate(0, 0, -10))
+		f.deniedVisit(t, time.Date(2025, 7, 17, 9, 0, 0, 0, time.UTC), "CO-16", now.AddDate(0, 0, -45))
+		f.deniedVisit(t, time.Date(2025, 7, 18, 9, 0, 0, 0, time.UTC), "CO-96", now.AddDate(0, 0, -120))
+
+		report, err := f.useCase.DenialAging(now, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.Total != 3 || report.TotalAmount != 63 {
+			t.Errorf("unexpected totals %d %v", report.Total, report.TotalAmount)
+		}
+		counts := []int{report.Buckets[0].Count, report.Buckets[1].Count, report.Buckets[2].Count, report.Buckets[3].Count}
+		if counts[0] != 1 || counts[1] != 1 || counts[2] != 0 || counts[3] != 1 {
+			t.Errorf("unexpected buckets %v", counts)
+		}
+		top := report.ByReason[0]
+		if top.Code != "CO-16" || top.Count != 2 || top.Description == "" {
+			t.Errorf("expected CO-16 to lead the reasons, got %+v", top)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package clienterror
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	domainClientError "caregiver/src/domain/clienterror"
+	logger "caregiver/src/infrastructure/logger"
+
+	"go.uber.org/zap"
+)
+
+// maxCauseLength keeps free-text rules, such as use case validation
+// messages, from bloating the counts.
+const maxCauseLength = 200
+
+type IClientErrorUseCase interface {
+	// RecordFailure counts one rejected request to the endpoint, once for
+	// each cause. A request without causes is counted by status alone.
+	RecordFailure(method, route string, status int, causes []domainClientError.Cause, at time.Time) error
+	// Report sums up failures per endpoint, optionally for one route and only
+	// failures seen since the given time. Endpoints failing most come first.
+	Report(route string, since *time.Time) ([]domainClientError.EndpointReport, error)
+}
+
+type ClientErrorUseCase struct {
+	clientErrorRepository domainClientError.IClientErrorRepository
+	Logger                *logger.Logger
+}
+
+func NewClientErrorUseCase(clientErrorRepository domainClientError.IClientErrorRepository, loggerInstance *logger.Logger) IClientErrorUseCase {
+	return &ClientErrorUseCase{
+		clientErrorRepository: clientErrorRepository,
+		Logger:                loggerInstance,
+	}
+}
+
+func (u *ClientErrorUseCase) RecordFailure(method, route string, status int, causes []domainClientError.Cause, at time.Time) error {
+	if len(causes) == 0 {
+		causes = []domainClientError.Cause{{}}
+	}
+	for _, cause := range causes {
+		u.Logger.Info("Request rejected", zap.String("method", method), zap.String("route", route), zap.Int("status", status), zap.String("field", cause.Field), zap.String("rule", cause.Rule))
+		err := u.clientErrorRepository.RecordFailure(&domainClientError.Failure{
+			Method:      method,
+			Route:       route,
+			Status:      status,
+			Field:       truncate(cause.Field),
+			Rule:        truncate(cause.Rule),
+			Count:       1,
+			FirstSeenAt: at,
+			LastSeenAt:  at,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *ClientErrorUseCase) Report(route string, since *time.Time) ([]domainClientError.EndpointReport, error) {
+	failures, err := u.clientErrorRepository.GetFailures(domainClientError.FailureFilter{Route: route, Since: since})
+	if err != nil {
+		return nil, err
+	}
+	byEndpoint := make(map[string]*domainClientError.EndpointReport)
+	reports := []*domainClientError.EndpointReport{}
+	for _, failure := range *failures {
+		key := failure.Method + " " + failure.Route
+		report, ok := byEndpoint[key]
+		if !ok {
+			report = &domainClientError.EndpointReport{Method: failure.Method, Route: failure.Route}
+			byEndpoint[key] = report
+			reports = append(reports, report)
+		}
+		report.Count += failure.Count
+		if failure.LastSeenAt.After(report.LastSeenAt) {
+			report.LastSeenAt = failure.LastSeenAt
+		}
+		report.Failures = append(report.Failures, failure)
+	}
+
+	res := make([]domainClientError.EndpointReport, len(reports))
+	for i, report := range reports {
+		sort.SliceStable(report.Failures, func(a, b int) bool {
+			return report.Failures[a].Count > report.Failures[b].Count
+		})
+		res[i] = *report
+	}
+	sort.SliceStable(res, func(a, b int) bool {
+		return res[a].Count > res[b].Count
+	})
+	return res, nil
+}
+
+func truncate(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > maxCauseLength {
+		return value[:maxCauseLength]
+	}
+	return value
+}
@@ -0,0 +1,97 @@
+package clienterror
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	domainClientError "caregiver/src/domain/clienterror"
+	logger "caregiver/src/infrastructure/logger"
+)
+
+// mockClientErrorRepository keeps one record per endpoint, status and cause
+type mockClientErrorRepository struct {
+	failures []domainClientError.Failure
+}
+
+func (m *mockClientErrorRepository) RecordFailure(failure *domainClientError.Failure) error {
+	for i := range m.failures {
+		existing := &m.failures[i]
+		if existing.Method == failure.Method && existing.Route == failure.Route && existing.Status == failure.Status && existing.Field == failure.Field && existing.Rule == failure.Rule {
+			existing.Count += failure.Count
+			existing.LastSeenAt = failure.LastSeenAt
+			return nil
+		}
+	}
+	m.failures = append(m.failures, *failure)
+	return nil
+}
+
+func (m *mockClientErrorRepository) GetFailures(filter domainClientError.FailureFilter) (*[]domainClientError.Failure, error) {
+	res := []domainClientError.Failure{}
+	for _, failure := range m.failures {
+		if (filter.Route != "" && failure.Route != filter.Route) || (filter.Since != nil && failure.LastSeenAt.Before(*filter.Since)) {
+			continue
+		}
+		res = append(res, failure)
+	}
+	return &res, nil
+}
+
+func TestReport(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	repository := &mockClientErrorRepository{}
+	useCase := NewClientErrorUseCase(repository, loggerInstance)
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	missingTimes := domainClientError.Cause{Field: "PreferredTimes", Rule: "required"}
+	requests := []struct {
+		method, route string
+		status        int
+		causes        []domainClientError.Cause
+		at            time.Time
+	}{
+		{"POST", "/v1/booking-requests", 400, []domainClientError.Cause{missingTimes, {Field: "ServiceName", Rule: "required"}}, now.AddDate(0, 0, -10)},
+		{"POST", "/v1/booking-requests", 400, []domainClientError.Cause{missingTimes}, now.AddDate(0, 0, -2)},
+		{"POST", "/v1/booking-requests", 400, []domainClientError.Cause{missingTimes}, now.AddDate(0, 0, -1)},
+		{"GET", "/v1/prospects/:id", 404, nil, now.AddDate(0, 0, -1)},
+	}
+	for _, r := range requests {
+		if err := useCase.RecordFailure(r.method, r.route, r.status, r.causes, r.at); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	reports, err := useCase.Report("", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reports) != 2 || reports[0].Route != "/v1/booking-requests" || reports[0].Count != 4 {
+		t.Fatalf("expected the booking form first with 4 failures, got %+v", reports)
+	}
+	if top := reports[0].Failures[0]; top.Field != "PreferredTimes" || top.Count != 3 {
+		t.Errorf("expected missing preferred times as the most common cause, got %+v", top)
+	}
+	if notFound := reports[1].Failures[0]; notFound.Status != 404 || notFound.Field != "" || notFound.Count != 1 {
+		t.Errorf("expected a cause-less 404 counted by status, got %+v", notFound)
+	}
+
+	since := now.AddDate(0, 0, -3)
+	reports, err = useCase.Report("/v1/booking-requests", &since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reports) != 1 || reports[0].Count != 3 {
+		t.Errorf("expected only recent booking form failures, got %+v", reports)
+	}
+
+	if err := useCase.RecordFailure("PUT", "/v1/users/:id", 400, []domainClientError.Cause{{Rule: strings.Repeat("x", 500)}}, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule := repository.failures[len(repository.failures)-1].Rule; len(rule) != maxCauseLength {
+		t.Errorf("expected long rules truncated, got %d characters", len(rule))
+	}
+}
@@ -0,0 +1,118 @@
+package clientmerge
+
+import (
+	"errors"
+
+	userUseCase "caregiver/src/application/usecases/user"
+	domainClientMerge "caregiver/src/domain/clientmerge"
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IClientMergeUseCase interface {
+	Preview(survivorID, duplicateID uuid.UUID) (*domainClientMerge.Preview, error)
+	Merge(merge *domainClientMerge.Merge) (*domainClientMerge.Merge, error)
+	GetAll() (*[]domainClientMerge.Merge, error)
+	GetByID(id uuid.UUID) (*domainClientMerge.Merge, error)
+}
+
+// MergeGuard can veto a merge, e.g. when either client is under legal hold.
+type MergeGuard interface {
+	BeforeMerge(survivorID, duplicateID uuid.UUID) error
+}
+
+type ClientMergeUseCase struct {
+	clientMergeRepository domainClientMerge.IClientMergeRepository
+	userUseCase           userUseCase.IUserUseCase
+	mergeGuards           []MergeGuard
+	Logger                *logger.Logger
+}
+
+type Option func(*ClientMergeUseCase)
+
+func WithMergeGuards(guards ...MergeGuard) Option {
+	return func(u *ClientMergeUseCase) {
+		u.mergeGuards = append(u.mergeGuards, guards...)
+	}
+}
+
+func NewClientMergeUseCase(clientMergeRepository domainClientMerge.IClientMergeRepository, userUseCase userUseCase.IUserUseCase, loggerInstance *logger.Logger, opts ...Option) IClientMergeUseCase {
+	useCase := &ClientMergeUseCase{
+		clientMergeRepository: clientMergeRepository,
+		userUseCase:           userUseCase,
+		Logger:                loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+// Preview counts what merging the duplicate into the survivor would move.
+func (u *ClientMergeUseCase) Preview(survivorID, duplicateID uuid.UUID) (*domainClientMerge.Preview, error) {
+	if err := u.checkClients(survivorID, duplicateID); err != nil {
+		return nil, err
+	}
+	rows, err := u.clientMergeRepository.Count(duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	return &domainClientMerge.Preview{SurvivorUserID: survivorID, DuplicateUserID: duplicateID, Rows: rows}, nil
+}
+
+// Merge moves the duplicate client's schedules, care records, billing and
+// everything else onto the survivor and tombstones the duplicate.
+func (u *ClientMergeUseCase) Merge(merge *domainClientMerge.Merge) (*domainClientMerge.Merge, error) {
+	u.Logger.Info("Merging clients", zap.String("survivorUserID", merge.SurvivorUserID.String()), zap.String("duplicateUserID", merge.DuplicateUserID.String()))
+	if err := u.checkClients(merge.SurvivorUserID, merge.DuplicateUserID); err != nil {
+		return nil, err
+	}
+	for _, guard := range u.mergeGuards {
+		if err := guard.BeforeMerge(merge.SurvivorUserID, merge.DuplicateUserID); err != nil {
+			return nil, err
+		}
+	}
+	if merge.MergedByUserID != nil {
+		admin, err := u.userUseCase.GetByID(*merge.MergedByUserID)
+		if err != nil {
+			return nil, err
+		}
+		if admin.Role != domainUser.RoleAdmin {
+			return nil, domainErrors.NewAppError(errors.New("only admins can merge clients"), domainErrors.NotAuthorized)
+		}
+	}
+	merge.ID = uuid.New()
+	return u.clientMergeRepository.Merge(merge)
+}
+
+func (u *ClientMergeUseCase) GetAll() (*[]domainClientMerge.Merge, error) {
+	return u.clientMergeRepository.GetAll()
+}
+
+func (u *ClientMergeUseCase) GetByID(id uuid.UUID) (*domainClientMerge.Merge, error) {
+	return u.clientMergeRepository.GetByID(id)
+}
+
+// checkClients makes sure both records are live clients and not the same one.
+func (u *ClientMergeUseCase) checkClients(survivorID, duplicateID uuid.UUID) error {
+	if survivorID == duplicateID {
+		return domainErrors.NewAppError(errors.New("a client cannot be merged into itself"), domainErrors.ValidationError)
+	}
+	for _, id := range []uuid.UUID{survivorID, duplicateID} {
+		client, err := u.userUseCase.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if client.Role != domainUser.RoleClient {
+			return domainErrors.NewAppError(errors.New("only clients can be merged"), domainErrors.ValidationError)
+		}
+		if client.DeletedAt != nil {
+			return domainErrors.NewAppError(errors.New("deleted clients cannot be merged"), domainErrors.ValidationError)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package clientmerge
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	userUseCase "caregiver/src/application/usecases/user"
+	domainClientMerge "caregiver/src/domain/clientmerge"
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockClientMergeRepository moves schedule counts between clients in memory
+type mockClientMergeRepository struct {
+	rows   map[uuid.UUID]int64
+	merges map[uuid.UUID]*domainClientMerge.Merge
+}
+
+func (m *mockClientMergeRepository) Count(clientUserID uuid.UUID) (map[string]int64, error) {
+	return map[string]int64{"schedules": m.rows[clientUserID]}, nil
+}
+
+func (m *mockClientMergeRepository) Merge(merge *domainClientMerge.Merge) (*domainClientMerge.Merge, error) {
+	merge.Moved = map[string]int64{"schedules": m.rows[merge.DuplicateUserID]}
+	m.rows[merge.SurvivorUserID] += m.rows[merge.DuplicateUserID]
+	m.rows[merge.DuplicateUserID] = 0
+	copied := *merge
+	m.merges[merge.ID] = &copied
+	return m.GetByID(merge.ID)
+}
+
+func (m *mockClientMergeRepository) GetAll() (*[]domainClientMerge.Merge, error) {
+	merges := []domainClientMerge.Merge{}
+	for _, merge := range m.merges {
+		merges = append(merges, *merge)
+	}
+	return &merges, nil
+}
+
+func (m *mockClientMergeRepository) GetByID(id uuid.UUID) (*domainClientMerge.Merge, error) {
+	merge, ok := m.merges[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *merge
+	return &copied, nil
+}
+
+// mockUserUseCase knows only the users it was given
+type mockUserUseCase struct {
+	userUseCase.IUserUseCase
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserUseCase) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return user, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestMerge(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	survivor, duplicate, caregiver, deleted, admin := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	deletedAt := time.Now()
+	users := &mockUserUseCase{users: map[uuid.UUID]*domainUser.User{
+		survivor:  {ID: survivor, Role: domainUser.RoleClient},
+		duplicate: {ID: duplicate, Role: domainUser.RoleClient},
+		caregiver: {ID: caregiver, Role: domainUser.RoleCaregiver},
+		deleted:   {ID: deleted, Role: domainUser.RoleClient, DeletedAt: &deletedAt},
+		admin:     {ID: admin, Role: domainUser.RoleAdmin},
+	}}
+	repo := &mockClientMergeRepository{
+		rows:   map[uuid.UUID]int64{survivor: 2, duplicate: 3},
+		merges: map[uuid.UUID]*domainClientMerge.Merge{},
+	}
+	useCase := NewClientMergeUseCase(repo, users, loggerInstance)
+
+	for _, tc := range []struct {
+		name        string
+		merge       domainClientMerge.Merge
+		expectedErr domainErrors.ErrorType
+	}{
+		{"Same client", domainClientMerge.Merge{SurvivorUserID: survivor, DuplicateUserID: survivor}, domainErrors.ValidationError},
+		{"Not a client", domainClientMerge.Merge{SurvivorUserID: survivor, DuplicateUserID: caregiver}, domainErrors.ValidationError},
+		{"Deleted client", domainClientMerge.Merge{SurvivorUserID: survivor, DuplicateUserID: deleted}, domainErrors.ValidationError},
+		{"Unknown client", domainClientMerge.Merge{SurvivorUserID: uuid.New(), DuplicateUserID: duplicate}, domainErrors.NotFound},
+		{"Merged by a caregiver", domainClientMerge.Merge{SurvivorUserID: survivor, DuplicateUserID: duplicate, MergedByUserID: &caregiver}, domainErrors.NotAuthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			merge := tc.merge
+			if _, err := useCase.Merge(&merge); errorType(err) != tc.expectedErr {
+				t.Errorf("expected %s, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+
+	preview, err := useCase.Preview(survivor, duplicate)
+	if err != nil || preview.Rows["schedules"] != 3 {
+		t.Fatalf("expected 3 schedules to move, got %+v, %v", preview, err)
+	}
+	merged, err := useCase.Merge(&domainClientMerge.Merge{SurvivorUserID: survivor, DuplicateUserID: duplicate, MergedByUserID: &admin, Note: "same person"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged.ID == uuid.Nil || merged.Moved["schedules"] != 3 || repo.rows[survivor] != 5 {
+		t.Errorf("expected the schedules moved to the survivor, got %+v", merged)
+	}
+	if all, _ := useCase.GetAll(); len(*all) != 1 {
+		t.Errorf("expected one merge on record, got %d", len(*all))
+	}
+}
@@ -0,0 +1,401 @@
+package compliance
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	domainCompliance "caregiver/src/domain/compliance"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IComplianceUseCase interface {
+	CreateRule(newRule *domainCompliance.Rule) (*domainCompliance.Rule, error)
+	GetRuleByID(id uuid.UUID) (*domainCompliance.Rule, error)
+	GetRules() (*[]domainCompliance.Rule, error)
+	UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainCompliance.Rule, error)
+	DeleteRule(id uuid.UUID) error
+	Report(from, to time.Time, filter domainCompliance.ReportFilter) (*domainCompliance.Report, error)
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+// TeamResolver expands team IDs into the caregivers on those teams.
+type TeamResolver interface {
+	GetMemberIDs(teamIDs []uuid.UUID) ([]uuid.UUID, error)
+}
+
+type ComplianceUseCase struct {
+	complianceRepository domainCompliance.IComplianceRepository
+	scheduleRepository   domainSchedule.IScheduleRepository
+	teamResolver         TeamResolver
+	Logger               *logger.Logger
+}
+
+type Option func(*ComplianceUseCase)
+
+// WithTeamResolver enables scoping the exceptions report to teams.
+func WithTeamResolver(resolver TeamResolver) Option {
+	return func(u *ComplianceUseCase) {
+		u.teamResolver = resolver
+	}
+}
+
+func NewComplianceUseCase(complianceRepository domainCompliance.IComplianceRepository, scheduleRepository domainSchedule.IScheduleRepository, loggerInstance *logger.Logger, opts ...Option) IComplianceUseCase {
+	useCase := &ComplianceUseCase{
+		complianceRepository: complianceRepository,
+		scheduleRepository:   scheduleRepository,
+		Logger:               loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+func (u *ComplianceUseCase) CreateRule(newRule *domainCompliance.Rule) (*domainCompliance.Rule, error) {
+	u.Logger.Info("Creating compliance rule", zap.String("name", newRule.Name), zap.String("type", newRule.Type))
+	if err := validateRule(newRule); err != nil {
+		return nil, err
+	}
+	newRule.ID = uuid.New()
+	newRule.Active = true
+	return u.complianceRepository.CreateRule(newRule)
+}
+
+func (u *ComplianceUseCase) GetRuleByID(id uuid.UUID) (*domainCompliance.Rule, error) {
+	u.Logger.Info("Getting compliance rule by ID", zap.String("id", id.String()))
+	return u.complianceRepository.GetRuleByID(id)
+}
+
+func (u *ComplianceUseCase) GetRules() (*[]domainCompliance.Rule, error) {
+	u.Logger.Info("Getting all compliance rules")
+	return u.complianceRepository.GetRules()
+}
+
+func (u *ComplianceUseCase) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainCompliance.Rule, error) {
+	u.Logger.Info("Updating compliance rule", zap.String("id", id.String()))
+
+	existing, err := u.complianceRepository.GetRuleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["name"].(string); ok {
+		candidate.Name = v
+	}
+	if v, ok := updates["type"].(string); ok {
+		candidate.Type = v
+	}
+	if v, ok := updates["threshold"].(int); ok {
+		candidate.Threshold = v
+	}
+	if err := validateRule(&candidate); err != nil {
+		return nil, err
+	}
+	return u.complianceRepository.UpdateRule(id, updates)
+}
+
+func (u *ComplianceUseCase) DeleteRule(id uuid.UUID) error {
+	u.Logger.Info("Deleting compliance rule", zap.String("id", id.String()))
+	return u.complianceRepository.DeleteRule(id)
+}
+
+// Report lists the exceptions detected in [from, to), optionally for a single
+// rule or the caregivers of some teams, with a per-rule count ordered from
+// most to least frequent.
+func (u *ComplianceUseCase) Report(from, to time.Time, filter domainCompliance.ReportFilter) (*domainCompliance.Report, error) {
+	u.Logger.Info("Building compliance report", zap.Time("from", from), zap.Time("to", to))
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+
+	exceptions, err := u.complianceRepository.GetExceptions(from, to, filter.RuleID)
+	if err != nil {
+		return nil, err
+	}
+	if len(filter.TeamIDs) > 0 {
+		if exceptions, err = u.forTeams(*exceptions, filter.TeamIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	counts := make(map[uuid.UUID]*domainCompliance.RuleSummary)
+	byRule := []domainCompliance.RuleSummary{}
+	for _, exception := range *exceptions {
+		summary, ok := counts[exception.RuleID]
+		if !ok {
+			byRule = append(byRule, domainCompliance.RuleSummary{RuleID: exception.RuleID, RuleName: exception.RuleName})
+			summary = &byRule[len(byRule)-1]
+			counts[exception.RuleID] = summary
+		}
+		summary.Count++
+	}
+	sort.SliceStable(byRule, func(i, j int) bool { return byRule[i].Count > byRule[j].Count })
+
+	return &domainCompliance.Report{
+		From:       from,
+		To:         to,
+		Total:      len(*exceptions),
+		ByRule:     byRule,
+		Exceptions: *exceptions,
+	}, nil
+}
+
+// forTeams keeps the exceptions raised against visits worked by the teams'
+// caregivers.
+func (u *ComplianceUseCase) forTeams(exceptions []domainCompliance.Exception, teamIDs []uuid.UUID) (*[]domainCompliance.Exception, error) {
+	if u.teamResolver == nil {
+		return nil, domainErrors.NewAppError(errors.New("teams are not available"), domainErrors.NotFound)
+	}
+	memberIDs, err := u.teamResolver.GetMemberIDs(teamIDs)
+	if err != nil {
+		u.Logger.Error("Error resolving team members for compliance report", zap.Error(err))
+		return nil, err
+	}
+	members := make(map[uuid.UUID]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		members[id] = true
+	}
+	scoped := []domainCompliance.Exception{}
+	for _, exception := range exceptions {
+		if members[exception.AssignedUserID] {
+			scoped = append(scoped, exception)
+		}
+	}
+	return &scoped, nil
+}
+
+// OnScheduleEvent evaluates scheduling rules whenever a visit is booked,
+// changed or cancelled, and completion rules once a visit is completed.
+func (u *ComplianceUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	schedule := event.Schedule
+	switch event.Type {
+	case domainSchedule.EventCreated, domainSchedule.EventUpdated:
+		u.evaluateScheduling(schedule)
+		u.reevaluateCompanions(schedule)
+		if event.Previous != nil {
+			u.reevaluateCompanions(event.Previous)
+		}
+	case domainSchedule.EventCancelled, domainSchedule.EventDeleted:
+		if err := u.complianceRepository.DeleteExceptionsBySchedule(schedule.ID); err != nil {
+			u.Logger.Error("Error clearing compliance exceptions for cancelled schedule", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		}
+		u.reevaluateCompanions(schedule)
+	case domainSchedule.EventCompleted:
+		// Checkout tasks are applied after the schedule is saved, so reload
+		// to see their final state.
+		completed, err := u.scheduleRepository.GetScheduleByID(schedule.ID)
+		if err != nil {
+			u.Logger.Error("Error loading completed schedule for compliance", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+			return
+		}
+		u.evaluateCompletion(completed)
+	}
+}
+
+func (u *ComplianceUseCase) evaluateScheduling(schedule *domainSchedule.Schedule) {
+	if !isActive(schedule.VisitStatus) {
+		return
+	}
+	rules, err := u.rulesFor(schedule, domainCompliance.StageScheduling)
+	if err != nil {
+		return
+	}
+
+	var exceptions []domainCompliance.Exception
+	for _, rule := range rules {
+		if rule.Type != domainCompliance.RuleMinCaregivers {
+			continue
+		}
+		caregivers, err := u.caregiversWithClient(schedule)
+		if err != nil {
+			u.Logger.Error("Error counting caregivers for compliance", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+			return
+		}
+		if caregivers < rule.Threshold {
+			exceptions = append(exceptions, newException(rule, schedule, domainCompliance.StageScheduling,
+				fmt.Sprintf("%d of %d required caregivers booked", caregivers, rule.Threshold)))
+		}
+	}
+	u.record(schedule, domainCompliance.StageScheduling, exceptions)
+}
+
+func (u *ComplianceUseCase) evaluateCompletion(schedule *domainSchedule.Schedule) {
+	rules, err := u.rulesFor(schedule, domainCompliance.StageCompletion)
+	if err != nil {
+		return
+	}
+
+	var exceptions []domainCompliance.Exception
+	for _, rule := range rules {
+		switch rule.Type {
+		case domainCompliance.RuleMaxStartDelay:
+			if schedule.CheckinTime == nil {
+				continue
+			}
+			delay := schedule.CheckinTime.Sub(schedule.ScheduledSlot.From)
+			if delay > time.Duration(rule.Threshold)*time.Minute {
+				exceptions = append(exceptions, newException(rule, schedule, domainCompliance.StageCompletion,
+					fmt.Sprintf("visit started %d minutes after the scheduled start (limit %d)", int(delay.Minutes()), rule.Threshold)))
+			}
+		case domainCompliance.RuleTasksCompleted:
+			open := 0
+			for _, task := range schedule.Tasks {
+				if task.Done == nil || !*task.Done {
+					open++
+				}
+			}
+			if open > 0 {
+				exceptions = append(exceptions, newException(rule, schedule, domainCompliance.StageCompletion,
+					fmt.Sprintf("%d of %d tasks not completed", open, len(schedule.Tasks))))
+			}
+		case domainCompliance.RuleMinConfidence:
+			if schedule.Confidence == nil || schedule.Confidence.Score >= rule.Threshold {
+				continue
+			}
+			exceptions = append(exceptions, newException(rule, schedule, domainCompliance.StageCompletion,
+				fmt.Sprintf("verification confidence %d below %d%s", schedule.Confidence.Score, rule.Threshold, weakestSignal(schedule.Confidence))))
+		}
+	}
+	u.record(schedule, domainCompliance.StageCompletion, exceptions)
+}
+
+// reevaluateCompanions re-checks the client's other visits around the same
+// slot, since booking or cancelling one caregiver changes their staffing.
+func (u *ComplianceUseCase) reevaluateCompanions(schedule *domainSchedule.Schedule) {
+	companions, err := u.clientSchedulesDuring(schedule)
+	if err != nil {
+		u.Logger.Error("Error loading companion schedules for compliance", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		return
+	}
+	for i := range companions {
+		if companions[i].ID != schedule.ID {
+			u.evaluateScheduling(&companions[i])
+		}
+	}
+}
+
+func (u *ComplianceUseCase) caregiversWithClient(schedule *domainSchedule.Schedule) (int, error) {
+	companions, err := u.clientSchedulesDuring(schedule)
+	if err != nil {
+		return 0, err
+	}
+	caregivers := map[uuid.UUID]bool{schedule.AssignedUserID: true}
+	for _, companion := range companions {
+		caregivers[companion.AssignedUserID] = true
+	}
+	return len(caregivers), nil
+}
+
+func (u *ComplianceUseCase) clientSchedulesDuring(schedule *domainSchedule.Schedule) ([]domainSchedule.Schedule, error) {
+	overlapping, err := u.scheduleRepository.GetActiveSchedulesBetween(schedule.ScheduledSlot.From, schedule.ScheduledSlot.To, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []domainSchedule.Schedule
+	for _, other := range *overlapping {
+		if other.ClientUserID == schedule.ClientUserID {
+			res = append(res, other)
+		}
+	}
+	return res, nil
+}
+
+func (u *ComplianceUseCase) rulesFor(schedule *domainSchedule.Schedule, stage string) ([]domainCompliance.Rule, error) {
+	rules, err := u.complianceRepository.GetActiveRules()
+	if err != nil {
+		u.Logger.Error("Error loading compliance rules", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		return nil, err
+	}
+	var res []domainCompliance.Rule
+	for _, rule := range *rules {
+		if rule.Stage() == stage && rule.AppliesTo(schedule.ServiceName) {
+			res = append(res, rule)
+		}
+	}
+	return res, nil
+}
+
+func (u *ComplianceUseCase) record(schedule *domainSchedule.Schedule, stage string, exceptions []domainCompliance.Exception) {
+	if err := u.complianceRepository.ReplaceExceptions(schedule.ID, stage, exceptions); err != nil {
+		u.Logger.Error("Error recording compliance exceptions", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		return
+	}
+	if len(exceptions) > 0 {
+		u.Logger.Warn("Compliance exceptions detected", zap.String("scheduleID", schedule.ID.String()), zap.String("stage", stage), zap.Int("count", len(exceptions)))
+	}
+}
+
+func newException(rule domainCompliance.Rule, schedule *domainSchedule.Schedule, stage string, message string) domainCompliance.Exception {
+	exception := domainCompliance.Exception{
+		RuleID:         rule.ID,
+		RuleName:       rule.Name,
+		ScheduleID:     schedule.ID,
+		ClientUserID:   schedule.ClientUserID,
+		AssignedUserID: schedule.AssignedUserID,
+		Stage:          stage,
+		Message:        message,
+		DetectedAt:     time.Now().UTC(),
+	}
+	if schedule.Confidence != nil {
+		score := schedule.Confidence.Score
+		exception.VerificationConfidence = &score
+	}
+	return exception
+}
+
+// weakestSignal names the signal that pulled the confidence down most, to
+// point the reviewer at it.
+func weakestSignal(confidence *domainSchedule.Confidence) string {
+	var weakest *domainSchedule.ConfidenceSignal
+	for i := range confidence.Signals {
+		signal := &confidence.Signals[i]
+		if weakest == nil || signal.Weight*(1-signal.Score) > weakest.Weight*(1-weakest.Score) {
+			weakest = signal
+		}
+	}
+	if weakest == nil || weakest.Score == 1 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s: %s)", weakest.Name, weakest.Detail)
+}
+
+func isActive(status string) bool {
+	for _, active := range domainSchedule.ActiveStatuses {
+		if status == active {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRule(r *domainCompliance.Rule) error {
+	if strings.TrimSpace(r.Name) == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	switch r.Type {
+	case domainCompliance.RuleMaxStartDelay:
+		if r.Threshold < 0 {
+			return domainErrors.NewAppError(errors.New("max start delay must not be negative"), domainErrors.ValidationError)
+		}
+	case domainCompliance.RuleMinCaregivers:
+		if r.Threshold < 1 {
+			return domainErrors.NewAppError(errors.New("minimum caregivers must be at least 1"), domainErrors.ValidationError)
+		}
+	case domainCompliance.RuleMinConfidence:
+		if r.Threshold < 0 || r.Threshold > 100 {
+			return domainErrors.NewAppError(errors.New("minimum verification confidence must be between 0 and 100"), domainErrors.ValidationError)
+		}
+	case domainCompliance.RuleTasksCompleted:
+	default:
+		return domainErrors.NewAppError(errors.New("type must be 'max_start_delay', 'min_caregivers', 'tasks_completed' or 'min_verification_confidence'"), domainErrors.ValidationError)
+	}
+	return nil
+}
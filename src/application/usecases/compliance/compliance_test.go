@@ -0,0 +1,265 @@
+package compliance
+
+import (
+	"testing"
+	"time"
+
+	domainCompliance "caregiver/src/domain/compliance"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockComplianceRepository keeps rules and exceptions in memory
+type mockComplianceRepository struct {
+	rules      []domainCompliance.Rule
+	exceptions map[uuid.UUID]map[string][]domainCompliance.Exception
+}
+
+func (m *mockComplianceRepository) CreateRule(newRule *domainCompliance.Rule) (*domainCompliance.Rule, error) {
+	m.rules = append(m.rules, *newRule)
+	return newRule, nil
+}
+
+func (m *mockComplianceRepository) GetRuleByID(id uuid.UUID) (*domainCompliance.Rule, error) {
+	for i := range m.rules {
+		if m.rules[i].ID == id {
+			return &m.rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockComplianceRepository) GetRules() (*[]domainCompliance.Rule, error) {
+	return &m.rules, nil
+}
+
+func (m *mockComplianceRepository) GetActiveRules() (*[]domainCompliance.Rule, error) {
+	res := []domainCompliance.Rule{}
+	for _, r := range m.rules {
+		if r.Active {
+			res = append(res, r)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockComplianceRepository) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainCompliance.Rule, error) {
+	return m.GetRuleByID(id)
+}
+
+func (m *mockComplianceRepository) DeleteRule(id uuid.UUID) error {
+	return nil
+}
+
+func (m *mockComplianceRepository) ReplaceExceptions(scheduleID uuid.UUID, stage string, exceptions []domainCompliance.Exception) error {
+	if m.exceptions[scheduleID] == nil {
+		m.exceptions[scheduleID] = make(map[string][]domainCompliance.Exception)
+	}
+	m.exceptions[scheduleID][stage] = exceptions
+	return nil
+}
+
+func (m *mockComplianceRepository) DeleteExceptionsBySchedule(scheduleID uuid.UUID) error {
+	delete(m.exceptions, scheduleID)
+	return nil
+}
+
+func (m *mockComplianceRepository) GetExceptions(from, to time.Time, ruleID *uuid.UUID) (*[]domainCompliance.Exception, error) {
+	res := []domainCompliance.Exception{}
+	for _, stages := range m.exceptions {
+		for _, exceptions := range stages {
+			for _, e := range exceptions {
+				if ruleID == nil || e.RuleID == *ruleID {
+					res = append(res, e)
+				}
+			}
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockComplianceRepository) count(scheduleID uuid.UUID, stage string) int {
+	return len(m.exceptions[scheduleID][stage])
+}
+
+// mockScheduleRepository serves schedules from an in-memory list
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockScheduleRepository) GetActiveSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		if isActive(s.VisitStatus) && s.Overlaps(from, to) {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+var slotStart = time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+
+func setupTestComplianceUseCase(t *testing.T, rules ...domainCompliance.Rule) (*ComplianceUseCase, *mockComplianceRepository, *mockScheduleRepository) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	complianceRepo := &mockComplianceRepository{rules: rules, exceptions: make(map[uuid.UUID]map[string][]domainCompliance.Exception)}
+	scheduleRepo := &mockScheduleRepository{}
+	return NewComplianceUseCase(complianceRepo, scheduleRepo, loggerInstance).(*ComplianceUseCase), complianceRepo, scheduleRepo
+}
+
+func visit(clientUserID uuid.UUID, serviceName string) domainSchedule.Schedule {
+	return domainSchedule.Schedule{
+		ID:             uuid.New(),
+		ClientUserID:   clientUserID,
+		AssignedUserID: uuid.New(),
+		ServiceName:    serviceName,
+		VisitStatus:    "upcoming",
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: slotStart, To: slotStart.Add(time.Hour)},
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	useCase, _, _ := setupTestComplianceUseCase(t)
+
+	t.Run("Activates valid rule", func(t *testing.T) {
+		created, err := useCase.CreateRule(&domainCompliance.Rule{Name: "Medication on time", Type: domainCompliance.RuleMaxStartDelay, ServiceName: "Medication", Threshold: 30})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !created.Active || created.ID == uuid.Nil {
+			t.Errorf("expected active rule with ID, got %+v", created)
+		}
+	})
+
+	t.Run("Rejects unknown type", func(t *testing.T) {
+		if _, err := useCase.CreateRule(&domainCompliance.Rule{Name: "Unknown", Type: "max_visits"}); err == nil {
+			t.Error("expected validation error, got nil")
+		}
+	})
+
+	t.Run("Rejects zero caregivers", func(t *testing.T) {
+		if _, err := useCase.CreateRule(&domainCompliance.Rule{Name: "Transfers", Type: domainCompliance.RuleMinCaregivers}); err == nil {
+			t.Error("expected validation error, got nil")
+		}
+	})
+}
+
+func TestMinCaregiversRule(t *testing.T) {
+	rule := domainCompliance.Rule{ID: uuid.New(), Name: "Two-person transfer", Type: domainCompliance.RuleMinCaregivers, ServiceName: "transfer", Threshold: 2, Active: true}
+	useCase, complianceRepo, scheduleRepo := setupTestComplianceUseCase(t, rule)
+	clientID := uuid.New()
+
+	first := visit(clientID, "Transfer")
+	scheduleRepo.schedules = []domainSchedule.Schedule{first}
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCreated, Schedule: &first})
+	if complianceRepo.count(first.ID, domainCompliance.StageScheduling) != 1 {
+		t.Fatalf("expected exception for single caregiver transfer")
+	}
+
+	second := visit(clientID, "Transfer")
+	scheduleRepo.schedules = append(scheduleRepo.schedules, second)
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCreated, Schedule: &second})
+	if complianceRepo.count(first.ID, domainCompliance.StageScheduling) != 0 || complianceRepo.count(second.ID, domainCompliance.StageScheduling) != 0 {
+		t.Errorf("expected exceptions to clear once a second caregiver is booked, got %+v", complianceRepo.exceptions)
+	}
+
+	other := visit(clientID, "Meal prep")
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCreated, Schedule: &other})
+	if complianceRepo.count(other.ID, domainCompliance.StageScheduling) != 0 {
+		t.Errorf("expected rule not to apply to other services")
+	}
+
+	scheduleRepo.schedules[1].VisitStatus = "cancelled"
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCancelled, Schedule: &scheduleRepo.schedules[1]})
+	if complianceRepo.count(first.ID, domainCompliance.StageScheduling) != 1 {
+		t.Errorf("expected exception to return after the second caregiver is cancelled")
+	}
+}
+
+func TestCompletionRules(t *testing.T) {
+	lateRule := domainCompliance.Rule{ID: uuid.New(), Name: "Medication on time", Type: domainCompliance.RuleMaxStartDelay, ServiceName: "Medication", Threshold: 30, Active: true}
+	tasksRule := domainCompliance.Rule{ID: uuid.New(), Name: "All tasks done", Type: domainCompliance.RuleTasksCompleted, Active: true}
+	useCase, complianceRepo, scheduleRepo := setupTestComplianceUseCase(t, lateRule, tasksRule)
+
+	done, notDone := true, false
+	checkin := slotStart.Add(45 * time.Minute)
+	late := visit(uuid.New(), "Medication")
+	late.VisitStatus = "completed"
+	late.CheckinTime = &checkin
+	late.Tasks = []domainSchedule.Task{{Done: &done}, {Done: &notDone}}
+	scheduleRepo.schedules = []domainSchedule.Schedule{late}
+
+	// The event carries the schedule as saved before checkout tasks were
+	// applied; the use case must reload it.
+	stale := late
+	stale.Tasks = nil
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCompleted, Schedule: &stale})
+	if got := complianceRepo.count(late.ID, domainCompliance.StageCompletion); got != 2 {
+		t.Fatalf("expected late start and open task exceptions, got %d", got)
+	}
+
+	report, err := useCase.Report(slotStart.Add(-time.Hour), time.Now().Add(time.Hour), domainCompliance.ReportFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Total != 2 || len(report.ByRule) != 2 {
+		t.Errorf("expected 2 exceptions across 2 rules, got %+v", report)
+	}
+
+	if _, err := useCase.Report(slotStart, slotStart, domainCompliance.ReportFilter{}); err == nil {
+		t.Error("expected validation error for empty range, got nil")
+	}
+}
+
+func TestMinConfidenceRule(t *testing.T) {
+	rule := domainCompliance.Rule{ID: uuid.New(), Name: "Verified visits", Type: domainCompliance.RuleMinConfidence, Threshold: 60, Active: true}
+	useCase, complianceRepo, scheduleRepo := setupTestComplianceUseCase(t, rule)
+
+	doubtful := visit(uuid.New(), "Personal care")
+	doubtful.VisitStatus = "completed"
+	doubtful.Confidence = &domainSchedule.Confidence{Score: 40, Signals: []domainSchedule.ConfidenceSignal{
+		{Name: domainSchedule.SignalMethod, Score: 0.6, Weight: 0.3, Detail: "verified by gps"},
+		{Name: domainSchedule.SignalDistance, Score: 0, Weight: 0.35, Detail: "recorded up to 3.10 km from the client's home"},
+	}}
+	confident := visit(uuid.New(), "Personal care")
+	confident.VisitStatus = "completed"
+	confident.Confidence = &domainSchedule.Confidence{Score: 85}
+	unscored := visit(uuid.New(), "Personal care")
+	unscored.VisitStatus = "completed"
+	scheduleRepo.schedules = []domainSchedule.Schedule{doubtful, confident, unscored}
+
+	for i := range scheduleRepo.schedules {
+		useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCompleted, Schedule: &scheduleRepo.schedules[i]})
+	}
+	exceptions := complianceRepo.exceptions[doubtful.ID][domainCompliance.StageCompletion]
+	if len(exceptions) != 1 {
+		t.Fatalf("expected an exception for the doubtful visit, got %+v", complianceRepo.exceptions)
+	}
+	if exceptions[0].VerificationConfidence == nil || *exceptions[0].VerificationConfidence != 40 {
+		t.Errorf("expected the score on the exception, got %v", exceptions[0].VerificationConfidence)
+	}
+	if want := "verification confidence 40 below 60 (distance: recorded up to 3.10 km from the client's home)"; exceptions[0].Message != want {
+		t.Errorf("expected %q, got %q", want, exceptions[0].Message)
+	}
+	if complianceRepo.count(confident.ID, domainCompliance.StageCompletion) != 0 || complianceRepo.count(unscored.ID, domainCompliance.StageCompletion) != 0 {
+		t.Errorf("expected no exception for confident or unscored visits")
+	}
+
+	if _, err := useCase.CreateRule(&domainCompliance.Rule{Name: "Impossible", Type: domainCompliance.RuleMinConfidence, Threshold: 101}); err == nil {
+		t.Error("expected validation error for a threshold above 100, got nil")
+	}
+}
@@ -0,0 +1,282 @@
+package confirmation
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	domainBranding "caregiver/src/domain/branding"
+	domainConfirmation "caregiver/src/domain/confirmation"
+	domainConsent "caregiver/src/domain/consent"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const tokenBytes = 32
+
+type IConfirmationUseCase interface {
+	IssueLink(scheduleID uuid.UUID) (*domainConfirmation.Confirmation, string, error)
+	IssueFamilyLink(scheduleID, consentID uuid.UUID) (*domainConfirmation.Confirmation, string, error)
+	GetByToken(token string) (*domainConfirmation.Confirmation, *domainSchedule.Schedule, error)
+	Confirm(token string, respondedBy string) (*domainConfirmation.Confirmation, error)
+	Decline(token string, decline domainConfirmation.Decline) (*domainConfirmation.ChangeRequest, error)
+	GetChangeRequests(status string) (*[]domainConfirmation.ChangeRequest, error)
+	ResolveChangeRequest(id uuid.UUID, status string, resolution string, resolvedByUserID uuid.UUID) (*domainConfirmation.ChangeRequest, error)
+	PortalBranding() (*domainBranding.Branding, error)
+}
+
+// ConsentChecker confirms a client still allows a family member to see their
+// visits.
+type ConsentChecker interface {
+	Require(consentID, clientUserID uuid.UUID, scope string, at time.Time) (*domainConsent.Consent, error)
+}
+
+// BrandingSource supplies the agency look the portal is shown in.
+type BrandingSource interface {
+	Get() (*domainBranding.Branding, error)
+}
+
+type ConfirmationUseCase struct {
+	confirmationRepository domainConfirmation.IConfirmationRepository
+	scheduleUseCase        scheduleUseCase.IScheduleUseCase
+	consents               ConsentChecker
+	branding               BrandingSource
+	Logger                 *logger.Logger
+	now                    func() time.Time
+}
+
+type Option func(*ConfirmationUseCase)
+
+// WithConsents enables family links, which are only honoured while the
+// client's consent for that family member covers their visits.
+func WithConsents(consents ConsentChecker) Option {
+	return func(u *ConfirmationUseCase) {
+		u.consents = consents
+	}
+}
+
+// WithBranding shows the portal in the agency's branding.
+func WithBranding(source BrandingSource) Option {
+	return func(u *ConfirmationUseCase) {
+		u.branding = source
+	}
+}
+
+func NewConfirmationUseCase(confirmationRepository domainConfirmation.IConfirmationRepository, scheduleUseCase scheduleUseCase.IScheduleUseCase, loggerInstance *logger.Logger, opts ...Option) IConfirmationUseCase {
+	useCase := &ConfirmationUseCase{
+		confirmationRepository: confirmationRepository,
+		scheduleUseCase:        scheduleUseCase,
+		Logger:                 loggerInstance,
+		now:                    time.Now,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+// IssueLink creates a confirmation link for an upcoming visit. The raw token
+// is returned only here; the link expires when the visit is due to start.
+func (u *ConfirmationUseCase) IssueLink(scheduleID uuid.UUID) (*domainConfirmation.Confirmation, string, error) {
+	return u.issue(scheduleID, nil)
+}
+
+// IssueFamilyLink creates a confirmation link for a family member, who must
+// hold the client's consent to see their visits.
+func (u *ConfirmationUseCase) IssueFamilyLink(scheduleID, consentID uuid.UUID) (*domainConfirmation.Confirmation, string, error) {
+	return u.issue(scheduleID, &consentID)
+}
+
+func (u *ConfirmationUseCase) issue(scheduleID uuid.UUID, consentID *uuid.UUID) (*domainConfirmation.Confirmation, string, error) {
+	u.Logger.Info("Issuing visit confirmation link", zap.String("scheduleID", scheduleID.String()))
+
+	schedule, err := u.scheduleUseCase.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, "", err
+	}
+	if schedule.VisitStatus != "upcoming" || !schedule.ScheduledSlot.From.After(u.now()) {
+		return nil, "", domainErrors.NewAppError(errors.New("only upcoming visits can be confirmed by the client"), domainErrors.ValidationError)
+	}
+	if consentID != nil {
+		if err := u.checkConsent(*consentID, schedule.ClientUserID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		u.Logger.Error("Error generating confirmation token", zap.Error(err))
+		return nil, "", domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	created, err := u.confirmationRepository.Create(&domainConfirmation.Confirmation{
+		ID:           uuid.New(),
+		ScheduleID:   schedule.ID,
+		ClientUserID: schedule.ClientUserID,
+		ConsentID:    consentID,
+		TokenHash:    hashToken(token),
+		Status:       domainConfirmation.StatusPending,
+		ExpiresAt:    schedule.ScheduledSlot.From,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return created, token, nil
+}
+
+// GetByToken resolves a portal token to its confirmation and visit. A family
+// link is refused once the consent behind it no longer covers visits.
+func (u *ConfirmationUseCase) GetByToken(token string) (*domainConfirmation.Confirmation, *domainSchedule.Schedule, error) {
+	confirmation, err := u.confirmationRepository.GetByTokenHash(hashToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+	if confirmation.ConsentID != nil {
+		if err := u.checkConsent(*confirmation.ConsentID, confirmation.ClientUserID); err != nil {
+			return nil, nil, err
+		}
+	}
+	schedule, err := u.scheduleUseCase.GetScheduleByID(confirmation.ScheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return confirmation, schedule, nil
+}
+
+func (u *ConfirmationUseCase) Confirm(token string, respondedBy string) (*domainConfirmation.Confirmation, error) {
+	confirmation, _, err := u.respondable(token)
+	if err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Client confirmed visit", zap.String("scheduleID", confirmation.ScheduleID.String()))
+	return u.confirmationRepository.Update(confirmation.ID, map[string]interface{}{
+		"status":       domainConfirmation.StatusConfirmed,
+		"responded_by": strings.TrimSpace(respondedBy),
+		"responded_at": u.now().UTC(),
+	})
+}
+
+// Decline records the client's refusal and queues a change request for the
+// coordinators. When asked to, it also cancels the visit to free the
+// caregiver's slot.
+func (u *ConfirmationUseCase) Decline(token string, decline domainConfirmation.Decline) (*domainConfirmation.ChangeRequest, error) {
+	confirmation, schedule, err := u.respondable(token)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(decline.Reason) == "" {
+		return nil, domainErrors.NewAppError(errors.New("reason is required when declining a visit"), domainErrors.ValidationError)
+	}
+	if decline.PreferredFrom != nil && decline.PreferredTo != nil && !decline.PreferredFrom.Before(*decline.PreferredTo) {
+		return nil, domainErrors.NewAppError(errors.New("preferred from must be before preferred to"), domainErrors.ValidationError)
+	}
+
+	u.Logger.Info("Client declined visit", zap.String("scheduleID", schedule.ID.String()), zap.Bool("releaseSlot", decline.ReleaseSlot))
+	now := u.now().UTC()
+	if _, err := u.confirmationRepository.Update(confirmation.ID, map[string]interface{}{
+		"status":       domainConfirmation.StatusDeclined,
+		"responded_by": strings.TrimSpace(decline.RespondedBy),
+		"responded_at": now,
+	}); err != nil {
+		return nil, err
+	}
+
+	if decline.ReleaseSlot {
+		if _, err := u.scheduleUseCase.UpdateSchedule(schedule.ID, map[string]interface{}{"visit_status": "cancelled"}); err != nil {
+			u.Logger.Error("Error releasing declined visit", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+			return nil, err
+		}
+	}
+
+	return u.confirmationRepository.CreateChangeRequest(&domainConfirmation.ChangeRequest{
+		ID:             uuid.New(),
+		ScheduleID:     schedule.ID,
+		ClientUserID:   schedule.ClientUserID,
+		ConfirmationID: confirmation.ID,
+		RequestedBy:    strings.TrimSpace(decline.RespondedBy),
+		Reason:         strings.TrimSpace(decline.Reason),
+		PreferredFrom:  decline.PreferredFrom,
+		PreferredTo:    decline.PreferredTo,
+		SlotReleased:   decline.ReleaseSlot,
+		Status:         domainConfirmation.RequestOpen,
+	})
+}
+
+func (u *ConfirmationUseCase) GetChangeRequests(status string) (*[]domainConfirmation.ChangeRequest, error) {
+	u.Logger.Info("Getting change requests", zap.String("status", status))
+	return u.confirmationRepository.GetChangeRequests(status)
+}
+
+func (u *ConfirmationUseCase) ResolveChangeRequest(id uuid.UUID, status string, resolution string, resolvedByUserID uuid.UUID) (*domainConfirmation.ChangeRequest, error) {
+	u.Logger.Info("Resolving change request", zap.String("id", id.String()), zap.String("status", status))
+	if status != domainConfirmation.RequestResolved && status != domainConfirmation.RequestDismissed {
+		return nil, domainErrors.NewAppError(errors.New("status must be 'resolved' or 'dismissed'"), domainErrors.ValidationError)
+	}
+	request, err := u.confirmationRepository.GetChangeRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domainConfirmation.RequestOpen {
+		return nil, domainErrors.NewAppError(errors.New("change request is already closed"), domainErrors.Conflict)
+	}
+	return u.confirmationRepository.UpdateChangeRequest(id, map[string]interface{}{
+		"status":              status,
+		"resolution":          strings.TrimSpace(resolution),
+		"resolved_by_user_id": resolvedByUserID,
+		"resolved_at":         u.now().UTC(),
+	})
+}
+
+// respondable loads the confirmation behind a token and checks that the
+// client may still answer it.
+// PortalBranding returns the agency branding for portal pages, empty when
+// branding is not enabled.
+func (u *ConfirmationUseCase) PortalBranding() (*domainBranding.Branding, error) {
+	if u.branding == nil {
+		return &domainBranding.Branding{}, nil
+	}
+	return u.branding.Get()
+}
+
+func (u *ConfirmationUseCase) respondable(token string) (*domainConfirmation.Confirmation, *domainSchedule.Schedule, error) {
+	confirmation, schedule, err := u.GetByToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if confirmation.Status != domainConfirmation.StatusPending {
+		return nil, nil, domainErrors.NewAppError(errors.New("visit has already been "+confirmation.Status), domainErrors.Conflict)
+	}
+	if !u.now().Before(confirmation.ExpiresAt) || schedule.VisitStatus != "upcoming" {
+		return nil, nil, domainErrors.NewAppError(errors.New("confirmation link has expired"), domainErrors.ValidationError)
+	}
+	return confirmation, schedule, nil
+}
+
+func (u *ConfirmationUseCase) checkConsent(consentID, clientUserID uuid.UUID) error {
+	if u.consents == nil {
+		return domainErrors.NewAppError(errors.New("family links are not enabled"), domainErrors.NotAuthorized)
+	}
+	_, err := u.consents.Require(consentID, clientUserID, domainConsent.ScopeVisits, u.now())
+	return err
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,297 @@
+package confirmation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	domainConfirmation "caregiver/src/domain/confirmation"
+	domainConsent "caregiver/src/domain/consent"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockConfirmationRepository keeps confirmations and change requests in memory
+type mockConfirmationRepository struct {
+	confirmations  []domainConfirmation.Confirmation
+	changeRequests []domainConfirmation.ChangeRequest
+}
+
+func (m *mockConfirmationRepository) Create(newConfirmation *domainConfirmation.Confirmation) (*domainConfirmation.Confirmation, error) {
+	m.confirmations = append(m.confirmations, *newConfirmation)
+	return newConfirmation, nil
+}
+
+func (m *mockConfirmationRepository) GetByTokenHash(tokenHash string) (*domainConfirmation.Confirmation, error) {
+	for i := range m.confirmations {
+		if m.confirmations[i].TokenHash == tokenHash {
+			c := m.confirmations[i]
+			return &c, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockConfirmationRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainConfirmation.Confirmation, error) {
+	for i := range m.confirmations {
+		if m.confirmations[i].ID == id {
+			if v, ok := updates["status"].(string); ok {
+				m.confirmations[i].Status = v
+			}
+			if v, ok := updates["responded_at"].(time.Time); ok {
+				m.confirmations[i].RespondedAt = &v
+			}
+			c := m.confirmations[i]
+			return &c, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockConfirmationRepository) CreateChangeRequest(newRequest *domainConfirmation.ChangeRequest) (*domainConfirmation.ChangeRequest, error) {
+	m.changeRequests = append(m.changeRequests, *newRequest)
+	return newRequest, nil
+}
+
+func (m *mockConfirmationRepository) GetChangeRequestByID(id uuid.UUID) (*domainConfirmation.ChangeRequest, error) {
+	for i := range m.changeRequests {
+		if m.changeRequests[i].ID == id {
+			return &m.changeRequests[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockConfirmationRepository) GetChangeRequests(status string) (*[]domainConfirmation.ChangeRequest, error) {
+	return &m.changeRequests, nil
+}
+
+func (m *mockConfirmationRepository) UpdateChangeRequest(id uuid.UUID, updates map[string]interface{}) (*domainConfirmation.ChangeRequest, error) {
+	request, err := m.GetChangeRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	request.Status = updates["status"].(string)
+	return request, nil
+}
+
+// mockScheduleUseCase serves a single schedule and records updates
+type mockScheduleUseCase struct {
+	scheduleUseCase.IScheduleUseCase
+	schedule *domainSchedule.Schedule
+	updates  map[string]interface{}
+}
+
+func (m *mockScheduleUseCase) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	if m.schedule.ID != id {
+		return nil, errors.New("schedule not found")
+	}
+	return m.schedule, nil
+}
+
+func (m *mockScheduleUseCase) UpdateSchedule(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+	m.updates = updates
+	if v, ok := updates["visit_status"].(string); ok {
+		m.schedule.VisitStatus = v
+	}
+	return m.schedule, nil
+}
+
+// mockConsentChecker allows the consents it holds while they cover the scope
+type mockConsentChecker struct {
+	consents map[uuid.UUID]*domainConsent.Consent
+}
+
+func (m *mockConsentChecker) Require(consentID, clientUserID uuid.UUID, scope string, at time.Time) (*domainConsent.Consent, error) {
+	consent, ok := m.consents[consentID]
+	if !ok || consent.ClientUserID != clientUserID || !consent.Covers(scope, at) {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotAuthorized)
+	}
+	return consent, nil
+}
+
+var now = time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC)
+
+func setupTestConfirmationUseCase(t *testing.T) (*ConfirmationUseCase, *mockConfirmationRepository, *mockScheduleUseCase) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	repo := &mockConfirmationRepository{}
+	schedules := &mockScheduleUseCase{schedule: &domainSchedule.Schedule{
+		ID:            uuid.New(),
+		ClientUserID:  uuid.New(),
+		VisitStatus:   "upcoming",
+		ScheduledSlot: domainSchedule.ScheduledSlot{From: now.Add(24 * time.Hour), To: now.Add(25 * time.Hour)},
+	}}
+	useCase := NewConfirmationUseCase(repo, schedules, loggerInstance).(*ConfirmationUseCase)
+	useCase.now = func() time.Time { return now }
+	return useCase, repo, schedules
+}
+
+func TestIssueLink(t *testing.T) {
+	useCase, repo, schedules := setupTestConfirmationUseCase(t)
+
+	confirmation, token, err := useCase.IssueLink(schedules.schedule.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token == "" || confirmation.TokenHash == token || repo.confirmations[0].TokenHash != hashToken(token) {
+		t.Errorf("expected only the token hash to be stored")
+	}
+	if !confirmation.ExpiresAt.Equal(schedules.schedule.ScheduledSlot.From) {
+		t.Errorf("expected link to expire at visit start, got %v", confirmation.ExpiresAt)
+	}
+
+	schedules.schedule.VisitStatus = "completed"
+	if _, _, err := useCase.IssueLink(schedules.schedule.ID); err == nil {
+		t.Error("expected error for non-upcoming visit, got nil")
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	useCase, _, schedules := setupTestConfirmationUseCase(t)
+	_, token, _ := useCase.IssueLink(schedules.schedule.ID)
+
+	confirmation, err := useCase.Confirm(token, "Daughter")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if confirmation.Status != domainConfirmation.StatusConfirmed {
+		t.Errorf("expected confirmed, got %s", confirmation.Status)
+	}
+
+	if _, err := useCase.Confirm(token, "Daughter"); err == nil {
+		t.Error("expected error when answering twice, got nil")
+	}
+	if _, err := useCase.Confirm("unknown-token", ""); err == nil {
+		t.Error("expected error for unknown token, got nil")
+	}
+}
+
+func TestDecline(t *testing.T) {
+	t.Run("Queues change request and releases slot", func(t *testing.T) {
+		useCase, repo, schedules := setupTestConfirmationUseCase(t)
+		_, token, _ := useCase.IssueLink(schedules.schedule.ID)
+
+		request, err := useCase.Decline(token, domainConfirmation.Decline{RespondedBy: "Client", Reason: "Hospital appointment", ReleaseSlot: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if request.Status != domainConfirmation.RequestOpen || !request.SlotReleased {
+			t.Errorf("expected open change request with released slot, got %+v", request)
+		}
+		if schedules.updates["visit_status"] != "cancelled" {
+			t.Errorf("expected visit to be cancelled, got %v", schedules.updates)
+		}
+		if repo.confirmations[0].Status != domainConfirmation.StatusDeclined {
+			t.Errorf("expected confirmation to be declined, got %s", repo.confirmations[0].Status)
+		}
+	})
+
+	t.Run("Keeps slot by default", func(t *testing.T) {
+		useCase, _, schedules := setupTestConfirmationUseCase(t)
+		_, token, _ := useCase.IssueLink(schedules.schedule.ID)
+
+		if _, err := useCase.Decline(token, domainConfirmation.Decline{Reason: "Please come later"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if schedules.updates != nil {
+			t.Errorf("expected schedule to be untouched, got %v", schedules.updates)
+		}
+	})
+
+	t.Run("Requires reason", func(t *testing.T) {
+		useCase, _, schedules := setupTestConfirmationUseCase(t)
+		_, token, _ := useCase.IssueLink(schedules.schedule.ID)
+
+		if _, err := useCase.Decline(token, domainConfirmation.Decline{Reason: " "}); err == nil {
+			t.Error("expected validation error, got nil")
+		}
+	})
+
+	t.Run("Expired link", func(t *testing.T) {
+		useCase, _, schedules := setupTestConfirmationUseCase(t)
+		_, token, _ := useCase.IssueLink(schedules.schedule.ID)
+		useCase.now = func() time.Time { return schedules.schedule.ScheduledSlot.From }
+
+		if _, err := useCase.Decline(token, domainConfirmation.Decline{Reason: "Too late"}); err == nil {
+			t.Error("expected expired link error, got nil")
+		}
+	})
+}
+
+func TestResolveChangeRequest(t *testing.T) {
+	useCase, _, schedules := setupTestConfirmationUseCase(t)
+	_, token, _ := useCase.IssueLink(schedules.schedule.ID)
+	request, _ := useCase.Decline(token, domainConfirmation.Decline{Reason: "Away"})
+
+	if _, err := useCase.ResolveChangeRequest(request.ID, "open", "", uuid.New()); err == nil {
+		t.Error("expected validation error for invalid status, got nil")
+	}
+	resolved, err := useCase.ResolveChangeRequest(request.ID, domainConfirmation.RequestResolved, "Moved to Friday", uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.Status != domainConfirmation.RequestResolved {
+		t.Errorf("expected resolved, got %s", resolved.Status)
+	}
+	if _, err := useCase.ResolveChangeRequest(request.ID, domainConfirmation.RequestDismissed, "", uuid.New()); err == nil {
+		t.Error("expected error when resolving a closed request, got nil")
+	}
+}
+
+func TestFamilyLinkRequiresConsent(t *testing.T) {
+	useCase, _, schedules := setupTestConfirmationUseCase(t)
+	consent := &domainConsent.Consent{
+		ID:           uuid.New(),
+		ClientUserID: schedules.schedule.ClientUserID,
+		Scopes:       []string{domainConsent.ScopeVisits},
+		Status:       domainConsent.StatusActive,
+	}
+	billingOnly := &domainConsent.Consent{
+		ID:           uuid.New(),
+		ClientUserID: schedules.schedule.ClientUserID,
+		Scopes:       []string{domainConsent.ScopeBilling},
+		Status:       domainConsent.StatusActive,
+	}
+
+	if _, _, err := useCase.IssueFamilyLink(schedules.schedule.ID, consent.ID); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected family links to be refused without consents enabled, got %v", err)
+	}
+
+	WithConsents(&mockConsentChecker{consents: map[uuid.UUID]*domainConsent.Consent{consent.ID: consent, billingOnly.ID: billingOnly}})(useCase)
+	if _, _, err := useCase.IssueFamilyLink(schedules.schedule.ID, billingOnly.ID); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected a consent without the visits scope to be refused, got %v", err)
+	}
+	confirmation, token, err := useCase.IssueFamilyLink(schedules.schedule.ID, consent.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if confirmation.ConsentID == nil || *confirmation.ConsentID != consent.ID {
+		t.Errorf("expected the link to record its consent, got %+v", confirmation.ConsentID)
+	}
+	if _, _, err := useCase.GetByToken(token); err != nil {
+		t.Errorf("expected the family member to see the visit, got %v", err)
+	}
+
+	consent.Status = domainConsent.StatusWithdrawn
+	if _, _, err := useCase.GetByToken(token); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected a withdrawn consent to close the portal, got %v", err)
+	}
+	if _, err := useCase.Confirm(token, "Daughter"); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected a withdrawn consent to block confirming, got %v", err)
+	}
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
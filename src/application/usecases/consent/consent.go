@@ -0,0 +1,222 @@
+package consent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainClaim "caregiver/src/domain/claim"
+	domainConsent "caregiver/src/domain/consent"
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var validScopes = map[string]bool{
+	domainConsent.ScopeVisits:  true,
+	domainConsent.ScopeBilling: true,
+	domainConsent.ScopeHealth:  true,
+}
+
+type IConsentUseCase interface {
+	Grant(newConsent *domainConsent.Consent, now time.Time) (*domainConsent.Consent, error)
+	// Revise replaces an active consent with a new version carrying the
+	// changed terms. Empty fields on the revision keep the current terms.
+	Revise(id uuid.UUID, revision *domainConsent.Consent, now time.Time) (*domainConsent.Consent, error)
+	Withdraw(id uuid.UUID, reason string, now time.Time) (*domainConsent.Consent, error)
+	GetByID(id uuid.UUID) (*domainConsent.Consent, error)
+	GetConsents(filter domainConsent.ConsentFilter) (*[]domainConsent.Consent, error)
+	// History returns every version of the consent's series, newest first.
+	History(id uuid.UUID) (*[]domainConsent.Consent, error)
+	// Require returns the consent if it belongs to the client and currently
+	// permits sharing scope, and a NotAuthorized error otherwise.
+	Require(consentID, clientUserID uuid.UUID, scope string, at time.Time) (*domainConsent.Consent, error)
+	// ConsentedClients returns the clients who currently permit sharing scope
+	// with the payer.
+	ConsentedClients(payerID uuid.UUID, scope string, at time.Time) (map[uuid.UUID]bool, error)
+}
+
+type ConsentUseCase struct {
+	consentRepository domainConsent.IConsentRepository
+	userRepository    domainUser.IUserRepository
+	claimRepository   domainClaim.IClaimRepository
+	Logger            *logger.Logger
+}
+
+func NewConsentUseCase(consentRepository domainConsent.IConsentRepository, userRepository domainUser.IUserRepository, claimRepository domainClaim.IClaimRepository, loggerInstance *logger.Logger) IConsentUseCase {
+	return &ConsentUseCase{
+		consentRepository: consentRepository,
+		userRepository:    userRepository,
+		claimRepository:   claimRepository,
+		Logger:            loggerInstance,
+	}
+}
+
+func (u *ConsentUseCase) Grant(newConsent *domainConsent.Consent, now time.Time) (*domainConsent.Consent, error) {
+	u.Logger.Info("Granting consent", zap.String("clientUserID", newConsent.ClientUserID.String()), zap.String("recipientType", newConsent.RecipientType))
+	client, err := u.userRepository.GetByID(newConsent.ClientUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("client not found"), domainErrors.NotFound)
+	}
+	if client.Role != domainUser.RoleClient {
+		return nil, domainErrors.NewAppError(errors.New("the user is not a client"), domainErrors.ValidationError)
+	}
+	switch newConsent.RecipientType {
+	case domainConsent.RecipientFamily:
+		newConsent.PayerID = nil
+		if strings.TrimSpace(newConsent.RecipientName) == "" {
+			return nil, domainErrors.NewAppError(errors.New("recipient name is required for a family member"), domainErrors.ValidationError)
+		}
+	case domainConsent.RecipientPayer:
+		if newConsent.PayerID == nil {
+			return nil, domainErrors.NewAppError(errors.New("payer id is required for a payer"), domainErrors.ValidationError)
+		}
+		payer, err := u.claimRepository.GetPayerByID(*newConsent.PayerID)
+		if err != nil {
+			return nil, domainErrors.NewAppError(errors.New("payer not found"), domainErrors.NotFound)
+		}
+		if strings.TrimSpace(newConsent.RecipientName) == "" {
+			newConsent.RecipientName = payer.Name
+		}
+	default:
+		return nil, domainErrors.NewAppError(errors.New("recipient type must be 'family' or 'payer'"), domainErrors.ValidationError)
+	}
+	if err := u.validateTerms(newConsent, now); err != nil {
+		return nil, err
+	}
+
+	newConsent.ID = uuid.New()
+	newConsent.SeriesID = newConsent.ID
+	newConsent.Version = 1
+	newConsent.Status = domainConsent.StatusActive
+	newConsent.GrantedAt = now
+	newConsent.WithdrawnAt = nil
+	newConsent.WithdrawnReason = ""
+	return u.consentRepository.Create(newConsent)
+}
+
+func (u *ConsentUseCase) Revise(id uuid.UUID, revision *domainConsent.Consent, now time.Time) (*domainConsent.Consent, error) {
+	u.Logger.Info("Revising consent", zap.String("id", id.String()))
+	current, err := u.consentRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != domainConsent.StatusActive {
+		return nil, domainErrors.NewAppError(fmt.Errorf("a %s consent cannot be revised", current.Status), domainErrors.Conflict)
+	}
+
+	next := *current
+	if strings.TrimSpace(revision.RecipientName) != "" {
+		next.RecipientName = revision.RecipientName
+	}
+	if revision.RecipientContact != "" {
+		next.RecipientContact = revision.RecipientContact
+	}
+	if revision.Scopes != nil {
+		next.Scopes = revision.Scopes
+	}
+	if revision.ExpiresAt != nil {
+		next.ExpiresAt = revision.ExpiresAt
+	}
+	if revision.GrantedBy != "" {
+		next.GrantedBy = revision.GrantedBy
+	}
+	if err := u.validateTerms(&next, now); err != nil {
+		return nil, err
+	}
+	next.ID = uuid.New()
+	next.Version = current.Version + 1
+	next.GrantedAt = now
+	return u.consentRepository.Supersede(current.ID, &next)
+}
+
+// Withdraw ends a consent. Anything relying on it, such as family portal
+// links, stops working straight away.
+func (u *ConsentUseCase) Withdraw(id uuid.UUID, reason string, now time.Time) (*domainConsent.Consent, error) {
+	u.Logger.Info("Withdrawing consent", zap.String("id", id.String()))
+	current, err := u.consentRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != domainConsent.StatusActive {
+		return nil, domainErrors.NewAppError(fmt.Errorf("a %s consent cannot be withdrawn", current.Status), domainErrors.Conflict)
+	}
+	return u.consentRepository.Update(id, map[string]interface{}{
+		"status":           domainConsent.StatusWithdrawn,
+		"withdrawn_at":     now,
+		"withdrawn_reason": strings.TrimSpace(reason),
+	})
+}
+
+func (u *ConsentUseCase) GetByID(id uuid.UUID) (*domainConsent.Consent, error) {
+	return u.consentRepository.GetByID(id)
+}
+
+func (u *ConsentUseCase) GetConsents(filter domainConsent.ConsentFilter) (*[]domainConsent.Consent, error) {
+	return u.consentRepository.GetAll(filter)
+}
+
+func (u *ConsentUseCase) History(id uuid.UUID) (*[]domainConsent.Consent, error) {
+	current, err := u.consentRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.consentRepository.GetAll(domainConsent.ConsentFilter{SeriesID: &current.SeriesID})
+}
+
+func (u *ConsentUseCase) Require(consentID, clientUserID uuid.UUID, scope string, at time.Time) (*domainConsent.Consent, error) {
+	consent, err := u.consentRepository.GetByID(consentID)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+			return nil, domainErrors.NewAppError(errors.New("the client has not consented to sharing this data"), domainErrors.NotAuthorized)
+		}
+		return nil, err
+	}
+	if consent.ClientUserID != clientUserID || !consent.Covers(scope, at) {
+		u.Logger.Warn("Data sharing blocked by consent", zap.String("consentID", consentID.String()), zap.String("scope", scope), zap.String("status", consent.Status))
+		return nil, domainErrors.NewAppError(errors.New("the client has not consented to sharing this data"), domainErrors.NotAuthorized)
+	}
+	return consent, nil
+}
+
+func (u *ConsentUseCase) ConsentedClients(payerID uuid.UUID, scope string, at time.Time) (map[uuid.UUID]bool, error) {
+	consents, err := u.consentRepository.GetAll(domainConsent.ConsentFilter{PayerID: &payerID, Status: domainConsent.StatusActive})
+	if err != nil {
+		return nil, err
+	}
+	clients := make(map[uuid.UUID]bool)
+	for _, consent := range *consents {
+		if consent.RecipientType == domainConsent.RecipientPayer && consent.Covers(scope, at) {
+			clients[consent.ClientUserID] = true
+		}
+	}
+	return clients, nil
+}
+
+// validateTerms checks the scopes and expiry, removing duplicate scopes.
+func (u *ConsentUseCase) validateTerms(consent *domainConsent.Consent, now time.Time) error {
+	if len(consent.Scopes) == 0 {
+		return domainErrors.NewAppError(errors.New("at least one scope is required"), domainErrors.ValidationError)
+	}
+	seen := make(map[string]bool)
+	scopes := []string{}
+	for _, scope := range consent.Scopes {
+		if !validScopes[scope] {
+			return domainErrors.NewAppError(fmt.Errorf("unknown scope %q: must be 'visits', 'billing' or 'health'", scope), domainErrors.ValidationError)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	consent.Scopes = scopes
+	if consent.ExpiresAt != nil && !consent.ExpiresAt.After(now) {
+		return domainErrors.NewAppError(errors.New("expiry must be in the future"), domainErrors.ValidationError)
+	}
+	return nil
+}
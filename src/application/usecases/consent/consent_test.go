@@ -0,0 +1,226 @@
+package consent
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainClaim "caregiver/src/domain/claim"
+	domainConsent "caregiver/src/domain/consent"
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockConsentRepository keeps consents in memory
+type mockConsentRepository struct {
+	consents []domainConsent.Consent
+}
+
+func (m *mockConsentRepository) Create(newConsent *domainConsent.Consent) (*domainConsent.Consent, error) {
+	m.consents = append(m.consents, *newConsent)
+	return newConsent, nil
+}
+
+func (m *mockConsentRepository) GetByID(id uuid.UUID) (*domainConsent.Consent, error) {
+	for i := range m.consents {
+		if m.consents[i].ID == id {
+			copied := m.consents[i]
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockConsentRepository) GetAll(filter domainConsent.ConsentFilter) (*[]domainConsent.Consent, error) {
+	res := []domainConsent.Consent{}
+	for i := len(m.consents) - 1; i >= 0; i-- {
+		c := m.consents[i]
+		if filter.ClientUserID != nil && c.ClientUserID != *filter.ClientUserID {
+			continue
+		}
+		if filter.PayerID != nil && (c.PayerID == nil || *c.PayerID != *filter.PayerID) {
+			continue
+		}
+		if (filter.SeriesID != nil && c.SeriesID != *filter.SeriesID) || (filter.Status != "" && c.Status != filter.Status) {
+			continue
+		}
+		res = append(res, c)
+	}
+	return &res, nil
+}
+
+func (m *mockConsentRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainConsent.Consent, error) {
+	for i := range m.consents {
+		if m.consents[i].ID != id {
+			continue
+		}
+		m.consents[i].Status = updates["status"].(string)
+		if v, ok := updates["withdrawn_at"].(time.Time); ok {
+			m.consents[i].WithdrawnAt = &v
+		}
+		if v, ok := updates["withdrawn_reason"].(string); ok {
+			m.consents[i].WithdrawnReason = v
+		}
+		return m.GetByID(id)
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockConsentRepository) Supersede(previousID uuid.UUID, next *domainConsent.Consent) (*domainConsent.Consent, error) {
+	if _, err := m.Update(previousID, map[string]interface{}{"status": domainConsent.StatusSuperseded}); err != nil {
+		return nil, err
+	}
+	return m.Create(next)
+}
+
+// mockUserRepository returns users by ID
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockClaimRepository serves a single payer
+type mockClaimRepository struct {
+	domainClaim.IClaimRepository
+	payer domainClaim.Payer
+}
+
+func (m *mockClaimRepository) GetPayerByID(id uuid.UUID) (*domainClaim.Payer, error) {
+	if m.payer.ID != id {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &m.payer, nil
+}
+
+var now = time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+func setupTestConsentUseCase(t *testing.T) (IConsentUseCase, *domainUser.User, *domainClaim.Payer) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	client := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, FirstName: "Ana"}
+	caregiver := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{client.ID: client, caregiver.ID: caregiver}}
+	claims := &mockClaimRepository{payer: domainClaim.Payer{ID: uuid.New(), Name: "Texas Medicaid", Active: true}}
+	return NewConsentUseCase(&mockConsentRepository{}, users, claims, loggerInstance), client, &claims.payer
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestGrantConsent(t *testing.T) {
+	useCase, client, payer := setupTestConsentUseCase(t)
+
+	invalid := []*domainConsent.Consent{
+		{ClientUserID: client.ID, RecipientType: domainConsent.RecipientFamily, Scopes: []string{domainConsent.ScopeVisits}},
+		{ClientUserID: client.ID, RecipientType: domainConsent.RecipientPayer, Scopes: []string{domainConsent.ScopeBilling}},
+		{ClientUserID: client.ID, RecipientType: domainConsent.RecipientFamily, RecipientName: "Maria", Scopes: []string{"photos"}},
+		{ClientUserID: client.ID, RecipientType: domainConsent.RecipientFamily, RecipientName: "Maria"},
+		{ClientUserID: client.ID, RecipientType: "neighbour", RecipientName: "Bob", Scopes: []string{domainConsent.ScopeVisits}},
+	}
+	for _, c := range invalid {
+		if _, err := useCase.Grant(c, now); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error for %+v, got %v", c, err)
+		}
+	}
+
+	consent, err := useCase.Grant(&domainConsent.Consent{
+		ClientUserID:  client.ID,
+		RecipientType: domainConsent.RecipientPayer,
+		PayerID:       &payer.ID,
+		Scopes:        []string{domainConsent.ScopeBilling, domainConsent.ScopeBilling},
+	}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if consent.Version != 1 || consent.SeriesID != consent.ID || consent.Status != domainConsent.StatusActive {
+		t.Errorf("expected the first active version of a new series, got %+v", consent)
+	}
+	if consent.RecipientName != payer.Name || len(consent.Scopes) != 1 {
+		t.Errorf("expected the payer's name and deduplicated scopes, got %+v", consent)
+	}
+
+	clients, err := useCase.ConsentedClients(payer.ID, domainConsent.ScopeBilling, now)
+	if err != nil || !clients[client.ID] {
+		t.Errorf("expected the client to be consented for billing, got %v %v", clients, err)
+	}
+	if clients, _ := useCase.ConsentedClients(payer.ID, domainConsent.ScopeHealth, now); clients[client.ID] {
+		t.Error("expected no consent for health data")
+	}
+}
+
+func TestReviseAndWithdrawConsent(t *testing.T) {
+	useCase, client, _ := setupTestConsentUseCase(t)
+	expires := now.AddDate(1, 0, 0)
+	first, err := useCase.Grant(&domainConsent.Consent{
+		ClientUserID:  client.ID,
+		RecipientType: domainConsent.RecipientFamily,
+		RecipientName: "Maria Ruiz",
+		Scopes:        []string{domainConsent.ScopeVisits},
+		ExpiresAt:     &expires,
+	}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.Require(first.ID, client.ID, domainConsent.ScopeVisits, now); err != nil {
+		t.Errorf("expected the consent to allow visits, got %v", err)
+	}
+	if _, err := useCase.Require(first.ID, client.ID, domainConsent.ScopeVisits, expires); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected an expired consent to be refused, got %v", err)
+	}
+
+	second, err := useCase.Revise(first.ID, &domainConsent.Consent{Scopes: []string{domainConsent.ScopeVisits, domainConsent.ScopeHealth}}, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.Version != 2 || second.SeriesID != first.ID || second.RecipientName != "Maria Ruiz" || len(second.Scopes) != 2 {
+		t.Errorf("expected version 2 with the new scopes, got %+v", second)
+	}
+	if _, err := useCase.Require(first.ID, client.ID, domainConsent.ScopeVisits, now.Add(time.Hour)); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected the superseded version to be refused, got %v", err)
+	}
+	if _, err := useCase.Revise(first.ID, &domainConsent.Consent{}, now.Add(time.Hour)); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a superseded version not to be revisable, got %v", err)
+	}
+
+	history, err := useCase.History(first.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*history) != 2 || (*history)[0].ID != second.ID || (*history)[1].Status != domainConsent.StatusSuperseded {
+		t.Errorf("expected both versions newest first, got %+v", *history)
+	}
+
+	withdrawn, err := useCase.Withdraw(second.ID, " Moved away ", now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if withdrawn.Status != domainConsent.StatusWithdrawn || withdrawn.WithdrawnAt == nil || withdrawn.WithdrawnReason != "Moved away" {
+		t.Errorf("expected a withdrawn consent, got %+v", withdrawn)
+	}
+	if _, err := useCase.Require(second.ID, client.ID, domainConsent.ScopeVisits, now.Add(2*time.Hour)); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected a withdrawn consent to be refused, got %v", err)
+	}
+	if _, err := useCase.Withdraw(second.ID, "", now.Add(3*time.Hour)); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a second withdrawal to conflict, got %v", err)
+	}
+	if _, err := useCase.Require(uuid.New(), client.ID, domainConsent.ScopeVisits, now); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected an unknown consent to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package deactivation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	userUseCase "caregiver/src/application/usecases/user"
+	domainDeactivation "caregiver/src/domain/deactivation"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IDeactivationUseCase interface {
+	Apply(request domainDeactivation.Request, now time.Time) (*domainDeactivation.Result, error)
+}
+
+type DeactivationUseCase struct {
+	userUseCase            userUseCase.IUserUseCase
+	scheduleRepository     domainSchedule.IScheduleRepository
+	deactivationRepository domainDeactivation.IDeactivationRepository
+	Logger                 *logger.Logger
+}
+
+type Option func(*DeactivationUseCase)
+
+// WithHistory records every status change, with its reason, for retention
+// analytics.
+func WithHistory(repository domainDeactivation.IDeactivationRepository) Option {
+	return func(u *DeactivationUseCase) {
+		u.deactivationRepository = repository
+	}
+}
+
+func NewDeactivationUseCase(userUseCase userUseCase.IUserUseCase, scheduleRepository domainSchedule.IScheduleRepository, loggerInstance *logger.Logger, opts ...Option) IDeactivationUseCase {
+	u := &DeactivationUseCase{
+		userUseCase:        userUseCase,
+		scheduleRepository: scheduleRepository,
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// Apply deactivates or reactivates the users and lists the visits each one
+// is booked on from now on, so coordinators can reassign them. Users that
+// do not exist or are already in the requested state are skipped. Visits
+// are left as they are. A deactivation may give one of the termination
+// reasons, which is recorded with each change.
+func (u *DeactivationUseCase) Apply(request domainDeactivation.Request, now time.Time) (*domainDeactivation.Result, error) {
+	u.Logger.Info("Applying bulk user status change", zap.String("action", request.Action), zap.Int("users", len(request.UserIDs)), zap.Bool("preview", request.Preview))
+	active, err := targetStatus(request.Action)
+	if err != nil {
+		return nil, err
+	}
+	if len(request.UserIDs) == 0 || len(request.UserIDs) > domainDeactivation.MaxUsers {
+		return nil, domainErrors.NewAppError(fmt.Errorf("between 1 and %d users are required", domainDeactivation.MaxUsers), domainErrors.ValidationError)
+	}
+	reason, err := changeReason(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domainDeactivation.Result{Action: request.Action, Preview: request.Preview, Outcomes: []domainDeactivation.Outcome{}}
+	seen := make(map[uuid.UUID]bool, len(request.UserIDs))
+	for _, userID := range request.UserIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		outcome := domainDeactivation.Outcome{UserID: userID}
+		user, err := u.userUseCase.GetByID(userID)
+		if err != nil {
+			outcome.Skipped = "user not found"
+			result.Outcomes = append(result.Outcomes, outcome)
+			continue
+		}
+		outcome.FirstName, outcome.LastName, outcome.Role = user.FirstName, user.LastName, user.Role
+		if user.Status == active {
+			outcome.Skipped = "already " + statusName(active)
+			result.Outcomes = append(result.Outcomes, outcome)
+			continue
+		}
+		if err := u.listSchedules(&outcome, now); err != nil {
+			return nil, err
+		}
+		if !request.Preview {
+			if _, err := u.userUseCase.Update(userID, map[string]interface{}{"Status": active}); err != nil {
+				u.Logger.Error("Error changing user status", zap.Error(err), zap.String("userID", userID.String()))
+				return nil, err
+			}
+			if err := u.record(user, request, reason, now); err != nil {
+				return nil, err
+			}
+			result.Changed++
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+	}
+	u.Logger.Info("Bulk user status change applied", zap.String("action", request.Action), zap.Int("changed", result.Changed))
+	return result, nil
+}
+
+// listSchedules fills in the user's upcoming and ongoing visits, as the
+// assigned caregiver or as the client, and the series they belong to.
+func (u *DeactivationUseCase) listSchedules(outcome *domainDeactivation.Outcome, now time.Time) error {
+	series := map[uuid.UUID]bool{}
+	for _, query := range []domainSchedule.SearchQuery{
+		{AssignedUserIDs: []uuid.UUID{outcome.UserID}},
+		{ClientUserIDs: []uuid.UUID{outcome.UserID}},
+	} {
+		query.Statuses = domainSchedule.ActiveStatuses
+		query.From = &now
+		query.PageSize = domainDeactivation.MaxListedSchedules
+		found, err := u.scheduleRepository.Search(query)
+		if err != nil {
+			return err
+		}
+		outcome.ScheduleTotal += found.Total
+		for _, schedule := range *found.Data {
+			if len(outcome.Schedules) < domainDeactivation.MaxListedSchedules {
+				outcome.Schedules = append(outcome.Schedules, schedule)
+			}
+			if schedule.SeriesID != nil && !series[*schedule.SeriesID] {
+				series[*schedule.SeriesID] = true
+				outcome.SeriesIDs = append(outcome.SeriesIDs, *schedule.SeriesID)
+			}
+		}
+	}
+	return nil
+}
+
+// record keeps the status change for retention analytics.
+func (u *DeactivationUseCase) record(user *domainUser.User, request domainDeactivation.Request, reason string, now time.Time) error {
+	if u.deactivationRepository == nil {
+		return nil
+	}
+	_, err := u.deactivationRepository.RecordChange(&domainDeactivation.Change{
+		UserID:    user.ID,
+		Role:      user.Role,
+		Action:    request.Action,
+		Reason:    reason,
+		Note:      strings.TrimSpace(request.Note),
+		ChangedAt: now,
+	})
+	return err
+}
+
+// changeReason checks the reason a deactivation gives. Reactivations carry
+// none.
+func changeReason(request domainDeactivation.Request) (string, error) {
+	if request.Action != domainDeactivation.ActionDeactivate {
+		return "", nil
+	}
+	if request.Reason == "" {
+		return domainDeactivation.ReasonUnspecified, nil
+	}
+	if !domainDeactivation.ValidReason(request.Reason) {
+		return "", domainErrors.NewAppError(fmt.Errorf("reason must be one of %s", strings.Join(domainDeactivation.Reasons, ", ")), domainErrors.ValidationError)
+	}
+	return request.Reason, nil
+}
+
+func targetStatus(action string) (bool, error) {
+	switch action {
+	case domainDeactivation.ActionDeactivate:
+		return false, nil
+	case domainDeactivation.ActionReactivate:
+		return true, nil
+	}
+	return false, domainErrors.NewAppError(errors.New("action must be deactivate or reactivate"), domainErrors.ValidationError)
+}
+
+func statusName(active bool) string {
+	if active {
+		return "active"
+	}
+	return "inactive"
+}
@@ -0,0 +1,164 @@
+package deactivation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	userUseCase "caregiver/src/application/usecases/user"
+	domainDeactivation "caregiver/src/domain/deactivation"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockUserUseCase keeps users in memory
+type mockUserUseCase struct {
+	userUseCase.IUserUseCase
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserUseCase) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (m *mockUserUseCase) Update(id uuid.UUID, userMap map[string]interface{}) (*domainUser.User, error) {
+	m.users[id].Status = userMap["Status"].(bool)
+	return m.GetByID(id)
+}
+
+// mockScheduleRepository searches a fixed list of schedules
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) Search(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+	found := []domainSchedule.Schedule{}
+	for _, schedule := range m.schedules {
+		if len(query.AssignedUserIDs) > 0 && schedule.AssignedUserID != query.AssignedUserIDs[0] {
+			continue
+		}
+		if len(query.ClientUserIDs) > 0 && schedule.ClientUserID != query.ClientUserIDs[0] {
+			continue
+		}
+		if !schedule.ScheduledSlot.To.After(*query.From) {
+			continue
+		}
+		found = append(found, schedule)
+	}
+	return &domainSchedule.SearchResultSchedule{Data: &found, Total: int64(len(found))}, nil
+}
+
+// memoryHistory keeps recorded changes in memory
+type memoryHistory struct {
+	changes []domainDeactivation.Change
+}
+
+func (m *memoryHistory) RecordChange(change *domainDeactivation.Change) (*domainDeactivation.Change, error) {
+	change.ID = uuid.New()
+	m.changes = append(m.changes, *change)
+	return change, nil
+}
+
+func (m *memoryHistory) GetChanges(role string, to time.Time) (*[]domainDeactivation.Change, error) {
+	return &m.changes, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestApply(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	now := time.Date(2026, 9, 1, 8, 0, 0, 0, time.UTC)
+	seasonal, inactive, client := uuid.New(), uuid.New(), uuid.New()
+	seriesID := uuid.New()
+	users := &mockUserUseCase{users: map[uuid.UUID]*domainUser.User{
+		seasonal: {ID: seasonal, Role: domainUser.RoleCaregiver, Status: true},
+		inactive: {ID: inactive, Role: domainUser.RoleCaregiver},
+		client:   {ID: client, Role: domainUser.RoleClient, Status: true},
+	}}
+	slot := func(days int) domainSchedule.ScheduledSlot {
+		from := now.AddDate(0, 0, days)
+		return domainSchedule.ScheduledSlot{From: from, To: from.Add(time.Hour)}
+	}
+	schedules := &mockScheduleRepository{schedules: []domainSchedule.Schedule{
+		{ID: uuid.New(), AssignedUserID: seasonal, ClientUserID: client, ScheduledSlot: slot(-1)},
+		{ID: uuid.New(), AssignedUserID: seasonal, ClientUserID: client, ScheduledSlot: slot(1), SeriesID: &seriesID},
+		{ID: uuid.New(), AssignedUserID: seasonal, ClientUserID: client, ScheduledSlot: slot(8), SeriesID: &seriesID},
+	}}
+	history := &memoryHistory{}
+	useCase := NewDeactivationUseCase(users, schedules, loggerInstance, WithHistory(history))
+
+	for _, tc := range []struct {
+		name    string
+		request domainDeactivation.Request
+	}{
+		{"Unknown action", domainDeactivation.Request{Action: "suspend", UserIDs: []uuid.UUID{seasonal}}},
+		{"No users", domainDeactivation.Request{Action: domainDeactivation.ActionDeactivate}},
+		{"Unknown reason", domainDeactivation.Request{Action: domainDeactivation.ActionDeactivate, UserIDs: []uuid.UUID{client}, Reason: "bored"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := useCase.Apply(tc.request, now); errorType(err) != domainErrors.ValidationError {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+
+	request := domainDeactivation.Request{Action: domainDeactivation.ActionDeactivate, UserIDs: []uuid.UUID{seasonal, inactive, uuid.New(), seasonal}, Preview: true}
+	preview, err := useCase.Apply(request, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.Changed != 0 || !users.users[seasonal].Status || len(history.changes) != 0 {
+		t.Fatalf("expected a preview to change nothing, got %+v", preview)
+	}
+	if len(preview.Outcomes) != 3 || preview.Outcomes[1].Skipped != "already inactive" || preview.Outcomes[2].Skipped != "user not found" {
+		t.Fatalf("expected the inactive and unknown users skipped once each, got %+v", preview.Outcomes)
+	}
+	affected := preview.Outcomes[0]
+	if affected.ScheduleTotal != 2 || len(affected.SeriesIDs) != 1 || affected.SeriesIDs[0] != seriesID {
+		t.Errorf("expected the two upcoming visits of one series, got %+v", affected)
+	}
+
+	request.Preview = false
+	applied, err := useCase.Apply(request, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied.Changed != 1 || users.users[seasonal].Status {
+		t.Errorf("expected the seasonal caregiver deactivated, got %+v", applied)
+	}
+	if len(history.changes) != 1 || history.changes[0].UserID != seasonal || history.changes[0].Reason != domainDeactivation.ReasonUnspecified {
+		t.Errorf("expected the deactivation recorded without a reason, got %+v", history.changes)
+	}
+
+	ended, err := useCase.Apply(domainDeactivation.Request{Action: domainDeactivation.ActionDeactivate, UserIDs: []uuid.UUID{client}, Reason: domainDeactivation.ReasonFacility, Note: " moved to Oak House "}, now)
+	if err != nil || ended.Changed != 1 {
+		t.Fatalf("expected the client deactivated, got %+v, %v", ended, err)
+	}
+	if change := history.changes[1]; change.Role != domainUser.RoleClient || change.Reason != domainDeactivation.ReasonFacility || change.Note != "moved to Oak House" {
+		t.Errorf("expected the client's termination reason recorded, got %+v", change)
+	}
+
+	reactivated, err := useCase.Apply(domainDeactivation.Request{Action: domainDeactivation.ActionReactivate, UserIDs: []uuid.UUID{seasonal, inactive}}, now)
+	if err != nil || reactivated.Changed != 2 || !users.users[inactive].Status {
+		t.Errorf("expected both caregivers reactivated, got %+v, %v", reactivated, err)
+	}
+}
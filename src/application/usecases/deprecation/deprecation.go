@@ -0,0 +1,93 @@
+package deprecation
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	domainDeprecation "caregiver/src/domain/deprecation"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"go.uber.org/zap"
+)
+
+// anonymousClient stands in for callers that send no API key.
+const anonymousClient = "anonymous"
+
+type IDeprecationUseCase interface {
+	// RecordUsage counts one call by the client to a deprecated feature.
+	RecordUsage(feature, method, route, client, userAgent string, at time.Time) error
+	// Report sums up usage per feature, optionally only usage seen since the
+	// given time. Features in use most recently come first.
+	Report(feature string, since *time.Time) ([]domainDeprecation.FeatureReport, error)
+}
+
+type DeprecationUseCase struct {
+	deprecationRepository domainDeprecation.IDeprecationRepository
+	Logger                *logger.Logger
+}
+
+func NewDeprecationUseCase(deprecationRepository domainDeprecation.IDeprecationRepository, loggerInstance *logger.Logger) IDeprecationUseCase {
+	return &DeprecationUseCase{
+		deprecationRepository: deprecationRepository,
+		Logger:                loggerInstance,
+	}
+}
+
+func (u *DeprecationUseCase) RecordUsage(feature, method, route, client, userAgent string, at time.Time) error {
+	feature = strings.TrimSpace(feature)
+	if feature == "" {
+		return domainErrors.NewAppError(errors.New("feature is required"), domainErrors.ValidationError)
+	}
+	if client == "" {
+		client = anonymousClient
+	}
+	u.Logger.Info("Deprecated feature called", zap.String("feature", feature), zap.String("client", client), zap.String("userAgent", userAgent))
+	return u.deprecationRepository.RecordUsage(&domainDeprecation.Usage{
+		Feature:     feature,
+		Method:      method,
+		Route:       route,
+		Client:      client,
+		UserAgent:   userAgent,
+		Calls:       1,
+		FirstSeenAt: at,
+		LastSeenAt:  at,
+	})
+}
+
+func (u *DeprecationUseCase) Report(feature string, since *time.Time) ([]domainDeprecation.FeatureReport, error) {
+	usage, err := u.deprecationRepository.GetUsage(domainDeprecation.UsageFilter{Feature: feature, Since: since})
+	if err != nil {
+		return nil, err
+	}
+	byFeature := make(map[string]*domainDeprecation.FeatureReport)
+	reports := []*domainDeprecation.FeatureReport{}
+	for _, entry := range *usage {
+		report, ok := byFeature[entry.Feature]
+		if !ok {
+			report = &domainDeprecation.FeatureReport{Feature: entry.Feature}
+			byFeature[entry.Feature] = report
+			reports = append(reports, report)
+		}
+		report.Calls += entry.Calls
+		report.Clients++
+		if entry.LastSeenAt.After(report.LastSeenAt) {
+			report.LastSeenAt = entry.LastSeenAt
+		}
+		report.Usage = append(report.Usage, entry)
+	}
+
+	res := make([]domainDeprecation.FeatureReport, len(reports))
+	for i, report := range reports {
+		sort.SliceStable(report.Usage, func(a, b int) bool {
+			return report.Usage[a].LastSeenAt.After(report.Usage[b].LastSeenAt)
+		})
+		res[i] = *report
+	}
+	sort.SliceStable(res, func(a, b int) bool {
+		return res[a].LastSeenAt.After(res[b].LastSeenAt)
+	})
+	return res, nil
+}
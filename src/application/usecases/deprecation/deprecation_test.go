@@ -0,0 +1,87 @@
+package deprecation
+
+import (
+	"testing"
+	"time"
+
+	domainDeprecation "caregiver/src/domain/deprecation"
+	logger "caregiver/src/infrastructure/logger"
+)
+
+// mockDeprecationRepository keeps one record per feature, client and user
+// agent
+type mockDeprecationRepository struct {
+	usage []domainDeprecation.Usage
+}
+
+func (m *mockDeprecationRepository) RecordUsage(usage *domainDeprecation.Usage) error {
+	for i := range m.usage {
+		existing := &m.usage[i]
+		if existing.Feature == usage.Feature && existing.Client == usage.Client && existing.UserAgent == usage.UserAgent {
+			existing.Calls += usage.Calls
+			existing.LastSeenAt = usage.LastSeenAt
+			return nil
+		}
+	}
+	m.usage = append(m.usage, *usage)
+	return nil
+}
+
+func (m *mockDeprecationRepository) GetUsage(filter domainDeprecation.UsageFilter) (*[]domainDeprecation.Usage, error) {
+	res := []domainDeprecation.Usage{}
+	for _, usage := range m.usage {
+		if (filter.Feature != "" && usage.Feature != filter.Feature) || (filter.Since != nil && usage.LastSeenAt.Before(*filter.Since)) {
+			continue
+		}
+		res = append(res, usage)
+	}
+	return &res, nil
+}
+
+func TestReport(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	useCase := NewDeprecationUseCase(&mockDeprecationRepository{}, loggerInstance)
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	if err := useCase.RecordUsage(" ", "GET", "/v1/old", "", "curl", now); err == nil {
+		t.Error("expected an unnamed feature to be rejected")
+	}
+	calls := []struct {
+		feature, client, userAgent string
+		at                         time.Time
+	}{
+		{"old-search", "key:abc", "partner/1.0", now.AddDate(0, 0, -10)},
+		{"old-search", "key:abc", "partner/1.0", now.AddDate(0, 0, -9)},
+		{"old-search", "", "mobile/3.1", now.AddDate(0, 0, -2)},
+		{"legacy-dates", "key:def", "billing/2.0", now.AddDate(0, 0, -1)},
+	}
+	for _, call := range calls {
+		if err := useCase.RecordUsage(call.feature, "GET", "/v1/old", call.client, call.userAgent, call.at); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	reports, err := useCase.Report("", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reports) != 2 || reports[0].Feature != "legacy-dates" {
+		t.Fatalf("expected the most recently used feature first, got %+v", reports)
+	}
+	search := reports[1]
+	if search.Calls != 3 || search.Clients != 2 || !search.LastSeenAt.Equal(now.AddDate(0, 0, -2)) {
+		t.Errorf("unexpected old-search summary %+v", search)
+	}
+	if search.Usage[0].Client != anonymousClient || search.Usage[1].Calls != 2 {
+		t.Errorf("expected anonymous mobile usage first and the partner's calls counted, got %+v", search.Usage)
+	}
+
+	since := now.AddDate(0, 0, -5)
+	recent, _ := useCase.Report("old-search", &since)
+	if len(recent) != 1 || recent[0].Clients != 1 || recent[0].Calls != 1 {
+		t.Errorf("expected only the recent mobile usage, got %+v", recent)
+	}
+}
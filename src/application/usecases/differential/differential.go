@@ -0,0 +1,303 @@
+package differential
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	payRateUseCase "caregiver/src/application/usecases/payrate"
+	domainClaim "caregiver/src/domain/claim"
+	domainDifferential "caregiver/src/domain/differential"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxMultiplier caps how far a differential may raise a rate.
+const MaxMultiplier = 5
+
+type IDifferentialUseCase interface {
+	CreateRule(newRule *domainDifferential.Rule) (*domainDifferential.Rule, error)
+	GetRuleByID(id uuid.UUID) (*domainDifferential.Rule, error)
+	GetRules() (*[]domainDifferential.Rule, error)
+	UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainDifferential.Rule, error)
+	DeleteRule(id uuid.UUID) error
+	ActiveRules(appliesTo string) ([]domainDifferential.Rule, error)
+	VisitPay(scheduleID uuid.UUID) (*domainDifferential.VisitPay, error)
+	Estimate(request domainDifferential.EstimateRequest) (*domainDifferential.Estimate, error)
+}
+
+// BillingRateSource supplies the client coverages and payer rate tables an
+// estimate bills against.
+type BillingRateSource interface {
+	GetCoverages(filter domainClaim.CoverageFilter) (*[]domainClaim.Coverage, error)
+	GetRates(payerID uuid.UUID) (*[]domainClaim.Rate, error)
+}
+
+type DifferentialUseCase struct {
+	differentialRepository domainDifferential.IDifferentialRepository
+	scheduleRepository     domainSchedule.IScheduleRepository
+	payRateUseCase         payRateUseCase.IPayRateUseCase
+	billingRates           BillingRateSource
+	Logger                 *logger.Logger
+}
+
+type Option func(*DifferentialUseCase)
+
+// WithBillingRates lets estimates include the bill amount.
+func WithBillingRates(source BillingRateSource) Option {
+	return func(u *DifferentialUseCase) {
+		u.billingRates = source
+	}
+}
+
+func NewDifferentialUseCase(differentialRepository domainDifferential.IDifferentialRepository, scheduleRepository domainSchedule.IScheduleRepository, payRateUseCase payRateUseCase.IPayRateUseCase, loggerInstance *logger.Logger, opts ...Option) IDifferentialUseCase {
+	useCase := &DifferentialUseCase{
+		differentialRepository: differentialRepository,
+		scheduleRepository:     scheduleRepository,
+		payRateUseCase:         payRateUseCase,
+		Logger:                 loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+func (u *DifferentialUseCase) CreateRule(newRule *domainDifferential.Rule) (*domainDifferential.Rule, error) {
+	u.Logger.Info("Creating shift differential rule", zap.String("name", newRule.Name), zap.String("appliesTo", newRule.AppliesTo))
+	if newRule.AppliesTo == "" {
+		newRule.AppliesTo = domainDifferential.AppliesToBoth
+	}
+	if newRule.Timezone == "" {
+		newRule.Timezone = "UTC"
+	}
+	if err := validateRule(newRule); err != nil {
+		return nil, err
+	}
+	newRule.ID = uuid.New()
+	newRule.Active = true
+	return u.differentialRepository.CreateRule(newRule)
+}
+
+func (u *DifferentialUseCase) GetRuleByID(id uuid.UUID) (*domainDifferential.Rule, error) {
+	return u.differentialRepository.GetRuleByID(id)
+}
+
+func (u *DifferentialUseCase) GetRules() (*[]domainDifferential.Rule, error) {
+	return u.differentialRepository.GetRules()
+}
+
+func (u *DifferentialUseCase) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainDifferential.Rule, error) {
+	u.Logger.Info("Updating shift differential rule", zap.String("id", id.String()))
+	existing, err := u.differentialRepository.GetRuleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["name"].(string); ok {
+		candidate.Name = v
+	}
+	if v, ok := updates["applies_to"].(string); ok {
+		candidate.AppliesTo = v
+	}
+	if v, ok := updates["weekdays"].([]int); ok {
+		candidate.Weekdays = v
+	}
+	if v, ok := updates["start_minute"].(int); ok {
+		candidate.StartMinute = v
+	}
+	if v, ok := updates["end_minute"].(int); ok {
+		candidate.EndMinute = v
+	}
+	if v, ok := updates["timezone"].(string); ok {
+		candidate.Timezone = v
+	}
+	if v, ok := updates["multiplier"].(float64); ok {
+		candidate.Multiplier = v
+	}
+	if err := validateRule(&candidate); err != nil {
+		return nil, err
+	}
+	return u.differentialRepository.UpdateRule(id, updates)
+}
+
+func (u *DifferentialUseCase) DeleteRule(id uuid.UUID) error {
+	u.Logger.Info("Deleting shift differential rule", zap.String("id", id.String()))
+	return u.differentialRepository.DeleteRule(id)
+}
+
+// ActiveRules returns the active rules for pay or billing, including those
+// that apply to both.
+func (u *DifferentialUseCase) ActiveRules(appliesTo string) ([]domainDifferential.Rule, error) {
+	rules, err := u.differentialRepository.GetActiveRules(appliesTo)
+	if err != nil {
+		return nil, err
+	}
+	return *rules, nil
+}
+
+// VisitPay prices a visit's checked-in time at the caregiver's rate for the
+// visit day, itemised by the pay differentials it falls under.
+func (u *DifferentialUseCase) VisitPay(scheduleID uuid.UUID) (*domainDifferential.VisitPay, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	periods := schedule.WorkedPeriods()
+	if len(periods) == 0 {
+		return nil, domainErrors.NewAppError(errors.New("the visit has no checked-in time"), domainErrors.ValidationError)
+	}
+	first := periods[0].From.UTC()
+	serviceDate := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	rate, err := u.payRateUseCase.RateFor(schedule.AssignedUserID, schedule.ServiceName, serviceDate)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := u.ActiveRules(domainDifferential.AppliesToPay)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := schedule.WorkedDuration().Hours()
+	return &domainDifferential.VisitPay{
+		ScheduleID:      schedule.ID,
+		CaregiverUserID: schedule.AssignedUserID,
+		ServiceName:     schedule.ServiceName,
+		ServiceDate:     serviceDate,
+		HourlyRate:      rate.HourlyRate,
+		RateSource:      rate.Source,
+		Hours:           math.Round(hours*100) / 100,
+		Breakdown:       domainDifferential.Price(rules, periods, math.Round(hours*rate.HourlyRate*100)/100),
+	}, nil
+}
+
+// Estimate quotes a visit before it is booked: the caregiver's pay and the
+// client's bill for the slot, each raised by the differentials it falls
+// under. The bill is left out, with a warning, when the client has no
+// active coverage or the payer has no rate for the service that day.
+func (u *DifferentialUseCase) Estimate(request domainDifferential.EstimateRequest) (*domainDifferential.Estimate, error) {
+	if strings.TrimSpace(request.ServiceName) == "" {
+		return nil, domainErrors.NewAppError(errors.New("service name is required"), domainErrors.ValidationError)
+	}
+	if !request.Slot.To.After(request.Slot.From) {
+		return nil, domainErrors.NewAppError(errors.New("the slot must end after it starts"), domainErrors.ValidationError)
+	}
+	start := request.Slot.From.UTC()
+	serviceDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	periods := []domainSchedule.ScheduledSlot{request.Slot}
+	hours := request.Slot.To.Sub(request.Slot.From).Hours()
+
+	rate, err := u.payRateUseCase.RateFor(request.CaregiverUserID, request.ServiceName, serviceDate)
+	if err != nil {
+		return nil, err
+	}
+	payRules, err := u.ActiveRules(domainDifferential.AppliesToPay)
+	if err != nil {
+		return nil, err
+	}
+	estimate := &domainDifferential.Estimate{
+		ClientUserID:    request.ClientUserID,
+		CaregiverUserID: request.CaregiverUserID,
+		ServiceName:     request.ServiceName,
+		ServiceDate:     serviceDate,
+		Hours:           math.Round(hours*100) / 100,
+		Pay: domainDifferential.PayEstimate{
+			HourlyRate: rate.HourlyRate,
+			RateSource: rate.Source,
+			Breakdown:  domainDifferential.Price(payRules, periods, math.Round(hours*rate.HourlyRate*100)/100),
+		},
+	}
+
+	if u.billingRates == nil {
+		estimate.Warnings = append(estimate.Warnings, "billing rates are not available")
+		return estimate, nil
+	}
+	payerID, warning, err := u.estimatePayer(request)
+	if err != nil {
+		return nil, err
+	}
+	if warning != "" {
+		estimate.Warnings = append(estimate.Warnings, warning)
+		return estimate, nil
+	}
+	rates, err := u.billingRates.GetRates(payerID)
+	if err != nil {
+		return nil, err
+	}
+	billRate, ok := domainClaim.EffectiveRate(*rates, request.ServiceName, serviceDate)
+	if !ok {
+		estimate.Warnings = append(estimate.Warnings, "the payer has no rate for the service on the visit day")
+		return estimate, nil
+	}
+	billRules, err := u.ActiveRules(domainDifferential.AppliesToBilling)
+	if err != nil {
+		return nil, err
+	}
+	units := int(math.Round(request.Slot.To.Sub(request.Slot.From).Minutes() / float64(billRate.UnitMinutes)))
+	estimate.Bill = &domainDifferential.BillEstimate{
+		PayerID:       payerID,
+		ProcedureCode: billRate.ProcedureCode,
+		Units:         units,
+		UnitRate:      billRate.UnitRate,
+		Breakdown:     domainDifferential.Price(billRules, periods, math.Round(float64(units)*billRate.UnitRate*100)/100),
+	}
+	return estimate, nil
+}
+
+// estimatePayer picks the payer to bill: the requested one if the client is
+// covered by it, else the client's only active coverage. It returns a
+// warning instead when there is no coverage to bill.
+func (u *DifferentialUseCase) estimatePayer(request domainDifferential.EstimateRequest) (uuid.UUID, string, error) {
+	coverages, err := u.billingRates.GetCoverages(domainClaim.CoverageFilter{ClientUserID: &request.ClientUserID, PayerID: request.PayerID})
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	var active []domainClaim.Coverage
+	for _, coverage := range *coverages {
+		if coverage.Active {
+			active = append(active, coverage)
+		}
+	}
+	switch {
+	case len(active) == 0 && request.PayerID != nil:
+		return uuid.Nil, "the client has no active coverage with the payer", nil
+	case len(active) == 0:
+		return uuid.Nil, "the client has no active coverage", nil
+	case len(active) > 1:
+		return uuid.Nil, "", domainErrors.NewAppError(errors.New("the client has several active coverages; choose a payer"), domainErrors.ValidationError)
+	}
+	return active[0].PayerID, "", nil
+}
+
+func validateRule(r *domainDifferential.Rule) error {
+	if strings.TrimSpace(r.Name) == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	switch r.AppliesTo {
+	case domainDifferential.AppliesToPay, domainDifferential.AppliesToBilling, domainDifferential.AppliesToBoth:
+	default:
+		return domainErrors.NewAppError(errors.New("applies to must be 'pay', 'billing' or 'both'"), domainErrors.ValidationError)
+	}
+	if r.Multiplier <= 1 || r.Multiplier > MaxMultiplier {
+		return domainErrors.NewAppError(errors.New("multiplier must be above 1 and at most 5"), domainErrors.ValidationError)
+	}
+	if r.StartMinute < 0 || r.StartMinute >= 24*60 || r.EndMinute < 0 || r.EndMinute >= 24*60 {
+		return domainErrors.NewAppError(errors.New("the window must start and end within the day"), domainErrors.ValidationError)
+	}
+	seen := make(map[int]bool)
+	for _, day := range r.Weekdays {
+		if day < 0 || day > 6 || seen[day] {
+			return domainErrors.NewAppError(errors.New("weekdays must be distinct days from 0 (Sunday) to 6 (Saturday)"), domainErrors.ValidationError)
+		}
+		seen[day] = true
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil || r.Timezone == "" {
+		return domainErrors.NewAppError(errors.New("timezone is not a known IANA zone"), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package differential
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	payRateUseCase "caregiver/src/application/usecases/payrate"
+	domainClaim "caregiver/src/domain/claim"
+	domainDifferential "caregiver/src/domain/differential"
+	domainErrors "caregiver/src/domain/errors"
+	domainPayRate "caregiver/src/domain/payrate"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockDifferentialRepository keeps rules in memory
+type mockDifferentialRepository struct {
+	domainDifferential.IDifferentialRepository
+	rules []domainDifferential.Rule
+}
+
+func (m *mockDifferentialRepository) CreateRule(newRule *domainDifferential.Rule) (*domainDifferential.Rule, error) {
+	m.rules = append(m.rules, *newRule)
+	return newRule, nil
+}
+
+func (m *mockDifferentialRepository) GetActiveRules(appliesTo string) (*[]domainDifferential.Rule, error) {
+	res := []domainDifferential.Rule{}
+	for _, r := range m.rules {
+		if r.Active && (r.AppliesTo == appliesTo || r.AppliesTo == domainDifferential.AppliesToBoth) {
+			res = append(res, r)
+		}
+	}
+	return &res, nil
+}
+
+// mockScheduleRepository returns visits by ID
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockPayRateUseCase pays every caregiver the same hourly rate
+type mockPayRateUseCase struct {
+	payRateUseCase.IPayRateUseCase
+	hourlyRate float64
+}
+
+func (m *mockPayRateUseCase) RateFor(caregiverUserID uuid.UUID, serviceName string, day time.Time) (*domainPayRate.EffectiveRate, error) {
+	return &domainPayRate.EffectiveRate{CaregiverUserID: caregiverUserID, ServiceName: serviceName, Date: day, HourlyRate: m.hourlyRate, Source: domainPayRate.SourceRateTable}, nil
+}
+
+// mockBillingRates holds coverages and payer rates in memory
+type mockBillingRates struct {
+	coverages []domainClaim.Coverage
+	rates     []domainClaim.Rate
+}
+
+func (m *mockBillingRates) GetCoverages(filter domainClaim.CoverageFilter) (*[]domainClaim.Coverage, error) {
+	res := []domainClaim.Coverage{}
+	for _, c := range m.coverages {
+		if (filter.ClientUserID == nil || c.ClientUserID == *filter.ClientUserID) && (filter.PayerID == nil || c.PayerID == *filter.PayerID) {
+			res = append(res, c)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockBillingRates) GetRates(payerID uuid.UUID) (*[]domainClaim.Rate, error) {
+	res := []domainClaim.Rate{}
+	for _, r := range m.rates {
+		if r.PayerID == payerID {
+			res = append(res, r)
+		}
+	}
+	return &res, nil
+}
+
+func setupTestDifferentialUseCase(t *testing.T) (IDifferentialUseCase, *mockScheduleRepository) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	schedules := &mockScheduleRepository{}
+	useCase := NewDifferentialUseCase(&mockDifferentialRepository{}, schedules, &mockPayRateUseCase{hourlyRate: 20}, loggerInstance)
+	return useCase, schedules
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestCreateRule(t *testing.T) {
+	useCase, _ := setupTestDifferentialUseCase(t)
+	for _, tc := range []struct {
+		name string
+		rule domainDifferential.Rule
+	}{
+		{"Multiplier must raise the rate", domainDifferential.Rule{Name: "Nights", StartMinute: 1320, EndMinute: 360, Multiplier: 1}},
+		{"Window outside the day", domainDifferential.Rule{Name: "Nights", StartMinute: 1320, EndMinute: 1440, Multiplier: 1.5}},
+		{"Unknown weekday", domainDifferential.Rule{Name: "Weekends", Weekdays: []int{6, 7}, Multiplier: 1.25}},
+		{"Unknown timezone", domainDifferential.Rule{Name: "Nights", StartMinute: 1320, EndMinute: 360, Timezone: "Mars/Olympus", Multiplier: 1.5}},
+		{"Unknown target", domainDifferential.Rule{Name: "Nights", AppliesTo: "overtime", Multiplier: 1.5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := tc.rule
+			if _, err := useCase.CreateRule(&rule); errorType(err) != domainErrors.ValidationError {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+
+	rule, err := useCase.CreateRule(&domainDifferential.Rule{Name: "Weekends", Weekdays: []int{0, 6}, Multiplier: 1.25})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule.AppliesTo != domainDifferential.AppliesToBoth || rule.Timezone != "UTC" || !rule.Active {
+		t.Errorf("expected defaults to apply, got %+v", rule)
+	}
+}
+
+func TestVisitPay(t *testing.T) {
+	useCase, schedules := setupTestDifferentialUseCase(t)
+	nights, _ := useCase.CreateRule(&domainDifferential.Rule{Name: "Nights", AppliesTo: domainDifferential.AppliesToPay, StartMinute: 22 * 60, EndMinute: 6 * 60, Multiplier: 1.5})
+	weekends, _ := useCase.CreateRule(&domainDifferential.Rule{Name: "Weekends", Weekdays: []int{0, 6}, Timezone: "America/Chicago", Multiplier: 1.25})
+	if _, err := useCase.CreateRule(&domainDifferential.Rule{Name: "Billing holidays", AppliesTo: domainDifferential.AppliesToBilling, Multiplier: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	visit := func(from time.Time, hours int) uuid.UUID {
+		to := from.Add(time.Duration(hours) * time.Hour)
+		s := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: uuid.New(), ServiceName: "Personal care", VisitStatus: "completed", CheckinTime: &from, CheckoutTime: &to}
+		schedules.schedules = append(schedules.schedules, s)
+		return s.ID
+	}
+
+	type line struct {
+		ruleID  *uuid.UUID
+		minutes float64
+		amount  float64
+	}
+	for _, tc := range []struct {
+		name   string
+		id     uuid.UUID
+		amount float64
+		lines  []line
+	}{
+		{
+			"Evening into the night",
+			visit(time.Date(2025, 3, 5, 20, 0, 0, 0, time.UTC), 4),
+			100,
+			[]line{{nil, 120, 40}, {&nights.ID, 120, 60}},
+		},
+		{
+			"Highest multiplier wins where rules overlap",
+			visit(time.Date(2025, 3, 8, 5, 0, 0, 0, time.UTC), 2),
+			55,
+			[]line{{&nights.ID, 60, 30}, {&weekends.ID, 60, 25}},
+		},
+		{
+			"Weekdays are read in the rule's timezone",
+			visit(time.Date(2025, 3, 8, 3, 0, 0, 0, time.UTC), 1),
+			30,
+			[]line{{&nights.ID, 60, 30}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pay, err := useCase.VisitPay(tc.id)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pay.Amount != tc.amount || len(pay.Lines) != len(tc.lines) {
+				t.Fatalf("expected %.2f over %d lines, got %+v", tc.amount, len(tc.lines), pay)
+			}
+			for i, want := range tc.lines {
+				got := pay.Lines[i]
+				if (want.ruleID == nil) != (got.RuleID == nil) || (want.ruleID != nil && *want.ruleID != *got.RuleID) || got.Minutes != want.minutes || got.Amount != want.amount {
+					t.Errorf("line %d: expected %+v, got %+v", i, want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("Visit without worked time", func(t *testing.T) {
+		id := uuid.New()
+		schedules.schedules = append(schedules.schedules, domainSchedule.Schedule{ID: id, VisitStatus: "upcoming"})
+		if _, err := useCase.VisitPay(id); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+}
+
+func TestEstimate(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	billing := &mockBillingRates{}
+	useCase := NewDifferentialUseCase(&mockDifferentialRepository{}, &mockScheduleRepository{}, &mockPayRateUseCase{hourlyRate: 20}, loggerInstance, WithBillingRates(billing))
+	nights, _ := useCase.CreateRule(&domainDifferential.Rule{Name: "Nights", StartMinute: 22 * 60, EndMinute: 6 * 60, Multiplier: 1.5})
+
+	clientID, privatePay, medicaid := uuid.New(), uuid.New(), uuid.New()
+	billing.coverages = []domainClaim.Coverage{{ClientUserID: clientID, PayerID: privatePay, Active: true}}
+	billing.rates = []domainClaim.Rate{{PayerID: privatePay, ServiceName: "Personal care", ProcedureCode: "T1019", UnitMinutes: 15, UnitRate: 8, EffectiveFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	from := time.Date(2025, 3, 5, 20, 0, 0, 0, time.UTC)
+	request := domainDifferential.EstimateRequest{
+		ClientUserID:    clientID,
+		CaregiverUserID: uuid.New(),
+		ServiceName:     "personal care",
+		Slot:            domainSchedule.ScheduledSlot{From: from, To: from.Add(4 * time.Hour)},
+	}
+
+	t.Run("Bill and pay are raised by the differentials", func(t *testing.T) {
+		estimate, err := useCase.Estimate(request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if estimate.Hours != 4 || estimate.Pay.Amount != 100 || len(estimate.Warnings) != 0 {
+			t.Errorf("expected 4 hours paid 100, got %+v", estimate)
+		}
+		bill := estimate.Bill
+		if bill == nil || bill.PayerID != privatePay || bill.Units != 16 || bill.BaseAmount != 128 || bill.Amount != 160 {
+			t.Fatalf("expected 16 units billed 160, got %+v", bill)
+		}
+		if len(bill.Lines) != 2 || *bill.Lines[1].RuleID != nights.ID {
+			t.Errorf("expected standard and night lines, got %+v", bill.Lines)
+		}
+	})
+
+	t.Run("Client covered by several payers must choose one", func(t *testing.T) {
+		billing.coverages = append(billing.coverages, domainClaim.Coverage{ClientUserID: clientID, PayerID: medicaid, Active: true})
+		defer func() { billing.coverages = billing.coverages[:1] }()
+		if _, err := useCase.Estimate(request); errorType(err) != domainErrors.ValidationError {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+		chosen := request
+		chosen.PayerID = &medicaid
+		estimate, err := useCase.Estimate(chosen)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if estimate.Bill != nil || len(estimate.Warnings) != 1 {
+			t.Errorf("expected no bill for a payer without a rate, got %+v", estimate)
+		}
+	})
+
+	t.Run("Client without coverage is quoted pay only", func(t *testing.T) {
+		uncovered := request
+		uncovered.ClientUserID = uuid.New()
+		estimate, err := useCase.Estimate(uncovered)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if estimate.Bill != nil || estimate.Pay.Amount != 100 || len(estimate.Warnings) != 1 {
+			t.Errorf("expected pay without a bill, got %+v", estimate)
+		}
+	})
+
+	t.Run("Slot must end after it starts", func(t *testing.T) {
+		backwards := request
+		backwards.Slot.To = from
+		if _, err := useCase.Estimate(backwards); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,355 @@
+package equipment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainEquipment "caregiver/src/domain/equipment"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaintenanceReminderLead is how long before the due date a maintenance
+// reminder goes out.
+const MaintenanceReminderLead = 7 * 24 * time.Hour
+
+var equipmentTypes = map[string]bool{
+	domainEquipment.TypeVehicle:     true,
+	domainEquipment.TypeHoist:       true,
+	domainEquipment.TypeMobilityAid: true,
+	domainEquipment.TypeOther:       true,
+}
+
+type IEquipmentUseCase interface {
+	CreateEquipment(newEquipment *domainEquipment.Equipment) (*domainEquipment.Equipment, error)
+	GetEquipment(status string) (*[]domainEquipment.Equipment, error)
+	GetEquipmentByID(id uuid.UUID) (*domainEquipment.Equipment, error)
+	UpdateEquipment(id uuid.UUID, updates map[string]interface{}) (*domainEquipment.Equipment, error)
+	DeleteEquipment(id uuid.UUID) error
+	Checkout(newCheckout *domainEquipment.Checkout, now time.Time) (*domainEquipment.Checkout, error)
+	Return(checkoutID uuid.UUID, condition string, needsMaintenance bool, now time.Time) (*domainEquipment.Checkout, error)
+	GetCheckouts(filter domainEquipment.CheckoutFilter) (*[]domainEquipment.Checkout, error)
+	RecordMaintenance(record *domainEquipment.MaintenanceRecord) (*domainEquipment.MaintenanceRecord, error)
+	GetMaintenanceRecords(equipmentID uuid.UUID) (*[]domainEquipment.MaintenanceRecord, error)
+	Sweep(now time.Time) (int, error)
+}
+
+type EquipmentUseCase struct {
+	equipmentRepository domainEquipment.IEquipmentRepository
+	scheduleRepository  domainSchedule.IScheduleRepository
+	userRepository      domainUser.IUserRepository
+	notifier            notification.INotifier
+	Logger              *logger.Logger
+}
+
+func NewEquipmentUseCase(equipmentRepository domainEquipment.IEquipmentRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, notifier notification.INotifier, loggerInstance *logger.Logger) IEquipmentUseCase {
+	return &EquipmentUseCase{
+		equipmentRepository: equipmentRepository,
+		scheduleRepository:  scheduleRepository,
+		userRepository:      userRepository,
+		notifier:            notifier,
+		Logger:              loggerInstance,
+	}
+}
+
+// CreateEquipment adds an asset as available. When it has a maintenance
+// interval and a last service date but no due date, the due date follows
+// from the two.
+func (u *EquipmentUseCase) CreateEquipment(newEquipment *domainEquipment.Equipment) (*domainEquipment.Equipment, error) {
+	u.Logger.Info("Creating equipment", zap.String("name", newEquipment.Name), zap.String("type", newEquipment.Type))
+	if err := validateEquipment(newEquipment); err != nil {
+		return nil, err
+	}
+	if newEquipment.NextMaintenanceDue == nil && newEquipment.LastMaintainedAt != nil && newEquipment.MaintenanceIntervalDays > 0 {
+		due := newEquipment.LastMaintainedAt.AddDate(0, 0, newEquipment.MaintenanceIntervalDays)
+		newEquipment.NextMaintenanceDue = &due
+	}
+	newEquipment.ID = uuid.New()
+	newEquipment.Status = domainEquipment.StatusAvailable
+	return u.equipmentRepository.CreateEquipment(newEquipment)
+}
+
+func (u *EquipmentUseCase) GetEquipment(status string) (*[]domainEquipment.Equipment, error) {
+	return u.equipmentRepository.GetEquipment(status)
+}
+
+func (u *EquipmentUseCase) GetEquipmentByID(id uuid.UUID) (*domainEquipment.Equipment, error) {
+	return u.equipmentRepository.GetEquipmentByID(id)
+}
+
+// UpdateEquipment edits an asset. The status can be moved between available,
+// maintenance and retired, but not while the equipment is checked out.
+// Moving the due date re-arms its reminder.
+func (u *EquipmentUseCase) UpdateEquipment(id uuid.UUID, updates map[string]interface{}) (*domainEquipment.Equipment, error) {
+	u.Logger.Info("Updating equipment", zap.String("id", id.String()))
+	existing, err := u.equipmentRepository.GetEquipmentByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["name"].(string); ok {
+		candidate.Name = v
+	}
+	if v, ok := updates["type"].(string); ok {
+		candidate.Type = v
+	}
+	if v, ok := updates["maintenance_interval_days"].(int); ok {
+		candidate.MaintenanceIntervalDays = v
+	}
+	if err := validateEquipment(&candidate); err != nil {
+		return nil, err
+	}
+	if status, ok := updates["status"].(string); ok {
+		switch status {
+		case domainEquipment.StatusAvailable, domainEquipment.StatusMaintenance, domainEquipment.StatusRetired:
+		default:
+			return nil, domainErrors.NewAppError(errors.New("status must be 'available', 'maintenance' or 'retired'"), domainErrors.ValidationError)
+		}
+		if existing.Status == domainEquipment.StatusCheckedOut {
+			return nil, domainErrors.NewAppError(errors.New("the equipment is checked out; return it first"), domainErrors.Conflict)
+		}
+	}
+	if _, ok := updates["next_maintenance_due"]; ok {
+		updates["reminder_sent_at"] = nil
+	}
+	return u.equipmentRepository.UpdateEquipment(id, updates)
+}
+
+func (u *EquipmentUseCase) DeleteEquipment(id uuid.UUID) error {
+	u.Logger.Info("Deleting equipment", zap.String("id", id.String()))
+	existing, err := u.equipmentRepository.GetEquipmentByID(id)
+	if err != nil {
+		return err
+	}
+	if existing.Status == domainEquipment.StatusCheckedOut {
+		return domainErrors.NewAppError(errors.New("the equipment is checked out; return it first"), domainErrors.Conflict)
+	}
+	return u.equipmentRepository.DeleteEquipment(id)
+}
+
+// Checkout lends available equipment to a caregiver or a client. When it is
+// for a visit, the holder defaults to the visit's caregiver and must be on
+// the visit, and the equipment is due back when the visit is scheduled to
+// end. Equipment past its maintenance date cannot go out.
+func (u *EquipmentUseCase) Checkout(newCheckout *domainEquipment.Checkout, now time.Time) (*domainEquipment.Checkout, error) {
+	u.Logger.Info("Checking out equipment", zap.String("equipmentID", newCheckout.EquipmentID.String()))
+	equipment, err := u.equipmentRepository.GetEquipmentByID(newCheckout.EquipmentID)
+	if err != nil {
+		return nil, err
+	}
+	switch equipment.Status {
+	case domainEquipment.StatusAvailable:
+	case domainEquipment.StatusCheckedOut:
+		return nil, domainErrors.NewAppError(errors.New("the equipment is already checked out"), domainErrors.Conflict)
+	default:
+		return nil, domainErrors.NewAppError(fmt.Errorf("equipment in %s cannot be checked out", equipment.Status), domainErrors.ValidationError)
+	}
+	if equipment.MaintenanceOverdue(now) {
+		return nil, domainErrors.NewAppError(errors.New("the equipment is overdue for maintenance"), domainErrors.ValidationError)
+	}
+	if newCheckout.DueBackAt != nil && !newCheckout.DueBackAt.After(now) {
+		return nil, domainErrors.NewAppError(errors.New("the due back time must be in the future"), domainErrors.ValidationError)
+	}
+
+	if newCheckout.ScheduleID != nil {
+		schedule, err := u.scheduleRepository.GetScheduleByID(*newCheckout.ScheduleID)
+		if err != nil {
+			return nil, err
+		}
+		if newCheckout.CaregiverUserID == nil && newCheckout.ClientUserID == nil {
+			caregiverID := schedule.AssignedUserID
+			newCheckout.CaregiverUserID = &caregiverID
+		}
+		if (newCheckout.CaregiverUserID != nil && *newCheckout.CaregiverUserID != schedule.AssignedUserID) ||
+			(newCheckout.ClientUserID != nil && *newCheckout.ClientUserID != schedule.ClientUserID) {
+			return nil, domainErrors.NewAppError(errors.New("the holder is not on the visit"), domainErrors.ValidationError)
+		}
+		if newCheckout.DueBackAt == nil {
+			dueBack := schedule.ScheduledSlot.To
+			newCheckout.DueBackAt = &dueBack
+		}
+	}
+	if (newCheckout.CaregiverUserID == nil) == (newCheckout.ClientUserID == nil) {
+		return nil, domainErrors.NewAppError(errors.New("equipment is checked out to either a caregiver or a client"), domainErrors.ValidationError)
+	}
+	if newCheckout.CaregiverUserID != nil {
+		if err := u.requireRole(*newCheckout.CaregiverUserID, domainUser.RoleCaregiver); err != nil {
+			return nil, err
+		}
+	} else if err := u.requireRole(*newCheckout.ClientUserID, domainUser.RoleClient); err != nil {
+		return nil, err
+	}
+
+	newCheckout.ID = uuid.New()
+	newCheckout.CheckedOutAt = now
+	created, err := u.equipmentRepository.CreateCheckout(newCheckout)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.equipmentRepository.UpdateEquipment(equipment.ID, map[string]interface{}{"status": domainEquipment.StatusCheckedOut}); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Return closes an open checkout and makes the equipment available again, or
+// sends it to maintenance when it came back needing service.
+func (u *EquipmentUseCase) Return(checkoutID uuid.UUID, condition string, needsMaintenance bool, now time.Time) (*domainEquipment.Checkout, error) {
+	u.Logger.Info("Returning equipment", zap.String("checkoutID", checkoutID.String()))
+	checkout, err := u.equipmentRepository.GetCheckoutByID(checkoutID)
+	if err != nil {
+		return nil, err
+	}
+	if checkout.ReturnedAt != nil {
+		return nil, domainErrors.NewAppError(errors.New("the equipment has already been returned"), domainErrors.Conflict)
+	}
+	updated, err := u.equipmentRepository.UpdateCheckout(checkoutID, map[string]interface{}{
+		"returned_at":  now,
+		"condition_in": condition,
+	})
+	if err != nil {
+		return nil, err
+	}
+	status := domainEquipment.StatusAvailable
+	if needsMaintenance {
+		status = domainEquipment.StatusMaintenance
+	}
+	if _, err := u.equipmentRepository.UpdateEquipment(checkout.EquipmentID, map[string]interface{}{"status": status}); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (u *EquipmentUseCase) GetCheckouts(filter domainEquipment.CheckoutFilter) (*[]domainEquipment.Checkout, error) {
+	return u.equipmentRepository.GetCheckouts(filter)
+}
+
+// RecordMaintenance logs a service, moves the due date on by the
+// maintenance interval and re-arms the reminder. Equipment that was in
+// maintenance becomes available again.
+func (u *EquipmentUseCase) RecordMaintenance(record *domainEquipment.MaintenanceRecord) (*domainEquipment.MaintenanceRecord, error) {
+	u.Logger.Info("Recording equipment maintenance", zap.String("equipmentID", record.EquipmentID.String()))
+	equipment, err := u.equipmentRepository.GetEquipmentByID(record.EquipmentID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(record.Description) == "" {
+		return nil, domainErrors.NewAppError(errors.New("a description of the work is required"), domainErrors.ValidationError)
+	}
+	if record.PerformedAt.IsZero() {
+		return nil, domainErrors.NewAppError(errors.New("the service date is required"), domainErrors.ValidationError)
+	}
+	if record.Cost < 0 {
+		return nil, domainErrors.NewAppError(errors.New("cost must not be negative"), domainErrors.ValidationError)
+	}
+	record.ID = uuid.New()
+	created, err := u.equipmentRepository.CreateMaintenanceRecord(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if equipment.LastMaintainedAt != nil && equipment.LastMaintainedAt.After(record.PerformedAt) {
+		// A late entry for an older service does not move the schedule.
+		return created, nil
+	}
+	updates := map[string]interface{}{
+		"last_maintained_at": record.PerformedAt,
+		"reminder_sent_at":   nil,
+	}
+	if equipment.MaintenanceIntervalDays > 0 {
+		updates["next_maintenance_due"] = record.PerformedAt.AddDate(0, 0, equipment.MaintenanceIntervalDays)
+	} else {
+		updates["next_maintenance_due"] = nil
+	}
+	if equipment.Status == domainEquipment.StatusMaintenance {
+		updates["status"] = domainEquipment.StatusAvailable
+	}
+	if _, err := u.equipmentRepository.UpdateEquipment(equipment.ID, updates); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (u *EquipmentUseCase) GetMaintenanceRecords(equipmentID uuid.UUID) (*[]domainEquipment.MaintenanceRecord, error) {
+	return u.equipmentRepository.GetMaintenanceRecords(equipmentID)
+}
+
+// Sweep reminds coordinators of equipment coming due for maintenance within
+// MaintenanceReminderLead, and the caregiver holding it if it is checked
+// out. Each due date is reminded of once.
+func (u *EquipmentUseCase) Sweep(now time.Time) (int, error) {
+	due, err := u.equipmentRepository.GetMaintenanceDue(now.Add(MaintenanceReminderLead))
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for i := range *due {
+		equipment := &(*due)[i]
+		message := notification.Message{
+			Role:    domainUser.RoleAdmin,
+			Subject: "Equipment maintenance due",
+			Body:    fmt.Sprintf("%s (%s) is due for maintenance on %s.", equipment.Name, equipment.Identifier, equipment.NextMaintenanceDue.Format("2006-01-02")),
+			Data: map[string]interface{}{
+				"equipmentID":        equipment.ID,
+				"nextMaintenanceDue": equipment.NextMaintenanceDue,
+			},
+		}
+		if err := u.notifier.Notify(message); err != nil {
+			u.Logger.Error("Error sending equipment maintenance reminder", zap.Error(err), zap.String("equipmentID", equipment.ID.String()))
+			continue
+		}
+		if equipment.Status == domainEquipment.StatusCheckedOut {
+			u.notifyHolder(equipment, message)
+		}
+		if _, err := u.equipmentRepository.UpdateEquipment(equipment.ID, map[string]interface{}{"reminder_sent_at": now}); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (u *EquipmentUseCase) notifyHolder(equipment *domainEquipment.Equipment, message notification.Message) {
+	checkouts, err := u.equipmentRepository.GetCheckouts(domainEquipment.CheckoutFilter{EquipmentID: &equipment.ID, OpenOnly: true})
+	if err != nil || len(*checkouts) == 0 || (*checkouts)[0].CaregiverUserID == nil {
+		return
+	}
+	message.Role = ""
+	message.UserID = (*checkouts)[0].CaregiverUserID
+	message.Body += " Please arrange to bring it in."
+	if err := u.notifier.Notify(message); err != nil {
+		u.Logger.Error("Error reminding equipment holder of maintenance", zap.Error(err), zap.String("equipmentID", equipment.ID.String()))
+	}
+}
+
+func (u *EquipmentUseCase) requireRole(userID uuid.UUID, role string) error {
+	user, err := u.userRepository.GetByID(userID)
+	if err != nil {
+		return domainErrors.NewAppError(fmt.Errorf("%s not found", role), domainErrors.NotFound)
+	}
+	if user.Role != role {
+		return domainErrors.NewAppError(fmt.Errorf("the holder is not a %s", role), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func validateEquipment(e *domainEquipment.Equipment) error {
+	if strings.TrimSpace(e.Name) == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	if !equipmentTypes[e.Type] {
+		return domainErrors.NewAppError(errors.New("type must be 'vehicle', 'hoist', 'mobility_aid' or 'other'"), domainErrors.ValidationError)
+	}
+	if e.MaintenanceIntervalDays < 0 {
+		return domainErrors.NewAppError(errors.New("maintenance interval must not be negative"), domainErrors.ValidationError)
+	}
+	return nil
+}
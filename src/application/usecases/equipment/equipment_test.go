@@ -0,0 +1,304 @@
+package equipment
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainEquipment "caregiver/src/domain/equipment"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+// mockEquipmentRepository keeps equipment and checkouts in memory
+type mockEquipmentRepository struct {
+	domainEquipment.IEquipmentRepository
+	equipment map[uuid.UUID]*domainEquipment.Equipment
+	checkouts []domainEquipment.Checkout
+}
+
+func (m *mockEquipmentRepository) CreateEquipment(newEquipment *domainEquipment.Equipment) (*domainEquipment.Equipment, error) {
+	equipment := *newEquipment
+	m.equipment[equipment.ID] = &equipment
+	return newEquipment, nil
+}
+
+func (m *mockEquipmentRepository) GetEquipmentByID(id uuid.UUID) (*domainEquipment.Equipment, error) {
+	if equipment, ok := m.equipment[id]; ok {
+		copied := *equipment
+		return &copied, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockEquipmentRepository) UpdateEquipment(id uuid.UUID, updates map[string]interface{}) (*domainEquipment.Equipment, error) {
+	equipment, ok := m.equipment[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	for key, value := range updates {
+		switch key {
+		case "status":
+			equipment.Status = value.(string)
+		case "last_maintained_at":
+			at := value.(time.Time)
+			equipment.LastMaintainedAt = &at
+		case "next_maintenance_due":
+			if at, ok := value.(time.Time); ok {
+				equipment.NextMaintenanceDue = &at
+			} else {
+				equipment.NextMaintenanceDue = nil
+			}
+		case "reminder_sent_at":
+			if at, ok := value.(time.Time); ok {
+				equipment.ReminderSentAt = &at
+			} else {
+				equipment.ReminderSentAt = nil
+			}
+		}
+	}
+	copied := *equipment
+	return &copied, nil
+}
+
+func (m *mockEquipmentRepository) GetMaintenanceDue(before time.Time) (*[]domainEquipment.Equipment, error) {
+	res := []domainEquipment.Equipment{}
+	for _, equipment := range m.equipment {
+		if equipment.Status == domainEquipment.StatusRetired || equipment.ReminderSentAt != nil {
+			continue
+		}
+		if equipment.NextMaintenanceDue != nil && !equipment.NextMaintenanceDue.After(before) {
+			res = append(res, *equipment)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockEquipmentRepository) CreateCheckout(newCheckout *domainEquipment.Checkout) (*domainEquipment.Checkout, error) {
+	m.checkouts = append(m.checkouts, *newCheckout)
+	return newCheckout, nil
+}
+
+func (m *mockEquipmentRepository) GetCheckoutByID(id uuid.UUID) (*domainEquipment.Checkout, error) {
+	for _, checkout := range m.checkouts {
+		if checkout.ID == id {
+			return &checkout, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockEquipmentRepository) GetCheckouts(filter domainEquipment.CheckoutFilter) (*[]domainEquipment.Checkout, error) {
+	res := []domainEquipment.Checkout{}
+	for _, checkout := range m.checkouts {
+		if filter.EquipmentID != nil && checkout.EquipmentID != *filter.EquipmentID {
+			continue
+		}
+		if filter.OpenOnly && checkout.ReturnedAt != nil {
+			continue
+		}
+		res = append(res, checkout)
+	}
+	return &res, nil
+}
+
+func (m *mockEquipmentRepository) UpdateCheckout(id uuid.UUID, updates map[string]interface{}) (*domainEquipment.Checkout, error) {
+	for i := range m.checkouts {
+		if m.checkouts[i].ID != id {
+			continue
+		}
+		if at, ok := updates["returned_at"].(time.Time); ok {
+			m.checkouts[i].ReturnedAt = &at
+		}
+		if condition, ok := updates["condition_in"].(string); ok {
+			m.checkouts[i].ConditionIn = condition
+		}
+		copied := m.checkouts[i]
+		return &copied, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockEquipmentRepository) CreateMaintenanceRecord(record *domainEquipment.MaintenanceRecord) (*domainEquipment.MaintenanceRecord, error) {
+	return record, nil
+}
+
+// mockScheduleRepository returns visits by ID
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockUserRepository returns users by ID
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockNotifier records the messages sent
+type mockNotifier struct {
+	messages []notification.Message
+}
+
+func (m *mockNotifier) Notify(message notification.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+type testDeps struct {
+	equipment *mockEquipmentRepository
+	schedules *mockScheduleRepository
+	users     *mockUserRepository
+	notifier  *mockNotifier
+}
+
+func setupTestEquipmentUseCase(t *testing.T) (IEquipmentUseCase, *testDeps) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	deps := &testDeps{
+		equipment: &mockEquipmentRepository{equipment: make(map[uuid.UUID]*domainEquipment.Equipment)},
+		schedules: &mockScheduleRepository{},
+		users:     &mockUserRepository{users: make(map[uuid.UUID]*domainUser.User)},
+		notifier:  &mockNotifier{},
+	}
+	return NewEquipmentUseCase(deps.equipment, deps.schedules, deps.users, deps.notifier, loggerInstance), deps
+}
+
+func (d *testDeps) addUser(role string) uuid.UUID {
+	id := uuid.New()
+	d.users.users[id] = &domainUser.User{ID: id, Role: role}
+	return id
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestCheckoutAndReturn(t *testing.T) {
+	useCase, deps := setupTestEquipmentUseCase(t)
+	now := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+	hoist, err := useCase.CreateEquipment(&domainEquipment.Equipment{Name: "Ceiling hoist", Type: domainEquipment.TypeHoist, Identifier: "H-100"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caregiverID := deps.addUser(domainUser.RoleCaregiver)
+	clientID := deps.addUser(domainUser.RoleClient)
+	visit := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: clientID, AssignedUserID: caregiverID}
+	visit.ScheduledSlot.From = now.Add(time.Hour)
+	visit.ScheduledSlot.To = now.Add(3 * time.Hour)
+	deps.schedules.schedules = append(deps.schedules.schedules, visit)
+
+	t.Run("Holder not on the visit", func(t *testing.T) {
+		other := deps.addUser(domainUser.RoleCaregiver)
+		_, err := useCase.Checkout(&domainEquipment.Checkout{EquipmentID: hoist.ID, ScheduleID: &visit.ID, CaregiverUserID: &other}, now)
+		if errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	checkout, err := useCase.Checkout(&domainEquipment.Checkout{EquipmentID: hoist.ID, ScheduleID: &visit.ID}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checkout.CaregiverUserID == nil || *checkout.CaregiverUserID != caregiverID || !checkout.DueBackAt.Equal(visit.ScheduledSlot.To) {
+		t.Errorf("expected the checkout to go to the visit's caregiver until the visit ends, got %+v", checkout)
+	}
+	if deps.equipment.equipment[hoist.ID].Status != domainEquipment.StatusCheckedOut {
+		t.Errorf("expected the hoist to be checked out, got %s", deps.equipment.equipment[hoist.ID].Status)
+	}
+
+	if _, err := useCase.Checkout(&domainEquipment.Checkout{EquipmentID: hoist.ID, ClientUserID: &clientID}, now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected conflict for a second checkout, got %v", err)
+	}
+	if err := useCase.DeleteEquipment(hoist.ID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected conflict deleting checked-out equipment, got %v", err)
+	}
+
+	returned, err := useCase.Return(checkout.ID, "sling frayed", true, now.Add(4*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if returned.ReturnedAt == nil || returned.ConditionIn != "sling frayed" {
+		t.Errorf("expected the return to be logged, got %+v", returned)
+	}
+	if deps.equipment.equipment[hoist.ID].Status != domainEquipment.StatusMaintenance {
+		t.Errorf("expected the hoist to go to maintenance, got %s", deps.equipment.equipment[hoist.ID].Status)
+	}
+	if _, err := useCase.Return(checkout.ID, "", false, now.Add(5*time.Hour)); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected conflict returning twice, got %v", err)
+	}
+	if _, err := useCase.Checkout(&domainEquipment.Checkout{EquipmentID: hoist.ID, ClientUserID: &clientID}, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected equipment in maintenance to be refused, got %v", err)
+	}
+
+	if _, err := useCase.RecordMaintenance(&domainEquipment.MaintenanceRecord{EquipmentID: hoist.ID, PerformedAt: now.Add(24 * time.Hour), Description: "Replaced sling"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps.equipment.equipment[hoist.ID].Status != domainEquipment.StatusAvailable {
+		t.Errorf("expected the hoist back in service after maintenance, got %s", deps.equipment.equipment[hoist.ID].Status)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	useCase, deps := setupTestEquipmentUseCase(t)
+	now := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+	lastService := now.AddDate(0, 0, -85)
+	van, _ := useCase.CreateEquipment(&domainEquipment.Equipment{Name: "Van", Type: domainEquipment.TypeVehicle, Identifier: "AB12 CDE", MaintenanceIntervalDays: 90, LastMaintainedAt: &lastService})
+	useCase.CreateEquipment(&domainEquipment.Equipment{Name: "Walker", Type: domainEquipment.TypeMobilityAid, MaintenanceIntervalDays: 365, LastMaintainedAt: &lastService})
+	caregiverID := deps.addUser(domainUser.RoleCaregiver)
+	if _, err := useCase.Checkout(&domainEquipment.Checkout{EquipmentID: van.ID, CaregiverUserID: &caregiverID}, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sent, err := useCase.Sweep(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 1 {
+		t.Fatalf("expected one reminder for the van, got %d", sent)
+	}
+	if len(deps.notifier.messages) != 2 || deps.notifier.messages[0].Role != domainUser.RoleAdmin || *deps.notifier.messages[1].UserID != caregiverID {
+		t.Errorf("expected coordinators and the van's holder to be reminded, got %+v", deps.notifier.messages)
+	}
+
+	if sent, _ := useCase.Sweep(now.Add(time.Hour)); sent != 0 {
+		t.Errorf("expected the due date to be reminded of once, got %d", sent)
+	}
+
+	if _, err := useCase.RecordMaintenance(&domainEquipment.MaintenanceRecord{EquipmentID: van.ID, PerformedAt: now, Description: "Service"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if due := deps.equipment.equipment[van.ID].NextMaintenanceDue; due == nil || !due.Equal(now.AddDate(0, 0, 90)) {
+		t.Errorf("expected the next service in 90 days, got %v", due)
+	}
+	if sent, _ := useCase.Sweep(now.AddDate(0, 0, 85)); sent != 1 {
+		t.Errorf("expected the new due date to be reminded of, got %d", sent)
+	}
+}
@@ -0,0 +1,422 @@
+package evv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainEVV "caregiver/src/domain/evv"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	evvAdapter "caregiver/src/infrastructure/evv"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxAttempts is how many delivery tries a submission gets before it is
+	// marked failed and needs a manual resubmission.
+	MaxAttempts = 8
+	// RetryBaseDelay is the wait after the first failed try; it doubles with
+	// each further try up to RetryMaxDelay.
+	RetryBaseDelay = time.Minute
+	RetryMaxDelay  = 6 * time.Hour
+	// SweepBatchSize bounds how many submissions one sweep sends.
+	SweepBatchSize = 100
+)
+
+type IEVVUseCase interface {
+	CreateAggregator(newAggregator *domainEVV.Aggregator) (*domainEVV.Aggregator, error)
+	GetAggregators() (*[]domainEVV.Aggregator, error)
+	GetAggregatorByID(id uuid.UUID) (*domainEVV.Aggregator, error)
+	UpdateAggregator(id uuid.UUID, updates map[string]interface{}) (*domainEVV.Aggregator, error)
+	DeleteAggregator(id uuid.UUID) error
+	Enqueue(scheduleID uuid.UUID) (*domainEVV.Submission, error)
+	Resubmit(id uuid.UUID) (*domainEVV.Submission, error)
+	GetSubmissions(filter domainEVV.SubmissionFilter) (*[]domainEVV.Submission, error)
+	GetSubmission(id uuid.UUID) (*domainEVV.Submission, *[]domainEVV.Attempt, error)
+	Sweep(now time.Time) (int, error)
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+type EVVUseCase struct {
+	evvRepository      domainEVV.IEVVRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	adapters           evvAdapter.Registry
+	allowedHosts       map[string]bool
+	Logger             *logger.Logger
+}
+
+type Option func(*EVVUseCase)
+
+// WithAllowedHosts lists the aggregator hosts visits and credentials may be
+// sent to. Aggregators pointing anywhere else are refused; without this
+// option no endpoint is accepted.
+func WithAllowedHosts(hosts ...string) Option {
+	return func(u *EVVUseCase) {
+		for _, host := range hosts {
+			if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+				u.allowedHosts[host] = true
+			}
+		}
+	}
+}
+
+func NewEVVUseCase(evvRepository domainEVV.IEVVRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, adapters evvAdapter.Registry, loggerInstance *logger.Logger, opts ...Option) IEVVUseCase {
+	u := &EVVUseCase{
+		evvRepository:      evvRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		adapters:           adapters,
+		allowedHosts:       map[string]bool{},
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+func (u *EVVUseCase) CreateAggregator(newAggregator *domainEVV.Aggregator) (*domainEVV.Aggregator, error) {
+	u.Logger.Info("Creating EVV aggregator", zap.String("name", newAggregator.Name), zap.String("state", newAggregator.State))
+	newAggregator.State = strings.ToUpper(strings.TrimSpace(newAggregator.State))
+	if err := u.validateAggregator(newAggregator); err != nil {
+		return nil, err
+	}
+	keyEnv, err := resolveKeyEnv(newAggregator.State, newAggregator.APIKeyEnv)
+	if err != nil {
+		return nil, err
+	}
+	newAggregator.APIKeyEnv = keyEnv
+	return u.evvRepository.CreateAggregator(newAggregator)
+}
+
+func (u *EVVUseCase) GetAggregators() (*[]domainEVV.Aggregator, error) {
+	return u.evvRepository.GetAggregators()
+}
+
+func (u *EVVUseCase) GetAggregatorByID(id uuid.UUID) (*domainEVV.Aggregator, error) {
+	return u.evvRepository.GetAggregatorByID(id)
+}
+
+func (u *EVVUseCase) UpdateAggregator(id uuid.UUID, updates map[string]interface{}) (*domainEVV.Aggregator, error) {
+	u.Logger.Info("Updating EVV aggregator", zap.String("id", id.String()))
+	existing, err := u.evvRepository.GetAggregatorByID(id)
+	if err != nil {
+		return nil, err
+	}
+	merged := *existing
+	if v, ok := updates["name"].(string); ok {
+		merged.Name = v
+	}
+	if v, ok := updates["state"].(string); ok {
+		merged.State = strings.ToUpper(strings.TrimSpace(v))
+		updates["state"] = merged.State
+	}
+	if v, ok := updates["adapter"].(string); ok {
+		merged.Adapter = v
+	}
+	if v, ok := updates["endpoint"].(string); ok {
+		merged.Endpoint = v
+	}
+	if err := u.validateAggregator(&merged); err != nil {
+		return nil, err
+	}
+	requested, _ := updates["api_key_env"].(string)
+	keyEnv, err := resolveKeyEnv(merged.State, requested)
+	if err != nil {
+		return nil, err
+	}
+	updates["api_key_env"] = keyEnv
+	return u.evvRepository.UpdateAggregator(id, updates)
+}
+
+func (u *EVVUseCase) DeleteAggregator(id uuid.UUID) error {
+	u.Logger.Info("Deleting EVV aggregator", zap.String("id", id.String()))
+	return u.evvRepository.DeleteAggregator(id)
+}
+
+func (u *EVVUseCase) validateAggregator(a *domainEVV.Aggregator) error {
+	if strings.TrimSpace(a.Name) == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	if !statePattern.MatchString(a.State) {
+		return domainErrors.NewAppError(errors.New("state must be a two-letter state code"), domainErrors.ValidationError)
+	}
+	if _, ok := u.adapters[a.Adapter]; !ok {
+		return domainErrors.NewAppError(fmt.Errorf("unknown adapter %q", a.Adapter), domainErrors.ValidationError)
+	}
+	endpoint, err := url.Parse(a.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return domainErrors.NewAppError(errors.New("endpoint must be an https URL"), domainErrors.ValidationError)
+	}
+	if !u.allowedHosts[strings.ToLower(endpoint.Hostname())] {
+		return domainErrors.NewAppError(fmt.Errorf("endpoint host %q is not an allowed EVV aggregator", endpoint.Hostname()), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+var statePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// resolveKeyEnv returns the credential variable for a state's aggregator.
+// The name is fixed by the state; a caller asking for any other variable is
+// refused rather than given access to unrelated secrets.
+func resolveKeyEnv(state, requested string) (string, error) {
+	keyEnv := domainEVV.KeyEnvForState(state)
+	if requested != "" && requested != keyEnv {
+		return "", domainErrors.NewAppError(fmt.Errorf("api key variable must be %s", keyEnv), domainErrors.ValidationError)
+	}
+	return keyEnv, nil
+}
+
+// Enqueue queues a completed visit for submission. A visit that was rejected
+// or failed is queued again, which is how corrected visits are resubmitted;
+// one the aggregator already accepted cannot be sent twice.
+func (u *EVVUseCase) Enqueue(scheduleID uuid.UUID) (*domainEVV.Submission, error) {
+	u.Logger.Info("Queueing visit for EVV submission", zap.String("scheduleID", scheduleID.String()))
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.VisitStatus != "completed" {
+		return nil, domainErrors.NewAppError(errors.New("only completed visits can be submitted"), domainErrors.ValidationError)
+	}
+
+	existing, err := u.evvRepository.GetSubmissionBySchedule(scheduleID)
+	if err != nil {
+		if !isNotFound(err) {
+			return nil, err
+		}
+		return u.evvRepository.CreateSubmission(&domainEVV.Submission{
+			ScheduleID: scheduleID,
+			Status:     domainEVV.StatusQueued,
+		})
+	}
+	return u.requeue(existing)
+}
+
+// Resubmit queues a rejected or failed submission again.
+func (u *EVVUseCase) Resubmit(id uuid.UUID) (*domainEVV.Submission, error) {
+	u.Logger.Info("Resubmitting EVV submission", zap.String("id", id.String()))
+	submission, err := u.evvRepository.GetSubmissionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.requeue(submission)
+}
+
+func (u *EVVUseCase) requeue(submission *domainEVV.Submission) (*domainEVV.Submission, error) {
+	switch submission.Status {
+	case domainEVV.StatusAccepted:
+		return nil, domainErrors.NewAppError(errors.New("the aggregator has already accepted this visit"), domainErrors.Conflict)
+	case domainEVV.StatusQueued:
+		return submission, nil
+	}
+	return u.evvRepository.UpdateSubmission(submission.ID, map[string]interface{}{
+		"status":           domainEVV.StatusQueued,
+		"attempts":         0,
+		"next_attempt_at":  nil,
+		"rejection_code":   "",
+		"rejection_reason": "",
+	})
+}
+
+func (u *EVVUseCase) GetSubmissions(filter domainEVV.SubmissionFilter) (*[]domainEVV.Submission, error) {
+	return u.evvRepository.GetSubmissions(filter)
+}
+
+// GetSubmission returns a submission with its delivery history.
+func (u *EVVUseCase) GetSubmission(id uuid.UUID) (*domainEVV.Submission, *[]domainEVV.Attempt, error) {
+	submission, err := u.evvRepository.GetSubmissionByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	attempts, err := u.evvRepository.GetAttempts(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return submission, attempts, nil
+}
+
+// OnScheduleEvent queues visits as soon as they are completed.
+func (u *EVVUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	if event.Type != domainSchedule.EventCompleted {
+		return
+	}
+	if _, err := u.Enqueue(event.Schedule.ID); err != nil {
+		u.Logger.Error("Error queueing visit for EVV submission", zap.Error(err), zap.String("scheduleID", event.Schedule.ID.String()))
+	}
+}
+
+// Sweep sends the queued submissions that are due and returns how many were
+// attempted.
+func (u *EVVUseCase) Sweep(now time.Time) (int, error) {
+	submissions, err := u.evvRepository.GetDueSubmissions(now, SweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	attempted := 0
+	for i := range *submissions {
+		if err := u.submit(&(*submissions)[i], now); err != nil {
+			u.Logger.Error("Error submitting visit to EVV aggregator", zap.Error(err), zap.String("submissionID", (*submissions)[i].ID.String()))
+			continue
+		}
+		attempted++
+	}
+	if attempted > 0 {
+		u.Logger.Info("EVV submission sweep completed", zap.Int("attempted", attempted))
+	}
+	return attempted, nil
+}
+
+func (u *EVVUseCase) submit(submission *domainEVV.Submission, now time.Time) error {
+	schedule, err := u.scheduleRepository.GetScheduleByID(submission.ScheduleID)
+	if err != nil {
+		if isNotFound(err) {
+			return u.fail(submission, nil, now, "VISIT_NOT_FOUND", "the visit no longer exists")
+		}
+		return err
+	}
+	if schedule.CheckinTime == nil || schedule.CheckoutTime == nil {
+		return u.fail(submission, nil, now, "INCOMPLETE_VISIT", "the visit has no check-in or check-out time")
+	}
+	client, err := u.userRepository.GetByID(schedule.ClientUserID)
+	if err != nil {
+		return err
+	}
+	caregiver, err := u.userRepository.GetByID(schedule.AssignedUserID)
+	if err != nil {
+		return err
+	}
+
+	aggregator, err := u.evvRepository.GetActiveAggregatorByState(client.Location.State)
+	if err != nil {
+		if isNotFound(err) {
+			return u.fail(submission, nil, now, "NO_AGGREGATOR", fmt.Sprintf("no active aggregator for state %q", client.Location.State))
+		}
+		return err
+	}
+	adapter, ok := u.adapters[aggregator.Adapter]
+	if !ok {
+		return u.fail(submission, &aggregator.ID, now, "UNKNOWN_ADAPTER", fmt.Sprintf("adapter %q is not available", aggregator.Adapter))
+	}
+
+	record := buildRecord(schedule, client, caregiver)
+	result, submitErr := adapter.Submit(context.Background(), aggregator, record)
+	attempts := submission.Attempts + 1
+	updates := map[string]interface{}{
+		"aggregator_id":   aggregator.ID,
+		"attempts":        attempts,
+		"last_attempt_at": now,
+	}
+
+	attempt := &domainEVV.Attempt{SubmissionID: submission.ID, AggregatorID: &aggregator.ID, AttemptedAt: now}
+	switch {
+	case submitErr != nil:
+		attempt.Outcome = domainEVV.OutcomeError
+		attempt.Message = submitErr.Error()
+		if attempts >= MaxAttempts {
+			updates["status"] = domainEVV.StatusFailed
+			updates["next_attempt_at"] = nil
+			updates["rejection_code"] = "DELIVERY_FAILED"
+			updates["rejection_reason"] = submitErr.Error()
+		} else {
+			updates["next_attempt_at"] = now.Add(retryDelay(attempts))
+		}
+	case result.Accepted:
+		attempt.Outcome = domainEVV.OutcomeAccepted
+		attempt.Code = result.Code
+		attempt.Message = result.Message
+		updates["status"] = domainEVV.StatusAccepted
+		updates["external_id"] = result.ExternalID
+		updates["accepted_at"] = now
+		updates["next_attempt_at"] = nil
+	default:
+		attempt.Outcome = domainEVV.OutcomeRejected
+		attempt.Code = result.Code
+		attempt.Message = result.Message
+		updates["status"] = domainEVV.StatusRejected
+		updates["rejection_code"] = result.Code
+		updates["rejection_reason"] = result.Message
+		updates["next_attempt_at"] = nil
+		u.Logger.Warn("EVV aggregator rejected visit", zap.String("scheduleID", schedule.ID.String()), zap.String("code", result.Code))
+	}
+
+	if _, err := u.evvRepository.CreateAttempt(attempt); err != nil {
+		u.Logger.Error("Error recording EVV submission attempt", zap.Error(err), zap.String("submissionID", submission.ID.String()))
+	}
+	_, err = u.evvRepository.UpdateSubmission(submission.ID, updates)
+	return err
+}
+
+// fail marks a submission that cannot be delivered without intervention.
+func (u *EVVUseCase) fail(submission *domainEVV.Submission, aggregatorID *uuid.UUID, now time.Time, code, reason string) error {
+	u.Logger.Warn("EVV submission failed", zap.String("submissionID", submission.ID.String()), zap.String("code", code))
+	if _, err := u.evvRepository.CreateAttempt(&domainEVV.Attempt{
+		SubmissionID: submission.ID,
+		AggregatorID: aggregatorID,
+		AttemptedAt:  now,
+		Outcome:      domainEVV.OutcomeError,
+		Code:         code,
+		Message:      reason,
+	}); err != nil {
+		u.Logger.Error("Error recording EVV submission attempt", zap.Error(err), zap.String("submissionID", submission.ID.String()))
+	}
+	_, err := u.evvRepository.UpdateSubmission(submission.ID, map[string]interface{}{
+		"status":           domainEVV.StatusFailed,
+		"attempts":         submission.Attempts + 1,
+		"last_attempt_at":  now,
+		"next_attempt_at":  nil,
+		"rejection_code":   code,
+		"rejection_reason": reason,
+	})
+	return err
+}
+
+func retryDelay(attempts int) time.Duration {
+	delay := RetryBaseDelay
+	for i := 1; i < attempts && delay < RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > RetryMaxDelay {
+		delay = RetryMaxDelay
+	}
+	return delay
+}
+
+func buildRecord(schedule *domainSchedule.Schedule, client, caregiver *domainUser.User) *domainEVV.Record {
+	method := schedule.CheckinVerification.Method
+	if method == "" {
+		method = domainSchedule.VerificationGPS
+	}
+	return &domainEVV.Record{
+		ScheduleID:         schedule.ID,
+		ServiceName:        schedule.ServiceName,
+		ClientUserID:       client.ID,
+		ClientName:         strings.TrimSpace(client.FirstName + " " + client.LastName),
+		CaregiverUserID:    caregiver.ID,
+		CaregiverName:      strings.TrimSpace(caregiver.FirstName + " " + caregiver.LastName),
+		ServiceDate:        schedule.CheckinTime.UTC().Format("2006-01-02"),
+		CheckinTime:        schedule.CheckinTime.UTC(),
+		CheckoutTime:       schedule.CheckoutTime.UTC(),
+		CheckinLat:         schedule.CheckinLocation.Lat,
+		CheckinLong:        schedule.CheckinLocation.Long,
+		CheckoutLat:        schedule.CheckoutLocation.Lat,
+		CheckoutLong:       schedule.CheckoutLocation.Long,
+		VerificationMethod: method,
+		AttestationStatus:  schedule.Attestation.Status,
+	}
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
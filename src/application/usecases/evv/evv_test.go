@@ -0,0 +1,425 @@
+package evv
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainEVV "caregiver/src/domain/evv"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	evvAdapter "caregiver/src/infrastructure/evv"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockEVVRepository keeps aggregators, submissions and attempts in memory
+type mockEVVRepository struct {
+	domainEVV.IEVVRepository
+	aggregators []domainEVV.Aggregator
+	submissions map[uuid.UUID]*domainEVV.Submission
+	attempts    []domainEVV.Attempt
+}
+
+func (m *mockEVVRepository) GetActiveAggregatorByState(state string) (*domainEVV.Aggregator, error) {
+	for i := range m.aggregators {
+		if m.aggregators[i].State == state && m.aggregators[i].Active {
+			return &m.aggregators[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockEVVRepository) CreateAggregator(newAggregator *domainEVV.Aggregator) (*domainEVV.Aggregator, error) {
+	newAggregator.ID = uuid.New()
+	m.aggregators = append(m.aggregators, *newAggregator)
+	return newAggregator, nil
+}
+
+func (m *mockEVVRepository) GetAggregatorByID(id uuid.UUID) (*domainEVV.Aggregator, error) {
+	for i := range m.aggregators {
+		if m.aggregators[i].ID == id {
+			copied := m.aggregators[i]
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockEVVRepository) UpdateAggregator(id uuid.UUID, updates map[string]interface{}) (*domainEVV.Aggregator, error) {
+	for i := range m.aggregators {
+		if m.aggregators[i].ID != id {
+			continue
+		}
+		if v, ok := updates["state"].(string); ok {
+			m.aggregators[i].State = v
+		}
+		if v, ok := updates["endpoint"].(string); ok {
+			m.aggregators[i].Endpoint = v
+		}
+		if v, ok := updates["api_key_env"].(string); ok {
+			m.aggregators[i].APIKeyEnv = v
+		}
+		return m.GetAggregatorByID(id)
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockEVVRepository) CreateSubmission(newSubmission *domainEVV.Submission) (*domainEVV.Submission, error) {
+	newSubmission.ID = uuid.New()
+	m.submissions[newSubmission.ID] = newSubmission
+	copied := *newSubmission
+	return &copied, nil
+}
+
+func (m *mockEVVRepository) GetSubmissionByID(id uuid.UUID) (*domainEVV.Submission, error) {
+	s, ok := m.submissions[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *s
+	return &copied, nil
+}
+
+func (m *mockEVVRepository) GetSubmissionBySchedule(scheduleID uuid.UUID) (*domainEVV.Submission, error) {
+	for _, s := range m.submissions {
+		if s.ScheduleID == scheduleID {
+			copied := *s
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockEVVRepository) GetDueSubmissions(now time.Time, limit int) (*[]domainEVV.Submission, error) {
+	res := []domainEVV.Submission{}
+	for _, s := range m.submissions {
+		if s.Status == domainEVV.StatusQueued && (s.NextAttemptAt == nil || !s.NextAttemptAt.After(now)) {
+			res = append(res, *s)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockEVVRepository) UpdateSubmission(id uuid.UUID, updates map[string]interface{}) (*domainEVV.Submission, error) {
+	s := m.submissions[id]
+	for key, value := range updates {
+		switch key {
+		case "aggregator_id":
+			v := value.(uuid.UUID)
+			s.AggregatorID = &v
+		case "status":
+			s.Status = value.(string)
+		case "attempts":
+			s.Attempts = value.(int)
+		case "external_id":
+			s.ExternalID = value.(string)
+		case "rejection_code":
+			s.RejectionCode = value.(string)
+		case "rejection_reason":
+			s.RejectionReason = value.(string)
+		case "next_attempt_at":
+			s.NextAttemptAt = nil
+			if v, ok := value.(time.Time); ok {
+				s.NextAttemptAt = &v
+			}
+		case "last_attempt_at":
+			v := value.(time.Time)
+			s.LastAttemptAt = &v
+		case "accepted_at":
+			v := value.(time.Time)
+			s.AcceptedAt = &v
+		}
+	}
+	return m.GetSubmissionByID(id)
+}
+
+func (m *mockEVVRepository) CreateAttempt(attempt *domainEVV.Attempt) (*domainEVV.Attempt, error) {
+	m.attempts = append(m.attempts, *attempt)
+	return attempt, nil
+}
+
+// mockScheduleRepository returns a fixed set of schedules
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules map[uuid.UUID]*domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	s, ok := m.schedules[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return s, nil
+}
+
+// mockUserRepository returns a fixed set of users
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	u, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return u, nil
+}
+
+// mockAdapter answers with the queued results in order and records what it
+// was sent
+type mockAdapter struct {
+	results []*evvAdapter.Result
+	errs    []error
+	records []domainEVV.Record
+}
+
+func (m *mockAdapter) Submit(ctx context.Context, aggregator *domainEVV.Aggregator, record *domainEVV.Record) (*evvAdapter.Result, error) {
+	m.records = append(m.records, *record)
+	i := len(m.records) - 1
+	if i < len(m.errs) && m.errs[i] != nil {
+		return nil, m.errs[i]
+	}
+	if i < len(m.results) {
+		return m.results[i], nil
+	}
+	return &evvAdapter.Result{Accepted: true}, nil
+}
+
+var (
+	checkinTime  = time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+	checkoutTime = checkinTime.Add(2 * time.Hour)
+	sweepTime    = checkoutTime.Add(time.Minute)
+)
+
+type testFixture struct {
+	useCase IEVVUseCase
+	evvRepo *mockEVVRepository
+	adapter *mockAdapter
+	visit   *domainSchedule.Schedule
+	client  *domainUser.User
+}
+
+func setupTestEVVUseCase(t *testing.T) *testFixture {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	checkin, checkout := checkinTime, checkoutTime
+	lat, long := 19.43, -99.13
+	client := &domainUser.User{ID: uuid.New(), FirstName: "Ana", LastName: "Ruiz", Location: domainUser.Location{State: "TX"}}
+	caregiver := &domainUser.User{ID: uuid.New(), FirstName: "Luis", LastName: "Mora"}
+	visit := &domainSchedule.Schedule{
+		ID:               uuid.New(),
+		ClientUserID:     client.ID,
+		AssignedUserID:   caregiver.ID,
+		ServiceName:      "Personal care",
+		VisitStatus:      "completed",
+		CheckinTime:      &checkin,
+		CheckoutTime:     &checkout,
+		CheckinLocation:  domainSchedule.Location{Lat: &lat, Long: &long},
+		CheckoutLocation: domainSchedule.Location{Lat: &lat, Long: &long},
+	}
+	evvRepo := &mockEVVRepository{
+		aggregators: []domainEVV.Aggregator{{ID: uuid.New(), Name: "Texas aggregator", State: "TX", Adapter: "rest", Endpoint: "https://evv.example.com/visits", Active: true}},
+		submissions: map[uuid.UUID]*domainEVV.Submission{},
+	}
+	adapter := &mockAdapter{}
+	useCase := NewEVVUseCase(
+		evvRepo,
+		&mockScheduleRepository{schedules: map[uuid.UUID]*domainSchedule.Schedule{visit.ID: visit}},
+		&mockUserRepository{users: map[uuid.UUID]*domainUser.User{client.ID: client, caregiver.ID: caregiver}},
+		evvAdapter.Registry{"rest": adapter},
+		loggerInstance,
+		WithAllowedHosts("evv.example.com"),
+	)
+	return &testFixture{useCase: useCase, evvRepo: evvRepo, adapter: adapter, visit: visit, client: client}
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestAggregatorCredentials(t *testing.T) {
+	newAggregator := func(endpoint, keyEnv string) *domainEVV.Aggregator {
+		return &domainEVV.Aggregator{Name: "Ohio aggregator", State: "oh", Adapter: "rest", Endpoint: endpoint, APIKeyEnv: keyEnv}
+	}
+
+	t.Run("Credential variable is derived from the state", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		created, err := f.useCase.CreateAggregator(newAggregator("https://evv.example.com/visits", ""))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created.APIKeyEnv != "EVV_OH_API_KEY" {
+			t.Errorf("expected EVV_OH_API_KEY, got %q", created.APIKeyEnv)
+		}
+	})
+
+	t.Run("Arbitrary variable is refused", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		if _, err := f.useCase.CreateAggregator(newAggregator("https://evv.example.com/visits", "DB_PASSWORD")); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+		existing := f.evvRepo.aggregators[0].ID
+		if _, err := f.useCase.UpdateAggregator(existing, map[string]interface{}{"api_key_env": "JWT_ACCESS_SECRET_KEY"}); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error on update, got %v", err)
+		}
+		if f.evvRepo.aggregators[0].APIKeyEnv != "" {
+			t.Errorf("variable should not change, got %q", f.evvRepo.aggregators[0].APIKeyEnv)
+		}
+	})
+
+	t.Run("Unknown endpoint is refused", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		if _, err := f.useCase.CreateAggregator(newAggregator("https://attacker.example.net/collect", "")); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+		existing := f.evvRepo.aggregators[0].ID
+		if _, err := f.useCase.UpdateAggregator(existing, map[string]interface{}{"endpoint": "http://evv.example.com/visits"}); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error for plain http, got %v", err)
+		}
+	})
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Run("Only completed visits", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		f.visit.VisitStatus = "in_progress"
+		if _, err := f.useCase.Enqueue(f.visit.ID); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("Completion queues the visit once", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		f.useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCompleted, Schedule: f.visit})
+		f.useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCompleted, Schedule: f.visit})
+		if len(f.evvRepo.submissions) != 1 {
+			t.Fatalf("expected one submission, got %d", len(f.evvRepo.submissions))
+		}
+	})
+
+	t.Run("Accepted visits are not resent", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		if _, err := f.useCase.Enqueue(f.visit.ID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := f.useCase.Sweep(sweepTime); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := f.useCase.Enqueue(f.visit.ID); errorType(err) != domainErrors.Conflict {
+			t.Errorf("expected conflict, got %v", err)
+		}
+	})
+}
+
+func TestSweep(t *testing.T) {
+	t.Run("Accepted", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		f.adapter.results = []*evvAdapter.Result{{Accepted: true, ExternalID: "TX-123"}}
+		submission, _ := f.useCase.Enqueue(f.visit.ID)
+		if attempted, err := f.useCase.Sweep(sweepTime); err != nil || attempted != 1 {
+			t.Fatalf("expected one attempt, got %d (%v)", attempted, err)
+		}
+		stored := f.evvRepo.submissions[submission.ID]
+		if stored.Status != domainEVV.StatusAccepted || stored.ExternalID != "TX-123" || stored.AcceptedAt == nil {
+			t.Errorf("expected accepted submission, got %+v", stored)
+		}
+		record := f.adapter.records[0]
+		if record.ServiceDate != "2025-07-16" || record.ClientName != "Ana Ruiz" || record.VerificationMethod != domainSchedule.VerificationGPS {
+			t.Errorf("unexpected record %+v", record)
+		}
+		if len(f.evvRepo.attempts) != 1 || f.evvRepo.attempts[0].Outcome != domainEVV.OutcomeAccepted {
+			t.Errorf("expected the attempt to be logged, got %+v", f.evvRepo.attempts)
+		}
+	})
+
+	t.Run("Rejected then resubmitted", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		f.adapter.results = []*evvAdapter.Result{{Code: "E42", Message: "missing service code"}}
+		submission, _ := f.useCase.Enqueue(f.visit.ID)
+		if _, err := f.useCase.Sweep(sweepTime); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stored := f.evvRepo.submissions[submission.ID]
+		if stored.Status != domainEVV.StatusRejected || stored.RejectionCode != "E42" {
+			t.Fatalf("expected rejected submission, got %+v", stored)
+		}
+		if attempted, _ := f.useCase.Sweep(sweepTime.Add(time.Hour)); attempted != 0 {
+			t.Error("expected rejected submissions to wait for a resubmission")
+		}
+
+		resubmitted, err := f.useCase.Resubmit(submission.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resubmitted.Status != domainEVV.StatusQueued || resubmitted.RejectionCode != "" || resubmitted.Attempts != 0 {
+			t.Errorf("expected a fresh queued submission, got %+v", resubmitted)
+		}
+		if _, err := f.useCase.Sweep(sweepTime.Add(time.Hour)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if f.evvRepo.submissions[submission.ID].Status != domainEVV.StatusAccepted {
+			t.Errorf("expected the resubmission to be accepted")
+		}
+	})
+
+	t.Run("Transient errors back off and eventually fail", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		for i := 0; i < MaxAttempts; i++ {
+			f.adapter.errs = append(f.adapter.errs, errors.New("connection refused"))
+		}
+		submission, _ := f.useCase.Enqueue(f.visit.ID)
+		now := sweepTime
+		if _, err := f.useCase.Sweep(now); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stored := f.evvRepo.submissions[submission.ID]
+		if stored.Status != domainEVV.StatusQueued || !stored.NextAttemptAt.Equal(now.Add(RetryBaseDelay)) {
+			t.Fatalf("expected a retry after %s, got %+v", RetryBaseDelay, stored)
+		}
+		if attempted, _ := f.useCase.Sweep(now.Add(30 * time.Second)); attempted != 0 {
+			t.Error("expected no retry before the backoff elapses")
+		}
+		for stored.Status == domainEVV.StatusQueued {
+			now = *stored.NextAttemptAt
+			if _, err := f.useCase.Sweep(now); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if stored.Status != domainEVV.StatusFailed || stored.Attempts != MaxAttempts {
+			t.Errorf("expected failure after %d attempts, got %+v", MaxAttempts, stored)
+		}
+	})
+
+	t.Run("No aggregator for the state", func(t *testing.T) {
+		f := setupTestEVVUseCase(t)
+		f.client.Location.State = "NM"
+		submission, _ := f.useCase.Enqueue(f.visit.ID)
+		if _, err := f.useCase.Sweep(sweepTime); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stored := f.evvRepo.submissions[submission.ID]
+		if stored.Status != domainEVV.StatusFailed || stored.RejectionCode != "NO_AGGREGATOR" {
+			t.Errorf("expected failed submission, got %+v", stored)
+		}
+		if len(f.adapter.records) != 0 {
+			t.Error("expected nothing to be sent")
+		}
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	if retryDelay(1) != time.Minute || retryDelay(3) != 4*time.Minute || retryDelay(20) != RetryMaxDelay {
+		t.Errorf("unexpected backoff: %s %s %s", retryDelay(1), retryDelay(3), retryDelay(20))
+	}
+}
@@ -0,0 +1,252 @@
+package fatigue
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainFatigue "caregiver/src/domain/fatigue"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxReportDays caps the range of a fatigue report.
+const MaxReportDays = 93
+
+type IFatigueUseCase interface {
+	CreateRule(newRule *domainFatigue.Rule) (*domainFatigue.Rule, error)
+	GetRuleByID(id uuid.UUID) (*domainFatigue.Rule, error)
+	GetRules() (*[]domainFatigue.Rule, error)
+	UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainFatigue.Rule, error)
+	DeleteRule(id uuid.UUID) error
+	Report(caregiverUserID uuid.UUID, from, to time.Time) (*domainFatigue.Report, error)
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+}
+
+type FatigueUseCase struct {
+	fatigueRepository  domainFatigue.IFatigueRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	Logger             *logger.Logger
+}
+
+func NewFatigueUseCase(fatigueRepository domainFatigue.IFatigueRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) IFatigueUseCase {
+	return &FatigueUseCase{
+		fatigueRepository:  fatigueRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *FatigueUseCase) CreateRule(newRule *domainFatigue.Rule) (*domainFatigue.Rule, error) {
+	u.Logger.Info("Creating fatigue rule", zap.String("name", newRule.Name), zap.String("type", newRule.Type))
+	if newRule.Mode == "" {
+		newRule.Mode = domainFatigue.ModeWarn
+	}
+	if err := validateRule(newRule); err != nil {
+		return nil, err
+	}
+	newRule.ID = uuid.New()
+	newRule.Active = true
+	return u.fatigueRepository.CreateRule(newRule)
+}
+
+func (u *FatigueUseCase) GetRuleByID(id uuid.UUID) (*domainFatigue.Rule, error) {
+	u.Logger.Info("Getting fatigue rule by ID", zap.String("id", id.String()))
+	return u.fatigueRepository.GetRuleByID(id)
+}
+
+func (u *FatigueUseCase) GetRules() (*[]domainFatigue.Rule, error) {
+	u.Logger.Info("Getting all fatigue rules")
+	return u.fatigueRepository.GetRules()
+}
+
+func (u *FatigueUseCase) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainFatigue.Rule, error) {
+	u.Logger.Info("Updating fatigue rule", zap.String("id", id.String()))
+
+	existing, err := u.fatigueRepository.GetRuleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["name"].(string); ok {
+		candidate.Name = v
+	}
+	if v, ok := updates["type"].(string); ok {
+		candidate.Type = v
+	}
+	if v, ok := updates["threshold"].(float64); ok {
+		candidate.Threshold = v
+	}
+	if v, ok := updates["mode"].(string); ok {
+		candidate.Mode = v
+	}
+	if err := validateRule(&candidate); err != nil {
+		return nil, err
+	}
+	return u.fatigueRepository.UpdateRule(id, updates)
+}
+
+func (u *FatigueUseCase) DeleteRule(id uuid.UUID) error {
+	u.Logger.Info("Deleting fatigue rule", zap.String("id", id.String()))
+	return u.fatigueRepository.DeleteRule(id)
+}
+
+// Report lists a caregiver's daily hours over [from, to) with the rule
+// violations in that range. Streaks are measured across the range bounds so
+// a run that started earlier still counts in full.
+func (u *FatigueUseCase) Report(caregiverUserID uuid.UUID, from, to time.Time) (*domainFatigue.Report, error) {
+	u.Logger.Info("Building fatigue report", zap.String("caregiverUserID", caregiverUserID.String()), zap.Time("from", from), zap.Time("to", to))
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+	if to.Sub(from) > MaxReportDays*24*time.Hour {
+		return nil, domainErrors.NewAppError(errors.New("report range must not exceed 93 days"), domainErrors.ValidationError)
+	}
+	caregiver, err := u.userRepository.GetByID(caregiverUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("caregiver not found"), domainErrors.NotFound)
+	}
+	if caregiver.Role != domainUser.RoleCaregiver {
+		return nil, domainErrors.NewAppError(errors.New("fatigue reports are only available for caregivers"), domainErrors.ValidationError)
+	}
+	rules, err := u.fatigueRepository.GetActiveRules()
+	if err != nil {
+		return nil, err
+	}
+
+	days := daysBetween(from, to)
+	loads, err := u.loadsAround(caregiverUserID, days, *rules, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domainFatigue.Report{
+		CaregiverUserID: caregiverUserID,
+		From:            from,
+		To:              to,
+		Days:            make([]domainFatigue.DayLoad, len(days)),
+		Violations:      domainFatigue.Evaluate(*rules, loads, days),
+	}
+	for i, day := range days {
+		report.Days[i] = domainFatigue.DayLoad{Date: day, ScheduleIDs: []uuid.UUID{}}
+		if load, ok := loads[day]; ok {
+			report.Days[i] = *load
+		}
+		report.TotalHours += report.Days[i].Hours
+		if length := domainFatigue.StreakLength(domainFatigue.Streak(loads, day)); length > report.LongestStreak {
+			report.LongestStreak = length
+		}
+	}
+	if report.Violations == nil {
+		report.Violations = []domainFatigue.Violation{}
+	}
+	return report, nil
+}
+
+// ValidateSchedule checks the caregiver's days touched by the schedule
+// against the active fatigue rules. Any broken blocking rule rejects the
+// assignment; warning rules are passed back as warnings.
+func (u *FatigueUseCase) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.VisitStatus == "cancelled" || schedule.AssignedUserID == uuid.Nil {
+		return nil, nil
+	}
+	rules, err := u.fatigueRepository.GetActiveRules()
+	if err != nil {
+		return nil, err
+	}
+	if len(*rules) == 0 {
+		return nil, nil
+	}
+
+	days := daysBetween(schedule.ScheduledSlot.From, schedule.ScheduledSlot.To)
+	loads, err := u.loadsAround(schedule.AssignedUserID, days, *rules, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings, blocking []string
+	for _, violation := range domainFatigue.Evaluate(*rules, loads, days) {
+		if violation.Mode == domainFatigue.ModeBlock {
+			blocking = append(blocking, violation.Message)
+		} else {
+			warnings = append(warnings, violation.Message)
+		}
+	}
+	if len(blocking) > 0 {
+		u.Logger.Warn("Schedule breaks fatigue rules",
+			zap.String("assignedUserID", schedule.AssignedUserID.String()),
+			zap.Strings("violations", blocking))
+		return nil, domainErrors.NewAppError(errors.New(strings.Join(blocking, "; ")), domainErrors.ValidationError)
+	}
+	return warnings, nil
+}
+
+// loadsAround computes the caregiver's daily loads for the given days plus
+// enough days on either side to measure the longest streak a rule allows.
+// When candidate is set it replaces any stored version of that schedule.
+func (u *FatigueUseCase) loadsAround(caregiverUserID uuid.UUID, days []time.Time, rules []domainFatigue.Rule, candidate *domainSchedule.Schedule) (map[time.Time]*domainFatigue.DayLoad, error) {
+	margin := 1
+	for _, rule := range rules {
+		if rule.Type == domainFatigue.RuleMaxConsecutiveDays {
+			if n := int(math.Ceil(rule.Threshold)) + 1; n > margin {
+				margin = n
+			}
+		}
+	}
+	from := days[0].AddDate(0, 0, -margin)
+	to := days[len(days)-1].AddDate(0, 0, margin+1)
+	schedules, err := u.scheduleRepository.GetWorkedSchedulesBetween(caregiverUserID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	worked := make([]domainSchedule.Schedule, 0, len(*schedules)+1)
+	for _, s := range *schedules {
+		if candidate == nil || s.ID != candidate.ID {
+			worked = append(worked, s)
+		}
+	}
+	if candidate != nil {
+		worked = append(worked, *candidate)
+	}
+	return domainFatigue.Loads(worked), nil
+}
+
+// daysBetween lists the UTC days overlapping [from, to), at least one.
+func daysBetween(from, to time.Time) []time.Time {
+	days := []time.Time{domainFatigue.Day(from)}
+	for day := days[0].AddDate(0, 0, 1); day.Before(to); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+func validateRule(r *domainFatigue.Rule) error {
+	if strings.TrimSpace(r.Name) == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	switch r.Type {
+	case domainFatigue.RuleMaxConsecutiveDays:
+		if r.Threshold < 1 || r.Threshold > 31 || r.Threshold != math.Trunc(r.Threshold) {
+			return domainErrors.NewAppError(errors.New("max consecutive days must be a whole number between 1 and 31"), domainErrors.ValidationError)
+		}
+	case domainFatigue.RuleMaxDailyHours:
+		if r.Threshold <= 0 || r.Threshold > 24 {
+			return domainErrors.NewAppError(errors.New("max daily hours must be above 0 and at most 24"), domainErrors.ValidationError)
+		}
+	default:
+		return domainErrors.NewAppError(errors.New("type must be 'max_consecutive_days' or 'max_daily_hours'"), domainErrors.ValidationError)
+	}
+	if r.Mode != domainFatigue.ModeWarn && r.Mode != domainFatigue.ModeBlock {
+		return domainErrors.NewAppError(errors.New("mode must be 'warn' or 'block'"), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package fatigue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainFatigue "caregiver/src/domain/fatigue"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type mockFatigueRepository struct {
+	domainFatigue.IFatigueRepository
+	rules []domainFatigue.Rule
+}
+
+func (m *mockFatigueRepository) CreateRule(newRule *domainFatigue.Rule) (*domainFatigue.Rule, error) {
+	m.rules = append(m.rules, *newRule)
+	return newRule, nil
+}
+
+func (m *mockFatigueRepository) GetActiveRules() (*[]domainFatigue.Rule, error) {
+	active := []domainFatigue.Rule{}
+	for _, rule := range m.rules {
+		if rule.Active {
+			active = append(active, rule)
+		}
+	}
+	return &active, nil
+}
+
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetWorkedSchedulesBetween(assignedUserID uuid.UUID, from, to time.Time) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		if s.AssignedUserID == assignedUserID && s.ScheduledSlot.From.Before(to) && s.ScheduledSlot.To.After(from) {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &user, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func visit(caregiverID uuid.UUID, from time.Time, hours float64) domainSchedule.Schedule {
+	return domainSchedule.Schedule{
+		ID:             uuid.New(),
+		AssignedUserID: caregiverID,
+		VisitStatus:    "upcoming",
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: from, To: from.Add(time.Duration(hours * float64(time.Hour)))},
+	}
+}
+
+func TestFatigueRules(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	// Monday to Thursday at 08:00 for 4 hours each.
+	schedules := &mockScheduleRepository{}
+	for i := 0; i < 4; i++ {
+		schedules.schedules = append(schedules.schedules, visit(caregiver.ID, monday.AddDate(0, 0, i).Add(8*time.Hour), 4))
+	}
+	rules := &mockFatigueRepository{}
+	users := &mockUserRepository{users: map[uuid.UUID]domainUser.User{caregiver.ID: caregiver, client.ID: client}}
+	uc := NewFatigueUseCase(rules, schedules, users, loggerInstance)
+
+	t.Run("No rules", func(t *testing.T) {
+		candidate := visit(caregiver.ID, monday.AddDate(0, 0, 4).Add(8*time.Hour), 12)
+		warnings, err := uc.ValidateSchedule(&candidate)
+		if err != nil || len(warnings) != 0 {
+			t.Errorf("expected no findings without rules, got %v, %v", warnings, err)
+		}
+	})
+
+	if _, err := uc.CreateRule(&domainFatigue.Rule{Name: "Streak", Type: domainFatigue.RuleMaxConsecutiveDays, Threshold: 2.5}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected validation error for fractional day threshold, got %v", err)
+	}
+	if _, err := uc.CreateRule(&domainFatigue.Rule{Name: "Long days", Type: domainFatigue.RuleMaxDailyHours, Threshold: 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.CreateRule(&domainFatigue.Rule{Name: "Rest days", Type: domainFatigue.RuleMaxConsecutiveDays, Threshold: 5, Mode: domainFatigue.ModeBlock}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("Daily hours warning", func(t *testing.T) {
+		// Thursday already has 4 hours; another 7 makes 11.
+		candidate := visit(caregiver.ID, monday.AddDate(0, 0, 3).Add(13*time.Hour), 7)
+		warnings, err := uc.ValidateSchedule(&candidate)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(warnings) != 1 {
+			t.Errorf("expected one daily hours warning, got %v", warnings)
+		}
+	})
+	t.Run("Overnight visit splits across days", func(t *testing.T) {
+		// Friday 20:00 to Saturday 04:00 adds a fifth and sixth day.
+		candidate := visit(caregiver.ID, monday.AddDate(0, 0, 4).Add(20*time.Hour), 8)
+		if _, err := uc.ValidateSchedule(&candidate); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected six-day streak to be blocked, got %v", err)
+		}
+	})
+	t.Run("Fifth day allowed", func(t *testing.T) {
+		candidate := visit(caregiver.ID, monday.AddDate(0, 0, 4).Add(8*time.Hour), 4)
+		if warnings, err := uc.ValidateSchedule(&candidate); err != nil || len(warnings) != 0 {
+			t.Errorf("expected five-day streak to pass, got %v, %v", warnings, err)
+		}
+	})
+	t.Run("Rescheduling the same visit", func(t *testing.T) {
+		moved := schedules.schedules[0]
+		moved.ScheduledSlot.From = moved.ScheduledSlot.From.Add(time.Hour)
+		moved.ScheduledSlot.To = moved.ScheduledSlot.To.Add(time.Hour)
+		if warnings, err := uc.ValidateSchedule(&moved); err != nil || len(warnings) != 0 {
+			t.Errorf("expected the stored copy to be replaced, got %v, %v", warnings, err)
+		}
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		schedules.schedules = append(schedules.schedules,
+			visit(caregiver.ID, monday.AddDate(0, 0, 4).Add(8*time.Hour), 4),
+			visit(caregiver.ID, monday.AddDate(0, 0, 5).Add(8*time.Hour), 11),
+		)
+		report, err := uc.Report(caregiver.ID, monday, monday.AddDate(0, 0, 7))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(report.Days) != 7 || report.TotalHours != 31 || report.LongestStreak != 6 {
+			t.Errorf("unexpected totals: %d days, %.1f hours, streak %d", len(report.Days), report.TotalHours, report.LongestStreak)
+		}
+		if len(report.Violations) != 2 {
+			t.Fatalf("expected daily hours and streak violations, got %+v", report.Violations)
+		}
+		if _, err := uc.Report(client.ID, monday, monday.AddDate(0, 0, 7)); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error for client report, got %v", err)
+		}
+	})
+}
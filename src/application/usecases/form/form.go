@@ -0,0 +1,271 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainForm "caregiver/src/domain/form"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var formKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+type IFormUseCase interface {
+	Create(definition *domainForm.Definition) (*domainForm.Definition, error)
+	Publish(key string, definition *domainForm.Definition) (*domainForm.Definition, error)
+	Retire(key string) error
+	GetAll() (*[]domainForm.Definition, error)
+	GetLatest(key string) (*domainForm.Definition, error)
+	GetVersion(key string, version int) (*domainForm.Definition, error)
+	GetVersions(key string) (*[]domainForm.Definition, error)
+	Submit(key string, submission *domainForm.Submission) (*domainForm.Submission, error)
+	GetSubmission(id uuid.UUID) (*domainForm.Submission, error)
+	GetSubmissions(key string, clientUserID *uuid.UUID) (*[]domainForm.Submission, error)
+	ScoreHistory(key string, clientUserID uuid.UUID) (*domainForm.ScoreHistory, error)
+}
+
+type FormUseCase struct {
+	formRepository domainForm.IFormRepository
+	userRepository domainUser.IUserRepository
+	Logger         *logger.Logger
+}
+
+func NewFormUseCase(formRepository domainForm.IFormRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) IFormUseCase {
+	return &FormUseCase{
+		formRepository: formRepository,
+		userRepository: userRepository,
+		Logger:         loggerInstance,
+	}
+}
+
+// Create defines a new form as version 1.
+func (u *FormUseCase) Create(definition *domainForm.Definition) (*domainForm.Definition, error) {
+	u.Logger.Info("Creating form", zap.String("key", definition.Key))
+	if err := u.validateDefinition(definition); err != nil {
+		return nil, err
+	}
+	if _, err := u.formRepository.GetLatest(definition.Key); err == nil {
+		return nil, domainErrors.NewAppError(errors.New("a form with this key already exists"), domainErrors.Conflict)
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+	definition.ID = uuid.New()
+	definition.Version = 1
+	definition.Active = true
+	return u.formRepository.CreateDefinition(definition)
+}
+
+// Publish stores the definition as the next version of an existing form and
+// reactivates the form if it was retired.
+func (u *FormUseCase) Publish(key string, definition *domainForm.Definition) (*domainForm.Definition, error) {
+	u.Logger.Info("Publishing form version", zap.String("key", key))
+	latest, err := u.formRepository.GetLatest(key)
+	if err != nil {
+		return nil, err
+	}
+	definition.Key = key
+	if err := u.validateDefinition(definition); err != nil {
+		return nil, err
+	}
+	if !latest.Active {
+		if err := u.formRepository.SetActive(key, true); err != nil {
+			return nil, err
+		}
+	}
+	definition.ID = uuid.New()
+	definition.Version = latest.Version + 1
+	definition.Active = true
+	return u.formRepository.CreateDefinition(definition)
+}
+
+// Retire stops new submissions for a form; its history stays available.
+func (u *FormUseCase) Retire(key string) error {
+	u.Logger.Info("Retiring form", zap.String("key", key))
+	return u.formRepository.SetActive(key, false)
+}
+
+func (u *FormUseCase) GetAll() (*[]domainForm.Definition, error) {
+	u.Logger.Info("Getting all forms")
+	return u.formRepository.GetAllLatest()
+}
+
+func (u *FormUseCase) GetLatest(key string) (*domainForm.Definition, error) {
+	u.Logger.Info("Getting form", zap.String("key", key))
+	return u.formRepository.GetLatest(key)
+}
+
+func (u *FormUseCase) GetVersion(key string, version int) (*domainForm.Definition, error) {
+	u.Logger.Info("Getting form version", zap.String("key", key), zap.Int("version", version))
+	return u.formRepository.GetVersion(key, version)
+}
+
+func (u *FormUseCase) GetVersions(key string) (*[]domainForm.Definition, error) {
+	u.Logger.Info("Getting form versions", zap.String("key", key))
+	versions, err := u.formRepository.GetVersions(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(*versions) == 0 {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return versions, nil
+}
+
+// Submit validates and scores answers against the latest version of the form.
+func (u *FormUseCase) Submit(key string, submission *domainForm.Submission) (*domainForm.Submission, error) {
+	u.Logger.Info("Submitting form", zap.String("key", key), zap.String("clientUserID", submission.ClientUserID.String()))
+
+	definition, err := u.formRepository.GetLatest(key)
+	if err != nil {
+		return nil, err
+	}
+	if !definition.Active {
+		return nil, domainErrors.NewAppError(errors.New("form is retired"), domainErrors.ValidationError)
+	}
+	client, err := u.userRepository.GetByID(submission.ClientUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("client not found"), domainErrors.NotFound)
+	}
+	if client.Role != domainUser.RoleClient {
+		return nil, domainErrors.NewAppError(errors.New("forms can only be submitted for clients"), domainErrors.ValidationError)
+	}
+	if _, err := u.userRepository.GetByID(submission.SubmittedByUserID); err != nil {
+		return nil, domainErrors.NewAppError(errors.New("submitting user not found"), domainErrors.NotFound)
+	}
+
+	fieldScores, score, err := definition.Evaluate(submission.Answers)
+	if err != nil {
+		return nil, domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
+	submission.ID = uuid.New()
+	submission.DefinitionID = definition.ID
+	submission.FormKey = definition.Key
+	submission.FormVersion = definition.Version
+	submission.FieldScores = fieldScores
+	submission.Score = score
+	submission.SubmittedAt = time.Now().UTC()
+	return u.formRepository.CreateSubmission(submission)
+}
+
+func (u *FormUseCase) GetSubmission(id uuid.UUID) (*domainForm.Submission, error) {
+	u.Logger.Info("Getting form submission", zap.String("id", id.String()))
+	return u.formRepository.GetSubmissionByID(id)
+}
+
+func (u *FormUseCase) GetSubmissions(key string, clientUserID *uuid.UUID) (*[]domainForm.Submission, error) {
+	u.Logger.Info("Getting form submissions", zap.String("key", key))
+	return u.formRepository.GetSubmissions(key, clientUserID)
+}
+
+// ScoreHistory returns the client's scores for a form across all versions.
+// When the form sets a reassessment interval, NextDueAt follows the latest
+// submission by that many days.
+func (u *FormUseCase) ScoreHistory(key string, clientUserID uuid.UUID) (*domainForm.ScoreHistory, error) {
+	u.Logger.Info("Getting score history", zap.String("key", key), zap.String("clientUserID", clientUserID.String()))
+
+	definition, err := u.formRepository.GetLatest(key)
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := u.formRepository.GetSubmissions(key, &clientUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &domainForm.ScoreHistory{
+		FormKey:      key,
+		ClientUserID: clientUserID,
+		Points:       make([]domainForm.ScorePoint, len(*submissions)),
+	}
+	for i, s := range *submissions {
+		history.Points[i] = domainForm.ScorePoint{
+			SubmissionID: s.ID,
+			FormVersion:  s.FormVersion,
+			Score:        s.Score,
+			SubmittedAt:  s.SubmittedAt,
+		}
+	}
+	if n := len(*submissions); n > 0 && definition.ReassessmentIntervalDays > 0 {
+		due := (*submissions)[n-1].SubmittedAt.AddDate(0, 0, definition.ReassessmentIntervalDays)
+		history.NextDueAt = &due
+	}
+	return history, nil
+}
+
+func (u *FormUseCase) validateDefinition(d *domainForm.Definition) error {
+	d.Key = strings.TrimSpace(d.Key)
+	d.Name = strings.TrimSpace(d.Name)
+	if !formKeyPattern.MatchString(d.Key) {
+		return domainErrors.NewAppError(errors.New("key must be lowercase letters, digits, '-' or '_'"), domainErrors.ValidationError)
+	}
+	if d.Name == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	if d.Purpose != domainForm.PurposeIntake && d.Purpose != domainForm.PurposeReassessment {
+		return domainErrors.NewAppError(errors.New("purpose must be 'intake' or 'reassessment'"), domainErrors.ValidationError)
+	}
+	if d.ReassessmentIntervalDays < 0 {
+		return domainErrors.NewAppError(errors.New("reassessment interval cannot be negative"), domainErrors.ValidationError)
+	}
+	if len(d.Fields) == 0 {
+		return domainErrors.NewAppError(errors.New("at least one field is required"), domainErrors.ValidationError)
+	}
+
+	author, err := u.userRepository.GetByID(d.CreatedByUserID)
+	if err != nil || author.Role != domainUser.RoleAdmin {
+		return domainErrors.NewAppError(errors.New("only coordinators can define forms"), domainErrors.NotAuthorized)
+	}
+
+	keys := make(map[string]bool)
+	for _, field := range d.Fields {
+		if err := validateField(field, keys); err != nil {
+			return domainErrors.NewAppError(err, domainErrors.ValidationError)
+		}
+	}
+	return nil
+}
+
+func validateField(field domainForm.Field, keys map[string]bool) error {
+	if field.Key == "" || field.Label == "" {
+		return errors.New("every field needs a key and a label")
+	}
+	if keys[field.Key] {
+		return fmt.Errorf("field key %q is used twice", field.Key)
+	}
+	keys[field.Key] = true
+
+	switch field.Type {
+	case domainForm.FieldText, domainForm.FieldDate, domainForm.FieldBoolean, domainForm.FieldNumber:
+	case domainForm.FieldScale:
+		if field.Min == nil || field.Max == nil || *field.Min >= *field.Max {
+			return fmt.Errorf("%s: scale fields need a min below their max", field.Key)
+		}
+	case domainForm.FieldSingleChoice, domainForm.FieldMultiChoice:
+		if len(field.Options) == 0 {
+			return fmt.Errorf("%s: choice fields need options", field.Key)
+		}
+		values := make(map[string]bool)
+		for _, option := range field.Options {
+			if option.Value == "" || values[option.Value] {
+				return fmt.Errorf("%s: option values must be distinct and non-empty", field.Key)
+			}
+			values[option.Value] = true
+		}
+	default:
+		return fmt.Errorf("%s: unsupported field type %q", field.Key, field.Type)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
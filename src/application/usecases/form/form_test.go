@@ -0,0 +1,227 @@
+package form
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainForm "caregiver/src/domain/form"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type mockFormRepository struct {
+	domainForm.IFormRepository
+	definitions []domainForm.Definition
+	submissions []domainForm.Submission
+}
+
+func (m *mockFormRepository) CreateDefinition(definition *domainForm.Definition) (*domainForm.Definition, error) {
+	m.definitions = append(m.definitions, *definition)
+	return definition, nil
+}
+
+func (m *mockFormRepository) GetLatest(key string) (*domainForm.Definition, error) {
+	var latest *domainForm.Definition
+	for i := range m.definitions {
+		if m.definitions[i].Key == key && (latest == nil || m.definitions[i].Version > latest.Version) {
+			latest = &m.definitions[i]
+		}
+	}
+	if latest == nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *latest
+	return &copied, nil
+}
+
+func (m *mockFormRepository) SetActive(key string, active bool) error {
+	for i := range m.definitions {
+		if m.definitions[i].Key == key {
+			m.definitions[i].Active = active
+		}
+	}
+	return nil
+}
+
+func (m *mockFormRepository) CreateSubmission(submission *domainForm.Submission) (*domainForm.Submission, error) {
+	m.submissions = append(m.submissions, *submission)
+	return submission, nil
+}
+
+func (m *mockFormRepository) GetSubmissions(key string, clientUserID *uuid.UUID) (*[]domainForm.Submission, error) {
+	submissions := []domainForm.Submission{}
+	for _, s := range m.submissions {
+		if s.FormKey == key && (clientUserID == nil || s.ClientUserID == *clientUserID) {
+			submissions = append(submissions, s)
+		}
+	}
+	return &submissions, nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &user, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func mobilityForm(createdBy uuid.UUID) *domainForm.Definition {
+	minScale, maxScale := 0.0, 10.0
+	return &domainForm.Definition{
+		Key:                      "mobility",
+		Name:                     "Mobility assessment",
+		Purpose:                  domainForm.PurposeReassessment,
+		ReassessmentIntervalDays: 90,
+		CreatedByUserID:          createdBy,
+		Fields: []domainForm.Field{
+			{Key: "walking", Label: "Walking", Type: domainForm.FieldSingleChoice, Required: true, Options: []domainForm.Option{
+				{Value: "independent", Score: 0},
+				{Value: "aid", Score: 2},
+				{Value: "unable", Score: 4},
+			}},
+			{Key: "pain", Label: "Pain", Type: domainForm.FieldScale, Min: &minScale, Max: &maxScale, Weight: 0.5},
+			{Key: "falls", Label: "Recent falls", Type: domainForm.FieldBoolean, Weight: 3},
+			{Key: "aids", Label: "Aids used", Type: domainForm.FieldMultiChoice, Options: []domainForm.Option{
+				{Value: "cane", Score: 1},
+				{Value: "walker", Score: 2},
+			}},
+			{Key: "notes", Label: "Notes", Type: domainForm.FieldText},
+		},
+	}
+}
+
+func TestFormLifecycle(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	coordinator := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	users := &mockUserRepository{users: map[uuid.UUID]domainUser.User{coordinator.ID: coordinator, caregiver.ID: caregiver, client.ID: client}}
+	forms := &mockFormRepository{}
+	uc := NewFormUseCase(forms, users, loggerInstance)
+
+	if _, err := uc.Create(mobilityForm(caregiver.ID)); errorType(err) != domainErrors.NotAuthorized {
+		t.Fatalf("expected not authorized for caregiver author, got %v", err)
+	}
+	created, err := uc.Create(mobilityForm(coordinator.ID))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Version != 1 || !created.Active {
+		t.Errorf("expected active version 1, got %+v", created)
+	}
+	if _, err := uc.Create(mobilityForm(coordinator.ID)); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected conflict for duplicate key, got %v", err)
+	}
+
+	t.Run("Scoring", func(t *testing.T) {
+		submission, err := uc.Submit("mobility", &domainForm.Submission{
+			ClientUserID:      client.ID,
+			SubmittedByUserID: caregiver.ID,
+			Answers: map[string]interface{}{
+				"walking": "aid",
+				"pain":    float64(6),
+				"falls":   true,
+				"aids":    []interface{}{"cane", "walker"},
+				"notes":   "Uses rail on stairs",
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// aid 2 + pain 6*0.5 + falls 3 + cane 1 + walker 2
+		if submission.Score != 11 {
+			t.Errorf("expected score 11, got %v (%v)", submission.Score, submission.FieldScores)
+		}
+		if submission.FormVersion != 1 {
+			t.Errorf("expected version 1, got %d", submission.FormVersion)
+		}
+	})
+
+	t.Run("Invalid answers", func(t *testing.T) {
+		cases := map[string]map[string]interface{}{
+			"missing required": {"pain": float64(2)},
+			"unknown field":    {"walking": "aid", "mood": "ok"},
+			"out of range":     {"walking": "aid", "pain": float64(11)},
+			"unknown option":   {"walking": "running"},
+		}
+		for name, answers := range cases {
+			_, err := uc.Submit("mobility", &domainForm.Submission{ClientUserID: client.ID, SubmittedByUserID: caregiver.ID, Answers: answers})
+			if errorType(err) != domainErrors.ValidationError {
+				t.Errorf("%s: expected validation error, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("Non-client subject", func(t *testing.T) {
+		_, err := uc.Submit("mobility", &domainForm.Submission{ClientUserID: caregiver.ID, SubmittedByUserID: caregiver.ID, Answers: map[string]interface{}{"walking": "aid"}})
+		if errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("Publish and retire", func(t *testing.T) {
+		if err := uc.Retire("mobility"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err := uc.Submit("mobility", &domainForm.Submission{ClientUserID: client.ID, SubmittedByUserID: caregiver.ID, Answers: map[string]interface{}{"walking": "aid"}})
+		if errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error for retired form, got %v", err)
+		}
+
+		published, err := uc.Publish("mobility", mobilityForm(coordinator.ID))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if published.Version != 2 || !published.Active {
+			t.Errorf("expected active version 2, got %+v", published)
+		}
+		submission, err := uc.Submit("mobility", &domainForm.Submission{ClientUserID: client.ID, SubmittedByUserID: caregiver.ID, Answers: map[string]interface{}{"walking": "unable"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if submission.FormVersion != 2 || submission.Score != 4 {
+			t.Errorf("expected version 2 with score 4, got %+v", submission)
+		}
+	})
+
+	t.Run("Score history", func(t *testing.T) {
+		history, err := uc.ScoreHistory("mobility", client.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(history.Points) != 2 || history.Points[0].Score != 11 || history.Points[1].Score != 4 {
+			t.Fatalf("unexpected history points: %+v", history.Points)
+		}
+		if history.NextDueAt == nil {
+			t.Fatal("expected next due date")
+		}
+		want := history.Points[1].SubmittedAt.AddDate(0, 0, 90)
+		if !history.NextDueAt.Equal(want) {
+			t.Errorf("expected next due %v, got %v", want, *history.NextDueAt)
+		}
+		if history.NextDueAt.Before(time.Now()) {
+			t.Errorf("expected next due in the future, got %v", *history.NextDueAt)
+		}
+	})
+}
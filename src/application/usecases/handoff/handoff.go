@@ -0,0 +1,232 @@
+package handoff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"caregiver/src/domain"
+	domainErrors "caregiver/src/domain/errors"
+	domainHandoff "caregiver/src/domain/handoff"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IHandoffUseCase interface {
+	// GetNextVisit returns the client's next visit by another caregiver
+	// starting within domainHandoff.Window of the end of the given visit, or
+	// NotFound when there is none.
+	GetNextVisit(scheduleID uuid.UUID) (*domainSchedule.Schedule, error)
+	// Leave records the outgoing caregiver's notes for the next visit. The
+	// caller, when known, must be the visit's caregiver or an admin.
+	Leave(scheduleID uuid.UUID, notes domainHandoff.Notes, callerID *uuid.UUID) (*domainHandoff.Handoff, error)
+	GetOutgoing(scheduleID uuid.UUID) (*domainHandoff.Handoff, error)
+	GetIncoming(scheduleID uuid.UUID) (*[]domainHandoff.Handoff, error)
+	// Acknowledge marks the notes left for a visit read by its caregiver.
+	Acknowledge(scheduleID uuid.UUID, callerID *uuid.UUID, now time.Time) (*[]domainHandoff.Handoff, error)
+	// BeforeCheckin blocks a check-in until the handoff notes left for the
+	// visit have been acknowledged.
+	BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error
+}
+
+type HandoffUseCase struct {
+	handoffRepository  domainHandoff.IHandoffRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	notifier           notification.INotifier
+	Logger             *logger.Logger
+}
+
+func NewHandoffUseCase(handoffRepository domainHandoff.IHandoffRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, notifier notification.INotifier, loggerInstance *logger.Logger) IHandoffUseCase {
+	return &HandoffUseCase{
+		handoffRepository:  handoffRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		notifier:           notifier,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *HandoffUseCase) GetNextVisit(scheduleID uuid.UUID) (*domainSchedule.Schedule, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	return u.nextVisit(schedule)
+}
+
+func (u *HandoffUseCase) Leave(scheduleID uuid.UUID, notes domainHandoff.Notes, callerID *uuid.UUID) (*domainHandoff.Handoff, error) {
+	notes = trimNotes(notes)
+	if notes.Summary == "" {
+		return nil, domainErrors.NewAppError(errors.New("a handoff summary is required"), domainErrors.ValidationError)
+	}
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authorize(schedule, callerID, "leave handoff notes for"); err != nil {
+		return nil, err
+	}
+	if schedule.VisitStatus != "in_progress" && schedule.VisitStatus != "completed" {
+		return nil, domainErrors.NewAppError(errors.New("handoff notes can only be left on a visit in progress or completed"), domainErrors.ValidationError)
+	}
+	if existing, err := u.handoffRepository.GetFrom(schedule.ID); err == nil && existing.Acknowledged() {
+		return nil, domainErrors.NewAppError(errors.New("the handoff has already been acknowledged and can no longer be changed"), domainErrors.Conflict)
+	} else if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	next, err := u.nextVisit(schedule)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, domainErrors.NewAppError(errors.New("the client has no back-to-back visit by another caregiver"), domainErrors.ValidationError)
+		}
+		return nil, err
+	}
+	saved, err := u.handoffRepository.Save(&domainHandoff.Handoff{
+		FromScheduleID:      schedule.ID,
+		ToScheduleID:        next.ID,
+		ClientUserID:        schedule.ClientUserID,
+		FromCaregiverUserID: schedule.AssignedUserID,
+		ToCaregiverUserID:   next.AssignedUserID,
+		Notes:               notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Handoff notes left", zap.String("fromScheduleID", schedule.ID.String()), zap.String("toScheduleID", next.ID.String()))
+	u.notify(saved, next)
+	return saved, nil
+}
+
+func (u *HandoffUseCase) GetOutgoing(scheduleID uuid.UUID) (*domainHandoff.Handoff, error) {
+	return u.handoffRepository.GetFrom(scheduleID)
+}
+
+func (u *HandoffUseCase) GetIncoming(scheduleID uuid.UUID) (*[]domainHandoff.Handoff, error) {
+	if _, err := u.scheduleRepository.GetScheduleByID(scheduleID); err != nil {
+		return nil, err
+	}
+	return u.handoffRepository.GetTo(scheduleID)
+}
+
+func (u *HandoffUseCase) Acknowledge(scheduleID uuid.UUID, callerID *uuid.UUID, now time.Time) (*[]domainHandoff.Handoff, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if callerID != nil && *callerID != schedule.AssignedUserID {
+		return nil, domainErrors.NewAppError(errors.New("only the visit's caregiver can acknowledge its handoff notes"), domainErrors.NotAuthorized)
+	}
+	handoffs, err := u.handoffRepository.GetTo(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if len(*handoffs) == 0 {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	u.Logger.Info("Acknowledging handoff notes", zap.String("scheduleID", scheduleID.String()))
+	return u.handoffRepository.Acknowledge(scheduleID, schedule.AssignedUserID, now)
+}
+
+func (u *HandoffUseCase) BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error {
+	handoffs, err := u.handoffRepository.GetTo(schedule.ID)
+	if err != nil {
+		return err
+	}
+	for _, handoff := range *handoffs {
+		if !handoff.Acknowledged() {
+			return domainErrors.NewAppError(errors.New("handoff notes from the previous visit must be acknowledged before check-in"), domainErrors.ValidationError)
+		}
+	}
+	return nil
+}
+
+// nextVisit finds the client's first active visit starting within the
+// handoff window of the end of the given one and assigned to someone else.
+func (u *HandoffUseCase) nextVisit(schedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error) {
+	from := schedule.ScheduledSlot.To
+	to := from.Add(domainHandoff.Window)
+	result, err := u.scheduleRepository.Search(domainSchedule.SearchQuery{
+		ClientUserIDs: []uuid.UUID{schedule.ClientUserID},
+		Statuses:      []string{"upcoming"},
+		From:          &from,
+		To:            &to,
+		SortBy:        "scheduled_slot_from",
+		SortDirection: domain.SortAsc,
+		PageSize:      10,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, next := range *result.Data {
+		if next.ID == schedule.ID || next.ScheduledSlot.From.Before(schedule.ScheduledSlot.From) {
+			continue
+		}
+		if next.AssignedUserID == schedule.AssignedUserID {
+			// The same caregiver carries on; nobody needs a handoff.
+			break
+		}
+		return &next, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (u *HandoffUseCase) authorize(schedule *domainSchedule.Schedule, callerID *uuid.UUID, action string) error {
+	if callerID == nil || *callerID == schedule.AssignedUserID {
+		return nil
+	}
+	caller, err := u.userRepository.GetByID(*callerID)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	if err == nil && caller.Role == domainUser.RoleAdmin {
+		return nil
+	}
+	return domainErrors.NewAppError(fmt.Errorf("only the assigned caregiver or an admin can %s this visit", action), domainErrors.NotAuthorized)
+}
+
+// notify tells the next caregiver there are notes to read before checking in.
+func (u *HandoffUseCase) notify(handoff *domainHandoff.Handoff, next *domainSchedule.Schedule) {
+	if u.notifier == nil {
+		return
+	}
+	err := u.notifier.Notify(notification.Message{
+		Role:    domainUser.RoleCaregiver,
+		UserID:  &next.AssignedUserID,
+		Subject: "Handoff notes",
+		Body:    fmt.Sprintf("The previous caregiver left notes for your %s visit: %s", next.ServiceName, handoff.Notes.Summary),
+		Data: map[string]interface{}{
+			"handoffID":  handoff.ID.String(),
+			"scheduleID": next.ID.String(),
+		},
+	})
+	if err != nil {
+		u.Logger.Warn("Handoff notification failed", zap.Error(err), zap.String("handoffID", handoff.ID.String()))
+	}
+}
+
+func trimNotes(notes domainHandoff.Notes) domainHandoff.Notes {
+	notes.Summary = strings.TrimSpace(notes.Summary)
+	notes.ClientMood = strings.TrimSpace(notes.ClientMood)
+	notes.Concerns = strings.TrimSpace(notes.Concerns)
+	notes.Medications = strings.TrimSpace(notes.Medications)
+	tasks := make([]string, 0, len(notes.PendingTasks))
+	for _, task := range notes.PendingTasks {
+		if task = strings.TrimSpace(task); task != "" {
+			tasks = append(tasks, task)
+		}
+	}
+	notes.PendingTasks = tasks
+	return notes
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
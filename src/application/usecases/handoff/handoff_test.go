@@ -0,0 +1,146 @@
+package handoff
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainHandoff "caregiver/src/domain/handoff"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps handoffs in a slice
+type memoryRepository struct {
+	handoffs []domainHandoff.Handoff
+}
+
+func (m *memoryRepository) GetFrom(fromScheduleID uuid.UUID) (*domainHandoff.Handoff, error) {
+	for i := range m.handoffs {
+		if m.handoffs[i].FromScheduleID == fromScheduleID {
+			return &m.handoffs[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *memoryRepository) GetTo(toScheduleID uuid.UUID) (*[]domainHandoff.Handoff, error) {
+	res := []domainHandoff.Handoff{}
+	for _, handoff := range m.handoffs {
+		if handoff.ToScheduleID == toScheduleID {
+			res = append(res, handoff)
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) Save(handoff *domainHandoff.Handoff) (*domainHandoff.Handoff, error) {
+	if existing, err := m.GetFrom(handoff.FromScheduleID); err == nil {
+		existing.Notes = handoff.Notes
+		existing.ToScheduleID = handoff.ToScheduleID
+		return existing, nil
+	}
+	handoff.ID = uuid.New()
+	m.handoffs = append(m.handoffs, *handoff)
+	return handoff, nil
+}
+
+func (m *memoryRepository) Acknowledge(toScheduleID uuid.UUID, userID uuid.UUID, at time.Time) (*[]domainHandoff.Handoff, error) {
+	for i := range m.handoffs {
+		if m.handoffs[i].ToScheduleID == toScheduleID && !m.handoffs[i].Acknowledged() {
+			m.handoffs[i].AcknowledgedAt = &at
+			m.handoffs[i].AcknowledgedBy = &userID
+		}
+	}
+	return m.GetTo(toScheduleID)
+}
+
+// mockScheduleRepository serves a fixed set of visits
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockScheduleRepository) Search(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+	var res []domainSchedule.Schedule
+	for _, s := range m.schedules {
+		if s.ClientUserID == query.ClientUserIDs[0] && s.VisitStatus == "upcoming" &&
+			s.ScheduledSlot.To.After(*query.From) && s.ScheduledSlot.From.Before(*query.To) {
+			res = append(res, s)
+		}
+	}
+	return &domainSchedule.SearchResultSchedule{Data: &res}, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestHandoffBetweenConsecutiveCaregivers(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	client, ana, ben := uuid.New(), uuid.New(), uuid.New()
+	at := func(hour int) time.Time { return time.Date(2026, 3, 2, hour, 0, 0, 0, time.UTC) }
+	slot := func(from, to int) domainSchedule.ScheduledSlot {
+		return domainSchedule.ScheduledSlot{From: at(from), To: at(to)}
+	}
+	morning := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: client, AssignedUserID: ana, VisitStatus: "in_progress", ScheduledSlot: slot(8, 10)}
+	midday := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: client, AssignedUserID: ben, VisitStatus: "upcoming", ScheduledSlot: slot(11, 12)}
+	evening := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: client, AssignedUserID: ben, VisitStatus: "upcoming", ScheduledSlot: slot(19, 20)}
+	repo := &memoryRepository{}
+	schedules := &mockScheduleRepository{schedules: []domainSchedule.Schedule{morning, midday, evening}}
+	useCase := NewHandoffUseCase(repo, schedules, nil, nil, loggerInstance)
+
+	next, err := useCase.GetNextVisit(morning.ID)
+	if err != nil || next.ID != midday.ID {
+		t.Fatalf("expected the midday visit to be next, got %+v, %v", next, err)
+	}
+	if _, err := useCase.GetNextVisit(midday.ID); errorType(err) != domainErrors.NotFound {
+		t.Errorf("expected no handoff target hours later, got %v", err)
+	}
+
+	if _, err := useCase.Leave(morning.ID, domainHandoff.Notes{Summary: "  "}, &ana); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a summary to be required, got %v", err)
+	}
+	handoff, err := useCase.Leave(morning.ID, domainHandoff.Notes{Summary: "Ate well", PendingTasks: []string{"Laundry", " "}}, &ana)
+	if err != nil {
+		t.Fatalf("expected the handoff to be left, got %v", err)
+	}
+	if handoff.ToScheduleID != midday.ID || handoff.ToCaregiverUserID != ben || len(handoff.Notes.PendingTasks) != 1 {
+		t.Errorf("unexpected handoff %+v", handoff)
+	}
+
+	if err := useCase.BeforeCheckin(&midday, at(11), &domainSchedule.Verification{}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected check-in to wait for the acknowledgement, got %v", err)
+	}
+	if _, err := useCase.Acknowledge(midday.ID, &ana, at(11)); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected only the next caregiver to acknowledge, got %v", err)
+	}
+	if _, err := useCase.Acknowledge(midday.ID, &ben, at(11)); err != nil {
+		t.Fatalf("expected the handoff to be acknowledged, got %v", err)
+	}
+	if err := useCase.BeforeCheckin(&midday, at(11), &domainSchedule.Verification{}); err != nil {
+		t.Errorf("expected check-in once acknowledged, got %v", err)
+	}
+	if _, err := useCase.Leave(morning.ID, domainHandoff.Notes{Summary: "Changed my mind"}, &ana); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected acknowledged notes to be final, got %v", err)
+	}
+}
@@ -0,0 +1,374 @@
+package identity
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainIdentity "caregiver/src/domain/identity"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MaxPhotoSize caps client photo uploads at 5 MiB.
+const MaxPhotoSize = 5 << 20
+
+// photoExtensions are the photo formats the apps can show.
+var photoExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+}
+
+type IIdentityUseCase interface {
+	GetSettings() (*domainIdentity.Settings, error)
+	SetSettings(required bool) (*domainIdentity.Settings, error)
+	GetChallenge(clientUserID uuid.UUID) (*domainIdentity.Challenge, error)
+	// SetQuestion replaces the client's security question and answer.
+	SetQuestion(clientUserID uuid.UUID, question string, answer string, callerID *uuid.UUID) (*domainIdentity.Challenge, error)
+	// UploadPhoto stores a PNG or JPEG photo of the client, replacing any
+	// earlier one.
+	UploadPhoto(clientUserID uuid.UUID, contentType string, size int64, content io.Reader, callerID *uuid.UUID) (*domainIdentity.Challenge, error)
+	OpenPhoto(clientUserID uuid.UUID) (*domainIdentity.Challenge, io.ReadCloser, error)
+	// OpenVisitPhoto serves the client's photo to the visit's caregiver.
+	OpenVisitPhoto(scheduleID uuid.UUID, callerID *uuid.UUID) (*domainIdentity.Challenge, io.ReadCloser, error)
+	GetStatus(scheduleID uuid.UUID) (*domainIdentity.Status, error)
+	// Verify records the caregiver's identity confirmation on a visit. A
+	// security question is checked against the coordinator's answer; a photo
+	// match is the caregiver's own judgement.
+	Verify(scheduleID uuid.UUID, method string, answer string, matched bool, note string, callerID *uuid.UUID, now time.Time) (*domainIdentity.Check, error)
+	// BeforeCheckin blocks a caregiver's first check-in with a client until
+	// the client's identity has been confirmed, when the organization
+	// requires it.
+	BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error
+}
+
+type IdentityUseCase struct {
+	identityRepository domainIdentity.IIdentityRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	storage            storage.IFileStorage
+	Logger             *logger.Logger
+}
+
+func NewIdentityUseCase(identityRepository domainIdentity.IIdentityRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, fileStorage storage.IFileStorage, loggerInstance *logger.Logger) IIdentityUseCase {
+	return &IdentityUseCase{
+		identityRepository: identityRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		storage:            fileStorage,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *IdentityUseCase) GetSettings() (*domainIdentity.Settings, error) {
+	settings, err := u.identityRepository.GetSettings()
+	if err != nil {
+		if isNotFound(err) {
+			return &domainIdentity.Settings{}, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (u *IdentityUseCase) SetSettings(required bool) (*domainIdentity.Settings, error) {
+	u.Logger.Info("Setting identity check settings", zap.Bool("required", required))
+	settings, err := u.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	settings.Required = required
+	return u.identityRepository.SaveSettings(settings)
+}
+
+func (u *IdentityUseCase) GetChallenge(clientUserID uuid.UUID) (*domainIdentity.Challenge, error) {
+	return u.identityRepository.GetChallenge(clientUserID)
+}
+
+func (u *IdentityUseCase) SetQuestion(clientUserID uuid.UUID, question string, answer string, callerID *uuid.UUID) (*domainIdentity.Challenge, error) {
+	question = strings.TrimSpace(question)
+	answer = normalizeAnswer(answer)
+	if question == "" || answer == "" {
+		return nil, domainErrors.NewAppError(errors.New("a security question and its answer are required"), domainErrors.ValidationError)
+	}
+	if len(answer) > 72 {
+		return nil, domainErrors.NewAppError(errors.New("the answer must be at most 72 characters"), domainErrors.ValidationError)
+	}
+	challenge, err := u.challengeFor(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(answer), bcrypt.DefaultCost)
+	if err != nil {
+		u.Logger.Error("Error hashing security answer", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	u.Logger.Info("Setting client security question", zap.String("clientUserID", clientUserID.String()))
+	challenge.Question = question
+	challenge.AnswerHash = string(hash)
+	challenge.UpdatedBy = callerID
+	return u.identityRepository.SaveChallenge(challenge)
+}
+
+func (u *IdentityUseCase) UploadPhoto(clientUserID uuid.UUID, contentType string, size int64, content io.Reader, callerID *uuid.UUID) (*domainIdentity.Challenge, error) {
+	u.Logger.Info("Uploading client identity photo", zap.String("clientUserID", clientUserID.String()), zap.String("contentType", contentType), zap.Int64("size", size))
+	extension, ok := photoExtensions[contentType]
+	if !ok {
+		return nil, domainErrors.NewAppError(fmt.Errorf("content type '%s' is not allowed; use PNG or JPEG", contentType), domainErrors.ValidationError)
+	}
+	if size <= 0 || size > MaxPhotoSize {
+		return nil, domainErrors.NewAppError(errors.New("photo must be between 1 byte and 5 MiB"), domainErrors.ValidationError)
+	}
+	data, err := io.ReadAll(io.LimitReader(content, MaxPhotoSize+1))
+	if err != nil {
+		return nil, domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
+	if len(data) > MaxPhotoSize {
+		return nil, domainErrors.NewAppError(errors.New("photo must be between 1 byte and 5 MiB"), domainErrors.ValidationError)
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return nil, domainErrors.NewAppError(errors.New("photo is not a readable image"), domainErrors.ValidationError)
+	}
+
+	challenge, err := u.challengeFor(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	staleKey := challenge.PhotoKey
+	challenge.PhotoKey = fmt.Sprintf("identity/%s/photo-%s%s", clientUserID, uuid.New(), extension)
+	challenge.PhotoContentType = contentType
+	challenge.UpdatedBy = callerID
+	if err := u.storage.Save(challenge.PhotoKey, bytes.NewReader(data)); err != nil {
+		u.Logger.Error("Error storing client identity photo", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	saved, err := u.identityRepository.SaveChallenge(challenge)
+	if err != nil {
+		u.removePhoto(challenge.PhotoKey)
+		return nil, err
+	}
+	u.removePhoto(staleKey)
+	return saved, nil
+}
+
+func (u *IdentityUseCase) OpenPhoto(clientUserID uuid.UUID) (*domainIdentity.Challenge, io.ReadCloser, error) {
+	challenge, err := u.identityRepository.GetChallenge(clientUserID)
+	if err != nil && !isNotFound(err) {
+		return nil, nil, err
+	}
+	if err != nil || !challenge.HasPhoto() {
+		return nil, nil, domainErrors.NewAppError(errors.New("no photo has been uploaded for this client"), domainErrors.NotFound)
+	}
+	content, err := u.storage.Open(challenge.PhotoKey)
+	if err != nil {
+		u.Logger.Error("Error opening client identity photo", zap.Error(err))
+		return nil, nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return challenge, content, nil
+}
+
+func (u *IdentityUseCase) OpenVisitPhoto(scheduleID uuid.UUID, callerID *uuid.UUID) (*domainIdentity.Challenge, io.ReadCloser, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := u.authorize(schedule, callerID); err != nil {
+		return nil, nil, err
+	}
+	return u.OpenPhoto(schedule.ClientUserID)
+}
+
+func (u *IdentityUseCase) GetStatus(scheduleID uuid.UUID) (*domainIdentity.Status, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	return u.status(schedule)
+}
+
+func (u *IdentityUseCase) Verify(scheduleID uuid.UUID, method string, answer string, matched bool, note string, callerID *uuid.UUID, now time.Time) (*domainIdentity.Check, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if callerID != nil && *callerID != schedule.AssignedUserID {
+		return nil, domainErrors.NewAppError(errors.New("only the visit's caregiver can confirm the client's identity"), domainErrors.NotAuthorized)
+	}
+	if schedule.VisitStatus != "upcoming" {
+		return nil, domainErrors.NewAppError(errors.New("identity can only be confirmed before the visit is checked in"), domainErrors.ValidationError)
+	}
+	status, err := u.status(schedule)
+	if err != nil {
+		return nil, err
+	}
+	if status.Verified {
+		return nil, domainErrors.NewAppError(errors.New("the client's identity has already been confirmed for this visit"), domainErrors.Conflict)
+	}
+	if status.FailedCount >= domainIdentity.MaxFailedChecks {
+		return nil, domainErrors.NewAppError(errors.New("too many failed identity checks; contact a coordinator"), domainErrors.TooManyRequests)
+	}
+
+	check := &domainIdentity.Check{
+		ScheduleID:      schedule.ID,
+		ClientUserID:    schedule.ClientUserID,
+		CaregiverUserID: schedule.AssignedUserID,
+		Method:          method,
+		Note:            strings.TrimSpace(note),
+		CheckedAt:       now,
+	}
+	switch method {
+	case domainIdentity.MethodSecurityQuestion:
+		if status.Question == "" {
+			return nil, domainErrors.NewAppError(errors.New("no security question has been set for this client"), domainErrors.ValidationError)
+		}
+		if strings.TrimSpace(answer) == "" {
+			return nil, domainErrors.NewAppError(errors.New("the client's answer is required"), domainErrors.ValidationError)
+		}
+		challenge, err := u.identityRepository.GetChallenge(schedule.ClientUserID)
+		if err != nil {
+			return nil, err
+		}
+		check.Passed = bcrypt.CompareHashAndPassword([]byte(challenge.AnswerHash), []byte(normalizeAnswer(answer))) == nil
+	case domainIdentity.MethodPhoto:
+		if !status.HasPhoto {
+			return nil, domainErrors.NewAppError(errors.New("no photo has been uploaded for this client"), domainErrors.ValidationError)
+		}
+		check.Passed = matched
+	default:
+		return nil, domainErrors.NewAppError(fmt.Errorf("method must be '%s' or '%s'", domainIdentity.MethodPhoto, domainIdentity.MethodSecurityQuestion), domainErrors.ValidationError)
+	}
+	u.Logger.Info("Recording identity check", zap.String("scheduleID", schedule.ID.String()), zap.String("method", method), zap.Bool("passed", check.Passed))
+	return u.identityRepository.CreateCheck(check)
+}
+
+func (u *IdentityUseCase) BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error {
+	status, err := u.status(schedule)
+	if err != nil {
+		return err
+	}
+	if !status.Required || !status.FirstVisit || status.Verified {
+		return nil
+	}
+	if status.Question == "" && !status.HasPhoto {
+		return domainErrors.NewAppError(errors.New("this is the caregiver's first visit to the client, but no identity photo or security question has been set up; contact a coordinator"), domainErrors.ValidationError)
+	}
+	return domainErrors.NewAppError(errors.New("the client's identity must be confirmed before the first check-in"), domainErrors.ValidationError)
+}
+
+// status works out whether the visit needs an identity check and what has
+// been recorded on it so far.
+func (u *IdentityUseCase) status(schedule *domainSchedule.Schedule) (*domainIdentity.Status, error) {
+	settings, err := u.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	status := &domainIdentity.Status{Required: settings.Required, Checks: []domainIdentity.Check{}}
+	if !settings.Required {
+		return status, nil
+	}
+	status.FirstVisit, err = u.firstVisit(schedule)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := u.identityRepository.GetChallenge(schedule.ClientUserID)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		if challenge.HasQuestion() {
+			status.Question = challenge.Question
+		}
+		status.HasPhoto = challenge.HasPhoto()
+	}
+	checks, err := u.identityRepository.GetChecks(schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	status.Checks = *checks
+	for _, check := range *checks {
+		if check.Passed {
+			status.Verified = true
+		} else {
+			status.FailedCount++
+		}
+	}
+	return status, nil
+}
+
+// firstVisit reports whether the visit's caregiver has yet to complete a
+// visit with its client.
+func (u *IdentityUseCase) firstVisit(schedule *domainSchedule.Schedule) (bool, error) {
+	result, err := u.scheduleRepository.Search(domainSchedule.SearchQuery{
+		ClientUserIDs:   []uuid.UUID{schedule.ClientUserID},
+		AssignedUserIDs: []uuid.UUID{schedule.AssignedUserID},
+		Statuses:        []string{"completed"},
+		PageSize:        1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(*result.Data) == 0, nil
+}
+
+// challengeFor returns the client's challenge, or a new one for a client who
+// has none yet.
+func (u *IdentityUseCase) challengeFor(clientUserID uuid.UUID) (*domainIdentity.Challenge, error) {
+	client, err := u.userRepository.GetByID(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	if client.Role != domainUser.RoleClient {
+		return nil, domainErrors.NewAppError(errors.New("identity checks can only be set up for clients"), domainErrors.ValidationError)
+	}
+	challenge, err := u.identityRepository.GetChallenge(clientUserID)
+	if isNotFound(err) {
+		return &domainIdentity.Challenge{ClientUserID: clientUserID}, nil
+	}
+	return challenge, err
+}
+
+func (u *IdentityUseCase) authorize(schedule *domainSchedule.Schedule, callerID *uuid.UUID) error {
+	if callerID == nil || *callerID == schedule.AssignedUserID {
+		return nil
+	}
+	caller, err := u.userRepository.GetByID(*callerID)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	if err == nil && caller.Role == domainUser.RoleAdmin {
+		return nil
+	}
+	return domainErrors.NewAppError(errors.New("only the assigned caregiver or an admin can see the client's identity photo"), domainErrors.NotAuthorized)
+}
+
+func (u *IdentityUseCase) removePhoto(key string) {
+	if key == "" {
+		return
+	}
+	if err := u.storage.Delete(key); err != nil {
+		u.Logger.Warn("Error removing client identity photo", zap.Error(err), zap.String("key", key))
+	}
+}
+
+// normalizeAnswer makes answers compare regardless of case and spacing.
+func normalizeAnswer(answer string) string {
+	return strings.ToLower(strings.Join(strings.Fields(answer), " "))
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
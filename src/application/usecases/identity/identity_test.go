@@ -0,0 +1,204 @@
+package identity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainIdentity "caregiver/src/domain/identity"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps settings, challenges and checks in memory
+type memoryRepository struct {
+	settings   *domainIdentity.Settings
+	challenges map[uuid.UUID]*domainIdentity.Challenge
+	checks     []domainIdentity.Check
+}
+
+func (m *memoryRepository) GetSettings() (*domainIdentity.Settings, error) {
+	if m.settings == nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return m.settings, nil
+}
+
+func (m *memoryRepository) SaveSettings(settings *domainIdentity.Settings) (*domainIdentity.Settings, error) {
+	m.settings = settings
+	return settings, nil
+}
+
+func (m *memoryRepository) GetChallenge(clientUserID uuid.UUID) (*domainIdentity.Challenge, error) {
+	challenge, ok := m.challenges[clientUserID]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return challenge, nil
+}
+
+func (m *memoryRepository) SaveChallenge(challenge *domainIdentity.Challenge) (*domainIdentity.Challenge, error) {
+	m.challenges[challenge.ClientUserID] = challenge
+	return challenge, nil
+}
+
+func (m *memoryRepository) CreateCheck(check *domainIdentity.Check) (*domainIdentity.Check, error) {
+	check.ID = uuid.New()
+	m.checks = append(m.checks, *check)
+	return check, nil
+}
+
+func (m *memoryRepository) GetChecks(scheduleID uuid.UUID) (*[]domainIdentity.Check, error) {
+	res := []domainIdentity.Check{}
+	for _, check := range m.checks {
+		if check.ScheduleID == scheduleID {
+			res = append(res, check)
+		}
+	}
+	return &res, nil
+}
+
+// mockScheduleRepository serves a fixed set of visits
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockScheduleRepository) Search(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		if s.ClientUserID == query.ClientUserIDs[0] && s.AssignedUserID == query.AssignedUserIDs[0] && s.VisitStatus == query.Statuses[0] {
+			res = append(res, s)
+		}
+	}
+	return &domainSchedule.SearchResultSchedule{Data: &res}, nil
+}
+
+// mockUserRepository knows only the users it was given
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return user, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestFirstVisitIdentityCheck(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	client := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	ana := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	ben := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	first := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: client.ID, AssignedUserID: ana.ID, VisitStatus: "upcoming"}
+	regular := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: client.ID, AssignedUserID: ben.ID, VisitStatus: "upcoming"}
+	earlier := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: client.ID, AssignedUserID: ben.ID, VisitStatus: "completed"}
+	schedules := &mockScheduleRepository{schedules: []domainSchedule.Schedule{first, regular, earlier}}
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{client.ID: client, ana.ID: ana, ben.ID: ben}}
+	repo := &memoryRepository{challenges: map[uuid.UUID]*domainIdentity.Challenge{}}
+	useCase := NewIdentityUseCase(repo, schedules, users, nil, loggerInstance)
+
+	if err := useCase.BeforeCheckin(&first, now, nil); err != nil {
+		t.Fatalf("expected no check while the organization does not require it, got %v", err)
+	}
+	if _, err := useCase.SetSettings(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := useCase.BeforeCheckin(&regular, now, nil); err != nil {
+		t.Errorf("expected a returning caregiver to check in freely, got %v", err)
+	}
+	if err := useCase.BeforeCheckin(&first, now, nil); errorType(err) != domainErrors.ValidationError {
+		t.Fatalf("expected the first check-in to be blocked, got %v", err)
+	}
+
+	if _, err := useCase.SetQuestion(ana.ID, "Pet's name?", "Rex", nil); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a question for a caregiver to be refused, got %v", err)
+	}
+	if _, err := useCase.SetQuestion(client.ID, "Name of your first dog?", "  Captain   Rex ", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.Verify(first.ID, domainIdentity.MethodPhoto, "", true, "", &ana.ID, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a photo match without a photo to be refused, got %v", err)
+	}
+	if _, err := useCase.Verify(first.ID, domainIdentity.MethodSecurityQuestion, "captain rex", false, "", &ben.ID, now); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected another caregiver to be refused, got %v", err)
+	}
+
+	check, err := useCase.Verify(first.ID, domainIdentity.MethodSecurityQuestion, "Rex", false, "", &ana.ID, now)
+	if err != nil || check.Passed {
+		t.Fatalf("expected a recorded failed check, got %+v, %v", check, err)
+	}
+	if err := useCase.BeforeCheckin(&first, now, nil); err == nil {
+		t.Error("expected a failed check to keep the check-in blocked")
+	}
+	check, err = useCase.Verify(first.ID, domainIdentity.MethodSecurityQuestion, "CAPTAIN rex", false, "", &ana.ID, now)
+	if err != nil || !check.Passed {
+		t.Fatalf("expected the answer to match regardless of case and spacing, got %+v, %v", check, err)
+	}
+	if err := useCase.BeforeCheckin(&first, now, nil); err != nil {
+		t.Errorf("expected the verified visit to check in, got %v", err)
+	}
+	if _, err := useCase.Verify(first.ID, domainIdentity.MethodSecurityQuestion, "Captain Rex", false, "", &ana.ID, now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a second confirmation to conflict, got %v", err)
+	}
+
+	status, err := useCase.GetStatus(first.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !status.FirstVisit || !status.Verified || status.FailedCount != 1 || len(status.Checks) != 2 || status.Question != "Name of your first dog?" {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestVerifyLimitsFailedChecks(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	visit := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: uuid.New(), AssignedUserID: uuid.New(), VisitStatus: "upcoming"}
+	repo := &memoryRepository{
+		settings:   &domainIdentity.Settings{Required: true},
+		challenges: map[uuid.UUID]*domainIdentity.Challenge{visit.ClientUserID: {ClientUserID: visit.ClientUserID, PhotoKey: "identity/photo.png"}},
+	}
+	useCase := NewIdentityUseCase(repo, &mockScheduleRepository{schedules: []domainSchedule.Schedule{visit}}, nil, nil, loggerInstance)
+
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < domainIdentity.MaxFailedChecks; i++ {
+		if _, err := useCase.Verify(visit.ID, domainIdentity.MethodPhoto, "", false, "does not look like the photo", nil, now); err != nil {
+			t.Fatalf("unexpected error on attempt %d: %v", i+1, err)
+		}
+	}
+	if _, err := useCase.Verify(visit.ID, domainIdentity.MethodPhoto, "", true, "", nil, now); errorType(err) != domainErrors.TooManyRequests {
+		t.Errorf("expected further attempts to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+	"caregiver/src/infrastructure/integrity"
+	logger "caregiver/src/infrastructure/logger"
+
+	"go.uber.org/zap"
+)
+
+type IIntegrityUseCase interface {
+	BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error
+}
+
+type IntegrityUseCase struct {
+	verifiers integrity.Registry
+	Logger    *logger.Logger
+}
+
+func NewIntegrityUseCase(verifiers integrity.Registry, loggerInstance *logger.Logger) IIntegrityUseCase {
+	return &IntegrityUseCase{
+		verifiers: verifiers,
+		Logger:    loggerInstance,
+	}
+}
+
+// BeforeCheckin checks the device attestation sent with a check-in and
+// records the outcome with the visit. Tokens are bound to the visit's ID.
+// The check-in goes ahead whatever the outcome: a failed attestation is
+// evidence for a later investigation, not a reason to turn a caregiver away
+// at the door. A verdict replaces the app's own device hints.
+func (u *IntegrityUseCase) BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error {
+	if verification.IntegrityToken == "" {
+		return nil
+	}
+	check := domainSchedule.IntegrityCheck{Platform: verification.IntegrityPlatform}
+	verdict, err := u.verifiers.Verify(context.Background(), verification.IntegrityPlatform, verification.IntegrityToken, schedule.ID.String())
+	switch {
+	case errors.Is(err, integrity.ErrInvalidToken):
+		u.Logger.Warn("Integrity token rejected", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		check.Status, check.Detail = domainSchedule.IntegrityInvalid, err.Error()
+	case err != nil:
+		u.Logger.Error("Error verifying integrity token", zap.Error(err), zap.String("scheduleID", schedule.ID.String()), zap.String("platform", check.Platform))
+		check.Status, check.Detail = domainSchedule.IntegrityUnverified, err.Error()
+	default:
+		check.Status, check.Detail = domainSchedule.IntegrityFailed, verdict.Detail
+		if verdict.Intact {
+			check.Status = domainSchedule.IntegrityVerified
+		} else {
+			u.Logger.Warn("Device failed integrity checks", zap.String("scheduleID", schedule.ID.String()), zap.String("detail", verdict.Detail))
+		}
+		rooted, emulator := verdict.Rooted, verdict.Emulator
+		verification.Device.Rooted = &rooted
+		verification.Device.Emulator = &emulator
+	}
+	verification.Integrity = check
+	return nil
+}
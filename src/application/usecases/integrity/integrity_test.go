@@ -0,0 +1,73 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+	"caregiver/src/infrastructure/integrity"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockVerifier returns the verdict or error configured for each token
+type mockVerifier struct {
+	verdicts map[string]*integrity.Verdict
+	nonces   []string
+}
+
+func (m *mockVerifier) Verify(ctx context.Context, token string, nonce string) (*integrity.Verdict, error) {
+	m.nonces = append(m.nonces, nonce)
+	if token == "unreachable" {
+		return nil, errors.New("connection refused")
+	}
+	verdict, ok := m.verdicts[token]
+	if !ok {
+		return nil, integrity.ErrInvalidToken
+	}
+	return verdict, nil
+}
+
+func TestBeforeCheckin(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	verifier := &mockVerifier{verdicts: map[string]*integrity.Verdict{
+		"genuine": {Intact: true, Detail: "app PLAY_RECOGNIZED; device MEETS_DEVICE_INTEGRITY"},
+		"rooted":  {Rooted: true, Detail: "app PLAY_RECOGNIZED; device no device verdict"},
+	}}
+	useCase := NewIntegrityUseCase(integrity.Registry{domainSchedule.PlatformAndroid: verifier}, loggerInstance)
+	schedule := &domainSchedule.Schedule{ID: uuid.New()}
+
+	tests := []struct {
+		token    string
+		platform string
+		status   string
+	}{
+		{"genuine", domainSchedule.PlatformAndroid, domainSchedule.IntegrityVerified},
+		{"rooted", domainSchedule.PlatformAndroid, domainSchedule.IntegrityFailed},
+		{"forged", domainSchedule.PlatformAndroid, domainSchedule.IntegrityInvalid},
+		{"unreachable", domainSchedule.PlatformAndroid, domainSchedule.IntegrityUnverified},
+		{"genuine", domainSchedule.PlatformIOS, domainSchedule.IntegrityUnverified},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		verification := &domainSchedule.Verification{IntegrityToken: tt.token, IntegrityPlatform: tt.platform}
+		if err := useCase.BeforeCheckin(schedule, time.Now(), verification); err != nil {
+			t.Errorf("%s on %s: expected the check-in allowed, got %v", tt.token, tt.platform, err)
+		}
+		if verification.Integrity.Status != tt.status {
+			t.Errorf("%s on %s: expected %q, got %q", tt.token, tt.platform, tt.status, verification.Integrity.Status)
+		}
+		if tt.token == "rooted" && (verification.Device.Rooted == nil || !*verification.Device.Rooted) {
+			t.Errorf("expected the verdict to replace the device hints, got %+v", verification.Device)
+		}
+	}
+	if len(verifier.nonces) == 0 || verifier.nonces[0] != schedule.ID.String() {
+		t.Errorf("expected tokens bound to the schedule ID, got %v", verifier.nonces)
+	}
+}
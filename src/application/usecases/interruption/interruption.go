@@ -0,0 +1,236 @@
+package interruption
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainInterruption "caregiver/src/domain/interruption"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IInterruptionUseCase interface {
+	// Start pauses a visit under way. The caller, when known, must be the
+	// visit's caregiver or an admin.
+	Start(interruption *domainSchedule.Interruption, callerID *uuid.UUID, now time.Time) (*domainSchedule.Interruption, error)
+	// Resume restarts the visit clock at endedAt.
+	Resume(scheduleID uuid.UUID, endedAt time.Time, callerID *uuid.UUID, now time.Time) (*domainSchedule.Interruption, error)
+	GetBySchedule(scheduleID uuid.UUID) (*[]domainSchedule.Interruption, error)
+	// Report counts the interruptions started in [from, to); open ones are
+	// timed up to now.
+	Report(from time.Time, to time.Time, now time.Time) (*domainInterruption.Report, error)
+	// OnScheduleEvent ends the open interruption of a visit that is checked
+	// out of or called off.
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+type InterruptionUseCase struct {
+	interruptionRepository domainInterruption.IInterruptionRepository
+	scheduleRepository     domainSchedule.IScheduleRepository
+	userRepository         domainUser.IUserRepository
+	Logger                 *logger.Logger
+}
+
+func NewInterruptionUseCase(interruptionRepository domainInterruption.IInterruptionRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) IInterruptionUseCase {
+	return &InterruptionUseCase{
+		interruptionRepository: interruptionRepository,
+		scheduleRepository:     scheduleRepository,
+		userRepository:         userRepository,
+		Logger:                 loggerInstance,
+	}
+}
+
+func (u *InterruptionUseCase) Start(interruption *domainSchedule.Interruption, callerID *uuid.UUID, now time.Time) (*domainSchedule.Interruption, error) {
+	if !domainSchedule.IsInterruptionReason(interruption.ReasonCode) {
+		return nil, domainErrors.NewAppError(fmt.Errorf("reason code must be one of %s", strings.Join(domainSchedule.InterruptionReasons, ", ")), domainErrors.ValidationError)
+	}
+	interruption.Note = strings.TrimSpace(interruption.Note)
+	if interruption.ReasonCode == domainSchedule.InterruptionOther && interruption.Note == "" {
+		return nil, domainErrors.NewAppError(errors.New("a note is required when the reason is 'other'"), domainErrors.ValidationError)
+	}
+	if interruption.StartedAt.IsZero() {
+		interruption.StartedAt = now
+	}
+	if interruption.StartedAt.After(now) {
+		return nil, domainErrors.NewAppError(errors.New("an interruption cannot start in the future"), domainErrors.ValidationError)
+	}
+	schedule, err := u.authorize(interruption.ScheduleID, callerID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.VisitStatus != "in_progress" {
+		return nil, domainErrors.NewAppError(errors.New("only a visit in progress can be interrupted"), domainErrors.ValidationError)
+	}
+	if checkin := checkedInAt(schedule); checkin != nil && interruption.StartedAt.Before(*checkin) {
+		return nil, domainErrors.NewAppError(errors.New("an interruption cannot start before check-in"), domainErrors.ValidationError)
+	}
+	if open, err := u.interruptionRepository.GetOpen(schedule.ID); err == nil {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the visit is already paused since %s", open.StartedAt.Format(time.RFC3339)), domainErrors.Conflict)
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+	interruption.ClientUserID = schedule.ClientUserID
+	interruption.CaregiverUserID = schedule.AssignedUserID
+	interruption.EndedAt = nil
+	u.Logger.Info("Interrupting visit", zap.String("scheduleID", schedule.ID.String()), zap.String("reason", interruption.ReasonCode))
+	return u.interruptionRepository.Create(interruption)
+}
+
+func (u *InterruptionUseCase) Resume(scheduleID uuid.UUID, endedAt time.Time, callerID *uuid.UUID, now time.Time) (*domainSchedule.Interruption, error) {
+	if _, err := u.authorize(scheduleID, callerID); err != nil {
+		return nil, err
+	}
+	open, err := u.interruptionRepository.GetOpen(scheduleID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, domainErrors.NewAppError(errors.New("the visit is not paused"), domainErrors.ValidationError)
+		}
+		return nil, err
+	}
+	if endedAt.IsZero() {
+		endedAt = now
+	}
+	if endedAt.After(now) || endedAt.Before(open.StartedAt) {
+		return nil, domainErrors.NewAppError(errors.New("the visit must resume between the start of the interruption and now"), domainErrors.ValidationError)
+	}
+	u.Logger.Info("Resuming visit", zap.String("scheduleID", scheduleID.String()), zap.Duration("paused", endedAt.Sub(open.StartedAt)))
+	return u.interruptionRepository.End(open.ID, endedAt)
+}
+
+func (u *InterruptionUseCase) GetBySchedule(scheduleID uuid.UUID) (*[]domainSchedule.Interruption, error) {
+	if _, err := u.scheduleRepository.GetScheduleByID(scheduleID); err != nil {
+		return nil, err
+	}
+	return u.interruptionRepository.GetBySchedule(scheduleID)
+}
+
+func (u *InterruptionUseCase) Report(from time.Time, to time.Time, now time.Time) (*domainInterruption.Report, error) {
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+	interruptions, err := u.interruptionRepository.GetBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return buildReport(*interruptions, from, to, now), nil
+}
+
+func (u *InterruptionUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	switch event.Type {
+	case domainSchedule.EventCompleted, domainSchedule.EventCancelled, domainSchedule.EventMissed:
+	default:
+		return
+	}
+	open, err := u.interruptionRepository.GetOpen(event.Schedule.ID)
+	if err != nil {
+		if !isNotFound(err) {
+			u.Logger.Error("Error getting open interruption", zap.Error(err), zap.String("scheduleID", event.Schedule.ID.String()))
+		}
+		return
+	}
+	endedAt := time.Now().UTC()
+	if event.Schedule.CheckoutTime != nil {
+		endedAt = *event.Schedule.CheckoutTime
+	}
+	if endedAt.Before(open.StartedAt) {
+		endedAt = open.StartedAt
+	}
+	if _, err := u.interruptionRepository.End(open.ID, endedAt); err != nil {
+		u.Logger.Error("Error ending interruption", zap.Error(err), zap.String("scheduleID", event.Schedule.ID.String()))
+	}
+}
+
+// authorize loads the visit and checks the caller may log interruptions on
+// it: only its caregiver, or an admin, may.
+func (u *InterruptionUseCase) authorize(scheduleID uuid.UUID, callerID *uuid.UUID) (*domainSchedule.Schedule, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if callerID == nil || schedule.AssignedUserID == *callerID {
+		return schedule, nil
+	}
+	caller, err := u.userRepository.GetByID(*callerID)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if err == nil && caller.Role == domainUser.RoleAdmin {
+		return schedule, nil
+	}
+	return nil, domainErrors.NewAppError(errors.New("only the assigned caregiver or an admin can log interruptions on this visit"), domainErrors.NotAuthorized)
+}
+
+// checkedInAt is when the caregiver checked in to the part of the visit
+// under way.
+func checkedInAt(schedule *domainSchedule.Schedule) *time.Time {
+	for i := range schedule.Segments {
+		if schedule.Segments[i].VisitStatus == "in_progress" {
+			return schedule.Segments[i].CheckinTime
+		}
+	}
+	return schedule.CheckinTime
+}
+
+func buildReport(interruptions []domainSchedule.Interruption, from time.Time, to time.Time, now time.Time) *domainInterruption.Report {
+	report := &domainInterruption.Report{From: from, To: to}
+	byReason := map[string]*domainInterruption.Count{}
+	byClient := map[string]*domainInterruption.Count{}
+	byCaregiver := map[string]*domainInterruption.Count{}
+	for _, interruption := range interruptions {
+		end := now
+		if interruption.EndedAt != nil {
+			end = *interruption.EndedAt
+		}
+		minutes := int(end.Sub(interruption.StartedAt).Minutes())
+		report.Total++
+		report.Minutes += minutes
+		tally(byReason, interruption.ReasonCode, minutes)
+		tally(byClient, interruption.ClientUserID.String(), minutes)
+		tally(byCaregiver, interruption.CaregiverUserID.String(), minutes)
+	}
+	report.ByReason = sorted(byReason)
+	report.ByClient = sorted(byClient)
+	report.ByCaregiver = sorted(byCaregiver)
+	return report
+}
+
+func tally(counts map[string]*domainInterruption.Count, key string, minutes int) {
+	count, ok := counts[key]
+	if !ok {
+		count = &domainInterruption.Count{Key: key}
+		counts[key] = count
+	}
+	count.Count++
+	count.Minutes += minutes
+}
+
+// sorted lists the counts busiest first.
+func sorted(counts map[string]*domainInterruption.Count) []domainInterruption.Count {
+	res := make([]domainInterruption.Count, 0, len(counts))
+	for _, count := range counts {
+		res = append(res, *count)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		if res[i].Minutes != res[j].Minutes {
+			return res[i].Minutes > res[j].Minutes
+		}
+		return res[i].Key < res[j].Key
+	})
+	return res
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
@@ -0,0 +1,178 @@
+package interruption
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps interruptions in a slice
+type memoryRepository struct {
+	interruptions []domainSchedule.Interruption
+}
+
+func (m *memoryRepository) Create(interruption *domainSchedule.Interruption) (*domainSchedule.Interruption, error) {
+	interruption.ID = uuid.New()
+	m.interruptions = append(m.interruptions, *interruption)
+	return interruption, nil
+}
+
+func (m *memoryRepository) GetByID(id uuid.UUID) (*domainSchedule.Interruption, error) {
+	for i := range m.interruptions {
+		if m.interruptions[i].ID == id {
+			return &m.interruptions[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *memoryRepository) GetBySchedule(scheduleID uuid.UUID) (*[]domainSchedule.Interruption, error) {
+	var res []domainSchedule.Interruption
+	for _, interruption := range m.interruptions {
+		if interruption.ScheduleID == scheduleID {
+			res = append(res, interruption)
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) GetOpen(scheduleID uuid.UUID) (*domainSchedule.Interruption, error) {
+	for i := range m.interruptions {
+		if m.interruptions[i].ScheduleID == scheduleID && m.interruptions[i].Open() {
+			return &m.interruptions[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *memoryRepository) GetBetween(from time.Time, to time.Time) (*[]domainSchedule.Interruption, error) {
+	return &m.interruptions, nil
+}
+
+func (m *memoryRepository) End(id uuid.UUID, endedAt time.Time) (*domainSchedule.Interruption, error) {
+	interruption, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	interruption.EndedAt = &endedAt
+	return interruption, nil
+}
+
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedule *domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	return m.schedule, nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	return &domainUser.User{ID: id, Role: domainUser.RoleCaregiver}, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestStartAndResume(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	checkin := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	now := checkin.Add(time.Hour)
+	visit := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: uuid.New(), AssignedUserID: uuid.New(), VisitStatus: "in_progress", CheckinTime: &checkin}
+	repo := &memoryRepository{}
+	useCase := NewInterruptionUseCase(repo, &mockScheduleRepository{schedule: visit}, &mockUserRepository{}, loggerInstance)
+
+	start := func(reason, note string, startedAt time.Time, callerID uuid.UUID) error {
+		_, err := useCase.Start(&domainSchedule.Interruption{ScheduleID: visit.ID, ReasonCode: reason, Note: note, StartedAt: startedAt}, &callerID, now)
+		return err
+	}
+	if err := start("nap", "", now, visit.AssignedUserID); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an unknown reason code to be rejected, got %v", err)
+	}
+	if err := start(domainSchedule.InterruptionOther, " ", now, visit.AssignedUserID); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected 'other' without a note to be rejected, got %v", err)
+	}
+	if err := start(domainSchedule.InterruptionClientRefused, "", checkin.Add(-time.Minute), visit.AssignedUserID); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an interruption before check-in to be rejected, got %v", err)
+	}
+	if err := start(domainSchedule.InterruptionClientRefused, "", now, uuid.New()); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected another caregiver to be refused, got %v", err)
+	}
+	if err := start(domainSchedule.InterruptionEmergencyTransport, "", now.Add(-20*time.Minute), visit.AssignedUserID); err != nil {
+		t.Fatalf("expected the interruption to start, got %v", err)
+	}
+	if err := start(domainSchedule.InterruptionClientRefused, "", now, visit.AssignedUserID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a second open interruption to conflict, got %v", err)
+	}
+	if repo.interruptions[0].ClientUserID != visit.ClientUserID || repo.interruptions[0].CaregiverUserID != visit.AssignedUserID {
+		t.Errorf("expected the interruption to record the visit's client and caregiver, got %+v", repo.interruptions[0])
+	}
+
+	ended, err := useCase.Resume(visit.ID, time.Time{}, &visit.AssignedUserID, now)
+	if err != nil || ended.EndedAt == nil || !ended.EndedAt.Equal(now) {
+		t.Fatalf("expected the visit to resume now, got %+v, %v", ended, err)
+	}
+	if _, err := useCase.Resume(visit.ID, time.Time{}, &visit.AssignedUserID, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected resuming a running visit to be rejected, got %v", err)
+	}
+
+	// Check-out ends an interruption left open.
+	if err := start(domainSchedule.InterruptionClientUnavailable, "", now, visit.AssignedUserID); err != nil {
+		t.Fatalf("expected the interruption to start, got %v", err)
+	}
+	checkout := now.Add(15 * time.Minute)
+	completed := *visit
+	completed.VisitStatus = "completed"
+	completed.CheckoutTime = &checkout
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCompleted, Schedule: &completed})
+	if last := repo.interruptions[1]; last.EndedAt == nil || !last.EndedAt.Equal(checkout) {
+		t.Errorf("expected check-out to end the interruption, got %+v", last)
+	}
+}
+
+func TestReport(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := from.Add(9 * time.Hour)
+	ended := func(minutes int) *time.Time {
+		end := at.Add(time.Duration(minutes) * time.Minute)
+		return &end
+	}
+	ana, ben, client := uuid.New(), uuid.New(), uuid.New()
+	interruptions := []domainSchedule.Interruption{
+		{ClientUserID: client, CaregiverUserID: ana, ReasonCode: domainSchedule.InterruptionClientRefused, StartedAt: at, EndedAt: ended(10)},
+		{ClientUserID: client, CaregiverUserID: ben, ReasonCode: domainSchedule.InterruptionClientRefused, StartedAt: at, EndedAt: ended(20)},
+		{ClientUserID: uuid.New(), CaregiverUserID: ben, ReasonCode: domainSchedule.InterruptionEmergencyTransport, StartedAt: at},
+	}
+	report := buildReport(interruptions, from, from.AddDate(0, 0, 7), at.Add(45*time.Minute))
+	if report.Total != 3 || report.Minutes != 75 {
+		t.Errorf("expected 3 interruptions over 75 minutes, got %d over %d", report.Total, report.Minutes)
+	}
+	if len(report.ByReason) != 2 || report.ByReason[0].Key != domainSchedule.InterruptionClientRefused || report.ByReason[0].Count != 2 {
+		t.Errorf("expected client refusals first, got %+v", report.ByReason)
+	}
+	if report.ByCaregiver[0].Key != ben.String() || report.ByCaregiver[0].Minutes != 65 {
+		t.Errorf("expected Ben first with 65 minutes, got %+v", report.ByCaregiver)
+	}
+	if report.ByClient[0].Key != client.String() || report.ByClient[0].Count != 2 {
+		t.Errorf("expected the client with two interruptions first, got %+v", report.ByClient)
+	}
+}
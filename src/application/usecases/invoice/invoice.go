@@ -0,0 +1,482 @@
+package invoice
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	domainBranding "caregiver/src/domain/branding"
+	domainClaim "caregiver/src/domain/claim"
+	domainErrors "caregiver/src/domain/errors"
+	domainInvoice "caregiver/src/domain/invoice"
+	domainReport "caregiver/src/domain/report"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/pdf"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	FormatPDF = "pdf"
+	FormatCSV = "csv"
+)
+
+// MaxDays caps the billing period invoices are generated for.
+const MaxDays = 93
+
+const dateLayout = "2006-01-02"
+
+// logoHeight is how tall the agency logo is drawn, in points.
+const logoHeight = 48
+
+var csvColumns = []string{"invoice_number", "client_name", "service_date", "service_name", "worked_minutes", "units", "unit_rate", "amount"}
+
+type IInvoiceUseCase interface {
+	CreateRateCard(newRateCard *domainInvoice.RateCard) (*domainInvoice.RateCard, error)
+	GetRateCards() (*[]domainInvoice.RateCard, error)
+	UpdateRateCard(id uuid.UUID, updates map[string]interface{}) (*domainInvoice.RateCard, error)
+	DeleteRateCard(id uuid.UUID) error
+	Generate(from, to time.Time, clientUserID *uuid.UUID, now time.Time) (*[]domainInvoice.Invoice, error)
+	GetInvoices(filter domainInvoice.Filter) (*[]domainInvoice.Invoice, error)
+	GetInvoiceByID(id uuid.UUID) (*domainInvoice.Invoice, error)
+	Download(id uuid.UUID, format string) (*domainReport.Export, error)
+}
+
+// BrandingSource supplies the agency's logo and colors for invoices.
+type BrandingSource interface {
+	Get() (*domainBranding.Branding, error)
+	LogoImage() ([]byte, error)
+}
+
+type InvoiceUseCase struct {
+	invoiceRepository  domainInvoice.IInvoiceRepository
+	claimRepository    domainClaim.IClaimRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	branding           BrandingSource
+	Logger             *logger.Logger
+}
+
+type Option func(*InvoiceUseCase)
+
+// WithBranding heads invoices with the agency's logo and colors.
+func WithBranding(source BrandingSource) Option {
+	return func(u *InvoiceUseCase) {
+		u.branding = source
+	}
+}
+
+func NewInvoiceUseCase(invoiceRepository domainInvoice.IInvoiceRepository, claimRepository domainClaim.IClaimRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger, opts ...Option) IInvoiceUseCase {
+	useCase := &InvoiceUseCase{
+		invoiceRepository:  invoiceRepository,
+		claimRepository:    claimRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+func (u *InvoiceUseCase) CreateRateCard(newRateCard *domainInvoice.RateCard) (*domainInvoice.RateCard, error) {
+	u.Logger.Info("Creating rate card", zap.String("serviceName", newRateCard.ServiceName))
+	newRateCard.ServiceName = strings.TrimSpace(newRateCard.ServiceName)
+	if err := validateRateCard(newRateCard); err != nil {
+		return nil, err
+	}
+	if err := u.checkOverlap(newRateCard); err != nil {
+		return nil, err
+	}
+	return u.invoiceRepository.CreateRateCard(newRateCard)
+}
+
+func (u *InvoiceUseCase) GetRateCards() (*[]domainInvoice.RateCard, error) {
+	return u.invoiceRepository.GetRateCards()
+}
+
+func (u *InvoiceUseCase) UpdateRateCard(id uuid.UUID, updates map[string]interface{}) (*domainInvoice.RateCard, error) {
+	u.Logger.Info("Updating rate card", zap.String("id", id.String()))
+	existing, err := u.invoiceRepository.GetRateCardByID(id)
+	if err != nil {
+		return nil, err
+	}
+	merged := *existing
+	if v, ok := updates["service_name"].(string); ok {
+		merged.ServiceName = strings.TrimSpace(v)
+		updates["service_name"] = merged.ServiceName
+	}
+	if v, ok := updates["unit_minutes"].(int); ok {
+		merged.UnitMinutes = v
+	}
+	if v, ok := updates["unit_rate"].(float64); ok {
+		merged.UnitRate = v
+	}
+	if v, ok := updates["effective_from"].(time.Time); ok {
+		merged.EffectiveFrom = v
+	}
+	if v, ok := updates["effective_to"]; ok {
+		merged.EffectiveTo = nil
+		if t, ok := v.(time.Time); ok {
+			merged.EffectiveTo = &t
+		}
+	}
+	if err := validateRateCard(&merged); err != nil {
+		return nil, err
+	}
+	if err := u.checkOverlap(&merged); err != nil {
+		return nil, err
+	}
+	return u.invoiceRepository.UpdateRateCard(id, updates)
+}
+
+func (u *InvoiceUseCase) DeleteRateCard(id uuid.UUID) error {
+	u.Logger.Info("Deleting rate card", zap.String("id", id.String()))
+	return u.invoiceRepository.DeleteRateCard(id)
+}
+
+func validateRateCard(r *domainInvoice.RateCard) error {
+	if r.ServiceName == "" {
+		return domainErrors.NewAppError(errors.New("service name is required"), domainErrors.ValidationError)
+	}
+	if r.UnitMinutes <= 0 || r.UnitRate <= 0 {
+		return domainErrors.NewAppError(errors.New("unit minutes and unit rate must be positive"), domainErrors.ValidationError)
+	}
+	if r.EffectiveTo != nil && r.EffectiveTo.Before(r.EffectiveFrom) {
+		return domainErrors.NewAppError(errors.New("effective to cannot be before effective from"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+// checkOverlap keeps at most one rate card per service in effect on any
+// day, so a visit date always resolves to a single price.
+func (u *InvoiceUseCase) checkOverlap(card *domainInvoice.RateCard) error {
+	cards, err := u.invoiceRepository.GetRateCards()
+	if err != nil {
+		return err
+	}
+	for i := range *cards {
+		other := &(*cards)[i]
+		if other.ID != card.ID && strings.EqualFold(other.ServiceName, card.ServiceName) && other.Overlaps(card) {
+			return domainErrors.NewAppError(fmt.Errorf("the rate card overlaps the %q rate card effective from %s", other.ServiceName, other.EffectiveFrom.Format(dateLayout)), domainErrors.Conflict)
+		}
+	}
+	return nil
+}
+
+// Generate invoices every client, or just the given one, for the visits
+// completed from the from day through the to day that are not yet billed,
+// one invoice per client. Visits claimed from a payer, and visits whose
+// service has no rate card in effect on the day, are left out.
+func (u *InvoiceUseCase) Generate(from, to time.Time, clientUserID *uuid.UUID, now time.Time) (*[]domainInvoice.Invoice, error) {
+	from, to = domainInvoice.DateOf(from), domainInvoice.DateOf(to)
+	u.Logger.Info("Generating invoices", zap.Time("from", from), zap.Time("to", to))
+	if to.Before(from) {
+		return nil, domainErrors.NewAppError(errors.New("the period must not end before it starts"), domainErrors.ValidationError)
+	}
+	if to.Sub(from) >= MaxDays*24*time.Hour {
+		return nil, domainErrors.NewAppError(fmt.Errorf("a billing period covers at most %d days", MaxDays), domainErrors.ValidationError)
+	}
+
+	clients, err := u.clients(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	clientIDs := make([]uuid.UUID, 0, len(clients))
+	for id := range clients {
+		clientIDs = append(clientIDs, id)
+	}
+	schedules, err := u.scheduleRepository.GetCompletedSchedulesBetween(from, to.AddDate(0, 0, 1), clientIDs)
+	if err != nil {
+		return nil, err
+	}
+	scheduleIDs := make([]uuid.UUID, len(*schedules))
+	for i, schedule := range *schedules {
+		scheduleIDs[i] = schedule.ID
+	}
+	invoiced, err := u.invoiceRepository.GetInvoicedScheduleIDs(scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := u.claimRepository.GetBilledScheduleIDs(scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := u.invoiceRepository.GetRateCards()
+	if err != nil {
+		return nil, err
+	}
+
+	byClient := make(map[uuid.UUID]int)
+	invoices := []domainInvoice.Invoice{}
+	for i := range *schedules {
+		schedule := &(*schedules)[i]
+		if invoiced[schedule.ID] || claimed[schedule.ID] {
+			continue
+		}
+		line, ok := u.price(schedule, *cards)
+		if !ok {
+			continue
+		}
+		index, ok := byClient[schedule.ClientUserID]
+		if !ok {
+			client := clients[schedule.ClientUserID]
+			id := uuid.New()
+			invoices = append(invoices, domainInvoice.Invoice{
+				ID:           id,
+				Number:       invoiceNumber(id, to),
+				ClientUserID: client.ID,
+				ClientName:   strings.TrimSpace(client.FirstName + " " + client.LastName),
+				PeriodStart:  from,
+				PeriodEnd:    to,
+				IssuedAt:     now,
+			})
+			index = len(invoices) - 1
+			byClient[schedule.ClientUserID] = index
+		}
+		invoice := &invoices[index]
+		line.InvoiceID = invoice.ID
+		invoice.Lines = append(invoice.Lines, line)
+		invoice.Total = roundCents(invoice.Total + line.Amount)
+	}
+	if len(invoices) == 0 {
+		return nil, domainErrors.NewAppError(errors.New("no billable visits in the period"), domainErrors.ValidationError)
+	}
+	if err := u.invoiceRepository.CreateInvoices(invoices); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Invoices generated", zap.Int("invoices", len(invoices)))
+	return &invoices, nil
+}
+
+// price bills the visit at the rate card in effect on the day it was
+// checked in. It reports false when there is no rate card or the worked
+// time rounds to zero units.
+func (u *InvoiceUseCase) price(schedule *domainSchedule.Schedule, cards []domainInvoice.RateCard) (domainInvoice.LineItem, bool) {
+	day := schedule.ScheduledSlot.From
+	if schedule.CheckinTime != nil {
+		day = *schedule.CheckinTime
+	}
+	day = domainInvoice.DateOf(day)
+	card, ok := domainInvoice.EffectiveRateCard(cards, schedule.ServiceName, day)
+	if !ok {
+		u.Logger.Warn("No rate card in effect for service on the visit date, visit not invoiced", zap.String("scheduleID", schedule.ID.String()), zap.String("serviceName", schedule.ServiceName))
+		return domainInvoice.LineItem{}, false
+	}
+	worked := schedule.WorkedDuration()
+	units := card.Units(worked)
+	if units <= 0 {
+		return domainInvoice.LineItem{}, false
+	}
+	return domainInvoice.LineItem{
+		ID:            uuid.New(),
+		ScheduleID:    schedule.ID,
+		ServiceDate:   day,
+		ServiceName:   schedule.ServiceName,
+		WorkedMinutes: int(math.Round(worked.Minutes())),
+		Units:         units,
+		UnitRate:      card.UnitRate,
+		Amount:        roundCents(float64(units) * card.UnitRate),
+	}, true
+}
+
+// clients returns the client to invoice, or every client when none is given.
+func (u *InvoiceUseCase) clients(clientUserID *uuid.UUID) (map[uuid.UUID]domainUser.User, error) {
+	clients := make(map[uuid.UUID]domainUser.User)
+	if clientUserID != nil {
+		client, err := u.userRepository.GetByID(*clientUserID)
+		if err != nil {
+			return nil, domainErrors.NewAppError(errors.New("client not found"), domainErrors.NotFound)
+		}
+		if client.Role != domainUser.RoleClient {
+			return nil, domainErrors.NewAppError(errors.New("invoices are only issued to clients"), domainErrors.ValidationError)
+		}
+		clients[client.ID] = *client
+		return clients, nil
+	}
+	users, err := u.userRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range *users {
+		if user.Role == domainUser.RoleClient {
+			clients[user.ID] = user
+		}
+	}
+	return clients, nil
+}
+
+func (u *InvoiceUseCase) GetInvoices(filter domainInvoice.Filter) (*[]domainInvoice.Invoice, error) {
+	return u.invoiceRepository.GetInvoices(filter)
+}
+
+func (u *InvoiceUseCase) GetInvoiceByID(id uuid.UUID) (*domainInvoice.Invoice, error) {
+	return u.invoiceRepository.GetInvoiceByID(id)
+}
+
+// Download renders the invoice as a printable PDF addressed to the client,
+// or as CSV with a row per line item.
+func (u *InvoiceUseCase) Download(id uuid.UUID, format string) (*domainReport.Export, error) {
+	if format != FormatPDF && format != FormatCSV {
+		return nil, domainErrors.NewAppError(errors.New("format must be 'pdf' or 'csv'"), domainErrors.ValidationError)
+	}
+	invoice, err := u.invoiceRepository.GetInvoiceByID(id)
+	if err != nil {
+		return nil, err
+	}
+	export := &domainReport.Export{
+		FileName: fmt.Sprintf("invoice-%s.%s", invoice.Number, format),
+		Rows:     len(invoice.Lines),
+		From:     invoice.PeriodStart,
+		To:       invoice.PeriodEnd,
+	}
+	if format == FormatCSV {
+		export.ContentType = "text/csv; charset=utf-8"
+		export.Data, err = u.renderCSV(invoice)
+	} else {
+		export.ContentType = "application/pdf"
+		export.Data, err = u.renderPDF(invoice)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (u *InvoiceUseCase) renderCSV(invoice *domainInvoice.Invoice) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write(csvColumns)
+	for _, line := range invoice.Lines {
+		_ = writer.Write([]string{
+			invoice.Number,
+			invoice.ClientName,
+			line.ServiceDate.Format(dateLayout),
+			line.ServiceName,
+			strconv.Itoa(line.WorkedMinutes),
+			strconv.Itoa(line.Units),
+			money(line.UnitRate),
+			money(line.Amount),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		u.Logger.Error("Error rendering invoice", zap.Error(err), zap.String("id", invoice.ID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderPDF lays the invoice out under the agency's logo and billing
+// details when they are set up.
+func (u *InvoiceUseCase) renderPDF(invoice *domainInvoice.Invoice) ([]byte, error) {
+	doc := pdf.New()
+	displayName, err := u.applyBranding(doc)
+	if err != nil {
+		return nil, err
+	}
+	if provider, err := u.claimRepository.GetProviderSettings(); err == nil {
+		if displayName == "" {
+			displayName = provider.Name
+		}
+		doc.Heading(displayName)
+		doc.Line(provider.Street)
+		doc.Line(fmt.Sprintf("%s, %s %s", provider.City, provider.State, provider.Zip))
+		if provider.ContactPhone != "" {
+			doc.Line(provider.ContactPhone)
+		}
+	} else if !isNotFound(err) {
+		return nil, err
+	} else if displayName != "" {
+		doc.Heading(displayName)
+	}
+	doc.Blank()
+	doc.Heading("Invoice " + invoice.Number)
+	doc.Line(fmt.Sprintf("Period: %s to %s", invoice.PeriodStart.Format(dateLayout), invoice.PeriodEnd.Format(dateLayout)))
+	doc.Line("Invoice date: " + invoice.IssuedAt.Format(dateLayout))
+	doc.Blank()
+	doc.Bold(invoice.ClientName)
+	if client, err := u.userRepository.GetByID(invoice.ClientUserID); err == nil {
+		location := client.Location
+		if street := strings.TrimSpace(location.HouseNumber + " " + location.Street); street != "" {
+			doc.Line(street)
+		}
+		if location.City != "" {
+			doc.Line(strings.TrimSpace(fmt.Sprintf("%s, %s %s", location.City, location.State, location.Pincode)))
+		}
+	}
+	doc.Blank()
+
+	row := "%-11s%-30s%8s%7s%10s%12s"
+	doc.Bold(fmt.Sprintf(row, "Date", "Service", "Hours", "Units", "Rate", "Amount"))
+	for _, line := range invoice.Lines {
+		hours := strconv.FormatFloat(float64(line.WorkedMinutes)/60, 'f', 2, 64)
+		doc.Line(fmt.Sprintf(row, line.ServiceDate.Format(dateLayout), truncate(line.ServiceName, 29), hours, strconv.Itoa(line.Units), money(line.UnitRate), money(line.Amount)))
+	}
+	doc.Blank()
+	doc.Bold(fmt.Sprintf("%-66s%12s", "Total due", money(invoice.Total)))
+	return doc.Bytes(), nil
+}
+
+// applyBranding draws the agency logo, colors the headings and returns the
+// agency's display name. A logo that cannot be drawn is left out rather
+// than failing the invoice.
+func (u *InvoiceUseCase) applyBranding(doc *pdf.Document) (string, error) {
+	if u.branding == nil {
+		return "", nil
+	}
+	branding, err := u.branding.Get()
+	if err != nil {
+		return "", err
+	}
+	if r, g, b, ok := domainBranding.ParseColor(branding.PrimaryColor); ok {
+		doc.HeadingColor(r, g, b)
+	}
+	if !branding.HasLogo() {
+		return branding.DisplayName, nil
+	}
+	logo, err := u.branding.LogoImage()
+	if err != nil {
+		return "", err
+	}
+	if err := doc.Image(logo, logoHeight); err != nil {
+		u.Logger.Warn("Leaving the agency logo off the invoice", zap.Error(err))
+	} else {
+		doc.Blank()
+	}
+	return branding.DisplayName, nil
+}
+
+// invoiceNumber is the billing month followed by the start of the invoice ID.
+func invoiceNumber(id uuid.UUID, periodEnd time.Time) string {
+	return fmt.Sprintf("INV-%s-%s", periodEnd.Format("200601"), strings.ToUpper(id.String()[:8]))
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
+
+func money(v float64) string {
+	return fmt.Sprintf("%.2f", roundCents(v))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "~"
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,228 @@
+package invoice
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domainClaim "caregiver/src/domain/claim"
+	domainErrors "caregiver/src/domain/errors"
+	domainInvoice "caregiver/src/domain/invoice"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockInvoiceRepository keeps rate cards and invoices in memory
+type mockInvoiceRepository struct {
+	domainInvoice.IInvoiceRepository
+	cards    []domainInvoice.RateCard
+	invoices []domainInvoice.Invoice
+}
+
+func (m *mockInvoiceRepository) CreateRateCard(newRateCard *domainInvoice.RateCard) (*domainInvoice.RateCard, error) {
+	newRateCard.ID = uuid.New()
+	m.cards = append(m.cards, *newRateCard)
+	return newRateCard, nil
+}
+
+func (m *mockInvoiceRepository) GetRateCards() (*[]domainInvoice.RateCard, error) {
+	return &m.cards, nil
+}
+
+func (m *mockInvoiceRepository) CreateInvoices(invoices []domainInvoice.Invoice) error {
+	m.invoices = append(m.invoices, invoices...)
+	return nil
+}
+
+func (m *mockInvoiceRepository) GetInvoiceByID(id uuid.UUID) (*domainInvoice.Invoice, error) {
+	for i := range m.invoices {
+		if m.invoices[i].ID == id {
+			return &m.invoices[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockInvoiceRepository) GetInvoicedScheduleIDs(scheduleIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	invoiced := map[uuid.UUID]bool{}
+	for _, invoice := range m.invoices {
+		for _, line := range invoice.Lines {
+			invoiced[line.ScheduleID] = true
+		}
+	}
+	return invoiced, nil
+}
+
+// mockClaimRepository knows which visits were claimed from a payer
+type mockClaimRepository struct {
+	domainClaim.IClaimRepository
+	claimed map[uuid.UUID]bool
+}
+
+func (m *mockClaimRepository) GetBilledScheduleIDs(scheduleIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	return m.claimed, nil
+}
+
+func (m *mockClaimRepository) GetProviderSettings() (*domainClaim.ProviderSettings, error) {
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockScheduleRepository serves the completed visits of the given clients
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	completed []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetCompletedSchedulesBetween(from, to time.Time, clientUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	clients := map[uuid.UUID]bool{}
+	for _, id := range clientUserIDs {
+		clients[id] = true
+	}
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.completed {
+		if clients[s.ClientUserID] && !s.CheckoutTime.Before(from) && s.CheckoutTime.Before(to) {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users []domainUser.User
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) {
+	return &m.users, nil
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	for i := range m.users {
+		if m.users[i].ID == id {
+			return &m.users[i], nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+func visit(clientID uuid.UUID, service string, checkin time.Time, minutes int) domainSchedule.Schedule {
+	checkout := checkin.Add(time.Duration(minutes) * time.Minute)
+	return domainSchedule.Schedule{
+		ID:            uuid.New(),
+		ClientUserID:  clientID,
+		ServiceName:   service,
+		VisitStatus:   "completed",
+		ScheduledSlot: domainSchedule.ScheduledSlot{From: checkin, To: checkout},
+		CheckinTime:   &checkin,
+		CheckoutTime:  &checkout,
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	rosa := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, FirstName: "Rosa", LastName: "Diaz"}
+	sam := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, FirstName: "Sam", LastName: "Lee"}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	day := func(d, hour int) time.Time { return time.Date(2026, 3, d, hour, 0, 0, 0, time.UTC) }
+
+	care := visit(rosa.ID, "Personal care", day(2, 9), 60)
+	second := visit(rosa.ID, "personal care", day(31, 21), 125)
+	unpriced := visit(rosa.ID, "Companionship", day(3, 9), 60)
+	claimed := visit(sam.ID, "Personal care", day(4, 9), 60)
+	nextMonth := visit(sam.ID, "Personal care", day(32, 9), 60)
+	repo := &mockInvoiceRepository{}
+	uc := NewInvoiceUseCase(repo,
+		&mockClaimRepository{claimed: map[uuid.UUID]bool{claimed.ID: true}},
+		&mockScheduleRepository{completed: []domainSchedule.Schedule{care, second, unpriced, claimed, nextMonth}},
+		&mockUserRepository{users: []domainUser.User{rosa, sam, caregiver}},
+		loggerInstance)
+
+	if _, err := uc.CreateRateCard(&domainInvoice.RateCard{ServiceName: " Personal care ", UnitMinutes: 15, UnitRate: 8.5}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.CreateRateCard(&domainInvoice.RateCard{ServiceName: "personal care", UnitMinutes: 60, UnitRate: 30, EffectiveFrom: day(15, 0)}); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected an overlapping rate card rejected, got %v", err)
+	}
+
+	now := day(32, 12)
+	invoices, err := uc.Generate(day(1, 0), day(31, 0), nil, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*invoices) != 1 {
+		t.Fatalf("expected one invoice, for Rosa, got %+v", *invoices)
+	}
+	invoice := (*invoices)[0]
+	if invoice.ClientName != "Rosa Diaz" || !strings.HasPrefix(invoice.Number, "INV-202603-") || !invoice.PeriodEnd.Equal(day(31, 0)) {
+		t.Errorf("unexpected invoice %+v", invoice)
+	}
+	if len(invoice.Lines) != 2 || invoice.Lines[0].Units != 4 || invoice.Lines[1].Units != 8 || invoice.Lines[1].WorkedMinutes != 125 {
+		t.Errorf("expected the two priced visits billed in 15 minute units, got %+v", invoice.Lines)
+	}
+	if invoice.Total != 102 {
+		t.Errorf("expected 12 units at 8.50, got %v", invoice.Total)
+	}
+
+	if _, err := uc.Generate(day(1, 0), day(31, 0), nil, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected nothing left to bill, got %v", err)
+	}
+	if _, err := uc.Generate(day(1, 0), day(31, 0), &caregiver.ID, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a caregiver rejected, got %v", err)
+	}
+	if _, err := uc.Generate(day(2, 0), day(1, 0), nil, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a backwards period rejected, got %v", err)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, FirstName: "Rosa", LastName: "Diaz", Location: domainUser.Location{HouseNumber: "12", Street: "Elm St", City: "Austin", State: "TX"}}
+	march := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	invoice := domainInvoice.Invoice{
+		ID: uuid.New(), Number: "INV-202603-ABCDEF12", ClientUserID: client.ID, ClientName: "Rosa Diaz",
+		PeriodStart: march, PeriodEnd: march.AddDate(0, 1, -1), IssuedAt: march.AddDate(0, 1, 0), Total: 34,
+		Lines: []domainInvoice.LineItem{{ScheduleID: uuid.New(), ServiceDate: march, ServiceName: "Personal care", WorkedMinutes: 60, Units: 4, UnitRate: 8.5, Amount: 34}},
+	}
+	uc := NewInvoiceUseCase(&mockInvoiceRepository{invoices: []domainInvoice.Invoice{invoice}}, &mockClaimRepository{},
+		&mockScheduleRepository{}, &mockUserRepository{users: []domainUser.User{client}}, loggerInstance)
+
+	export, err := uc.Download(invoice.ID, FormatCSV)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "invoice_number,client_name,service_date,service_name,worked_minutes,units,unit_rate,amount\n" +
+		"INV-202603-ABCDEF12,Rosa Diaz,2026-03-01,Personal care,60,4,8.50,34.00\n"
+	if string(export.Data) != expected || export.FileName != "invoice-INV-202603-ABCDEF12.csv" {
+		t.Errorf("unexpected CSV %q named %s", export.Data, export.FileName)
+	}
+
+	export, err = uc.Download(invoice.ID, FormatPDF)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(export.Data, []byte("%PDF-")) || export.ContentType != "application/pdf" {
+		t.Errorf("expected a PDF, got %q", export.ContentType)
+	}
+	if _, err := uc.Download(invoice.ID, "xlsx"); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an unknown format rejected, got %v", err)
+	}
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
@@ -0,0 +1,324 @@
+package kiosk
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	domainErrors "caregiver/src/domain/errors"
+	domainKiosk "caregiver/src/domain/kiosk"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	tokenPrefix = "kiosk_"
+	// MaxPINFailures wrong PINs within PINLockout lock a caregiver out of
+	// every kiosk until the window has passed.
+	MaxPINFailures = 5
+	PINLockout     = 15 * time.Minute
+)
+
+type IKioskUseCase interface {
+	Register(newKiosk *domainKiosk.Kiosk) (*domainKiosk.Kiosk, string, error)
+	GetKiosks() (*[]domainKiosk.Kiosk, error)
+	GetByID(id uuid.UUID) (*domainKiosk.Kiosk, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*domainKiosk.Kiosk, error)
+	RotateToken(id uuid.UUID) (*domainKiosk.Kiosk, string, error)
+	Delete(id uuid.UUID) error
+	SetPIN(userID uuid.UUID, pin string) error
+	GetAudit(kioskID uuid.UUID, from, to time.Time) (*[]domainKiosk.AuditEntry, error)
+	Authenticate(token string) (*domainKiosk.Kiosk, error)
+	GetSchedules(kiosk *domainKiosk.Kiosk, now time.Time) (*[]domainSchedule.Schedule, error)
+	CheckIn(kiosk *domainKiosk.Kiosk, scheduleID uuid.UUID, pin string, ipAddress string, now time.Time) (*domainSchedule.Schedule, error)
+	CheckOut(kiosk *domainKiosk.Kiosk, scheduleID uuid.UUID, pin string, ipAddress string, now time.Time) (*domainSchedule.Schedule, error)
+}
+
+type KioskUseCase struct {
+	kioskRepository    domainKiosk.IKioskRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	scheduleUseCase    scheduleUseCase.IScheduleUseCase
+	Logger             *logger.Logger
+}
+
+func NewKioskUseCase(kioskRepository domainKiosk.IKioskRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, scheduleUseCase scheduleUseCase.IScheduleUseCase, loggerInstance *logger.Logger) IKioskUseCase {
+	return &KioskUseCase{
+		kioskRepository:    kioskRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		scheduleUseCase:    scheduleUseCase,
+		Logger:             loggerInstance,
+	}
+}
+
+// Register creates a kiosk and returns its token. Only a hash of the token is
+// stored, so this is the one time it can be read.
+func (u *KioskUseCase) Register(newKiosk *domainKiosk.Kiosk) (*domainKiosk.Kiosk, string, error) {
+	u.Logger.Info("Registering kiosk", zap.String("name", newKiosk.Name))
+	if strings.TrimSpace(newKiosk.Name) == "" {
+		return nil, "", domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	if strings.TrimSpace(newKiosk.Address.Street) == "" {
+		return nil, "", domainErrors.NewAppError(errors.New("facility street address is required"), domainErrors.ValidationError)
+	}
+	token, err := generateToken()
+	if err != nil {
+		u.Logger.Error("Error generating kiosk token", zap.Error(err))
+		return nil, "", domainErrors.NewAppErrorWithType(domainErrors.TokenGeneratorError)
+	}
+	newKiosk.ID = uuid.New()
+	newKiosk.TokenHash = hashToken(token)
+	newKiosk.Active = true
+	created, err := u.kioskRepository.Create(newKiosk)
+	if err != nil {
+		return nil, "", err
+	}
+	return created, token, nil
+}
+
+func (u *KioskUseCase) GetKiosks() (*[]domainKiosk.Kiosk, error) {
+	return u.kioskRepository.GetAll()
+}
+
+func (u *KioskUseCase) GetByID(id uuid.UUID) (*domainKiosk.Kiosk, error) {
+	return u.kioskRepository.GetByID(id)
+}
+
+func (u *KioskUseCase) Update(id uuid.UUID, updates map[string]interface{}) (*domainKiosk.Kiosk, error) {
+	u.Logger.Info("Updating kiosk", zap.String("id", id.String()))
+	if name, ok := updates["name"].(string); ok && strings.TrimSpace(name) == "" {
+		return nil, domainErrors.NewAppError(errors.New("name cannot be empty"), domainErrors.ValidationError)
+	}
+	if street, ok := updates["address_street"].(string); ok && strings.TrimSpace(street) == "" {
+		return nil, domainErrors.NewAppError(errors.New("facility street address cannot be empty"), domainErrors.ValidationError)
+	}
+	if _, err := u.kioskRepository.GetByID(id); err != nil {
+		return nil, err
+	}
+	return u.kioskRepository.Update(id, updates)
+}
+
+// RotateToken replaces a kiosk's token, e.g. when a tablet is lost. The old
+// token stops working immediately.
+func (u *KioskUseCase) RotateToken(id uuid.UUID) (*domainKiosk.Kiosk, string, error) {
+	u.Logger.Info("Rotating kiosk token", zap.String("id", id.String()))
+	if _, err := u.kioskRepository.GetByID(id); err != nil {
+		return nil, "", err
+	}
+	token, err := generateToken()
+	if err != nil {
+		u.Logger.Error("Error generating kiosk token", zap.Error(err))
+		return nil, "", domainErrors.NewAppErrorWithType(domainErrors.TokenGeneratorError)
+	}
+	updated, err := u.kioskRepository.Update(id, map[string]interface{}{"token_hash": hashToken(token)})
+	if err != nil {
+		return nil, "", err
+	}
+	return updated, token, nil
+}
+
+func (u *KioskUseCase) Delete(id uuid.UUID) error {
+	u.Logger.Info("Deleting kiosk", zap.String("id", id.String()))
+	return u.kioskRepository.Delete(id)
+}
+
+// SetPIN sets the PIN a caregiver enters at kiosks: 4 to 8 digits.
+func (u *KioskUseCase) SetPIN(userID uuid.UUID, pin string) error {
+	u.Logger.Info("Setting kiosk PIN", zap.String("userID", userID.String()))
+	user, err := u.userRepository.GetByID(userID)
+	if err != nil {
+		return domainErrors.NewAppError(errors.New("user not found"), domainErrors.NotFound)
+	}
+	if user.Role != domainUser.RoleCaregiver {
+		return domainErrors.NewAppError(errors.New("only caregivers can have a kiosk PIN"), domainErrors.ValidationError)
+	}
+	if len(pin) < 4 || len(pin) > 8 || strings.Trim(pin, "0123456789") != "" {
+		return domainErrors.NewAppError(errors.New("PIN must be 4 to 8 digits"), domainErrors.ValidationError)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		u.Logger.Error("Error hashing kiosk PIN", zap.Error(err))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	_, err = u.kioskRepository.SavePIN(&domainKiosk.PIN{UserID: userID, PINHash: string(hash)})
+	return err
+}
+
+func (u *KioskUseCase) GetAudit(kioskID uuid.UUID, from, to time.Time) (*[]domainKiosk.AuditEntry, error) {
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+	if _, err := u.kioskRepository.GetByID(kioskID); err != nil {
+		return nil, err
+	}
+	return u.kioskRepository.GetAuditEntries(kioskID, from, to)
+}
+
+// Authenticate resolves a kiosk token to its active kiosk.
+func (u *KioskUseCase) Authenticate(token string) (*domainKiosk.Kiosk, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, domainErrors.NewAppError(errors.New("kiosk token is invalid"), domainErrors.NotAuthenticated)
+	}
+	kiosk, err := u.kioskRepository.GetByTokenHash(hashToken(token))
+	if err != nil {
+		if isNotFound(err) {
+			return nil, domainErrors.NewAppError(errors.New("kiosk token is invalid"), domainErrors.NotAuthenticated)
+		}
+		return nil, err
+	}
+	if !kiosk.Active {
+		return nil, domainErrors.NewAppError(errors.New("kiosk is deactivated"), domainErrors.NotAuthenticated)
+	}
+	if _, err := u.kioskRepository.Update(kiosk.ID, map[string]interface{}{"last_used_at": time.Now().UTC()}); err != nil {
+		u.Logger.Warn("Error recording kiosk use", zap.Error(err), zap.String("kioskID", kiosk.ID.String()))
+	}
+	return kiosk, nil
+}
+
+// GetSchedules lists today's visits (UTC) at the kiosk's facility.
+func (u *KioskUseCase) GetSchedules(kiosk *domainKiosk.Kiosk, now time.Time) (*[]domainSchedule.Schedule, error) {
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	schedules, err := u.scheduleRepository.GetActiveSchedulesBetween(dayStart, dayStart.AddDate(0, 0, 1), nil)
+	if err != nil {
+		return nil, err
+	}
+	atFacility := map[uuid.UUID]bool{}
+	res := []domainSchedule.Schedule{}
+	for _, s := range *schedules {
+		matches, ok := atFacility[s.ClientUserID]
+		if !ok {
+			matches = u.clientAtFacility(kiosk, s.ClientUserID)
+			atFacility[s.ClientUserID] = matches
+		}
+		if matches {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+// CheckIn starts a visit at the kiosk's facility once the assigned caregiver
+// has entered their PIN. The kiosk stands in for the caregiver's GPS fix.
+func (u *KioskUseCase) CheckIn(kiosk *domainKiosk.Kiosk, scheduleID uuid.UUID, pin string, ipAddress string, now time.Time) (*domainSchedule.Schedule, error) {
+	u.Logger.Info("Kiosk check-in", zap.String("kioskID", kiosk.ID.String()), zap.String("scheduleID", scheduleID.String()))
+	return u.clock(kiosk, scheduleID, pin, ipAddress, now, domainKiosk.ActionCheckIn, func() (*domainSchedule.Schedule, error) {
+		verification := domainSchedule.Verification{Method: domainSchedule.VerificationKiosk, KioskID: &kiosk.ID}
+		return u.scheduleUseCase.StartSchedule(scheduleID, now, domainSchedule.Location{}, verification)
+	})
+}
+
+func (u *KioskUseCase) CheckOut(kiosk *domainKiosk.Kiosk, scheduleID uuid.UUID, pin string, ipAddress string, now time.Time) (*domainSchedule.Schedule, error) {
+	u.Logger.Info("Kiosk check-out", zap.String("kioskID", kiosk.ID.String()), zap.String("scheduleID", scheduleID.String()))
+	return u.clock(kiosk, scheduleID, pin, ipAddress, now, domainKiosk.ActionCheckOut, func() (*domainSchedule.Schedule, error) {
+		return u.scheduleUseCase.EndSchedule(scheduleID, now, domainSchedule.Location{}, nil)
+	})
+}
+
+// clock checks that the visit belongs to the kiosk's facility and that the PIN
+// is the assigned caregiver's, runs the check-in or check-out, and audits the
+// outcome either way.
+func (u *KioskUseCase) clock(kiosk *domainKiosk.Kiosk, scheduleID uuid.UUID, pin string, ipAddress string, now time.Time, action string, run func() (*domainSchedule.Schedule, error)) (*domainSchedule.Schedule, error) {
+	entry := &domainKiosk.AuditEntry{KioskID: kiosk.ID, ScheduleID: &scheduleID, IPAddress: ipAddress, OccurredAt: now}
+
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		u.audit(entry, domainKiosk.ActionRejected, "visit not found")
+		return nil, err
+	}
+	caregiverID := schedule.AssignedUserID
+	entry.CaregiverUserID = &caregiverID
+
+	if !u.clientAtFacility(kiosk, schedule.ClientUserID) {
+		u.audit(entry, domainKiosk.ActionRejected, "visit is not at this facility")
+		return nil, domainErrors.NewAppError(errors.New("visit is not at this kiosk's facility"), domainErrors.NotAuthorized)
+	}
+	if err := u.verifyPIN(caregiverID, pin, now); err != nil {
+		u.audit(entry, domainKiosk.ActionPINRejected, err.Error())
+		return nil, err
+	}
+
+	updated, err := run()
+	if err != nil {
+		u.audit(entry, domainKiosk.ActionRejected, err.Error())
+		return nil, err
+	}
+	u.audit(entry, action, "")
+	return updated, nil
+}
+
+func (u *KioskUseCase) verifyPIN(caregiverID uuid.UUID, pin string, now time.Time) error {
+	failures, err := u.kioskRepository.CountPINFailuresSince(caregiverID, now.Add(-PINLockout))
+	if err != nil {
+		return err
+	}
+	if failures >= MaxPINFailures {
+		u.Logger.Warn("Kiosk PIN locked out", zap.String("caregiverUserID", caregiverID.String()))
+		return domainErrors.NewAppError(fmt.Errorf("too many wrong PINs, try again in %d minutes", int(PINLockout.Minutes())), domainErrors.NotAuthorized)
+	}
+	stored, err := u.kioskRepository.GetPIN(caregiverID)
+	if err != nil {
+		if isNotFound(err) {
+			return domainErrors.NewAppError(errors.New("caregiver has no kiosk PIN"), domainErrors.NotAuthorized)
+		}
+		return err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(stored.PINHash), []byte(pin)) != nil {
+		return domainErrors.NewAppError(errors.New("PIN is incorrect"), domainErrors.NotAuthorized)
+	}
+	return nil
+}
+
+func (u *KioskUseCase) clientAtFacility(kiosk *domainKiosk.Kiosk, clientUserID uuid.UUID) bool {
+	client, err := u.userRepository.GetByID(clientUserID)
+	if err != nil {
+		u.Logger.Warn("Client not found for kiosk visit", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return false
+	}
+	return kiosk.Address.Matches(domainKiosk.Address{
+		HouseNumber: client.Location.HouseNumber,
+		Street:      client.Location.Street,
+		City:        client.Location.City,
+		State:       client.Location.State,
+		Pincode:     client.Location.Pincode,
+	})
+}
+
+// audit records a kiosk attempt. A failure to write the entry is logged but
+// does not change the outcome of the attempt.
+func (u *KioskUseCase) audit(entry *domainKiosk.AuditEntry, action string, detail string) {
+	entry.ID = uuid.New()
+	entry.Action = action
+	entry.Detail = detail
+	if _, err := u.kioskRepository.CreateAuditEntry(entry); err != nil {
+		u.Logger.Error("Error recording kiosk audit entry", zap.Error(err), zap.String("kioskID", entry.KioskID.String()), zap.String("action", action))
+	}
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return tokenPrefix + hex.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
@@ -0,0 +1,324 @@
+package kiosk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	domainErrors "caregiver/src/domain/errors"
+	domainKiosk "caregiver/src/domain/kiosk"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockKioskRepository is an in-memory implementation of IKioskRepository
+type mockKioskRepository struct {
+	kiosks map[uuid.UUID]*domainKiosk.Kiosk
+	pins   map[uuid.UUID]domainKiosk.PIN
+	audit  []domainKiosk.AuditEntry
+}
+
+func newMockKioskRepository() *mockKioskRepository {
+	return &mockKioskRepository{kiosks: map[uuid.UUID]*domainKiosk.Kiosk{}, pins: map[uuid.UUID]domainKiosk.PIN{}}
+}
+
+func (m *mockKioskRepository) Create(newKiosk *domainKiosk.Kiosk) (*domainKiosk.Kiosk, error) {
+	stored := *newKiosk
+	m.kiosks[stored.ID] = &stored
+	return &stored, nil
+}
+
+func (m *mockKioskRepository) GetByID(id uuid.UUID) (*domainKiosk.Kiosk, error) {
+	kiosk, ok := m.kiosks[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return kiosk, nil
+}
+
+func (m *mockKioskRepository) GetAll() (*[]domainKiosk.Kiosk, error) {
+	res := []domainKiosk.Kiosk{}
+	for _, k := range m.kiosks {
+		res = append(res, *k)
+	}
+	return &res, nil
+}
+
+func (m *mockKioskRepository) GetByTokenHash(tokenHash string) (*domainKiosk.Kiosk, error) {
+	for _, k := range m.kiosks {
+		if k.TokenHash == tokenHash {
+			return k, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockKioskRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainKiosk.Kiosk, error) {
+	kiosk, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := updates["token_hash"].(string); ok {
+		kiosk.TokenHash = v
+	}
+	if v, ok := updates["active"].(bool); ok {
+		kiosk.Active = v
+	}
+	return kiosk, nil
+}
+
+func (m *mockKioskRepository) Delete(id uuid.UUID) error {
+	delete(m.kiosks, id)
+	return nil
+}
+
+func (m *mockKioskRepository) GetPIN(userID uuid.UUID) (*domainKiosk.PIN, error) {
+	pin, ok := m.pins[userID]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &pin, nil
+}
+
+func (m *mockKioskRepository) SavePIN(pin *domainKiosk.PIN) (*domainKiosk.PIN, error) {
+	m.pins[pin.UserID] = *pin
+	return pin, nil
+}
+
+func (m *mockKioskRepository) CreateAuditEntry(entry *domainKiosk.AuditEntry) (*domainKiosk.AuditEntry, error) {
+	m.audit = append(m.audit, *entry)
+	return entry, nil
+}
+
+func (m *mockKioskRepository) GetAuditEntries(kioskID uuid.UUID, from, to time.Time) (*[]domainKiosk.AuditEntry, error) {
+	return &m.audit, nil
+}
+
+func (m *mockKioskRepository) CountPINFailuresSince(caregiverUserID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	for _, e := range m.audit {
+		if e.Action == domainKiosk.ActionPINRejected && *e.CaregiverUserID == caregiverUserID && !e.OccurredAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// mockScheduleRepository serves a fixed set of schedules
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockScheduleRepository) GetActiveSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	return &m.schedules, nil
+}
+
+// mockUserRepository looks users up in a map
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &user, nil
+}
+
+// mockScheduleUseCase records the check-ins it is asked to perform
+type mockScheduleUseCase struct {
+	scheduleUseCase.IScheduleUseCase
+	started      []domainSchedule.Verification
+	startErr     error
+	endedIDs     []uuid.UUID
+	scheduleRepo *mockScheduleRepository
+}
+
+func (m *mockScheduleUseCase) StartSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, verification domainSchedule.Verification) (*domainSchedule.Schedule, error) {
+	if m.startErr != nil {
+		return nil, m.startErr
+	}
+	m.started = append(m.started, verification)
+	return m.scheduleRepo.GetScheduleByID(scheduleID)
+}
+
+func (m *mockScheduleUseCase) EndSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, tasks []domainSchedule.Task) (*domainSchedule.Schedule, error) {
+	m.endedIDs = append(m.endedIDs, scheduleID)
+	return m.scheduleRepo.GetScheduleByID(scheduleID)
+}
+
+var facility = domainKiosk.Address{HouseNumber: "12", Street: "Elm Street", City: "Springfield", Pincode: "12345"}
+
+type fixture struct {
+	useCase    IKioskUseCase
+	kioskRepo  *mockKioskRepository
+	scheduleUC *mockScheduleUseCase
+	kiosk      *domainKiosk.Kiosk
+	token      string
+	caregiver  uuid.UUID
+	visit      domainSchedule.Schedule
+	elsewhere  domainSchedule.Schedule
+}
+
+func setupTestKioskUseCase(t *testing.T) *fixture {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	caregiverID, residentID, homeClientID := uuid.New(), uuid.New(), uuid.New()
+	users := &mockUserRepository{users: map[uuid.UUID]domainUser.User{
+		caregiverID: {ID: caregiverID, Role: domainUser.RoleCaregiver},
+		// Same building, formatted differently.
+		residentID:   {ID: residentID, Role: domainUser.RoleClient, Location: domainUser.Location{HouseNumber: "12", Street: "elm street ", City: "SPRINGFIELD", Pincode: "12345"}},
+		homeClientID: {ID: homeClientID, Role: domainUser.RoleClient, Location: domainUser.Location{HouseNumber: "7", Street: "Oak Avenue", City: "Springfield", Pincode: "12345"}},
+	}}
+	visit := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: residentID, AssignedUserID: caregiverID, VisitStatus: "upcoming"}
+	elsewhere := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: homeClientID, AssignedUserID: caregiverID, VisitStatus: "upcoming"}
+	scheduleRepo := &mockScheduleRepository{schedules: []domainSchedule.Schedule{visit, elsewhere}}
+	scheduleUC := &mockScheduleUseCase{scheduleRepo: scheduleRepo}
+	kioskRepo := newMockKioskRepository()
+
+	useCase := NewKioskUseCase(kioskRepo, scheduleRepo, users, scheduleUC, loggerInstance)
+	kiosk, token, err := useCase.Register(&domainKiosk.Kiosk{Name: "Lobby tablet", Address: facility})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := useCase.SetPIN(caregiverID, "4821"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &fixture{useCase, kioskRepo, scheduleUC, kiosk, token, caregiverID, visit, elsewhere}
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestAuthenticate(t *testing.T) {
+	f := setupTestKioskUseCase(t)
+
+	if kiosk, err := f.useCase.Authenticate(f.token); err != nil || kiosk.ID != f.kiosk.ID {
+		t.Fatalf("expected token to resolve to the kiosk, got %v", err)
+	}
+	if f.kioskRepo.kiosks[f.kiosk.ID].TokenHash == f.token {
+		t.Error("expected only the token hash to be stored")
+	}
+
+	_, rotated, err := f.useCase.RotateToken(f.kiosk.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.useCase.Authenticate(f.token); errorType(err) != domainErrors.NotAuthenticated {
+		t.Errorf("expected old token to be rejected, got %v", err)
+	}
+
+	if _, err := f.useCase.Update(f.kiosk.ID, map[string]interface{}{"active": false}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.useCase.Authenticate(rotated); errorType(err) != domainErrors.NotAuthenticated {
+		t.Errorf("expected deactivated kiosk to be rejected, got %v", err)
+	}
+}
+
+func TestSetPIN(t *testing.T) {
+	f := setupTestKioskUseCase(t)
+	for _, pin := range []string{"123", "123456789", "12a4"} {
+		if err := f.useCase.SetPIN(f.caregiver, pin); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error for %q, got %v", pin, err)
+		}
+	}
+	if err := f.useCase.SetPIN(f.visit.ClientUserID, "1234"); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected clients to be refused a PIN, got %v", err)
+	}
+}
+
+func TestGetSchedules(t *testing.T) {
+	f := setupTestKioskUseCase(t)
+	schedules, err := f.useCase.GetSchedules(f.kiosk, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*schedules) != 1 || (*schedules)[0].ID != f.visit.ID {
+		t.Errorf("expected only the visit at the facility, got %d", len(*schedules))
+	}
+}
+
+func TestCheckIn(t *testing.T) {
+	now := time.Now().UTC()
+
+	t.Run("Success", func(t *testing.T) {
+		f := setupTestKioskUseCase(t)
+		if _, err := f.useCase.CheckIn(f.kiosk, f.visit.ID, "4821", "10.0.0.5", now); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(f.scheduleUC.started) != 1 {
+			t.Fatalf("expected the visit to be started")
+		}
+		verification := f.scheduleUC.started[0]
+		if verification.Method != domainSchedule.VerificationKiosk || verification.KioskID == nil || *verification.KioskID != f.kiosk.ID {
+			t.Errorf("expected kiosk verification, got %+v", verification)
+		}
+		if _, err := f.useCase.CheckOut(f.kiosk, f.visit.ID, "4821", "10.0.0.5", now.Add(time.Hour)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(f.kioskRepo.audit) != 2 || f.kioskRepo.audit[0].Action != domainKiosk.ActionCheckIn || f.kioskRepo.audit[1].Action != domainKiosk.ActionCheckOut {
+			t.Errorf("expected check-in and check-out audited, got %+v", f.kioskRepo.audit)
+		}
+	})
+
+	t.Run("Visit at another address", func(t *testing.T) {
+		f := setupTestKioskUseCase(t)
+		_, err := f.useCase.CheckIn(f.kiosk, f.elsewhere.ID, "4821", "10.0.0.5", now)
+		if errorType(err) != domainErrors.NotAuthorized {
+			t.Errorf("expected not authorized, got %v", err)
+		}
+		if len(f.scheduleUC.started) != 0 || len(f.kioskRepo.audit) != 1 || f.kioskRepo.audit[0].Action != domainKiosk.ActionRejected {
+			t.Errorf("expected a rejected attempt and no check-in")
+		}
+	})
+
+	t.Run("Schedule refuses check-in", func(t *testing.T) {
+		f := setupTestKioskUseCase(t)
+		f.scheduleUC.startErr = domainErrors.NewAppError(errors.New("schedule is not in 'upcoming' status"), domainErrors.ValidationError)
+		if _, err := f.useCase.CheckIn(f.kiosk, f.visit.ID, "4821", "10.0.0.5", now); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected the schedule error, got %v", err)
+		}
+		if len(f.kioskRepo.audit) != 1 || f.kioskRepo.audit[0].Detail == "" {
+			t.Errorf("expected the refusal audited with its reason, got %+v", f.kioskRepo.audit)
+		}
+	})
+
+	t.Run("Wrong PIN locks out", func(t *testing.T) {
+		f := setupTestKioskUseCase(t)
+		for i := 0; i < MaxPINFailures; i++ {
+			if _, err := f.useCase.CheckIn(f.kiosk, f.visit.ID, "0000", "10.0.0.5", now); errorType(err) != domainErrors.NotAuthorized {
+				t.Fatalf("expected not authorized, got %v", err)
+			}
+		}
+		if _, err := f.useCase.CheckIn(f.kiosk, f.visit.ID, "4821", "10.0.0.5", now); errorType(err) != domainErrors.NotAuthorized {
+			t.Errorf("expected lockout despite correct PIN, got %v", err)
+		}
+		if _, err := f.useCase.CheckIn(f.kiosk, f.visit.ID, "4821", "10.0.0.5", now.Add(PINLockout+time.Minute)); err != nil {
+			t.Errorf("expected check-in after the lockout window, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,444 @@
+package leave
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLeave "caregiver/src/domain/leave"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// blockingStatuses are the requests that hold a caregiver's time.
+var blockingStatuses = []string{domainLeave.StatusPending, domainLeave.StatusApproved}
+
+type ILeaveUseCase interface {
+	CreateRule(newRule *domainLeave.AccrualRule) (*domainLeave.AccrualRule, error)
+	GetRules() (*[]domainLeave.AccrualRule, error)
+	GetRuleByID(id uuid.UUID) (*domainLeave.AccrualRule, error)
+	UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainLeave.AccrualRule, error)
+	DeleteRule(id uuid.UUID) error
+	CreateRequest(newRequest *domainLeave.Request) (*domainLeave.Request, error)
+	GetRequests(filter domainLeave.RequestFilter) (*[]domainLeave.Request, error)
+	GetRequestByID(id uuid.UUID) (*domainLeave.Request, error)
+	ApproveRequest(id, deciderID uuid.UUID, note string, now time.Time) (*domainLeave.Decision, error)
+	DenyRequest(id, deciderID uuid.UUID, note string, now time.Time) (*domainLeave.Request, error)
+	CancelRequest(id uuid.UUID, now time.Time) (*domainLeave.Request, error)
+	GetBalance(caregiverUserID uuid.UUID) (*domainLeave.Balance, error)
+	GetLedger(caregiverUserID uuid.UUID) (*[]domainLeave.Entry, error)
+	AdjustBalance(caregiverUserID uuid.UUID, hours float64, note string) (*domainLeave.Entry, error)
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+type LeaveUseCase struct {
+	leaveRepository    domainLeave.ILeaveRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	Logger             *logger.Logger
+}
+
+func NewLeaveUseCase(leaveRepository domainLeave.ILeaveRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) ILeaveUseCase {
+	return &LeaveUseCase{
+		leaveRepository:    leaveRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *LeaveUseCase) CreateRule(newRule *domainLeave.AccrualRule) (*domainLeave.AccrualRule, error) {
+	u.Logger.Info("Creating leave accrual rule", zap.String("caregiverUserID", newRule.CaregiverUserID.String()))
+	if err := u.requireCaregiver(newRule.CaregiverUserID); err != nil {
+		return nil, err
+	}
+	if err := validateRule(newRule); err != nil {
+		return nil, err
+	}
+	if _, err := u.leaveRepository.GetRuleByCaregiver(newRule.CaregiverUserID); err == nil {
+		return nil, domainErrors.NewAppError(errors.New("the caregiver already has an accrual rule"), domainErrors.Conflict)
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+	newRule.ID = uuid.New()
+	newRule.Active = true
+	return u.leaveRepository.CreateRule(newRule)
+}
+
+func (u *LeaveUseCase) GetRules() (*[]domainLeave.AccrualRule, error) {
+	return u.leaveRepository.GetRules()
+}
+
+func (u *LeaveUseCase) GetRuleByID(id uuid.UUID) (*domainLeave.AccrualRule, error) {
+	return u.leaveRepository.GetRuleByID(id)
+}
+
+func (u *LeaveUseCase) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainLeave.AccrualRule, error) {
+	u.Logger.Info("Updating leave accrual rule", zap.String("id", id.String()))
+	existing, err := u.leaveRepository.GetRuleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["hours_per_hour_worked"].(float64); ok {
+		candidate.HoursPerHourWorked = v
+	}
+	if v, ok := updates["max_balance"].(float64); ok {
+		candidate.MaxBalance = v
+	}
+	if err := validateRule(&candidate); err != nil {
+		return nil, err
+	}
+	return u.leaveRepository.UpdateRule(id, updates)
+}
+
+func (u *LeaveUseCase) DeleteRule(id uuid.UUID) error {
+	u.Logger.Info("Deleting leave accrual rule", zap.String("id", id.String()))
+	return u.leaveRepository.DeleteRule(id)
+}
+
+// CreateRequest files a pending leave request. PTO requests must name the
+// hours they draw; a caregiver cannot hold two live requests for the same
+// time.
+func (u *LeaveUseCase) CreateRequest(newRequest *domainLeave.Request) (*domainLeave.Request, error) {
+	u.Logger.Info("Creating leave request", zap.String("caregiverUserID", newRequest.CaregiverUserID.String()), zap.String("type", newRequest.Type))
+	if err := u.requireCaregiver(newRequest.CaregiverUserID); err != nil {
+		return nil, err
+	}
+	newRequest.From = newRequest.From.UTC()
+	newRequest.To = newRequest.To.UTC()
+	newRequest.Reason = strings.TrimSpace(newRequest.Reason)
+	switch newRequest.Type {
+	case domainLeave.TypePTO, domainLeave.TypeSick, domainLeave.TypeUnpaid:
+	default:
+		return nil, domainErrors.NewAppError(errors.New("type must be 'pto', 'sick' or 'unpaid'"), domainErrors.ValidationError)
+	}
+	if !newRequest.From.Before(newRequest.To) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+	if newRequest.Hours < 0 || newRequest.Hours > newRequest.To.Sub(newRequest.From).Hours() {
+		return nil, domainErrors.NewAppError(errors.New("hours must be between 0 and the length of the leave"), domainErrors.ValidationError)
+	}
+	if newRequest.Type == domainLeave.TypePTO && newRequest.Hours == 0 {
+		return nil, domainErrors.NewAppError(errors.New("hours are required for PTO requests"), domainErrors.ValidationError)
+	}
+
+	overlapping, err := u.leaveRepository.GetRequests(domainLeave.RequestFilter{
+		CaregiverUserID: &newRequest.CaregiverUserID,
+		Statuses:        blockingStatuses,
+		From:            &newRequest.From,
+		To:              &newRequest.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(*overlapping) > 0 {
+		other := (*overlapping)[0]
+		return nil, domainErrors.NewAppError(fmt.Errorf("the leave overlaps a %s request from %s to %s", other.Status, other.From.Format(time.RFC3339), other.To.Format(time.RFC3339)), domainErrors.Conflict)
+	}
+
+	newRequest.ID = uuid.New()
+	newRequest.Status = domainLeave.StatusPending
+	newRequest.DecidedByUserID = nil
+	newRequest.DecidedAt = nil
+	newRequest.DecisionNote = ""
+	return u.leaveRepository.CreateRequest(newRequest)
+}
+
+func (u *LeaveUseCase) GetRequests(filter domainLeave.RequestFilter) (*[]domainLeave.Request, error) {
+	return u.leaveRepository.GetRequests(filter)
+}
+
+func (u *LeaveUseCase) GetRequestByID(id uuid.UUID) (*domainLeave.Request, error) {
+	return u.leaveRepository.GetRequestByID(id)
+}
+
+// ApproveRequest approves a pending request, drawing PTO from the balance.
+// Visits already assigned to the caregiver during the leave are returned so
+// they can be reassigned; new assignments are blocked from now on.
+func (u *LeaveUseCase) ApproveRequest(id, deciderID uuid.UUID, note string, now time.Time) (*domainLeave.Decision, error) {
+	u.Logger.Info("Approving leave request", zap.String("id", id.String()), zap.String("deciderID", deciderID.String()))
+	request, err := u.pendingRequest(id, deciderID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Type == domainLeave.TypePTO {
+		balance, err := u.GetBalance(request.CaregiverUserID)
+		if err != nil {
+			return nil, err
+		}
+		if request.Hours > balance.Available {
+			return nil, domainErrors.NewAppError(fmt.Errorf("insufficient PTO balance: %.2f hours requested, %.2f available", request.Hours, balance.Available), domainErrors.ValidationError)
+		}
+	}
+
+	updated, err := u.leaveRepository.UpdateRequest(id, decisionUpdates(domainLeave.StatusApproved, deciderID, note, now))
+	if err != nil {
+		return nil, err
+	}
+	if updated.Type == domainLeave.TypePTO {
+		if _, err := u.leaveRepository.CreateEntry(&domainLeave.Entry{
+			CaregiverUserID: updated.CaregiverUserID,
+			Type:            domainLeave.EntryUsage,
+			Hours:           -updated.Hours,
+			RequestID:       &updated.ID,
+			Note:            "Approved PTO request",
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	schedules, err := u.scheduleRepository.GetActiveSchedulesBetween(updated.From, updated.To, []uuid.UUID{updated.CaregiverUserID})
+	if err != nil {
+		return nil, err
+	}
+	decision := &domainLeave.Decision{Request: updated, ConflictingScheduleIDs: make([]uuid.UUID, 0, len(*schedules))}
+	for _, schedule := range *schedules {
+		decision.ConflictingScheduleIDs = append(decision.ConflictingScheduleIDs, schedule.ID)
+	}
+	if len(decision.ConflictingScheduleIDs) > 0 {
+		u.Logger.Warn("Approved leave overlaps assigned visits",
+			zap.String("requestID", id.String()),
+			zap.Int("visits", len(decision.ConflictingScheduleIDs)))
+	}
+	return decision, nil
+}
+
+func (u *LeaveUseCase) DenyRequest(id, deciderID uuid.UUID, note string, now time.Time) (*domainLeave.Request, error) {
+	u.Logger.Info("Denying leave request", zap.String("id", id.String()), zap.String("deciderID", deciderID.String()))
+	if _, err := u.pendingRequest(id, deciderID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(note) == "" {
+		return nil, domainErrors.NewAppError(errors.New("a note is required to deny a request"), domainErrors.ValidationError)
+	}
+	return u.leaveRepository.UpdateRequest(id, decisionUpdates(domainLeave.StatusDenied, deciderID, note, now))
+}
+
+// CancelRequest withdraws a pending or approved request. PTO drawn by an
+// approved request is refunded only if the leave has not started yet.
+func (u *LeaveUseCase) CancelRequest(id uuid.UUID, now time.Time) (*domainLeave.Request, error) {
+	u.Logger.Info("Cancelling leave request", zap.String("id", id.String()))
+	request, err := u.leaveRepository.GetRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	switch request.Status {
+	case domainLeave.StatusPending:
+	case domainLeave.StatusApproved:
+		if !now.Before(request.From) {
+			return nil, domainErrors.NewAppError(errors.New("leave that has already started cannot be cancelled"), domainErrors.Conflict)
+		}
+	default:
+		return nil, domainErrors.NewAppError(fmt.Errorf("the request is already %s", request.Status), domainErrors.Conflict)
+	}
+
+	updated, err := u.leaveRepository.UpdateRequest(id, map[string]interface{}{"status": domainLeave.StatusCancelled})
+	if err != nil {
+		return nil, err
+	}
+	if request.Status == domainLeave.StatusApproved && request.Type == domainLeave.TypePTO {
+		if _, err := u.leaveRepository.CreateEntry(&domainLeave.Entry{
+			CaregiverUserID: request.CaregiverUserID,
+			Type:            domainLeave.EntryRefund,
+			Hours:           request.Hours,
+			RequestID:       &request.ID,
+			Note:            "Cancelled PTO request",
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+func (u *LeaveUseCase) GetBalance(caregiverUserID uuid.UUID) (*domainLeave.Balance, error) {
+	entries, err := u.leaveRepository.GetEntries(caregiverUserID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := u.leaveRepository.GetRequests(domainLeave.RequestFilter{
+		CaregiverUserID: &caregiverUserID,
+		Statuses:        []string{domainLeave.StatusPending},
+	})
+	if err != nil {
+		return nil, err
+	}
+	balance := domainLeave.Summarize(caregiverUserID, *entries, *pending)
+	return &balance, nil
+}
+
+func (u *LeaveUseCase) GetLedger(caregiverUserID uuid.UUID) (*[]domainLeave.Entry, error) {
+	return u.leaveRepository.GetEntries(caregiverUserID)
+}
+
+// AdjustBalance records a manual correction, such as an opening balance.
+// The balance may not go negative.
+func (u *LeaveUseCase) AdjustBalance(caregiverUserID uuid.UUID, hours float64, note string) (*domainLeave.Entry, error) {
+	u.Logger.Info("Adjusting PTO balance", zap.String("caregiverUserID", caregiverUserID.String()), zap.Float64("hours", hours))
+	if err := u.requireCaregiver(caregiverUserID); err != nil {
+		return nil, err
+	}
+	if hours == 0 {
+		return nil, domainErrors.NewAppError(errors.New("hours must not be zero"), domainErrors.ValidationError)
+	}
+	if strings.TrimSpace(note) == "" {
+		return nil, domainErrors.NewAppError(errors.New("a note is required for adjustments"), domainErrors.ValidationError)
+	}
+	balance, err := u.GetBalance(caregiverUserID)
+	if err != nil {
+		return nil, err
+	}
+	if balance.Available+hours < 0 {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the adjustment would leave a negative balance (%.2f available)", balance.Available), domainErrors.ValidationError)
+	}
+	return u.leaveRepository.CreateEntry(&domainLeave.Entry{
+		CaregiverUserID: caregiverUserID,
+		Type:            domainLeave.EntryAdjustment,
+		Hours:           hours,
+		Note:            strings.TrimSpace(note),
+	})
+}
+
+// ValidateSchedule rejects assigning a caregiver to a visit during their
+// approved leave and warns when it falls in a pending request.
+func (u *LeaveUseCase) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.VisitStatus == "cancelled" || schedule.AssignedUserID == uuid.Nil {
+		return nil, nil
+	}
+	requests, err := u.leaveRepository.GetRequests(domainLeave.RequestFilter{
+		CaregiverUserID: &schedule.AssignedUserID,
+		Statuses:        blockingStatuses,
+		From:            &schedule.ScheduledSlot.From,
+		To:              &schedule.ScheduledSlot.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, request := range *requests {
+		period := fmt.Sprintf("from %s to %s", request.From.Format(time.RFC3339), request.To.Format(time.RFC3339))
+		if request.Status == domainLeave.StatusApproved {
+			u.Logger.Warn("Schedule falls in approved leave",
+				zap.String("assignedUserID", schedule.AssignedUserID.String()),
+				zap.String("requestID", request.ID.String()))
+			return nil, domainErrors.NewAppError(errors.New("the caregiver is on approved leave "+period), domainErrors.Conflict)
+		}
+		warnings = append(warnings, "the caregiver has a pending leave request "+period)
+	}
+	return warnings, nil
+}
+
+// OnScheduleEvent accrues PTO for completed visits. Each visit accrues at
+// most once, so re-completing it does not pay out twice.
+func (u *LeaveUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	if event.Type != domainSchedule.EventCompleted {
+		return
+	}
+	if err := u.accrue(event.Schedule); err != nil {
+		u.Logger.Error("Error accruing PTO for visit", zap.Error(err), zap.String("scheduleID", event.Schedule.ID.String()))
+	}
+}
+
+func (u *LeaveUseCase) accrue(schedule *domainSchedule.Schedule) error {
+	rule, err := u.leaveRepository.GetRuleByCaregiver(schedule.AssignedUserID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !rule.Active {
+		return nil
+	}
+	worked := schedule.WorkedDuration().Hours()
+	if worked <= 0 {
+		return nil
+	}
+	accrued, err := u.leaveRepository.HasAccrualForSchedule(schedule.ID)
+	if err != nil || accrued {
+		return err
+	}
+
+	hours := worked * rule.HoursPerHourWorked
+	if rule.MaxBalance > 0 {
+		balance, err := u.GetBalance(schedule.AssignedUserID)
+		if err != nil {
+			return err
+		}
+		hours = math.Min(hours, rule.MaxBalance-balance.Available)
+	}
+	hours = math.Round(hours*100) / 100
+	if hours <= 0 {
+		return nil
+	}
+	_, err = u.leaveRepository.CreateEntry(&domainLeave.Entry{
+		CaregiverUserID: schedule.AssignedUserID,
+		Type:            domainLeave.EntryAccrual,
+		Hours:           hours,
+		ScheduleID:      &schedule.ID,
+		Note:            fmt.Sprintf("%.2f hours worked", worked),
+	})
+	return err
+}
+
+// pendingRequest loads a request an admin is about to decide.
+func (u *LeaveUseCase) pendingRequest(id, deciderID uuid.UUID) (*domainLeave.Request, error) {
+	request, err := u.leaveRepository.GetRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domainLeave.StatusPending {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the request is already %s", request.Status), domainErrors.Conflict)
+	}
+	decider, err := u.userRepository.GetByID(deciderID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("decider not found"), domainErrors.NotFound)
+	}
+	if decider.Role != domainUser.RoleAdmin {
+		return nil, domainErrors.NewAppError(errors.New("only coordinators can decide leave requests"), domainErrors.NotAuthorized)
+	}
+	return request, nil
+}
+
+func (u *LeaveUseCase) requireCaregiver(caregiverUserID uuid.UUID) error {
+	caregiver, err := u.userRepository.GetByID(caregiverUserID)
+	if err != nil {
+		return domainErrors.NewAppError(errors.New("caregiver not found"), domainErrors.NotFound)
+	}
+	if caregiver.Role != domainUser.RoleCaregiver {
+		return domainErrors.NewAppError(errors.New("leave is only tracked for caregivers"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func decisionUpdates(status string, deciderID uuid.UUID, note string, now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"status":             status,
+		"decided_by_user_id": deciderID,
+		"decision_note":      strings.TrimSpace(note),
+		"decided_at":         now.UTC(),
+	}
+}
+
+func validateRule(r *domainLeave.AccrualRule) error {
+	if r.HoursPerHourWorked <= 0 || r.HoursPerHourWorked > 1 {
+		return domainErrors.NewAppError(errors.New("hours per hour worked must be above 0 and at most 1"), domainErrors.ValidationError)
+	}
+	if r.MaxBalance < 0 {
+		return domainErrors.NewAppError(errors.New("max balance cannot be negative"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
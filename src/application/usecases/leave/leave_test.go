@@ -0,0 +1,316 @@
+package leave
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLeave "caregiver/src/domain/leave"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockLeaveRepository keeps rules, requests and ledger entries in memory
+type mockLeaveRepository struct {
+	domainLeave.ILeaveRepository
+	rules    []domainLeave.AccrualRule
+	requests map[uuid.UUID]*domainLeave.Request
+	entries  []domainLeave.Entry
+}
+
+func (m *mockLeaveRepository) CreateRule(newRule *domainLeave.AccrualRule) (*domainLeave.AccrualRule, error) {
+	m.rules = append(m.rules, *newRule)
+	return newRule, nil
+}
+
+func (m *mockLeaveRepository) GetRuleByCaregiver(caregiverUserID uuid.UUID) (*domainLeave.AccrualRule, error) {
+	for i := range m.rules {
+		if m.rules[i].CaregiverUserID == caregiverUserID {
+			return &m.rules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockLeaveRepository) CreateRequest(newRequest *domainLeave.Request) (*domainLeave.Request, error) {
+	copied := *newRequest
+	m.requests[copied.ID] = &copied
+	return newRequest, nil
+}
+
+func (m *mockLeaveRepository) GetRequestByID(id uuid.UUID) (*domainLeave.Request, error) {
+	r, ok := m.requests[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *r
+	return &copied, nil
+}
+
+func (m *mockLeaveRepository) GetRequests(filter domainLeave.RequestFilter) (*[]domainLeave.Request, error) {
+	res := []domainLeave.Request{}
+	for _, r := range m.requests {
+		if filter.CaregiverUserID != nil && r.CaregiverUserID != *filter.CaregiverUserID {
+			continue
+		}
+		if filter.From != nil && filter.To != nil && !r.Overlaps(*filter.From, *filter.To) {
+			continue
+		}
+		matches := len(filter.Statuses) == 0
+		for _, status := range filter.Statuses {
+			matches = matches || r.Status == status
+		}
+		if matches {
+			res = append(res, *r)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockLeaveRepository) UpdateRequest(id uuid.UUID, updates map[string]interface{}) (*domainLeave.Request, error) {
+	r := m.requests[id]
+	if v, ok := updates["status"].(string); ok {
+		r.Status = v
+	}
+	if v, ok := updates["decided_by_user_id"].(uuid.UUID); ok {
+		r.DecidedByUserID = &v
+	}
+	if v, ok := updates["decision_note"].(string); ok {
+		r.DecisionNote = v
+	}
+	if v, ok := updates["decided_at"].(time.Time); ok {
+		r.DecidedAt = &v
+	}
+	return m.GetRequestByID(id)
+}
+
+func (m *mockLeaveRepository) CreateEntry(entry *domainLeave.Entry) (*domainLeave.Entry, error) {
+	entry.ID = uuid.New()
+	m.entries = append(m.entries, *entry)
+	return entry, nil
+}
+
+func (m *mockLeaveRepository) GetEntries(caregiverUserID uuid.UUID) (*[]domainLeave.Entry, error) {
+	res := []domainLeave.Entry{}
+	for _, e := range m.entries {
+		if e.CaregiverUserID == caregiverUserID {
+			res = append(res, e)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockLeaveRepository) HasAccrualForSchedule(scheduleID uuid.UUID) (bool, error) {
+	for _, e := range m.entries {
+		if e.Type == domainLeave.EntryAccrual && e.ScheduleID != nil && *e.ScheduleID == scheduleID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mockScheduleRepository returns the active schedules of the listed
+// caregivers
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetActiveSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		for _, id := range assignedUserIDs {
+			if s.AssignedUserID == id && s.Overlaps(from, to) {
+				res = append(res, s)
+			}
+		}
+	}
+	return &res, nil
+}
+
+// mockUserRepository returns a fixed set of users
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	u, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return u, nil
+}
+
+type testSetup struct {
+	useCase   ILeaveUseCase
+	repo      *mockLeaveRepository
+	schedules *mockScheduleRepository
+	caregiver *domainUser.User
+	admin     *domainUser.User
+}
+
+func setupTestLeaveUseCase(t *testing.T) *testSetup {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	caregiver := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	admin := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	repo := &mockLeaveRepository{requests: map[uuid.UUID]*domainLeave.Request{}}
+	schedules := &mockScheduleRepository{}
+	useCase := NewLeaveUseCase(repo, schedules,
+		&mockUserRepository{users: map[uuid.UUID]*domainUser.User{caregiver.ID: caregiver, admin.ID: admin}},
+		loggerInstance,
+	)
+	return &testSetup{useCase: useCase, repo: repo, schedules: schedules, caregiver: caregiver, admin: admin}
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func completedVisit(caregiverID uuid.UUID, checkin time.Time, hours float64) domainSchedule.Event {
+	checkout := checkin.Add(time.Duration(hours * float64(time.Hour)))
+	return domainSchedule.Event{Type: domainSchedule.EventCompleted, Schedule: &domainSchedule.Schedule{
+		ID:             uuid.New(),
+		AssignedUserID: caregiverID,
+		VisitStatus:    "completed",
+		CheckinTime:    &checkin,
+		CheckoutTime:   &checkout,
+	}}
+}
+
+func TestAccrual(t *testing.T) {
+	s := setupTestLeaveUseCase(t)
+	if _, err := s.useCase.CreateRule(&domainLeave.AccrualRule{CaregiverUserID: s.caregiver.ID, HoursPerHourWorked: 0.05, MaxBalance: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	day := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	visit := completedVisit(s.caregiver.ID, day, 8)
+	s.useCase.OnScheduleEvent(visit)
+	s.useCase.OnScheduleEvent(visit)
+	balance, _ := s.useCase.GetBalance(s.caregiver.ID)
+	if balance.Accrued != 0.4 {
+		t.Errorf("expected 0.4 hours accrued once, got %v", balance.Accrued)
+	}
+
+	for i := 1; i <= 3; i++ {
+		s.useCase.OnScheduleEvent(completedVisit(s.caregiver.ID, day.AddDate(0, 0, i), 8))
+	}
+	balance, _ = s.useCase.GetBalance(s.caregiver.ID)
+	if balance.Available != 1 {
+		t.Errorf("expected the balance capped at 1, got %v", balance.Available)
+	}
+
+	t.Run("Second rule for the caregiver", func(t *testing.T) {
+		_, err := s.useCase.CreateRule(&domainLeave.AccrualRule{CaregiverUserID: s.caregiver.ID, HoursPerHourWorked: 0.1})
+		if errorType(err) != domainErrors.Conflict {
+			t.Errorf("expected conflict, got %v", err)
+		}
+	})
+}
+
+func TestLeaveWorkflow(t *testing.T) {
+	s := setupTestLeaveUseCase(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	if _, err := s.useCase.AdjustBalance(s.caregiver.ID, 12, "Opening balance"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	visit := domainSchedule.Schedule{
+		ID:             uuid.New(),
+		AssignedUserID: s.caregiver.ID,
+		VisitStatus:    "upcoming",
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: from.Add(9 * time.Hour), To: from.Add(13 * time.Hour)},
+	}
+	s.schedules.schedules = []domainSchedule.Schedule{visit}
+
+	request, err := s.useCase.CreateRequest(&domainLeave.Request{CaregiverUserID: s.caregiver.ID, Type: domainLeave.TypePTO, From: from, To: to, Hours: 16})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("Overlapping request", func(t *testing.T) {
+		_, err := s.useCase.CreateRequest(&domainLeave.Request{CaregiverUserID: s.caregiver.ID, Type: domainLeave.TypeSick, From: from.Add(time.Hour), To: to})
+		if errorType(err) != domainErrors.Conflict {
+			t.Errorf("expected conflict, got %v", err)
+		}
+	})
+
+	t.Run("Pending leave only warns", func(t *testing.T) {
+		warnings, err := s.useCase.ValidateSchedule(&visit)
+		if err != nil || len(warnings) != 1 {
+			t.Errorf("expected one warning, got %v, %v", warnings, err)
+		}
+	})
+
+	t.Run("Only coordinators decide", func(t *testing.T) {
+		_, err := s.useCase.ApproveRequest(request.ID, s.caregiver.ID, "", now)
+		if errorType(err) != domainErrors.NotAuthorized {
+			t.Errorf("expected not authorized, got %v", err)
+		}
+	})
+
+	t.Run("Insufficient balance", func(t *testing.T) {
+		_, err := s.useCase.ApproveRequest(request.ID, s.admin.ID, "", now)
+		if errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	if _, err := s.useCase.AdjustBalance(s.caregiver.ID, 4, "Carried over"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decision, err := s.useCase.ApproveRequest(request.ID, s.admin.ID, "Enjoy", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Request.Status != domainLeave.StatusApproved || len(decision.ConflictingScheduleIDs) != 1 || decision.ConflictingScheduleIDs[0] != visit.ID {
+		t.Errorf("unexpected decision %+v", decision)
+	}
+	balance, _ := s.useCase.GetBalance(s.caregiver.ID)
+	if balance.Available != 0 || balance.Used != 16 {
+		t.Errorf("expected 16 hours used and none left, got %+v", balance)
+	}
+
+	t.Run("Approved leave blocks assignment", func(t *testing.T) {
+		_, err := s.useCase.ValidateSchedule(&visit)
+		if errorType(err) != domainErrors.Conflict {
+			t.Errorf("expected conflict, got %v", err)
+		}
+		outside := visit
+		outside.ScheduledSlot = domainSchedule.ScheduledSlot{From: to, To: to.Add(4 * time.Hour)}
+		if warnings, err := s.useCase.ValidateSchedule(&outside); err != nil || len(warnings) != 0 {
+			t.Errorf("expected no objection outside the leave, got %v, %v", warnings, err)
+		}
+	})
+
+	t.Run("Decided twice", func(t *testing.T) {
+		_, err := s.useCase.DenyRequest(request.ID, s.admin.ID, "Too late", now)
+		if errorType(err) != domainErrors.Conflict {
+			t.Errorf("expected conflict, got %v", err)
+		}
+	})
+
+	if _, err := s.useCase.CancelRequest(request.ID, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	balance, _ = s.useCase.GetBalance(s.caregiver.ID)
+	if balance.Available != 16 || balance.Used != 0 {
+		t.Errorf("expected the hours refunded, got %+v", balance)
+	}
+	if _, err := s.useCase.ValidateSchedule(&visit); err != nil {
+		t.Errorf("expected cancelled leave not to block, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package legalhold
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLegalHold "caregiver/src/domain/legalhold"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/security"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Names of the files in an export archive. The signature covers the
+// manifest, which holds the digest of the timeline.
+const (
+	TimelineFile  = "timeline.json"
+	ManifestFile  = "manifest.json"
+	SignatureFile = "manifest.json.sig"
+)
+
+type ILegalHoldUseCase interface {
+	Place(hold *domainLegalHold.Hold, now time.Time) (*domainLegalHold.Hold, error)
+	Release(id uuid.UUID, releasedBy *uuid.UUID, now time.Time) (*domainLegalHold.Hold, error)
+	GetActive() (*[]domainLegalHold.Hold, error)
+	GetByClient(clientUserID uuid.UUID) (*[]domainLegalHold.Hold, error)
+	// Export packs every record about the client, oldest first, into a zip
+	// archive with a signed manifest of its contents.
+	Export(clientUserID uuid.UUID, exportedBy *uuid.UUID, now time.Time) (*domainLegalHold.Archive, error)
+	// BeforeDelete refuses to delete a client under legal hold.
+	BeforeDelete(userID uuid.UUID) error
+	// BeforeMerge refuses to merge a client under legal hold, either way.
+	BeforeMerge(survivorID, duplicateID uuid.UUID) error
+}
+
+type LegalHoldUseCase struct {
+	legalHoldRepository domainLegalHold.ILegalHoldRepository
+	userRepository      domainUser.IUserRepository
+	signer              security.ISigner
+	Logger              *logger.Logger
+}
+
+func NewLegalHoldUseCase(legalHoldRepository domainLegalHold.ILegalHoldRepository, userRepository domainUser.IUserRepository, signer security.ISigner, loggerInstance *logger.Logger) ILegalHoldUseCase {
+	return &LegalHoldUseCase{
+		legalHoldRepository: legalHoldRepository,
+		userRepository:      userRepository,
+		signer:              signer,
+		Logger:              loggerInstance,
+	}
+}
+
+func (u *LegalHoldUseCase) Place(hold *domainLegalHold.Hold, now time.Time) (*domainLegalHold.Hold, error) {
+	hold.Reason = strings.TrimSpace(hold.Reason)
+	if hold.Reason == "" {
+		return nil, domainErrors.NewAppError(errors.New("a reason is required"), domainErrors.ValidationError)
+	}
+	if err := u.checkClient(hold.ClientUserID); err != nil {
+		return nil, err
+	}
+	if err := u.notHeld(hold.ClientUserID); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Placing legal hold", zap.String("clientUserID", hold.ClientUserID.String()))
+	hold.PlacedAt = now
+	return u.legalHoldRepository.Create(hold)
+}
+
+func (u *LegalHoldUseCase) Release(id uuid.UUID, releasedBy *uuid.UUID, now time.Time) (*domainLegalHold.Hold, error) {
+	u.Logger.Info("Releasing legal hold", zap.String("id", id.String()))
+	return u.legalHoldRepository.Release(id, releasedBy, now)
+}
+
+func (u *LegalHoldUseCase) GetActive() (*[]domainLegalHold.Hold, error) {
+	return u.legalHoldRepository.GetAllActive()
+}
+
+func (u *LegalHoldUseCase) GetByClient(clientUserID uuid.UUID) (*[]domainLegalHold.Hold, error) {
+	return u.legalHoldRepository.GetByClient(clientUserID)
+}
+
+func (u *LegalHoldUseCase) Export(clientUserID uuid.UUID, exportedBy *uuid.UUID, now time.Time) (*domainLegalHold.Archive, error) {
+	if err := u.checkClient(clientUserID); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Exporting client timeline", zap.String("clientUserID", clientUserID.String()))
+	holds, err := u.legalHoldRepository.GetByClient(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := u.legalHoldRepository.GetRecords(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(*records, func(i, j int) bool {
+		a, b := (*records)[i], (*records)[j]
+		if !a.At.Equal(b.At) {
+			return a.At.Before(b.At)
+		}
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		return a.ID < b.ID
+	})
+	timeline, err := json.MarshalIndent(domainLegalHold.Timeline{
+		ClientUserID:     clientUserID,
+		ExportedByUserID: exportedBy,
+		GeneratedAt:      now.UTC(),
+		Holds:            *holds,
+		Records:          *records,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(timeline)
+	manifest, err := json.MarshalIndent(domainLegalHold.Manifest{
+		ClientUserID: clientUserID,
+		GeneratedAt:  now.UTC(),
+		Records:      len(*records),
+		Algorithm:    security.SignatureAlgorithm,
+		PublicKey:    base64.StdEncoding.EncodeToString(u.signer.PublicKey()),
+		Files:        []domainLegalHold.ManifestFile{{Name: TimelineFile, Size: len(timeline), SHA256: hex.EncodeToString(digest[:])}},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	signature := []byte(base64.StdEncoding.EncodeToString(u.signer.Sign(manifest)))
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{{TimelineFile, timeline}, {ManifestFile, manifest}, {SignatureFile, signature}} {
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: now.UTC()})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(file.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return &domainLegalHold.Archive{
+		FileName:    fmt.Sprintf("client-%s-%s.zip", clientUserID, now.UTC().Format("20060102T150405Z")),
+		ContentType: "application/zip",
+		Data:        buf.Bytes(),
+		Records:     len(*records),
+	}, nil
+}
+
+func (u *LegalHoldUseCase) BeforeDelete(userID uuid.UUID) error {
+	return u.notHeld(userID)
+}
+
+func (u *LegalHoldUseCase) BeforeMerge(survivorID, duplicateID uuid.UUID) error {
+	for _, id := range []uuid.UUID{survivorID, duplicateID} {
+		if err := u.notHeld(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notHeld returns a Conflict error when the client is under legal hold.
+func (u *LegalHoldUseCase) notHeld(clientUserID uuid.UUID) error {
+	hold, err := u.legalHoldRepository.GetActive(clientUserID)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+			return nil
+		}
+		return err
+	}
+	return domainErrors.NewAppError(fmt.Errorf("client is under legal hold since %s", hold.PlacedAt.UTC().Format("2006-01-02")), domainErrors.Conflict)
+}
+
+func (u *LegalHoldUseCase) checkClient(clientUserID uuid.UUID) error {
+	client, err := u.userRepository.GetByID(clientUserID)
+	if err != nil {
+		return err
+	}
+	if client.Role != domainUser.RoleClient {
+		return domainErrors.NewAppError(errors.New("the user is not a client"), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package legalhold
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLegalHold "caregiver/src/domain/legalhold"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/security"
+
+	"github.com/google/uuid"
+)
+
+// mockLegalHoldRepository keeps holds in memory and returns fixed records
+type mockLegalHoldRepository struct {
+	holds   []domainLegalHold.Hold
+	records []domainLegalHold.Record
+}
+
+func (m *mockLegalHoldRepository) GetActive(clientUserID uuid.UUID) (*domainLegalHold.Hold, error) {
+	for _, hold := range m.holds {
+		if hold.ClientUserID == clientUserID && hold.Active() {
+			return &hold, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockLegalHoldRepository) GetAllActive() (*[]domainLegalHold.Hold, error) {
+	res := []domainLegalHold.Hold{}
+	for _, hold := range m.holds {
+		if hold.Active() {
+			res = append(res, hold)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockLegalHoldRepository) GetByClient(clientUserID uuid.UUID) (*[]domainLegalHold.Hold, error) {
+	res := []domainLegalHold.Hold{}
+	for _, hold := range m.holds {
+		if hold.ClientUserID == clientUserID {
+			res = append(res, hold)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockLegalHoldRepository) Create(hold *domainLegalHold.Hold) (*domainLegalHold.Hold, error) {
+	hold.ID = uuid.New()
+	m.holds = append(m.holds, *hold)
+	return hold, nil
+}
+
+func (m *mockLegalHoldRepository) Release(id uuid.UUID, releasedBy *uuid.UUID, at time.Time) (*domainLegalHold.Hold, error) {
+	for i := range m.holds {
+		if m.holds[i].ID == id && m.holds[i].Active() {
+			m.holds[i].ReleasedByUserID = releasedBy
+			m.holds[i].ReleasedAt = &at
+			return &m.holds[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockLegalHoldRepository) GetRecords(clientUserID uuid.UUID) (*[]domainLegalHold.Record, error) {
+	records := append([]domainLegalHold.Record{}, m.records...)
+	return &records, nil
+}
+
+// mockUserRepository knows only the users it was given
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return user, nil
+}
+
+func setup(t *testing.T, users ...*domainUser.User) (ILegalHoldUseCase, *mockLegalHoldRepository, security.ISigner) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	signer, err := security.NewSigner(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	userRepo := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{}}
+	for _, user := range users {
+		userRepo.users[user.ID] = user
+	}
+	repo := &mockLegalHoldRepository{}
+	return NewLegalHoldUseCase(repo, userRepo, signer, loggerInstance), repo, signer
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestHoldBlocksDeleteAndMerge(t *testing.T) {
+	client := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	other := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	caregiver := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	uc, _, _ := setup(t, client, other, caregiver)
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	if _, err := uc.Place(&domainLegalHold.Hold{ClientUserID: client.ID, Reason: "  "}, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a hold without a reason to be rejected, got %v", err)
+	}
+	if _, err := uc.Place(&domainLegalHold.Hold{ClientUserID: caregiver.ID, Reason: "Audit"}, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a hold on a caregiver to be rejected, got %v", err)
+	}
+	hold, err := uc.Place(&domainLegalHold.Hold{ClientUserID: client.ID, Reason: "Case 24-1187"}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.Place(&domainLegalHold.Hold{ClientUserID: client.ID, Reason: "Second"}, now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a second hold to conflict, got %v", err)
+	}
+
+	if err := uc.BeforeDelete(client.ID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected deleting a held client to conflict, got %v", err)
+	}
+	if err := uc.BeforeMerge(other.ID, client.ID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected merging away a held client to conflict, got %v", err)
+	}
+	if err := uc.BeforeMerge(client.ID, other.ID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected merging into a held client to conflict, got %v", err)
+	}
+	if err := uc.BeforeDelete(other.ID); err != nil {
+		t.Errorf("expected a client without a hold to be deletable, got %v", err)
+	}
+
+	if _, err := uc.Release(hold.ID, &other.ID, now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.BeforeDelete(client.ID); err != nil {
+		t.Errorf("expected a released hold to allow deletion, got %v", err)
+	}
+	holds, _ := uc.GetByClient(client.ID)
+	if len(*holds) != 1 || (*holds)[0].Active() {
+		t.Errorf("expected the released hold to stay on record, got %+v", *holds)
+	}
+}
+
+func TestExport(t *testing.T) {
+	client := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	uc, repo, signer := setup(t, client)
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	repo.records = []domainLegalHold.Record{
+		{Table: "schedules", ID: "b", At: now.Add(-2 * time.Hour)},
+		{Table: "users", ID: "a", At: now.Add(-48 * time.Hour)},
+		{Table: "alerts", ID: "c", At: now.Add(-2 * time.Hour)},
+	}
+
+	archive, err := uc.Export(client.ID, nil, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if archive.Records != 3 || archive.ContentType != "application/zip" {
+		t.Errorf("unexpected archive %s with %d records", archive.ContentType, archive.Records)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(archive.Data), int64(len(archive.Data)))
+	if err != nil {
+		t.Fatalf("expected a zip archive: %v", err)
+	}
+	files := map[string][]byte{}
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		files[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+
+	var timeline domainLegalHold.Timeline
+	if err := json.Unmarshal(files[TimelineFile], &timeline); err != nil {
+		t.Fatalf("unexpected timeline: %v", err)
+	}
+	var order []string
+	for _, record := range timeline.Records {
+		order = append(order, record.ID)
+	}
+	if len(order) != 3 || order[0] != "a" || order[1] != "c" || order[2] != "b" {
+		t.Errorf("expected records oldest first, then by table, got %v", order)
+	}
+
+	var manifest domainLegalHold.Manifest
+	if err := json.Unmarshal(files[ManifestFile], &manifest); err != nil {
+		t.Fatalf("unexpected manifest: %v", err)
+	}
+	digest := sha256.Sum256(files[TimelineFile])
+	if len(manifest.Files) != 1 || manifest.Files[0].SHA256 != hex.EncodeToString(digest[:]) {
+		t.Errorf("expected the manifest to hold the timeline digest, got %+v", manifest.Files)
+	}
+	signature, _ := base64.StdEncoding.DecodeString(string(files[SignatureFile]))
+	if !ed25519.Verify(signer.PublicKey(), files[ManifestFile], signature) {
+		t.Error("expected the manifest signature to verify")
+	}
+	tampered := bytes.Replace(files[ManifestFile], []byte(`"Records": 3`), []byte(`"Records": 2`), 1)
+	if ed25519.Verify(signer.PublicKey(), tampered, signature) {
+		t.Error("expected a changed manifest to fail verification")
+	}
+}
@@ -0,0 +1,208 @@
+package locale
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	"caregiver/src/infrastructure/holiday"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxHolidayRangeDays caps how many days a holiday listing may cover.
+const MaxHolidayRangeDays = 731
+
+var regionPattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+
+type ILocaleUseCase interface {
+	GetSettings() (*domainLocale.Settings, error)
+	SetSettings(timezone string, region string, skipHolidays bool) (*domainLocale.Settings, error)
+	// GetHolidays lists the public and custom holidays from from to to
+	// inclusive, both written as YYYY-MM-DD.
+	GetHolidays(from, to string) (*[]domainLocale.Holiday, error)
+	CreateHoliday(date string, name string) (*domainLocale.Holiday, error)
+	DeleteHoliday(id uuid.UUID) error
+	// Calendar returns the organization's calendar with the holidays around
+	// [from, to). A holiday source that cannot be reached leaves the public
+	// holidays out rather than failing the caller.
+	Calendar(from, to time.Time) (*domainLocale.Calendar, error)
+}
+
+type LocaleUseCase struct {
+	localeRepository domainLocale.ILocaleRepository
+	source           holiday.ISource
+	Logger           *logger.Logger
+	now              func() time.Time
+}
+
+func NewLocaleUseCase(localeRepository domainLocale.ILocaleRepository, source holiday.ISource, loggerInstance *logger.Logger) ILocaleUseCase {
+	return &LocaleUseCase{
+		localeRepository: localeRepository,
+		source:           source,
+		Logger:           loggerInstance,
+		now:              time.Now,
+	}
+}
+
+// GetSettings returns the organization's locale, UTC without holidays when
+// none has been saved.
+func (u *LocaleUseCase) GetSettings() (*domainLocale.Settings, error) {
+	settings, err := u.localeRepository.GetSettings()
+	if err != nil {
+		if isNotFound(err) {
+			return &domainLocale.Settings{Timezone: "UTC"}, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (u *LocaleUseCase) SetSettings(timezone string, region string, skipHolidays bool) (*domainLocale.Settings, error) {
+	timezone = strings.TrimSpace(timezone)
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, domainErrors.NewAppError(fmt.Errorf("unknown time zone %q", timezone), domainErrors.ValidationError)
+	}
+	if region != "" {
+		if !regionPattern.MatchString(region) {
+			return nil, domainErrors.NewAppError(errors.New("region must be an ISO 3166 country code, optionally with a subdivision such as CA-ON"), domainErrors.ValidationError)
+		}
+		if _, err := u.source.Holidays(region, u.now().Year()); err != nil {
+			u.Logger.Warn("Holiday source rejected region", zap.Error(err), zap.String("region", region))
+			return nil, domainErrors.NewAppError(fmt.Errorf("no holiday data is available for region %s", region), domainErrors.ValidationError)
+		}
+	}
+	if skipHolidays && region == "" {
+		return nil, domainErrors.NewAppError(errors.New("a region is required to skip holidays"), domainErrors.ValidationError)
+	}
+	settings, err := u.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Setting organization locale", zap.String("timezone", timezone), zap.String("region", region), zap.Bool("skipHolidays", skipHolidays))
+	settings.Timezone = timezone
+	settings.Region = region
+	settings.SkipHolidays = skipHolidays
+	return u.localeRepository.SaveSettings(settings)
+}
+
+func (u *LocaleUseCase) GetHolidays(from, to string) (*[]domainLocale.Holiday, error) {
+	fromDate, err := time.Parse(domainLocale.DateLayout, from)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("from must be a date written as YYYY-MM-DD"), domainErrors.ValidationError)
+	}
+	toDate, err := time.Parse(domainLocale.DateLayout, to)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("to must be a date written as YYYY-MM-DD"), domainErrors.ValidationError)
+	}
+	if toDate.Before(fromDate) || toDate.Sub(fromDate) > MaxHolidayRangeDays*24*time.Hour {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the range must run forward and cover at most %d days", MaxHolidayRangeDays), domainErrors.ValidationError)
+	}
+	settings, err := u.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := u.holidays(settings, from, to, fromDate.Year(), toDate.Year())
+	if err != nil {
+		return nil, err
+	}
+	return &holidays, nil
+}
+
+func (u *LocaleUseCase) CreateHoliday(date string, name string) (*domainLocale.Holiday, error) {
+	name = strings.TrimSpace(name)
+	if _, err := time.Parse(domainLocale.DateLayout, date); err != nil {
+		return nil, domainErrors.NewAppError(errors.New("date must be written as YYYY-MM-DD"), domainErrors.ValidationError)
+	}
+	if name == "" {
+		return nil, domainErrors.NewAppError(errors.New("a holiday name is required"), domainErrors.ValidationError)
+	}
+	existing, err := u.localeRepository.GetHolidays(date, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(*existing) > 0 {
+		return nil, domainErrors.NewAppError(fmt.Errorf("%s is already a custom holiday", date), domainErrors.Conflict)
+	}
+	u.Logger.Info("Adding custom holiday", zap.String("date", date), zap.String("name", name))
+	return u.localeRepository.CreateHoliday(&domainLocale.Holiday{Date: date, Name: name})
+}
+
+func (u *LocaleUseCase) DeleteHoliday(id uuid.UUID) error {
+	u.Logger.Info("Removing custom holiday", zap.String("id", id.String()))
+	return u.localeRepository.DeleteHoliday(id)
+}
+
+func (u *LocaleUseCase) Calendar(from, to time.Time) (*domainLocale.Calendar, error) {
+	settings, err := u.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	loc := settings.Location()
+	first, last := from.In(loc).AddDate(0, 0, -1), to.In(loc).AddDate(0, 0, 1)
+	firstDate, lastDate := first.Format(domainLocale.DateLayout), last.Format(domainLocale.DateLayout)
+	custom, err := u.localeRepository.GetHolidays(firstDate, lastDate)
+	if err != nil {
+		return nil, err
+	}
+	public, err := u.publicHolidays(settings.Region, firstDate, lastDate, first.Year(), last.Year())
+	if err != nil {
+		u.Logger.Warn("Holiday source unavailable, using custom holidays only", zap.Error(err), zap.String("region", settings.Region))
+	}
+	return domainLocale.NewCalendar(settings, append(*custom, public...)), nil
+}
+
+// holidays merges the region's public holidays with the custom ones between
+// the two dates.
+func (u *LocaleUseCase) holidays(settings *domainLocale.Settings, from, to string, firstYear, lastYear int) ([]domainLocale.Holiday, error) {
+	custom, err := u.localeRepository.GetHolidays(from, to)
+	if err != nil {
+		return nil, err
+	}
+	public, err := u.publicHolidays(settings.Region, from, to, firstYear, lastYear)
+	if err != nil {
+		u.Logger.Error("Error getting public holidays", zap.Error(err), zap.String("region", settings.Region))
+		return nil, domainErrors.NewAppError(errors.New("the holiday source is unavailable"), domainErrors.UnknownError)
+	}
+	holidays := append(*custom, public...)
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date < holidays[j].Date })
+	return holidays, nil
+}
+
+// publicHolidays lists the region's public holidays between the two dates.
+// The year after the last one is read too, since a holiday observed early
+// can fall in the year before.
+func (u *LocaleUseCase) publicHolidays(region string, from, to string, firstYear, lastYear int) ([]domainLocale.Holiday, error) {
+	if region == "" {
+		return nil, nil
+	}
+	var holidays []domainLocale.Holiday
+	for year := firstYear; year <= lastYear+1; year++ {
+		public, err := u.source.Holidays(region, year)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range public {
+			if h.Date >= from && h.Date <= to {
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	return holidays, nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
@@ -0,0 +1,267 @@
+package locale
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	"caregiver/src/infrastructure/holiday"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type mockLocaleRepository struct {
+	getSettingsFn   func() (*domainLocale.Settings, error)
+	saveSettingsFn  func(*domainLocale.Settings) (*domainLocale.Settings, error)
+	getHolidaysFn   func(from, to string) (*[]domainLocale.Holiday, error)
+	createHolidayFn func(*domainLocale.Holiday) (*domainLocale.Holiday, error)
+}
+
+func (m *mockLocaleRepository) GetSettings() (*domainLocale.Settings, error) {
+	if m.getSettingsFn != nil {
+		return m.getSettingsFn()
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockLocaleRepository) SaveSettings(settings *domainLocale.Settings) (*domainLocale.Settings, error) {
+	if m.saveSettingsFn != nil {
+		return m.saveSettingsFn(settings)
+	}
+	return settings, nil
+}
+
+func (m *mockLocaleRepository) GetHolidays(from, to string) (*[]domainLocale.Holiday, error) {
+	if m.getHolidaysFn != nil {
+		return m.getHolidaysFn(from, to)
+	}
+	return &[]domainLocale.Holiday{}, nil
+}
+
+func (m *mockLocaleRepository) CreateHoliday(h *domainLocale.Holiday) (*domainLocale.Holiday, error) {
+	if m.createHolidayFn != nil {
+		return m.createHolidayFn(h)
+	}
+	h.ID = uuid.New()
+	return h, nil
+}
+
+func (m *mockLocaleRepository) DeleteHoliday(id uuid.UUID) error {
+	return nil
+}
+
+// mockSource answers with holidaysFn and counts the lookups per year
+type mockSource struct {
+	holidaysFn func(region string, year int) ([]domainLocale.Holiday, error)
+	calls      map[int]int
+}
+
+func (m *mockSource) Holidays(region string, year int) ([]domainLocale.Holiday, error) {
+	m.calls[year]++
+	if m.holidaysFn != nil {
+		return m.holidaysFn(region, year)
+	}
+	return nil, nil
+}
+
+// usHolidays returns New Year's Day and Independence Day of the year.
+func usHolidays(region string, year int) ([]domainLocale.Holiday, error) {
+	return []domainLocale.Holiday{
+		{Date: time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Format(domainLocale.DateLayout), Name: "New Year's Day", Region: region},
+		{Date: time.Date(year, 7, 4, 0, 0, 0, 0, time.UTC).Format(domainLocale.DateLayout), Name: "Independence Day", Region: region},
+	}, nil
+}
+
+func setupTestLocaleUseCase(t *testing.T, repo *mockLocaleRepository, source holiday.ISource) ILocaleUseCase {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	useCase := NewLocaleUseCase(repo, source, loggerInstance).(*LocaleUseCase)
+	useCase.now = func() time.Time { return time.Date(2025, 7, 16, 12, 0, 0, 0, time.UTC) }
+	return useCase
+}
+
+func settingsFn(settings domainLocale.Settings) func() (*domainLocale.Settings, error) {
+	return func() (*domainLocale.Settings, error) {
+		copied := settings
+		return &copied, nil
+	}
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestSetSettings(t *testing.T) {
+	source := &mockSource{calls: map[int]int{}, holidaysFn: func(region string, year int) ([]domainLocale.Holiday, error) {
+		if region == "ZZ" {
+			return nil, errors.New("unknown region")
+		}
+		return usHolidays(region, year)
+	}}
+
+	for _, tc := range []struct {
+		name         string
+		timezone     string
+		region       string
+		skipHolidays bool
+	}{
+		{"Unknown IANA zone", "America/Atlantis", "US", false},
+		{"Malformed region", "UTC", "United States", false},
+		{"Region without holiday data", "UTC", "ZZ", false},
+		{"Skipping holidays needs a region", "UTC", "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			saved := false
+			useCase := setupTestLocaleUseCase(t, &mockLocaleRepository{saveSettingsFn: func(s *domainLocale.Settings) (*domainLocale.Settings, error) {
+				saved = true
+				return s, nil
+			}}, source)
+			if _, err := useCase.SetSettings(tc.timezone, tc.region, tc.skipHolidays); errorType(err) != domainErrors.ValidationError {
+				t.Errorf("expected validation error, got %v", err)
+			}
+			if saved {
+				t.Error("invalid settings should not be saved")
+			}
+		})
+	}
+
+	t.Run("Valid settings are normalized", func(t *testing.T) {
+		useCase := setupTestLocaleUseCase(t, &mockLocaleRepository{}, source)
+		settings, err := useCase.SetSettings(" America/Chicago ", " us-tx ", true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.Timezone != "America/Chicago" || settings.Region != "US-TX" || !settings.SkipHolidays {
+			t.Errorf("unexpected settings %+v", settings)
+		}
+	})
+
+	t.Run("Empty zone means UTC", func(t *testing.T) {
+		useCase := setupTestLocaleUseCase(t, &mockLocaleRepository{}, source)
+		settings, err := useCase.SetSettings("", "", false)
+		if err != nil || settings.Timezone != "UTC" {
+			t.Errorf("expected UTC, got %+v, %v", settings, err)
+		}
+	})
+}
+
+func TestGetHolidays(t *testing.T) {
+	office := domainLocale.Holiday{ID: uuid.New(), Date: "2025-12-26", Name: "Office closed", Custom: true}
+	newRepo := func() *mockLocaleRepository {
+		return &mockLocaleRepository{
+			getSettingsFn: settingsFn(domainLocale.Settings{Timezone: "America/New_York", Region: "US"}),
+			getHolidaysFn: func(from, to string) (*[]domainLocale.Holiday, error) {
+				res := []domainLocale.Holiday{}
+				if office.Date >= from && office.Date <= to {
+					res = append(res, office)
+				}
+				return &res, nil
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		from, to string
+	}{
+		{"From must be a date", "12/20/2025", "2026-01-05"},
+		{"To must be a date", "2025-12-20", "tomorrow"},
+		{"Range must run forward", "2026-01-05", "2025-12-20"},
+		{"Range is capped", "2025-01-01", "2027-01-03"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			useCase := setupTestLocaleUseCase(t, newRepo(), &mockSource{calls: map[int]int{}, holidaysFn: usHolidays})
+			if _, err := useCase.GetHolidays(tc.from, tc.to); errorType(err) != domainErrors.ValidationError {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("Custom and public holidays across a year end", func(t *testing.T) {
+		source := &mockSource{calls: map[int]int{}, holidaysFn: usHolidays}
+		useCase := setupTestLocaleUseCase(t, newRepo(), source)
+		holidays, err := useCase.GetHolidays("2025-12-20", "2026-01-05")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(*holidays) != 2 || (*holidays)[0].Date != "2025-12-26" || (*holidays)[1].Date != "2026-01-01" {
+			t.Errorf("expected the office closure then New Year's Day, got %+v", *holidays)
+		}
+		if source.calls[2025] != 1 || source.calls[2026] != 1 {
+			t.Errorf("expected both years to be read, got %v", source.calls)
+		}
+	})
+
+	t.Run("Source failure is reported", func(t *testing.T) {
+		source := &mockSource{calls: map[int]int{}, holidaysFn: func(string, int) ([]domainLocale.Holiday, error) {
+			return nil, errors.New("holiday API down")
+		}}
+		useCase := setupTestLocaleUseCase(t, newRepo(), source)
+		if _, err := useCase.GetHolidays("2025-12-20", "2026-01-05"); errorType(err) != domainErrors.UnknownError {
+			t.Errorf("expected unknown error, got %v", err)
+		}
+	})
+}
+
+func TestCalendar(t *testing.T) {
+	independence := domainLocale.Holiday{ID: uuid.New(), Date: "2025-07-04", Name: "Company picnic", Custom: true}
+	repo := &mockLocaleRepository{
+		getSettingsFn: settingsFn(domainLocale.Settings{Timezone: "America/Los_Angeles", Region: "US", SkipHolidays: true}),
+		getHolidaysFn: func(from, to string) (*[]domainLocale.Holiday, error) {
+			return &[]domainLocale.Holiday{independence}, nil
+		},
+	}
+	// 2025-07-05 03:00 UTC is still the evening of the 4th in Los Angeles.
+	from := time.Date(2025, 7, 5, 3, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	t.Run("Custom holidays win and days are local", func(t *testing.T) {
+		useCase := setupTestLocaleUseCase(t, repo, &mockSource{calls: map[int]int{}, holidaysFn: usHolidays})
+		calendar, err := useCase.Calendar(from, to)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		holiday, ok := calendar.HolidayOn(from)
+		if !ok || holiday.Name != "Company picnic" {
+			t.Errorf("expected the custom holiday on the local 4th, got %+v, %v", holiday, ok)
+		}
+		if !calendar.Closed(from) || calendar.Closed(from.Add(12*time.Hour)) {
+			t.Error("expected only the local 4th to be closed")
+		}
+	})
+
+	t.Run("Unreachable source leaves custom holidays", func(t *testing.T) {
+		useCase := setupTestLocaleUseCase(t, repo, &mockSource{calls: map[int]int{}, holidaysFn: func(string, int) ([]domainLocale.Holiday, error) {
+			return nil, errors.New("holiday API down")
+		}})
+		calendar, err := useCase.Calendar(from, to)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := calendar.HolidayOn(from); !ok {
+			t.Error("expected the custom holiday to remain")
+		}
+	})
+
+	t.Run("Cached source is read once per year", func(t *testing.T) {
+		source := &mockSource{calls: map[int]int{}, holidaysFn: usHolidays}
+		useCase := setupTestLocaleUseCase(t, repo, holiday.NewCache(source))
+		for i := 0; i < 3; i++ {
+			if _, err := useCase.Calendar(from, to); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if source.calls[2025] != 1 || source.calls[2026] != 1 {
+			t.Errorf("expected one lookup per year, got %v", source.calls)
+		}
+	})
+}
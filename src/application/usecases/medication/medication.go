@@ -0,0 +1,246 @@
+package medication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainMedication "caregiver/src/domain/medication"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/medication"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const checkTimeout = 10 * time.Second
+
+type IMedicationUseCase interface {
+	AddAllergy(newAllergy *domainMedication.Allergy) (*domainMedication.Allergy, error)
+	GetAllergies(clientUserID uuid.UUID) (*[]domainMedication.Allergy, error)
+	DeleteAllergy(id uuid.UUID) error
+	CreateMedication(newMedication *domainMedication.Medication) (*domainMedication.Medication, error)
+	GetMedications(clientUserID uuid.UUID) (*[]domainMedication.Medication, error)
+	UpdateMedication(id uuid.UUID, updates map[string]interface{}) (*domainMedication.Medication, error)
+	DeleteMedication(id uuid.UUID) error
+	LogAdministration(newAdministration *domainMedication.Administration, acknowledged bool, now time.Time) (*domainMedication.Administration, error)
+	GetAdministrations(filter domainMedication.AdministrationFilter) (*[]domainMedication.Administration, error)
+}
+
+type MedicationUseCase struct {
+	medicationRepository domainMedication.IMedicationRepository
+	userRepository       domainUser.IUserRepository
+	scheduleRepository   domainSchedule.IScheduleRepository
+	checker              medication.IInteractionChecker
+	Logger               *logger.Logger
+}
+
+func NewMedicationUseCase(medicationRepository domainMedication.IMedicationRepository, userRepository domainUser.IUserRepository, scheduleRepository domainSchedule.IScheduleRepository, checker medication.IInteractionChecker, loggerInstance *logger.Logger) IMedicationUseCase {
+	return &MedicationUseCase{
+		medicationRepository: medicationRepository,
+		userRepository:       userRepository,
+		scheduleRepository:   scheduleRepository,
+		checker:              checker,
+		Logger:               loggerInstance,
+	}
+}
+
+func (u *MedicationUseCase) AddAllergy(newAllergy *domainMedication.Allergy) (*domainMedication.Allergy, error) {
+	u.Logger.Info("Adding client allergy", zap.String("clientUserID", newAllergy.ClientUserID.String()))
+	if err := u.requireClient(newAllergy.ClientUserID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(newAllergy.Substance) == "" {
+		return nil, domainErrors.NewAppError(errors.New("substance is required"), domainErrors.ValidationError)
+	}
+	switch newAllergy.Severity {
+	case "", domainMedication.SeverityMild, domainMedication.SeverityModerate, domainMedication.SeveritySevere:
+	default:
+		return nil, domainErrors.NewAppError(errors.New("severity must be 'mild', 'moderate' or 'severe'"), domainErrors.ValidationError)
+	}
+	newAllergy.ID = uuid.New()
+	return u.medicationRepository.CreateAllergy(newAllergy)
+}
+
+func (u *MedicationUseCase) GetAllergies(clientUserID uuid.UUID) (*[]domainMedication.Allergy, error) {
+	return u.medicationRepository.GetAllergies(clientUserID)
+}
+
+func (u *MedicationUseCase) DeleteAllergy(id uuid.UUID) error {
+	u.Logger.Info("Deleting client allergy", zap.String("id", id.String()))
+	return u.medicationRepository.DeleteAllergy(id)
+}
+
+func (u *MedicationUseCase) CreateMedication(newMedication *domainMedication.Medication) (*domainMedication.Medication, error) {
+	u.Logger.Info("Adding client medication", zap.String("clientUserID", newMedication.ClientUserID.String()), zap.String("name", newMedication.Name))
+	if err := u.requireClient(newMedication.ClientUserID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(newMedication.Name) == "" {
+		return nil, domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	newMedication.ID = uuid.New()
+	newMedication.Active = true
+	return u.medicationRepository.CreateMedication(newMedication)
+}
+
+func (u *MedicationUseCase) GetMedications(clientUserID uuid.UUID) (*[]domainMedication.Medication, error) {
+	return u.medicationRepository.GetMedications(clientUserID)
+}
+
+func (u *MedicationUseCase) UpdateMedication(id uuid.UUID, updates map[string]interface{}) (*domainMedication.Medication, error) {
+	u.Logger.Info("Updating client medication", zap.String("id", id.String()))
+	if v, ok := updates["name"].(string); ok && strings.TrimSpace(v) == "" {
+		return nil, domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	return u.medicationRepository.UpdateMedication(id, updates)
+}
+
+func (u *MedicationUseCase) DeleteMedication(id uuid.UUID) error {
+	u.Logger.Info("Deleting client medication", zap.String("id", id.String()))
+	return u.medicationRepository.DeleteMedication(id)
+}
+
+// LogAdministration records a drug given during a visit. The drug is checked
+// against the client's allergies and, through the interaction checker,
+// against their other active medications. When that raises warnings the
+// administration is only stored once acknowledged, together with the
+// warnings; otherwise the warnings are returned in a WarningsError.
+func (u *MedicationUseCase) LogAdministration(newAdministration *domainMedication.Administration, acknowledged bool, now time.Time) (*domainMedication.Administration, error) {
+	u.Logger.Info("Logging medication administration", zap.String("scheduleID", newAdministration.ScheduleID.String()))
+	schedule, err := u.scheduleRepository.GetScheduleByID(newAdministration.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.CheckinTime == nil {
+		return nil, domainErrors.NewAppError(errors.New("medications can only be logged once the visit has started"), domainErrors.ValidationError)
+	}
+	if newAdministration.MedicationID != nil {
+		prescribed, err := u.medicationRepository.GetMedicationByID(*newAdministration.MedicationID)
+		if err != nil {
+			return nil, err
+		}
+		if prescribed.ClientUserID != schedule.ClientUserID {
+			return nil, domainErrors.NewAppError(errors.New("the medication is not on this client's list"), domainErrors.ValidationError)
+		}
+		if newAdministration.DrugName == "" {
+			newAdministration.DrugName = prescribed.Name
+		}
+		if newAdministration.Dose == "" {
+			newAdministration.Dose = prescribed.Dose
+		}
+		if newAdministration.Route == "" {
+			newAdministration.Route = prescribed.Route
+		}
+	}
+	if strings.TrimSpace(newAdministration.DrugName) == "" {
+		return nil, domainErrors.NewAppError(errors.New("drug name is required"), domainErrors.ValidationError)
+	}
+
+	warnings, err := u.check(schedule.ClientUserID, newAdministration)
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) > 0 && !acknowledged {
+		u.Logger.Warn("Medication administration raised warnings", zap.String("scheduleID", schedule.ID.String()), zap.Int("warnings", len(warnings)))
+		return nil, domainErrors.NewAppError(&domainMedication.WarningsError{
+			Message:  "the administration raised warnings that must be acknowledged",
+			Warnings: warnings,
+		}, domainErrors.Conflict)
+	}
+
+	newAdministration.ID = uuid.New()
+	newAdministration.ClientUserID = schedule.ClientUserID
+	newAdministration.CaregiverUserID = schedule.AssignedUserID
+	newAdministration.Warnings = warnings
+	if len(warnings) > 0 {
+		newAdministration.AcknowledgedAt = &now
+	}
+	if newAdministration.AdministeredAt.IsZero() {
+		newAdministration.AdministeredAt = now
+	}
+	return u.medicationRepository.CreateAdministration(newAdministration)
+}
+
+func (u *MedicationUseCase) GetAdministrations(filter domainMedication.AdministrationFilter) (*[]domainMedication.Administration, error) {
+	return u.medicationRepository.GetAdministrations(filter)
+}
+
+// check collects the allergy and interaction warnings for an administration.
+// If the interaction checker fails, that is itself a warning so the caregiver
+// knows the check did not happen.
+func (u *MedicationUseCase) check(clientUserID uuid.UUID, administration *domainMedication.Administration) ([]domainMedication.Warning, error) {
+	var warnings []domainMedication.Warning
+	allergies, err := u.medicationRepository.GetAllergies(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, allergy := range *allergies {
+		if medication.Matches(administration.DrugName, allergy.Substance) {
+			message := fmt.Sprintf("the client is allergic to %s", allergy.Substance)
+			if allergy.Reaction != "" {
+				message += " (" + allergy.Reaction + ")"
+			}
+			warnings = append(warnings, domainMedication.Warning{
+				Kind:     domainMedication.WarningAllergy,
+				Severity: allergy.Severity,
+				Subject:  allergy.Substance,
+				Message:  message,
+			})
+		}
+	}
+
+	medications, err := u.medicationRepository.GetMedications(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	var others []string
+	for _, current := range *medications {
+		if !current.Active || (administration.MedicationID != nil && current.ID == *administration.MedicationID) || medication.Matches(current.Name, administration.DrugName) {
+			continue
+		}
+		others = append(others, current.Name)
+	}
+	if len(others) == 0 {
+		return warnings, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+	interactions, err := u.checker.Check(ctx, administration.DrugName, others)
+	if err != nil {
+		u.Logger.Error("Error checking medication interactions", zap.Error(err), zap.String("checker", u.checker.Name()))
+		return append(warnings, domainMedication.Warning{
+			Kind:    domainMedication.WarningCheckUnavailable,
+			Message: "drug interactions could not be checked",
+		}), nil
+	}
+	for _, interaction := range interactions {
+		message := fmt.Sprintf("%s interacts with %s", interaction.DrugA, interaction.DrugB)
+		if interaction.Description != "" {
+			message += ": " + interaction.Description
+		}
+		warnings = append(warnings, domainMedication.Warning{
+			Kind:     domainMedication.WarningInteraction,
+			Severity: interaction.Severity,
+			Subject:  interaction.DrugB,
+			Message:  message,
+		})
+	}
+	return warnings, nil
+}
+
+func (u *MedicationUseCase) requireClient(clientUserID uuid.UUID) error {
+	client, err := u.userRepository.GetByID(clientUserID)
+	if err != nil {
+		return domainErrors.NewAppError(errors.New("client not found"), domainErrors.NotFound)
+	}
+	if client.Role != domainUser.RoleClient {
+		return domainErrors.NewAppError(errors.New("the user is not a client"), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package medication
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainMedication "caregiver/src/domain/medication"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/medication"
+
+	"github.com/google/uuid"
+)
+
+// mockMedicationRepository keeps allergies, medications and administrations
+// in memory
+type mockMedicationRepository struct {
+	domainMedication.IMedicationRepository
+	allergies       []domainMedication.Allergy
+	medications     []domainMedication.Medication
+	administrations []domainMedication.Administration
+}
+
+func (m *mockMedicationRepository) CreateAllergy(newAllergy *domainMedication.Allergy) (*domainMedication.Allergy, error) {
+	m.allergies = append(m.allergies, *newAllergy)
+	return newAllergy, nil
+}
+
+func (m *mockMedicationRepository) GetAllergies(clientUserID uuid.UUID) (*[]domainMedication.Allergy, error) {
+	res := []domainMedication.Allergy{}
+	for _, allergy := range m.allergies {
+		if allergy.ClientUserID == clientUserID {
+			res = append(res, allergy)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockMedicationRepository) CreateMedication(newMedication *domainMedication.Medication) (*domainMedication.Medication, error) {
+	m.medications = append(m.medications, *newMedication)
+	return newMedication, nil
+}
+
+func (m *mockMedicationRepository) GetMedicationByID(id uuid.UUID) (*domainMedication.Medication, error) {
+	for _, medication := range m.medications {
+		if medication.ID == id {
+			return &medication, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockMedicationRepository) GetMedications(clientUserID uuid.UUID) (*[]domainMedication.Medication, error) {
+	res := []domainMedication.Medication{}
+	for _, medication := range m.medications {
+		if medication.ClientUserID == clientUserID {
+			res = append(res, medication)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockMedicationRepository) CreateAdministration(newAdministration *domainMedication.Administration) (*domainMedication.Administration, error) {
+	m.administrations = append(m.administrations, *newAdministration)
+	return newAdministration, nil
+}
+
+// mockUserRepository returns users by ID
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockScheduleRepository returns a fixed set of schedules
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules map[uuid.UUID]*domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	if schedule, ok := m.schedules[id]; ok {
+		return schedule, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// failingChecker stands in for an interaction service that is down
+type failingChecker struct{}
+
+func (failingChecker) Name() string { return "failing" }
+
+func (failingChecker) Check(ctx context.Context, drug string, others []string) ([]medication.Interaction, error) {
+	return nil, errors.New("service unavailable")
+}
+
+func setupTestMedicationUseCase(t *testing.T, checker medication.IInteractionChecker) (IMedicationUseCase, *mockMedicationRepository, *domainSchedule.Schedule) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	clientID := uuid.New()
+	checkin := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	visit := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: clientID, AssignedUserID: uuid.New(), VisitStatus: "in_progress", CheckinTime: &checkin}
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{
+		clientID: {ID: clientID, Role: domainUser.RoleClient},
+	}}
+	schedules := &mockScheduleRepository{schedules: map[uuid.UUID]*domainSchedule.Schedule{visit.ID: visit}}
+	medications := &mockMedicationRepository{}
+	return NewMedicationUseCase(medications, users, schedules, checker, loggerInstance), medications, visit
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestLogAdministrationWarnings(t *testing.T) {
+	checker := medication.NewTableChecker([]medication.Interaction{
+		{DrugA: "warfarin", DrugB: "aspirin", Severity: domainMedication.SeveritySevere, Description: "increased bleeding risk"},
+	})
+	useCase, repo, visit := setupTestMedicationUseCase(t, checker)
+	now := visit.CheckinTime.Add(30 * time.Minute)
+
+	if _, err := useCase.AddAllergy(&domainMedication.Allergy{ClientUserID: visit.ClientUserID, Substance: "Penicillin", Reaction: "hives", Severity: domainMedication.SeverityModerate}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	warfarin, err := useCase.CreateMedication(&domainMedication.Medication{ClientUserID: visit.ClientUserID, Name: "Warfarin", Dose: "5mg"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logged, err := useCase.LogAdministration(&domainMedication.Administration{ScheduleID: visit.ID, MedicationID: &warfarin.ID}, false, now)
+	if err != nil {
+		t.Fatalf("expected the prescribed medication to log cleanly, got %v", err)
+	}
+	if logged.DrugName != "Warfarin" || logged.Dose != "5mg" || len(logged.Warnings) != 0 || logged.AcknowledgedAt != nil || !logged.AdministeredAt.Equal(now) {
+		t.Errorf("expected the medication's details without warnings, got %+v", logged)
+	}
+
+	_, err = useCase.LogAdministration(&domainMedication.Administration{ScheduleID: visit.ID, DrugName: "Aspirin 75mg"}, false, now)
+	var warningsErr *domainMedication.WarningsError
+	if errorType(err) != domainErrors.Conflict || !errors.As(err, &warningsErr) {
+		t.Fatalf("expected unacknowledged warnings to be rejected, got %v", err)
+	}
+	if len(warningsErr.Warnings) != 1 || warningsErr.Warnings[0].Kind != domainMedication.WarningInteraction || warningsErr.Warnings[0].Subject != "Warfarin" {
+		t.Errorf("expected an interaction with warfarin, got %+v", warningsErr.Warnings)
+	}
+	if len(repo.administrations) != 1 {
+		t.Errorf("expected the rejected administration not to be stored, got %d", len(repo.administrations))
+	}
+
+	logged, err = useCase.LogAdministration(&domainMedication.Administration{ScheduleID: visit.ID, DrugName: "amoxicillin/penicillin"}, true, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(logged.Warnings) != 1 || logged.Warnings[0].Kind != domainMedication.WarningAllergy || logged.AcknowledgedAt == nil || !logged.AcknowledgedAt.Equal(now) {
+		t.Errorf("expected the acknowledged allergy warning to be stored, got %+v", logged)
+	}
+	if logged.ClientUserID != visit.ClientUserID || logged.CaregiverUserID != visit.AssignedUserID {
+		t.Errorf("expected the visit's client and caregiver, got %+v", logged)
+	}
+}
+
+func TestLogAdministrationChecks(t *testing.T) {
+	useCase, _, visit := setupTestMedicationUseCase(t, failingChecker{})
+	now := visit.CheckinTime.Add(time.Hour)
+
+	if _, err := useCase.CreateMedication(&domainMedication.Medication{ClientUserID: visit.ClientUserID, Name: "Metformin"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := useCase.LogAdministration(&domainMedication.Administration{ScheduleID: visit.ID, DrugName: "Ibuprofen"}, false, now)
+	var warningsErr *domainMedication.WarningsError
+	if !errors.As(err, &warningsErr) || warningsErr.Warnings[0].Kind != domainMedication.WarningCheckUnavailable {
+		t.Errorf("expected an unavailable interaction check to need acknowledging, got %v", err)
+	}
+
+	if _, err := useCase.LogAdministration(&domainMedication.Administration{ScheduleID: visit.ID}, true, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a drug name to be required, got %v", err)
+	}
+	other := uuid.New()
+	if _, err := useCase.LogAdministration(&domainMedication.Administration{ScheduleID: visit.ID, MedicationID: &other}, true, now); errorType(err) != domainErrors.NotFound {
+		t.Errorf("expected an unknown medication to be rejected, got %v", err)
+	}
+
+	visit.CheckinTime = nil
+	if _, err := useCase.LogAdministration(&domainMedication.Administration{ScheduleID: visit.ID, DrugName: "Ibuprofen"}, true, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected logging before check-in to be rejected, got %v", err)
+	}
+}
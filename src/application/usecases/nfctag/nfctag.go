@@ -0,0 +1,100 @@
+package nfctag
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainNFCTag "caregiver/src/domain/nfctag"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type INFCTagUseCase interface {
+	Register(newTag *domainNFCTag.Tag) (*domainNFCTag.Tag, error)
+	GetByClientUserID(clientUserID uuid.UUID) (*[]domainNFCTag.Tag, error)
+	SetActive(id uuid.UUID, active bool) (*domainNFCTag.Tag, error)
+	Delete(id uuid.UUID) error
+	BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error
+}
+
+type NFCTagUseCase struct {
+	tagRepository  domainNFCTag.INFCTagRepository
+	userRepository domainUser.IUserRepository
+	Logger         *logger.Logger
+}
+
+func NewNFCTagUseCase(tagRepository domainNFCTag.INFCTagRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) INFCTagUseCase {
+	return &NFCTagUseCase{
+		tagRepository:  tagRepository,
+		userRepository: userRepository,
+		Logger:         loggerInstance,
+	}
+}
+
+func (u *NFCTagUseCase) Register(newTag *domainNFCTag.Tag) (*domainNFCTag.Tag, error) {
+	u.Logger.Info("Registering NFC tag", zap.String("clientUserID", newTag.ClientUserID.String()))
+
+	newTag.TagValue = normalizeTagValue(newTag.TagValue)
+	if newTag.TagValue == "" {
+		return nil, domainErrors.NewAppError(errors.New("tag value is required"), domainErrors.ValidationError)
+	}
+	if _, err := u.userRepository.GetByID(newTag.ClientUserID); err != nil {
+		u.Logger.Error("Client user not found for NFC tag", zap.Error(err), zap.String("clientUserID", newTag.ClientUserID.String()))
+		return nil, domainErrors.NewAppError(errors.New("client user not found"), domainErrors.NotFound)
+	}
+
+	newTag.ID = uuid.New()
+	newTag.Active = true
+	return u.tagRepository.Create(newTag)
+}
+
+func (u *NFCTagUseCase) GetByClientUserID(clientUserID uuid.UUID) (*[]domainNFCTag.Tag, error) {
+	u.Logger.Info("Getting NFC tags for client", zap.String("clientUserID", clientUserID.String()))
+	return u.tagRepository.GetByClientUserID(clientUserID)
+}
+
+func (u *NFCTagUseCase) SetActive(id uuid.UUID, active bool) (*domainNFCTag.Tag, error) {
+	u.Logger.Info("Updating NFC tag status", zap.String("id", id.String()), zap.Bool("active", active))
+	return u.tagRepository.Update(id, map[string]interface{}{"active": active})
+}
+
+func (u *NFCTagUseCase) Delete(id uuid.UUID) error {
+	u.Logger.Info("Deleting NFC tag", zap.String("id", id.String()))
+	return u.tagRepository.Delete(id)
+}
+
+// BeforeCheckin verifies a scanned tag against the tags registered for the
+// visit's client. Check-ins without a scan are left to other factors.
+func (u *NFCTagUseCase) BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error {
+	tagValue := normalizeTagValue(verification.NFCTagValue)
+	if tagValue == "" {
+		return nil
+	}
+
+	tag, err := u.tagRepository.GetActiveByValue(schedule.ClientUserID, tagValue)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+			u.Logger.Warn("Scanned NFC tag does not match client", zap.String("scheduleID", schedule.ID.String()), zap.String("clientUserID", schedule.ClientUserID.String()))
+			return domainErrors.NewAppError(errors.New("scanned NFC tag is not registered for this client's home"), domainErrors.ValidationError)
+		}
+		return err
+	}
+
+	verification.Method = domainSchedule.VerificationNFC
+	verification.NFCTagID = &tag.ID
+	return nil
+}
+
+// normalizeTagValue makes UIDs comparable regardless of how the reader
+// formats them (case, separators).
+func normalizeTagValue(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	return strings.NewReplacer(":", "", "-", "", " ", "").Replace(value)
+}
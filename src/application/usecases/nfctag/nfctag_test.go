@@ -0,0 +1,167 @@
+package nfctag
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"caregiver/src/domain"
+	domainErrors "caregiver/src/domain/errors"
+	domainNFCTag "caregiver/src/domain/nfctag"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockNFCTagRepository is an in-memory implementation of INFCTagRepository
+type mockNFCTagRepository struct {
+	tags []domainNFCTag.Tag
+}
+
+func (m *mockNFCTagRepository) Create(newTag *domainNFCTag.Tag) (*domainNFCTag.Tag, error) {
+	m.tags = append(m.tags, *newTag)
+	return newTag, nil
+}
+
+func (m *mockNFCTagRepository) GetByID(id uuid.UUID) (*domainNFCTag.Tag, error) {
+	for i := range m.tags {
+		if m.tags[i].ID == id {
+			return &m.tags[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockNFCTagRepository) GetByClientUserID(clientUserID uuid.UUID) (*[]domainNFCTag.Tag, error) {
+	return &m.tags, nil
+}
+
+func (m *mockNFCTagRepository) GetActiveByValue(clientUserID uuid.UUID, tagValue string) (*domainNFCTag.Tag, error) {
+	for i := range m.tags {
+		if m.tags[i].ClientUserID == clientUserID && m.tags[i].TagValue == tagValue && m.tags[i].Active {
+			return &m.tags[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockNFCTagRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainNFCTag.Tag, error) {
+	tag, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if active, ok := updates["active"].(bool); ok {
+		tag.Active = active
+	}
+	return tag, nil
+}
+
+func (m *mockNFCTagRepository) Delete(id uuid.UUID) error {
+	return nil
+}
+
+// mockUserRepository is a mock implementation of the IUserRepository interface
+type mockUserRepository struct {
+	getByIDFn func(id uuid.UUID) (*domainUser.User, error)
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) { return nil, nil }
+func (m *mockUserRepository) Create(userDomain *domainUser.User) (*domainUser.User, error) {
+	return nil, nil
+}
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) { return m.getByIDFn(id) }
+func (m *mockUserRepository) GetByEmail(email string) (*domainUser.User, error) {
+	return nil, nil
+}
+func (m *mockUserRepository) Update(id uuid.UUID, userMap map[string]interface{}) (*domainUser.User, error) {
+	return nil, nil
+}
+func (m *mockUserRepository) Delete(id uuid.UUID) error { return nil }
+func (m *mockUserRepository) SearchPaginated(filters domain.DataFilters) (*domainUser.SearchResultUser, error) {
+	return nil, nil
+}
+func (m *mockUserRepository) SearchByProperty(search domainUser.PropertySearch) (*domainUser.SearchResultProperty, error) {
+	return nil, nil
+}
+
+func setupTestNFCTagUseCase(t *testing.T) (INFCTagUseCase, *mockUserRepository) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	userRepo := &mockUserRepository{getByIDFn: func(id uuid.UUID) (*domainUser.User, error) {
+		return &domainUser.User{ID: id}, nil
+	}}
+	return NewNFCTagUseCase(&mockNFCTagRepository{}, userRepo, loggerInstance), userRepo
+}
+
+func TestRegister(t *testing.T) {
+	useCase, userRepo := setupTestNFCTagUseCase(t)
+
+	t.Run("Normalizes tag value", func(t *testing.T) {
+		tag, err := useCase.Register(&domainNFCTag.Tag{ClientUserID: uuid.New(), TagValue: " 04:a2:3b:1c "})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tag.TagValue != "04A23B1C" || !tag.Active {
+			t.Errorf("expected active tag 04A23B1C, got %+v", tag)
+		}
+	})
+
+	t.Run("Client not found", func(t *testing.T) {
+		userRepo.getByIDFn = func(id uuid.UUID) (*domainUser.User, error) {
+			return nil, errors.New("user not found")
+		}
+		if _, err := useCase.Register(&domainNFCTag.Tag{ClientUserID: uuid.New(), TagValue: "04A2"}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestBeforeCheckin(t *testing.T) {
+	useCase, _ := setupTestNFCTagUseCase(t)
+	clientID := uuid.New()
+	tag, err := useCase.Register(&domainNFCTag.Tag{ClientUserID: clientID, TagValue: "04A23B1C"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schedule := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: clientID}
+
+	t.Run("No scan leaves verification untouched", func(t *testing.T) {
+		verification := &domainSchedule.Verification{}
+		if err := useCase.BeforeCheckin(schedule, time.Now(), verification); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if verification.Method != "" {
+			t.Errorf("expected no method, got %s", verification.Method)
+		}
+	})
+
+	t.Run("Matching scan verifies", func(t *testing.T) {
+		verification := &domainSchedule.Verification{NFCTagValue: "04-a2-3b-1c"}
+		if err := useCase.BeforeCheckin(schedule, time.Now(), verification); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if verification.Method != domainSchedule.VerificationNFC || verification.NFCTagID == nil || *verification.NFCTagID != tag.ID {
+			t.Errorf("expected NFC verification with tag %s, got %+v", tag.ID, verification)
+		}
+	})
+
+	t.Run("Tag from another home is rejected", func(t *testing.T) {
+		other := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: uuid.New()}
+		if err := useCase.BeforeCheckin(other, time.Now(), &domainSchedule.Verification{NFCTagValue: "04A23B1C"}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("Deactivated tag is rejected", func(t *testing.T) {
+		if _, err := useCase.SetActive(tag.ID, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := useCase.BeforeCheckin(schedule, time.Now(), &domainSchedule.Verification{NFCTagValue: "04A23B1C"}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainNotification "caregiver/src/domain/notification"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// visitTimeLayout is how visit times read in notifications.
+const visitTimeLayout = "Mon 2 Jan 2006 15:04 MST"
+
+type INotificationUseCase interface {
+	GetDevices(userID uuid.UUID) (*[]domainNotification.Device, error)
+	RegisterDevice(device *domainNotification.Device, now time.Time) (*domainNotification.Device, error)
+	RemoveDevice(userID uuid.UUID, id uuid.UUID) error
+	GetPreference(userID uuid.UUID) (*domainNotification.ChannelPreference, error)
+	SetPreference(userID uuid.UUID, channels []string) (*domainNotification.ChannelPreference, error)
+	// OnScheduleEvent tells clients when their visits are scheduled, moved,
+	// started, completed or cancelled, and caregivers when visits are
+	// assigned to them or taken away.
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+type NotificationUseCase struct {
+	deviceRepository     domainNotification.IDeviceRepository
+	preferenceRepository domainNotification.IPreferenceRepository
+	userRepository       domainUser.IUserRepository
+	notifier             notification.INotifier
+	Logger               *logger.Logger
+}
+
+func NewNotificationUseCase(deviceRepository domainNotification.IDeviceRepository, preferenceRepository domainNotification.IPreferenceRepository, userRepository domainUser.IUserRepository, notifier notification.INotifier, loggerInstance *logger.Logger) INotificationUseCase {
+	return &NotificationUseCase{
+		deviceRepository:     deviceRepository,
+		preferenceRepository: preferenceRepository,
+		userRepository:       userRepository,
+		notifier:             notifier,
+		Logger:               loggerInstance,
+	}
+}
+
+func (u *NotificationUseCase) GetDevices(userID uuid.UUID) (*[]domainNotification.Device, error) {
+	return u.deviceRepository.GetByUser(userID)
+}
+
+func (u *NotificationUseCase) RegisterDevice(device *domainNotification.Device, now time.Time) (*domainNotification.Device, error) {
+	device.Token = strings.TrimSpace(device.Token)
+	if device.Token == "" {
+		return nil, domainErrors.NewAppError(errors.New("a push token is required"), domainErrors.ValidationError)
+	}
+	if !domainNotification.IsPlatform(device.Platform) {
+		return nil, domainErrors.NewAppError(fmt.Errorf("platform must be one of %s", strings.Join(domainNotification.Platforms, ", ")), domainErrors.ValidationError)
+	}
+	if _, err := u.userRepository.GetByID(device.UserID); err != nil {
+		return nil, err
+	}
+	device.LastSeenAt = now
+	u.Logger.Info("Registering push device", zap.String("userID", device.UserID.String()), zap.String("platform", device.Platform))
+	return u.deviceRepository.Register(device)
+}
+
+func (u *NotificationUseCase) RemoveDevice(userID uuid.UUID, id uuid.UUID) error {
+	return u.deviceRepository.Delete(userID, id)
+}
+
+// GetPreference returns the channels the user wants to be notified on. Users
+// who never chose are notified on every channel.
+func (u *NotificationUseCase) GetPreference(userID uuid.UUID) (*domainNotification.ChannelPreference, error) {
+	preference, err := u.preferenceRepository.GetPreference(userID)
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+		if _, err := u.userRepository.GetByID(userID); err != nil {
+			return nil, err
+		}
+		return &domainNotification.ChannelPreference{UserID: userID, Channels: domainNotification.Channels}, nil
+	}
+	return preference, err
+}
+
+// SetPreference limits the user's notifications to the channels, at least
+// one of them.
+func (u *NotificationUseCase) SetPreference(userID uuid.UUID, channels []string) (*domainNotification.ChannelPreference, error) {
+	if len(channels) == 0 {
+		return nil, domainErrors.NewAppError(errors.New("at least one channel is required"), domainErrors.ValidationError)
+	}
+	seen := make(map[string]bool, len(channels))
+	unique := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		if !domainNotification.IsChannel(channel) {
+			return nil, domainErrors.NewAppError(fmt.Errorf("channels must be among %s", strings.Join(domainNotification.Channels, ", ")), domainErrors.ValidationError)
+		}
+		if !seen[channel] {
+			seen[channel] = true
+			unique = append(unique, channel)
+		}
+	}
+	if _, err := u.userRepository.GetByID(userID); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Setting notification channels", zap.String("userID", userID.String()), zap.Strings("channels", unique))
+	return u.preferenceRepository.SavePreference(&domainNotification.ChannelPreference{UserID: userID, Channels: unique})
+}
+
+func (u *NotificationUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	visit := event.Schedule
+	switch event.Type {
+	case domainSchedule.EventCreated:
+		u.send(visit.ClientUserID, event, "Visit scheduled",
+			fmt.Sprintf("Your %s visit with %s is booked for %s.", visit.ServiceName, u.name(visit.AssignedUserID, "your caregiver"), when(visit)))
+		u.send(visit.AssignedUserID, event, "New visit assigned",
+			fmt.Sprintf("You have a %s visit with %s on %s.", visit.ServiceName, u.name(visit.ClientUserID, "your client"), when(visit)))
+	case domainSchedule.EventUpdated:
+		u.onUpdated(event)
+	case domainSchedule.EventStarted:
+		u.send(visit.ClientUserID, event, "Visit started",
+			fmt.Sprintf("%s has checked in for your %s visit.", u.name(visit.AssignedUserID, "Your caregiver"), visit.ServiceName))
+	case domainSchedule.EventCompleted:
+		u.send(visit.ClientUserID, event, "Visit completed",
+			fmt.Sprintf("%s has completed your %s visit.", u.name(visit.AssignedUserID, "Your caregiver"), visit.ServiceName))
+	case domainSchedule.EventCancelled, domainSchedule.EventDeleted:
+		u.send(visit.ClientUserID, event, "Visit cancelled",
+			fmt.Sprintf("Your %s visit on %s has been cancelled.", visit.ServiceName, when(visit)))
+		u.send(visit.AssignedUserID, event, "Visit cancelled",
+			fmt.Sprintf("Your %s visit with %s on %s has been cancelled.", visit.ServiceName, u.name(visit.ClientUserID, "your client"), when(visit)))
+	}
+}
+
+// onUpdated tells caregivers about a reassignment and the people on the
+// visit what changed about it: clients its date and times, the caregiver
+// those and its address. Other edits are not announced.
+func (u *NotificationUseCase) onUpdated(event domainSchedule.Event) {
+	visit, previous := event.Schedule, event.Previous
+	if previous == nil || visit.VisitStatus != "upcoming" {
+		return
+	}
+	if previous.AssignedUserID != visit.AssignedUserID {
+		u.send(visit.AssignedUserID, event, "New visit assigned",
+			fmt.Sprintf("You have a %s visit with %s on %s.", visit.ServiceName, u.name(visit.ClientUserID, "your client"), when(visit)))
+		u.send(previous.AssignedUserID, event, "Visit reassigned",
+			fmt.Sprintf("Your %s visit with %s on %s has been given to another caregiver.", previous.ServiceName, u.name(previous.ClientUserID, "your client"), when(previous)))
+	}
+	if len(event.Changes) > 0 {
+		var timeChanges []domainSchedule.FieldChange
+		for _, change := range event.Changes {
+			if change.TimeChange() {
+				timeChanges = append(timeChanges, change)
+			}
+		}
+		if len(timeChanges) > 0 {
+			u.send(visit.ClientUserID, event, "Visit rescheduled", changedBody(previous, timeChanges))
+		}
+		if previous.AssignedUserID == visit.AssignedUserID {
+			subject := "Visit address changed"
+			if len(timeChanges) > 0 {
+				subject = "Visit rescheduled"
+			}
+			u.send(visit.AssignedUserID, event, subject, changedBody(previous, event.Changes))
+		}
+		return
+	}
+	// Events raised without the changes worked out still announce a move.
+	if !previous.ScheduledSlot.From.Equal(visit.ScheduledSlot.From) || !previous.ScheduledSlot.To.Equal(visit.ScheduledSlot.To) {
+		body := fmt.Sprintf("Your %s visit has moved from %s to %s.", visit.ServiceName, when(previous), when(visit))
+		u.send(visit.ClientUserID, event, "Visit rescheduled", body)
+		if previous.AssignedUserID == visit.AssignedUserID {
+			u.send(visit.AssignedUserID, event, "Visit rescheduled", body)
+		}
+	}
+}
+
+// send notifies one user, logging rather than failing the schedule change
+// when delivery fails.
+func (u *NotificationUseCase) send(userID uuid.UUID, event domainSchedule.Event, subject, body string) {
+	if userID == uuid.Nil {
+		return
+	}
+	err := u.notifier.Notify(notification.Message{
+		UserID:  &userID,
+		Subject: subject,
+		Body:    body,
+		Data: map[string]interface{}{
+			"event":      event.Type,
+			"scheduleID": event.Schedule.ID.String(),
+			"from":       event.Schedule.ScheduledSlot.From,
+		},
+	})
+	if err != nil {
+		u.Logger.Warn("Visit notification failed", zap.Error(err), zap.String("event", event.Type),
+			zap.String("scheduleID", event.Schedule.ID.String()), zap.String("userID", userID.String()))
+	}
+}
+
+func (u *NotificationUseCase) name(userID uuid.UUID, fallback string) string {
+	if user, err := u.userRepository.GetByID(userID); err == nil {
+		if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+			return name
+		}
+	}
+	return fallback
+}
+
+// changedBody lists the changes, e.g. "Your Personal care visit on Mon 3
+// Mar 2025 09:00 UTC has changed: start time changed from 09:00 to 10:00."
+func changedBody(previous *domainSchedule.Schedule, changes []domainSchedule.FieldChange) string {
+	parts := make([]string, len(changes))
+	for i, change := range changes {
+		parts[i] = fmt.Sprintf("%s changed from %s to %s", change.Field, orUnknown(change.Previous), orUnknown(change.Current))
+	}
+	return fmt.Sprintf("Your %s visit on %s has changed: %s.", previous.ServiceName, when(previous), strings.Join(parts, "; "))
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+func when(visit *domainSchedule.Schedule) string {
+	return visit.ScheduledSlot.From.UTC().Format(visitTimeLayout)
+}
@@ -0,0 +1,178 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainNotification "caregiver/src/domain/notification"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+// recordingNotifier keeps the messages it is asked to send
+type recordingNotifier struct {
+	messages []notification.Message
+}
+
+func (n *recordingNotifier) Notify(message notification.Message) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+// mockUserRepository knows only the users it was given
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return user, nil
+}
+
+// mockPreferenceRepository keeps preferences in memory
+type mockPreferenceRepository struct {
+	domainNotification.IPreferenceRepository
+	preferences map[uuid.UUID]domainNotification.ChannelPreference
+}
+
+func (m *mockPreferenceRepository) GetPreference(userID uuid.UUID) (*domainNotification.ChannelPreference, error) {
+	preference, ok := m.preferences[userID]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &preference, nil
+}
+
+func (m *mockPreferenceRepository) SavePreference(preference *domainNotification.ChannelPreference) (*domainNotification.ChannelPreference, error) {
+	m.preferences[preference.UserID] = *preference
+	return preference, nil
+}
+
+func TestChannelPreference(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	ana := &domainUser.User{ID: uuid.New(), FirstName: "Ana"}
+	preferences := &mockPreferenceRepository{preferences: map[uuid.UUID]domainNotification.ChannelPreference{}}
+	uc := NewNotificationUseCase(nil, preferences, &mockUserRepository{users: map[uuid.UUID]*domainUser.User{ana.ID: ana}}, &recordingNotifier{}, loggerInstance)
+
+	preference, err := uc.GetPreference(ana.ID)
+	if err != nil || len(preference.Channels) != len(domainNotification.Channels) {
+		t.Fatalf("expected every channel by default, got %v, %v", preference, err)
+	}
+	if _, err := uc.SetPreference(ana.ID, []string{"sms", "fax"}); err == nil {
+		t.Error("expected an unknown channel rejected")
+	}
+	if _, err := uc.SetPreference(ana.ID, nil); err == nil {
+		t.Error("expected at least one channel required")
+	}
+	if _, err := uc.SetPreference(uuid.New(), []string{"sms"}); err == nil {
+		t.Error("expected an unknown user rejected")
+	}
+	if _, err := uc.SetPreference(ana.ID, []string{"push", "sms", "push"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	preference, _ = uc.GetPreference(ana.ID)
+	if strings.Join(preference.Channels, ",") != "push,sms" {
+		t.Errorf("expected the channels saved once each in order, got %v", preference.Channels)
+	}
+}
+
+func TestOnScheduleEvent(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	client := &domainUser.User{ID: uuid.New(), FirstName: "Rosa", LastName: "Diaz"}
+	ana := &domainUser.User{ID: uuid.New(), FirstName: "Ana"}
+	ben := &domainUser.User{ID: uuid.New(), FirstName: "Ben"}
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{client.ID: client, ana.ID: ana, ben.ID: ben}}
+
+	from := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	visit := &domainSchedule.Schedule{
+		ID:             uuid.New(),
+		ClientUserID:   client.ID,
+		AssignedUserID: ana.ID,
+		ServiceName:    "Personal care",
+		VisitStatus:    "upcoming",
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: from, To: from.Add(time.Hour)},
+	}
+	reassigned := *visit
+	reassigned.AssignedUserID = ben.ID
+	moved := *visit
+	moved.ScheduledSlot = domainSchedule.ScheduledSlot{From: from.Add(2 * time.Hour), To: from.Add(3 * time.Hour)}
+	edited := *visit
+	edited.ServiceName = "Companionship"
+
+	type sent struct {
+		to      uuid.UUID
+		subject string
+	}
+	tests := []struct {
+		name     string
+		event    domainSchedule.Event
+		expected []sent
+	}{
+		{"Created", domainSchedule.Event{Type: domainSchedule.EventCreated, Schedule: visit},
+			[]sent{{client.ID, "Visit scheduled"}, {ana.ID, "New visit assigned"}}},
+		{"Started", domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit},
+			[]sent{{client.ID, "Visit started"}}},
+		{"Completed", domainSchedule.Event{Type: domainSchedule.EventCompleted, Schedule: visit},
+			[]sent{{client.ID, "Visit completed"}}},
+		{"Cancelled", domainSchedule.Event{Type: domainSchedule.EventCancelled, Schedule: visit},
+			[]sent{{client.ID, "Visit cancelled"}, {ana.ID, "Visit cancelled"}}},
+		{"Reassigned", domainSchedule.Event{Type: domainSchedule.EventUpdated, Schedule: &reassigned, Previous: visit},
+			[]sent{{ben.ID, "New visit assigned"}, {ana.ID, "Visit reassigned"}}},
+		{"Moved", domainSchedule.Event{Type: domainSchedule.EventUpdated, Schedule: &moved, Previous: visit},
+			[]sent{{client.ID, "Visit rescheduled"}, {ana.ID, "Visit rescheduled"}}},
+		{"Other edits are quiet", domainSchedule.Event{Type: domainSchedule.EventUpdated, Schedule: &edited, Previous: visit}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &recordingNotifier{}
+			NewNotificationUseCase(nil, nil, users, notifier, loggerInstance).OnScheduleEvent(tt.event)
+			if len(notifier.messages) != len(tt.expected) {
+				t.Fatalf("expected %d messages, got %+v", len(tt.expected), notifier.messages)
+			}
+			for i, e := range tt.expected {
+				got := notifier.messages[i]
+				if *got.UserID != e.to || got.Subject != e.subject {
+					t.Errorf("message %d: expected %q to %s, got %q to %s", i, e.subject, e.to, got.Subject, *got.UserID)
+				}
+			}
+		})
+	}
+
+	changes := []domainSchedule.FieldChange{
+		{Field: domainSchedule.FieldStartTime, Previous: "09:00", Current: "10:00"},
+		{Field: domainSchedule.FieldAddress, Previous: "1 Elm St", Current: "2 Oak Ave"},
+	}
+	notifier := &recordingNotifier{}
+	NewNotificationUseCase(nil, nil, users, notifier, loggerInstance).OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventUpdated, Schedule: &moved, Previous: visit, Changes: changes})
+	if len(notifier.messages) != 2 {
+		t.Fatalf("expected the client and caregiver told, got %+v", notifier.messages)
+	}
+	if body := notifier.messages[0].Body; !strings.HasSuffix(body, "has changed: start time changed from 09:00 to 10:00.") {
+		t.Errorf("expected the client told only the new time, got %q", body)
+	}
+	if body := notifier.messages[1].Body; !strings.HasSuffix(body, "start time changed from 09:00 to 10:00; address changed from 1 Elm St to 2 Oak Ave.") {
+		t.Errorf("expected the caregiver told every change, got %q", body)
+	}
+
+	notifier = &recordingNotifier{}
+	NewNotificationUseCase(nil, nil, users, notifier, loggerInstance).OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit})
+	if body := notifier.messages[0].Body; body != "Ana has checked in for your Personal care visit." {
+		t.Errorf("unexpected body %q", body)
+	}
+}
@@ -0,0 +1,167 @@
+package payrate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainPayRate "caregiver/src/domain/payrate"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IPayRateUseCase interface {
+	Create(newRate *domainPayRate.PayRate) (*domainPayRate.PayRate, error)
+	GetAll(caregiverUserID *uuid.UUID) (*[]domainPayRate.PayRate, error)
+	GetByID(id uuid.UUID) (*domainPayRate.PayRate, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*domainPayRate.PayRate, error)
+	Delete(id uuid.UUID) error
+	RateFor(caregiverUserID uuid.UUID, serviceName string, day time.Time) (*domainPayRate.EffectiveRate, error)
+}
+
+type PayRateUseCase struct {
+	payRateRepository     domainPayRate.IPayRateRepository
+	userRepository        domainUser.IUserRepository
+	userVersionRepository domainUser.IUserVersionRepository
+	Logger                *logger.Logger
+}
+
+func NewPayRateUseCase(payRateRepository domainPayRate.IPayRateRepository, userRepository domainUser.IUserRepository, userVersionRepository domainUser.IUserVersionRepository, loggerInstance *logger.Logger) IPayRateUseCase {
+	return &PayRateUseCase{
+		payRateRepository:     payRateRepository,
+		userRepository:        userRepository,
+		userVersionRepository: userVersionRepository,
+		Logger:                loggerInstance,
+	}
+}
+
+func (u *PayRateUseCase) Create(newRate *domainPayRate.PayRate) (*domainPayRate.PayRate, error) {
+	u.Logger.Info("Creating pay rate", zap.String("caregiverUserID", newRate.CaregiverUserID.String()), zap.String("serviceName", newRate.ServiceName))
+	caregiver, err := u.userRepository.GetByID(newRate.CaregiverUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("caregiver not found"), domainErrors.NotFound)
+	}
+	if caregiver.Role != domainUser.RoleCaregiver {
+		return nil, domainErrors.NewAppError(errors.New("pay rates can only be set for caregivers"), domainErrors.ValidationError)
+	}
+	newRate.ServiceName = strings.TrimSpace(newRate.ServiceName)
+	if err := validate(newRate); err != nil {
+		return nil, err
+	}
+	if err := u.checkOverlap(newRate); err != nil {
+		return nil, err
+	}
+	return u.payRateRepository.Create(newRate)
+}
+
+func (u *PayRateUseCase) GetAll(caregiverUserID *uuid.UUID) (*[]domainPayRate.PayRate, error) {
+	return u.payRateRepository.GetAll(caregiverUserID)
+}
+
+func (u *PayRateUseCase) GetByID(id uuid.UUID) (*domainPayRate.PayRate, error) {
+	return u.payRateRepository.GetByID(id)
+}
+
+func (u *PayRateUseCase) Update(id uuid.UUID, updates map[string]interface{}) (*domainPayRate.PayRate, error) {
+	u.Logger.Info("Updating pay rate", zap.String("id", id.String()))
+	existing, err := u.payRateRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	merged := *existing
+	if v, ok := updates["service_name"].(string); ok {
+		merged.ServiceName = strings.TrimSpace(v)
+		updates["service_name"] = merged.ServiceName
+	}
+	if v, ok := updates["hourly_rate"].(float64); ok {
+		merged.HourlyRate = v
+	}
+	if v, ok := updates["effective_from"].(time.Time); ok {
+		merged.EffectiveFrom = v
+	}
+	if v, ok := updates["effective_to"]; ok {
+		merged.EffectiveTo = nil
+		if t, ok := v.(time.Time); ok {
+			merged.EffectiveTo = &t
+		}
+	}
+	if err := validate(&merged); err != nil {
+		return nil, err
+	}
+	if err := u.checkOverlap(&merged); err != nil {
+		return nil, err
+	}
+	return u.payRateRepository.Update(id, updates)
+}
+
+func (u *PayRateUseCase) Delete(id uuid.UUID) error {
+	u.Logger.Info("Deleting pay rate", zap.String("id", id.String()))
+	return u.payRateRepository.Delete(id)
+}
+
+// RateFor resolves the hourly pay for a visit: the caregiver's rate for the
+// service in effect on the visit day, else their general rate, else the
+// hourly rate on their profile as it stood that day. Later edits never
+// reprice past visits.
+func (u *PayRateUseCase) RateFor(caregiverUserID uuid.UUID, serviceName string, day time.Time) (*domainPayRate.EffectiveRate, error) {
+	rates, err := u.payRateRepository.GetAll(&caregiverUserID)
+	if err != nil {
+		return nil, err
+	}
+	res := &domainPayRate.EffectiveRate{CaregiverUserID: caregiverUserID, ServiceName: serviceName, Date: day}
+	if rate, ok := domainPayRate.Select(*rates, serviceName, day); ok {
+		id := rate.ID
+		res.HourlyRate = rate.HourlyRate
+		res.Source = domainPayRate.SourceRateTable
+		res.PayRateID = &id
+		return res, nil
+	}
+
+	endOfDay := time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, time.UTC)
+	version, err := u.userVersionRepository.GetAt(caregiverUserID, endOfDay)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if version == nil || version.HourlyRate == nil {
+		return nil, domainErrors.NewAppError(fmt.Errorf("no pay rate in effect for the caregiver on %s", day.Format("2006-01-02")), domainErrors.NotFound)
+	}
+	res.HourlyRate = *version.HourlyRate
+	res.Source = domainPayRate.SourceProfile
+	return res, nil
+}
+
+func validate(r *domainPayRate.PayRate) error {
+	if r.HourlyRate <= 0 {
+		return domainErrors.NewAppError(errors.New("hourly rate must be positive"), domainErrors.ValidationError)
+	}
+	if r.EffectiveTo != nil && r.EffectiveTo.Before(r.EffectiveFrom) {
+		return domainErrors.NewAppError(errors.New("effective to cannot be before effective from"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+// checkOverlap keeps at most one rate per caregiver and service in effect on
+// any day.
+func (u *PayRateUseCase) checkOverlap(rate *domainPayRate.PayRate) error {
+	rates, err := u.payRateRepository.GetAll(&rate.CaregiverUserID)
+	if err != nil {
+		return err
+	}
+	for i := range *rates {
+		other := &(*rates)[i]
+		if other.ID != rate.ID && other.Overlaps(rate) {
+			return domainErrors.NewAppError(fmt.Errorf("the rate overlaps the one effective from %s", other.EffectiveFrom.Format("2006-01-02")), domainErrors.Conflict)
+		}
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
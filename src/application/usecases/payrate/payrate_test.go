@@ -0,0 +1,195 @@
+package payrate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainPayRate "caregiver/src/domain/payrate"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockPayRateRepository keeps pay rates in memory
+type mockPayRateRepository struct {
+	rates map[uuid.UUID]*domainPayRate.PayRate
+}
+
+func (m *mockPayRateRepository) Create(newRate *domainPayRate.PayRate) (*domainPayRate.PayRate, error) {
+	newRate.ID = uuid.New()
+	m.rates[newRate.ID] = newRate
+	return newRate, nil
+}
+
+func (m *mockPayRateRepository) GetByID(id uuid.UUID) (*domainPayRate.PayRate, error) {
+	r, ok := m.rates[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *r
+	return &copied, nil
+}
+
+func (m *mockPayRateRepository) GetAll(caregiverUserID *uuid.UUID) (*[]domainPayRate.PayRate, error) {
+	res := []domainPayRate.PayRate{}
+	for _, r := range m.rates {
+		if caregiverUserID == nil || r.CaregiverUserID == *caregiverUserID {
+			res = append(res, *r)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockPayRateRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainPayRate.PayRate, error) {
+	r := m.rates[id]
+	if v, ok := updates["hourly_rate"].(float64); ok {
+		r.HourlyRate = v
+	}
+	if v, ok := updates["effective_to"]; ok {
+		r.EffectiveTo = nil
+		if t, ok := v.(time.Time); ok {
+			r.EffectiveTo = &t
+		}
+	}
+	return m.GetByID(id)
+}
+
+func (m *mockPayRateRepository) Delete(id uuid.UUID) error {
+	delete(m.rates, id)
+	return nil
+}
+
+// mockUserRepository returns a fixed set of users
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	u, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return u, nil
+}
+
+// mockUserVersionRepository answers GetAt from an ordered version list
+type mockUserVersionRepository struct {
+	domainUser.IUserVersionRepository
+	versions []domainUser.Version
+}
+
+func (m *mockUserVersionRepository) GetAt(userID uuid.UUID, at time.Time) (*domainUser.Version, error) {
+	var found *domainUser.Version
+	for i := range m.versions {
+		if m.versions[i].UserID == userID && !m.versions[i].EffectiveFrom.After(at) {
+			found = &m.versions[i]
+		}
+	}
+	if found == nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return found, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func setupTestPayRateUseCase(t *testing.T) (IPayRateUseCase, *mockUserVersionRepository, *domainUser.User) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	caregiver := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	client := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	versions := &mockUserVersionRepository{}
+	useCase := NewPayRateUseCase(
+		&mockPayRateRepository{rates: map[uuid.UUID]*domainPayRate.PayRate{}},
+		&mockUserRepository{users: map[uuid.UUID]*domainUser.User{caregiver.ID: caregiver, client.ID: client}},
+		versions,
+		loggerInstance,
+	)
+	return useCase, versions, caregiver
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestCreate(t *testing.T) {
+	useCase, _, caregiver := setupTestPayRateUseCase(t)
+	juneEnd := date(2025, 6, 30)
+	if _, err := useCase.Create(&domainPayRate.PayRate{CaregiverUserID: caregiver.ID, HourlyRate: 18, EffectiveFrom: date(2025, 1, 1), EffectiveTo: &juneEnd}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("Overlapping general rate", func(t *testing.T) {
+		_, err := useCase.Create(&domainPayRate.PayRate{CaregiverUserID: caregiver.ID, HourlyRate: 19, EffectiveFrom: juneEnd})
+		if errorType(err) != domainErrors.Conflict {
+			t.Errorf("expected conflict, got %v", err)
+		}
+	})
+
+	t.Run("A service rate may overlap the general rate", func(t *testing.T) {
+		_, err := useCase.Create(&domainPayRate.PayRate{CaregiverUserID: caregiver.ID, ServiceName: "Skilled nursing", HourlyRate: 30, EffectiveFrom: date(2025, 3, 1)})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("End before start", func(t *testing.T) {
+		end := date(2025, 6, 1)
+		_, err := useCase.Create(&domainPayRate.PayRate{CaregiverUserID: caregiver.ID, HourlyRate: 19, EffectiveFrom: date(2025, 7, 1), EffectiveTo: &end})
+		if errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+}
+
+func TestRateFor(t *testing.T) {
+	useCase, versions, caregiver := setupTestPayRateUseCase(t)
+	juneEnd := date(2025, 6, 30)
+	general, _ := useCase.Create(&domainPayRate.PayRate{CaregiverUserID: caregiver.ID, HourlyRate: 18, EffectiveFrom: date(2025, 1, 1), EffectiveTo: &juneEnd})
+	raise, _ := useCase.Create(&domainPayRate.PayRate{CaregiverUserID: caregiver.ID, HourlyRate: 20, EffectiveFrom: date(2025, 7, 1)})
+	nursing, _ := useCase.Create(&domainPayRate.PayRate{CaregiverUserID: caregiver.ID, ServiceName: "Skilled nursing", HourlyRate: 30, EffectiveFrom: date(2025, 3, 1)})
+	profileRate := 15.0
+	versions.versions = []domainUser.Version{{UserID: caregiver.ID, HourlyRate: &profileRate, EffectiveFrom: date(2024, 1, 1)}}
+
+	for _, tc := range []struct {
+		name    string
+		service string
+		day     time.Time
+		rate    float64
+		source  string
+		id      *uuid.UUID
+	}{
+		{"Rate on the visit date, not today's", "Personal care", date(2025, 6, 30), 18, domainPayRate.SourceRateTable, &general.ID},
+		{"New rate from its effective date", "Personal care", date(2025, 7, 1), 20, domainPayRate.SourceRateTable, &raise.ID},
+		{"Service rate wins over general", "skilled nursing", date(2025, 7, 1), 30, domainPayRate.SourceRateTable, &nursing.ID},
+		{"Profile rate before the table", "Personal care", date(2024, 12, 31), 15, domainPayRate.SourceProfile, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rate, err := useCase.RateFor(caregiver.ID, tc.service, tc.day)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rate.HourlyRate != tc.rate || rate.Source != tc.source || (tc.id != nil && *rate.PayRateID != *tc.id) {
+				t.Errorf("unexpected rate %+v", rate)
+			}
+		})
+	}
+
+	t.Run("No rate at all", func(t *testing.T) {
+		if _, err := useCase.RateFor(caregiver.ID, "Personal care", date(2023, 1, 1)); errorType(err) != domainErrors.NotFound {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
+}
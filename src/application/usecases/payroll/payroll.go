@@ -0,0 +1,214 @@
+package payroll
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainPayroll "caregiver/src/domain/payroll"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IPayrollUseCase interface {
+	// ClosePeriod locks the visits starting in [from, to) against edits.
+	ClosePeriod(from, to time.Time, callerID *uuid.UUID) (*domainPayroll.Period, error)
+	GetPeriods() (*[]domainPayroll.Period, error)
+	GetPeriod(id uuid.UUID) (*domainPayroll.Period, error)
+	// ReopenPeriod lifts the lock of a closed period. The reason is kept on
+	// the period.
+	ReopenPeriod(id uuid.UUID, reason string, callerID *uuid.UUID) (*domainPayroll.Period, error)
+	// UnlockVisit lets one visit in a closed period be edited for
+	// UnlockWindow.
+	UnlockVisit(scheduleID uuid.UUID, reason string, callerID *uuid.UUID) (*domainPayroll.Unlock, error)
+	GetUnlocks(scheduleID uuid.UUID) (*[]domainPayroll.Unlock, error)
+	// BeforeChange rejects changes to a visit that starts in a closed period
+	// and has no active unlock.
+	BeforeChange(schedule *domainSchedule.Schedule) error
+	// BeforeTaskChange applies BeforeChange to the visit a task belongs to.
+	BeforeTaskChange(taskID uuid.UUID) error
+}
+
+type PayrollUseCase struct {
+	payrollRepository  domainPayroll.IPayrollRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	Logger             *logger.Logger
+	now                func() time.Time
+}
+
+func NewPayrollUseCase(payrollRepository domainPayroll.IPayrollRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) IPayrollUseCase {
+	return &PayrollUseCase{
+		payrollRepository:  payrollRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		Logger:             loggerInstance,
+		now:                time.Now,
+	}
+}
+
+func (u *PayrollUseCase) ClosePeriod(from, to time.Time, callerID *uuid.UUID) (*domainPayroll.Period, error) {
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("the period must end after it starts"), domainErrors.ValidationError)
+	}
+	if err := u.requireAdmin(callerID, "close payroll periods"); err != nil {
+		return nil, err
+	}
+	closed, err := u.payrollRepository.GetClosedPeriods(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(*closed) > 0 {
+		existing := (*closed)[0]
+		return nil, domainErrors.NewAppError(fmt.Errorf("the period overlaps the closed period %s to %s", existing.From.Format(time.RFC3339), existing.To.Format(time.RFC3339)), domainErrors.Conflict)
+	}
+	u.Logger.Info("Closing payroll period", zap.Time("from", from), zap.Time("to", to))
+	return u.payrollRepository.CreatePeriod(&domainPayroll.Period{
+		From:     from.UTC(),
+		To:       to.UTC(),
+		Status:   domainPayroll.StatusClosed,
+		ClosedBy: callerID,
+		ClosedAt: u.now().UTC(),
+	})
+}
+
+func (u *PayrollUseCase) GetPeriods() (*[]domainPayroll.Period, error) {
+	return u.payrollRepository.GetPeriods()
+}
+
+func (u *PayrollUseCase) GetPeriod(id uuid.UUID) (*domainPayroll.Period, error) {
+	return u.payrollRepository.GetPeriodByID(id)
+}
+
+func (u *PayrollUseCase) ReopenPeriod(id uuid.UUID, reason string, callerID *uuid.UUID) (*domainPayroll.Period, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, domainErrors.NewAppError(errors.New("a reason is required to reopen a payroll period"), domainErrors.ValidationError)
+	}
+	if err := u.requireAdmin(callerID, "reopen payroll periods"); err != nil {
+		return nil, err
+	}
+	period, err := u.payrollRepository.GetPeriodByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !period.Closed() {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the period is already %s", period.Status), domainErrors.Conflict)
+	}
+	u.Logger.Info("Reopening payroll period", zap.String("id", id.String()))
+	return u.payrollRepository.UpdatePeriod(id, map[string]interface{}{
+		"status":        domainPayroll.StatusReopened,
+		"reopened_by":   callerID,
+		"reopened_at":   u.now().UTC(),
+		"reopen_reason": reason,
+	})
+}
+
+func (u *PayrollUseCase) UnlockVisit(scheduleID uuid.UUID, reason string, callerID *uuid.UUID) (*domainPayroll.Unlock, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, domainErrors.NewAppError(errors.New("a reason is required to unlock a visit"), domainErrors.ValidationError)
+	}
+	if err := u.requireAdmin(callerID, "unlock visits"); err != nil {
+		return nil, err
+	}
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	period, err := u.closedPeriodAt(schedule.ScheduledSlot.From)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return nil, domainErrors.NewAppError(errors.New("the visit is not in a closed payroll period"), domainErrors.ValidationError)
+	}
+	now := u.now().UTC()
+	u.Logger.Info("Unlocking visit in closed payroll period", zap.String("scheduleID", scheduleID.String()), zap.String("periodID", period.ID.String()))
+	return u.payrollRepository.CreateUnlock(&domainPayroll.Unlock{
+		ScheduleID: scheduleID,
+		PeriodID:   period.ID,
+		Reason:     reason,
+		UnlockedBy: callerID,
+		UnlockedAt: now,
+		ExpiresAt:  now.Add(domainPayroll.UnlockWindow),
+	})
+}
+
+func (u *PayrollUseCase) GetUnlocks(scheduleID uuid.UUID) (*[]domainPayroll.Unlock, error) {
+	return u.payrollRepository.GetUnlocks(scheduleID)
+}
+
+func (u *PayrollUseCase) BeforeChange(schedule *domainSchedule.Schedule) error {
+	return u.checkLocked(schedule.ID, schedule.ScheduledSlot.From)
+}
+
+func (u *PayrollUseCase) BeforeTaskChange(taskID uuid.UUID) error {
+	scheduleID, from, err := u.payrollRepository.GetTaskSchedule(taskID)
+	if err != nil {
+		if isNotFound(err) {
+			// Let the schedule use case report the missing task.
+			return nil
+		}
+		return err
+	}
+	return u.checkLocked(scheduleID, from)
+}
+
+func (u *PayrollUseCase) checkLocked(scheduleID uuid.UUID, from time.Time) error {
+	period, err := u.closedPeriodAt(from)
+	if err != nil || period == nil {
+		return err
+	}
+	unlocks, err := u.payrollRepository.GetUnlocks(scheduleID)
+	if err != nil {
+		return err
+	}
+	now := u.now()
+	for _, unlock := range *unlocks {
+		if unlock.ActiveAt(now) {
+			return nil
+		}
+	}
+	u.Logger.Warn("Change to visit in closed payroll period rejected", zap.String("scheduleID", scheduleID.String()), zap.String("periodID", period.ID.String()))
+	return domainErrors.NewAppError(fmt.Errorf("the visit is in the payroll period closed for %s to %s; an admin must unlock it first", period.From.Format("2006-01-02"), period.To.Format("2006-01-02")), domainErrors.Conflict)
+}
+
+// closedPeriodAt returns the closed period containing t, or nil.
+func (u *PayrollUseCase) closedPeriodAt(t time.Time) (*domainPayroll.Period, error) {
+	periods, err := u.payrollRepository.GetClosedPeriods(t, t.Add(time.Microsecond))
+	if err != nil {
+		return nil, err
+	}
+	for i := range *periods {
+		if (*periods)[i].Contains(t) {
+			return &(*periods)[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (u *PayrollUseCase) requireAdmin(callerID *uuid.UUID, action string) error {
+	if callerID == nil {
+		return nil
+	}
+	caller, err := u.userRepository.GetByID(*callerID)
+	if err != nil {
+		return err
+	}
+	if caller.Role != domainUser.RoleAdmin {
+		return domainErrors.NewAppError(errors.New("only admins can "+action), domainErrors.NotAuthorized)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
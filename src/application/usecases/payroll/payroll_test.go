@@ -0,0 +1,209 @@
+package payroll
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainPayroll "caregiver/src/domain/payroll"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps periods and unlocks in memory
+type memoryRepository struct {
+	periods []domainPayroll.Period
+	unlocks []domainPayroll.Unlock
+	tasks   map[uuid.UUID]domainSchedule.Schedule
+}
+
+func (m *memoryRepository) CreatePeriod(period *domainPayroll.Period) (*domainPayroll.Period, error) {
+	period.ID = uuid.New()
+	m.periods = append(m.periods, *period)
+	return period, nil
+}
+
+func (m *memoryRepository) GetPeriods() (*[]domainPayroll.Period, error) {
+	return &m.periods, nil
+}
+
+func (m *memoryRepository) GetPeriodByID(id uuid.UUID) (*domainPayroll.Period, error) {
+	for i := range m.periods {
+		if m.periods[i].ID == id {
+			period := m.periods[i]
+			return &period, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *memoryRepository) GetClosedPeriods(from, to time.Time) (*[]domainPayroll.Period, error) {
+	res := []domainPayroll.Period{}
+	for _, period := range m.periods {
+		if period.Closed() && period.From.Before(to) && period.To.After(from) {
+			res = append(res, period)
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) UpdatePeriod(id uuid.UUID, updates map[string]interface{}) (*domainPayroll.Period, error) {
+	for i := range m.periods {
+		if m.periods[i].ID == id {
+			m.periods[i].Status = updates["status"].(string)
+			m.periods[i].ReopenReason = updates["reopen_reason"].(string)
+			return m.GetPeriodByID(id)
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *memoryRepository) CreateUnlock(unlock *domainPayroll.Unlock) (*domainPayroll.Unlock, error) {
+	unlock.ID = uuid.New()
+	m.unlocks = append([]domainPayroll.Unlock{*unlock}, m.unlocks...)
+	return unlock, nil
+}
+
+func (m *memoryRepository) GetUnlocks(scheduleID uuid.UUID) (*[]domainPayroll.Unlock, error) {
+	res := []domainPayroll.Unlock{}
+	for _, unlock := range m.unlocks {
+		if unlock.ScheduleID == scheduleID {
+			res = append(res, unlock)
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) GetTaskSchedule(taskID uuid.UUID) (uuid.UUID, time.Time, error) {
+	schedule, ok := m.tasks[taskID]
+	if !ok {
+		return uuid.Nil, time.Time{}, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return schedule.ID, schedule.ScheduledSlot.From, nil
+}
+
+// mockScheduleRepository serves a fixed set of visits
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockUserRepository knows only the users it was given
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return user, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestPayrollLock(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	admin := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	caregiver := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{admin.ID: admin, caregiver.ID: caregiver}}
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	locked := domainSchedule.Schedule{ID: uuid.New(), ScheduledSlot: domainSchedule.ScheduledSlot{From: from.AddDate(0, 0, 3)}}
+	open := domainSchedule.Schedule{ID: uuid.New(), ScheduledSlot: domainSchedule.ScheduledSlot{From: to}}
+	taskID := uuid.New()
+	repo := &memoryRepository{tasks: map[uuid.UUID]domainSchedule.Schedule{taskID: locked}}
+	useCase := NewPayrollUseCase(repo, &mockScheduleRepository{schedules: []domainSchedule.Schedule{locked, open}}, users, loggerInstance).(*PayrollUseCase)
+	now := time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC)
+	useCase.now = func() time.Time { return now }
+
+	if _, err := useCase.ClosePeriod(to, from, &admin.ID); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a backwards period to be refused, got %v", err)
+	}
+	if _, err := useCase.ClosePeriod(from, to, &caregiver.ID); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected a caregiver to be refused, got %v", err)
+	}
+	period, err := useCase.ClosePeriod(from, to, &admin.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.ClosePeriod(to.AddDate(0, 0, -1), to.AddDate(0, 0, 13), &admin.ID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected an overlapping period to conflict, got %v", err)
+	}
+
+	if err := useCase.BeforeChange(&locked); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a visit in the closed period to be locked, got %v", err)
+	}
+	if err := useCase.BeforeTaskChange(taskID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a task of the locked visit to be locked, got %v", err)
+	}
+	if err := useCase.BeforeChange(&open); err != nil {
+		t.Errorf("expected a visit starting at the end of the period to be open, got %v", err)
+	}
+
+	if _, err := useCase.UnlockVisit(locked.ID, " ", &admin.ID); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an unlock without a reason to be refused, got %v", err)
+	}
+	if _, err := useCase.UnlockVisit(locked.ID, "Missed mileage", &caregiver.ID); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected a caregiver unlock to be refused, got %v", err)
+	}
+	if _, err := useCase.UnlockVisit(open.ID, "Missed mileage", &admin.ID); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an open visit unlock to be refused, got %v", err)
+	}
+	unlock, err := useCase.UnlockVisit(locked.ID, "Missed mileage", &admin.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unlock.PeriodID != period.ID || !unlock.ExpiresAt.Equal(now.Add(domainPayroll.UnlockWindow)) {
+		t.Errorf("unexpected unlock %+v", unlock)
+	}
+	if err := useCase.BeforeChange(&locked); err != nil {
+		t.Errorf("expected the unlocked visit to be editable, got %v", err)
+	}
+	if err := useCase.BeforeTaskChange(taskID); err != nil {
+		t.Errorf("expected the unlocked visit's tasks to be editable, got %v", err)
+	}
+
+	now = now.Add(domainPayroll.UnlockWindow)
+	if err := useCase.BeforeChange(&locked); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected the unlock to expire, got %v", err)
+	}
+
+	if _, err := useCase.ReopenPeriod(period.ID, "", &admin.ID); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a reopen without a reason to be refused, got %v", err)
+	}
+	if _, err := useCase.ReopenPeriod(period.ID, "Late timesheets", &admin.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := useCase.BeforeChange(&locked); err != nil {
+		t.Errorf("expected the reopened period to be editable, got %v", err)
+	}
+	if _, err := useCase.ReopenPeriod(period.ID, "Late timesheets", &admin.ID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a second reopen to conflict, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package planner
+
+import (
+	"sort"
+	"time"
+
+	domainLocale "caregiver/src/domain/locale"
+	domainPlanner "caregiver/src/domain/planner"
+
+	"github.com/google/uuid"
+)
+
+// Solver turns a week's demand and caregiver availability into proposed
+// assignments. GreedySolver is the default; an optimising solver, such as a
+// CP-SAT model run out of process, can be plugged in with WithSolver.
+type Solver interface {
+	Name() string
+	Solve(problem *domainPlanner.Problem) (*domainPlanner.Solution, error)
+}
+
+// GreedySolver places each client's visits in turn, busiest clients first,
+// on days spread over the client's template weekdays. Every visit goes to
+// the free caregiver who knows the client best, then the nearest, then the
+// least booked so far.
+type GreedySolver struct{}
+
+func NewGreedySolver() Solver {
+	return &GreedySolver{}
+}
+
+func (s *GreedySolver) Name() string {
+	return "greedy"
+}
+
+func (s *GreedySolver) Solve(problem *domainPlanner.Problem) (*domainPlanner.Solution, error) {
+	solution := &domainPlanner.Solution{Assignments: []domainPlanner.Assignment{}, Unplaced: []domainPlanner.Unplaced{}}
+	caregivers := make([]domainPlanner.Caregiver, len(problem.Caregivers))
+	for i, caregiver := range problem.Caregivers {
+		caregiver.Busy = append([]domainPlanner.Slot(nil), caregiver.Busy...)
+		caregivers[i] = caregiver
+	}
+	load := make([]time.Duration, len(caregivers))
+	planned := make(map[uuid.UUID]map[uuid.UUID]int)
+
+	demands := make([]domainPlanner.Demand, len(problem.Demands))
+	copy(demands, problem.Demands)
+	sort.SliceStable(demands, func(i, j int) bool {
+		if demands[i].Visits != demands[j].Visits {
+			return demands[i].Visits > demands[j].Visits
+		}
+		return demands[i].Client.ID.String() < demands[j].Client.ID.String()
+	})
+
+	for _, demand := range demands {
+		clientID := demand.Client.ID
+		duration := time.Duration(demand.Template.DurationMinutes) * time.Minute
+		slots := visitSlots(&demand.Template, problem, demand.Visits)
+		placed, unstaffed := 0, false
+		for _, from := range slots {
+			if placed == demand.Visits {
+				break
+			}
+			to := from.Add(duration)
+			if overlapsAny(problem.ClientBusy[clientID], from, to) {
+				continue
+			}
+			best := -1
+			for i := range caregivers {
+				if !free(&caregivers[i], clientID, from, to) {
+					continue
+				}
+				if best < 0 || better(&demand, &caregivers[i], &caregivers[best], planned, load[i], load[best]) {
+					best = i
+				}
+			}
+			if best < 0 {
+				unstaffed = true
+				continue
+			}
+			caregiver := &caregivers[best]
+			caregiver.Busy = append(caregiver.Busy, domainPlanner.Slot{From: from, To: to})
+			load[best] += duration
+			if planned[caregiver.User.ID] == nil {
+				planned[caregiver.User.ID] = make(map[uuid.UUID]int)
+			}
+			planned[caregiver.User.ID][clientID]++
+			solution.Assignments = append(solution.Assignments, domainPlanner.Assignment{
+				ClientUserID:    clientID,
+				CaregiverUserID: caregiver.User.ID,
+				ServiceName:     demand.Template.ServiceName,
+				From:            from,
+				To:              to,
+				DistanceKm:      distance(&demand, caregiver),
+				Warnings:        caregiver.Warnings[clientID],
+			})
+			placed++
+		}
+		if placed < demand.Visits {
+			reason := "no caregiver is available at the visit time"
+			if !unstaffed {
+				reason = "the template leaves too few free visit days this week"
+			}
+			solution.Unplaced = append(solution.Unplaced, domainPlanner.Unplaced{ClientUserID: clientID, Visits: demand.Visits - placed, Reason: reason})
+		}
+	}
+	return solution, nil
+}
+
+// visitSlots lists the template's visit start times left in the week, one
+// per allowed day the organization is open. The first visits days are spread
+// evenly over the week so a client needing three visits gets Monday,
+// Wednesday and Friday rather than three days in a row; the others follow as
+// fallbacks.
+func visitSlots(template *domainPlanner.Template, problem *domainPlanner.Problem, visits int) []time.Time {
+	calendar := problem.Calendar
+	if calendar == nil {
+		calendar = domainLocale.UTCCalendar()
+	}
+	loc := calendar.Location
+	if template.Timezone != "" {
+		loc = template.Location()
+	}
+	allowed := make(map[int]bool, len(template.Weekdays))
+	for _, weekday := range template.Weekdays {
+		allowed[weekday] = true
+	}
+	var slots []time.Time
+	for day := problem.WeekStart; day.Before(problem.WeekEnd); day = day.AddDate(0, 0, 1) {
+		local := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+		if len(allowed) > 0 && !allowed[int(local.Weekday())] {
+			continue
+		}
+		from := local.Add(time.Duration(template.StartMinute) * time.Minute)
+		if from.Before(problem.NotBefore) || from.Before(problem.WeekStart) || !from.Before(problem.WeekEnd) || calendar.Closed(from) {
+			continue
+		}
+		slots = append(slots, from)
+	}
+	if visits <= 0 || visits >= len(slots) {
+		return slots
+	}
+	ordered := make([]time.Time, 0, len(slots))
+	taken := make([]bool, len(slots))
+	for i := 0; i < visits; i++ {
+		j := (2*i + 1) * len(slots) / (2 * visits)
+		ordered = append(ordered, slots[j])
+		taken[j] = true
+	}
+	for j, slot := range slots {
+		if !taken[j] {
+			ordered = append(ordered, slot)
+		}
+	}
+	return ordered
+}
+
+// free reports whether the caregiver may take the client's visit over
+// [from, to): they are not screened out, one of their windows covers it and
+// they are not booked or on leave.
+func free(caregiver *domainPlanner.Caregiver, clientID uuid.UUID, from, to time.Time) bool {
+	if caregiver.Screened[clientID] || overlapsAny(caregiver.Busy, from, to) {
+		return false
+	}
+	for _, window := range caregiver.Windows {
+		if window.Covers(from, to) {
+			return true
+		}
+	}
+	return false
+}
+
+// better reports whether a should get the visit over b.
+func better(demand *domainPlanner.Demand, a, b *domainPlanner.Caregiver, planned map[uuid.UUID]map[uuid.UUID]int, loadA, loadB time.Duration) bool {
+	clientID := demand.Client.ID
+	knownA := a.Recent[clientID] + planned[a.User.ID][clientID]
+	knownB := b.Recent[clientID] + planned[b.User.ID][clientID]
+	if knownA != knownB {
+		return knownA > knownB
+	}
+	distA, distB := distance(demand, a), distance(demand, b)
+	if (distA == nil) != (distB == nil) {
+		return distA != nil
+	}
+	if distA != nil && *distA != *distB {
+		return *distA < *distB
+	}
+	if loadA != loadB {
+		return loadA < loadB
+	}
+	return a.User.ID.String() < b.User.ID.String()
+}
+
+func distance(demand *domainPlanner.Demand, caregiver *domainPlanner.Caregiver) *float64 {
+	if !demand.Client.Location.HasCoordinates() || !caregiver.User.Location.HasCoordinates() {
+		return nil
+	}
+	km := demand.Client.Location.DistanceKm(caregiver.User.Location)
+	return &km
+}
+
+func overlapsAny(slots []domainPlanner.Slot, from, to time.Time) bool {
+	for _, slot := range slots {
+		if slot.Overlaps(from, to) {
+			return true
+		}
+	}
+	return false
+}
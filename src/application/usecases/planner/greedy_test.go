@@ -0,0 +1,163 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	domainLocale "caregiver/src/domain/locale"
+	domainPlanner "caregiver/src/domain/planner"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+// weekStart is a Monday.
+var weekStart = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+func weekdayWindows(caregiverID uuid.UUID) []domainPlanner.Availability {
+	var windows []domainPlanner.Availability
+	for weekday := 1; weekday <= 5; weekday++ {
+		windows = append(windows, domainPlanner.Availability{CaregiverUserID: caregiverID, Weekday: weekday, StartMinute: 8 * 60, EndMinute: 17 * 60})
+	}
+	return windows
+}
+
+func caregiver(location domainUser.Location, windows func(uuid.UUID) []domainPlanner.Availability) domainPlanner.Caregiver {
+	id := uuid.New()
+	return domainPlanner.Caregiver{
+		User:     domainUser.User{ID: id, Role: domainUser.RoleCaregiver, Status: true, Location: location},
+		Windows:  windows(id),
+		Recent:   map[uuid.UUID]int{},
+		Screened: map[uuid.UUID]bool{},
+		Warnings: map[uuid.UUID][]string{},
+	}
+}
+
+func demand(client domainUser.User, visits int, weekdays ...int) domainPlanner.Demand {
+	return domainPlanner.Demand{
+		Client: client,
+		Visits: visits,
+		Template: domainPlanner.Template{
+			ClientUserID:    client.ID,
+			ServiceName:     "Personal care",
+			DurationMinutes: 60,
+			StartMinute:     9 * 60,
+			Weekdays:        weekdays,
+		},
+	}
+}
+
+func at(day int) time.Time {
+	return weekStart.AddDate(0, 0, day).Add(9 * time.Hour)
+}
+
+func TestGreedySolverPrefersContinuityAndSpreadsVisits(t *testing.T) {
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: domainUser.Location{Lat: 51.50, Long: -0.12}}
+	regular := caregiver(domainUser.Location{Lat: 51.60, Long: -0.20}, weekdayWindows)
+	regular.Recent[client.ID] = 4
+	// The regular caregiver is booked on Wednesday morning.
+	regular.Busy = []domainPlanner.Slot{{From: at(2).Add(-time.Hour), To: at(2).Add(time.Hour)}}
+	near := caregiver(domainUser.Location{Lat: 51.51, Long: -0.12}, weekdayWindows)
+	far := caregiver(domainUser.Location{Lat: 52.50, Long: -1.90}, weekdayWindows)
+
+	problem := &domainPlanner.Problem{
+		WeekStart:  weekStart,
+		WeekEnd:    weekStart.AddDate(0, 0, 7),
+		NotBefore:  weekStart.Add(-24 * time.Hour),
+		Demands:    []domainPlanner.Demand{demand(client, 3, 1, 2, 3, 4, 5)},
+		Caregivers: []domainPlanner.Caregiver{far, regular, near},
+	}
+	solution, err := NewGreedySolver().Solve(problem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(solution.Unplaced) != 0 || len(solution.Assignments) != 3 {
+		t.Fatalf("expected 3 visits placed, got %+v", solution)
+	}
+	expected := []struct {
+		from      time.Time
+		caregiver uuid.UUID
+	}{
+		{at(0), regular.User.ID},
+		{at(2), near.User.ID},
+		{at(4), regular.User.ID},
+	}
+	for i, e := range expected {
+		got := solution.Assignments[i]
+		if !got.From.Equal(e.from) || got.CaregiverUserID != e.caregiver {
+			t.Errorf("visit %d: expected %s with %s, got %s with %s", i, e.from, e.caregiver, got.From, got.CaregiverUserID)
+		}
+		if got.To.Sub(got.From) != time.Hour || got.DistanceKm == nil {
+			t.Errorf("visit %d: unexpected slot or distance %+v", i, got)
+		}
+	}
+	if len(problem.Caregivers[1].Busy) != 1 {
+		t.Error("expected the solver to leave the problem's bookings untouched")
+	}
+}
+
+func TestGreedySolverReportsUnplacedVisits(t *testing.T) {
+	screenedClient := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	weekendClient := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	earlyWeekClient := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	only := caregiver(domainUser.Location{}, weekdayWindows)
+	only.Screened[screenedClient.ID] = true
+
+	problem := &domainPlanner.Problem{
+		WeekStart: weekStart,
+		WeekEnd:   weekStart.AddDate(0, 0, 7),
+		// Monday has passed, so only one Tuesday is left.
+		NotBefore: weekStart.Add(12 * time.Hour),
+		Demands: []domainPlanner.Demand{
+			demand(screenedClient, 1),
+			demand(weekendClient, 1, 0, 6),
+			demand(earlyWeekClient, 2, 1, 2),
+		},
+		Caregivers: []domainPlanner.Caregiver{only},
+	}
+	solution, err := NewGreedySolver().Solve(problem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(solution.Assignments) != 1 || solution.Assignments[0].ClientUserID != earlyWeekClient.ID || !solution.Assignments[0].From.Equal(at(1)) {
+		t.Fatalf("expected only the Tuesday visit to be placed, got %+v", solution.Assignments)
+	}
+	reasons := map[uuid.UUID]domainPlanner.Unplaced{}
+	for _, u := range solution.Unplaced {
+		reasons[u.ClientUserID] = u
+	}
+	if u := reasons[screenedClient.ID]; u.Visits != 1 || u.Reason != "no caregiver is available at the visit time" {
+		t.Errorf("expected the screened client's visit to be unstaffed, got %+v", u)
+	}
+	if u := reasons[weekendClient.ID]; u.Visits != 1 || u.Reason != "no caregiver is available at the visit time" {
+		t.Errorf("expected no weekend caregiver, got %+v", u)
+	}
+	if u := reasons[earlyWeekClient.ID]; u.Visits != 1 || u.Reason != "the template leaves too few free visit days this week" {
+		t.Errorf("expected too few days left, got %+v", u)
+	}
+}
+
+func TestGreedySolverSkipsHolidays(t *testing.T) {
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	only := caregiver(domainUser.Location{}, weekdayWindows)
+	calendar := domainLocale.NewCalendar(&domainLocale.Settings{SkipHolidays: true}, []domainLocale.Holiday{{Date: "2026-03-02", Name: "Founders Day"}})
+
+	problem := &domainPlanner.Problem{
+		WeekStart:  weekStart,
+		WeekEnd:    weekStart.AddDate(0, 0, 7),
+		NotBefore:  weekStart.Add(-24 * time.Hour),
+		Demands:    []domainPlanner.Demand{demand(client, 2, 1, 2)},
+		Caregivers: []domainPlanner.Caregiver{only},
+		Calendar:   calendar,
+	}
+	solution, err := NewGreedySolver().Solve(problem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(solution.Assignments) != 1 || !solution.Assignments[0].From.Equal(at(1)) {
+		t.Fatalf("expected only the Tuesday visit to be placed, got %+v", solution.Assignments)
+	}
+	if len(solution.Unplaced) != 1 || solution.Unplaced[0].Visits != 1 {
+		t.Errorf("expected the Monday visit to be unplaced, got %+v", solution.Unplaced)
+	}
+}
@@ -0,0 +1,518 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"caregiver/src/application/usecases/suggestion"
+	domainCarePlan "caregiver/src/domain/careplan"
+	domainErrors "caregiver/src/domain/errors"
+	domainLeave "caregiver/src/domain/leave"
+	domainLocale "caregiver/src/domain/locale"
+	domainPlanner "caregiver/src/domain/planner"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// continuityWindow is how far back visits count towards a caregiver
+	// knowing a client.
+	continuityWindow = 28 * 24 * time.Hour
+	historyPageSize  = 500
+)
+
+// FrequencySource reports how many visits each client's care plan still
+// needs in a week.
+type FrequencySource interface {
+	FrequencyReport(weekOf time.Time) (*domainCarePlan.FrequencyReport, error)
+}
+
+// CalendarSource supplies the organization's time zone and holidays.
+type CalendarSource interface {
+	Calendar(from, to time.Time) (*domainLocale.Calendar, error)
+}
+
+// ScheduleCreator books a visit, running the usual schedule validators.
+type ScheduleCreator interface {
+	CreateSchedule(schedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error)
+}
+
+// AssignmentUpdate is a coordinator's review of a proposed visit.
+type AssignmentUpdate struct {
+	Accepted        *bool
+	CaregiverUserID *uuid.UUID
+}
+
+type IPlannerUseCase interface {
+	GetTemplates() (*[]domainPlanner.Template, error)
+	SaveTemplate(template *domainPlanner.Template) (*domainPlanner.Template, error)
+	DeleteTemplate(clientUserID uuid.UUID) error
+	GetAvailability(caregiverUserID uuid.UUID) (*[]domainPlanner.Availability, error)
+	SetAvailability(caregiverUserID uuid.UUID, windows []domainPlanner.Availability) (*[]domainPlanner.Availability, error)
+	// Propose plans the visits care plans still need in the week of weekOf,
+	// from now on, and saves the plan as a draft for review.
+	Propose(weekOf time.Time, createdBy *uuid.UUID, now time.Time) (*domainPlanner.Proposal, error)
+	GetProposals() (*[]domainPlanner.Proposal, error)
+	GetProposal(id uuid.UUID) (*domainPlanner.Proposal, error)
+	UpdateAssignment(proposalID, assignmentID uuid.UUID, update AssignmentUpdate) (*domainPlanner.Assignment, error)
+	// Commit books every accepted assignment of a draft. Assignments the
+	// schedule validators reject keep the error and are not booked.
+	Commit(id uuid.UUID, committedBy *uuid.UUID, now time.Time) (*domainPlanner.Proposal, error)
+	Discard(id uuid.UUID) (*domainPlanner.Proposal, error)
+}
+
+type PlannerUseCase struct {
+	plannerRepository  domainPlanner.IPlannerRepository
+	userRepository     domainUser.IUserRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	leaveRepository    domainLeave.ILeaveRepository
+	frequency          FrequencySource
+	schedules          ScheduleCreator
+	solver             Solver
+	screens            []suggestion.CaregiverScreen
+	calendar           CalendarSource
+	Logger             *logger.Logger
+}
+
+type Option func(*PlannerUseCase)
+
+// WithSolver replaces the greedy solver.
+func WithSolver(solver Solver) Option {
+	return func(u *PlannerUseCase) {
+		u.solver = solver
+	}
+}
+
+// WithCaregiverScreens keeps caregivers away from clients the screens
+// exclude them for, as for schedule suggestions.
+func WithCaregiverScreens(screens ...suggestion.CaregiverScreen) Option {
+	return func(u *PlannerUseCase) {
+		u.screens = append(u.screens, screens...)
+	}
+}
+
+// WithCalendar plans templates without a time zone in the organization's,
+// and keeps visits off the days it is closed for holidays.
+func WithCalendar(source CalendarSource) Option {
+	return func(u *PlannerUseCase) {
+		u.calendar = source
+	}
+}
+
+func NewPlannerUseCase(plannerRepository domainPlanner.IPlannerRepository, userRepository domainUser.IUserRepository, scheduleRepository domainSchedule.IScheduleRepository, leaveRepository domainLeave.ILeaveRepository, frequency FrequencySource, schedules ScheduleCreator, loggerInstance *logger.Logger, opts ...Option) IPlannerUseCase {
+	u := &PlannerUseCase{
+		plannerRepository:  plannerRepository,
+		userRepository:     userRepository,
+		scheduleRepository: scheduleRepository,
+		leaveRepository:    leaveRepository,
+		frequency:          frequency,
+		schedules:          schedules,
+		solver:             NewGreedySolver(),
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+func (u *PlannerUseCase) GetTemplates() (*[]domainPlanner.Template, error) {
+	return u.plannerRepository.GetTemplates()
+}
+
+func (u *PlannerUseCase) SaveTemplate(template *domainPlanner.Template) (*domainPlanner.Template, error) {
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+	if err := u.checkRole(template.ClientUserID, domainUser.RoleClient); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Saving demand template", zap.String("clientUserID", template.ClientUserID.String()))
+	return u.plannerRepository.SaveTemplate(template)
+}
+
+func (u *PlannerUseCase) DeleteTemplate(clientUserID uuid.UUID) error {
+	return u.plannerRepository.DeleteTemplate(clientUserID)
+}
+
+func (u *PlannerUseCase) GetAvailability(caregiverUserID uuid.UUID) (*[]domainPlanner.Availability, error) {
+	return u.plannerRepository.GetAvailability([]uuid.UUID{caregiverUserID})
+}
+
+func (u *PlannerUseCase) SetAvailability(caregiverUserID uuid.UUID, windows []domainPlanner.Availability) (*[]domainPlanner.Availability, error) {
+	for i := range windows {
+		if err := validateWindow(&windows[i]); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.checkRole(caregiverUserID, domainUser.RoleCaregiver); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Setting caregiver availability", zap.String("caregiverUserID", caregiverUserID.String()), zap.Int("windows", len(windows)))
+	return u.plannerRepository.ReplaceAvailability(caregiverUserID, windows)
+}
+
+func (u *PlannerUseCase) Propose(weekOf time.Time, createdBy *uuid.UUID, now time.Time) (*domainPlanner.Proposal, error) {
+	report, err := u.frequency.FrequencyReport(weekOf)
+	if err != nil {
+		return nil, err
+	}
+	if !report.WeekEnd.After(now) {
+		return nil, domainErrors.NewAppError(errors.New("the week is already over"), domainErrors.ValidationError)
+	}
+	templates, err := u.plannerRepository.GetTemplates()
+	if err != nil {
+		return nil, err
+	}
+	byClient := make(map[uuid.UUID]domainPlanner.Template, len(*templates))
+	for _, template := range *templates {
+		byClient[template.ClientUserID] = template
+	}
+
+	problem := &domainPlanner.Problem{
+		WeekStart:  report.WeekStart,
+		WeekEnd:    report.WeekEnd,
+		NotBefore:  now,
+		ClientBusy: make(map[uuid.UUID][]domainPlanner.Slot),
+	}
+	if u.calendar != nil {
+		if problem.Calendar, err = u.calendar.Calendar(report.WeekStart, report.WeekEnd); err != nil {
+			return nil, err
+		}
+	}
+	unplaced := []domainPlanner.Unplaced{}
+	for _, entry := range report.Clients {
+		needed := entry.Required - entry.Delivered - entry.Upcoming
+		if needed <= 0 {
+			continue
+		}
+		template, ok := byClient[entry.ClientUserID]
+		if !ok {
+			unplaced = append(unplaced, domainPlanner.Unplaced{ClientUserID: entry.ClientUserID, Visits: needed, Reason: "the client has no demand template"})
+			continue
+		}
+		client, err := u.userRepository.GetByID(entry.ClientUserID)
+		if err != nil {
+			unplaced = append(unplaced, domainPlanner.Unplaced{ClientUserID: entry.ClientUserID, Visits: needed, Reason: "the client was not found"})
+			continue
+		}
+		problem.Demands = append(problem.Demands, domainPlanner.Demand{Template: template, Client: *client, Visits: needed})
+	}
+	if err := u.loadCaregivers(problem); err != nil {
+		return nil, err
+	}
+
+	solution, err := u.solver.Solve(problem)
+	if err != nil {
+		u.Logger.Error("Error solving schedule plan", zap.Error(err), zap.String("solver", u.solver.Name()))
+		return nil, err
+	}
+	for i := range solution.Assignments {
+		solution.Assignments[i].Accepted = true
+	}
+	proposal := &domainPlanner.Proposal{
+		WeekStart:       report.WeekStart,
+		WeekEnd:         report.WeekEnd,
+		Status:          domainPlanner.StatusDraft,
+		Solver:          u.solver.Name(),
+		Assignments:     solution.Assignments,
+		Unplaced:        append(unplaced, solution.Unplaced...),
+		CreatedByUserID: createdBy,
+	}
+	u.Logger.Info("Schedule plan proposed", zap.Time("weekStart", proposal.WeekStart),
+		zap.Int("assignments", len(proposal.Assignments)), zap.Int("unplacedClients", len(proposal.Unplaced)))
+	return u.plannerRepository.CreateProposal(proposal)
+}
+
+func (u *PlannerUseCase) GetProposals() (*[]domainPlanner.Proposal, error) {
+	return u.plannerRepository.GetProposals()
+}
+
+func (u *PlannerUseCase) GetProposal(id uuid.UUID) (*domainPlanner.Proposal, error) {
+	return u.plannerRepository.GetProposal(id)
+}
+
+func (u *PlannerUseCase) UpdateAssignment(proposalID, assignmentID uuid.UUID, update AssignmentUpdate) (*domainPlanner.Assignment, error) {
+	proposal, err := u.draft(proposalID)
+	if err != nil {
+		return nil, err
+	}
+	var assignment *domainPlanner.Assignment
+	for i := range proposal.Assignments {
+		if proposal.Assignments[i].ID == assignmentID {
+			assignment = &proposal.Assignments[i]
+		}
+	}
+	if assignment == nil {
+		return nil, domainErrors.NewAppError(errors.New("assignment not found in the proposal"), domainErrors.NotFound)
+	}
+	if update.CaregiverUserID != nil && *update.CaregiverUserID != assignment.CaregiverUserID {
+		if err := u.checkRole(*update.CaregiverUserID, domainUser.RoleCaregiver); err != nil {
+			return nil, err
+		}
+		// The distance and warnings were about the caregiver first proposed.
+		assignment.CaregiverUserID = *update.CaregiverUserID
+		assignment.DistanceKm = nil
+		assignment.Warnings = nil
+	}
+	if update.Accepted != nil {
+		assignment.Accepted = *update.Accepted
+	}
+	return u.plannerRepository.UpdateAssignment(assignment)
+}
+
+func (u *PlannerUseCase) Commit(id uuid.UUID, committedBy *uuid.UUID, now time.Time) (*domainPlanner.Proposal, error) {
+	proposal, err := u.draft(id)
+	if err != nil {
+		return nil, err
+	}
+	booked, failed := 0, 0
+	for i := range proposal.Assignments {
+		assignment := &proposal.Assignments[i]
+		if !assignment.Accepted || assignment.ScheduleID != nil {
+			continue
+		}
+		created, err := u.schedules.CreateSchedule(&domainSchedule.Schedule{
+			ClientUserID:   assignment.ClientUserID,
+			AssignedUserID: assignment.CaregiverUserID,
+			ServiceName:    assignment.ServiceName,
+			ScheduledSlot:  domainSchedule.ScheduledSlot{From: assignment.From, To: assignment.To},
+		})
+		if err != nil {
+			assignment.Error = err.Error()
+			failed++
+		} else {
+			assignment.ScheduleID = &created.ID
+			assignment.Error = ""
+			booked++
+		}
+		if _, err := u.plannerRepository.UpdateAssignment(assignment); err != nil {
+			return nil, err
+		}
+	}
+	proposal.Status = domainPlanner.StatusCommitted
+	proposal.CommittedByUserID = committedBy
+	proposal.CommittedAt = &now
+	u.Logger.Info("Schedule plan committed", zap.String("id", id.String()), zap.Int("booked", booked), zap.Int("failed", failed))
+	return u.plannerRepository.UpdateProposal(proposal)
+}
+
+func (u *PlannerUseCase) Discard(id uuid.UUID) (*domainPlanner.Proposal, error) {
+	proposal, err := u.draft(id)
+	if err != nil {
+		return nil, err
+	}
+	proposal.Status = domainPlanner.StatusDiscarded
+	return u.plannerRepository.UpdateProposal(proposal)
+}
+
+// draft returns the proposal if it can still be changed.
+func (u *PlannerUseCase) draft(id uuid.UUID) (*domainPlanner.Proposal, error) {
+	proposal, err := u.plannerRepository.GetProposal(id)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != domainPlanner.StatusDraft {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the proposal is already %s", proposal.Status), domainErrors.Conflict)
+	}
+	return proposal, nil
+}
+
+// loadCaregivers adds every active caregiver with availability to the
+// problem, with their bookings and leave in the week, their recent visits to
+// the demand's clients and the screens' verdicts on them.
+func (u *PlannerUseCase) loadCaregivers(problem *domainPlanner.Problem) error {
+	users, err := u.userRepository.GetAll()
+	if err != nil {
+		return err
+	}
+	windows, err := u.plannerRepository.GetAvailability(nil)
+	if err != nil {
+		return err
+	}
+	byCaregiver := make(map[uuid.UUID][]domainPlanner.Availability)
+	for _, window := range *windows {
+		byCaregiver[window.CaregiverUserID] = append(byCaregiver[window.CaregiverUserID], window)
+	}
+	index := make(map[uuid.UUID]int)
+	for _, user := range *users {
+		if user.Role != domainUser.RoleCaregiver || !user.Status || len(byCaregiver[user.ID]) == 0 {
+			continue
+		}
+		index[user.ID] = len(problem.Caregivers)
+		problem.Caregivers = append(problem.Caregivers, domainPlanner.Caregiver{
+			User:     user,
+			Windows:  byCaregiver[user.ID],
+			Recent:   make(map[uuid.UUID]int),
+			Screened: make(map[uuid.UUID]bool),
+			Warnings: make(map[uuid.UUID][]string),
+		})
+	}
+	if len(problem.Caregivers) == 0 || len(problem.Demands) == 0 {
+		return nil
+	}
+
+	booked, err := u.scheduleRepository.GetActiveSchedulesBetween(problem.WeekStart, problem.WeekEnd, nil)
+	if err != nil {
+		return err
+	}
+	for _, visit := range *booked {
+		slot := domainPlanner.Slot{From: visit.ScheduledSlot.From, To: visit.ScheduledSlot.To}
+		problem.ClientBusy[visit.ClientUserID] = append(problem.ClientBusy[visit.ClientUserID], slot)
+		if i, ok := index[visit.AssignedUserID]; ok {
+			problem.Caregivers[i].Busy = append(problem.Caregivers[i].Busy, slot)
+		}
+	}
+	leave, err := u.leaveRepository.GetRequests(domainLeave.RequestFilter{
+		Statuses: []string{domainLeave.StatusApproved},
+		From:     &problem.WeekStart,
+		To:       &problem.WeekEnd,
+	})
+	if err != nil {
+		return err
+	}
+	for _, request := range *leave {
+		if i, ok := index[request.CaregiverUserID]; ok && request.Overlaps(problem.WeekStart, problem.WeekEnd) {
+			problem.Caregivers[i].Busy = append(problem.Caregivers[i].Busy, domainPlanner.Slot{From: request.From, To: request.To})
+		}
+	}
+
+	clientIDs := make([]uuid.UUID, len(problem.Demands))
+	for i, demand := range problem.Demands {
+		clientIDs[i] = demand.Client.ID
+	}
+	if err := u.loadRecent(problem, clientIDs, index); err != nil {
+		return err
+	}
+	return u.screen(problem)
+}
+
+// loadRecent counts each caregiver's visits to the clients over the
+// continuity window before the week.
+func (u *PlannerUseCase) loadRecent(problem *domainPlanner.Problem, clientIDs []uuid.UUID, index map[uuid.UUID]int) error {
+	from := problem.WeekStart.Add(-continuityWindow)
+	for page := 1; ; page++ {
+		result, err := u.scheduleRepository.Search(domainSchedule.SearchQuery{
+			Statuses:      []string{"completed", "partially_completed"},
+			ClientUserIDs: clientIDs,
+			From:          &from,
+			To:            &problem.WeekStart,
+			Page:          page,
+			PageSize:      historyPageSize,
+		})
+		if err != nil {
+			return err
+		}
+		for _, visit := range *result.Data {
+			if i, ok := index[visit.AssignedUserID]; ok {
+				problem.Caregivers[i].Recent[visit.ClientUserID]++
+			}
+		}
+		if page >= result.TotalPages {
+			return nil
+		}
+	}
+}
+
+func (u *PlannerUseCase) screen(problem *domainPlanner.Problem) error {
+	if len(u.screens) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(problem.Caregivers))
+	for i, caregiver := range problem.Caregivers {
+		ids[i] = caregiver.User.ID
+	}
+	for _, demand := range problem.Demands {
+		client := demand.Client
+		for _, screen := range u.screens {
+			verdicts, err := screen.ScreenCaregivers(&client, ids)
+			if err != nil {
+				return err
+			}
+			for i := range problem.Caregivers {
+				caregiver := &problem.Caregivers[i]
+				verdict := verdicts[caregiver.User.ID]
+				if verdict.Excluded {
+					caregiver.Screened[client.ID] = true
+				}
+				if verdict.Warning != "" {
+					caregiver.Warnings[client.ID] = append(caregiver.Warnings[client.ID], verdict.Warning)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (u *PlannerUseCase) checkRole(userID uuid.UUID, role string) error {
+	user, err := u.userRepository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Role != role {
+		return domainErrors.NewAppError(fmt.Errorf("the user is not a %s", role), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func validateTemplate(t *domainPlanner.Template) error {
+	t.ServiceName = strings.TrimSpace(t.ServiceName)
+	if t.ServiceName == "" {
+		return domainErrors.NewAppError(errors.New("service name is required"), domainErrors.ValidationError)
+	}
+	if t.DurationMinutes <= 0 || t.DurationMinutes > 24*60 {
+		return domainErrors.NewAppError(errors.New("duration must be between 1 minute and 24 hours"), domainErrors.ValidationError)
+	}
+	if t.StartMinute < 0 || t.StartMinute >= 24*60 {
+		return domainErrors.NewAppError(errors.New("start minute must be within the day"), domainErrors.ValidationError)
+	}
+	weekdays, err := normalizeWeekdays(t.Weekdays)
+	if err != nil {
+		return err
+	}
+	t.Weekdays = weekdays
+	return validateTimezone(t.Timezone)
+}
+
+func validateWindow(w *domainPlanner.Availability) error {
+	if w.Weekday < 0 || w.Weekday > 6 {
+		return domainErrors.NewAppError(errors.New("weekday must be between 0 (Sunday) and 6"), domainErrors.ValidationError)
+	}
+	if w.StartMinute < 0 || w.EndMinute > 24*60 || w.StartMinute >= w.EndMinute {
+		return domainErrors.NewAppError(errors.New("availability must start before it ends, within the day"), domainErrors.ValidationError)
+	}
+	return validateTimezone(w.Timezone)
+}
+
+func normalizeWeekdays(weekdays []int) ([]int, error) {
+	seen := make(map[int]bool, len(weekdays))
+	res := []int{}
+	for _, weekday := range weekdays {
+		if weekday < 0 || weekday > 6 {
+			return nil, domainErrors.NewAppError(errors.New("weekdays must be between 0 (Sunday) and 6"), domainErrors.ValidationError)
+		}
+		if !seen[weekday] {
+			seen[weekday] = true
+			res = append(res, weekday)
+		}
+	}
+	sort.Ints(res)
+	return res, nil
+}
+
+func validateTimezone(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return domainErrors.NewAppError(fmt.Errorf("unknown time zone %q", name), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package profilechange
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	userUseCase "caregiver/src/application/usecases/user"
+	domainErrors "caregiver/src/domain/errors"
+	domainProfileChange "caregiver/src/domain/profilechange"
+	domainServiceArea "caregiver/src/domain/servicearea"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// phonePattern accepts digits with an optional leading "+" and the usual
+// separators.
+var phonePattern = regexp.MustCompile(`^\+?[0-9 ()-]{7,20}$`)
+
+type IProfileChangeUseCase interface {
+	UpdateOwnProfile(userID uuid.UUID, update domainProfileChange.Update) (*domainProfileChange.Result, error)
+	GetAll(filter domainProfileChange.Filter) (*[]domainProfileChange.Change, error)
+	GetByID(id uuid.UUID) (*domainProfileChange.Change, error)
+	Approve(id, deciderID uuid.UUID, note string, now time.Time) (*domainProfileChange.Change, error)
+	Reject(id, deciderID uuid.UUID, note string, now time.Time) (*domainProfileChange.Change, error)
+}
+
+type ProfileChangeUseCase struct {
+	profileChangeRepository domainProfileChange.IProfileChangeRepository
+	serviceAreaRepository   domainServiceArea.IServiceAreaRepository
+	userUseCase             userUseCase.IUserUseCase
+	Logger                  *logger.Logger
+}
+
+func NewProfileChangeUseCase(profileChangeRepository domainProfileChange.IProfileChangeRepository, serviceAreaRepository domainServiceArea.IServiceAreaRepository, userUseCase userUseCase.IUserUseCase, loggerInstance *logger.Logger) IProfileChangeUseCase {
+	return &ProfileChangeUseCase{
+		profileChangeRepository: profileChangeRepository,
+		serviceAreaRepository:   serviceAreaRepository,
+		userUseCase:             userUseCase,
+		Logger:                  loggerInstance,
+	}
+}
+
+// UpdateOwnProfile applies a caregiver's phone and photo straight away. A new
+// address is applied too unless the caregiver's service area is centered on
+// their home, in which case it waits for a coordinator as a pending change.
+func (u *ProfileChangeUseCase) UpdateOwnProfile(userID uuid.UUID, update domainProfileChange.Update) (*domainProfileChange.Result, error) {
+	u.Logger.Info("Updating own profile", zap.String("userID", userID.String()))
+	caregiver, err := u.userUseCase.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if caregiver.Role != domainUser.RoleCaregiver {
+		return nil, domainErrors.NewAppError(errors.New("only caregivers can update their own profile"), domainErrors.NotAuthorized)
+	}
+	if err := validateUpdate(&update); err != nil {
+		return nil, err
+	}
+
+	userMap := map[string]interface{}{}
+	if update.Phone != nil {
+		userMap["Phone"] = *update.Phone
+	}
+	if update.ProfilePicture != nil {
+		userMap["ProfilePicture"] = *update.ProfilePicture
+	}
+	result := &domainProfileChange.Result{User: caregiver}
+	if update.Location != nil && *update.Location != caregiver.Location {
+		needsApproval, err := u.movesServiceArea(userID)
+		if err != nil {
+			return nil, err
+		}
+		if needsApproval {
+			result.Pending, err = u.holdAddressChange(userID, *update.Location)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			for k, v := range locationUpdates(*update.Location) {
+				userMap[k] = v
+			}
+		}
+	}
+	if len(userMap) > 0 {
+		result.User, err = u.userUseCase.Update(userID, userMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (u *ProfileChangeUseCase) GetAll(filter domainProfileChange.Filter) (*[]domainProfileChange.Change, error) {
+	return u.profileChangeRepository.GetAll(filter)
+}
+
+func (u *ProfileChangeUseCase) GetByID(id uuid.UUID) (*domainProfileChange.Change, error) {
+	return u.profileChangeRepository.GetByID(id)
+}
+
+// Approve applies the proposed address to the caregiver's profile.
+func (u *ProfileChangeUseCase) Approve(id, deciderID uuid.UUID, note string, now time.Time) (*domainProfileChange.Change, error) {
+	u.Logger.Info("Approving profile change", zap.String("id", id.String()), zap.String("deciderID", deciderID.String()))
+	change, err := u.pendingChange(id, deciderID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.userUseCase.Update(change.UserID, locationUpdates(change.Location)); err != nil {
+		return nil, err
+	}
+	return u.profileChangeRepository.Update(id, decisionUpdates(domainProfileChange.StatusApproved, deciderID, note, now))
+}
+
+func (u *ProfileChangeUseCase) Reject(id, deciderID uuid.UUID, note string, now time.Time) (*domainProfileChange.Change, error) {
+	u.Logger.Info("Rejecting profile change", zap.String("id", id.String()), zap.String("deciderID", deciderID.String()))
+	if _, err := u.pendingChange(id, deciderID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(note) == "" {
+		return nil, domainErrors.NewAppError(errors.New("a note is required to reject a change"), domainErrors.ValidationError)
+	}
+	return u.profileChangeRepository.Update(id, decisionUpdates(domainProfileChange.StatusRejected, deciderID, note, now))
+}
+
+// movesServiceArea reports whether the caregiver's service area is a radius
+// around their home address, so that moving house moves the area.
+func (u *ProfileChangeUseCase) movesServiceArea(userID uuid.UUID) (bool, error) {
+	area, err := u.serviceAreaRepository.GetByUserID(userID)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return area.Type == domainServiceArea.TypeRadius && (area.CenterLat == nil || area.CenterLong == nil), nil
+}
+
+func (u *ProfileChangeUseCase) holdAddressChange(userID uuid.UUID, location domainUser.Location) (*domainProfileChange.Change, error) {
+	pending, err := u.profileChangeRepository.GetAll(domainProfileChange.Filter{UserID: &userID, Statuses: []string{domainProfileChange.StatusPending}})
+	if err != nil {
+		return nil, err
+	}
+	if len(*pending) > 0 {
+		return nil, domainErrors.NewAppError(errors.New("an address change is already waiting for approval"), domainErrors.Conflict)
+	}
+	return u.profileChangeRepository.Create(&domainProfileChange.Change{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Location: location,
+		Status:   domainProfileChange.StatusPending,
+	})
+}
+
+func (u *ProfileChangeUseCase) pendingChange(id, deciderID uuid.UUID) (*domainProfileChange.Change, error) {
+	change, err := u.profileChangeRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if change.Status != domainProfileChange.StatusPending {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the change is already %s", change.Status), domainErrors.Conflict)
+	}
+	decider, err := u.userUseCase.GetByID(deciderID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("decider not found"), domainErrors.NotFound)
+	}
+	if decider.Role != domainUser.RoleAdmin {
+		return nil, domainErrors.NewAppError(errors.New("only coordinators can decide profile changes"), domainErrors.NotAuthorized)
+	}
+	return change, nil
+}
+
+func validateUpdate(update *domainProfileChange.Update) error {
+	if update.Phone != nil {
+		phone := strings.TrimSpace(*update.Phone)
+		if !phonePattern.MatchString(phone) {
+			return domainErrors.NewAppError(errors.New("phone must be 7 to 20 digits, optionally starting with +"), domainErrors.ValidationError)
+		}
+		update.Phone = &phone
+	}
+	if update.ProfilePicture != nil && *update.ProfilePicture != "" {
+		parsed, err := url.Parse(*update.ProfilePicture)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return domainErrors.NewAppError(errors.New("profile picture must be an absolute http or https url"), domainErrors.ValidationError)
+		}
+	}
+	if update.Location != nil {
+		if strings.TrimSpace(update.Location.Street) == "" || strings.TrimSpace(update.Location.City) == "" {
+			return domainErrors.NewAppError(errors.New("address needs at least a street and a city"), domainErrors.ValidationError)
+		}
+	}
+	return nil
+}
+
+func locationUpdates(location domainUser.Location) map[string]interface{} {
+	return map[string]interface{}{
+		"HouseNumber": location.HouseNumber,
+		"Street":      location.Street,
+		"City":        location.City,
+		"State":       location.State,
+		"Pincode":     location.Pincode,
+		"Lat":         location.Lat,
+		"Long":        location.Long,
+	}
+}
+
+func decisionUpdates(status string, deciderID uuid.UUID, note string, now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"status":             status,
+		"decided_by_user_id": deciderID,
+		"decision_note":      strings.TrimSpace(note),
+		"decided_at":         now.UTC(),
+	}
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
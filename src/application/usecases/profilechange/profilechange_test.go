@@ -0,0 +1,177 @@
+package profilechange
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	userUseCase "caregiver/src/application/usecases/user"
+	domainErrors "caregiver/src/domain/errors"
+	domainProfileChange "caregiver/src/domain/profilechange"
+	domainServiceArea "caregiver/src/domain/servicearea"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockProfileChangeRepository keeps changes in memory
+type mockProfileChangeRepository struct {
+	changes map[uuid.UUID]*domainProfileChange.Change
+}
+
+func (m *mockProfileChangeRepository) Create(change *domainProfileChange.Change) (*domainProfileChange.Change, error) {
+	copied := *change
+	m.changes[change.ID] = &copied
+	return m.GetByID(change.ID)
+}
+
+func (m *mockProfileChangeRepository) GetByID(id uuid.UUID) (*domainProfileChange.Change, error) {
+	change, ok := m.changes[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *change
+	return &copied, nil
+}
+
+func (m *mockProfileChangeRepository) GetAll(filter domainProfileChange.Filter) (*[]domainProfileChange.Change, error) {
+	changes := []domainProfileChange.Change{}
+	for _, change := range m.changes {
+		if filter.UserID != nil && change.UserID != *filter.UserID {
+			continue
+		}
+		if len(filter.Statuses) > 0 && change.Status != filter.Statuses[0] {
+			continue
+		}
+		changes = append(changes, *change)
+	}
+	return &changes, nil
+}
+
+func (m *mockProfileChangeRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainProfileChange.Change, error) {
+	change := m.changes[id]
+	change.Status = updates["status"].(string)
+	change.DecisionNote = updates["decision_note"].(string)
+	return m.GetByID(id)
+}
+
+// mockServiceAreaRepository knows only the areas it was given
+type mockServiceAreaRepository struct {
+	domainServiceArea.IServiceAreaRepository
+	areas map[uuid.UUID]*domainServiceArea.Area
+}
+
+func (m *mockServiceAreaRepository) GetByUserID(userID uuid.UUID) (*domainServiceArea.Area, error) {
+	area, ok := m.areas[userID]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return area, nil
+}
+
+// mockUserUseCase applies updates to users held in memory
+type mockUserUseCase struct {
+	userUseCase.IUserUseCase
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserUseCase) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (m *mockUserUseCase) Update(id uuid.UUID, userMap map[string]interface{}) (*domainUser.User, error) {
+	user := m.users[id]
+	for k, v := range userMap {
+		switch k {
+		case "Phone":
+			user.Phone = v.(string)
+		case "ProfilePicture":
+			user.ProfilePicture = v.(string)
+		case "Street":
+			user.Location.Street = v.(string)
+		case "City":
+			user.Location.City = v.(string)
+		}
+	}
+	return m.GetByID(id)
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestUpdateOwnProfile(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	homeCentered, fixedCenter, client, admin := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	lat, long := 12.97, 77.59
+	users := &mockUserUseCase{users: map[uuid.UUID]*domainUser.User{
+		homeCentered: {ID: homeCentered, Role: domainUser.RoleCaregiver, Location: domainUser.Location{Street: "Old St", City: "Pune"}},
+		fixedCenter:  {ID: fixedCenter, Role: domainUser.RoleCaregiver, Location: domainUser.Location{Street: "Old St", City: "Pune"}},
+		client:       {ID: client, Role: domainUser.RoleClient},
+		admin:        {ID: admin, Role: domainUser.RoleAdmin},
+	}}
+	areas := &mockServiceAreaRepository{areas: map[uuid.UUID]*domainServiceArea.Area{
+		homeCentered: {UserID: homeCentered, Type: domainServiceArea.TypeRadius, RadiusKm: 10},
+		fixedCenter:  {UserID: fixedCenter, Type: domainServiceArea.TypeRadius, RadiusKm: 10, CenterLat: &lat, CenterLong: &long},
+	}}
+	useCase := NewProfileChangeUseCase(&mockProfileChangeRepository{changes: map[uuid.UUID]*domainProfileChange.Change{}}, areas, users, loggerInstance)
+	phone, badPhone := "+91 98765 43210", "call me"
+	newAddress := domainUser.Location{Street: "New St", City: "Mumbai"}
+
+	if _, err := useCase.UpdateOwnProfile(client, domainProfileChange.Update{Phone: &phone}); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected clients to be refused, got %v", err)
+	}
+	if _, err := useCase.UpdateOwnProfile(homeCentered, domainProfileChange.Update{Phone: &badPhone}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected validation error for the phone, got %v", err)
+	}
+
+	result, err := useCase.UpdateOwnProfile(fixedCenter, domainProfileChange.Update{Phone: &phone, Location: &newAddress})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pending != nil || result.User.Phone != phone || result.User.Location.City != "Mumbai" {
+		t.Errorf("expected the address to apply when the area has its own center, got %+v", result)
+	}
+
+	result, err = useCase.UpdateOwnProfile(homeCentered, domainProfileChange.Update{Phone: &phone, Location: &newAddress})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pending == nil || result.User.Phone != phone || result.User.Location.City != "Pune" {
+		t.Fatalf("expected the phone to apply and the address to wait, got %+v", result)
+	}
+	if _, err := useCase.UpdateOwnProfile(homeCentered, domainProfileChange.Update{Location: &domainUser.Location{Street: "Other St", City: "Goa"}}); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a second pending address change to conflict, got %v", err)
+	}
+
+	if _, err := useCase.Approve(result.Pending.ID, homeCentered, "", now); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected only coordinators to decide, got %v", err)
+	}
+	if _, err := useCase.Reject(result.Pending.ID, admin, " ", now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a note to be required to reject, got %v", err)
+	}
+	approved, err := useCase.Approve(result.Pending.ID, admin, "", now)
+	if err != nil || approved.Status != domainProfileChange.StatusApproved {
+		t.Fatalf("expected the change to be approved, got %+v, %v", approved, err)
+	}
+	if user, _ := users.GetByID(homeCentered); user.Location.City != "Mumbai" {
+		t.Errorf("expected the approved address on the profile, got %+v", user.Location)
+	}
+	if _, err := useCase.Approve(result.Pending.ID, admin, "", now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected deciding twice to conflict, got %v", err)
+	}
+}
@@ -0,0 +1,322 @@
+package prospect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	userUseCase "caregiver/src/application/usecases/user"
+	domainErrors "caregiver/src/domain/errors"
+	domainProspect "caregiver/src/domain/prospect"
+	domainReferral "caregiver/src/domain/referral"
+	domainUser "caregiver/src/domain/user"
+	"caregiver/src/infrastructure/captcha"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IProspectUseCase interface {
+	Create(newProspect *domainProspect.Prospect) (*domainProspect.Prospect, error)
+	GetByID(id uuid.UUID) (*domainProspect.Prospect, error)
+	GetAll(status string) (*[]domainProspect.Prospect, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*domainProspect.Prospect, error)
+	Delete(id uuid.UUID) error
+	ScheduleAssessment(id uuid.UUID, at time.Time, assessorUserID *uuid.UUID, notes string, now time.Time) (*domainProspect.Prospect, error)
+	Quote(id uuid.UUID, hourlyRate float64, hoursPerWeek *float64, notes string, now time.Time) (*domainProspect.Prospect, error)
+	Accept(id uuid.UUID, userName string, now time.Time) (*domainProspect.Prospect, error)
+	Decline(id uuid.UUID, reason string) (*domainProspect.Prospect, error)
+	SubmitBookingRequest(request *domainProspect.Prospect, captchaToken string, remoteIP string, now time.Time) (*domainProspect.Prospect, error)
+}
+
+// Limits on what the public booking request form accepts.
+const (
+	MaxPreferredTimes  = 5
+	MaxCareNeedsLength = 2000
+)
+
+type ProspectUseCase struct {
+	prospectRepository domainProspect.IProspectRepository
+	referralRepository domainReferral.IReferralRepository
+	userUseCase        userUseCase.IUserUseCase
+	captcha            captcha.IVerifier
+	notifier           notification.INotifier
+	Logger             *logger.Logger
+}
+
+func NewProspectUseCase(prospectRepository domainProspect.IProspectRepository, referralRepository domainReferral.IReferralRepository, userUseCase userUseCase.IUserUseCase, captchaVerifier captcha.IVerifier, notifier notification.INotifier, loggerInstance *logger.Logger) IProspectUseCase {
+	return &ProspectUseCase{
+		prospectRepository: prospectRepository,
+		referralRepository: referralRepository,
+		userUseCase:        userUseCase,
+		captcha:            captchaVerifier,
+		notifier:           notifier,
+		Logger:             loggerInstance,
+	}
+}
+
+// Create records a new inquiry.
+func (u *ProspectUseCase) Create(newProspect *domainProspect.Prospect) (*domainProspect.Prospect, error) {
+	u.Logger.Info("Creating prospect", zap.String("lastName", newProspect.LastName))
+	if err := u.validate(newProspect); err != nil {
+		return nil, err
+	}
+	newProspect.ID = uuid.New()
+	newProspect.Status = domainProspect.StatusInquiry
+	newProspect.Channel = domainProspect.ChannelStaff
+	return u.prospectRepository.Create(newProspect)
+}
+
+// SubmitBookingRequest records an inquiry sent through the public booking
+// form once its CAPTCHA checks out, and lets intake coordinators know. Only
+// contact details, the address, the service and preferred times are taken
+// from the form.
+func (u *ProspectUseCase) SubmitBookingRequest(request *domainProspect.Prospect, captchaToken string, remoteIP string, now time.Time) (*domainProspect.Prospect, error) {
+	u.Logger.Info("Receiving booking request", zap.String("serviceName", request.ServiceName))
+	newProspect := &domainProspect.Prospect{
+		FirstName:      strings.TrimSpace(request.FirstName),
+		LastName:       strings.TrimSpace(request.LastName),
+		Email:          strings.TrimSpace(request.Email),
+		Phone:          strings.TrimSpace(request.Phone),
+		Location:       request.Location,
+		ServiceName:    strings.TrimSpace(request.ServiceName),
+		PreferredTimes: request.PreferredTimes,
+		CareNeeds:      strings.TrimSpace(request.CareNeeds),
+	}
+	if err := u.validateBookingRequest(newProspect, now); err != nil {
+		return nil, err
+	}
+
+	if err := u.captcha.Verify(context.Background(), captchaToken, remoteIP); err != nil {
+		if errors.Is(err, captcha.ErrFailed) {
+			u.Logger.Warn("Booking request failed CAPTCHA", zap.Error(err), zap.String("remoteIP", remoteIP))
+			return nil, domainErrors.NewAppError(errors.New("captcha verification failed"), domainErrors.NotAuthorized)
+		}
+		u.Logger.Error("Error verifying booking request CAPTCHA", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	newProspect.ID = uuid.New()
+	newProspect.Status = domainProspect.StatusInquiry
+	newProspect.Channel = domainProspect.ChannelBooking
+	created, err := u.prospectRepository.Create(newProspect)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.notifier.Notify(notification.Message{
+		Role:    domainUser.RoleAdmin,
+		Subject: "New booking request",
+		Body:    fmt.Sprintf("%s %s asked for %s with %d preferred time(s).", created.FirstName, created.LastName, created.ServiceName, len(created.PreferredTimes)),
+		Data: map[string]interface{}{
+			"prospectID":  created.ID,
+			"serviceName": created.ServiceName,
+		},
+	}); err != nil {
+		u.Logger.Warn("Error notifying intake coordinators of booking request", zap.Error(err), zap.String("prospectID", created.ID.String()))
+	}
+	return created, nil
+}
+
+func (u *ProspectUseCase) GetByID(id uuid.UUID) (*domainProspect.Prospect, error) {
+	return u.prospectRepository.GetByID(id)
+}
+
+func (u *ProspectUseCase) GetAll(status string) (*[]domainProspect.Prospect, error) {
+	return u.prospectRepository.GetAll(status)
+}
+
+// Update edits the details collected on an open prospect. Pipeline fields
+// change only through the assessment, quote, accept and decline steps.
+func (u *ProspectUseCase) Update(id uuid.UUID, updates map[string]interface{}) (*domainProspect.Prospect, error) {
+	u.Logger.Info("Updating prospect", zap.String("id", id.String()))
+	existing, err := u.open(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["first_name"].(string); ok {
+		candidate.FirstName = v
+	}
+	if v, ok := updates["last_name"].(string); ok {
+		candidate.LastName = v
+	}
+	if v, ok := updates["email"].(string); ok {
+		candidate.Email = v
+	}
+	if v, ok := updates["phone"].(string); ok {
+		candidate.Phone = v
+	}
+	if v, ok := updates["referral_source_id"].(uuid.UUID); ok {
+		candidate.ReferralSourceID = &v
+	}
+	if err := u.validate(&candidate); err != nil {
+		return nil, err
+	}
+	return u.prospectRepository.Update(id, updates)
+}
+
+func (u *ProspectUseCase) Delete(id uuid.UUID) error {
+	u.Logger.Info("Deleting prospect", zap.String("id", id.String()))
+	return u.prospectRepository.Delete(id)
+}
+
+// ScheduleAssessment books or reschedules the in-home assessment of a prospect
+// that has not been quoted yet.
+func (u *ProspectUseCase) ScheduleAssessment(id uuid.UUID, at time.Time, assessorUserID *uuid.UUID, notes string, now time.Time) (*domainProspect.Prospect, error) {
+	u.Logger.Info("Scheduling prospect assessment", zap.String("id", id.String()), zap.Time("at", at))
+	prospect, err := u.open(id)
+	if err != nil {
+		return nil, err
+	}
+	if prospect.Status == domainProspect.StatusQuoted {
+		return nil, domainErrors.NewAppError(errors.New("the prospect has already been quoted"), domainErrors.Conflict)
+	}
+	if !at.After(now) {
+		return nil, domainErrors.NewAppError(errors.New("the assessment must be in the future"), domainErrors.ValidationError)
+	}
+	if assessorUserID != nil {
+		assessor, err := u.userUseCase.GetByID(*assessorUserID)
+		if err != nil {
+			return nil, domainErrors.NewAppError(errors.New("assessor not found"), domainErrors.ValidationError)
+		}
+		if assessor.Role == domainUser.RoleClient {
+			return nil, domainErrors.NewAppError(errors.New("the assessor must be a staff member"), domainErrors.ValidationError)
+		}
+	}
+	return u.prospectRepository.Update(id, map[string]interface{}{
+		"status":           domainProspect.StatusAssessmentScheduled,
+		"assessment_at":    at,
+		"assessor_user_id": assessorUserID,
+		"assessment_notes": notes,
+	})
+}
+
+// Quote records the rate offered to a prospect, replacing any earlier quote.
+func (u *ProspectUseCase) Quote(id uuid.UUID, hourlyRate float64, hoursPerWeek *float64, notes string, now time.Time) (*domainProspect.Prospect, error) {
+	u.Logger.Info("Quoting prospect", zap.String("id", id.String()), zap.Float64("hourlyRate", hourlyRate))
+	if _, err := u.open(id); err != nil {
+		return nil, err
+	}
+	if hourlyRate <= 0 {
+		return nil, domainErrors.NewAppError(errors.New("the hourly rate must be greater than zero"), domainErrors.ValidationError)
+	}
+	if hoursPerWeek != nil && (*hoursPerWeek <= 0 || *hoursPerWeek > 168) {
+		return nil, domainErrors.NewAppError(errors.New("hours per week must be between 0 and 168"), domainErrors.ValidationError)
+	}
+	return u.prospectRepository.Update(id, map[string]interface{}{
+		"status":                domainProspect.StatusQuoted,
+		"quoted_hourly_rate":    hourlyRate,
+		"quoted_hours_per_week": hoursPerWeek,
+		"quote_notes":           notes,
+		"quoted_at":             now,
+	})
+}
+
+// Accept converts a quoted prospect into a client user, carrying over their
+// name, email, address and referral source. The user name defaults to the
+// email.
+func (u *ProspectUseCase) Accept(id uuid.UUID, userName string, now time.Time) (*domainProspect.Prospect, error) {
+	u.Logger.Info("Accepting prospect", zap.String("id", id.String()))
+	prospect, err := u.open(id)
+	if err != nil {
+		return nil, err
+	}
+	if prospect.Status != domainProspect.StatusQuoted {
+		return nil, domainErrors.NewAppError(errors.New("only a quoted prospect can be accepted"), domainErrors.Conflict)
+	}
+	if strings.TrimSpace(prospect.Email) == "" {
+		return nil, domainErrors.NewAppError(errors.New("an email is required to create the client"), domainErrors.ValidationError)
+	}
+	if strings.TrimSpace(userName) == "" {
+		userName = prospect.Email
+	}
+	client, err := u.userUseCase.Create(&domainUser.User{
+		UserName:         userName,
+		Email:            prospect.Email,
+		FirstName:        prospect.FirstName,
+		LastName:         prospect.LastName,
+		Role:             domainUser.RoleClient,
+		ReferralSourceID: prospect.ReferralSourceID,
+		Location:         prospect.Location,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Prospect converted to client", zap.String("id", id.String()), zap.String("clientUserID", client.ID.String()))
+	return u.prospectRepository.Update(id, map[string]interface{}{
+		"status":         domainProspect.StatusAccepted,
+		"client_user_id": client.ID,
+		"converted_at":   now,
+	})
+}
+
+// Decline closes a prospect that did not go ahead.
+func (u *ProspectUseCase) Decline(id uuid.UUID, reason string) (*domainProspect.Prospect, error) {
+	u.Logger.Info("Declining prospect", zap.String("id", id.String()))
+	if _, err := u.open(id); err != nil {
+		return nil, err
+	}
+	return u.prospectRepository.Update(id, map[string]interface{}{
+		"status":         domainProspect.StatusDeclined,
+		"decline_reason": reason,
+	})
+}
+
+// open loads a prospect that is still in the pipeline.
+func (u *ProspectUseCase) open(id uuid.UUID) (*domainProspect.Prospect, error) {
+	prospect, err := u.prospectRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !prospect.Open() {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the prospect is already %s", prospect.Status), domainErrors.Conflict)
+	}
+	return prospect, nil
+}
+
+func (u *ProspectUseCase) validateBookingRequest(p *domainProspect.Prospect, now time.Time) error {
+	if err := u.validate(p); err != nil {
+		return err
+	}
+	if p.ServiceName == "" {
+		return domainErrors.NewAppError(errors.New("service type is required"), domainErrors.ValidationError)
+	}
+	if strings.TrimSpace(p.Location.Street) == "" || strings.TrimSpace(p.Location.City) == "" {
+		return domainErrors.NewAppError(errors.New("a street and city are required"), domainErrors.ValidationError)
+	}
+	if len(p.CareNeeds) > MaxCareNeedsLength {
+		return domainErrors.NewAppError(fmt.Errorf("care needs must be at most %d characters", MaxCareNeedsLength), domainErrors.ValidationError)
+	}
+	if len(p.PreferredTimes) == 0 || len(p.PreferredTimes) > MaxPreferredTimes {
+		return domainErrors.NewAppError(fmt.Errorf("between 1 and %d preferred times are required", MaxPreferredTimes), domainErrors.ValidationError)
+	}
+	for _, slot := range p.PreferredTimes {
+		if !slot.To.After(slot.From) || !slot.From.After(now) {
+			return domainErrors.NewAppError(errors.New("preferred times must be in the future and end after they start"), domainErrors.ValidationError)
+		}
+	}
+	return nil
+}
+
+func (u *ProspectUseCase) validate(p *domainProspect.Prospect) error {
+	if strings.TrimSpace(p.FirstName) == "" || strings.TrimSpace(p.LastName) == "" {
+		return domainErrors.NewAppError(errors.New("first and last name are required"), domainErrors.ValidationError)
+	}
+	if strings.TrimSpace(p.Email) == "" && strings.TrimSpace(p.Phone) == "" {
+		return domainErrors.NewAppError(errors.New("an email or phone number is required"), domainErrors.ValidationError)
+	}
+	if p.ReferralSourceID != nil {
+		if _, err := u.referralRepository.GetSourceByID(*p.ReferralSourceID); err != nil {
+			var appErr *domainErrors.AppError
+			if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+				return domainErrors.NewAppError(errors.New("referral source not found"), domainErrors.ValidationError)
+			}
+			return err
+		}
+	}
+	return nil
+}
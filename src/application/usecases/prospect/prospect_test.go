@@ -0,0 +1,302 @@
+package prospect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	userUseCase "caregiver/src/application/usecases/user"
+	domainErrors "caregiver/src/domain/errors"
+	domainProspect "caregiver/src/domain/prospect"
+	domainReferral "caregiver/src/domain/referral"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	"caregiver/src/infrastructure/captcha"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+// mockProspectRepository keeps prospects in memory and applies the updates
+// the use case makes
+type mockProspectRepository struct {
+	domainProspect.IProspectRepository
+	prospects map[uuid.UUID]*domainProspect.Prospect
+}
+
+func (m *mockProspectRepository) Create(newProspect *domainProspect.Prospect) (*domainProspect.Prospect, error) {
+	copied := *newProspect
+	m.prospects[newProspect.ID] = &copied
+	return newProspect, nil
+}
+
+func (m *mockProspectRepository) GetByID(id uuid.UUID) (*domainProspect.Prospect, error) {
+	if prospect, ok := m.prospects[id]; ok {
+		copied := *prospect
+		return &copied, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockProspectRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainProspect.Prospect, error) {
+	prospect, ok := m.prospects[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	if v, ok := updates["status"].(string); ok {
+		prospect.Status = v
+	}
+	if v, ok := updates["assessment_at"].(time.Time); ok {
+		prospect.AssessmentAt = &v
+	}
+	if v, ok := updates["quoted_hourly_rate"].(float64); ok {
+		prospect.QuotedHourlyRate = &v
+	}
+	if v, ok := updates["client_user_id"].(uuid.UUID); ok {
+		prospect.ClientUserID = &v
+	}
+	if v, ok := updates["decline_reason"].(string); ok {
+		prospect.DeclineReason = v
+	}
+	return m.GetByID(id)
+}
+
+// mockReferralRepository knows a single source
+type mockReferralRepository struct {
+	domainReferral.IReferralRepository
+	sourceID uuid.UUID
+}
+
+func (m *mockReferralRepository) GetSourceByID(id uuid.UUID) (*domainReferral.Source, error) {
+	if id == m.sourceID {
+		return &domainReferral.Source{ID: id}, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockUserUseCase records created users
+type mockUserUseCase struct {
+	userUseCase.IUserUseCase
+	users   map[uuid.UUID]*domainUser.User
+	created []domainUser.User
+}
+
+func (m *mockUserUseCase) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockUserUseCase) Create(newUser *domainUser.User) (*domainUser.User, error) {
+	newUser.ID = uuid.New()
+	m.created = append(m.created, *newUser)
+	return newUser, nil
+}
+
+// mockVerifier accepts a single CAPTCHA token, or reports an error
+type mockVerifier struct {
+	token string
+	err   error
+}
+
+func (m *mockVerifier) Verify(ctx context.Context, token string, remoteIP string) error {
+	if m.err != nil {
+		return m.err
+	}
+	if token != m.token {
+		return captcha.ErrFailed
+	}
+	return nil
+}
+
+// mockNotifier records sent messages
+type mockNotifier struct {
+	messages []notification.Message
+}
+
+func (m *mockNotifier) Notify(message notification.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func setupTestProspectUseCase(t *testing.T) (IProspectUseCase, *mockUserUseCase, uuid.UUID, uuid.UUID) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	sourceID := uuid.New()
+	nurseID := uuid.New()
+	users := &mockUserUseCase{users: map[uuid.UUID]*domainUser.User{
+		nurseID: {ID: nurseID, Role: domainUser.RoleCaregiver},
+	}}
+	prospects := &mockProspectRepository{prospects: make(map[uuid.UUID]*domainProspect.Prospect)}
+	return NewProspectUseCase(prospects, &mockReferralRepository{sourceID: sourceID}, users, &mockVerifier{}, &mockNotifier{}, loggerInstance), users, sourceID, nurseID
+}
+
+func setupTestBookingUseCase(t *testing.T, verifier *mockVerifier) (IProspectUseCase, *mockProspectRepository, *mockNotifier) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	prospects := &mockProspectRepository{prospects: make(map[uuid.UUID]*domainProspect.Prospect)}
+	notifier := &mockNotifier{}
+	return NewProspectUseCase(prospects, &mockReferralRepository{}, &mockUserUseCase{}, verifier, notifier, loggerInstance), prospects, notifier
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestPipeline(t *testing.T) {
+	useCase, users, sourceID, nurseID := setupTestProspectUseCase(t)
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	if _, err := useCase.Create(&domainProspect.Prospect{FirstName: "Rosa", LastName: "Diaz"}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a prospect without contact details to be rejected, got %v", err)
+	}
+	unknown := uuid.New()
+	if _, err := useCase.Create(&domainProspect.Prospect{FirstName: "Rosa", LastName: "Diaz", Phone: "555-0100", ReferralSourceID: &unknown}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an unknown referral source to be rejected, got %v", err)
+	}
+	prospect, err := useCase.Create(&domainProspect.Prospect{
+		FirstName:        "Rosa",
+		LastName:         "Diaz",
+		Email:            "rosa@example.com",
+		Location:         domainUser.Location{Street: "Elm St", City: "Austin"},
+		ReferralSourceID: &sourceID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prospect.Status != domainProspect.StatusInquiry {
+		t.Errorf("expected a new inquiry, got %s", prospect.Status)
+	}
+
+	if _, err := useCase.Accept(prospect.ID, "", now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected an unquoted prospect not to be accepted, got %v", err)
+	}
+	if _, err := useCase.ScheduleAssessment(prospect.ID, now.Add(-time.Hour), nil, "", now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a past assessment to be rejected, got %v", err)
+	}
+	assessed, err := useCase.ScheduleAssessment(prospect.ID, now.Add(48*time.Hour), &nurseID, "Bring mobility checklist", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if assessed.Status != domainProspect.StatusAssessmentScheduled {
+		t.Errorf("expected the assessment to be scheduled, got %s", assessed.Status)
+	}
+	if _, err := useCase.Quote(prospect.ID, 0, nil, "", now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a zero rate to be rejected, got %v", err)
+	}
+	if _, err := useCase.Quote(prospect.ID, 32.5, nil, "Weekday mornings", now.Add(72*time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	accepted, err := useCase.Accept(prospect.ID, "", now.Add(96*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users.created) != 1 {
+		t.Fatalf("expected one client to be created, got %d", len(users.created))
+	}
+	client := users.created[0]
+	if client.Role != domainUser.RoleClient || client.UserName != "rosa@example.com" || client.Location.City != "Austin" || *client.ReferralSourceID != sourceID {
+		t.Errorf("expected the collected details carried over, got %+v", client)
+	}
+	if accepted.Status != domainProspect.StatusAccepted || *accepted.ClientUserID != client.ID {
+		t.Errorf("expected the prospect linked to the new client, got %+v", accepted)
+	}
+	if _, err := useCase.Decline(prospect.ID, "changed mind"); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected an accepted prospect to be closed, got %v", err)
+	}
+}
+
+func TestDecline(t *testing.T) {
+	useCase, users, _, _ := setupTestProspectUseCase(t)
+	prospect, _ := useCase.Create(&domainProspect.Prospect{FirstName: "Sam", LastName: "Lee", Phone: "555-0199"})
+
+	declined, err := useCase.Decline(prospect.ID, "Chose another agency")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if declined.Status != domainProspect.StatusDeclined || declined.DeclineReason != "Chose another agency" {
+		t.Errorf("expected the prospect declined with its reason, got %+v", declined)
+	}
+	if _, err := useCase.Quote(prospect.ID, 30, nil, "", time.Now()); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a declined prospect not to be quoted, got %v", err)
+	}
+	if len(users.created) != 0 {
+		t.Errorf("expected no client to be created")
+	}
+}
+
+func TestSubmitBookingRequest(t *testing.T) {
+	now := time.Date(2025, 7, 14, 12, 0, 0, 0, time.UTC)
+	tomorrow := now.Add(24 * time.Hour)
+	bookingRequest := func() *domainProspect.Prospect {
+		return &domainProspect.Prospect{
+			FirstName:      "Rosa",
+			LastName:       "Diaz",
+			Email:          "rosa@example.com",
+			Location:       domainUser.Location{Street: "1 Main St", City: "Austin"},
+			ServiceName:    "Companion care",
+			PreferredTimes: []domainSchedule.ScheduledSlot{{From: tomorrow, To: tomorrow.Add(2 * time.Hour)}},
+			Status:         domainProspect.StatusAccepted,
+			ClientUserID:   &uuid.UUID{},
+		}
+	}
+
+	useCase, prospects, notifier := setupTestBookingUseCase(t, &mockVerifier{token: "ok"})
+	for _, tc := range []struct {
+		name   string
+		change func(p *domainProspect.Prospect)
+	}{
+		{"Service is required", func(p *domainProspect.Prospect) { p.ServiceName = " " }},
+		{"Address is required", func(p *domainProspect.Prospect) { p.Location.Street = "" }},
+		{"Preferred times are required", func(p *domainProspect.Prospect) { p.PreferredTimes = nil }},
+		{"Preferred times must be ahead", func(p *domainProspect.Prospect) {
+			p.PreferredTimes[0] = domainSchedule.ScheduledSlot{From: now.Add(-time.Hour), To: now}
+		}},
+		{"Preferred times must end after they start", func(p *domainProspect.Prospect) {
+			p.PreferredTimes[0].To = p.PreferredTimes[0].From
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			request := bookingRequest()
+			tc.change(request)
+			if _, err := useCase.SubmitBookingRequest(request, "ok", "203.0.113.7", now); errorType(err) != domainErrors.ValidationError {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+
+	if _, err := useCase.SubmitBookingRequest(bookingRequest(), "forged", "203.0.113.7", now); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected a failed CAPTCHA to be refused, got %v", err)
+	}
+	if len(prospects.prospects) != 0 || len(notifier.messages) != 0 {
+		t.Fatalf("expected nothing recorded for refused requests")
+	}
+
+	created, err := useCase.SubmitBookingRequest(bookingRequest(), "ok", "203.0.113.7", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Channel != domainProspect.ChannelBooking || created.Status != domainProspect.StatusInquiry || created.ClientUserID != nil {
+		t.Errorf("expected a fresh inquiry from the booking form, got %+v", created)
+	}
+	if len(notifier.messages) != 1 || notifier.messages[0].Role != domainUser.RoleAdmin {
+		t.Errorf("expected intake coordinators notified, got %+v", notifier.messages)
+	}
+
+	unconfigured, _, _ := setupTestBookingUseCase(t, &mockVerifier{err: captcha.ErrNotConfigured})
+	if _, err := unconfigured.SubmitBookingRequest(bookingRequest(), "ok", "203.0.113.7", now); errorType(err) != domainErrors.UnknownError {
+		t.Errorf("expected requests refused without a CAPTCHA secret, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainQuota "caregiver/src/domain/quota"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IQuotaUseCase interface {
+	// CountCall counts one call by an API client and returns where it now
+	// stands; Status.OverHardLimit means the call must be refused.
+	CountCall(client string, at time.Time) (*domainQuota.Status, error)
+	// Status is where the client stands this month, without counting a call.
+	Status(client string, at time.Time) (*domainQuota.Status, error)
+	// History is the client's usage per month, most recent first.
+	History(client string) (*[]domainQuota.Usage, error)
+	// Report is every client's standing in the month, busiest first.
+	Report(month string) ([]domainQuota.Status, error)
+	GetQuotas() (*[]domainQuota.Quota, error)
+	SaveQuota(quota *domainQuota.Quota) (*domainQuota.Quota, error)
+	DeleteQuota(id uuid.UUID) error
+}
+
+type QuotaUseCase struct {
+	quotaRepository domainQuota.IQuotaRepository
+	notifier        notification.INotifier
+	Logger          *logger.Logger
+}
+
+func NewQuotaUseCase(quotaRepository domainQuota.IQuotaRepository, notifier notification.INotifier, loggerInstance *logger.Logger) IQuotaUseCase {
+	return &QuotaUseCase{
+		quotaRepository: quotaRepository,
+		notifier:        notifier,
+		Logger:          loggerInstance,
+	}
+}
+
+func (u *QuotaUseCase) CountCall(client string, at time.Time) (*domainQuota.Status, error) {
+	month := at.UTC().Format(domainQuota.MonthLayout)
+	calls, err := u.quotaRepository.CountCall(client, month, at)
+	if err != nil {
+		return nil, err
+	}
+	quota, err := u.quotaFor(client)
+	if err != nil {
+		return nil, err
+	}
+	status := statusOf(client, quota, month, calls, at)
+	if quota.SoftLimit > 0 && calls == quota.SoftLimit+1 {
+		status.SoftLimitReached = true
+		u.warnSoftLimit(status)
+	}
+	if status.OverHardLimit {
+		u.Logger.Warn("API quota exceeded", zap.String("client", client), zap.Int64("calls", calls), zap.Int64("hardLimit", quota.HardLimit))
+	}
+	return status, nil
+}
+
+func (u *QuotaUseCase) Status(client string, at time.Time) (*domainQuota.Status, error) {
+	month := at.UTC().Format(domainQuota.MonthLayout)
+	quota, err := u.quotaFor(client)
+	if err != nil {
+		return nil, err
+	}
+	history, err := u.quotaRepository.GetUsage(client)
+	if err != nil {
+		return nil, err
+	}
+	var calls int64
+	for _, usage := range *history {
+		if usage.Month == month {
+			calls = usage.Calls
+		}
+	}
+	return statusOf(client, quota, month, calls, at), nil
+}
+
+func (u *QuotaUseCase) History(client string) (*[]domainQuota.Usage, error) {
+	return u.quotaRepository.GetUsage(client)
+}
+
+func (u *QuotaUseCase) Report(month string) ([]domainQuota.Status, error) {
+	start, err := time.Parse(domainQuota.MonthLayout, month)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("month must be YYYY-MM"), domainErrors.ValidationError)
+	}
+	usage, err := u.quotaRepository.GetMonthUsage(month)
+	if err != nil {
+		return nil, err
+	}
+	quotas, err := u.quotaRepository.GetQuotas()
+	if err != nil {
+		return nil, err
+	}
+	byClient := make(map[string]*domainQuota.Quota, len(*quotas))
+	for i := range *quotas {
+		byClient[(*quotas)[i].Client] = &(*quotas)[i]
+	}
+	res := make([]domainQuota.Status, len(*usage))
+	for i, entry := range *usage {
+		quota, ok := byClient[entry.Client]
+		if !ok {
+			quota = &domainQuota.Quota{Client: entry.Client}
+		}
+		res[i] = *statusOf(entry.Client, quota, month, entry.Calls, start)
+	}
+	return res, nil
+}
+
+func (u *QuotaUseCase) GetQuotas() (*[]domainQuota.Quota, error) {
+	return u.quotaRepository.GetQuotas()
+}
+
+func (u *QuotaUseCase) SaveQuota(quota *domainQuota.Quota) (*domainQuota.Quota, error) {
+	quota.Name = strings.TrimSpace(quota.Name)
+	if quota.Client == "" {
+		return nil, domainErrors.NewAppError(errors.New("an API key or client is required"), domainErrors.ValidationError)
+	}
+	if quota.SoftLimit < 0 || quota.HardLimit < 0 {
+		return nil, domainErrors.NewAppError(errors.New("limits cannot be negative"), domainErrors.ValidationError)
+	}
+	if quota.SoftLimit > 0 && quota.HardLimit > 0 && quota.SoftLimit > quota.HardLimit {
+		return nil, domainErrors.NewAppError(errors.New("the soft limit cannot be above the hard limit"), domainErrors.ValidationError)
+	}
+	u.Logger.Info("Saving API quota", zap.String("client", quota.Client), zap.Int64("softLimit", quota.SoftLimit), zap.Int64("hardLimit", quota.HardLimit))
+	return u.quotaRepository.SaveQuota(quota)
+}
+
+func (u *QuotaUseCase) DeleteQuota(id uuid.UUID) error {
+	u.Logger.Info("Deleting API quota", zap.String("id", id.String()))
+	return u.quotaRepository.DeleteQuota(id)
+}
+
+// quotaFor returns the client's quota, or an unlimited one when none is set.
+func (u *QuotaUseCase) quotaFor(client string) (*domainQuota.Quota, error) {
+	quota, err := u.quotaRepository.GetQuotaByClient(client)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+			return &domainQuota.Quota{Client: client}, nil
+		}
+		return nil, err
+	}
+	return quota, nil
+}
+
+func (u *QuotaUseCase) warnSoftLimit(status *domainQuota.Status) {
+	name := status.Name
+	if name == "" {
+		name = status.Client
+	}
+	message := notification.Message{
+		Role:    domainUser.RoleAdmin,
+		Subject: "API usage soft limit reached",
+		Body:    fmt.Sprintf("%s has made more than %d API calls in %s.", name, status.SoftLimit, status.Month),
+		Data: map[string]interface{}{
+			"client":    status.Client,
+			"month":     status.Month,
+			"softLimit": status.SoftLimit,
+			"hardLimit": status.HardLimit,
+		},
+	}
+	if err := u.notifier.Notify(message); err != nil {
+		u.Logger.Error("Error sending API quota warning", zap.Error(err), zap.String("client", status.Client))
+	}
+}
+
+func statusOf(client string, quota *domainQuota.Quota, month string, calls int64, at time.Time) *domainQuota.Status {
+	utc := at.UTC()
+	status := &domainQuota.Status{
+		Client:        client,
+		Name:          quota.Name,
+		Month:         month,
+		Calls:         calls,
+		SoftLimit:     quota.SoftLimit,
+		HardLimit:     quota.HardLimit,
+		OverSoftLimit: quota.SoftLimit > 0 && calls > quota.SoftLimit,
+		OverHardLimit: quota.HardLimit > 0 && calls > quota.HardLimit,
+		ResetsAt:      time.Date(utc.Year(), utc.Month()+1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if quota.HardLimit > 0 {
+		remaining := quota.HardLimit - calls
+		if remaining < 0 {
+			remaining = 0
+		}
+		status.Remaining = &remaining
+	}
+	return status
+}
@@ -0,0 +1,138 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainQuota "caregiver/src/domain/quota"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+// mockQuotaRepository keeps usage per client and month in memory
+type mockQuotaRepository struct {
+	quotas map[string]domainQuota.Quota
+	usage  map[string]int64
+}
+
+func (m *mockQuotaRepository) CountCall(client, month string, at time.Time) (int64, error) {
+	m.usage[client+"/"+month]++
+	return m.usage[client+"/"+month], nil
+}
+
+func (m *mockQuotaRepository) GetUsage(client string) (*[]domainQuota.Usage, error) {
+	res := []domainQuota.Usage{}
+	for key, calls := range m.usage {
+		if len(key) > len(client) && key[:len(client)] == client {
+			res = append(res, domainQuota.Usage{Client: client, Month: key[len(client)+1:], Calls: calls})
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockQuotaRepository) GetMonthUsage(month string) (*[]domainQuota.Usage, error) {
+	return &[]domainQuota.Usage{}, nil
+}
+
+func (m *mockQuotaRepository) GetQuotas() (*[]domainQuota.Quota, error) {
+	res := []domainQuota.Quota{}
+	for _, quota := range m.quotas {
+		res = append(res, quota)
+	}
+	return &res, nil
+}
+
+func (m *mockQuotaRepository) GetQuotaByClient(client string) (*domainQuota.Quota, error) {
+	quota, ok := m.quotas[client]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &quota, nil
+}
+
+func (m *mockQuotaRepository) SaveQuota(quota *domainQuota.Quota) (*domainQuota.Quota, error) {
+	m.quotas[quota.Client] = *quota
+	return quota, nil
+}
+
+func (m *mockQuotaRepository) DeleteQuota(id uuid.UUID) error {
+	return nil
+}
+
+// mockNotifier records sent messages
+type mockNotifier struct {
+	messages []notification.Message
+}
+
+func (m *mockNotifier) Notify(message notification.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func setupTestQuotaUseCase(t *testing.T) (IQuotaUseCase, *mockQuotaRepository, *mockNotifier) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	repo := &mockQuotaRepository{quotas: map[string]domainQuota.Quota{}, usage: map[string]int64{}}
+	notifier := &mockNotifier{}
+	return NewQuotaUseCase(repo, notifier, loggerInstance), repo, notifier
+}
+
+func TestCountCall(t *testing.T) {
+	useCase, _, notifier := setupTestQuotaUseCase(t)
+	client := domainQuota.ClientOf("agency-key")
+	if _, err := useCase.SaveQuota(&domainQuota.Quota{Client: client, Name: "Sunrise Home Care", SoftLimit: 2, HardLimit: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	at := time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+
+	var statuses []*domainQuota.Status
+	for i := 0; i < 4; i++ {
+		status, err := useCase.CountCall(client, at)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	if statuses[1].OverSoftLimit || !statuses[2].OverSoftLimit || !statuses[2].SoftLimitReached || statuses[3].SoftLimitReached {
+		t.Errorf("expected the third call to cross the soft limit, got %+v", statuses)
+	}
+	if statuses[2].OverHardLimit || !statuses[3].OverHardLimit || *statuses[3].Remaining != 0 {
+		t.Errorf("expected only the fourth call over the hard limit, got %+v", statuses)
+	}
+	if len(notifier.messages) != 1 {
+		t.Errorf("expected one soft limit warning, got %d", len(notifier.messages))
+	}
+	if !statuses[0].ResetsAt.Equal(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the quota to reset on the first of next month, got %v", statuses[0].ResetsAt)
+	}
+}
+
+func TestCountCallWithoutQuota(t *testing.T) {
+	useCase, _, notifier := setupTestQuotaUseCase(t)
+	status, err := useCase.CountCall(domainQuota.ClientOf("unmetered-key"), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.OverHardLimit || status.Remaining != nil || len(notifier.messages) != 0 {
+		t.Errorf("expected a client without a quota to be unlimited, got %+v", status)
+	}
+}
+
+func TestSaveQuotaValidation(t *testing.T) {
+	useCase, _, _ := setupTestQuotaUseCase(t)
+	for _, quota := range []domainQuota.Quota{
+		{SoftLimit: 10},
+		{Client: "key:abc", SoftLimit: -1},
+		{Client: "key:abc", SoftLimit: 20, HardLimit: 10},
+	} {
+		if _, err := useCase.SaveQuota(&quota); err == nil {
+			t.Errorf("expected %+v rejected", quota)
+		}
+	}
+}
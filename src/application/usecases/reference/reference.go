@@ -0,0 +1,208 @@
+package reference
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	domainReference "caregiver/src/domain/reference"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IReferenceUseCase interface {
+	// GetReference returns the reference data for the mobile app. Sections
+	// whose version is in known are left out.
+	GetReference(known []string) (*domainReference.Snapshot, error)
+	GetServices() (*[]domainReference.Service, error)
+	CreateService(service *domainReference.Service) (*domainReference.Service, error)
+	UpdateService(id uuid.UUID, updates map[string]interface{}) (*domainReference.Service, error)
+	DeleteService(id uuid.UUID) error
+	GetTaskTemplates(serviceID uuid.UUID) (*[]domainReference.TaskTemplate, error)
+	CreateTaskTemplate(template *domainReference.TaskTemplate) (*domainReference.TaskTemplate, error)
+	DeleteTaskTemplate(id uuid.UUID) error
+}
+
+// SettingsSource supplies the organization's locale settings.
+type SettingsSource interface {
+	GetSettings() (*domainLocale.Settings, error)
+}
+
+type ReferenceUseCase struct {
+	referenceRepository domainReference.IReferenceRepository
+	settings            SettingsSource
+	featureFlags        []string
+	Logger              *logger.Logger
+}
+
+type Option func(*ReferenceUseCase)
+
+// WithSettings includes the organization's locale in the reference data.
+func WithSettings(source SettingsSource) Option {
+	return func(u *ReferenceUseCase) {
+		u.settings = source
+	}
+}
+
+// WithFeatureFlags lists the features switched on for this deployment. Blank
+// entries are ignored.
+func WithFeatureFlags(flags ...string) Option {
+	return func(u *ReferenceUseCase) {
+		for _, flag := range flags {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				u.featureFlags = append(u.featureFlags, flag)
+			}
+		}
+		sort.Strings(u.featureFlags)
+	}
+}
+
+func NewReferenceUseCase(referenceRepository domainReference.IReferenceRepository, loggerInstance *logger.Logger, opts ...Option) IReferenceUseCase {
+	u := &ReferenceUseCase{
+		referenceRepository: referenceRepository,
+		featureFlags:        []string{},
+		Logger:              loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// GetReference builds the snapshot from active services and their task
+// templates, the visit statuses, the locale settings and the feature flags.
+func (u *ReferenceUseCase) GetReference(known []string) (*domainReference.Snapshot, error) {
+	services, err := u.referenceRepository.GetServices()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &domainReference.Snapshot{
+		Services:      []domainReference.Service{},
+		TaskTemplates: []domainReference.TaskTemplate{},
+		Statuses:      make([]domainReference.Status, len(domainSchedule.VisitStatuses)),
+		Settings:      map[string]string{},
+		FeatureFlags:  u.featureFlags,
+	}
+	var serviceIDs []uuid.UUID
+	for _, service := range *services {
+		if service.Active {
+			snapshot.Services = append(snapshot.Services, service)
+			serviceIDs = append(serviceIDs, service.ID)
+		}
+	}
+	if len(serviceIDs) > 0 {
+		templates, err := u.referenceRepository.GetTaskTemplates(serviceIDs)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.TaskTemplates = append(snapshot.TaskTemplates, *templates...)
+	}
+	for i, status := range domainSchedule.VisitStatuses {
+		snapshot.Statuses[i] = domainReference.Status{Name: status, Color: domainSchedule.StatusColors[status]}
+	}
+	if u.settings != nil {
+		settings, err := u.settings.GetSettings()
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Settings["Timezone"] = settings.Timezone
+		snapshot.Settings["Region"] = settings.Region
+		snapshot.Settings["SkipHolidays"] = strconv.FormatBool(settings.SkipHolidays)
+	}
+	snapshot.Seal()
+	if !snapshot.Current(known) {
+		snapshot.Omit(known)
+	}
+	return snapshot, nil
+}
+
+func (u *ReferenceUseCase) GetServices() (*[]domainReference.Service, error) {
+	return u.referenceRepository.GetServices()
+}
+
+func (u *ReferenceUseCase) CreateService(service *domainReference.Service) (*domainReference.Service, error) {
+	service.Name = strings.TrimSpace(service.Name)
+	if err := u.validateService(uuid.Nil, service.Name, service.DurationMinutes); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Creating service", zap.String("name", service.Name))
+	return u.referenceRepository.CreateService(service)
+}
+
+func (u *ReferenceUseCase) UpdateService(id uuid.UUID, updates map[string]interface{}) (*domainReference.Service, error) {
+	existing, err := u.referenceRepository.GetServiceByID(id)
+	if err != nil {
+		return nil, err
+	}
+	name := existing.Name
+	if v, ok := updates["name"].(string); ok {
+		name = strings.TrimSpace(v)
+		updates["name"] = name
+	}
+	duration := existing.DurationMinutes
+	if v, ok := updates["duration_minutes"].(int); ok {
+		duration = v
+	}
+	if err := u.validateService(id, name, duration); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Updating service", zap.String("id", id.String()))
+	return u.referenceRepository.UpdateService(id, updates)
+}
+
+func (u *ReferenceUseCase) DeleteService(id uuid.UUID) error {
+	u.Logger.Info("Deleting service", zap.String("id", id.String()))
+	return u.referenceRepository.DeleteService(id)
+}
+
+func (u *ReferenceUseCase) GetTaskTemplates(serviceID uuid.UUID) (*[]domainReference.TaskTemplate, error) {
+	if _, err := u.referenceRepository.GetServiceByID(serviceID); err != nil {
+		return nil, err
+	}
+	return u.referenceRepository.GetTaskTemplates([]uuid.UUID{serviceID})
+}
+
+func (u *ReferenceUseCase) CreateTaskTemplate(template *domainReference.TaskTemplate) (*domainReference.TaskTemplate, error) {
+	template.Title = strings.TrimSpace(template.Title)
+	if template.Title == "" {
+		return nil, domainErrors.NewAppError(errors.New("a task title is required"), domainErrors.ValidationError)
+	}
+	if _, err := u.referenceRepository.GetServiceByID(template.ServiceID); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Creating task template", zap.String("serviceID", template.ServiceID.String()), zap.String("title", template.Title))
+	return u.referenceRepository.CreateTaskTemplate(template)
+}
+
+func (u *ReferenceUseCase) DeleteTaskTemplate(id uuid.UUID) error {
+	u.Logger.Info("Deleting task template", zap.String("id", id.String()))
+	return u.referenceRepository.DeleteTaskTemplate(id)
+}
+
+// validateService checks a service's fields and that no other service has
+// the same name.
+func (u *ReferenceUseCase) validateService(id uuid.UUID, name string, durationMinutes int) error {
+	if name == "" {
+		return domainErrors.NewAppError(errors.New("a service name is required"), domainErrors.ValidationError)
+	}
+	if durationMinutes < 0 {
+		return domainErrors.NewAppError(errors.New("duration must not be negative"), domainErrors.ValidationError)
+	}
+	services, err := u.referenceRepository.GetServices()
+	if err != nil {
+		return err
+	}
+	for _, service := range *services {
+		if service.ID != id && strings.EqualFold(service.Name, name) {
+			return domainErrors.NewAppError(fmt.Errorf("a service named %q already exists", service.Name), domainErrors.Conflict)
+		}
+	}
+	return nil
+}
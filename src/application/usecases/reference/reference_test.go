@@ -0,0 +1,166 @@
+package reference
+
+import (
+	"errors"
+	"testing"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	domainReference "caregiver/src/domain/reference"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps services and task templates in memory
+type memoryRepository struct {
+	services  []domainReference.Service
+	templates []domainReference.TaskTemplate
+}
+
+func (m *memoryRepository) GetServices() (*[]domainReference.Service, error) {
+	res := append([]domainReference.Service{}, m.services...)
+	return &res, nil
+}
+
+func (m *memoryRepository) GetServiceByID(id uuid.UUID) (*domainReference.Service, error) {
+	for i := range m.services {
+		if m.services[i].ID == id {
+			service := m.services[i]
+			return &service, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *memoryRepository) CreateService(service *domainReference.Service) (*domainReference.Service, error) {
+	service.ID = uuid.New()
+	m.services = append(m.services, *service)
+	return service, nil
+}
+
+func (m *memoryRepository) UpdateService(id uuid.UUID, updates map[string]interface{}) (*domainReference.Service, error) {
+	for i := range m.services {
+		if m.services[i].ID == id {
+			if v, ok := updates["name"].(string); ok {
+				m.services[i].Name = v
+			}
+			if v, ok := updates["active"].(bool); ok {
+				m.services[i].Active = v
+			}
+		}
+	}
+	return m.GetServiceByID(id)
+}
+
+func (m *memoryRepository) DeleteService(id uuid.UUID) error {
+	return nil
+}
+
+func (m *memoryRepository) GetTaskTemplates(serviceIDs []uuid.UUID) (*[]domainReference.TaskTemplate, error) {
+	res := []domainReference.TaskTemplate{}
+	for _, template := range m.templates {
+		for _, id := range serviceIDs {
+			if template.ServiceID == id {
+				res = append(res, template)
+			}
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) CreateTaskTemplate(template *domainReference.TaskTemplate) (*domainReference.TaskTemplate, error) {
+	template.ID = uuid.New()
+	m.templates = append(m.templates, *template)
+	return template, nil
+}
+
+func (m *memoryRepository) DeleteTaskTemplate(id uuid.UUID) error {
+	return nil
+}
+
+type fixedSettings struct {
+	settings domainLocale.Settings
+}
+
+func (f *fixedSettings) GetSettings() (*domainLocale.Settings, error) {
+	settings := f.settings
+	return &settings, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestReferenceSnapshot(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	repo := &memoryRepository{}
+	settings := &fixedSettings{settings: domainLocale.Settings{Timezone: "Europe/London", Region: "GB"}}
+	useCase := NewReferenceUseCase(repo, loggerInstance, WithSettings(settings), WithFeatureFlags("planner", " ", "kiosk"))
+
+	care, err := useCase.CreateService(&domainReference.Service{Name: " Personal care ", DurationMinutes: 60, Active: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	retired, _ := useCase.CreateService(&domainReference.Service{Name: "Laundry", Active: false})
+	if _, err := useCase.CreateService(&domainReference.Service{Name: "personal CARE"}); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a duplicate name to conflict, got %v", err)
+	}
+	if _, err := useCase.CreateTaskTemplate(&domainReference.TaskTemplate{ServiceID: care.ID, Title: "Bathing"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.CreateTaskTemplate(&domainReference.TaskTemplate{ServiceID: retired.ID, Title: "Ironing"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("Lists active services and their templates", func(t *testing.T) {
+		snapshot, err := useCase.GetReference(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(snapshot.Services) != 1 || snapshot.Services[0].Name != "Personal care" {
+			t.Errorf("expected only the active service, got %+v", snapshot.Services)
+		}
+		if len(snapshot.TaskTemplates) != 1 || snapshot.TaskTemplates[0].Title != "Bathing" {
+			t.Errorf("expected only the active service's templates, got %+v", snapshot.TaskTemplates)
+		}
+		if len(snapshot.FeatureFlags) != 2 || snapshot.FeatureFlags[0] != "kiosk" {
+			t.Errorf("unexpected feature flags %v", snapshot.FeatureFlags)
+		}
+		if snapshot.Settings["Timezone"] != "Europe/London" || len(snapshot.Statuses) == 0 {
+			t.Errorf("expected settings and statuses, got %+v", snapshot)
+		}
+	})
+
+	t.Run("Leaves out sections the client has", func(t *testing.T) {
+		first, _ := useCase.GetReference(nil)
+		if _, err := useCase.UpdateService(care.ID, map[string]interface{}{"name": "Personal care plus"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		known := []string{first.Version}
+		for _, version := range first.Versions {
+			known = append(known, version)
+		}
+		second, err := useCase.GetReference(known)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if second.Current(known) || second.Services == nil || second.Services[0].Name != "Personal care plus" {
+			t.Errorf("expected the renamed service to be sent, got %+v", second)
+		}
+		if len(second.Unchanged) != 4 || second.Statuses != nil {
+			t.Errorf("expected the other sections to be left out, got %v", second.Unchanged)
+		}
+		third, _ := useCase.GetReference([]string{second.Version})
+		if !third.Current([]string{second.Version}) {
+			t.Error("expected an unchanged snapshot to be current")
+		}
+	})
+}
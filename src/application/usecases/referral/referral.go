@@ -0,0 +1,266 @@
+package referral
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReferral "caregiver/src/domain/referral"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RetentionWindow is how recently a converted client must have had a
+// completed visit to count as retained.
+const RetentionWindow = 30 * 24 * time.Hour
+
+const monthLayout = "2006-01"
+
+var sourceTypes = map[string]bool{
+	domainReferral.TypeHospital:    true,
+	domainReferral.TypePhysician:   true,
+	domainReferral.TypeWebsite:     true,
+	domainReferral.TypeWordOfMouth: true,
+	domainReferral.TypeAgency:      true,
+	domainReferral.TypeOther:       true,
+}
+
+type IReferralUseCase interface {
+	CreateSource(newSource *domainReferral.Source) (*domainReferral.Source, error)
+	GetSourceByID(id uuid.UUID) (*domainReferral.Source, error)
+	GetSources() (*[]domainReferral.Source, error)
+	UpdateSource(id uuid.UUID, updates map[string]interface{}) (*domainReferral.Source, error)
+	DeleteSource(id uuid.UUID) error
+	Attribute(clientID uuid.UUID, sourceID *uuid.UUID) (*domainUser.User, error)
+	Report(from, to, asOf time.Time) (*domainReferral.Report, error)
+}
+
+type ReferralUseCase struct {
+	referralRepository domainReferral.IReferralRepository
+	userRepository     domainUser.IUserRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	Logger             *logger.Logger
+}
+
+func NewReferralUseCase(referralRepository domainReferral.IReferralRepository, userRepository domainUser.IUserRepository, scheduleRepository domainSchedule.IScheduleRepository, loggerInstance *logger.Logger) IReferralUseCase {
+	return &ReferralUseCase{
+		referralRepository: referralRepository,
+		userRepository:     userRepository,
+		scheduleRepository: scheduleRepository,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *ReferralUseCase) CreateSource(newSource *domainReferral.Source) (*domainReferral.Source, error) {
+	u.Logger.Info("Creating referral source", zap.String("name", newSource.Name))
+	if err := validateSource(newSource); err != nil {
+		return nil, err
+	}
+	newSource.ID = uuid.New()
+	newSource.Active = true
+	return u.referralRepository.CreateSource(newSource)
+}
+
+func (u *ReferralUseCase) GetSourceByID(id uuid.UUID) (*domainReferral.Source, error) {
+	return u.referralRepository.GetSourceByID(id)
+}
+
+func (u *ReferralUseCase) GetSources() (*[]domainReferral.Source, error) {
+	return u.referralRepository.GetSources()
+}
+
+func (u *ReferralUseCase) UpdateSource(id uuid.UUID, updates map[string]interface{}) (*domainReferral.Source, error) {
+	u.Logger.Info("Updating referral source", zap.String("id", id.String()))
+	existing, err := u.referralRepository.GetSourceByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["name"].(string); ok {
+		candidate.Name = v
+	}
+	if v, ok := updates["type"].(string); ok {
+		candidate.Type = v
+	}
+	if err := validateSource(&candidate); err != nil {
+		return nil, err
+	}
+	return u.referralRepository.UpdateSource(id, updates)
+}
+
+// DeleteSource removes a source no client is attributed to. Sources with
+// referrals are deactivated instead so that the report keeps them.
+func (u *ReferralUseCase) DeleteSource(id uuid.UUID) error {
+	u.Logger.Info("Deleting referral source", zap.String("id", id.String()))
+	users, err := u.userRepository.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, user := range *users {
+		if user.ReferralSourceID != nil && *user.ReferralSourceID == id {
+			return domainErrors.NewAppError(errors.New("clients are attributed to this referral source; deactivate it instead"), domainErrors.Conflict)
+		}
+	}
+	return u.referralRepository.DeleteSource(id)
+}
+
+// Attribute sets or clears the referral source of a client.
+func (u *ReferralUseCase) Attribute(clientID uuid.UUID, sourceID *uuid.UUID) (*domainUser.User, error) {
+	u.Logger.Info("Attributing client to referral source", zap.String("clientUserID", clientID.String()))
+	client, err := u.userRepository.GetByID(clientID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("client not found"), domainErrors.NotFound)
+	}
+	if client.Role != domainUser.RoleClient {
+		return nil, domainErrors.NewAppError(errors.New("only clients are attributed to a referral source"), domainErrors.ValidationError)
+	}
+	var value interface{}
+	if sourceID != nil {
+		if _, err := u.referralRepository.GetSourceByID(*sourceID); err != nil {
+			return nil, err
+		}
+		value = *sourceID
+	}
+	return u.userRepository.Update(clientID, map[string]interface{}{"referral_source_id": value})
+}
+
+// Report counts the clients referred in [from, to) by source and month, how
+// many of them converted to a completed visit by asOf, and how many of those
+// were still being served within RetentionWindow of asOf.
+func (u *ReferralUseCase) Report(from, to, asOf time.Time) (*domainReferral.Report, error) {
+	u.Logger.Info("Building referral report", zap.Time("from", from), zap.Time("to", to))
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+	sources, err := u.referralRepository.GetSources()
+	if err != nil {
+		return nil, err
+	}
+	users, err := u.userRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var clients []domainUser.User
+	var clientIDs []uuid.UUID
+	for _, user := range *users {
+		if user.Role == domainUser.RoleClient && !user.CreatedAt.Before(from) && user.CreatedAt.Before(to) {
+			clients = append(clients, user)
+			clientIDs = append(clientIDs, user.ID)
+		}
+	}
+	visits, err := u.scheduleRepository.GetCompletedSchedulesBetween(from, asOf, clientIDs)
+	if err != nil {
+		return nil, err
+	}
+	converted := make(map[uuid.UUID]bool)
+	retained := make(map[uuid.UUID]bool)
+	for _, visit := range *visits {
+		converted[visit.ClientUserID] = true
+		if visit.CheckoutTime != nil && !visit.CheckoutTime.Before(asOf.Add(-RetentionWindow)) {
+			retained[visit.ClientUserID] = true
+		}
+	}
+
+	months := monthsBetween(from, to)
+	rows := make(map[uuid.UUID]*domainReferral.SourceStats)
+	var order []*domainReferral.SourceStats
+	for i := range *sources {
+		source := &(*sources)[i]
+		sourceID := source.ID
+		row := newRow(&sourceID, source.Name, source.Type, months)
+		rows[source.ID] = row
+		order = append(order, row)
+	}
+	var unattributed *domainReferral.SourceStats
+	for _, client := range clients {
+		var row *domainReferral.SourceStats
+		if client.ReferralSourceID != nil {
+			row = rows[*client.ReferralSourceID]
+		}
+		if row == nil {
+			if unattributed == nil {
+				unattributed = newRow(nil, "Unattributed", "", months)
+			}
+			row = unattributed
+		}
+		index := monthIndex(months, client.CreatedAt)
+		for _, stats := range []*domainReferral.PeriodStats{&row.Totals, &row.Periods[index]} {
+			stats.Referred++
+			if converted[client.ID] {
+				stats.Converted++
+			}
+			if retained[client.ID] {
+				stats.Retained++
+			}
+		}
+	}
+	if unattributed != nil {
+		order = append(order, unattributed)
+	}
+
+	report := &domainReferral.Report{From: from, To: to, AsOf: asOf, Sources: make([]domainReferral.SourceStats, 0, len(order))}
+	for _, row := range order {
+		setRates(&row.Totals)
+		for i := range row.Periods {
+			setRates(&row.Periods[i])
+		}
+		report.Sources = append(report.Sources, *row)
+	}
+	sort.SliceStable(report.Sources, func(i, j int) bool {
+		return report.Sources[i].Totals.Referred > report.Sources[j].Totals.Referred
+	})
+	return report, nil
+}
+
+func newRow(sourceID *uuid.UUID, name, sourceType string, months []time.Time) *domainReferral.SourceStats {
+	row := &domainReferral.SourceStats{SourceID: sourceID, SourceName: name, SourceType: sourceType, Periods: make([]domainReferral.PeriodStats, len(months))}
+	for i, month := range months {
+		row.Periods[i].Month = month.Format(monthLayout)
+	}
+	return row
+}
+
+// monthsBetween returns the first instant of each calendar month touching
+// [from, to), in from's location.
+func monthsBetween(from, to time.Time) []time.Time {
+	var months []time.Time
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()); month.Before(to); month = month.AddDate(0, 1, 0) {
+		months = append(months, month)
+	}
+	return months
+}
+
+func monthIndex(months []time.Time, at time.Time) int {
+	at = at.In(months[0].Location())
+	for i := len(months) - 1; i > 0; i-- {
+		if !at.Before(months[i]) {
+			return i
+		}
+	}
+	return 0
+}
+
+func setRates(stats *domainReferral.PeriodStats) {
+	if stats.Referred > 0 {
+		stats.ConversionRate = float64(stats.Converted) / float64(stats.Referred)
+	}
+	if stats.Converted > 0 {
+		stats.RetentionRate = float64(stats.Retained) / float64(stats.Converted)
+	}
+}
+
+func validateSource(s *domainReferral.Source) error {
+	if strings.TrimSpace(s.Name) == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	if !sourceTypes[s.Type] {
+		return domainErrors.NewAppError(errors.New("type must be 'hospital', 'physician', 'website', 'word_of_mouth', 'agency' or 'other'"), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package referral
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReferral "caregiver/src/domain/referral"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockReferralRepository keeps sources in memory
+type mockReferralRepository struct {
+	domainReferral.IReferralRepository
+	sources []domainReferral.Source
+	deleted []uuid.UUID
+}
+
+func (m *mockReferralRepository) CreateSource(newSource *domainReferral.Source) (*domainReferral.Source, error) {
+	m.sources = append(m.sources, *newSource)
+	return newSource, nil
+}
+
+func (m *mockReferralRepository) GetSourceByID(id uuid.UUID) (*domainReferral.Source, error) {
+	for _, source := range m.sources {
+		if source.ID == id {
+			return &source, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockReferralRepository) GetSources() (*[]domainReferral.Source, error) {
+	return &m.sources, nil
+}
+
+func (m *mockReferralRepository) DeleteSource(id uuid.UUID) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+// mockUserRepository returns a fixed set of users
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users []domainUser.User
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) {
+	return &m.users, nil
+}
+
+// mockScheduleRepository returns completed visits checked out in the range
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	visits []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetCompletedSchedulesBetween(from, to time.Time, clientUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, visit := range m.visits {
+		if !visit.CheckoutTime.Before(from) && visit.CheckoutTime.Before(to) {
+			res = append(res, visit)
+		}
+	}
+	return &res, nil
+}
+
+func setupTestReferralUseCase(t *testing.T) (IReferralUseCase, *mockReferralRepository, *mockUserRepository, *mockScheduleRepository) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	referrals := &mockReferralRepository{}
+	users := &mockUserRepository{}
+	schedules := &mockScheduleRepository{}
+	return NewReferralUseCase(referrals, users, schedules, loggerInstance), referrals, users, schedules
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func completedVisit(clientID uuid.UUID, checkout time.Time) domainSchedule.Schedule {
+	return domainSchedule.Schedule{ID: uuid.New(), ClientUserID: clientID, VisitStatus: "completed", CheckoutTime: &checkout}
+}
+
+func TestReport(t *testing.T) {
+	useCase, _, users, schedules := setupTestReferralUseCase(t)
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	if _, err := useCase.CreateSource(&domainReferral.Source{Name: "General Hospital", Type: "clinic"}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an unknown type to be rejected, got %v", err)
+	}
+	hospital, err := useCase.CreateSource(&domainReferral.Source{Name: "General Hospital", Type: domainReferral.TypeHospital})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	website, _ := useCase.CreateSource(&domainReferral.Source{Name: "Website", Type: domainReferral.TypeWebsite})
+
+	retained := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, ReferralSourceID: &hospital.ID, CreatedAt: from.AddDate(0, 0, 3)}
+	lapsed := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, ReferralSourceID: &hospital.ID, CreatedAt: from.AddDate(0, 1, 2)}
+	neverServed := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, ReferralSourceID: &hospital.ID, CreatedAt: from.AddDate(0, 1, 5)}
+	walkIn := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, CreatedAt: from.AddDate(0, 0, 10)}
+	tooLate := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, ReferralSourceID: &hospital.ID, CreatedAt: to}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, CreatedAt: from}
+	users.users = []domainUser.User{retained, lapsed, neverServed, walkIn, tooLate, caregiver}
+	schedules.visits = []domainSchedule.Schedule{
+		completedVisit(retained.ID, from.AddDate(0, 0, 5)),
+		completedVisit(retained.ID, asOf.AddDate(0, 0, -3)),
+		completedVisit(lapsed.ID, from.AddDate(0, 1, 10)),
+		completedVisit(walkIn.ID, asOf.AddDate(0, 0, -1)),
+	}
+
+	if _, err := useCase.Report(to, from, asOf); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an inverted range to be rejected, got %v", err)
+	}
+	report, err := useCase.Report(from, to, asOf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Sources) != 3 {
+		t.Fatalf("expected the hospital, unattributed and website rows, got %+v", report.Sources)
+	}
+	row := report.Sources[0]
+	if row.SourceID == nil || *row.SourceID != hospital.ID {
+		t.Fatalf("expected the hospital first, got %+v", row)
+	}
+	if row.Totals.Referred != 3 || row.Totals.Converted != 2 || row.Totals.Retained != 1 || row.Totals.RetentionRate != 0.5 {
+		t.Errorf("unexpected hospital totals %+v", row.Totals)
+	}
+	if len(row.Periods) != 2 || row.Periods[0].Month != "2025-01" || row.Periods[0].Referred != 1 || row.Periods[1].Referred != 2 || row.Periods[1].Converted != 1 {
+		t.Errorf("unexpected hospital periods %+v", row.Periods)
+	}
+	if unattributed := report.Sources[1]; unattributed.SourceID != nil || unattributed.Totals.Retained != 1 {
+		t.Errorf("expected the walk-in unattributed and retained, got %+v", unattributed)
+	}
+	if empty := report.Sources[2]; *empty.SourceID != website.ID || empty.Totals.Referred != 0 || empty.Totals.ConversionRate != 0 {
+		t.Errorf("expected an empty website row, got %+v", empty)
+	}
+}
+
+func TestDeleteSource(t *testing.T) {
+	useCase, referrals, users, _ := setupTestReferralUseCase(t)
+	used, _ := useCase.CreateSource(&domainReferral.Source{Name: "Dr. Patel", Type: domainReferral.TypePhysician})
+	unused, _ := useCase.CreateSource(&domainReferral.Source{Name: "Flyer", Type: domainReferral.TypeOther})
+	users.users = []domainUser.User{{ID: uuid.New(), Role: domainUser.RoleClient, ReferralSourceID: &used.ID}}
+
+	if err := useCase.DeleteSource(used.ID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a source with referrals to be kept, got %v", err)
+	}
+	if err := useCase.DeleteSource(unused.ID); err != nil || len(referrals.deleted) != 1 {
+		t.Errorf("expected the unused source to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,368 @@
+package reminder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	domainReminder "caregiver/src/domain/reminder"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxOffsetMinutes is the earliest a reminder can go out: one week ahead.
+	MaxOffsetMinutes = 7 * 24 * 60
+	// MaxOffsets caps the number of reminders per visit.
+	MaxOffsets = 5
+)
+
+// SweepResult counts the reminders a sweep sent and the ones it recorded as
+// skipped.
+type SweepResult struct {
+	Sent    int
+	Skipped int
+}
+
+type IReminderUseCase interface {
+	GetDefaults() (*domainReminder.Settings, error)
+	SetDefaults(offsetsMinutes []int) (*domainReminder.Settings, error)
+	GetPlan(scheduleID uuid.UUID) (*domainReminder.Plan, error)
+	SetOverride(scheduleID uuid.UUID, offsetsMinutes []int) (*domainReminder.Plan, error)
+	ClearOverride(scheduleID uuid.UUID) (*domainReminder.Plan, error)
+	GetPreference(userID uuid.UUID) (*domainReminder.Preference, error)
+	SetPreference(userID uuid.UUID, enabled bool) (*domainReminder.Preference, error)
+	Sweep(now time.Time) (*SweepResult, error)
+}
+
+// CalendarSource supplies the organization's time zone and holidays.
+type CalendarSource interface {
+	Calendar(from, to time.Time) (*domainLocale.Calendar, error)
+}
+
+type ReminderUseCase struct {
+	reminderRepository domainReminder.IReminderRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	notifier           notification.INotifier
+	calendar           CalendarSource
+	Logger             *logger.Logger
+}
+
+type Option func(*ReminderUseCase)
+
+// WithCalendar times whole-day offsets by the organization's local days, so
+// a reminder "a day before" keeps its wall-clock time across DST changes,
+// and words reminders in local time with any holiday the visit falls on.
+func WithCalendar(source CalendarSource) Option {
+	return func(u *ReminderUseCase) {
+		u.calendar = source
+	}
+}
+
+func NewReminderUseCase(reminderRepository domainReminder.IReminderRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, notifier notification.INotifier, loggerInstance *logger.Logger, opts ...Option) IReminderUseCase {
+	useCase := &ReminderUseCase{
+		reminderRepository: reminderRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		notifier:           notifier,
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+// GetDefaults returns the organization's default offsets, falling back to
+// DefaultOffsetsMinutes when none have been saved.
+func (u *ReminderUseCase) GetDefaults() (*domainReminder.Settings, error) {
+	settings, err := u.reminderRepository.GetSettings()
+	if err != nil {
+		if isNotFound(err) {
+			return &domainReminder.Settings{OffsetsMinutes: domainReminder.DefaultOffsetsMinutes}, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (u *ReminderUseCase) SetDefaults(offsetsMinutes []int) (*domainReminder.Settings, error) {
+	u.Logger.Info("Setting default reminder offsets", zap.Ints("offsetsMinutes", offsetsMinutes))
+	offsets, err := validateOffsets(offsetsMinutes)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := u.GetDefaults()
+	if err != nil {
+		return nil, err
+	}
+	settings.OffsetsMinutes = offsets
+	return u.reminderRepository.SaveSettings(settings)
+}
+
+// GetPlan returns the offsets that apply to a schedule, whether they come
+// from an override or the defaults, and the reminders handled so far.
+func (u *ReminderUseCase) GetPlan(scheduleID uuid.UUID) (*domainReminder.Plan, error) {
+	if _, err := u.scheduleRepository.GetScheduleByID(scheduleID); err != nil {
+		return nil, err
+	}
+	defaults, err := u.GetDefaults()
+	if err != nil {
+		return nil, err
+	}
+	plan := &domainReminder.Plan{ScheduleID: scheduleID, Source: domainReminder.SourceDefault, OffsetsMinutes: defaults.OffsetsMinutes}
+	override, err := u.reminderRepository.GetOverride(scheduleID)
+	if err == nil {
+		plan.Source = domainReminder.SourceOverride
+		plan.OffsetsMinutes = override.OffsetsMinutes
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+	deliveries, err := u.reminderRepository.GetDeliveries([]uuid.UUID{scheduleID})
+	if err != nil {
+		return nil, err
+	}
+	plan.Deliveries = *deliveries
+	return plan, nil
+}
+
+// SetOverride replaces the default offsets for one schedule. An empty list
+// turns its reminders off.
+func (u *ReminderUseCase) SetOverride(scheduleID uuid.UUID, offsetsMinutes []int) (*domainReminder.Plan, error) {
+	u.Logger.Info("Setting schedule reminder override", zap.String("scheduleID", scheduleID.String()), zap.Ints("offsetsMinutes", offsetsMinutes))
+	if _, err := u.scheduleRepository.GetScheduleByID(scheduleID); err != nil {
+		return nil, err
+	}
+	offsets, err := validateOffsets(offsetsMinutes)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.reminderRepository.SaveOverride(&domainReminder.Override{ScheduleID: scheduleID, OffsetsMinutes: offsets}); err != nil {
+		return nil, err
+	}
+	return u.GetPlan(scheduleID)
+}
+
+func (u *ReminderUseCase) ClearOverride(scheduleID uuid.UUID) (*domainReminder.Plan, error) {
+	u.Logger.Info("Clearing schedule reminder override", zap.String("scheduleID", scheduleID.String()))
+	if err := u.reminderRepository.DeleteOverride(scheduleID); err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	return u.GetPlan(scheduleID)
+}
+
+func (u *ReminderUseCase) GetPreference(userID uuid.UUID) (*domainReminder.Preference, error) {
+	if _, err := u.userRepository.GetByID(userID); err != nil {
+		return nil, domainErrors.NewAppError(errors.New("user not found"), domainErrors.NotFound)
+	}
+	preference, err := u.reminderRepository.GetPreference(userID)
+	if err != nil {
+		if isNotFound(err) {
+			return &domainReminder.Preference{UserID: userID, Enabled: true}, nil
+		}
+		return nil, err
+	}
+	return preference, nil
+}
+
+func (u *ReminderUseCase) SetPreference(userID uuid.UUID, enabled bool) (*domainReminder.Preference, error) {
+	u.Logger.Info("Setting reminder preference", zap.String("userID", userID.String()), zap.Bool("enabled", enabled))
+	if _, err := u.userRepository.GetByID(userID); err != nil {
+		return nil, domainErrors.NewAppError(errors.New("user not found"), domainErrors.NotFound)
+	}
+	return u.reminderRepository.SavePreference(&domainReminder.Preference{UserID: userID, Enabled: enabled})
+}
+
+// Sweep sends the reminders that have come due for upcoming visits to the
+// assigned caregiver and the client. When several offsets are due at once,
+// e.g. for a visit booked at short notice, only the closest one is sent and
+// the others are recorded as skipped.
+func (u *ReminderUseCase) Sweep(now time.Time) (*SweepResult, error) {
+	result := &SweepResult{}
+
+	// A day-based offset reaches a little further than its minutes when the
+	// clocks go back in between.
+	horizon := now.Add(MaxOffsetMinutes*time.Minute + time.Hour)
+	schedules, err := u.scheduleRepository.GetActiveSchedulesBetween(now, horizon, nil)
+	if err != nil {
+		return nil, err
+	}
+	var upcoming []domainSchedule.Schedule
+	var scheduleIDs, recipientIDs []uuid.UUID
+	for _, s := range *schedules {
+		if s.VisitStatus != "upcoming" || !s.ScheduledSlot.From.After(now) {
+			continue
+		}
+		upcoming = append(upcoming, s)
+		scheduleIDs = append(scheduleIDs, s.ID)
+		recipientIDs = append(recipientIDs, recipients(&s)...)
+	}
+	if len(upcoming) == 0 {
+		return result, nil
+	}
+
+	defaults, err := u.GetDefaults()
+	if err != nil {
+		return nil, err
+	}
+	calendar := u.calendarFor(now, horizon)
+	overrides, err := u.reminderRepository.GetOverrides(scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	offsetsBySchedule := make(map[uuid.UUID][]int, len(*overrides))
+	for _, o := range *overrides {
+		offsetsBySchedule[o.ScheduleID] = o.OffsetsMinutes
+	}
+	deliveries, err := u.reminderRepository.GetDeliveries(scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	handled := make(map[string]bool, len(*deliveries))
+	for _, d := range *deliveries {
+		handled[deliveryKey(d.ScheduleID, d.RecipientUserID, d.OffsetMinutes)] = true
+	}
+	preferences, err := u.reminderRepository.GetPreferences(recipientIDs)
+	if err != nil {
+		return nil, err
+	}
+	optedOut := make(map[uuid.UUID]bool)
+	for _, p := range *preferences {
+		optedOut[p.UserID] = !p.Enabled
+	}
+
+	var records []domainReminder.Delivery
+	for i := range upcoming {
+		schedule := &upcoming[i]
+		offsets, ok := offsetsBySchedule[schedule.ID]
+		if !ok {
+			offsets = defaults.OffsetsMinutes
+		}
+		for _, recipient := range recipients(schedule) {
+			// Offsets run from furthest to closest. Due offsets after the last
+			// handled one are pending, and the closest of them is sent.
+			var pending []int
+			for _, offset := range offsets {
+				if dueAt(calendar, schedule.ScheduledSlot.From, offset).After(now) {
+					continue
+				}
+				if handled[deliveryKey(schedule.ID, recipient, offset)] {
+					pending = nil
+					continue
+				}
+				pending = append(pending, offset)
+			}
+			for j, offset := range pending {
+				skipped := j < len(pending)-1 || optedOut[recipient]
+				if !skipped {
+					if err := u.send(calendar, schedule, recipient, now); err != nil {
+						u.Logger.Error("Error sending visit reminder", zap.Error(err), zap.String("scheduleID", schedule.ID.String()), zap.String("recipientUserID", recipient.String()))
+						continue
+					}
+					result.Sent++
+				} else {
+					result.Skipped++
+				}
+				records = append(records, domainReminder.Delivery{
+					ID:              uuid.New(),
+					ScheduleID:      schedule.ID,
+					OffsetMinutes:   offset,
+					RecipientUserID: recipient,
+					Skipped:         skipped,
+					SentAt:          now,
+				})
+			}
+		}
+	}
+	if err := u.reminderRepository.CreateDeliveries(records); err != nil {
+		return nil, err
+	}
+	if result.Sent > 0 || result.Skipped > 0 {
+		u.Logger.Info("Reminder sweep completed", zap.Int("sent", result.Sent), zap.Int("skipped", result.Skipped))
+	}
+	return result, nil
+}
+
+func (u *ReminderUseCase) send(calendar *domainLocale.Calendar, schedule *domainSchedule.Schedule, recipient uuid.UUID, now time.Time) error {
+	until := schedule.ScheduledSlot.From.Sub(now).Round(time.Minute)
+	body := fmt.Sprintf("%s visit starts at %s (in %s).", schedule.ServiceName, calendar.Local(schedule.ScheduledSlot.From).Format(time.RFC3339), until)
+	data := map[string]interface{}{
+		"scheduleID": schedule.ID.String(),
+		"from":       schedule.ScheduledSlot.From,
+	}
+	if holiday, ok := calendar.HolidayOn(schedule.ScheduledSlot.From); ok {
+		body += fmt.Sprintf(" The visit falls on %s.", holiday.Name)
+		data["holiday"] = holiday.Name
+	}
+	return u.notifier.Notify(notification.Message{
+		UserID:  &recipient,
+		Subject: "Visit reminder",
+		Body:    body,
+		Data:    data,
+	})
+}
+
+// calendarFor returns the organization's calendar, or UTC days without
+// holidays when none is configured or it cannot be read.
+func (u *ReminderUseCase) calendarFor(from, to time.Time) *domainLocale.Calendar {
+	if u.calendar == nil {
+		return domainLocale.UTCCalendar()
+	}
+	calendar, err := u.calendar.Calendar(from, to)
+	if err != nil {
+		u.Logger.Error("Error getting organization calendar for reminders", zap.Error(err))
+		return domainLocale.UTCCalendar()
+	}
+	return calendar
+}
+
+// dueAt is when the reminder offset minutes before from goes out. Whole-day
+// offsets count local days, so they keep the visit's wall-clock time when
+// the clocks change in between.
+func dueAt(calendar *domainLocale.Calendar, from time.Time, offset int) time.Time {
+	if offset%(24*60) == 0 {
+		return calendar.Local(from).AddDate(0, 0, -offset/(24*60))
+	}
+	return from.Add(-time.Duration(offset) * time.Minute)
+}
+
+// recipients are the people expecting the visit: its caregiver and client.
+func recipients(schedule *domainSchedule.Schedule) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, id := range []uuid.UUID{schedule.AssignedUserID, schedule.ClientUserID} {
+		if id != uuid.Nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func deliveryKey(scheduleID, recipientUserID uuid.UUID, offset int) string {
+	return fmt.Sprintf("%s/%s/%d", scheduleID, recipientUserID, offset)
+}
+
+func validateOffsets(offsets []int) ([]int, error) {
+	normalized := domainReminder.NormalizeOffsets(offsets)
+	if len(normalized) > MaxOffsets {
+		return nil, domainErrors.NewAppError(fmt.Errorf("at most %d reminder offsets are allowed", MaxOffsets), domainErrors.ValidationError)
+	}
+	for _, offset := range normalized {
+		if offset < 1 || offset > MaxOffsetMinutes {
+			return nil, domainErrors.NewAppError(fmt.Errorf("reminder offsets must be between 1 and %d minutes", MaxOffsetMinutes), domainErrors.ValidationError)
+		}
+	}
+	return normalized, nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
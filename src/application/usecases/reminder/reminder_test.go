@@ -0,0 +1,356 @@
+package reminder
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	domainReminder "caregiver/src/domain/reminder"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+// mockReminderRepository is an in-memory implementation of IReminderRepository
+type mockReminderRepository struct {
+	settings    *domainReminder.Settings
+	overrides   map[uuid.UUID]domainReminder.Override
+	preferences map[uuid.UUID]domainReminder.Preference
+	deliveries  []domainReminder.Delivery
+}
+
+func newMockReminderRepository() *mockReminderRepository {
+	return &mockReminderRepository{
+		overrides:   map[uuid.UUID]domainReminder.Override{},
+		preferences: map[uuid.UUID]domainReminder.Preference{},
+	}
+}
+
+func notFound() error {
+	return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockReminderRepository) GetSettings() (*domainReminder.Settings, error) {
+	if m.settings == nil {
+		return nil, notFound()
+	}
+	return m.settings, nil
+}
+
+func (m *mockReminderRepository) SaveSettings(settings *domainReminder.Settings) (*domainReminder.Settings, error) {
+	m.settings = settings
+	return settings, nil
+}
+
+func (m *mockReminderRepository) GetOverride(scheduleID uuid.UUID) (*domainReminder.Override, error) {
+	override, ok := m.overrides[scheduleID]
+	if !ok {
+		return nil, notFound()
+	}
+	return &override, nil
+}
+
+func (m *mockReminderRepository) GetOverrides(scheduleIDs []uuid.UUID) (*[]domainReminder.Override, error) {
+	res := []domainReminder.Override{}
+	for _, id := range scheduleIDs {
+		if override, ok := m.overrides[id]; ok {
+			res = append(res, override)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockReminderRepository) SaveOverride(override *domainReminder.Override) (*domainReminder.Override, error) {
+	m.overrides[override.ScheduleID] = *override
+	return override, nil
+}
+
+func (m *mockReminderRepository) DeleteOverride(scheduleID uuid.UUID) error {
+	delete(m.overrides, scheduleID)
+	return nil
+}
+
+func (m *mockReminderRepository) GetPreference(userID uuid.UUID) (*domainReminder.Preference, error) {
+	preference, ok := m.preferences[userID]
+	if !ok {
+		return nil, notFound()
+	}
+	return &preference, nil
+}
+
+func (m *mockReminderRepository) GetPreferences(userIDs []uuid.UUID) (*[]domainReminder.Preference, error) {
+	res := []domainReminder.Preference{}
+	for _, id := range userIDs {
+		if preference, ok := m.preferences[id]; ok {
+			res = append(res, preference)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockReminderRepository) SavePreference(preference *domainReminder.Preference) (*domainReminder.Preference, error) {
+	m.preferences[preference.UserID] = *preference
+	return preference, nil
+}
+
+func (m *mockReminderRepository) CreateDeliveries(deliveries []domainReminder.Delivery) error {
+	m.deliveries = append(m.deliveries, deliveries...)
+	return nil
+}
+
+func (m *mockReminderRepository) GetDeliveries(scheduleIDs []uuid.UUID) (*[]domainReminder.Delivery, error) {
+	res := []domainReminder.Delivery{}
+	for _, d := range m.deliveries {
+		for _, id := range scheduleIDs {
+			if d.ScheduleID == id {
+				res = append(res, d)
+			}
+		}
+	}
+	return &res, nil
+}
+
+// mockScheduleRepository serves a fixed set of schedules
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, notFound()
+}
+
+func (m *mockScheduleRepository) GetActiveSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		if s.ScheduledSlot.From.Before(to) && s.ScheduledSlot.To.After(from) {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+// mockUserRepository knows every user
+type mockUserRepository struct {
+	domainUser.IUserRepository
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("user not found")
+	}
+	return &domainUser.User{ID: id}, nil
+}
+
+// mockNotifier records sent messages
+type mockNotifier struct {
+	messages []notification.Message
+}
+
+func (m *mockNotifier) Notify(message notification.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+var visitStart = time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+
+func setupTestReminderUseCase(t *testing.T) (IReminderUseCase, *mockReminderRepository, *mockScheduleRepository, *mockNotifier) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	reminderRepo := newMockReminderRepository()
+	scheduleRepo := &mockScheduleRepository{}
+	notifier := &mockNotifier{}
+	return NewReminderUseCase(reminderRepo, scheduleRepo, &mockUserRepository{}, notifier, loggerInstance), reminderRepo, scheduleRepo, notifier
+}
+
+func newVisit() domainSchedule.Schedule {
+	return domainSchedule.Schedule{
+		ID:             uuid.New(),
+		ClientUserID:   uuid.New(),
+		AssignedUserID: uuid.New(),
+		ServiceName:    "Personal care",
+		VisitStatus:    "upcoming",
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: visitStart, To: visitStart.Add(time.Hour)},
+	}
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestPlan(t *testing.T) {
+	useCase, _, scheduleRepo, _ := setupTestReminderUseCase(t)
+	visit := newVisit()
+	scheduleRepo.schedules = []domainSchedule.Schedule{visit}
+
+	t.Run("Falls back to defaults", func(t *testing.T) {
+		plan, err := useCase.GetPlan(visit.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if plan.Source != domainReminder.SourceDefault || len(plan.OffsetsMinutes) != 2 || plan.OffsetsMinutes[0] != 1440 {
+			t.Errorf("expected the built-in defaults, got %s %v", plan.Source, plan.OffsetsMinutes)
+		}
+	})
+
+	t.Run("Override replaces defaults", func(t *testing.T) {
+		plan, err := useCase.SetOverride(visit.ID, []int{30, 120, 30})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if plan.Source != domainReminder.SourceOverride || len(plan.OffsetsMinutes) != 2 || plan.OffsetsMinutes[0] != 120 {
+			t.Errorf("expected normalized override, got %s %v", plan.Source, plan.OffsetsMinutes)
+		}
+
+		plan, err = useCase.ClearOverride(visit.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if plan.Source != domainReminder.SourceDefault {
+			t.Errorf("expected defaults after clearing, got %s", plan.Source)
+		}
+	})
+
+	t.Run("Rejects invalid offsets", func(t *testing.T) {
+		for _, offsets := range [][]int{{0}, {MaxOffsetMinutes + 1}, {1, 2, 3, 4, 5, 6}} {
+			if _, err := useCase.SetDefaults(offsets); errorType(err) != domainErrors.ValidationError {
+				t.Errorf("expected validation error for %v, got %v", offsets, err)
+			}
+		}
+	})
+}
+
+func TestSweep(t *testing.T) {
+	t.Run("Sends the closest due offset once", func(t *testing.T) {
+		useCase, reminderRepo, scheduleRepo, notifier := setupTestReminderUseCase(t)
+		visit := newVisit()
+		scheduleRepo.schedules = []domainSchedule.Schedule{visit}
+
+		// Booked late: both the 24h and the 1h reminder are due.
+		now := visitStart.Add(-30 * time.Minute)
+		result, err := useCase.Sweep(now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Sent != 2 || result.Skipped != 2 {
+			t.Errorf("expected 2 sent and 2 skipped, got %+v", result)
+		}
+		if len(notifier.messages) != 2 {
+			t.Fatalf("expected one message per recipient, got %d", len(notifier.messages))
+		}
+		for _, d := range reminderRepo.deliveries {
+			if d.Skipped != (d.OffsetMinutes == 1440) {
+				t.Errorf("expected only the 24h reminder skipped, got %+v", d)
+			}
+		}
+
+		result, err = useCase.Sweep(now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Sent != 0 || len(notifier.messages) != 2 {
+			t.Errorf("expected no resend, got %+v", result)
+		}
+	})
+
+	t.Run("Waits for each offset", func(t *testing.T) {
+		useCase, _, scheduleRepo, notifier := setupTestReminderUseCase(t)
+		scheduleRepo.schedules = []domainSchedule.Schedule{newVisit()}
+
+		if _, err := useCase.Sweep(visitStart.Add(-25 * time.Hour)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.messages) != 0 {
+			t.Fatalf("expected nothing before the first offset, got %d", len(notifier.messages))
+		}
+		if _, err := useCase.Sweep(visitStart.Add(-23 * time.Hour)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := useCase.Sweep(visitStart.Add(-59 * time.Minute)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.messages) != 4 {
+			t.Errorf("expected both reminders for both recipients, got %d", len(notifier.messages))
+		}
+	})
+
+	t.Run("Respects opt-out and disabled override", func(t *testing.T) {
+		useCase, _, scheduleRepo, notifier := setupTestReminderUseCase(t)
+		visit := newVisit()
+		quiet := newVisit()
+		scheduleRepo.schedules = []domainSchedule.Schedule{visit, quiet}
+
+		if _, err := useCase.SetPreference(visit.ClientUserID, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := useCase.SetOverride(quiet.ID, []int{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := useCase.Sweep(visitStart.Add(-59 * time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.messages) != 1 || *notifier.messages[0].UserID != visit.AssignedUserID {
+			t.Errorf("expected only the caregiver to be reminded, got %d messages", len(notifier.messages))
+		}
+		// The caregiver's stale 24h reminder and both of the client's.
+		if result.Skipped != 3 {
+			t.Errorf("expected the client's reminders recorded as skipped, got %+v", result)
+		}
+	})
+}
+
+// fixedCalendar serves the same calendar for any range
+type fixedCalendar struct {
+	calendar *domainLocale.Calendar
+}
+
+func (c fixedCalendar) Calendar(from, to time.Time) (*domainLocale.Calendar, error) {
+	return c.calendar, nil
+}
+
+func TestSweepFollowsLocalDays(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	// Clocks in New York go forward on 8 March 2026, so the day before a 9am
+	// visit that Sunday is 23 hours long.
+	calendar := domainLocale.NewCalendar(&domainLocale.Settings{Timezone: "America/New_York"}, []domainLocale.Holiday{{Date: "2026-03-08", Name: "Founders' Day"}})
+	reminderRepo := newMockReminderRepository()
+	reminderRepo.settings = &domainReminder.Settings{OffsetsMinutes: []int{1440}}
+	visit := newVisit()
+	visit.ScheduledSlot = domainSchedule.ScheduledSlot{From: time.Date(2026, 3, 8, 13, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 8, 14, 0, 0, 0, time.UTC)}
+	notifier := &mockNotifier{}
+	useCase := NewReminderUseCase(reminderRepo, &mockScheduleRepository{schedules: []domainSchedule.Schedule{visit}}, &mockUserRepository{}, notifier, loggerInstance, WithCalendar(fixedCalendar{calendar}))
+
+	result, err := useCase.Sweep(time.Date(2026, 3, 7, 13, 30, 0, 0, time.UTC))
+	if err != nil || result.Sent != 0 {
+		t.Fatalf("expected nothing due 24 hours ahead, got %+v, %v", result, err)
+	}
+	result, err = useCase.Sweep(time.Date(2026, 3, 7, 14, 0, 0, 0, time.UTC))
+	if err != nil || result.Sent != 2 {
+		t.Fatalf("expected the reminders at 9am local the day before, got %+v, %v", result, err)
+	}
+	want := "Personal care visit starts at 2026-03-08T09:00:00-04:00 (in 23h0m0s). The visit falls on Founders' Day."
+	if body := notifier.messages[0].Body; body != want {
+		t.Errorf("unexpected body %q", body)
+	}
+}
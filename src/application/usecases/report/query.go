@@ -0,0 +1,104 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReport "caregiver/src/domain/report"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultQueryLimit = 100
+	maxQueryLimit     = 1000
+)
+
+// Entities lists what the report builder can query, with the fields each
+// can be grouped and filtered by and the measures it can aggregate.
+func (u *ReportUseCase) Entities() []domainReport.Entity {
+	return u.queryRepository.Entities()
+}
+
+// Query runs an ad-hoc report builder query. Only whitelisted entities,
+// fields and measures are accepted; results are capped at 1000 rows and
+// default to 100.
+func (u *ReportUseCase) Query(query domainReport.Query) (*domainReport.Table, error) {
+	u.Logger.Info("Running report query", zap.String("entity", query.Entity), zap.Strings("dimensions", query.Dimensions), zap.Strings("measures", query.Measures))
+	if err := u.validateQuery(&query); err != nil {
+		return nil, err
+	}
+	return u.queryRepository.RunQuery(query)
+}
+
+func (u *ReportUseCase) validateQuery(query *domainReport.Query) error {
+	var entity *domainReport.Entity
+	entities := u.queryRepository.Entities()
+	names := make([]string, len(entities))
+	for i := range entities {
+		names[i] = entities[i].Name
+		if entities[i].Name == query.Entity {
+			entity = &entities[i]
+		}
+	}
+	if entity == nil {
+		return domainErrors.NewAppError(fmt.Errorf("entity must be one of: %s", strings.Join(names, ", ")), domainErrors.ValidationError)
+	}
+
+	chosen := make(map[string]bool)
+	for _, name := range query.Dimensions {
+		if _, ok := entity.Field(name); !ok {
+			return domainErrors.NewAppError(fmt.Errorf("unknown dimension '%s' for %s", name, entity.Name), domainErrors.ValidationError)
+		}
+		if chosen[name] {
+			return domainErrors.NewAppError(fmt.Errorf("'%s' is chosen twice", name), domainErrors.ValidationError)
+		}
+		chosen[name] = true
+	}
+	if len(query.Measures) == 0 {
+		return domainErrors.NewAppError(errors.New("at least one measure is required"), domainErrors.ValidationError)
+	}
+	for _, name := range query.Measures {
+		if !contains(entity.Measures, name) {
+			return domainErrors.NewAppError(fmt.Errorf("unknown measure '%s' for %s", name, entity.Name), domainErrors.ValidationError)
+		}
+		if chosen[name] {
+			return domainErrors.NewAppError(fmt.Errorf("'%s' is chosen twice", name), domainErrors.ValidationError)
+		}
+		chosen[name] = true
+	}
+
+	for _, condition := range query.Filters {
+		field, ok := entity.Field(condition.Field)
+		if !ok {
+			return domainErrors.NewAppError(fmt.Errorf("unknown filter field '%s' for %s", condition.Field, entity.Name), domainErrors.ValidationError)
+		}
+		if !contains(field.Operators(), condition.Operator) {
+			return domainErrors.NewAppError(fmt.Errorf("operator '%s' cannot be used on %s", condition.Operator, field.Name), domainErrors.ValidationError)
+		}
+		if condition.Operator == domainReport.OperatorIn && len(condition.Values) == 0 {
+			return domainErrors.NewAppError(fmt.Errorf("filter on %s needs at least one value", field.Name), domainErrors.ValidationError)
+		}
+		if condition.Operator != domainReport.OperatorIn && len(condition.Values) != 1 {
+			return domainErrors.NewAppError(fmt.Errorf("filter on %s needs exactly one value", field.Name), domainErrors.ValidationError)
+		}
+		for _, value := range condition.Values {
+			if _, err := field.Parse(value); err != nil {
+				return domainErrors.NewAppError(err, domainErrors.ValidationError)
+			}
+		}
+	}
+
+	if query.OrderBy != "" && !chosen[query.OrderBy] {
+		return domainErrors.NewAppError(errors.New("can only order by a chosen dimension or measure"), domainErrors.ValidationError)
+	}
+	switch {
+	case query.Limit < 0 || query.Limit > maxQueryLimit:
+		return domainErrors.NewAppError(fmt.Errorf("limit must be between 1 and %d", maxQueryLimit), domainErrors.ValidationError)
+	case query.Limit == 0:
+		query.Limit = defaultQueryLimit
+	}
+	return nil
+}
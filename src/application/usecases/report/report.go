@@ -0,0 +1,450 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReport "caregiver/src/domain/report"
+	logger "caregiver/src/infrastructure/logger"
+	mailer "caregiver/src/infrastructure/mail"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type IReportUseCase interface {
+	Definitions() []domainReport.Definition
+	Create(newReport *domainReport.SavedReport, now time.Time) (*domainReport.SavedReport, error)
+	GetAll() (*[]domainReport.SavedReport, error)
+	GetByID(id uuid.UUID) (*domainReport.SavedReport, error)
+	Update(id uuid.UUID, updates map[string]interface{}, now time.Time) (*domainReport.SavedReport, error)
+	Delete(id uuid.UUID) error
+	Export(id uuid.UUID, now time.Time) (*domainReport.Export, error)
+	Deliver(id uuid.UUID, now time.Time) (*domainReport.Run, error)
+	GetRuns(id uuid.UUID) (*[]domainReport.Run, error)
+	Sweep(now time.Time) (int, error)
+	Entities() []domainReport.Entity
+	Query(query domainReport.Query) (*domainReport.Table, error)
+}
+
+type ReportUseCase struct {
+	reportRepository domainReport.IReportRepository
+	queryRepository  domainReport.IQueryRepository
+	mailer           mailer.IMailer
+	sources          map[string]Source
+	kinds            []string
+	Logger           *logger.Logger
+}
+
+type Option func(*ReportUseCase)
+
+// WithSources makes the given kinds of report available to save.
+func WithSources(sources ...Source) Option {
+	return func(u *ReportUseCase) {
+		for _, source := range sources {
+			kind := source.Definition().Kind
+			if _, ok := u.sources[kind]; !ok {
+				u.kinds = append(u.kinds, kind)
+			}
+			u.sources[kind] = source
+		}
+	}
+}
+
+func NewReportUseCase(reportRepository domainReport.IReportRepository, queryRepository domainReport.IQueryRepository, mailer mailer.IMailer, loggerInstance *logger.Logger, opts ...Option) IReportUseCase {
+	u := &ReportUseCase{
+		reportRepository: reportRepository,
+		queryRepository:  queryRepository,
+		mailer:           mailer,
+		sources:          make(map[string]Source),
+		Logger:           loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// Definitions lists the kinds of report that can be saved.
+func (u *ReportUseCase) Definitions() []domainReport.Definition {
+	definitions := make([]domainReport.Definition, len(u.kinds))
+	for i, kind := range u.kinds {
+		definitions[i] = u.sources[kind].Definition()
+	}
+	return definitions
+}
+
+// Create saves a report configuration. Scheduled reports are first delivered
+// at the end of the current day, week or month.
+func (u *ReportUseCase) Create(newReport *domainReport.SavedReport, now time.Time) (*domainReport.SavedReport, error) {
+	u.Logger.Info("Saving report", zap.String("name", newReport.Name), zap.String("kind", newReport.Kind))
+	if newReport.Format == "" {
+		newReport.Format = domainReport.FormatCSV
+	}
+	if err := u.validate(newReport); err != nil {
+		return nil, err
+	}
+	newReport.ID = uuid.New()
+	newReport.Active = true
+	newReport.NextRunAt = nextRunAt(newReport, now)
+	newReport.LastRunAt = nil
+	return u.reportRepository.Create(newReport)
+}
+
+func (u *ReportUseCase) GetAll() (*[]domainReport.SavedReport, error) {
+	return u.reportRepository.GetAll()
+}
+
+func (u *ReportUseCase) GetByID(id uuid.UUID) (*domainReport.SavedReport, error) {
+	return u.reportRepository.GetByID(id)
+}
+
+// Update changes a saved report. Changing its frequency, recipients or
+// active flag reschedules the next delivery.
+func (u *ReportUseCase) Update(id uuid.UUID, updates map[string]interface{}, now time.Time) (*domainReport.SavedReport, error) {
+	u.Logger.Info("Updating saved report", zap.String("id", id.String()))
+	existing, err := u.reportRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["name"].(string); ok {
+		candidate.Name = v
+	}
+	if v, ok := updates["filters"].(map[string]string); ok {
+		candidate.Filters = v
+	}
+	if v, ok := updates["columns"].([]string); ok {
+		candidate.Columns = v
+	}
+	if v, ok := updates["format"].(string); ok {
+		candidate.Format = v
+	}
+	if v, ok := updates["frequency"].(string); ok {
+		candidate.Frequency = v
+	}
+	if v, ok := updates["recipients"].([]string); ok {
+		candidate.Recipients = v
+	}
+	if v, ok := updates["active"].(bool); ok {
+		candidate.Active = v
+	}
+	if err := u.validate(&candidate); err != nil {
+		return nil, err
+	}
+	if candidate.Scheduled() != existing.Scheduled() || candidate.Frequency != existing.Frequency {
+		updates["next_run_at"] = nextRunAt(&candidate, now)
+	}
+	return u.reportRepository.Update(id, updates)
+}
+
+func (u *ReportUseCase) Delete(id uuid.UUID) error {
+	u.Logger.Info("Deleting saved report", zap.String("id", id.String()))
+	return u.reportRepository.Delete(id)
+}
+
+// Export runs a saved report for the period ending at now and renders it in
+// its format, without sending it.
+func (u *ReportUseCase) Export(id uuid.UUID, now time.Time) (*domainReport.Export, error) {
+	report, err := u.reportRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.export(report, now)
+}
+
+// Deliver emails a saved report to its recipients straight away. The
+// schedule is left as it is.
+func (u *ReportUseCase) Deliver(id uuid.UUID, now time.Time) (*domainReport.Run, error) {
+	u.Logger.Info("Delivering saved report", zap.String("id", id.String()))
+	report, err := u.reportRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(report.Recipients) == 0 {
+		return nil, domainErrors.NewAppError(errors.New("the report has no recipients"), domainErrors.ValidationError)
+	}
+	run, err := u.deliver(report, now, nil)
+	if run == nil {
+		return nil, err
+	}
+	if err != nil {
+		var appErr *domainErrors.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, err
+		case errors.Is(err, mailer.ErrNotConfigured):
+			return nil, domainErrors.NewAppError(err, domainErrors.ValidationError)
+		default:
+			return nil, domainErrors.NewAppError(fmt.Errorf("report delivery failed: %w", err), domainErrors.UnknownError)
+		}
+	}
+	return run, nil
+}
+
+func (u *ReportUseCase) GetRuns(id uuid.UUID) (*[]domainReport.Run, error) {
+	if _, err := u.reportRepository.GetByID(id); err != nil {
+		return nil, err
+	}
+	return u.reportRepository.GetRuns(id)
+}
+
+// Sweep delivers the scheduled reports that are due and moves each on to its
+// next delivery, skipping any periods missed while the sweeper was not
+// running. It returns how many were sent.
+func (u *ReportUseCase) Sweep(now time.Time) (int, error) {
+	due, err := u.reportRepository.GetDue(now)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for i := range *due {
+		report := &(*due)[i]
+		if !report.Scheduled() {
+			if _, err := u.reportRepository.Update(report.ID, map[string]interface{}{"next_run_at": nil}); err != nil {
+				u.Logger.Error("Error unscheduling saved report", zap.Error(err), zap.String("reportID", report.ID.String()))
+			}
+			continue
+		}
+		if _, err := u.deliver(report, now, nextRunAt(report, now)); err == nil {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// deliver generates the report, emails it and records the run. When
+// generating or sending fails the failed run is returned along with the
+// error; the run is nil only if it could not be recorded. When next is set
+// the report is rescheduled to it either way.
+func (u *ReportUseCase) deliver(report *domainReport.SavedReport, now time.Time, next *time.Time) (*domainReport.Run, error) {
+	from, to := report.Period(now)
+	run := &domainReport.Run{
+		ID:         uuid.New(),
+		ReportID:   report.ID,
+		PeriodFrom: from,
+		PeriodTo:   to,
+		Recipients: report.Recipients,
+		Status:     domainReport.RunStatusSent,
+		RanAt:      now,
+	}
+	export, sendErr := u.export(report, now)
+	if sendErr == nil {
+		run.Rows = export.Rows
+		sendErr = u.mailer.Send(context.Background(), mailer.Message{
+			To:      report.Recipients,
+			Subject: "Report: " + report.Name,
+			Body:    deliveryBody(report, export),
+			Attachments: []mailer.Attachment{{
+				FileName:    export.FileName,
+				ContentType: export.ContentType,
+				Data:        export.Data,
+			}},
+		})
+	}
+	if sendErr != nil {
+		u.Logger.Error("Error sending saved report", zap.Error(sendErr), zap.String("reportID", report.ID.String()))
+		run.Status = domainReport.RunStatusFailed
+		run.Error = sendErr.Error()
+	}
+
+	created, err := u.reportRepository.CreateRun(run)
+	if err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{"last_run_at": now}
+	if next != nil {
+		updates["next_run_at"] = next
+	}
+	if _, err := u.reportRepository.Update(report.ID, updates); err != nil {
+		return nil, err
+	}
+	return created, sendErr
+}
+
+func (u *ReportUseCase) export(report *domainReport.SavedReport, now time.Time) (*domainReport.Export, error) {
+	source, ok := u.sources[report.Kind]
+	if !ok {
+		return nil, domainErrors.NewAppError(fmt.Errorf("reports of kind '%s' are not available", report.Kind), domainErrors.ValidationError)
+	}
+	filters, err := parseFilters(source.Definition(), report.Filters)
+	if err != nil {
+		return nil, err
+	}
+	from, to := report.Period(now)
+	rows, err := source.Rows(filters, from, to)
+	if err != nil {
+		return nil, err
+	}
+	table := selectColumns(source.Definition(), report.Columns, rows)
+
+	export := &domainReport.Export{
+		FileName: fmt.Sprintf("%s-%s.%s", slug(report.Name), from.Format(dateLayout), report.Format),
+		Rows:     len(table.Rows),
+		From:     from,
+		To:       to,
+	}
+	if report.Format == domainReport.FormatJSON {
+		export.ContentType = "application/json"
+		export.Data, err = renderJSON(table)
+	} else {
+		export.ContentType = "text/csv; charset=utf-8"
+		export.Data, err = renderCSV(table)
+	}
+	if err != nil {
+		u.Logger.Error("Error rendering saved report", zap.Error(err), zap.String("reportID", report.ID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return export, nil
+}
+
+func (u *ReportUseCase) validate(report *domainReport.SavedReport) error {
+	if strings.TrimSpace(report.Name) == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	source, ok := u.sources[report.Kind]
+	if !ok {
+		return domainErrors.NewAppError(fmt.Errorf("kind must be one of: %s", strings.Join(u.kinds, ", ")), domainErrors.ValidationError)
+	}
+	definition := source.Definition()
+	seen := make(map[string]bool, len(report.Columns))
+	for _, column := range report.Columns {
+		if !contains(definition.Columns, column) {
+			return domainErrors.NewAppError(fmt.Errorf("unknown column '%s' for %s reports", column, report.Kind), domainErrors.ValidationError)
+		}
+		if seen[column] {
+			return domainErrors.NewAppError(fmt.Errorf("column '%s' is listed twice", column), domainErrors.ValidationError)
+		}
+		seen[column] = true
+	}
+	if _, err := parseFilters(definition, report.Filters); err != nil {
+		return err
+	}
+	if report.Format != domainReport.FormatCSV && report.Format != domainReport.FormatJSON {
+		return domainErrors.NewAppError(errors.New("format must be 'csv' or 'json'"), domainErrors.ValidationError)
+	}
+	switch report.Frequency {
+	case "", domainReport.FrequencyDaily, domainReport.FrequencyWeekly, domainReport.FrequencyMonthly:
+	default:
+		return domainErrors.NewAppError(errors.New("frequency must be 'daily', 'weekly' or 'monthly'"), domainErrors.ValidationError)
+	}
+	if report.Frequency != "" && len(report.Recipients) == 0 {
+		return domainErrors.NewAppError(errors.New("scheduled reports need at least one recipient"), domainErrors.ValidationError)
+	}
+	for _, recipient := range report.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return domainErrors.NewAppError(fmt.Errorf("recipient '%s' is not a valid email address", recipient), domainErrors.ValidationError)
+		}
+	}
+	return nil
+}
+
+func nextRunAt(report *domainReport.SavedReport, now time.Time) *time.Time {
+	if !report.Scheduled() {
+		return nil
+	}
+	next := domainReport.NextRun(report.Frequency, now)
+	return &next
+}
+
+func parseFilters(definition domainReport.Definition, filters map[string]string) (map[string]uuid.UUID, error) {
+	res := make(map[string]uuid.UUID, len(filters))
+	for key, value := range filters {
+		if !contains(definition.Filters, key) {
+			return nil, domainErrors.NewAppError(fmt.Errorf("unknown filter '%s' for %s reports", key, definition.Kind), domainErrors.ValidationError)
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, domainErrors.NewAppError(fmt.Errorf("filter '%s' must be an id", key), domainErrors.ValidationError)
+		}
+		res[key] = id
+	}
+	return res, nil
+}
+
+// selectColumns keeps the chosen columns in the order given, or every column
+// of the definition when none are chosen.
+func selectColumns(definition domainReport.Definition, columns []string, rows []map[string]string) domainReport.Table {
+	if len(columns) == 0 {
+		columns = definition.Columns
+	}
+	table := domainReport.Table{Columns: columns, Rows: make([][]string, len(rows))}
+	for i, row := range rows {
+		values := make([]string, len(columns))
+		for j, column := range columns {
+			values[j] = row[column]
+		}
+		table.Rows[i] = values
+	}
+	return table
+}
+
+func renderCSV(table domainReport.Table) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(table.Columns); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(table.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderJSON writes the rows as an array of objects keyed by column.
+func renderJSON(table domainReport.Table) ([]byte, error) {
+	objects := make([]map[string]string, len(table.Rows))
+	for i, values := range table.Rows {
+		object := make(map[string]string, len(table.Columns))
+		for j, column := range table.Columns {
+			object[column] = values[j]
+		}
+		objects[i] = object
+	}
+	return json.Marshal(objects)
+}
+
+func deliveryBody(report *domainReport.SavedReport, export *domainReport.Export) string {
+	return fmt.Sprintf("%s for %s to %s: %d rows.\n\nThe report is attached as %s.\n",
+		report.Name, export.From.Format(dateLayout), export.To.Add(-time.Nanosecond).Format(dateLayout), export.Rows, export.FileName)
+}
+
+// slug turns a report name into a file name.
+func slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	res := strings.TrimSuffix(b.String(), "-")
+	if res == "" {
+		return "report"
+	}
+	return res
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
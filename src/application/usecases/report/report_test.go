@@ -0,0 +1,321 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReport "caregiver/src/domain/report"
+	logger "caregiver/src/infrastructure/logger"
+	mailer "caregiver/src/infrastructure/mail"
+
+	"github.com/google/uuid"
+)
+
+// mockReportRepository keeps saved reports and runs in memory
+type mockReportRepository struct {
+	domainReport.IReportRepository
+	reports map[uuid.UUID]*domainReport.SavedReport
+	runs    []domainReport.Run
+}
+
+func (m *mockReportRepository) Create(newReport *domainReport.SavedReport) (*domainReport.SavedReport, error) {
+	copied := *newReport
+	m.reports[newReport.ID] = &copied
+	return newReport, nil
+}
+
+func (m *mockReportRepository) GetByID(id uuid.UUID) (*domainReport.SavedReport, error) {
+	if report, ok := m.reports[id]; ok {
+		copied := *report
+		return &copied, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockReportRepository) GetDue(at time.Time) (*[]domainReport.SavedReport, error) {
+	res := []domainReport.SavedReport{}
+	for _, report := range m.reports {
+		if report.Active && report.NextRunAt != nil && !report.NextRunAt.After(at) {
+			res = append(res, *report)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockReportRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainReport.SavedReport, error) {
+	report, ok := m.reports[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	for key, value := range updates {
+		switch key {
+		case "next_run_at":
+			report.NextRunAt, _ = value.(*time.Time)
+		case "last_run_at":
+			at := value.(time.Time)
+			report.LastRunAt = &at
+		case "frequency":
+			report.Frequency = value.(string)
+		case "recipients":
+			report.Recipients = value.([]string)
+		case "active":
+			report.Active = value.(bool)
+		}
+	}
+	return m.GetByID(id)
+}
+
+func (m *mockReportRepository) CreateRun(newRun *domainReport.Run) (*domainReport.Run, error) {
+	m.runs = append([]domainReport.Run{*newRun}, m.runs...)
+	return newRun, nil
+}
+
+// mockQueryRepository lists one entity and records the queries it runs
+type mockQueryRepository struct {
+	queries []domainReport.Query
+}
+
+func (m *mockQueryRepository) Entities() []domainReport.Entity {
+	return []domainReport.Entity{{
+		Name: "visits",
+		Fields: []domainReport.Field{
+			{Name: "service_name", Type: domainReport.FieldString},
+			{Name: "visit_date", Type: domainReport.FieldDate},
+		},
+		Measures: []string{"visits", "worked_hours"},
+	}}
+}
+
+func (m *mockQueryRepository) RunQuery(query domainReport.Query) (*domainReport.Table, error) {
+	m.queries = append(m.queries, query)
+	return &domainReport.Table{Columns: append(query.Dimensions, query.Measures...)}, nil
+}
+
+// mockSource returns fixed rows and remembers the period it was asked for
+type mockSource struct {
+	rows    []map[string]string
+	filters map[string]uuid.UUID
+	from    time.Time
+	to      time.Time
+}
+
+func (m *mockSource) Definition() domainReport.Definition {
+	return domainReport.Definition{Kind: domainReport.KindSupplyConsumption, Columns: []string{"client_user_id", "item", "quantity"}, Filters: []string{"client_user_id"}}
+}
+
+func (m *mockSource) Rows(filters map[string]uuid.UUID, from, to time.Time) ([]map[string]string, error) {
+	m.filters, m.from, m.to = filters, from, to
+	return m.rows, nil
+}
+
+// mockMailer records the messages sent, or fails with err
+type mockMailer struct {
+	messages []mailer.Message
+	err      error
+}
+
+func (m *mockMailer) Send(ctx context.Context, message mailer.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func setupTestReportUseCase(t *testing.T) (IReportUseCase, *mockReportRepository, *mockQueryRepository, *mockSource, *mockMailer) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	reports := &mockReportRepository{reports: make(map[uuid.UUID]*domainReport.SavedReport)}
+	source := &mockSource{rows: []map[string]string{
+		{"client_user_id": "c1", "item": "Gloves, nitrile", "quantity": "40"},
+		{"client_user_id": "c2", "item": "Wipes", "quantity": "3"},
+	}}
+	queries := &mockQueryRepository{}
+	mail := &mockMailer{}
+	return NewReportUseCase(reports, queries, mail, loggerInstance, WithSources(source)), reports, queries, source, mail
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestCreateValidation(t *testing.T) {
+	useCase, _, _, _, _ := setupTestReportUseCase(t)
+	now := time.Date(2025, 6, 4, 15, 0, 0, 0, time.UTC)
+
+	invalid := []domainReport.SavedReport{
+		{Name: "Unknown kind", Kind: "payroll"},
+		{Name: "Unknown column", Kind: domainReport.KindSupplyConsumption, Columns: []string{"cost"}},
+		{Name: "Repeated column", Kind: domainReport.KindSupplyConsumption, Columns: []string{"item", "item"}},
+		{Name: "Unknown filter", Kind: domainReport.KindSupplyConsumption, Filters: map[string]string{"payer_id": uuid.NewString()}},
+		{Name: "Bad filter", Kind: domainReport.KindSupplyConsumption, Filters: map[string]string{"client_user_id": "someone"}},
+		{Name: "Bad format", Kind: domainReport.KindSupplyConsumption, Format: "xlsx"},
+		{Name: "No recipients", Kind: domainReport.KindSupplyConsumption, Frequency: domainReport.FrequencyDaily},
+		{Name: "Bad recipient", Kind: domainReport.KindSupplyConsumption, Frequency: domainReport.FrequencyDaily, Recipients: []string{"not-an-email"}},
+	}
+	for _, report := range invalid {
+		if _, err := useCase.Create(&report, now); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("%s: expected a validation error, got %v", report.Name, err)
+		}
+	}
+
+	onDemand, err := useCase.Create(&domainReport.SavedReport{Name: "Supplies", Kind: domainReport.KindSupplyConsumption}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if onDemand.Format != domainReport.FormatCSV || onDemand.NextRunAt != nil {
+		t.Errorf("expected an unscheduled CSV report, got %+v", onDemand)
+	}
+}
+
+func TestSweepDeliversOnSchedule(t *testing.T) {
+	useCase, reports, _, source, mail := setupTestReportUseCase(t)
+	wednesday := time.Date(2025, 6, 4, 15, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	clientID := uuid.New()
+
+	report, err := useCase.Create(&domainReport.SavedReport{
+		Name:       "Weekly supplies",
+		Kind:       domainReport.KindSupplyConsumption,
+		Filters:    map[string]string{"client_user_id": clientID.String()},
+		Columns:    []string{"item", "quantity"},
+		Frequency:  domainReport.FrequencyWeekly,
+		Recipients: []string{"office@example.com"},
+	}, wednesday)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.NextRunAt == nil || !report.NextRunAt.Equal(monday) {
+		t.Fatalf("expected the first delivery next Monday, got %v", report.NextRunAt)
+	}
+
+	if sent, _ := useCase.Sweep(monday.Add(-time.Minute)); sent != 0 {
+		t.Errorf("expected nothing due before Monday, got %d", sent)
+	}
+	if sent, _ := useCase.Sweep(monday.Add(5 * time.Minute)); sent != 1 {
+		t.Fatalf("expected the report to be sent, got %d", sent)
+	}
+	if !source.from.Equal(monday.AddDate(0, 0, -7)) || !source.to.Equal(monday) || source.filters["client_user_id"] != clientID {
+		t.Errorf("expected last week for the client, got %v to %v with %v", source.from, source.to, source.filters)
+	}
+	if len(mail.messages) != 1 || mail.messages[0].To[0] != "office@example.com" || len(mail.messages[0].Attachments) != 1 {
+		t.Fatalf("expected one email with the report attached, got %+v", mail.messages)
+	}
+	attachment := mail.messages[0].Attachments[0]
+	if attachment.FileName != "weekly-supplies-2025-06-02.csv" {
+		t.Errorf("unexpected file name %q", attachment.FileName)
+	}
+	if want := "item,quantity\n\"Gloves, nitrile\",40\nWipes,3\n"; string(attachment.Data) != want {
+		t.Errorf("expected only the chosen columns, got %q", attachment.Data)
+	}
+	saved := reports.reports[report.ID]
+	if !saved.NextRunAt.Equal(monday.AddDate(0, 0, 7)) || saved.LastRunAt == nil {
+		t.Errorf("expected the report rescheduled for the following Monday, got %+v", saved)
+	}
+	if len(reports.runs) != 1 || reports.runs[0].Status != domainReport.RunStatusSent || reports.runs[0].Rows != 2 {
+		t.Errorf("expected a sent run of two rows, got %+v", reports.runs)
+	}
+
+	// A failed send is recorded and the report still moves on, so a broken
+	// mail server does not resend the same report every sweep.
+	mail.err = mailer.ErrNotConfigured
+	if sent, _ := useCase.Sweep(monday.AddDate(0, 0, 7)); sent != 0 {
+		t.Errorf("expected nothing sent, got %d", sent)
+	}
+	if reports.runs[0].Status != domainReport.RunStatusFailed || !strings.Contains(reports.runs[0].Error, "not configured") {
+		t.Errorf("expected a failed run, got %+v", reports.runs[0])
+	}
+	if !reports.reports[report.ID].NextRunAt.Equal(monday.AddDate(0, 0, 14)) {
+		t.Errorf("expected the report rescheduled after the failure, got %v", reports.reports[report.ID].NextRunAt)
+	}
+	if _, err := useCase.Deliver(report.ID, monday.AddDate(0, 0, 8)); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected delivering without a mail server to be rejected, got %v", err)
+	}
+
+	// Turning the schedule off stops deliveries.
+	if _, err := useCase.Update(report.ID, map[string]interface{}{"frequency": ""}, monday.AddDate(0, 0, 8)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reports.reports[report.ID].NextRunAt != nil {
+		t.Errorf("expected no further deliveries, got %v", reports.reports[report.ID].NextRunAt)
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	useCase, _, _, source, _ := setupTestReportUseCase(t)
+	now := time.Date(2025, 6, 4, 15, 0, 0, 0, time.UTC)
+	report, err := useCase.Create(&domainReport.SavedReport{Name: "Supplies", Kind: domainReport.KindSupplyConsumption, Format: domainReport.FormatJSON}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	export, err := useCase.Export(report.ID, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !source.from.Equal(now.AddDate(0, 0, -30)) || !source.to.Equal(now) {
+		t.Errorf("expected on-demand reports to cover the last 30 days, got %v to %v", source.from, source.to)
+	}
+	var rows []map[string]string
+	if err := json.Unmarshal(export.Data, &rows); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", export.Data, err)
+	}
+	if len(rows) != 2 || rows[1]["item"] != "Wipes" || rows[1]["client_user_id"] != "c2" || export.ContentType != "application/json" {
+		t.Errorf("expected every column of both rows, got %+v", rows)
+	}
+}
+
+func TestQueryValidation(t *testing.T) {
+	useCase, _, queries, _, _ := setupTestReportUseCase(t)
+
+	invalid := []domainReport.Query{
+		{Entity: "payroll", Measures: []string{"visits"}},
+		{Entity: "visits"},
+		{Entity: "visits", Measures: []string{"visits; DROP TABLE users"}},
+		{Entity: "visits", Dimensions: []string{"hash_password"}, Measures: []string{"visits"}},
+		{Entity: "visits", Dimensions: []string{"service_name", "service_name"}, Measures: []string{"visits"}},
+		{Entity: "visits", Measures: []string{"visits"}, Filters: []domainReport.Condition{{Field: "service_name", Operator: "gt", Values: []string{"A"}}}},
+		{Entity: "visits", Measures: []string{"visits"}, Filters: []domainReport.Condition{{Field: "visit_date", Operator: "gte", Values: []string{"last week"}}}},
+		{Entity: "visits", Measures: []string{"visits"}, Filters: []domainReport.Condition{{Field: "service_name", Operator: "eq", Values: []string{"A", "B"}}}},
+		{Entity: "visits", Measures: []string{"visits"}, Filters: []domainReport.Condition{{Field: "service_name", Operator: "in"}}},
+		{Entity: "visits", Measures: []string{"visits"}, OrderBy: "worked_hours"},
+		{Entity: "visits", Measures: []string{"visits"}, Limit: 5000},
+	}
+	for _, query := range invalid {
+		if _, err := useCase.Query(query); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected %+v to be rejected, got %v", query, err)
+		}
+	}
+	if len(queries.queries) != 0 {
+		t.Fatalf("expected no invalid query to reach the database, got %+v", queries.queries)
+	}
+
+	table, err := useCase.Query(domainReport.Query{
+		Entity:     "visits",
+		Dimensions: []string{"service_name"},
+		Measures:   []string{"visits", "worked_hours"},
+		Filters: []domainReport.Condition{
+			{Field: "visit_date", Operator: "gte", Values: []string{"2025-06-01"}},
+			{Field: "service_name", Operator: "in", Values: []string{"Personal Care", "Companionship"}},
+		},
+		OrderBy:    "worked_hours",
+		Descending: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(table.Columns) != 3 || len(queries.queries) != 1 || queries.queries[0].Limit != 100 {
+		t.Errorf("expected the query to run with the default limit, got %+v %+v", table, queries.queries)
+	}
+}
@@ -0,0 +1,257 @@
+package report
+
+import (
+	"strconv"
+	"time"
+
+	domainBudget "caregiver/src/domain/budget"
+	domainClaim "caregiver/src/domain/claim"
+	domainCompliance "caregiver/src/domain/compliance"
+	domainReferral "caregiver/src/domain/referral"
+	domainReport "caregiver/src/domain/report"
+	domainSupply "caregiver/src/domain/supply"
+
+	"github.com/google/uuid"
+)
+
+// Source builds the rows of one kind of report over [from, to). Each row maps
+// every column of the definition to its value.
+type Source interface {
+	Definition() domainReport.Definition
+	Rows(filters map[string]uuid.UUID, from, to time.Time) ([]map[string]string, error)
+}
+
+type ComplianceReporter interface {
+	Report(from, to time.Time, filter domainCompliance.ReportFilter) (*domainCompliance.Report, error)
+}
+
+type ReferralReporter interface {
+	Report(from, to, asOf time.Time) (*domainReferral.Report, error)
+}
+
+type SupplyReporter interface {
+	ConsumptionReport(clientUserID *uuid.UUID, from, to time.Time) (*domainSupply.ConsumptionReport, error)
+}
+
+type DenialReporter interface {
+	DenialAging(now time.Time, payerID *uuid.UUID) (*domainClaim.DenialAgingReport, error)
+}
+
+type BudgetReporter interface {
+	Report(at time.Time) (*[]domainBudget.Usage, error)
+}
+
+// NewComplianceSource reports the compliance exceptions detected in the
+// period, optionally for one rule or the caregivers of one team.
+func NewComplianceSource(reporter ComplianceReporter) Source {
+	return &complianceSource{reporter: reporter}
+}
+
+type complianceSource struct {
+	reporter ComplianceReporter
+}
+
+func (s *complianceSource) Definition() domainReport.Definition {
+	return domainReport.Definition{
+		Kind:    domainReport.KindComplianceExceptions,
+		Columns: []string{"detected_at", "rule", "stage", "message", "schedule_id", "client_user_id", "caregiver_user_id"},
+		Filters: []string{"rule_id", "team_id"},
+	}
+}
+
+func (s *complianceSource) Rows(filters map[string]uuid.UUID, from, to time.Time) ([]map[string]string, error) {
+	var filter domainCompliance.ReportFilter
+	if id, ok := filters["rule_id"]; ok {
+		filter.RuleID = &id
+	}
+	if id, ok := filters["team_id"]; ok {
+		filter.TeamIDs = []uuid.UUID{id}
+	}
+	report, err := s.reporter.Report(from, to, filter)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]map[string]string, 0, len(report.Exceptions))
+	for _, exception := range report.Exceptions {
+		rows = append(rows, map[string]string{
+			"detected_at":       exception.DetectedAt.UTC().Format(time.RFC3339),
+			"rule":              exception.RuleName,
+			"stage":             exception.Stage,
+			"message":           exception.Message,
+			"schedule_id":       exception.ScheduleID.String(),
+			"client_user_id":    exception.ClientUserID.String(),
+			"caregiver_user_id": exception.AssignedUserID.String(),
+		})
+	}
+	return rows, nil
+}
+
+// NewReferralSource reports conversion and retention per referral source for
+// the clients referred in the period.
+func NewReferralSource(reporter ReferralReporter) Source {
+	return &referralSource{reporter: reporter}
+}
+
+type referralSource struct {
+	reporter ReferralReporter
+}
+
+func (s *referralSource) Definition() domainReport.Definition {
+	return domainReport.Definition{
+		Kind:    domainReport.KindReferralConversion,
+		Columns: []string{"source", "source_type", "referred", "converted", "retained", "conversion_rate", "retention_rate"},
+		Filters: []string{"source_id"},
+	}
+}
+
+func (s *referralSource) Rows(filters map[string]uuid.UUID, from, to time.Time) ([]map[string]string, error) {
+	report, err := s.reporter.Report(from, to, to)
+	if err != nil {
+		return nil, err
+	}
+	sourceID, filtered := filters["source_id"]
+	rows := make([]map[string]string, 0, len(report.Sources))
+	for _, source := range report.Sources {
+		if filtered && (source.SourceID == nil || *source.SourceID != sourceID) {
+			continue
+		}
+		rows = append(rows, map[string]string{
+			"source":          source.SourceName,
+			"source_type":     source.SourceType,
+			"referred":        strconv.Itoa(source.Totals.Referred),
+			"converted":       strconv.Itoa(source.Totals.Converted),
+			"retained":        strconv.Itoa(source.Totals.Retained),
+			"conversion_rate": formatAmount(source.Totals.ConversionRate),
+			"retention_rate":  formatAmount(source.Totals.RetentionRate),
+		})
+	}
+	return rows, nil
+}
+
+// NewSupplySource reports the supplies each client used in the period, one
+// row per client and item.
+func NewSupplySource(reporter SupplyReporter) Source {
+	return &supplySource{reporter: reporter}
+}
+
+type supplySource struct {
+	reporter SupplyReporter
+}
+
+func (s *supplySource) Definition() domainReport.Definition {
+	return domainReport.Definition{
+		Kind:    domainReport.KindSupplyConsumption,
+		Columns: []string{"client_user_id", "item", "unit", "quantity", "cost"},
+		Filters: []string{"client_user_id"},
+	}
+}
+
+func (s *supplySource) Rows(filters map[string]uuid.UUID, from, to time.Time) ([]map[string]string, error) {
+	var clientUserID *uuid.UUID
+	if id, ok := filters["client_user_id"]; ok {
+		clientUserID = &id
+	}
+	// The consumption report includes its last day.
+	report, err := s.reporter.ConsumptionReport(clientUserID, from, to.Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]string
+	for _, client := range report.Clients {
+		for _, line := range client.Lines {
+			rows = append(rows, map[string]string{
+				"client_user_id": client.ClientUserID.String(),
+				"item":           line.Name,
+				"unit":           line.Unit,
+				"quantity":       strconv.Itoa(line.Quantity),
+				"cost":           formatAmount(line.Cost),
+			})
+		}
+	}
+	return rows, nil
+}
+
+// NewDenialAgingSource reports the open claim denials by age at the end of
+// the period, optionally for one payer.
+func NewDenialAgingSource(reporter DenialReporter) Source {
+	return &denialAgingSource{reporter: reporter}
+}
+
+type denialAgingSource struct {
+	reporter DenialReporter
+}
+
+func (s *denialAgingSource) Definition() domainReport.Definition {
+	return domainReport.Definition{
+		Kind:    domainReport.KindDenialAging,
+		Columns: []string{"bucket", "count", "amount"},
+		Filters: []string{"payer_id"},
+	}
+}
+
+func (s *denialAgingSource) Rows(filters map[string]uuid.UUID, from, to time.Time) ([]map[string]string, error) {
+	var payerID *uuid.UUID
+	if id, ok := filters["payer_id"]; ok {
+		payerID = &id
+	}
+	report, err := s.reporter.DenialAging(to, payerID)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]map[string]string, 0, len(report.Buckets))
+	for _, bucket := range report.Buckets {
+		rows = append(rows, map[string]string{
+			"bucket": bucket.Label,
+			"count":  strconv.Itoa(bucket.Count),
+			"amount": formatAmount(bucket.Amount),
+		})
+	}
+	return rows, nil
+}
+
+// NewBudgetSource reports spend against every active budget for the budget
+// period in force at the end of the report period.
+func NewBudgetSource(reporter BudgetReporter) Source {
+	return &budgetSource{reporter: reporter}
+}
+
+type budgetSource struct {
+	reporter BudgetReporter
+}
+
+func (s *budgetSource) Definition() domainReport.Definition {
+	return domainReport.Definition{
+		Kind:    domainReport.KindBudgetUsage,
+		Columns: []string{"client_user_id", "period_start", "period_end", "unit", "limit", "spent", "remaining", "percent_used"},
+		Filters: []string{"client_user_id"},
+	}
+}
+
+func (s *budgetSource) Rows(filters map[string]uuid.UUID, from, to time.Time) ([]map[string]string, error) {
+	usages, err := s.reporter.Report(to.Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+	clientUserID, filtered := filters["client_user_id"]
+	rows := make([]map[string]string, 0, len(*usages))
+	for _, usage := range *usages {
+		if filtered && usage.Budget.ClientUserID != clientUserID {
+			continue
+		}
+		rows = append(rows, map[string]string{
+			"client_user_id": usage.Budget.ClientUserID.String(),
+			"period_start":   usage.PeriodStart.Format(dateLayout),
+			"period_end":     usage.PeriodEnd.Format(dateLayout),
+			"unit":           usage.Budget.Unit,
+			"limit":          formatAmount(usage.Budget.Limit),
+			"spent":          formatAmount(usage.Spent),
+			"remaining":      formatAmount(usage.Remaining),
+			"percent_used":   formatAmount(usage.PercentUsed),
+		})
+	}
+	return rows, nil
+}
+
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
@@ -0,0 +1,123 @@
+package retention
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	domainDeactivation "caregiver/src/domain/deactivation"
+	domainErrors "caregiver/src/domain/errors"
+	domainRetention "caregiver/src/domain/retention"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// IRetentionUseCase computes monthly client retention series over the
+// months from the one holding from up to the one holding to, exclusive.
+type IRetentionUseCase interface {
+	Churn(from, to time.Time) ([]domainRetention.ChurnPoint, error)
+	Tenure(from, to time.Time) ([]domainRetention.TenurePoint, error)
+	TerminationReasons(from, to time.Time) ([]domainRetention.ReasonPoint, error)
+}
+
+type RetentionUseCase struct {
+	userRepository         domainUser.IUserRepository
+	deactivationRepository domainDeactivation.IDeactivationRepository
+	Logger                 *logger.Logger
+}
+
+func NewRetentionUseCase(userRepository domainUser.IUserRepository, deactivationRepository domainDeactivation.IDeactivationRepository, loggerInstance *logger.Logger) IRetentionUseCase {
+	return &RetentionUseCase{
+		userRepository:         userRepository,
+		deactivationRepository: deactivationRepository,
+		Logger:                 loggerInstance,
+	}
+}
+
+func (u *RetentionUseCase) Churn(from, to time.Time) ([]domainRetention.ChurnPoint, error) {
+	spells, months, err := u.load(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domainRetention.Churn(spells, months), nil
+}
+
+func (u *RetentionUseCase) Tenure(from, to time.Time) ([]domainRetention.TenurePoint, error) {
+	spells, months, err := u.load(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domainRetention.Tenure(spells, months), nil
+}
+
+func (u *RetentionUseCase) TerminationReasons(from, to time.Time) ([]domainRetention.ReasonPoint, error) {
+	spells, months, err := u.load(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domainRetention.Reasons(spells, months), nil
+}
+
+func (u *RetentionUseCase) load(from, to time.Time) ([]domainRetention.Spell, []domainRetention.Month, error) {
+	months := domainRetention.Months(from, to)
+	if len(months) == 0 || len(months) > domainRetention.MaxMonths {
+		return nil, nil, domainErrors.NewAppError(fmt.Errorf("the range must cover between 1 and %d months", domainRetention.MaxMonths), domainErrors.ValidationError)
+	}
+	u.Logger.Info("Computing client retention", zap.String("from", months[0].Name), zap.String("to", months[len(months)-1].Name))
+	users, err := u.userRepository.GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	end := months[len(months)-1].End
+	changes, err := u.deactivationRepository.GetChanges(domainUser.RoleClient, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	return spells(*users, *changes), months, nil
+}
+
+// spells rebuilds each client's stretches of service. Service starts when
+// the client is created, ends at each recorded deactivation and starts again
+// at each reactivation. A client who is inactive without a recorded
+// deactivation, e.g. one switched off before changes were recorded, counts
+// as having left at their last update for an unspecified reason.
+func spells(users []domainUser.User, changes []domainDeactivation.Change) []domainRetention.Spell {
+	byUser := map[uuid.UUID][]domainDeactivation.Change{}
+	for _, change := range changes {
+		byUser[change.UserID] = append(byUser[change.UserID], change)
+	}
+	var result []domainRetention.Spell
+	for _, user := range users {
+		if user.Role != domainUser.RoleClient {
+			continue
+		}
+		userChanges := byUser[user.ID]
+		sort.SliceStable(userChanges, func(i, j int) bool {
+			return userChanges[i].ChangedAt.Before(userChanges[j].ChangedAt)
+		})
+		current := &domainRetention.Spell{ClientUserID: user.ID, Start: user.CreatedAt}
+		for _, change := range userChanges {
+			switch {
+			case change.Action == domainDeactivation.ActionDeactivate && current != nil:
+				end := change.ChangedAt
+				current.End, current.Reason = &end, change.Reason
+				result = append(result, *current)
+				current = nil
+			case change.Action == domainDeactivation.ActionReactivate && current == nil:
+				current = &domainRetention.Spell{ClientUserID: user.ID, Start: change.ChangedAt}
+			}
+		}
+		if current == nil {
+			continue
+		}
+		if !user.Status {
+			end := user.UpdatedAt
+			current.End, current.Reason = &end, domainDeactivation.ReasonUnspecified
+		}
+		result = append(result, *current)
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package retention
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainDeactivation "caregiver/src/domain/deactivation"
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockUserRepository lists a fixed set of users
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users []domainUser.User
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) {
+	return &m.users, nil
+}
+
+// mockHistory lists a fixed set of status changes
+type mockHistory struct {
+	changes []domainDeactivation.Change
+}
+
+func (m *mockHistory) RecordChange(change *domainDeactivation.Change) (*domainDeactivation.Change, error) {
+	return change, nil
+}
+
+func (m *mockHistory) GetChanges(role string, to time.Time) (*[]domainDeactivation.Change, error) {
+	return &m.changes, nil
+}
+
+func TestRetentionSeries(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	returning, legacy, caregiver := uuid.New(), uuid.New(), uuid.New()
+	users := &mockUserRepository{users: []domainUser.User{
+		{ID: returning, Role: domainUser.RoleClient, Status: true, CreatedAt: at(time.January, 1)},
+		{ID: legacy, Role: domainUser.RoleClient, CreatedAt: at(time.January, 1), UpdatedAt: at(time.February, 5)},
+		{ID: caregiver, Role: domainUser.RoleCaregiver, CreatedAt: at(time.January, 1)},
+	}}
+	history := &mockHistory{changes: []domainDeactivation.Change{
+		{UserID: returning, Action: domainDeactivation.ActionReactivate, ChangedAt: at(time.March, 1)},
+		{UserID: returning, Action: domainDeactivation.ActionDeactivate, Reason: domainDeactivation.ReasonCost, ChangedAt: at(time.January, 20)},
+	}}
+	useCase := NewRetentionUseCase(users, history, loggerInstance)
+
+	if _, err := useCase.Churn(at(time.March, 1), at(time.January, 1)); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a backwards range to be refused, got %v", err)
+	}
+	churn, err := useCase.Churn(at(time.January, 1), at(time.April, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(churn) != 3 || churn[0].ActiveAtStart != 2 || churn[0].Churned != 1 || churn[1].Churned != 1 || churn[2].New != 1 || churn[2].ActiveAtEnd != 1 {
+		t.Errorf("unexpected churn %+v", churn)
+	}
+	reasons, err := useCase.TerminationReasons(at(time.January, 1), at(time.March, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reasons[0].Reasons[domainDeactivation.ReasonCost] != 1 || reasons[1].Reasons[domainDeactivation.ReasonUnspecified] != 1 {
+		t.Errorf("expected the recorded and the legacy reason, got %+v", reasons)
+	}
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
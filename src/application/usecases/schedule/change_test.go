@@ -0,0 +1,55 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+func TestUpdateScheduleAnnouncesFieldChanges(t *testing.T) {
+	from := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	oldClient := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: domainUser.Location{HouseNumber: "1", Street: "Elm St", City: "Springfield"}}
+	newClient := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: domainUser.Location{HouseNumber: "2", Street: "Oak Ave", City: "Springfield"}}
+	existing := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: oldClient.ID, AssignedUserID: uuid.New(), VisitStatus: "upcoming",
+		ScheduledSlot: domainSchedule.ScheduledSlot{From: from, To: from.Add(2 * time.Hour)}}
+	repo := &mockScheduleRepository{
+		getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			copied := *existing
+			return &copied, nil
+		},
+		updateScheduleFn: func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			return mergeUpdates(existing, updates), nil
+		},
+	}
+	observer := &recordingObserver{}
+	useCase := NewScheduleUseCase(repo, openShiftUsers(oldClient, newClient), setupLogger(t), WithObservers(observer))
+
+	_, err := useCase.UpdateSchedule(existing.ID, map[string]interface{}{
+		"client_user_id":      newClient.ID,
+		"scheduled_slot_from": from.Add(time.Hour),
+		"scheduled_slot_to":   from.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(observer.events) != 1 {
+		t.Fatalf("expected one event, got %d", len(observer.events))
+	}
+	expected := []domainSchedule.FieldChange{
+		{Field: domainSchedule.FieldStartTime, Previous: "09:00", Current: "10:00"},
+		{Field: domainSchedule.FieldAddress, Previous: "1 Elm St, Springfield", Current: "2 Oak Ave, Springfield"},
+	}
+	changes := observer.events[0].Changes
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("change %d: expected %v, got %v", i, expected[i], changes[i])
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"go.uber.org/zap"
+)
+
+// WithConfidenceWeights sets how much each signal counts towards a visit's
+// verification confidence. Without it DefaultConfidenceWeights apply.
+func WithConfidenceWeights(weights domainSchedule.ConfidenceWeights) Option {
+	return func(s *ScheduleUseCase) {
+		s.confidenceWeights = weights
+	}
+}
+
+// addDeviceHints adds the app's integrity report from check-in, and the
+// outcome of checking its attestation, to updates.
+func addDeviceHints(updates map[string]interface{}, verification domainSchedule.Verification) {
+	updates["checkin_device_mock_location"] = verification.Device.MockLocation
+	updates["checkin_device_rooted"] = verification.Device.Rooted
+	updates["checkin_device_emulator"] = verification.Device.Emulator
+	updates["checkin_clock_skew_seconds"] = verification.ClockSkewSeconds
+	updates["checkin_integrity_status"] = verification.Integrity.Status
+	updates["checkin_integrity_platform"] = verification.Integrity.Platform
+	updates["checkin_integrity_detail"] = verification.Integrity.Detail
+}
+
+// addConfidence scores the visit being checked out at timestamp and adds the
+// score to updates. A client without a geocoded home only loses the distance
+// signal.
+func (s *ScheduleUseCase) addConfidence(updates map[string]interface{}, schedule *domainSchedule.Schedule, timestamp time.Time, location domainSchedule.Location) {
+	input := domainSchedule.ConfidenceInput{
+		Method:       schedule.CheckinVerification.Method,
+		CheckoutTime: timestamp,
+		Slot:         schedule.ScheduledSlot,
+		Device:       schedule.CheckinVerification.Device,
+		Integrity:    schedule.CheckinVerification.Integrity.Status,
+	}
+	if schedule.CheckinTime != nil {
+		input.CheckinTime = *schedule.CheckinTime
+	}
+	if skew := schedule.CheckinVerification.ClockSkewSeconds; skew != nil {
+		duration := time.Duration(*skew) * time.Second
+		input.ClockSkew = &duration
+	}
+
+	var recorded []domainUser.Location
+	for _, fix := range []domainSchedule.Location{schedule.CheckinLocation, location} {
+		if fix.Lat != nil && fix.Long != nil {
+			recorded = append(recorded, domainUser.Location{Lat: *fix.Lat, Long: *fix.Long})
+		}
+	}
+	if len(recorded) > 0 {
+		client, err := s.userRepository.GetByID(schedule.ClientUserID)
+		if err != nil {
+			s.Logger.Warn("Client not found for verification confidence", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		} else if client.Location.HasCoordinates() {
+			for _, fix := range recorded {
+				input.DistancesKm = append(input.DistancesKm, client.Location.DistanceKm(fix))
+			}
+		}
+	}
+
+	weights := s.confidenceWeights
+	if weights == nil {
+		weights = domainSchedule.DefaultConfidenceWeights
+	}
+	confidence := domainSchedule.ScoreConfidence(input, weights)
+	if confidence == nil {
+		return
+	}
+	updates["verification_confidence"] = confidence.Score
+	updates["verification_signals"] = confidence.Signals
+}
@@ -0,0 +1,59 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+func TestEndScheduleScoresConfidence(t *testing.T) {
+	from := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: domainUser.Location{Lat: 40.7128, Long: -74.006}}
+	home, away := 40.7128, 40.75
+	long := -74.006
+	mocked := true
+	schedule := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: client.ID, VisitStatus: "in_progress",
+		ScheduledSlot:       domainSchedule.ScheduledSlot{From: from, To: from.Add(time.Hour)},
+		CheckinTime:         &from,
+		CheckinLocation:     domainSchedule.Location{Lat: &home, Long: &long},
+		CheckinVerification: domainSchedule.Verification{Method: domainSchedule.VerificationGPS, Device: domainSchedule.DeviceIntegrity{MockLocation: &mocked}},
+	}
+
+	var updates map[string]interface{}
+	repo := &mockScheduleRepository{
+		getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return schedule, nil
+		},
+		updateScheduleFn: func(id uuid.UUID, u map[string]interface{}) (*domainSchedule.Schedule, error) {
+			updates = u
+			return schedule, nil
+		},
+	}
+	weights := domainSchedule.ConfidenceWeights{domainSchedule.SignalDistance: 1, domainSchedule.SignalDevice: 1}
+	useCase := NewScheduleUseCase(repo, openShiftUsers(client), setupLogger(t), WithConfidenceWeights(weights))
+
+	// Checked out about 4 km from home with a mock location: both
+	// weighted signals score 0, and the unweighted ones are left out.
+	if _, err := useCase.EndSchedule(schedule.ID, from.Add(time.Hour), domainSchedule.Location{Lat: &away, Long: &long}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updates["verification_confidence"] != 0 {
+		t.Errorf("expected a confidence of 0, got %v", updates["verification_confidence"])
+	}
+	signals, _ := updates["verification_signals"].([]domainSchedule.ConfidenceSignal)
+	if len(signals) != 2 || signals[0].Name != domainSchedule.SignalDistance || signals[1].Name != domainSchedule.SignalDevice {
+		t.Errorf("expected the distance and device signals, got %+v", signals)
+	}
+
+	mocked = false
+	if _, err := useCase.EndSchedule(schedule.ID, from.Add(time.Hour), domainSchedule.Location{Lat: &home, Long: &long}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updates["verification_confidence"] != 100 {
+		t.Errorf("expected a confidence of 100 at the client's home, got %v", updates["verification_confidence"])
+	}
+}
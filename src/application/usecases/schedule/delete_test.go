@@ -0,0 +1,49 @@
+package schedule
+
+import (
+	"testing"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+// recordingObserver keeps the schedule events it receives
+type recordingObserver struct {
+	events []domainSchedule.Event
+}
+
+func (o *recordingObserver) OnScheduleEvent(event domainSchedule.Event) {
+	o.events = append(o.events, event)
+}
+
+func TestDeleteAndRestoreSchedule(t *testing.T) {
+	schedule := &domainSchedule.Schedule{ID: uuid.New(), VisitStatus: "upcoming"}
+	deleted := false
+	mockScheduleRepo := &mockScheduleRepository{
+		getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return schedule, nil
+		},
+		deleteFn: func(id uuid.UUID, deletedBy *uuid.UUID) error {
+			deleted = true
+			return nil
+		},
+		restoreFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			deleted = false
+			return schedule, nil
+		},
+	}
+	observer := &recordingObserver{}
+	useCase := NewScheduleUseCase(mockScheduleRepo, &mockUserRepository{}, setupLogger(t), WithObservers(observer))
+
+	if err := useCase.DeleteSchedule(schedule.ID, nil); err != nil || !deleted {
+		t.Fatalf("expected the schedule deleted, got %v", err)
+	}
+	restored, err := useCase.RestoreSchedule(schedule.ID)
+	if err != nil || deleted || restored.ID != schedule.ID {
+		t.Fatalf("expected the schedule restored, got %+v, %v", restored, err)
+	}
+	if len(observer.events) != 2 || observer.events[0].Type != domainSchedule.EventDeleted || observer.events[1].Type != domainSchedule.EventCreated {
+		t.Errorf("expected deleted then created events, got %+v", observer.events)
+	}
+}
@@ -0,0 +1,112 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PublishDrafts makes the drafts upcoming, earliest first, and announces each
+// one as created so its caregiver is notified. Every draft goes through the
+// validators again as an upcoming visit, against the visits already
+// published including those published before it in the same call. Drafts
+// that fail, are not drafts or whose slot has ended are left as they are and
+// listed with the reason.
+func (s *ScheduleUseCase) PublishDrafts(scheduleIDs []uuid.UUID, now time.Time) (*domainSchedule.PublishResult, error) {
+	s.Logger.Info("Publishing drafts", zap.Int("schedules", len(scheduleIDs)))
+	if len(scheduleIDs) == 0 || len(scheduleIDs) > domainSchedule.MaxPublish {
+		return nil, domainErrors.NewAppError(fmt.Errorf("between 1 and %d schedules are required", domainSchedule.MaxPublish), domainErrors.ValidationError)
+	}
+
+	result := &domainSchedule.PublishResult{Published: []domainSchedule.Schedule{}, Rejected: []domainSchedule.PublishRejection{}}
+	reject := func(scheduleID uuid.UUID, reason string) {
+		result.Rejected = append(result.Rejected, domainSchedule.PublishRejection{ScheduleID: scheduleID, Reason: reason})
+	}
+
+	seen := make(map[uuid.UUID]bool, len(scheduleIDs))
+	var drafts []domainSchedule.Schedule
+	for _, scheduleID := range scheduleIDs {
+		if seen[scheduleID] {
+			continue
+		}
+		seen[scheduleID] = true
+		schedule, err := s.scheduleRepository.GetScheduleByID(scheduleID)
+		if err != nil {
+			var appErr *domainErrors.AppError
+			if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+				reject(scheduleID, "schedule not found")
+				continue
+			}
+			return nil, err
+		}
+		switch {
+		case !schedule.Draft():
+			reject(scheduleID, "schedule is not a draft")
+		case !schedule.ScheduledSlot.To.After(now):
+			reject(scheduleID, "the visit has already ended")
+		default:
+			drafts = append(drafts, *schedule)
+		}
+	}
+	sort.SliceStable(drafts, func(i, j int) bool {
+		return drafts[i].ScheduledSlot.From.Before(drafts[j].ScheduledSlot.From)
+	})
+
+	for i := range drafts {
+		draft := &drafts[i]
+		if err := s.checkChange(draft); err != nil {
+			reject(draft.ID, err.Error())
+			continue
+		}
+		candidate := *draft
+		candidate.VisitStatus = "upcoming"
+		warnings, err := s.runValidators(&candidate)
+		if err != nil {
+			s.Logger.Warn("Draft rejected by validator", zap.Error(err), zap.String("scheduleID", draft.ID.String()))
+			reject(draft.ID, err.Error())
+			continue
+		}
+		published, err := s.scheduleRepository.UpdateSchedule(draft.ID, map[string]interface{}{"visit_status": "upcoming"})
+		if err != nil {
+			s.Logger.Error("Error publishing draft", zap.Error(err), zap.String("scheduleID", draft.ID.String()))
+			return nil, err
+		}
+		published.Warnings = warnings
+		s.notify(domainSchedule.EventCreated, published, nil)
+		result.Published = append(result.Published, *published)
+	}
+	s.Logger.Info("Drafts published", zap.Int("published", len(result.Published)), zap.Int("rejected", len(result.Rejected)))
+	return result, nil
+}
+
+// validateSchedule runs the validators. A draft is saved whatever they say,
+// with their objection passed back as a warning, so the coordinator can keep
+// working on it; the objection blocks publishing instead.
+func (s *ScheduleUseCase) validateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	warnings, err := s.runValidators(schedule)
+	if err != nil && schedule.Draft() {
+		return []string{err.Error()}, nil
+	}
+	return warnings, err
+}
+
+// withoutDrafts leaves out the visits caregivers must not see yet.
+func withoutDrafts(schedules *[]domainSchedule.Schedule) *[]domainSchedule.Schedule {
+	if schedules == nil {
+		return nil
+	}
+	published := make([]domainSchedule.Schedule, 0, len(*schedules))
+	for _, schedule := range *schedules {
+		if !schedule.Draft() {
+			published = append(published, schedule)
+		}
+	}
+	return &published
+}
@@ -0,0 +1,124 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateDraftSchedule(t *testing.T) {
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Status: true}
+	repo := &mockScheduleRepository{
+		createFn: func(newSchedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error) {
+			created := *newSchedule
+			created.ID = uuid.New()
+			return &created, nil
+		},
+	}
+	observer := &recordingObserver{}
+	useCase := NewScheduleUseCase(repo, openShiftUsers(caregiver, client), setupLogger(t),
+		WithValidators(ineligibleValidator{caregiverID: caregiver.ID}), WithObservers(observer))
+
+	draft := &domainSchedule.Schedule{
+		ClientUserID:   client.ID,
+		AssignedUserID: caregiver.ID,
+		VisitStatus:    domainSchedule.StatusDraft,
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: now.Add(time.Hour), To: now.Add(2 * time.Hour)},
+		Tasks:          []domainSchedule.Task{{Title: "Lunch"}},
+	}
+	created, err := useCase.CreateSchedule(draft)
+	if err != nil {
+		t.Fatalf("expected the draft saved despite the validator, got %v", err)
+	}
+	if !created.Draft() {
+		t.Errorf("expected the visit kept as a draft, got %s", created.VisitStatus)
+	}
+	if len(created.Warnings) != 1 {
+		t.Errorf("expected the validator's objection as a warning, got %v", created.Warnings)
+	}
+	if len(observer.events) != 0 {
+		t.Errorf("expected no event for a draft, got %v", observer.events)
+	}
+
+	draft.VisitStatus = ""
+	if _, err := useCase.CreateSchedule(draft); errorTypeOf(err) != domainErrors.ValidationError {
+		t.Errorf("expected the validator to block a published visit, got %v", err)
+	}
+}
+
+func TestPublishDrafts(t *testing.T) {
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	ineligible := uuid.New()
+	slot := func(hours int) domainSchedule.ScheduledSlot {
+		from := now.Add(time.Duration(hours) * time.Hour)
+		return domainSchedule.ScheduledSlot{From: from, To: from.Add(time.Hour)}
+	}
+	later := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: uuid.New(), VisitStatus: domainSchedule.StatusDraft, ScheduledSlot: slot(5)}
+	earlier := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: uuid.New(), VisitStatus: domainSchedule.StatusDraft, ScheduledSlot: slot(2)}
+	failing := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: ineligible, VisitStatus: domainSchedule.StatusDraft, ScheduledSlot: slot(3)}
+	published := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: uuid.New(), VisitStatus: "upcoming", ScheduledSlot: slot(4)}
+	ended := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: uuid.New(), VisitStatus: domainSchedule.StatusDraft, ScheduledSlot: slot(-3)}
+	stored := map[uuid.UUID]domainSchedule.Schedule{}
+	for _, schedule := range []domainSchedule.Schedule{later, earlier, failing, published, ended} {
+		stored[schedule.ID] = schedule
+	}
+
+	var order []uuid.UUID
+	repo := &mockScheduleRepository{
+		getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			schedule, ok := stored[id]
+			if !ok {
+				return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+			}
+			return &schedule, nil
+		},
+		updateScheduleFn: func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			order = append(order, id)
+			schedule := stored[id]
+			schedule.VisitStatus = updates["visit_status"].(string)
+			stored[id] = schedule
+			return &schedule, nil
+		},
+	}
+	observer := &recordingObserver{}
+	useCase := NewScheduleUseCase(repo, &mockUserRepository{}, setupLogger(t),
+		WithValidators(ineligibleValidator{caregiverID: ineligible}), WithObservers(observer))
+
+	unknown := uuid.New()
+	result, err := useCase.PublishDrafts([]uuid.UUID{later.ID, earlier.ID, failing.ID, published.ID, ended.ID, unknown, later.ID}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(order) != 2 || order[0] != earlier.ID || order[1] != later.ID {
+		t.Errorf("expected the two valid drafts published earliest first, got %v", order)
+	}
+	if len(result.Published) != 2 || result.Published[0].VisitStatus != "upcoming" {
+		t.Errorf("expected two upcoming visits, got %v", result.Published)
+	}
+	if len(observer.events) != 2 || observer.events[0].Type != domainSchedule.EventCreated {
+		t.Errorf("expected each published draft announced as created, got %v", observer.events)
+	}
+	rejected := map[uuid.UUID]bool{}
+	for _, rejection := range result.Rejected {
+		rejected[rejection.ScheduleID] = true
+	}
+	for _, id := range []uuid.UUID{failing.ID, published.ID, ended.ID, unknown} {
+		if !rejected[id] {
+			t.Errorf("expected %s rejected, got %v", id, result.Rejected)
+		}
+	}
+	if stored[failing.ID].VisitStatus != domainSchedule.StatusDraft {
+		t.Errorf("expected the failing draft left a draft")
+	}
+
+	if _, err := useCase.PublishDrafts(nil, now); errorTypeOf(err) != domainErrors.ValidationError {
+		t.Errorf("expected ValidationError for no schedules, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+// lockingGuard rejects changes to visits starting before a cutoff
+type lockingGuard struct {
+	cutoff time.Time
+	tasks  map[uuid.UUID]time.Time
+}
+
+func (g *lockingGuard) BeforeChange(schedule *domainSchedule.Schedule) error {
+	if schedule.ScheduledSlot.From.Before(g.cutoff) {
+		return domainErrors.NewAppError(errors.New("visit is locked"), domainErrors.Conflict)
+	}
+	return nil
+}
+
+func (g *lockingGuard) BeforeTaskChange(taskID uuid.UUID) error {
+	if g.tasks[taskID].Before(g.cutoff) {
+		return domainErrors.NewAppError(errors.New("visit is locked"), domainErrors.Conflict)
+	}
+	return nil
+}
+
+func TestChangeGuards(t *testing.T) {
+	cutoff := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	locked := &domainSchedule.Schedule{ID: uuid.New(), VisitStatus: "completed", ScheduledSlot: domainSchedule.ScheduledSlot{From: cutoff.Add(-48 * time.Hour)}}
+	open := &domainSchedule.Schedule{ID: uuid.New(), VisitStatus: "upcoming", ScheduledSlot: domainSchedule.ScheduledSlot{From: cutoff.Add(48 * time.Hour)}}
+	lockedTask, openTask := uuid.New(), uuid.New()
+	writes := 0
+	mockScheduleRepo := &mockScheduleRepository{
+		getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			if id == locked.ID {
+				return locked, nil
+			}
+			return open, nil
+		},
+		updateScheduleFn: func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			writes++
+			return open, nil
+		},
+		updateTaskFn: func(taskID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Task, error) {
+			writes++
+			return &domainSchedule.Task{ID: taskID}, nil
+		},
+		deleteFn: func(id uuid.UUID, deletedBy *uuid.UUID) error {
+			writes++
+			return nil
+		},
+		restoreFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			writes++
+			return locked, nil
+		},
+	}
+	guard := &lockingGuard{cutoff: cutoff, tasks: map[uuid.UUID]time.Time{lockedTask: locked.ScheduledSlot.From, openTask: open.ScheduledSlot.From}}
+	useCase := NewScheduleUseCase(mockScheduleRepo, &mockUserRepository{}, setupLogger(t), WithChangeGuards(guard))
+
+	if _, err := useCase.UpdateSchedule(locked.ID, map[string]interface{}{"service_name": "Companionship"}); err == nil {
+		t.Error("expected an edit to a locked visit to be rejected")
+	}
+	if _, err := useCase.UpdateSchedule(open.ID, map[string]interface{}{"scheduled_slot_from": locked.ScheduledSlot.From}); err == nil {
+		t.Error("expected moving a visit into the locked range to be rejected")
+	}
+	if _, err := useCase.UpdateTaskStatus(lockedTask, "completed", true, "", nil); err == nil {
+		t.Error("expected a task of a locked visit to be rejected")
+	}
+	if err := useCase.DeleteSchedule(locked.ID, nil); err == nil {
+		t.Error("expected deleting a locked visit to be rejected")
+	}
+	if writes != 0 {
+		t.Fatalf("expected no writes for rejected changes, got %d", writes)
+	}
+	if _, err := useCase.RestoreSchedule(locked.ID); err == nil || mockScheduleRepo.rollbacks != 1 {
+		t.Errorf("expected restoring a locked visit to roll back, got %v with %d rollbacks", err, mockScheduleRepo.rollbacks)
+	}
+
+	if _, err := useCase.UpdateSchedule(open.ID, map[string]interface{}{"service_name": "Companionship"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := useCase.UpdateTaskStatus(openTask, "completed", true, "", nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetClientTaskHistory reports how often each category of a client's tasks
+// was completed or not on the visits delivered within [from, to), overall
+// and per week or month, for care plan reviews.
+func (s *ScheduleUseCase) GetClientTaskHistory(clientUserID uuid.UUID, from, to time.Time, period string) ([]domainSchedule.TaskHistory, error) {
+	s.Logger.Info("Getting client task history", zap.String("clientUserID", clientUserID.String()), zap.String("period", period))
+	if period != domainSchedule.HistoryPeriodWeek && period != domainSchedule.HistoryPeriodMonth {
+		return nil, domainErrors.NewAppError(fmt.Errorf("period must be %s or %s", domainSchedule.HistoryPeriodWeek, domainSchedule.HistoryPeriodMonth), domainErrors.ValidationError)
+	}
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+	if to.Sub(from) > domainSchedule.MaxHistoryDays*24*time.Hour {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the range cannot exceed %d days", domainSchedule.MaxHistoryDays), domainErrors.ValidationError)
+	}
+	client, err := s.userRepository.GetByID(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	if client.Role != domainUser.RoleClient {
+		return nil, domainErrors.NewAppError(errors.New("the user is not a client"), domainErrors.ValidationError)
+	}
+
+	calendar, err := s.calendarFor(from, to)
+	if err != nil {
+		return nil, err
+	}
+	outcomes, err := s.scheduleRepository.GetTaskOutcomes(clientUserID, from, to, period, calendar.Location.String())
+	if err != nil {
+		s.Logger.Error("Error getting task outcomes", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, err
+	}
+	return domainSchedule.TaskHistories(outcomes), nil
+}
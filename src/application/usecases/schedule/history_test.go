@@ -0,0 +1,96 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+func TestGetClientTaskHistory(t *testing.T) {
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	january, february := from, from.AddDate(0, 1, 0)
+	repo := &mockScheduleRepository{
+		getTaskOutcomesFn: func(clientUserID uuid.UUID, gotFrom, gotTo time.Time, period string, timezone string) ([]domainSchedule.TaskOutcomes, error) {
+			if clientUserID != client.ID || !gotFrom.Equal(from) || !gotTo.Equal(to) || period != domainSchedule.HistoryPeriodMonth || timezone != "UTC" {
+				t.Errorf("unexpected query %s %s %s %s %s", clientUserID, gotFrom, gotTo, period, timezone)
+			}
+			return []domainSchedule.TaskOutcomes{
+				{Category: "bathing", PeriodStart: january, Total: 4, Completed: 3, NotCompleted: 1},
+				{Category: "bathing", PeriodStart: february, Total: 6, Completed: 4, NotCompleted: 2},
+				{Category: domainSchedule.TaskKindMedication, PeriodStart: february, Total: 5, Completed: 4, Unrecorded: 1},
+			}, nil
+		},
+	}
+	useCase := NewScheduleUseCase(repo, openShiftUsers(client, caregiver), setupLogger(t))
+
+	histories, err := useCase.GetClientTaskHistory(client.ID, from, to, domainSchedule.HistoryPeriodMonth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(histories) != 2 {
+		t.Fatalf("expected two categories, got %v", histories)
+	}
+	bathing := histories[0]
+	if bathing.Category != "bathing" || bathing.Total != 10 || bathing.NotCompleted != 3 || len(bathing.Periods) != 2 {
+		t.Errorf("expected bathing totalled over both months, got %+v", bathing)
+	}
+	if rate := bathing.NotCompletedRate(); rate < 0.299 || rate > 0.301 {
+		t.Errorf("expected bathing not done 30%% of the time, got %v", rate)
+	}
+	if medication := histories[1]; medication.Unrecorded != 1 || medication.CompletionRate() != 0.8 {
+		t.Errorf("unexpected medication history %+v", medication)
+	}
+
+	t.Run("Periods use the organization time zone", func(t *testing.T) {
+		var zone string
+		repo := &mockScheduleRepository{getTaskOutcomesFn: func(clientUserID uuid.UUID, from, to time.Time, period string, timezone string) ([]domainSchedule.TaskOutcomes, error) {
+			zone = timezone
+			return nil, nil
+		}}
+		calendar := domainLocale.NewCalendar(&domainLocale.Settings{Timezone: "America/Chicago"}, nil)
+		useCase := NewScheduleUseCase(repo, openShiftUsers(client, caregiver), setupLogger(t), WithCalendar(fixedCalendar{calendar}))
+		if _, err := useCase.GetClientTaskHistory(client.ID, from, to, domainSchedule.HistoryPeriodWeek); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if zone != "America/Chicago" {
+			t.Errorf("expected America/Chicago, got %q", zone)
+		}
+	})
+
+	invalid := []struct {
+		name     string
+		userID   uuid.UUID
+		from, to time.Time
+		period   string
+	}{
+		{"Unknown period", client.ID, from, to, "year"},
+		{"Reversed range", client.ID, to, from, domainSchedule.HistoryPeriodWeek},
+		{"Range too long", client.ID, from.AddDate(-3, 0, 0), to, domainSchedule.HistoryPeriodMonth},
+		{"Not a client", caregiver.ID, from, to, domainSchedule.HistoryPeriodMonth},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := useCase.GetClientTaskHistory(tc.userID, tc.from, tc.to, tc.period); errorTypeOf(err) != domainErrors.ValidationError {
+				t.Errorf("expected ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskCategory(t *testing.T) {
+	if got := domainSchedule.TaskCategory(domainSchedule.TaskKindGeneric, "  Bathing "); got != "bathing" {
+		t.Errorf("expected titles grouped ignoring case and spaces, got %q", got)
+	}
+	if got := domainSchedule.TaskCategory(domainSchedule.TaskKindVitals, "Morning BP"); got != domainSchedule.TaskKindVitals {
+		t.Errorf("expected vitals tasks grouped by kind, got %q", got)
+	}
+}
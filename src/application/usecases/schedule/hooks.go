@@ -0,0 +1,257 @@
+package schedule
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ScheduleValidator is consulted before a schedule is created or its
+// assignment changes. Returned warnings are passed back to the caller; an
+// error blocks the write.
+type ScheduleValidator interface {
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+}
+
+// ScheduleObserver is notified after a schedule change has been persisted.
+type ScheduleObserver interface {
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+// CheckinGuard can veto a check-in, e.g. when a caregiver has not yet
+// acknowledged required visit documents. Guards that prove the caregiver's
+// presence record the method they used on the verification.
+type CheckinGuard interface {
+	BeforeCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, verification *domainSchedule.Verification) error
+}
+
+// ChangeGuard can veto changes to an existing visit or its tasks, e.g. once
+// the visit falls in a closed payroll period.
+type ChangeGuard interface {
+	BeforeChange(schedule *domainSchedule.Schedule) error
+	BeforeTaskChange(taskID uuid.UUID) error
+}
+
+// ViewResolver expands a saved view into the filters, sort order and color
+// rules of a schedule search. Explicit query fields take precedence.
+type ViewResolver interface {
+	ApplyView(viewID uuid.UUID, query *domainSchedule.SearchQuery) error
+}
+
+// TeamResolver expands team IDs into the caregivers on those teams.
+type TeamResolver interface {
+	GetMemberIDs(teamIDs []uuid.UUID) ([]uuid.UUID, error)
+}
+
+// CalendarSource supplies the organization's time zone and holidays.
+type CalendarSource interface {
+	Calendar(from, to time.Time) (*domainLocale.Calendar, error)
+}
+
+// Layouts of the visit date and times in change notifications.
+const (
+	changeDateLayout = "Mon 2 Jan 2006"
+	changeTimeLayout = "15:04"
+)
+
+type Option func(*ScheduleUseCase)
+
+func WithValidators(validators ...ScheduleValidator) Option {
+	return func(s *ScheduleUseCase) {
+		s.validators = append(s.validators, validators...)
+	}
+}
+
+func WithObservers(observers ...ScheduleObserver) Option {
+	return func(s *ScheduleUseCase) {
+		s.observers = append(s.observers, observers...)
+	}
+}
+
+func WithViewResolver(resolver ViewResolver) Option {
+	return func(s *ScheduleUseCase) {
+		s.viewResolver = resolver
+	}
+}
+
+func WithTeamResolver(resolver TeamResolver) Option {
+	return func(s *ScheduleUseCase) {
+		s.teamResolver = resolver
+	}
+}
+
+func WithCheckinGuards(guards ...CheckinGuard) Option {
+	return func(s *ScheduleUseCase) {
+		s.checkinGuards = append(s.checkinGuards, guards...)
+	}
+}
+
+func WithChangeGuards(guards ...ChangeGuard) Option {
+	return func(s *ScheduleUseCase) {
+		s.changeGuards = append(s.changeGuards, guards...)
+	}
+}
+
+// WithCalendar repeats recurring visits at the same local time across DST
+// changes and, when the organization asks for it, leaves out holidays.
+func WithCalendar(source CalendarSource) Option {
+	return func(s *ScheduleUseCase) {
+		s.calendar = source
+	}
+}
+
+// verifyCheckin runs the check-in guards and settles the verification method.
+// A GPS fix is the default factor; without one another guard (e.g. an NFC tag
+// scan) must have verified the caregiver's presence, or the caller must have
+// (a kiosk check-in arrives already verified).
+func (s *ScheduleUseCase) verifyCheckin(schedule *domainSchedule.Schedule, timestamp time.Time, location domainSchedule.Location, verification *domainSchedule.Verification) error {
+	for _, guard := range s.checkinGuards {
+		if err := guard.BeforeCheckin(schedule, timestamp, verification); err != nil {
+			s.Logger.Warn("Check-in rejected by guard", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+			return err
+		}
+	}
+	if verification.Method == "" {
+		if location.Lat == nil || location.Long == nil {
+			return domainErrors.NewAppError(errors.New("location or a verified NFC tag is required for check-in"), domainErrors.ValidationError)
+		}
+		verification.Method = domainSchedule.VerificationGPS
+	}
+	return nil
+}
+
+func (s *ScheduleUseCase) checkChange(schedule *domainSchedule.Schedule) error {
+	for _, guard := range s.changeGuards {
+		if err := guard.BeforeChange(schedule); err != nil {
+			s.Logger.Warn("Schedule change rejected by guard", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ScheduleUseCase) checkTaskChange(taskID uuid.UUID) error {
+	for _, guard := range s.changeGuards {
+		if err := guard.BeforeTaskChange(taskID); err != nil {
+			s.Logger.Warn("Task change rejected by guard", zap.Error(err), zap.String("taskID", taskID.String()))
+			return err
+		}
+	}
+	return nil
+}
+
+// calendarFor returns the organization's calendar, or UTC days without
+// holidays when none is configured.
+func (s *ScheduleUseCase) calendarFor(from, to time.Time) (*domainLocale.Calendar, error) {
+	if s.calendar == nil {
+		return domainLocale.UTCCalendar(), nil
+	}
+	return s.calendar.Calendar(from, to)
+}
+
+func (s *ScheduleUseCase) runValidators(schedule *domainSchedule.Schedule) ([]string, error) {
+	var warnings []string
+	for _, validator := range s.validators {
+		validatorWarnings, err := validator.ValidateSchedule(schedule)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, validatorWarnings...)
+	}
+	return warnings, nil
+}
+
+// notify tells the observers about a change. Changes to drafts are kept
+// quiet; publishing a draft announces it as created.
+func (s *ScheduleUseCase) notify(eventType string, schedule *domainSchedule.Schedule, previous *domainSchedule.Schedule) {
+	if schedule == nil || schedule.Draft() {
+		return
+	}
+	event := domainSchedule.Event{Type: eventType, Schedule: schedule, Previous: previous}
+	if eventType == domainSchedule.EventUpdated && previous != nil {
+		event.Changes = s.fieldChanges(previous, schedule)
+	}
+	for _, observer := range s.observers {
+		observer.OnScheduleEvent(event)
+	}
+	s.Logger.Info("Schedule event dispatched", zap.String("event", eventType), zap.String("scheduleID", schedule.ID.String()))
+}
+
+// fieldChanges compares a visit before and after an update: its date, start
+// and end time in the organization's time zone, and its address, which
+// changes with the client.
+func (s *ScheduleUseCase) fieldChanges(previous, current *domainSchedule.Schedule) []domainSchedule.FieldChange {
+	var changes []domainSchedule.FieldChange
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, domainSchedule.FieldChange{Field: field, Previous: before, Current: after})
+		}
+	}
+
+	before, after := previous.ScheduledSlot, current.ScheduledSlot
+	if !before.From.Equal(after.From) || !before.To.Equal(after.To) {
+		calendar, err := s.calendarFor(before.From, after.To)
+		if err != nil {
+			s.Logger.Warn("Error getting calendar for change notification", zap.Error(err), zap.String("scheduleID", current.ID.String()))
+			calendar = domainLocale.UTCCalendar()
+		}
+		add(domainSchedule.FieldDate, calendar.Local(before.From).Format(changeDateLayout), calendar.Local(after.From).Format(changeDateLayout))
+		add(domainSchedule.FieldStartTime, calendar.Local(before.From).Format(changeTimeLayout), calendar.Local(after.From).Format(changeTimeLayout))
+		add(domainSchedule.FieldEndTime, calendar.Local(before.To).Format(changeTimeLayout), calendar.Local(after.To).Format(changeTimeLayout))
+	}
+
+	if previous.ClientUserID != current.ClientUserID {
+		add(domainSchedule.FieldAddress, s.clientAddress(previous.ClientUserID), s.clientAddress(current.ClientUserID))
+	}
+	return changes
+}
+
+func (s *ScheduleUseCase) clientAddress(clientUserID uuid.UUID) string {
+	client, err := s.userRepository.GetByID(clientUserID)
+	if err != nil {
+		return ""
+	}
+	return client.Location.Address()
+}
+
+// mergeUpdates returns a copy of the schedule with the column updates used by
+// UpdateSchedule applied, so validators can inspect the resulting state.
+func mergeUpdates(existing *domainSchedule.Schedule, updates map[string]interface{}) *domainSchedule.Schedule {
+	candidate := *existing
+	if v, ok := updates["client_user_id"].(uuid.UUID); ok {
+		candidate.ClientUserID = v
+	}
+	if v, ok := updates["assigned_user_id"].(uuid.UUID); ok {
+		candidate.AssignedUserID = v
+	}
+	if v, ok := updates["service_name"].(string); ok {
+		candidate.ServiceName = v
+	}
+	if v, ok := updates["visit_status"].(string); ok {
+		candidate.VisitStatus = v
+	}
+	if v, ok := updates["scheduled_slot_from"].(time.Time); ok {
+		candidate.ScheduledSlot.From = v
+	}
+	if v, ok := updates["scheduled_slot_to"].(time.Time); ok {
+		candidate.ScheduledSlot.To = v
+	}
+	return &candidate
+}
+
+// affectsAssignment reports whether the updates change who or when a visit is
+// worked, which is what validators care about.
+func affectsAssignment(updates map[string]interface{}) bool {
+	for _, key := range []string{"client_user_id", "assigned_user_id", "scheduled_slot_from", "scheduled_slot_to"} {
+		if _, ok := updates[key]; ok {
+			return true
+		}
+	}
+	return false
+}
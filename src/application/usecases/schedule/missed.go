@@ -0,0 +1,29 @@
+package schedule
+
+import (
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"go.uber.org/zap"
+)
+
+// MarkMissedVisits moves every upcoming visit whose slot ended before now
+// without a check-in to "missed", logging and announcing each one with
+// EventMissed. It is run periodically by the background jobs.
+func (s *ScheduleUseCase) MarkMissedVisits(now time.Time) (*[]domainSchedule.Schedule, error) {
+	missed, err := s.scheduleRepository.MarkMissedBefore(now)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *missed {
+		schedule := &(*missed)[i]
+		s.Logger.Info("Visit missed",
+			zap.String("scheduleID", schedule.ID.String()),
+			zap.String("assignedUserID", schedule.AssignedUserID.String()),
+			zap.String("clientUserID", schedule.ClientUserID.String()),
+			zap.Time("slotTo", schedule.ScheduledSlot.To))
+		s.notify(domainSchedule.EventMissed, schedule, nil)
+	}
+	return missed, nil
+}
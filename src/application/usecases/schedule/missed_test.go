@@ -0,0 +1,42 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+func TestMarkMissedVisits(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	var markedBefore time.Time
+	mockScheduleRepo := &mockScheduleRepository{
+		markMissedBeforeFn: func(before time.Time) (*[]domainSchedule.Schedule, error) {
+			markedBefore = before
+			return &[]domainSchedule.Schedule{
+				{ID: uuid.New(), VisitStatus: "missed"},
+				{ID: uuid.New(), VisitStatus: "missed"},
+			}, nil
+		},
+	}
+	observer := &recordingObserver{}
+	useCase := NewScheduleUseCase(mockScheduleRepo, &mockUserRepository{}, setupLogger(t), WithObservers(observer))
+
+	missed, err := useCase.MarkMissedVisits(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !markedBefore.Equal(now) {
+		t.Errorf("expected visits ended before %v marked, got %v", now, markedBefore)
+	}
+	if len(*missed) != 2 || len(observer.events) != 2 {
+		t.Fatalf("expected 2 missed visits announced, got %d visits and %d events", len(*missed), len(observer.events))
+	}
+	for i, event := range observer.events {
+		if event.Type != domainSchedule.EventMissed || event.Schedule.ID != (*missed)[i].ID {
+			t.Errorf("expected a missed event for %s, got %+v", (*missed)[i].ID, event)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package schedule
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetOpenShifts lists up to MaxPageSize open shifts that have not started
+// yet, earliest first, with their clients. A caregiver only sees the shifts
+// they could take: each one is run through the schedule validators as if it
+// were assigned to them, which leaves out shifts outside their service area,
+// needing training they lack, during their leave or overlapping their other
+// visits. Admins and trusted callers see every open shift.
+func (s *ScheduleUseCase) GetOpenShifts(callerID *uuid.UUID, now time.Time) (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
+	var caregiver *domainUser.User
+	if callerID != nil {
+		caller, err := s.userRepository.GetByID(*callerID)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch caller.Role {
+		case domainUser.RoleAdmin:
+		case domainUser.RoleCaregiver:
+			caregiver = caller
+		default:
+			return nil, nil, domainErrors.NewAppError(errors.New("only caregivers and admins can browse open shifts"), domainErrors.NotAuthorized)
+		}
+	}
+
+	found, err := s.scheduleRepository.Search(domainSchedule.SearchQuery{
+		AssignedUserIDs: []uuid.UUID{uuid.Nil},
+		Statuses:        []string{"upcoming"},
+		From:            &now,
+		PageSize:        MaxPageSize,
+	})
+	if err != nil {
+		s.Logger.Error("Error searching open shifts", zap.Error(err))
+		return nil, nil, err
+	}
+	shifts := []domainSchedule.Schedule{}
+	for _, shift := range *found.Data {
+		if !shift.ScheduledSlot.From.After(now) {
+			continue
+		}
+		if caregiver != nil {
+			candidate := shift
+			candidate.AssignedUserID = caregiver.ID
+			if _, err := s.runValidators(&candidate); err != nil {
+				continue
+			}
+		}
+		shifts = append(shifts, shift)
+	}
+	clients := s.clientsOf(shifts)
+	return &shifts, &clients, nil
+}
+
+// ClaimOpenShift assigns an open shift to the caregiver, after the same
+// checks as any other assignment. Only the first of several caregivers
+// claiming the shift at once gets it; the others get a Conflict.
+func (s *ScheduleUseCase) ClaimOpenShift(scheduleID, caregiverID uuid.UUID, now time.Time) (*domainSchedule.Schedule, error) {
+	s.Logger.Info("Claiming open shift", zap.String("scheduleID", scheduleID.String()), zap.String("caregiverID", caregiverID.String()))
+	shift, err := s.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	caregiver, err := s.userRepository.GetByID(caregiverID)
+	if err != nil {
+		return nil, err
+	}
+	if caregiver.Role != domainUser.RoleCaregiver || !caregiver.Status {
+		return nil, domainErrors.NewAppError(errors.New("only active caregivers can claim open shifts"), domainErrors.NotAuthorized)
+	}
+	if !shift.Open() {
+		return nil, errAlreadyClaimed()
+	}
+	if shift.VisitStatus != "upcoming" || !shift.ScheduledSlot.From.After(now) {
+		return nil, domainErrors.NewAppError(errors.New("only open shifts that have not started can be claimed"), domainErrors.ValidationError)
+	}
+	if err := s.checkChange(shift); err != nil {
+		return nil, err
+	}
+
+	candidate := *shift
+	candidate.AssignedUserID = caregiverID
+	warnings, err := s.runValidators(&candidate)
+	if err != nil {
+		s.Logger.Warn("Open shift claim rejected by validator", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, err
+	}
+
+	claimed, err := s.scheduleRepository.ClaimOpenSchedule(scheduleID, caregiverID)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) && appErr.Type == domainErrors.Conflict {
+			return nil, errAlreadyClaimed()
+		}
+		return nil, err
+	}
+	claimed.Warnings = warnings
+	s.Logger.Info("Open shift claimed", zap.String("scheduleID", scheduleID.String()), zap.String("caregiverID", caregiverID.String()))
+	s.notify(domainSchedule.EventUpdated, claimed, shift)
+	return claimed, nil
+}
+
+func errAlreadyClaimed() error {
+	return domainErrors.NewAppError(errors.New("the shift has already been claimed"), domainErrors.Conflict)
+}
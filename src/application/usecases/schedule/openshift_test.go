@@ -0,0 +1,179 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+// ineligibleValidator rejects any schedule assigned to the given caregiver
+type ineligibleValidator struct {
+	caregiverID uuid.UUID
+}
+
+func (v ineligibleValidator) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.AssignedUserID == v.caregiverID {
+		return nil, domainErrors.NewAppError(errors.New("outside the caregiver's service area"), domainErrors.ValidationError)
+	}
+	return nil, nil
+}
+
+func openShiftUsers(users ...domainUser.User) *mockUserRepository {
+	return &mockUserRepository{
+		getByIDFn: func(id uuid.UUID) (*domainUser.User, error) {
+			for i := range users {
+				if users[i].ID == id {
+					return &users[i], nil
+				}
+			}
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		},
+	}
+}
+
+func TestGetOpenShifts(t *testing.T) {
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	clientID := uuid.New()
+	eligible := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	ineligible := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	admin := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin, Status: true}
+	client := domainUser.User{ID: clientID, Role: domainUser.RoleClient, Status: true}
+	shifts := []domainSchedule.Schedule{
+		{ID: uuid.New(), ClientUserID: clientID, VisitStatus: "upcoming", ScheduledSlot: domainSchedule.ScheduledSlot{From: now.Add(-time.Hour), To: now.Add(time.Hour)}},
+		{ID: uuid.New(), ClientUserID: clientID, VisitStatus: "upcoming", ScheduledSlot: domainSchedule.ScheduledSlot{From: now.Add(2 * time.Hour), To: now.Add(3 * time.Hour)}},
+	}
+	var query domainSchedule.SearchQuery
+	mockScheduleRepo := &mockScheduleRepository{
+		searchFn: func(q domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+			query = q
+			data := append([]domainSchedule.Schedule(nil), shifts...)
+			return &domainSchedule.SearchResultSchedule{Data: &data}, nil
+		},
+	}
+	useCase := NewScheduleUseCase(mockScheduleRepo, openShiftUsers(eligible, ineligible, admin, client), setupLogger(t),
+		WithValidators(ineligibleValidator{caregiverID: ineligible.ID}))
+
+	found, clients, err := useCase.GetOpenShifts(&eligible.ID, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(query.AssignedUserIDs) != 1 || query.AssignedUserIDs[0] != uuid.Nil {
+		t.Errorf("expected a search for unassigned visits, got %v", query.AssignedUserIDs)
+	}
+	if len(*found) != 1 || (*found)[0].ID != shifts[1].ID {
+		t.Errorf("expected only the shift that has not started, got %v", *found)
+	}
+	if len(*clients) != 1 || (*clients)[0].ID != clientID {
+		t.Errorf("expected the shift's client, got %v", *clients)
+	}
+	if (*found)[0].AssignedUserID != uuid.Nil {
+		t.Errorf("expected the listed shift to stay open")
+	}
+
+	if found, _, _ := useCase.GetOpenShifts(&ineligible.ID, now); len(*found) != 0 {
+		t.Errorf("expected no shifts for an ineligible caregiver, got %d", len(*found))
+	}
+	if found, _, _ := useCase.GetOpenShifts(&admin.ID, now); len(*found) != 1 {
+		t.Errorf("expected admins to see every open shift, got %d", len(*found))
+	}
+	if _, _, err := useCase.GetOpenShifts(&clientID, now); errorTypeOf(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected NotAuthorized for a client, got %v", err)
+	}
+}
+
+func TestClaimOpenShift(t *testing.T) {
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	ineligible := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	inactive := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	admin := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin, Status: true}
+	shift := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: uuid.New(), VisitStatus: "upcoming",
+		ScheduledSlot: domainSchedule.ScheduledSlot{From: now.Add(time.Hour), To: now.Add(2 * time.Hour)}}
+
+	setup := func(claimErr error) (*mockScheduleRepository, *recordingObserver, IScheduleUseCase) {
+		current := *shift
+		repo := &mockScheduleRepository{
+			getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+				copied := current
+				return &copied, nil
+			},
+			claimOpenScheduleFn: func(id uuid.UUID, assignedUserID uuid.UUID) (*domainSchedule.Schedule, error) {
+				if claimErr != nil {
+					return nil, claimErr
+				}
+				if !current.Open() {
+					return nil, domainErrors.NewAppErrorWithType(domainErrors.Conflict)
+				}
+				current.AssignedUserID = assignedUserID
+				claimed := current
+				return &claimed, nil
+			},
+		}
+		observer := &recordingObserver{}
+		useCase := NewScheduleUseCase(repo, openShiftUsers(caregiver, ineligible, inactive, admin), setupLogger(t),
+			WithValidators(ineligibleValidator{caregiverID: ineligible.ID}), WithObservers(observer))
+		return repo, observer, useCase
+	}
+
+	t.Run("first claim wins", func(t *testing.T) {
+		_, observer, useCase := setup(nil)
+		claimed, err := useCase.ClaimOpenShift(shift.ID, caregiver.ID, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if claimed.AssignedUserID != caregiver.ID {
+			t.Errorf("expected the shift assigned to the caregiver, got %s", claimed.AssignedUserID)
+		}
+		if len(observer.events) != 1 || observer.events[0].Type != domainSchedule.EventUpdated || !observer.events[0].Previous.Open() {
+			t.Errorf("expected one update event from the open shift, got %v", observer.events)
+		}
+		if _, err := useCase.ClaimOpenShift(shift.ID, ineligible.ID, now); errorTypeOf(err) != domainErrors.Conflict {
+			t.Errorf("expected Conflict for a second claim, got %v", err)
+		}
+	})
+
+	t.Run("lost race", func(t *testing.T) {
+		_, observer, useCase := setup(domainErrors.NewAppErrorWithType(domainErrors.Conflict))
+		if _, err := useCase.ClaimOpenShift(shift.ID, caregiver.ID, now); errorTypeOf(err) != domainErrors.Conflict {
+			t.Errorf("expected Conflict, got %v", err)
+		}
+		if len(observer.events) != 0 {
+			t.Errorf("expected no event for a lost claim")
+		}
+	})
+
+	t.Run("rejected claims", func(t *testing.T) {
+		repo, _, useCase := setup(nil)
+		claims := 0
+		claim := repo.claimOpenScheduleFn
+		repo.claimOpenScheduleFn = func(id uuid.UUID, assignedUserID uuid.UUID) (*domainSchedule.Schedule, error) {
+			claims++
+			return claim(id, assignedUserID)
+		}
+		tests := []struct {
+			name     string
+			callerID uuid.UUID
+			now      time.Time
+			want     domainErrors.ErrorType
+		}{
+			{"ineligible caregiver", ineligible.ID, now, domainErrors.ValidationError},
+			{"inactive caregiver", inactive.ID, now, domainErrors.NotAuthorized},
+			{"admin", admin.ID, now, domainErrors.NotAuthorized},
+			{"shift started", caregiver.ID, now.Add(90 * time.Minute), domainErrors.ValidationError},
+		}
+		for _, tt := range tests {
+			if _, err := useCase.ClaimOpenShift(shift.ID, tt.callerID, tt.now); errorTypeOf(err) != tt.want {
+				t.Errorf("%s: expected %s, got %v", tt.name, tt.want, err)
+			}
+		}
+		if claims != 0 {
+			t.Errorf("expected no claim to reach the repository, got %d", claims)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package schedule
+
+import (
+	"errors"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AuthorizeOperator checks that the caller may check in to or out of a visit:
+// only the caregiver assigned to it, or an admin, may.
+func (s *ScheduleUseCase) AuthorizeOperator(scheduleID, callerID uuid.UUID) error {
+	schedule, err := s.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return err
+	}
+	if schedule.AssignedUserID == callerID {
+		return nil
+	}
+	caller, err := s.userRepository.GetByID(callerID)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Type != domainErrors.NotFound {
+			return err
+		}
+	} else if caller.Role == domainUser.RoleAdmin {
+		return nil
+	}
+	s.Logger.Warn("Caller is not assigned to the visit",
+		zap.String("scheduleID", scheduleID.String()),
+		zap.String("callerID", callerID.String()),
+		zap.String("assignedUserID", schedule.AssignedUserID.String()))
+	return domainErrors.NewAppError(errors.New("only the assigned caregiver or an admin can check in to or out of this visit"), domainErrors.NotAuthorized)
+}
@@ -0,0 +1,60 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+func TestAuthorizeOperator(t *testing.T) {
+	caregiverID, otherCaregiverID, adminID := uuid.New(), uuid.New(), uuid.New()
+	schedule := &domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiverID}
+	roles := map[uuid.UUID]string{
+		caregiverID:      domainUser.RoleCaregiver,
+		otherCaregiverID: domainUser.RoleCaregiver,
+		adminID:          domainUser.RoleAdmin,
+	}
+	mockScheduleRepo := &mockScheduleRepository{
+		getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return schedule, nil
+		},
+	}
+	mockUserRepo := &mockUserRepository{
+		getByIDFn: func(id uuid.UUID) (*domainUser.User, error) {
+			role, ok := roles[id]
+			if !ok {
+				return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+			}
+			return &domainUser.User{ID: id, Role: role}, nil
+		},
+	}
+	useCase := NewScheduleUseCase(mockScheduleRepo, mockUserRepo, setupLogger(t))
+
+	tests := []struct {
+		name     string
+		callerID uuid.UUID
+		allowed  bool
+	}{
+		{"assigned caregiver", caregiverID, true},
+		{"admin", adminID, true},
+		{"another caregiver", otherCaregiverID, false},
+		{"unknown user", uuid.New(), false},
+	}
+	for _, tt := range tests {
+		err := useCase.AuthorizeOperator(schedule.ID, tt.callerID)
+		if tt.allowed && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !tt.allowed {
+			var appErr *domainErrors.AppError
+			if !errors.As(err, &appErr) || appErr.Type != domainErrors.NotAuthorized {
+				t.Errorf("%s: expected NotAuthorized, got %v", tt.name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CreateRecurringSchedule books the first visit and every later occurrence of
+// the recurrence as visits of one series. Occurrences keep the first visit's
+// local time in the organization's time zone, and later ones falling on a
+// holiday are left out when the organization skips holidays; the first visit
+// carries a warning for each. Every occurrence goes through the same checks
+// as CreateSchedule before any is saved, all are saved in one transaction,
+// and observers hear of them only once the whole series is booked.
+func (s *ScheduleUseCase) CreateRecurringSchedule(first *domainSchedule.Schedule, recurrence domainSchedule.Recurrence) (*[]domainSchedule.Schedule, error) {
+	s.Logger.Info("Creating recurring schedule", zap.String("clientUserID", first.ClientUserID.String()), zap.String("frequency", recurrence.Frequency), zap.Int("interval", recurrence.Interval))
+	if err := validateRecurrence(recurrence, first.ScheduledSlot.From); err != nil {
+		return nil, err
+	}
+	calendar, err := s.calendarFor(first.ScheduledSlot.From, recurrence.Until.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	local := recurrence
+	local.Until = time.Date(recurrence.Until.Year(), recurrence.Until.Month(), recurrence.Until.Day(), 0, 0, 0, 0, calendar.Location)
+	starts := local.Occurrences(calendar.Local(first.ScheduledSlot.From))
+	if len(starts) > domainSchedule.MaxOccurrences {
+		return nil, domainErrors.NewAppError(fmt.Errorf("a recurring schedule may create at most %d visits", domainSchedule.MaxOccurrences), domainErrors.ValidationError)
+	}
+
+	seriesID := uuid.New()
+	occurrences := make([]*domainSchedule.Schedule, 0, len(starts))
+	warnings := make([][]string, 0, len(starts))
+	var skipped []string
+	for i, start := range starts {
+		if i > 0 && calendar.Closed(start) {
+			holiday, _ := calendar.HolidayOn(start)
+			skipped = append(skipped, fmt.Sprintf("no visit on %s (%s)", holiday.Date, holiday.Name))
+			continue
+		}
+		occurrence := first.Occurrence(start.UTC())
+		occurrence.SeriesID = &seriesID
+		occurrence.Recurrence = &recurrence
+		occurrenceWarnings, err := s.prepareSchedule(occurrence)
+		if err != nil {
+			s.Logger.Warn("Recurring schedule rejected", zap.Error(err), zap.String("seriesID", seriesID.String()), zap.Time("occurrence", start))
+			return nil, err
+		}
+		occurrences = append(occurrences, occurrence)
+		warnings = append(warnings, occurrenceWarnings)
+	}
+
+	created := make([]domainSchedule.Schedule, 0, len(occurrences))
+	err = s.scheduleRepository.Transaction(func(repo domainSchedule.IScheduleRepository) error {
+		for i, occurrence := range occurrences {
+			schedule, err := repo.Create(occurrence)
+			if err != nil {
+				s.Logger.Error("Error creating occurrence", zap.Error(err), zap.String("seriesID", seriesID.String()), zap.Time("occurrence", occurrence.ScheduledSlot.From))
+				return err
+			}
+			schedule.Warnings = warnings[i]
+			created = append(created, *schedule)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range created {
+		s.notify(domainSchedule.EventCreated, &created[i], nil)
+	}
+	created[0].Warnings = append(created[0].Warnings, skipped...)
+	s.Logger.Info("Recurring schedule created", zap.String("seriesID", seriesID.String()), zap.Int("occurrences", len(created)))
+	return &created, nil
+}
+
+// UpdateOccurrence edits a single visit of a series and takes it out of the
+// series, so later edits to all future occurrences leave it as it is.
+func (s *ScheduleUseCase) UpdateOccurrence(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+	s.Logger.Info("Updating one occurrence", zap.String("scheduleID", scheduleID.String()))
+	if _, err := s.seriesOf(scheduleID); err != nil {
+		return nil, err
+	}
+	detached := make(map[string]interface{}, len(updates)+4)
+	for key, value := range updates {
+		detached[key] = value
+	}
+	detached["series_id"] = nil
+	detached["recurrence_frequency"] = ""
+	detached["recurrence_interval"] = 0
+	detached["recurrence_until"] = nil
+	return s.UpdateSchedule(scheduleID, detached)
+}
+
+// UpdateFollowingOccurrences applies the updates to the visit and every
+// later upcoming visit of its series. A new slot moves each occurrence by as
+// much as the given visit moves and gives it the new length. All occurrences
+// are checked before any is changed, all are changed in one transaction, and
+// observers hear of the changes once it commits.
+func (s *ScheduleUseCase) UpdateFollowingOccurrences(scheduleID uuid.UUID, updates map[string]interface{}) (*[]domainSchedule.Schedule, error) {
+	s.Logger.Info("Updating following occurrences", zap.String("scheduleID", scheduleID.String()))
+	existing, err := s.seriesOf(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	occurrences, err := s.scheduleRepository.GetUpcomingSeriesSchedules(*existing.SeriesID, existing.ScheduledSlot.From)
+	if err != nil {
+		return nil, err
+	}
+	if len(*occurrences) == 0 {
+		return nil, domainErrors.NewAppError(errors.New("the series has no upcoming visits from this one on"), domainErrors.ValidationError)
+	}
+
+	planned := make([]map[string]interface{}, len(*occurrences))
+	warnings := make([][]string, len(*occurrences))
+	for i := range *occurrences {
+		occurrence := &(*occurrences)[i]
+		planned[i] = shiftUpdates(updates, existing.ScheduledSlot, occurrence.ScheduledSlot)
+		if warnings[i], err = s.checkUpdate(occurrence, planned[i]); err != nil {
+			s.Logger.Warn("Series update rejected", zap.Error(err), zap.String("scheduleID", occurrence.ID.String()))
+			return nil, err
+		}
+	}
+
+	updated := make([]domainSchedule.Schedule, 0, len(planned))
+	err = s.scheduleRepository.Transaction(func(repo domainSchedule.IScheduleRepository) error {
+		for i := range *occurrences {
+			schedule, err := repo.UpdateSchedule((*occurrences)[i].ID, planned[i])
+			if err != nil {
+				s.Logger.Error("Error updating occurrence", zap.Error(err), zap.String("scheduleID", (*occurrences)[i].ID.String()))
+				return err
+			}
+			schedule.Warnings = warnings[i]
+			updated = append(updated, *schedule)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range updated {
+		s.notifyUpdate(&updated[i], &(*occurrences)[i])
+	}
+	return &updated, nil
+}
+
+// seriesOf returns the schedule, which must belong to a recurring series.
+func (s *ScheduleUseCase) seriesOf(scheduleID uuid.UUID) (*domainSchedule.Schedule, error) {
+	schedule, err := s.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		s.Logger.Error("Schedule not found for series update", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppError(errors.New("schedule not found"), domainErrors.NotFound)
+	}
+	if schedule.SeriesID == nil {
+		return nil, domainErrors.NewAppError(errors.New("schedule is not part of a recurring series"), domainErrors.ValidationError)
+	}
+	return schedule, nil
+}
+
+// shiftUpdates copies updates for one occurrence, moving a new slot by the
+// change made to the edited visit's slot.
+func shiftUpdates(updates map[string]interface{}, edited, occurrence domainSchedule.ScheduledSlot) map[string]interface{} {
+	shifted := make(map[string]interface{}, len(updates))
+	for key, value := range updates {
+		shifted[key] = value
+	}
+	from, hasFrom := updates["scheduled_slot_from"].(time.Time)
+	to, hasTo := updates["scheduled_slot_to"].(time.Time)
+	if hasFrom && hasTo {
+		start := occurrence.From.Add(from.Sub(edited.From))
+		shifted["scheduled_slot_from"] = start
+		shifted["scheduled_slot_to"] = start.Add(to.Sub(from))
+	}
+	return shifted
+}
+
+func validateRecurrence(recurrence domainSchedule.Recurrence, first time.Time) error {
+	switch recurrence.Frequency {
+	case domainSchedule.RecurrenceDaily, domainSchedule.RecurrenceWeekly, domainSchedule.RecurrenceMonthly:
+	default:
+		return domainErrors.NewAppError(fmt.Errorf("frequency must be one of %s, %s or %s", domainSchedule.RecurrenceDaily, domainSchedule.RecurrenceWeekly, domainSchedule.RecurrenceMonthly), domainErrors.ValidationError)
+	}
+	if recurrence.Interval < 1 {
+		return domainErrors.NewAppError(errors.New("interval must be at least 1"), domainErrors.ValidationError)
+	}
+	if recurrence.Until.IsZero() || recurrence.Until.Before(first.Truncate(24*time.Hour)) {
+		return domainErrors.NewAppError(errors.New("until must not be before the first visit"), domainErrors.ValidationError)
+	}
+	return nil
+}
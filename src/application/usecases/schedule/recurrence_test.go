@@ -0,0 +1,302 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+// rejectingValidator blocks any schedule starting at the given time
+type rejectingValidator struct {
+	from time.Time
+}
+
+func (v rejectingValidator) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.ScheduledSlot.From.Equal(v.from) {
+		return nil, domainErrors.NewAppError(errors.New("caregiver is busy"), domainErrors.ResourceAlreadyExists)
+	}
+	return nil, nil
+}
+
+// inMemorySchedules backs a mockScheduleRepository with a map
+func inMemorySchedules(schedules map[uuid.UUID]*domainSchedule.Schedule) *mockScheduleRepository {
+	return &mockScheduleRepository{
+		createFn: func(newSchedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error) {
+			created := *newSchedule
+			created.ID = uuid.New()
+			schedules[created.ID] = &created
+			return &created, nil
+		},
+		getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			schedule, ok := schedules[id]
+			if !ok {
+				return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+			}
+			copied := *schedule
+			return &copied, nil
+		},
+		deleteFn: func(id uuid.UUID, deletedBy *uuid.UUID) error {
+			delete(schedules, id)
+			return nil
+		},
+		updateScheduleFn: func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			schedule := mergeUpdates(schedules[id], updates)
+			if _, ok := updates["series_id"]; ok {
+				schedule.SeriesID, schedule.Recurrence = nil, nil
+			}
+			schedules[id] = schedule
+			return schedule, nil
+		},
+		getUpcomingSeriesSchedulesFn: func(seriesID uuid.UUID, from time.Time) (*[]domainSchedule.Schedule, error) {
+			var upcoming []domainSchedule.Schedule
+			for _, schedule := range schedules {
+				if schedule.SeriesID != nil && *schedule.SeriesID == seriesID && !schedule.ScheduledSlot.From.Before(from) {
+					upcoming = append(upcoming, *schedule)
+				}
+			}
+			return &upcoming, nil
+		},
+	}
+}
+
+// fixedCalendar serves the same calendar for any range
+type fixedCalendar struct {
+	calendar *domainLocale.Calendar
+}
+
+func (c fixedCalendar) Calendar(from, to time.Time) (*domainLocale.Calendar, error) {
+	return c.calendar, nil
+}
+
+func anyUser() *mockUserRepository {
+	return &mockUserRepository{
+		getByIDFn: func(id uuid.UUID) (*domainUser.User, error) {
+			return &domainUser.User{ID: id}, nil
+		},
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	first := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name       string
+		recurrence domainSchedule.Recurrence
+		expected   []time.Time
+	}{
+		{"Every other day", domainSchedule.Recurrence{Frequency: domainSchedule.RecurrenceDaily, Interval: 2, Until: time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)},
+			[]time.Time{first, first.AddDate(0, 0, 2), first.AddDate(0, 0, 4)}},
+		{"Weekly", domainSchedule.Recurrence{Frequency: domainSchedule.RecurrenceWeekly, Interval: 1, Until: time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)},
+			[]time.Time{first, first.AddDate(0, 0, 7), first.AddDate(0, 0, 14)}},
+		{"Monthly skips short months", domainSchedule.Recurrence{Frequency: domainSchedule.RecurrenceMonthly, Interval: 1, Until: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)},
+			[]time.Time{first, time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			starts := tc.recurrence.Occurrences(first)
+			if len(starts) != len(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, starts)
+			}
+			for i := range starts {
+				if !starts[i].Equal(tc.expected[i]) {
+					t.Errorf("expected %v, got %v", tc.expected, starts)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateRecurringSchedule(t *testing.T) {
+	first := &domainSchedule.Schedule{
+		ClientUserID:   uuid.New(),
+		AssignedUserID: uuid.New(),
+		ServiceName:    "Companionship",
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 2, 11, 0, 0, 0, time.UTC)},
+		Tasks:          []domainSchedule.Task{{Title: "Lunch"}},
+	}
+	weekly := domainSchedule.Recurrence{Frequency: domainSchedule.RecurrenceWeekly, Interval: 1, Until: time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("Invalid rules", func(t *testing.T) {
+		useCase := NewScheduleUseCase(inMemorySchedules(map[uuid.UUID]*domainSchedule.Schedule{}), anyUser(), setupLogger(t))
+		for _, recurrence := range []domainSchedule.Recurrence{
+			{Frequency: "yearly", Interval: 1, Until: weekly.Until},
+			{Frequency: domainSchedule.RecurrenceWeekly, Until: weekly.Until},
+			{Frequency: domainSchedule.RecurrenceWeekly, Interval: 1, Until: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+			{Frequency: domainSchedule.RecurrenceDaily, Interval: 1, Until: time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)},
+		} {
+			if _, err := useCase.CreateRecurringSchedule(first, recurrence); errorTypeOf(err) != domainErrors.ValidationError {
+				t.Errorf("expected validation error for %+v, got %v", recurrence, err)
+			}
+		}
+	})
+
+	t.Run("Materializes occurrences", func(t *testing.T) {
+		schedules := map[uuid.UUID]*domainSchedule.Schedule{}
+		useCase := NewScheduleUseCase(inMemorySchedules(schedules), anyUser(), setupLogger(t))
+		created, err := useCase.CreateRecurringSchedule(first, weekly)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(*created) != 5 || len(schedules) != 5 {
+			t.Fatalf("expected 5 weekly visits, got %d", len(*created))
+		}
+		last := (*created)[4]
+		if !last.ScheduledSlot.To.Equal(time.Date(2026, 3, 30, 11, 0, 0, 0, time.UTC)) || last.SeriesID == nil || *last.SeriesID != *(*created)[0].SeriesID {
+			t.Errorf("expected the last visit on March 30 in the same series, got %+v", last)
+		}
+		if len(last.Tasks) != 1 || last.Tasks[0].ID == first.Tasks[0].ID {
+			t.Errorf("expected each occurrence to get its own tasks, got %+v", last.Tasks)
+		}
+	})
+
+	t.Run("Keeps local time and skips holidays", func(t *testing.T) {
+		calendar := domainLocale.NewCalendar(&domainLocale.Settings{Timezone: "America/New_York", SkipHolidays: true},
+			[]domainLocale.Holiday{{Date: "2026-03-16", Name: "Agency closure", Custom: true}})
+		local := *first
+		local.ScheduledSlot = domainSchedule.ScheduledSlot{From: time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 2, 16, 0, 0, 0, time.UTC)}
+		useCase := NewScheduleUseCase(inMemorySchedules(map[uuid.UUID]*domainSchedule.Schedule{}), anyUser(), setupLogger(t), WithCalendar(fixedCalendar{calendar}))
+		created, err := useCase.CreateRecurringSchedule(&local, weekly)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []time.Time{
+			time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 23, 13, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 30, 13, 0, 0, 0, time.UTC),
+		}
+		if len(*created) != len(expected) {
+			t.Fatalf("expected %d visits, got %d", len(expected), len(*created))
+		}
+		for i, visit := range *created {
+			if !visit.ScheduledSlot.From.Equal(expected[i]) || visit.ScheduledSlot.To.Sub(visit.ScheduledSlot.From) != 2*time.Hour {
+				t.Errorf("expected visit %d at 9am New York time, got %v", i, visit.ScheduledSlot)
+			}
+		}
+		if warnings := (*created)[0].Warnings; len(warnings) != 1 || warnings[0] != "no visit on 2026-03-16 (Agency closure)" {
+			t.Errorf("expected the skipped holiday on the first visit, got %v", warnings)
+		}
+	})
+
+	t.Run("Rejected occurrence books nothing", func(t *testing.T) {
+		schedules := map[uuid.UUID]*domainSchedule.Schedule{}
+		busy := rejectingValidator{from: time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)}
+		observer := &recordingObserver{}
+		useCase := NewScheduleUseCase(inMemorySchedules(schedules), anyUser(), setupLogger(t), WithValidators(busy), WithObservers(observer))
+		if _, err := useCase.CreateRecurringSchedule(first, weekly); errorTypeOf(err) != domainErrors.ResourceAlreadyExists {
+			t.Fatalf("expected the conflict, got %v", err)
+		}
+		if len(schedules) != 0 || len(observer.events) != 0 {
+			t.Errorf("expected no visits booked or announced, got %d visits and %d events", len(schedules), len(observer.events))
+		}
+	})
+
+	t.Run("Failed insert rolls back quietly", func(t *testing.T) {
+		repo := inMemorySchedules(map[uuid.UUID]*domainSchedule.Schedule{})
+		created := repo.createFn
+		inserts := 0
+		repo.createFn = func(newSchedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error) {
+			if inserts++; inserts == 3 {
+				return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+			}
+			return created(newSchedule)
+		}
+		observer := &recordingObserver{}
+		useCase := NewScheduleUseCase(repo, anyUser(), setupLogger(t), WithObservers(observer))
+		if _, err := useCase.CreateRecurringSchedule(first, weekly); errorTypeOf(err) != domainErrors.UnknownError {
+			t.Fatalf("expected the insert error, got %v", err)
+		}
+		if repo.rollbacks != 1 || len(observer.events) != 0 {
+			t.Errorf("expected one rollback and no events, got %d rollbacks and %v", repo.rollbacks, observer.events)
+		}
+	})
+
+	t.Run("Announces each visit once booked", func(t *testing.T) {
+		observer := &recordingObserver{}
+		useCase := NewScheduleUseCase(inMemorySchedules(map[uuid.UUID]*domainSchedule.Schedule{}), anyUser(), setupLogger(t), WithObservers(observer))
+		created, err := useCase.CreateRecurringSchedule(first, weekly)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(observer.events) != len(*created) || observer.events[0].Type != domainSchedule.EventCreated {
+			t.Errorf("expected a created event per visit, got %v", observer.events)
+		}
+	})
+}
+
+func TestUpdateOccurrences(t *testing.T) {
+	seriesID := uuid.New()
+	schedules := map[uuid.UUID]*domainSchedule.Schedule{}
+	ids := make([]uuid.UUID, 4)
+	for i := range ids {
+		ids[i] = uuid.New()
+		from := time.Date(2026, 3, 2+7*i, 9, 0, 0, 0, time.UTC)
+		schedules[ids[i]] = &domainSchedule.Schedule{
+			ID:            ids[i],
+			VisitStatus:   "upcoming",
+			SeriesID:      &seriesID,
+			ScheduledSlot: domainSchedule.ScheduledSlot{From: from, To: from.Add(2 * time.Hour)},
+		}
+	}
+	oneOff := uuid.New()
+	schedules[oneOff] = &domainSchedule.Schedule{ID: oneOff, VisitStatus: "upcoming"}
+	repo := inMemorySchedules(schedules)
+	observer := &recordingObserver{}
+	useCase := NewScheduleUseCase(repo, anyUser(), setupLogger(t), WithObservers(observer))
+
+	if _, err := useCase.UpdateFollowingOccurrences(oneOff, map[string]interface{}{"service_name": "Respite"}); errorTypeOf(err) != domainErrors.ValidationError {
+		t.Errorf("expected a one-off visit to be rejected, got %v", err)
+	}
+
+	edited, err := useCase.UpdateOccurrence(ids[3], map[string]interface{}{"service_name": "Respite"})
+	if err != nil || edited.SeriesID != nil || edited.ServiceName != "Respite" {
+		t.Fatalf("expected the occurrence edited and detached, got %+v, %v", edited, err)
+	}
+
+	later := time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC)
+	updated, err := useCase.UpdateFollowingOccurrences(ids[1], map[string]interface{}{
+		"scheduled_slot_from": later,
+		"scheduled_slot_to":   later.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*updated) != 2 {
+		t.Fatalf("expected the edited and the following attached visit updated, got %d", len(*updated))
+	}
+	moved := schedules[ids[2]].ScheduledSlot
+	if !moved.From.Equal(time.Date(2026, 3, 16, 13, 0, 0, 0, time.UTC)) || moved.To.Sub(moved.From) != time.Hour {
+		t.Errorf("expected the following visit moved to 13:00 for an hour, got %+v", moved)
+	}
+	if !schedules[ids[0]].ScheduledSlot.From.Equal(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)) || !schedules[ids[3]].ScheduledSlot.From.Equal(time.Date(2026, 3, 23, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected earlier and detached visits left alone")
+	}
+
+	observer.events = nil
+	updateSchedule := repo.updateScheduleFn
+	repo.updateScheduleFn = func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+		if id == ids[2] {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		return updateSchedule(id, updates)
+	}
+	if _, err := useCase.UpdateFollowingOccurrences(ids[1], map[string]interface{}{"service_name": "Respite"}); errorTypeOf(err) != domainErrors.UnknownError {
+		t.Fatalf("expected the update error, got %v", err)
+	}
+	if repo.rollbacks != 1 || len(observer.events) != 0 {
+		t.Errorf("expected the series update rolled back quietly, got %d rollbacks and %v", repo.rollbacks, observer.events)
+	}
+}
+
+func errorTypeOf(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
@@ -2,10 +2,13 @@ package schedule
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"caregiver/src/domain"
 	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
 	domainSchedule "caregiver/src/domain/schedule"
 	domainUser "caregiver/src/domain/user"
 	logger "caregiver/src/infrastructure/logger"
@@ -14,35 +17,67 @@ import (
 	"go.uber.org/zap"
 )
 
+// MaxPageSize caps a page of the schedule listing.
+const MaxPageSize = 100
+
 type IScheduleUseCase interface {
 	GetSchedules() (*[]domainSchedule.Schedule, error)
-	GetSchedulesWithClientInfo() (*[]domainSchedule.Schedule, *[]domainUser.User, error)
+	GetSchedulesWithClientInfo(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, *[]domainUser.User, error)
+	StreamSchedulesWithClientInfo(filter domainSchedule.ListFilter, fn func(*domainSchedule.Schedule, *domainUser.User) error) error
+	GetSchedulesWithClientInfoPaginated(filters domain.DataFilters, filter domainSchedule.ListFilter) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error)
 	GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error)
 	GetScheduleWithClientInfo(id uuid.UUID) (*domainSchedule.Schedule, *domainUser.User, error)
 	GetTodaySchedules(userID uuid.UUID) (*[]domainSchedule.Schedule, error)
 	GetTodaySchedulesWithClientInfo(userID uuid.UUID) (*[]domainSchedule.Schedule, *[]domainUser.User, error)
-	StartSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location) (*domainSchedule.Schedule, error)
+	StartSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, verification domainSchedule.Verification) (*domainSchedule.Schedule, error)
 	EndSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, tasks []domainSchedule.Task) (*domainSchedule.Schedule, error)
-	UpdateTaskStatus(taskID uuid.UUID, status string, done bool, feedback string) (*domainSchedule.Task, error)
+	UpdateTaskStatus(taskID uuid.UUID, status string, done bool, feedback string, vitals *domainSchedule.Vitals) (*domainSchedule.Task, error)
 	UpdateSchedule(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error)
 	CreateSchedule(newSchedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error)
+	CreateRecurringSchedule(first *domainSchedule.Schedule, recurrence domainSchedule.Recurrence) (*[]domainSchedule.Schedule, error)
+	UpdateOccurrence(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error)
+	UpdateFollowingOccurrences(scheduleID uuid.UUID, updates map[string]interface{}) (*[]domainSchedule.Schedule, error)
 	GetTodaySchedulesByAssignedUserID(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error)
 	GetTodaySchedulesByAssignedUserIDWithClientInfo(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, *[]domainUser.User, error)
 	GetSchedulesInProgressByAssignedUserID(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error)
+	SearchSchedules(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error)
+	DeleteSchedule(scheduleID uuid.UUID, deletedBy *uuid.UUID) error
+	RestoreSchedule(scheduleID uuid.UUID) (*domainSchedule.Schedule, error)
+	DeleteTask(taskID uuid.UUID) error
+	RestoreTask(taskID uuid.UUID) (*domainSchedule.Task, error)
+	MarkMissedVisits(now time.Time) (*[]domainSchedule.Schedule, error)
+	CloseStuckVisits(before time.Time) (*[]domainSchedule.Schedule, error)
+	AuthorizeOperator(scheduleID, callerID uuid.UUID) error
+	GetOpenShifts(callerID *uuid.UUID, now time.Time) (*[]domainSchedule.Schedule, *[]domainUser.User, error)
+	ClaimOpenShift(scheduleID, caregiverID uuid.UUID, now time.Time) (*domainSchedule.Schedule, error)
+	PublishDrafts(scheduleIDs []uuid.UUID, now time.Time) (*domainSchedule.PublishResult, error)
+	GetClientTaskHistory(clientUserID uuid.UUID, from, to time.Time, period string) ([]domainSchedule.TaskHistory, error)
 }
 
 type ScheduleUseCase struct {
 	scheduleRepository domainSchedule.IScheduleRepository
 	userRepository     domainUser.IUserRepository
+	validators         []ScheduleValidator
+	observers          []ScheduleObserver
+	checkinGuards      []CheckinGuard
+	changeGuards       []ChangeGuard
+	viewResolver       ViewResolver
+	teamResolver       TeamResolver
+	calendar           CalendarSource
+	confidenceWeights  domainSchedule.ConfidenceWeights
 	Logger             *logger.Logger
 }
 
-func NewScheduleUseCase(scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, logger *logger.Logger) IScheduleUseCase {
-	return &ScheduleUseCase{
+func NewScheduleUseCase(scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, logger *logger.Logger, opts ...Option) IScheduleUseCase {
+	useCase := &ScheduleUseCase{
 		scheduleRepository: scheduleRepository,
 		userRepository:     userRepository,
 		Logger:             logger,
 	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
 }
 
 func (s *ScheduleUseCase) GetSchedules() (*[]domainSchedule.Schedule, error) {
@@ -63,10 +98,14 @@ func (s *ScheduleUseCase) GetTodaySchedules(userID uuid.UUID) (*[]domainSchedule
 		s.Logger.Error("User not found for today's schedules", zap.Error(err), zap.String("userID", userID.String()))
 		return nil, domainErrors.NewAppError(errors.New("user not found"), domainErrors.NotFound)
 	}
-	return s.scheduleRepository.GetTodaySchedules(userID)
+	schedules, err := s.scheduleRepository.GetTodaySchedules(userID)
+	if err != nil {
+		return nil, err
+	}
+	return withoutDrafts(schedules), nil
 }
 
-func (s *ScheduleUseCase) StartSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location) (*domainSchedule.Schedule, error) {
+func (s *ScheduleUseCase) StartSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, verification domainSchedule.Verification) (*domainSchedule.Schedule, error) {
 	s.Logger.Info("Starting schedule", zap.String("scheduleID", scheduleID.String()))
 
 	schedule, err := s.scheduleRepository.GetScheduleByID(scheduleID)
@@ -74,6 +113,19 @@ func (s *ScheduleUseCase) StartSchedule(scheduleID uuid.UUID, timestamp time.Tim
 		s.Logger.Error("Schedule not found for start", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
 		return nil, err
 	}
+	if err := s.checkChange(schedule); err != nil {
+		return nil, err
+	}
+	if schedule.Open() {
+		return nil, domainErrors.NewAppError(errors.New("an open shift must be claimed before check-in"), domainErrors.ValidationError)
+	}
+	if schedule.Draft() {
+		return nil, domainErrors.NewAppError(errors.New("a draft must be published before check-in"), domainErrors.ValidationError)
+	}
+
+	if len(schedule.Segments) > 0 {
+		return s.startSegment(schedule, timestamp, location, verification)
+	}
 
 	if schedule.VisitStatus != "upcoming" {
 		s.Logger.Warn("Cannot start schedule, invalid status", zap.String("scheduleID", scheduleID.String()), zap.String("status", schedule.VisitStatus))
@@ -89,27 +141,23 @@ func (s *ScheduleUseCase) StartSchedule(scheduleID uuid.UUID, timestamp time.Tim
 		return nil, domainErrors.NewAppError(errors.New("cannot start schedule before the scheduled start time"), domainErrors.ValidationError)
 	}
 
-	// Check if there are any other schedules in progress for the same assigned user
-	schedulesInProgress, err := s.scheduleRepository.GetSchedulesInProgressByAssignedUserID(schedule.AssignedUserID)
-	if err != nil {
-		s.Logger.Error("Error checking for schedules in progress", zap.Error(err), zap.String("assignedUserID", schedule.AssignedUserID.String()))
+	if err := s.ensureNoScheduleInProgress(schedule); err != nil {
 		return nil, err
 	}
-
-	if schedulesInProgress != nil && len(*schedulesInProgress) > 0 {
-		s.Logger.Warn("Cannot start schedule, another schedule is already in progress",
-			zap.String("scheduleID", scheduleID.String()),
-			zap.String("assignedUserID", schedule.AssignedUserID.String()),
-			zap.Int("inProgressCount", len(*schedulesInProgress)))
-		return nil, domainErrors.NewAppError(errors.New("cannot start schedule: another schedule is already in progress for this user"), domainErrors.ValidationError)
+	if err := s.verifyCheckin(schedule, timestamp, location, &verification); err != nil {
+		return nil, err
 	}
 
 	updates := map[string]interface{}{
-		"visit_status":          "in_progress",
-		"checkin_time":          timestamp,
-		"checkin_location_lat":  location.Lat,
-		"checkin_location_long": location.Long,
+		"visit_status":                "in_progress",
+		"checkin_time":                timestamp,
+		"checkin_location_lat":        location.Lat,
+		"checkin_location_long":       location.Long,
+		"checkin_verification_method": verification.Method,
+		"checkin_nfc_tag_id":          verification.NFCTagID,
+		"checkin_kiosk_id":            verification.KioskID,
 	}
+	addDeviceHints(updates, verification)
 
 	updatedSchedule, err := s.scheduleRepository.UpdateSchedule(scheduleID, updates)
 	if err != nil {
@@ -117,6 +165,7 @@ func (s *ScheduleUseCase) StartSchedule(scheduleID uuid.UUID, timestamp time.Tim
 		return nil, err
 	}
 	s.Logger.Info("Schedule started successfully", zap.String("scheduleID", scheduleID.String()))
+	s.notify(domainSchedule.EventStarted, updatedSchedule, schedule)
 	return updatedSchedule, nil
 }
 
@@ -128,11 +177,21 @@ func (s *ScheduleUseCase) EndSchedule(scheduleID uuid.UUID, timestamp time.Time,
 		s.Logger.Error("Schedule not found for end", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
 		return nil, err
 	}
+	if err := s.checkChange(schedule); err != nil {
+		return nil, err
+	}
 
 	if schedule.VisitStatus != "in_progress" {
 		s.Logger.Warn("Cannot end schedule, invalid status", zap.String("scheduleID", scheduleID.String()), zap.String("status", schedule.VisitStatus))
 		return nil, domainErrors.NewAppError(errors.New("schedule is not in 'in_progress' status"), domainErrors.ValidationError)
 	}
+	if err := validateCheckoutTasks(schedule, tasks); err != nil {
+		return nil, err
+	}
+
+	if len(schedule.Segments) > 0 {
+		return s.endSegment(schedule, timestamp, location, tasks)
+	}
 
 	updates := map[string]interface{}{
 		"visit_status":           "completed",
@@ -140,40 +199,47 @@ func (s *ScheduleUseCase) EndSchedule(scheduleID uuid.UUID, timestamp time.Time,
 		"checkout_location_lat":  location.Lat,
 		"checkout_location_long": location.Long,
 	}
+	s.addConfidence(updates, schedule, timestamp, location)
 
-	updatedSchedule, err := s.scheduleRepository.UpdateSchedule(scheduleID, updates)
-	if err != nil {
-		s.Logger.Error("Error updating schedule for end", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
-		return nil, err
-	}
-
-	for _, task := range tasks {
-		_, err := s.scheduleRepository.UpdateTask(task.ID, map[string]interface{}{
-			"status":   task.Status,
-			"done":     task.Done,
-			"feedback": task.Feedback,
-		})
+	var updatedSchedule *domainSchedule.Schedule
+	err = s.scheduleRepository.Transaction(func(repo domainSchedule.IScheduleRepository) error {
+		updated, err := repo.UpdateSchedule(scheduleID, updates)
 		if err != nil {
-			s.Logger.Error("Error updating task during EndSchedule", zap.Error(err), zap.String("taskID", task.ID.String()))
-
+			s.Logger.Error("Error updating schedule for end", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+			return err
 		}
-	}
+		updatedSchedule = updated
+		return s.applyCheckoutTasks(repo, tasks)
+	})
 	if err != nil {
-		s.Logger.Error("Error updating schedule for end", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
 		return nil, err
 	}
 	s.Logger.Info("Schedule ended successfully", zap.String("scheduleID", scheduleID.String()))
+	s.notify(domainSchedule.EventCompleted, updatedSchedule, schedule)
 	return updatedSchedule, nil
 }
 
-func (s *ScheduleUseCase) UpdateTaskStatus(taskID uuid.UUID, status string, done bool, feedback string) (*domainSchedule.Task, error) {
+// UpdateTaskStatus records a task's outcome. Vitals tasks take their
+// readings here; other kinds take none.
+func (s *ScheduleUseCase) UpdateTaskStatus(taskID uuid.UUID, status string, done bool, feedback string, vitals *domainSchedule.Vitals) (*domainSchedule.Task, error) {
 	s.Logger.Info("Updating task status", zap.String("taskID", taskID.String()))
+	if err := s.checkTaskChange(taskID); err != nil {
+		return nil, err
+	}
+	task, err := s.scheduleRepository.GetTaskByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := task.ValidateOutcome(done, vitals); err != nil {
+		return nil, domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
 
 	updates := map[string]interface{}{
 		"Status":   status,
 		"Done":     done,
 		"Feedback": feedback,
 	}
+	addVitals(updates, vitals)
 
 	updatedTask, err := s.scheduleRepository.UpdateTask(taskID, updates)
 	if err != nil {
@@ -184,38 +250,139 @@ func (s *ScheduleUseCase) UpdateTaskStatus(taskID uuid.UUID, status string, done
 	return updatedTask, nil
 }
 
+// DeleteSchedule soft-deletes a visit. Observers see it as deleted, so
+// whatever it held, such as budget, is released. deletedBy, when known, is
+// shown in the admin trash.
+func (s *ScheduleUseCase) DeleteSchedule(scheduleID uuid.UUID, deletedBy *uuid.UUID) error {
+	s.Logger.Info("Deleting schedule", zap.String("scheduleID", scheduleID.String()))
+	schedule, err := s.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkChange(schedule); err != nil {
+		return err
+	}
+	if err := s.scheduleRepository.Delete(scheduleID, deletedBy); err != nil {
+		s.Logger.Error("Error deleting schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return err
+	}
+	s.notify(domainSchedule.EventDeleted, schedule, nil)
+	return nil
+}
+
+// RestoreSchedule brings back a soft-deleted visit, announced to observers as
+// created. The change guards see the restored visit and a rejection rolls the
+// restore back.
+func (s *ScheduleUseCase) RestoreSchedule(scheduleID uuid.UUID) (*domainSchedule.Schedule, error) {
+	s.Logger.Info("Restoring schedule", zap.String("scheduleID", scheduleID.String()))
+	var restored *domainSchedule.Schedule
+	err := s.scheduleRepository.Transaction(func(repo domainSchedule.IScheduleRepository) error {
+		schedule, err := repo.Restore(scheduleID)
+		if err != nil {
+			s.Logger.Error("Error restoring schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+			return err
+		}
+		restored = schedule
+		return s.checkChange(schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notify(domainSchedule.EventCreated, restored, nil)
+	return restored, nil
+}
+
+func (s *ScheduleUseCase) DeleteTask(taskID uuid.UUID) error {
+	s.Logger.Info("Deleting task", zap.String("taskID", taskID.String()))
+	if err := s.checkTaskChange(taskID); err != nil {
+		return err
+	}
+	return s.scheduleRepository.DeleteTask(taskID)
+}
+
+func (s *ScheduleUseCase) RestoreTask(taskID uuid.UUID) (*domainSchedule.Task, error) {
+	s.Logger.Info("Restoring task", zap.String("taskID", taskID.String()))
+	if err := s.checkTaskChange(taskID); err != nil {
+		return nil, err
+	}
+	return s.scheduleRepository.RestoreTask(taskID)
+}
+
 func (s *ScheduleUseCase) CreateSchedule(newSchedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error) {
 	s.Logger.Info("Creating new schedule", zap.String("clientUserID", newSchedule.ClientUserID.String()), zap.String("assignedUserID", newSchedule.AssignedUserID.String()))
 
+	warnings, err := s.prepareSchedule(newSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	createdSchedule, err := s.scheduleRepository.Create(newSchedule)
+	if err != nil {
+		s.Logger.Error("Error creating schedule in repository", zap.Error(err), zap.String("clientUserID", newSchedule.ClientUserID.String()))
+		return nil, err
+	}
+	createdSchedule.Warnings = warnings
+
+	s.Logger.Info("Schedule created successfully in use case", zap.String("scheduleID", createdSchedule.ID.String()))
+	s.notify(domainSchedule.EventCreated, createdSchedule, nil)
+	return createdSchedule, nil
+}
+
+// prepareSchedule checks a new visit before it is saved and fills in its
+// status and the IDs of its tasks and segments. It returns the validators'
+// warnings.
+func (s *ScheduleUseCase) prepareSchedule(newSchedule *domainSchedule.Schedule) ([]string, error) {
 	_, err := s.userRepository.GetByID(newSchedule.ClientUserID)
 	if err != nil {
 		s.Logger.Error("Client user not found for schedule creation", zap.Error(err), zap.String("clientUserID", newSchedule.ClientUserID.String()))
 		return nil, domainErrors.NewAppError(errors.New("client user not found"), domainErrors.NotFound)
 	}
 
-	_, err = s.userRepository.GetByID(newSchedule.AssignedUserID)
-	if err != nil {
-		s.Logger.Error("Assigned user not found for schedule creation", zap.Error(err), zap.String("assignedUserID", newSchedule.AssignedUserID.String()))
-		return nil, domainErrors.NewAppError(errors.New("assigned user not found"), domainErrors.NotFound)
+	// A visit without a caregiver is an open shift, left for caregivers to
+	// claim.
+	if !newSchedule.Open() {
+		_, err = s.userRepository.GetByID(newSchedule.AssignedUserID)
+		if err != nil {
+			s.Logger.Error("Assigned user not found for schedule creation", zap.Error(err), zap.String("assignedUserID", newSchedule.AssignedUserID.String()))
+			return nil, domainErrors.NewAppError(errors.New("assigned user not found"), domainErrors.NotFound)
+		}
 	}
 
-	newSchedule.VisitStatus = "upcoming"
+	// Drafts stay drafts until published.
+	if !newSchedule.Draft() {
+		newSchedule.VisitStatus = "upcoming"
+	}
 
 	for i := range newSchedule.Tasks {
-		if newSchedule.Tasks[i].ID == uuid.Nil {
-			newSchedule.Tasks[i].ID = uuid.New()
+		task := &newSchedule.Tasks[i]
+		if task.ID == uuid.Nil {
+			task.ID = uuid.New()
+		}
+		task.Status = "pending"
+		task.Normalize()
+		if err := task.Validate(newSchedule.ScheduledSlot); err != nil {
+			return nil, domainErrors.NewAppError(fmt.Errorf("task %q: %w", task.Title, err), domainErrors.ValidationError)
 		}
-		newSchedule.Tasks[i].Status = "pending"
 	}
 
-	createdSchedule, err := s.scheduleRepository.Create(newSchedule)
-	if err != nil {
-		s.Logger.Error("Error creating schedule in repository", zap.Error(err), zap.String("clientUserID", newSchedule.ClientUserID.String()))
+	if err := validateSegments(newSchedule.ScheduledSlot, newSchedule.Segments); err != nil {
+		s.Logger.Error("Invalid segments for schedule creation", zap.Error(err), zap.String("clientUserID", newSchedule.ClientUserID.String()))
 		return nil, err
 	}
+	for i := range newSchedule.Segments {
+		if newSchedule.Segments[i].ID == uuid.Nil {
+			newSchedule.Segments[i].ID = uuid.New()
+		}
+		newSchedule.Segments[i].Sequence = i + 1
+		newSchedule.Segments[i].VisitStatus = "upcoming"
+	}
 
-	s.Logger.Info("Schedule created successfully in use case", zap.String("scheduleID", createdSchedule.ID.String()))
-	return createdSchedule, nil
+	warnings, err := s.validateSchedule(newSchedule)
+	if err != nil {
+		s.Logger.Warn("Schedule rejected by validator", zap.Error(err), zap.String("clientUserID", newSchedule.ClientUserID.String()))
+		return nil, err
+	}
+	return warnings, nil
 }
 
 func (s *ScheduleUseCase) GetTodaySchedulesByAssignedUserID(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error) {
@@ -227,8 +394,8 @@ func (s *ScheduleUseCase) GetTodaySchedulesByAssignedUserID(assignedUserID uuid.
 		return nil, domainErrors.NewAppError(errors.New("assigned user not found"), domainErrors.NotFound)
 	}
 
-	now := time.Now()
-	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	now := time.Now().UTC()
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 	todayEnd := todayStart.Add(24 * time.Hour).Add(-time.Nanosecond) // End of today
 
 	filters := domain.DataFilters{
@@ -247,7 +414,7 @@ func (s *ScheduleUseCase) GetTodaySchedulesByAssignedUserID(assignedUserID uuid.
 		return nil, err
 	}
 
-	return schedulesResult.Data, nil
+	return withoutDrafts(schedulesResult.Data), nil
 }
 
 func (s *ScheduleUseCase) GetScheduleWithClientInfo(id uuid.UUID) (*domainSchedule.Schedule, *domainUser.User, error) {
@@ -328,10 +495,21 @@ func (s *ScheduleUseCase) GetTodaySchedulesByAssignedUserIDWithClientInfo(assign
 	return schedules, &clients, nil
 }
 
-func (s *ScheduleUseCase) GetSchedulesWithClientInfo() (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
+// GetSchedulesWithClientInfo returns the schedules the filter keeps, every
+// schedule when it is empty, with the clients they are for.
+func (s *ScheduleUseCase) GetSchedulesWithClientInfo(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
 	s.Logger.Info("Getting all schedules with client info")
+	filter, err := s.resolveListFilter(filter)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	schedules, err := s.scheduleRepository.GetSchedules()
+	var schedules *[]domainSchedule.Schedule
+	if filter.IsEmpty() {
+		schedules, err = s.scheduleRepository.GetSchedules()
+	} else {
+		schedules, err = s.scheduleRepository.GetFilteredSchedules(filter)
+	}
 	if err != nil {
 		s.Logger.Error("Error getting all schedules", zap.Error(err))
 		return nil, nil, err
@@ -359,6 +537,276 @@ func (s *ScheduleUseCase) GetSchedulesWithClientInfo() (*[]domainSchedule.Schedu
 	return schedules, &clients, nil
 }
 
+// StreamSchedulesWithClientInfo calls fn with every schedule the filter
+// keeps and the client it is for, or nil when the client cannot be found.
+// The schedules are not loaded all at once, and each client is looked up
+// once.
+func (s *ScheduleUseCase) StreamSchedulesWithClientInfo(filter domainSchedule.ListFilter, fn func(*domainSchedule.Schedule, *domainUser.User) error) error {
+	s.Logger.Info("Streaming all schedules with client info")
+	filter, err := s.resolveListFilter(filter)
+	if err != nil {
+		return err
+	}
+	clients := make(map[uuid.UUID]*domainUser.User)
+	return s.scheduleRepository.StreamSchedules(filter, func(schedule *domainSchedule.Schedule) error {
+		client, seen := clients[schedule.ClientUserID]
+		if !seen {
+			var err error
+			if client, err = s.userRepository.GetByID(schedule.ClientUserID); err != nil {
+				s.Logger.Warn("Client user not found", zap.Error(err), zap.String("clientUserID", schedule.ClientUserID.String()))
+				client = nil
+			}
+			clients[schedule.ClientUserID] = client
+		}
+		return fn(schedule, client)
+	})
+}
+
+// GetSchedulesWithClientInfoPaginated returns one page of the schedules the
+// filter keeps with the clients they are for.
+func (s *ScheduleUseCase) GetSchedulesWithClientInfoPaginated(filters domain.DataFilters, filter domainSchedule.ListFilter) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error) {
+	s.Logger.Info("Getting schedules page with client info", zap.Int("page", filters.Page), zap.Int("pageSize", filters.PageSize))
+	for _, sortBy := range filters.SortBy {
+		if !isSortable(sortBy) {
+			return nil, nil, domainErrors.NewAppError(fmt.Errorf("sortBy must be one of %v", domainSchedule.SortableColumns), domainErrors.ValidationError)
+		}
+	}
+	if filters.SortDirection != "" && !filters.SortDirection.IsValid() {
+		return nil, nil, domainErrors.NewAppError(errors.New("sortDirection must be 'asc' or 'desc'"), domainErrors.ValidationError)
+	}
+	if filters.PageSize > MaxPageSize {
+		return nil, nil, domainErrors.NewAppError(fmt.Errorf("pageSize must be at most %d", MaxPageSize), domainErrors.ValidationError)
+	}
+	filter, err := s.resolveListFilter(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result *domainSchedule.SearchResultSchedule
+	if filter.IsEmpty() {
+		result, err = s.scheduleRepository.SearchPaginated(filters)
+	} else {
+		query := filter.Query()
+		query.SortDirection = filters.SortDirection
+		query.Deleted = filters.Deleted
+		query.Page, query.PageSize = filters.Page, filters.PageSize
+		if len(filters.SortBy) > 0 {
+			query.SortBy = filters.SortBy[0]
+		}
+		result, err = s.scheduleRepository.Search(query)
+	}
+	if err != nil {
+		s.Logger.Error("Error getting schedules page", zap.Error(err))
+		return nil, nil, err
+	}
+	clients := s.clientsOf(*result.Data)
+	return result, &clients, nil
+}
+
+// resolveListFilter turns the filter's days into the start of FromDate and
+// the end of ToDate in the organization's time zone, then validates it.
+func (s *ScheduleUseCase) resolveListFilter(filter domainSchedule.ListFilter) (domainSchedule.ListFilter, error) {
+	var days [2]*time.Time
+	for i, value := range []string{filter.FromDate, filter.ToDate} {
+		if value == "" {
+			continue
+		}
+		day, err := time.Parse(domainLocale.DateLayout, value)
+		if err != nil {
+			return filter, domainErrors.NewAppError(errors.New("dates must be YYYY-MM-DD"), domainErrors.ValidationError)
+		}
+		days[i] = &day
+	}
+	if first, last := days[0], days[1]; first != nil || last != nil {
+		if first == nil {
+			first = last
+		} else if last == nil {
+			last = first
+		}
+		calendar, err := s.calendarFor(*first, last.AddDate(0, 0, 1))
+		if err != nil {
+			return filter, err
+		}
+		midnight := func(day time.Time) *time.Time {
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, calendar.Location).UTC()
+			return &start
+		}
+		if days[0] != nil {
+			filter.From = midnight(*days[0])
+		}
+		if days[1] != nil {
+			filter.To = midnight(days[1].AddDate(0, 0, 1))
+		}
+	}
+	filter.FromDate, filter.ToDate = "", ""
+	return filter, validateListFilter(filter)
+}
+
+// validateListFilter checks the range runs forwards and every status is one
+// a visit can have.
+func validateListFilter(filter domainSchedule.ListFilter) error {
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return domainErrors.NewAppError(errors.New("to must be after from"), domainErrors.ValidationError)
+	}
+	for _, status := range filter.VisitStatuses {
+		if !isVisitStatus(status) {
+			return domainErrors.NewAppError(fmt.Errorf("visitStatus must be one of %v", domainSchedule.VisitStatuses), domainErrors.ValidationError)
+		}
+	}
+	return nil
+}
+
+func isVisitStatus(status string) bool {
+	for _, known := range domainSchedule.VisitStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isSortable(column string) bool {
+	for _, sortable := range domainSchedule.SortableColumns {
+		if column == sortable {
+			return true
+		}
+	}
+	return false
+}
+
+// clientsOf looks up the clients of the given schedules, once each.
+func (s *ScheduleUseCase) clientsOf(schedules []domainSchedule.Schedule) []domainUser.User {
+	clientIDs := make(map[uuid.UUID]bool)
+	clients := []domainUser.User{}
+	for _, schedule := range schedules {
+		if clientIDs[schedule.ClientUserID] {
+			continue
+		}
+		clientIDs[schedule.ClientUserID] = true
+		client, err := s.userRepository.GetByID(schedule.ClientUserID)
+		if err != nil {
+			s.Logger.Warn("Client user not found", zap.Error(err), zap.String("clientUserID", schedule.ClientUserID.String()))
+			continue
+		}
+		clients = append(clients, *client)
+	}
+	return clients
+}
+
+// SearchSchedules runs a filtered, paginated schedule search, optionally
+// starting from a saved view. Schedules without their own color tag are
+// colored by the first matching color rule.
+func (s *ScheduleUseCase) SearchSchedules(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error) {
+	s.Logger.Info("Searching schedules")
+
+	if query.ViewID != nil {
+		if s.viewResolver == nil {
+			return nil, nil, domainErrors.NewAppError(errors.New("saved views are not available"), domainErrors.NotFound)
+		}
+		if err := s.viewResolver.ApplyView(*query.ViewID, &query); err != nil {
+			s.Logger.Error("Error applying saved view", zap.Error(err), zap.String("viewID", query.ViewID.String()))
+			return nil, nil, err
+		}
+	}
+	if len(query.TeamIDs) > 0 {
+		assignedIDs, err := s.teamMembers(query.TeamIDs, query.AssignedUserIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(assignedIDs) == 0 {
+			return &domainSchedule.SearchResultSchedule{Data: &[]domainSchedule.Schedule{}, Page: query.Page, PageSize: query.PageSize}, &[]domainUser.User{}, nil
+		}
+		query.AssignedUserIDs = assignedIDs
+	}
+	if len(query.Regions) > 0 {
+		clientIDs, err := s.clientsInRegions(query.Regions, query.ClientUserIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(clientIDs) == 0 {
+			return &domainSchedule.SearchResultSchedule{Data: &[]domainSchedule.Schedule{}, Page: query.Page, PageSize: query.PageSize}, &[]domainUser.User{}, nil
+		}
+		query.ClientUserIDs = clientIDs
+	}
+
+	result, err := s.scheduleRepository.Search(query)
+	if err != nil {
+		s.Logger.Error("Error searching schedules", zap.Error(err))
+		return nil, nil, err
+	}
+	for i := range *result.Data {
+		schedule := &(*result.Data)[i]
+		if schedule.ColorTag != "" {
+			continue
+		}
+		for _, rule := range query.ColorRules {
+			if rule.Matches(schedule) {
+				schedule.ColorTag = rule.Color
+				break
+			}
+		}
+	}
+
+	clients := s.clientsOf(*result.Data)
+
+	s.Logger.Info("Schedule search completed", zap.Int64("total", result.Total), zap.Int("page", result.Page))
+	return result, &clients, nil
+}
+
+// teamMembers returns the caregivers on the teams, restricted to allowed when
+// it is not empty.
+func (s *ScheduleUseCase) teamMembers(teamIDs []uuid.UUID, allowed []uuid.UUID) ([]uuid.UUID, error) {
+	if s.teamResolver == nil {
+		return nil, domainErrors.NewAppError(errors.New("teams are not available"), domainErrors.NotFound)
+	}
+	members, err := s.teamResolver.GetMemberIDs(teamIDs)
+	if err != nil {
+		s.Logger.Error("Error resolving team members", zap.Error(err))
+		return nil, err
+	}
+	if len(allowed) == 0 {
+		return members, nil
+	}
+	allowedSet := make(map[uuid.UUID]bool, len(allowed))
+	for _, id := range allowed {
+		allowedSet[id] = true
+	}
+	var assignedIDs []uuid.UUID
+	for _, id := range members {
+		if allowedSet[id] {
+			assignedIDs = append(assignedIDs, id)
+		}
+	}
+	return assignedIDs, nil
+}
+
+// clientsInRegions returns the clients whose city or state matches one of the
+// regions, restricted to allowed when it is not empty.
+func (s *ScheduleUseCase) clientsInRegions(regions []string, allowed []uuid.UUID) ([]uuid.UUID, error) {
+	users, err := s.userRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	allowedSet := make(map[uuid.UUID]bool, len(allowed))
+	for _, id := range allowed {
+		allowedSet[id] = true
+	}
+
+	var clientIDs []uuid.UUID
+	for _, user := range *users {
+		if user.Role != domainUser.RoleClient || (len(allowed) > 0 && !allowedSet[user.ID]) {
+			continue
+		}
+		for _, region := range regions {
+			if strings.EqualFold(user.Location.City, region) || strings.EqualFold(user.Location.State, region) {
+				clientIDs = append(clientIDs, user.ID)
+				break
+			}
+		}
+	}
+	return clientIDs, nil
+}
+
 func (s *ScheduleUseCase) UpdateSchedule(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
 	s.Logger.Info("Updating schedule", zap.String("scheduleID", scheduleID.String()))
 
@@ -367,7 +815,27 @@ func (s *ScheduleUseCase) UpdateSchedule(scheduleID uuid.UUID, updates map[strin
 		s.Logger.Error("Schedule not found for update", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
 		return nil, domainErrors.NewAppError(errors.New("schedule not found"), domainErrors.NotFound)
 	}
+	warnings, err := s.checkUpdate(existingSchedule, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSchedule, err := s.scheduleRepository.UpdateSchedule(scheduleID, updates)
+	if err != nil {
+		s.Logger.Error("Error updating schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, err
+	}
+	updatedSchedule.Warnings = warnings
+
+	s.Logger.Info("Schedule updated successfully", zap.String("scheduleID", scheduleID.String()))
+	s.notifyUpdate(updatedSchedule, existingSchedule)
+	return updatedSchedule, nil
+}
 
+// checkUpdate checks the updates to a visit before they are saved and
+// returns the validators' warnings.
+func (s *ScheduleUseCase) checkUpdate(existingSchedule *domainSchedule.Schedule, updates map[string]interface{}) ([]string, error) {
+	scheduleID := existingSchedule.ID
 	if clientUserID, ok := updates["client_user_id"].(uuid.UUID); ok {
 		_, err := s.userRepository.GetByID(clientUserID)
 		if err != nil {
@@ -385,11 +853,17 @@ func (s *ScheduleUseCase) UpdateSchedule(scheduleID uuid.UUID, updates map[strin
 	}
 
 	if status, ok := updates["visit_status"].(string); ok {
+		if existingSchedule.Draft() {
+			return nil, domainErrors.NewAppError(errors.New("a draft is published, not given a status"), domainErrors.ValidationError)
+		}
 		validStatuses := map[string]bool{
 			"upcoming":    true,
 			"in_progress": true,
 			"completed":   true,
 			"cancelled":   true,
+			"missed":      true,
+
+			"partially_completed": true,
 		}
 
 		if !validStatuses[status] {
@@ -410,17 +884,256 @@ func (s *ScheduleUseCase) UpdateSchedule(scheduleID uuid.UUID, updates map[strin
 		}
 	}
 
-	updatedSchedule, err := s.scheduleRepository.UpdateSchedule(scheduleID, updates)
+	// Moving a visit into a closed payroll period is as much a change to
+	// that period as editing one already in it.
+	candidate := mergeUpdates(existingSchedule, updates)
+	if err := s.checkChange(existingSchedule); err != nil {
+		return nil, err
+	}
+	if !candidate.ScheduledSlot.From.Equal(existingSchedule.ScheduledSlot.From) {
+		if err := s.checkChange(candidate); err != nil {
+			return nil, err
+		}
+	}
+
+	if !affectsAssignment(updates) {
+		return nil, nil
+	}
+	warnings, err := s.validateSchedule(candidate)
 	if err != nil {
-		s.Logger.Error("Error updating schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		s.Logger.Warn("Schedule update rejected by validator", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
 		return nil, err
 	}
+	return warnings, nil
+}
 
-	s.Logger.Info("Schedule updated successfully", zap.String("scheduleID", scheduleID.String()))
-	return updatedSchedule, nil
+// notifyUpdate announces a saved update, as a cancellation when it cancelled
+// the visit.
+func (s *ScheduleUseCase) notifyUpdate(updatedSchedule, existingSchedule *domainSchedule.Schedule) {
+	if updatedSchedule.VisitStatus == "cancelled" && existingSchedule.VisitStatus != "cancelled" {
+		s.notify(domainSchedule.EventCancelled, updatedSchedule, existingSchedule)
+	} else {
+		s.notify(domainSchedule.EventUpdated, updatedSchedule, existingSchedule)
+	}
 }
 
 func (s *ScheduleUseCase) GetSchedulesInProgressByAssignedUserID(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error) {
 	s.Logger.Info("Getting schedules in progress by assigned user ID", zap.String("assignedUserID", assignedUserID.String()))
 	return s.scheduleRepository.GetSchedulesInProgressByAssignedUserID(assignedUserID)
 }
+
+// ensureNoScheduleInProgress rejects a check-in while the caregiver is still
+// checked in to another visit.
+func (s *ScheduleUseCase) ensureNoScheduleInProgress(schedule *domainSchedule.Schedule) error {
+	schedulesInProgress, err := s.scheduleRepository.GetSchedulesInProgressByAssignedUserID(schedule.AssignedUserID)
+	if err != nil {
+		s.Logger.Error("Error checking for schedules in progress", zap.Error(err), zap.String("assignedUserID", schedule.AssignedUserID.String()))
+		return err
+	}
+
+	if schedulesInProgress != nil && len(*schedulesInProgress) > 0 {
+		s.Logger.Warn("Cannot start schedule, another schedule is already in progress",
+			zap.String("scheduleID", schedule.ID.String()),
+			zap.String("assignedUserID", schedule.AssignedUserID.String()),
+			zap.Int("inProgressCount", len(*schedulesInProgress)))
+		return domainErrors.NewAppError(errors.New("cannot start schedule: another schedule is already in progress for this user"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+// startSegment checks the caregiver in to the next pending segment of a split
+// shift. The schedule itself moves to in_progress while a segment is open.
+func (s *ScheduleUseCase) startSegment(schedule *domainSchedule.Schedule, timestamp time.Time, location domainSchedule.Location, verification domainSchedule.Verification) (*domainSchedule.Schedule, error) {
+	if schedule.VisitStatus != "upcoming" && schedule.VisitStatus != "partially_completed" {
+		s.Logger.Warn("Cannot start segment, invalid schedule status", zap.String("scheduleID", schedule.ID.String()), zap.String("status", schedule.VisitStatus))
+		return nil, domainErrors.NewAppError(errors.New("schedule is not in 'upcoming' or 'partially_completed' status"), domainErrors.ValidationError)
+	}
+
+	segment := schedule.NextSegment()
+	if segment == nil {
+		s.Logger.Warn("Cannot start segment, no pending segments", zap.String("scheduleID", schedule.ID.String()))
+		return nil, domainErrors.NewAppError(errors.New("schedule has no pending segments"), domainErrors.ValidationError)
+	}
+
+	if timestamp.Before(segment.From) {
+		s.Logger.Warn("Cannot start segment before its scheduled time",
+			zap.String("scheduleID", schedule.ID.String()),
+			zap.String("segmentID", segment.ID.String()),
+			zap.Time("currentTime", timestamp),
+			zap.Time("segmentStartTime", segment.From))
+		return nil, domainErrors.NewAppError(errors.New("cannot start segment before its scheduled start time"), domainErrors.ValidationError)
+	}
+
+	if err := s.ensureNoScheduleInProgress(schedule); err != nil {
+		return nil, err
+	}
+	if err := s.verifyCheckin(schedule, timestamp, location, &verification); err != nil {
+		return nil, err
+	}
+
+	_, err := s.scheduleRepository.UpdateSegment(segment.ID, map[string]interface{}{
+		"visit_status":                "in_progress",
+		"checkin_time":                timestamp,
+		"checkin_location_lat":        location.Lat,
+		"checkin_location_long":       location.Long,
+		"checkin_verification_method": verification.Method,
+		"checkin_nfc_tag_id":          verification.NFCTagID,
+		"checkin_kiosk_id":            verification.KioskID,
+	})
+	if err != nil {
+		s.Logger.Error("Error updating segment for start", zap.Error(err), zap.String("segmentID", segment.ID.String()))
+		return nil, err
+	}
+
+	updates := map[string]interface{}{
+		"visit_status": "in_progress",
+	}
+	// The schedule-level check-in records the first segment only.
+	if schedule.CheckinTime == nil {
+		updates["checkin_time"] = timestamp
+		updates["checkin_location_lat"] = location.Lat
+		updates["checkin_location_long"] = location.Long
+		updates["checkin_verification_method"] = verification.Method
+		updates["checkin_nfc_tag_id"] = verification.NFCTagID
+		updates["checkin_kiosk_id"] = verification.KioskID
+		addDeviceHints(updates, verification)
+	}
+
+	updatedSchedule, err := s.scheduleRepository.UpdateSchedule(schedule.ID, updates)
+	if err != nil {
+		s.Logger.Error("Error updating schedule for segment start", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		return nil, err
+	}
+	s.Logger.Info("Schedule segment started successfully", zap.String("scheduleID", schedule.ID.String()), zap.String("segmentID", segment.ID.String()))
+	if schedule.CheckinTime == nil {
+		s.notify(domainSchedule.EventStarted, updatedSchedule, schedule)
+	}
+	return updatedSchedule, nil
+}
+
+// endSegment checks the caregiver out of the open segment. The schedule is
+// completed with the last segment; otherwise it waits in partially_completed
+// so the caregiver is free to work other visits in between.
+func (s *ScheduleUseCase) endSegment(schedule *domainSchedule.Schedule, timestamp time.Time, location domainSchedule.Location, tasks []domainSchedule.Task) (*domainSchedule.Schedule, error) {
+	segment := schedule.ActiveSegment()
+	if segment == nil {
+		s.Logger.Warn("Cannot end segment, no segment in progress", zap.String("scheduleID", schedule.ID.String()))
+		return nil, domainErrors.NewAppError(errors.New("schedule has no segment in progress"), domainErrors.ValidationError)
+	}
+
+	updates := map[string]interface{}{
+		"visit_status": "partially_completed",
+	}
+	if schedule.NextSegment() == nil {
+		updates["visit_status"] = "completed"
+		updates["checkout_time"] = timestamp
+		updates["checkout_location_lat"] = location.Lat
+		updates["checkout_location_long"] = location.Long
+		s.addConfidence(updates, schedule, timestamp, location)
+	}
+
+	var updatedSchedule *domainSchedule.Schedule
+	err := s.scheduleRepository.Transaction(func(repo domainSchedule.IScheduleRepository) error {
+		_, err := repo.UpdateSegment(segment.ID, map[string]interface{}{
+			"visit_status":           "completed",
+			"checkout_time":          timestamp,
+			"checkout_location_lat":  location.Lat,
+			"checkout_location_long": location.Long,
+		})
+		if err != nil {
+			s.Logger.Error("Error updating segment for end", zap.Error(err), zap.String("segmentID", segment.ID.String()))
+			return err
+		}
+		updated, err := repo.UpdateSchedule(schedule.ID, updates)
+		if err != nil {
+			s.Logger.Error("Error updating schedule for segment end", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+			return err
+		}
+		updatedSchedule = updated
+		return s.applyCheckoutTasks(repo, tasks)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.Logger.Info("Schedule segment ended successfully", zap.String("scheduleID", schedule.ID.String()), zap.String("segmentID", segment.ID.String()))
+	if updates["visit_status"] == "completed" {
+		s.notify(domainSchedule.EventCompleted, updatedSchedule, schedule)
+	}
+	return updatedSchedule, nil
+}
+
+// applyCheckoutTasks saves the task outcomes reported at check-out through
+// repo, stopping at the first failure so the check-out is rolled back.
+func (s *ScheduleUseCase) applyCheckoutTasks(repo domainSchedule.IScheduleRepository, tasks []domainSchedule.Task) error {
+	for _, task := range tasks {
+		updates := map[string]interface{}{
+			"status":   task.Status,
+			"done":     task.Done,
+			"feedback": task.Feedback,
+		}
+		addVitals(updates, task.Vitals)
+		_, err := repo.UpdateTask(task.ID, updates)
+		if err != nil {
+			s.Logger.Error("Error updating task during EndSchedule", zap.Error(err), zap.String("taskID", task.ID.String()))
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCheckoutTasks checks the outcomes reported at check-out against the
+// kinds of the visit's tasks, refusing outcomes for tasks of another visit.
+func validateCheckoutTasks(schedule *domainSchedule.Schedule, tasks []domainSchedule.Task) error {
+	for _, outcome := range tasks {
+		task := findTask(schedule.Tasks, outcome.ID)
+		if task == nil {
+			return domainErrors.NewAppError(fmt.Errorf("task %s is not part of this visit", outcome.ID), domainErrors.ValidationError)
+		}
+		done := outcome.Done != nil && *outcome.Done
+		if err := task.ValidateOutcome(done, outcome.Vitals); err != nil {
+			return domainErrors.NewAppError(fmt.Errorf("task %q: %w", task.Title, err), domainErrors.ValidationError)
+		}
+	}
+	return nil
+}
+
+// findTask returns the task with the ID, or nil when there is none.
+func findTask(tasks []domainSchedule.Task, id uuid.UUID) *domainSchedule.Task {
+	for i := range tasks {
+		if tasks[i].ID == id {
+			return &tasks[i]
+		}
+	}
+	return nil
+}
+
+// addVitals adds the readings of a vitals task to its updates.
+func addVitals(updates map[string]interface{}, vitals *domainSchedule.Vitals) {
+	if vitals == nil {
+		return
+	}
+	updates["vitals_systolic_bp"] = vitals.SystolicBP
+	updates["vitals_diastolic_bp"] = vitals.DiastolicBP
+	updates["vitals_heart_rate"] = vitals.HeartRate
+	updates["vitals_respiratory_rate"] = vitals.RespiratoryRate
+	updates["vitals_temperature"] = vitals.Temperature
+	updates["vitals_oxygen_saturation"] = vitals.OxygenSaturation
+	updates["vitals_blood_glucose"] = vitals.BloodGlucose
+}
+
+// validateSegments checks that split-shift segments are ordered,
+// non-overlapping and contained in the schedule's overall slot.
+func validateSegments(slot domainSchedule.ScheduledSlot, segments []domainSchedule.Segment) error {
+	for i, segment := range segments {
+		if !segment.From.Before(segment.To) {
+			return domainErrors.NewAppError(errors.New("segment 'From' must be before 'To'"), domainErrors.ValidationError)
+		}
+		if segment.From.Before(slot.From) || segment.To.After(slot.To) {
+			return domainErrors.NewAppError(errors.New("segments must fall within the scheduled slot"), domainErrors.ValidationError)
+		}
+		if i > 0 && segment.From.Before(segments[i-1].To) {
+			return domainErrors.NewAppError(errors.New("segments must be in order and must not overlap"), domainErrors.ValidationError)
+		}
+	}
+	return nil
+}
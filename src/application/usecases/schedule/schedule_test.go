@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"caregiver/src/domain"
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
 	domainSchedule "caregiver/src/domain/schedule"
 	domainUser "caregiver/src/domain/user"
 	logger "caregiver/src/infrastructure/logger"
@@ -15,13 +17,31 @@ import (
 
 // mockScheduleRepository is a mock implementation of the IScheduleRepository interface
 type mockScheduleRepository struct {
-	getSchedulesFn                          func() (*[]domainSchedule.Schedule, error)
-	getScheduleByIDFn                       func(id uuid.UUID) (*domainSchedule.Schedule, error)
-	getTodaySchedulesFn                     func(userID uuid.UUID) (*[]domainSchedule.Schedule, error)
-	updateScheduleFn                        func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error)
-	updateTaskFn                            func(taskID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Task, error)
-	createFn                                func(newSchedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error)
-	getSchedulesByAssignedUserIDPaginatedFn func(assignedUserID uuid.UUID, filters domain.DataFilters) (*domainSchedule.SearchResultSchedule, error)
+	getSchedulesFn                           func() (*[]domainSchedule.Schedule, error)
+	streamSchedulesFn                        func(filter domainSchedule.ListFilter, fn func(*domainSchedule.Schedule) error) error
+	searchPaginatedFn                        func(filters domain.DataFilters) (*domainSchedule.SearchResultSchedule, error)
+	getFilteredSchedulesFn                   func(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, error)
+	getScheduleByIDFn                        func(id uuid.UUID) (*domainSchedule.Schedule, error)
+	getTodaySchedulesFn                      func(userID uuid.UUID) (*[]domainSchedule.Schedule, error)
+	updateScheduleFn                         func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error)
+	getTaskByIDFn                            func(taskID uuid.UUID) (*domainSchedule.Task, error)
+	updateTaskFn                             func(taskID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Task, error)
+	createFn                                 func(newSchedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error)
+	getSchedulesByAssignedUserIDPaginatedFn  func(assignedUserID uuid.UUID, filters domain.DataFilters) (*domainSchedule.SearchResultSchedule, error)
+	getSchedulesInProgressByAssignedUserIDFn func(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error)
+	updateSegmentFn                          func(segmentID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Segment, error)
+	getActiveSchedulesBetweenFn              func(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error)
+	searchFn                                 func(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error)
+	deleteFn                                 func(id uuid.UUID, deletedBy *uuid.UUID) error
+	restoreFn                                func(id uuid.UUID) (*domainSchedule.Schedule, error)
+	deleteTaskFn                             func(taskID uuid.UUID) error
+	restoreTaskFn                            func(taskID uuid.UUID) (*domainSchedule.Task, error)
+	getUpcomingSeriesSchedulesFn             func(seriesID uuid.UUID, from time.Time) (*[]domainSchedule.Schedule, error)
+	markMissedBeforeFn                       func(before time.Time) (*[]domainSchedule.Schedule, error)
+	getStuckSchedulesBeforeFn                func(before time.Time) (*[]domainSchedule.Schedule, error)
+	claimOpenScheduleFn                      func(id uuid.UUID, assignedUserID uuid.UUID) (*domainSchedule.Schedule, error)
+	getTaskOutcomesFn                        func(clientUserID uuid.UUID, from, to time.Time, period string, timezone string) ([]domainSchedule.TaskOutcomes, error)
+	rollbacks                                int
 }
 
 // Implement all methods of the IScheduleRepository interface
@@ -29,6 +49,18 @@ func (m *mockScheduleRepository) GetSchedules() (*[]domainSchedule.Schedule, err
 	return m.getSchedulesFn()
 }
 
+func (m *mockScheduleRepository) StreamSchedules(filter domainSchedule.ListFilter, fn func(*domainSchedule.Schedule) error) error {
+	return m.streamSchedulesFn(filter, fn)
+}
+
+func (m *mockScheduleRepository) SearchPaginated(filters domain.DataFilters) (*domainSchedule.SearchResultSchedule, error) {
+	return m.searchPaginatedFn(filters)
+}
+
+func (m *mockScheduleRepository) GetFilteredSchedules(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, error) {
+	return m.getFilteredSchedulesFn(filter)
+}
+
 func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
 	return m.getScheduleByIDFn(id)
 }
@@ -41,10 +73,34 @@ func (m *mockScheduleRepository) UpdateSchedule(id uuid.UUID, updates map[string
 	return m.updateScheduleFn(id, updates)
 }
 
+// GetTaskByID returns a generic task unless the test says otherwise.
+func (m *mockScheduleRepository) GetTaskByID(taskID uuid.UUID) (*domainSchedule.Task, error) {
+	if m.getTaskByIDFn == nil {
+		return &domainSchedule.Task{ID: taskID, Kind: domainSchedule.TaskKindGeneric}, nil
+	}
+	return m.getTaskByIDFn(taskID)
+}
+
 func (m *mockScheduleRepository) UpdateTask(taskID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Task, error) {
 	return m.updateTaskFn(taskID, updates)
 }
 
+func (m *mockScheduleRepository) Delete(id uuid.UUID, deletedBy *uuid.UUID) error {
+	return m.deleteFn(id, deletedBy)
+}
+
+func (m *mockScheduleRepository) Restore(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	return m.restoreFn(id)
+}
+
+func (m *mockScheduleRepository) DeleteTask(taskID uuid.UUID) error {
+	return m.deleteTaskFn(taskID)
+}
+
+func (m *mockScheduleRepository) RestoreTask(taskID uuid.UUID) (*domainSchedule.Task, error) {
+	return m.restoreTaskFn(taskID)
+}
+
 func (m *mockScheduleRepository) Create(newSchedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error) {
 	return m.createFn(newSchedule)
 }
@@ -53,6 +109,78 @@ func (m *mockScheduleRepository) GetSchedulesByAssignedUserIDPaginated(assignedU
 	return m.getSchedulesByAssignedUserIDPaginatedFn(assignedUserID, filters)
 }
 
+func (m *mockScheduleRepository) GetSchedulesInProgressByAssignedUserID(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	if m.getSchedulesInProgressByAssignedUserIDFn == nil {
+		return &[]domainSchedule.Schedule{}, nil
+	}
+	return m.getSchedulesInProgressByAssignedUserIDFn(assignedUserID)
+}
+
+func (m *mockScheduleRepository) UpdateSegment(segmentID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Segment, error) {
+	return m.updateSegmentFn(segmentID, updates)
+}
+
+func (m *mockScheduleRepository) GetActiveSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	if m.getActiveSchedulesBetweenFn == nil {
+		return &[]domainSchedule.Schedule{}, nil
+	}
+	return m.getActiveSchedulesBetweenFn(from, to, assignedUserIDs)
+}
+
+func (m *mockScheduleRepository) Search(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+	return m.searchFn(query)
+}
+
+func (m *mockScheduleRepository) GetUnstartedSchedulesBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	return &[]domainSchedule.Schedule{}, nil
+}
+
+func (m *mockScheduleRepository) MarkMissedBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	return m.markMissedBeforeFn(before)
+}
+
+func (m *mockScheduleRepository) GetStuckSchedulesBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	return m.getStuckSchedulesBeforeFn(before)
+}
+
+func (m *mockScheduleRepository) GetWorkedSchedulesBetween(assignedUserID uuid.UUID, from, to time.Time) (*[]domainSchedule.Schedule, error) {
+	return &[]domainSchedule.Schedule{}, nil
+}
+
+func (m *mockScheduleRepository) GetCheckedInSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	return &[]domainSchedule.Schedule{}, nil
+}
+
+func (m *mockScheduleRepository) GetPendingAttestationsDueBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	return &[]domainSchedule.Schedule{}, nil
+}
+
+func (m *mockScheduleRepository) GetCompletedSchedulesBetween(from, to time.Time, clientUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	return &[]domainSchedule.Schedule{}, nil
+}
+
+func (m *mockScheduleRepository) GetUpcomingSeriesSchedules(seriesID uuid.UUID, from time.Time) (*[]domainSchedule.Schedule, error) {
+	return m.getUpcomingSeriesSchedulesFn(seriesID, from)
+}
+
+func (m *mockScheduleRepository) ClaimOpenSchedule(id uuid.UUID, assignedUserID uuid.UUID) (*domainSchedule.Schedule, error) {
+	return m.claimOpenScheduleFn(id, assignedUserID)
+}
+
+func (m *mockScheduleRepository) GetTaskOutcomes(clientUserID uuid.UUID, from, to time.Time, period string, timezone string) ([]domainSchedule.TaskOutcomes, error) {
+	return m.getTaskOutcomesFn(clientUserID, from, to, period, timezone)
+}
+
+// Transaction runs fn against the mock itself and counts the transactions
+// that would have been rolled back.
+func (m *mockScheduleRepository) Transaction(fn func(repo domainSchedule.IScheduleRepository) error) error {
+	err := fn(m)
+	if err != nil {
+		m.rollbacks++
+	}
+	return err
+}
+
 // mockUserRepository is a mock implementation of the IUserRepository interface
 type mockUserRepository struct {
 	getAllFn           func() (*[]domainUser.User, error)
@@ -62,7 +190,7 @@ type mockUserRepository struct {
 	updateFn           func(id uuid.UUID, userMap map[string]interface{}) (*domainUser.User, error)
 	deleteFn           func(id uuid.UUID) error
 	searchPaginatedFn  func(filters domain.DataFilters) (*domainUser.SearchResultUser, error)
-	searchByPropertyFn func(property string, searchText string) (*[]string, error)
+	searchByPropertyFn func(search domainUser.PropertySearch) (*domainUser.SearchResultProperty, error)
 }
 
 // Implement all methods of the IUserRepository interface
@@ -94,8 +222,8 @@ func (m *mockUserRepository) SearchPaginated(filters domain.DataFilters) (*domai
 	return m.searchPaginatedFn(filters)
 }
 
-func (m *mockUserRepository) SearchByProperty(property string, searchText string) (*[]string, error) {
-	return m.searchByPropertyFn(property, searchText)
+func (m *mockUserRepository) SearchByProperty(search domainUser.PropertySearch) (*domainUser.SearchResultProperty, error) {
+	return m.searchByPropertyFn(search)
 }
 
 // setupLogger creates a logger instance for testing
@@ -299,7 +427,7 @@ func TestGetSchedulesWithClientInfo(t *testing.T) {
 		}
 
 		// Execute
-		schedules, clients, err := useCase.GetSchedulesWithClientInfo()
+		schedules, clients, err := useCase.GetSchedulesWithClientInfo(domainSchedule.ListFilter{})
 
 		// Verify
 		if err != nil {
@@ -326,7 +454,7 @@ func TestGetSchedulesWithClientInfo(t *testing.T) {
 		}
 
 		// Execute
-		schedules, clients, err := useCase.GetSchedulesWithClientInfo()
+		schedules, clients, err := useCase.GetSchedulesWithClientInfo(domainSchedule.ListFilter{})
 
 		// Verify
 		if err == nil {
@@ -348,7 +476,7 @@ func TestGetSchedulesWithClientInfo(t *testing.T) {
 		}
 
 		// Execute
-		schedules, clients, err := useCase.GetSchedulesWithClientInfo()
+		schedules, clients, err := useCase.GetSchedulesWithClientInfo(domainSchedule.ListFilter{})
 
 		// Verify
 		if err != nil {
@@ -369,6 +497,223 @@ func TestGetSchedulesWithClientInfo(t *testing.T) {
 	})
 }
 
+func TestStreamSchedulesWithClientInfo(t *testing.T) {
+	useCase, mockScheduleRepo, mockUserRepo, _ := setupTestScheduleUseCase(t)
+
+	clientID := uuid.New()
+	schedules := *createTestScheduleList(3)
+	for i := range schedules {
+		schedules[i].ClientUserID = clientID
+	}
+	schedules[2].ClientUserID = uuid.New()
+	mockScheduleRepo.streamSchedulesFn = func(filter domainSchedule.ListFilter, fn func(*domainSchedule.Schedule) error) error {
+		for i := range schedules {
+			if err := fn(&schedules[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	lookups := 0
+	mockUserRepo.getByIDFn = func(id uuid.UUID) (*domainUser.User, error) {
+		lookups++
+		if id != clientID {
+			return nil, errors.New("user not found")
+		}
+		return createTestUser(id), nil
+	}
+
+	var withClient, withoutClient int
+	err := useCase.StreamSchedulesWithClientInfo(domainSchedule.ListFilter{}, func(schedule *domainSchedule.Schedule, client *domainUser.User) error {
+		if client != nil {
+			withClient++
+		} else {
+			withoutClient++
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if withClient != 2 || withoutClient != 1 {
+		t.Errorf("expected 2 schedules with a client and 1 without, got %d and %d", withClient, withoutClient)
+	}
+	if lookups != 2 {
+		t.Errorf("expected each client to be looked up once, got %d lookups", lookups)
+	}
+}
+
+// TestGetSchedulesWithClientInfoPaginated tests the paginated schedule listing
+func TestGetSchedulesWithClientInfoPaginated(t *testing.T) {
+	useCase, mockScheduleRepo, mockUserRepo, _ := setupTestScheduleUseCase(t)
+
+	t.Run("Success", func(t *testing.T) {
+		schedules := createTestScheduleList(2)
+		(*schedules)[1].ClientUserID = (*schedules)[0].ClientUserID
+		var received domain.DataFilters
+		mockScheduleRepo.searchPaginatedFn = func(filters domain.DataFilters) (*domainSchedule.SearchResultSchedule, error) {
+			received = filters
+			return &domainSchedule.SearchResultSchedule{Data: schedules, Total: 12, Page: 2, PageSize: 2, TotalPages: 6}, nil
+		}
+		mockUserRepo.getByIDFn = func(id uuid.UUID) (*domainUser.User, error) {
+			return createTestUser(id), nil
+		}
+
+		result, clients, err := useCase.GetSchedulesWithClientInfoPaginated(domain.DataFilters{Page: 2, PageSize: 2, SortBy: []string{"created_at"}, SortDirection: domain.SortDesc}, domainSchedule.ListFilter{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if received.Page != 2 || received.SortBy[0] != "created_at" {
+			t.Errorf("expected the filters to reach the repository, got %+v", received)
+		}
+		if result.Total != 12 || len(*result.Data) != 2 {
+			t.Errorf("expected the page of 2 out of 12, got %+v", result)
+		}
+		if len(*clients) != 1 {
+			t.Errorf("expected the shared client once, got %d", len(*clients))
+		}
+	})
+
+	for _, tc := range []struct {
+		name    string
+		filters domain.DataFilters
+	}{
+		{"Unknown sort column", domain.DataFilters{SortBy: []string{"hash_password"}}},
+		{"Unknown sort direction", domain.DataFilters{SortDirection: "sideways"}},
+		{"Page too large", domain.DataFilters{PageSize: MaxPageSize + 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockScheduleRepo.searchPaginatedFn = func(filters domain.DataFilters) (*domainSchedule.SearchResultSchedule, error) {
+				t.Fatal("expected the repository not to be called")
+				return nil, nil
+			}
+			_, _, err := useCase.GetSchedulesWithClientInfoPaginated(tc.filters, domainSchedule.ListFilter{})
+			var appErr *domainErrors.AppError
+			if !errors.As(err, &appErr) || appErr.Type != domainErrors.ValidationError {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestGetSchedulesFiltered tests listing schedules by range, status and user
+func TestGetSchedulesFiltered(t *testing.T) {
+	useCase, mockScheduleRepo, mockUserRepo, _ := setupTestScheduleUseCase(t)
+	mockUserRepo.getByIDFn = func(id uuid.UUID) (*domainUser.User, error) {
+		return createTestUser(id), nil
+	}
+	from := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	clientID := uuid.New()
+	filter := domainSchedule.ListFilter{From: &from, To: &to, VisitStatuses: []string{"cancelled"}, ClientUserID: &clientID}
+
+	t.Run("List", func(t *testing.T) {
+		var received domainSchedule.ListFilter
+		mockScheduleRepo.getFilteredSchedulesFn = func(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, error) {
+			received = filter
+			return createTestScheduleList(2), nil
+		}
+		mockScheduleRepo.getSchedulesFn = func() (*[]domainSchedule.Schedule, error) {
+			t.Fatal("expected the filtered query")
+			return nil, nil
+		}
+
+		schedules, _, err := useCase.GetSchedulesWithClientInfo(filter)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(*schedules) != 2 || *received.ClientUserID != clientID || received.VisitStatuses[0] != "cancelled" {
+			t.Errorf("expected the filter to reach the repository, got %+v", received)
+		}
+	})
+
+	t.Run("Page", func(t *testing.T) {
+		var received domainSchedule.SearchQuery
+		mockScheduleRepo.searchFn = func(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+			received = query
+			return &domainSchedule.SearchResultSchedule{Data: createTestScheduleList(1), Total: 1, Page: 1, PageSize: 10, TotalPages: 1}, nil
+		}
+
+		if _, _, err := useCase.GetSchedulesWithClientInfoPaginated(domain.DataFilters{Page: 1, PageSize: 10, SortBy: []string{"visit_status"}}, filter); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if received.From != &from || received.To != &to || len(received.ClientUserIDs) != 1 || received.ClientUserIDs[0] != clientID ||
+			received.AssignedUserIDs != nil || received.SortBy != "visit_status" || received.PageSize != 10 {
+			t.Errorf("expected the filter and page in the search, got %+v", received)
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		mockScheduleRepo.getFilteredSchedulesFn = func(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, error) {
+			t.Fatal("expected the filtered schedules streamed, not loaded at once")
+			return nil, nil
+		}
+		mockScheduleRepo.streamSchedulesFn = func(received domainSchedule.ListFilter, fn func(*domainSchedule.Schedule) error) error {
+			if *received.ClientUserID != clientID {
+				t.Errorf("expected the filter to reach the repository, got %+v", received)
+			}
+			schedules := *createTestScheduleList(3)
+			for i := range schedules {
+				if err := fn(&schedules[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		streamed := 0
+		err := useCase.StreamSchedulesWithClientInfo(filter, func(schedule *domainSchedule.Schedule, client *domainUser.User) error {
+			streamed++
+			return nil
+		})
+		if err != nil || streamed != 3 {
+			t.Errorf("expected the 3 filtered schedules streamed, got %d (%v)", streamed, err)
+		}
+	})
+
+	t.Run("Days in the organization's time zone", func(t *testing.T) {
+		calendar := domainLocale.NewCalendar(&domainLocale.Settings{Timezone: "America/New_York"}, nil)
+		useCase := NewScheduleUseCase(mockScheduleRepo, mockUserRepo, setupLogger(t), WithCalendar(fixedCalendar{calendar}))
+		var received domainSchedule.ListFilter
+		mockScheduleRepo.getFilteredSchedulesFn = func(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, error) {
+			received = filter
+			return createTestScheduleList(1), nil
+		}
+
+		if _, _, err := useCase.GetSchedulesWithClientInfo(domainSchedule.ListFilter{FromDate: "2025-03-10", ToDate: "2025-03-16"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if received.From == nil || !received.From.Equal(time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected from at local midnight on March 10, got %v", received.From)
+		}
+		if received.To == nil || !received.To.Equal(time.Date(2025, 3, 17, 4, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected to at local midnight after March 16, got %v", received.To)
+		}
+	})
+
+	for _, tc := range []struct {
+		name   string
+		filter domainSchedule.ListFilter
+	}{
+		{"Range backwards", domainSchedule.ListFilter{From: &to, To: &from}},
+		{"Days backwards", domainSchedule.ListFilter{FromDate: "2025-03-16", ToDate: "2025-03-10"}},
+		{"Malformed day", domainSchedule.ListFilter{FromDate: "10/03/2025"}},
+		{"Unknown status", domainSchedule.ListFilter{VisitStatuses: []string{"cancelled", "postponed"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockScheduleRepo.getFilteredSchedulesFn = func(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, error) {
+				t.Fatal("expected the repository not to be called")
+				return nil, nil
+			}
+			_, _, err := useCase.GetSchedulesWithClientInfo(tc.filter)
+			var appErr *domainErrors.AppError
+			if !errors.As(err, &appErr) || appErr.Type != domainErrors.ValidationError {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
 // TestGetScheduleByID tests the GetScheduleByID method
 func TestGetScheduleByID(t *testing.T) {
 	// Setup
@@ -547,9 +892,10 @@ func TestStartSchedule(t *testing.T) {
 			Long: &long,
 		}
 
-		// Create test schedule
+		// Create test schedule whose slot has already opened
 		originalSchedule := createTestSchedule(scheduleID)
 		originalSchedule.VisitStatus = "upcoming"
+		originalSchedule.ScheduledSlot.From = timestamp.Add(-5 * time.Minute)
 
 		// Create updated schedule
 		updatedSchedule := *originalSchedule
@@ -579,6 +925,9 @@ func TestStartSchedule(t *testing.T) {
 				if updates["checkin_location_long"] != location.Long {
 					t.Errorf("expected checkin_location_long to be %v, got %v", location.Long, updates["checkin_location_long"])
 				}
+				if updates["checkin_verification_method"] != domainSchedule.VerificationGPS {
+					t.Errorf("expected checkin_verification_method to be 'gps', got %v", updates["checkin_verification_method"])
+				}
 
 				return &updatedSchedule, nil
 			}
@@ -586,7 +935,7 @@ func TestStartSchedule(t *testing.T) {
 		}
 
 		// Execute
-		result, err := useCase.StartSchedule(scheduleID, timestamp, location)
+		result, err := useCase.StartSchedule(scheduleID, timestamp, location, domainSchedule.Verification{})
 
 		// Verify
 		if err != nil {
@@ -629,7 +978,7 @@ func TestStartSchedule(t *testing.T) {
 			Lat:  &lat,
 			Long: &long,
 		}
-		result, err := useCase.StartSchedule(uuid.New(), timestamp, location)
+		result, err := useCase.StartSchedule(uuid.New(), timestamp, location, domainSchedule.Verification{})
 
 		// Verify
 		if err == nil {
@@ -663,7 +1012,7 @@ func TestStartSchedule(t *testing.T) {
 			Lat:  &lat,
 			Long: &long,
 		}
-		result, err := useCase.StartSchedule(scheduleID, timestamp, location)
+		result, err := useCase.StartSchedule(scheduleID, timestamp, location, domainSchedule.Verification{})
 
 		// Verify
 		if err == nil {
@@ -678,9 +1027,11 @@ func TestStartSchedule(t *testing.T) {
 		// Setup mock behavior
 		scheduleID := uuid.New()
 
-		// Create test schedule
+		// Create test schedule whose slot has already opened
+		timestamp := time.Now()
 		originalSchedule := createTestSchedule(scheduleID)
 		originalSchedule.VisitStatus = "upcoming"
+		originalSchedule.ScheduledSlot.From = timestamp.Add(-5 * time.Minute)
 
 		mockScheduleRepo.getScheduleByIDFn = func(id uuid.UUID) (*domainSchedule.Schedule, error) {
 			if id == scheduleID {
@@ -694,14 +1045,13 @@ func TestStartSchedule(t *testing.T) {
 		}
 
 		// Execute
-		timestamp := time.Now()
 		lat := 12.345
 		long := 67.890
 		location := domainSchedule.Location{
 			Lat:  &lat,
 			Long: &long,
 		}
-		result, err := useCase.StartSchedule(scheduleID, timestamp, location)
+		result, err := useCase.StartSchedule(scheduleID, timestamp, location, domainSchedule.Verification{})
 
 		// Verify
 		if err == nil {
@@ -711,12 +1061,39 @@ func TestStartSchedule(t *testing.T) {
 			t.Error("expected nil result")
 		}
 	})
+
+	t.Run("Missing location and NFC tag", func(t *testing.T) {
+		scheduleID := uuid.New()
+		timestamp := time.Now()
+		originalSchedule := createTestSchedule(scheduleID)
+		originalSchedule.VisitStatus = "upcoming"
+		originalSchedule.ScheduledSlot.From = timestamp.Add(-5 * time.Minute)
+
+		mockScheduleRepo.getScheduleByIDFn = func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return originalSchedule, nil
+		}
+		mockScheduleRepo.updateScheduleFn = func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			t.Error("schedule must not be updated without a verification factor")
+			return nil, nil
+		}
+
+		result, err := useCase.StartSchedule(scheduleID, timestamp, domainSchedule.Location{}, domainSchedule.Verification{NFCTagValue: "04A23B1C"})
+		if err == nil {
+			t.Error("expected error for unverified NFC tag without location, got nil")
+		}
+		if result != nil {
+			t.Error("expected nil result")
+		}
+	})
 }
 
 // TestEndSchedule tests the EndSchedule method
 func TestEndSchedule(t *testing.T) {
 	// Setup
-	useCase, mockScheduleRepo, _, _ := setupTestScheduleUseCase(t)
+	useCase, mockScheduleRepo, mockUserRepo, _ := setupTestScheduleUseCase(t)
+	mockUserRepo.getByIDFn = func(id uuid.UUID) (*domainUser.User, error) {
+		return &domainUser.User{ID: id, Role: domainUser.RoleClient}, nil
+	}
 
 	t.Run("Success", func(t *testing.T) {
 		// Setup mock behavior
@@ -747,6 +1124,7 @@ func TestEndSchedule(t *testing.T) {
 				Feedback:    &feedback,
 			},
 		}
+		originalSchedule.Tasks = tasks
 
 		// Create updated schedule
 		updatedSchedule := *originalSchedule
@@ -932,6 +1310,225 @@ func TestEndSchedule(t *testing.T) {
 			t.Error("expected nil result")
 		}
 	})
+
+	t.Run("Task update error rolls back checkout", func(t *testing.T) {
+		scheduleID := uuid.New()
+		originalSchedule := createTestSchedule(scheduleID)
+		originalSchedule.VisitStatus = "in_progress"
+
+		mockScheduleRepo.getScheduleByIDFn = func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return originalSchedule, nil
+		}
+		mockScheduleRepo.updateScheduleFn = func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			updated := *originalSchedule
+			updated.VisitStatus = "completed"
+			return &updated, nil
+		}
+		var updatedTasks int
+		mockScheduleRepo.updateTaskFn = func(taskID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Task, error) {
+			updatedTasks++
+			if updatedTasks == 2 {
+				return nil, errors.New("database error")
+			}
+			return &domainSchedule.Task{ID: taskID}, nil
+		}
+		rollbacks := mockScheduleRepo.rollbacks
+
+		tasks := []domainSchedule.Task{{ID: uuid.New(), Status: "completed"}, {ID: uuid.New(), Status: "completed"}, {ID: uuid.New(), Status: "completed"}}
+		originalSchedule.Tasks = tasks
+		result, err := useCase.EndSchedule(scheduleID, time.Now(), domainSchedule.Location{}, tasks)
+
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+		if result != nil {
+			t.Error("expected nil result")
+		}
+		if updatedTasks != 2 {
+			t.Errorf("expected the checkout to stop at the failing task, got %d task updates", updatedTasks)
+		}
+		if mockScheduleRepo.rollbacks != rollbacks+1 {
+			t.Error("expected the checkout to be rolled back")
+		}
+	})
+
+	t.Run("Task of another visit is refused", func(t *testing.T) {
+		scheduleID := uuid.New()
+		originalSchedule := createTestSchedule(scheduleID)
+		originalSchedule.VisitStatus = "in_progress"
+		originalSchedule.Tasks = []domainSchedule.Task{{ID: uuid.New(), ScheduleID: scheduleID, Title: "Task 1"}}
+
+		mockScheduleRepo.getScheduleByIDFn = func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return originalSchedule, nil
+		}
+		mockScheduleRepo.updateScheduleFn = func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			t.Error("expected the visit not to be checked out")
+			return nil, errors.New("unexpected update")
+		}
+		mockScheduleRepo.updateTaskFn = func(taskID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Task, error) {
+			t.Errorf("expected no task updated, got %s", taskID)
+			return nil, errors.New("unexpected update")
+		}
+
+		tasks := []domainSchedule.Task{{ID: originalSchedule.Tasks[0].ID, Status: "completed"}, {ID: uuid.New(), Status: "completed"}}
+		result, err := useCase.EndSchedule(scheduleID, time.Now(), domainSchedule.Location{}, tasks)
+
+		var appErr *domainErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Type != domainErrors.ValidationError {
+			t.Errorf("expected ValidationError, got %v", err)
+		}
+		if result != nil {
+			t.Error("expected nil result")
+		}
+	})
+}
+
+// createTestSplitSchedule creates a schedule with a morning and an evening segment
+func createTestSplitSchedule(id uuid.UUID, now time.Time) *domainSchedule.Schedule {
+	schedule := createTestSchedule(id)
+	schedule.ScheduledSlot = domainSchedule.ScheduledSlot{
+		From: now.Add(-1 * time.Hour),
+		To:   now.Add(10 * time.Hour),
+	}
+	schedule.Segments = []domainSchedule.Segment{
+		{ID: uuid.New(), ScheduleID: id, Sequence: 1, From: now.Add(-1 * time.Hour), To: now.Add(1 * time.Hour), VisitStatus: "upcoming"},
+		{ID: uuid.New(), ScheduleID: id, Sequence: 2, From: now.Add(8 * time.Hour), To: now.Add(10 * time.Hour), VisitStatus: "upcoming"},
+	}
+	return schedule
+}
+
+// TestStartScheduleWithSegments tests check-in semantics for split shifts
+func TestStartScheduleWithSegments(t *testing.T) {
+	useCase, mockScheduleRepo, _, _ := setupTestScheduleUseCase(t)
+	lat := 12.345
+	long := 67.890
+	location := domainSchedule.Location{Lat: &lat, Long: &long}
+
+	t.Run("Starts first pending segment", func(t *testing.T) {
+		now := time.Now()
+		scheduleID := uuid.New()
+		schedule := createTestSplitSchedule(scheduleID, now)
+
+		mockScheduleRepo.getScheduleByIDFn = func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return schedule, nil
+		}
+		var startedSegment uuid.UUID
+		mockScheduleRepo.updateSegmentFn = func(segmentID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Segment, error) {
+			startedSegment = segmentID
+			if updates["visit_status"] != "in_progress" {
+				t.Errorf("expected segment visit_status 'in_progress', got %v", updates["visit_status"])
+			}
+			return &domainSchedule.Segment{ID: segmentID}, nil
+		}
+		mockScheduleRepo.updateScheduleFn = func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			if updates["checkin_time"] != now {
+				t.Errorf("expected first segment to set schedule checkin_time")
+			}
+			updated := *schedule
+			updated.VisitStatus = "in_progress"
+			return &updated, nil
+		}
+
+		result, err := useCase.StartSchedule(scheduleID, now, location, domainSchedule.Verification{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if startedSegment != schedule.Segments[0].ID {
+			t.Errorf("expected first segment to be started, got %v", startedSegment)
+		}
+		if result.VisitStatus != "in_progress" {
+			t.Errorf("expected visit_status 'in_progress', got %s", result.VisitStatus)
+		}
+	})
+
+	t.Run("Rejects segment before its slot", func(t *testing.T) {
+		now := time.Now()
+		scheduleID := uuid.New()
+		schedule := createTestSplitSchedule(scheduleID, now)
+		schedule.VisitStatus = "partially_completed"
+		schedule.Segments[0].VisitStatus = "completed"
+
+		mockScheduleRepo.getScheduleByIDFn = func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return schedule, nil
+		}
+		mockScheduleRepo.updateSegmentFn = func(segmentID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Segment, error) {
+			t.Error("segment should not be updated")
+			return nil, nil
+		}
+
+		result, err := useCase.StartSchedule(scheduleID, now, location, domainSchedule.Verification{})
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+		if result != nil {
+			t.Error("expected nil result")
+		}
+	})
+}
+
+// TestEndScheduleWithSegments tests check-out semantics for split shifts
+func TestEndScheduleWithSegments(t *testing.T) {
+	useCase, mockScheduleRepo, mockUserRepo, _ := setupTestScheduleUseCase(t)
+	mockUserRepo.getByIDFn = func(id uuid.UUID) (*domainUser.User, error) {
+		return &domainUser.User{ID: id, Role: domainUser.RoleClient}, nil
+	}
+	lat := 12.345
+	long := 67.890
+	location := domainSchedule.Location{Lat: &lat, Long: &long}
+	mockScheduleRepo.updateSegmentFn = func(segmentID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Segment, error) {
+		return &domainSchedule.Segment{ID: segmentID}, nil
+	}
+
+	t.Run("Intermediate segment leaves schedule partially completed", func(t *testing.T) {
+		now := time.Now()
+		scheduleID := uuid.New()
+		schedule := createTestSplitSchedule(scheduleID, now)
+		schedule.VisitStatus = "in_progress"
+		schedule.Segments[0].VisitStatus = "in_progress"
+
+		mockScheduleRepo.getScheduleByIDFn = func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return schedule, nil
+		}
+		mockScheduleRepo.updateScheduleFn = func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			if updates["visit_status"] != "partially_completed" {
+				t.Errorf("expected visit_status 'partially_completed', got %v", updates["visit_status"])
+			}
+			if _, ok := updates["checkout_time"]; ok {
+				t.Error("schedule checkout_time should only be set by the last segment")
+			}
+			return schedule, nil
+		}
+
+		if _, err := useCase.EndSchedule(scheduleID, now, location, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Last segment completes schedule", func(t *testing.T) {
+		now := time.Now()
+		scheduleID := uuid.New()
+		schedule := createTestSplitSchedule(scheduleID, now)
+		schedule.VisitStatus = "in_progress"
+		schedule.Segments[0].VisitStatus = "completed"
+		schedule.Segments[1].VisitStatus = "in_progress"
+
+		mockScheduleRepo.getScheduleByIDFn = func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			return schedule, nil
+		}
+		mockScheduleRepo.updateScheduleFn = func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			if updates["visit_status"] != "completed" {
+				t.Errorf("expected visit_status 'completed', got %v", updates["visit_status"])
+			}
+			if updates["checkout_time"] != now {
+				t.Error("expected last segment to set schedule checkout_time")
+			}
+			return schedule, nil
+		}
+
+		if _, err := useCase.EndSchedule(scheduleID, now, location, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
 }
 
 // TestUpdateTaskStatus tests the UpdateTaskStatus method
@@ -973,7 +1570,7 @@ func TestUpdateTaskStatus(t *testing.T) {
 		}
 
 		// Execute
-		result, err := useCase.UpdateTaskStatus(taskID, status, done, feedback)
+		result, err := useCase.UpdateTaskStatus(taskID, status, done, feedback, nil)
 
 		// Verify
 		if err != nil {
@@ -1007,7 +1604,7 @@ func TestUpdateTaskStatus(t *testing.T) {
 		}
 
 		// Execute
-		result, err := useCase.UpdateTaskStatus(uuid.New(), "completed", true, "feedback")
+		result, err := useCase.UpdateTaskStatus(uuid.New(), "completed", true, "feedback", nil)
 
 		// Verify
 		if err == nil {
@@ -1017,6 +1614,33 @@ func TestUpdateTaskStatus(t *testing.T) {
 			t.Error("expected nil result")
 		}
 	})
+
+	t.Run("Vitals readings", func(t *testing.T) {
+		mockScheduleRepo.getTaskByIDFn = func(id uuid.UUID) (*domainSchedule.Task, error) {
+			return &domainSchedule.Task{ID: id, Kind: domainSchedule.TaskKindVitals}, nil
+		}
+		defer func() { mockScheduleRepo.getTaskByIDFn = nil }()
+		var saved map[string]interface{}
+		mockScheduleRepo.updateTaskFn = func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Task, error) {
+			saved = updates
+			return &domainSchedule.Task{ID: id}, nil
+		}
+
+		if _, err := useCase.UpdateTaskStatus(uuid.New(), "completed", true, "", nil); err == nil {
+			t.Error("expected an error for a vitals task done without readings")
+		}
+		if saved != nil {
+			t.Error("expected nothing saved without readings")
+		}
+
+		heartRate := 72
+		if _, err := useCase.UpdateTaskStatus(uuid.New(), "completed", true, "", &domainSchedule.Vitals{HeartRate: &heartRate}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if saved["vitals_heart_rate"] != &heartRate {
+			t.Errorf("expected the heart rate saved, got %v", saved["vitals_heart_rate"])
+		}
+	})
 }
 
 // TestCreateSchedule tests the CreateSchedule method
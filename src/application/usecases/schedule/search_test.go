@@ -0,0 +1,106 @@
+package schedule
+
+import (
+	"testing"
+
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+type mockViewResolver struct {
+	apply func(viewID uuid.UUID, query *domainSchedule.SearchQuery) error
+}
+
+func (m *mockViewResolver) ApplyView(viewID uuid.UUID, query *domainSchedule.SearchQuery) error {
+	return m.apply(viewID, query)
+}
+
+type mockTeamResolver struct {
+	members []uuid.UUID
+}
+
+func (m *mockTeamResolver) GetMemberIDs(teamIDs []uuid.UUID) ([]uuid.UUID, error) {
+	return m.members, nil
+}
+
+func TestSearchSchedules(t *testing.T) {
+	berlinClient := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: domainUser.Location{City: "Berlin"}}
+	hamburgClient := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: domainUser.Location{City: "Hamburg"}}
+	caregiverID := uuid.New()
+
+	mockScheduleRepo := &mockScheduleRepository{}
+	mockUserRepo := &mockUserRepository{
+		getAllFn: func() (*[]domainUser.User, error) {
+			return &[]domainUser.User{berlinClient, hamburgClient}, nil
+		},
+		getByIDFn: func(id uuid.UUID) (*domainUser.User, error) {
+			return &berlinClient, nil
+		},
+	}
+	viewID := uuid.New()
+	resolver := &mockViewResolver{apply: func(id uuid.UUID, query *domainSchedule.SearchQuery) error {
+		if id != viewID {
+			t.Errorf("unexpected view %s", id)
+		}
+		query.Regions = []string{"berlin"}
+		query.ColorRules = []domainSchedule.ColorRule{{Field: domainSchedule.ColorByCaregiver, Value: caregiverID.String(), Color: "#112233"}}
+		return nil
+	}}
+	useCase := NewScheduleUseCase(mockScheduleRepo, mockUserRepo, setupLogger(t), WithViewResolver(resolver))
+
+	var received domainSchedule.SearchQuery
+	mockScheduleRepo.searchFn = func(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+		received = query
+		return &domainSchedule.SearchResultSchedule{Data: &[]domainSchedule.Schedule{
+			{ID: uuid.New(), ClientUserID: berlinClient.ID, AssignedUserID: caregiverID, VisitStatus: "upcoming"},
+			{ID: uuid.New(), ClientUserID: berlinClient.ID, AssignedUserID: caregiverID, VisitStatus: "upcoming", ColorTag: "#FFFFFF"},
+			{ID: uuid.New(), ClientUserID: berlinClient.ID, AssignedUserID: uuid.New(), VisitStatus: "upcoming"},
+		}, Total: 3}, nil
+	}
+
+	result, clients, err := useCase.SearchSchedules(domainSchedule.SearchQuery{ViewID: &viewID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received.ClientUserIDs) != 1 || received.ClientUserIDs[0] != berlinClient.ID {
+		t.Errorf("expected region to resolve to the Berlin client, got %v", received.ClientUserIDs)
+	}
+	data := *result.Data
+	if data[0].ColorTag != "#112233" || data[1].ColorTag != "#FFFFFF" || data[2].ColorTag != "" {
+		t.Errorf("unexpected color tags %q %q %q", data[0].ColorTag, data[1].ColorTag, data[2].ColorTag)
+	}
+	if len(*clients) != 1 {
+		t.Errorf("expected one distinct client, got %d", len(*clients))
+	}
+
+	t.Run("Team scope", func(t *testing.T) {
+		teamID, otherCaregiverID := uuid.New(), uuid.New()
+		teamUseCase := NewScheduleUseCase(mockScheduleRepo, mockUserRepo, setupLogger(t), WithTeamResolver(&mockTeamResolver{members: []uuid.UUID{caregiverID, otherCaregiverID}}))
+		mockScheduleRepo.searchFn = func(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+			received = query
+			return &domainSchedule.SearchResultSchedule{Data: &[]domainSchedule.Schedule{}}, nil
+		}
+		if _, _, err := teamUseCase.SearchSchedules(domainSchedule.SearchQuery{TeamIDs: []uuid.UUID{teamID}, AssignedUserIDs: []uuid.UUID{otherCaregiverID, uuid.New()}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(received.AssignedUserIDs) != 1 || received.AssignedUserIDs[0] != otherCaregiverID {
+			t.Errorf("expected search narrowed to team members, got %v", received.AssignedUserIDs)
+		}
+		if _, _, err := useCase.SearchSchedules(domainSchedule.SearchQuery{TeamIDs: []uuid.UUID{teamID}}); err == nil {
+			t.Error("expected error without a team resolver")
+		}
+	})
+
+	t.Run("Region without clients", func(t *testing.T) {
+		mockScheduleRepo.searchFn = func(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+			t.Error("repository should not be queried")
+			return nil, nil
+		}
+		result, _, err := useCase.SearchSchedules(domainSchedule.SearchQuery{Regions: []string{"Munich"}})
+		if err != nil || len(*result.Data) != 0 {
+			t.Errorf("expected empty result, got %v, %v", result, err)
+		}
+	})
+}
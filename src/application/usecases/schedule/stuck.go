@@ -0,0 +1,50 @@
+package schedule
+
+import (
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"go.uber.org/zap"
+)
+
+// CloseStuckVisits checks out every visit still in progress whose slot ended
+// before the given time, as if the caregiver had checked out when the slot
+// (or the segment they were checked into) ended. The check-out location is
+// left empty. Visits that cannot be closed are logged and skipped; the ones
+// closed are returned.
+func (s *ScheduleUseCase) CloseStuckVisits(before time.Time) (*[]domainSchedule.Schedule, error) {
+	stuck, err := s.scheduleRepository.GetStuckSchedulesBefore(before)
+	if err != nil {
+		return nil, err
+	}
+	closed := make([]domainSchedule.Schedule, 0, len(*stuck))
+	for i := range *stuck {
+		schedule := &(*stuck)[i]
+		checkout := stuckCheckoutTime(schedule)
+		updated, err := s.EndSchedule(schedule.ID, checkout, domainSchedule.Location{}, nil)
+		if err != nil {
+			s.Logger.Error("Error closing stuck visit", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+			continue
+		}
+		s.Logger.Warn("Stuck visit closed",
+			zap.String("scheduleID", schedule.ID.String()),
+			zap.String("assignedUserID", schedule.AssignedUserID.String()),
+			zap.Time("checkoutTime", checkout))
+		closed = append(closed, *updated)
+	}
+	return &closed, nil
+}
+
+// stuckCheckoutTime is when the checked-in slot or segment was due to end,
+// or the check-in itself when that was later.
+func stuckCheckoutTime(schedule *domainSchedule.Schedule) time.Time {
+	end, checkin := schedule.ScheduledSlot.To, schedule.CheckinTime
+	if segment := schedule.ActiveSegment(); segment != nil {
+		end, checkin = segment.To, segment.CheckinTime
+	}
+	if checkin != nil && checkin.After(end) {
+		return *checkin
+	}
+	return end
+}
@@ -0,0 +1,50 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+func TestCloseStuckVisits(t *testing.T) {
+	slotEnd := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	lateCheckin := slotEnd.Add(30 * time.Minute)
+	onTime := domainSchedule.Schedule{ID: uuid.New(), VisitStatus: "in_progress", ScheduledSlot: domainSchedule.ScheduledSlot{To: slotEnd}}
+	late := domainSchedule.Schedule{ID: uuid.New(), VisitStatus: "in_progress", ScheduledSlot: domainSchedule.ScheduledSlot{To: slotEnd}, CheckinTime: &lateCheckin}
+	byID := map[uuid.UUID]domainSchedule.Schedule{onTime.ID: onTime, late.ID: late}
+
+	checkouts := make(map[uuid.UUID]time.Time)
+	mockScheduleRepo := &mockScheduleRepository{
+		getStuckSchedulesBeforeFn: func(before time.Time) (*[]domainSchedule.Schedule, error) {
+			return &[]domainSchedule.Schedule{onTime, late}, nil
+		},
+		getScheduleByIDFn: func(id uuid.UUID) (*domainSchedule.Schedule, error) {
+			schedule := byID[id]
+			return &schedule, nil
+		},
+		updateScheduleFn: func(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+			checkouts[id] = updates["checkout_time"].(time.Time)
+			schedule := byID[id]
+			schedule.VisitStatus = updates["visit_status"].(string)
+			return &schedule, nil
+		},
+	}
+	useCase := NewScheduleUseCase(mockScheduleRepo, &mockUserRepository{}, setupLogger(t))
+
+	closed, err := useCase.CloseStuckVisits(slotEnd.Add(12 * time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*closed) != 2 {
+		t.Fatalf("expected 2 visits closed, got %d", len(*closed))
+	}
+	if !checkouts[onTime.ID].Equal(slotEnd) {
+		t.Errorf("expected check-out at the end of the slot, got %v", checkouts[onTime.ID])
+	}
+	if !checkouts[late.ID].Equal(lateCheckin) {
+		t.Errorf("expected check-out no earlier than the check-in, got %v", checkouts[late.ID])
+	}
+}
@@ -0,0 +1,143 @@
+package scheduleview
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainScheduleView "caregiver/src/domain/scheduleview"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IScheduleViewUseCase interface {
+	Create(view *domainScheduleView.View) (*domainScheduleView.View, error)
+	GetByID(id uuid.UUID) (*domainScheduleView.View, error)
+	GetByOwner(ownerUserID uuid.UUID) (*[]domainScheduleView.View, error)
+	Update(id uuid.UUID, view *domainScheduleView.View) (*domainScheduleView.View, error)
+	Delete(id uuid.UUID) error
+	ApplyView(viewID uuid.UUID, query *domainSchedule.SearchQuery) error
+}
+
+type ScheduleViewUseCase struct {
+	viewRepository domainScheduleView.IScheduleViewRepository
+	userRepository domainUser.IUserRepository
+	Logger         *logger.Logger
+}
+
+func NewScheduleViewUseCase(viewRepository domainScheduleView.IScheduleViewRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) IScheduleViewUseCase {
+	return &ScheduleViewUseCase{
+		viewRepository: viewRepository,
+		userRepository: userRepository,
+		Logger:         loggerInstance,
+	}
+}
+
+func (u *ScheduleViewUseCase) Create(view *domainScheduleView.View) (*domainScheduleView.View, error) {
+	u.Logger.Info("Creating schedule view", zap.String("ownerUserID", view.OwnerUserID.String()), zap.String("name", view.Name))
+
+	owner, err := u.userRepository.GetByID(view.OwnerUserID)
+	if err != nil {
+		u.Logger.Error("Owner not found for schedule view", zap.Error(err), zap.String("ownerUserID", view.OwnerUserID.String()))
+		return nil, domainErrors.NewAppError(errors.New("owner user not found"), domainErrors.NotFound)
+	}
+	if owner.Role != domainUser.RoleAdmin {
+		return nil, domainErrors.NewAppError(errors.New("only coordinators can save schedule views"), domainErrors.NotAuthorized)
+	}
+	if err := u.validateView(view, uuid.Nil); err != nil {
+		return nil, err
+	}
+	view.ID = uuid.New()
+	return u.viewRepository.Create(view)
+}
+
+func (u *ScheduleViewUseCase) GetByID(id uuid.UUID) (*domainScheduleView.View, error) {
+	u.Logger.Info("Getting schedule view by ID", zap.String("id", id.String()))
+	return u.viewRepository.GetByID(id)
+}
+
+func (u *ScheduleViewUseCase) GetByOwner(ownerUserID uuid.UUID) (*[]domainScheduleView.View, error) {
+	u.Logger.Info("Getting schedule views for owner", zap.String("ownerUserID", ownerUserID.String()))
+	return u.viewRepository.GetByOwner(ownerUserID)
+}
+
+// Update replaces a view's name, filters, sort order and color rules. The
+// owner cannot be changed.
+func (u *ScheduleViewUseCase) Update(id uuid.UUID, view *domainScheduleView.View) (*domainScheduleView.View, error) {
+	u.Logger.Info("Updating schedule view", zap.String("id", id.String()))
+
+	existing, err := u.viewRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	view.ID = existing.ID
+	view.OwnerUserID = existing.OwnerUserID
+	view.CreatedAt = existing.CreatedAt
+	if err := u.validateView(view, id); err != nil {
+		return nil, err
+	}
+	return u.viewRepository.Update(view)
+}
+
+func (u *ScheduleViewUseCase) Delete(id uuid.UUID) error {
+	u.Logger.Info("Deleting schedule view", zap.String("id", id.String()))
+	return u.viewRepository.Delete(id)
+}
+
+// ApplyView lets the schedule search start from a saved view.
+func (u *ScheduleViewUseCase) ApplyView(viewID uuid.UUID, query *domainSchedule.SearchQuery) error {
+	view, err := u.viewRepository.GetByID(viewID)
+	if err != nil {
+		return err
+	}
+	view.ApplyTo(query)
+	return nil
+}
+
+func (u *ScheduleViewUseCase) validateView(view *domainScheduleView.View, currentID uuid.UUID) error {
+	view.Name = strings.TrimSpace(view.Name)
+	if view.Name == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	if view.SortBy != "" && !contains(domainSchedule.SortableColumns, view.SortBy) {
+		return domainErrors.NewAppError(fmt.Errorf("sortBy must be one of %s", strings.Join(domainSchedule.SortableColumns, ", ")), domainErrors.ValidationError)
+	}
+	if view.SortDirection != "" && !view.SortDirection.IsValid() {
+		return domainErrors.NewAppError(errors.New("sortDirection must be 'asc' or 'desc'"), domainErrors.ValidationError)
+	}
+	for _, rule := range view.ColorRules {
+		switch rule.Field {
+		case domainSchedule.ColorByStatus, domainSchedule.ColorByService, domainSchedule.ColorByCaregiver, domainSchedule.ColorByClient:
+		default:
+			return domainErrors.NewAppError(fmt.Errorf("unknown color rule field %q", rule.Field), domainErrors.ValidationError)
+		}
+		if !domainSchedule.ValidColor(rule.Color) {
+			return domainErrors.NewAppError(fmt.Errorf("color %q must be a #RRGGBB hex value", rule.Color), domainErrors.ValidationError)
+		}
+	}
+
+	existing, err := u.viewRepository.GetByOwner(view.OwnerUserID)
+	if err != nil {
+		return err
+	}
+	for _, other := range *existing {
+		if other.ID != currentID && strings.EqualFold(other.Name, view.Name) {
+			return domainErrors.NewAppError(fmt.Errorf("a view named %q already exists", view.Name), domainErrors.Conflict)
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package scheduleview
+
+import (
+	"errors"
+	"testing"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainScheduleView "caregiver/src/domain/scheduleview"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type mockViewRepository struct {
+	views map[uuid.UUID]domainScheduleView.View
+}
+
+func (m *mockViewRepository) Create(view *domainScheduleView.View) (*domainScheduleView.View, error) {
+	m.views[view.ID] = *view
+	return view, nil
+}
+
+func (m *mockViewRepository) GetByID(id uuid.UUID) (*domainScheduleView.View, error) {
+	view, ok := m.views[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &view, nil
+}
+
+func (m *mockViewRepository) GetByOwner(ownerUserID uuid.UUID) (*[]domainScheduleView.View, error) {
+	views := []domainScheduleView.View{}
+	for _, view := range m.views {
+		if view.OwnerUserID == ownerUserID {
+			views = append(views, view)
+		}
+	}
+	return &views, nil
+}
+
+func (m *mockViewRepository) Update(view *domainScheduleView.View) (*domainScheduleView.View, error) {
+	m.views[view.ID] = *view
+	return view, nil
+}
+
+func (m *mockViewRepository) Delete(id uuid.UUID) error {
+	delete(m.views, id)
+	return nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &user, nil
+}
+
+func setupTestUseCase(t *testing.T, users ...domainUser.User) *ScheduleViewUseCase {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	userRepo := &mockUserRepository{users: make(map[uuid.UUID]domainUser.User)}
+	for _, user := range users {
+		userRepo.users[user.ID] = user
+	}
+	repo := &mockViewRepository{views: make(map[uuid.UUID]domainScheduleView.View)}
+	return NewScheduleViewUseCase(repo, userRepo, loggerInstance).(*ScheduleViewUseCase)
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestCreate(t *testing.T) {
+	coordinator := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	uc := setupTestUseCase(t, coordinator, caregiver)
+
+	view, err := uc.Create(&domainScheduleView.View{OwnerUserID: coordinator.ID, Name: " Night shifts ", SortBy: "visit_status", SortDirection: "desc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if view.Name != "Night shifts" {
+		t.Errorf("expected trimmed name, got %q", view.Name)
+	}
+
+	cases := []struct {
+		name     string
+		view     domainScheduleView.View
+		expected domainErrors.ErrorType
+	}{
+		{"Duplicate name", domainScheduleView.View{OwnerUserID: coordinator.ID, Name: "night shifts"}, domainErrors.Conflict},
+		{"Caregiver owner", domainScheduleView.View{OwnerUserID: caregiver.ID, Name: "Mine"}, domainErrors.NotAuthorized},
+		{"Unknown sort", domainScheduleView.View{OwnerUserID: coordinator.ID, Name: "Sorted", SortBy: "password"}, domainErrors.ValidationError},
+		{"Bad color", domainScheduleView.View{OwnerUserID: coordinator.ID, Name: "Colors", ColorRules: []domainSchedule.ColorRule{{Field: domainSchedule.ColorByStatus, Value: "missed", Color: "red"}}}, domainErrors.ValidationError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			view := tc.view
+			if _, err := uc.Create(&view); errorType(err) != tc.expected {
+				t.Errorf("expected %s, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestApplyView(t *testing.T) {
+	coordinator := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	uc := setupTestUseCase(t, coordinator)
+	view, err := uc.Create(&domainScheduleView.View{
+		OwnerUserID:   coordinator.ID,
+		Name:          "North",
+		Filters:       domainScheduleView.Filters{Statuses: []string{"upcoming"}, Regions: []string{"Hamburg"}},
+		SortBy:        "service_name",
+		SortDirection: "desc",
+		ColorRules:    []domainSchedule.ColorRule{{Field: domainSchedule.ColorByStatus, Value: "upcoming", Color: "#00FF00"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query := domainSchedule.SearchQuery{Statuses: []string{"missed"}}
+	if err := uc.ApplyView(view.ID, &query); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(query.Statuses) != 1 || query.Statuses[0] != "missed" {
+		t.Errorf("explicit statuses should win, got %v", query.Statuses)
+	}
+	if len(query.Regions) != 1 || query.SortBy != "service_name" || query.SortDirection != "desc" || len(query.ColorRules) != 1 {
+		t.Errorf("view defaults not applied: %+v", query)
+	}
+}
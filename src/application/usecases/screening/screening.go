@@ -0,0 +1,338 @@
+package screening
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainScreening "caregiver/src/domain/screening"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+	"caregiver/src/infrastructure/screening"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ProviderManual marks checks whose result was entered by a coordinator.
+const ProviderManual = "manual"
+
+type IScreeningUseCase interface {
+	OrderBackgroundCheck(caregiverID uuid.UUID, now time.Time) (*domainScreening.Check, error)
+	VerifyLicense(caregiverID uuid.UUID, number, state, licenseType string, now time.Time) (*domainScreening.Check, error)
+	RecordCheck(newCheck *domainScreening.Check, now time.Time) (*domainScreening.Check, error)
+	Refresh(id uuid.UUID, now time.Time) (*domainScreening.Check, error)
+	GetChecks(filter domainScreening.CheckFilter) (*[]domainScreening.Check, error)
+	GetCheckByID(id uuid.UUID) (*domainScreening.Check, error)
+	DeleteCheck(id uuid.UUID) error
+	Sweep(now time.Time) (int, error)
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+}
+
+type ScreeningUseCase struct {
+	screeningRepository domainScreening.IScreeningRepository
+	userRepository      domainUser.IUserRepository
+	provider            screening.IProvider
+	notifier            notification.INotifier
+	Logger              *logger.Logger
+}
+
+func NewScreeningUseCase(screeningRepository domainScreening.IScreeningRepository, userRepository domainUser.IUserRepository, provider screening.IProvider, notifier notification.INotifier, loggerInstance *logger.Logger) IScreeningUseCase {
+	return &ScreeningUseCase{
+		screeningRepository: screeningRepository,
+		userRepository:      userRepository,
+		provider:            provider,
+		notifier:            notifier,
+		Logger:              loggerInstance,
+	}
+}
+
+// OrderBackgroundCheck orders a background check for a caregiver from the
+// screening provider. It usually stays pending until Sweep or Refresh picks
+// up the result.
+func (u *ScreeningUseCase) OrderBackgroundCheck(caregiverID uuid.UUID, now time.Time) (*domainScreening.Check, error) {
+	u.Logger.Info("Ordering background check", zap.String("caregiverUserID", caregiverID.String()))
+	caregiver, err := u.requireCaregiver(caregiverID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := u.provider.OrderBackgroundCheck(context.Background(), screening.Candidate{
+		ID:        caregiver.ID.String(),
+		FirstName: caregiver.FirstName,
+		LastName:  caregiver.LastName,
+		Email:     caregiver.Email,
+	})
+	if err != nil {
+		return nil, u.providerError("Error ordering background check", err)
+	}
+	check := &domainScreening.Check{
+		ID:              uuid.New(),
+		CaregiverUserID: caregiverID,
+		Kind:            domainScreening.KindBackgroundCheck,
+		Provider:        u.provider.Name(),
+		OrderedAt:       now,
+	}
+	applyResult(check, result, now)
+	created, err := u.screeningRepository.CreateCheck(check)
+	if err != nil {
+		return nil, err
+	}
+	u.notifyFailure(created)
+	return created, nil
+}
+
+// VerifyLicense looks a caregiver's professional license up with the
+// screening provider and stores the answer.
+func (u *ScreeningUseCase) VerifyLicense(caregiverID uuid.UUID, number, state, licenseType string, now time.Time) (*domainScreening.Check, error) {
+	u.Logger.Info("Verifying license", zap.String("caregiverUserID", caregiverID.String()))
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return nil, domainErrors.NewAppError(errors.New("license number is required"), domainErrors.ValidationError)
+	}
+	caregiver, err := u.requireCaregiver(caregiverID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := u.provider.VerifyLicense(context.Background(), screening.License{
+		Number:    number,
+		State:     state,
+		Type:      licenseType,
+		FirstName: caregiver.FirstName,
+		LastName:  caregiver.LastName,
+	})
+	if err != nil {
+		return nil, u.providerError("Error verifying license", err)
+	}
+	check := &domainScreening.Check{
+		ID:              uuid.New(),
+		CaregiverUserID: caregiverID,
+		Kind:            domainScreening.KindLicense,
+		Provider:        u.provider.Name(),
+		LicenseNumber:   number,
+		LicenseState:    state,
+		LicenseType:     licenseType,
+		OrderedAt:       now,
+	}
+	applyResult(check, result, now)
+	created, err := u.screeningRepository.CreateCheck(check)
+	if err != nil {
+		return nil, err
+	}
+	u.notifyFailure(created)
+	return created, nil
+}
+
+// RecordCheck stores a result obtained outside the provider, such as a paper
+// background check or a license verified on the board's website.
+func (u *ScreeningUseCase) RecordCheck(newCheck *domainScreening.Check, now time.Time) (*domainScreening.Check, error) {
+	u.Logger.Info("Recording screening check", zap.String("caregiverUserID", newCheck.CaregiverUserID.String()), zap.String("kind", newCheck.Kind))
+	if newCheck.Kind != domainScreening.KindBackgroundCheck && newCheck.Kind != domainScreening.KindLicense {
+		return nil, domainErrors.NewAppError(errors.New("kind must be 'background_check' or 'license'"), domainErrors.ValidationError)
+	}
+	if newCheck.Status != domainScreening.StatusPassed && newCheck.Status != domainScreening.StatusFailed {
+		return nil, domainErrors.NewAppError(errors.New("status must be 'passed' or 'failed'"), domainErrors.ValidationError)
+	}
+	if newCheck.Kind == domainScreening.KindLicense && strings.TrimSpace(newCheck.LicenseNumber) == "" {
+		return nil, domainErrors.NewAppError(errors.New("license number is required"), domainErrors.ValidationError)
+	}
+	if _, err := u.requireCaregiver(newCheck.CaregiverUserID); err != nil {
+		return nil, err
+	}
+	newCheck.ID = uuid.New()
+	newCheck.Provider = ProviderManual
+	newCheck.ExternalID = ""
+	if newCheck.OrderedAt.IsZero() {
+		newCheck.OrderedAt = now
+	}
+	newCheck.CompletedAt = &now
+	return u.screeningRepository.CreateCheck(newCheck)
+}
+
+// Refresh asks the provider for the progress of a pending background check.
+func (u *ScreeningUseCase) Refresh(id uuid.UUID, now time.Time) (*domainScreening.Check, error) {
+	check, err := u.screeningRepository.GetCheckByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if check.Status != domainScreening.StatusPending {
+		return check, nil
+	}
+	return u.poll(check, now)
+}
+
+func (u *ScreeningUseCase) GetChecks(filter domainScreening.CheckFilter) (*[]domainScreening.Check, error) {
+	return u.screeningRepository.GetChecks(filter)
+}
+
+func (u *ScreeningUseCase) GetCheckByID(id uuid.UUID) (*domainScreening.Check, error) {
+	return u.screeningRepository.GetCheckByID(id)
+}
+
+func (u *ScreeningUseCase) DeleteCheck(id uuid.UUID) error {
+	u.Logger.Info("Deleting screening check", zap.String("id", id.String()))
+	return u.screeningRepository.DeleteCheck(id)
+}
+
+// Sweep polls the provider for pending background checks and returns how
+// many completed.
+func (u *ScreeningUseCase) Sweep(now time.Time) (int, error) {
+	pending, err := u.screeningRepository.GetChecks(domainScreening.CheckFilter{
+		Kind:   domainScreening.KindBackgroundCheck,
+		Status: domainScreening.StatusPending,
+	})
+	if err != nil {
+		return 0, err
+	}
+	completed := 0
+	for i := range *pending {
+		check := &(*pending)[i]
+		if check.Provider != u.provider.Name() || check.ExternalID == "" {
+			continue
+		}
+		updated, err := u.poll(check, now)
+		if err != nil {
+			continue
+		}
+		if updated.Status != domainScreening.StatusPending {
+			completed++
+		}
+	}
+	return completed, nil
+}
+
+// ValidateSchedule blocks assigning a caregiver whose latest background
+// check or license verification failed, or had expired by the start of the
+// visit. Each license counts separately. Caregivers without any checks on
+// file are not blocked.
+func (u *ScreeningUseCase) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.VisitStatus == "cancelled" || schedule.AssignedUserID == uuid.Nil {
+		return nil, nil
+	}
+	checks, err := u.screeningRepository.GetChecks(domainScreening.CheckFilter{CaregiverUserID: &schedule.AssignedUserID})
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, check := range latestResults(*checks) {
+		label := describe(&check)
+		if check.Status == domainScreening.StatusFailed {
+			return nil, domainErrors.NewAppError(fmt.Errorf("the caregiver's %s did not pass", label), domainErrors.ValidationError)
+		}
+		if check.Expired(schedule.ScheduledSlot.From) {
+			return nil, domainErrors.NewAppError(fmt.Errorf("the caregiver's %s expired on %s", label, check.ExpiresAt.Format("2006-01-02")), domainErrors.ValidationError)
+		}
+		if check.Expired(schedule.ScheduledSlot.To) {
+			warnings = append(warnings, fmt.Sprintf("the caregiver's %s expires during the visit", label))
+		}
+	}
+	return warnings, nil
+}
+
+func (u *ScreeningUseCase) poll(check *domainScreening.Check, now time.Time) (*domainScreening.Check, error) {
+	result, err := u.provider.GetBackgroundCheck(context.Background(), check.ExternalID)
+	if err != nil {
+		return nil, u.providerError("Error polling background check", err)
+	}
+	if result.Status == domainScreening.StatusPending {
+		return check, nil
+	}
+	applyResult(check, result, now)
+	updated, err := u.screeningRepository.UpdateCheck(check.ID, map[string]interface{}{
+		"status":       check.Status,
+		"detail":       check.Detail,
+		"completed_at": check.CompletedAt,
+		"expires_at":   check.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.notifyFailure(updated)
+	return updated, nil
+}
+
+// notifyFailure tells coordinators when a check comes back failed.
+func (u *ScreeningUseCase) notifyFailure(check *domainScreening.Check) {
+	if check.Status != domainScreening.StatusFailed {
+		return
+	}
+	err := u.notifier.Notify(notification.Message{
+		Role:    domainUser.RoleAdmin,
+		Subject: "Caregiver screening failed",
+		Body:    strings.TrimSpace(fmt.Sprintf("A caregiver's %s did not pass. %s", describe(check), check.Detail)),
+		Data: map[string]interface{}{
+			"checkID":         check.ID,
+			"caregiverUserID": check.CaregiverUserID,
+			"kind":            check.Kind,
+		},
+	})
+	if err != nil {
+		u.Logger.Error("Error sending screening failure notification", zap.Error(err), zap.String("checkID", check.ID.String()))
+	}
+}
+
+func (u *ScreeningUseCase) providerError(message string, err error) error {
+	u.Logger.Error(message, zap.Error(err))
+	if errors.Is(err, screening.ErrNotConfigured) {
+		return domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
+	return domainErrors.NewAppError(fmt.Errorf("screening provider error: %w", err), domainErrors.UnknownError)
+}
+
+func (u *ScreeningUseCase) requireCaregiver(id uuid.UUID) (*domainUser.User, error) {
+	user, err := u.userRepository.GetByID(id)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("caregiver not found"), domainErrors.NotFound)
+	}
+	if user.Role != domainUser.RoleCaregiver {
+		return nil, domainErrors.NewAppError(errors.New("screening checks are for caregivers"), domainErrors.ValidationError)
+	}
+	return user, nil
+}
+
+func applyResult(check *domainScreening.Check, result *screening.Result, now time.Time) {
+	if result.ExternalID != "" {
+		check.ExternalID = result.ExternalID
+	}
+	check.Status = result.Status
+	check.Detail = result.Detail
+	check.ExpiresAt = result.ExpiresAt
+	if result.Status != domainScreening.StatusPending {
+		check.CompletedAt = &now
+	}
+}
+
+// latestResults returns the result in force for the background check and
+// for each license, by number and state. Checks come most recently ordered
+// first; pending ones are skipped so that a renewal in progress does not hide
+// the result it is replacing.
+func latestResults(checks []domainScreening.Check) []domainScreening.Check {
+	seen := make(map[string]bool)
+	var res []domainScreening.Check
+	for _, check := range checks {
+		if check.Status == domainScreening.StatusPending {
+			continue
+		}
+		key := check.Kind
+		if check.Kind == domainScreening.KindLicense {
+			key += "|" + strings.ToUpper(check.LicenseState) + "|" + strings.ToUpper(check.LicenseNumber)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		res = append(res, check)
+	}
+	return res
+}
+
+func describe(check *domainScreening.Check) string {
+	if check.Kind == domainScreening.KindLicense {
+		return "license " + check.LicenseNumber
+	}
+	return "background check"
+}
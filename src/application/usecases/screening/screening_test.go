@@ -0,0 +1,239 @@
+package screening
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainScreening "caregiver/src/domain/screening"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+	"caregiver/src/infrastructure/screening"
+
+	"github.com/google/uuid"
+)
+
+// mockScreeningRepository keeps checks in memory, most recently ordered first
+type mockScreeningRepository struct {
+	domainScreening.IScreeningRepository
+	checks []domainScreening.Check
+}
+
+func (m *mockScreeningRepository) CreateCheck(newCheck *domainScreening.Check) (*domainScreening.Check, error) {
+	m.checks = append([]domainScreening.Check{*newCheck}, m.checks...)
+	return newCheck, nil
+}
+
+func (m *mockScreeningRepository) GetCheckByID(id uuid.UUID) (*domainScreening.Check, error) {
+	for _, check := range m.checks {
+		if check.ID == id {
+			return &check, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockScreeningRepository) GetChecks(filter domainScreening.CheckFilter) (*[]domainScreening.Check, error) {
+	res := []domainScreening.Check{}
+	for _, check := range m.checks {
+		if filter.CaregiverUserID != nil && check.CaregiverUserID != *filter.CaregiverUserID {
+			continue
+		}
+		if (filter.Kind != "" && check.Kind != filter.Kind) || (filter.Status != "" && check.Status != filter.Status) {
+			continue
+		}
+		res = append(res, check)
+	}
+	return &res, nil
+}
+
+func (m *mockScreeningRepository) UpdateCheck(id uuid.UUID, updates map[string]interface{}) (*domainScreening.Check, error) {
+	for i := range m.checks {
+		if m.checks[i].ID != id {
+			continue
+		}
+		m.checks[i].Status = updates["status"].(string)
+		m.checks[i].Detail = updates["detail"].(string)
+		m.checks[i].CompletedAt = updates["completed_at"].(*time.Time)
+		m.checks[i].ExpiresAt = updates["expires_at"].(*time.Time)
+		copied := m.checks[i]
+		return &copied, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockUserRepository returns users by ID
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockProvider answers background checks from a map of external IDs and
+// licenses from a fixed result
+type mockProvider struct {
+	backgroundChecks map[string]*screening.Result
+	license          *screening.Result
+	ordered          int
+}
+
+func (m *mockProvider) Name() string { return "mock" }
+
+func (m *mockProvider) OrderBackgroundCheck(ctx context.Context, candidate screening.Candidate) (*screening.Result, error) {
+	m.ordered++
+	id := uuid.NewString()
+	m.backgroundChecks[id] = &screening.Result{ExternalID: id, Status: domainScreening.StatusPending}
+	return m.backgroundChecks[id], nil
+}
+
+func (m *mockProvider) GetBackgroundCheck(ctx context.Context, externalID string) (*screening.Result, error) {
+	if result, ok := m.backgroundChecks[externalID]; ok {
+		return result, nil
+	}
+	return nil, errors.New("unknown check")
+}
+
+func (m *mockProvider) VerifyLicense(ctx context.Context, license screening.License) (*screening.Result, error) {
+	return m.license, nil
+}
+
+// mockNotifier records the messages sent
+type mockNotifier struct {
+	messages []notification.Message
+}
+
+func (m *mockNotifier) Notify(message notification.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func setupTestScreeningUseCase(t *testing.T) (IScreeningUseCase, *mockScreeningRepository, *mockProvider, *mockNotifier, uuid.UUID) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	caregiverID := uuid.New()
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{
+		caregiverID: {ID: caregiverID, Role: domainUser.RoleCaregiver, FirstName: "Ana", LastName: "Lopez"},
+	}}
+	checks := &mockScreeningRepository{}
+	provider := &mockProvider{backgroundChecks: make(map[string]*screening.Result)}
+	notifier := &mockNotifier{}
+	return NewScreeningUseCase(checks, users, provider, notifier, loggerInstance), checks, provider, notifier, caregiverID
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func visit(caregiverID uuid.UUID, from time.Time) *domainSchedule.Schedule {
+	s := &domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiverID, VisitStatus: "upcoming"}
+	s.ScheduledSlot.From = from
+	s.ScheduledSlot.To = from.Add(2 * time.Hour)
+	return s
+}
+
+func TestBackgroundCheck(t *testing.T) {
+	useCase, _, provider, notifier, caregiverID := setupTestScreeningUseCase(t)
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	check, err := useCase.OrderBackgroundCheck(caregiverID, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if check.Status != domainScreening.StatusPending || check.Provider != "mock" || check.ExternalID == "" {
+		t.Errorf("expected a pending check at the provider, got %+v", check)
+	}
+	if completed, _ := useCase.Sweep(now.Add(time.Hour)); completed != 0 {
+		t.Errorf("expected nothing completed yet, got %d", completed)
+	}
+
+	expires := now.AddDate(1, 0, 0)
+	provider.backgroundChecks[check.ExternalID] = &screening.Result{Status: domainScreening.StatusPassed, ExpiresAt: &expires}
+	if completed, _ := useCase.Sweep(now.Add(2 * time.Hour)); completed != 1 {
+		t.Fatalf("expected the check to complete, got %d", completed)
+	}
+	updated, _ := useCase.GetCheckByID(check.ID)
+	if updated.Status != domainScreening.StatusPassed || updated.CompletedAt == nil || !updated.ExpiresAt.Equal(expires) {
+		t.Errorf("expected a passed check expiring in a year, got %+v", updated)
+	}
+	if len(notifier.messages) != 0 {
+		t.Errorf("expected no notification for a passed check, got %+v", notifier.messages)
+	}
+
+	if _, err := useCase.ValidateSchedule(visit(caregiverID, now.AddDate(0, 6, 0))); err != nil {
+		t.Errorf("expected a visit within the check's validity to be allowed, got %v", err)
+	}
+	if _, err := useCase.ValidateSchedule(visit(caregiverID, expires.Add(time.Hour))); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a visit after expiry to be blocked, got %v", err)
+	}
+	if warnings, err := useCase.ValidateSchedule(visit(caregiverID, expires.Add(-time.Hour))); err != nil || len(warnings) != 1 {
+		t.Errorf("expected a warning for a visit spanning the expiry, got %v %v", warnings, err)
+	}
+
+	// A renewal in progress leaves the current result in force.
+	if _, err := useCase.OrderBackgroundCheck(caregiverID, now.AddDate(0, 11, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.ValidateSchedule(visit(caregiverID, now.AddDate(0, 6, 0))); err != nil {
+		t.Errorf("expected the passed check to still count, got %v", err)
+	}
+}
+
+func TestLicenseVerification(t *testing.T) {
+	useCase, _, provider, notifier, caregiverID := setupTestScreeningUseCase(t)
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	if _, err := useCase.ValidateSchedule(visit(caregiverID, now)); err != nil {
+		t.Errorf("expected a caregiver without checks on file to be allowed, got %v", err)
+	}
+
+	expired := now.AddDate(0, 0, -1)
+	provider.license = &screening.Result{Status: domainScreening.StatusPassed, ExpiresAt: &expired}
+	if _, err := useCase.VerifyLicense(caregiverID, "RN-12345", "TX", "RN", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.ValidateSchedule(visit(caregiverID, now.Add(time.Hour))); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an expired license to block assignment, got %v", err)
+	}
+
+	renewed := now.AddDate(2, 0, 0)
+	if _, err := useCase.RecordCheck(&domainScreening.Check{
+		CaregiverUserID: caregiverID,
+		Kind:            domainScreening.KindLicense,
+		Status:          domainScreening.StatusPassed,
+		LicenseNumber:   "RN-12345",
+		LicenseState:    "TX",
+		ExpiresAt:       &renewed,
+	}, now.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.ValidateSchedule(visit(caregiverID, now.Add(time.Hour))); err != nil {
+		t.Errorf("expected the renewed license to allow assignment, got %v", err)
+	}
+
+	provider.license = &screening.Result{Status: domainScreening.StatusFailed, Detail: "No matching license"}
+	if _, err := useCase.VerifyLicense(caregiverID, "LVN-999", "CA", "LVN", now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.messages) != 1 || notifier.messages[0].Role != domainUser.RoleAdmin {
+		t.Errorf("expected coordinators to be told of the failed license, got %+v", notifier.messages)
+	}
+	if _, err := useCase.ValidateSchedule(visit(caregiverID, now.Add(time.Hour))); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a failed license to block assignment, got %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/search"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+	indexTimeout = 5 * time.Second
+)
+
+var validTypes = map[string]bool{
+	search.TypeClient:    true,
+	search.TypeCaregiver: true,
+	search.TypeSchedule:  true,
+	search.TypeVisitNote: true,
+}
+
+type ISearchUseCase interface {
+	// Search ranks clients, caregivers, schedules and visit notes against
+	// text, optionally limited to some document types.
+	Search(text string, types []string, limit int) (*search.Result, error)
+	// Reindex indexes every user and schedule again, for a new cluster or
+	// after the index fell behind. It returns how many documents were
+	// indexed.
+	Reindex() (int, error)
+	OnScheduleEvent(event domainSchedule.Event)
+	OnUserEvent(event domainUser.Event)
+}
+
+// SearchUseCase keeps the search index in step with users and schedules by
+// subscribing to their events. Indexing failures are logged and never fail
+// the change that triggered them; Reindex catches the index up.
+type SearchUseCase struct {
+	index              search.IIndex
+	userRepository     domainUser.IUserRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	Logger             *logger.Logger
+}
+
+func NewSearchUseCase(index search.IIndex, userRepository domainUser.IUserRepository, scheduleRepository domainSchedule.IScheduleRepository, loggerInstance *logger.Logger) ISearchUseCase {
+	return &SearchUseCase{
+		index:              index,
+		userRepository:     userRepository,
+		scheduleRepository: scheduleRepository,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *SearchUseCase) Search(text string, types []string, limit int) (*search.Result, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, domainErrors.NewAppError(errors.New("search text is required"), domainErrors.ValidationError)
+	}
+	for _, docType := range types {
+		if !validTypes[docType] {
+			return nil, domainErrors.NewAppError(fmt.Errorf("unknown type %q: must be 'client', 'caregiver', 'schedule' or 'visit_note'", docType), domainErrors.ValidationError)
+		}
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+	result, err := u.index.Search(ctx, search.Query{Text: text, Types: types, Limit: limit})
+	if err != nil {
+		if errors.Is(err, search.ErrNotConfigured) {
+			return nil, domainErrors.NewAppError(err, domainErrors.ValidationError)
+		}
+		u.Logger.Error("Error searching index", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return result, nil
+}
+
+func (u *SearchUseCase) Reindex() (int, error) {
+	u.Logger.Info("Reindexing search documents")
+	users, err := u.userRepository.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	schedules, err := u.scheduleRepository.GetSchedules()
+	if err != nil {
+		return 0, err
+	}
+
+	names := make(map[uuid.UUID]string, len(*users))
+	docs := []search.Document{}
+	for i := range *users {
+		user := &(*users)[i]
+		names[user.ID] = fullName(user)
+		if doc, ok := userDocument(user); ok {
+			docs = append(docs, doc)
+		}
+	}
+	for i := range *schedules {
+		schedule := &(*schedules)[i]
+		docs = append(docs, scheduleDocument(schedule, names[schedule.ClientUserID], names[schedule.AssignedUserID]))
+		if doc, ok := noteDocument(schedule, names[schedule.ClientUserID]); ok {
+			docs = append(docs, doc)
+		}
+	}
+
+	indexed := 0
+	for _, doc := range docs {
+		ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+		err := u.index.Index(ctx, doc)
+		cancel()
+		if errors.Is(err, search.ErrNotConfigured) {
+			return 0, domainErrors.NewAppError(err, domainErrors.ValidationError)
+		}
+		if err != nil {
+			u.Logger.Error("Error reindexing document", zap.Error(err), zap.String("type", doc.Type), zap.String("id", doc.ID))
+			continue
+		}
+		indexed++
+	}
+	u.Logger.Info("Search reindex finished", zap.Int("indexed", indexed), zap.Int("documents", len(docs)))
+	return indexed, nil
+}
+
+// OnScheduleEvent indexes the schedule and its visit note, or removes both
+// once the schedule is deleted.
+func (u *SearchUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	schedule := event.Schedule
+	if event.Type == domainSchedule.EventDeleted {
+		u.remove(search.TypeSchedule, schedule.ID)
+		u.remove(search.TypeVisitNote, schedule.ID)
+		return
+	}
+	clientName := u.userName(schedule.ClientUserID)
+	u.put(scheduleDocument(schedule, clientName, u.userName(schedule.AssignedUserID)))
+	if doc, ok := noteDocument(schedule, clientName); ok {
+		u.put(doc)
+	} else if event.Previous != nil && event.Previous.ServiceNote != nil {
+		u.remove(search.TypeVisitNote, schedule.ID)
+	}
+}
+
+// OnUserEvent indexes clients and caregivers. Other users are kept out of
+// the index, including users whose role changed.
+func (u *SearchUseCase) OnUserEvent(event domainUser.Event) {
+	user := event.User
+	if event.Type == domainUser.EventDeleted {
+		u.remove(search.TypeClient, user.ID)
+		u.remove(search.TypeCaregiver, user.ID)
+		return
+	}
+	doc, ok := userDocument(user)
+	if ok {
+		u.put(doc)
+	}
+	if event.Type != domainUser.EventUpdated {
+		return
+	}
+	// A changed role moves the user out of the type it was indexed as.
+	for _, docType := range []string{search.TypeClient, search.TypeCaregiver} {
+		if !ok || doc.Type != docType {
+			u.remove(docType, user.ID)
+		}
+	}
+}
+
+func (u *SearchUseCase) put(doc search.Document) {
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+	if err := u.index.Index(ctx, doc); err != nil && !errors.Is(err, search.ErrNotConfigured) {
+		u.Logger.Error("Error indexing document", zap.Error(err), zap.String("type", doc.Type), zap.String("id", doc.ID))
+	}
+}
+
+func (u *SearchUseCase) remove(docType string, id uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+	if err := u.index.Delete(ctx, docType, id.String()); err != nil && !errors.Is(err, search.ErrNotConfigured) {
+		u.Logger.Error("Error removing document from index", zap.Error(err), zap.String("type", docType), zap.String("id", id.String()))
+	}
+}
+
+func (u *SearchUseCase) userName(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	user, err := u.userRepository.GetByID(id)
+	if err != nil {
+		return ""
+	}
+	return fullName(user)
+}
+
+func fullName(user *domainUser.User) string {
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
+
+func userDocument(user *domainUser.User) (search.Document, bool) {
+	var docType string
+	switch user.Role {
+	case domainUser.RoleClient:
+		docType = search.TypeClient
+	case domainUser.RoleCaregiver:
+		docType = search.TypeCaregiver
+	default:
+		return search.Document{}, false
+	}
+	location := user.Location
+	body := strings.Join(nonEmpty(user.UserName, user.Email, location.Street, location.City, location.State, location.Pincode), " ")
+	return search.Document{Type: docType, ID: user.ID.String(), Title: fullName(user), Body: body}, true
+}
+
+func scheduleDocument(schedule *domainSchedule.Schedule, clientName, caregiverName string) search.Document {
+	parts := nonEmpty(clientName, caregiverName, schedule.VisitStatus)
+	for _, task := range schedule.Tasks {
+		parts = append(parts, task.Title)
+	}
+	from := schedule.ScheduledSlot.From
+	return search.Document{
+		Type:  search.TypeSchedule,
+		ID:    schedule.ID.String(),
+		Title: schedule.ServiceName,
+		Body:  strings.Join(parts, " "),
+		Date:  &from,
+	}
+}
+
+func noteDocument(schedule *domainSchedule.Schedule, clientName string) (search.Document, bool) {
+	if schedule.ServiceNote == nil || strings.TrimSpace(*schedule.ServiceNote) == "" {
+		return search.Document{}, false
+	}
+	from := schedule.ScheduledSlot.From
+	title := strings.Join(nonEmpty(schedule.ServiceName, clientName), " - ")
+	return search.Document{
+		Type:  search.TypeVisitNote,
+		ID:    schedule.ID.String(),
+		Title: title,
+		Body:  *schedule.ServiceNote,
+		Date:  &from,
+	}, true
+}
+
+func nonEmpty(values ...string) []string {
+	res := []string{}
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			res = append(res, value)
+		}
+	}
+	return res
+}
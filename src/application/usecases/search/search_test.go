@@ -0,0 +1,175 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/search"
+
+	"github.com/google/uuid"
+)
+
+// mockIndex keeps documents by type and ID and records queries
+type mockIndex struct {
+	docs    map[string]search.Document
+	queries []search.Query
+}
+
+func (m *mockIndex) Index(ctx context.Context, doc search.Document) error {
+	m.docs[doc.Type+":"+doc.ID] = doc
+	return nil
+}
+
+func (m *mockIndex) Delete(ctx context.Context, docType, id string) error {
+	delete(m.docs, docType+":"+id)
+	return nil
+}
+
+func (m *mockIndex) Search(ctx context.Context, query search.Query) (*search.Result, error) {
+	m.queries = append(m.queries, query)
+	return &search.Result{Hits: []search.Hit{}}, nil
+}
+
+// mockUserRepository serves a fixed set of users
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users []domainUser.User
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) {
+	return &m.users, nil
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	for i := range m.users {
+		if m.users[i].ID == id {
+			return &m.users[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockScheduleRepository serves a fixed set of schedules
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetSchedules() (*[]domainSchedule.Schedule, error) {
+	return &m.schedules, nil
+}
+
+func setupTestSearchUseCase(t *testing.T, index search.IIndex) (ISearchUseCase, *mockUserRepository, *mockScheduleRepository) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	users := &mockUserRepository{}
+	schedules := &mockScheduleRepository{}
+	return NewSearchUseCase(index, users, schedules, loggerInstance), users, schedules
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestIndexingFollowsEvents(t *testing.T) {
+	index := &mockIndex{docs: map[string]search.Document{}}
+	useCase, users, _ := setupTestSearchUseCase(t, index)
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, FirstName: "Ana", LastName: "Ruiz", Location: domainUser.Location{City: "Austin"}}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, FirstName: "Vera", LastName: "Cole"}
+	admin := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin, FirstName: "Ada"}
+	users.users = []domainUser.User{client, caregiver, admin}
+
+	for _, user := range users.users {
+		useCase.OnUserEvent(domainUser.Event{Type: domainUser.EventCreated, User: &user})
+	}
+	if len(index.docs) != 2 || index.docs["client:"+client.ID.String()].Title != "Ana Ruiz" || index.docs["client:"+client.ID.String()].Body != "Austin" {
+		t.Fatalf("expected the client and caregiver indexed, got %+v", index.docs)
+	}
+
+	promoted := caregiver
+	promoted.Role = domainUser.RoleAdmin
+	useCase.OnUserEvent(domainUser.Event{Type: domainUser.EventUpdated, User: &promoted})
+	if _, ok := index.docs["caregiver:"+caregiver.ID.String()]; ok {
+		t.Error("expected a caregiver who became an admin to leave the index")
+	}
+	useCase.OnUserEvent(domainUser.Event{Type: domainUser.EventDeleted, User: &domainUser.User{ID: client.ID}})
+	if len(index.docs) != 0 {
+		t.Errorf("expected the deleted client to leave the index, got %+v", index.docs)
+	}
+
+	note := "Client was in good spirits"
+	visit := &domainSchedule.Schedule{
+		ID:             uuid.New(),
+		ClientUserID:   client.ID,
+		AssignedUserID: caregiver.ID,
+		ServiceName:    "Personal care",
+		VisitStatus:    "completed",
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)},
+		Tasks:          []domainSchedule.Task{{Title: "Bathing"}},
+		ServiceNote:    &note,
+	}
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCompleted, Schedule: visit})
+	schedule := index.docs["schedule:"+visit.ID.String()]
+	if schedule.Title != "Personal care" || schedule.Body != "Ana Ruiz Vera Cole completed Bathing" || !schedule.Date.Equal(visit.ScheduledSlot.From) {
+		t.Errorf("unexpected schedule document %+v", schedule)
+	}
+	if doc := index.docs["visit_note:"+visit.ID.String()]; doc.Body != note || doc.Title != "Personal care - Ana Ruiz" {
+		t.Errorf("unexpected visit note document %+v", doc)
+	}
+
+	cleared := *visit
+	cleared.ServiceNote = nil
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventUpdated, Schedule: &cleared, Previous: visit})
+	if _, ok := index.docs["visit_note:"+visit.ID.String()]; ok {
+		t.Error("expected a cleared note to leave the index")
+	}
+}
+
+func TestSearchAndReindex(t *testing.T) {
+	index := &mockIndex{docs: map[string]search.Document{}}
+	useCase, users, schedules := setupTestSearchUseCase(t, index)
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, FirstName: "Ana"}
+	users.users = []domainUser.User{client}
+	note := "Refused lunch"
+	schedules.schedules = []domainSchedule.Schedule{{ID: uuid.New(), ClientUserID: client.ID, ServiceName: "Meals", ServiceNote: &note}}
+
+	indexed, err := useCase.Reindex()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if indexed != 3 || index.docs["visit_note:"+schedules.schedules[0].ID.String()].Title != "Meals - Ana" {
+		t.Errorf("expected the client, visit and note indexed, got %d %+v", indexed, index.docs)
+	}
+
+	if _, err := useCase.Search("  ", nil, 0); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected empty text to be rejected, got %v", err)
+	}
+	if _, err := useCase.Search("ana", []string{"invoice"}, 0); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an unknown type to be rejected, got %v", err)
+	}
+	if _, err := useCase.Search(" lunch ", []string{search.TypeVisitNote}, 500); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query := index.queries[0]; query.Text != "lunch" || query.Limit != maxLimit || len(query.Types) != 1 {
+		t.Errorf("unexpected query %+v", query)
+	}
+
+	t.Setenv("SEARCH_URL", "")
+	disabled, _, _ := setupTestSearchUseCase(t, search.NewIndexFromEnv())
+	if _, err := disabled.Search("ana", nil, 0); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected search without a cluster to be rejected, got %v", err)
+	}
+	disabled.OnUserEvent(domainUser.Event{Type: domainUser.EventCreated, User: &client})
+}
@@ -0,0 +1,176 @@
+package servicearea
+
+import (
+	"errors"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainServiceArea "caregiver/src/domain/servicearea"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const outsideAreaMessage = "client address is outside the caregiver's service area"
+
+type IServiceAreaUseCase interface {
+	Set(area *domainServiceArea.Area) (*domainServiceArea.Area, error)
+	GetByUserID(userID uuid.UUID) (*domainServiceArea.Area, error)
+	GetAll() (*[]domainServiceArea.Area, error)
+	Delete(userID uuid.UUID) error
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+	ScreenCaregivers(client *domainUser.User, caregiverIDs []uuid.UUID) (map[uuid.UUID]domainSchedule.Screening, error)
+}
+
+type ServiceAreaUseCase struct {
+	serviceAreaRepository domainServiceArea.IServiceAreaRepository
+	userRepository        domainUser.IUserRepository
+	Logger                *logger.Logger
+}
+
+func NewServiceAreaUseCase(serviceAreaRepository domainServiceArea.IServiceAreaRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) IServiceAreaUseCase {
+	return &ServiceAreaUseCase{
+		serviceAreaRepository: serviceAreaRepository,
+		userRepository:        userRepository,
+		Logger:                loggerInstance,
+	}
+}
+
+// Set defines or replaces a caregiver's service area.
+func (u *ServiceAreaUseCase) Set(area *domainServiceArea.Area) (*domainServiceArea.Area, error) {
+	u.Logger.Info("Setting service area", zap.String("userID", area.UserID.String()))
+
+	caregiver, err := u.userRepository.GetByID(area.UserID)
+	if err != nil {
+		u.Logger.Error("Caregiver not found for service area", zap.Error(err), zap.String("userID", area.UserID.String()))
+		return nil, domainErrors.NewAppError(errors.New("caregiver not found"), domainErrors.NotFound)
+	}
+	if caregiver.Role != domainUser.RoleCaregiver {
+		return nil, domainErrors.NewAppError(errors.New("service areas can only be set for caregivers"), domainErrors.ValidationError)
+	}
+	if area.Mode == "" {
+		area.Mode = domainServiceArea.ModeWarn
+	}
+	if err := validateArea(area, caregiver); err != nil {
+		return nil, err
+	}
+	return u.serviceAreaRepository.Save(area)
+}
+
+func (u *ServiceAreaUseCase) GetByUserID(userID uuid.UUID) (*domainServiceArea.Area, error) {
+	u.Logger.Info("Getting service area", zap.String("userID", userID.String()))
+	return u.serviceAreaRepository.GetByUserID(userID)
+}
+
+func (u *ServiceAreaUseCase) GetAll() (*[]domainServiceArea.Area, error) {
+	u.Logger.Info("Getting all service areas")
+	return u.serviceAreaRepository.GetAll()
+}
+
+func (u *ServiceAreaUseCase) Delete(userID uuid.UUID) error {
+	u.Logger.Info("Deleting service area", zap.String("userID", userID.String()))
+	return u.serviceAreaRepository.DeleteByUserID(userID)
+}
+
+// ValidateSchedule checks the client's address against the assigned
+// caregiver's service area. Enforced areas reject the assignment; others
+// yield a warning. Clients without a geocoded address are not checked.
+func (u *ServiceAreaUseCase) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.VisitStatus == "cancelled" {
+		return nil, nil
+	}
+	area, err := u.serviceAreaRepository.GetByUserID(schedule.AssignedUserID)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	client, err := u.userRepository.GetByID(schedule.ClientUserID)
+	if err != nil || !client.Location.HasCoordinates() {
+		return nil, nil
+	}
+	caregiver, err := u.userRepository.GetByID(schedule.AssignedUserID)
+	if err != nil {
+		return nil, err
+	}
+	if area.Contains(caregiver.Location, client.Location) {
+		return nil, nil
+	}
+
+	u.Logger.Warn("Schedule assigned outside caregiver service area",
+		zap.String("assignedUserID", schedule.AssignedUserID.String()),
+		zap.String("clientUserID", schedule.ClientUserID.String()),
+		zap.String("mode", area.Mode))
+	if area.Mode == domainServiceArea.ModeEnforce {
+		return nil, domainErrors.NewAppError(errors.New(outsideAreaMessage), domainErrors.ValidationError)
+	}
+	return []string{outsideAreaMessage}, nil
+}
+
+// ScreenCaregivers excludes caregivers whose enforced area does not cover the
+// client and flags those whose advisory area does not.
+func (u *ServiceAreaUseCase) ScreenCaregivers(client *domainUser.User, caregiverIDs []uuid.UUID) (map[uuid.UUID]domainSchedule.Screening, error) {
+	verdicts := make(map[uuid.UUID]domainSchedule.Screening)
+	if !client.Location.HasCoordinates() {
+		return verdicts, nil
+	}
+	areas, err := u.serviceAreaRepository.GetByUserIDs(caregiverIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(*areas) == 0 {
+		return verdicts, nil
+	}
+	users, err := u.userRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	homes := make(map[uuid.UUID]domainUser.Location, len(*users))
+	for _, user := range *users {
+		homes[user.ID] = user.Location
+	}
+
+	for i := range *areas {
+		area := &(*areas)[i]
+		if area.Contains(homes[area.UserID], client.Location) {
+			continue
+		}
+		if area.Mode == domainServiceArea.ModeEnforce {
+			verdicts[area.UserID] = domainSchedule.Screening{Excluded: true}
+		} else {
+			verdicts[area.UserID] = domainSchedule.Screening{Warning: outsideAreaMessage}
+		}
+	}
+	return verdicts, nil
+}
+
+func validateArea(area *domainServiceArea.Area, caregiver *domainUser.User) error {
+	if area.Mode != domainServiceArea.ModeWarn && area.Mode != domainServiceArea.ModeEnforce {
+		return domainErrors.NewAppError(errors.New("mode must be 'warn' or 'enforce'"), domainErrors.ValidationError)
+	}
+	switch area.Type {
+	case domainServiceArea.TypeRadius:
+		if area.RadiusKm <= 0 {
+			return domainErrors.NewAppError(errors.New("radius must be greater than zero"), domainErrors.ValidationError)
+		}
+		if (area.CenterLat == nil) != (area.CenterLong == nil) {
+			return domainErrors.NewAppError(errors.New("center requires both latitude and longitude"), domainErrors.ValidationError)
+		}
+		if !area.Center(caregiver.Location).HasCoordinates() {
+			return domainErrors.NewAppError(errors.New("a center is required when the caregiver's address has no coordinates"), domainErrors.ValidationError)
+		}
+		area.Polygon = nil
+	case domainServiceArea.TypePolygon:
+		if len(area.Polygon) < 3 {
+			return domainErrors.NewAppError(errors.New("polygon needs at least three points"), domainErrors.ValidationError)
+		}
+		area.CenterLat, area.CenterLong, area.RadiusKm = nil, nil, 0
+	default:
+		return domainErrors.NewAppError(errors.New("type must be 'radius' or 'polygon'"), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package servicearea
+
+import (
+	"errors"
+	"testing"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainServiceArea "caregiver/src/domain/servicearea"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type mockServiceAreaRepository struct {
+	areas map[uuid.UUID]domainServiceArea.Area
+}
+
+func (m *mockServiceAreaRepository) Save(area *domainServiceArea.Area) (*domainServiceArea.Area, error) {
+	m.areas[area.UserID] = *area
+	return area, nil
+}
+
+func (m *mockServiceAreaRepository) GetByUserID(userID uuid.UUID) (*domainServiceArea.Area, error) {
+	area, ok := m.areas[userID]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &area, nil
+}
+
+func (m *mockServiceAreaRepository) GetByUserIDs(userIDs []uuid.UUID) (*[]domainServiceArea.Area, error) {
+	res := []domainServiceArea.Area{}
+	for _, id := range userIDs {
+		if area, ok := m.areas[id]; ok {
+			res = append(res, area)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockServiceAreaRepository) GetAll() (*[]domainServiceArea.Area, error) {
+	return m.GetByUserIDs(nil)
+}
+
+func (m *mockServiceAreaRepository) DeleteByUserID(userID uuid.UUID) error {
+	delete(m.areas, userID)
+	return nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users []domainUser.User
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) {
+	return &m.users, nil
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	for i := range m.users {
+		if m.users[i].ID == id {
+			return &m.users[i], nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+// Berlin Mitte and Potsdam are roughly 27km apart.
+var (
+	mitte   = domainUser.Location{Lat: 52.5200, Long: 13.4050}
+	potsdam = domainUser.Location{Lat: 52.3906, Long: 13.0645}
+)
+
+func setupTestUseCase(t *testing.T, users []domainUser.User, areas ...domainServiceArea.Area) *ServiceAreaUseCase {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	repo := &mockServiceAreaRepository{areas: make(map[uuid.UUID]domainServiceArea.Area)}
+	for _, area := range areas {
+		repo.areas[area.UserID] = area
+	}
+	return NewServiceAreaUseCase(repo, &mockUserRepository{users: users}, loggerInstance).(*ServiceAreaUseCase)
+}
+
+func TestValidateSchedule(t *testing.T) {
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Location: mitte}
+	nearClient := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: domainUser.Location{Lat: 52.5300, Long: 13.4200}}
+	farClient := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: potsdam}
+	users := []domainUser.User{caregiver, nearClient, farClient}
+	schedule := func(client domainUser.User) *domainSchedule.Schedule {
+		return &domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiver.ID, ClientUserID: client.ID, VisitStatus: "upcoming"}
+	}
+
+	t.Run("Inside radius", func(t *testing.T) {
+		uc := setupTestUseCase(t, users, domainServiceArea.Area{UserID: caregiver.ID, Type: domainServiceArea.TypeRadius, RadiusKm: 10, Mode: domainServiceArea.ModeEnforce})
+		warnings, err := uc.ValidateSchedule(schedule(nearClient))
+		if err != nil || len(warnings) != 0 {
+			t.Errorf("expected no findings, got %v, %v", warnings, err)
+		}
+	})
+
+	t.Run("Outside advisory radius", func(t *testing.T) {
+		uc := setupTestUseCase(t, users, domainServiceArea.Area{UserID: caregiver.ID, Type: domainServiceArea.TypeRadius, RadiusKm: 10, Mode: domainServiceArea.ModeWarn})
+		warnings, err := uc.ValidateSchedule(schedule(farClient))
+		if err != nil || len(warnings) != 1 {
+			t.Errorf("expected a warning, got %v, %v", warnings, err)
+		}
+	})
+
+	t.Run("Outside enforced radius", func(t *testing.T) {
+		uc := setupTestUseCase(t, users, domainServiceArea.Area{UserID: caregiver.ID, Type: domainServiceArea.TypeRadius, RadiusKm: 10, Mode: domainServiceArea.ModeEnforce})
+		_, err := uc.ValidateSchedule(schedule(farClient))
+		var appErr *domainErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Type != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("Polygon", func(t *testing.T) {
+		box := []domainServiceArea.Point{{Lat: 52.45, Long: 13.30}, {Lat: 52.45, Long: 13.55}, {Lat: 52.60, Long: 13.55}, {Lat: 52.60, Long: 13.30}}
+		uc := setupTestUseCase(t, users, domainServiceArea.Area{UserID: caregiver.ID, Type: domainServiceArea.TypePolygon, Polygon: box, Mode: domainServiceArea.ModeEnforce})
+		if _, err := uc.ValidateSchedule(schedule(nearClient)); err != nil {
+			t.Errorf("expected client inside polygon, got %v", err)
+		}
+		if _, err := uc.ValidateSchedule(schedule(farClient)); err == nil {
+			t.Error("expected client outside polygon to be rejected")
+		}
+	})
+
+	t.Run("No area", func(t *testing.T) {
+		uc := setupTestUseCase(t, users)
+		warnings, err := uc.ValidateSchedule(schedule(farClient))
+		if err != nil || len(warnings) != 0 {
+			t.Errorf("expected no findings, got %v, %v", warnings, err)
+		}
+	})
+}
+
+func TestScreenCaregivers(t *testing.T) {
+	enforced := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Location: mitte}
+	advisory := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Location: mitte}
+	unrestricted := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Location: mitte}
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: potsdam}
+	uc := setupTestUseCase(t, []domainUser.User{enforced, advisory, unrestricted, client},
+		domainServiceArea.Area{UserID: enforced.ID, Type: domainServiceArea.TypeRadius, RadiusKm: 5, Mode: domainServiceArea.ModeEnforce},
+		domainServiceArea.Area{UserID: advisory.ID, Type: domainServiceArea.TypeRadius, RadiusKm: 5, Mode: domainServiceArea.ModeWarn},
+	)
+
+	verdicts, err := uc.ScreenCaregivers(&client, []uuid.UUID{enforced.ID, advisory.ID, unrestricted.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !verdicts[enforced.ID].Excluded {
+		t.Error("expected enforced caregiver to be excluded")
+	}
+	if verdicts[advisory.ID].Excluded || verdicts[advisory.ID].Warning == "" {
+		t.Errorf("expected advisory caregiver to be flagged, got %+v", verdicts[advisory.ID])
+	}
+	if _, ok := verdicts[unrestricted.ID]; ok {
+		t.Error("expected no verdict for caregiver without an area")
+	}
+}
+
+func TestSetValidatesArea(t *testing.T) {
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: mitte}
+	uc := setupTestUseCase(t, []domainUser.User{caregiver, client})
+
+	if _, err := uc.Set(&domainServiceArea.Area{UserID: caregiver.ID, Type: domainServiceArea.TypeRadius, RadiusKm: 10}); err == nil {
+		t.Error("expected radius without any center to be rejected")
+	}
+	if _, err := uc.Set(&domainServiceArea.Area{UserID: client.ID, Type: domainServiceArea.TypeRadius, RadiusKm: 10}); err == nil {
+		t.Error("expected area for a client to be rejected")
+	}
+	lat, long := mitte.Lat, mitte.Long
+	area, err := uc.Set(&domainServiceArea.Area{UserID: caregiver.ID, Type: domainServiceArea.TypeRadius, RadiusKm: 10, CenterLat: &lat, CenterLong: &long})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if area.Mode != domainServiceArea.ModeWarn {
+		t.Errorf("expected default mode warn, got %s", area.Mode)
+	}
+}
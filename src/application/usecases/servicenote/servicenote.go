@@ -0,0 +1,175 @@
+package servicenote
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainServiceNote "caregiver/src/domain/servicenote"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IServiceNoteUseCase interface {
+	Create(scheduleID uuid.UUID, body string, callerID *uuid.UUID, now time.Time) (*domainServiceNote.Revision, error)
+	Update(scheduleID uuid.UUID, body string, callerID *uuid.UUID, now time.Time) (*domainServiceNote.Revision, error)
+	GetHistory(scheduleID uuid.UUID) (*[]domainServiceNote.Revision, error)
+}
+
+// ScheduleObserver is told when a visit's service note changes.
+type ScheduleObserver interface {
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+type ServiceNoteUseCase struct {
+	noteRepository     domainServiceNote.IServiceNoteRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	observers          []ScheduleObserver
+	Logger             *logger.Logger
+}
+
+type Option func(*ServiceNoteUseCase)
+
+func WithObservers(observers ...ScheduleObserver) Option {
+	return func(u *ServiceNoteUseCase) {
+		u.observers = append(u.observers, observers...)
+	}
+}
+
+func NewServiceNoteUseCase(noteRepository domainServiceNote.IServiceNoteRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger, opts ...Option) IServiceNoteUseCase {
+	useCase := &ServiceNoteUseCase{
+		noteRepository:     noteRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+// Create writes the first service note of a visit. A visit that already has
+// a note is a Conflict; Update revises it instead.
+func (u *ServiceNoteUseCase) Create(scheduleID uuid.UUID, body string, callerID *uuid.UUID, now time.Time) (*domainServiceNote.Revision, error) {
+	u.Logger.Info("Creating service note", zap.String("scheduleID", scheduleID.String()))
+	schedule, body, err := u.prepare(scheduleID, body, callerID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.ServiceNote != nil && strings.TrimSpace(*schedule.ServiceNote) != "" {
+		return nil, domainErrors.NewAppError(errors.New("the visit already has a service note"), domainErrors.Conflict)
+	}
+	return u.append(schedule, body, callerID, now)
+}
+
+// Update saves a new revision of a visit's service note. Saving the note
+// unchanged records nothing and returns the latest revision.
+func (u *ServiceNoteUseCase) Update(scheduleID uuid.UUID, body string, callerID *uuid.UUID, now time.Time) (*domainServiceNote.Revision, error) {
+	u.Logger.Info("Updating service note", zap.String("scheduleID", scheduleID.String()))
+	schedule, body, err := u.prepare(scheduleID, body, callerID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.ServiceNote == nil || strings.TrimSpace(*schedule.ServiceNote) == "" {
+		return nil, domainErrors.NewAppError(errors.New("the visit has no service note yet"), domainErrors.NotFound)
+	}
+	if n := len(schedule.NoteRevisions); n > 0 && *schedule.ServiceNote == body {
+		return &schedule.NoteRevisions[n-1], nil
+	}
+	return u.append(schedule, body, callerID, now)
+}
+
+// GetHistory returns every revision of a visit's service note, oldest first.
+func (u *ServiceNoteUseCase) GetHistory(scheduleID uuid.UUID) (*[]domainServiceNote.Revision, error) {
+	if _, err := u.scheduleRepository.GetScheduleByID(scheduleID); err != nil {
+		return nil, err
+	}
+	return u.noteRepository.GetBySchedule(scheduleID)
+}
+
+// prepare loads the visit and checks that its note can be written now, by
+// the caller, with the given body, which it returns trimmed.
+func (u *ServiceNoteUseCase) prepare(scheduleID uuid.UUID, body string, callerID *uuid.UUID) (*domainSchedule.Schedule, string, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !writable(schedule.VisitStatus) {
+		return nil, "", domainErrors.NewAppError(fmt.Errorf("a service note cannot be written for a visit that is %s", schedule.VisitStatus), domainErrors.ValidationError)
+	}
+	if err := u.authorize(schedule, callerID); err != nil {
+		return nil, "", err
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, "", domainErrors.NewAppError(errors.New("the service note cannot be empty"), domainErrors.ValidationError)
+	}
+	if utf8.RuneCountInString(body) > domainServiceNote.MaxBodyLength {
+		return nil, "", domainErrors.NewAppError(fmt.Errorf("the service note cannot exceed %d characters", domainServiceNote.MaxBodyLength), domainErrors.ValidationError)
+	}
+	return schedule, body, nil
+}
+
+// authorize lets the visit's caregiver or an admin write its note. A nil
+// caller is trusted.
+func (u *ServiceNoteUseCase) authorize(schedule *domainSchedule.Schedule, callerID *uuid.UUID) error {
+	if callerID == nil || *callerID == schedule.AssignedUserID {
+		return nil
+	}
+	caller, err := u.userRepository.GetByID(*callerID)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Type != domainErrors.NotFound {
+			return err
+		}
+	} else if caller.Role == domainUser.RoleAdmin {
+		return nil
+	}
+	u.Logger.Warn("Caller may not write the service note",
+		zap.String("scheduleID", schedule.ID.String()), zap.String("callerID", callerID.String()))
+	return domainErrors.NewAppError(errors.New("only the assigned caregiver or an admin can write this visit's service note"), domainErrors.NotAuthorized)
+}
+
+func (u *ServiceNoteUseCase) append(schedule *domainSchedule.Schedule, body string, callerID *uuid.UUID, now time.Time) (*domainServiceNote.Revision, error) {
+	revision, err := u.noteRepository.Append(&domainServiceNote.Revision{
+		ScheduleID:   schedule.ID,
+		Body:         body,
+		AuthorUserID: callerID,
+		CreatedAt:    now,
+	})
+	if err != nil {
+		u.Logger.Error("Error saving service note", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+		return nil, err
+	}
+	if len(u.observers) > 0 {
+		updated, err := u.scheduleRepository.GetScheduleByID(schedule.ID)
+		if err != nil {
+			u.Logger.Error("Error reloading schedule after service note", zap.Error(err), zap.String("scheduleID", schedule.ID.String()))
+			return revision, nil
+		}
+		event := domainSchedule.Event{Type: domainSchedule.EventUpdated, Schedule: updated, Previous: schedule}
+		for _, observer := range u.observers {
+			observer.OnScheduleEvent(event)
+		}
+	}
+	u.Logger.Info("Service note saved", zap.String("scheduleID", schedule.ID.String()), zap.Int("revision", revision.Revision))
+	return revision, nil
+}
+
+func writable(status string) bool {
+	for _, s := range domainServiceNote.WritableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
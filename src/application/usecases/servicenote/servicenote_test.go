@@ -0,0 +1,151 @@
+package servicenote
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainServiceNote "caregiver/src/domain/servicenote"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockStore keeps one visit and its note revisions, as the database would.
+type mockStore struct {
+	domainSchedule.IScheduleRepository
+	schedule domainSchedule.Schedule
+}
+
+func (m *mockStore) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	if id != m.schedule.ID {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := m.schedule
+	copied.NoteRevisions = append([]domainServiceNote.Revision(nil), m.schedule.NoteRevisions...)
+	return &copied, nil
+}
+
+func (m *mockStore) Append(revision *domainServiceNote.Revision) (*domainServiceNote.Revision, error) {
+	appended := *revision
+	appended.ID = uuid.New()
+	appended.Revision = len(m.schedule.NoteRevisions) + 1
+	m.schedule.NoteRevisions = append(m.schedule.NoteRevisions, appended)
+	body := appended.Body
+	m.schedule.ServiceNote = &body
+	return &appended, nil
+}
+
+func (m *mockStore) GetBySchedule(scheduleID uuid.UUID) (*[]domainServiceNote.Revision, error) {
+	revisions := append([]domainServiceNote.Revision(nil), m.schedule.NoteRevisions...)
+	return &revisions, nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return user, nil
+}
+
+type recordingObserver struct {
+	events []domainSchedule.Event
+}
+
+func (o *recordingObserver) OnScheduleEvent(event domainSchedule.Event) {
+	o.events = append(o.events, event)
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestServiceNoteRevisions(t *testing.T) {
+	now := time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC)
+	caregiverID, adminID, otherID := uuid.New(), uuid.New(), uuid.New()
+	store := &mockStore{schedule: domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiverID, VisitStatus: "in_progress"}}
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{
+		adminID: {ID: adminID, Role: domainUser.RoleAdmin},
+		otherID: {ID: otherID, Role: domainUser.RoleCaregiver},
+	}}
+	observer := &recordingObserver{}
+	loggerInstance, _ := logger.NewLogger()
+	useCase := NewServiceNoteUseCase(store, store, users, loggerInstance, WithObservers(observer))
+	scheduleID := store.schedule.ID
+
+	if _, err := useCase.Update(scheduleID, "Ate lunch", &caregiverID, now); errorType(err) != domainErrors.NotFound {
+		t.Errorf("expected NotFound updating a visit without a note, got %v", err)
+	}
+	first, err := useCase.Create(scheduleID, "  Ate lunch  ", &caregiverID, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Revision != 1 || first.Body != "Ate lunch" || *first.AuthorUserID != caregiverID {
+		t.Errorf("unexpected first revision %+v", first)
+	}
+	if _, err := useCase.Create(scheduleID, "Again", &caregiverID, now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected Conflict creating a second note, got %v", err)
+	}
+	if _, err := useCase.Update(scheduleID, "Ate lunch, refused bath", &otherID, now); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected another caregiver refused, got %v", err)
+	}
+
+	store.schedule.VisitStatus = "completed"
+	second, err := useCase.Update(scheduleID, "Ate lunch, refused bath", &adminID, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.Revision != 2 || *second.AuthorUserID != adminID {
+		t.Errorf("unexpected second revision %+v", second)
+	}
+	unchanged, err := useCase.Update(scheduleID, "Ate lunch, refused bath", &caregiverID, now.Add(2*time.Hour))
+	if err != nil || unchanged.Revision != 2 {
+		t.Errorf("expected an unchanged note to keep revision 2, got %+v, %v", unchanged, err)
+	}
+
+	history, err := useCase.GetHistory(scheduleID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*history) != 2 || (*history)[0].Body != "Ate lunch" {
+		t.Errorf("expected both revisions kept, got %+v", *history)
+	}
+	if len(observer.events) != 2 || *observer.events[1].Schedule.ServiceNote != "Ate lunch, refused bath" {
+		t.Errorf("expected an update event per revision, got %v", observer.events)
+	}
+}
+
+func TestServiceNoteValidation(t *testing.T) {
+	now := time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC)
+	store := &mockStore{schedule: domainSchedule.Schedule{ID: uuid.New(), VisitStatus: "upcoming"}}
+	loggerInstance, _ := logger.NewLogger()
+	useCase := NewServiceNoteUseCase(store, store, &mockUserRepository{}, loggerInstance)
+
+	if _, err := useCase.Create(store.schedule.ID, "Early note", nil, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected ValidationError before the visit starts, got %v", err)
+	}
+	store.schedule.VisitStatus = "in_progress"
+	if _, err := useCase.Create(store.schedule.ID, "   ", nil, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected ValidationError for an empty note, got %v", err)
+	}
+	if _, err := useCase.Create(uuid.New(), "Note", nil, now); errorType(err) != domainErrors.NotFound {
+		t.Errorf("expected NotFound for an unknown visit, got %v", err)
+	}
+	created, err := useCase.Create(store.schedule.ID, "Note", nil, now)
+	if err != nil || created.AuthorUserID != nil {
+		t.Errorf("expected a trusted note without an author, got %+v, %v", created, err)
+	}
+}
@@ -0,0 +1,209 @@
+package signature
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	domainAttachment "caregiver/src/domain/attachment"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainSignature "caregiver/src/domain/signature"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	drawnSignaturePrefix = "data:image/png;base64,"
+	// MaxDrawnSignatureSize caps a drawn signature image at 256 KiB.
+	MaxDrawnSignatureSize = 256 << 10
+	maxTypedSignatureLen  = 200
+)
+
+type ISignatureUseCase interface {
+	Sign(newSignature *domainSignature.Signature) (*domainSignature.Signature, error)
+	GetByID(id uuid.UUID) (*domainSignature.Signature, error)
+	GetByAttachment(scheduleID uuid.UUID, attachmentID uuid.UUID) (*[]domainSignature.Signature, error)
+	Verify(id uuid.UUID) (*domainSignature.Verification, error)
+}
+
+type SignatureUseCase struct {
+	signatureRepository  domainSignature.ISignatureRepository
+	attachmentRepository domainAttachment.IAttachmentRepository
+	scheduleRepository   domainSchedule.IScheduleRepository
+	userRepository       domainUser.IUserRepository
+	storage              storage.IFileStorage
+	Logger               *logger.Logger
+}
+
+func NewSignatureUseCase(signatureRepository domainSignature.ISignatureRepository, attachmentRepository domainAttachment.IAttachmentRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, fileStorage storage.IFileStorage, loggerInstance *logger.Logger) ISignatureUseCase {
+	return &SignatureUseCase{
+		signatureRepository:  signatureRepository,
+		attachmentRepository: attachmentRepository,
+		scheduleRepository:   scheduleRepository,
+		userRepository:       userRepository,
+		storage:              fileStorage,
+		Logger:               loggerInstance,
+	}
+}
+
+// Sign records a signature on an attachment. The visit's client, its assigned
+// caregiver and coordinators may sign, each once per document. The document
+// is hashed as stored right now so later changes to the file are detectable.
+func (u *SignatureUseCase) Sign(newSignature *domainSignature.Signature) (*domainSignature.Signature, error) {
+	u.Logger.Info("Signing document", zap.String("attachmentID", newSignature.AttachmentID.String()), zap.String("signerUserID", newSignature.SignerUserID.String()))
+
+	if newSignature.DocumentType != domainSignature.DocumentCareAgreement && newSignature.DocumentType != domainSignature.DocumentConsent {
+		return nil, domainErrors.NewAppError(errors.New("document type must be 'care_agreement' or 'consent'"), domainErrors.ValidationError)
+	}
+	if err := validateSignatureData(newSignature); err != nil {
+		return nil, err
+	}
+
+	attachment, err := u.getForSchedule(newSignature.ScheduleID, newSignature.AttachmentID)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := u.scheduleRepository.GetScheduleByID(attachment.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := u.userRepository.GetByID(newSignature.SignerUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("signer not found"), domainErrors.NotFound)
+	}
+	if signer.ID != schedule.ClientUserID && signer.ID != schedule.AssignedUserID && signer.Role != domainUser.RoleAdmin {
+		return nil, domainErrors.NewAppError(errors.New("only the visit's client, caregiver or a coordinator can sign this document"), domainErrors.NotAuthorized)
+	}
+
+	existing, err := u.signatureRepository.GetByAttachment(attachment.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range *existing {
+		if s.SignerUserID == signer.ID {
+			return nil, domainErrors.NewAppError(errors.New("document already signed by this user"), domainErrors.Conflict)
+		}
+	}
+
+	documentHash, err := u.hashDocument(attachment)
+	if err != nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	newSignature.ID = uuid.New()
+	newSignature.ScheduleID = attachment.ScheduleID
+	newSignature.SignerName = strings.TrimSpace(signer.FirstName + " " + signer.LastName)
+	newSignature.DocumentHash = documentHash
+	newSignature.SignedAt = time.Now().UTC().Truncate(time.Microsecond)
+	newSignature.RecordHash = newSignature.ComputeRecordHash()
+	return u.signatureRepository.Create(newSignature)
+}
+
+func (u *SignatureUseCase) GetByID(id uuid.UUID) (*domainSignature.Signature, error) {
+	u.Logger.Info("Getting signature", zap.String("id", id.String()))
+	return u.signatureRepository.GetByID(id)
+}
+
+func (u *SignatureUseCase) GetByAttachment(scheduleID uuid.UUID, attachmentID uuid.UUID) (*[]domainSignature.Signature, error) {
+	u.Logger.Info("Getting signatures for attachment", zap.String("attachmentID", attachmentID.String()))
+	if _, err := u.getForSchedule(scheduleID, attachmentID); err != nil {
+		return nil, err
+	}
+	return u.signatureRepository.GetByAttachment(attachmentID)
+}
+
+// Verify re-hashes the stored document and the signature record. The
+// signature is valid only when both still match what was captured at signing.
+func (u *SignatureUseCase) Verify(id uuid.UUID) (*domainSignature.Verification, error) {
+	u.Logger.Info("Verifying signature", zap.String("id", id.String()))
+	signature, err := u.signatureRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &domainSignature.Verification{
+		Signature:    signature,
+		RecordIntact: signature.ComputeRecordHash() == signature.RecordHash,
+	}
+	attachment, err := u.attachmentRepository.GetByID(signature.AttachmentID)
+	if err == nil {
+		verification.DocumentFound = true
+		if verification.CurrentDocumentHash, err = u.hashDocument(attachment); err != nil {
+			verification.DocumentFound = false
+		}
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+	verification.DocumentIntact = verification.DocumentFound && verification.CurrentDocumentHash == signature.DocumentHash
+	verification.Valid = verification.RecordIntact && verification.DocumentIntact
+	if !verification.Valid {
+		u.Logger.Warn("Signature verification failed", zap.String("id", id.String()), zap.Bool("recordIntact", verification.RecordIntact), zap.Bool("documentIntact", verification.DocumentIntact))
+	}
+	return verification, nil
+}
+
+func (u *SignatureUseCase) hashDocument(attachment *domainAttachment.Attachment) (string, error) {
+	content, err := u.storage.Open(attachment.StorageKey)
+	if err != nil {
+		u.Logger.Error("Error opening document for hashing", zap.Error(err), zap.String("attachmentID", attachment.ID.String()))
+		return "", err
+	}
+	defer content.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		u.Logger.Error("Error hashing document", zap.Error(err), zap.String("attachmentID", attachment.ID.String()))
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (u *SignatureUseCase) getForSchedule(scheduleID uuid.UUID, attachmentID uuid.UUID) (*domainAttachment.Attachment, error) {
+	attachment, err := u.attachmentRepository.GetByID(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.ScheduleID != scheduleID {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return attachment, nil
+}
+
+// validateSignatureData accepts a typed name or a drawn signature sent as a
+// PNG data URL.
+func validateSignatureData(s *domainSignature.Signature) error {
+	switch s.Method {
+	case domainSignature.MethodTyped:
+		s.SignatureData = strings.TrimSpace(s.SignatureData)
+		if s.SignatureData == "" || len(s.SignatureData) > maxTypedSignatureLen {
+			return domainErrors.NewAppError(errors.New("typed signature must be between 1 and 200 characters"), domainErrors.ValidationError)
+		}
+	case domainSignature.MethodDrawn:
+		if !strings.HasPrefix(s.SignatureData, drawnSignaturePrefix) {
+			return domainErrors.NewAppError(errors.New("drawn signature must be a PNG data URL"), domainErrors.ValidationError)
+		}
+		image, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s.SignatureData, drawnSignaturePrefix))
+		if err != nil || len(image) == 0 {
+			return domainErrors.NewAppError(errors.New("drawn signature is not valid base64"), domainErrors.ValidationError)
+		}
+		if len(image) > MaxDrawnSignatureSize {
+			return domainErrors.NewAppError(errors.New("drawn signature must be at most 256 KiB"), domainErrors.ValidationError)
+		}
+	default:
+		return domainErrors.NewAppError(errors.New("method must be 'drawn' or 'typed'"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
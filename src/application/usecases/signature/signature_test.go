@@ -0,0 +1,220 @@
+package signature
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"testing"
+
+	domainAttachment "caregiver/src/domain/attachment"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainSignature "caregiver/src/domain/signature"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type mockSignatureRepository struct {
+	signatures []domainSignature.Signature
+}
+
+func (m *mockSignatureRepository) Create(newSignature *domainSignature.Signature) (*domainSignature.Signature, error) {
+	m.signatures = append(m.signatures, *newSignature)
+	return newSignature, nil
+}
+
+func (m *mockSignatureRepository) GetByID(id uuid.UUID) (*domainSignature.Signature, error) {
+	for i := range m.signatures {
+		if m.signatures[i].ID == id {
+			copied := m.signatures[i]
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockSignatureRepository) GetByAttachment(attachmentID uuid.UUID) (*[]domainSignature.Signature, error) {
+	res := []domainSignature.Signature{}
+	for _, s := range m.signatures {
+		if s.AttachmentID == attachmentID {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+type mockAttachmentRepository struct {
+	domainAttachment.IAttachmentRepository
+	attachments map[uuid.UUID]domainAttachment.Attachment
+}
+
+func (m *mockAttachmentRepository) GetByID(id uuid.UUID) (*domainAttachment.Attachment, error) {
+	attachment, ok := m.attachments[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &attachment, nil
+}
+
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedule domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	if id != m.schedule.ID {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return &m.schedule, nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &user, nil
+}
+
+// mockStorage keeps file contents in memory
+type mockStorage struct {
+	files map[string][]byte
+}
+
+func (m *mockStorage) Save(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	m.files[key] = data
+	return nil
+}
+
+func (m *mockStorage) Open(key string) (io.ReadCloser, error) {
+	data, ok := m.files[key]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *mockStorage) Delete(key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestSignAndVerify(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, FirstName: "Ada", LastName: "Lovelace"}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	otherCaregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	schedule := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: client.ID, AssignedUserID: caregiver.ID}
+	agreement := domainAttachment.Attachment{ID: uuid.New(), ScheduleID: schedule.ID, StorageKey: "attachments/agreement.pdf"}
+
+	signatures := &mockSignatureRepository{}
+	attachments := &mockAttachmentRepository{attachments: map[uuid.UUID]domainAttachment.Attachment{agreement.ID: agreement}}
+	files := &mockStorage{files: map[string][]byte{agreement.StorageKey: []byte("%PDF care agreement v1")}}
+	users := &mockUserRepository{users: map[uuid.UUID]domainUser.User{client.ID: client, caregiver.ID: caregiver, otherCaregiver.ID: otherCaregiver}}
+	uc := NewSignatureUseCase(signatures, attachments, &mockScheduleRepository{schedule: schedule}, users, files, loggerInstance)
+
+	sign := func(signer uuid.UUID, method, data string) (*domainSignature.Signature, error) {
+		return uc.Sign(&domainSignature.Signature{
+			AttachmentID:  agreement.ID,
+			ScheduleID:    schedule.ID,
+			DocumentType:  domainSignature.DocumentCareAgreement,
+			SignerUserID:  signer,
+			Method:        method,
+			SignatureData: data,
+			IPAddress:     "203.0.113.7",
+		})
+	}
+
+	t.Run("Invalid signature data", func(t *testing.T) {
+		if _, err := sign(client.ID, domainSignature.MethodTyped, "   "); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error for blank typed signature, got %v", err)
+		}
+		if _, err := sign(client.ID, domainSignature.MethodDrawn, "data:image/jpeg;base64,AAAA"); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error for non-PNG drawing, got %v", err)
+		}
+	})
+	t.Run("Unrelated signer", func(t *testing.T) {
+		if _, err := sign(otherCaregiver.ID, domainSignature.MethodTyped, "Someone"); errorType(err) != domainErrors.NotAuthorized {
+			t.Errorf("expected not authorized, got %v", err)
+		}
+	})
+
+	signed, err := sign(client.ID, domainSignature.MethodTyped, "Ada Lovelace")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signed.SignerName != "Ada Lovelace" || signed.DocumentHash == "" || signed.RecordHash == "" {
+		t.Errorf("expected signer name and hashes to be captured, got %+v", signed)
+	}
+	if _, err := sign(client.ID, domainSignature.MethodTyped, "Ada Lovelace"); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected conflict on second signature, got %v", err)
+	}
+	drawn := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("\x89PNG strokes"))
+	if _, err := sign(caregiver.ID, domainSignature.MethodDrawn, drawn); err != nil {
+		t.Errorf("unexpected error for drawn signature: %v", err)
+	}
+
+	verification, err := uc.Verify(signed.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !verification.Valid {
+		t.Errorf("expected untouched signature to verify, got %+v", verification)
+	}
+
+	t.Run("Tampered record", func(t *testing.T) {
+		original := signatures.signatures[0]
+		signatures.signatures[0].IPAddress = "198.51.100.1"
+		defer func() { signatures.signatures[0] = original }()
+		verification, err := uc.Verify(signed.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if verification.RecordIntact || verification.Valid || !verification.DocumentIntact {
+			t.Errorf("expected record tampering to be detected, got %+v", verification)
+		}
+	})
+	t.Run("Changed document", func(t *testing.T) {
+		files.files[agreement.StorageKey] = []byte("%PDF care agreement v2")
+		verification, err := uc.Verify(signed.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if verification.DocumentIntact || verification.Valid || !verification.RecordIntact {
+			t.Errorf("expected document change to be detected, got %+v", verification)
+		}
+	})
+	t.Run("Deleted document", func(t *testing.T) {
+		delete(attachments.attachments, agreement.ID)
+		verification, err := uc.Verify(signed.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if verification.DocumentFound || verification.Valid {
+			t.Errorf("expected missing document to fail verification, got %+v", verification)
+		}
+	})
+}
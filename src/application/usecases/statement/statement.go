@@ -0,0 +1,354 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	domainBranding "caregiver/src/domain/branding"
+	domainClaim "caregiver/src/domain/claim"
+	domainErrors "caregiver/src/domain/errors"
+	domainStatement "caregiver/src/domain/statement"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/pdf"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+// logoHeight is how tall the agency logo is drawn, in points.
+const logoHeight = 48
+
+var paymentMethods = map[string]bool{
+	domainStatement.MethodCheck: true,
+	domainStatement.MethodCard:  true,
+	domainStatement.MethodACH:   true,
+	domainStatement.MethodCash:  true,
+}
+
+type IStatementUseCase interface {
+	RecordPayment(newPayment *domainStatement.Payment) (*domainStatement.Payment, error)
+	GetPayments(filter domainStatement.Filter) (*[]domainStatement.Payment, error)
+	DeletePayment(id uuid.UUID) error
+	RecordAdjustment(newAdjustment *domainStatement.Adjustment) (*domainStatement.Adjustment, error)
+	GetAdjustments(filter domainStatement.Filter) (*[]domainStatement.Adjustment, error)
+	DeleteAdjustment(id uuid.UUID) error
+	GetStatement(clientUserID uuid.UUID, from, to time.Time, now time.Time) (*domainStatement.Statement, error)
+	RenderStatement(statement *domainStatement.Statement) ([]byte, error)
+}
+
+// BrandingSource supplies the agency's logo and colors for statements.
+type BrandingSource interface {
+	Get() (*domainBranding.Branding, error)
+	LogoImage() ([]byte, error)
+}
+
+type StatementUseCase struct {
+	statementRepository domainStatement.IStatementRepository
+	claimRepository     domainClaim.IClaimRepository
+	userRepository      domainUser.IUserRepository
+	branding            BrandingSource
+	Logger              *logger.Logger
+}
+
+type Option func(*StatementUseCase)
+
+// WithBranding heads statements with the agency's logo and colors.
+func WithBranding(source BrandingSource) Option {
+	return func(u *StatementUseCase) {
+		u.branding = source
+	}
+}
+
+func NewStatementUseCase(statementRepository domainStatement.IStatementRepository, claimRepository domainClaim.IClaimRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger, opts ...Option) IStatementUseCase {
+	useCase := &StatementUseCase{
+		statementRepository: statementRepository,
+		claimRepository:     claimRepository,
+		userRepository:      userRepository,
+		Logger:              loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+func (u *StatementUseCase) RecordPayment(newPayment *domainStatement.Payment) (*domainStatement.Payment, error) {
+	u.Logger.Info("Recording client payment", zap.String("clientUserID", newPayment.ClientUserID.String()))
+	if _, err := u.requireClient(newPayment.ClientUserID); err != nil {
+		return nil, err
+	}
+	if newPayment.Amount <= 0 {
+		return nil, domainErrors.NewAppError(errors.New("amount must be positive"), domainErrors.ValidationError)
+	}
+	newPayment.Method = strings.ToLower(strings.TrimSpace(newPayment.Method))
+	if !paymentMethods[newPayment.Method] {
+		return nil, domainErrors.NewAppError(errors.New("method must be 'check', 'card', 'ach' or 'cash'"), domainErrors.ValidationError)
+	}
+	if newPayment.ReceivedAt.IsZero() {
+		return nil, domainErrors.NewAppError(errors.New("received date is required"), domainErrors.ValidationError)
+	}
+	newPayment.ID = uuid.New()
+	newPayment.Amount = roundCents(newPayment.Amount)
+	return u.statementRepository.CreatePayment(newPayment)
+}
+
+func (u *StatementUseCase) GetPayments(filter domainStatement.Filter) (*[]domainStatement.Payment, error) {
+	return u.statementRepository.GetPayments(filter)
+}
+
+func (u *StatementUseCase) DeletePayment(id uuid.UUID) error {
+	u.Logger.Info("Deleting client payment", zap.String("id", id.String()))
+	return u.statementRepository.DeletePayment(id)
+}
+
+func (u *StatementUseCase) RecordAdjustment(newAdjustment *domainStatement.Adjustment) (*domainStatement.Adjustment, error) {
+	u.Logger.Info("Recording client account adjustment", zap.String("clientUserID", newAdjustment.ClientUserID.String()))
+	if _, err := u.requireClient(newAdjustment.ClientUserID); err != nil {
+		return nil, err
+	}
+	if roundCents(newAdjustment.Amount) == 0 {
+		return nil, domainErrors.NewAppError(errors.New("amount must not be zero"), domainErrors.ValidationError)
+	}
+	if strings.TrimSpace(newAdjustment.Reason) == "" {
+		return nil, domainErrors.NewAppError(errors.New("a reason is required for an adjustment"), domainErrors.ValidationError)
+	}
+	if newAdjustment.EffectiveAt.IsZero() {
+		return nil, domainErrors.NewAppError(errors.New("effective date is required"), domainErrors.ValidationError)
+	}
+	newAdjustment.ID = uuid.New()
+	newAdjustment.Amount = roundCents(newAdjustment.Amount)
+	return u.statementRepository.CreateAdjustment(newAdjustment)
+}
+
+func (u *StatementUseCase) GetAdjustments(filter domainStatement.Filter) (*[]domainStatement.Adjustment, error) {
+	return u.statementRepository.GetAdjustments(filter)
+}
+
+func (u *StatementUseCase) DeleteAdjustment(id uuid.UUID) error {
+	u.Logger.Info("Deleting client account adjustment", zap.String("id", id.String()))
+	return u.statementRepository.DeleteAdjustment(id)
+}
+
+// GetStatement collects the client's billed visits, payer and client
+// payments, write-offs and manual adjustments, and runs the balance over the
+// from and to days. Claims replaced by a rebill are left out, as the rebill
+// carries the charge.
+func (u *StatementUseCase) GetStatement(clientUserID uuid.UUID, from, to time.Time, now time.Time) (*domainStatement.Statement, error) {
+	u.Logger.Info("Building client statement", zap.String("clientUserID", clientUserID.String()))
+	client, err := u.requireClient(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, domainErrors.NewAppError(errors.New("the period must not end before it starts"), domainErrors.ValidationError)
+	}
+
+	claims, err := u.claimRepository.GetClaims(domainClaim.ClaimFilter{ClientUserID: &clientUserID})
+	if err != nil {
+		return nil, err
+	}
+	payers, err := u.claimRepository.GetPayers()
+	if err != nil {
+		return nil, err
+	}
+	payerNames := make(map[uuid.UUID]string)
+	for _, payer := range *payers {
+		payerNames[payer.ID] = payer.Name
+	}
+	end := to.AddDate(0, 0, 1)
+	filter := domainStatement.Filter{ClientUserID: clientUserID, To: &end}
+	payments, err := u.statementRepository.GetPayments(filter)
+	if err != nil {
+		return nil, err
+	}
+	adjustments, err := u.statementRepository.GetAdjustments(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []domainStatement.Line
+	for _, claim := range *claims {
+		if claim.Status == domainClaim.StatusRebilled {
+			continue
+		}
+		entries = append(entries, domainStatement.Line{
+			Date:        claim.ServiceDate,
+			Type:        domainStatement.LineInvoice,
+			Reference:   claim.ControlNumber,
+			Description: fmt.Sprintf("Visit %s, %d units of %s", claim.ServiceDate.Format(dateLayout), claim.Units, claim.ProcedureCode),
+			Amount:      claim.Charge,
+		})
+		settledAt := claim.UpdatedAt
+		if claim.AdjudicatedAt != nil {
+			settledAt = *claim.AdjudicatedAt
+		}
+		if claim.PaidAmount > 0 {
+			entries = append(entries, domainStatement.Line{
+				Date:        settledAt,
+				Type:        domainStatement.LinePayment,
+				Reference:   claim.ControlNumber,
+				Description: "Payment from " + payerNames[claim.PayerID],
+				Amount:      -claim.PaidAmount,
+			})
+		}
+		if claim.Status == domainClaim.StatusWrittenOff && claim.Charge > claim.PaidAmount {
+			entries = append(entries, domainStatement.Line{
+				Date:        claim.UpdatedAt,
+				Type:        domainStatement.LineAdjustment,
+				Reference:   claim.ControlNumber,
+				Description: "Written off",
+				Amount:      -roundCents(claim.Charge - claim.PaidAmount),
+			})
+		}
+	}
+	for _, payment := range *payments {
+		entries = append(entries, domainStatement.Line{
+			Date:        payment.ReceivedAt,
+			Type:        domainStatement.LinePayment,
+			Reference:   payment.Reference,
+			Description: "Payment by " + payment.Method,
+			Amount:      -payment.Amount,
+		})
+	}
+	for _, adjustment := range *adjustments {
+		entries = append(entries, domainStatement.Line{
+			Date:        adjustment.EffectiveAt,
+			Type:        domainStatement.LineAdjustment,
+			Description: adjustment.Reason,
+			Amount:      adjustment.Amount,
+		})
+	}
+
+	statement := domainStatement.Build(clientUserID, from, to, entries)
+	statement.ClientName = strings.TrimSpace(client.FirstName + " " + client.LastName)
+	statement.GeneratedAt = now
+	return &statement, nil
+}
+
+// RenderStatement lays the statement out as a printable PDF addressed to the
+// client, under the agency's logo and billing details when they are set up.
+func (u *StatementUseCase) RenderStatement(statement *domainStatement.Statement) ([]byte, error) {
+	client, err := u.userRepository.GetByID(statement.ClientUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("client not found"), domainErrors.NotFound)
+	}
+	doc := pdf.New()
+	displayName, err := u.applyBranding(doc)
+	if err != nil {
+		return nil, err
+	}
+	if provider, err := u.claimRepository.GetProviderSettings(); err == nil {
+		if displayName == "" {
+			displayName = provider.Name
+		}
+		doc.Heading(displayName)
+		doc.Line(provider.Street)
+		doc.Line(fmt.Sprintf("%s, %s %s", provider.City, provider.State, provider.Zip))
+		if provider.ContactPhone != "" {
+			doc.Line(provider.ContactPhone)
+		}
+	} else if !isNotFound(err) {
+		return nil, err
+	} else if displayName != "" {
+		doc.Heading(displayName)
+	}
+	doc.Blank()
+	doc.Heading("Account Statement")
+	doc.Line(fmt.Sprintf("Period: %s to %s", statement.From.Format(dateLayout), statement.To.Format(dateLayout)))
+	doc.Line("Statement date: " + statement.GeneratedAt.Format(dateLayout))
+	doc.Blank()
+	doc.Bold(statement.ClientName)
+	location := client.Location
+	if street := strings.TrimSpace(location.HouseNumber + " " + location.Street); street != "" {
+		doc.Line(street)
+	}
+	if location.City != "" {
+		doc.Line(strings.TrimSpace(fmt.Sprintf("%s, %s %s", location.City, location.State, location.Pincode)))
+	}
+	doc.Blank()
+
+	row := "%-11s%-14s%-33s%12s%12s"
+	doc.Bold(fmt.Sprintf(row, "Date", "Reference", "Description", "Amount", "Balance"))
+	doc.Line(fmt.Sprintf(row, "", "", "Opening balance", "", money(statement.OpeningBalance)))
+	for _, line := range statement.Lines {
+		doc.Line(fmt.Sprintf(row, line.Date.Format(dateLayout), truncate(line.Reference, 13), truncate(line.Description, 32), money(line.Amount), money(line.Balance)))
+	}
+	doc.Blank()
+	total := "%-46s%12s"
+	doc.Line(fmt.Sprintf(total, "Invoiced", money(statement.Invoiced)))
+	doc.Line(fmt.Sprintf(total, "Payments", money(-statement.Paid)))
+	doc.Line(fmt.Sprintf(total, "Adjustments", money(statement.Adjusted)))
+	doc.Bold(fmt.Sprintf(total, "Balance due", money(statement.ClosingBalance)))
+	return doc.Bytes(), nil
+}
+
+// applyBranding draws the agency logo, colors the headings and returns the
+// agency's display name. A logo that cannot be drawn is left out rather
+// than failing the statement.
+func (u *StatementUseCase) applyBranding(doc *pdf.Document) (string, error) {
+	if u.branding == nil {
+		return "", nil
+	}
+	branding, err := u.branding.Get()
+	if err != nil {
+		return "", err
+	}
+	if r, g, b, ok := domainBranding.ParseColor(branding.PrimaryColor); ok {
+		doc.HeadingColor(r, g, b)
+	}
+	if !branding.HasLogo() {
+		return branding.DisplayName, nil
+	}
+	logo, err := u.branding.LogoImage()
+	if err != nil {
+		return "", err
+	}
+	if err := doc.Image(logo, logoHeight); err != nil {
+		u.Logger.Warn("Leaving the agency logo off the statement", zap.Error(err))
+	} else {
+		doc.Blank()
+	}
+	return branding.DisplayName, nil
+}
+
+func (u *StatementUseCase) requireClient(clientUserID uuid.UUID) (*domainUser.User, error) {
+	client, err := u.userRepository.GetByID(clientUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("client not found"), domainErrors.NotFound)
+	}
+	if client.Role != domainUser.RoleClient {
+		return nil, domainErrors.NewAppError(errors.New("statements are only kept for clients"), domainErrors.ValidationError)
+	}
+	return client, nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
+
+func money(v float64) string {
+	v = roundCents(v)
+	if v == 0 {
+		// Avoid printing negative zero.
+		v = 0
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "~"
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
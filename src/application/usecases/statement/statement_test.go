@@ -0,0 +1,261 @@
+package statement
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	domainBranding "caregiver/src/domain/branding"
+	domainClaim "caregiver/src/domain/claim"
+	domainErrors "caregiver/src/domain/errors"
+	domainStatement "caregiver/src/domain/statement"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockStatementRepository keeps payments and adjustments in memory
+type mockStatementRepository struct {
+	domainStatement.IStatementRepository
+	payments    []domainStatement.Payment
+	adjustments []domainStatement.Adjustment
+}
+
+func (m *mockStatementRepository) CreatePayment(newPayment *domainStatement.Payment) (*domainStatement.Payment, error) {
+	m.payments = append(m.payments, *newPayment)
+	return newPayment, nil
+}
+
+func (m *mockStatementRepository) GetPayments(filter domainStatement.Filter) (*[]domainStatement.Payment, error) {
+	res := []domainStatement.Payment{}
+	for _, p := range m.payments {
+		if p.ClientUserID == filter.ClientUserID && (filter.To == nil || p.ReceivedAt.Before(*filter.To)) {
+			res = append(res, p)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockStatementRepository) CreateAdjustment(newAdjustment *domainStatement.Adjustment) (*domainStatement.Adjustment, error) {
+	m.adjustments = append(m.adjustments, *newAdjustment)
+	return newAdjustment, nil
+}
+
+func (m *mockStatementRepository) GetAdjustments(filter domainStatement.Filter) (*[]domainStatement.Adjustment, error) {
+	res := []domainStatement.Adjustment{}
+	for _, a := range m.adjustments {
+		if a.ClientUserID == filter.ClientUserID && (filter.To == nil || a.EffectiveAt.Before(*filter.To)) {
+			res = append(res, a)
+		}
+	}
+	return &res, nil
+}
+
+// mockClaimRepository serves the client's claims and one payer
+type mockClaimRepository struct {
+	domainClaim.IClaimRepository
+	payer  domainClaim.Payer
+	claims []domainClaim.Claim
+}
+
+func (m *mockClaimRepository) GetClaims(filter domainClaim.ClaimFilter) (*[]domainClaim.Claim, error) {
+	res := []domainClaim.Claim{}
+	for _, c := range m.claims {
+		if filter.ClientUserID == nil || c.ClientUserID == *filter.ClientUserID {
+			res = append(res, c)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockClaimRepository) GetPayers() (*[]domainClaim.Payer, error) {
+	return &[]domainClaim.Payer{m.payer}, nil
+}
+
+func (m *mockClaimRepository) GetProviderSettings() (*domainClaim.ProviderSettings, error) {
+	return &domainClaim.ProviderSettings{Name: "Sunrise Home Care", Street: "1 Main St", City: "Austin", State: "TX", Zip: "78701"}, nil
+}
+
+// mockUserRepository only implements lookups by ID
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if u, ok := m.users[id]; ok {
+		return u, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockBranding returns fixed branding without a logo
+type mockBranding struct {
+	branding domainBranding.Branding
+}
+
+func (m *mockBranding) Get() (*domainBranding.Branding, error) {
+	return &m.branding, nil
+}
+
+func (m *mockBranding) LogoImage() ([]byte, error) {
+	return nil, nil
+}
+
+func setupTestStatementUseCase(t *testing.T) (IStatementUseCase, *mockClaimRepository, *mockUserRepository) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	claims := &mockClaimRepository{payer: domainClaim.Payer{ID: uuid.New(), Name: "Texas Medicaid"}}
+	users := &mockUserRepository{users: make(map[uuid.UUID]*domainUser.User)}
+	return NewStatementUseCase(&mockStatementRepository{}, claims, users, loggerInstance), claims, users
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func day(d int) time.Time {
+	return time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRecordPayment(t *testing.T) {
+	useCase, _, users := setupTestStatementUseCase(t)
+	client := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	caregiver := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	users.users[client.ID] = client
+	users.users[caregiver.ID] = caregiver
+
+	for _, tc := range []struct {
+		name    string
+		payment domainStatement.Payment
+		want    domainErrors.ErrorType
+	}{
+		{"Unknown client", domainStatement.Payment{ClientUserID: uuid.New(), Amount: 10, Method: "check", ReceivedAt: day(1)}, domainErrors.NotFound},
+		{"Not a client", domainStatement.Payment{ClientUserID: caregiver.ID, Amount: 10, Method: "check", ReceivedAt: day(1)}, domainErrors.ValidationError},
+		{"Amount must be positive", domainStatement.Payment{ClientUserID: client.ID, Amount: -10, Method: "check", ReceivedAt: day(1)}, domainErrors.ValidationError},
+		{"Unknown method", domainStatement.Payment{ClientUserID: client.ID, Amount: 10, Method: "barter", ReceivedAt: day(1)}, domainErrors.ValidationError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			payment := tc.payment
+			if _, err := useCase.RecordPayment(&payment); errorType(err) != tc.want {
+				t.Errorf("expected %s, got %v", tc.want, err)
+			}
+		})
+	}
+
+	payment, err := useCase.RecordPayment(&domainStatement.Payment{ClientUserID: client.ID, Amount: 25.004, Method: " Check ", ReceivedAt: day(1)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payment.Method != domainStatement.MethodCheck || payment.Amount != 25 {
+		t.Errorf("expected the method and amount to be normalised, got %+v", payment)
+	}
+}
+
+func TestGetStatement(t *testing.T) {
+	useCase, claims, users := setupTestStatementUseCase(t)
+	client := &domainUser.User{ID: uuid.New(), FirstName: "Ana", LastName: "Ruiz", Role: domainUser.RoleClient}
+	users.users[client.ID] = client
+
+	paidAt := day(12)
+	claims.claims = []domainClaim.Claim{
+		// Billed before the period and paid within it.
+		{ClientUserID: client.ID, PayerID: claims.payer.ID, ControlNumber: "C1", ServiceDate: day(3), Units: 4, ProcedureCode: "T1019", Charge: 100, PaidAmount: 80, Status: domainClaim.StatusPaid, AdjudicatedAt: &paidAt},
+		// Denied and written off within the period.
+		{ClientUserID: client.ID, PayerID: claims.payer.ID, ControlNumber: "C2", ServiceDate: day(10), Units: 2, ProcedureCode: "T1019", Charge: 50, Status: domainClaim.StatusWrittenOff, UpdatedAt: day(14)},
+		// Replaced by C3, which carries the charge.
+		{ClientUserID: client.ID, PayerID: claims.payer.ID, ControlNumber: "C2-OLD", ServiceDate: day(11), Charge: 60, Status: domainClaim.StatusRebilled},
+		{ClientUserID: client.ID, PayerID: claims.payer.ID, ControlNumber: "C3", ServiceDate: day(11), Units: 3, ProcedureCode: "T1019", Charge: 60, Status: domainClaim.StatusSubmitted},
+		// After the period.
+		{ClientUserID: client.ID, PayerID: claims.payer.ID, ControlNumber: "C4", ServiceDate: day(25), Charge: 70, Status: domainClaim.StatusSubmitted},
+		// Another client's.
+		{ClientUserID: uuid.New(), PayerID: claims.payer.ID, ControlNumber: "X1", ServiceDate: day(11), Charge: 999, Status: domainClaim.StatusSubmitted},
+	}
+	if _, err := useCase.RecordPayment(&domainStatement.Payment{ClientUserID: client.ID, Amount: 20, Method: "check", Reference: "1042", ReceivedAt: day(15).Add(9 * time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.RecordAdjustment(&domainStatement.Adjustment{ClientUserID: client.ID, Amount: -5, Reason: "Courtesy credit", EffectiveAt: day(20)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	statement, err := useCase.GetStatement(client.ID, day(10), day(20), day(21))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statement.ClientName != "Ana Ruiz" || statement.OpeningBalance != 100 || statement.Invoiced != 110 || statement.Paid != 100 || statement.Adjusted != -55 || statement.ClosingBalance != 55 {
+		t.Errorf("unexpected totals: %+v", statement)
+	}
+	want := []struct {
+		reference string
+		amount    float64
+		balance   float64
+	}{
+		{"C2", 50, 150},
+		{"C3", 60, 210},
+		{"C1", -80, 130},
+		{"C2", -50, 80},
+		{"1042", -20, 60},
+		{"", -5, 55},
+	}
+	if len(statement.Lines) != len(want) {
+		t.Fatalf("expected %d lines, got %+v", len(want), statement.Lines)
+	}
+	for i, w := range want {
+		got := statement.Lines[i]
+		if got.Reference != w.reference || got.Amount != w.amount || got.Balance != w.balance {
+			t.Errorf("line %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+
+	t.Run("Period ending before it starts", func(t *testing.T) {
+		if _, err := useCase.GetStatement(client.ID, day(20), day(10), day(21)); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("PDF for mailing", func(t *testing.T) {
+		content, err := useCase.RenderStatement(statement)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.HasPrefix(content, []byte("%PDF-")) {
+			t.Fatalf("expected a PDF, got %q", content)
+		}
+		for _, text := range []string{"Sunrise Home Care", "Ana Ruiz", "Courtesy credit", "Balance due"} {
+			if !bytes.Contains(content, []byte(text)) {
+				t.Errorf("expected the PDF to mention %q", text)
+			}
+		}
+	})
+}
+
+func TestRenderStatementBranding(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	users := &mockUserRepository{users: make(map[uuid.UUID]*domainUser.User)}
+	client := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, FirstName: "Ana", LastName: "Ruiz"}
+	users.users[client.ID] = client
+	branding := &mockBranding{branding: domainBranding.Branding{DisplayName: "Sunrise Care Co", PrimaryColor: "#003366"}}
+	useCase := NewStatementUseCase(&mockStatementRepository{}, &mockClaimRepository{}, users, loggerInstance, WithBranding(branding))
+
+	content, err := useCase.RenderStatement(&domainStatement.Statement{ClientUserID: client.ID, ClientName: "Ana Ruiz", From: day(1), To: day(30), GeneratedAt: day(30)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Contains(content, []byte("(Sunrise Care Co) Tj")) || bytes.Contains(content, []byte("(Sunrise Home Care) Tj")) {
+		t.Errorf("expected the display name to head the statement")
+	}
+	if !bytes.Contains(content, []byte("0.000 0.200 0.400 rg")) {
+		t.Errorf("expected headings in the primary color")
+	}
+}
@@ -0,0 +1,255 @@
+package suggestion
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	slotStep          = 30 * time.Minute
+	searchBackward    = 24 * time.Hour
+	searchForward     = 7 * 24 * time.Hour
+	maxSlotSuggestion = 3
+	maxCaregivers     = 5
+)
+
+// CaregiverScreen lets other features drop or flag caregivers before they are
+// proposed for a client, e.g. when the client lives outside their service area.
+type CaregiverScreen interface {
+	ScreenCaregivers(client *domainUser.User, caregiverIDs []uuid.UUID) (map[uuid.UUID]domainSchedule.Screening, error)
+}
+
+type Option func(*SuggestionService)
+
+func WithCaregiverScreens(screens ...CaregiverScreen) Option {
+	return func(s *SuggestionService) {
+		s.screens = append(s.screens, screens...)
+	}
+}
+
+// ISuggestionService detects double bookings and proposes ways around them.
+type ISuggestionService interface {
+	Suggest(schedule *domainSchedule.Schedule) (*domainSchedule.Suggestions, error)
+	AvailableCaregivers(clientUserID uuid.UUID, from, to time.Time) ([]domainSchedule.CaregiverSuggestion, error)
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+}
+
+type SuggestionService struct {
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	screens            []CaregiverScreen
+	Logger             *logger.Logger
+	now                func() time.Time
+}
+
+func NewSuggestionService(scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger, opts ...Option) ISuggestionService {
+	service := &SuggestionService{
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		Logger:             loggerInstance,
+		now:                time.Now,
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
+}
+
+// ValidateSchedule rejects a schedule whose caregiver is already booked for
+// an overlapping visit. The error carries suggestions for resolving it.
+func (s *SuggestionService) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if !isActive(schedule.VisitStatus) || schedule.Open() {
+		return nil, nil
+	}
+
+	busy, err := s.scheduleRepository.GetActiveSchedulesBetween(schedule.ScheduledSlot.From, schedule.ScheduledSlot.To, []uuid.UUID{schedule.AssignedUserID})
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []uuid.UUID
+	for _, other := range *busy {
+		if other.ID != schedule.ID {
+			conflicts = append(conflicts, other.ID)
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	s.Logger.Warn("Schedule conflicts with existing visits", zap.String("assignedUserID", schedule.AssignedUserID.String()), zap.Int("conflicts", len(conflicts)))
+	suggestions, err := s.Suggest(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return nil, domainErrors.NewAppError(&domainSchedule.ConflictError{
+		Message:     "caregiver is already booked for an overlapping visit",
+		Conflicts:   conflicts,
+		Suggestions: suggestions,
+	}, domainErrors.Conflict)
+}
+
+// Suggest returns the nearest free slots for the assigned caregiver and other
+// active caregivers who are free for the requested slot, closest first.
+func (s *SuggestionService) Suggest(schedule *domainSchedule.Schedule) (*domainSchedule.Suggestions, error) {
+	duration := schedule.ScheduledSlot.To.Sub(schedule.ScheduledSlot.From)
+	if duration <= 0 {
+		return nil, domainErrors.NewAppError(errors.New("scheduled slot must have a positive duration"), domainErrors.ValidationError)
+	}
+
+	freeSlots, err := s.freeSlots(schedule, duration)
+	if err != nil {
+		return nil, err
+	}
+	caregivers, err := s.freeCaregivers(schedule.ClientUserID, schedule.ScheduledSlot.From, schedule.ScheduledSlot.To, schedule.ID, schedule.AssignedUserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domainSchedule.Suggestions{FreeSlots: freeSlots, Caregivers: caregivers}, nil
+}
+
+func (s *SuggestionService) freeSlots(schedule *domainSchedule.Schedule, duration time.Duration) ([]domainSchedule.SlotSuggestion, error) {
+	requested := schedule.ScheduledSlot.From
+	windowStart := requested.Add(-searchBackward)
+	if now := s.now(); windowStart.Before(now) {
+		windowStart = now
+	}
+	windowEnd := requested.Add(searchForward)
+
+	busy, err := s.scheduleRepository.GetActiveSchedulesBetween(windowStart, windowEnd.Add(duration), []uuid.UUID{schedule.AssignedUserID})
+	if err != nil {
+		return nil, err
+	}
+	isFree := func(from time.Time) bool {
+		to := from.Add(duration)
+		for i := range *busy {
+			other := &(*busy)[i]
+			if other.ID != schedule.ID && other.Overlaps(from, to) {
+				return false
+			}
+		}
+		return true
+	}
+
+	slots := []domainSchedule.SlotSuggestion{}
+	maxSteps := int(searchForward / slotStep)
+	for step := 1; step <= maxSteps && len(slots) < maxSlotSuggestion; step++ {
+		offset := time.Duration(step) * slotStep
+		for _, from := range []time.Time{requested.Add(-offset), requested.Add(offset)} {
+			if from.Before(windowStart) || from.After(windowEnd) || len(slots) == maxSlotSuggestion {
+				continue
+			}
+			if isFree(from) {
+				slots = append(slots, domainSchedule.SlotSuggestion{From: from, To: from.Add(duration)})
+			}
+		}
+	}
+	return slots, nil
+}
+
+// AvailableCaregivers returns active caregivers with no visit during
+// [from, to), closest to the client first.
+func (s *SuggestionService) AvailableCaregivers(clientUserID uuid.UUID, from, to time.Time) ([]domainSchedule.CaregiverSuggestion, error) {
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("scheduled slot must have a positive duration"), domainErrors.ValidationError)
+	}
+	return s.freeCaregivers(clientUserID, from, to, uuid.Nil, uuid.Nil)
+}
+
+func (s *SuggestionService) freeCaregivers(clientUserID uuid.UUID, from, to time.Time, excludeScheduleID, excludeUserID uuid.UUID) ([]domainSchedule.CaregiverSuggestion, error) {
+	users, err := s.userRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	busy, err := s.scheduleRepository.GetActiveSchedulesBetween(from, to, nil)
+	if err != nil {
+		return nil, err
+	}
+	busyUsers := make(map[uuid.UUID]bool)
+	for _, other := range *busy {
+		if other.ID != excludeScheduleID {
+			busyUsers[other.AssignedUserID] = true
+		}
+	}
+
+	var client *domainUser.User
+	if found, err := s.userRepository.GetByID(clientUserID); err == nil {
+		client = found
+	}
+
+	caregivers := []domainSchedule.CaregiverSuggestion{}
+	for _, user := range *users {
+		if user.Role != domainUser.RoleCaregiver || !user.Status || user.ID == excludeUserID || busyUsers[user.ID] {
+			continue
+		}
+		suggestion := domainSchedule.CaregiverSuggestion{UserID: user.ID, FirstName: user.FirstName, LastName: user.LastName}
+		if client != nil && client.Location.HasCoordinates() && user.Location.HasCoordinates() {
+			distance := client.Location.DistanceKm(user.Location)
+			suggestion.DistanceKm = &distance
+		}
+		caregivers = append(caregivers, suggestion)
+	}
+	if client != nil {
+		if caregivers, err = s.screen(client, caregivers); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(caregivers, func(i, j int) bool {
+		a, b := caregivers[i].DistanceKm, caregivers[j].DistanceKm
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return *a < *b
+	})
+	if len(caregivers) > maxCaregivers {
+		caregivers = caregivers[:maxCaregivers]
+	}
+	return caregivers, nil
+}
+
+func (s *SuggestionService) screen(client *domainUser.User, caregivers []domainSchedule.CaregiverSuggestion) ([]domainSchedule.CaregiverSuggestion, error) {
+	if len(s.screens) == 0 || len(caregivers) == 0 {
+		return caregivers, nil
+	}
+	ids := make([]uuid.UUID, len(caregivers))
+	for i, caregiver := range caregivers {
+		ids[i] = caregiver.UserID
+	}
+	for _, screen := range s.screens {
+		verdicts, err := screen.ScreenCaregivers(client, ids)
+		if err != nil {
+			return nil, err
+		}
+		kept := caregivers[:0]
+		for _, caregiver := range caregivers {
+			verdict := verdicts[caregiver.UserID]
+			if verdict.Excluded {
+				continue
+			}
+			if verdict.Warning != "" {
+				caregiver.Warnings = append(caregiver.Warnings, verdict.Warning)
+			}
+			kept = append(kept, caregiver)
+		}
+		caregivers = kept
+	}
+	return caregivers, nil
+}
+
+func isActive(status string) bool {
+	for _, active := range domainSchedule.ActiveStatuses {
+		if status == active {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package suggestion
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockScheduleRepository answers overlap queries from an in-memory list
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetActiveSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		if !s.Overlaps(from, to) {
+			continue
+		}
+		if len(assignedUserIDs) > 0 {
+			matched := false
+			for _, id := range assignedUserIDs {
+				matched = matched || id == s.AssignedUserID
+			}
+			if !matched {
+				continue
+			}
+		}
+		res = append(res, s)
+	}
+	return &res, nil
+}
+
+// mockUserRepository serves a fixed set of users
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users []domainUser.User
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) {
+	return &m.users, nil
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	for i := range m.users {
+		if m.users[i].ID == id {
+			return &m.users[i], nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+var slotStart = time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+
+func setupTestSuggestionService(t *testing.T, schedules []domainSchedule.Schedule, users []domainUser.User) *SuggestionService {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	service := NewSuggestionService(&mockScheduleRepository{schedules: schedules}, &mockUserRepository{users: users}, loggerInstance).(*SuggestionService)
+	service.now = func() time.Time { return slotStart.Add(-48 * time.Hour) }
+	return service
+}
+
+func booking(assignedUserID uuid.UUID, from time.Time, hours int) domainSchedule.Schedule {
+	return domainSchedule.Schedule{
+		ID:             uuid.New(),
+		AssignedUserID: assignedUserID,
+		VisitStatus:    "upcoming",
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: from, To: from.Add(time.Duration(hours) * time.Hour)},
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	caregiverID := uuid.New()
+	clientID := uuid.New()
+	near := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true, FirstName: "Near", Location: domainUser.Location{Lat: 51.51, Long: -0.12}}
+	far := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true, FirstName: "Far", Location: domainUser.Location{Lat: 53.48, Long: -2.24}}
+	busy := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true, FirstName: "Busy"}
+	inactive := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: false, FirstName: "Inactive"}
+	users := []domainUser.User{
+		{ID: clientID, Role: domainUser.RoleClient, Status: true, Location: domainUser.Location{Lat: 51.5, Long: -0.1}},
+		{ID: caregiverID, Role: domainUser.RoleCaregiver, Status: true},
+		far, near, busy, inactive,
+	}
+	existing := []domainSchedule.Schedule{
+		booking(caregiverID, slotStart, 2),
+		booking(busy.ID, slotStart, 2),
+	}
+
+	t.Run("No conflict", func(t *testing.T) {
+		service := setupTestSuggestionService(t, existing, users)
+		candidate := booking(caregiverID, slotStart.Add(3*time.Hour), 1)
+		if _, err := service.ValidateSchedule(&candidate); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Updating the same schedule is not a conflict", func(t *testing.T) {
+		service := setupTestSuggestionService(t, existing, users)
+		candidate := existing[0]
+		candidate.ScheduledSlot.To = candidate.ScheduledSlot.To.Add(time.Hour)
+		if _, err := service.ValidateSchedule(&candidate); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Cancelled schedule is ignored", func(t *testing.T) {
+		service := setupTestSuggestionService(t, existing, users)
+		candidate := booking(caregiverID, slotStart, 1)
+		candidate.VisitStatus = "cancelled"
+		if _, err := service.ValidateSchedule(&candidate); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Conflict returns suggestions", func(t *testing.T) {
+		service := setupTestSuggestionService(t, existing, users)
+		candidate := booking(caregiverID, slotStart.Add(time.Hour), 1)
+		candidate.ClientUserID = clientID
+
+		_, err := service.ValidateSchedule(&candidate)
+		var appErr *domainErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Type != domainErrors.Conflict {
+			t.Fatalf("expected conflict error, got %v", err)
+		}
+		var conflict *domainSchedule.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Fatalf("expected conflict details, got %v", err)
+		}
+		if len(conflict.Conflicts) != 1 || conflict.Conflicts[0] != existing[0].ID {
+			t.Errorf("expected conflict with %s, got %v", existing[0].ID, conflict.Conflicts)
+		}
+
+		slots := conflict.Suggestions.FreeSlots
+		if len(slots) != maxSlotSuggestion {
+			t.Fatalf("expected %d free slots, got %d", maxSlotSuggestion, len(slots))
+		}
+		expected := []time.Time{slotStart.Add(2 * time.Hour), slotStart.Add(150 * time.Minute), slotStart.Add(-time.Hour)}
+		for i, slot := range slots {
+			if !slot.From.Equal(expected[i]) {
+				t.Errorf("expected slot %d to start at %v, got %v", i, expected[i], slot.From)
+			}
+		}
+
+		caregivers := conflict.Suggestions.Caregivers
+		if len(caregivers) != 2 {
+			t.Fatalf("expected 2 alternative caregivers, got %+v", caregivers)
+		}
+		if caregivers[0].UserID != near.ID || caregivers[1].UserID != far.ID {
+			t.Errorf("expected caregivers ordered by distance, got %+v", caregivers)
+		}
+		if caregivers[0].DistanceKm == nil || *caregivers[0].DistanceKm > 5 {
+			t.Errorf("expected nearby caregiver within 5km, got %v", caregivers[0].DistanceKm)
+		}
+	})
+}
+
+func TestSuggestSkipsPastSlots(t *testing.T) {
+	caregiverID := uuid.New()
+	service := setupTestSuggestionService(t, nil, nil)
+	service.now = func() time.Time { return slotStart }
+
+	candidate := booking(caregiverID, slotStart, 1)
+	suggestions, err := service.Suggest(&candidate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, slot := range suggestions.FreeSlots {
+		if slot.From.Before(slotStart) {
+			t.Errorf("expected no slot in the past, got %v", slot.From)
+		}
+	}
+}
+
+type mockScreen struct {
+	verdicts map[uuid.UUID]domainSchedule.Screening
+}
+
+func (m *mockScreen) ScreenCaregivers(client *domainUser.User, caregiverIDs []uuid.UUID) (map[uuid.UUID]domainSchedule.Screening, error) {
+	return m.verdicts, nil
+}
+
+func TestAvailableCaregiversAppliesScreens(t *testing.T) {
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient, Location: domainUser.Location{Lat: 52.52, Long: 13.40}}
+	excluded := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	flagged := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	service := setupTestSuggestionService(t, nil, []domainUser.User{client, excluded, flagged})
+	service.screens = []CaregiverScreen{&mockScreen{verdicts: map[uuid.UUID]domainSchedule.Screening{
+		excluded.ID: {Excluded: true},
+		flagged.ID:  {Warning: "outside service area"},
+	}}}
+
+	caregivers, err := service.AvailableCaregivers(client.ID, slotStart, slotStart.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(caregivers) != 1 || caregivers[0].UserID != flagged.ID {
+		t.Fatalf("expected only the flagged caregiver, got %+v", caregivers)
+	}
+	if len(caregivers[0].Warnings) != 1 || caregivers[0].Warnings[0] != "outside service area" {
+		t.Errorf("expected screening warning, got %v", caregivers[0].Warnings)
+	}
+}
@@ -0,0 +1,241 @@
+package supply
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainSupply "caregiver/src/domain/supply"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ISupplyUseCase interface {
+	CreateItem(newItem *domainSupply.Item) (*domainSupply.Item, error)
+	GetItems() (*[]domainSupply.Item, error)
+	GetItemByID(id uuid.UUID) (*domainSupply.Item, error)
+	UpdateItem(id uuid.UUID, updates map[string]interface{}) (*domainSupply.Item, error)
+	DeleteItem(id uuid.UUID) error
+	Restock(id uuid.UUID, quantity int) (*domainSupply.Item, error)
+	GetLowStockItems() (*[]domainSupply.Item, error)
+	LogUsage(newUsage *domainSupply.Usage) (*domainSupply.Usage, error)
+	GetUsages(filter domainSupply.UsageFilter) (*[]domainSupply.Usage, error)
+	DeleteUsage(id uuid.UUID) error
+	ConsumptionReport(clientUserID *uuid.UUID, from, to time.Time) (*domainSupply.ConsumptionReport, error)
+}
+
+type SupplyUseCase struct {
+	supplyRepository   domainSupply.ISupplyRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	notifier           notification.INotifier
+	Logger             *logger.Logger
+}
+
+func NewSupplyUseCase(supplyRepository domainSupply.ISupplyRepository, scheduleRepository domainSchedule.IScheduleRepository, notifier notification.INotifier, loggerInstance *logger.Logger) ISupplyUseCase {
+	return &SupplyUseCase{
+		supplyRepository:   supplyRepository,
+		scheduleRepository: scheduleRepository,
+		notifier:           notifier,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *SupplyUseCase) CreateItem(newItem *domainSupply.Item) (*domainSupply.Item, error) {
+	u.Logger.Info("Creating supply item", zap.String("name", newItem.Name))
+	if err := validateItem(newItem); err != nil {
+		return nil, err
+	}
+	newItem.ID = uuid.New()
+	newItem.Active = true
+	return u.supplyRepository.CreateItem(newItem)
+}
+
+func (u *SupplyUseCase) GetItems() (*[]domainSupply.Item, error) {
+	return u.supplyRepository.GetItems()
+}
+
+func (u *SupplyUseCase) GetItemByID(id uuid.UUID) (*domainSupply.Item, error) {
+	return u.supplyRepository.GetItemByID(id)
+}
+
+// UpdateItem edits the catalog entry. Setting "on_hand" records a stock
+// count and replaces the running total.
+func (u *SupplyUseCase) UpdateItem(id uuid.UUID, updates map[string]interface{}) (*domainSupply.Item, error) {
+	u.Logger.Info("Updating supply item", zap.String("id", id.String()))
+	existing, err := u.supplyRepository.GetItemByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["name"].(string); ok {
+		candidate.Name = v
+	}
+	if v, ok := updates["unit"].(string); ok {
+		candidate.Unit = v
+	}
+	if v, ok := updates["unit_cost"].(float64); ok {
+		candidate.UnitCost = v
+	}
+	if v, ok := updates["on_hand"].(int); ok {
+		candidate.OnHand = v
+	}
+	if v, ok := updates["reorder_level"].(int); ok {
+		candidate.ReorderLevel = v
+	}
+	if err := validateItem(&candidate); err != nil {
+		return nil, err
+	}
+	return u.supplyRepository.UpdateItem(id, updates)
+}
+
+func (u *SupplyUseCase) DeleteItem(id uuid.UUID) error {
+	u.Logger.Info("Deleting supply item", zap.String("id", id.String()))
+	return u.supplyRepository.DeleteItem(id)
+}
+
+// Restock adds a delivery to the item's stock.
+func (u *SupplyUseCase) Restock(id uuid.UUID, quantity int) (*domainSupply.Item, error) {
+	u.Logger.Info("Restocking supply item", zap.String("id", id.String()), zap.Int("quantity", quantity))
+	if quantity <= 0 {
+		return nil, domainErrors.NewAppError(errors.New("quantity must be positive"), domainErrors.ValidationError)
+	}
+	return u.supplyRepository.AdjustStock(id, quantity)
+}
+
+func (u *SupplyUseCase) GetLowStockItems() (*[]domainSupply.Item, error) {
+	return u.supplyRepository.GetLowStockItems()
+}
+
+// LogUsage records supplies used on a visit that has started and takes them
+// out of stock. Usage is recorded even when it takes the count below zero,
+// since the supplies were used either way; coordinators are alerted when the
+// item drops to its reorder level.
+func (u *SupplyUseCase) LogUsage(newUsage *domainSupply.Usage) (*domainSupply.Usage, error) {
+	u.Logger.Info("Logging supply usage", zap.String("scheduleID", newUsage.ScheduleID.String()), zap.String("itemID", newUsage.ItemID.String()))
+	if newUsage.Quantity <= 0 {
+		return nil, domainErrors.NewAppError(errors.New("quantity must be positive"), domainErrors.ValidationError)
+	}
+	schedule, err := u.scheduleRepository.GetScheduleByID(newUsage.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.CheckinTime == nil {
+		return nil, domainErrors.NewAppError(errors.New("supplies can only be logged once the visit has started"), domainErrors.ValidationError)
+	}
+	item, err := u.supplyRepository.GetItemByID(newUsage.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	if !item.Active {
+		return nil, domainErrors.NewAppError(errors.New("the supply item is no longer active"), domainErrors.ValidationError)
+	}
+
+	newUsage.ID = uuid.New()
+	newUsage.ClientUserID = schedule.ClientUserID
+	newUsage.CaregiverUserID = schedule.AssignedUserID
+	newUsage.UnitCost = item.UnitCost
+	if newUsage.UsedAt.IsZero() {
+		newUsage.UsedAt = *schedule.CheckinTime
+	}
+	created, err := u.supplyRepository.CreateUsage(newUsage)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := u.supplyRepository.AdjustStock(item.ID, -newUsage.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	if !item.IsLow() && updated.IsLow() {
+		u.notifyLowStock(updated)
+	}
+	return created, nil
+}
+
+func (u *SupplyUseCase) GetUsages(filter domainSupply.UsageFilter) (*[]domainSupply.Usage, error) {
+	return u.supplyRepository.GetUsages(filter)
+}
+
+// DeleteUsage removes a usage logged in error and returns it to stock.
+func (u *SupplyUseCase) DeleteUsage(id uuid.UUID) error {
+	u.Logger.Info("Deleting supply usage", zap.String("id", id.String()))
+	usage, err := u.supplyRepository.GetUsageByID(id)
+	if err != nil {
+		return err
+	}
+	if err := u.supplyRepository.DeleteUsage(id); err != nil {
+		return err
+	}
+	_, err = u.supplyRepository.AdjustStock(usage.ItemID, usage.Quantity)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// ConsumptionReport totals the supplies used per client over the from and
+// to days, at the cost recorded with each usage. A nil client covers every
+// client.
+func (u *SupplyUseCase) ConsumptionReport(clientUserID *uuid.UUID, from, to time.Time) (*domainSupply.ConsumptionReport, error) {
+	if to.Before(from) {
+		return nil, domainErrors.NewAppError(errors.New("the period must not end before it starts"), domainErrors.ValidationError)
+	}
+	end := to.AddDate(0, 0, 1)
+	usages, err := u.supplyRepository.GetUsages(domainSupply.UsageFilter{ClientUserID: clientUserID, From: &from, To: &end})
+	if err != nil {
+		return nil, err
+	}
+	items, err := u.supplyRepository.GetItems()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]domainSupply.Item, len(*items))
+	for _, item := range *items {
+		byID[item.ID] = item
+	}
+	report := domainSupply.Summarize(from, to, *usages, byID)
+	return &report, nil
+}
+
+func (u *SupplyUseCase) notifyLowStock(item *domainSupply.Item) {
+	err := u.notifier.Notify(notification.Message{
+		Role:    domainUser.RoleAdmin,
+		Subject: "Supply running low",
+		Body:    fmt.Sprintf("%s is down to %d %s (reorder level %d).", item.Name, item.OnHand, item.Unit, item.ReorderLevel),
+		Data: map[string]interface{}{
+			"itemID":       item.ID,
+			"onHand":       item.OnHand,
+			"reorderLevel": item.ReorderLevel,
+		},
+	})
+	if err != nil {
+		u.Logger.Error("Error notifying coordinators of low supply stock", zap.Error(err), zap.String("itemID", item.ID.String()))
+	}
+}
+
+func validateItem(item *domainSupply.Item) error {
+	if strings.TrimSpace(item.Name) == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	if strings.TrimSpace(item.Unit) == "" {
+		return domainErrors.NewAppError(errors.New("unit is required"), domainErrors.ValidationError)
+	}
+	if item.UnitCost < 0 {
+		return domainErrors.NewAppError(errors.New("unit cost must not be negative"), domainErrors.ValidationError)
+	}
+	if item.ReorderLevel < 0 {
+		return domainErrors.NewAppError(errors.New("reorder level must not be negative"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
@@ -0,0 +1,235 @@
+package supply
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainSupply "caregiver/src/domain/supply"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+// mockSupplyRepository keeps the catalog and usage in memory
+type mockSupplyRepository struct {
+	domainSupply.ISupplyRepository
+	items  map[uuid.UUID]*domainSupply.Item
+	usages []domainSupply.Usage
+}
+
+func (m *mockSupplyRepository) CreateItem(newItem *domainSupply.Item) (*domainSupply.Item, error) {
+	item := *newItem
+	m.items[item.ID] = &item
+	return newItem, nil
+}
+
+func (m *mockSupplyRepository) GetItemByID(id uuid.UUID) (*domainSupply.Item, error) {
+	if item, ok := m.items[id]; ok {
+		copied := *item
+		return &copied, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockSupplyRepository) GetItems() (*[]domainSupply.Item, error) {
+	res := []domainSupply.Item{}
+	for _, item := range m.items {
+		res = append(res, *item)
+	}
+	return &res, nil
+}
+
+func (m *mockSupplyRepository) AdjustStock(id uuid.UUID, delta int) (*domainSupply.Item, error) {
+	item, ok := m.items[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	item.OnHand += delta
+	copied := *item
+	return &copied, nil
+}
+
+func (m *mockSupplyRepository) CreateUsage(newUsage *domainSupply.Usage) (*domainSupply.Usage, error) {
+	m.usages = append(m.usages, *newUsage)
+	return newUsage, nil
+}
+
+func (m *mockSupplyRepository) GetUsageByID(id uuid.UUID) (*domainSupply.Usage, error) {
+	for _, usage := range m.usages {
+		if usage.ID == id {
+			return &usage, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockSupplyRepository) GetUsages(filter domainSupply.UsageFilter) (*[]domainSupply.Usage, error) {
+	res := []domainSupply.Usage{}
+	for _, usage := range m.usages {
+		if filter.ClientUserID != nil && usage.ClientUserID != *filter.ClientUserID {
+			continue
+		}
+		if (filter.From != nil && usage.UsedAt.Before(*filter.From)) || (filter.To != nil && !usage.UsedAt.Before(*filter.To)) {
+			continue
+		}
+		res = append(res, usage)
+	}
+	return &res, nil
+}
+
+func (m *mockSupplyRepository) DeleteUsage(id uuid.UUID) error {
+	for i, usage := range m.usages {
+		if usage.ID == id {
+			m.usages = append(m.usages[:i], m.usages[i+1:]...)
+			return nil
+		}
+	}
+	return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockScheduleRepository returns visits by ID
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockNotifier records the messages sent
+type mockNotifier struct {
+	messages []notification.Message
+}
+
+func (m *mockNotifier) Notify(message notification.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func setupTestSupplyUseCase(t *testing.T) (ISupplyUseCase, *mockSupplyRepository, *mockScheduleRepository, *mockNotifier) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	supplies := &mockSupplyRepository{items: make(map[uuid.UUID]*domainSupply.Item)}
+	schedules := &mockScheduleRepository{}
+	notifier := &mockNotifier{}
+	return NewSupplyUseCase(supplies, schedules, notifier, loggerInstance), supplies, schedules, notifier
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func startedVisit(schedules *mockScheduleRepository, clientID uuid.UUID, at time.Time) uuid.UUID {
+	s := domainSchedule.Schedule{ID: uuid.New(), ClientUserID: clientID, AssignedUserID: uuid.New(), VisitStatus: "in_progress", CheckinTime: &at}
+	schedules.schedules = append(schedules.schedules, s)
+	return s.ID
+}
+
+func TestLogUsage(t *testing.T) {
+	useCase, supplies, schedules, notifier := setupTestSupplyUseCase(t)
+	gloves, err := useCase.CreateItem(&domainSupply.Item{Name: "Nitrile gloves", Unit: "pair", UnitCost: 0.25, OnHand: 12, ReorderLevel: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clientID := uuid.New()
+	checkin := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	visitID := startedVisit(schedules, clientID, checkin)
+
+	t.Run("Visit not started", func(t *testing.T) {
+		upcoming := domainSchedule.Schedule{ID: uuid.New(), VisitStatus: "upcoming"}
+		schedules.schedules = append(schedules.schedules, upcoming)
+		if _, err := useCase.LogUsage(&domainSupply.Usage{ItemID: gloves.ID, ScheduleID: upcoming.ID, Quantity: 1}); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	usage, err := useCase.LogUsage(&domainSupply.Usage{ItemID: gloves.ID, ScheduleID: visitID, Quantity: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.ClientUserID != clientID || usage.UnitCost != 0.25 || !usage.UsedAt.Equal(checkin) {
+		t.Errorf("expected the usage to take the visit's client, time and the item cost, got %+v", usage)
+	}
+	if len(notifier.messages) != 0 {
+		t.Errorf("expected no alert above the reorder level, got %+v", notifier.messages)
+	}
+
+	if _, err := useCase.LogUsage(&domainSupply.Usage{ItemID: gloves.ID, ScheduleID: visitID, Quantity: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.LogUsage(&domainSupply.Usage{ItemID: gloves.ID, ScheduleID: visitID, Quantity: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if supplies.items[gloves.ID].OnHand != 7 {
+		t.Errorf("expected 7 left in stock, got %d", supplies.items[gloves.ID].OnHand)
+	}
+	if len(notifier.messages) != 1 {
+		t.Errorf("expected a single low-stock alert when crossing the reorder level, got %d", len(notifier.messages))
+	}
+
+	if err := useCase.DeleteUsage(usage.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if supplies.items[gloves.ID].OnHand != 8 {
+		t.Errorf("expected the deleted usage back in stock, got %d", supplies.items[gloves.ID].OnHand)
+	}
+}
+
+func TestConsumptionReport(t *testing.T) {
+	useCase, _, schedules, _ := setupTestSupplyUseCase(t)
+	gloves, _ := useCase.CreateItem(&domainSupply.Item{Name: "Nitrile gloves", Unit: "pair", UnitCost: 0.25, OnHand: 100})
+	kits, _ := useCase.CreateItem(&domainSupply.Item{Name: "Wound kit", Unit: "kit", UnitCost: 12.5, OnHand: 10})
+	clientID := uuid.New()
+	june := startedVisit(schedules, clientID, time.Date(2025, 6, 30, 22, 0, 0, 0, time.UTC))
+	july := startedVisit(schedules, clientID, time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC))
+	other := startedVisit(schedules, uuid.New(), time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC))
+
+	for _, usage := range []domainSupply.Usage{
+		{ItemID: gloves.ID, ScheduleID: june, Quantity: 4},
+		{ItemID: kits.ID, ScheduleID: june, Quantity: 1},
+		{ItemID: gloves.ID, ScheduleID: june, Quantity: 2},
+		{ItemID: kits.ID, ScheduleID: july, Quantity: 3},
+		{ItemID: gloves.ID, ScheduleID: other, Quantity: 10},
+	} {
+		usage := usage
+		if _, err := useCase.LogUsage(&usage); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	report, err := useCase.ConsumptionReport(&clientID, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Clients) != 1 || report.TotalCost != 14 {
+		t.Fatalf("expected one client costing 14.00, got %+v", report)
+	}
+	lines := report.Clients[0].Lines
+	if len(lines) != 2 || lines[0].Name != "Nitrile gloves" || lines[0].Quantity != 6 || lines[0].Cost != 1.5 || lines[1].Quantity != 1 || lines[1].Cost != 12.5 {
+		t.Errorf("unexpected lines: %+v", lines)
+	}
+
+	all, err := useCase.ConsumptionReport(nil, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all.Clients) != 2 || all.TotalCost != 16.5 {
+		t.Errorf("expected both clients costing 16.50, got %+v", all)
+	}
+}
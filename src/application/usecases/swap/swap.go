@@ -0,0 +1,326 @@
+package swap
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainSwap "caregiver/src/domain/swap"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ISwapUseCase interface {
+	// RequestSwap offers the caller's visit to other caregivers.
+	RequestSwap(scheduleID uuid.UUID, reason string, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error)
+	// GetOpenSwaps lists the pending requests the caller could accept.
+	GetOpenSwaps(callerID *uuid.UUID, now time.Time) (*[]domainSwap.Request, error)
+	// GetSwaps lists the requests in a status for admins; an empty status
+	// lists the ones awaiting approval.
+	GetSwaps(status string, callerID *uuid.UUID) (*[]domainSwap.Request, error)
+	GetSwap(id uuid.UUID) (*domainSwap.Request, error)
+	GetHistory(scheduleID uuid.UUID) (*[]domainSwap.Request, error)
+	AcceptSwap(id uuid.UUID, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error)
+	// ApproveSwap reassigns the visit to the caregiver who accepted.
+	ApproveSwap(id uuid.UUID, note string, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error)
+	RejectSwap(id uuid.UUID, note string, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error)
+	CancelSwap(id uuid.UUID, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error)
+}
+
+// ScheduleUpdater reassigns the visit once a swap is approved, running the
+// usual schedule checks.
+type ScheduleUpdater interface {
+	UpdateSchedule(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error)
+}
+
+// EligibilityCheck decides whether a caregiver may take a visit, e.g. the
+// schedule validators for availability, leave and screening. An error makes
+// the caregiver ineligible.
+type EligibilityCheck interface {
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+}
+
+type SwapUseCase struct {
+	swapRepository     domainSwap.ISwapRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	scheduleUpdater    ScheduleUpdater
+	checks             []EligibilityCheck
+	Logger             *logger.Logger
+}
+
+type Option func(*SwapUseCase)
+
+func WithEligibilityChecks(checks ...EligibilityCheck) Option {
+	return func(u *SwapUseCase) {
+		u.checks = append(u.checks, checks...)
+	}
+}
+
+func NewSwapUseCase(swapRepository domainSwap.ISwapRepository, scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, scheduleUpdater ScheduleUpdater, loggerInstance *logger.Logger, opts ...Option) ISwapUseCase {
+	u := &SwapUseCase{
+		swapRepository:     swapRepository,
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		scheduleUpdater:    scheduleUpdater,
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+func (u *SwapUseCase) RequestSwap(scheduleID uuid.UUID, reason string, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error) {
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if callerID != nil && *callerID != schedule.AssignedUserID {
+		return nil, domainErrors.NewAppError(errors.New("only the visit's caregiver can ask to swap it"), domainErrors.NotAuthorized)
+	}
+	if err := swappable(schedule, now); err != nil {
+		return nil, err
+	}
+	history, err := u.swapRepository.GetBySchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range *history {
+		if request.Open() {
+			return nil, domainErrors.NewAppError(errors.New("the visit already has an open swap request"), domainErrors.Conflict)
+		}
+	}
+	u.Logger.Info("Requesting visit swap", zap.String("scheduleID", scheduleID.String()), zap.String("fromUserID", schedule.AssignedUserID.String()))
+	return u.swapRepository.Create(&domainSwap.Request{
+		ScheduleID: scheduleID,
+		FromUserID: schedule.AssignedUserID,
+		Reason:     strings.TrimSpace(reason),
+		Status:     domainSwap.StatusPending,
+	})
+}
+
+func (u *SwapUseCase) GetOpenSwaps(callerID *uuid.UUID, now time.Time) (*[]domainSwap.Request, error) {
+	pending, err := u.swapRepository.GetByStatus(domainSwap.StatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if callerID == nil {
+		return pending, nil
+	}
+	caller, err := u.userRepository.GetByID(*callerID)
+	if err != nil {
+		return nil, err
+	}
+	open := []domainSwap.Request{}
+	for _, request := range *pending {
+		schedule, err := u.scheduleRepository.GetScheduleByID(request.ScheduleID)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if u.eligible(&request, schedule, caller, now) == nil {
+			open = append(open, request)
+		}
+	}
+	return &open, nil
+}
+
+func (u *SwapUseCase) GetSwaps(status string, callerID *uuid.UUID) (*[]domainSwap.Request, error) {
+	if err := u.requireAdmin(callerID, "list swap requests"); err != nil {
+		return nil, err
+	}
+	if status == "" {
+		status = domainSwap.StatusAccepted
+	}
+	return u.swapRepository.GetByStatus(status)
+}
+
+func (u *SwapUseCase) GetSwap(id uuid.UUID) (*domainSwap.Request, error) {
+	return u.swapRepository.GetByID(id)
+}
+
+func (u *SwapUseCase) GetHistory(scheduleID uuid.UUID) (*[]domainSwap.Request, error) {
+	if _, err := u.scheduleRepository.GetScheduleByID(scheduleID); err != nil {
+		return nil, err
+	}
+	return u.swapRepository.GetBySchedule(scheduleID)
+}
+
+func (u *SwapUseCase) AcceptSwap(id uuid.UUID, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error) {
+	if callerID == nil {
+		return nil, domainErrors.NewAppError(errors.New("a signed-in caregiver is required to accept a swap"), domainErrors.ValidationError)
+	}
+	request, err := u.swapRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domainSwap.StatusPending {
+		return nil, domainErrors.NewAppError(errors.New("the swap request is no longer open to caregivers"), domainErrors.Conflict)
+	}
+	schedule, err := u.scheduleRepository.GetScheduleByID(request.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := u.userRepository.GetByID(*callerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.eligible(request, schedule, caller, now); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Accepting visit swap", zap.String("id", id.String()), zap.String("toUserID", callerID.String()))
+	return u.transition(id, []string{domainSwap.StatusPending}, map[string]interface{}{
+		"status":      domainSwap.StatusAccepted,
+		"to_user_id":  *callerID,
+		"accepted_at": now,
+	})
+}
+
+func (u *SwapUseCase) ApproveSwap(id uuid.UUID, note string, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error) {
+	if err := u.requireAdmin(callerID, "approve swaps"); err != nil {
+		return nil, err
+	}
+	request, err := u.swapRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domainSwap.StatusAccepted || request.ToUserID == nil {
+		return nil, domainErrors.NewAppError(errors.New("only swaps accepted by a caregiver can be approved"), domainErrors.Conflict)
+	}
+	schedule, err := u.scheduleRepository.GetScheduleByID(request.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.AssignedUserID != request.FromUserID {
+		return nil, domainErrors.NewAppError(errors.New("the visit has been reassigned since the swap was requested"), domainErrors.Conflict)
+	}
+	if err := swappable(schedule, now); err != nil {
+		return nil, err
+	}
+
+	// Claim the request first so a concurrent rejection cannot leave a
+	// reassigned visit behind a rejected swap.
+	approved, err := u.transition(id, []string{domainSwap.StatusAccepted}, map[string]interface{}{
+		"status":        domainSwap.StatusApproved,
+		"decided_by":    callerID,
+		"decided_at":    now,
+		"decision_note": strings.TrimSpace(note),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.scheduleUpdater.UpdateSchedule(request.ScheduleID, map[string]interface{}{"assigned_user_id": *request.ToUserID}); err != nil {
+		u.Logger.Warn("Swap reassignment rejected", zap.Error(err), zap.String("id", id.String()))
+		if _, revertErr := u.swapRepository.Transition(id, []string{domainSwap.StatusApproved}, map[string]interface{}{
+			"status":        domainSwap.StatusAccepted,
+			"decided_by":    nil,
+			"decided_at":    nil,
+			"decision_note": "",
+		}); revertErr != nil {
+			u.Logger.Error("Error reverting swap approval", zap.Error(revertErr), zap.String("id", id.String()))
+		}
+		return nil, err
+	}
+	u.Logger.Info("Visit swap approved", zap.String("id", id.String()), zap.String("scheduleID", request.ScheduleID.String()))
+	return approved, nil
+}
+
+func (u *SwapUseCase) RejectSwap(id uuid.UUID, note string, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error) {
+	if err := u.requireAdmin(callerID, "reject swaps"); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Rejecting visit swap", zap.String("id", id.String()))
+	return u.transition(id, []string{domainSwap.StatusPending, domainSwap.StatusAccepted}, map[string]interface{}{
+		"status":        domainSwap.StatusRejected,
+		"decided_by":    callerID,
+		"decided_at":    now,
+		"decision_note": strings.TrimSpace(note),
+	})
+}
+
+func (u *SwapUseCase) CancelSwap(id uuid.UUID, callerID *uuid.UUID, now time.Time) (*domainSwap.Request, error) {
+	request, err := u.swapRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if callerID != nil && *callerID != request.FromUserID {
+		return nil, domainErrors.NewAppError(errors.New("only the requesting caregiver can cancel a swap"), domainErrors.NotAuthorized)
+	}
+	u.Logger.Info("Cancelling visit swap", zap.String("id", id.String()))
+	return u.transition(id, []string{domainSwap.StatusPending, domainSwap.StatusAccepted}, map[string]interface{}{
+		"status":     domainSwap.StatusCancelled,
+		"decided_at": now,
+	})
+}
+
+// eligible checks that the caregiver could take over the requested visit.
+func (u *SwapUseCase) eligible(request *domainSwap.Request, schedule *domainSchedule.Schedule, caregiver *domainUser.User, now time.Time) error {
+	if caregiver.ID == request.FromUserID {
+		return domainErrors.NewAppError(errors.New("caregivers cannot accept their own swap request"), domainErrors.ValidationError)
+	}
+	if caregiver.Role != domainUser.RoleCaregiver || !caregiver.Status {
+		return domainErrors.NewAppError(errors.New("only active caregivers can accept swaps"), domainErrors.NotAuthorized)
+	}
+	if schedule.AssignedUserID != request.FromUserID {
+		return domainErrors.NewAppError(errors.New("the visit has been reassigned since the swap was requested"), domainErrors.Conflict)
+	}
+	if err := swappable(schedule, now); err != nil {
+		return err
+	}
+	candidate := *schedule
+	candidate.AssignedUserID = caregiver.ID
+	for _, check := range u.checks {
+		if _, err := check.ValidateSchedule(&candidate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *SwapUseCase) transition(id uuid.UUID, from []string, updates map[string]interface{}) (*domainSwap.Request, error) {
+	request, err := u.swapRepository.Transition(id, from, updates)
+	if isConflict(err) {
+		return nil, domainErrors.NewAppError(errors.New("the swap request has already moved on"), domainErrors.Conflict)
+	}
+	return request, err
+}
+
+func (u *SwapUseCase) requireAdmin(callerID *uuid.UUID, action string) error {
+	if callerID == nil {
+		return nil
+	}
+	caller, err := u.userRepository.GetByID(*callerID)
+	if err != nil {
+		return err
+	}
+	if caller.Role != domainUser.RoleAdmin {
+		return domainErrors.NewAppError(errors.New("only admins can "+action), domainErrors.NotAuthorized)
+	}
+	return nil
+}
+
+// swappable checks that the visit has not started yet.
+func swappable(schedule *domainSchedule.Schedule, now time.Time) error {
+	if schedule.VisitStatus != "upcoming" || !schedule.ScheduledSlot.From.After(now) {
+		return domainErrors.NewAppError(errors.New("only upcoming visits that have not started can be swapped"), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
+
+func isConflict(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.Conflict
+}
@@ -0,0 +1,213 @@
+package swap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainSwap "caregiver/src/domain/swap"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps swap requests in memory
+type memoryRepository struct {
+	requests []domainSwap.Request
+}
+
+func (m *memoryRepository) Create(request *domainSwap.Request) (*domainSwap.Request, error) {
+	request.ID = uuid.New()
+	m.requests = append(m.requests, *request)
+	return request, nil
+}
+
+func (m *memoryRepository) GetByID(id uuid.UUID) (*domainSwap.Request, error) {
+	for i := range m.requests {
+		if m.requests[i].ID == id {
+			request := m.requests[i]
+			return &request, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *memoryRepository) GetBySchedule(scheduleID uuid.UUID) (*[]domainSwap.Request, error) {
+	res := []domainSwap.Request{}
+	for _, request := range m.requests {
+		if request.ScheduleID == scheduleID {
+			res = append([]domainSwap.Request{request}, res...)
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) GetByStatus(statuses ...string) (*[]domainSwap.Request, error) {
+	res := []domainSwap.Request{}
+	for _, request := range m.requests {
+		for _, status := range statuses {
+			if request.Status == status {
+				res = append(res, request)
+			}
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) Transition(id uuid.UUID, from []string, updates map[string]interface{}) (*domainSwap.Request, error) {
+	for i := range m.requests {
+		request := &m.requests[i]
+		if request.ID != id {
+			continue
+		}
+		allowed := false
+		for _, status := range from {
+			allowed = allowed || request.Status == status
+		}
+		if !allowed {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.Conflict)
+		}
+		request.Status = updates["status"].(string)
+		if v, ok := updates["to_user_id"].(uuid.UUID); ok {
+			request.ToUserID = &v
+		}
+		return m.GetByID(id)
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockScheduleRepository serves a fixed set of visits
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			schedule := m.schedules[i]
+			return &schedule, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// UpdateSchedule reassigns the visit.
+func (m *mockScheduleRepository) UpdateSchedule(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			m.schedules[i].AssignedUserID = updates["assigned_user_id"].(uuid.UUID)
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockUserRepository knows only the users it was given
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return user, nil
+}
+
+// onLeave rejects visits for one caregiver
+type onLeave struct {
+	caregiverID uuid.UUID
+}
+
+func (o *onLeave) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.AssignedUserID == o.caregiverID {
+		return nil, domainErrors.NewAppError(errors.New("caregiver is on leave"), domainErrors.ValidationError)
+	}
+	return nil, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestSwapWorkflow(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	admin := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin, Status: true}
+	owner := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	colleague := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	away := &domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, Status: true}
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{admin.ID: admin, owner.ID: owner, colleague.ID: colleague, away.ID: away}}
+	visit := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: owner.ID, VisitStatus: "upcoming", ScheduledSlot: domainSchedule.ScheduledSlot{From: now.Add(24 * time.Hour)}}
+	started := domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: owner.ID, VisitStatus: "in_progress", ScheduledSlot: domainSchedule.ScheduledSlot{From: now.Add(-time.Hour)}}
+	schedules := &mockScheduleRepository{schedules: []domainSchedule.Schedule{visit, started}}
+	useCase := NewSwapUseCase(&memoryRepository{}, schedules, users, schedules, loggerInstance, WithEligibilityChecks(&onLeave{caregiverID: away.ID}))
+
+	if _, err := useCase.RequestSwap(visit.ID, "family emergency", &colleague.ID, now); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected another caregiver's request to be refused, got %v", err)
+	}
+	if _, err := useCase.RequestSwap(started.ID, "", &owner.ID, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a started visit to be refused, got %v", err)
+	}
+	request, err := useCase.RequestSwap(visit.ID, "family emergency", &owner.ID, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.RequestSwap(visit.ID, "again", &owner.ID, now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a second open request to conflict, got %v", err)
+	}
+
+	open, err := useCase.GetOpenSwaps(&colleague.ID, now)
+	if err != nil || len(*open) != 1 {
+		t.Fatalf("expected the colleague to see the request, got %v, %v", open, err)
+	}
+	if open, _ := useCase.GetOpenSwaps(&away.ID, now); len(*open) != 0 {
+		t.Errorf("expected a caregiver on leave not to see the request, got %v", open)
+	}
+	if _, err := useCase.AcceptSwap(request.ID, &owner.ID, now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected the requester not to accept, got %v", err)
+	}
+	if _, err := useCase.ApproveSwap(request.ID, "", &admin.ID, now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected an unaccepted swap not to be approved, got %v", err)
+	}
+	if _, err := useCase.AcceptSwap(request.ID, &colleague.ID, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.AcceptSwap(request.ID, &away.ID, now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected a second acceptance to conflict, got %v", err)
+	}
+	if _, err := useCase.ApproveSwap(request.ID, "", &colleague.ID, now); errorType(err) != domainErrors.NotAuthorized {
+		t.Errorf("expected caregivers not to approve, got %v", err)
+	}
+
+	approved, err := useCase.ApproveSwap(request.ID, "ok", &admin.ID, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if approved.Status != domainSwap.StatusApproved || *approved.ToUserID != colleague.ID {
+		t.Errorf("unexpected approved request %+v", approved)
+	}
+	if schedules.schedules[0].AssignedUserID != colleague.ID {
+		t.Error("expected the visit to be reassigned to the colleague")
+	}
+	history, _ := useCase.GetHistory(visit.ID)
+	if len(*history) != 1 || (*history)[0].FromUserID != owner.ID {
+		t.Errorf("expected the swap in the visit's history, got %v", history)
+	}
+	if _, err := useCase.CancelSwap(request.ID, &owner.ID, now); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected an approved swap not to be cancelled, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package team
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	complianceUseCase "caregiver/src/application/usecases/compliance"
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	domainCompliance "caregiver/src/domain/compliance"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTeam "caregiver/src/domain/team"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ITeamUseCase interface {
+	Create(newTeam *domainTeam.Team) (*domainTeam.Team, error)
+	GetByID(id uuid.UUID) (*domainTeam.Team, error)
+	GetAll() (*[]domainTeam.Team, error)
+	GetBySupervisor(supervisorUserID uuid.UUID) (*[]domainTeam.Team, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*domainTeam.Team, error)
+	Delete(id uuid.UUID) error
+	AddMember(teamID, userID uuid.UUID) (*domainTeam.Team, error)
+	RemoveMember(teamID, userID uuid.UUID) (*domainTeam.Team, error)
+	Overview(teamID, supervisorUserID uuid.UUID, from, to time.Time, page, pageSize int) (*domainTeam.Overview, error)
+}
+
+type TeamUseCase struct {
+	teamRepository    domainTeam.ITeamRepository
+	userRepository    domainUser.IUserRepository
+	scheduleUseCase   scheduleUseCase.IScheduleUseCase
+	complianceUseCase complianceUseCase.IComplianceUseCase
+	Logger            *logger.Logger
+}
+
+func NewTeamUseCase(teamRepository domainTeam.ITeamRepository, userRepository domainUser.IUserRepository, scheduleUseCase scheduleUseCase.IScheduleUseCase, complianceUseCase complianceUseCase.IComplianceUseCase, loggerInstance *logger.Logger) ITeamUseCase {
+	return &TeamUseCase{
+		teamRepository:    teamRepository,
+		userRepository:    userRepository,
+		scheduleUseCase:   scheduleUseCase,
+		complianceUseCase: complianceUseCase,
+		Logger:            loggerInstance,
+	}
+}
+
+func (u *TeamUseCase) Create(newTeam *domainTeam.Team) (*domainTeam.Team, error) {
+	u.Logger.Info("Creating team", zap.String("name", newTeam.Name))
+	newTeam.Name = strings.TrimSpace(newTeam.Name)
+	newTeam.Region = strings.TrimSpace(newTeam.Region)
+	if err := u.validateTeam(newTeam); err != nil {
+		return nil, err
+	}
+	newTeam.ID = uuid.New()
+	return u.teamRepository.Create(newTeam)
+}
+
+func (u *TeamUseCase) GetByID(id uuid.UUID) (*domainTeam.Team, error) {
+	u.Logger.Info("Getting team by ID", zap.String("id", id.String()))
+	return u.teamRepository.GetByID(id)
+}
+
+func (u *TeamUseCase) GetAll() (*[]domainTeam.Team, error) {
+	u.Logger.Info("Getting all teams")
+	return u.teamRepository.GetAll()
+}
+
+func (u *TeamUseCase) GetBySupervisor(supervisorUserID uuid.UUID) (*[]domainTeam.Team, error) {
+	u.Logger.Info("Getting teams for supervisor", zap.String("supervisorUserID", supervisorUserID.String()))
+	return u.teamRepository.GetBySupervisor(supervisorUserID)
+}
+
+func (u *TeamUseCase) Update(id uuid.UUID, updates map[string]interface{}) (*domainTeam.Team, error) {
+	u.Logger.Info("Updating team", zap.String("id", id.String()))
+
+	existing, err := u.teamRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["name"].(string); ok {
+		candidate.Name = strings.TrimSpace(v)
+		updates["name"] = candidate.Name
+	}
+	if v, ok := updates["region"].(string); ok {
+		candidate.Region = strings.TrimSpace(v)
+		updates["region"] = candidate.Region
+	}
+	if v, ok := updates["supervisor_user_id"].(uuid.UUID); ok {
+		candidate.SupervisorUserID = v
+	}
+	if err := u.validateTeam(&candidate); err != nil {
+		return nil, err
+	}
+	return u.teamRepository.Update(id, updates)
+}
+
+func (u *TeamUseCase) Delete(id uuid.UUID) error {
+	u.Logger.Info("Deleting team", zap.String("id", id.String()))
+	return u.teamRepository.Delete(id)
+}
+
+// AddMember puts a caregiver on the team. Caregivers belong to at most one
+// team and must be removed from their current team first.
+func (u *TeamUseCase) AddMember(teamID, userID uuid.UUID) (*domainTeam.Team, error) {
+	u.Logger.Info("Adding team member", zap.String("teamID", teamID.String()), zap.String("userID", userID.String()))
+
+	if _, err := u.teamRepository.GetByID(teamID); err != nil {
+		return nil, err
+	}
+	caregiver, err := u.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("caregiver not found"), domainErrors.NotFound)
+	}
+	if caregiver.Role != domainUser.RoleCaregiver {
+		return nil, domainErrors.NewAppError(errors.New("only caregivers can be team members"), domainErrors.ValidationError)
+	}
+	current, err := u.teamRepository.GetByMember(userID)
+	if err == nil {
+		return nil, domainErrors.NewAppError(errors.New("caregiver already belongs to team "+current.Name), domainErrors.Conflict)
+	}
+	var appErr *domainErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Type != domainErrors.NotFound {
+		return nil, err
+	}
+
+	if err := u.teamRepository.AddMember(teamID, userID); err != nil {
+		return nil, err
+	}
+	return u.teamRepository.GetByID(teamID)
+}
+
+func (u *TeamUseCase) RemoveMember(teamID, userID uuid.UUID) (*domainTeam.Team, error) {
+	u.Logger.Info("Removing team member", zap.String("teamID", teamID.String()), zap.String("userID", userID.String()))
+	if err := u.teamRepository.RemoveMember(teamID, userID); err != nil {
+		return nil, err
+	}
+	return u.teamRepository.GetByID(teamID)
+}
+
+// Overview returns the team's visits in [from, to) and the compliance
+// exceptions detected in that range. Only the team's supervisor may see it.
+func (u *TeamUseCase) Overview(teamID, supervisorUserID uuid.UUID, from, to time.Time, page, pageSize int) (*domainTeam.Overview, error) {
+	u.Logger.Info("Building team overview", zap.String("teamID", teamID.String()), zap.String("supervisorUserID", supervisorUserID.String()))
+
+	team, err := u.teamRepository.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team.SupervisorUserID != supervisorUserID {
+		u.Logger.Warn("Team overview requested by non-supervisor", zap.String("teamID", teamID.String()), zap.String("userID", supervisorUserID.String()))
+		return nil, domainErrors.NewAppError(errors.New("only the team's supervisor can view its overview"), domainErrors.NotAuthorized)
+	}
+
+	visits, _, err := u.scheduleUseCase.SearchSchedules(domainSchedule.SearchQuery{
+		TeamIDs:  []uuid.UUID{teamID},
+		From:     &from,
+		To:       &to,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	report, err := u.complianceUseCase.Report(from, to, domainCompliance.ReportFilter{TeamIDs: []uuid.UUID{teamID}})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domainTeam.Overview{
+		Team:       *team,
+		From:       from,
+		To:         to,
+		Visits:     *visits,
+		Exceptions: report.Exceptions,
+	}, nil
+}
+
+func (u *TeamUseCase) validateTeam(team *domainTeam.Team) error {
+	if team.Name == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	supervisor, err := u.userRepository.GetByID(team.SupervisorUserID)
+	if err != nil {
+		return domainErrors.NewAppError(errors.New("supervisor not found"), domainErrors.NotFound)
+	}
+	if supervisor.Role == domainUser.RoleClient {
+		return domainErrors.NewAppError(errors.New("clients cannot supervise teams"), domainErrors.ValidationError)
+	}
+
+	teams, err := u.teamRepository.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, other := range *teams {
+		if other.ID != team.ID && strings.EqualFold(other.Name, team.Name) {
+			return domainErrors.NewAppError(errors.New("a team with this name already exists"), domainErrors.Conflict)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package team
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	complianceUseCase "caregiver/src/application/usecases/compliance"
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	domainCompliance "caregiver/src/domain/compliance"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTeam "caregiver/src/domain/team"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type mockTeamRepository struct {
+	domainTeam.ITeamRepository
+	teams map[uuid.UUID]*domainTeam.Team
+}
+
+func (m *mockTeamRepository) Create(newTeam *domainTeam.Team) (*domainTeam.Team, error) {
+	m.teams[newTeam.ID] = newTeam
+	return newTeam, nil
+}
+
+func (m *mockTeamRepository) GetByID(id uuid.UUID) (*domainTeam.Team, error) {
+	team, ok := m.teams[id]
+	if !ok {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	copied := *team
+	return &copied, nil
+}
+
+func (m *mockTeamRepository) GetAll() (*[]domainTeam.Team, error) {
+	teams := []domainTeam.Team{}
+	for _, team := range m.teams {
+		teams = append(teams, *team)
+	}
+	return &teams, nil
+}
+
+func (m *mockTeamRepository) GetByMember(userID uuid.UUID) (*domainTeam.Team, error) {
+	for _, team := range m.teams {
+		if team.HasMember(userID) {
+			return team, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockTeamRepository) AddMember(teamID, userID uuid.UUID) error {
+	m.teams[teamID].MemberUserIDs = append(m.teams[teamID].MemberUserIDs, userID)
+	return nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &user, nil
+}
+
+type mockScheduleUseCase struct {
+	scheduleUseCase.IScheduleUseCase
+	query domainSchedule.SearchQuery
+}
+
+func (m *mockScheduleUseCase) SearchSchedules(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error) {
+	m.query = query
+	return &domainSchedule.SearchResultSchedule{Data: &[]domainSchedule.Schedule{{ID: uuid.New()}}, Total: 1}, &[]domainUser.User{}, nil
+}
+
+type mockComplianceUseCase struct {
+	complianceUseCase.IComplianceUseCase
+	filter domainCompliance.ReportFilter
+}
+
+func (m *mockComplianceUseCase) Report(from, to time.Time, filter domainCompliance.ReportFilter) (*domainCompliance.Report, error) {
+	m.filter = filter
+	return &domainCompliance.Report{Exceptions: []domainCompliance.Exception{{ID: uuid.New()}}}, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestTeamMembership(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	supervisor := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin}
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver}
+	client := domainUser.User{ID: uuid.New(), Role: domainUser.RoleClient}
+	users := &mockUserRepository{users: map[uuid.UUID]domainUser.User{supervisor.ID: supervisor, caregiver.ID: caregiver, client.ID: client}}
+	teams := &mockTeamRepository{teams: make(map[uuid.UUID]*domainTeam.Team)}
+	schedules := &mockScheduleUseCase{}
+	compliance := &mockComplianceUseCase{}
+	uc := NewTeamUseCase(teams, users, schedules, compliance, loggerInstance)
+
+	north, err := uc.Create(&domainTeam.Team{Name: " North ", Region: "Hamburg", SupervisorUserID: supervisor.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	south, err := uc.Create(&domainTeam.Team{Name: "South", SupervisorUserID: supervisor.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("Duplicate name", func(t *testing.T) {
+		if _, err := uc.Create(&domainTeam.Team{Name: "north", SupervisorUserID: supervisor.ID}); errorType(err) != domainErrors.Conflict {
+			t.Errorf("expected conflict, got %v", err)
+		}
+	})
+	t.Run("Client supervisor", func(t *testing.T) {
+		if _, err := uc.Create(&domainTeam.Team{Name: "East", SupervisorUserID: client.ID}); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+	t.Run("Client member", func(t *testing.T) {
+		if _, err := uc.AddMember(north.ID, client.ID); errorType(err) != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	team, err := uc.AddMember(north.ID, caregiver.ID)
+	if err != nil || !team.HasMember(caregiver.ID) {
+		t.Fatalf("expected caregiver on team, got %v, %v", team, err)
+	}
+	if _, err := uc.AddMember(south.ID, caregiver.ID); errorType(err) != domainErrors.Conflict {
+		t.Errorf("expected conflict for caregiver on another team, got %v", err)
+	}
+
+	t.Run("Overview", func(t *testing.T) {
+		from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+		if _, err := uc.Overview(north.ID, caregiver.ID, from, to, 1, 10); errorType(err) != domainErrors.NotAuthorized {
+			t.Errorf("expected not authorized for non-supervisor, got %v", err)
+		}
+		overview, err := uc.Overview(north.ID, supervisor.ID, from, to, 1, 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(schedules.query.TeamIDs) != 1 || schedules.query.TeamIDs[0] != north.ID {
+			t.Errorf("expected visits scoped to team, got %v", schedules.query.TeamIDs)
+		}
+		if len(compliance.filter.TeamIDs) != 1 || compliance.filter.TeamIDs[0] != north.ID {
+			t.Errorf("expected exceptions scoped to team, got %v", compliance.filter.TeamIDs)
+		}
+		if len(*overview.Visits.Data) != 1 || len(overview.Exceptions) != 1 {
+			t.Errorf("unexpected overview %+v", overview)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package timesheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReport "caregiver/src/domain/report"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTimesheet "caregiver/src/domain/timesheet"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxDays caps the range of a timesheet report.
+const MaxDays = 62
+
+const dateLayout = "2006-01-02"
+
+// csvColumns is the payroll import layout: one earnings line per caregiver
+// and pay code.
+var csvColumns = []string{"employee_id", "last_name", "first_name", "email", "period_start", "period_end", "pay_code", "hours"}
+
+type ITimesheetUseCase interface {
+	Report(from, to time.Time, caregiverUserID *uuid.UUID) (*domainTimesheet.Report, error)
+	Export(from, to time.Time, caregiverUserID *uuid.UUID) (*domainReport.Export, error)
+}
+
+type TimesheetUseCase struct {
+	scheduleRepository domainSchedule.IScheduleRepository
+	userRepository     domainUser.IUserRepository
+	policy             domainTimesheet.Policy
+	Logger             *logger.Logger
+}
+
+type Option func(*TimesheetUseCase)
+
+// WithPolicy sets the rounding and overtime rules; DefaultPolicy otherwise.
+func WithPolicy(policy domainTimesheet.Policy) Option {
+	return func(u *TimesheetUseCase) {
+		u.policy = policy
+	}
+}
+
+func NewTimesheetUseCase(scheduleRepository domainSchedule.IScheduleRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger, opts ...Option) ITimesheetUseCase {
+	u := &TimesheetUseCase{
+		scheduleRepository: scheduleRepository,
+		userRepository:     userRepository,
+		policy:             domainTimesheet.DefaultPolicy,
+		Logger:             loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// Report builds the timesheets of every caregiver, or just the given one,
+// who checked in to a visit in the days [from, to), ordered by name.
+func (u *TimesheetUseCase) Report(from, to time.Time, caregiverUserID *uuid.UUID) (*domainTimesheet.Report, error) {
+	from, to = domainTimesheet.DayOf(from), domainTimesheet.DayOf(to)
+	if !to.After(from) {
+		return nil, domainErrors.NewAppError(errors.New("to must be after from"), domainErrors.ValidationError)
+	}
+	if to.Sub(from) > MaxDays*24*time.Hour {
+		return nil, domainErrors.NewAppError(fmt.Errorf("timesheets cover at most %d days", MaxDays), domainErrors.ValidationError)
+	}
+	u.Logger.Info("Building timesheets", zap.Time("from", from), zap.Time("to", to))
+
+	var caregiverIDs []uuid.UUID
+	if caregiverUserID != nil {
+		caregiverIDs = []uuid.UUID{*caregiverUserID}
+	}
+	// Earlier days of the first week count toward weekly overtime, and a
+	// visit checked in the day before may have a segment in range.
+	schedules, err := u.scheduleRepository.GetCheckedInSchedulesBetween(domainTimesheet.WeekStart(from).AddDate(0, 0, -1), to, caregiverIDs)
+	if err != nil {
+		return nil, err
+	}
+	byCaregiver := map[uuid.UUID][]domainSchedule.Schedule{}
+	var order []uuid.UUID
+	for _, schedule := range *schedules {
+		if _, ok := byCaregiver[schedule.AssignedUserID]; !ok {
+			order = append(order, schedule.AssignedUserID)
+		}
+		byCaregiver[schedule.AssignedUserID] = append(byCaregiver[schedule.AssignedUserID], schedule)
+	}
+
+	report := &domainTimesheet.Report{From: from, To: to, Policy: u.policy, Timesheets: []domainTimesheet.Timesheet{}}
+	for _, id := range order {
+		timesheet := domainTimesheet.Build(id, byCaregiver[id], from, to, u.policy)
+		if len(timesheet.Days) == 0 {
+			continue
+		}
+		if caregiver, err := u.userRepository.GetByID(id); err == nil {
+			timesheet.FirstName, timesheet.LastName, timesheet.Email = caregiver.FirstName, caregiver.LastName, caregiver.Email
+		} else {
+			u.Logger.Warn("Caregiver not found for timesheet", zap.Error(err), zap.String("caregiverUserID", id.String()))
+		}
+		report.Timesheets = append(report.Timesheets, timesheet)
+	}
+	sort.SliceStable(report.Timesheets, func(i, j int) bool {
+		a, b := report.Timesheets[i], report.Timesheets[j]
+		if !strings.EqualFold(a.LastName, b.LastName) {
+			return strings.ToLower(a.LastName) < strings.ToLower(b.LastName)
+		}
+		return strings.ToLower(a.FirstName) < strings.ToLower(b.FirstName)
+	})
+	return report, nil
+}
+
+// Export renders the report as CSV for import into payroll, with a line
+// for each caregiver's regular and overtime hours. Lines without hours are
+// left out.
+func (u *TimesheetUseCase) Export(from, to time.Time, caregiverUserID *uuid.UUID) (*domainReport.Export, error) {
+	report, err := u.Report(from, to, caregiverUserID)
+	if err != nil {
+		return nil, err
+	}
+	periodStart, periodEnd := report.From.Format(dateLayout), report.To.AddDate(0, 0, -1).Format(dateLayout)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write(csvColumns)
+	rows := 0
+	for _, timesheet := range report.Timesheets {
+		for _, line := range []struct {
+			payCode string
+			minutes int
+		}{
+			{domainTimesheet.PayCodeRegular, timesheet.RegularMinutes},
+			{domainTimesheet.PayCodeOvertime, timesheet.OvertimeMinutes},
+		} {
+			if line.minutes == 0 {
+				continue
+			}
+			_ = writer.Write([]string{
+				timesheet.CaregiverUserID.String(),
+				timesheet.LastName,
+				timesheet.FirstName,
+				timesheet.Email,
+				periodStart,
+				periodEnd,
+				line.payCode,
+				strconv.FormatFloat(domainTimesheet.Hours(line.minutes), 'f', 2, 64),
+			})
+			rows++
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		u.Logger.Error("Error rendering timesheets", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return &domainReport.Export{
+		FileName:    fmt.Sprintf("timesheets-%s-%s.csv", periodStart, periodEnd),
+		ContentType: "text/csv; charset=utf-8",
+		Data:        buf.Bytes(),
+		Rows:        rows,
+		From:        report.From,
+		To:          report.To,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package timesheet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTimesheet "caregiver/src/domain/timesheet"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules []domainSchedule.Schedule
+	from      time.Time
+}
+
+func (m *mockScheduleRepository) GetCheckedInSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	m.from = from
+	res := []domainSchedule.Schedule{}
+	for _, s := range m.schedules {
+		if len(assignedUserIDs) > 0 && s.AssignedUserID != assignedUserIDs[0] {
+			continue
+		}
+		if !s.CheckinTime.Before(from) && s.CheckinTime.Before(to) {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &user, nil
+}
+
+func TestTimesheetExport(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	zoe := domainUser.User{ID: uuid.New(), FirstName: "Zoe", LastName: "Adams", Email: "zoe@example.com"}
+	amir := domainUser.User{ID: uuid.New(), FirstName: "Amir", LastName: "Young", Email: "amir@example.com"}
+	monday := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
+	visit := func(caregiver uuid.UUID, start time.Time, duration time.Duration) domainSchedule.Schedule {
+		checkout := start.Add(duration)
+		return domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiver, CheckinTime: &start, CheckoutTime: &checkout}
+	}
+	repo := &mockScheduleRepository{schedules: []domainSchedule.Schedule{
+		visit(amir.ID, monday, 7*time.Hour+50*time.Minute),
+		visit(zoe.ID, monday, 10*time.Hour),
+		visit(zoe.ID, monday.AddDate(0, 0, 1), 6*time.Hour),
+	}}
+	policy := domainTimesheet.Policy{RoundingMinutes: 15, Rounding: domainTimesheet.RoundNearest, DailyOvertime: 8, WeeklyOvertime: 40}
+	useCase := NewTimesheetUseCase(repo, &mockUserRepository{users: map[uuid.UUID]domainUser.User{zoe.ID: zoe, amir.ID: amir}}, loggerInstance, WithPolicy(policy))
+
+	from, to := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	export, err := useCase.Export(from, to, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := strings.Join([]string{
+		"employee_id,last_name,first_name,email,period_start,period_end,pay_code,hours",
+		zoe.ID.String() + ",Adams,Zoe,zoe@example.com,2025-03-03,2025-03-09,REG,14.00",
+		zoe.ID.String() + ",Adams,Zoe,zoe@example.com,2025-03-03,2025-03-09,OT,2.00",
+		amir.ID.String() + ",Young,Amir,amir@example.com,2025-03-03,2025-03-09,REG,7.75",
+	}, "\n") + "\n"
+	if string(export.Data) != expected {
+		t.Errorf("unexpected export\n got: %s\nwant: %s", export.Data, expected)
+	}
+	if export.FileName != "timesheets-2025-03-03-2025-03-09.csv" || export.Rows != 3 {
+		t.Errorf("unexpected export file %s with %d rows", export.FileName, export.Rows)
+	}
+	if !repo.from.Equal(from.AddDate(0, 0, -1)) {
+		t.Errorf("expected visits loaded from the day before the week, got %v", repo.from)
+	}
+
+	report, err := useCase.Report(from, to, &amir.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Timesheets) != 1 || report.Timesheets[0].CaregiverUserID != amir.ID {
+		t.Errorf("expected only Amir's timesheet, got %+v", report.Timesheets)
+	}
+
+	for _, tt := range []struct{ from, to time.Time }{{to, from}, {from, from.AddDate(0, 0, MaxDays+1)}} {
+		if _, err := useCase.Report(tt.from, tt.to, nil); err == nil || err.(*domainErrors.AppError).Type != domainErrors.ValidationError {
+			t.Errorf("%v to %v: expected a validation error, got %v", tt.from, tt.to, err)
+		}
+	}
+}
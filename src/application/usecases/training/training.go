@@ -0,0 +1,316 @@
+package training
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTraining "caregiver/src/domain/training"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ITrainingUseCase interface {
+	CreateCourse(newCourse *domainTraining.Course) (*domainTraining.Course, error)
+	GetCourseByID(id uuid.UUID) (*domainTraining.Course, error)
+	GetCourses() (*[]domainTraining.Course, error)
+	UpdateCourse(id uuid.UUID, updates map[string]interface{}) (*domainTraining.Course, error)
+	DeleteCourse(id uuid.UUID) error
+	RecordCompletion(newCompletion *domainTraining.Completion, now time.Time) (*domainTraining.Completion, error)
+	GetCompletions(filter domainTraining.CompletionFilter) (*[]domainTraining.Completion, error)
+	DeleteCompletion(id uuid.UUID) error
+	GetRequirements(userID uuid.UUID, now time.Time) ([]domainTraining.Requirement, error)
+	OutOfCompliance(role string, now time.Time, window time.Duration) ([]domainTraining.UserCompliance, error)
+	ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error)
+}
+
+type TrainingUseCase struct {
+	trainingRepository domainTraining.ITrainingRepository
+	userRepository     domainUser.IUserRepository
+	Logger             *logger.Logger
+}
+
+func NewTrainingUseCase(trainingRepository domainTraining.ITrainingRepository, userRepository domainUser.IUserRepository, loggerInstance *logger.Logger) ITrainingUseCase {
+	return &TrainingUseCase{
+		trainingRepository: trainingRepository,
+		userRepository:     userRepository,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *TrainingUseCase) CreateCourse(newCourse *domainTraining.Course) (*domainTraining.Course, error) {
+	u.Logger.Info("Creating training course", zap.String("name", newCourse.Name))
+	if err := validateCourse(newCourse); err != nil {
+		return nil, err
+	}
+	newCourse.ID = uuid.New()
+	newCourse.Active = true
+	return u.trainingRepository.CreateCourse(newCourse)
+}
+
+func (u *TrainingUseCase) GetCourseByID(id uuid.UUID) (*domainTraining.Course, error) {
+	return u.trainingRepository.GetCourseByID(id)
+}
+
+func (u *TrainingUseCase) GetCourses() (*[]domainTraining.Course, error) {
+	return u.trainingRepository.GetCourses()
+}
+
+func (u *TrainingUseCase) UpdateCourse(id uuid.UUID, updates map[string]interface{}) (*domainTraining.Course, error) {
+	u.Logger.Info("Updating training course", zap.String("id", id.String()))
+	existing, err := u.trainingRepository.GetCourseByID(id)
+	if err != nil {
+		return nil, err
+	}
+	candidate := *existing
+	if v, ok := updates["name"].(string); ok {
+		candidate.Name = v
+	}
+	if v, ok := updates["required_for_role"].(string); ok {
+		candidate.RequiredForRole = v
+	}
+	if v, ok := updates["due_within_days"].(int); ok {
+		candidate.DueWithinDays = v
+	}
+	if v, ok := updates["validity_days"].(int); ok {
+		candidate.ValidityDays = v
+	}
+	if err := validateCourse(&candidate); err != nil {
+		return nil, err
+	}
+	return u.trainingRepository.UpdateCourse(id, updates)
+}
+
+func (u *TrainingUseCase) DeleteCourse(id uuid.UUID) error {
+	u.Logger.Info("Deleting training course", zap.String("id", id.String()))
+	return u.trainingRepository.DeleteCourse(id)
+}
+
+// RecordCompletion logs a user finishing a course. The completion lapses
+// after the course's validity unless an explicit expiry is given.
+func (u *TrainingUseCase) RecordCompletion(newCompletion *domainTraining.Completion, now time.Time) (*domainTraining.Completion, error) {
+	u.Logger.Info("Recording training completion", zap.String("courseID", newCompletion.CourseID.String()), zap.String("userID", newCompletion.UserID.String()))
+	course, err := u.trainingRepository.GetCourseByID(newCompletion.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.userRepository.GetByID(newCompletion.UserID); err != nil {
+		return nil, domainErrors.NewAppError(errors.New("user not found"), domainErrors.NotFound)
+	}
+	if newCompletion.CompletedAt.IsZero() {
+		newCompletion.CompletedAt = now
+	}
+	if newCompletion.CompletedAt.After(now) {
+		return nil, domainErrors.NewAppError(errors.New("the completion date must not be in the future"), domainErrors.ValidationError)
+	}
+	if newCompletion.ExpiresAt == nil && course.ValidityDays > 0 {
+		expires := newCompletion.CompletedAt.AddDate(0, 0, course.ValidityDays)
+		newCompletion.ExpiresAt = &expires
+	}
+	if newCompletion.ExpiresAt != nil && !newCompletion.ExpiresAt.After(newCompletion.CompletedAt) {
+		return nil, domainErrors.NewAppError(errors.New("the expiry must be after the completion date"), domainErrors.ValidationError)
+	}
+	newCompletion.ID = uuid.New()
+	return u.trainingRepository.CreateCompletion(newCompletion)
+}
+
+func (u *TrainingUseCase) GetCompletions(filter domainTraining.CompletionFilter) (*[]domainTraining.Completion, error) {
+	return u.trainingRepository.GetCompletions(filter)
+}
+
+func (u *TrainingUseCase) DeleteCompletion(id uuid.UUID) error {
+	u.Logger.Info("Deleting training completion", zap.String("id", id.String()))
+	return u.trainingRepository.DeleteCompletion(id)
+}
+
+// GetRequirements returns where a user stands on every active course required
+// for their role.
+func (u *TrainingUseCase) GetRequirements(userID uuid.UUID, now time.Time) ([]domainTraining.Requirement, error) {
+	user, err := u.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("user not found"), domainErrors.NotFound)
+	}
+	courses, err := u.trainingRepository.GetActiveCourses()
+	if err != nil {
+		return nil, err
+	}
+	completions, err := u.trainingRepository.GetCompletions(domainTraining.CompletionFilter{UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+	return requirements(user, *courses, *completions, now, 0), nil
+}
+
+// OutOfCompliance lists the users with a role (caregivers by default) who are
+// overdue on a required course or will be within window, with only those
+// courses listed.
+func (u *TrainingUseCase) OutOfCompliance(role string, now time.Time, window time.Duration) ([]domainTraining.UserCompliance, error) {
+	if role == "" {
+		role = domainUser.RoleCaregiver
+	}
+	if window < 0 {
+		return nil, domainErrors.NewAppError(errors.New("the due window must not be negative"), domainErrors.ValidationError)
+	}
+	courses, err := u.trainingRepository.GetActiveCourses()
+	if err != nil {
+		return nil, err
+	}
+	users, err := u.userRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	completions, err := u.trainingRepository.GetCompletions(domainTraining.CompletionFilter{})
+	if err != nil {
+		return nil, err
+	}
+	byUser := make(map[uuid.UUID][]domainTraining.Completion)
+	for _, completion := range *completions {
+		byUser[completion.UserID] = append(byUser[completion.UserID], completion)
+	}
+
+	res := []domainTraining.UserCompliance{}
+	for i := range *users {
+		user := &(*users)[i]
+		if user.Role != role {
+			continue
+		}
+		var pending []domainTraining.Requirement
+		for _, requirement := range requirements(user, *courses, byUser[user.ID], now, window) {
+			if requirement.Status != domainTraining.StatusCompliant {
+				pending = append(pending, requirement)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+		res = append(res, domainTraining.UserCompliance{
+			UserID:       user.ID,
+			FirstName:    user.FirstName,
+			LastName:     user.LastName,
+			Role:         user.Role,
+			Requirements: pending,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return earliestDue(res[i].Requirements).Before(earliestDue(res[j].Requirements))
+	})
+	return res, nil
+}
+
+// ValidateSchedule blocks assigning a visit to a caregiver who does not hold
+// a current completion of every course its service requires, and warns when
+// one lapses during the visit.
+func (u *TrainingUseCase) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	if schedule.VisitStatus == "cancelled" || schedule.AssignedUserID == uuid.Nil {
+		return nil, nil
+	}
+	courses, err := u.trainingRepository.GetActiveCourses()
+	if err != nil {
+		return nil, err
+	}
+	var required []domainTraining.Course
+	for _, course := range *courses {
+		if course.RequiredFor(schedule.ServiceName) {
+			required = append(required, course)
+		}
+	}
+	if len(required) == 0 {
+		return nil, nil
+	}
+	completions, err := u.trainingRepository.GetCompletions(domainTraining.CompletionFilter{UserID: &schedule.AssignedUserID})
+	if err != nil {
+		return nil, err
+	}
+
+	var missing, warnings []string
+	for _, course := range required {
+		current := false
+		lapses := false
+		for _, completion := range *completions {
+			if completion.CourseID == course.ID && completion.Current(schedule.ScheduledSlot.From) {
+				current = true
+				lapses = !completion.Current(schedule.ScheduledSlot.To)
+				if !lapses {
+					break
+				}
+			}
+		}
+		if !current {
+			missing = append(missing, course.Name)
+		} else if lapses {
+			warnings = append(warnings, fmt.Sprintf("the caregiver's %s training lapses during the visit", course.Name))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, domainErrors.NewAppError(fmt.Errorf("the caregiver is missing training required for this service: %s", strings.Join(missing, ", ")), domainErrors.ValidationError)
+	}
+	return warnings, nil
+}
+
+// requirements works out a user's standing on each course required for their
+// role. A course never completed is due DueWithinDays after the user joined;
+// a completed one is due again when its latest completion expires.
+func requirements(user *domainUser.User, courses []domainTraining.Course, completions []domainTraining.Completion, now time.Time, window time.Duration) []domainTraining.Requirement {
+	res := []domainTraining.Requirement{}
+	for _, course := range courses {
+		if course.RequiredForRole == "" || course.RequiredForRole != user.Role {
+			continue
+		}
+		requirement := domainTraining.Requirement{CourseID: course.ID, CourseName: course.Name, Status: domainTraining.StatusCompliant}
+		var latest *domainTraining.Completion
+		for i := range completions {
+			if completions[i].CourseID == course.ID && (latest == nil || completions[i].CompletedAt.After(latest.CompletedAt)) {
+				latest = &completions[i]
+			}
+		}
+		if latest != nil {
+			completedAt := latest.CompletedAt
+			requirement.CompletedAt = &completedAt
+			requirement.DueAt = latest.ExpiresAt
+		} else {
+			due := user.CreatedAt.AddDate(0, 0, course.DueWithinDays)
+			requirement.DueAt = &due
+		}
+		if requirement.DueAt != nil {
+			if !requirement.DueAt.After(now) {
+				requirement.Status = domainTraining.StatusOverdue
+			} else if !requirement.DueAt.After(now.Add(window)) {
+				requirement.Status = domainTraining.StatusDueSoon
+			}
+		}
+		res = append(res, requirement)
+	}
+	return res
+}
+
+func earliestDue(requirements []domainTraining.Requirement) time.Time {
+	var earliest time.Time
+	for _, requirement := range requirements {
+		if requirement.DueAt != nil && (earliest.IsZero() || requirement.DueAt.Before(earliest)) {
+			earliest = *requirement.DueAt
+		}
+	}
+	return earliest
+}
+
+func validateCourse(c *domainTraining.Course) error {
+	if strings.TrimSpace(c.Name) == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	switch c.RequiredForRole {
+	case "", domainUser.RoleAdmin, domainUser.RoleCaregiver, domainUser.RoleClient:
+	default:
+		return domainErrors.NewAppError(errors.New("required role must be 'admin', 'caregiver' or 'client'"), domainErrors.ValidationError)
+	}
+	if c.DueWithinDays < 0 || c.ValidityDays < 0 {
+		return domainErrors.NewAppError(errors.New("days must not be negative"), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package training
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTraining "caregiver/src/domain/training"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockTrainingRepository keeps courses and completions in memory
+type mockTrainingRepository struct {
+	domainTraining.ITrainingRepository
+	courses     []domainTraining.Course
+	completions []domainTraining.Completion
+}
+
+func (m *mockTrainingRepository) CreateCourse(newCourse *domainTraining.Course) (*domainTraining.Course, error) {
+	m.courses = append(m.courses, *newCourse)
+	return newCourse, nil
+}
+
+func (m *mockTrainingRepository) GetCourseByID(id uuid.UUID) (*domainTraining.Course, error) {
+	for _, course := range m.courses {
+		if course.ID == id {
+			return &course, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockTrainingRepository) GetActiveCourses() (*[]domainTraining.Course, error) {
+	res := []domainTraining.Course{}
+	for _, course := range m.courses {
+		if course.Active {
+			res = append(res, course)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockTrainingRepository) CreateCompletion(newCompletion *domainTraining.Completion) (*domainTraining.Completion, error) {
+	m.completions = append(m.completions, *newCompletion)
+	return newCompletion, nil
+}
+
+func (m *mockTrainingRepository) GetCompletions(filter domainTraining.CompletionFilter) (*[]domainTraining.Completion, error) {
+	res := []domainTraining.Completion{}
+	for _, completion := range m.completions {
+		if (filter.UserID != nil && completion.UserID != *filter.UserID) || (filter.CourseID != nil && completion.CourseID != *filter.CourseID) {
+			continue
+		}
+		res = append(res, completion)
+	}
+	return &res, nil
+}
+
+// mockUserRepository returns a fixed set of users
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users []domainUser.User
+}
+
+func (m *mockUserRepository) GetAll() (*[]domainUser.User, error) {
+	return &m.users, nil
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	for i := range m.users {
+		if m.users[i].ID == id {
+			return &m.users[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func setupTestTrainingUseCase(t *testing.T) (ITrainingUseCase, *mockTrainingRepository, *mockUserRepository) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	trainings := &mockTrainingRepository{}
+	users := &mockUserRepository{}
+	return NewTrainingUseCase(trainings, users, loggerInstance), trainings, users
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestOutOfCompliance(t *testing.T) {
+	useCase, _, users := setupTestTrainingUseCase(t)
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	veteran := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, FirstName: "Vera", CreatedAt: now.AddDate(-2, 0, 0)}
+	newHire := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, FirstName: "Nate", CreatedAt: now.AddDate(0, 0, -10)}
+	admin := domainUser.User{ID: uuid.New(), Role: domainUser.RoleAdmin, CreatedAt: now.AddDate(-2, 0, 0)}
+	users.users = []domainUser.User{veteran, newHire, admin}
+
+	infection, err := useCase.CreateCourse(&domainTraining.Course{Name: "Infection control", RequiredForRole: domainUser.RoleCaregiver, DueWithinDays: 30, ValidityDays: 365})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.CreateCourse(&domainTraining.Course{Name: "Dementia care"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.RecordCompletion(&domainTraining.Completion{CourseID: infection.ID, UserID: veteran.ID, CompletedAt: now.AddDate(0, -11, -20)}, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	overdue, err := useCase.OutOfCompliance("", now, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(overdue) != 0 {
+		t.Errorf("expected nobody overdue yet, got %+v", overdue)
+	}
+
+	dueSoon, err := useCase.OutOfCompliance("", now, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dueSoon) != 2 || dueSoon[0].UserID != veteran.ID || dueSoon[0].Requirements[0].Status != domainTraining.StatusDueSoon {
+		t.Fatalf("expected the renewal first then the new hire, got %+v", dueSoon)
+	}
+
+	later, _ := useCase.OutOfCompliance("", now.AddDate(0, 1, 0), 0)
+	if len(later) != 2 || later[1].Requirements[0].Status != domainTraining.StatusOverdue {
+		t.Errorf("expected both caregivers overdue a month on, got %+v", later)
+	}
+
+	requirements, err := useCase.GetRequirements(newHire.ID, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requirements) != 1 || !requirements[0].DueAt.Equal(newHire.CreatedAt.AddDate(0, 0, 30)) {
+		t.Errorf("expected the new hire due 30 days after joining, got %+v", requirements)
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	useCase, _, users := setupTestTrainingUseCase(t)
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	caregiver := domainUser.User{ID: uuid.New(), Role: domainUser.RoleCaregiver, CreatedAt: now.AddDate(-1, 0, 0)}
+	users.users = []domainUser.User{caregiver}
+	hoist, _ := useCase.CreateCourse(&domainTraining.Course{Name: "Hoist transfers", ServiceNames: []string{"Personal Care"}, ValidityDays: 30})
+
+	visit := &domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiver.ID, ServiceName: "personal care", VisitStatus: "upcoming"}
+	visit.ScheduledSlot.From = now.Add(24 * time.Hour)
+	visit.ScheduledSlot.To = now.Add(26 * time.Hour)
+
+	if _, err := useCase.ValidateSchedule(visit); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an untrained caregiver to be blocked, got %v", err)
+	}
+	other := *visit
+	other.ServiceName = "Companionship"
+	if _, err := useCase.ValidateSchedule(&other); err != nil {
+		t.Errorf("expected services without required training to be allowed, got %v", err)
+	}
+
+	if _, err := useCase.RecordCompletion(&domainTraining.Completion{CourseID: hoist.ID, UserID: caregiver.ID, CompletedAt: now.AddDate(0, 0, -29)}, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.ValidateSchedule(visit); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected a completion lapsed by the visit to block, got %v", err)
+	}
+
+	if _, err := useCase.RecordCompletion(&domainTraining.Completion{CourseID: hoist.ID, UserID: caregiver.ID}, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if warnings, err := useCase.ValidateSchedule(visit); err != nil || len(warnings) != 0 {
+		t.Errorf("expected the refreshed training to allow the visit, got %v %v", warnings, err)
+	}
+}
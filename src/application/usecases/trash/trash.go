@@ -0,0 +1,76 @@
+package trash
+
+import (
+	"fmt"
+	"time"
+
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	userUseCase "caregiver/src/application/usecases/user"
+	domainErrors "caregiver/src/domain/errors"
+	domainTrash "caregiver/src/domain/trash"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RetentionWindow is how long deleted records stay in the trash and can be
+// restored from it.
+const RetentionWindow = 30 * 24 * time.Hour
+
+type ITrashUseCase interface {
+	// List returns records of the kind, or of every kind when empty, deleted
+	// within the retention window, most recently deleted first.
+	List(kind domainTrash.Kind, now time.Time) (*[]domainTrash.Item, error)
+	// Restore undoes the deletion of a record still in the trash.
+	Restore(kind domainTrash.Kind, id uuid.UUID, now time.Time) (*domainTrash.Item, error)
+}
+
+type TrashUseCase struct {
+	trashRepository domainTrash.ITrashRepository
+	userUseCase     userUseCase.IUserUseCase
+	scheduleUseCase scheduleUseCase.IScheduleUseCase
+	Logger          *logger.Logger
+}
+
+func NewTrashUseCase(trashRepository domainTrash.ITrashRepository, userUseCase userUseCase.IUserUseCase, scheduleUseCase scheduleUseCase.IScheduleUseCase, loggerInstance *logger.Logger) ITrashUseCase {
+	return &TrashUseCase{
+		trashRepository: trashRepository,
+		userUseCase:     userUseCase,
+		scheduleUseCase: scheduleUseCase,
+		Logger:          loggerInstance,
+	}
+}
+
+func (u *TrashUseCase) List(kind domainTrash.Kind, now time.Time) (*[]domainTrash.Item, error) {
+	if kind != "" && !kind.IsValid() {
+		return nil, domainErrors.NewAppError(fmt.Errorf("type must be one of %v", domainTrash.Kinds), domainErrors.ValidationError)
+	}
+	return u.trashRepository.List(kind, now.Add(-RetentionWindow))
+}
+
+// Restore goes through the user and schedule use cases so observers hear of
+// the record coming back.
+func (u *TrashUseCase) Restore(kind domainTrash.Kind, id uuid.UUID, now time.Time) (*domainTrash.Item, error) {
+	if !kind.IsValid() {
+		return nil, domainErrors.NewAppError(fmt.Errorf("type must be one of %v", domainTrash.Kinds), domainErrors.ValidationError)
+	}
+	item, err := u.trashRepository.Get(kind, id)
+	if err != nil {
+		return nil, err
+	}
+	if item.DeletedAt.Before(now.Add(-RetentionWindow)) {
+		return nil, domainErrors.NewAppError(fmt.Errorf("deleted on %s, past the %d day retention window", item.DeletedAt.UTC().Format("2006-01-02"), int(RetentionWindow.Hours()/24)), domainErrors.Conflict)
+	}
+	u.Logger.Info("Restoring from trash", zap.String("kind", string(kind)), zap.String("id", id.String()))
+	switch kind {
+	case domainTrash.KindUser:
+		_, err = u.userUseCase.Restore(id)
+	case domainTrash.KindSchedule:
+		_, err = u.scheduleUseCase.RestoreSchedule(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
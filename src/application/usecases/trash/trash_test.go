@@ -0,0 +1,107 @@
+package trash
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	userUseCase "caregiver/src/application/usecases/user"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainTrash "caregiver/src/domain/trash"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockTrashRepository keeps deleted records in memory
+type mockTrashRepository struct {
+	items []domainTrash.Item
+}
+
+func (m *mockTrashRepository) List(kind domainTrash.Kind, since time.Time) (*[]domainTrash.Item, error) {
+	items := []domainTrash.Item{}
+	for _, item := range m.items {
+		if (kind == "" || item.Kind == kind) && !item.DeletedAt.Before(since) {
+			items = append(items, item)
+		}
+	}
+	return &items, nil
+}
+
+func (m *mockTrashRepository) Get(kind domainTrash.Kind, id uuid.UUID) (*domainTrash.Item, error) {
+	for _, item := range m.items {
+		if item.Kind == kind && item.ID == id {
+			return &item, nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+type mockUserUseCase struct {
+	userUseCase.IUserUseCase
+	restored []uuid.UUID
+}
+
+func (m *mockUserUseCase) Restore(id uuid.UUID) (*domainUser.User, error) {
+	m.restored = append(m.restored, id)
+	return &domainUser.User{ID: id}, nil
+}
+
+type mockScheduleUseCase struct {
+	scheduleUseCase.IScheduleUseCase
+	restored []uuid.UUID
+}
+
+func (m *mockScheduleUseCase) RestoreSchedule(scheduleID uuid.UUID) (*domainSchedule.Schedule, error) {
+	m.restored = append(m.restored, scheduleID)
+	return &domainSchedule.Schedule{ID: scheduleID}, nil
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func TestTrash(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	recentUser := domainTrash.Item{Kind: domainTrash.KindUser, ID: uuid.New(), DeletedAt: now.Add(-time.Hour)}
+	recentSchedule := domainTrash.Item{Kind: domainTrash.KindSchedule, ID: uuid.New(), DeletedAt: now.Add(-48 * time.Hour)}
+	expired := domainTrash.Item{Kind: domainTrash.KindSchedule, ID: uuid.New(), DeletedAt: now.Add(-RetentionWindow - time.Hour)}
+	users := &mockUserUseCase{}
+	schedules := &mockScheduleUseCase{}
+	useCase := NewTrashUseCase(&mockTrashRepository{items: []domainTrash.Item{recentUser, recentSchedule, expired}}, users, schedules, loggerInstance)
+
+	items, err := useCase.List("", now)
+	if err != nil || len(*items) != 2 {
+		t.Fatalf("expected the two records inside the window, got %v, %v", items, err)
+	}
+	if items, _ := useCase.List(domainTrash.KindSchedule, now); len(*items) != 1 || (*items)[0].ID != recentSchedule.ID {
+		t.Errorf("expected only the recent schedule, got %v", items)
+	}
+	if _, err := useCase.List("visits", now); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected validation error for an unknown type, got %v", err)
+	}
+
+	if _, err := useCase.Restore(domainTrash.KindUser, recentUser.ID, now); err != nil || len(users.restored) != 1 {
+		t.Errorf("expected the user restored, got %v", err)
+	}
+	if _, err := useCase.Restore(domainTrash.KindSchedule, recentSchedule.ID, now); err != nil || len(schedules.restored) != 1 {
+		t.Errorf("expected the schedule restored, got %v", err)
+	}
+	if _, err := useCase.Restore(domainTrash.KindSchedule, expired.ID, now); errorType(err) != domainErrors.Conflict || len(schedules.restored) != 1 {
+		t.Errorf("expected conflict past the retention window, got %v", err)
+	}
+	if _, err := useCase.Restore(domainTrash.KindUser, recentSchedule.ID, now); errorType(err) != domainErrors.NotFound {
+		t.Errorf("expected not found for the wrong type, got %v", err)
+	}
+}
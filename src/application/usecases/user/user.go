@@ -1,7 +1,13 @@
 package user
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"caregiver/src/domain"
+	domainErrors "caregiver/src/domain/errors"
+	domainReferral "caregiver/src/domain/referral"
 	userDomain "caregiver/src/domain/user"
 	logger "caregiver/src/infrastructure/logger"
 	"caregiver/src/infrastructure/repository/psql/user"
@@ -12,25 +18,78 @@ import (
 
 type IUserUseCase interface {
 	GetAll() (*[]userDomain.User, error)
+	Stream(fn func(*userDomain.User) error) error
 	GetByID(id uuid.UUID) (*userDomain.User, error)
 	GetByEmail(email string) (*userDomain.User, error)
 	Create(newUser *userDomain.User) (*userDomain.User, error)
 	Delete(id uuid.UUID) error
+	DeleteBy(id uuid.UUID, deletedBy *uuid.UUID) error
+	Restore(id uuid.UUID) (*userDomain.User, error)
 	Update(id uuid.UUID, userMap map[string]interface{}) (*userDomain.User, error)
 	SearchPaginated(filters domain.DataFilters) (*userDomain.SearchResultUser, error)
-	SearchByProperty(property string, searchText string) (*[]string, error)
+	SearchByProperty(search userDomain.PropertySearch) (*userDomain.SearchResultProperty, error)
+	GetVersions(id uuid.UUID) (*[]userDomain.Version, error)
+	GetVersionAt(id uuid.UUID, at time.Time) (*userDomain.Version, error)
+}
+
+// UserObserver is notified after a user change has been persisted.
+type UserObserver interface {
+	OnUserEvent(event userDomain.Event)
+}
+
+// DeleteGuard can veto deleting a user, e.g. a client under legal hold.
+type DeleteGuard interface {
+	BeforeDelete(userID uuid.UUID) error
 }
 
 type UserUseCase struct {
-	userRepository user.UserRepositoryInterface
-	Logger         *logger.Logger
+	userRepository     user.UserRepositoryInterface
+	versionRepository  userDomain.IUserVersionRepository
+	referralRepository domainReferral.IReferralRepository
+	observers          []UserObserver
+	deleteGuards       []DeleteGuard
+	Logger             *logger.Logger
+}
+
+type Option func(*UserUseCase)
+
+// WithVersionHistory records a profile snapshot on every change that affects
+// versioned fields.
+func WithVersionHistory(versionRepository userDomain.IUserVersionRepository) Option {
+	return func(s *UserUseCase) {
+		s.versionRepository = versionRepository
+	}
+}
+
+// WithReferralSources checks that the referral source a client is
+// attributed to on creation exists.
+func WithReferralSources(referralRepository domainReferral.IReferralRepository) Option {
+	return func(s *UserUseCase) {
+		s.referralRepository = referralRepository
+	}
+}
+
+func WithObservers(observers ...UserObserver) Option {
+	return func(s *UserUseCase) {
+		s.observers = append(s.observers, observers...)
+	}
+}
+
+func WithDeleteGuards(guards ...DeleteGuard) Option {
+	return func(s *UserUseCase) {
+		s.deleteGuards = append(s.deleteGuards, guards...)
+	}
 }
 
-func NewUserUseCase(userRepository user.UserRepositoryInterface, logger *logger.Logger) IUserUseCase {
-	return &UserUseCase{
+func NewUserUseCase(userRepository user.UserRepositoryInterface, logger *logger.Logger, opts ...Option) IUserUseCase {
+	useCase := &UserUseCase{
 		userRepository: userRepository,
 		Logger:         logger,
 	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
 }
 
 func (s *UserUseCase) GetAll() (*[]userDomain.User, error) {
@@ -38,6 +97,12 @@ func (s *UserUseCase) GetAll() (*[]userDomain.User, error) {
 	return s.userRepository.GetAll()
 }
 
+// Stream calls fn with every user without loading them all at once.
+func (s *UserUseCase) Stream(fn func(*userDomain.User) error) error {
+	s.Logger.Info("Streaming all users")
+	return s.userRepository.Stream(fn)
+}
+
 func (s *UserUseCase) GetByID(id uuid.UUID) (*userDomain.User, error) {
 	s.Logger.Info("Getting user by ID", zap.String("id", id.String()))
 	return s.userRepository.GetByID(id)
@@ -50,21 +115,140 @@ func (s *UserUseCase) GetByEmail(email string) (*userDomain.User, error) {
 
 func (s *UserUseCase) Create(newUser *userDomain.User) (*userDomain.User, error) {
 	s.Logger.Info("Creating new user", zap.String("email", newUser.Email))
+	if newUser.ReferralSourceID != nil {
+		if newUser.Role != userDomain.RoleClient {
+			return nil, domainErrors.NewAppError(errors.New("only clients are attributed to a referral source"), domainErrors.ValidationError)
+		}
+		if s.referralRepository != nil {
+			if _, err := s.referralRepository.GetSourceByID(*newUser.ReferralSourceID); err != nil {
+				var appErr *domainErrors.AppError
+				if errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound {
+					return nil, domainErrors.NewAppError(errors.New("referral source not found"), domainErrors.ValidationError)
+				}
+				return nil, err
+			}
+		}
+	}
 
 	newUser.Status = true
 	newUser.ID = uuid.New()
 
-	return s.userRepository.Create(newUser)
+	created, err := s.userRepository.Create(newUser)
+	if err != nil {
+		return created, err
+	}
+	if s.versionRepository != nil {
+		s.recordVersion(created.Snapshot(), nil)
+	}
+	s.notify(userDomain.EventCreated, created)
+	return created, nil
 }
 
 func (s *UserUseCase) Delete(id uuid.UUID) error {
+	return s.DeleteBy(id, nil)
+}
+
+// DeleteBy soft-deletes the user; deletedBy, when known, is shown in the
+// admin trash.
+func (s *UserUseCase) DeleteBy(id uuid.UUID, deletedBy *uuid.UUID) error {
 	s.Logger.Info("Deleting user", zap.String("id", id.String()))
-	return s.userRepository.Delete(id)
+	for _, guard := range s.deleteGuards {
+		if err := guard.BeforeDelete(id); err != nil {
+			return err
+		}
+	}
+	if err := s.userRepository.DeleteBy(id, deletedBy); err != nil {
+		return err
+	}
+	s.notify(userDomain.EventDeleted, &userDomain.User{ID: id})
+	return nil
+}
+
+// Restore brings back a soft-deleted user.
+func (s *UserUseCase) Restore(id uuid.UUID) (*userDomain.User, error) {
+	s.Logger.Info("Restoring user", zap.String("id", id.String()))
+	restored, err := s.userRepository.Restore(id)
+	if err != nil {
+		return nil, err
+	}
+	s.notify(userDomain.EventCreated, restored)
+	return restored, nil
 }
 
 func (s *UserUseCase) Update(id uuid.UUID, userMap map[string]interface{}) (*userDomain.User, error) {
 	s.Logger.Info("Updating user", zap.String("id", id.String()))
-	return s.userRepository.Update(id, userMap)
+	updated, err := s.update(id, userMap)
+	if err != nil {
+		return updated, err
+	}
+	s.notify(userDomain.EventUpdated, updated)
+	return updated, nil
+}
+
+// update applies the changes, recording a version when versioned fields
+// changed.
+func (s *UserUseCase) update(id uuid.UUID, userMap map[string]interface{}) (*userDomain.User, error) {
+	if s.versionRepository == nil {
+		return s.userRepository.Update(id, userMap)
+	}
+
+	previous, err := s.userRepository.GetByID(id)
+	if err != nil {
+		return previous, err
+	}
+	updated, err := s.userRepository.Update(id, userMap)
+	if err != nil {
+		return updated, err
+	}
+
+	before, after := previous.Snapshot(), updated.Snapshot()
+	changed := after.ChangedSince(&before)
+	if len(changed) == 0 {
+		return updated, nil
+	}
+	// Users created before versioning have no history yet; record their
+	// prior profile as the baseline so earlier periods still resolve.
+	if versions, err := s.versionRepository.GetByUserID(id); err == nil && len(*versions) == 0 {
+		before.EffectiveFrom = previous.CreatedAt
+		s.recordVersion(before, nil)
+	}
+	s.recordVersion(after, changed)
+	return updated, nil
+}
+
+func (s *UserUseCase) GetVersions(id uuid.UUID) (*[]userDomain.Version, error) {
+	s.Logger.Info("Getting user versions", zap.String("id", id.String()))
+	if s.versionRepository == nil {
+		return &[]userDomain.Version{}, nil
+	}
+	return s.versionRepository.GetByUserID(id)
+}
+
+// GetVersionAt returns the profile in effect at the given time, for reports
+// covering past periods.
+func (s *UserUseCase) GetVersionAt(id uuid.UUID, at time.Time) (*userDomain.Version, error) {
+	s.Logger.Info("Getting user version in effect", zap.String("id", id.String()), zap.Time("at", at))
+	if s.versionRepository == nil {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return s.versionRepository.GetAt(id, at)
+}
+
+func (s *UserUseCase) notify(eventType string, user *userDomain.User) {
+	event := userDomain.Event{Type: eventType, User: user}
+	for _, observer := range s.observers {
+		observer.OnUserEvent(event)
+	}
+}
+
+func (s *UserUseCase) recordVersion(version userDomain.Version, changed []string) {
+	if version.EffectiveFrom.IsZero() {
+		version.EffectiveFrom = time.Now().UTC()
+	}
+	version.ChangedFields = changed
+	if _, err := s.versionRepository.Append(&version); err != nil {
+		s.Logger.Error("Error recording user version", zap.Error(err), zap.String("id", version.UserID.String()))
+	}
 }
 
 func (s *UserUseCase) SearchPaginated(filters domain.DataFilters) (*userDomain.SearchResultUser, error) {
@@ -74,9 +258,69 @@ func (s *UserUseCase) SearchPaginated(filters domain.DataFilters) (*userDomain.S
 	return s.userRepository.SearchPaginated(filters)
 }
 
-func (s *UserUseCase) SearchByProperty(property string, searchText string) (*[]string, error) {
+// propertySearchLimits caps how many matches each role can page through;
+// other roles get defaultPropertySearchLimit.
+var propertySearchLimits = map[string]int{
+	userDomain.RoleAdmin:     200,
+	userDomain.RoleCaregiver: 50,
+}
+
+const defaultPropertySearchLimit = 20
+
+// sharedSearchProperties are the properties non-admins may search, those the
+// user responses show every role.
+var sharedSearchProperties = map[string]bool{
+	"FirstName": true,
+	"LastName":  true,
+	"Role":      true,
+}
+
+// SearchByProperty ranks matching property values across users. Non-admins
+// may only search names and role, and every role can page through only its
+// limit of matches; Total is capped to match.
+func (s *UserUseCase) SearchByProperty(search userDomain.PropertySearch) (*userDomain.SearchResultProperty, error) {
 	s.Logger.Info("Searching users by property",
-		zap.String("property", property),
-		zap.String("searchText", searchText))
-	return s.userRepository.SearchByProperty(property, searchText)
+		zap.Strings("properties", search.Properties),
+		zap.String("searchText", search.Text),
+		zap.String("viewerRole", search.ViewerRole))
+	if search.ViewerRole != userDomain.RoleAdmin {
+		for _, property := range search.Properties {
+			if !sharedSearchProperties[property] {
+				return nil, domainErrors.NewAppError(fmt.Errorf("%s can only be searched by admins", property), domainErrors.ValidationError)
+			}
+		}
+	}
+	limit, ok := propertySearchLimits[search.ViewerRole]
+	if !ok {
+		limit = defaultPropertySearchLimit
+	}
+	if search.Page < 1 {
+		search.Page = 1
+	}
+	if search.PageSize < 1 {
+		search.PageSize = 10
+	}
+	if search.PageSize > limit {
+		search.PageSize = limit
+	}
+
+	result, err := s.userRepository.SearchByProperty(search)
+	if err != nil {
+		return nil, err
+	}
+	remaining := limit - (search.Page-1)*search.PageSize
+	if remaining < 0 {
+		remaining = 0
+	}
+	if len(*result.Data) > remaining {
+		trimmed := (*result.Data)[:remaining]
+		result.Data = &trimmed
+	}
+	if result.Total > int64(limit) {
+		result.Total = int64(limit)
+	}
+	result.Page = search.Page
+	result.PageSize = search.PageSize
+	result.TotalPages = int((result.Total + int64(search.PageSize) - 1) / int64(search.PageSize))
+	return result, nil
 }
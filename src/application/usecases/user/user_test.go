@@ -14,16 +14,21 @@ import (
 
 type mockUserService struct {
 	getAllFn     func() (*[]userDomain.User, error)
+	streamFn     func(fn func(*userDomain.User) error) error
 	getByIDFn    func(id uuid.UUID) (*userDomain.User, error)
 	getByEmailFn func(email string) (*userDomain.User, error)
 	createFn     func(u *userDomain.User) (*userDomain.User, error)
 	deleteFn     func(id uuid.UUID) error
 	updateFn     func(id uuid.UUID, m map[string]interface{}) (*userDomain.User, error)
+	searchFn     func(search userDomain.PropertySearch) (*userDomain.SearchResultProperty, error)
 }
 
 func (m *mockUserService) GetAll() (*[]userDomain.User, error) {
 	return m.getAllFn()
 }
+func (m *mockUserService) Stream(fn func(*userDomain.User) error) error {
+	return m.streamFn(fn)
+}
 func (m *mockUserService) GetByID(id uuid.UUID) (*userDomain.User, error) {
 	return m.getByIDFn(id)
 }
@@ -36,14 +41,20 @@ func (m *mockUserService) Create(newUser *userDomain.User) (*userDomain.User, er
 func (m *mockUserService) Delete(id uuid.UUID) error {
 	return m.deleteFn(id)
 }
+func (m *mockUserService) DeleteBy(id uuid.UUID, deletedBy *uuid.UUID) error {
+	return m.deleteFn(id)
+}
+func (m *mockUserService) Restore(id uuid.UUID) (*userDomain.User, error) {
+	return nil, nil
+}
 func (m *mockUserService) Update(id uuid.UUID, userMap map[string]interface{}) (*userDomain.User, error) {
 	return m.updateFn(id, userMap)
 }
 func (m *mockUserService) SearchPaginated(filters domain.DataFilters) (*userDomain.SearchResultUser, error) {
 	return nil, nil
 }
-func (m *mockUserService) SearchByProperty(property string, searchText string) (*[]string, error) {
-	return nil, nil
+func (m *mockUserService) SearchByProperty(search userDomain.PropertySearch) (*userDomain.SearchResultProperty, error) {
+	return m.searchFn(search)
 }
 
 func setupLogger(t *testing.T) *logger.Logger {
@@ -187,3 +198,35 @@ func TestNewUserUseCase(t *testing.T) {
 		t.Error("expected *user.UserUseCase type")
 	}
 }
+
+func TestSearchByPropertyLimits(t *testing.T) {
+	mockRepo := &mockUserService{}
+	useCase := NewUserUseCase(mockRepo, setupLogger(t))
+	var searched userDomain.PropertySearch
+	mockRepo.searchFn = func(search userDomain.PropertySearch) (*userDomain.SearchResultProperty, error) {
+		searched = search
+		data := make([]userDomain.PropertyMatch, search.PageSize)
+		return &userDomain.SearchResultProperty{Data: &data, Total: 500}, nil
+	}
+
+	result, err := useCase.SearchByProperty(userDomain.PropertySearch{Properties: []string{"Email"}, Text: "a", PageSize: 500, ViewerRole: userDomain.RoleAdmin})
+	if err != nil || searched.PageSize != 200 || result.Total != 200 || result.TotalPages != 1 {
+		t.Errorf("expected admins capped at 200 matches, got %+v, %v", result, err)
+	}
+
+	if _, err := useCase.SearchByProperty(userDomain.PropertySearch{Properties: []string{"FirstName", "Email"}, Text: "a", ViewerRole: userDomain.RoleCaregiver}); err == nil {
+		t.Error("expected caregivers not to search email")
+	}
+
+	result, err = useCase.SearchByProperty(userDomain.PropertySearch{Properties: []string{"FirstName"}, Text: "a", Page: 2, PageSize: 15, ViewerRole: userDomain.RoleFamily})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*result.Data) != 5 || result.Total != 20 || result.TotalPages != 2 {
+		t.Errorf("expected family to see only the first 20 matches, got %d of %d", len(*result.Data), result.Total)
+	}
+	result, _ = useCase.SearchByProperty(userDomain.PropertySearch{Properties: []string{"FirstName"}, Text: "a", Page: 3, PageSize: 15, ViewerRole: userDomain.RoleFamily})
+	if len(*result.Data) != 0 {
+		t.Errorf("expected no matches past the limit, got %d", len(*result.Data))
+	}
+}
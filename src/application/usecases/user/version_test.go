@@ -0,0 +1,97 @@
+package user
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	userDomain "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+type mockVersionRepository struct {
+	versions []userDomain.Version
+}
+
+func (m *mockVersionRepository) Append(version *userDomain.Version) (*userDomain.Version, error) {
+	version.Version = len(m.versions) + 1
+	m.versions = append(m.versions, *version)
+	return version, nil
+}
+
+func (m *mockVersionRepository) GetByUserID(userID uuid.UUID) (*[]userDomain.Version, error) {
+	versions := []userDomain.Version{}
+	for _, v := range m.versions {
+		if v.UserID == userID {
+			versions = append(versions, v)
+		}
+	}
+	return &versions, nil
+}
+
+func (m *mockVersionRepository) GetAt(userID uuid.UUID, at time.Time) (*userDomain.Version, error) {
+	return nil, nil
+}
+
+func TestUserUseCase_VersionHistory(t *testing.T) {
+	id := uuid.New()
+	createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	rate := 20.0
+	stored := userDomain.User{ID: id, Role: userDomain.RoleCaregiver, Status: true, HourlyRate: &rate, CreatedAt: createdAt}
+
+	mockRepo := &mockUserService{
+		getByIDFn: func(uuid.UUID) (*userDomain.User, error) {
+			current := stored
+			return &current, nil
+		},
+		updateFn: func(_ uuid.UUID, m map[string]interface{}) (*userDomain.User, error) {
+			if r, ok := m["hourly_rate"].(float64); ok {
+				stored.HourlyRate = &r
+			}
+			if v, ok := m["user_name"].(string); ok {
+				stored.UserName = v
+			}
+			current := stored
+			return &current, nil
+		},
+	}
+	versions := &mockVersionRepository{}
+	uc := NewUserUseCase(mockRepo, setupLogger(t), WithVersionHistory(versions))
+
+	if _, err := uc.Update(id, map[string]interface{}{"user_name": "renamed"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions.versions) != 0 {
+		t.Fatalf("expected no version for unversioned fields, got %d", len(versions.versions))
+	}
+
+	if _, err := uc.Update(id, map[string]interface{}{"hourly_rate": 25.0}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions.versions) != 2 {
+		t.Fatalf("expected baseline and new version, got %d", len(versions.versions))
+	}
+	baseline, latest := versions.versions[0], versions.versions[1]
+	if !baseline.EffectiveFrom.Equal(createdAt) || *baseline.HourlyRate != 20 {
+		t.Errorf("unexpected baseline %+v", baseline)
+	}
+	if *latest.HourlyRate != 25 || !reflect.DeepEqual(latest.ChangedFields, []string{"HourlyRate"}) {
+		t.Errorf("unexpected latest version %+v", latest)
+	}
+
+	if _, err := uc.Update(id, map[string]interface{}{"hourly_rate": 30.0}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions.versions) != 3 {
+		t.Errorf("expected a single new version once history exists, got %d", len(versions.versions))
+	}
+}
+
+func TestUserUseCase_GetVersionsWithoutHistory(t *testing.T) {
+	uc := NewUserUseCase(&mockUserService{}, setupLogger(t))
+	versions, err := uc.GetVersions(uuid.New())
+	if err != nil || len(*versions) != 0 {
+		t.Errorf("expected empty history, got %v, %v", versions, err)
+	}
+}
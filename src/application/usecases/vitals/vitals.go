@@ -0,0 +1,268 @@
+package vitals
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	domainVitals "caregiver/src/domain/vitals"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IVitalsUseCase interface {
+	SetRange(newRange *domainVitals.Range) (*domainVitals.Range, error)
+	// GetRanges returns the ranges in force for the client: their own where
+	// set, the defaults otherwise.
+	GetRanges(clientUserID uuid.UUID) ([]domainVitals.Range, error)
+	DeleteRange(id uuid.UUID) error
+	RecordVitals(newReading *domainVitals.Reading) (*domainVitals.Reading, error)
+	GetReadings(filter domainVitals.ReadingFilter) (*[]domainVitals.Reading, error)
+	DeleteReading(id uuid.UUID) error
+	Trends(clientUserID uuid.UUID, measure string, from, to time.Time) ([]domainVitals.Series, error)
+}
+
+type VitalsUseCase struct {
+	vitalsRepository   domainVitals.IVitalsRepository
+	userRepository     domainUser.IUserRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	notifier           notification.INotifier
+	Logger             *logger.Logger
+}
+
+func NewVitalsUseCase(vitalsRepository domainVitals.IVitalsRepository, userRepository domainUser.IUserRepository, scheduleRepository domainSchedule.IScheduleRepository, notifier notification.INotifier, loggerInstance *logger.Logger) IVitalsUseCase {
+	return &VitalsUseCase{
+		vitalsRepository:   vitalsRepository,
+		userRepository:     userRepository,
+		scheduleRepository: scheduleRepository,
+		notifier:           notifier,
+		Logger:             loggerInstance,
+	}
+}
+
+func (u *VitalsUseCase) SetRange(newRange *domainVitals.Range) (*domainVitals.Range, error) {
+	u.Logger.Info("Setting vital range", zap.String("clientUserID", newRange.ClientUserID.String()), zap.String("measure", newRange.Measure))
+	client, err := u.userRepository.GetByID(newRange.ClientUserID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(errors.New("client not found"), domainErrors.NotFound)
+	}
+	if client.Role != domainUser.RoleClient {
+		return nil, domainErrors.NewAppError(errors.New("the user is not a client"), domainErrors.ValidationError)
+	}
+	if err := validateMeasure(newRange.Measure); err != nil {
+		return nil, err
+	}
+	if newRange.Min == nil && newRange.Max == nil {
+		return nil, domainErrors.NewAppError(errors.New("a range needs a minimum, a maximum or both"), domainErrors.ValidationError)
+	}
+	if newRange.Min != nil && newRange.Max != nil && *newRange.Min > *newRange.Max {
+		return nil, domainErrors.NewAppError(errors.New("min must not be greater than max"), domainErrors.ValidationError)
+	}
+	newRange.ID = uuid.New()
+	return u.vitalsRepository.SaveRange(newRange)
+}
+
+func (u *VitalsUseCase) GetRanges(clientUserID uuid.UUID) ([]domainVitals.Range, error) {
+	own, err := u.vitalsRepository.GetRanges(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	byMeasure := make(map[string]domainVitals.Range)
+	for _, r := range domainVitals.DefaultRanges() {
+		r.ClientUserID = clientUserID
+		byMeasure[r.Measure] = r
+	}
+	for _, r := range *own {
+		byMeasure[r.Measure] = r
+	}
+	ranges := []domainVitals.Range{}
+	for _, measure := range domainVitals.Measures {
+		if r, ok := byMeasure[measure]; ok {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges, nil
+}
+
+func (u *VitalsUseCase) DeleteRange(id uuid.UUID) error {
+	u.Logger.Info("Deleting vital range", zap.String("id", id.String()))
+	return u.vitalsRepository.DeleteRange(id)
+}
+
+// RecordVitals stores the vitals taken during a visit. Values outside the
+// client's ranges are kept on the reading as breaches and coordinators are
+// alerted.
+func (u *VitalsUseCase) RecordVitals(newReading *domainVitals.Reading) (*domainVitals.Reading, error) {
+	u.Logger.Info("Recording vitals", zap.String("scheduleID", newReading.ScheduleID.String()))
+	schedule, err := u.scheduleRepository.GetScheduleByID(newReading.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.CheckinTime == nil {
+		return nil, domainErrors.NewAppError(errors.New("vitals can only be recorded once the visit has started"), domainErrors.ValidationError)
+	}
+	values := newReading.Values()
+	if len(values) == 0 {
+		return nil, domainErrors.NewAppError(errors.New("at least one vital sign is required"), domainErrors.ValidationError)
+	}
+	if (newReading.Systolic == nil) != (newReading.Diastolic == nil) {
+		return nil, domainErrors.NewAppError(errors.New("blood pressure needs both systolic and diastolic values"), domainErrors.ValidationError)
+	}
+	for _, measure := range domainVitals.Measures {
+		if value, ok := values[measure]; ok && value <= 0 {
+			return nil, domainErrors.NewAppError(fmt.Errorf("%s must be positive", measure), domainErrors.ValidationError)
+		}
+	}
+
+	ranges, err := u.GetRanges(schedule.ClientUserID)
+	if err != nil {
+		return nil, err
+	}
+	newReading.Breaches = nil
+	for _, r := range ranges {
+		if value, ok := values[r.Measure]; ok && !r.Contains(value) {
+			newReading.Breaches = append(newReading.Breaches, domainVitals.Breach{Measure: r.Measure, Value: value, Min: r.Min, Max: r.Max})
+		}
+	}
+
+	newReading.ID = uuid.New()
+	newReading.ClientUserID = schedule.ClientUserID
+	newReading.RecordedByUserID = schedule.AssignedUserID
+	if newReading.RecordedAt.IsZero() {
+		newReading.RecordedAt = *schedule.CheckinTime
+	}
+	created, err := u.vitalsRepository.CreateReading(newReading)
+	if err != nil {
+		return nil, err
+	}
+	if len(created.Breaches) > 0 {
+		u.notifyBreaches(created)
+	}
+	return created, nil
+}
+
+func (u *VitalsUseCase) GetReadings(filter domainVitals.ReadingFilter) (*[]domainVitals.Reading, error) {
+	return u.vitalsRepository.GetReadings(filter)
+}
+
+func (u *VitalsUseCase) DeleteReading(id uuid.UUID) error {
+	u.Logger.Info("Deleting vitals reading", zap.String("id", id.String()))
+	return u.vitalsRepository.DeleteReading(id)
+}
+
+// Trends returns a series per measure recorded for the client between from
+// and to, or just the one measure if given. Points are judged against the
+// ranges in force now.
+func (u *VitalsUseCase) Trends(clientUserID uuid.UUID, measure string, from, to time.Time) ([]domainVitals.Series, error) {
+	if !from.Before(to) {
+		return nil, domainErrors.NewAppError(errors.New("from must be before to"), domainErrors.ValidationError)
+	}
+	measures := domainVitals.Measures
+	if measure != "" {
+		if err := validateMeasure(measure); err != nil {
+			return nil, err
+		}
+		measures = []string{measure}
+	}
+	readings, err := u.vitalsRepository.GetReadings(domainVitals.ReadingFilter{ClientUserID: &clientUserID, From: &from, To: &to})
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := u.GetRanges(clientUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	trends := []domainVitals.Series{}
+	for _, m := range measures {
+		series := domainVitals.Series{Measure: m, Unit: domainVitals.Units[m], Points: []domainVitals.Point{}}
+		for i := range ranges {
+			if ranges[i].Measure == m {
+				series.Range = &ranges[i]
+			}
+		}
+		var total float64
+		for i := range *readings {
+			value, ok := (*readings)[i].Values()[m]
+			if !ok {
+				continue
+			}
+			point := domainVitals.Point{At: (*readings)[i].RecordedAt, Value: value}
+			if series.Range != nil && !series.Range.Contains(value) {
+				point.OutOfRange = true
+				series.OutOfRange++
+			}
+			series.Points = append(series.Points, point)
+			total += value
+			if series.Min == nil || value < *series.Min {
+				series.Min = &point.Value
+			}
+			if series.Max == nil || value > *series.Max {
+				series.Max = &point.Value
+			}
+		}
+		if n := len(series.Points); n > 0 {
+			latest := series.Points[n-1].Value
+			average := total / float64(n)
+			series.Latest, series.Average = &latest, &average
+		} else if measure == "" {
+			continue
+		}
+		trends = append(trends, series)
+	}
+	return trends, nil
+}
+
+func (u *VitalsUseCase) notifyBreaches(reading *domainVitals.Reading) {
+	clientName := reading.ClientUserID.String()
+	if client, err := u.userRepository.GetByID(reading.ClientUserID); err == nil {
+		clientName = strings.TrimSpace(client.FirstName + " " + client.LastName)
+	}
+	details := make([]string, len(reading.Breaches))
+	for i, breach := range reading.Breaches {
+		details[i] = fmt.Sprintf("%s %s %s (normal %s)", breach.Measure, formatValue(breach.Value), domainVitals.Units[breach.Measure], formatRange(breach.Min, breach.Max))
+	}
+	err := u.notifier.Notify(notification.Message{
+		Role:    domainUser.RoleAdmin,
+		Subject: "Vitals out of range",
+		Body:    fmt.Sprintf("Vitals recorded for %s are out of range: %s.", clientName, strings.Join(details, ", ")),
+		Data: map[string]interface{}{
+			"readingID":    reading.ID,
+			"scheduleID":   reading.ScheduleID,
+			"clientUserID": reading.ClientUserID,
+			"breaches":     reading.Breaches,
+		},
+	})
+	if err != nil {
+		u.Logger.Error("Error sending vitals alert", zap.Error(err), zap.String("readingID", reading.ID.String()))
+	}
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatRange(min, max *float64) string {
+	switch {
+	case min == nil:
+		return "up to " + formatValue(*max)
+	case max == nil:
+		return "at least " + formatValue(*min)
+	}
+	return formatValue(*min) + "-" + formatValue(*max)
+}
+
+func validateMeasure(measure string) error {
+	if _, ok := domainVitals.Units[measure]; !ok {
+		return domainErrors.NewAppError(fmt.Errorf("measure must be one of %s", strings.Join(domainVitals.Measures, ", ")), domainErrors.ValidationError)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package vitals
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	domainVitals "caregiver/src/domain/vitals"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+// mockVitalsRepository keeps ranges and readings in memory
+type mockVitalsRepository struct {
+	domainVitals.IVitalsRepository
+	ranges   []domainVitals.Range
+	readings []domainVitals.Reading
+}
+
+func (m *mockVitalsRepository) SaveRange(newRange *domainVitals.Range) (*domainVitals.Range, error) {
+	for i := range m.ranges {
+		if m.ranges[i].ClientUserID == newRange.ClientUserID && m.ranges[i].Measure == newRange.Measure {
+			m.ranges[i].Min, m.ranges[i].Max = newRange.Min, newRange.Max
+			return &m.ranges[i], nil
+		}
+	}
+	m.ranges = append(m.ranges, *newRange)
+	return newRange, nil
+}
+
+func (m *mockVitalsRepository) GetRanges(clientUserID uuid.UUID) (*[]domainVitals.Range, error) {
+	res := []domainVitals.Range{}
+	for _, r := range m.ranges {
+		if r.ClientUserID == clientUserID {
+			res = append(res, r)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockVitalsRepository) CreateReading(newReading *domainVitals.Reading) (*domainVitals.Reading, error) {
+	m.readings = append(m.readings, *newReading)
+	return newReading, nil
+}
+
+func (m *mockVitalsRepository) GetReadings(filter domainVitals.ReadingFilter) (*[]domainVitals.Reading, error) {
+	res := []domainVitals.Reading{}
+	for _, reading := range m.readings {
+		if filter.ClientUserID != nil && reading.ClientUserID != *filter.ClientUserID {
+			continue
+		}
+		if (filter.From != nil && reading.RecordedAt.Before(*filter.From)) || (filter.To != nil && !reading.RecordedAt.Before(*filter.To)) {
+			continue
+		}
+		res = append(res, reading)
+	}
+	return &res, nil
+}
+
+// mockUserRepository returns users by ID
+type mockUserRepository struct {
+	domainUser.IUserRepository
+	users map[uuid.UUID]*domainUser.User
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockScheduleRepository returns a fixed set of schedules
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedules map[uuid.UUID]*domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	if schedule, ok := m.schedules[id]; ok {
+		return schedule, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+// mockNotifier records the messages sent
+type mockNotifier struct {
+	messages []notification.Message
+}
+
+func (m *mockNotifier) Notify(message notification.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+type fixture struct {
+	useCase   IVitalsUseCase
+	schedules *mockScheduleRepository
+	notifier  *mockNotifier
+	clientID  uuid.UUID
+}
+
+func setupTestVitalsUseCase(t *testing.T) *fixture {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	clientID := uuid.New()
+	users := &mockUserRepository{users: map[uuid.UUID]*domainUser.User{
+		clientID: {ID: clientID, Role: domainUser.RoleClient, FirstName: "Rosa", LastName: "Diaz"},
+	}}
+	schedules := &mockScheduleRepository{schedules: make(map[uuid.UUID]*domainSchedule.Schedule)}
+	notifier := &mockNotifier{}
+	return &fixture{
+		useCase:   NewVitalsUseCase(&mockVitalsRepository{}, users, schedules, notifier, loggerInstance),
+		schedules: schedules,
+		notifier:  notifier,
+		clientID:  clientID,
+	}
+}
+
+func (f *fixture) visit(checkin time.Time) uuid.UUID {
+	s := &domainSchedule.Schedule{ID: uuid.New(), ClientUserID: f.clientID, AssignedUserID: uuid.New(), VisitStatus: "in_progress", CheckinTime: &checkin}
+	f.schedules.schedules[s.ID] = s
+	return s.ID
+}
+
+func errorType(err error) domainErrors.ErrorType {
+	var appErr *domainErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type
+	}
+	return ""
+}
+
+func value(v float64) *float64 { return &v }
+
+func TestRecordVitals(t *testing.T) {
+	f := setupTestVitalsUseCase(t)
+	checkin := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	reading, err := f.useCase.RecordVitals(&domainVitals.Reading{ScheduleID: f.visit(checkin), Systolic: value(128), Diastolic: value(82), Pulse: value(72)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reading.Breaches) != 0 || len(f.notifier.messages) != 0 || !reading.RecordedAt.Equal(checkin) {
+		t.Errorf("expected normal vitals without an alert, got %+v %+v", reading, f.notifier.messages)
+	}
+
+	// The client's diabetic glucose target replaces the default range.
+	if _, err := f.useCase.SetRange(&domainVitals.Range{ClientUserID: f.clientID, Measure: domainVitals.MeasureGlucose, Min: value(80), Max: value(130)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reading, err = f.useCase.RecordVitals(&domainVitals.Reading{ScheduleID: f.visit(checkin.AddDate(0, 0, 1)), Glucose: value(150), Pulse: value(110)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reading.Breaches) != 2 || reading.Breaches[0].Measure != domainVitals.MeasurePulse || reading.Breaches[1].Measure != domainVitals.MeasureGlucose {
+		t.Errorf("expected pulse and glucose breaches, got %+v", reading.Breaches)
+	}
+	if len(f.notifier.messages) != 1 || f.notifier.messages[0].Role != domainUser.RoleAdmin {
+		t.Errorf("expected coordinators to be alerted, got %+v", f.notifier.messages)
+	}
+
+	if _, err := f.useCase.RecordVitals(&domainVitals.Reading{ScheduleID: f.visit(checkin), Systolic: value(120)}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected half a blood pressure to be rejected, got %v", err)
+	}
+	if _, err := f.useCase.RecordVitals(&domainVitals.Reading{ScheduleID: f.visit(checkin)}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an empty reading to be rejected, got %v", err)
+	}
+	if _, err := f.useCase.SetRange(&domainVitals.Range{ClientUserID: f.clientID, Measure: "temperature", Max: value(38)}); errorType(err) != domainErrors.ValidationError {
+		t.Errorf("expected an unknown measure to be rejected, got %v", err)
+	}
+}
+
+func TestTrends(t *testing.T) {
+	f := setupTestVitalsUseCase(t)
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	for i, weight := range []float64{80, 81.5, 83} {
+		if _, err := f.useCase.RecordVitals(&domainVitals.Reading{ScheduleID: f.visit(start.AddDate(0, 0, 7*i)), Weight: value(weight), Pulse: value(60 + 25*float64(i))}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := f.useCase.SetRange(&domainVitals.Range{ClientUserID: f.clientID, Measure: domainVitals.MeasureWeight, Max: value(82)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	trends, err := f.useCase.Trends(f.clientID, "", start, start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trends) != 2 || trends[0].Measure != domainVitals.MeasurePulse || trends[1].Measure != domainVitals.MeasureWeight {
+		t.Fatalf("expected pulse and weight series, got %+v", trends)
+	}
+	weight := trends[1]
+	if len(weight.Points) != 3 || *weight.Latest != 83 || *weight.Min != 80 || *weight.Average != 81.5 || weight.OutOfRange != 1 || !weight.Points[2].OutOfRange {
+		t.Errorf("expected three weights with the last above the client's range, got %+v", weight)
+	}
+	if trends[0].OutOfRange != 1 || trends[0].Range == nil || !trends[0].Range.Default {
+		t.Errorf("expected one pulse out of the default range, got %+v", trends[0])
+	}
+
+	glucose, err := f.useCase.Trends(f.clientID, domainVitals.MeasureGlucose, start, start.AddDate(0, 0, 10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(glucose) != 1 || len(glucose[0].Points) != 0 {
+		t.Errorf("expected an empty glucose series, got %+v", glucose)
+	}
+}
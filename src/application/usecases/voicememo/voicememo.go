@@ -0,0 +1,242 @@
+package voicememo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainServiceNote "caregiver/src/domain/servicenote"
+	domainVoiceMemo "caregiver/src/domain/voicememo"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/storage"
+	"caregiver/src/infrastructure/transcription"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxMemoSize keeps memos short; roughly a few minutes of compressed audio.
+	MaxMemoSize = 5 << 20
+
+	maxConcurrentTranscriptions = 4
+	transcriptionTimeout        = 3 * time.Minute
+	defaultSearchLimit          = 50
+)
+
+var allowedContentTypes = map[string]string{
+	"audio/mpeg":  ".mp3",
+	"audio/mp4":   ".m4a",
+	"audio/x-m4a": ".m4a",
+	"audio/aac":   ".aac",
+	"audio/wav":   ".wav",
+	"audio/webm":  ".webm",
+	"audio/ogg":   ".ogg",
+}
+
+type IVoiceMemoUseCase interface {
+	Upload(newMemo *domainVoiceMemo.Memo, content io.Reader) (*domainVoiceMemo.Memo, error)
+	GetBySchedule(scheduleID uuid.UUID) (*[]domainVoiceMemo.Memo, error)
+	Search(query string) (*[]domainVoiceMemo.Memo, error)
+}
+
+// ScheduleObserver is told when a transcript is added to a visit's service
+// note.
+type ScheduleObserver interface {
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+type VoiceMemoUseCase struct {
+	memoRepository     domainVoiceMemo.IVoiceMemoRepository
+	scheduleRepository domainSchedule.IScheduleRepository
+	storage            storage.IFileStorage
+	transcriber        transcription.ITranscriber
+	noteRepository     domainServiceNote.IServiceNoteRepository
+	observers          []ScheduleObserver
+	Logger             *logger.Logger
+
+	slots    chan struct{}
+	noteLock sync.Mutex
+	wg       sync.WaitGroup
+}
+
+type Option func(*VoiceMemoUseCase)
+
+func WithObservers(observers ...ScheduleObserver) Option {
+	return func(u *VoiceMemoUseCase) {
+		u.observers = append(u.observers, observers...)
+	}
+}
+
+// WithServiceNotes records each transcript added to a service note as a
+// revision of the note, authored by the memo's uploader.
+func WithServiceNotes(noteRepository domainServiceNote.IServiceNoteRepository) Option {
+	return func(u *VoiceMemoUseCase) {
+		u.noteRepository = noteRepository
+	}
+}
+
+func NewVoiceMemoUseCase(memoRepository domainVoiceMemo.IVoiceMemoRepository, scheduleRepository domainSchedule.IScheduleRepository, fileStorage storage.IFileStorage, transcriber transcription.ITranscriber, loggerInstance *logger.Logger, opts ...Option) IVoiceMemoUseCase {
+	useCase := &VoiceMemoUseCase{
+		memoRepository:     memoRepository,
+		scheduleRepository: scheduleRepository,
+		storage:            fileStorage,
+		transcriber:        transcriber,
+		Logger:             loggerInstance,
+		slots:              make(chan struct{}, maxConcurrentTranscriptions),
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+// Upload stores a checkout memo and queues it for transcription. The memo is
+// returned immediately in the pending state.
+func (u *VoiceMemoUseCase) Upload(newMemo *domainVoiceMemo.Memo, content io.Reader) (*domainVoiceMemo.Memo, error) {
+	u.Logger.Info("Uploading voice memo", zap.String("scheduleID", newMemo.ScheduleID.String()))
+
+	schedule, err := u.scheduleRepository.GetScheduleByID(newMemo.ScheduleID)
+	if err != nil {
+		u.Logger.Error("Schedule not found for voice memo", zap.Error(err), zap.String("scheduleID", newMemo.ScheduleID.String()))
+		return nil, err
+	}
+	if schedule.AssignedUserID != newMemo.UploadedByUserID {
+		return nil, domainErrors.NewAppError(errors.New("only the assigned caregiver can record a voice memo for this visit"), domainErrors.NotAuthorized)
+	}
+	if schedule.VisitStatus != "in_progress" && schedule.VisitStatus != "partially_completed" && schedule.VisitStatus != "completed" {
+		return nil, domainErrors.NewAppError(errors.New("voice memos can only be recorded at checkout"), domainErrors.ValidationError)
+	}
+	ext, ok := allowedContentTypes[newMemo.ContentType]
+	if !ok {
+		return nil, domainErrors.NewAppError(fmt.Errorf("content type '%s' is not allowed", newMemo.ContentType), domainErrors.ValidationError)
+	}
+	if newMemo.Size <= 0 || newMemo.Size > MaxMemoSize {
+		return nil, domainErrors.NewAppError(errors.New("voice memo must be between 1 byte and 5 MiB"), domainErrors.ValidationError)
+	}
+
+	newMemo.ID = uuid.New()
+	newMemo.Status = domainVoiceMemo.StatusPending
+	newMemo.StorageKey = fmt.Sprintf("voice-memos/%s/%s%s", newMemo.ScheduleID, newMemo.ID, ext)
+	if err := u.storage.Save(newMemo.StorageKey, io.LimitReader(content, MaxMemoSize)); err != nil {
+		u.Logger.Error("Error storing voice memo audio", zap.Error(err), zap.String("scheduleID", newMemo.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	created, err := u.memoRepository.Create(newMemo)
+	if err != nil {
+		_ = u.storage.Delete(newMemo.StorageKey)
+		return nil, err
+	}
+
+	u.wg.Add(1)
+	go u.transcribe(*created)
+	return created, nil
+}
+
+func (u *VoiceMemoUseCase) GetBySchedule(scheduleID uuid.UUID) (*[]domainVoiceMemo.Memo, error) {
+	u.Logger.Info("Getting voice memos for schedule", zap.String("scheduleID", scheduleID.String()))
+	return u.memoRepository.GetBySchedule(scheduleID)
+}
+
+func (u *VoiceMemoUseCase) Search(query string) (*[]domainVoiceMemo.Memo, error) {
+	query = strings.TrimSpace(query)
+	if len(query) < 2 {
+		return nil, domainErrors.NewAppError(errors.New("search query must be at least 2 characters"), domainErrors.ValidationError)
+	}
+	return u.memoRepository.SearchTranscripts(query, defaultSearchLimit)
+}
+
+// transcribe runs in the background; the number of concurrent provider calls
+// is bounded by the slots channel.
+func (u *VoiceMemoUseCase) transcribe(memo domainVoiceMemo.Memo) {
+	defer u.wg.Done()
+	u.slots <- struct{}{}
+	defer func() { <-u.slots }()
+
+	transcript, err := u.runTranscriber(memo)
+	if err != nil {
+		u.Logger.Error("Voice memo transcription failed", zap.Error(err), zap.String("memoID", memo.ID.String()))
+		reason := err.Error()
+		_, _ = u.memoRepository.Update(memo.ID, map[string]interface{}{
+			"status":         domainVoiceMemo.StatusFailed,
+			"failure_reason": &reason,
+		})
+		return
+	}
+
+	if _, err := u.memoRepository.Update(memo.ID, map[string]interface{}{
+		"status":     domainVoiceMemo.StatusTranscribed,
+		"transcript": &transcript,
+	}); err != nil {
+		return
+	}
+	u.appendToServiceNote(memo, transcript)
+	u.Logger.Info("Voice memo transcribed", zap.String("memoID", memo.ID.String()))
+}
+
+func (u *VoiceMemoUseCase) runTranscriber(memo domainVoiceMemo.Memo) (string, error) {
+	audio, err := u.storage.Open(memo.StorageKey)
+	if err != nil {
+		return "", err
+	}
+	defer audio.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), transcriptionTimeout)
+	defer cancel()
+	transcript, err := u.transcriber.Transcribe(ctx, audio, memo.ContentType)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(transcript), nil
+}
+
+func (u *VoiceMemoUseCase) appendToServiceNote(memo domainVoiceMemo.Memo, transcript string) {
+	if transcript == "" {
+		return
+	}
+	scheduleID := memo.ScheduleID
+	u.noteLock.Lock()
+	defer u.noteLock.Unlock()
+
+	schedule, err := u.scheduleRepository.GetScheduleByID(scheduleID)
+	if err != nil {
+		u.Logger.Error("Schedule not found for voice memo transcript", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return
+	}
+	note := "[Voice memo] " + transcript
+	if schedule.ServiceNote != nil && *schedule.ServiceNote != "" {
+		note = *schedule.ServiceNote + "\n\n" + note
+	}
+	updated, err := u.saveServiceNote(memo, note)
+	if err != nil {
+		u.Logger.Error("Error attaching transcript to service note", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return
+	}
+	event := domainSchedule.Event{Type: domainSchedule.EventUpdated, Schedule: updated, Previous: schedule}
+	for _, observer := range u.observers {
+		observer.OnScheduleEvent(event)
+	}
+}
+
+func (u *VoiceMemoUseCase) saveServiceNote(memo domainVoiceMemo.Memo, note string) (*domainSchedule.Schedule, error) {
+	if u.noteRepository == nil {
+		return u.scheduleRepository.UpdateSchedule(memo.ScheduleID, map[string]interface{}{"service_note": note})
+	}
+	authorID := memo.UploadedByUserID
+	if _, err := u.noteRepository.Append(&domainServiceNote.Revision{
+		ScheduleID:   memo.ScheduleID,
+		Body:         note,
+		AuthorUserID: &authorID,
+		CreatedAt:    time.Now().UTC(),
+	}); err != nil {
+		return nil, err
+	}
+	return u.scheduleRepository.GetScheduleByID(memo.ScheduleID)
+}
@@ -0,0 +1,210 @@
+package voicememo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	domainSchedule "caregiver/src/domain/schedule"
+	domainVoiceMemo "caregiver/src/domain/voicememo"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// mockVoiceMemoRepository is an in-memory implementation of IVoiceMemoRepository
+type mockVoiceMemoRepository struct {
+	mu    sync.Mutex
+	memos map[uuid.UUID]*domainVoiceMemo.Memo
+}
+
+func (m *mockVoiceMemoRepository) Create(newMemo *domainVoiceMemo.Memo) (*domainVoiceMemo.Memo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *newMemo
+	m.memos[newMemo.ID] = &stored
+	return newMemo, nil
+}
+
+func (m *mockVoiceMemoRepository) GetByID(id uuid.UUID) (*domainVoiceMemo.Memo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	memo, ok := m.memos[id]
+	if !ok {
+		return nil, errors.New("voice memo not found")
+	}
+	copied := *memo
+	return &copied, nil
+}
+
+func (m *mockVoiceMemoRepository) GetBySchedule(scheduleID uuid.UUID) (*[]domainVoiceMemo.Memo, error) {
+	return &[]domainVoiceMemo.Memo{}, nil
+}
+
+func (m *mockVoiceMemoRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainVoiceMemo.Memo, error) {
+	m.mu.Lock()
+	memo := m.memos[id]
+	if status, ok := updates["status"].(string); ok {
+		memo.Status = status
+	}
+	if transcript, ok := updates["transcript"].(*string); ok {
+		memo.Transcript = transcript
+	}
+	if reason, ok := updates["failure_reason"].(*string); ok {
+		memo.FailureReason = reason
+	}
+	m.mu.Unlock()
+	return m.GetByID(id)
+}
+
+func (m *mockVoiceMemoRepository) SearchTranscripts(query string, limit int) (*[]domainVoiceMemo.Memo, error) {
+	return &[]domainVoiceMemo.Memo{}, nil
+}
+
+// mockScheduleRepository only implements the calls the voice memo use case makes
+type mockScheduleRepository struct {
+	domainSchedule.IScheduleRepository
+	schedule *domainSchedule.Schedule
+}
+
+func (m *mockScheduleRepository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	return m.schedule, nil
+}
+
+func (m *mockScheduleRepository) UpdateSchedule(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+	if note, ok := updates["service_note"].(string); ok {
+		m.schedule.ServiceNote = &note
+	}
+	return m.schedule, nil
+}
+
+type mockStorage struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func (m *mockStorage) Save(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[key] = data
+	return nil
+}
+
+func (m *mockStorage) Open(key string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return io.NopCloser(bytes.NewReader(m.files[key])), nil
+}
+
+func (m *mockStorage) Delete(key string) error {
+	return nil
+}
+
+type mockTranscriber struct {
+	text string
+	err  error
+}
+
+func (m *mockTranscriber) Transcribe(ctx context.Context, audio io.Reader, contentType string) (string, error) {
+	return m.text, m.err
+}
+
+func setupTestVoiceMemoUseCase(t *testing.T, transcriber *mockTranscriber, schedule *domainSchedule.Schedule) (*VoiceMemoUseCase, *mockVoiceMemoRepository) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	memoRepo := &mockVoiceMemoRepository{memos: map[uuid.UUID]*domainVoiceMemo.Memo{}}
+	useCase := NewVoiceMemoUseCase(memoRepo, &mockScheduleRepository{schedule: schedule}, &mockStorage{files: map[string][]byte{}}, transcriber, loggerInstance)
+	return useCase.(*VoiceMemoUseCase), memoRepo
+}
+
+func TestUploadTranscribesIntoServiceNote(t *testing.T) {
+	caregiverID := uuid.New()
+	note := "Client was in good spirits."
+	schedule := &domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiverID, VisitStatus: "completed", ServiceNote: &note}
+	useCase, memoRepo := setupTestVoiceMemoUseCase(t, &mockTranscriber{text: " Refilled water jug. "}, schedule)
+
+	memo, err := useCase.Upload(&domainVoiceMemo.Memo{
+		ScheduleID:       schedule.ID,
+		UploadedByUserID: caregiverID,
+		ContentType:      "audio/mp4",
+		Size:             5,
+	}, strings.NewReader("audio"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if memo.Status != domainVoiceMemo.StatusPending {
+		t.Errorf("expected pending status, got %s", memo.Status)
+	}
+	useCase.wg.Wait()
+
+	stored, _ := memoRepo.GetByID(memo.ID)
+	if stored.Status != domainVoiceMemo.StatusTranscribed || stored.Transcript == nil || *stored.Transcript != "Refilled water jug." {
+		t.Errorf("expected transcribed memo, got %+v", stored)
+	}
+	expectedNote := "Client was in good spirits.\n\n[Voice memo] Refilled water jug."
+	if schedule.ServiceNote == nil || *schedule.ServiceNote != expectedNote {
+		t.Errorf("expected service note %q, got %v", expectedNote, schedule.ServiceNote)
+	}
+}
+
+func TestUploadTranscriptionFailure(t *testing.T) {
+	caregiverID := uuid.New()
+	schedule := &domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiverID, VisitStatus: "in_progress"}
+	useCase, memoRepo := setupTestVoiceMemoUseCase(t, &mockTranscriber{err: errors.New("provider down")}, schedule)
+
+	memo, err := useCase.Upload(&domainVoiceMemo.Memo{
+		ScheduleID:       schedule.ID,
+		UploadedByUserID: caregiverID,
+		ContentType:      "audio/mpeg",
+		Size:             5,
+	}, strings.NewReader("audio"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	useCase.wg.Wait()
+
+	stored, _ := memoRepo.GetByID(memo.ID)
+	if stored.Status != domainVoiceMemo.StatusFailed || stored.FailureReason == nil {
+		t.Errorf("expected failed memo with reason, got %+v", stored)
+	}
+	if schedule.ServiceNote != nil {
+		t.Errorf("expected service note untouched, got %s", *schedule.ServiceNote)
+	}
+}
+
+func TestUploadValidation(t *testing.T) {
+	caregiverID := uuid.New()
+	schedule := &domainSchedule.Schedule{ID: uuid.New(), AssignedUserID: caregiverID, VisitStatus: "upcoming"}
+	useCase, _ := setupTestVoiceMemoUseCase(t, &mockTranscriber{}, schedule)
+
+	tests := []struct {
+		name   string
+		memo   domainVoiceMemo.Memo
+		status string
+	}{
+		{"Before check-in", domainVoiceMemo.Memo{UploadedByUserID: caregiverID, ContentType: "audio/mpeg", Size: 5}, "upcoming"},
+		{"Other caregiver", domainVoiceMemo.Memo{UploadedByUserID: uuid.New(), ContentType: "audio/mpeg", Size: 5}, "completed"},
+		{"Unsupported type", domainVoiceMemo.Memo{UploadedByUserID: caregiverID, ContentType: "video/mp4", Size: 5}, "completed"},
+		{"Too large", domainVoiceMemo.Memo{UploadedByUserID: caregiverID, ContentType: "audio/mpeg", Size: MaxMemoSize + 1}, "completed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule.VisitStatus = tt.status
+			memo := tt.memo
+			memo.ScheduleID = schedule.ID
+			if _, err := useCase.Upload(&memo, strings.NewReader("audio")); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
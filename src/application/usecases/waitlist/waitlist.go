@@ -0,0 +1,186 @@
+package waitlist
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	suggestionUseCase "caregiver/src/application/usecases/suggestion"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	domainWaitlist "caregiver/src/domain/waitlist"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IWaitlistUseCase interface {
+	Request(newEntry *domainWaitlist.Entry) (*domainWaitlist.Entry, []domainSchedule.CaregiverSuggestion, error)
+	GetByID(id uuid.UUID) (*domainWaitlist.Entry, error)
+	GetAll(status string) (*[]domainWaitlist.Entry, error)
+	UpdateStatus(id uuid.UUID, status string) (*domainWaitlist.Entry, error)
+	Rematch() (int, error)
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+type WaitlistUseCase struct {
+	waitlistRepository domainWaitlist.IWaitlistRepository
+	userRepository     domainUser.IUserRepository
+	suggestions        suggestionUseCase.ISuggestionService
+	notifier           notification.INotifier
+	Logger             *logger.Logger
+}
+
+func NewWaitlistUseCase(waitlistRepository domainWaitlist.IWaitlistRepository, userRepository domainUser.IUserRepository, suggestions suggestionUseCase.ISuggestionService, notifier notification.INotifier, loggerInstance *logger.Logger) IWaitlistUseCase {
+	return &WaitlistUseCase{
+		waitlistRepository: waitlistRepository,
+		userRepository:     userRepository,
+		suggestions:        suggestions,
+		notifier:           notifier,
+		Logger:             loggerInstance,
+	}
+}
+
+// Request checks whether a visit can be staffed right away. If caregivers are
+// free the candidates are returned and nothing is stored; otherwise the
+// request is put on the waitlist.
+func (u *WaitlistUseCase) Request(newEntry *domainWaitlist.Entry) (*domainWaitlist.Entry, []domainSchedule.CaregiverSuggestion, error) {
+	u.Logger.Info("Handling visit request", zap.String("clientUserID", newEntry.ClientUserID.String()))
+
+	if _, err := u.userRepository.GetByID(newEntry.ClientUserID); err != nil {
+		u.Logger.Error("Client user not found for visit request", zap.Error(err), zap.String("clientUserID", newEntry.ClientUserID.String()))
+		return nil, nil, domainErrors.NewAppError(errors.New("client user not found"), domainErrors.NotFound)
+	}
+	if !newEntry.RequestedFrom.Before(newEntry.RequestedTo) {
+		return nil, nil, domainErrors.NewAppError(errors.New("requested from must be before requested to"), domainErrors.ValidationError)
+	}
+
+	candidates, err := u.suggestions.AvailableCaregivers(newEntry.ClientUserID, newEntry.RequestedFrom, newEntry.RequestedTo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(candidates) > 0 {
+		return nil, candidates, nil
+	}
+
+	newEntry.ID = uuid.New()
+	newEntry.Status = domainWaitlist.StatusWaiting
+	newEntry.Candidates = nil
+	u.Logger.Info("No caregiver available, adding request to waitlist", zap.String("clientUserID", newEntry.ClientUserID.String()))
+	created, err := u.waitlistRepository.Create(newEntry)
+	if err != nil {
+		return nil, nil, err
+	}
+	return created, nil, nil
+}
+
+func (u *WaitlistUseCase) GetByID(id uuid.UUID) (*domainWaitlist.Entry, error) {
+	u.Logger.Info("Getting waitlist entry by ID", zap.String("id", id.String()))
+	return u.waitlistRepository.GetByID(id)
+}
+
+func (u *WaitlistUseCase) GetAll(status string) (*[]domainWaitlist.Entry, error) {
+	u.Logger.Info("Getting waitlist entries", zap.String("status", status))
+	return u.waitlistRepository.GetAll(status)
+}
+
+// UpdateStatus lets coordinators close an entry once it has been booked or
+// withdrawn, or put a stale match back to waiting.
+func (u *WaitlistUseCase) UpdateStatus(id uuid.UUID, status string) (*domainWaitlist.Entry, error) {
+	u.Logger.Info("Updating waitlist entry status", zap.String("id", id.String()), zap.String("status", status))
+	switch status {
+	case domainWaitlist.StatusWaiting, domainWaitlist.StatusFulfilled, domainWaitlist.StatusWithdrawn:
+	default:
+		return nil, domainErrors.NewAppError(errors.New("status must be 'waiting', 'fulfilled' or 'withdrawn'"), domainErrors.ValidationError)
+	}
+	if _, err := u.waitlistRepository.GetByID(id); err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{"status": status}
+	if status == domainWaitlist.StatusWaiting {
+		updates["matched_at"] = nil
+	}
+	return u.waitlistRepository.Update(id, updates)
+}
+
+// Rematch re-checks every waiting entry, e.g. after caregivers are added or
+// their availability changes. It returns the number of new matches.
+func (u *WaitlistUseCase) Rematch() (int, error) {
+	entries, err := u.waitlistRepository.GetAll(domainWaitlist.StatusWaiting)
+	if err != nil {
+		return 0, err
+	}
+	return u.match(entries), nil
+}
+
+// OnScheduleEvent re-checks waiting entries whenever caregiver time is freed
+// by a cancellation or a rescheduled visit.
+func (u *WaitlistUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	var freed *domainSchedule.Schedule
+	switch event.Type {
+	case domainSchedule.EventCancelled, domainSchedule.EventDeleted:
+		freed = event.Schedule
+	case domainSchedule.EventUpdated:
+		if event.Previous != nil && (event.Previous.AssignedUserID != event.Schedule.AssignedUserID ||
+			!event.Previous.ScheduledSlot.From.Equal(event.Schedule.ScheduledSlot.From) ||
+			!event.Previous.ScheduledSlot.To.Equal(event.Schedule.ScheduledSlot.To)) {
+			freed = event.Previous
+		}
+	}
+	if freed == nil {
+		return
+	}
+
+	entries, err := u.waitlistRepository.GetWaitingBetween(freed.ScheduledSlot.From, freed.ScheduledSlot.To)
+	if err != nil {
+		u.Logger.Error("Error loading waitlist for freed slot", zap.Error(err), zap.String("scheduleID", freed.ID.String()))
+		return
+	}
+	u.match(entries)
+}
+
+func (u *WaitlistUseCase) match(entries *[]domainWaitlist.Entry) int {
+	matched := 0
+	for _, entry := range *entries {
+		candidates, err := u.suggestions.AvailableCaregivers(entry.ClientUserID, entry.RequestedFrom, entry.RequestedTo)
+		if err != nil {
+			u.Logger.Error("Error finding caregivers for waitlist entry", zap.Error(err), zap.String("entryID", entry.ID.String()))
+			continue
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		updated, err := u.waitlistRepository.Update(entry.ID, map[string]interface{}{
+			"status":     domainWaitlist.StatusMatched,
+			"candidates": candidates,
+			"matched_at": time.Now().UTC(),
+		})
+		if err != nil {
+			continue
+		}
+		matched++
+		u.notifyMatch(updated, candidates)
+	}
+	return matched
+}
+
+func (u *WaitlistUseCase) notifyMatch(entry *domainWaitlist.Entry, candidates []domainSchedule.CaregiverSuggestion) {
+	err := u.notifier.Notify(notification.Message{
+		Role:    domainUser.RoleAdmin,
+		Subject: "Waitlisted visit can now be staffed",
+		Body: fmt.Sprintf("%d caregiver(s) are free for the %s request on %s.",
+			len(candidates), entry.ServiceName, entry.RequestedFrom.Format(time.RFC3339)),
+		Data: map[string]interface{}{
+			"waitlistEntryID": entry.ID,
+			"clientUserID":    entry.ClientUserID,
+			"candidates":      candidates,
+		},
+	})
+	if err != nil {
+		u.Logger.Error("Error notifying coordinators of waitlist match", zap.Error(err), zap.String("entryID", entry.ID.String()))
+	}
+}
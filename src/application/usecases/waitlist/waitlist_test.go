@@ -0,0 +1,212 @@
+package waitlist
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	suggestionUseCase "caregiver/src/application/usecases/suggestion"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	domainWaitlist "caregiver/src/domain/waitlist"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"github.com/google/uuid"
+)
+
+// mockWaitlistRepository keeps entries in memory
+type mockWaitlistRepository struct {
+	entries []domainWaitlist.Entry
+}
+
+func (m *mockWaitlistRepository) Create(newEntry *domainWaitlist.Entry) (*domainWaitlist.Entry, error) {
+	m.entries = append(m.entries, *newEntry)
+	return newEntry, nil
+}
+
+func (m *mockWaitlistRepository) GetByID(id uuid.UUID) (*domainWaitlist.Entry, error) {
+	for i := range m.entries {
+		if m.entries[i].ID == id {
+			return &m.entries[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *mockWaitlistRepository) GetAll(status string) (*[]domainWaitlist.Entry, error) {
+	res := []domainWaitlist.Entry{}
+	for _, e := range m.entries {
+		if status == "" || e.Status == status {
+			res = append(res, e)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockWaitlistRepository) GetWaitingBetween(from, to time.Time) (*[]domainWaitlist.Entry, error) {
+	res := []domainWaitlist.Entry{}
+	for _, e := range m.entries {
+		if e.Status == domainWaitlist.StatusWaiting && e.RequestedFrom.Before(to) && from.Before(e.RequestedTo) {
+			res = append(res, e)
+		}
+	}
+	return &res, nil
+}
+
+func (m *mockWaitlistRepository) Update(id uuid.UUID, updates map[string]interface{}) (*domainWaitlist.Entry, error) {
+	entry, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := updates["status"].(string); ok {
+		entry.Status = v
+	}
+	if v, ok := updates["candidates"].([]domainSchedule.CaregiverSuggestion); ok {
+		entry.Candidates = v
+	}
+	return entry, nil
+}
+
+// mockSuggestionService reports the configured caregivers as available
+type mockSuggestionService struct {
+	suggestionUseCase.ISuggestionService
+	available []domainSchedule.CaregiverSuggestion
+}
+
+func (m *mockSuggestionService) AvailableCaregivers(clientUserID uuid.UUID, from, to time.Time) ([]domainSchedule.CaregiverSuggestion, error) {
+	return m.available, nil
+}
+
+// mockUserRepository knows every user
+type mockUserRepository struct {
+	domainUser.IUserRepository
+}
+
+func (m *mockUserRepository) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("user not found")
+	}
+	return &domainUser.User{ID: id}, nil
+}
+
+// mockNotifier records sent messages
+type mockNotifier struct {
+	messages []notification.Message
+}
+
+func (m *mockNotifier) Notify(message notification.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+var slotStart = time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+
+func setupTestWaitlistUseCase(t *testing.T) (IWaitlistUseCase, *mockWaitlistRepository, *mockSuggestionService, *mockNotifier) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	repo := &mockWaitlistRepository{}
+	suggestions := &mockSuggestionService{}
+	notifier := &mockNotifier{}
+	return NewWaitlistUseCase(repo, &mockUserRepository{}, suggestions, notifier, loggerInstance), repo, suggestions, notifier
+}
+
+func newRequest() *domainWaitlist.Entry {
+	return &domainWaitlist.Entry{
+		ClientUserID:  uuid.New(),
+		ServiceName:   "Personal care",
+		RequestedFrom: slotStart,
+		RequestedTo:   slotStart.Add(time.Hour),
+	}
+}
+
+func TestRequest(t *testing.T) {
+	t.Run("Returns candidates when servable", func(t *testing.T) {
+		useCase, repo, suggestions, _ := setupTestWaitlistUseCase(t)
+		suggestions.available = []domainSchedule.CaregiverSuggestion{{UserID: uuid.New()}}
+
+		entry, candidates, err := useCase.Request(newRequest())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if entry != nil || len(candidates) != 1 || len(repo.entries) != 0 {
+			t.Errorf("expected candidates without waitlisting, got entry %+v", entry)
+		}
+	})
+
+	t.Run("Waitlists when no caregiver is free", func(t *testing.T) {
+		useCase, repo, _, _ := setupTestWaitlistUseCase(t)
+
+		entry, _, err := useCase.Request(newRequest())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if entry == nil || entry.Status != domainWaitlist.StatusWaiting || len(repo.entries) != 1 {
+			t.Errorf("expected waiting entry, got %+v", entry)
+		}
+	})
+
+	t.Run("Rejects inverted slot", func(t *testing.T) {
+		useCase, _, _, _ := setupTestWaitlistUseCase(t)
+		request := newRequest()
+		request.RequestedTo = request.RequestedFrom
+
+		if _, _, err := useCase.Request(request); err == nil {
+			t.Error("expected validation error, got nil")
+		}
+	})
+}
+
+func TestOnScheduleEvent(t *testing.T) {
+	useCase, repo, suggestions, notifier := setupTestWaitlistUseCase(t)
+	entry, _, _ := useCase.Request(newRequest())
+	later := newRequest()
+	later.RequestedFrom, later.RequestedTo = slotStart.Add(48*time.Hour), slotStart.Add(49*time.Hour)
+	useCase.Request(later)
+
+	suggestions.available = []domainSchedule.CaregiverSuggestion{{UserID: uuid.New(), FirstName: "Ada"}}
+	cancelled := &domainSchedule.Schedule{
+		ID:            uuid.New(),
+		VisitStatus:   "cancelled",
+		ScheduledSlot: domainSchedule.ScheduledSlot{From: slotStart.Add(-30 * time.Minute), To: slotStart.Add(30 * time.Minute)},
+	}
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCancelled, Schedule: cancelled})
+
+	matched, _ := repo.GetByID(entry.ID)
+	if matched.Status != domainWaitlist.StatusMatched || len(matched.Candidates) != 1 {
+		t.Errorf("expected overlapping entry to be matched, got %+v", matched)
+	}
+	if repo.entries[1].Status != domainWaitlist.StatusWaiting {
+		t.Errorf("expected entry outside the freed slot to keep waiting, got %s", repo.entries[1].Status)
+	}
+	if len(notifier.messages) != 1 || notifier.messages[0].Role != domainUser.RoleAdmin {
+		t.Errorf("expected one coordinator notification, got %+v", notifier.messages)
+	}
+
+	count, err := useCase.Rematch()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 1 || len(notifier.messages) != 2 {
+		t.Errorf("expected rematch to match the remaining entry, got %d", count)
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	useCase, _, _, _ := setupTestWaitlistUseCase(t)
+	entry, _, _ := useCase.Request(newRequest())
+
+	if _, err := useCase.UpdateStatus(entry.ID, domainWaitlist.StatusMatched); err == nil {
+		t.Error("expected validation error for matched status, got nil")
+	}
+	updated, err := useCase.UpdateStatus(entry.ID, domainWaitlist.StatusFulfilled)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Status != domainWaitlist.StatusFulfilled {
+		t.Errorf("expected fulfilled, got %s", updated.Status)
+	}
+}
@@ -0,0 +1,515 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainWebhook "caregiver/src/domain/webhook"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/webhook"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxAttempts is how many delivery tries an event gets before it is
+	// marked failed and needs a manual redelivery.
+	MaxAttempts = 8
+	// RetryBaseDelay is the wait after the first failed try; it doubles with
+	// each further try up to RetryMaxDelay.
+	RetryBaseDelay = 30 * time.Second
+	RetryMaxDelay  = 2 * time.Hour
+	// SweepBatchSize bounds how many deliveries one sweep sends.
+	SweepBatchSize = 100
+	// MaxDigestEvents bounds the events carried by one digest; the rest go
+	// out in the next one, on the following sweep.
+	MaxDigestEvents = 500
+	// MinSecretLength is the shortest signing secret accepted.
+	MinSecretLength = 16
+
+	secretPrefix = "whsec_"
+)
+
+// scheduleEventTypes maps visit lifecycle events to webhook event types.
+var scheduleEventTypes = map[string]string{
+	domainSchedule.EventCreated:   domainWebhook.EventVisitCreated,
+	domainSchedule.EventUpdated:   domainWebhook.EventVisitUpdated,
+	domainSchedule.EventStarted:   domainWebhook.EventVisitCheckedIn,
+	domainSchedule.EventCompleted: domainWebhook.EventVisitCheckedOut,
+	domainSchedule.EventCancelled: domainWebhook.EventVisitCancelled,
+	domainSchedule.EventMissed:    domainWebhook.EventVisitMissed,
+	domainSchedule.EventDeleted:   domainWebhook.EventVisitDeleted,
+}
+
+type IWebhookUseCase interface {
+	// CreateSubscription generates a signing secret when none is given.
+	CreateSubscription(subscription *domainWebhook.Subscription) (*domainWebhook.Subscription, error)
+	GetSubscriptions() (*[]domainWebhook.Subscription, error)
+	GetSubscriptionByID(id uuid.UUID) (*domainWebhook.Subscription, error)
+	UpdateSubscription(id uuid.UUID, updates map[string]interface{}) (*domainWebhook.Subscription, error)
+	// RotateSecret replaces the signing secret with a generated one.
+	RotateSecret(id uuid.UUID) (*domainWebhook.Subscription, error)
+	DeleteSubscription(id uuid.UUID) error
+	// Ping queues a test event for the subscription.
+	Ping(id uuid.UUID, now time.Time) (*domainWebhook.Delivery, error)
+	GetDeliveries(filter domainWebhook.DeliveryFilter) (*[]domainWebhook.Delivery, error)
+	GetDelivery(id uuid.UUID) (*domainWebhook.Delivery, error)
+	// Redeliver queues a delivery again with a fresh set of retries.
+	Redeliver(id uuid.UUID) (*domainWebhook.Delivery, error)
+	// Sweep queues the digests that are due, then sends the deliveries that
+	// are due and returns how many were attempted.
+	Sweep(now time.Time) (int, error)
+	// OnScheduleEvent queues the visit event for every active subscription
+	// that asked for it.
+	OnScheduleEvent(event domainSchedule.Event)
+}
+
+// CalendarSource supplies the organization's time zone.
+type CalendarSource interface {
+	Calendar(from, to time.Time) (*domainLocale.Calendar, error)
+}
+
+type WebhookUseCase struct {
+	webhookRepository domainWebhook.IWebhookRepository
+	sender            webhook.ISender
+	calendar          CalendarSource
+	Logger            *logger.Logger
+}
+
+type Option func(*WebhookUseCase)
+
+// WithCalendar closes digest windows on the organization's local midnight
+// rather than UTC's.
+func WithCalendar(source CalendarSource) Option {
+	return func(u *WebhookUseCase) {
+		u.calendar = source
+	}
+}
+
+func NewWebhookUseCase(webhookRepository domainWebhook.IWebhookRepository, sender webhook.ISender, loggerInstance *logger.Logger, opts ...Option) IWebhookUseCase {
+	useCase := &WebhookUseCase{
+		webhookRepository: webhookRepository,
+		sender:            sender,
+		Logger:            loggerInstance,
+	}
+	for _, opt := range opts {
+		opt(useCase)
+	}
+	return useCase
+}
+
+// payload is the JSON body of every webhook. Data holds the event subject.
+type payload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+// digestData is the Data of a digest: the held event payloads, oldest first.
+type digestData struct {
+	SubscriptionID string            `json:"subscriptionId"`
+	Count          int               `json:"count"`
+	Events         []json.RawMessage `json:"events"`
+}
+
+type visitData struct {
+	VisitID            string     `json:"visitId"`
+	ClientID           string     `json:"clientId"`
+	CaregiverID        string     `json:"caregiverId"`
+	ServiceName        string     `json:"serviceName"`
+	Status             string     `json:"status"`
+	ScheduledStart     time.Time  `json:"scheduledStart"`
+	ScheduledEnd       time.Time  `json:"scheduledEnd"`
+	CheckinTime        *time.Time `json:"checkinTime,omitempty"`
+	CheckoutTime       *time.Time `json:"checkoutTime,omitempty"`
+	CheckinLatitude    *float64   `json:"checkinLatitude,omitempty"`
+	CheckinLongitude   *float64   `json:"checkinLongitude,omitempty"`
+	CheckoutLatitude   *float64   `json:"checkoutLatitude,omitempty"`
+	CheckoutLongitude  *float64   `json:"checkoutLongitude,omitempty"`
+	VerificationMethod string     `json:"verificationMethod,omitempty"`
+	WorkedMinutes      int        `json:"workedMinutes,omitempty"`
+}
+
+func (u *WebhookUseCase) CreateSubscription(subscription *domainWebhook.Subscription) (*domainWebhook.Subscription, error) {
+	if subscription.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			u.Logger.Error("Error generating webhook secret", zap.Error(err))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		subscription.Secret = secret
+	}
+	if err := validateSubscription(subscription); err != nil {
+		return nil, err
+	}
+	u.Logger.Info("Creating webhook subscription", zap.String("url", subscription.URL), zap.Strings("eventTypes", subscription.EventTypes))
+	return u.webhookRepository.CreateSubscription(subscription)
+}
+
+func (u *WebhookUseCase) GetSubscriptions() (*[]domainWebhook.Subscription, error) {
+	return u.webhookRepository.GetSubscriptions()
+}
+
+func (u *WebhookUseCase) GetSubscriptionByID(id uuid.UUID) (*domainWebhook.Subscription, error) {
+	return u.webhookRepository.GetSubscriptionByID(id)
+}
+
+func (u *WebhookUseCase) UpdateSubscription(id uuid.UUID, updates map[string]interface{}) (*domainWebhook.Subscription, error) {
+	u.Logger.Info("Updating webhook subscription", zap.String("id", id.String()))
+	existing, err := u.webhookRepository.GetSubscriptionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	merged := *existing
+	if v, ok := updates["name"].(string); ok {
+		merged.Name = v
+	}
+	if v, ok := updates["url"].(string); ok {
+		merged.URL = v
+	}
+	if v, ok := updates["secret"].(string); ok {
+		merged.Secret = v
+	}
+	if v, ok := updates["event_types"].([]string); ok {
+		merged.EventTypes = v
+	}
+	if v, ok := updates["digest_minutes"].(int); ok {
+		merged.DigestMinutes = v
+	}
+	if err := validateSubscription(&merged); err != nil {
+		return nil, err
+	}
+	return u.webhookRepository.UpdateSubscription(id, updates)
+}
+
+func (u *WebhookUseCase) RotateSecret(id uuid.UUID) (*domainWebhook.Subscription, error) {
+	if _, err := u.webhookRepository.GetSubscriptionByID(id); err != nil {
+		return nil, err
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		u.Logger.Error("Error generating webhook secret", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	u.Logger.Info("Rotating webhook secret", zap.String("id", id.String()))
+	return u.webhookRepository.UpdateSubscription(id, map[string]interface{}{"secret": secret})
+}
+
+func (u *WebhookUseCase) DeleteSubscription(id uuid.UUID) error {
+	u.Logger.Info("Deleting webhook subscription", zap.String("id", id.String()))
+	return u.webhookRepository.DeleteSubscription(id)
+}
+
+func (u *WebhookUseCase) Ping(id uuid.UUID, now time.Time) (*domainWebhook.Delivery, error) {
+	subscription, err := u.webhookRepository.GetSubscriptionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := u.enqueue([]domainWebhook.Subscription{*subscription}, domainWebhook.EventPing, now, map[string]string{"subscriptionId": id.String()})
+	if err != nil {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+func (u *WebhookUseCase) GetDeliveries(filter domainWebhook.DeliveryFilter) (*[]domainWebhook.Delivery, error) {
+	return u.webhookRepository.GetDeliveries(filter)
+}
+
+func (u *WebhookUseCase) GetDelivery(id uuid.UUID) (*domainWebhook.Delivery, error) {
+	return u.webhookRepository.GetDeliveryByID(id)
+}
+
+func (u *WebhookUseCase) Redeliver(id uuid.UUID) (*domainWebhook.Delivery, error) {
+	delivery, err := u.webhookRepository.GetDeliveryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status == domainWebhook.StatusQueued || delivery.Status == domainWebhook.StatusHeld {
+		return delivery, nil
+	}
+	u.Logger.Info("Redelivering webhook", zap.String("id", id.String()))
+	return u.webhookRepository.UpdateDelivery(id, map[string]interface{}{
+		"status":          domainWebhook.StatusQueued,
+		"attempts":        0,
+		"next_attempt_at": nil,
+		"last_error":      "",
+	})
+}
+
+func (u *WebhookUseCase) OnScheduleEvent(event domainSchedule.Event) {
+	eventType, ok := scheduleEventTypes[event.Type]
+	if !ok {
+		return
+	}
+	subscriptions, err := u.webhookRepository.GetActiveSubscriptions()
+	if err != nil {
+		u.Logger.Error("Error getting webhook subscriptions", zap.Error(err), zap.String("event", eventType))
+		return
+	}
+	var wanted []domainWebhook.Subscription
+	for _, subscription := range *subscriptions {
+		if subscription.Wants(eventType) {
+			wanted = append(wanted, subscription)
+		}
+	}
+	if len(wanted) == 0 {
+		return
+	}
+	if _, err := u.enqueue(wanted, eventType, time.Now().UTC(), toVisitData(event.Schedule)); err != nil {
+		u.Logger.Error("Error queueing webhook deliveries", zap.Error(err), zap.String("event", eventType), zap.String("scheduleID", event.Schedule.ID.String()))
+	}
+}
+
+func (u *WebhookUseCase) Sweep(now time.Time) (int, error) {
+	if err := u.queueDigests(now); err != nil {
+		return 0, err
+	}
+	deliveries, err := u.webhookRepository.GetDueDeliveries(now, SweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	subscriptions := map[uuid.UUID]*domainWebhook.Subscription{}
+	attempted := 0
+	for _, delivery := range *deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = u.webhookRepository.GetSubscriptionByID(delivery.SubscriptionID)
+			if err != nil && !isNotFound(err) {
+				u.Logger.Error("Error getting webhook subscription", zap.Error(err), zap.String("deliveryID", delivery.ID.String()))
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+		if err := u.deliver(&delivery, subscription, now); err != nil {
+			u.Logger.Error("Error recording webhook delivery", zap.Error(err), zap.String("deliveryID", delivery.ID.String()))
+			continue
+		}
+		attempted++
+	}
+	if attempted > 0 {
+		u.Logger.Info("Webhook delivery sweep completed", zap.Int("attempted", attempted))
+	}
+	return attempted, nil
+}
+
+// queueDigests gathers the held events of each subscription whose digest is
+// due into one digest delivery. Events of a paused subscription stay held
+// until it is active again.
+func (u *WebhookUseCase) queueDigests(now time.Time) error {
+	heldSince, err := u.webhookRepository.GetHeldSince()
+	if err != nil {
+		return err
+	}
+	loc := time.UTC
+	if u.calendar != nil && len(heldSince) > 0 {
+		calendar, err := u.calendar.Calendar(now, now)
+		if err != nil {
+			u.Logger.Error("Error getting organization calendar for digests", zap.Error(err))
+		} else {
+			loc = calendar.Location
+		}
+	}
+	for subscriptionID, since := range heldSince {
+		subscription, err := u.webhookRepository.GetSubscriptionByID(subscriptionID)
+		if err != nil {
+			u.Logger.Error("Error getting webhook subscription", zap.Error(err), zap.String("subscriptionID", subscriptionID.String()))
+			continue
+		}
+		if !subscription.Active || !subscription.DigestDue(since, now, loc) {
+			continue
+		}
+		if err := u.queueDigest(subscription, now); err != nil {
+			u.Logger.Error("Error queueing webhook digest", zap.Error(err), zap.String("subscriptionID", subscriptionID.String()))
+		}
+	}
+	return nil
+}
+
+func (u *WebhookUseCase) queueDigest(subscription *domainWebhook.Subscription, now time.Time) error {
+	held, err := u.webhookRepository.GetHeldDeliveries(subscription.ID, MaxDigestEvents)
+	if err != nil || len(*held) == 0 {
+		return err
+	}
+	data := digestData{SubscriptionID: subscription.ID.String(), Count: len(*held), Events: make([]json.RawMessage, len(*held))}
+	ids := make([]uuid.UUID, len(*held))
+	for i, delivery := range *held {
+		data.Events[i] = json.RawMessage(delivery.Payload)
+		ids[i] = delivery.ID
+	}
+	eventID := uuid.New()
+	body, err := json.Marshal(payload{ID: eventID.String(), Type: domainWebhook.EventDigest, CreatedAt: now, Data: data})
+	if err != nil {
+		return err
+	}
+	digest, err := u.webhookRepository.CreateDigest(&domainWebhook.Delivery{
+		SubscriptionID: subscription.ID,
+		EventID:        eventID,
+		EventType:      domainWebhook.EventDigest,
+		Payload:        string(body),
+		Status:         domainWebhook.StatusQueued,
+	}, ids)
+	if err != nil {
+		return err
+	}
+	u.Logger.Info("Webhook digest queued", zap.String("subscriptionID", subscription.ID.String()), zap.String("deliveryID", digest.ID.String()), zap.Int("events", len(ids)))
+	return nil
+}
+
+// deliver sends one delivery and records the outcome, scheduling a retry
+// after a failure until MaxAttempts is reached.
+func (u *WebhookUseCase) deliver(delivery *domainWebhook.Delivery, subscription *domainWebhook.Subscription, now time.Time) error {
+	attempts := delivery.Attempts + 1
+	updates := map[string]interface{}{
+		"attempts":        attempts,
+		"last_attempt_at": now,
+	}
+	if subscription == nil || !subscription.Active {
+		updates["status"] = domainWebhook.StatusFailed
+		updates["next_attempt_at"] = nil
+		updates["last_error"] = "the subscription is no longer active"
+		_, err := u.webhookRepository.UpdateDelivery(delivery.ID, updates)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	status, sendErr := u.sender.Send(ctx, webhook.Request{
+		URL:       subscription.URL,
+		Secret:    subscription.Secret,
+		ID:        delivery.ID.String(),
+		EventType: delivery.EventType,
+		Body:      []byte(delivery.Payload),
+	}, now)
+	updates["response_status"] = status
+	switch {
+	case sendErr == nil:
+		updates["status"] = domainWebhook.StatusDelivered
+		updates["delivered_at"] = now
+		updates["next_attempt_at"] = nil
+		updates["last_error"] = ""
+	case attempts >= MaxAttempts:
+		updates["status"] = domainWebhook.StatusFailed
+		updates["next_attempt_at"] = nil
+		updates["last_error"] = sendErr.Error()
+		u.Logger.Warn("Webhook delivery failed", zap.String("deliveryID", delivery.ID.String()), zap.Error(sendErr))
+	default:
+		updates["next_attempt_at"] = now.Add(retryDelay(attempts))
+		updates["last_error"] = sendErr.Error()
+	}
+	_, err := u.webhookRepository.UpdateDelivery(delivery.ID, updates)
+	return err
+}
+
+// enqueue queues one delivery of the event per subscription, or holds it for
+// the next digest of a subscription that receives digests. The event ID is
+// shared so receivers can tell deliveries of the same event apart from
+// retries.
+func (u *WebhookUseCase) enqueue(subscriptions []domainWebhook.Subscription, eventType string, now time.Time, data interface{}) ([]domainWebhook.Delivery, error) {
+	eventID := uuid.New()
+	body, err := json.Marshal(payload{ID: eventID.String(), Type: eventType, CreatedAt: now, Data: data})
+	if err != nil {
+		u.Logger.Error("Error encoding webhook payload", zap.Error(err), zap.String("event", eventType))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	deliveries := make([]domainWebhook.Delivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		status := domainWebhook.StatusQueued
+		if subscription.Batched() && eventType != domainWebhook.EventPing {
+			status = domainWebhook.StatusHeld
+		}
+		deliveries[i] = domainWebhook.Delivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        string(body),
+			Status:         status,
+		}
+	}
+	if err := u.webhookRepository.CreateDeliveries(deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func toVisitData(schedule *domainSchedule.Schedule) visitData {
+	return visitData{
+		VisitID:            schedule.ID.String(),
+		ClientID:           schedule.ClientUserID.String(),
+		CaregiverID:        schedule.AssignedUserID.String(),
+		ServiceName:        schedule.ServiceName,
+		Status:             schedule.VisitStatus,
+		ScheduledStart:     schedule.ScheduledSlot.From,
+		ScheduledEnd:       schedule.ScheduledSlot.To,
+		CheckinTime:        schedule.CheckinTime,
+		CheckoutTime:       schedule.CheckoutTime,
+		CheckinLatitude:    schedule.CheckinLocation.Lat,
+		CheckinLongitude:   schedule.CheckinLocation.Long,
+		CheckoutLatitude:   schedule.CheckoutLocation.Lat,
+		CheckoutLongitude:  schedule.CheckoutLocation.Long,
+		VerificationMethod: schedule.CheckinVerification.Method,
+		WorkedMinutes:      int(schedule.WorkedDuration().Minutes()),
+	}
+}
+
+func validateSubscription(subscription *domainWebhook.Subscription) error {
+	subscription.Name = strings.TrimSpace(subscription.Name)
+	if subscription.Name == "" {
+		return domainErrors.NewAppError(errors.New("name is required"), domainErrors.ValidationError)
+	}
+	parsed, err := url.Parse(subscription.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return domainErrors.NewAppError(errors.New("url must be an absolute http(s) URL"), domainErrors.ValidationError)
+	}
+	if len(subscription.Secret) < MinSecretLength {
+		return domainErrors.NewAppError(fmt.Errorf("secret must be at least %d characters", MinSecretLength), domainErrors.ValidationError)
+	}
+	if len(subscription.EventTypes) == 0 {
+		return domainErrors.NewAppError(errors.New("at least one event type is required"), domainErrors.ValidationError)
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !domainWebhook.IsEventType(eventType) {
+			return domainErrors.NewAppError(fmt.Errorf("event types must be among %s", strings.Join(domainWebhook.EventTypes, ", ")), domainErrors.ValidationError)
+		}
+	}
+	if subscription.DigestMinutes != 0 && !domainWebhook.IsDigestInterval(subscription.DigestMinutes) {
+		return domainErrors.NewAppError(fmt.Errorf("digest minutes must be 0 for realtime delivery, or divide a day and be between %d and %d", domainWebhook.MinDigestMinutes, domainWebhook.MaxDigestMinutes), domainErrors.ValidationError)
+	}
+	return nil
+}
+
+func retryDelay(attempts int) time.Duration {
+	delay := RetryBaseDelay
+	for i := 1; i < attempts && delay < RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > RetryMaxDelay {
+		delay = RetryMaxDelay
+	}
+	return delay
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(buf), nil
+}
+
+func isNotFound(err error) bool {
+	var appErr *domainErrors.AppError
+	return errors.As(err, &appErr) && appErr.Type == domainErrors.NotFound
+}
@@ -0,0 +1,258 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainWebhook "caregiver/src/domain/webhook"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/webhook"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository keeps subscriptions and deliveries in memory
+type memoryRepository struct {
+	domainWebhook.IWebhookRepository
+	subscriptions []domainWebhook.Subscription
+	deliveries    []domainWebhook.Delivery
+}
+
+func (m *memoryRepository) GetActiveSubscriptions() (*[]domainWebhook.Subscription, error) {
+	var res []domainWebhook.Subscription
+	for _, s := range m.subscriptions {
+		if s.Active {
+			res = append(res, s)
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) GetSubscriptionByID(id uuid.UUID) (*domainWebhook.Subscription, error) {
+	for i := range m.subscriptions {
+		if m.subscriptions[i].ID == id {
+			return &m.subscriptions[i], nil
+		}
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *memoryRepository) CreateDeliveries(deliveries []domainWebhook.Delivery) error {
+	for _, d := range deliveries {
+		d.ID = uuid.New()
+		m.deliveries = append(m.deliveries, d)
+	}
+	return nil
+}
+
+func (m *memoryRepository) GetDueDeliveries(now time.Time, limit int) (*[]domainWebhook.Delivery, error) {
+	var res []domainWebhook.Delivery
+	for _, d := range m.deliveries {
+		if d.Status == domainWebhook.StatusQueued && (d.NextAttemptAt == nil || !d.NextAttemptAt.After(now)) {
+			res = append(res, d)
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) UpdateDelivery(id uuid.UUID, updates map[string]interface{}) (*domainWebhook.Delivery, error) {
+	for i := range m.deliveries {
+		d := &m.deliveries[i]
+		if d.ID != id {
+			continue
+		}
+		if v, ok := updates["status"].(string); ok {
+			d.Status = v
+		}
+		if v, ok := updates["attempts"].(int); ok {
+			d.Attempts = v
+		}
+		if v, ok := updates["last_error"].(string); ok {
+			d.LastError = v
+		}
+		if v, ok := updates["next_attempt_at"]; ok {
+			if at, ok := v.(time.Time); ok {
+				d.NextAttemptAt = &at
+			} else {
+				d.NextAttemptAt = nil
+			}
+		}
+		return d, nil
+	}
+	return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+}
+
+func (m *memoryRepository) GetHeldSince() (map[uuid.UUID]time.Time, error) {
+	res := map[uuid.UUID]time.Time{}
+	for _, d := range m.deliveries {
+		if since, ok := res[d.SubscriptionID]; d.Status == domainWebhook.StatusHeld && (!ok || d.CreatedAt.Before(since)) {
+			res[d.SubscriptionID] = d.CreatedAt
+		}
+	}
+	return res, nil
+}
+
+func (m *memoryRepository) GetHeldDeliveries(subscriptionID uuid.UUID, limit int) (*[]domainWebhook.Delivery, error) {
+	var res []domainWebhook.Delivery
+	for _, d := range m.deliveries {
+		if d.SubscriptionID == subscriptionID && d.Status == domainWebhook.StatusHeld && len(res) < limit {
+			res = append(res, d)
+		}
+	}
+	return &res, nil
+}
+
+func (m *memoryRepository) CreateDigest(digest *domainWebhook.Delivery, batchedIDs []uuid.UUID) (*domainWebhook.Delivery, error) {
+	digest.ID = uuid.New()
+	for i := range m.deliveries {
+		for _, id := range batchedIDs {
+			if m.deliveries[i].ID == id {
+				m.deliveries[i].Status = domainWebhook.StatusBatched
+				m.deliveries[i].DigestID = &digest.ID
+			}
+		}
+	}
+	m.deliveries = append(m.deliveries, *digest)
+	return digest, nil
+}
+
+// scriptedSender fails until it has been called failures times
+type scriptedSender struct {
+	failures int
+	requests []webhook.Request
+}
+
+func (s *scriptedSender) Send(ctx context.Context, request webhook.Request, now time.Time) (int, error) {
+	s.requests = append(s.requests, request)
+	if len(s.requests) <= s.failures {
+		return 502, errors.New("bad gateway")
+	}
+	return 200, nil
+}
+
+func TestScheduleEventsAreDeliveredWithRetries(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	emr := domainWebhook.Subscription{ID: uuid.New(), Name: "EMR", URL: "https://emr.example/hooks", Secret: "whsec_0123456789abcdef",
+		EventTypes: []string{domainWebhook.EventVisitCheckedIn, domainWebhook.EventVisitCheckedOut}, Active: true}
+	paused := domainWebhook.Subscription{ID: uuid.New(), Name: "Old", URL: "https://old.example", Secret: "whsec_0123456789abcdef",
+		EventTypes: []string{domainWebhook.EventVisitCheckedIn}, Active: false}
+	repo := &memoryRepository{subscriptions: []domainWebhook.Subscription{emr, paused}}
+	sender := &scriptedSender{failures: 2}
+	useCase := NewWebhookUseCase(repo, sender, loggerInstance)
+
+	checkin := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	visit := &domainSchedule.Schedule{ID: uuid.New(), ServiceName: "Personal care", VisitStatus: "in_progress", CheckinTime: &checkin}
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCreated, Schedule: visit})
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit})
+	if len(repo.deliveries) != 1 || repo.deliveries[0].SubscriptionID != emr.ID {
+		t.Fatalf("expected one check-in delivery to the active subscription, got %+v", repo.deliveries)
+	}
+	var body payload
+	if err := json.Unmarshal([]byte(repo.deliveries[0].Payload), &body); err != nil || body.Type != domainWebhook.EventVisitCheckedIn {
+		t.Errorf("unexpected payload %s", repo.deliveries[0].Payload)
+	}
+
+	now := checkin.Add(time.Minute)
+	if n, _ := useCase.Sweep(now); n != 1 || repo.deliveries[0].Status != domainWebhook.StatusQueued || repo.deliveries[0].Attempts != 1 {
+		t.Fatalf("expected the failed delivery to be queued for a retry, got %+v", repo.deliveries[0])
+	}
+	if n, _ := useCase.Sweep(now); n != 0 {
+		t.Error("expected the retry to wait for its backoff")
+	}
+	now = *repo.deliveries[0].NextAttemptAt
+	_, _ = useCase.Sweep(now)
+	now = *repo.deliveries[0].NextAttemptAt
+	_, _ = useCase.Sweep(now)
+	if d := repo.deliveries[0]; d.Status != domainWebhook.StatusDelivered || d.Attempts != 3 {
+		t.Errorf("expected delivery on the third attempt, got %+v", d)
+	}
+	if sender.requests[0].Secret != emr.Secret || sender.requests[0].URL != emr.URL {
+		t.Errorf("expected the request to use the subscription's URL and secret, got %+v", sender.requests[0])
+	}
+}
+
+func TestCreateSubscriptionValidation(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	useCase := NewWebhookUseCase(&memoryRepository{}, &scriptedSender{}, loggerInstance)
+	tests := []struct {
+		name         string
+		subscription domainWebhook.Subscription
+	}{
+		{"Relative URL", domainWebhook.Subscription{Name: "EMR", URL: "/hooks", EventTypes: []string{domainWebhook.EventVisitCreated}}},
+		{"Unknown event", domainWebhook.Subscription{Name: "EMR", URL: "https://emr.example", EventTypes: []string{"visit.teleported"}}},
+		{"Short secret", domainWebhook.Subscription{Name: "EMR", URL: "https://emr.example", Secret: "abc", EventTypes: []string{domainWebhook.EventVisitCreated}}},
+		{"No events", domainWebhook.Subscription{Name: "EMR", URL: "https://emr.example"}},
+		{"Uneven digest", domainWebhook.Subscription{Name: "EMR", URL: "https://emr.example", EventTypes: []string{domainWebhook.EventVisitCreated}, DigestMinutes: 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var appErr *domainErrors.AppError
+			if _, err := useCase.CreateSubscription(&tt.subscription); !errors.As(err, &appErr) || appErr.Type != domainErrors.ValidationError {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDigestSubscriptionsReceiveBatches(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	billing := domainWebhook.Subscription{ID: uuid.New(), Name: "Billing", URL: "https://billing.example/hooks", Secret: "whsec_0123456789abcdef",
+		EventTypes: []string{domainWebhook.EventVisitCheckedIn, domainWebhook.EventVisitCheckedOut}, Active: true, DigestMinutes: 60}
+	repo := &memoryRepository{subscriptions: []domainWebhook.Subscription{billing}}
+	sender := &scriptedSender{}
+	useCase := NewWebhookUseCase(repo, sender, loggerInstance)
+
+	visit := &domainSchedule.Schedule{ID: uuid.New(), ServiceName: "Personal care", VisitStatus: "completed"}
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventStarted, Schedule: visit})
+	useCase.OnScheduleEvent(domainSchedule.Event{Type: domainSchedule.EventCompleted, Schedule: visit})
+	for i := range repo.deliveries {
+		repo.deliveries[i].CreatedAt = time.Date(2026, 3, 2, 9, 10+i, 0, 0, time.UTC)
+		if repo.deliveries[i].Status != domainWebhook.StatusHeld {
+			t.Fatalf("expected the events to be held for the digest, got %+v", repo.deliveries[i])
+		}
+	}
+
+	if n, _ := useCase.Sweep(time.Date(2026, 3, 2, 9, 59, 0, 0, time.UTC)); n != 0 || len(sender.requests) != 0 {
+		t.Fatalf("expected nothing sent before the hour closes, got %d", n)
+	}
+	if n, _ := useCase.Sweep(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)); n != 1 || len(sender.requests) != 1 {
+		t.Fatalf("expected one digest sent when the hour closes, got %d", n)
+	}
+	request := sender.requests[0]
+	var body struct {
+		Type string     `json:"type"`
+		Data digestData `json:"data"`
+	}
+	if err := json.Unmarshal(request.Body, &body); err != nil || request.EventType != domainWebhook.EventDigest || body.Data.Count != 2 || len(body.Data.Events) != 2 {
+		t.Fatalf("unexpected digest %s", request.Body)
+	}
+	var first payload
+	if err := json.Unmarshal(body.Data.Events[0], &first); err != nil || first.Type != domainWebhook.EventVisitCheckedIn {
+		t.Errorf("expected the digest to carry the events oldest first, got %s", body.Data.Events[0])
+	}
+	digest := repo.deliveries[2]
+	if digest.Status != domainWebhook.StatusDelivered || repo.deliveries[0].Status != domainWebhook.StatusBatched || *repo.deliveries[1].DigestID != digest.ID {
+		t.Errorf("expected the events to point at the delivered digest, got %+v", repo.deliveries)
+	}
+
+	if _, err := useCase.Ping(billing.ID, time.Date(2026, 3, 2, 10, 5, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ping := repo.deliveries[3]; ping.Status != domainWebhook.StatusQueued {
+		t.Errorf("expected a ping to skip the digest, got %+v", ping)
+	}
+}
@@ -19,6 +19,20 @@ func (sd SortDirection) IsValid() bool {
 	return sd == SortAsc || sd == SortDesc
 }
 
+// DeletedScope picks which soft-deleted records a query returns. The zero
+// value leaves them out.
+type DeletedScope string
+
+const (
+	WithoutDeleted DeletedScope = ""
+	WithDeleted    DeletedScope = "with"
+	OnlyDeleted    DeletedScope = "only"
+)
+
+func (ds DeletedScope) IsValid() bool {
+	return ds == WithoutDeleted || ds == WithDeleted || ds == OnlyDeleted
+}
+
 type DataFilters struct {
 	LikeFilters      map[string][]string `json:"likeFilters"`
 	Matches          map[string][]string `json:"matches"`
@@ -27,4 +41,5 @@ type DataFilters struct {
 	SortDirection    SortDirection       `json:"sortDirection"`
 	Page             int                 `json:"page"`
 	PageSize         int                 `json:"pageSize"`
+	Deleted          DeletedScope        `json:"deleted"`
 }
@@ -0,0 +1,185 @@
+package access
+
+import (
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+// Kind is the type of record access is explained for.
+type Kind string
+
+const (
+	KindUser     Kind = "users"
+	KindSchedule Kind = "schedules"
+)
+
+// Kinds lists the record types that can be explained.
+var Kinds = []Kind{KindUser, KindSchedule}
+
+func (k Kind) IsValid() bool {
+	for _, kind := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Levels of access, from most to least. Limited access to a user shows only
+// the fields their role allows.
+const (
+	LevelFull    = "full"
+	LevelLimited = "limited"
+	LevelNone    = "none"
+)
+
+// Effects a rule can have. A rule that does not apply is skipped and the
+// next one is tried.
+const (
+	EffectGrant = "grant"
+	EffectDeny  = "deny"
+	EffectSkip  = "skip"
+)
+
+// Facts is what the rules decide on: the viewer, the record and the people
+// it belongs to.
+type Facts struct {
+	Viewer   domainUser.User
+	Kind     Kind
+	EntityID uuid.UUID
+	// SubjectRole is the role of the user being looked at; empty for other
+	// kinds.
+	SubjectRole string
+	// OwnerIDs are the users the record is about: the user themselves, or a
+	// visit's client and assigned caregiver.
+	OwnerIDs []uuid.UUID
+	// SupervisedIDs are the members of teams the viewer supervises.
+	SupervisedIDs []uuid.UUID
+}
+
+// Outcome is one rule's verdict. Level is set when the rule grants.
+type Outcome struct {
+	Effect string
+	Level  string
+	Reason string
+}
+
+// Rule is a named check in the access policy.
+type Rule struct {
+	Name  string
+	Check func(facts Facts) Outcome
+}
+
+// Step records how a rule judged the request. Decisive marks the rule whose
+// verdict stood.
+type Step struct {
+	Rule      string
+	Effect    string
+	Reason    string
+	Decisive  bool
+	Evaluated bool
+}
+
+// Explanation is why a viewer can or cannot see a record.
+type Explanation struct {
+	Kind     Kind
+	EntityID uuid.UUID
+	UserID   uuid.UUID
+	Role     string
+	Allowed  bool
+	Level    string
+	Steps    []Step
+}
+
+// Explain runs the rules in order; the first that grants or denies decides.
+// Rules after it are listed as not evaluated so the whole policy is visible.
+func Explain(rules []Rule, facts Facts) *Explanation {
+	explanation := &Explanation{
+		Kind:     facts.Kind,
+		EntityID: facts.EntityID,
+		UserID:   facts.Viewer.ID,
+		Role:     facts.Viewer.Role,
+		Level:    LevelNone,
+		Steps:    make([]Step, 0, len(rules)),
+	}
+	decided := false
+	for _, rule := range rules {
+		if decided {
+			explanation.Steps = append(explanation.Steps, Step{Rule: rule.Name, Effect: EffectSkip, Reason: "not evaluated; an earlier rule decided"})
+			continue
+		}
+		outcome := rule.Check(facts)
+		step := Step{Rule: rule.Name, Effect: outcome.Effect, Reason: outcome.Reason, Evaluated: true}
+		if outcome.Effect != EffectSkip {
+			decided = true
+			step.Decisive = true
+			explanation.Allowed = outcome.Effect == EffectGrant
+			if explanation.Allowed {
+				explanation.Level = outcome.Level
+			}
+		}
+		explanation.Steps = append(explanation.Steps, step)
+	}
+	return explanation
+}
+
+// Policy is the agency's access policy, checked in order.
+var Policy = []Rule{
+	{Name: "account:active", Check: func(f Facts) Outcome {
+		if !f.Viewer.Status {
+			return Outcome{Effect: EffectDeny, Reason: "the viewer's account is deactivated"}
+		}
+		return Outcome{Effect: EffectSkip, Reason: "the viewer's account is active"}
+	}},
+	{Name: "role:admin", Check: func(f Facts) Outcome {
+		if f.Viewer.Role == domainUser.RoleAdmin {
+			return Outcome{Effect: EffectGrant, Level: LevelFull, Reason: "coordinators see every record"}
+		}
+		return Outcome{Effect: EffectSkip, Reason: "the viewer is not a coordinator"}
+	}},
+	{Name: "ownership", Check: func(f Facts) Outcome {
+		if containsID(f.OwnerIDs, f.Viewer.ID) {
+			if f.Kind == KindUser {
+				return Outcome{Effect: EffectGrant, Level: LevelFull, Reason: "users see their own profile"}
+			}
+			return Outcome{Effect: EffectGrant, Level: LevelFull, Reason: "the viewer is the visit's client or assigned caregiver"}
+		}
+		return Outcome{Effect: EffectSkip, Reason: "the record is not the viewer's own"}
+	}},
+	{Name: "team:supervisor", Check: func(f Facts) Outcome {
+		for _, id := range f.OwnerIDs {
+			if containsID(f.SupervisedIDs, id) {
+				return Outcome{Effect: EffectGrant, Level: LevelFull, Reason: "the record belongs to a member of a team the viewer supervises"}
+			}
+		}
+		return Outcome{Effect: EffectSkip, Reason: "the viewer supervises no one the record belongs to"}
+	}},
+	{Name: "directory:caregiver", Check: func(f Facts) Outcome {
+		if f.Kind != KindUser || f.Viewer.Role != domainUser.RoleCaregiver {
+			return Outcome{Effect: EffectSkip, Reason: "applies only to caregivers looking up users"}
+		}
+		if f.SubjectRole == domainUser.RoleClient {
+			return Outcome{Effect: EffectGrant, Level: LevelLimited, Reason: "caregivers see a client's name, role, status and address"}
+		}
+		return Outcome{Effect: EffectGrant, Level: LevelLimited, Reason: "caregivers see other users' names, role and status"}
+	}},
+	{Name: "directory:basic", Check: func(f Facts) Outcome {
+		if f.Kind != KindUser {
+			return Outcome{Effect: EffectSkip, Reason: "applies only to users"}
+		}
+		return Outcome{Effect: EffectGrant, Level: LevelLimited, Reason: "everyone sees users' names, role and status"}
+	}},
+	{Name: "default", Check: func(f Facts) Outcome {
+		return Outcome{Effect: EffectDeny, Reason: "no rule grants access"}
+	}},
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package alert
+
+import (
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TypeMissedVisit = "missed_visit"
+	TypeIncident    = "incident"
+	// TypeUnderService is raised once a week for a client whose care plan
+	// visit frequency will not be met. It has no visit or caregiver, so
+	// policies for it should escalate to on-call users.
+	TypeUnderService = "under_service"
+
+	// Escalation targets, resolved per alert: the visit's caregiver, the
+	// supervisor of the caregiver's team, or a fixed on-call manager.
+	TargetCaregiver  = "caregiver"
+	TargetSupervisor = "supervisor"
+	TargetOnCall     = "on_call"
+
+	StatusOpen         = "open"
+	StatusAcknowledged = "acknowledged"
+)
+
+// Step is one rung of an escalation chain. DelayMinutes is the wait after the
+// previous step, or after the alert was raised for the first step.
+type Step struct {
+	Target       string
+	DelayMinutes int
+	UserID       *uuid.UUID
+}
+
+// Policy is the escalation chain used for every alert of one type.
+type Policy struct {
+	ID        uuid.UUID
+	Name      string
+	AlertType string
+	Steps     []Step
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Notification records who was told about an alert at which step.
+type Notification struct {
+	Step   int
+	Target string
+	UserID uuid.UUID
+	SentAt time.Time
+}
+
+// Alert is raised for a missed visit, a reported incident or an under-served
+// client and walks its
+// policy's steps until someone acknowledges it. Emergency incidents come from
+// a caregiver's SOS, carry where it was raised and notify every step at once.
+type Alert struct {
+	ID               uuid.UUID
+	Type             string
+	PolicyID         *uuid.UUID
+	ScheduleID       *uuid.UUID
+	CaregiverUserID  *uuid.UUID
+	ClientUserID     *uuid.UUID
+	Message          string
+	Emergency        bool
+	Location         domainSchedule.Location
+	Status           string
+	NextStep         int
+	NextEscalationAt *time.Time
+	Notifications    []Notification
+	AcknowledgedBy   *uuid.UUID
+	AcknowledgedAt   *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// WasNotified reports whether the user has been told about the alert.
+func (a *Alert) WasNotified(userID uuid.UUID) bool {
+	for _, n := range a.Notifications {
+		if n.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+type IAlertRepository interface {
+	CreatePolicy(policy *Policy) (*Policy, error)
+	GetPolicyByID(id uuid.UUID) (*Policy, error)
+	GetPolicies() (*[]Policy, error)
+	GetActivePolicy(alertType string) (*Policy, error)
+	UpdatePolicy(policy *Policy) (*Policy, error)
+	DeletePolicy(id uuid.UUID) error
+	CreateAlert(alert *Alert) (*Alert, error)
+	GetAlertByID(id uuid.UUID) (*Alert, error)
+	GetAlerts(status string) (*[]Alert, error)
+	// GetDueAlerts returns open alerts whose next escalation is at or before now.
+	GetDueAlerts(now time.Time) (*[]Alert, error)
+	GetAlertBySchedule(scheduleID uuid.UUID, alertType string) (*Alert, error)
+	// GetAlertByClientSince returns the client's latest alert of the type
+	// raised at or after since.
+	GetAlertByClientSince(clientUserID uuid.UUID, alertType string, since time.Time) (*Alert, error)
+	SaveAlert(alert *Alert) (*Alert, error)
+}
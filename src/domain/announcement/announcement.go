@@ -0,0 +1,72 @@
+package announcement
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DeliverySent and DeliveryFailed record one channel's attempt to reach
+	// a caregiver. DeliveryQueued holds the caregiver's place, with an empty
+	// Channel, until the notification worker reports back, and
+	// DeliveryUnreachable replaces it when no channel could reach them.
+	DeliverySent        = "sent"
+	DeliveryFailed      = "failed"
+	DeliveryQueued      = "queued"
+	DeliveryUnreachable = "unreachable"
+)
+
+// MaxRecipients caps how many caregivers one announcement can go to.
+const MaxRecipients = 2000
+
+// Audience picks the active caregivers an announcement goes to. Filters
+// combine, and empty ones do not filter: Regions match the city or state of
+// the caregiver's address, and UpcomingFrom and UpcomingTo, set together,
+// keep caregivers with a visit scheduled in that window.
+type Audience struct {
+	TeamIDs      []uuid.UUID
+	Regions      []string
+	UpcomingFrom *time.Time
+	UpcomingTo   *time.Time
+}
+
+// Announcement is a message a coordinator sent to a set of caregivers, kept
+// as an archive with how many of them it reached.
+type Announcement struct {
+	ID           uuid.UUID
+	Subject      string
+	Body         string
+	Audience     Audience
+	SentByUserID *uuid.UUID
+	SentAt       time.Time
+	Recipients   int
+	Reached      int
+	CreatedAt    time.Time
+}
+
+// Delivery is the outcome of an announcement for one caregiver on one
+// channel.
+type Delivery struct {
+	ID             uuid.UUID
+	AnnouncementID uuid.UUID
+	UserID         uuid.UUID
+	Channel        string
+	Status         string
+	Error          string
+	CreatedAt      time.Time
+}
+
+type IAnnouncementRepository interface {
+	// Create saves the announcement together with its deliveries.
+	Create(announcement *Announcement, deliveries []Delivery) (*Announcement, error)
+	GetByID(id uuid.UUID) (*Announcement, error)
+	// GetAll returns the sent announcements, most recent first.
+	GetAll() (*[]Announcement, error)
+	GetDeliveries(announcementID uuid.UUID) (*[]Delivery, error)
+	// RecordDelivery saves a reported attempt. A sent or failed one replaces
+	// the caregiver's queued or unreachable delivery, and the first sent one
+	// counts them as reached; an unreachable one only marks a delivery still
+	// queued.
+	RecordDelivery(delivery Delivery) error
+}
@@ -0,0 +1,111 @@
+package appversion
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+)
+
+// Platforms are the caregiver app builds a minimum version can be set for.
+var Platforms = []string{PlatformIOS, PlatformAndroid}
+
+// IsPlatform reports whether p is one of Platforms.
+func IsPlatform(p string) bool {
+	for _, platform := range Platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// Requirement is the oldest caregiver app version still allowed to call the
+// API on a platform. Older apps are told to upgrade from StoreURL.
+type Requirement struct {
+	ID             uuid.UUID
+	Platform       string
+	MinimumVersion string
+	StoreURL       string
+	Message        string
+	UpdatedAt      time.Time
+}
+
+// Version is a dotted numeric app version such as 2.14.1. Build metadata and
+// pre-release suffixes ("2.14.1-beta+412") are ignored.
+type Version []int
+
+// ParseVersion reads a version of one to four numeric parts.
+func ParseVersion(value string) (Version, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if i := strings.IndexAny(value, "-+ "); i >= 0 {
+		value = value[:i]
+	}
+	parts := strings.Split(value, ".")
+	if value == "" || len(parts) > 4 {
+		return nil, fmt.Errorf("version %q must look like 2.14.1", value)
+	}
+	version := make(Version, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("version %q must look like 2.14.1", value)
+		}
+		version[i] = n
+	}
+	return version, nil
+}
+
+// Compare returns -1, 0 or 1 as v is older than, the same as or newer than
+// other. Missing parts count as zero, so 2.1 equals 2.1.0.
+func (v Version) Compare(other Version) int {
+	for i := 0; i < len(v) || i < len(other); i++ {
+		var a, b int
+		if i < len(v) {
+			a = v[i]
+		}
+		if i < len(other) {
+			b = other[i]
+		}
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// UpgradeRequiredError tells an outdated app that it must be upgraded before
+// it can be used again.
+type UpgradeRequiredError struct {
+	Platform       string
+	Version        string
+	MinimumVersion string
+	StoreURL       string
+	Message        string
+	ForceUpgrade   bool
+}
+
+func (e *UpgradeRequiredError) Error() string {
+	return e.Message
+}
+
+func (e *UpgradeRequiredError) ErrorDetails() any {
+	return e
+}
+
+type IAppVersionRepository interface {
+	GetRequirements() (*[]Requirement, error)
+	// SaveRequirement creates the platform's requirement or replaces it.
+	SaveRequirement(requirement *Requirement) (*Requirement, error)
+	DeleteRequirement(platform string) error
+}
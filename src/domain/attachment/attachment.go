@@ -0,0 +1,41 @@
+package attachment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a file uploaded to a visit, such as a care plan, a wound
+// photo or a receipt. TaskID is set when it belongs to one of the visit's
+// tasks rather than the visit as a whole.
+type Attachment struct {
+	ID                    uuid.UUID
+	ScheduleID            uuid.UUID
+	TaskID                *uuid.UUID
+	FileName              string
+	ContentType           string
+	Size                  int64
+	StorageKey            string
+	RequiredBeforeCheckin bool
+	UploadedByUserID      uuid.UUID
+	CreatedAt             time.Time
+}
+
+// View records that a user opened an attachment for a given visit.
+type View struct {
+	ID           uuid.UUID
+	AttachmentID uuid.UUID
+	ScheduleID   uuid.UUID
+	UserID       uuid.UUID
+	ViewedAt     time.Time
+}
+
+type IAttachmentRepository interface {
+	Create(newAttachment *Attachment) (*Attachment, error)
+	GetByID(id uuid.UUID) (*Attachment, error)
+	GetBySchedule(scheduleID uuid.UUID) (*[]Attachment, error)
+	Delete(id uuid.UUID) error
+	RecordView(view *View) (*View, error)
+	GetViewsBySchedule(scheduleID uuid.UUID) (*[]View, error)
+}
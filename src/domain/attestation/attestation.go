@@ -0,0 +1,25 @@
+package attestation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultWindowMinutes is how long a client has to confirm the caregiver's
+// arrival when no window has been configured.
+const DefaultWindowMinutes = 15
+
+// Settings controls whether clients are asked to confirm arrivals from their
+// own device. There is one row for the organization.
+type Settings struct {
+	ID            uuid.UUID
+	Required      bool
+	WindowMinutes int
+	UpdatedAt     time.Time
+}
+
+type IAttestationRepository interface {
+	GetSettings() (*Settings, error)
+	SaveSettings(settings *Settings) (*Settings, error)
+}
@@ -0,0 +1,52 @@
+package branding
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogoPath is where an uploaded logo is served; LogoURL points here after an
+// upload.
+const LogoPath = "/v1/branding/logo"
+
+// Branding is the agency's white-label look, of which there is at most one.
+// DisplayName and the colors head PDFs and the family portal; SenderName and
+// ReplyTo address outgoing email. LogoURL may link a logo hosted elsewhere;
+// an uploaded logo is kept under LogoKey and is the only one embedded in
+// PDFs. Colors are written as "#RRGGBB".
+type Branding struct {
+	ID              uuid.UUID
+	DisplayName     string
+	LogoURL         string
+	LogoKey         string
+	LogoContentType string
+	PrimaryColor    string
+	AccentColor     string
+	SenderName      string
+	ReplyTo         string
+	UpdatedAt       time.Time
+}
+
+type IBrandingRepository interface {
+	Get() (*Branding, error)
+	Save(branding *Branding) (*Branding, error)
+}
+
+// HasLogo reports whether a logo was uploaded.
+func (b *Branding) HasLogo() bool {
+	return b.LogoKey != ""
+}
+
+// ParseColor splits a "#RRGGBB" color into its channels.
+func ParseColor(color string) (r, g, b uint8, ok bool) {
+	if len(color) != 7 || color[0] != '#' {
+		return 0, 0, 0, false
+	}
+	value, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(value >> 16), uint8(value >> 8), uint8(value), true
+}
@@ -0,0 +1,87 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+
+	UnitHours    = "hours"
+	UnitCurrency = "currency"
+)
+
+// Budget caps how much care a client can be scheduled for within a period,
+// measured either in hours or in currency at HourlyRate.
+type Budget struct {
+	ID               uuid.UUID
+	ClientUserID     uuid.UUID
+	Period           string
+	Unit             string
+	Limit            float64
+	HourlyRate       *float64
+	WarningThreshold float64
+	HardCap          bool
+	Active           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Entry is the amount a single schedule consumes from a budget period.
+type Entry struct {
+	ID          uuid.UUID
+	BudgetID    uuid.UUID
+	ScheduleID  uuid.UUID
+	PeriodStart time.Time
+	Amount      float64
+	CreatedAt   time.Time
+}
+
+// Usage is the spend-versus-budget summary for one budget period.
+type Usage struct {
+	Budget      Budget
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Spent       float64
+	Remaining   float64
+	PercentUsed float64
+}
+
+// PeriodBounds returns the start (inclusive) and end (exclusive) of the
+// budget period containing t. Periods are computed in UTC and weeks start on
+// Monday.
+func (b *Budget) PeriodBounds(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if b.Period == PeriodMonthly {
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+	offset := (int(day.Weekday()) + 6) % 7
+	start := day.AddDate(0, 0, -offset)
+	return start, start.AddDate(0, 0, 7)
+}
+
+// AmountFor converts a scheduled duration into the budget's unit.
+func (b *Budget) AmountFor(duration time.Duration) float64 {
+	hours := duration.Hours()
+	if b.Unit == UnitCurrency && b.HourlyRate != nil {
+		return hours * *b.HourlyRate
+	}
+	return hours
+}
+
+type IBudgetRepository interface {
+	Create(newBudget *Budget) (*Budget, error)
+	GetByID(id uuid.UUID) (*Budget, error)
+	GetAll() (*[]Budget, error)
+	GetActiveByClientUserID(clientUserID uuid.UUID) (*[]Budget, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Budget, error)
+	Delete(id uuid.UUID) error
+	SumEntries(budgetID uuid.UUID, periodStart time.Time, excludeScheduleID uuid.UUID) (float64, error)
+	ReplaceEntries(scheduleID uuid.UUID, entries []Entry) error
+	DeleteEntriesBySchedule(scheduleID uuid.UUID) error
+}
@@ -0,0 +1,150 @@
+package careplan
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CadencePerVisit = "per_visit"
+	CadenceDaily    = "daily"
+	CadenceWeekly   = "weekly"
+	CadenceMonthly  = "monthly"
+)
+
+const (
+	GoalStatusActive       = "active"
+	GoalStatusAchieved     = "achieved"
+	GoalStatusDiscontinued = "discontinued"
+)
+
+// Plan is a client's care plan. Goals hang off the plan and are reviewed
+// together by ReviewDate. VisitsPerWeek is the visit frequency the plan
+// requires, monitored while the plan is active; zero means none.
+type Plan struct {
+	ID            uuid.UUID
+	ClientUserID  uuid.UUID
+	Title         string
+	Notes         string
+	StartDate     time.Time
+	ReviewDate    *time.Time
+	VisitsPerWeek int
+	Active        bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Goal is a measurable outcome on a plan: a Metric moving from Baseline
+// towards Target, measured once per Cadence.
+type Goal struct {
+	ID          uuid.UUID
+	PlanID      uuid.UUID
+	Description string
+	Metric      string
+	Unit        string
+	Baseline    float64
+	Target      float64
+	Cadence     string
+	TargetDate  *time.Time
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Progress is how far value has moved from the baseline towards the target,
+// as a fraction: 0 at the baseline, 1 at the target. It works whether the
+// target is above or below the baseline.
+func (g *Goal) Progress(value float64) float64 {
+	if g.Target == g.Baseline {
+		return 0
+	}
+	return (value - g.Baseline) / (g.Target - g.Baseline)
+}
+
+// Entry is one measurement of a goal, taken during a visit.
+type Entry struct {
+	ID               uuid.UUID
+	GoalID           uuid.UUID
+	ScheduleID       uuid.UUID
+	RecordedByUserID uuid.UUID
+	Value            float64
+	Note             string
+	RecordedAt       time.Time
+	CreatedAt        time.Time
+}
+
+// ChartPoint is one point of a goal's progress chart. For per-visit goals
+// each entry is a point; otherwise At is the start of the period and Value
+// the average of its Count entries.
+type ChartPoint struct {
+	At       time.Time
+	Value    float64
+	Count    int
+	Progress float64
+}
+
+// Chart is a goal's progress over a period for outcome reporting.
+// MissedPeriods counts the daily, weekly or monthly periods in range with no
+// measurement.
+type Chart struct {
+	Goal          Goal
+	From          time.Time
+	To            time.Time
+	Points        []ChartPoint
+	Latest        *float64
+	Progress      float64
+	Achieved      bool
+	MissedPeriods int
+}
+
+// VisitFrequency compares a client's visits in one ISO week with the
+// frequency their plan requires. Delivered visits were completed, fully or in
+// part; Upcoming ones can still be delivered this week. The client is
+// under-served when even delivering every upcoming visit would fall Shortfall
+// visits short.
+type VisitFrequency struct {
+	PlanID       uuid.UUID
+	ClientUserID uuid.UUID
+	Required     int
+	Scheduled    int
+	Delivered    int
+	Upcoming     int
+	Missed       int
+	Shortfall    int
+	UnderServed  bool
+}
+
+// FrequencyReport is the visit frequency compliance of every active plan
+// with a required frequency, for the week starting WeekStart (a Monday).
+type FrequencyReport struct {
+	WeekStart   time.Time
+	WeekEnd     time.Time
+	Clients     []VisitFrequency
+	UnderServed int
+}
+
+type ICarePlanRepository interface {
+	CreatePlan(newPlan *Plan) (*Plan, error)
+	GetPlanByID(id uuid.UUID) (*Plan, error)
+	// GetPlans returns the plans of a client, or all plans when clientUserID
+	// is nil, newest first.
+	GetPlans(clientUserID *uuid.UUID) (*[]Plan, error)
+	// GetFrequencyPlans returns the active plans that require a visit
+	// frequency.
+	GetFrequencyPlans() (*[]Plan, error)
+	UpdatePlan(id uuid.UUID, updates map[string]interface{}) (*Plan, error)
+	DeletePlan(id uuid.UUID) error
+	CreateGoal(newGoal *Goal) (*Goal, error)
+	GetGoalByID(id uuid.UUID) (*Goal, error)
+	GetGoals(planID uuid.UUID) (*[]Goal, error)
+	UpdateGoal(id uuid.UUID, updates map[string]interface{}) (*Goal, error)
+	DeleteGoal(id uuid.UUID) error
+	CreateEntry(newEntry *Entry) (*Entry, error)
+	GetEntryByID(id uuid.UUID) (*Entry, error)
+	// GetEntries returns a goal's entries recorded in [from, to), oldest
+	// first.
+	GetEntries(goalID uuid.UUID, from, to time.Time) (*[]Entry, error)
+	GetEntriesBySchedule(scheduleID uuid.UUID) (*[]Entry, error)
+	DeleteEntry(id uuid.UUID) error
+}
@@ -0,0 +1,338 @@
+package claim
+
+import (
+	"strings"
+	"time"
+
+	domainDifferential "caregiver/src/domain/differential"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusSubmitted = "submitted"
+	StatusAccepted  = "accepted"
+	StatusDenied    = "denied"
+	StatusPaid      = "paid"
+	// StatusRebilled marks a denied claim that was replaced by a corrected
+	// one; StatusWrittenOff one the agency gave up collecting.
+	StatusRebilled   = "rebilled"
+	StatusWrittenOff = "written_off"
+
+	DenialOpen       = "open"
+	DenialRebilled   = "rebilled"
+	DenialWrittenOff = "written_off"
+
+	// DefaultFilingIndicator is the claim filing indicator code (SBR09) for
+	// Medicaid, the usual payer for home care.
+	DefaultFilingIndicator = "MC"
+)
+
+// Payer is an insurer or program claims are billed to. PayerCode is the
+// identifier the payer uses in X12 interchanges.
+type Payer struct {
+	ID              uuid.UUID
+	Name            string
+	PayerCode       string
+	FilingIndicator string
+	Active          bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Coverage enrolls a client with a payer and carries the subscriber details
+// a professional claim needs.
+type Coverage struct {
+	ID            uuid.UUID
+	ClientUserID  uuid.UUID
+	PayerID       uuid.UUID
+	MemberID      string
+	BirthDate     time.Time
+	Gender        string
+	DiagnosisCode string
+	Active        bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type CoverageFilter struct {
+	ClientUserID *uuid.UUID
+	PayerID      *uuid.UUID
+}
+
+// Rate prices a service for one payer: the visit's worked time is billed in
+// units of UnitMinutes at UnitRate under ProcedureCode. A rate applies to
+// visits from EffectiveFrom through EffectiveTo, both inclusive dates; a nil
+// EffectiveTo leaves it open-ended.
+type Rate struct {
+	ID            uuid.UUID
+	PayerID       uuid.UUID
+	ServiceName   string
+	ProcedureCode string
+	Modifier      string
+	UnitMinutes   int
+	UnitRate      float64
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// EffectiveOn reports whether the rate applies to a visit on the given day.
+func (r *Rate) EffectiveOn(day time.Time) bool {
+	day = dateOf(day)
+	return !day.Before(dateOf(r.EffectiveFrom)) && (r.EffectiveTo == nil || !day.After(dateOf(*r.EffectiveTo)))
+}
+
+// Overlaps reports whether two rates are in effect on a common day.
+func (r *Rate) Overlaps(other *Rate) bool {
+	startsBeforeOtherEnds := other.EffectiveTo == nil || !dateOf(r.EffectiveFrom).After(dateOf(*other.EffectiveTo))
+	otherStartsBeforeEnd := r.EffectiveTo == nil || !dateOf(other.EffectiveFrom).After(dateOf(*r.EffectiveTo))
+	return startsBeforeOtherEnds && otherStartsBeforeEnd
+}
+
+// EffectiveRate picks the rate for a service in effect on the visit day.
+func EffectiveRate(rates []Rate, serviceName string, day time.Time) (*Rate, bool) {
+	for i := range rates {
+		if strings.EqualFold(rates[i].ServiceName, serviceName) && rates[i].EffectiveOn(day) {
+			return &rates[i], true
+		}
+	}
+	return nil, false
+}
+
+func dateOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ProviderSettings identify the agency as submitter and billing provider.
+// There is one row for the organization.
+type ProviderSettings struct {
+	ID           uuid.UUID
+	Name         string
+	NPI          string
+	TaxID        string
+	Street       string
+	City         string
+	State        string
+	Zip          string
+	SubmitterID  string
+	ContactName  string
+	ContactPhone string
+	UpdatedAt    time.Time
+}
+
+// Batch is one generated 837P file for a payer.
+type Batch struct {
+	ID            uuid.UUID
+	PayerID       uuid.UUID
+	ControlNumber int
+	From          time.Time
+	To            time.Time
+	ClaimCount    int
+	TotalCharge   float64
+	Content       string
+	CreatedAt     time.Time
+}
+
+// Claim bills one visit. ControlNumber is the patient control number sent in
+// CLM01 and echoed back on remittances.
+type Claim struct {
+	ID            uuid.UUID
+	BatchID       uuid.UUID
+	PayerID       uuid.UUID
+	ClientUserID  uuid.UUID
+	ScheduleID    uuid.UUID
+	ControlNumber string
+	ServiceDate   time.Time
+	ProcedureCode string
+	Modifier      string
+	Units         int
+	Charge        float64
+	// ChargeBreakdown itemises Charge into standard time and the shift
+	// differentials the visit was worked under.
+	ChargeBreakdown []domainDifferential.Line
+	PaidAmount      float64
+	Status          string
+	// StatusReason explains the last status change, such as the adjustment
+	// reason codes of a denial.
+	StatusReason  string
+	RemittanceID  *uuid.UUID
+	AdjudicatedAt *time.Time
+	// OriginalClaimID links a rebill to the denied claim it replaces.
+	OriginalClaimID *uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type ClaimFilter struct {
+	Status       string
+	PayerID      *uuid.UUID
+	ClientUserID *uuid.UUID
+	BatchID      *uuid.UUID
+}
+
+// Remittance is an ingested 835 payment advice.
+type Remittance struct {
+	ID          uuid.UUID
+	PayerID     *uuid.UUID
+	PayerName   string
+	TraceNumber string
+	PaymentDate *time.Time
+	TotalPaid   float64
+	Matched     int
+	Unmatched   int
+	Content     string
+	CreatedAt   time.Time
+}
+
+// RemittanceLine is the payer's decision on one claim. ClaimID is nil when
+// the control number did not match a claim.
+type RemittanceLine struct {
+	ID            uuid.UUID
+	RemittanceID  uuid.UUID
+	ClaimID       *uuid.UUID
+	ControlNumber string
+	StatusCode    string
+	Charge        float64
+	Paid          float64
+	Adjustments   string
+}
+
+// Denial is the correction task opened when a claim is denied. It stays open
+// until the claim is rebilled or written off.
+type Denial struct {
+	ID             uuid.UUID
+	ClaimID        uuid.UUID
+	ScheduleID     uuid.UUID
+	ClientUserID   uuid.UUID
+	PayerID        uuid.UUID
+	Amount         float64
+	ReasonCodes    []string
+	Note           string
+	AssignedUserID *uuid.UUID
+	Status         string
+	RebillClaimID  *uuid.UUID
+	DeniedAt       time.Time
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type DenialFilter struct {
+	Status         string
+	PayerID        *uuid.UUID
+	AssignedUserID *uuid.UUID
+	ReasonCode     string
+}
+
+// DenialReason describes a claim adjustment reason code (CARC). Correctable
+// reasons are usually fixed by correcting the claim data and rebilling.
+type DenialReason struct {
+	Code        string
+	Description string
+	Correctable bool
+}
+
+// DenialReasons are the adjustment reason codes most often seen on home care
+// denials. Codes outside the list are still recorded as received.
+var DenialReasons = []DenialReason{
+	{Code: "4", Description: "Procedure code is inconsistent with the modifier used", Correctable: true},
+	{Code: "16", Description: "Claim lacks information needed for adjudication", Correctable: true},
+	{Code: "18", Description: "Exact duplicate claim or service", Correctable: false},
+	{Code: "27", Description: "Expenses incurred after coverage terminated", Correctable: false},
+	{Code: "29", Description: "The time limit for filing has expired", Correctable: false},
+	{Code: "31", Description: "Patient cannot be identified as our insured", Correctable: true},
+	{Code: "50", Description: "Not deemed a medical necessity by the payer", Correctable: false},
+	{Code: "96", Description: "Non-covered charge", Correctable: false},
+	{Code: "97", Description: "Payment is included in another service already adjudicated", Correctable: false},
+	{Code: "109", Description: "Claim not covered by this payer", Correctable: true},
+	{Code: "119", Description: "Benefit maximum for this time period has been reached", Correctable: false},
+	{Code: "140", Description: "Patient or insured health identification number and name do not match", Correctable: true},
+	{Code: "197", Description: "Precertification or authorization absent", Correctable: true},
+	{Code: "198", Description: "Precertification or authorization exceeded", Correctable: true},
+}
+
+// LookupDenialReason finds a reason by its code, with or without the group
+// prefix ("CO-16" or "16").
+func LookupDenialReason(code string) (DenialReason, bool) {
+	if i := strings.LastIndex(code, "-"); i >= 0 {
+		code = code[i+1:]
+	}
+	for _, reason := range DenialReasons {
+		if reason.Code == code {
+			return reason, true
+		}
+	}
+	return DenialReason{}, false
+}
+
+// DenialAgingBucket counts open denials denied between MinDays and MaxDays
+// ago. MaxDays is zero for the open-ended last bucket.
+type DenialAgingBucket struct {
+	Label   string
+	MinDays int
+	MaxDays int
+	Count   int
+	Amount  float64
+}
+
+type DenialReasonSummary struct {
+	Code        string
+	Description string
+	Count       int
+	Amount      float64
+}
+
+// DenialAgingReport summarizes open denials by age and by reason. A denial
+// with several reason codes counts once under each.
+type DenialAgingReport struct {
+	AsOf        time.Time
+	Total       int
+	TotalAmount float64
+	Buckets     []DenialAgingBucket
+	ByReason    []DenialReasonSummary
+}
+
+type IClaimRepository interface {
+	CreatePayer(newPayer *Payer) (*Payer, error)
+	GetPayerByID(id uuid.UUID) (*Payer, error)
+	GetPayers() (*[]Payer, error)
+	UpdatePayer(id uuid.UUID, updates map[string]interface{}) (*Payer, error)
+	DeletePayer(id uuid.UUID) error
+	CreateCoverage(newCoverage *Coverage) (*Coverage, error)
+	GetCoverageByID(id uuid.UUID) (*Coverage, error)
+	GetCoverages(filter CoverageFilter) (*[]Coverage, error)
+	UpdateCoverage(id uuid.UUID, updates map[string]interface{}) (*Coverage, error)
+	DeleteCoverage(id uuid.UUID) error
+	CreateRate(newRate *Rate) (*Rate, error)
+	GetRateByID(id uuid.UUID) (*Rate, error)
+	GetRates(payerID uuid.UUID) (*[]Rate, error)
+	UpdateRate(id uuid.UUID, updates map[string]interface{}) (*Rate, error)
+	DeleteRate(id uuid.UUID) error
+	GetProviderSettings() (*ProviderSettings, error)
+	SaveProviderSettings(settings *ProviderSettings) (*ProviderSettings, error)
+	// CreateBatch stores a batch together with its claims.
+	CreateBatch(newBatch *Batch, claims []Claim) (*Batch, error)
+	GetBatchByID(id uuid.UUID) (*Batch, error)
+	GetBatches(payerID *uuid.UUID) (*[]Batch, error)
+	GetLastBatchControlNumber() (int, error)
+	GetClaimByID(id uuid.UUID) (*Claim, error)
+	GetClaimByControlNumber(controlNumber string) (*Claim, error)
+	GetClaims(filter ClaimFilter) (*[]Claim, error)
+	// GetBilledScheduleIDs returns which of the given visits already have a
+	// claim. Denied visits are billed again only through a rebill.
+	GetBilledScheduleIDs(scheduleIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	UpdateClaim(id uuid.UUID, updates map[string]interface{}) (*Claim, error)
+	// CreateRemittance stores a remittance together with its lines.
+	CreateRemittance(newRemittance *Remittance, lines []RemittanceLine) (*Remittance, error)
+	GetRemittanceByID(id uuid.UUID) (*Remittance, error)
+	GetRemittances() (*[]Remittance, error)
+	GetRemittanceLines(remittanceID uuid.UUID) (*[]RemittanceLine, error)
+	CreateDenial(newDenial *Denial) (*Denial, error)
+	GetDenialByID(id uuid.UUID) (*Denial, error)
+	GetOpenDenialByClaim(claimID uuid.UUID) (*Denial, error)
+	GetDenials(filter DenialFilter) (*[]Denial, error)
+	UpdateDenial(id uuid.UUID, updates map[string]interface{}) (*Denial, error)
+}
@@ -0,0 +1,53 @@
+package clienterror
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cause is why a request was rejected: the request Field that failed and the
+// Rule it broke, such as "required" or "type". Field is empty when the error
+// does not name one, for example malformed JSON or a rule spanning fields.
+type Cause struct {
+	Field string
+	Rule  string
+}
+
+// Failure counts the requests to one endpoint rejected with the same status
+// and cause. A request failing several fields counts once for each.
+type Failure struct {
+	ID          uuid.UUID
+	Method      string
+	Route       string
+	Status      int
+	Field       string
+	Rule        string
+	Count       int64
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
+
+type FailureFilter struct {
+	Route string
+	// Since keeps only failures seen at or after the given time.
+	Since *time.Time
+}
+
+// EndpointReport sums up how clients get one endpoint wrong, its most common
+// causes first.
+type EndpointReport struct {
+	Method     string
+	Route      string
+	Count      int64
+	LastSeenAt time.Time
+	Failures   []Failure
+}
+
+type IClientErrorRepository interface {
+	// RecordFailure adds failure.Count to the count for its endpoint, status
+	// and cause, creating the record on first use.
+	RecordFailure(failure *Failure) error
+	// GetFailures returns matching failures, most recently seen first.
+	GetFailures(filter FailureFilter) (*[]Failure, error)
+}
@@ -0,0 +1,40 @@
+package clientmerge
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Merge is the audit record of folding a duplicate client into the surviving
+// one. Moved counts the re-pointed rows per table; Kept counts the rows left
+// on the tombstoned duplicate because the survivor already had their match,
+// such as a vital range for the same measure.
+type Merge struct {
+	ID              uuid.UUID
+	SurvivorUserID  uuid.UUID
+	DuplicateUserID uuid.UUID
+	MergedByUserID  *uuid.UUID
+	Note            string
+	Moved           map[string]int64
+	Kept            map[string]int64
+	CreatedAt       time.Time
+}
+
+// Preview counts the rows a merge would move, per table.
+type Preview struct {
+	SurvivorUserID  uuid.UUID
+	DuplicateUserID uuid.UUID
+	Rows            map[string]int64
+}
+
+type IClientMergeRepository interface {
+	// Count returns how many rows in each table belong to the client.
+	Count(clientUserID uuid.UUID) (map[string]int64, error)
+	// Merge re-points everything of the duplicate to the survivor,
+	// soft-deletes the duplicate and saves the audit record, all in one
+	// transaction.
+	Merge(merge *Merge) (*Merge, error)
+	GetAll() (*[]Merge, error)
+	GetByID(id uuid.UUID) (*Merge, error)
+}
@@ -0,0 +1,101 @@
+package compliance
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RuleMaxStartDelay requires check-in within Threshold minutes of the
+	// scheduled slot start.
+	RuleMaxStartDelay = "max_start_delay"
+	// RuleMinCaregivers requires at least Threshold distinct caregivers booked
+	// with the client during the visit, e.g. two-person transfers.
+	RuleMinCaregivers = "min_caregivers"
+	// RuleTasksCompleted requires every task to be marked done at checkout.
+	RuleTasksCompleted = "tasks_completed"
+	// RuleMinConfidence requires a verification confidence of at least
+	// Threshold (0-100) at checkout. Visits without a score are not checked.
+	RuleMinConfidence = "min_verification_confidence"
+
+	StageScheduling = "scheduling"
+	StageCompletion = "completion"
+)
+
+// Rule is a configurable compliance requirement for visits. ServiceName
+// limits the rule to one service; an empty value applies it to every visit.
+type Rule struct {
+	ID          uuid.UUID
+	Name        string
+	Type        string
+	ServiceName string
+	Threshold   int
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Stage returns when the rule is evaluated.
+func (r *Rule) Stage() string {
+	if r.Type == RuleMinCaregivers {
+		return StageScheduling
+	}
+	return StageCompletion
+}
+
+// AppliesTo reports whether the rule covers visits for serviceName.
+func (r *Rule) AppliesTo(serviceName string) bool {
+	return r.ServiceName == "" || strings.EqualFold(strings.TrimSpace(r.ServiceName), strings.TrimSpace(serviceName))
+}
+
+// Exception records a visit that broke a rule.
+type Exception struct {
+	ID             uuid.UUID
+	RuleID         uuid.UUID
+	RuleName       string
+	ScheduleID     uuid.UUID
+	ClientUserID   uuid.UUID
+	AssignedUserID uuid.UUID
+	Stage          string
+	Message        string
+	// VerificationConfidence is the visit's confidence score when the
+	// exception was detected; nil before the visit is completed.
+	VerificationConfidence *int
+	DetectedAt             time.Time
+}
+
+// RuleSummary counts the exceptions raised by one rule.
+type RuleSummary struct {
+	RuleID   uuid.UUID
+	RuleName string
+	Count    int
+}
+
+// ReportFilter narrows the exceptions report. Empty fields do not filter.
+type ReportFilter struct {
+	RuleID  *uuid.UUID
+	TeamIDs []uuid.UUID
+}
+
+// Report is the compliance exceptions report for a time range.
+type Report struct {
+	From       time.Time
+	To         time.Time
+	Total      int
+	ByRule     []RuleSummary
+	Exceptions []Exception
+}
+
+type IComplianceRepository interface {
+	CreateRule(newRule *Rule) (*Rule, error)
+	GetRuleByID(id uuid.UUID) (*Rule, error)
+	GetRules() (*[]Rule, error)
+	GetActiveRules() (*[]Rule, error)
+	UpdateRule(id uuid.UUID, updates map[string]interface{}) (*Rule, error)
+	DeleteRule(id uuid.UUID) error
+	ReplaceExceptions(scheduleID uuid.UUID, stage string, exceptions []Exception) error
+	DeleteExceptionsBySchedule(scheduleID uuid.UUID) error
+	GetExceptions(from, to time.Time, ruleID *uuid.UUID) (*[]Exception, error)
+}
@@ -0,0 +1,75 @@
+package confirmation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusDeclined  = "declined"
+
+	RequestOpen      = "open"
+	RequestResolved  = "resolved"
+	RequestDismissed = "dismissed"
+)
+
+// Confirmation is a tokenized link that lets a client or their family confirm
+// or decline one upcoming visit without logging in. Only a hash of the token
+// is stored. Links for a family member carry the consent they were issued
+// under and stop working if it is withdrawn.
+type Confirmation struct {
+	ID           uuid.UUID
+	ScheduleID   uuid.UUID
+	ClientUserID uuid.UUID
+	ConsentID    *uuid.UUID
+	TokenHash    string
+	Status       string
+	RespondedBy  string
+	RespondedAt  *time.Time
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ChangeRequest is raised when a client declines a visit and waits in the
+// coordinator queue until it is resolved or dismissed.
+type ChangeRequest struct {
+	ID               uuid.UUID
+	ScheduleID       uuid.UUID
+	ClientUserID     uuid.UUID
+	ConfirmationID   uuid.UUID
+	RequestedBy      string
+	Reason           string
+	PreferredFrom    *time.Time
+	PreferredTo      *time.Time
+	SlotReleased     bool
+	Status           string
+	Resolution       string
+	ResolvedByUserID *uuid.UUID
+	ResolvedAt       *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Decline carries the client's answer when they cannot take a visit.
+// ReleaseSlot cancels the visit so the caregiver can be booked elsewhere.
+type Decline struct {
+	RespondedBy   string
+	Reason        string
+	PreferredFrom *time.Time
+	PreferredTo   *time.Time
+	ReleaseSlot   bool
+}
+
+type IConfirmationRepository interface {
+	Create(newConfirmation *Confirmation) (*Confirmation, error)
+	GetByTokenHash(tokenHash string) (*Confirmation, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Confirmation, error)
+	CreateChangeRequest(newRequest *ChangeRequest) (*ChangeRequest, error)
+	GetChangeRequestByID(id uuid.UUID) (*ChangeRequest, error)
+	GetChangeRequests(status string) (*[]ChangeRequest, error)
+	UpdateChangeRequest(id uuid.UUID, updates map[string]interface{}) (*ChangeRequest, error)
+}
@@ -0,0 +1,81 @@
+package consent
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RecipientFamily = "family"
+	RecipientPayer  = "payer"
+)
+
+// Scopes a consent can cover.
+const (
+	ScopeVisits  = "visits"
+	ScopeBilling = "billing"
+	ScopeHealth  = "health"
+)
+
+const (
+	StatusActive     = "active"
+	StatusSuperseded = "superseded"
+	StatusWithdrawn  = "withdrawn"
+)
+
+// Consent is a client's permission to share the data in Scopes with one
+// recipient: a family member, or a payer identified by PayerID. Changing a
+// consent supersedes it with a new version in the same series, so the terms
+// in force at any time can be shown later. Only the latest version of a
+// series can be active.
+type Consent struct {
+	ID               uuid.UUID
+	SeriesID         uuid.UUID
+	Version          int
+	ClientUserID     uuid.UUID
+	RecipientType    string
+	RecipientName    string
+	RecipientContact string
+	PayerID          *uuid.UUID
+	Scopes           []string
+	Status           string
+	GrantedBy        string
+	GrantedAt        time.Time
+	ExpiresAt        *time.Time
+	WithdrawnAt      *time.Time
+	WithdrawnReason  string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Covers reports whether the consent permits sharing scope at the given time.
+func (c *Consent) Covers(scope string, at time.Time) bool {
+	if c.Status != StatusActive || (c.ExpiresAt != nil && !at.Before(*c.ExpiresAt)) {
+		return false
+	}
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type ConsentFilter struct {
+	ClientUserID *uuid.UUID
+	PayerID      *uuid.UUID
+	SeriesID     *uuid.UUID
+	Status       string
+}
+
+type IConsentRepository interface {
+	Create(newConsent *Consent) (*Consent, error)
+	GetByID(id uuid.UUID) (*Consent, error)
+	// GetAll returns matching consents, newest version first.
+	GetAll(filter ConsentFilter) (*[]Consent, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Consent, error)
+	// Supersede marks the previous version superseded and stores its
+	// replacement in one step.
+	Supersede(previousID uuid.UUID, next *Consent) (*Consent, error)
+}
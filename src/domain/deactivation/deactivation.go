@@ -0,0 +1,98 @@
+package deactivation
+
+import (
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ActionDeactivate = "deactivate"
+	ActionReactivate = "reactivate"
+)
+
+// Reasons a client's service ends, recorded when clients are deactivated.
+const (
+	ReasonDeceased     = "deceased"
+	ReasonFacility     = "moved_to_facility"
+	ReasonRelocated    = "relocated"
+	ReasonNoLongerNeed = "no_longer_needed"
+	ReasonDissatisfied = "dissatisfied"
+	ReasonCost         = "cost"
+	ReasonOther        = "other"
+	// ReasonUnspecified is recorded when no reason was given.
+	ReasonUnspecified = "unspecified"
+)
+
+// Reasons lists the termination reasons a deactivation may give.
+var Reasons = []string{ReasonDeceased, ReasonFacility, ReasonRelocated, ReasonNoLongerNeed, ReasonDissatisfied, ReasonCost, ReasonOther}
+
+// ValidReason reports whether reason is one of Reasons.
+func ValidReason(reason string) bool {
+	for _, r := range Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxUsers caps how many users one bulk request may change.
+const MaxUsers = 500
+
+// MaxListedSchedules caps how many affected visits are listed per user;
+// ScheduleTotal still counts them all.
+const MaxListedSchedules = 100
+
+// Request switches a set of users, e.g. seasonal staff, on or off. With
+// Preview set nothing is changed and the result shows what would be. Reason
+// and Note explain a deactivation and are kept for retention analytics.
+type Request struct {
+	Action  string
+	UserIDs []uuid.UUID
+	Preview bool
+	Reason  string
+	Note    string
+}
+
+// Outcome is what happens to one user. Skipped explains why the user is
+// left as they are; otherwise Schedules are their upcoming and ongoing
+// visits, as caregiver or client, and SeriesIDs the recurring schedules
+// those visits belong to.
+type Outcome struct {
+	UserID        uuid.UUID
+	FirstName     string
+	LastName      string
+	Role          string
+	Skipped       string
+	Schedules     []domainSchedule.Schedule
+	ScheduleTotal int64
+	SeriesIDs     []uuid.UUID
+}
+
+type Result struct {
+	Action   string
+	Preview  bool
+	Changed  int
+	Outcomes []Outcome
+}
+
+// Change records a user being switched off or on.
+type Change struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Role      string
+	Action    string
+	Reason    string
+	Note      string
+	ChangedAt time.Time
+}
+
+type IDeactivationRepository interface {
+	RecordChange(change *Change) (*Change, error)
+	// GetChanges returns the changes to users in the role made before to,
+	// oldest first.
+	GetChanges(role string, to time.Time) (*[]Change, error)
+}
@@ -0,0 +1,46 @@
+package deprecation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Usage counts the calls one client has made to a deprecated endpoint or
+// legacy behaviour, named by Feature. Clients are told apart by a
+// fingerprint of their API key and their user agent.
+type Usage struct {
+	ID          uuid.UUID
+	Feature     string
+	Method      string
+	Route       string
+	Client      string
+	UserAgent   string
+	Calls       int64
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
+
+type UsageFilter struct {
+	Feature string
+	// Since keeps only usage seen at or after the given time.
+	Since *time.Time
+}
+
+// FeatureReport sums up who still relies on a deprecated feature. A feature
+// nobody has called for a while can be removed safely.
+type FeatureReport struct {
+	Feature    string
+	Calls      int64
+	Clients    int
+	LastSeenAt time.Time
+	Usage      []Usage
+}
+
+type IDeprecationRepository interface {
+	// RecordUsage adds usage.Calls to the client's count for the feature,
+	// creating the record on first use.
+	RecordUsage(usage *Usage) error
+	// GetUsage returns matching usage, most recently seen first.
+	GetUsage(filter UsageFilter) (*[]Usage, error)
+}
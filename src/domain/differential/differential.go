@@ -0,0 +1,213 @@
+package differential
+
+import (
+	"math"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AppliesToPay raises what caregivers are paid, AppliesToBilling what
+	// payers are charged; AppliesToBoth does both.
+	AppliesToPay     = "pay"
+	AppliesToBilling = "billing"
+	AppliesToBoth    = "both"
+
+	// StandardName labels the time no differential applies to.
+	StandardName = "Standard"
+)
+
+// Rule multiplies the rate for time worked inside its window. The window
+// runs from StartMinute to EndMinute after local midnight in Timezone and
+// wraps past midnight when it ends before it starts; equal bounds cover the
+// whole day. Weekdays (0 is Sunday) limit the rule to those local calendar
+// days, all days when empty. Where rules overlap the highest multiplier wins.
+type Rule struct {
+	ID          uuid.UUID
+	Name        string
+	AppliesTo   string
+	Weekdays    []int
+	StartMinute int
+	EndMinute   int
+	Timezone    string
+	Multiplier  float64
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Line is the worked time priced at one multiplier. RuleID is nil for
+// standard time.
+type Line struct {
+	RuleID     *uuid.UUID
+	Name       string
+	Multiplier float64
+	Minutes    float64
+	Amount     float64
+}
+
+// Breakdown itemises an amount by differential. BaseAmount is what the time
+// would cost without differentials; Amount is the sum of the lines.
+type Breakdown struct {
+	BaseAmount float64
+	Amount     float64
+	Lines      []Line
+}
+
+// VisitPay is what a caregiver earns for one completed visit.
+type VisitPay struct {
+	ScheduleID      uuid.UUID
+	CaregiverUserID uuid.UUID
+	ServiceName     string
+	ServiceDate     time.Time
+	HourlyRate      float64
+	RateSource      string
+	Hours           float64
+	Breakdown
+}
+
+// EstimateRequest describes a visit not yet booked. PayerID picks the
+// client's coverage to bill when they have more than one.
+type EstimateRequest struct {
+	ClientUserID    uuid.UUID
+	CaregiverUserID uuid.UUID
+	ServiceName     string
+	Slot            domainSchedule.ScheduledSlot
+	PayerID         *uuid.UUID
+}
+
+// BillEstimate is what the payer would be charged for the slot.
+type BillEstimate struct {
+	PayerID       uuid.UUID
+	ProcedureCode string
+	Units         int
+	UnitRate      float64
+	Breakdown
+}
+
+// PayEstimate is what the caregiver would earn for the slot.
+type PayEstimate struct {
+	HourlyRate float64
+	RateSource string
+	Breakdown
+}
+
+// Estimate projects the bill and pay for a slot as if it were worked as
+// scheduled, at the rates in effect on the visit day and the active
+// differentials. Bill is nil when the client has no billable rate for the
+// service; Warnings say why.
+type Estimate struct {
+	ClientUserID    uuid.UUID
+	CaregiverUserID uuid.UUID
+	ServiceName     string
+	ServiceDate     time.Time
+	Hours           float64
+	Bill            *BillEstimate
+	Pay             PayEstimate
+	Warnings        []string
+}
+
+type IDifferentialRepository interface {
+	CreateRule(newRule *Rule) (*Rule, error)
+	GetRuleByID(id uuid.UUID) (*Rule, error)
+	GetRules() (*[]Rule, error)
+	GetActiveRules(appliesTo string) (*[]Rule, error)
+	UpdateRule(id uuid.UUID, updates map[string]interface{}) (*Rule, error)
+	DeleteRule(id uuid.UUID) error
+}
+
+// Covers reports whether the rule applies at t, read in loc.
+func (r *Rule) Covers(t time.Time, loc *time.Location) bool {
+	local := t.In(loc)
+	if len(r.Weekdays) > 0 {
+		found := false
+		for _, day := range r.Weekdays {
+			found = found || time.Weekday(day) == local.Weekday()
+		}
+		if !found {
+			return false
+		}
+	}
+	minute := local.Hour()*60 + local.Minute()
+	switch {
+	case r.StartMinute == r.EndMinute:
+		return true
+	case r.StartMinute < r.EndMinute:
+		return minute >= r.StartMinute && minute < r.EndMinute
+	default:
+		return minute >= r.StartMinute || minute < r.EndMinute
+	}
+}
+
+// Split divides the periods minute by minute between standard time and the
+// rules, standard time first and then in rule order. Lines without time are
+// left out.
+func Split(rules []Rule, periods []domainSchedule.ScheduledSlot) []Line {
+	locations := make([]*time.Location, len(rules))
+	for i, rule := range rules {
+		loc, err := time.LoadLocation(rule.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		locations[i] = loc
+	}
+
+	minutes := make([]float64, len(rules)+1)
+	for _, period := range periods {
+		for t := period.From; t.Before(period.To); {
+			next := t.Truncate(time.Minute).Add(time.Minute)
+			if next.After(period.To) {
+				next = period.To
+			}
+			best := -1
+			for i := range rules {
+				if rules[i].Covers(t, locations[i]) && (best < 0 || rules[i].Multiplier > rules[best].Multiplier) {
+					best = i
+				}
+			}
+			minutes[best+1] += next.Sub(t).Minutes()
+			t = next
+		}
+	}
+
+	var lines []Line
+	if minutes[0] > 0 {
+		lines = append(lines, Line{Name: StandardName, Multiplier: 1, Minutes: minutes[0]})
+	}
+	for i := range rules {
+		if minutes[i+1] > 0 {
+			id := rules[i].ID
+			lines = append(lines, Line{RuleID: &id, Name: rules[i].Name, Multiplier: rules[i].Multiplier, Minutes: minutes[i+1]})
+		}
+	}
+	return lines
+}
+
+// Price spreads baseAmount over the worked time and applies each line's
+// multiplier, rounding every line to the cent.
+func Price(rules []Rule, periods []domainSchedule.ScheduledSlot, baseAmount float64) Breakdown {
+	lines := Split(rules, periods)
+	total := 0.0
+	for _, line := range lines {
+		total += line.Minutes
+	}
+	breakdown := Breakdown{BaseAmount: baseAmount, Lines: lines}
+	if total == 0 {
+		breakdown.Amount = baseAmount
+		return breakdown
+	}
+	for i := range breakdown.Lines {
+		line := &breakdown.Lines[i]
+		line.Amount = roundCents(baseAmount * line.Minutes / total * line.Multiplier)
+		breakdown.Amount += line.Amount
+	}
+	breakdown.Amount = roundCents(breakdown.Amount)
+	return breakdown
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,98 @@
+package equipment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TypeVehicle     = "vehicle"
+	TypeHoist       = "hoist"
+	TypeMobilityAid = "mobility_aid"
+	TypeOther       = "other"
+
+	// StatusCheckedOut is only set by a checkout and cleared by its return;
+	// the other statuses are set by coordinators.
+	StatusAvailable   = "available"
+	StatusCheckedOut  = "checked_out"
+	StatusMaintenance = "maintenance"
+	StatusRetired     = "retired"
+)
+
+// Equipment is an agency asset lent to caregivers or clients, such as a
+// vehicle or a patient hoist. Identifier is the serial number, or the plate
+// for vehicles. Equipment with a MaintenanceIntervalDays is due for
+// maintenance at NextMaintenanceDue; ReminderSentAt records the reminder for
+// that due date.
+type Equipment struct {
+	ID                      uuid.UUID
+	Name                    string
+	Type                    string
+	Identifier              string
+	Status                  string
+	MaintenanceIntervalDays int
+	LastMaintainedAt        *time.Time
+	NextMaintenanceDue      *time.Time
+	ReminderSentAt          *time.Time
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
+
+// MaintenanceOverdue reports whether the equipment has passed its due date.
+func (e *Equipment) MaintenanceOverdue(now time.Time) bool {
+	return e.NextMaintenanceDue != nil && e.NextMaintenanceDue.Before(now)
+}
+
+// Checkout lends equipment to a caregiver or a client, optionally for a
+// visit. It is open until ReturnedAt is set.
+type Checkout struct {
+	ID              uuid.UUID
+	EquipmentID     uuid.UUID
+	CaregiverUserID *uuid.UUID
+	ClientUserID    *uuid.UUID
+	ScheduleID      *uuid.UUID
+	CheckedOutAt    time.Time
+	DueBackAt       *time.Time
+	ReturnedAt      *time.Time
+	ConditionOut    string
+	ConditionIn     string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type CheckoutFilter struct {
+	EquipmentID     *uuid.UUID
+	CaregiverUserID *uuid.UUID
+	ClientUserID    *uuid.UUID
+	ScheduleID      *uuid.UUID
+	OpenOnly        bool
+}
+
+// MaintenanceRecord is one service performed on the equipment.
+type MaintenanceRecord struct {
+	ID          uuid.UUID
+	EquipmentID uuid.UUID
+	PerformedAt time.Time
+	Description string
+	PerformedBy string
+	Cost        float64
+	CreatedAt   time.Time
+}
+
+type IEquipmentRepository interface {
+	CreateEquipment(newEquipment *Equipment) (*Equipment, error)
+	GetEquipmentByID(id uuid.UUID) (*Equipment, error)
+	GetEquipment(status string) (*[]Equipment, error)
+	UpdateEquipment(id uuid.UUID, updates map[string]interface{}) (*Equipment, error)
+	DeleteEquipment(id uuid.UUID) error
+	// GetMaintenanceDue returns equipment in service whose maintenance is due
+	// at or before the given time and has not been reminded of yet.
+	GetMaintenanceDue(before time.Time) (*[]Equipment, error)
+	CreateCheckout(newCheckout *Checkout) (*Checkout, error)
+	GetCheckoutByID(id uuid.UUID) (*Checkout, error)
+	GetCheckouts(filter CheckoutFilter) (*[]Checkout, error)
+	UpdateCheckout(id uuid.UUID, updates map[string]interface{}) (*Checkout, error)
+	CreateMaintenanceRecord(record *MaintenanceRecord) (*MaintenanceRecord, error)
+	GetMaintenanceRecords(equipmentID uuid.UUID) (*[]MaintenanceRecord, error)
+}
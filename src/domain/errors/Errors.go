@@ -30,6 +30,15 @@ const (
 	NotAuthorized             ErrorType    = "NotAuthorized"
 	notAuthorizedErrorMessage ErrorMessage = "not authorized"
 
+	Conflict             ErrorType    = "Conflict"
+	conflictErrorMessage ErrorMessage = "conflicts with an existing resource"
+
+	TooManyRequests             ErrorType    = "TooManyRequests"
+	tooManyRequestsErrorMessage ErrorMessage = "too many requests"
+
+	UpgradeRequired             ErrorType    = "UpgradeRequired"
+	upgradeRequiredErrorMessage ErrorMessage = "this app version is no longer supported"
+
 	UnknownError        ErrorType    = "UnknownError"
 	unknownErrorMessage ErrorMessage = "something went wrong"
 )
@@ -64,6 +73,12 @@ func NewAppErrorWithType(errType ErrorType) *AppError {
 		err = errors.New(string(notAuthorizedErrorMessage))
 	case TokenGeneratorError:
 		err = errors.New(string(tokenGeneratorErrorMessage))
+	case Conflict:
+		err = errors.New(string(conflictErrorMessage))
+	case TooManyRequests:
+		err = errors.New(string(tooManyRequestsErrorMessage))
+	case UpgradeRequired:
+		err = errors.New(string(upgradeRequiredErrorMessage))
 	default:
 		err = errors.New(string(unknownErrorMessage))
 	}
@@ -78,6 +93,17 @@ func (appErr *AppError) Error() string {
 	return appErr.Err.Error()
 }
 
+func (appErr *AppError) Unwrap() error {
+	return appErr.Err
+}
+
+// DetailedError is implemented by errors that carry a structured payload the
+// client can act on, returned next to the message as "details".
+type DetailedError interface {
+	error
+	ErrorDetails() any
+}
+
 func AppErrorToHTTP(appErr *AppError) (int, string) {
 	switch appErr.Type {
 	case NotFound:
@@ -90,6 +116,12 @@ func AppErrorToHTTP(appErr *AppError) (int, string) {
 		return http.StatusUnauthorized, appErr.Error()
 	case NotAuthorized:
 		return http.StatusForbidden, appErr.Error()
+	case Conflict:
+		return http.StatusConflict, appErr.Error()
+	case TooManyRequests:
+		return http.StatusTooManyRequests, appErr.Error()
+	case UpgradeRequired:
+		return http.StatusUpgradeRequired, appErr.Error()
 	default:
 		return http.StatusInternalServerError, "Internal Server Error"
 	}
@@ -0,0 +1,121 @@
+package evv
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// StatusQueued submissions are waiting for the worker, either for their
+	// first attempt or for a retry after a transport error.
+	StatusQueued   = "queued"
+	StatusAccepted = "accepted"
+	// StatusRejected means the aggregator refused the record. It stays
+	// rejected until the visit is corrected and resubmitted.
+	StatusRejected = "rejected"
+	// StatusFailed means the record could not be delivered: retries ran out
+	// or no aggregator is configured for the client's state.
+	StatusFailed = "failed"
+
+	OutcomeAccepted = "accepted"
+	OutcomeRejected = "rejected"
+	OutcomeError    = "error"
+)
+
+// Aggregator is a state EVV aggregator endpoint. Visits are routed to the
+// active aggregator for their client's state, and Adapter names the protocol
+// implementation used to talk to it.
+type Aggregator struct {
+	ID       uuid.UUID
+	Name     string
+	State    string
+	Payer    string
+	Adapter  string
+	Endpoint string
+	// APIKeyEnv names the environment variable holding the credential, so
+	// secrets stay out of the database. It is always KeyEnvForState(State);
+	// callers never choose it.
+	APIKeyEnv string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// KeyEnvForState returns the environment variable holding the credential for
+// a state's aggregator, for example EVV_TX_API_KEY.
+func KeyEnvForState(state string) string {
+	return "EVV_" + state + "_API_KEY"
+}
+
+// Record carries the electronic visit verification elements of one visit:
+// the service, who received and provided it, and when and where it started
+// and ended, along with how presence was verified.
+type Record struct {
+	ScheduleID         uuid.UUID
+	ServiceName        string
+	ClientUserID       uuid.UUID
+	ClientName         string
+	CaregiverUserID    uuid.UUID
+	CaregiverName      string
+	ServiceDate        string
+	CheckinTime        time.Time
+	CheckoutTime       time.Time
+	CheckinLat         *float64
+	CheckinLong        *float64
+	CheckoutLat        *float64
+	CheckoutLong       *float64
+	VerificationMethod string
+	AttestationStatus  string
+}
+
+// Submission tracks the delivery of one visit to its aggregator. There is one
+// per visit; resubmitting reuses it.
+type Submission struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	AggregatorID    *uuid.UUID
+	Status          string
+	Attempts        int
+	ExternalID      string
+	RejectionCode   string
+	RejectionReason string
+	NextAttemptAt   *time.Time
+	LastAttemptAt   *time.Time
+	AcceptedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Attempt is one delivery try and its outcome.
+type Attempt struct {
+	ID           uuid.UUID
+	SubmissionID uuid.UUID
+	AggregatorID *uuid.UUID
+	AttemptedAt  time.Time
+	Outcome      string
+	Code         string
+	Message      string
+}
+
+type SubmissionFilter struct {
+	Status     string
+	ScheduleID *uuid.UUID
+}
+
+type IEVVRepository interface {
+	CreateAggregator(newAggregator *Aggregator) (*Aggregator, error)
+	GetAggregatorByID(id uuid.UUID) (*Aggregator, error)
+	GetAggregators() (*[]Aggregator, error)
+	GetActiveAggregatorByState(state string) (*Aggregator, error)
+	UpdateAggregator(id uuid.UUID, updates map[string]interface{}) (*Aggregator, error)
+	DeleteAggregator(id uuid.UUID) error
+	CreateSubmission(newSubmission *Submission) (*Submission, error)
+	GetSubmissionByID(id uuid.UUID) (*Submission, error)
+	GetSubmissionBySchedule(scheduleID uuid.UUID) (*Submission, error)
+	GetSubmissions(filter SubmissionFilter) (*[]Submission, error)
+	GetDueSubmissions(now time.Time, limit int) (*[]Submission, error)
+	UpdateSubmission(id uuid.UUID, updates map[string]interface{}) (*Submission, error)
+	CreateAttempt(attempt *Attempt) (*Attempt, error)
+	GetAttempts(submissionID uuid.UUID) (*[]Attempt, error)
+}
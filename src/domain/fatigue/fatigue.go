@@ -0,0 +1,185 @@
+package fatigue
+
+import (
+	"fmt"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RuleMaxConsecutiveDays limits how many days in a row a caregiver may
+	// work; Threshold is a number of days.
+	RuleMaxConsecutiveDays = "max_consecutive_days"
+	// RuleMaxDailyHours limits the scheduled hours on a single day.
+	RuleMaxDailyHours = "max_daily_hours"
+
+	// ModeWarn lets an assignment through with a warning; ModeBlock rejects it.
+	ModeWarn  = "warn"
+	ModeBlock = "block"
+)
+
+// Rule is a configurable fatigue limit applied to every caregiver.
+type Rule struct {
+	ID        uuid.UUID
+	Name      string
+	Type      string
+	Threshold float64
+	Mode      string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DayLoad is the work scheduled for a caregiver on one UTC calendar day.
+// Visits crossing midnight count towards each day they cover.
+type DayLoad struct {
+	Date        time.Time
+	Hours       float64
+	ScheduleIDs []uuid.UUID
+}
+
+// Violation is a day on which a caregiver breaks a rule. For consecutive-day
+// rules Date is the last day of the streak.
+type Violation struct {
+	RuleID    uuid.UUID
+	RuleName  string
+	Type      string
+	Mode      string
+	Date      time.Time
+	Value     float64
+	Threshold float64
+	Message   string
+}
+
+// Report is the fatigue picture for one caregiver over [From, To).
+type Report struct {
+	CaregiverUserID uuid.UUID
+	From            time.Time
+	To              time.Time
+	Days            []DayLoad
+	TotalHours      float64
+	LongestStreak   int
+	Violations      []Violation
+}
+
+type IFatigueRepository interface {
+	CreateRule(newRule *Rule) (*Rule, error)
+	GetRuleByID(id uuid.UUID) (*Rule, error)
+	GetRules() (*[]Rule, error)
+	GetActiveRules() (*[]Rule, error)
+	UpdateRule(id uuid.UUID, updates map[string]interface{}) (*Rule, error)
+	DeleteRule(id uuid.UUID) error
+}
+
+// Day truncates t to the start of its UTC calendar day.
+func Day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Loads spreads the schedules' working time over the days it falls on. Split
+// shifts count their segments, other visits their scheduled slot.
+func Loads(schedules []domainSchedule.Schedule) map[time.Time]*DayLoad {
+	loads := make(map[time.Time]*DayLoad)
+	add := func(scheduleID uuid.UUID, from, to time.Time) {
+		for day := Day(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+			start, end := from, to
+			if start.Before(day) {
+				start = day
+			}
+			if next := day.AddDate(0, 0, 1); end.After(next) {
+				end = next
+			}
+			load, ok := loads[day]
+			if !ok {
+				load = &DayLoad{Date: day}
+				loads[day] = load
+			}
+			load.Hours += end.Sub(start).Hours()
+			if n := len(load.ScheduleIDs); n == 0 || load.ScheduleIDs[n-1] != scheduleID {
+				load.ScheduleIDs = append(load.ScheduleIDs, scheduleID)
+			}
+		}
+	}
+	for _, schedule := range schedules {
+		if len(schedule.Segments) == 0 {
+			add(schedule.ID, schedule.ScheduledSlot.From, schedule.ScheduledSlot.To)
+			continue
+		}
+		for _, segment := range schedule.Segments {
+			add(schedule.ID, segment.From, segment.To)
+		}
+	}
+	return loads
+}
+
+// Streak returns the first and last day of the run of worked days containing
+// day, or zero times when nothing is scheduled that day.
+func Streak(loads map[time.Time]*DayLoad, day time.Time) (time.Time, time.Time) {
+	day = Day(day)
+	if !worked(loads, day) {
+		return time.Time{}, time.Time{}
+	}
+	first, last := day, day
+	for worked(loads, first.AddDate(0, 0, -1)) {
+		first = first.AddDate(0, 0, -1)
+	}
+	for worked(loads, last.AddDate(0, 0, 1)) {
+		last = last.AddDate(0, 0, 1)
+	}
+	return first, last
+}
+
+// StreakLength counts the days from first to last inclusive.
+func StreakLength(first, last time.Time) int {
+	if first.IsZero() {
+		return 0
+	}
+	return int(last.Sub(first).Hours()/24) + 1
+}
+
+// Evaluate checks the given days against the rules. A streak that breaks a
+// rule is reported once, however many of its days are checked.
+func Evaluate(rules []Rule, loads map[time.Time]*DayLoad, days []time.Time) []Violation {
+	var violations []Violation
+	reported := make(map[string]bool)
+	for _, rule := range rules {
+		for _, day := range days {
+			day = Day(day)
+			switch rule.Type {
+			case RuleMaxDailyHours:
+				load, ok := loads[day]
+				if !ok || load.Hours <= rule.Threshold {
+					continue
+				}
+				violations = append(violations, Violation{
+					RuleID: rule.ID, RuleName: rule.Name, Type: rule.Type, Mode: rule.Mode,
+					Date: day, Value: load.Hours, Threshold: rule.Threshold,
+					Message: fmt.Sprintf("%s: %.1f hours scheduled on %s (limit %.1f)", rule.Name, load.Hours, day.Format("2006-01-02"), rule.Threshold),
+				})
+			case RuleMaxConsecutiveDays:
+				first, last := Streak(loads, day)
+				length := StreakLength(first, last)
+				key := rule.ID.String() + first.String()
+				if float64(length) <= rule.Threshold || reported[key] {
+					continue
+				}
+				reported[key] = true
+				violations = append(violations, Violation{
+					RuleID: rule.ID, RuleName: rule.Name, Type: rule.Type, Mode: rule.Mode,
+					Date: last, Value: float64(length), Threshold: rule.Threshold,
+					Message: fmt.Sprintf("%s: %d consecutive working days from %s to %s (limit %.0f)", rule.Name, length, first.Format("2006-01-02"), last.Format("2006-01-02"), rule.Threshold),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+func worked(loads map[time.Time]*DayLoad, day time.Time) bool {
+	load, ok := loads[day]
+	return ok && load.Hours > 0
+}
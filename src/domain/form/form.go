@@ -0,0 +1,219 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PurposeIntake       = "intake"
+	PurposeReassessment = "reassessment"
+
+	FieldText         = "text"
+	FieldNumber       = "number"
+	FieldBoolean      = "boolean"
+	FieldDate         = "date"
+	FieldScale        = "scale"
+	FieldSingleChoice = "single_choice"
+	FieldMultiChoice  = "multi_choice"
+)
+
+// Option is a choice of a single or multiple choice field.
+type Option struct {
+	Value string
+	Label string
+	Score float64
+}
+
+// Field is one question of a form. Choice fields score the chosen options;
+// number and scale fields score their value times Weight, and boolean fields
+// score Weight when answered true.
+type Field struct {
+	Key      string
+	Label    string
+	Type     string
+	Required bool
+	Options  []Option
+	Min      *float64
+	Max      *float64
+	Weight   float64
+}
+
+// Definition is one version of an assessment form. Editing a form publishes
+// a new version; submissions keep pointing at the version they answered.
+type Definition struct {
+	ID                       uuid.UUID
+	Key                      string
+	Version                  int
+	Name                     string
+	Purpose                  string
+	ReassessmentIntervalDays int
+	Fields                   []Field
+	Active                   bool
+	CreatedByUserID          uuid.UUID
+	CreatedAt                time.Time
+}
+
+// Submission is a completed form for a client with its computed score.
+type Submission struct {
+	ID                uuid.UUID
+	DefinitionID      uuid.UUID
+	FormKey           string
+	FormVersion       int
+	ClientUserID      uuid.UUID
+	SubmittedByUserID uuid.UUID
+	Answers           map[string]interface{}
+	FieldScores       map[string]float64
+	Score             float64
+	SubmittedAt       time.Time
+}
+
+// ScorePoint is one entry of a client's score history for a form.
+type ScorePoint struct {
+	SubmissionID uuid.UUID
+	FormVersion  int
+	Score        float64
+	SubmittedAt  time.Time
+}
+
+// ScoreHistory lists a client's scores for a form, oldest first, with the
+// date the next reassessment is due when the form has an interval.
+type ScoreHistory struct {
+	FormKey      string
+	ClientUserID uuid.UUID
+	Points       []ScorePoint
+	NextDueAt    *time.Time
+}
+
+type IFormRepository interface {
+	CreateDefinition(definition *Definition) (*Definition, error)
+	GetLatest(key string) (*Definition, error)
+	GetVersion(key string, version int) (*Definition, error)
+	GetVersions(key string) (*[]Definition, error)
+	GetAllLatest() (*[]Definition, error)
+	SetActive(key string, active bool) error
+	CreateSubmission(submission *Submission) (*Submission, error)
+	GetSubmissionByID(id uuid.UUID) (*Submission, error)
+	// GetSubmissions lists a form's submissions oldest first, optionally for
+	// one client.
+	GetSubmissions(key string, clientUserID *uuid.UUID) (*[]Submission, error)
+}
+
+// FieldByKey returns the field with the given key.
+func (d *Definition) FieldByKey(key string) (*Field, bool) {
+	for i := range d.Fields {
+		if d.Fields[i].Key == key {
+			return &d.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+// Evaluate checks the answers against the form and scores them, returning the
+// score of each answered field and the total.
+func (d *Definition) Evaluate(answers map[string]interface{}) (map[string]float64, float64, error) {
+	for key := range answers {
+		if _, ok := d.FieldByKey(key); !ok {
+			return nil, 0, fmt.Errorf("unknown field %q", key)
+		}
+	}
+
+	scores := make(map[string]float64)
+	total := 0.0
+	for _, field := range d.Fields {
+		answer, ok := answers[field.Key]
+		if !ok || answer == nil {
+			if field.Required {
+				return nil, 0, fmt.Errorf("%s is required", field.Key)
+			}
+			continue
+		}
+		score, err := field.score(answer)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", field.Key, err)
+		}
+		scores[field.Key] = score
+		total += score
+	}
+	return scores, total, nil
+}
+
+func (f *Field) score(answer interface{}) (float64, error) {
+	switch f.Type {
+	case FieldText:
+		if _, ok := answer.(string); !ok {
+			return 0, errors.New("must be text")
+		}
+		return 0, nil
+	case FieldDate:
+		value, ok := answer.(string)
+		if !ok {
+			return 0, errors.New("must be a date (YYYY-MM-DD)")
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return 0, errors.New("must be a date (YYYY-MM-DD)")
+		}
+		return 0, nil
+	case FieldBoolean:
+		value, ok := answer.(bool)
+		if !ok {
+			return 0, errors.New("must be true or false")
+		}
+		if value {
+			return f.Weight, nil
+		}
+		return 0, nil
+	case FieldNumber, FieldScale:
+		value, ok := answer.(float64)
+		if !ok {
+			return 0, errors.New("must be a number")
+		}
+		if (f.Min != nil && value < *f.Min) || (f.Max != nil && value > *f.Max) {
+			return 0, errors.New("is out of range")
+		}
+		return value * f.Weight, nil
+	case FieldSingleChoice:
+		value, ok := answer.(string)
+		if !ok {
+			return 0, errors.New("must be one of the options")
+		}
+		option, ok := f.option(value)
+		if !ok {
+			return 0, errors.New("must be one of the options")
+		}
+		return option.Score, nil
+	case FieldMultiChoice:
+		values, ok := answer.([]interface{})
+		if !ok {
+			return 0, errors.New("must be a list of options")
+		}
+		total := 0.0
+		seen := make(map[string]bool)
+		for _, v := range values {
+			value, _ := v.(string)
+			option, ok := f.option(value)
+			if !ok || seen[value] {
+				return 0, errors.New("must be a list of distinct options")
+			}
+			seen[value] = true
+			total += option.Score
+		}
+		if f.Required && len(values) == 0 {
+			return 0, errors.New("requires at least one option")
+		}
+		return total, nil
+	}
+	return 0, fmt.Errorf("unsupported field type %q", f.Type)
+}
+
+func (f *Field) option(value string) (Option, bool) {
+	for _, option := range f.Options {
+		if option.Value == value {
+			return option, true
+		}
+	}
+	return Option{}, false
+}
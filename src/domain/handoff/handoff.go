@@ -0,0 +1,53 @@
+package handoff
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Window is how soon after a visit ends the client's next visit must start
+// for the two to be back to back.
+const Window = 4 * time.Hour
+
+// Notes are what the outgoing caregiver tells the next one about the client.
+// Summary is required; the rest may be left empty.
+type Notes struct {
+	Summary      string
+	ClientMood   string
+	Concerns     string
+	PendingTasks []string
+	Medications  string
+}
+
+// Handoff carries notes from one visit to the client's next visit by another
+// caregiver, who must acknowledge them before checking in.
+type Handoff struct {
+	ID                  uuid.UUID
+	FromScheduleID      uuid.UUID
+	ToScheduleID        uuid.UUID
+	ClientUserID        uuid.UUID
+	FromCaregiverUserID uuid.UUID
+	ToCaregiverUserID   uuid.UUID
+	Notes               Notes
+	AcknowledgedAt      *time.Time
+	AcknowledgedBy      *uuid.UUID
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// Acknowledged reports whether the next caregiver has read the notes.
+func (h *Handoff) Acknowledged() bool {
+	return h.AcknowledgedAt != nil
+}
+
+type IHandoffRepository interface {
+	// GetFrom returns the handoff left at the end of a visit, or NotFound.
+	GetFrom(fromScheduleID uuid.UUID) (*Handoff, error)
+	// GetTo returns the handoffs left for a visit, oldest first.
+	GetTo(toScheduleID uuid.UUID) (*[]Handoff, error)
+	// Save creates the handoff of its FromScheduleID or replaces its notes.
+	Save(handoff *Handoff) (*Handoff, error)
+	// Acknowledge marks the unacknowledged handoffs left for a visit read.
+	Acknowledge(toScheduleID uuid.UUID, userID uuid.UUID, at time.Time) (*[]Handoff, error)
+}
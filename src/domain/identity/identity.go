@@ -0,0 +1,83 @@
+package identity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The ways a caregiver can confirm who the client is.
+const (
+	MethodPhoto            = "photo"
+	MethodSecurityQuestion = "security_question"
+)
+
+// MaxFailedChecks is how many failed confirmations a visit allows before a
+// coordinator has to step in.
+const MaxFailedChecks = 3
+
+// Settings is the organization's choice of whether a caregiver's first visit
+// to a client requires an identity check. There is at most one row.
+type Settings struct {
+	ID        uuid.UUID
+	Required  bool
+	UpdatedAt time.Time
+}
+
+// Challenge is what a coordinator supplied to confirm a client's identity:
+// a photo, a security question, or both. The answer is kept only as a hash.
+type Challenge struct {
+	ID               uuid.UUID
+	ClientUserID     uuid.UUID
+	Question         string
+	AnswerHash       string
+	PhotoKey         string
+	PhotoContentType string
+	UpdatedBy        *uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (c *Challenge) HasQuestion() bool {
+	return c.Question != "" && c.AnswerHash != ""
+}
+
+func (c *Challenge) HasPhoto() bool {
+	return c.PhotoKey != ""
+}
+
+// Check is one identity confirmation recorded on a visit.
+type Check struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	ClientUserID    uuid.UUID
+	CaregiverUserID uuid.UUID
+	Method          string
+	Passed          bool
+	Note            string
+	CheckedAt       time.Time
+}
+
+// Status is what a caregiver sees about the identity check for a visit. It
+// never carries the security answer.
+type Status struct {
+	Required    bool
+	FirstVisit  bool
+	Verified    bool
+	Question    string
+	HasPhoto    bool
+	FailedCount int
+	Checks      []Check
+}
+
+type IIdentityRepository interface {
+	GetSettings() (*Settings, error)
+	SaveSettings(settings *Settings) (*Settings, error)
+	// GetChallenge returns the client's challenge, or NotFound.
+	GetChallenge(clientUserID uuid.UUID) (*Challenge, error)
+	// SaveChallenge creates the challenge of its ClientUserID or replaces it.
+	SaveChallenge(challenge *Challenge) (*Challenge, error)
+	CreateCheck(check *Check) (*Check, error)
+	// GetChecks returns the checks recorded on a visit, oldest first.
+	GetChecks(scheduleID uuid.UUID) (*[]Check, error)
+}
@@ -0,0 +1,40 @@
+package interruption
+
+import (
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+type IInterruptionRepository interface {
+	Create(interruption *domainSchedule.Interruption) (*domainSchedule.Interruption, error)
+	GetByID(id uuid.UUID) (*domainSchedule.Interruption, error)
+	GetBySchedule(scheduleID uuid.UUID) (*[]domainSchedule.Interruption, error)
+	// GetOpen returns the interruption the visit is paused on, or NotFound.
+	GetOpen(scheduleID uuid.UUID) (*domainSchedule.Interruption, error)
+	// GetBetween returns the interruptions started in [from, to).
+	GetBetween(from time.Time, to time.Time) (*[]domainSchedule.Interruption, error)
+	End(id uuid.UUID, endedAt time.Time) (*domainSchedule.Interruption, error)
+}
+
+// Count is how often visits were interrupted and for how long, grouped by
+// Key: a reason code or a user ID.
+type Count struct {
+	Key     string
+	Count   int
+	Minutes int
+}
+
+// Report is how often visits were interrupted over a period, by reason,
+// client and caregiver, busiest first.
+type Report struct {
+	From        time.Time
+	To          time.Time
+	Total       int
+	Minutes     int
+	ByReason    []Count
+	ByClient    []Count
+	ByCaregiver []Count
+}
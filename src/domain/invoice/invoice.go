@@ -0,0 +1,112 @@
+package invoice
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RateCard prices a service for clients paying privately: a visit's worked
+// time is billed in units of UnitMinutes at UnitRate. A rate card applies to
+// visits from EffectiveFrom through EffectiveTo, both inclusive dates; a nil
+// EffectiveTo leaves it open-ended. Visits billed to a payer are priced by
+// the payer's rates instead.
+type RateCard struct {
+	ID            uuid.UUID
+	ServiceName   string
+	UnitMinutes   int
+	UnitRate      float64
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// EffectiveOn reports whether the rate card applies to a visit on the day.
+func (r *RateCard) EffectiveOn(day time.Time) bool {
+	day = DateOf(day)
+	return !day.Before(DateOf(r.EffectiveFrom)) && (r.EffectiveTo == nil || !day.After(DateOf(*r.EffectiveTo)))
+}
+
+// Overlaps reports whether two rate cards are in effect on a common day.
+func (r *RateCard) Overlaps(other *RateCard) bool {
+	startsBeforeOtherEnds := other.EffectiveTo == nil || !DateOf(r.EffectiveFrom).After(DateOf(*other.EffectiveTo))
+	otherStartsBeforeEnd := r.EffectiveTo == nil || !DateOf(other.EffectiveFrom).After(DateOf(*r.EffectiveTo))
+	return startsBeforeOtherEnds && otherStartsBeforeEnd
+}
+
+// Units is how many units the worked time rounds to.
+func (r *RateCard) Units(worked time.Duration) int {
+	return int(math.Round(worked.Minutes() / float64(r.UnitMinutes)))
+}
+
+// EffectiveRateCard picks the rate card for a service in effect on the day.
+func EffectiveRateCard(cards []RateCard, serviceName string, day time.Time) (*RateCard, bool) {
+	for i := range cards {
+		if strings.EqualFold(cards[i].ServiceName, serviceName) && cards[i].EffectiveOn(day) {
+			return &cards[i], true
+		}
+	}
+	return nil, false
+}
+
+// DateOf returns the UTC day t falls on.
+func DateOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Invoice bills a client for the visits completed from PeriodStart through
+// PeriodEnd, both inclusive dates. Number is the reference printed on it.
+type Invoice struct {
+	ID           uuid.UUID
+	Number       string
+	ClientUserID uuid.UUID
+	ClientName   string
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+	Total        float64
+	Lines        []LineItem
+	IssuedAt     time.Time
+	CreatedAt    time.Time
+}
+
+// LineItem bills one visit. A visit is billed on at most one invoice.
+type LineItem struct {
+	ID            uuid.UUID
+	InvoiceID     uuid.UUID
+	ScheduleID    uuid.UUID
+	ServiceDate   time.Time
+	ServiceName   string
+	WorkedMinutes int
+	Units         int
+	UnitRate      float64
+	Amount        float64
+}
+
+// Filter narrows invoices to a client and to those whose period overlaps
+// the days From through To.
+type Filter struct {
+	ClientUserID *uuid.UUID
+	From         *time.Time
+	To           *time.Time
+}
+
+type IInvoiceRepository interface {
+	CreateRateCard(newRateCard *RateCard) (*RateCard, error)
+	GetRateCardByID(id uuid.UUID) (*RateCard, error)
+	GetRateCards() (*[]RateCard, error)
+	UpdateRateCard(id uuid.UUID, updates map[string]interface{}) (*RateCard, error)
+	DeleteRateCard(id uuid.UUID) error
+	// CreateInvoices saves the invoices and their line items together.
+	CreateInvoices(invoices []Invoice) error
+	// GetInvoices returns the invoices without their line items, most
+	// recent period first.
+	GetInvoices(filter Filter) (*[]Invoice, error)
+	GetInvoiceByID(id uuid.UUID) (*Invoice, error)
+	// GetInvoicedScheduleIDs reports which of the visits are already billed
+	// on an invoice.
+	GetInvoicedScheduleIDs(scheduleIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+}
@@ -0,0 +1,33 @@
+package invoice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEffectiveRateCard(t *testing.T) {
+	march := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	endFebruary := march.AddDate(0, 0, -1)
+	cards := []RateCard{
+		{ServiceName: "Personal care", UnitMinutes: 15, UnitRate: 7, EffectiveTo: &endFebruary},
+		{ServiceName: "Personal care", UnitMinutes: 15, UnitRate: 8, EffectiveFrom: march},
+	}
+
+	card, ok := EffectiveRateCard(cards, "personal care", time.Date(2026, 2, 28, 23, 30, 0, 0, time.UTC))
+	if !ok || card.UnitRate != 7 {
+		t.Errorf("expected the February rate on the last day of February, got %+v", card)
+	}
+	card, ok = EffectiveRateCard(cards, "Personal care", march.Add(9*time.Hour))
+	if !ok || card.UnitRate != 8 {
+		t.Errorf("expected the March rate, got %+v", card)
+	}
+	if _, ok := EffectiveRateCard(cards, "Companionship", march); ok {
+		t.Error("expected no rate card for another service")
+	}
+	if cards[0].Overlaps(&cards[1]) {
+		t.Error("expected consecutive rate cards not to overlap")
+	}
+	if units := cards[1].Units(52 * time.Minute); units != 3 {
+		t.Errorf("expected 52 minutes to round to 3 units, got %d", units)
+	}
+}
@@ -0,0 +1,90 @@
+package kiosk
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ActionCheckIn  = "check_in"
+	ActionCheckOut = "check_out"
+	// ActionPINRejected records a wrong PIN. Repeated failures lock the
+	// caregiver out of kiosks for a while.
+	ActionPINRejected = "pin_rejected"
+	// ActionRejected records any other refused attempt, e.g. a visit at
+	// another address or one that cannot be started yet.
+	ActionRejected = "rejected"
+)
+
+// Address is the facility a kiosk is installed at. Visits whose client lives
+// at the same address can be checked in from the kiosk.
+type Address struct {
+	HouseNumber string
+	Street      string
+	City        string
+	State       string
+	Pincode     string
+}
+
+// Matches compares the parts of an address that identify a building,
+// ignoring case and surrounding whitespace.
+func (a Address) Matches(other Address) bool {
+	parts := func(x Address) [4]string {
+		return [4]string{x.HouseNumber, x.Street, x.City, x.Pincode}
+	}
+	mine, theirs := parts(a), parts(other)
+	for i := range mine {
+		if !strings.EqualFold(strings.TrimSpace(mine[i]), strings.TrimSpace(theirs[i])) {
+			return false
+		}
+	}
+	return strings.TrimSpace(a.Street) != ""
+}
+
+// Kiosk is a shared tablet at a facility. It authenticates with its own
+// token, which only grants check-in and check-out for visits at its address.
+type Kiosk struct {
+	ID         uuid.UUID
+	Name       string
+	Address    Address
+	TokenHash  string
+	Active     bool
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// PIN is a caregiver's kiosk PIN. Only its hash is stored.
+type PIN struct {
+	UserID    uuid.UUID
+	PINHash   string
+	UpdatedAt time.Time
+}
+
+// AuditEntry records every kiosk attempt, successful or not.
+type AuditEntry struct {
+	ID              uuid.UUID
+	KioskID         uuid.UUID
+	ScheduleID      *uuid.UUID
+	CaregiverUserID *uuid.UUID
+	Action          string
+	Detail          string
+	IPAddress       string
+	OccurredAt      time.Time
+}
+
+type IKioskRepository interface {
+	Create(newKiosk *Kiosk) (*Kiosk, error)
+	GetByID(id uuid.UUID) (*Kiosk, error)
+	GetAll() (*[]Kiosk, error)
+	GetByTokenHash(tokenHash string) (*Kiosk, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Kiosk, error)
+	Delete(id uuid.UUID) error
+	GetPIN(userID uuid.UUID) (*PIN, error)
+	SavePIN(pin *PIN) (*PIN, error)
+	CreateAuditEntry(entry *AuditEntry) (*AuditEntry, error)
+	GetAuditEntries(kioskID uuid.UUID, from, to time.Time) (*[]AuditEntry, error)
+	CountPINFailuresSince(caregiverUserID uuid.UUID, since time.Time) (int64, error)
+}
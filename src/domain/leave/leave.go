@@ -0,0 +1,143 @@
+package leave
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TypePTO draws on the caregiver's accrued balance; sick and unpaid
+	// leave only block scheduling.
+	TypePTO    = "pto"
+	TypeSick   = "sick"
+	TypeUnpaid = "unpaid"
+
+	StatusPending   = "pending"
+	StatusApproved  = "approved"
+	StatusDenied    = "denied"
+	StatusCancelled = "cancelled"
+
+	// EntryAccrual is earned on a completed visit, EntryUsage is taken by an
+	// approved PTO request and EntryRefund gives it back on cancellation.
+	// EntryAdjustment is a manual correction such as an opening balance.
+	EntryAccrual    = "accrual"
+	EntryUsage      = "usage"
+	EntryRefund     = "refund"
+	EntryAdjustment = "adjustment"
+)
+
+// AccrualRule earns a caregiver HoursPerHourWorked hours of PTO for every
+// hour worked on a completed visit, until the balance reaches MaxBalance.
+// A MaxBalance of 0 means no cap.
+type AccrualRule struct {
+	ID                 uuid.UUID
+	CaregiverUserID    uuid.UUID
+	HoursPerHourWorked float64
+	MaxBalance         float64
+	Active             bool
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// Request is a caregiver's request to be away over [From, To). Hours is the
+// PTO it draws, which may be less than the period for part-time caregivers.
+type Request struct {
+	ID              uuid.UUID
+	CaregiverUserID uuid.UUID
+	Type            string
+	From            time.Time
+	To              time.Time
+	Hours           float64
+	Reason          string
+	Status          string
+	DecidedByUserID *uuid.UUID
+	DecisionNote    string
+	DecidedAt       *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Overlaps reports whether the request's period intersects [from, to).
+func (r *Request) Overlaps(from, to time.Time) bool {
+	return r.From.Before(to) && from.Before(r.To)
+}
+
+// Entry is one movement on a caregiver's PTO ledger. Positive hours credit
+// the balance, negative hours debit it.
+type Entry struct {
+	ID              uuid.UUID
+	CaregiverUserID uuid.UUID
+	Type            string
+	Hours           float64
+	ScheduleID      *uuid.UUID
+	RequestID       *uuid.UUID
+	Note            string
+	CreatedAt       time.Time
+}
+
+// Balance summarises a caregiver's ledger. Available is what approved
+// requests can still draw; Pending is the PTO asked for but not yet decided.
+type Balance struct {
+	CaregiverUserID uuid.UUID
+	Accrued         float64
+	Used            float64
+	Adjusted        float64
+	Available       float64
+	Pending         float64
+}
+
+// RequestFilter narrows a request listing. From and To select requests whose
+// period overlaps [From, To).
+type RequestFilter struct {
+	CaregiverUserID *uuid.UUID
+	Statuses        []string
+	From            *time.Time
+	To              *time.Time
+}
+
+// Decision is the result of approving a request. ConflictingScheduleIDs are
+// visits the caregiver is still assigned during the leave and that need
+// another caregiver.
+type Decision struct {
+	Request                *Request
+	ConflictingScheduleIDs []uuid.UUID
+}
+
+type ILeaveRepository interface {
+	CreateRule(newRule *AccrualRule) (*AccrualRule, error)
+	GetRuleByID(id uuid.UUID) (*AccrualRule, error)
+	GetRuleByCaregiver(caregiverUserID uuid.UUID) (*AccrualRule, error)
+	GetRules() (*[]AccrualRule, error)
+	UpdateRule(id uuid.UUID, updates map[string]interface{}) (*AccrualRule, error)
+	DeleteRule(id uuid.UUID) error
+	CreateRequest(newRequest *Request) (*Request, error)
+	GetRequestByID(id uuid.UUID) (*Request, error)
+	GetRequests(filter RequestFilter) (*[]Request, error)
+	UpdateRequest(id uuid.UUID, updates map[string]interface{}) (*Request, error)
+	CreateEntry(entry *Entry) (*Entry, error)
+	GetEntries(caregiverUserID uuid.UUID) (*[]Entry, error)
+	HasAccrualForSchedule(scheduleID uuid.UUID) (bool, error)
+}
+
+// Summarize totals the ledger entries and the PTO held by pending requests.
+func Summarize(caregiverUserID uuid.UUID, entries []Entry, pending []Request) Balance {
+	balance := Balance{CaregiverUserID: caregiverUserID}
+	for _, e := range entries {
+		switch e.Type {
+		case EntryAccrual:
+			balance.Accrued += e.Hours
+		case EntryUsage, EntryRefund:
+			balance.Used -= e.Hours
+		case EntryAdjustment:
+			balance.Adjusted += e.Hours
+		}
+		balance.Available += e.Hours
+	}
+	for _, r := range pending {
+		if r.Status == StatusPending && r.Type == TypePTO {
+			balance.Pending += r.Hours
+		}
+	}
+	return balance
+}
@@ -0,0 +1,86 @@
+package legalhold
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Hold keeps a client's records from being deleted or merged into another
+// client while litigation or an audit needs them. A released hold stays on
+// record with who released it and when.
+type Hold struct {
+	ID               uuid.UUID
+	ClientUserID     uuid.UUID
+	Reason           string
+	PlacedByUserID   *uuid.UUID
+	PlacedAt         time.Time
+	ReleasedByUserID *uuid.UUID
+	ReleasedAt       *time.Time
+}
+
+// Active reports whether the hold has not been released.
+func (h Hold) Active() bool {
+	return h.ReleasedAt == nil
+}
+
+// Record is one row of a client's data: the table it is in, its primary key,
+// the time it was created or happened, and all its columns.
+type Record struct {
+	Table string
+	ID    string
+	At    time.Time
+	Data  map[string]interface{}
+}
+
+// Timeline is everything held about a client, oldest record first, as
+// written into an export.
+type Timeline struct {
+	ClientUserID     uuid.UUID
+	ExportedByUserID *uuid.UUID
+	GeneratedAt      time.Time
+	Holds            []Hold
+	Records          []Record
+}
+
+// ManifestFile is the digest of one file in an export archive.
+type ManifestFile struct {
+	Name   string
+	Size   int
+	SHA256 string
+}
+
+// Manifest lists the files of an export archive. It is signed, so the
+// signature covers every file through its digest.
+type Manifest struct {
+	ClientUserID uuid.UUID
+	GeneratedAt  time.Time
+	Records      int
+	Algorithm    string
+	PublicKey    string
+	Files        []ManifestFile
+}
+
+// Archive is a signed client timeline export.
+type Archive struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+	Records     int
+}
+
+type ILegalHoldRepository interface {
+	// GetActive returns the client's unreleased hold, or a NotFound error
+	// when there is none.
+	GetActive(clientUserID uuid.UUID) (*Hold, error)
+	// GetAllActive returns every unreleased hold, most recent first.
+	GetAllActive() (*[]Hold, error)
+	// GetByClient returns the client's holds, released ones included, most
+	// recent first.
+	GetByClient(clientUserID uuid.UUID) (*[]Hold, error)
+	Create(hold *Hold) (*Hold, error)
+	Release(id uuid.UUID, releasedBy *uuid.UUID, at time.Time) (*Hold, error)
+	// GetRecords returns every row belonging to the client across the
+	// database, soft-deleted ones included, with secrets left out.
+	GetRecords(clientUserID uuid.UUID) (*[]Record, error)
+}
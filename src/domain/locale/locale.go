@@ -0,0 +1,114 @@
+package locale
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DateLayout is how holiday dates are written.
+const DateLayout = "2006-01-02"
+
+// Settings is the organization's locale, of which there is at most one.
+// Timezone is an IANA zone name and Region an ISO 3166 country code,
+// optionally with a subdivision ("US", "CA-ON"), whose public holidays
+// apply. With SkipHolidays set, recurring and planned visits are not booked
+// on holidays.
+type Settings struct {
+	ID           uuid.UUID
+	Timezone     string
+	Region       string
+	SkipHolidays bool
+	UpdatedAt    time.Time
+}
+
+// Location returns the organization's time zone, UTC when unset or unknown.
+func (s *Settings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Holiday is a day off in the organization's calendar. Public holidays come
+// from the holiday source for the region; custom ones, such as an office
+// closure, are added by admins and carry an ID.
+type Holiday struct {
+	ID        uuid.UUID
+	Date      string
+	Name      string
+	Region    string
+	Custom    bool
+	CreatedAt time.Time
+}
+
+// Calendar answers what day and which holidays it is in the organization's
+// locale.
+type Calendar struct {
+	Location     *time.Location
+	SkipHolidays bool
+	holidays     map[string]Holiday
+}
+
+// NewCalendar builds the calendar from the settings and the holidays that
+// apply. Custom holidays win over public ones on the same day.
+func NewCalendar(settings *Settings, holidays []Holiday) *Calendar {
+	calendar := &Calendar{
+		Location:     settings.Location(),
+		SkipHolidays: settings.SkipHolidays,
+		holidays:     make(map[string]Holiday, len(holidays)),
+	}
+	for _, holiday := range holidays {
+		if existing, ok := calendar.holidays[holiday.Date]; ok && existing.Custom {
+			continue
+		}
+		calendar.holidays[holiday.Date] = holiday
+	}
+	return calendar
+}
+
+// UTCCalendar is the calendar used when no locale is configured: UTC days
+// without holidays.
+func UTCCalendar() *Calendar {
+	return NewCalendar(&Settings{}, nil)
+}
+
+// Local returns t in the organization's time zone.
+func (c *Calendar) Local(t time.Time) time.Time {
+	return t.In(c.Location)
+}
+
+// Midnight returns the start of the local day holding t.
+func (c *Calendar) Midnight(t time.Time) time.Time {
+	local := c.Local(t)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.Location)
+}
+
+// HolidayOn returns the holiday falling on the local day holding t.
+func (c *Calendar) HolidayOn(t time.Time) (Holiday, bool) {
+	holiday, ok := c.holidays[c.Local(t).Format(DateLayout)]
+	return holiday, ok
+}
+
+// Closed reports whether visits are kept off the local day holding t.
+func (c *Calendar) Closed(t time.Time) bool {
+	if !c.SkipHolidays {
+		return false
+	}
+	_, ok := c.HolidayOn(t)
+	return ok
+}
+
+type ILocaleRepository interface {
+	GetSettings() (*Settings, error)
+	SaveSettings(settings *Settings) (*Settings, error)
+	// GetHolidays returns the custom holidays dated from to to inclusive,
+	// both written as DateLayout.
+	GetHolidays(from, to string) (*[]Holiday, error)
+	CreateHoliday(holiday *Holiday) (*Holiday, error)
+	DeleteHoliday(id uuid.UUID) error
+}
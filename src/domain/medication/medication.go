@@ -0,0 +1,104 @@
+package medication
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SeverityMild     = "mild"
+	SeverityModerate = "moderate"
+	SeveritySevere   = "severe"
+)
+
+const (
+	WarningAllergy          = "allergy"
+	WarningInteraction      = "interaction"
+	WarningCheckUnavailable = "check_unavailable"
+)
+
+// Allergy is a substance a client must not be given.
+type Allergy struct {
+	ID           uuid.UUID
+	ClientUserID uuid.UUID
+	Substance    string
+	Reaction     string
+	Severity     string
+	CreatedAt    time.Time
+}
+
+// Medication is an entry on a client's medication list. Active medications
+// are checked for interactions with anything administered.
+type Medication struct {
+	ID           uuid.UUID
+	ClientUserID uuid.UUID
+	Name         string
+	Dose         string
+	Route        string
+	Frequency    string
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Warning is a safety concern raised when logging an administration.
+type Warning struct {
+	Kind     string
+	Severity string
+	Subject  string
+	Message  string
+}
+
+// Administration records a caregiver giving a client a drug during a visit.
+// Warnings raised at the time are kept with the record together with when the
+// caregiver acknowledged them.
+type Administration struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	ClientUserID    uuid.UUID
+	CaregiverUserID uuid.UUID
+	MedicationID    *uuid.UUID
+	DrugName        string
+	Dose            string
+	Route           string
+	AdministeredAt  time.Time
+	Note            string
+	Warnings        []Warning
+	AcknowledgedAt  *time.Time
+	CreatedAt       time.Time
+}
+
+type AdministrationFilter struct {
+	ScheduleID   *uuid.UUID
+	ClientUserID *uuid.UUID
+}
+
+// WarningsError is returned when an administration raises warnings that have
+// not been acknowledged. The warnings are sent to the client as details.
+type WarningsError struct {
+	Message  string
+	Warnings []Warning
+}
+
+func (e *WarningsError) Error() string {
+	return e.Message
+}
+
+func (e *WarningsError) ErrorDetails() any {
+	return e
+}
+
+type IMedicationRepository interface {
+	CreateAllergy(newAllergy *Allergy) (*Allergy, error)
+	GetAllergies(clientUserID uuid.UUID) (*[]Allergy, error)
+	DeleteAllergy(id uuid.UUID) error
+	CreateMedication(newMedication *Medication) (*Medication, error)
+	GetMedicationByID(id uuid.UUID) (*Medication, error)
+	GetMedications(clientUserID uuid.UUID) (*[]Medication, error)
+	UpdateMedication(id uuid.UUID, updates map[string]interface{}) (*Medication, error)
+	DeleteMedication(id uuid.UUID) error
+	CreateAdministration(newAdministration *Administration) (*Administration, error)
+	// GetAdministrations returns matching administrations, most recent first.
+	GetAdministrations(filter AdministrationFilter) (*[]Administration, error)
+}
@@ -0,0 +1,28 @@
+package nfctag
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag is an NFC sticker installed in a client's home. Scanning it at check-in
+// proves the caregiver is on site when GPS is unreliable.
+type Tag struct {
+	ID           uuid.UUID
+	ClientUserID uuid.UUID
+	TagValue     string
+	Label        string
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type INFCTagRepository interface {
+	Create(newTag *Tag) (*Tag, error)
+	GetByID(id uuid.UUID) (*Tag, error)
+	GetByClientUserID(clientUserID uuid.UUID) (*[]Tag, error)
+	GetActiveByValue(clientUserID uuid.UUID, tagValue string) (*Tag, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Tag, error)
+	Delete(id uuid.UUID) error
+}
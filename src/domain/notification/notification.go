@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+	PlatformWeb     = "web"
+)
+
+// Platforms are the apps a device can be registered from.
+var Platforms = []string{PlatformIOS, PlatformAndroid, PlatformWeb}
+
+// IsPlatform reports whether p is one of Platforms.
+func IsPlatform(p string) bool {
+	for _, platform := range Platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelPush  = "push"
+)
+
+// Channels are the ways a notification can reach a user.
+var Channels = []string{ChannelEmail, ChannelSMS, ChannelPush}
+
+// IsChannel reports whether c is one of Channels.
+func IsChannel(c string) bool {
+	for _, channel := range Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// Device is an app install that receives push notifications for a user.
+// Token is the push token the app got from Firebase Cloud Messaging.
+type Device struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Platform   string
+	Token      string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+}
+
+type IDeviceRepository interface {
+	GetByUser(userID uuid.UUID) (*[]Device, error)
+	// GetTokens returns the push tokens of each of the users.
+	GetTokens(userIDs []uuid.UUID) (map[uuid.UUID][]string, error)
+	// Register adds the device, or moves an already known token to the user
+	// and marks it seen.
+	Register(device *Device) (*Device, error)
+	Delete(userID uuid.UUID, id uuid.UUID) error
+}
+
+// ChannelPreference is the channels a user wants to be notified on. Users
+// without one are notified on every channel that can reach them.
+type ChannelPreference struct {
+	UserID    uuid.UUID
+	Channels  []string
+	UpdatedAt time.Time
+}
+
+type IPreferenceRepository interface {
+	// GetPreference returns the user's preference, NotFound when they have
+	// not set one.
+	GetPreference(userID uuid.UUID) (*ChannelPreference, error)
+	// SavePreference creates or replaces the user's preference.
+	SavePreference(preference *ChannelPreference) (*ChannelPreference, error)
+	// GetChannels returns the preferred channels of those of the users who
+	// set a preference.
+	GetChannels(userIDs []uuid.UUID) (map[uuid.UUID][]string, error)
+}
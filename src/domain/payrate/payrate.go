@@ -0,0 +1,89 @@
+package payrate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// SourceRateTable marks a rate resolved from the pay rate table;
+	// SourceProfile one taken from the caregiver profile in effect that day.
+	SourceRateTable = "rate_table"
+	SourceProfile   = "profile"
+)
+
+// PayRate is what a caregiver earns per hour worked. A rate with an empty
+// ServiceName covers every service the caregiver has no specific rate for.
+// It applies from EffectiveFrom through EffectiveTo, both inclusive dates; a
+// nil EffectiveTo leaves it open-ended.
+type PayRate struct {
+	ID              uuid.UUID
+	CaregiverUserID uuid.UUID
+	ServiceName     string
+	HourlyRate      float64
+	EffectiveFrom   time.Time
+	EffectiveTo     *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// EffectiveOn reports whether the rate applies to a visit on the given day.
+func (r *PayRate) EffectiveOn(day time.Time) bool {
+	day = dateOf(day)
+	return !day.Before(dateOf(r.EffectiveFrom)) && (r.EffectiveTo == nil || !day.After(dateOf(*r.EffectiveTo)))
+}
+
+// Overlaps reports whether two rates for the same caregiver and service are
+// in effect on a common day.
+func (r *PayRate) Overlaps(other *PayRate) bool {
+	if !strings.EqualFold(r.ServiceName, other.ServiceName) {
+		return false
+	}
+	startsBeforeOtherEnds := other.EffectiveTo == nil || !dateOf(r.EffectiveFrom).After(dateOf(*other.EffectiveTo))
+	otherStartsBeforeEnd := r.EffectiveTo == nil || !dateOf(other.EffectiveFrom).After(dateOf(*r.EffectiveTo))
+	return startsBeforeOtherEnds && otherStartsBeforeEnd
+}
+
+// Select picks the rate for a service in effect on the visit day, preferring
+// a service-specific rate over the caregiver's general one.
+func Select(rates []PayRate, serviceName string, day time.Time) (*PayRate, bool) {
+	var general *PayRate
+	for i := range rates {
+		if !rates[i].EffectiveOn(day) {
+			continue
+		}
+		if rates[i].ServiceName == "" {
+			general = &rates[i]
+		} else if strings.EqualFold(rates[i].ServiceName, serviceName) {
+			return &rates[i], true
+		}
+	}
+	return general, general != nil
+}
+
+// EffectiveRate is the hourly rate resolved for a caregiver, service and day.
+// PayRateID is set when it came from the rate table.
+type EffectiveRate struct {
+	CaregiverUserID uuid.UUID
+	ServiceName     string
+	Date            time.Time
+	HourlyRate      float64
+	Source          string
+	PayRateID       *uuid.UUID
+}
+
+func dateOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+type IPayRateRepository interface {
+	Create(newRate *PayRate) (*PayRate, error)
+	GetByID(id uuid.UUID) (*PayRate, error)
+	// GetAll lists rates, for one caregiver when caregiverUserID is set.
+	GetAll(caregiverUserID *uuid.UUID) (*[]PayRate, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*PayRate, error)
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,76 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// StatusClosed periods lock their visits against edits.
+	StatusClosed = "closed"
+	// StatusReopened periods were closed and opened again by an admin.
+	StatusReopened = "reopened"
+)
+
+// UnlockWindow is how long an admin unlock lets a locked visit be edited.
+const UnlockWindow = 24 * time.Hour
+
+// Period is a payroll period over [From, To). While it is closed, visits
+// starting in it cannot be changed through the API unless an admin unlocks
+// them.
+type Period struct {
+	ID           uuid.UUID
+	From         time.Time
+	To           time.Time
+	Status       string
+	ClosedBy     *uuid.UUID
+	ClosedAt     time.Time
+	ReopenedBy   *uuid.UUID
+	ReopenedAt   *time.Time
+	ReopenReason string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Closed reports whether the period currently locks its visits.
+func (p *Period) Closed() bool {
+	return p.Status == StatusClosed
+}
+
+// Contains reports whether t falls in the period.
+func (p *Period) Contains(t time.Time) bool {
+	return !t.Before(p.From) && t.Before(p.To)
+}
+
+// Unlock lets one visit in a closed period be edited until ExpiresAt. The
+// reason is kept for the payroll audit.
+type Unlock struct {
+	ID         uuid.UUID
+	ScheduleID uuid.UUID
+	PeriodID   uuid.UUID
+	Reason     string
+	UnlockedBy *uuid.UUID
+	UnlockedAt time.Time
+	ExpiresAt  time.Time
+}
+
+// ActiveAt reports whether the unlock still applies at t.
+func (u *Unlock) ActiveAt(t time.Time) bool {
+	return t.Before(u.ExpiresAt)
+}
+
+type IPayrollRepository interface {
+	CreatePeriod(period *Period) (*Period, error)
+	GetPeriods() (*[]Period, error)
+	GetPeriodByID(id uuid.UUID) (*Period, error)
+	// GetClosedPeriods returns the closed periods overlapping [from, to).
+	GetClosedPeriods(from, to time.Time) (*[]Period, error)
+	UpdatePeriod(id uuid.UUID, updates map[string]interface{}) (*Period, error)
+	CreateUnlock(unlock *Unlock) (*Unlock, error)
+	// GetUnlocks returns the unlocks of a visit, newest first.
+	GetUnlocks(scheduleID uuid.UUID) (*[]Unlock, error)
+	// GetTaskSchedule returns the visit a task belongs to and when it is
+	// scheduled to start, including soft-deleted tasks and visits.
+	GetTaskSchedule(taskID uuid.UUID) (uuid.UUID, time.Time, error)
+}
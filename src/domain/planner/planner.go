@@ -0,0 +1,217 @@
+package planner
+
+import (
+	"time"
+
+	domainLocale "caregiver/src/domain/locale"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusDraft     = "draft"
+	StatusCommitted = "committed"
+	StatusDiscarded = "discarded"
+)
+
+// Template describes the visits a client needs when the planner books them:
+// what service, how long, from what time of day and on which weekdays
+// (0 = Sunday). No weekdays means any day. How many visits comes from the
+// client's care plan.
+type Template struct {
+	ID              uuid.UUID
+	ClientUserID    uuid.UUID
+	ServiceName     string
+	DurationMinutes int
+	StartMinute     int
+	Weekdays        []int
+	Timezone        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Location returns the template's time zone, UTC when unset or unknown.
+func (t *Template) Location() *time.Location {
+	return loadLocation(t.Timezone)
+}
+
+// Availability is a weekly window in which a caregiver can be booked, from
+// StartMinute to EndMinute of the Weekday in the caregiver's Timezone.
+// Caregivers without any window are never planned.
+type Availability struct {
+	ID              uuid.UUID
+	CaregiverUserID uuid.UUID
+	Weekday         int
+	StartMinute     int
+	EndMinute       int
+	Timezone        string
+}
+
+// Covers reports whether the window holds all of [from, to).
+func (a *Availability) Covers(from, to time.Time) bool {
+	local := from.In(loadLocation(a.Timezone))
+	if int(local.Weekday()) != a.Weekday {
+		return false
+	}
+	start := local.Hour()*60 + local.Minute()
+	end := start + int(to.Sub(from).Minutes())
+	return start >= a.StartMinute && end <= a.EndMinute
+}
+
+// CoveredBy reports whether windows together hold all of [from, to). Back to
+// back windows count as one, so a visit may run from one into the next,
+// including across midnight.
+func CoveredBy(windows []Availability, from, to time.Time) bool {
+	cursor := from
+	for i := 0; i <= len(windows) && cursor.Before(to); i++ {
+		advanced := false
+		for _, window := range windows {
+			if end, ok := window.endAfter(cursor); ok && end.After(cursor) {
+				cursor = end
+				advanced = true
+				break
+			}
+		}
+		if !advanced {
+			return false
+		}
+	}
+	return !cursor.Before(to)
+}
+
+// endAfter returns when the window ends if it is open at t.
+func (a *Availability) endAfter(t time.Time) (time.Time, bool) {
+	local := t.In(loadLocation(a.Timezone))
+	if int(local.Weekday()) != a.Weekday {
+		return time.Time{}, false
+	}
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	offset := local.Sub(midnight)
+	if offset < time.Duration(a.StartMinute)*time.Minute || offset >= time.Duration(a.EndMinute)*time.Minute {
+		return time.Time{}, false
+	}
+	return midnight.Add(time.Duration(a.EndMinute) * time.Minute), true
+}
+
+// Proposal is a planned week of visits for coordinators to review. Nothing
+// is booked until it is committed; then each accepted assignment becomes a
+// schedule, or records why it could not.
+type Proposal struct {
+	ID                uuid.UUID
+	WeekStart         time.Time
+	WeekEnd           time.Time
+	Status            string
+	Solver            string
+	Assignments       []Assignment
+	Unplaced          []Unplaced
+	CreatedByUserID   *uuid.UUID
+	CreatedAt         time.Time
+	CommittedByUserID *uuid.UUID
+	CommittedAt       *time.Time
+}
+
+// Assignment is one proposed visit. Coordinators can reject it or give it to
+// another caregiver before committing.
+type Assignment struct {
+	ID              uuid.UUID
+	ProposalID      uuid.UUID
+	ClientUserID    uuid.UUID
+	CaregiverUserID uuid.UUID
+	ServiceName     string
+	From            time.Time
+	To              time.Time
+	DistanceKm      *float64
+	Warnings        []string
+	Accepted        bool
+	ScheduleID      *uuid.UUID
+	Error           string
+}
+
+// Unplaced counts the visits a client needs that the planner could not fit.
+type Unplaced struct {
+	ClientUserID uuid.UUID
+	Visits       int
+	Reason       string
+}
+
+// Demand is the visits one client still needs in the planned week.
+type Demand struct {
+	Template Template
+	Client   domainUser.User
+	Visits   int
+}
+
+// Caregiver is a caregiver the solver may book: when they work, what they
+// are already booked for, and how many recent visits they made to each
+// client. Screened holds the clients the caregiver must not be sent to, and
+// Warnings any notes to pass on with their assignments.
+type Caregiver struct {
+	User     domainUser.User
+	Windows  []Availability
+	Busy     []Slot
+	Recent   map[uuid.UUID]int
+	Screened map[uuid.UUID]bool
+	Warnings map[uuid.UUID][]string
+}
+
+// Slot is a booked period.
+type Slot struct {
+	From time.Time
+	To   time.Time
+}
+
+// Overlaps reports whether the slot intersects [from, to).
+func (s Slot) Overlaps(from, to time.Time) bool {
+	return s.From.Before(to) && from.Before(s.To)
+}
+
+// Problem is everything a solver needs to plan a week. ClientBusy holds the
+// visits clients already have, NotBefore the earliest a visit may start.
+type Problem struct {
+	WeekStart  time.Time
+	WeekEnd    time.Time
+	NotBefore  time.Time
+	Demands    []Demand
+	Caregivers []Caregiver
+	ClientBusy map[uuid.UUID][]Slot
+	// Calendar is the organization's: templates without a time zone use its
+	// location and days it is closed get no visits. Nil means UTC days
+	// without holidays.
+	Calendar *domainLocale.Calendar
+}
+
+// Solution is a solver's plan.
+type Solution struct {
+	Assignments []Assignment
+	Unplaced    []Unplaced
+}
+
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+type IPlannerRepository interface {
+	GetTemplates() (*[]Template, error)
+	GetTemplate(clientUserID uuid.UUID) (*Template, error)
+	// SaveTemplate creates the client's template or replaces it.
+	SaveTemplate(template *Template) (*Template, error)
+	DeleteTemplate(clientUserID uuid.UUID) error
+	// GetAvailability returns the windows of the given caregivers, or of
+	// every caregiver when none are given.
+	GetAvailability(caregiverUserIDs []uuid.UUID) (*[]Availability, error)
+	// ReplaceAvailability swaps all of a caregiver's windows for windows.
+	ReplaceAvailability(caregiverUserID uuid.UUID, windows []Availability) (*[]Availability, error)
+	CreateProposal(proposal *Proposal) (*Proposal, error)
+	GetProposal(id uuid.UUID) (*Proposal, error)
+	GetProposals() (*[]Proposal, error)
+	UpdateProposal(proposal *Proposal) (*Proposal, error)
+	UpdateAssignment(assignment *Assignment) (*Assignment, error)
+}
@@ -0,0 +1,56 @@
+package profilechange
+
+import (
+	"time"
+
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// Update is what a caregiver may change on their own profile. Nil fields are
+// left as they are.
+type Update struct {
+	Phone          *string
+	ProfilePicture *string
+	Location       *domainUser.Location
+}
+
+// Change is a caregiver's address change held for a coordinator because it
+// moves the center of their service area. Location is the proposed address.
+type Change struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	Location        domainUser.Location
+	Status          string
+	DecidedByUserID *uuid.UUID
+	DecisionNote    string
+	DecidedAt       *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Result is the outcome of a self-service update: the profile as it now
+// stands and, if the address change needs approval, the pending change.
+type Result struct {
+	User    *domainUser.User
+	Pending *Change
+}
+
+type Filter struct {
+	UserID   *uuid.UUID
+	Statuses []string
+}
+
+type IProfileChangeRepository interface {
+	Create(change *Change) (*Change, error)
+	GetByID(id uuid.UUID) (*Change, error)
+	GetAll(filter Filter) (*[]Change, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Change, error)
+}
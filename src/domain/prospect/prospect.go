@@ -0,0 +1,72 @@
+package prospect
+
+import (
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusInquiry             = "inquiry"
+	StatusAssessmentScheduled = "assessment_scheduled"
+	StatusQuoted              = "quoted"
+	StatusAccepted            = "accepted"
+	StatusDeclined            = "declined"
+)
+
+// Channels a prospect can arrive through: entered by staff or submitted on
+// the public booking request form.
+const (
+	ChannelStaff   = "staff"
+	ChannelBooking = "booking_form"
+)
+
+// Prospect is a prospective client moving through the sales pipeline: an
+// inquiry, an in-home assessment and a quote. Accepting the quote creates the
+// client user, whose ID is kept in ClientUserID. ServiceName and
+// PreferredTimes record what a booking request asked for.
+type Prospect struct {
+	ID                 uuid.UUID
+	FirstName          string
+	LastName           string
+	Email              string
+	Phone              string
+	Location           domainUser.Location
+	ReferralSourceID   *uuid.UUID
+	CareNeeds          string
+	ServiceName        string
+	PreferredTimes     []domainSchedule.ScheduledSlot
+	Channel            string
+	Notes              string
+	Status             string
+	AssessmentAt       *time.Time
+	AssessorUserID     *uuid.UUID
+	AssessmentNotes    string
+	QuotedHourlyRate   *float64
+	QuotedHoursPerWeek *float64
+	QuoteNotes         string
+	QuotedAt           *time.Time
+	ClientUserID       *uuid.UUID
+	ConvertedAt        *time.Time
+	DeclineReason      string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// Open reports whether the prospect is still in the pipeline.
+func (p *Prospect) Open() bool {
+	return p.Status != StatusAccepted && p.Status != StatusDeclined
+}
+
+type IProspectRepository interface {
+	Create(newProspect *Prospect) (*Prospect, error)
+	GetByID(id uuid.UUID) (*Prospect, error)
+	// GetAll returns prospects with the given status, or all of them when it
+	// is empty, newest first.
+	GetAll(status string) (*[]Prospect, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Prospect, error)
+	Delete(id uuid.UUID) error
+}
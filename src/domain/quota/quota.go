@@ -0,0 +1,75 @@
+package quota
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MonthLayout names the calendar month, in UTC, that usage is counted in.
+const MonthLayout = "2006-01"
+
+// ClientOf identifies an API key without storing it. Usage, quotas and the
+// deprecation report all refer to callers this way.
+func ClientOf(apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return "key:" + hex.EncodeToString(sum[:])[:12]
+}
+
+// Quota caps the calls an agency's API key may make in a month. Past
+// SoftLimit calls still succeed but the agency is warned; past HardLimit
+// they are refused until the month ends. A zero limit is no limit.
+type Quota struct {
+	ID        uuid.UUID
+	Client    string
+	Name      string
+	SoftLimit int64
+	HardLimit int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Usage is the number of calls a client made in one month.
+type Usage struct {
+	Client     string
+	Month      string
+	Calls      int64
+	LastCallAt time.Time
+}
+
+// Status is where a client stands against its quota this month. Remaining
+// is nil without a hard limit.
+type Status struct {
+	Client        string
+	Name          string
+	Month         string
+	Calls         int64
+	SoftLimit     int64
+	HardLimit     int64
+	Remaining     *int64
+	OverSoftLimit bool
+	OverHardLimit bool
+	// SoftLimitReached is set on the one call that went past the soft limit.
+	SoftLimitReached bool
+	ResetsAt         time.Time
+}
+
+type IQuotaRepository interface {
+	// CountCall adds one call to the client's usage for the month and
+	// returns the new total.
+	CountCall(client, month string, at time.Time) (int64, error)
+	// GetUsage returns a client's usage, most recent month first.
+	GetUsage(client string) (*[]Usage, error)
+	// GetMonthUsage returns every client's usage in the month, busiest first.
+	GetMonthUsage(month string) (*[]Usage, error)
+	GetQuotas() (*[]Quota, error)
+	GetQuotaByClient(client string) (*Quota, error)
+	// SaveQuota creates the client's quota or replaces its limits.
+	SaveQuota(quota *Quota) (*Quota, error)
+	DeleteQuota(id uuid.UUID) error
+}
@@ -0,0 +1,139 @@
+package reference
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sections of a snapshot, each versioned on its own so the mobile app only
+// downloads the ones that changed.
+const (
+	SectionServices      = "Services"
+	SectionTaskTemplates = "TaskTemplates"
+	SectionStatuses      = "Statuses"
+	SectionSettings      = "Settings"
+	SectionFeatureFlags  = "FeatureFlags"
+)
+
+// Service is an entry of the service catalog visits are booked for.
+type Service struct {
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	DurationMinutes int
+	Active          bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// TaskTemplate is a task suggested for every visit of a service, listed by
+// Position.
+type TaskTemplate struct {
+	ID          uuid.UUID
+	ServiceID   uuid.UUID
+	Title       string
+	Description string
+	Position    int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Status is a visit status with its default calendar color.
+type Status struct {
+	Name  string
+	Color string
+}
+
+// Snapshot is the reference data the mobile app keeps offline. Versions holds
+// a hash per section and Version one over all of them; both change exactly
+// when the data does.
+type Snapshot struct {
+	Version       string
+	Versions      map[string]string
+	Services      []Service
+	TaskTemplates []TaskTemplate
+	Statuses      []Status
+	Settings      map[string]string
+	FeatureFlags  []string
+	// Unchanged lists the sections left out because the client already has
+	// their current version.
+	Unchanged []string
+}
+
+// Seal computes the snapshot's versions from its content.
+func (s *Snapshot) Seal() {
+	s.Versions = map[string]string{
+		SectionServices:      hash(SectionServices, s.Services),
+		SectionTaskTemplates: hash(SectionTaskTemplates, s.TaskTemplates),
+		SectionStatuses:      hash(SectionStatuses, s.Statuses),
+		SectionSettings:      hash(SectionSettings, s.Settings),
+		SectionFeatureFlags:  hash(SectionFeatureFlags, s.FeatureFlags),
+	}
+	s.Version = hash("", s.Versions)
+}
+
+// Current reports whether known holds the snapshot's overall version.
+func (s *Snapshot) Current(known []string) bool {
+	return contains(known, s.Version)
+}
+
+// Omit drops the sections whose current version is in known and lists them
+// as unchanged.
+func (s *Snapshot) Omit(known []string) {
+	s.Unchanged = nil
+	if contains(known, s.Versions[SectionServices]) {
+		s.Services = nil
+		s.Unchanged = append(s.Unchanged, SectionServices)
+	}
+	if contains(known, s.Versions[SectionTaskTemplates]) {
+		s.TaskTemplates = nil
+		s.Unchanged = append(s.Unchanged, SectionTaskTemplates)
+	}
+	if contains(known, s.Versions[SectionStatuses]) {
+		s.Statuses = nil
+		s.Unchanged = append(s.Unchanged, SectionStatuses)
+	}
+	if contains(known, s.Versions[SectionSettings]) {
+		s.Settings = nil
+		s.Unchanged = append(s.Unchanged, SectionSettings)
+	}
+	if contains(known, s.Versions[SectionFeatureFlags]) {
+		s.FeatureFlags = nil
+		s.Unchanged = append(s.Unchanged, SectionFeatureFlags)
+	}
+}
+
+// hash is a short content hash of a section. The section name is part of it
+// so two empty sections do not share a version.
+func hash(section string, content any) string {
+	encoded, _ := json.Marshal(content)
+	sum := sha256.Sum256(append([]byte(section+":"), encoded...))
+	return hex.EncodeToString(sum[:8])
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type IReferenceRepository interface {
+	GetServices() (*[]Service, error)
+	GetServiceByID(id uuid.UUID) (*Service, error)
+	CreateService(service *Service) (*Service, error)
+	UpdateService(id uuid.UUID, updates map[string]interface{}) (*Service, error)
+	// DeleteService removes the service and its task templates.
+	DeleteService(id uuid.UUID) error
+	// GetTaskTemplates returns the templates of the services, or of every
+	// service when none are given, ordered by service and position.
+	GetTaskTemplates(serviceIDs []uuid.UUID) (*[]TaskTemplate, error)
+	CreateTaskTemplate(template *TaskTemplate) (*TaskTemplate, error)
+	DeleteTaskTemplate(id uuid.UUID) error
+}
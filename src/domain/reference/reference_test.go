@@ -0,0 +1,49 @@
+package reference
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func snapshot() *Snapshot {
+	s := &Snapshot{
+		Services:     []Service{{ID: uuid.New(), Name: "Personal care", Active: true}},
+		Statuses:     []Status{{Name: "upcoming", Color: "#3B82F6"}},
+		Settings:     map[string]string{"Timezone": "UTC"},
+		FeatureFlags: []string{"planner"},
+	}
+	s.Seal()
+	return s
+}
+
+func TestSealVersionsFollowContent(t *testing.T) {
+	first := snapshot()
+	second := snapshot()
+	if first.Versions[SectionStatuses] != second.Versions[SectionStatuses] {
+		t.Error("expected equal statuses to share a version")
+	}
+	if first.Versions[SectionServices] == second.Versions[SectionServices] || first.Version == second.Version {
+		t.Error("expected different services to change the versions")
+	}
+	if first.Versions[SectionTaskTemplates] == first.Versions[SectionFeatureFlags] {
+		t.Error("expected sections to have distinct versions")
+	}
+}
+
+func TestOmitDropsKnownSections(t *testing.T) {
+	s := snapshot()
+	if s.Current([]string{s.Versions[SectionServices]}) {
+		t.Error("expected a section version not to make the snapshot current")
+	}
+	if !s.Current([]string{"stale", s.Version}) {
+		t.Error("expected the overall version to make the snapshot current")
+	}
+	s.Omit([]string{s.Versions[SectionServices], s.Versions[SectionSettings], "stale"})
+	if s.Services != nil || s.Settings != nil || s.Statuses == nil || s.FeatureFlags == nil {
+		t.Errorf("expected only services and settings to be left out, got %+v", s)
+	}
+	if len(s.Unchanged) != 2 || s.Unchanged[0] != SectionServices || s.Unchanged[1] != SectionSettings {
+		t.Errorf("unexpected unchanged sections %v", s.Unchanged)
+	}
+}
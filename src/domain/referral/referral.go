@@ -0,0 +1,69 @@
+package referral
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TypeHospital    = "hospital"
+	TypePhysician   = "physician"
+	TypeWebsite     = "website"
+	TypeWordOfMouth = "word_of_mouth"
+	TypeAgency      = "agency"
+	TypeOther       = "other"
+)
+
+// Source is where clients are referred from, such as a hospital discharge
+// planner or the agency website.
+type Source struct {
+	ID          uuid.UUID
+	Name        string
+	Type        string
+	ContactName string
+	Phone       string
+	Email       string
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// PeriodStats counts the clients referred by a source in one month. A client
+// converts with their first completed visit and is retained when they had a
+// completed visit in the retention window before the report date.
+type PeriodStats struct {
+	Month          string
+	Referred       int
+	Converted      int
+	Retained       int
+	ConversionRate float64
+	RetentionRate  float64
+}
+
+// SourceStats is one row of the conversion report. SourceID is nil for
+// clients without an attributed source.
+type SourceStats struct {
+	SourceID   *uuid.UUID
+	SourceName string
+	SourceType string
+	Totals     PeriodStats
+	Periods    []PeriodStats
+}
+
+// Report is the conversion and retention report for clients referred in
+// [From, To).
+type Report struct {
+	From    time.Time
+	To      time.Time
+	AsOf    time.Time
+	Sources []SourceStats
+}
+
+type IReferralRepository interface {
+	CreateSource(newSource *Source) (*Source, error)
+	GetSourceByID(id uuid.UUID) (*Source, error)
+	GetSources() (*[]Source, error)
+	UpdateSource(id uuid.UUID, updates map[string]interface{}) (*Source, error)
+	DeleteSource(id uuid.UUID) error
+}
@@ -0,0 +1,90 @@
+package reminder
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SourceDefault  = "default"
+	SourceOverride = "override"
+)
+
+// DefaultOffsetsMinutes is used until the organization configures its own:
+// a reminder a day before the visit and another an hour before.
+var DefaultOffsetsMinutes = []int{1440, 60}
+
+// Settings holds the organization-wide reminder offsets, in minutes before
+// ScheduledSlot.From.
+type Settings struct {
+	ID             uuid.UUID
+	OffsetsMinutes []int
+	UpdatedAt      time.Time
+}
+
+// Override replaces the default offsets for one schedule. An empty list turns
+// reminders off for that visit.
+type Override struct {
+	ScheduleID     uuid.UUID
+	OffsetsMinutes []int
+	UpdatedAt      time.Time
+}
+
+// Preference is a user's choice to receive visit reminders. Users without a
+// stored preference receive them.
+type Preference struct {
+	UserID    uuid.UUID
+	Enabled   bool
+	UpdatedAt time.Time
+}
+
+// Delivery records that the reminder for one offset of a schedule was handled
+// for a recipient. Skipped deliveries were superseded by a later offset that
+// was already due, or the recipient opted out.
+type Delivery struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	OffsetMinutes   int
+	RecipientUserID uuid.UUID
+	Skipped         bool
+	SentAt          time.Time
+}
+
+// Plan is the effective reminder configuration of a schedule.
+type Plan struct {
+	ScheduleID     uuid.UUID
+	Source         string
+	OffsetsMinutes []int
+	Deliveries     []Delivery
+}
+
+type IReminderRepository interface {
+	GetSettings() (*Settings, error)
+	SaveSettings(settings *Settings) (*Settings, error)
+	GetOverride(scheduleID uuid.UUID) (*Override, error)
+	GetOverrides(scheduleIDs []uuid.UUID) (*[]Override, error)
+	SaveOverride(override *Override) (*Override, error)
+	DeleteOverride(scheduleID uuid.UUID) error
+	GetPreference(userID uuid.UUID) (*Preference, error)
+	GetPreferences(userIDs []uuid.UUID) (*[]Preference, error)
+	SavePreference(preference *Preference) (*Preference, error)
+	CreateDeliveries(deliveries []Delivery) error
+	GetDeliveries(scheduleIDs []uuid.UUID) (*[]Delivery, error)
+}
+
+// NormalizeOffsets sorts offsets from furthest to closest and drops
+// duplicates.
+func NormalizeOffsets(offsets []int) []int {
+	normalized := make([]int, 0, len(offsets))
+	seen := make(map[int]bool)
+	for _, offset := range offsets {
+		if !seen[offset] {
+			seen[offset] = true
+			normalized = append(normalized, offset)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(normalized)))
+	return normalized
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FieldString = "string"
+	FieldID     = "id"
+	FieldNumber = "number"
+	FieldDate   = "date"
+)
+
+const (
+	OperatorEq  = "eq"
+	OperatorNe  = "ne"
+	OperatorIn  = "in"
+	OperatorGt  = "gt"
+	OperatorGte = "gte"
+	OperatorLt  = "lt"
+	OperatorLte = "lte"
+)
+
+// Field is a column of an entity that reports can group and filter by.
+type Field struct {
+	Name string
+	Type string
+}
+
+// Operators lists the comparisons a filter on the field may use. Strings
+// and IDs only compare for equality; numbers and dates also by order.
+func (f Field) Operators() []string {
+	if f.Type == FieldNumber || f.Type == FieldDate {
+		return []string{OperatorEq, OperatorNe, OperatorIn, OperatorGt, OperatorGte, OperatorLt, OperatorLte}
+	}
+	return []string{OperatorEq, OperatorNe, OperatorIn}
+}
+
+// Parse converts a filter value to the field's type. Dates are written as
+// 2006-01-02.
+func (f Field) Parse(value string) (interface{}, error) {
+	switch f.Type {
+	case FieldID:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an id", f.Name)
+		}
+		return id, nil
+	case FieldNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", f.Name)
+		}
+		return number, nil
+	case FieldDate:
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date formatted as YYYY-MM-DD", f.Name)
+		}
+		return date, nil
+	default:
+		return value, nil
+	}
+}
+
+// Entity is something the report builder can query: the fields it can be
+// grouped and filtered by and the measures it can aggregate.
+type Entity struct {
+	Name     string
+	Fields   []Field
+	Measures []string
+}
+
+// Field returns the entity's field with the given name.
+func (e *Entity) Field(name string) (Field, bool) {
+	for _, field := range e.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// Condition filters the rows of a query. Every operator takes one value
+// except "in", which takes one or more.
+type Condition struct {
+	Field    string
+	Operator string
+	Values   []string
+}
+
+// Query is an ad-hoc report: the measures of an entity aggregated over the
+// rows matching every filter, grouped by the dimensions. OrderBy names one of
+// the dimensions or measures; without it rows are ordered by dimension.
+type Query struct {
+	Entity     string
+	Dimensions []string
+	Measures   []string
+	Filters    []Condition
+	OrderBy    string
+	Descending bool
+	Limit      int
+}
+
+// IQueryRepository runs report builder queries. Only the entities, fields
+// and measures it lists can be queried.
+type IQueryRepository interface {
+	Entities() []Entity
+	RunQuery(query Query) (*Table, error)
+}
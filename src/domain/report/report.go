@@ -0,0 +1,144 @@
+package report
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	KindComplianceExceptions = "compliance_exceptions"
+	KindReferralConversion   = "referral_conversion"
+	KindSupplyConsumption    = "supply_consumption"
+	KindDenialAging          = "denial_aging"
+	KindBudgetUsage          = "budget_usage"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+const (
+	RunStatusSent   = "sent"
+	RunStatusFailed = "failed"
+)
+
+// Definition describes a kind of report: the columns it can include and the
+// filters it accepts. Every filter takes an ID.
+type Definition struct {
+	Kind    string
+	Columns []string
+	Filters []string
+}
+
+// SavedReport is a report configuration an admin can run on demand or have
+// emailed to Recipients on a schedule. An empty Frequency means the report is
+// only run on demand. Empty Columns selects every column of the kind.
+type SavedReport struct {
+	ID          uuid.UUID
+	Name        string
+	Kind        string
+	Filters     map[string]string
+	Columns     []string
+	Format      string
+	Frequency   string
+	Recipients  []string
+	OwnerUserID uuid.UUID
+	Active      bool
+	NextRunAt   *time.Time
+	LastRunAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Scheduled reports whether the report is delivered on its own.
+func (r *SavedReport) Scheduled() bool {
+	return r.Active && r.Frequency != "" && len(r.Recipients) > 0
+}
+
+// NextRun returns the first delivery after t. Deliveries happen at midnight
+// UTC: every day, every Monday or on the first of every month.
+func NextRun(frequency string, t time.Time) time.Time {
+	_, end := periodBounds(frequency, t)
+	return end
+}
+
+// Period returns the start (inclusive) and end (exclusive) of the time range
+// a run at t covers. Scheduled reports cover the last full day, week or
+// month; on-demand reports cover the 30 days up to t.
+func (r *SavedReport) Period(t time.Time) (time.Time, time.Time) {
+	if r.Frequency == "" {
+		return t.AddDate(0, 0, -30), t
+	}
+	start, _ := periodBounds(r.Frequency, t)
+	end := start
+	start, _ = periodBounds(r.Frequency, end.Add(-time.Nanosecond))
+	return start, end
+}
+
+// periodBounds returns the delivery period containing t. Weeks start on
+// Monday.
+func periodBounds(frequency string, t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch frequency {
+	case FrequencyMonthly:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	case FrequencyWeekly:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	default:
+		return day, day.AddDate(0, 0, 1)
+	}
+}
+
+// Table is a generated report: one row of values per line, in column order.
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Export is a generated report rendered in its saved format.
+type Export struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+	Rows        int
+	From        time.Time
+	To          time.Time
+}
+
+// Run records one scheduled or on-demand delivery of a saved report.
+type Run struct {
+	ID         uuid.UUID
+	ReportID   uuid.UUID
+	PeriodFrom time.Time
+	PeriodTo   time.Time
+	Rows       int
+	Recipients []string
+	Status     string
+	Error      string
+	RanAt      time.Time
+	CreatedAt  time.Time
+}
+
+type IReportRepository interface {
+	Create(newReport *SavedReport) (*SavedReport, error)
+	GetByID(id uuid.UUID) (*SavedReport, error)
+	GetAll() (*[]SavedReport, error)
+	// GetDue returns active reports whose next delivery is at or before at.
+	GetDue(at time.Time) (*[]SavedReport, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*SavedReport, error)
+	Delete(id uuid.UUID) error
+	CreateRun(newRun *Run) (*Run, error)
+	// GetRuns returns the report's deliveries, most recent first.
+	GetRuns(reportID uuid.UUID) (*[]Run, error)
+}
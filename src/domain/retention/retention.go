@@ -0,0 +1,201 @@
+package retention
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MonthLayout names a calendar month in UTC.
+const MonthLayout = "2006-01"
+
+// MaxMonths caps how many months one series may cover.
+const MaxMonths = 60
+
+// Spell is a stretch of service for one client, from Start until End, which
+// is nil while the client is still served. Reason is why the service ended.
+type Spell struct {
+	ClientUserID uuid.UUID
+	Start        time.Time
+	End          *time.Time
+	Reason       string
+}
+
+// ActiveAt reports whether the client was served at t.
+func (s *Spell) ActiveAt(t time.Time) bool {
+	return !s.Start.After(t) && (s.End == nil || s.End.After(t))
+}
+
+// EndedIn reports whether the service ended in [from, to).
+func (s *Spell) EndedIn(from, to time.Time) bool {
+	return s.End != nil && !s.End.Before(from) && s.End.Before(to)
+}
+
+// servedBefore is how long the spell had lasted by t.
+func (s *Spell) servedBefore(t time.Time) time.Duration {
+	end := t
+	if s.End != nil && s.End.Before(end) {
+		end = *s.End
+	}
+	if !end.After(s.Start) {
+		return 0
+	}
+	return end.Sub(s.Start)
+}
+
+// Month is a calendar month [Start, End) in UTC.
+type Month struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// Months lists the calendar months from the one holding from up to, but not
+// including, the one holding to.
+func Months(from, to time.Time) []Month {
+	var months []Month
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for start.Before(to) {
+		end := start.AddDate(0, 1, 0)
+		months = append(months, Month{Name: start.Format(MonthLayout), Start: start, End: end})
+		start = end
+	}
+	return months
+}
+
+// ChurnPoint is one month of client churn. ChurnRate is the share of the
+// clients served at the start of the month whose service ended during it.
+type ChurnPoint struct {
+	Month         string
+	ActiveAtStart int
+	New           int
+	Churned       int
+	ActiveAtEnd   int
+	ChurnRate     float64
+}
+
+// TenurePoint is how long clients had been served at the end of a month, and
+// how long the clients who left that month had been.
+type TenurePoint struct {
+	Month                    string
+	ActiveClients            int
+	AverageTenureDays        float64
+	MedianTenureDays         float64
+	ChurnedClients           int
+	ChurnedAverageTenureDays float64
+}
+
+// ReasonPoint counts the reasons service ended in a month.
+type ReasonPoint struct {
+	Month   string
+	Total   int
+	Reasons map[string]int
+}
+
+func Churn(spells []Spell, months []Month) []ChurnPoint {
+	points := make([]ChurnPoint, len(months))
+	for i, month := range months {
+		point := ChurnPoint{Month: month.Name}
+		for j := range spells {
+			spell := &spells[j]
+			if spell.ActiveAt(month.Start) {
+				point.ActiveAtStart++
+				if spell.EndedIn(month.Start, month.End) {
+					point.Churned++
+				}
+			}
+			if !spell.Start.Before(month.Start) && spell.Start.Before(month.End) {
+				point.New++
+			}
+			if spell.ActiveAt(month.End) {
+				point.ActiveAtEnd++
+			}
+		}
+		if point.ActiveAtStart > 0 {
+			point.ChurnRate = float64(point.Churned) / float64(point.ActiveAtStart)
+		}
+		points[i] = point
+	}
+	return points
+}
+
+// Tenure counts each client's service over all their spells, so a client who
+// came back keeps the time served before.
+func Tenure(spells []Spell, months []Month) []TenurePoint {
+	byClient := map[uuid.UUID][]*Spell{}
+	for i := range spells {
+		byClient[spells[i].ClientUserID] = append(byClient[spells[i].ClientUserID], &spells[i])
+	}
+	points := make([]TenurePoint, len(months))
+	for i, month := range months {
+		point := TenurePoint{Month: month.Name}
+		var active []float64
+		var churned float64
+		for _, clientSpells := range byClient {
+			var served time.Duration
+			isActive, left := false, false
+			for _, spell := range clientSpells {
+				served += spell.servedBefore(month.End)
+				isActive = isActive || spell.ActiveAt(month.End)
+				left = left || spell.EndedIn(month.Start, month.End)
+			}
+			days := served.Hours() / 24
+			if isActive {
+				active = append(active, days)
+			} else if left {
+				point.ChurnedClients++
+				churned += days
+			}
+		}
+		point.ActiveClients = len(active)
+		if len(active) > 0 {
+			point.AverageTenureDays = round(sum(active) / float64(len(active)))
+			point.MedianTenureDays = round(median(active))
+		}
+		if point.ChurnedClients > 0 {
+			point.ChurnedAverageTenureDays = round(churned / float64(point.ChurnedClients))
+		}
+		points[i] = point
+	}
+	return points
+}
+
+func Reasons(spells []Spell, months []Month) []ReasonPoint {
+	points := make([]ReasonPoint, len(months))
+	for i, month := range months {
+		point := ReasonPoint{Month: month.Name, Reasons: map[string]int{}}
+		for j := range spells {
+			if spells[j].EndedIn(month.Start, month.End) {
+				point.Total++
+				point.Reasons[spells[j].Reason]++
+			}
+		}
+		points[i] = point
+	}
+	return points
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// round keeps one decimal place.
+func round(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,52 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func ended(t time.Time) *time.Time {
+	return &t
+}
+
+func TestMonthlySeries(t *testing.T) {
+	longstanding, leaver, returning := uuid.New(), uuid.New(), uuid.New()
+	spells := []Spell{
+		{ClientUserID: longstanding, Start: day(time.January, 1)},
+		{ClientUserID: leaver, Start: day(time.January, 11), End: ended(day(time.February, 10)), Reason: "deceased"},
+		{ClientUserID: returning, Start: day(time.January, 1), End: ended(day(time.January, 21)), Reason: "cost"},
+		{ClientUserID: returning, Start: day(time.February, 20)},
+	}
+	months := Months(day(time.January, 15), day(time.March, 1))
+	if len(months) != 2 || months[0].Name != "2026-01" || months[1].Name != "2026-02" {
+		t.Fatalf("unexpected months %+v", months)
+	}
+
+	churn := Churn(spells, months)
+	if p := churn[0]; p.ActiveAtStart != 2 || p.New != 3 || p.Churned != 1 || p.ActiveAtEnd != 2 || p.ChurnRate != 0.5 {
+		t.Errorf("unexpected January churn %+v", p)
+	}
+	if p := churn[1]; p.ActiveAtStart != 2 || p.New != 1 || p.Churned != 1 || p.ActiveAtEnd != 2 || p.ChurnRate != 0.5 {
+		t.Errorf("unexpected February churn %+v", p)
+	}
+
+	tenure := Tenure(spells, months)
+	if p := tenure[1]; p.ActiveClients != 2 || p.ChurnedClients != 1 || p.ChurnedAverageTenureDays != 30 {
+		t.Errorf("unexpected February tenure %+v", p)
+	}
+	// 59 days for the longstanding client, 20 + 9 for the returning one.
+	if p := tenure[1]; p.AverageTenureDays != 44 || p.MedianTenureDays != 44 {
+		t.Errorf("expected tenure to add up earlier spells, got %+v", p)
+	}
+
+	reasons := Reasons(spells, months)
+	if reasons[0].Total != 1 || reasons[0].Reasons["cost"] != 1 || reasons[1].Reasons["deceased"] != 1 {
+		t.Errorf("unexpected reasons %+v", reasons)
+	}
+}
@@ -0,0 +1,23 @@
+package schedule
+
+// Fields of a visit whose changes are announced to the people on it.
+const (
+	FieldDate      = "date"
+	FieldStartTime = "start time"
+	FieldEndTime   = "end time"
+	FieldAddress   = "address"
+)
+
+// FieldChange is one announced field of a visit that an update changed, with
+// its old and new values as they read to people: dates and times in the
+// organization's time zone, the client's address on one line.
+type FieldChange struct {
+	Field    string
+	Previous string
+	Current  string
+}
+
+// TimeChange reports whether the field is part of when the visit happens.
+func (c FieldChange) TimeChange() bool {
+	return c.Field == FieldDate || c.Field == FieldStartTime || c.Field == FieldEndTime
+}
@@ -0,0 +1,199 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signals combined into a visit's verification confidence.
+const (
+	SignalDistance = "distance"
+	SignalMethod   = "method"
+	SignalTiming   = "timing"
+	SignalDevice   = "device"
+)
+
+// ConfidenceWeights is how much each signal counts towards the score.
+// Signals without a weight, or with a weight of zero, are ignored.
+type ConfidenceWeights map[string]float64
+
+// DefaultConfidenceWeights favours where the caregiver was and how it was
+// proven over timing and the device's own report.
+var DefaultConfidenceWeights = ConfidenceWeights{
+	SignalDistance: 0.35,
+	SignalMethod:   0.30,
+	SignalTiming:   0.20,
+	SignalDevice:   0.15,
+}
+
+// ParseConfidenceWeights reads weights written as "distance=0.4,method=0.3".
+// Signals left out keep their default weight; an empty string gives the
+// defaults.
+func ParseConfidenceWeights(value string) (ConfidenceWeights, error) {
+	weights := ConfidenceWeights{}
+	for signal, weight := range DefaultConfidenceWeights {
+		weights[signal] = weight
+	}
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		signal, raw, ok := strings.Cut(part, "=")
+		signal = strings.TrimSpace(signal)
+		if _, known := DefaultConfidenceWeights[signal]; !ok || !known {
+			return nil, fmt.Errorf("invalid confidence weight %q", part)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid confidence weight %q", part)
+		}
+		weights[signal] = weight
+	}
+	return weights, nil
+}
+
+// DeviceIntegrity is what the caregiver's app reports about its device at
+// check-in. Nil fields were not reported.
+type DeviceIntegrity struct {
+	MockLocation *bool `gorm:"column:mock_location"`
+	Rooted       *bool `gorm:"column:rooted"`
+	Emulator     *bool `gorm:"column:emulator"`
+}
+
+// Reported tells whether the app sent any integrity hint.
+func (d DeviceIntegrity) Reported() bool {
+	return d.MockLocation != nil || d.Rooted != nil || d.Emulator != nil
+}
+
+// ConfidenceSignal is one signal's contribution: Score runs from 0 (no
+// confidence) to 1, and Detail explains it.
+type ConfidenceSignal struct {
+	Name   string
+	Score  float64
+	Weight float64
+	Detail string
+}
+
+// Confidence is how sure we are that a visit happened as recorded, from 0
+// to 100, computed at check-out. Signals lists the ones available for the
+// visit; missing signals are left out rather than counted against it.
+type Confidence struct {
+	Score   int
+	Signals []ConfidenceSignal
+}
+
+// ConfidenceInput gathers the evidence for a completed visit.
+type ConfidenceInput struct {
+	// DistancesKm are how far check-in and check-out were from the client's
+	// home, for those recorded with a location.
+	DistancesKm  []float64
+	Method       string
+	CheckinTime  time.Time
+	CheckoutTime time.Time
+	Slot         ScheduledSlot
+	// ClockSkew is how far the device's clock was from the server's at
+	// check-in, when known.
+	ClockSkew *time.Duration
+	Device    DeviceIntegrity
+	// Integrity is the status of the device's attestation, if it sent one.
+	Integrity string
+}
+
+// Thresholds of the signals.
+const (
+	confidentDistanceKm = 0.15
+	doubtfulDistanceKm  = 2.0
+	confidentDelay      = 15 * time.Minute
+	doubtfulDelay       = 2 * time.Hour
+	doubtfulSkew        = 10 * time.Minute
+)
+
+// methodScores rates each verification method by how hard it is to fake.
+var methodScores = map[string]float64{
+	VerificationNFC:   1,
+	VerificationKiosk: 0.9,
+	VerificationGPS:   0.6,
+}
+
+// ScoreConfidence combines the available signals, weighted. It returns nil
+// when no weighted signal is available.
+func ScoreConfidence(input ConfidenceInput, weights ConfidenceWeights) *Confidence {
+	var signals []ConfidenceSignal
+	add := func(name string, score float64, detail string) {
+		if weight := weights[name]; weight > 0 {
+			signals = append(signals, ConfidenceSignal{Name: name, Score: math.Max(0, math.Min(1, score)), Weight: weight, Detail: detail})
+		}
+	}
+
+	if len(input.DistancesKm) > 0 {
+		farthest := 0.0
+		for _, distance := range input.DistancesKm {
+			farthest = math.Max(farthest, distance)
+		}
+		add(SignalDistance, falloff(farthest, confidentDistanceKm, doubtfulDistanceKm),
+			fmt.Sprintf("recorded up to %.2f km from the client's home", farthest))
+	}
+	if score, ok := methodScores[input.Method]; ok {
+		add(SignalMethod, score, "verified by "+input.Method)
+	}
+	if !input.CheckinTime.IsZero() {
+		delay := input.CheckinTime.Sub(input.Slot.From)
+		score := falloff(delay.Minutes(), confidentDelay.Minutes(), doubtfulDelay.Minutes())
+		details := []string{fmt.Sprintf("started %d minutes after the scheduled start", int(delay.Minutes()))}
+		if scheduled := input.Slot.To.Sub(input.Slot.From); !input.CheckoutTime.IsZero() && scheduled > 0 {
+			ratio := input.CheckoutTime.Sub(input.CheckinTime).Seconds() / scheduled.Seconds()
+			score = math.Min(score, math.Min(ratio/0.5, 1))
+			details = append(details, fmt.Sprintf("lasted %d%% of the scheduled time", int(math.Round(ratio*100))))
+		}
+		if input.ClockSkew != nil {
+			skew := input.ClockSkew.Abs()
+			score = math.Min(score, falloff(skew.Minutes(), 1, doubtfulSkew.Minutes()))
+			details = append(details, fmt.Sprintf("device clock off by %s", skew.Round(time.Second)))
+		}
+		add(SignalTiming, score, strings.Join(details, ", "))
+	}
+	if input.Device.Reported() || input.Integrity != "" {
+		score, detail := 1.0, "no integrity warnings"
+		switch {
+		case isTrue(input.Device.MockLocation):
+			score, detail = 0, "mock location enabled"
+		case input.Integrity == IntegrityInvalid:
+			score, detail = 0, "integrity attestation rejected"
+		case isTrue(input.Device.Rooted) || isTrue(input.Device.Emulator):
+			score, detail = 0.3, "rooted device or emulator"
+		case input.Integrity == IntegrityFailed:
+			score, detail = 0.3, "app or device failed integrity checks"
+		case input.Integrity == IntegrityVerified:
+			detail = "device integrity attested"
+		}
+		add(SignalDevice, score, detail)
+	}
+
+	var total, weighted float64
+	for _, signal := range signals {
+		total += signal.Weight
+		weighted += signal.Weight * signal.Score
+	}
+	if total == 0 {
+		return nil
+	}
+	return &Confidence{Score: int(math.Round(100 * weighted / total)), Signals: signals}
+}
+
+// falloff is 1 up to good, 0 from bad on, and linear in between.
+func falloff(value, good, bad float64) float64 {
+	switch {
+	case value <= good:
+		return 1
+	case value >= bad:
+		return 0
+	}
+	return (bad - value) / (bad - good)
+}
+
+func isTrue(value *bool) bool {
+	return value != nil && *value
+}
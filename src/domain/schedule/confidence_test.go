@@ -0,0 +1,59 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScoreConfidence(t *testing.T) {
+	from := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	slot := ScheduledSlot{From: from, To: from.Add(2 * time.Hour)}
+	yes, no := true, false
+	skew := 30 * time.Second
+
+	tests := []struct {
+		name  string
+		input ConfidenceInput
+		score int
+	}{
+		{"strong evidence", ConfidenceInput{
+			DistancesKm: []float64{0.05, 0.1}, Method: VerificationNFC, Slot: slot,
+			CheckinTime: from.Add(5 * time.Minute), CheckoutTime: from.Add(2 * time.Hour),
+			ClockSkew: &skew, Device: DeviceIntegrity{MockLocation: &no, Rooted: &no},
+		}, 100},
+		// Distance 0, method 0.6, timing 0.5 (a quarter of the slot), device 0.
+		{"weak evidence", ConfidenceInput{
+			DistancesKm: []float64{0.1, 2}, Method: VerificationGPS, Slot: slot,
+			CheckinTime: from, CheckoutTime: from.Add(30 * time.Minute),
+			Device: DeviceIntegrity{MockLocation: &yes},
+		}, 28},
+		{"missing signals are left out", ConfidenceInput{Method: VerificationKiosk}, 90},
+		// Method 1 (0.30) and device 0 (0.15).
+		{"rejected attestation", ConfidenceInput{Method: VerificationNFC, Integrity: IntegrityInvalid}, 67},
+	}
+	for _, tt := range tests {
+		confidence := ScoreConfidence(tt.input, DefaultConfidenceWeights)
+		if confidence == nil || confidence.Score != tt.score {
+			t.Errorf("%s: expected %d, got %+v", tt.name, tt.score, confidence)
+		}
+	}
+
+	if confidence := ScoreConfidence(ConfidenceInput{}, DefaultConfidenceWeights); confidence != nil {
+		t.Errorf("expected no score without signals, got %+v", confidence)
+	}
+}
+
+func TestParseConfidenceWeights(t *testing.T) {
+	weights, err := ParseConfidenceWeights("distance=0.5, device=0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if weights[SignalDistance] != 0.5 || weights[SignalDevice] != 0 || weights[SignalMethod] != DefaultConfidenceWeights[SignalMethod] {
+		t.Errorf("expected distance and device overridden, got %v", weights)
+	}
+	for _, value := range []string{"speed=1", "distance", "distance=-1", "distance=high"} {
+		if _, err := ParseConfidenceWeights(value); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package schedule
+
+import "github.com/google/uuid"
+
+// StatusDraft marks a visit a coordinator is still planning. Drafts are not
+// shown to caregivers or announced until they are published, when they
+// become upcoming.
+const StatusDraft = "draft"
+
+// MaxPublish caps the drafts published at once.
+const MaxPublish = 500
+
+// Draft reports whether the visit has not been published yet.
+func (s *Schedule) Draft() bool {
+	return s.VisitStatus == StatusDraft
+}
+
+// PublishResult lists the drafts a publish made upcoming and those it left
+// as drafts.
+type PublishResult struct {
+	Published []Schedule
+	Rejected  []PublishRejection
+}
+
+// PublishRejection is a draft that could not be published, and why.
+type PublishRejection struct {
+	ScheduleID uuid.UUID
+	Reason     string
+}
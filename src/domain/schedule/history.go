@@ -0,0 +1,91 @@
+package schedule
+
+import (
+	"strings"
+	"time"
+)
+
+// Periods a task history can be broken down by.
+const (
+	HistoryPeriodWeek  = "week"
+	HistoryPeriodMonth = "month"
+)
+
+// MaxHistoryDays caps the range of a task history.
+const MaxHistoryDays = 730
+
+// DeliveredStatuses are the visit states whose tasks were due, completed
+// fully or in part.
+var DeliveredStatuses = []string{"completed", "partially_completed"}
+
+// TaskCategory is what task outcomes are grouped by: the kind for medication
+// and vitals tasks, otherwise the title ignoring case and surrounding
+// spaces, so "Bathing" and "bathing " count together.
+func TaskCategory(kind, title string) string {
+	if kind == TaskKindMedication || kind == TaskKindVitals {
+		return kind
+	}
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+// TaskOutcomes counts the outcomes of one category of a client's tasks on
+// the visits delivered in a period. Tasks left pending at checkout are
+// Unrecorded.
+type TaskOutcomes struct {
+	Category     string
+	PeriodStart  time.Time
+	Total        int
+	Completed    int
+	NotCompleted int
+	Unrecorded   int
+}
+
+// CompletionRate is the share of the tasks completed, 0 without tasks.
+func (o *TaskOutcomes) CompletionRate() float64 {
+	if o.Total == 0 {
+		return 0
+	}
+	return float64(o.Completed) / float64(o.Total)
+}
+
+// NotCompletedRate is the share of the tasks recorded as not done, such as
+// a client refusing a bath.
+func (o *TaskOutcomes) NotCompletedRate() float64 {
+	if o.Total == 0 {
+		return 0
+	}
+	return float64(o.NotCompleted) / float64(o.Total)
+}
+
+// add counts other into o.
+func (o *TaskOutcomes) add(other TaskOutcomes) {
+	o.Total += other.Total
+	o.Completed += other.Completed
+	o.NotCompleted += other.NotCompleted
+	o.Unrecorded += other.Unrecorded
+}
+
+// TaskHistory is the outcome of one category of a client's tasks over a
+// range, overall and per period, earliest first.
+type TaskHistory struct {
+	TaskOutcomes
+	Periods []TaskOutcomes
+}
+
+// TaskHistories groups per-period outcomes by category, in the order the
+// categories first appear, and totals each one.
+func TaskHistories(outcomes []TaskOutcomes) []TaskHistory {
+	histories := []TaskHistory{}
+	index := map[string]int{}
+	for _, outcome := range outcomes {
+		i, ok := index[outcome.Category]
+		if !ok {
+			i = len(histories)
+			index[outcome.Category] = i
+			histories = append(histories, TaskHistory{TaskOutcomes: TaskOutcomes{Category: outcome.Category}})
+		}
+		histories[i].add(outcome)
+		histories[i].Periods = append(histories[i].Periods, outcome)
+	}
+	return histories
+}
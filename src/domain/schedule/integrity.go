@@ -0,0 +1,33 @@
+package schedule
+
+// Platforms whose apps attest their integrity at check-in.
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+)
+
+// Outcomes of checking a device integrity attestation. A check-in without
+// one leaves the status empty.
+const (
+	// IntegrityVerified is a genuine app on a device that passed the
+	// platform's integrity checks.
+	IntegrityVerified = "verified"
+	// IntegrityFailed is a genuine attestation reporting a modified app or
+	// a rooted, jailbroken or emulated device.
+	IntegrityFailed = "failed"
+	// IntegrityInvalid is an attestation the provider rejected: forged,
+	// expired or issued for another request.
+	IntegrityInvalid = "invalid"
+	// IntegrityUnverified is an attestation that could not be checked, e.g.
+	// no provider is set up for the platform or it was unreachable.
+	IntegrityUnverified = "unverified"
+)
+
+// IntegrityCheck records the attestation the app sent at check-in and what
+// its provider made of it, kept with the visit for fraud investigations.
+type IntegrityCheck struct {
+	Status   string `gorm:"column:status"`
+	Platform string `gorm:"column:platform"`
+	// Detail is the provider's verdict, or why none was reached.
+	Detail string `gorm:"column:detail"`
+}
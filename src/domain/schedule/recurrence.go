@@ -0,0 +1,82 @@
+package schedule
+
+import (
+	"time"
+)
+
+const (
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+)
+
+// MaxOccurrences caps how many visits one recurring schedule may create.
+const MaxOccurrences = 366
+
+// Recurrence repeats a visit every Interval days, weeks or months until the
+// end of the Until day, like an iCalendar RRULE with FREQ, INTERVAL and
+// UNTIL. Monthly visits skip months without the first visit's day of month.
+type Recurrence struct {
+	Frequency string    `gorm:"column:frequency"`
+	Interval  int       `gorm:"column:interval"`
+	Until     time.Time `gorm:"column:until"`
+}
+
+// Occurrences returns the start of each visit from first up to Until,
+// first included, stopping early at MaxOccurrences+1 so callers can reject
+// a rule that runs too long.
+func (r Recurrence) Occurrences(first time.Time) []time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	end := time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day(), 0, 0, 0, 0, r.Until.Location()).AddDate(0, 0, 1)
+	var starts []time.Time
+	for i := 0; len(starts) <= MaxOccurrences; i++ {
+		var next time.Time
+		switch r.Frequency {
+		case RecurrenceDaily:
+			next = first.AddDate(0, 0, i*interval)
+		case RecurrenceWeekly:
+			next = first.AddDate(0, 0, 7*i*interval)
+		case RecurrenceMonthly:
+			next = first.AddDate(0, i*interval, 0)
+			if next.Day() != first.Day() && next.Before(end) {
+				continue
+			}
+		default:
+			return []time.Time{first}
+		}
+		if !next.Before(end) {
+			break
+		}
+		starts = append(starts, next)
+	}
+	return starts
+}
+
+// Occurrence shifts the visit's slot, segments and tasks to start at from,
+// returning an unsaved copy in the same series.
+func (s *Schedule) Occurrence(from time.Time) *Schedule {
+	offset := from.Sub(s.ScheduledSlot.From)
+	occurrence := *s
+	occurrence.ScheduledSlot = ScheduledSlot{From: from, To: s.ScheduledSlot.To.Add(offset)}
+	occurrence.Tasks = make([]Task, len(s.Tasks))
+	for i, task := range s.Tasks {
+		occurrence.Tasks[i] = Task{Title: task.Title, Description: task.Description, Kind: task.Kind}
+		if task.Medication != nil {
+			medication := *task.Medication
+			medication.ScheduledAt = medication.ScheduledAt.Add(offset)
+			occurrence.Tasks[i].Medication = &medication
+		}
+	}
+	occurrence.Segments = make([]Segment, len(s.Segments))
+	for i, segment := range s.Segments {
+		occurrence.Segments[i] = Segment{From: segment.From.Add(offset), To: segment.To.Add(offset)}
+	}
+	occurrence.Attachments = nil
+	occurrence.NoteRevisions = nil
+	occurrence.Confidence = nil
+	occurrence.Warnings = nil
+	return &occurrence
+}
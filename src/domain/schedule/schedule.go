@@ -1,28 +1,108 @@
 package schedule
 
 import (
+	"regexp"
 	"time"
 
 	"caregiver/src/domain"
+	domainAttachment "caregiver/src/domain/attachment"
+	domainServiceNote "caregiver/src/domain/servicenote"
 
 	"github.com/google/uuid"
 )
 
 type Schedule struct {
-	ID               uuid.UUID     `gorm:"primaryKey"`
-	ClientUserID     uuid.UUID     `gorm:"column:client_user_id"`
-	AssignedUserID   uuid.UUID     `gorm:"column:assigned_user_id"`
-	ServiceName      string        `gorm:"column:service_name"`
-	ScheduledSlot    ScheduledSlot `gorm:"embedded;embeddedPrefix:scheduled_slot_"`
-	VisitStatus      string        `gorm:"column:visit_status"`
-	CheckinTime      *time.Time    `gorm:"column:checkin_time"`
-	CheckoutTime     *time.Time    `gorm:"column:checkout_time"`
-	CheckinLocation  Location      `gorm:"embedded;embeddedPrefix:checkin_location_"`
-	CheckoutLocation Location      `gorm:"embedded;embeddedPrefix:checkout_location_"`
-	Tasks            []Task        `gorm:"foreignKey:ScheduleID"`
-	ServiceNote      *string       `gorm:"column:service_note"`
-	CreatedAt        time.Time     `gorm:"autoCreateTime:milli"`
-	UpdatedAt        time.Time     `gorm:"autoUpdateTime:milli"`
+	ID                  uuid.UUID      `gorm:"primaryKey"`
+	ClientUserID        uuid.UUID      `gorm:"column:client_user_id"`
+	AssignedUserID      uuid.UUID      `gorm:"column:assigned_user_id"`
+	ServiceName         string         `gorm:"column:service_name"`
+	ScheduledSlot       ScheduledSlot  `gorm:"embedded;embeddedPrefix:scheduled_slot_"`
+	VisitStatus         string         `gorm:"column:visit_status"`
+	CheckinTime         *time.Time     `gorm:"column:checkin_time"`
+	CheckoutTime        *time.Time     `gorm:"column:checkout_time"`
+	CheckinLocation     Location       `gorm:"embedded;embeddedPrefix:checkin_location_"`
+	CheckoutLocation    Location       `gorm:"embedded;embeddedPrefix:checkout_location_"`
+	CheckinVerification Verification   `gorm:"embedded;embeddedPrefix:checkin_"`
+	Attestation         Attestation    `gorm:"embedded;embeddedPrefix:attestation_"`
+	Tasks               []Task         `gorm:"foreignKey:ScheduleID"`
+	Segments            []Segment      `gorm:"foreignKey:ScheduleID"`
+	Interruptions       []Interruption `gorm:"foreignKey:ScheduleID"`
+	// Confidence scores the check-in and check-out evidence once the visit
+	// is completed; nil before then.
+	Confidence *Confidence `gorm:"-"`
+	// Attachments are the files uploaded to the visit and its tasks. Only
+	// their metadata is loaded.
+	Attachments []domainAttachment.Attachment `gorm:"foreignKey:ScheduleID"`
+	// ServiceNote is the latest revision of the visit's note.
+	// NoteRevisions are all of them, oldest first, loaded only when a
+	// single visit is fetched by ID.
+	ServiceNote   *string                      `gorm:"column:service_note"`
+	NoteRevisions []domainServiceNote.Revision `gorm:"foreignKey:ScheduleID"`
+	ColorTag      string                       `gorm:"column:color_tag"`
+	// SeriesID groups the visits created from one recurring schedule, and
+	// Recurrence is the rule they were created by. Both are nil for one-off
+	// visits and for occurrences edited on their own.
+	SeriesID   *uuid.UUID  `gorm:"column:series_id"`
+	Recurrence *Recurrence `gorm:"embedded;embeddedPrefix:recurrence_"`
+	CreatedAt  time.Time   `gorm:"autoCreateTime:milli"`
+	UpdatedAt  time.Time   `gorm:"autoUpdateTime:milli"`
+	// DeletedAt is set while the visit is soft-deleted.
+	DeletedAt *time.Time `gorm:"-"`
+	// Warnings carries non-blocking validation messages back to the caller.
+	// It is never persisted.
+	Warnings []string `gorm:"-"`
+}
+
+// Open reports whether the visit is an open shift: booked for the client
+// but not yet assigned, waiting for a caregiver to claim it.
+func (s *Schedule) Open() bool {
+	return s.AssignedUserID == uuid.Nil
+}
+
+// ScheduledDuration is the planned working time of the visit: the sum of its
+// segments for split shifts, otherwise the length of the scheduled slot.
+func (s *Schedule) ScheduledDuration() time.Duration {
+	if len(s.Segments) == 0 {
+		return s.ScheduledSlot.To.Sub(s.ScheduledSlot.From)
+	}
+	var total time.Duration
+	for _, segment := range s.Segments {
+		total += segment.To.Sub(segment.From)
+	}
+	return total
+}
+
+// WorkedDuration is the checked-in time of the visit: the sum of its
+// completed segments for split shifts, otherwise check-in to check-out,
+// less any interruptions.
+func (s *Schedule) WorkedDuration() time.Duration {
+	var total time.Duration
+	for _, period := range s.WorkedPeriods() {
+		total += period.To.Sub(period.From)
+	}
+	return total
+}
+
+// WorkedPeriods are the check-in to check-out spans of the visit, one per
+// completed segment for split shifts, less the time the visit was
+// interrupted.
+func (s *Schedule) WorkedPeriods() []ScheduledSlot {
+	var periods []ScheduledSlot
+	if len(s.Segments) == 0 {
+		if s.CheckinTime == nil || s.CheckoutTime == nil {
+			return nil
+		}
+		periods = []ScheduledSlot{{From: *s.CheckinTime, To: *s.CheckoutTime}}
+	}
+	for _, segment := range s.Segments {
+		if segment.CheckinTime != nil && segment.CheckoutTime != nil {
+			periods = append(periods, ScheduledSlot{From: *segment.CheckinTime, To: *segment.CheckoutTime})
+		}
+	}
+	for _, interruption := range s.Interruptions {
+		periods = interruption.cut(periods)
+	}
+	return periods
 }
 
 type ScheduledSlot struct {
@@ -35,18 +115,374 @@ type Location struct {
 	Long *float64 `gorm:"column:long"`
 }
 
+const (
+	VerificationGPS = "gps"
+	VerificationNFC = "nfc"
+	// VerificationKiosk marks a check-in made with a PIN at a facility kiosk.
+	VerificationKiosk = "kiosk"
+)
+
+// Verification records how a check-in was proven, for EVV reporting.
+type Verification struct {
+	Method   string     `gorm:"column:verification_method"`
+	NFCTagID *uuid.UUID `gorm:"column:nfc_tag_id"`
+	KioskID  *uuid.UUID `gorm:"column:kiosk_id"`
+	// NFCTagValue is the raw value scanned by the caregiver's device. It is
+	// resolved to NFCTagID during check-in and never persisted.
+	NFCTagValue string `gorm:"-"`
+	// Device is the app's integrity report, and ClockSkewSeconds how far the
+	// device's clock was ahead of the server's (negative when behind). Both
+	// feed the visit's Confidence and are kept for the visit only.
+	Device           DeviceIntegrity `gorm:"embedded;embeddedPrefix:device_"`
+	ClockSkewSeconds *int            `gorm:"column:clock_skew_seconds"`
+	// IntegrityToken is the platform attestation (Play Integrity, App
+	// Attest) sent from IntegrityPlatform. It is checked during check-in
+	// into Integrity and never persisted.
+	IntegrityToken    string         `gorm:"-"`
+	IntegrityPlatform string         `gorm:"-"`
+	Integrity         IntegrityCheck `gorm:"embedded;embeddedPrefix:integrity_"`
+}
+
+const (
+	AttestationPending   = "pending"
+	AttestationConfirmed = "confirmed"
+	AttestationDisputed  = "disputed"
+	AttestationExpired   = "expired"
+)
+
+// Attestation is the client's own confirmation, from their device, that the
+// caregiver arrived. Status is empty when none was requested for the visit.
+type Attestation struct {
+	Status      string     `gorm:"column:status"`
+	DueAt       *time.Time `gorm:"column:due_at"`
+	RespondedAt *time.Time `gorm:"column:responded_at"`
+	DeviceID    string     `gorm:"column:device_id"`
+}
+
+// Segment is one independently checked-in part of a split shift, e.g. the
+// morning and evening halves of the same client visit.
+type Segment struct {
+	ID                  uuid.UUID    `gorm:"primaryKey"`
+	ScheduleID          uuid.UUID    `gorm:"column:schedule_id"`
+	Sequence            int          `gorm:"column:sequence"`
+	From                time.Time    `gorm:"column:from"`
+	To                  time.Time    `gorm:"column:to"`
+	VisitStatus         string       `gorm:"column:visit_status"`
+	CheckinTime         *time.Time   `gorm:"column:checkin_time"`
+	CheckoutTime        *time.Time   `gorm:"column:checkout_time"`
+	CheckinLocation     Location     `gorm:"embedded;embeddedPrefix:checkin_location_"`
+	CheckoutLocation    Location     `gorm:"embedded;embeddedPrefix:checkout_location_"`
+	CheckinVerification Verification `gorm:"embedded;embeddedPrefix:checkin_"`
+	CreatedAt           time.Time    `gorm:"autoCreateTime:milli"`
+	UpdatedAt           time.Time    `gorm:"autoUpdateTime:milli"`
+}
+
+const (
+	InterruptionClientRefused      = "client_refused"
+	InterruptionEmergencyTransport = "emergency_transport"
+	InterruptionClientUnavailable  = "client_unavailable"
+	InterruptionCaregiverEmergency = "caregiver_emergency"
+	InterruptionUnsafeEnvironment  = "unsafe_environment"
+	InterruptionOther              = "other"
+)
+
+// InterruptionReasons are the reason codes an interruption can be logged
+// with. InterruptionOther needs a note.
+var InterruptionReasons = []string{
+	InterruptionClientRefused,
+	InterruptionEmergencyTransport,
+	InterruptionClientUnavailable,
+	InterruptionCaregiverEmergency,
+	InterruptionUnsafeEnvironment,
+	InterruptionOther,
+}
+
+// IsInterruptionReason reports whether code is one of InterruptionReasons.
+func IsInterruptionReason(code string) bool {
+	for _, reason := range InterruptionReasons {
+		if code == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// Interruption is a pause in a visit under way, e.g. while the client
+// refuses care or is taken to hospital. It lasts until EndedAt; an open
+// interruption runs to check-out.
+type Interruption struct {
+	ID              uuid.UUID  `gorm:"primaryKey"`
+	ScheduleID      uuid.UUID  `gorm:"column:schedule_id"`
+	ClientUserID    uuid.UUID  `gorm:"column:client_user_id"`
+	CaregiverUserID uuid.UUID  `gorm:"column:caregiver_user_id"`
+	ReasonCode      string     `gorm:"column:reason_code"`
+	Note            string     `gorm:"column:note"`
+	StartedAt       time.Time  `gorm:"column:started_at"`
+	EndedAt         *time.Time `gorm:"column:ended_at"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+// Open reports whether the visit is still paused.
+func (i *Interruption) Open() bool {
+	return i.EndedAt == nil
+}
+
+// cut removes the interruption from the periods, splitting any it falls
+// inside.
+func (i *Interruption) cut(periods []ScheduledSlot) []ScheduledSlot {
+	var kept []ScheduledSlot
+	for _, period := range periods {
+		end := period.To
+		if i.EndedAt != nil {
+			end = *i.EndedAt
+		}
+		if !i.StartedAt.Before(period.To) || !period.From.Before(end) {
+			kept = append(kept, period)
+			continue
+		}
+		if period.From.Before(i.StartedAt) {
+			kept = append(kept, ScheduledSlot{From: period.From, To: i.StartedAt})
+		}
+		if end.Before(period.To) {
+			kept = append(kept, ScheduledSlot{From: end, To: period.To})
+		}
+	}
+	return kept
+}
+
+// NextSegment returns the first segment that has not been started yet, or
+// nil when the schedule has no pending segments.
+func (s *Schedule) NextSegment() *Segment {
+	for i := range s.Segments {
+		if s.Segments[i].VisitStatus == "upcoming" {
+			return &s.Segments[i]
+		}
+	}
+	return nil
+}
+
+// ActiveSegment returns the segment currently checked in, if any.
+func (s *Schedule) ActiveSegment() *Segment {
+	for i := range s.Segments {
+		if s.Segments[i].VisitStatus == "in_progress" {
+			return &s.Segments[i]
+		}
+	}
+	return nil
+}
+
 type Task struct {
 	ID          uuid.UUID `gorm:"primaryKey"`
 	ScheduleID  uuid.UUID `gorm:"column:schedule_id"`
 	Title       string    `gorm:"column:title"`
 	Description string    `gorm:"column:description"`
-	Status      string    `gorm:"column:status"`
-	Done        *bool     `gorm:"column:done"`
-	Feedback    *string   `gorm:"column:feedback"`
-	CreatedAt   time.Time `gorm:"autoCreateTime:milli"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime:milli"`
+	// Kind is one of TaskKinds. Medication tasks carry Medication, and
+	// vitals tasks record Vitals once done.
+	Kind       string          `gorm:"column:kind"`
+	Medication *TaskMedication `gorm:"embedded;embeddedPrefix:medication_"`
+	Vitals     *Vitals         `gorm:"embedded;embeddedPrefix:vitals_"`
+	Status     string          `gorm:"column:status"`
+	Done       *bool           `gorm:"column:done"`
+	Feedback   *string         `gorm:"column:feedback"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime:milli"`
+	UpdatedAt  time.Time       `gorm:"autoUpdateTime:milli"`
+	// DeletedAt is set while the task is soft-deleted.
+	DeletedAt *time.Time `gorm:"-"`
+}
+
+const (
+	EventCreated   = "schedule.created"
+	EventUpdated   = "schedule.updated"
+	EventStarted   = "schedule.started"
+	EventCompleted = "schedule.completed"
+	EventCancelled = "schedule.cancelled"
+	EventDeleted   = "schedule.deleted"
+	// EventMissed is sent when a visit's slot ended without a check-in.
+	EventMissed = "schedule.missed"
+)
+
+// Event describes a change to a schedule. Previous is only set for updates,
+// and Changes lists the announced fields an update changed. A deleted visit
+// is gone until restored, which is announced as created.
+type Event struct {
+	Type     string
+	Schedule *Schedule
+	Previous *Schedule
+	Changes  []FieldChange
 }
 
+// ActiveStatuses are the visit states that occupy a caregiver's time.
+var ActiveStatuses = []string{"upcoming", "in_progress", "partially_completed"}
+
+// WorkedStatuses are the visit states that count as time a caregiver works,
+// past or planned.
+var WorkedStatuses = []string{"upcoming", "in_progress", "partially_completed", "completed"}
+
+// Overlaps reports whether the schedule's slot intersects [from, to).
+func (s *Schedule) Overlaps(from, to time.Time) bool {
+	return s.ScheduledSlot.From.Before(to) && from.Before(s.ScheduledSlot.To)
+}
+
+// SlotSuggestion is a free window for the same caregiver.
+type SlotSuggestion struct {
+	From time.Time
+	To   time.Time
+}
+
+// CaregiverSuggestion is another caregiver who is free for the requested slot.
+type CaregiverSuggestion struct {
+	UserID     uuid.UUID
+	FirstName  string
+	LastName   string
+	DistanceKm *float64
+	Warnings   []string
+}
+
+// Screening is a feature's verdict on proposing a caregiver for a client:
+// excluded caregivers are dropped, warnings are passed on with the suggestion.
+type Screening struct {
+	Excluded bool
+	Warning  string
+}
+
+type Suggestions struct {
+	FreeSlots  []SlotSuggestion
+	Caregivers []CaregiverSuggestion
+}
+
+// ConflictError explains why a schedule cannot be booked as requested and
+// what the coordinator could do instead.
+type ConflictError struct {
+	Message     string
+	Conflicts   []uuid.UUID
+	Suggestions *Suggestions
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+func (e *ConflictError) ErrorDetails() any {
+	return e
+}
+
+// VisitStatuses lists every visit status in lifecycle order.
+var VisitStatuses = []string{StatusDraft, "upcoming", "in_progress", "partially_completed", "completed", "missed", "cancelled"}
+
+// StatusColors is the default calendar color for each visit status, used when
+// a schedule has no color tag of its own.
+var StatusColors = map[string]string{
+	StatusDraft:           "#CBD5E1",
+	"upcoming":            "#3B82F6",
+	"in_progress":         "#F59E0B",
+	"partially_completed": "#F97316",
+	"completed":           "#10B981",
+	"missed":              "#EF4444",
+	"cancelled":           "#9CA3AF",
+}
+
+// Color returns the schedule's color tag, falling back to its status color.
+func (s *Schedule) Color() string {
+	if s.ColorTag != "" {
+		return s.ColorTag
+	}
+	return StatusColors[s.VisitStatus]
+}
+
+var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// ValidColor reports whether color is a #RRGGBB hex color.
+func ValidColor(color string) bool {
+	return colorPattern.MatchString(color)
+}
+
+const (
+	ColorByStatus    = "status"
+	ColorByService   = "service"
+	ColorByCaregiver = "caregiver"
+	ColorByClient    = "client"
+)
+
+// ColorRule colors the schedules whose Field equals Value, e.g. every visit
+// of one caregiver.
+type ColorRule struct {
+	Field string
+	Value string
+	Color string
+}
+
+func (r ColorRule) Matches(s *Schedule) bool {
+	switch r.Field {
+	case ColorByStatus:
+		return s.VisitStatus == r.Value
+	case ColorByService:
+		return s.ServiceName == r.Value
+	case ColorByCaregiver:
+		return s.AssignedUserID.String() == r.Value
+	case ColorByClient:
+		return s.ClientUserID.String() == r.Value
+	}
+	return false
+}
+
+// SearchQuery narrows a schedule search. Empty fields do not filter; Regions
+// match the client's city or state.
+type SearchQuery struct {
+	ViewID          *uuid.UUID
+	Statuses        []string
+	AssignedUserIDs []uuid.UUID
+	ClientUserIDs   []uuid.UUID
+	ServiceNames    []string
+	Regions         []string
+	TeamIDs         []uuid.UUID
+	From            *time.Time
+	To              *time.Time
+	SortBy          string
+	SortDirection   domain.SortDirection
+	ColorRules      []ColorRule
+	// Deleted picks whether soft-deleted visits are included.
+	Deleted  domain.DeletedScope
+	Page     int
+	PageSize int
+}
+
+// ListFilter narrows a list of schedules. Empty fields do not filter; From
+// and To keep the visits whose slot overlaps [From, To). FromDate and ToDate
+// are days, written as YYYY-MM-DD, that the use case turns into From and To
+// in the organization's time zone; ToDate is included whole.
+type ListFilter struct {
+	From           *time.Time
+	To             *time.Time
+	FromDate       string
+	ToDate         string
+	VisitStatuses  []string
+	ClientUserID   *uuid.UUID
+	AssignedUserID *uuid.UUID
+}
+
+// IsEmpty reports whether the filter keeps every schedule.
+func (f ListFilter) IsEmpty() bool {
+	return f.From == nil && f.To == nil && f.FromDate == "" && f.ToDate == "" && len(f.VisitStatuses) == 0 &&
+		f.ClientUserID == nil && f.AssignedUserID == nil
+}
+
+// Query returns the search query keeping the schedules the filter keeps.
+// FromDate and ToDate must already be resolved into From and To.
+func (f ListFilter) Query() SearchQuery {
+	query := SearchQuery{Statuses: f.VisitStatuses, From: f.From, To: f.To}
+	if f.ClientUserID != nil {
+		query.ClientUserIDs = []uuid.UUID{*f.ClientUserID}
+	}
+	if f.AssignedUserID != nil {
+		query.AssignedUserIDs = []uuid.UUID{*f.AssignedUserID}
+	}
+	return query
+}
+
+// SortableColumns are the columns a schedule search may be ordered by.
+var SortableColumns = []string{"scheduled_slot_from", "scheduled_slot_to", "visit_status", "service_name", "created_at"}
+
 type SearchResultSchedule struct {
 	Data       *[]Schedule
 	Total      int64
@@ -57,11 +493,70 @@ type SearchResultSchedule struct {
 
 type IScheduleRepository interface {
 	GetSchedules() (*[]Schedule, error)
+	// StreamSchedules calls fn with every schedule the filter keeps, reading
+	// them from the database a batch at a time, and stops at the first error
+	// fn returns.
+	StreamSchedules(filter ListFilter, fn func(*Schedule) error) error
+	// SearchPaginated returns one page of all schedules, ordered by the first
+	// of filters.SortBy, which must be one of SortableColumns.
+	SearchPaginated(filters domain.DataFilters) (*SearchResultSchedule, error)
+	// GetFilteredSchedules returns the schedules the filter keeps, ordered by
+	// scheduled start.
+	GetFilteredSchedules(filter ListFilter) (*[]Schedule, error)
 	GetScheduleByID(id uuid.UUID) (*Schedule, error)
 	GetTodaySchedules(userID uuid.UUID) (*[]Schedule, error)
 	UpdateSchedule(id uuid.UUID, updates map[string]interface{}) (*Schedule, error)
+	GetTaskByID(taskID uuid.UUID) (*Task, error)
 	UpdateTask(taskID uuid.UUID, updates map[string]interface{}) (*Task, error)
+	// Delete soft-deletes a visit, hiding it from every other query until it
+	// is restored. deletedBy, when known, is shown in the admin trash.
+	Delete(id uuid.UUID, deletedBy *uuid.UUID) error
+	Restore(id uuid.UUID) (*Schedule, error)
+	// DeleteTask soft-deletes a task, leaving it out of its visit.
+	DeleteTask(taskID uuid.UUID) error
+	RestoreTask(taskID uuid.UUID) (*Task, error)
 	Create(newSchedule *Schedule) (*Schedule, error)
 	GetSchedulesByAssignedUserIDPaginated(assignedUserID uuid.UUID, filters domain.DataFilters) (*SearchResultSchedule, error)
 	GetSchedulesInProgressByAssignedUserID(assignedUserID uuid.UUID) (*[]Schedule, error)
+	UpdateSegment(segmentID uuid.UUID, updates map[string]interface{}) (*Segment, error)
+	GetActiveSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]Schedule, error)
+	Search(query SearchQuery) (*SearchResultSchedule, error)
+	// GetUnstartedSchedulesBefore returns upcoming visits whose slot began
+	// before the given time without a check-in.
+	GetUnstartedSchedulesBefore(before time.Time) (*[]Schedule, error)
+	// MarkMissedBefore moves upcoming visits whose slot ended before the
+	// given time without a check-in to "missed" and returns them.
+	MarkMissedBefore(before time.Time) (*[]Schedule, error)
+	// GetStuckSchedulesBefore returns visits still in progress whose slot
+	// ended before the given time, with their segments.
+	GetStuckSchedulesBefore(before time.Time) (*[]Schedule, error)
+	// GetWorkedSchedulesBetween returns a caregiver's visits in WorkedStatuses
+	// overlapping [from, to), with their segments.
+	GetWorkedSchedulesBetween(assignedUserID uuid.UUID, from, to time.Time) (*[]Schedule, error)
+	// GetCheckedInSchedulesBetween returns the visits in WorkedStatuses
+	// checked in within [from, to), of the given caregivers or of everyone
+	// when none are given, with their segments, earliest first.
+	GetCheckedInSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]Schedule, error)
+	// GetPendingAttestationsDueBefore returns visits still awaiting the
+	// client's confirmation whose window closed before the given time.
+	GetPendingAttestationsDueBefore(before time.Time) (*[]Schedule, error)
+	// GetCompletedSchedulesBetween returns the completed visits of the given
+	// clients checked out within [from, to), with their segments.
+	GetCompletedSchedulesBetween(from, to time.Time, clientUserIDs []uuid.UUID) (*[]Schedule, error)
+	// GetUpcomingSeriesSchedules returns the not yet started visits of a
+	// recurring series, drafts included, starting at or after from, earliest
+	// first.
+	GetUpcomingSeriesSchedules(seriesID uuid.UUID, from time.Time) (*[]Schedule, error)
+	// ClaimOpenSchedule assigns an open, upcoming visit to the caregiver in
+	// a single conditional update, so only the first of several concurrent
+	// claims succeeds; the others get a Conflict.
+	ClaimOpenSchedule(id uuid.UUID, assignedUserID uuid.UUID) (*Schedule, error)
+	// GetTaskOutcomes counts the outcomes of a client's tasks on visits in
+	// DeliveredStatuses starting within [from, to), by TaskCategory and by
+	// the week or month the visit started in, earliest first. Periods begin
+	// at local midnight in timezone, an IANA zone name.
+	GetTaskOutcomes(clientUserID uuid.UUID, from, to time.Time, period string, timezone string) ([]TaskOutcomes, error)
+	// Transaction runs fn with a repository whose changes are committed
+	// together when fn returns nil and rolled back when it returns an error.
+	Transaction(fn func(repo IScheduleRepository) error) error
 }
@@ -0,0 +1,68 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkedPeriodsLessInterruptions(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, time.UTC)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name          string
+		schedule      Schedule
+		expectedHours float64
+		periods       int
+	}{
+		{
+			name:          "Uninterrupted",
+			schedule:      Schedule{CheckinTime: ptr(at(9, 0)), CheckoutTime: ptr(at(11, 0))},
+			expectedHours: 2,
+			periods:       1,
+		},
+		{
+			name: "Paused mid-visit",
+			schedule: Schedule{CheckinTime: ptr(at(9, 0)), CheckoutTime: ptr(at(11, 0)), Interruptions: []Interruption{
+				{ReasonCode: InterruptionClientRefused, StartedAt: at(9, 30), EndedAt: ptr(at(10, 0))},
+			}},
+			expectedHours: 1.5,
+			periods:       2,
+		},
+		{
+			name: "Open interruption runs to check-out",
+			schedule: Schedule{CheckinTime: ptr(at(9, 0)), CheckoutTime: ptr(at(11, 0)), Interruptions: []Interruption{
+				{ReasonCode: InterruptionEmergencyTransport, StartedAt: at(10, 15)},
+			}},
+			expectedHours: 1.25,
+			periods:       1,
+		},
+		{
+			name: "Interruption across split shift segments",
+			schedule: Schedule{
+				Segments: []Segment{
+					{CheckinTime: ptr(at(8, 0)), CheckoutTime: ptr(at(9, 0))},
+					{CheckinTime: ptr(at(12, 0)), CheckoutTime: ptr(at(13, 0))},
+				},
+				Interruptions: []Interruption{
+					{ReasonCode: InterruptionOther, StartedAt: at(8, 30), EndedAt: ptr(at(12, 30))},
+				},
+			},
+			expectedHours: 1,
+			periods:       2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods := tt.schedule.WorkedPeriods()
+			if len(periods) != tt.periods {
+				t.Errorf("expected %d periods, got %+v", tt.periods, periods)
+			}
+			if hours := tt.schedule.WorkedDuration().Hours(); hours != tt.expectedHours {
+				t.Errorf("expected %.2f hours worked, got %.2f", tt.expectedHours, hours)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Task kinds. Generic tasks are a title and a description; medication tasks
+// carry the order to administer and vitals tasks record readings when done.
+const (
+	TaskKindGeneric    = "generic"
+	TaskKindMedication = "medication"
+	TaskKindVitals     = "vitals"
+)
+
+var TaskKinds = []string{TaskKindGeneric, TaskKindMedication, TaskKindVitals}
+
+// TaskMedication is the dose a medication task gives, and when.
+type TaskMedication struct {
+	DrugName    string    `gorm:"column:drug_name"`
+	Dose        string    `gorm:"column:dose"`
+	Route       string    `gorm:"column:route"`
+	ScheduledAt time.Time `gorm:"column:scheduled_at"`
+}
+
+// Vitals are the readings a vitals task records when it is done. Readings
+// that were not taken are nil. Temperature is in degrees Celsius and blood
+// glucose in mg/dL.
+type Vitals struct {
+	SystolicBP       *int     `gorm:"column:systolic_bp"`
+	DiastolicBP      *int     `gorm:"column:diastolic_bp"`
+	HeartRate        *int     `gorm:"column:heart_rate"`
+	RespiratoryRate  *int     `gorm:"column:respiratory_rate"`
+	Temperature      *float64 `gorm:"column:temperature"`
+	OxygenSaturation *int     `gorm:"column:oxygen_saturation"`
+	BloodGlucose     *float64 `gorm:"column:blood_glucose"`
+}
+
+// vitalRange is the span a reading must fall in to be taken as plausible
+// rather than mistyped.
+type vitalRange struct {
+	name     string
+	min, max float64
+}
+
+var (
+	systolicRange    = vitalRange{"systolic blood pressure", 50, 260}
+	diastolicRange   = vitalRange{"diastolic blood pressure", 30, 160}
+	heartRateRange   = vitalRange{"heart rate", 20, 250}
+	respiratoryRange = vitalRange{"respiratory rate", 4, 60}
+	temperatureRange = vitalRange{"temperature", 30, 45}
+	oxygenRange      = vitalRange{"oxygen saturation", 50, 100}
+	glucoseRange     = vitalRange{"blood glucose", 20, 600}
+)
+
+func (r vitalRange) check(value float64) error {
+	if value < r.min || value > r.max {
+		return fmt.Errorf("%s must be between %g and %g", r.name, r.min, r.max)
+	}
+	return nil
+}
+
+// IsTaskKind reports whether kind is one of TaskKinds.
+func IsTaskKind(kind string) bool {
+	for _, k := range TaskKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize fills in the generic kind for tasks created without one.
+func (t *Task) Normalize() {
+	if t.Kind == "" {
+		t.Kind = TaskKindGeneric
+	}
+	if t.Medication != nil {
+		t.Medication.DrugName = strings.TrimSpace(t.Medication.DrugName)
+		t.Medication.Dose = strings.TrimSpace(t.Medication.Dose)
+		t.Medication.Route = strings.TrimSpace(t.Medication.Route)
+	}
+}
+
+// Validate checks a planned task against its kind. A medication's scheduled
+// time must fall within the visit's slot.
+func (t *Task) Validate(slot ScheduledSlot) error {
+	if !IsTaskKind(t.Kind) {
+		return fmt.Errorf("task kind must be one of %s", strings.Join(TaskKinds, ", "))
+	}
+	if t.Vitals != nil {
+		return errors.New("vitals are recorded when the task is done")
+	}
+	if t.Kind != TaskKindMedication {
+		if t.Medication != nil {
+			return fmt.Errorf("only %s tasks carry a medication", TaskKindMedication)
+		}
+		return nil
+	}
+	m := t.Medication
+	if m == nil || m.DrugName == "" || m.Dose == "" || m.Route == "" || m.ScheduledAt.IsZero() {
+		return errors.New("a medication task needs a drug name, dose, route and scheduled time")
+	}
+	if m.ScheduledAt.Before(slot.From) || m.ScheduledAt.After(slot.To) {
+		return errors.New("a medication's scheduled time must fall within the visit")
+	}
+	return nil
+}
+
+// ValidateOutcome checks the outcome reported for a task. A vitals task that
+// is done must record at least one reading, and only vitals tasks take
+// readings.
+func (t *Task) ValidateOutcome(done bool, vitals *Vitals) error {
+	if t.Kind != TaskKindVitals {
+		if vitals != nil {
+			return fmt.Errorf("only %s tasks record readings", TaskKindVitals)
+		}
+		return nil
+	}
+	if vitals == nil || vitals.Empty() {
+		if done {
+			return errors.New("a completed vitals task must record at least one reading")
+		}
+		return nil
+	}
+	return vitals.Validate()
+}
+
+// Empty reports whether no reading was taken.
+func (v *Vitals) Empty() bool {
+	return v.SystolicBP == nil && v.DiastolicBP == nil && v.HeartRate == nil && v.RespiratoryRate == nil &&
+		v.Temperature == nil && v.OxygenSaturation == nil && v.BloodGlucose == nil
+}
+
+// Validate checks that each reading is plausible and that blood pressure is
+// given as both numbers.
+func (v *Vitals) Validate() error {
+	if (v.SystolicBP == nil) != (v.DiastolicBP == nil) {
+		return errors.New("blood pressure needs both systolic and diastolic readings")
+	}
+	if v.SystolicBP != nil && *v.SystolicBP <= *v.DiastolicBP {
+		return errors.New("systolic blood pressure must be above diastolic")
+	}
+	readings := []struct {
+		r     vitalRange
+		value *float64
+	}{
+		{systolicRange, intReading(v.SystolicBP)},
+		{diastolicRange, intReading(v.DiastolicBP)},
+		{heartRateRange, intReading(v.HeartRate)},
+		{respiratoryRange, intReading(v.RespiratoryRate)},
+		{temperatureRange, v.Temperature},
+		{oxygenRange, intReading(v.OxygenSaturation)},
+		{glucoseRange, v.BloodGlucose},
+	}
+	for _, reading := range readings {
+		if reading.value == nil {
+			continue
+		}
+		if err := reading.r.check(*reading.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func intReading(value *int) *float64 {
+	if value == nil {
+		return nil
+	}
+	f := float64(*value)
+	return &f
+}
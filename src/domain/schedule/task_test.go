@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskValidate(t *testing.T) {
+	from := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	slot := ScheduledSlot{From: from, To: from.Add(2 * time.Hour)}
+	medication := func(at time.Time) *TaskMedication {
+		return &TaskMedication{DrugName: "Metformin", Dose: "500 mg", Route: "oral", ScheduledAt: at}
+	}
+
+	tests := []struct {
+		name  string
+		task  Task
+		valid bool
+	}{
+		{"generic", Task{Title: "Lunch"}, true},
+		{"medication", Task{Kind: TaskKindMedication, Medication: medication(from.Add(time.Hour))}, true},
+		{"medication without details", Task{Kind: TaskKindMedication}, false},
+		{"medication missing route", Task{Kind: TaskKindMedication, Medication: &TaskMedication{DrugName: "Metformin", Dose: "500 mg", ScheduledAt: from}}, false},
+		{"medication outside visit", Task{Kind: TaskKindMedication, Medication: medication(from.Add(3 * time.Hour))}, false},
+		{"generic with medication", Task{Kind: TaskKindGeneric, Medication: medication(from)}, false},
+		{"vitals", Task{Kind: TaskKindVitals}, true},
+		{"readings before the visit", Task{Kind: TaskKindVitals, Vitals: &Vitals{HeartRate: intPtr(70)}}, false},
+		{"unknown kind", Task{Kind: "wound_care"}, false},
+	}
+	for _, tt := range tests {
+		tt.task.Normalize()
+		err := tt.task.Validate(slot)
+		if tt.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestTaskValidateOutcome(t *testing.T) {
+	temperature := 37.2
+	tests := []struct {
+		name   string
+		kind   string
+		done   bool
+		vitals *Vitals
+		valid  bool
+	}{
+		{"generic done", TaskKindGeneric, true, nil, true},
+		{"generic with readings", TaskKindGeneric, true, &Vitals{HeartRate: intPtr(70)}, false},
+		{"vitals recorded", TaskKindVitals, true, &Vitals{SystolicBP: intPtr(120), DiastolicBP: intPtr(80), Temperature: &temperature}, true},
+		{"vitals done without readings", TaskKindVitals, true, &Vitals{}, false},
+		{"vitals not done", TaskKindVitals, false, nil, true},
+		{"half a blood pressure", TaskKindVitals, true, &Vitals{SystolicBP: intPtr(120)}, false},
+		{"systolic below diastolic", TaskKindVitals, true, &Vitals{SystolicBP: intPtr(70), DiastolicBP: intPtr(80)}, false},
+		{"implausible oxygen saturation", TaskKindVitals, true, &Vitals{OxygenSaturation: intPtr(101)}, false},
+	}
+	for _, tt := range tests {
+		task := Task{Kind: tt.kind}
+		err := task.ValidateOutcome(tt.done, tt.vitals)
+		if tt.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestOccurrenceShiftsMedicationTime(t *testing.T) {
+	from := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	first := Schedule{
+		ScheduledSlot: ScheduledSlot{From: from, To: from.Add(2 * time.Hour)},
+		Tasks: []Task{{Title: "Evening dose", Kind: TaskKindMedication,
+			Medication: &TaskMedication{DrugName: "Metformin", Dose: "500 mg", Route: "oral", ScheduledAt: from.Add(time.Hour)}}},
+	}
+	next := first.Occurrence(from.AddDate(0, 0, 7))
+	task := next.Tasks[0]
+	if task.Kind != TaskKindMedication || task.Medication == nil {
+		t.Fatalf("expected the medication task copied, got %+v", task)
+	}
+	if want := from.AddDate(0, 0, 7).Add(time.Hour); !task.Medication.ScheduledAt.Equal(want) {
+		t.Errorf("expected the dose at %s, got %s", want, task.Medication.ScheduledAt)
+	}
+	if !first.Tasks[0].Medication.ScheduledAt.Equal(from.Add(time.Hour)) {
+		t.Errorf("expected the first visit's dose left unchanged")
+	}
+}
+
+func intPtr(v int) *int { return &v }
@@ -0,0 +1,72 @@
+package scheduleview
+
+import (
+	"time"
+
+	"caregiver/src/domain"
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+// Filters are the schedule search filters a view saves. Empty fields do not
+// filter.
+type Filters struct {
+	Statuses        []string
+	AssignedUserIDs []uuid.UUID
+	ClientUserIDs   []uuid.UUID
+	ServiceNames    []string
+	Regions         []string
+	TeamIDs         []uuid.UUID
+}
+
+// View is a coordinator's named filter and sort preset for the schedule
+// search, with optional color rules for the calendar.
+type View struct {
+	ID            uuid.UUID
+	OwnerUserID   uuid.UUID
+	Name          string
+	Filters       Filters
+	SortBy        string
+	SortDirection domain.SortDirection
+	ColorRules    []domainSchedule.ColorRule
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ApplyTo fills the query fields the caller left empty from the view.
+func (v *View) ApplyTo(query *domainSchedule.SearchQuery) {
+	if len(query.Statuses) == 0 {
+		query.Statuses = v.Filters.Statuses
+	}
+	if len(query.AssignedUserIDs) == 0 {
+		query.AssignedUserIDs = v.Filters.AssignedUserIDs
+	}
+	if len(query.ClientUserIDs) == 0 {
+		query.ClientUserIDs = v.Filters.ClientUserIDs
+	}
+	if len(query.ServiceNames) == 0 {
+		query.ServiceNames = v.Filters.ServiceNames
+	}
+	if len(query.Regions) == 0 {
+		query.Regions = v.Filters.Regions
+	}
+	if len(query.TeamIDs) == 0 {
+		query.TeamIDs = v.Filters.TeamIDs
+	}
+	if query.SortBy == "" {
+		query.SortBy = v.SortBy
+	}
+	if query.SortDirection == "" {
+		query.SortDirection = v.SortDirection
+	}
+	query.ColorRules = append(query.ColorRules, v.ColorRules...)
+}
+
+type IScheduleViewRepository interface {
+	Create(view *View) (*View, error)
+	GetByID(id uuid.UUID) (*View, error)
+	GetByOwner(ownerUserID uuid.UUID) (*[]View, error)
+	Update(view *View) (*View, error)
+	Delete(id uuid.UUID) error
+}
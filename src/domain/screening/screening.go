@@ -0,0 +1,61 @@
+package screening
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	KindBackgroundCheck = "background_check"
+	KindLicense         = "license"
+
+	// StatusPending checks are still with the provider; the others are
+	// results. A failed check covers both adverse background findings and
+	// license numbers the issuing board does not recognise as active.
+	StatusPending = "pending"
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+)
+
+// Check is a background check ordered for a caregiver or the verification of
+// one of their professional licenses. Provider and ExternalID identify it at
+// the screening provider; checks entered by hand have the "manual" provider.
+// A passed check stops counting once ExpiresAt is reached.
+type Check struct {
+	ID              uuid.UUID
+	CaregiverUserID uuid.UUID
+	Kind            string
+	Provider        string
+	ExternalID      string
+	LicenseNumber   string
+	LicenseState    string
+	LicenseType     string
+	Status          string
+	Detail          string
+	OrderedAt       time.Time
+	CompletedAt     *time.Time
+	ExpiresAt       *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Expired reports whether a passed check has lapsed by the given time.
+func (c *Check) Expired(at time.Time) bool {
+	return c.ExpiresAt != nil && !c.ExpiresAt.After(at)
+}
+
+type CheckFilter struct {
+	CaregiverUserID *uuid.UUID
+	Kind            string
+	Status          string
+}
+
+type IScreeningRepository interface {
+	CreateCheck(newCheck *Check) (*Check, error)
+	GetCheckByID(id uuid.UUID) (*Check, error)
+	// GetChecks returns matching checks, most recently ordered first.
+	GetChecks(filter CheckFilter) (*[]Check, error)
+	UpdateCheck(id uuid.UUID, updates map[string]interface{}) (*Check, error)
+	DeleteCheck(id uuid.UUID) error
+}
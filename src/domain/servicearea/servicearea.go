@@ -0,0 +1,83 @@
+package servicearea
+
+import (
+	"time"
+
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TypeRadius  = "radius"
+	TypePolygon = "polygon"
+
+	// ModeWarn lets out-of-area assignments through with a warning;
+	// ModeEnforce rejects them.
+	ModeWarn    = "warn"
+	ModeEnforce = "enforce"
+)
+
+type Point struct {
+	Lat  float64
+	Long float64
+}
+
+// Area is the region a caregiver is willing to work in: either a radius
+// around a center (the caregiver's home address unless set) or a polygon.
+type Area struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Type       string
+	CenterLat  *float64
+	CenterLong *float64
+	RadiusKm   float64
+	Polygon    []Point
+	Mode       string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Center returns the radius center, falling back to the caregiver's home.
+func (a *Area) Center(home domainUser.Location) domainUser.Location {
+	if a.CenterLat != nil && a.CenterLong != nil {
+		return domainUser.Location{Lat: *a.CenterLat, Long: *a.CenterLong}
+	}
+	return home
+}
+
+// Contains reports whether the location lies inside the area. home is the
+// caregiver's address, used as the center of radius areas without one.
+func (a *Area) Contains(home domainUser.Location, location domainUser.Location) bool {
+	if a.Type == TypePolygon {
+		return polygonContains(a.Polygon, location)
+	}
+	center := a.Center(home)
+	if !center.HasCoordinates() {
+		return true
+	}
+	return center.DistanceKm(location) <= a.RadiusKm
+}
+
+// polygonContains uses ray casting, treating latitude and longitude as planar
+// coordinates, which is accurate enough at service-area scale.
+func polygonContains(polygon []Point, location domainUser.Location) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > location.Lat) != (b.Lat > location.Lat) &&
+			location.Long < (b.Long-a.Long)*(location.Lat-a.Lat)/(b.Lat-a.Lat)+a.Long {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+type IServiceAreaRepository interface {
+	// Save creates or replaces the caregiver's service area.
+	Save(area *Area) (*Area, error)
+	GetByUserID(userID uuid.UUID) (*Area, error)
+	GetByUserIDs(userIDs []uuid.UUID) (*[]Area, error)
+	GetAll() (*[]Area, error)
+	DeleteByUserID(userID uuid.UUID) error
+}
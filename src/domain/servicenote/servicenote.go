@@ -0,0 +1,35 @@
+package servicenote
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBodyLength caps a service note at 10,000 characters.
+const MaxBodyLength = 10000
+
+// WritableStatuses are the visit states a service note can be written in:
+// during the visit or after it.
+var WritableStatuses = []string{"in_progress", "partially_completed", "completed"}
+
+// Revision is one version of a visit's service note. Revisions are never
+// changed: saving the note appends a revision, and the latest one is the
+// note shown on the visit. AuthorUserID is nil when the author is not
+// known, such as a note saved by a trusted integration.
+type Revision struct {
+	ID           uuid.UUID
+	ScheduleID   uuid.UUID
+	Revision     int
+	Body         string
+	AuthorUserID *uuid.UUID
+	CreatedAt    time.Time
+}
+
+type IServiceNoteRepository interface {
+	// Append stores the next revision of the visit's note and makes its body
+	// the visit's service note, in one transaction.
+	Append(revision *Revision) (*Revision, error)
+	// GetBySchedule returns the revisions of a visit's note, oldest first.
+	GetBySchedule(scheduleID uuid.UUID) (*[]Revision, error)
+}
@@ -0,0 +1,76 @@
+package signature
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DocumentCareAgreement = "care_agreement"
+	DocumentConsent       = "consent"
+
+	MethodDrawn = "drawn"
+	MethodTyped = "typed"
+)
+
+// Signature records a user signing an attachment. DocumentHash is the
+// SHA-256 of the file content at signing time and RecordHash seals the
+// signature fields themselves, so either changing later shows up on
+// verification.
+type Signature struct {
+	ID            uuid.UUID
+	AttachmentID  uuid.UUID
+	ScheduleID    uuid.UUID
+	DocumentType  string
+	SignerUserID  uuid.UUID
+	SignerName    string
+	Method        string
+	SignatureData string
+	DocumentHash  string
+	IPAddress     string
+	UserAgent     string
+	SignedAt      time.Time
+	RecordHash    string
+}
+
+// Verification is the result of re-checking a signature against the stored
+// document and its own record.
+type Verification struct {
+	Signature           *Signature
+	CurrentDocumentHash string
+	DocumentFound       bool
+	DocumentIntact      bool
+	RecordIntact        bool
+	Valid               bool
+}
+
+type ISignatureRepository interface {
+	Create(newSignature *Signature) (*Signature, error)
+	GetByID(id uuid.UUID) (*Signature, error)
+	GetByAttachment(attachmentID uuid.UUID) (*[]Signature, error)
+}
+
+// ComputeRecordHash hashes the fields a signature vouches for. SignedAt is
+// taken at microsecond precision, which is what the database keeps.
+func (s *Signature) ComputeRecordHash() string {
+	signatureDigest := sha256.Sum256([]byte(s.SignatureData))
+	fields := []string{
+		s.ID.String(),
+		s.AttachmentID.String(),
+		s.DocumentType,
+		s.SignerUserID.String(),
+		s.SignerName,
+		s.Method,
+		hex.EncodeToString(signatureDigest[:]),
+		s.DocumentHash,
+		s.IPAddress,
+		s.UserAgent,
+		s.SignedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\n")))
+	return hex.EncodeToString(sum[:])
+}
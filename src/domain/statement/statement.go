@@ -0,0 +1,131 @@
+package statement
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// LineInvoice is a billed visit, LinePayment money received from the
+	// payer or the client and LineAdjustment any other correction such as a
+	// write-off.
+	LineInvoice    = "invoice"
+	LinePayment    = "payment"
+	LineAdjustment = "adjustment"
+
+	MethodCheck = "check"
+	MethodCard  = "card"
+	MethodACH   = "ach"
+	MethodCash  = "cash"
+)
+
+// Payment is money received from a client or their family toward the
+// client's balance. Payer remittances are tracked on claims instead.
+type Payment struct {
+	ID           uuid.UUID
+	ClientUserID uuid.UUID
+	Amount       float64
+	Method       string
+	Reference    string
+	Note         string
+	ReceivedAt   time.Time
+	CreatedAt    time.Time
+}
+
+// Adjustment corrects a client's balance outside of billing. A positive
+// amount adds a charge and a negative one gives a credit.
+type Adjustment struct {
+	ID           uuid.UUID
+	ClientUserID uuid.UUID
+	Amount       float64
+	Reason       string
+	EffectiveAt  time.Time
+	CreatedAt    time.Time
+}
+
+// Filter narrows payments or adjustments to a client and, optionally, to
+// those dated within [From, To).
+type Filter struct {
+	ClientUserID uuid.UUID
+	From         *time.Time
+	To           *time.Time
+}
+
+// Line is one entry on a statement. Amount is positive for charges and
+// negative for credits; Balance is the running balance after the line.
+type Line struct {
+	Date        time.Time
+	Type        string
+	Reference   string
+	Description string
+	Amount      float64
+	Balance     float64
+}
+
+// Statement is a client's account activity from the From day through the To
+// day. OpeningBalance carries everything dated before From.
+type Statement struct {
+	ClientUserID   uuid.UUID
+	ClientName     string
+	From           time.Time
+	To             time.Time
+	OpeningBalance float64
+	Invoiced       float64
+	Paid           float64
+	Adjusted       float64
+	ClosingBalance float64
+	Lines          []Line
+	GeneratedAt    time.Time
+}
+
+type IStatementRepository interface {
+	CreatePayment(newPayment *Payment) (*Payment, error)
+	GetPaymentByID(id uuid.UUID) (*Payment, error)
+	GetPayments(filter Filter) (*[]Payment, error)
+	DeletePayment(id uuid.UUID) error
+	CreateAdjustment(newAdjustment *Adjustment) (*Adjustment, error)
+	GetAdjustmentByID(id uuid.UUID) (*Adjustment, error)
+	GetAdjustments(filter Filter) (*[]Adjustment, error)
+	DeleteAdjustment(id uuid.UUID) error
+}
+
+// Build orders the account entries by date and runs the balance through
+// them. Entries before the from day go into the opening balance and entries
+// after the to day are left out.
+func Build(clientUserID uuid.UUID, from, to time.Time, entries []Line) Statement {
+	end := to.AddDate(0, 0, 1)
+	sorted := append([]Line(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	statement := Statement{ClientUserID: clientUserID, From: from, To: to, Lines: []Line{}}
+	balance := 0.0
+	for _, line := range sorted {
+		if !line.Date.Before(end) {
+			break
+		}
+		balance = roundCents(balance + line.Amount)
+		if line.Date.Before(from) {
+			statement.OpeningBalance = balance
+			continue
+		}
+		switch line.Type {
+		case LineInvoice:
+			statement.Invoiced = roundCents(statement.Invoiced + line.Amount)
+		case LinePayment:
+			statement.Paid = roundCents(statement.Paid - line.Amount)
+		case LineAdjustment:
+			statement.Adjusted = roundCents(statement.Adjusted + line.Amount)
+		}
+		line.Balance = balance
+		statement.Lines = append(statement.Lines, line)
+	}
+	statement.ClosingBalance = balance
+	return statement
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
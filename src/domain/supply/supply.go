@@ -0,0 +1,134 @@
+package supply
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Item is a catalog entry for a consumable used on visits, such as gloves or
+// wound kits. OnHand is the stock count in Unit; an item at or below
+// ReorderLevel is low on stock.
+type Item struct {
+	ID           uuid.UUID
+	Name         string
+	SKU          string
+	Unit         string
+	UnitCost     float64
+	OnHand       int
+	ReorderLevel int
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsLow reports whether the item needs reordering.
+func (i *Item) IsLow() bool {
+	return i.OnHand <= i.ReorderLevel
+}
+
+// Usage records supplies a caregiver used on a visit. UnitCost is copied from
+// the catalog at the time so later price changes do not alter reimbursement.
+type Usage struct {
+	ID              uuid.UUID
+	ItemID          uuid.UUID
+	ScheduleID      uuid.UUID
+	ClientUserID    uuid.UUID
+	CaregiverUserID uuid.UUID
+	Quantity        int
+	UnitCost        float64
+	Note            string
+	UsedAt          time.Time
+	CreatedAt       time.Time
+}
+
+// UsageFilter narrows usage to a visit, a client or the period [From, To).
+type UsageFilter struct {
+	ScheduleID   *uuid.UUID
+	ClientUserID *uuid.UUID
+	From         *time.Time
+	To           *time.Time
+}
+
+// ConsumptionLine totals one item used for a client.
+type ConsumptionLine struct {
+	ItemID   uuid.UUID
+	Name     string
+	Unit     string
+	Quantity int
+	Cost     float64
+}
+
+// ClientConsumption is what one client used over the report period.
+type ClientConsumption struct {
+	ClientUserID uuid.UUID
+	Lines        []ConsumptionLine
+	TotalCost    float64
+}
+
+// ConsumptionReport totals supply usage per client from the From day through
+// the To day, for reimbursement.
+type ConsumptionReport struct {
+	From      time.Time
+	To        time.Time
+	Clients   []ClientConsumption
+	TotalCost float64
+}
+
+type ISupplyRepository interface {
+	CreateItem(newItem *Item) (*Item, error)
+	GetItemByID(id uuid.UUID) (*Item, error)
+	GetItems() (*[]Item, error)
+	GetLowStockItems() (*[]Item, error)
+	UpdateItem(id uuid.UUID, updates map[string]interface{}) (*Item, error)
+	DeleteItem(id uuid.UUID) error
+	// AdjustStock adds delta, which may be negative, to the item's stock in
+	// one statement and returns the updated item.
+	AdjustStock(id uuid.UUID, delta int) (*Item, error)
+	CreateUsage(newUsage *Usage) (*Usage, error)
+	GetUsageByID(id uuid.UUID) (*Usage, error)
+	GetUsages(filter UsageFilter) (*[]Usage, error)
+	DeleteUsage(id uuid.UUID) error
+}
+
+// Summarize groups usage by client and item. Clients and their lines are
+// ordered by client ID and item name so reports are stable.
+func Summarize(from, to time.Time, usages []Usage, items map[uuid.UUID]Item) ConsumptionReport {
+	report := ConsumptionReport{From: from, To: to, Clients: []ClientConsumption{}}
+	byClient := make(map[uuid.UUID]map[uuid.UUID]*ConsumptionLine)
+	for _, usage := range usages {
+		lines, ok := byClient[usage.ClientUserID]
+		if !ok {
+			lines = make(map[uuid.UUID]*ConsumptionLine)
+			byClient[usage.ClientUserID] = lines
+		}
+		line, ok := lines[usage.ItemID]
+		if !ok {
+			item := items[usage.ItemID]
+			line = &ConsumptionLine{ItemID: usage.ItemID, Name: item.Name, Unit: item.Unit}
+			lines[usage.ItemID] = line
+		}
+		line.Quantity += usage.Quantity
+		line.Cost = roundCents(line.Cost + float64(usage.Quantity)*usage.UnitCost)
+	}
+	for clientID, lines := range byClient {
+		client := ClientConsumption{ClientUserID: clientID}
+		for _, line := range lines {
+			client.Lines = append(client.Lines, *line)
+			client.TotalCost = roundCents(client.TotalCost + line.Cost)
+		}
+		sort.Slice(client.Lines, func(i, j int) bool { return client.Lines[i].Name < client.Lines[j].Name })
+		report.Clients = append(report.Clients, client)
+		report.TotalCost = roundCents(report.TotalCost + client.TotalCost)
+	}
+	sort.Slice(report.Clients, func(i, j int) bool {
+		return report.Clients[i].ClientUserID.String() < report.Clients[j].ClientUserID.String()
+	})
+	return report
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
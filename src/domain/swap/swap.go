@@ -0,0 +1,57 @@
+package swap
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// StatusPending requests wait for another caregiver to accept.
+	StatusPending = "pending"
+	// StatusAccepted requests wait for an admin to approve.
+	StatusAccepted = "accepted"
+	// StatusApproved requests have moved the visit to the accepting caregiver.
+	StatusApproved = "approved"
+	// StatusRejected requests were turned down by an admin.
+	StatusRejected = "rejected"
+	// StatusCancelled requests were withdrawn by the requesting caregiver.
+	StatusCancelled = "cancelled"
+)
+
+// Request asks to hand a visit from its caregiver (FromUserID) to another
+// one. Any eligible caregiver may accept it, becoming ToUserID, and the visit
+// is reassigned once an admin approves. Requests are kept after they close
+// as the visit's swap history.
+type Request struct {
+	ID           uuid.UUID
+	ScheduleID   uuid.UUID
+	FromUserID   uuid.UUID
+	ToUserID     *uuid.UUID
+	Reason       string
+	Status       string
+	AcceptedAt   *time.Time
+	DecidedBy    *uuid.UUID
+	DecidedAt    *time.Time
+	DecisionNote string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Open reports whether the request is still waiting on someone.
+func (r *Request) Open() bool {
+	return r.Status == StatusPending || r.Status == StatusAccepted
+}
+
+type ISwapRepository interface {
+	Create(request *Request) (*Request, error)
+	GetByID(id uuid.UUID) (*Request, error)
+	// GetBySchedule returns the swap history of a visit, newest first.
+	GetBySchedule(scheduleID uuid.UUID) (*[]Request, error)
+	// GetByStatus returns the requests in any of the statuses, oldest first.
+	GetByStatus(statuses ...string) (*[]Request, error)
+	// Transition applies the updates only while the request is in one of the
+	// from statuses, so two callers cannot both move it on. It returns
+	// Conflict when the request has moved on already.
+	Transition(id uuid.UUID, from []string, updates map[string]interface{}) (*Request, error)
+}
@@ -0,0 +1,56 @@
+package team
+
+import (
+	"time"
+
+	domainCompliance "caregiver/src/domain/compliance"
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+// Team groups caregivers working a region under one supervisor. A caregiver
+// belongs to at most one team.
+type Team struct {
+	ID               uuid.UUID
+	Name             string
+	Region           string
+	SupervisorUserID uuid.UUID
+	MemberUserIDs    []uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// HasMember reports whether the user is on the team.
+func (t *Team) HasMember(userID uuid.UUID) bool {
+	for _, id := range t.MemberUserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Overview is a supervisor's view of the team's visits and compliance
+// exceptions over a time range.
+type Overview struct {
+	Team       Team
+	From       time.Time
+	To         time.Time
+	Visits     domainSchedule.SearchResultSchedule
+	Exceptions []domainCompliance.Exception
+}
+
+type ITeamRepository interface {
+	Create(newTeam *Team) (*Team, error)
+	GetByID(id uuid.UUID) (*Team, error)
+	GetAll() (*[]Team, error)
+	GetBySupervisor(supervisorUserID uuid.UUID) (*[]Team, error)
+	GetByMember(userID uuid.UUID) (*Team, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Team, error)
+	Delete(id uuid.UUID) error
+	AddMember(teamID, userID uuid.UUID) error
+	RemoveMember(teamID, userID uuid.UUID) error
+	// GetMemberIDs returns the members of all the given teams.
+	GetMemberIDs(teamIDs []uuid.UUID) ([]uuid.UUID, error)
+}
@@ -0,0 +1,260 @@
+package timesheet
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoundNearest = "nearest"
+	RoundUp      = "up"
+	RoundDown    = "down"
+)
+
+const (
+	// PayCodeRegular and PayCodeOvertime are the earnings codes hours are
+	// exported under.
+	PayCodeRegular  = "REG"
+	PayCodeOvertime = "OT"
+)
+
+// Policy decides how worked time is paid. Each visit's time on a day is
+// rounded to RoundingMinutes, to the whole minute when 0, in the Rounding
+// direction. Paid time past DailyOvertime hours in a day, then regular time
+// past WeeklyOvertime hours in a week, is overtime; a threshold of 0 turns
+// that rule off. Days are UTC and weeks start on Monday.
+type Policy struct {
+	RoundingMinutes int
+	Rounding        string
+	DailyOvertime   float64
+	WeeklyOvertime  float64
+}
+
+// DefaultPolicy rounds to the minute and pays overtime past 40 hours a week.
+var DefaultPolicy = Policy{Rounding: RoundNearest, WeeklyOvertime: 40}
+
+// ParsePolicy reads a policy written as "rounding_minutes=15,rounding=up,
+// daily_overtime=8,weekly_overtime=40". Settings left out keep their default;
+// an empty string gives DefaultPolicy.
+func ParsePolicy(value string) (Policy, error) {
+	policy := DefaultPolicy
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		key, raw, ok := strings.Cut(part, "=")
+		if !ok {
+			return Policy{}, fmt.Errorf("invalid timesheet policy setting %q", part)
+		}
+		raw = strings.TrimSpace(raw)
+		var err error
+		switch strings.TrimSpace(key) {
+		case "rounding_minutes":
+			policy.RoundingMinutes, err = strconv.Atoi(raw)
+			if err == nil && (policy.RoundingMinutes < 0 || policy.RoundingMinutes > 60) {
+				err = fmt.Errorf("out of range")
+			}
+		case "rounding":
+			policy.Rounding = raw
+			if raw != RoundNearest && raw != RoundUp && raw != RoundDown {
+				err = fmt.Errorf("unknown rounding")
+			}
+		case "daily_overtime":
+			policy.DailyOvertime, err = parseHours(raw, 24)
+		case "weekly_overtime":
+			policy.WeeklyOvertime, err = parseHours(raw, 168)
+		default:
+			err = fmt.Errorf("unknown setting")
+		}
+		if err != nil {
+			return Policy{}, fmt.Errorf("invalid timesheet policy setting %q", part)
+		}
+	}
+	return policy, nil
+}
+
+func parseHours(value string, max float64) (float64, error) {
+	hours, err := strconv.ParseFloat(value, 64)
+	if err != nil || hours < 0 || hours > max {
+		return 0, fmt.Errorf("invalid hours")
+	}
+	return hours, nil
+}
+
+// Round returns the paid minutes for the time worked.
+func (p Policy) Round(worked time.Duration) int {
+	increment := int64(60)
+	if p.RoundingMinutes > 0 {
+		increment = int64(p.RoundingMinutes) * 60
+	}
+	seconds := int64(worked / time.Second)
+	switch p.Rounding {
+	case RoundUp:
+		seconds += increment - 1
+	case RoundDown:
+	default:
+		seconds += increment / 2
+	}
+	return int(seconds/increment*increment) / 60
+}
+
+// Entry is one visit's time on one day. CheckinTime and CheckoutTime bound
+// the periods worked that day; Minutes is the rounded time paid for them.
+type Entry struct {
+	ScheduleID    uuid.UUID
+	ServiceName   string
+	CheckinTime   time.Time
+	CheckoutTime  time.Time
+	WorkedMinutes float64
+	Minutes       int
+}
+
+// Day is a caregiver's paid time on one day, split into regular and
+// overtime minutes.
+type Day struct {
+	Date            time.Time
+	RegularMinutes  int
+	OvertimeMinutes int
+	Entries         []Entry
+}
+
+// Timesheet is one caregiver's paid time over a range of days, with the
+// days they worked, earliest first.
+type Timesheet struct {
+	CaregiverUserID uuid.UUID
+	FirstName       string
+	LastName        string
+	Email           string
+	RegularMinutes  int
+	OvertimeMinutes int
+	Visits          int
+	Days            []Day
+}
+
+// Report is the timesheets of everyone who worked in [From, To).
+type Report struct {
+	From       time.Time
+	To         time.Time
+	Policy     Policy
+	Timesheets []Timesheet
+}
+
+// Hours converts paid minutes to hours, to the hundredth.
+func Hours(minutes int) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
+
+// DayOf returns the UTC day t falls on.
+func DayOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// WeekStart returns the Monday starting the week t falls in.
+func WeekStart(t time.Time) time.Time {
+	day := DayOf(t)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// Build works out a caregiver's timesheet for the days in [from, to) from
+// the visits they checked in to. Worked time counts on the day each period
+// starts. Days earlier in the week of from count toward weekly overtime but
+// are not listed.
+func Build(caregiverUserID uuid.UUID, schedules []domainSchedule.Schedule, from, to time.Time, policy Policy) Timesheet {
+	type key struct {
+		day        time.Time
+		scheduleID uuid.UUID
+	}
+	worked := map[key]*Entry{}
+	durations := map[key]time.Duration{}
+	weekStart := WeekStart(from)
+	for _, schedule := range schedules {
+		for _, period := range schedule.WorkedPeriods() {
+			day := DayOf(period.From)
+			if day.Before(weekStart) || !day.Before(to) {
+				continue
+			}
+			k := key{day: day, scheduleID: schedule.ID}
+			entry, ok := worked[k]
+			if !ok {
+				entry = &Entry{ScheduleID: schedule.ID, ServiceName: schedule.ServiceName, CheckinTime: period.From, CheckoutTime: period.To}
+				worked[k] = entry
+			}
+			if period.From.Before(entry.CheckinTime) {
+				entry.CheckinTime = period.From
+			}
+			if period.To.After(entry.CheckoutTime) {
+				entry.CheckoutTime = period.To
+			}
+			durations[k] += period.To.Sub(period.From)
+		}
+	}
+
+	days := map[time.Time]*Day{}
+	for k, entry := range worked {
+		entry.WorkedMinutes = math.Round(durations[k].Minutes()*100) / 100
+		entry.Minutes = policy.Round(durations[k])
+		day, ok := days[k.day]
+		if !ok {
+			day = &Day{Date: k.day}
+			days[k.day] = day
+		}
+		day.Entries = append(day.Entries, *entry)
+	}
+	ordered := make([]*Day, 0, len(days))
+	for _, day := range days {
+		sort.Slice(day.Entries, func(i, j int) bool {
+			return day.Entries[i].CheckinTime.Before(day.Entries[j].CheckinTime)
+		})
+		ordered = append(ordered, day)
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].Date.Before(ordered[j].Date) })
+
+	timesheet := Timesheet{CaregiverUserID: caregiverUserID, Days: []Day{}}
+	visits := map[uuid.UUID]bool{}
+	var week time.Time
+	weekRegular := 0
+	for _, day := range ordered {
+		total := 0
+		for _, entry := range day.Entries {
+			total += entry.Minutes
+		}
+		day.RegularMinutes = total
+		if policy.DailyOvertime > 0 {
+			if limit := int(policy.DailyOvertime * 60); total > limit {
+				day.RegularMinutes, day.OvertimeMinutes = limit, total-limit
+			}
+		}
+		if start := WeekStart(day.Date); !start.Equal(week) {
+			week, weekRegular = start, 0
+		}
+		if policy.WeeklyOvertime > 0 {
+			limit := int(policy.WeeklyOvertime * 60)
+			if excess := weekRegular + day.RegularMinutes - limit; excess > 0 {
+				day.RegularMinutes -= excess
+				day.OvertimeMinutes += excess
+			}
+		}
+		weekRegular += day.RegularMinutes
+
+		if day.Date.Before(from) {
+			continue
+		}
+		timesheet.RegularMinutes += day.RegularMinutes
+		timesheet.OvertimeMinutes += day.OvertimeMinutes
+		for _, entry := range day.Entries {
+			visits[entry.ScheduleID] = true
+		}
+		timesheet.Days = append(timesheet.Days, *day)
+	}
+	timesheet.Visits = len(visits)
+	return timesheet
+}
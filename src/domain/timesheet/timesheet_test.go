@@ -0,0 +1,95 @@
+package timesheet
+
+import (
+	"testing"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+// worked is a visit checked in at start and out after the given time.
+func worked(start time.Time, duration time.Duration) domainSchedule.Schedule {
+	checkout := start.Add(duration)
+	return domainSchedule.Schedule{ID: uuid.New(), CheckinTime: &start, CheckoutTime: &checkout}
+}
+
+func TestBuild(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return monday.AddDate(0, 0, n) }
+	first, second := day(2), day(2).Add(5*time.Hour)
+	firstOut, secondOut := first.Add(4*time.Hour), second.Add(4*time.Hour)
+	split := domainSchedule.Schedule{ID: uuid.New(), Segments: []domainSchedule.Segment{
+		{CheckinTime: &first, CheckoutTime: &firstOut},
+		{CheckinTime: &second, CheckoutTime: &secondOut},
+	}}
+	schedules := []domainSchedule.Schedule{
+		worked(day(-1), 10*time.Hour),
+		worked(day(0), 10*time.Hour),
+		worked(day(1), 8*time.Hour),
+		split,
+		worked(day(3), 8*time.Hour),
+		worked(day(4), 9*time.Hour+5*time.Minute),
+		worked(day(5), 2*time.Hour),
+	}
+	policy := Policy{RoundingMinutes: 15, Rounding: RoundNearest, DailyOvertime: 8, WeeklyOvertime: 40}
+
+	timesheet := Build(uuid.New(), schedules, DayOf(day(1)), DayOf(day(6)), policy)
+	// Monday's 8 regular hours count toward the week but are not listed:
+	// Friday's rounded 9 hours are 8 regular and 1 daily overtime, and
+	// Saturday is past 40 regular hours for the week.
+	if len(timesheet.Days) != 5 || timesheet.Visits != 5 {
+		t.Fatalf("expected Tuesday through Saturday, got %+v", timesheet.Days)
+	}
+	if timesheet.RegularMinutes != 32*60 || timesheet.OvertimeMinutes != 3*60 {
+		t.Errorf("expected 32 regular and 3 overtime hours, got %d and %d minutes", timesheet.RegularMinutes, timesheet.OvertimeMinutes)
+	}
+	wednesday := timesheet.Days[1]
+	if len(wednesday.Entries) != 1 || wednesday.Entries[0].Minutes != 8*60 || !wednesday.Entries[0].CheckoutTime.Equal(secondOut) {
+		t.Errorf("expected the split shift as one 8 hour entry, got %+v", wednesday.Entries)
+	}
+	if friday := timesheet.Days[3]; friday.Entries[0].WorkedMinutes != 545 || friday.RegularMinutes != 8*60 || friday.OvertimeMinutes != 60 {
+		t.Errorf("expected Friday rounded to 8 regular and 1 overtime hour, got %+v", friday)
+	}
+
+	if empty := Build(uuid.New(), schedules, DayOf(day(7)), DayOf(day(8)), policy); len(empty.Days) != 0 {
+		t.Errorf("expected no days outside the visits, got %+v", empty.Days)
+	}
+}
+
+func TestPolicyRound(t *testing.T) {
+	tests := []struct {
+		policy  Policy
+		worked  time.Duration
+		minutes int
+	}{
+		{Policy{RoundingMinutes: 15, Rounding: RoundNearest}, 7*time.Minute + 29*time.Second, 0},
+		{Policy{RoundingMinutes: 15, Rounding: RoundNearest}, 7*time.Minute + 30*time.Second, 15},
+		{Policy{RoundingMinutes: 15, Rounding: RoundUp}, time.Second, 15},
+		{Policy{RoundingMinutes: 15, Rounding: RoundDown}, 14*time.Minute + 59*time.Second, 0},
+		{DefaultPolicy, 89 * time.Second, 1},
+		{DefaultPolicy, 90 * time.Second, 2},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.Round(tt.worked); got != tt.minutes {
+			t.Errorf("%+v rounding %s: expected %d, got %d", tt.policy, tt.worked, tt.minutes, got)
+		}
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("rounding_minutes=6, rounding=up, daily_overtime=8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Policy{RoundingMinutes: 6, Rounding: RoundUp, DailyOvertime: 8, WeeklyOvertime: 40}
+	if policy != expected {
+		t.Errorf("expected %+v, got %+v", expected, policy)
+	}
+	for _, value := range []string{"rounding=sideways", "rounding_minutes=90", "weekly_overtime=-1", "overtime=8", "daily_overtime"} {
+		if _, err := ParsePolicy(value); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
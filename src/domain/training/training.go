@@ -0,0 +1,99 @@
+package training
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusCompliant = "compliant"
+	StatusDueSoon   = "due_soon"
+	StatusOverdue   = "overdue"
+)
+
+// Course is a training staff complete. RequiredForRole makes it mandatory for
+// every user with that role, who must complete it within DueWithinDays of
+// joining. ServiceNames lists the services whose visits may only be assigned
+// to caregivers holding a current completion. A completion stays current for
+// ValidityDays; zero means it never lapses.
+type Course struct {
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	RequiredForRole string
+	ServiceNames    []string
+	DueWithinDays   int
+	ValidityDays    int
+	Active          bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// RequiredFor reports whether visits for serviceName need the course.
+func (c *Course) RequiredFor(serviceName string) bool {
+	for _, name := range c.ServiceNames {
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(serviceName)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Completion records a user finishing a course. ExpiresAt is set from the
+// course's validity when it is recorded.
+type Completion struct {
+	ID          uuid.UUID
+	CourseID    uuid.UUID
+	UserID      uuid.UUID
+	CompletedAt time.Time
+	ExpiresAt   *time.Time
+	Certificate string
+	Note        string
+	CreatedAt   time.Time
+}
+
+// Current reports whether the completion still counts at the given time.
+func (c *Completion) Current(at time.Time) bool {
+	return !c.CompletedAt.After(at) && (c.ExpiresAt == nil || c.ExpiresAt.After(at))
+}
+
+type CompletionFilter struct {
+	UserID   *uuid.UUID
+	CourseID *uuid.UUID
+}
+
+// Requirement is where one user stands on one required course. DueAt is when
+// the course must next be completed; it is nil once completed for good.
+type Requirement struct {
+	CourseID    uuid.UUID
+	CourseName  string
+	Status      string
+	DueAt       *time.Time
+	CompletedAt *time.Time
+}
+
+// UserCompliance lists a user's required courses that are overdue or coming
+// due.
+type UserCompliance struct {
+	UserID       uuid.UUID
+	FirstName    string
+	LastName     string
+	Role         string
+	Requirements []Requirement
+}
+
+type ITrainingRepository interface {
+	CreateCourse(newCourse *Course) (*Course, error)
+	GetCourseByID(id uuid.UUID) (*Course, error)
+	GetCourses() (*[]Course, error)
+	GetActiveCourses() (*[]Course, error)
+	UpdateCourse(id uuid.UUID, updates map[string]interface{}) (*Course, error)
+	DeleteCourse(id uuid.UUID) error
+	CreateCompletion(newCompletion *Completion) (*Completion, error)
+	GetCompletionByID(id uuid.UUID) (*Completion, error)
+	// GetCompletions returns matching completions, most recent first.
+	GetCompletions(filter CompletionFilter) (*[]Completion, error)
+	DeleteCompletion(id uuid.UUID) error
+}
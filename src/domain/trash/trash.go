@@ -0,0 +1,48 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind names a soft-deleted table shown in the trash, and is also the
+// :type path segment of the admin endpoints.
+type Kind string
+
+const (
+	KindUser     Kind = "users"
+	KindSchedule Kind = "schedules"
+)
+
+// Kinds lists everything the trash shows.
+var Kinds = []Kind{KindUser, KindSchedule}
+
+func (k Kind) IsValid() bool {
+	for _, kind := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Item is a soft-deleted record. Label is a human-readable name for it, such
+// as a user's full name or a visit's service and time. DeletedByUserID is
+// nil when the delete request did not say who made it.
+type Item struct {
+	Kind            Kind
+	ID              uuid.UUID
+	Label           string
+	DeletedAt       time.Time
+	DeletedByUserID *uuid.UUID
+}
+
+type ITrashRepository interface {
+	// List returns records of the kind, or of every kind when empty, deleted
+	// at or after since, most recently deleted first.
+	List(kind Kind, since time.Time) (*[]Item, error)
+	// Get returns a deleted record, or a NotFound error when there is no such
+	// record or it is not deleted.
+	Get(kind Kind, id uuid.UUID) (*Item, error)
+}
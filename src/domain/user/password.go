@@ -0,0 +1,31 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordToken lets a user choose a password without knowing the current
+// one: their first password, or a new one after an admin reset. It can be
+// used once, until ExpiresAt. Only a hash of the token is stored.
+type PasswordToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type IPasswordRepository interface {
+	// SetPassword replaces a user's password hash. An empty hash leaves the
+	// user unable to log in until they set a password with a token.
+	SetPassword(userID uuid.UUID, hash string) error
+	// CreatePasswordToken stores a token, discarding the user's unused ones.
+	CreatePasswordToken(token *PasswordToken) (*PasswordToken, error)
+	// SetPasswordWithToken uses up an unexpired token and sets its user's
+	// password hash in one transaction, returning the user's ID. The token
+	// is NotFound when unknown, used or expired.
+	SetPasswordWithToken(tokenHash, passwordHash string, now time.Time) (uuid.UUID, error)
+}
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"math"
+	"strings"
 	"time"
 
 	"caregiver/src/domain"
@@ -8,19 +10,34 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	RoleAdmin     = "admin"
+	RoleCaregiver = "caregiver"
+	RoleClient    = "client"
+	// RoleFamily is a client's relative following their care through the
+	// family portal.
+	RoleFamily = "family"
+)
+
 type User struct {
 	ID             uuid.UUID `gorm:"primaryKey"`
 	UserName       string    `gorm:"column:user_name;unique"`
 	Email          string    `gorm:"unique"`
+	Phone          string    `gorm:"column:phone"`
 	FirstName      string    `gorm:"column:first_name"`
 	LastName       string    `gorm:"column:last_name"`
 	Status         bool      `gorm:"column:status"`
 	HashPassword   string    `gorm:"column:hash_password"`
 	Role           string    `gorm:"column:role"`
 	ProfilePicture string    `gorm:"column:profile_picture"`
-	Location       Location  `gorm:"embedded;embeddedPrefix:location_"`
-	CreatedAt      time.Time `gorm:"autoCreateTime:milli"`
-	UpdatedAt      time.Time `gorm:"autoUpdateTime:milli"`
+	HourlyRate     *float64  `gorm:"column:hourly_rate"`
+	// ReferralSourceID attributes a client to where they were referred from.
+	ReferralSourceID *uuid.UUID `gorm:"column:referral_source_id;type:uuid"`
+	Location         Location   `gorm:"embedded;embeddedPrefix:location_"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime:milli"`
+	// DeletedAt is set while the user is soft-deleted.
+	DeletedAt *time.Time
 }
 
 type Location struct {
@@ -33,6 +50,87 @@ type Location struct {
 	Long        float64 `json:"long"`
 }
 
+const earthRadiusKm = 6371.0
+
+// HasCoordinates reports whether the location has been geocoded.
+func (l Location) HasCoordinates() bool {
+	return l.Lat != 0 || l.Long != 0
+}
+
+// Address is the location on one line, e.g. "12 Main St, Springfield, IL
+// 62701". Empty parts are left out.
+func (l Location) Address() string {
+	var parts []string
+	for _, part := range []string{
+		strings.TrimSpace(l.HouseNumber + " " + l.Street),
+		l.City,
+		strings.TrimSpace(l.State + " " + l.Pincode),
+	} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DistanceKm is the great-circle distance between two locations.
+func (l Location) DistanceKm(other Location) float64 {
+	lat1, lat2 := l.Lat*math.Pi/180, other.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLong := (other.Long - l.Long) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+const (
+	EventCreated = "user.created"
+	EventUpdated = "user.updated"
+	EventDeleted = "user.deleted"
+)
+
+// Event describes a change to a user. For deletions only the user's ID is
+// set.
+type Event struct {
+	Type string
+	User *User
+}
+
+// Match ranks, best first.
+const (
+	MatchExact    = 3
+	MatchPrefix   = 2
+	MatchContains = 1
+)
+
+// PropertySearch looks for users whose Properties, named as in the API
+// (e.g. "FirstName", "City"), contain Text.
+type PropertySearch struct {
+	Properties []string
+	Text       string
+	Page       int
+	PageSize   int
+	// ViewerRole decides which properties may be searched and how many
+	// matches can be paged through.
+	ViewerRole string
+}
+
+// PropertyMatch is one user property containing the search text. Matches
+// come ranked exact first, then by prefix, then anywhere in the value.
+type PropertyMatch struct {
+	UserID   uuid.UUID
+	Property string
+	Value    string
+	Rank     int
+}
+
+type SearchResultProperty struct {
+	Data       *[]PropertyMatch
+	Total      int64
+	Page       int
+	PageSize   int
+	TotalPages int
+}
+
 type SearchResultUser struct {
 	Data       *[]User
 	Total      int64
@@ -43,12 +141,17 @@ type SearchResultUser struct {
 
 type IUserService interface {
 	GetAll() (*[]User, error)
+	Stream(fn func(*User) error) error
 	GetByID(id uuid.UUID) (*User, error)
 	Create(newUser *User) (*User, error)
 	Delete(id uuid.UUID) error
+	DeleteBy(id uuid.UUID, deletedBy *uuid.UUID) error
+	Restore(id uuid.UUID) (*User, error)
 	Update(id uuid.UUID, userMap map[string]interface{}) (*User, error)
 	SearchPaginated(filters domain.DataFilters) (*SearchResultUser, error)
-	SearchByProperty(property string, searchText string) (*[]string, error)
+	SearchByProperty(search PropertySearch) (*SearchResultProperty, error)
+	GetVersions(id uuid.UUID) (*[]Version, error)
+	GetVersionAt(id uuid.UUID, at time.Time) (*Version, error)
 }
 
 type IUserRepository interface {
@@ -59,5 +162,5 @@ type IUserRepository interface {
 	Update(id uuid.UUID, userMap map[string]interface{}) (*User, error)
 	Delete(id uuid.UUID) error
 	SearchPaginated(filters domain.DataFilters) (*SearchResultUser, error)
-	SearchByProperty(property string, searchText string) (*[]string, error)
+	SearchByProperty(search PropertySearch) (*SearchResultProperty, error)
 }
@@ -0,0 +1,72 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version is a snapshot of the profile fields that reports depend on. It is
+// in effect from EffectiveFrom until EffectiveTo; the current version has no
+// EffectiveTo.
+type Version struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	Version       int
+	FirstName     string
+	LastName      string
+	Role          string
+	Status        bool
+	HourlyRate    *float64
+	Location      Location
+	ChangedFields []string
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+	CreatedAt     time.Time
+}
+
+// Snapshot captures the user's versioned fields.
+func (u *User) Snapshot() Version {
+	return Version{
+		UserID:     u.ID,
+		FirstName:  u.FirstName,
+		LastName:   u.LastName,
+		Role:       u.Role,
+		Status:     u.Status,
+		HourlyRate: u.HourlyRate,
+		Location:   u.Location,
+	}
+}
+
+// ChangedSince lists the versioned fields that differ from previous.
+func (v *Version) ChangedSince(previous *Version) []string {
+	var changed []string
+	if v.FirstName != previous.FirstName {
+		changed = append(changed, "FirstName")
+	}
+	if v.LastName != previous.LastName {
+		changed = append(changed, "LastName")
+	}
+	if v.Role != previous.Role {
+		changed = append(changed, "Role")
+	}
+	if v.Status != previous.Status {
+		changed = append(changed, "Status")
+	}
+	if (v.HourlyRate == nil) != (previous.HourlyRate == nil) || (v.HourlyRate != nil && *v.HourlyRate != *previous.HourlyRate) {
+		changed = append(changed, "HourlyRate")
+	}
+	if v.Location != previous.Location {
+		changed = append(changed, "Location")
+	}
+	return changed
+}
+
+type IUserVersionRepository interface {
+	// Append stores a new current version, closing the previous one at the
+	// new version's EffectiveFrom.
+	Append(version *Version) (*Version, error)
+	GetByUserID(userID uuid.UUID) (*[]Version, error)
+	// GetAt returns the version in effect at the given time.
+	GetAt(userID uuid.UUID, at time.Time) (*Version, error)
+}
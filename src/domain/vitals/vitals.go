@@ -0,0 +1,141 @@
+package vitals
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MeasureSystolic  = "systolic"
+	MeasureDiastolic = "diastolic"
+	MeasurePulse     = "pulse"
+	MeasureGlucose   = "glucose"
+	MeasureWeight    = "weight"
+)
+
+// Measures lists every vital sign in the order they are reported.
+var Measures = []string{MeasureSystolic, MeasureDiastolic, MeasurePulse, MeasureGlucose, MeasureWeight}
+
+// Units are the units each measure is recorded in.
+var Units = map[string]string{
+	MeasureSystolic:  "mmHg",
+	MeasureDiastolic: "mmHg",
+	MeasurePulse:     "bpm",
+	MeasureGlucose:   "mg/dL",
+	MeasureWeight:    "kg",
+}
+
+// Range is the normal range for one measure. Either bound may be open. A
+// client's own ranges replace the defaults measure by measure.
+type Range struct {
+	ID           uuid.UUID
+	ClientUserID uuid.UUID
+	Measure      string
+	Min          *float64
+	Max          *float64
+	Default      bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Contains reports whether value lies within the range.
+func (r *Range) Contains(value float64) bool {
+	return (r.Min == nil || value >= *r.Min) && (r.Max == nil || value <= *r.Max)
+}
+
+func bound(v float64) *float64 { return &v }
+
+// DefaultRanges are the adult normal ranges used for measures a client has
+// no range of their own for. Weight has none; it only alerts once a range is
+// set for the client.
+func DefaultRanges() []Range {
+	return []Range{
+		{Measure: MeasureSystolic, Min: bound(90), Max: bound(140), Default: true},
+		{Measure: MeasureDiastolic, Min: bound(60), Max: bound(90), Default: true},
+		{Measure: MeasurePulse, Min: bound(50), Max: bound(100), Default: true},
+		{Measure: MeasureGlucose, Min: bound(70), Max: bound(180), Default: true},
+	}
+}
+
+// Breach is a recorded value outside the client's normal range.
+type Breach struct {
+	Measure string
+	Value   float64
+	Min     *float64
+	Max     *float64
+}
+
+// Reading is the set of vitals taken during a visit. Any measure may be left
+// out. Breaches holds the values found out of range when it was recorded.
+type Reading struct {
+	ID               uuid.UUID
+	ScheduleID       uuid.UUID
+	ClientUserID     uuid.UUID
+	RecordedByUserID uuid.UUID
+	Systolic         *float64
+	Diastolic        *float64
+	Pulse            *float64
+	Glucose          *float64
+	Weight           *float64
+	Note             string
+	Breaches         []Breach
+	RecordedAt       time.Time
+	CreatedAt        time.Time
+}
+
+// Values returns the measures present on the reading.
+func (r *Reading) Values() map[string]float64 {
+	values := make(map[string]float64)
+	for measure, value := range map[string]*float64{
+		MeasureSystolic:  r.Systolic,
+		MeasureDiastolic: r.Diastolic,
+		MeasurePulse:     r.Pulse,
+		MeasureGlucose:   r.Glucose,
+		MeasureWeight:    r.Weight,
+	} {
+		if value != nil {
+			values[measure] = *value
+		}
+	}
+	return values
+}
+
+type ReadingFilter struct {
+	ClientUserID *uuid.UUID
+	ScheduleID   *uuid.UUID
+	From         *time.Time
+	To           *time.Time
+}
+
+// Point is one value of a measure over time.
+type Point struct {
+	At         time.Time
+	Value      float64
+	OutOfRange bool
+}
+
+// Series is a client's values for one measure over a period, together with
+// the range they were judged against.
+type Series struct {
+	Measure    string
+	Unit       string
+	Range      *Range
+	Points     []Point
+	Latest     *float64
+	Min        *float64
+	Max        *float64
+	Average    *float64
+	OutOfRange int
+}
+
+type IVitalsRepository interface {
+	// SaveRange creates or replaces the client's range for the measure.
+	SaveRange(newRange *Range) (*Range, error)
+	GetRanges(clientUserID uuid.UUID) (*[]Range, error)
+	DeleteRange(id uuid.UUID) error
+	CreateReading(newReading *Reading) (*Reading, error)
+	// GetReadings returns matching readings in the order they were taken.
+	GetReadings(filter ReadingFilter) (*[]Reading, error)
+	DeleteReading(id uuid.UUID) error
+}
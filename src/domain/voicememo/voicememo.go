@@ -0,0 +1,36 @@
+package voicememo
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusPending     = "pending"
+	StatusTranscribed = "transcribed"
+	StatusFailed      = "failed"
+)
+
+// Memo is a short audio note recorded by the caregiver at checkout.
+type Memo struct {
+	ID               uuid.UUID
+	ScheduleID       uuid.UUID
+	UploadedByUserID uuid.UUID
+	ContentType      string
+	Size             int64
+	StorageKey       string
+	Status           string
+	Transcript       *string
+	FailureReason    *string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type IVoiceMemoRepository interface {
+	Create(newMemo *Memo) (*Memo, error)
+	GetByID(id uuid.UUID) (*Memo, error)
+	GetBySchedule(scheduleID uuid.UUID) (*[]Memo, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Memo, error)
+	SearchTranscripts(query string, limit int) (*[]Memo, error)
+}
@@ -0,0 +1,40 @@
+package waitlist
+
+import (
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusWaiting   = "waiting"
+	StatusMatched   = "matched"
+	StatusFulfilled = "fulfilled"
+	StatusWithdrawn = "withdrawn"
+)
+
+// Entry is a visit request that could not be staffed when it was made. It is
+// re-checked whenever caregiver time frees up.
+type Entry struct {
+	ID            uuid.UUID
+	ClientUserID  uuid.UUID
+	ServiceName   string
+	RequestedFrom time.Time
+	RequestedTo   time.Time
+	Notes         string
+	Status        string
+	Candidates    []domainSchedule.CaregiverSuggestion
+	MatchedAt     *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type IWaitlistRepository interface {
+	Create(newEntry *Entry) (*Entry, error)
+	GetByID(id uuid.UUID) (*Entry, error)
+	GetAll(status string) (*[]Entry, error)
+	GetWaitingBetween(from, to time.Time) (*[]Entry, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*Entry, error)
+}
@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventVisitCreated    = "visit.created"
+	EventVisitUpdated    = "visit.updated"
+	EventVisitCheckedIn  = "visit.checked_in"
+	EventVisitCheckedOut = "visit.checked_out"
+	EventVisitCancelled  = "visit.cancelled"
+	EventVisitMissed     = "visit.missed"
+	EventVisitDeleted    = "visit.deleted"
+	// EventPing is sent on request to test a subscription's endpoint; it is
+	// delivered whatever event types the subscription has.
+	EventPing = "ping"
+	// EventDigest carries a batch of held events to a subscription that
+	// receives digests.
+	EventDigest = "digest"
+)
+
+// EventTypes are the events a subscription can ask for.
+var EventTypes = []string{
+	EventVisitCreated,
+	EventVisitUpdated,
+	EventVisitCheckedIn,
+	EventVisitCheckedOut,
+	EventVisitCancelled,
+	EventVisitMissed,
+	EventVisitDeleted,
+}
+
+// IsEventType reports whether t is one of EventTypes.
+func IsEventType(t string) bool {
+	for _, eventType := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// StatusQueued deliveries are waiting for the worker, either for their
+	// first attempt or for a retry.
+	StatusQueued    = "queued"
+	StatusDelivered = "delivered"
+	// StatusFailed means retries ran out; the delivery can be redelivered by
+	// hand.
+	StatusFailed = "failed"
+	// StatusHeld events wait for their subscription's next digest.
+	StatusHeld = "held"
+	// StatusBatched events went out in the digest named by DigestID.
+	StatusBatched = "batched"
+)
+
+const (
+	// MinDigestMinutes is the shortest digest interval.
+	MinDigestMinutes = 5
+	// MaxDigestMinutes is the longest digest interval, one a day.
+	MaxDigestMinutes = 24 * 60
+)
+
+// IsDigestInterval reports whether minutes is a valid digest interval: it
+// must divide a day so digests fall at the same clock times every day.
+func IsDigestInterval(minutes int) bool {
+	return minutes >= MinDigestMinutes && minutes <= MaxDigestMinutes && MaxDigestMinutes%minutes == 0
+}
+
+// Subscription is a third-party endpoint that receives the events it asked
+// for. Payloads are signed with Secret so the receiver can check they came
+// from us. With DigestMinutes set, events are batched into one digest per
+// interval instead of being sent as they happen.
+type Subscription struct {
+	ID            uuid.UUID
+	Name          string
+	URL           string
+	Secret        string
+	EventTypes    []string
+	Active        bool
+	DigestMinutes int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Wants reports whether the subscription receives events of the type.
+func (s *Subscription) Wants(eventType string) bool {
+	if eventType == EventPing {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Batched reports whether the subscription receives digests.
+func (s *Subscription) Batched() bool {
+	return s.DigestMinutes > 0
+}
+
+// DigestDue reports whether the digest holding an event from heldSince is
+// due. Digests close at multiples of the interval from local midnight in loc,
+// and always at the next midnight, so a daily digest stays daily on days the
+// clocks change. Held events of a subscription that went back to realtime
+// are due at once.
+func (s *Subscription) DigestDue(heldSince time.Time, now time.Time, loc *time.Location) bool {
+	if !s.Batched() {
+		return true
+	}
+	interval := time.Duration(s.DigestMinutes) * time.Minute
+	local := heldSince.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	closes := midnight.Add((heldSince.Sub(midnight)/interval + 1) * interval)
+	if next := midnight.AddDate(0, 0, 1); closes.After(next) {
+		closes = next
+	}
+	return !now.Before(closes)
+}
+
+// Delivery is one event on its way to one subscription. Payload is the JSON
+// body, fixed when the event happened so retries send the same thing. A
+// batched event points at the digest delivery that carried it.
+type Delivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        string
+	Status         string
+	Attempts       int
+	ResponseStatus int
+	LastError      string
+	NextAttemptAt  *time.Time
+	LastAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+	DigestID       *uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type DeliveryFilter struct {
+	SubscriptionID *uuid.UUID
+	Status         string
+	EventType      string
+	Limit          int
+}
+
+type IWebhookRepository interface {
+	CreateSubscription(subscription *Subscription) (*Subscription, error)
+	GetSubscriptions() (*[]Subscription, error)
+	GetSubscriptionByID(id uuid.UUID) (*Subscription, error)
+	GetActiveSubscriptions() (*[]Subscription, error)
+	UpdateSubscription(id uuid.UUID, updates map[string]interface{}) (*Subscription, error)
+	DeleteSubscription(id uuid.UUID) error
+	CreateDeliveries(deliveries []Delivery) error
+	GetDeliveryByID(id uuid.UUID) (*Delivery, error)
+	// GetDeliveries returns the matching deliveries, newest first.
+	GetDeliveries(filter DeliveryFilter) (*[]Delivery, error)
+	// GetDueDeliveries returns queued deliveries whose next attempt is due,
+	// oldest first.
+	GetDueDeliveries(now time.Time, limit int) (*[]Delivery, error)
+	UpdateDelivery(id uuid.UUID, updates map[string]interface{}) (*Delivery, error)
+	// GetHeldSince returns, per subscription with held events, when its
+	// oldest held event was queued.
+	GetHeldSince() (map[uuid.UUID]time.Time, error)
+	// GetHeldDeliveries returns a subscription's held events, oldest first.
+	GetHeldDeliveries(subscriptionID uuid.UUID, limit int) (*[]Delivery, error)
+	// CreateDigest queues the digest and marks the events it carries batched,
+	// in one transaction.
+	CreateDigest(digest *Delivery, batchedIDs []uuid.UUID) (*Delivery, error)
+}
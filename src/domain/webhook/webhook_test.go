@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDigestDueFollowsLocalMidnight(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	daily := &Subscription{DigestMinutes: 1440}
+	hourly := &Subscription{DigestMinutes: 60}
+	// Clocks in New York go forward at 2am on 8 March 2026 (07:00 UTC).
+	held := time.Date(2026, 3, 8, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		subscription *Subscription
+		now          time.Time
+		loc          *time.Location
+		due          bool
+	}{
+		{"Daily digest waits for local midnight", daily, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), newYork, false},
+		{"Daily digest closes at local midnight after a 23 hour day", daily, time.Date(2026, 3, 9, 4, 0, 0, 0, time.UTC), newYork, true},
+		{"UTC keeps UTC midnight", daily, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), time.UTC, true},
+		{"Hourly digest closes on the hour", hourly, time.Date(2026, 3, 8, 11, 0, 0, 0, time.UTC), newYork, true},
+		{"Realtime subscription is due at once", &Subscription{}, held, newYork, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if due := tt.subscription.DigestDue(held, tt.now, tt.loc); due != tt.due {
+				t.Errorf("expected due %v, got %v", tt.due, due)
+			}
+		})
+	}
+}
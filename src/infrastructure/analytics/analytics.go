@@ -0,0 +1,169 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	logger "caregiver/src/infrastructure/logger"
+
+	"go.uber.org/zap"
+)
+
+// ErrNotConfigured is returned when no analytics provider is set up.
+var ErrNotConfigured = errors.New("analytics not configured")
+
+// ErrDropped is returned when an event is discarded because the queue is full.
+var ErrDropped = errors.New("analytics event dropped")
+
+const (
+	defaultSegmentURL = "https://api.segment.io/v1/track"
+	defaultPostHogURL = "https://app.posthog.com"
+)
+
+// Event is one product-analytics event. DistinctID identifies the caller
+// without revealing who they are.
+type Event struct {
+	Name       string
+	DistinctID string
+	Properties map[string]interface{}
+	Timestamp  time.Time
+}
+
+// ISink delivers events to a product-analytics provider.
+type ISink interface {
+	Track(ctx context.Context, event Event) error
+}
+
+// NewSinkFromEnv picks the provider named by ANALYTICS_PROVIDER: "segment"
+// with SEGMENT_WRITE_KEY, or "posthog" with POSTHOG_API_KEY and optionally
+// POSTHOG_HOST. Otherwise events are discarded with ErrNotConfigured.
+func NewSinkFromEnv() ISink {
+	client := &http.Client{Timeout: 10 * time.Second}
+	switch strings.ToLower(os.Getenv("ANALYTICS_PROVIDER")) {
+	case "segment":
+		if key := os.Getenv("SEGMENT_WRITE_KEY"); key != "" {
+			return &SegmentSink{URL: defaultSegmentURL, WriteKey: key, Client: client}
+		}
+	case "posthog":
+		if key := os.Getenv("POSTHOG_API_KEY"); key != "" {
+			host := os.Getenv("POSTHOG_HOST")
+			if host == "" {
+				host = defaultPostHogURL
+			}
+			return &PostHogSink{URL: strings.TrimRight(host, "/") + "/capture/", APIKey: key, Client: client}
+		}
+	}
+	return disabledSink{}
+}
+
+type disabledSink struct{}
+
+func (disabledSink) Track(ctx context.Context, event Event) error {
+	return ErrNotConfigured
+}
+
+// SegmentSink sends events to Segment's HTTP tracking API.
+type SegmentSink struct {
+	URL      string
+	WriteKey string
+	Client   *http.Client
+}
+
+func (s *SegmentSink) Track(ctx context.Context, event Event) error {
+	body := map[string]interface{}{
+		"event":       event.Name,
+		"anonymousId": event.DistinctID,
+		"properties":  event.Properties,
+		"timestamp":   event.Timestamp.UTC().Format(time.RFC3339Nano),
+	}
+	return post(ctx, s.Client, s.URL, body, func(req *http.Request) {
+		req.SetBasicAuth(s.WriteKey, "")
+	})
+}
+
+// PostHogSink sends events to PostHog's capture endpoint.
+type PostHogSink struct {
+	URL    string
+	APIKey string
+	Client *http.Client
+}
+
+func (s *PostHogSink) Track(ctx context.Context, event Event) error {
+	body := map[string]interface{}{
+		"api_key":     s.APIKey,
+		"event":       event.Name,
+		"distinct_id": event.DistinctID,
+		"properties":  event.Properties,
+		"timestamp":   event.Timestamp.UTC().Format(time.RFC3339Nano),
+	}
+	return post(ctx, s.Client, s.URL, body, nil)
+}
+
+func post(ctx context.Context, client *http.Client, url string, body interface{}, prepare func(*http.Request)) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if prepare != nil {
+		prepare(req)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("analytics provider returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Queue hands events to a sink from a background goroutine so tracking never
+// slows a request down. Events are dropped while the buffer is full.
+type Queue struct {
+	sink   ISink
+	events chan Event
+	Logger *logger.Logger
+}
+
+// NewQueue starts delivering to sink with room for size waiting events. An
+// unconfigured sink is not started at all.
+func NewQueue(sink ISink, size int, loggerInstance *logger.Logger) *Queue {
+	q := &Queue{sink: sink, events: make(chan Event, size), Logger: loggerInstance}
+	if _, disabled := sink.(disabledSink); !disabled {
+		go q.run()
+	}
+	return q
+}
+
+func (q *Queue) Track(ctx context.Context, event Event) error {
+	if _, disabled := q.sink.(disabledSink); disabled {
+		return ErrNotConfigured
+	}
+	select {
+	case q.events <- event:
+		return nil
+	default:
+		return ErrDropped
+	}
+}
+
+func (q *Queue) run() {
+	for event := range q.events {
+		if err := q.sink.Track(context.Background(), event); err != nil {
+			q.Logger.Warn("Error sending analytics event", zap.Error(err), zap.String("event", event.Name))
+		}
+	}
+}
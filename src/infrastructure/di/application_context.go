@@ -1,37 +1,476 @@
 package di
 
 import (
+	"os"
+	"strings"
 	"sync"
 
+	accessUseCase "caregiver/src/application/usecases/access"
+	alertUseCase "caregiver/src/application/usecases/alert"
+	announcementUseCase "caregiver/src/application/usecases/announcement"
+	appVersionUseCase "caregiver/src/application/usecases/appversion"
+	attachmentUseCase "caregiver/src/application/usecases/attachment"
+	attestationUseCase "caregiver/src/application/usecases/attestation"
 	authUseCase "caregiver/src/application/usecases/auth"
+	availabilityUseCase "caregiver/src/application/usecases/availability"
+	brandingUseCase "caregiver/src/application/usecases/branding"
+	budgetUseCase "caregiver/src/application/usecases/budget"
+	carePlanUseCase "caregiver/src/application/usecases/careplan"
+	claimUseCase "caregiver/src/application/usecases/claim"
+	clientErrorUseCase "caregiver/src/application/usecases/clienterror"
+	clientMergeUseCase "caregiver/src/application/usecases/clientmerge"
+	complianceUseCase "caregiver/src/application/usecases/compliance"
+	confirmationUseCase "caregiver/src/application/usecases/confirmation"
+	consentUseCase "caregiver/src/application/usecases/consent"
+	deactivationUseCase "caregiver/src/application/usecases/deactivation"
+	deprecationUseCase "caregiver/src/application/usecases/deprecation"
+	differentialUseCase "caregiver/src/application/usecases/differential"
+	equipmentUseCase "caregiver/src/application/usecases/equipment"
+	evvUseCase "caregiver/src/application/usecases/evv"
+	fatigueUseCase "caregiver/src/application/usecases/fatigue"
+	formUseCase "caregiver/src/application/usecases/form"
+	handoffUseCase "caregiver/src/application/usecases/handoff"
+	identityUseCase "caregiver/src/application/usecases/identity"
+	integrityUseCase "caregiver/src/application/usecases/integrity"
+	interruptionUseCase "caregiver/src/application/usecases/interruption"
+	invoiceUseCase "caregiver/src/application/usecases/invoice"
+	kioskUseCase "caregiver/src/application/usecases/kiosk"
+	leaveUseCase "caregiver/src/application/usecases/leave"
+	legalHoldUseCase "caregiver/src/application/usecases/legalhold"
+	localeUseCase "caregiver/src/application/usecases/locale"
+	medicationUseCase "caregiver/src/application/usecases/medication"
+	nfcTagUseCase "caregiver/src/application/usecases/nfctag"
+	notificationUseCase "caregiver/src/application/usecases/notification"
+	payRateUseCase "caregiver/src/application/usecases/payrate"
+	payrollUseCase "caregiver/src/application/usecases/payroll"
+	plannerUseCase "caregiver/src/application/usecases/planner"
+	profileChangeUseCase "caregiver/src/application/usecases/profilechange"
+	prospectUseCase "caregiver/src/application/usecases/prospect"
+	quotaUseCase "caregiver/src/application/usecases/quota"
+	referenceUseCase "caregiver/src/application/usecases/reference"
+	referralUseCase "caregiver/src/application/usecases/referral"
+	reminderUseCase "caregiver/src/application/usecases/reminder"
+	reportUseCase "caregiver/src/application/usecases/report"
+	retentionUseCase "caregiver/src/application/usecases/retention"
 	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	scheduleViewUseCase "caregiver/src/application/usecases/scheduleview"
+	screeningUseCase "caregiver/src/application/usecases/screening"
+	searchUseCase "caregiver/src/application/usecases/search"
+	serviceAreaUseCase "caregiver/src/application/usecases/servicearea"
+	serviceNoteUseCase "caregiver/src/application/usecases/servicenote"
+	signatureUseCase "caregiver/src/application/usecases/signature"
+	statementUseCase "caregiver/src/application/usecases/statement"
+	suggestionUseCase "caregiver/src/application/usecases/suggestion"
+	supplyUseCase "caregiver/src/application/usecases/supply"
+	swapUseCase "caregiver/src/application/usecases/swap"
+	teamUseCase "caregiver/src/application/usecases/team"
+	timesheetUseCase "caregiver/src/application/usecases/timesheet"
+	trainingUseCase "caregiver/src/application/usecases/training"
+	trashUseCase "caregiver/src/application/usecases/trash"
 	userUseCase "caregiver/src/application/usecases/user"
+	vitalsUseCase "caregiver/src/application/usecases/vitals"
+	voiceMemoUseCase "caregiver/src/application/usecases/voicememo"
+	waitlistUseCase "caregiver/src/application/usecases/waitlist"
+	webhookUseCase "caregiver/src/application/usecases/webhook"
+	domainAlert "caregiver/src/domain/alert"
+	domainAnnouncement "caregiver/src/domain/announcement"
+	domainAppVersion "caregiver/src/domain/appversion"
+	domainAttachment "caregiver/src/domain/attachment"
+	domainAttestation "caregiver/src/domain/attestation"
+	domainBranding "caregiver/src/domain/branding"
+	domainBudget "caregiver/src/domain/budget"
+	domainCarePlan "caregiver/src/domain/careplan"
+	domainClaim "caregiver/src/domain/claim"
+	domainClientError "caregiver/src/domain/clienterror"
+	domainClientMerge "caregiver/src/domain/clientmerge"
+	domainCompliance "caregiver/src/domain/compliance"
+	domainConfirmation "caregiver/src/domain/confirmation"
+	domainConsent "caregiver/src/domain/consent"
+	domainDeactivation "caregiver/src/domain/deactivation"
+	domainDeprecation "caregiver/src/domain/deprecation"
+	domainDifferential "caregiver/src/domain/differential"
+	domainEquipment "caregiver/src/domain/equipment"
+	domainEVV "caregiver/src/domain/evv"
+	domainFatigue "caregiver/src/domain/fatigue"
+	domainForm "caregiver/src/domain/form"
+	domainHandoff "caregiver/src/domain/handoff"
+	domainIdentity "caregiver/src/domain/identity"
+	domainInterruption "caregiver/src/domain/interruption"
+	domainInvoice "caregiver/src/domain/invoice"
+	domainKiosk "caregiver/src/domain/kiosk"
+	domainLeave "caregiver/src/domain/leave"
+	domainLegalHold "caregiver/src/domain/legalhold"
+	domainLocale "caregiver/src/domain/locale"
+	domainMedication "caregiver/src/domain/medication"
+	domainNFCTag "caregiver/src/domain/nfctag"
+	domainNotification "caregiver/src/domain/notification"
+	domainPayRate "caregiver/src/domain/payrate"
+	domainPayroll "caregiver/src/domain/payroll"
+	domainPlanner "caregiver/src/domain/planner"
+	domainProfileChange "caregiver/src/domain/profilechange"
+	domainProspect "caregiver/src/domain/prospect"
+	domainQuota "caregiver/src/domain/quota"
+	domainReference "caregiver/src/domain/reference"
+	domainReferral "caregiver/src/domain/referral"
+	domainReminder "caregiver/src/domain/reminder"
+	domainReport "caregiver/src/domain/report"
 	domainSchedule "caregiver/src/domain/schedule"
+	domainScheduleView "caregiver/src/domain/scheduleview"
+	domainScreening "caregiver/src/domain/screening"
+	domainServiceArea "caregiver/src/domain/servicearea"
+	domainServiceNote "caregiver/src/domain/servicenote"
+	domainSignature "caregiver/src/domain/signature"
+	domainStatement "caregiver/src/domain/statement"
+	domainSupply "caregiver/src/domain/supply"
+	domainSwap "caregiver/src/domain/swap"
+	domainTeam "caregiver/src/domain/team"
+	domainTimesheet "caregiver/src/domain/timesheet"
+	domainTraining "caregiver/src/domain/training"
+	domainTrash "caregiver/src/domain/trash"
+	domainVitals "caregiver/src/domain/vitals"
+	domainVoiceMemo "caregiver/src/domain/voicememo"
+	domainWaitlist "caregiver/src/domain/waitlist"
+	domainWebhook "caregiver/src/domain/webhook"
+	alertRepo "caregiver/src/infrastructure/repository/psql/alert"
+	announcementRepo "caregiver/src/infrastructure/repository/psql/announcement"
+	appVersionRepo "caregiver/src/infrastructure/repository/psql/appversion"
+	attachmentRepo "caregiver/src/infrastructure/repository/psql/attachment"
+	attestationRepo "caregiver/src/infrastructure/repository/psql/attestation"
+	brandingRepo "caregiver/src/infrastructure/repository/psql/branding"
+	budgetRepo "caregiver/src/infrastructure/repository/psql/budget"
+	carePlanRepo "caregiver/src/infrastructure/repository/psql/careplan"
+	claimRepo "caregiver/src/infrastructure/repository/psql/claim"
+	clientErrorRepo "caregiver/src/infrastructure/repository/psql/clienterror"
+	clientMergeRepo "caregiver/src/infrastructure/repository/psql/clientmerge"
+	complianceRepo "caregiver/src/infrastructure/repository/psql/compliance"
+	confirmationRepo "caregiver/src/infrastructure/repository/psql/confirmation"
+	consentRepo "caregiver/src/infrastructure/repository/psql/consent"
+	deactivationRepo "caregiver/src/infrastructure/repository/psql/deactivation"
+	deprecationRepo "caregiver/src/infrastructure/repository/psql/deprecation"
+	differentialRepo "caregiver/src/infrastructure/repository/psql/differential"
+	equipmentRepo "caregiver/src/infrastructure/repository/psql/equipment"
+	evvRepo "caregiver/src/infrastructure/repository/psql/evv"
+	fatigueRepo "caregiver/src/infrastructure/repository/psql/fatigue"
+	formRepo "caregiver/src/infrastructure/repository/psql/form"
+	handoffRepo "caregiver/src/infrastructure/repository/psql/handoff"
+	identityRepo "caregiver/src/infrastructure/repository/psql/identity"
+	interruptionRepo "caregiver/src/infrastructure/repository/psql/interruption"
+	invoiceRepo "caregiver/src/infrastructure/repository/psql/invoice"
+	kioskRepo "caregiver/src/infrastructure/repository/psql/kiosk"
+	leaveRepo "caregiver/src/infrastructure/repository/psql/leave"
+	legalHoldRepo "caregiver/src/infrastructure/repository/psql/legalhold"
+	localeRepo "caregiver/src/infrastructure/repository/psql/locale"
+	medicationRepo "caregiver/src/infrastructure/repository/psql/medication"
+	nfcTagRepo "caregiver/src/infrastructure/repository/psql/nfctag"
+	notificationRepo "caregiver/src/infrastructure/repository/psql/notification"
+	payRateRepo "caregiver/src/infrastructure/repository/psql/payrate"
+	payrollRepo "caregiver/src/infrastructure/repository/psql/payroll"
+	plannerRepo "caregiver/src/infrastructure/repository/psql/planner"
+	profileChangeRepo "caregiver/src/infrastructure/repository/psql/profilechange"
+	prospectRepo "caregiver/src/infrastructure/repository/psql/prospect"
+	quotaRepo "caregiver/src/infrastructure/repository/psql/quota"
+	referenceRepo "caregiver/src/infrastructure/repository/psql/reference"
+	referralRepo "caregiver/src/infrastructure/repository/psql/referral"
+	reminderRepo "caregiver/src/infrastructure/repository/psql/reminder"
+	reportRepo "caregiver/src/infrastructure/repository/psql/report"
 	scheduleRepo "caregiver/src/infrastructure/repository/psql/schedule"
+	scheduleViewRepo "caregiver/src/infrastructure/repository/psql/scheduleview"
+	screeningRepo "caregiver/src/infrastructure/repository/psql/screening"
+	serviceAreaRepo "caregiver/src/infrastructure/repository/psql/servicearea"
+	serviceNoteRepo "caregiver/src/infrastructure/repository/psql/servicenote"
+	signatureRepo "caregiver/src/infrastructure/repository/psql/signature"
+	statementRepo "caregiver/src/infrastructure/repository/psql/statement"
+	supplyRepo "caregiver/src/infrastructure/repository/psql/supply"
+	swapRepo "caregiver/src/infrastructure/repository/psql/swap"
+	teamRepo "caregiver/src/infrastructure/repository/psql/team"
+	trainingRepo "caregiver/src/infrastructure/repository/psql/training"
+	trashRepo "caregiver/src/infrastructure/repository/psql/trash"
+	voiceMemoRepo "caregiver/src/infrastructure/repository/psql/voicememo"
+	waitlistRepo "caregiver/src/infrastructure/repository/psql/waitlist"
+	webhookRepo "caregiver/src/infrastructure/repository/psql/webhook"
 
+	"caregiver/src/infrastructure/captcha"
+	evvAdapter "caregiver/src/infrastructure/evv"
+	"caregiver/src/infrastructure/holiday"
+	"caregiver/src/infrastructure/integrity"
 	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/mail"
+	"caregiver/src/infrastructure/medication"
+	"caregiver/src/infrastructure/notification"
+	"caregiver/src/infrastructure/plugins"
 	"caregiver/src/infrastructure/repository/psql"
 	userRepo "caregiver/src/infrastructure/repository/psql/user"
+	vitalsRepo "caregiver/src/infrastructure/repository/psql/vitals"
+	accessController "caregiver/src/infrastructure/rest/controllers/access"
+	alertController "caregiver/src/infrastructure/rest/controllers/alert"
+	announcementController "caregiver/src/infrastructure/rest/controllers/announcement"
+	appVersionController "caregiver/src/infrastructure/rest/controllers/appversion"
+	attachmentController "caregiver/src/infrastructure/rest/controllers/attachment"
+	attestationController "caregiver/src/infrastructure/rest/controllers/attestation"
 	authController "caregiver/src/infrastructure/rest/controllers/auth"
+	availabilityController "caregiver/src/infrastructure/rest/controllers/availability"
+	brandingController "caregiver/src/infrastructure/rest/controllers/branding"
+	budgetController "caregiver/src/infrastructure/rest/controllers/budget"
+	carePlanController "caregiver/src/infrastructure/rest/controllers/careplan"
+	claimController "caregiver/src/infrastructure/rest/controllers/claim"
+	clientErrorController "caregiver/src/infrastructure/rest/controllers/clienterror"
+	clientMergeController "caregiver/src/infrastructure/rest/controllers/clientmerge"
+	complianceController "caregiver/src/infrastructure/rest/controllers/compliance"
+	confirmationController "caregiver/src/infrastructure/rest/controllers/confirmation"
+	consentController "caregiver/src/infrastructure/rest/controllers/consent"
+	deactivationController "caregiver/src/infrastructure/rest/controllers/deactivation"
+	deprecationController "caregiver/src/infrastructure/rest/controllers/deprecation"
+	differentialController "caregiver/src/infrastructure/rest/controllers/differential"
+	equipmentController "caregiver/src/infrastructure/rest/controllers/equipment"
+	evvController "caregiver/src/infrastructure/rest/controllers/evv"
+	fatigueController "caregiver/src/infrastructure/rest/controllers/fatigue"
+	formController "caregiver/src/infrastructure/rest/controllers/form"
+	handoffController "caregiver/src/infrastructure/rest/controllers/handoff"
+	identityController "caregiver/src/infrastructure/rest/controllers/identity"
+	interruptionController "caregiver/src/infrastructure/rest/controllers/interruption"
+	invoiceController "caregiver/src/infrastructure/rest/controllers/invoice"
+	kioskController "caregiver/src/infrastructure/rest/controllers/kiosk"
+	leaveController "caregiver/src/infrastructure/rest/controllers/leave"
+	legalHoldController "caregiver/src/infrastructure/rest/controllers/legalhold"
+	localeController "caregiver/src/infrastructure/rest/controllers/locale"
+	medicationController "caregiver/src/infrastructure/rest/controllers/medication"
+	nfcTagController "caregiver/src/infrastructure/rest/controllers/nfctag"
+	notificationController "caregiver/src/infrastructure/rest/controllers/notification"
+	payRateController "caregiver/src/infrastructure/rest/controllers/payrate"
+	payrollController "caregiver/src/infrastructure/rest/controllers/payroll"
+	plannerController "caregiver/src/infrastructure/rest/controllers/planner"
+	profileChangeController "caregiver/src/infrastructure/rest/controllers/profilechange"
+	prospectController "caregiver/src/infrastructure/rest/controllers/prospect"
+	quotaController "caregiver/src/infrastructure/rest/controllers/quota"
+	referenceController "caregiver/src/infrastructure/rest/controllers/reference"
+	referralController "caregiver/src/infrastructure/rest/controllers/referral"
+	reminderController "caregiver/src/infrastructure/rest/controllers/reminder"
+	reportController "caregiver/src/infrastructure/rest/controllers/report"
+	retentionController "caregiver/src/infrastructure/rest/controllers/retention"
 	scheduleController "caregiver/src/infrastructure/rest/controllers/schedule"
+	scheduleViewController "caregiver/src/infrastructure/rest/controllers/scheduleview"
+	screeningController "caregiver/src/infrastructure/rest/controllers/screening"
+	searchController "caregiver/src/infrastructure/rest/controllers/search"
+	serviceAreaController "caregiver/src/infrastructure/rest/controllers/servicearea"
+	serviceNoteController "caregiver/src/infrastructure/rest/controllers/servicenote"
+	signatureController "caregiver/src/infrastructure/rest/controllers/signature"
+	statementController "caregiver/src/infrastructure/rest/controllers/statement"
+	supplyController "caregiver/src/infrastructure/rest/controllers/supply"
+	swapController "caregiver/src/infrastructure/rest/controllers/swap"
+	teamController "caregiver/src/infrastructure/rest/controllers/team"
+	timesheetController "caregiver/src/infrastructure/rest/controllers/timesheet"
+	trainingController "caregiver/src/infrastructure/rest/controllers/training"
+	trashController "caregiver/src/infrastructure/rest/controllers/trash"
 	userController "caregiver/src/infrastructure/rest/controllers/user"
+	vitalsController "caregiver/src/infrastructure/rest/controllers/vitals"
+	voiceMemoController "caregiver/src/infrastructure/rest/controllers/voicememo"
+	waitlistController "caregiver/src/infrastructure/rest/controllers/waitlist"
+	webhookController "caregiver/src/infrastructure/rest/controllers/webhook"
+	"caregiver/src/infrastructure/screening"
+	"caregiver/src/infrastructure/search"
 	"caregiver/src/infrastructure/security"
+	"caregiver/src/infrastructure/storage"
+	"caregiver/src/infrastructure/transcription"
+	"caregiver/src/infrastructure/webhook"
 
 	"gorm.io/gorm"
 )
 
 type ApplicationContext struct {
-	DB                 *gorm.DB
-	Logger             *logger.Logger
-	AuthController     authController.IAuthController
-	UserController     userController.IUserController
-	ScheduleController scheduleController.IScheduleController
-	JWTService         security.IJWTService
-	UserRepository     userRepo.UserRepositoryInterface
-	ScheduleRepository domainSchedule.IScheduleRepository
-	AuthUseCase        authUseCase.IAuthUseCase
-	UserUseCase        userUseCase.IUserUseCase
-	ScheduleUseCase    scheduleUseCase.IScheduleUseCase
+	DB                      *gorm.DB
+	Logger                  *logger.Logger
+	AuthController          authController.IAuthController
+	UserController          userController.IUserController
+	ScheduleController      scheduleController.IScheduleController
+	BudgetController        budgetController.IBudgetController
+	AttachmentController    attachmentController.IAttachmentController
+	NFCTagController        nfcTagController.INFCTagController
+	VoiceMemoController     voiceMemoController.IVoiceMemoController
+	ComplianceController    complianceController.IComplianceController
+	ConfirmationController  confirmationController.IConfirmationController
+	WaitlistController      waitlistController.IWaitlistController
+	ServiceAreaController   serviceAreaController.IServiceAreaController
+	ScheduleViewController  scheduleViewController.IScheduleViewController
+	TeamController          teamController.ITeamController
+	AlertController         alertController.IAlertController
+	FormController          formController.IFormController
+	SignatureController     signatureController.ISignatureController
+	FatigueController       fatigueController.IFatigueController
+	ReminderController      reminderController.IReminderController
+	KioskController         kioskController.IKioskController
+	AttestationController   attestationController.IAttestationController
+	EVVController           evvController.IEVVController
+	ClaimController         claimController.IClaimController
+	PayRateController       payRateController.IPayRateController
+	LeaveController         leaveController.ILeaveController
+	DifferentialController  differentialController.IDifferentialController
+	StatementController     statementController.IStatementController
+	SupplyController        supplyController.ISupplyController
+	EquipmentController     equipmentController.IEquipmentController
+	ScreeningController     screeningController.IScreeningController
+	SearchController        searchController.ISearchController
+	TrainingController      trainingController.ITrainingController
+	ReferralController      referralController.IReferralController
+	ProspectController      prospectController.IProspectController
+	CarePlanController      carePlanController.ICarePlanController
+	MedicationController    medicationController.IMedicationController
+	VitalsController        vitalsController.IVitalsController
+	ConsentController       consentController.IConsentController
+	DeprecationController   deprecationController.IDeprecationController
+	ReportController        reportController.IReportController
+	BrandingController      brandingController.IBrandingController
+	ClientErrorController   clientErrorController.IClientErrorController
+	TrashController         trashController.ITrashController
+	ClientMergeController   clientMergeController.IClientMergeController
+	QuotaController         quotaController.IQuotaController
+	AppVersionController    appVersionController.IAppVersionController
+	LegalHoldController     legalHoldController.ILegalHoldController
+	PlannerController       plannerController.IPlannerController
+	NotificationController  notificationController.INotificationController
+	InterruptionController  interruptionController.IInterruptionController
+	HandoffController       handoffController.IHandoffController
+	WebhookController       webhookController.IWebhookController
+	IdentityController      identityController.IIdentityController
+	PayrollController       payrollController.IPayrollController
+	LocaleController        localeController.ILocaleController
+	ReferenceController     referenceController.IReferenceController
+	SwapController          swapController.ISwapController
+	ServiceNoteController   serviceNoteController.IServiceNoteController
+	TimesheetController     timesheetController.ITimesheetController
+	AnnouncementController  announcementController.IAnnouncementController
+	InvoiceController       invoiceController.IInvoiceController
+	RetentionController     retentionController.IRetentionController
+	AvailabilityController  availabilityController.IAvailabilityController
+	ProfileChangeController profileChangeController.IProfileChangeController
+	AccessController        accessController.IAccessController
+	DeactivationController  deactivationController.IDeactivationController
+	JWTService              security.IJWTService
+	UserRepository          userRepo.UserRepositoryInterface
+	ScheduleRepository      domainSchedule.IScheduleRepository
+	BudgetRepository        domainBudget.IBudgetRepository
+	AttachmentRepository    domainAttachment.IAttachmentRepository
+	NFCTagRepository        domainNFCTag.INFCTagRepository
+	VoiceMemoRepository     domainVoiceMemo.IVoiceMemoRepository
+	ComplianceRepository    domainCompliance.IComplianceRepository
+	ConfirmationRepository  domainConfirmation.IConfirmationRepository
+	WaitlistRepository      domainWaitlist.IWaitlistRepository
+	ServiceAreaRepository   domainServiceArea.IServiceAreaRepository
+	ScheduleViewRepository  domainScheduleView.IScheduleViewRepository
+	TeamRepository          domainTeam.ITeamRepository
+	AlertRepository         domainAlert.IAlertRepository
+	FormRepository          domainForm.IFormRepository
+	SignatureRepository     domainSignature.ISignatureRepository
+	FatigueRepository       domainFatigue.IFatigueRepository
+	ReminderRepository      domainReminder.IReminderRepository
+	KioskRepository         domainKiosk.IKioskRepository
+	AttestationRepository   domainAttestation.IAttestationRepository
+	EVVRepository           domainEVV.IEVVRepository
+	ClaimRepository         domainClaim.IClaimRepository
+	PayRateRepository       domainPayRate.IPayRateRepository
+	LeaveRepository         domainLeave.ILeaveRepository
+	DifferentialRepository  domainDifferential.IDifferentialRepository
+	StatementRepository     domainStatement.IStatementRepository
+	SupplyRepository        domainSupply.ISupplyRepository
+	EquipmentRepository     domainEquipment.IEquipmentRepository
+	ScreeningRepository     domainScreening.IScreeningRepository
+	TrainingRepository      domainTraining.ITrainingRepository
+	ReferralRepository      domainReferral.IReferralRepository
+	ProspectRepository      domainProspect.IProspectRepository
+	CarePlanRepository      domainCarePlan.ICarePlanRepository
+	MedicationRepository    domainMedication.IMedicationRepository
+	VitalsRepository        domainVitals.IVitalsRepository
+	ConsentRepository       domainConsent.IConsentRepository
+	DeprecationRepository   domainDeprecation.IDeprecationRepository
+	ReportRepository        domainReport.IReportRepository
+	BrandingRepository      domainBranding.IBrandingRepository
+	ClientErrorRepository   domainClientError.IClientErrorRepository
+	TrashRepository         domainTrash.ITrashRepository
+	ClientMergeRepository   domainClientMerge.IClientMergeRepository
+	QuotaRepository         domainQuota.IQuotaRepository
+	AppVersionRepository    domainAppVersion.IAppVersionRepository
+	LegalHoldRepository     domainLegalHold.ILegalHoldRepository
+	PlannerRepository       domainPlanner.IPlannerRepository
+	NotificationRepository  domainNotification.IDeviceRepository
+	InterruptionRepository  domainInterruption.IInterruptionRepository
+	HandoffRepository       domainHandoff.IHandoffRepository
+	WebhookRepository       domainWebhook.IWebhookRepository
+	IdentityRepository      domainIdentity.IIdentityRepository
+	PayrollRepository       domainPayroll.IPayrollRepository
+	LocaleRepository        domainLocale.ILocaleRepository
+	ReferenceRepository     domainReference.IReferenceRepository
+	SwapRepository          domainSwap.ISwapRepository
+	ServiceNoteRepository   domainServiceNote.IServiceNoteRepository
+	DeactivationRepository  domainDeactivation.IDeactivationRepository
+	ProfileChangeRepository domainProfileChange.IProfileChangeRepository
+	AnnouncementRepository  domainAnnouncement.IAnnouncementRepository
+	InvoiceRepository       domainInvoice.IInvoiceRepository
+	AuthUseCase             authUseCase.IAuthUseCase
+	UserUseCase             userUseCase.IUserUseCase
+	ScheduleUseCase         scheduleUseCase.IScheduleUseCase
+	BudgetUseCase           budgetUseCase.IBudgetUseCase
+	AttachmentUseCase       attachmentUseCase.IAttachmentUseCase
+	NFCTagUseCase           nfcTagUseCase.INFCTagUseCase
+	VoiceMemoUseCase        voiceMemoUseCase.IVoiceMemoUseCase
+	SuggestionService       suggestionUseCase.ISuggestionService
+	ComplianceUseCase       complianceUseCase.IComplianceUseCase
+	ConfirmationUseCase     confirmationUseCase.IConfirmationUseCase
+	WaitlistUseCase         waitlistUseCase.IWaitlistUseCase
+	ServiceAreaUseCase      serviceAreaUseCase.IServiceAreaUseCase
+	ScheduleViewUseCase     scheduleViewUseCase.IScheduleViewUseCase
+	TeamUseCase             teamUseCase.ITeamUseCase
+	AlertUseCase            alertUseCase.IAlertUseCase
+	FormUseCase             formUseCase.IFormUseCase
+	SignatureUseCase        signatureUseCase.ISignatureUseCase
+	FatigueUseCase          fatigueUseCase.IFatigueUseCase
+	ReminderUseCase         reminderUseCase.IReminderUseCase
+	KioskUseCase            kioskUseCase.IKioskUseCase
+	AttestationUseCase      attestationUseCase.IAttestationUseCase
+	EVVUseCase              evvUseCase.IEVVUseCase
+	ClaimUseCase            claimUseCase.IClaimUseCase
+	PayRateUseCase          payRateUseCase.IPayRateUseCase
+	LeaveUseCase            leaveUseCase.ILeaveUseCase
+	DifferentialUseCase     differentialUseCase.IDifferentialUseCase
+	StatementUseCase        statementUseCase.IStatementUseCase
+	SupplyUseCase           supplyUseCase.ISupplyUseCase
+	EquipmentUseCase        equipmentUseCase.IEquipmentUseCase
+	ScreeningUseCase        screeningUseCase.IScreeningUseCase
+	SearchUseCase           searchUseCase.ISearchUseCase
+	TrainingUseCase         trainingUseCase.ITrainingUseCase
+	ReferralUseCase         referralUseCase.IReferralUseCase
+	ProspectUseCase         prospectUseCase.IProspectUseCase
+	CarePlanUseCase         carePlanUseCase.ICarePlanUseCase
+	MedicationUseCase       medicationUseCase.IMedicationUseCase
+	VitalsUseCase           vitalsUseCase.IVitalsUseCase
+	ConsentUseCase          consentUseCase.IConsentUseCase
+	DeprecationUseCase      deprecationUseCase.IDeprecationUseCase
+	ReportUseCase           reportUseCase.IReportUseCase
+	BrandingUseCase         brandingUseCase.IBrandingUseCase
+	ClientErrorUseCase      clientErrorUseCase.IClientErrorUseCase
+	TrashUseCase            trashUseCase.ITrashUseCase
+	ClientMergeUseCase      clientMergeUseCase.IClientMergeUseCase
+	QuotaUseCase            quotaUseCase.IQuotaUseCase
+	AppVersionUseCase       appVersionUseCase.IAppVersionUseCase
+	LegalHoldUseCase        legalHoldUseCase.ILegalHoldUseCase
+	PlannerUseCase          plannerUseCase.IPlannerUseCase
+	NotificationUseCase     notificationUseCase.INotificationUseCase
+	NotificationQueue       notification.IQueue
+	InterruptionUseCase     interruptionUseCase.IInterruptionUseCase
+	HandoffUseCase          handoffUseCase.IHandoffUseCase
+	WebhookUseCase          webhookUseCase.IWebhookUseCase
+	IdentityUseCase         identityUseCase.IIdentityUseCase
+	PayrollUseCase          payrollUseCase.IPayrollUseCase
+	LocaleUseCase           localeUseCase.ILocaleUseCase
+	ReferenceUseCase        referenceUseCase.IReferenceUseCase
+	SwapUseCase             swapUseCase.ISwapUseCase
+	ServiceNoteUseCase      serviceNoteUseCase.IServiceNoteUseCase
+	TimesheetUseCase        timesheetUseCase.ITimesheetUseCase
+	AnnouncementUseCase     announcementUseCase.IAnnouncementUseCase
+	InvoiceUseCase          invoiceUseCase.IInvoiceUseCase
+	RetentionUseCase        retentionUseCase.IRetentionUseCase
+	AvailabilityUseCase     availabilityUseCase.IAvailabilityUseCase
+	ProfileChangeUseCase    profileChangeUseCase.IProfileChangeUseCase
+	AccessUseCase           accessUseCase.IAccessUseCase
+	DeactivationUseCase     deactivationUseCase.IDeactivationUseCase
 }
 
 var (
@@ -54,29 +493,470 @@ func SetupDependencies(loggerInstance *logger.Logger) (*ApplicationContext, erro
 
 	jwtService := security.NewJWTService()
 
-	userRepo := userRepo.NewUserRepository(db, loggerInstance)
+	userVersionRepo := userRepo.NewUserVersionRepository(db, loggerInstance)
+	passwordRepo := userRepo.NewPasswordRepository(db, loggerInstance)
+	userRepo := userRepo.WithShadowWrites(userRepo.NewUserRepository(db, loggerInstance), db, loggerInstance)
 	scheduleRepo := scheduleRepo.NewScheduleRepository(db, loggerInstance)
+	budgetRepo := budgetRepo.NewBudgetRepository(db, loggerInstance)
+	attachmentRepo := attachmentRepo.NewAttachmentRepository(db, loggerInstance)
+	nfcTagRepo := nfcTagRepo.NewNFCTagRepository(db, loggerInstance)
+	voiceMemoRepo := voiceMemoRepo.NewVoiceMemoRepository(db, loggerInstance)
+	complianceRepo := complianceRepo.NewComplianceRepository(db, loggerInstance)
+	confirmationRepo := confirmationRepo.NewConfirmationRepository(db, loggerInstance)
+	waitlistRepo := waitlistRepo.NewWaitlistRepository(db, loggerInstance)
+	serviceAreaRepo := serviceAreaRepo.NewServiceAreaRepository(db, loggerInstance)
+	scheduleViewRepo := scheduleViewRepo.NewScheduleViewRepository(db, loggerInstance)
+	teamRepo := teamRepo.NewTeamRepository(db, loggerInstance)
+	alertRepo := alertRepo.NewAlertRepository(db, loggerInstance)
+	formRepo := formRepo.NewFormRepository(db, loggerInstance)
+	signatureRepo := signatureRepo.NewSignatureRepository(db, loggerInstance)
+	fatigueRepo := fatigueRepo.NewFatigueRepository(db, loggerInstance)
+	reminderRepo := reminderRepo.NewReminderRepository(db, loggerInstance)
+	kioskRepo := kioskRepo.NewKioskRepository(db, loggerInstance)
+	attestationRepo := attestationRepo.NewAttestationRepository(db, loggerInstance)
+	evvRepo := evvRepo.NewEVVRepository(db, loggerInstance)
+	claimRepo := claimRepo.NewClaimRepository(db, loggerInstance)
+	payRateRepo := payRateRepo.NewPayRateRepository(db, loggerInstance)
+	leaveRepo := leaveRepo.NewLeaveRepository(db, loggerInstance)
+	differentialRepo := differentialRepo.NewDifferentialRepository(db, loggerInstance)
+	statementRepo := statementRepo.NewStatementRepository(db, loggerInstance)
+	supplyRepo := supplyRepo.NewSupplyRepository(db, loggerInstance)
+	equipmentRepo := equipmentRepo.NewEquipmentRepository(db, loggerInstance)
+	screeningRepo := screeningRepo.NewScreeningRepository(db, loggerInstance)
+	trainingRepo := trainingRepo.NewTrainingRepository(db, loggerInstance)
+	referralRepo := referralRepo.NewReferralRepository(db, loggerInstance)
+	prospectRepo := prospectRepo.NewProspectRepository(db, loggerInstance)
+	carePlanRepo := carePlanRepo.NewCarePlanRepository(db, loggerInstance)
+	medicationRepo := medicationRepo.NewMedicationRepository(db, loggerInstance)
+	vitalsRepo := vitalsRepo.NewVitalsRepository(db, loggerInstance)
+	consentRepo := consentRepo.NewConsentRepository(db, loggerInstance)
+	deprecationRepo := deprecationRepo.NewDeprecationRepository(db, loggerInstance)
+	reportQueryRepo := reportRepo.NewQueryRepository(db, loggerInstance)
+	reportRepo := reportRepo.NewReportRepository(db, loggerInstance)
+	brandingRepo := brandingRepo.NewBrandingRepository(db, loggerInstance)
+	clientErrorRepo := clientErrorRepo.NewClientErrorRepository(db, loggerInstance)
+	trashRepo := trashRepo.NewTrashRepository(db, loggerInstance)
+	clientMergeRepo := clientMergeRepo.NewClientMergeRepository(db, loggerInstance)
+	quotaRepo := quotaRepo.NewQuotaRepository(db, loggerInstance)
+	appVersionRepo := appVersionRepo.NewAppVersionRepository(db, loggerInstance)
+	legalHoldRepo := legalHoldRepo.NewLegalHoldRepository(db, loggerInstance)
+	plannerRepo := plannerRepo.NewPlannerRepository(db, loggerInstance)
+	notificationPreferenceRepo := notificationRepo.NewPreferenceRepository(db, loggerInstance)
+	notificationRepo := notificationRepo.NewDeviceRepository(db, loggerInstance)
+	interruptionRepo := interruptionRepo.NewInterruptionRepository(db, loggerInstance)
+	handoffRepo := handoffRepo.NewHandoffRepository(db, loggerInstance)
+	webhookRepo := webhookRepo.NewWebhookRepository(db, loggerInstance)
+	identityRepo := identityRepo.NewIdentityRepository(db, loggerInstance)
+	payrollRepo := payrollRepo.NewPayrollRepository(db, loggerInstance)
+	localeRepo := localeRepo.NewLocaleRepository(db, loggerInstance)
+	referenceRepo := referenceRepo.NewReferenceRepository(db, loggerInstance)
+	swapRepo := swapRepo.NewSwapRepository(db, loggerInstance)
+	serviceNoteRepo := serviceNoteRepo.NewServiceNoteRepository(db, loggerInstance)
+	announcementRepo := announcementRepo.NewAnnouncementRepository(db, loggerInstance)
+	invoiceRepo := invoiceRepo.NewInvoiceRepository(db, loggerInstance)
+	deactivationRepo := deactivationRepo.NewDeactivationRepository(db, loggerInstance)
+	profileChangeRepo := profileChangeRepo.NewProfileChangeRepository(db, loggerInstance)
 
-	authUC := authUseCase.NewAuthUseCase(userRepo, jwtService, loggerInstance)
-	userUC := userUseCase.NewUserUseCase(userRepo, loggerInstance)
-	scheduleUC := scheduleUseCase.NewScheduleUseCase(scheduleRepo, userRepo, loggerInstance)
+	fileStorage, err := storage.NewStorageFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	enabledPlugins, err := plugins.LoadFromEnv(plugins.Deps{DB: db, Logger: loggerInstance})
+	if err != nil {
+		return nil, err
+	}
+	baseNotifier, notificationQueue := notification.NewNotifierFromEnv(notification.NewUserDirectory(userRepo, notificationRepo, notificationPreferenceRepo), mail.NewMailerFromEnv(), loggerInstance)
+	notifier := enabledPlugins.Notifier(baseNotifier)
+	notificationUC := notificationUseCase.NewNotificationUseCase(notificationRepo, notificationPreferenceRepo, userRepo, notifier, loggerInstance)
+	interruptionUC := interruptionUseCase.NewInterruptionUseCase(interruptionRepo, scheduleRepo, userRepo, loggerInstance)
+	handoffUC := handoffUseCase.NewHandoffUseCase(handoffRepo, scheduleRepo, userRepo, notifier, loggerInstance)
+	localeUC := localeUseCase.NewLocaleUseCase(localeRepo, holiday.NewSourceFromEnv(), loggerInstance)
+	referenceUC := referenceUseCase.NewReferenceUseCase(referenceRepo, loggerInstance, referenceUseCase.WithSettings(localeUC), referenceUseCase.WithFeatureFlags(strings.Split(os.Getenv("FEATURE_FLAGS"), ",")...))
+	webhookUC := webhookUseCase.NewWebhookUseCase(webhookRepo, webhook.NewHTTPSender(), loggerInstance, webhookUseCase.WithCalendar(localeUC))
+	identityUC := identityUseCase.NewIdentityUseCase(identityRepo, scheduleRepo, userRepo, fileStorage, loggerInstance)
+	payrollUC := payrollUseCase.NewPayrollUseCase(payrollRepo, scheduleRepo, userRepo, loggerInstance)
+	evvAdapters := evvAdapter.NewRegistry()
+	if err := enabledPlugins.ExtendEVV(evvAdapters); err != nil {
+		return nil, err
+	}
+	integrityVerifiers, err := integrity.NewRegistryFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if err := enabledPlugins.ExtendIntegrity(integrityVerifiers); err != nil {
+		return nil, err
+	}
+	interactionChecker, err := medication.NewInteractionCheckerFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	signer, err := security.NewSignerFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	confidenceWeights, err := domainSchedule.ParseConfidenceWeights(os.Getenv("VERIFICATION_WEIGHTS"))
+	if err != nil {
+		return nil, err
+	}
+	timesheetPolicy, err := domainTimesheet.ParsePolicy(os.Getenv("TIMESHEET_POLICY"))
+	if err != nil {
+		return nil, err
+	}
+
+	authUC := authUseCase.NewAuthUseCase(userRepo, passwordRepo, jwtService, security.NewPasswordService(), loggerInstance)
+	searchUC := searchUseCase.NewSearchUseCase(search.NewIndexFromEnv(), userRepo, scheduleRepo, loggerInstance)
+	legalHoldUC := legalHoldUseCase.NewLegalHoldUseCase(legalHoldRepo, userRepo, signer, loggerInstance)
+	userUC := userUseCase.NewUserUseCase(userRepo, loggerInstance, userUseCase.WithVersionHistory(userVersionRepo), userUseCase.WithReferralSources(referralRepo), userUseCase.WithObservers(searchUC), userUseCase.WithDeleteGuards(legalHoldUC))
+	budgetUC := budgetUseCase.NewBudgetUseCase(budgetRepo, userRepo, loggerInstance)
+	attachmentUC := attachmentUseCase.NewAttachmentUseCase(attachmentRepo, scheduleRepo, fileStorage, loggerInstance)
+	nfcTagUC := nfcTagUseCase.NewNFCTagUseCase(nfcTagRepo, userRepo, loggerInstance)
+	integrityUC := integrityUseCase.NewIntegrityUseCase(integrityVerifiers, loggerInstance)
+	serviceAreaUC := serviceAreaUseCase.NewServiceAreaUseCase(serviceAreaRepo, userRepo, loggerInstance)
+	suggestionSvc := suggestionUseCase.NewSuggestionService(scheduleRepo, userRepo, loggerInstance,
+		suggestionUseCase.WithCaregiverScreens(serviceAreaUC),
+	)
+	voiceMemoUC := voiceMemoUseCase.NewVoiceMemoUseCase(voiceMemoRepo, scheduleRepo, fileStorage, transcription.NewTranscriberFromEnv(), loggerInstance,
+		voiceMemoUseCase.WithObservers(searchUC),
+		voiceMemoUseCase.WithServiceNotes(serviceNoteRepo),
+	)
+	waitlistUC := waitlistUseCase.NewWaitlistUseCase(waitlistRepo, userRepo, suggestionSvc, notifier, loggerInstance)
+	complianceUC := complianceUseCase.NewComplianceUseCase(complianceRepo, scheduleRepo, loggerInstance,
+		complianceUseCase.WithTeamResolver(teamRepo),
+	)
+	fatigueUC := fatigueUseCase.NewFatigueUseCase(fatigueRepo, scheduleRepo, userRepo, loggerInstance)
+	reminderUC := reminderUseCase.NewReminderUseCase(reminderRepo, scheduleRepo, userRepo, notifier, loggerInstance, reminderUseCase.WithCalendar(localeUC))
+	attestationUC := attestationUseCase.NewAttestationUseCase(attestationRepo, scheduleRepo, notifier, loggerInstance)
+	evvUC := evvUseCase.NewEVVUseCase(evvRepo, scheduleRepo, userRepo, evvAdapters, loggerInstance, evvUseCase.WithAllowedHosts(strings.Split(os.Getenv("EVV_ALLOWED_HOSTS"), ",")...))
+	payRateUC := payRateUseCase.NewPayRateUseCase(payRateRepo, userRepo, userVersionRepo, loggerInstance)
+	differentialUC := differentialUseCase.NewDifferentialUseCase(differentialRepo, scheduleRepo, payRateUC, loggerInstance, differentialUseCase.WithBillingRates(claimRepo))
+	brandingUC := brandingUseCase.NewBrandingUseCase(brandingRepo, fileStorage, loggerInstance)
+	clientErrorUC := clientErrorUseCase.NewClientErrorUseCase(clientErrorRepo, loggerInstance)
+	statementUC := statementUseCase.NewStatementUseCase(statementRepo, claimRepo, userRepo, loggerInstance, statementUseCase.WithBranding(brandingUC))
+	invoiceUC := invoiceUseCase.NewInvoiceUseCase(invoiceRepo, claimRepo, scheduleRepo, userRepo, loggerInstance, invoiceUseCase.WithBranding(brandingUC))
+	supplyUC := supplyUseCase.NewSupplyUseCase(supplyRepo, scheduleRepo, notifier, loggerInstance)
+	equipmentUC := equipmentUseCase.NewEquipmentUseCase(equipmentRepo, scheduleRepo, userRepo, notifier, loggerInstance)
+	screeningUC := screeningUseCase.NewScreeningUseCase(screeningRepo, userRepo, screening.NewProviderFromEnv(), notifier, loggerInstance)
+	trainingUC := trainingUseCase.NewTrainingUseCase(trainingRepo, userRepo, loggerInstance)
+	referralUC := referralUseCase.NewReferralUseCase(referralRepo, userRepo, scheduleRepo, loggerInstance)
+	prospectUC := prospectUseCase.NewProspectUseCase(prospectRepo, referralRepo, userUC, captcha.NewVerifierFromEnv(), notifier, loggerInstance)
+	medicationUC := medicationUseCase.NewMedicationUseCase(medicationRepo, userRepo, scheduleRepo, interactionChecker, loggerInstance)
+	vitalsUC := vitalsUseCase.NewVitalsUseCase(vitalsRepo, userRepo, scheduleRepo, notifier, loggerInstance)
+	consentUC := consentUseCase.NewConsentUseCase(consentRepo, userRepo, claimRepo, loggerInstance)
+	deprecationUC := deprecationUseCase.NewDeprecationUseCase(deprecationRepo, loggerInstance)
+	claimUC := claimUseCase.NewClaimUseCase(claimRepo, scheduleRepo, userRepo, loggerInstance,
+		claimUseCase.WithDifferentials(differentialUC),
+		claimUseCase.WithConsents(consentUC),
+	)
+	leaveUC := leaveUseCase.NewLeaveUseCase(leaveRepo, scheduleRepo, userRepo, loggerInstance)
+	availabilityUC := availabilityUseCase.NewAvailabilityUseCase(plannerRepo, leaveRepo, loggerInstance)
+	scheduleViewUC := scheduleViewUseCase.NewScheduleViewUseCase(scheduleViewRepo, userRepo, loggerInstance)
+	scheduleUC := scheduleUseCase.NewScheduleUseCase(scheduleRepo, userRepo, loggerInstance,
+		scheduleUseCase.WithValidators(suggestionSvc, budgetUC, serviceAreaUC, fatigueUC, leaveUC, availabilityUC, screeningUC, trainingUC),
+		scheduleUseCase.WithObservers(interruptionUC, budgetUC, complianceUC, waitlistUC, attestationUC, evvUC, leaveUC, searchUC, notificationUC, webhookUC),
+		scheduleUseCase.WithCheckinGuards(attachmentUC, handoffUC, identityUC, nfcTagUC, integrityUC),
+		scheduleUseCase.WithChangeGuards(payrollUC),
+		scheduleUseCase.WithValidators(enabledPlugins.Validators()...),
+		scheduleUseCase.WithObservers(enabledPlugins.Observers()...),
+		scheduleUseCase.WithCheckinGuards(enabledPlugins.CheckinGuards()...),
+		scheduleUseCase.WithViewResolver(scheduleViewUC),
+		scheduleUseCase.WithTeamResolver(teamRepo),
+		scheduleUseCase.WithCalendar(localeUC),
+		scheduleUseCase.WithConfidenceWeights(confidenceWeights),
+	)
+	swapUC := swapUseCase.NewSwapUseCase(swapRepo, scheduleRepo, userRepo, scheduleUC, loggerInstance, swapUseCase.WithEligibilityChecks(serviceAreaUC, leaveUC, availabilityUC, screeningUC, trainingUC))
+	serviceNoteUC := serviceNoteUseCase.NewServiceNoteUseCase(serviceNoteRepo, scheduleRepo, userRepo, loggerInstance, serviceNoteUseCase.WithObservers(searchUC))
+	timesheetUC := timesheetUseCase.NewTimesheetUseCase(scheduleRepo, userRepo, loggerInstance, timesheetUseCase.WithPolicy(timesheetPolicy))
+	retentionUC := retentionUseCase.NewRetentionUseCase(userRepo, deactivationRepo, loggerInstance)
+	teamUC := teamUseCase.NewTeamUseCase(teamRepo, userRepo, scheduleUC, complianceUC, loggerInstance)
+	trashUC := trashUseCase.NewTrashUseCase(trashRepo, userUC, scheduleUC, loggerInstance)
+	clientMergeUC := clientMergeUseCase.NewClientMergeUseCase(clientMergeRepo, userUC, loggerInstance, clientMergeUseCase.WithMergeGuards(legalHoldUC))
+	quotaUC := quotaUseCase.NewQuotaUseCase(quotaRepo, notifier, loggerInstance)
+	appVersionUC := appVersionUseCase.NewAppVersionUseCase(appVersionRepo, loggerInstance)
+	profileChangeUC := profileChangeUseCase.NewProfileChangeUseCase(profileChangeRepo, serviceAreaRepo, userUC, loggerInstance)
+	accessUC := accessUseCase.NewAccessUseCase(userRepo, scheduleRepo, teamRepo, loggerInstance)
+	deactivationUC := deactivationUseCase.NewDeactivationUseCase(userUC, scheduleRepo, loggerInstance, deactivationUseCase.WithHistory(deactivationRepo))
+	alertUC := alertUseCase.NewAlertUseCase(alertRepo, scheduleRepo, userRepo, teamRepo, notifier, loggerInstance)
+	announcementUC := announcementUseCase.NewAnnouncementUseCase(announcementRepo, userRepo, teamRepo, scheduleRepo, notifier, loggerInstance)
+	carePlanUC := carePlanUseCase.NewCarePlanUseCase(carePlanRepo, userRepo, scheduleRepo, loggerInstance, carePlanUseCase.WithUnderServiceAlerts(alertUC))
+	formUC := formUseCase.NewFormUseCase(formRepo, userRepo, loggerInstance)
+	signatureUC := signatureUseCase.NewSignatureUseCase(signatureRepo, attachmentRepo, scheduleRepo, userRepo, fileStorage, loggerInstance)
+	kioskUC := kioskUseCase.NewKioskUseCase(kioskRepo, scheduleRepo, userRepo, scheduleUC, loggerInstance)
+	confirmationUC := confirmationUseCase.NewConfirmationUseCase(confirmationRepo, scheduleUC, loggerInstance,
+		confirmationUseCase.WithConsents(consentUC),
+		confirmationUseCase.WithBranding(brandingUC),
+	)
+	reportUC := reportUseCase.NewReportUseCase(reportRepo, reportQueryRepo, brandingUC.Mailer(mail.NewMailerFromEnv()), loggerInstance,
+		reportUseCase.WithSources(
+			reportUseCase.NewComplianceSource(complianceUC),
+			reportUseCase.NewReferralSource(referralUC),
+			reportUseCase.NewSupplySource(supplyUC),
+			reportUseCase.NewDenialAgingSource(claimUC),
+			reportUseCase.NewBudgetSource(budgetUC),
+		),
+	)
+	plannerUC := plannerUseCase.NewPlannerUseCase(plannerRepo, userRepo, scheduleRepo, leaveRepo, carePlanUC, scheduleUC, loggerInstance, plannerUseCase.WithCaregiverScreens(serviceAreaUC), plannerUseCase.WithCalendar(localeUC))
 
 	authController := authController.NewAuthController(authUC, loggerInstance)
 	userController := userController.NewUserController(userUC, loggerInstance)
 	scheduleController := scheduleController.NewScheduleController(scheduleUC, loggerInstance)
+	budgetController := budgetController.NewBudgetController(budgetUC, loggerInstance)
+	attachmentController := attachmentController.NewAttachmentController(attachmentUC, loggerInstance)
+	nfcTagController := nfcTagController.NewNFCTagController(nfcTagUC, loggerInstance)
+	voiceMemoController := voiceMemoController.NewVoiceMemoController(voiceMemoUC, loggerInstance)
+	complianceController := complianceController.NewComplianceController(complianceUC, loggerInstance)
+	confirmationController := confirmationController.NewConfirmationController(confirmationUC, loggerInstance)
+	waitlistController := waitlistController.NewWaitlistController(waitlistUC, loggerInstance)
+	serviceAreaController := serviceAreaController.NewServiceAreaController(serviceAreaUC, loggerInstance)
+	scheduleViewController := scheduleViewController.NewScheduleViewController(scheduleViewUC, loggerInstance)
+	teamController := teamController.NewTeamController(teamUC, loggerInstance)
+	alertController := alertController.NewAlertController(alertUC, loggerInstance)
+	formController := formController.NewFormController(formUC, loggerInstance)
+	signatureController := signatureController.NewSignatureController(signatureUC, loggerInstance)
+	fatigueController := fatigueController.NewFatigueController(fatigueUC, loggerInstance)
+	reminderController := reminderController.NewReminderController(reminderUC, loggerInstance)
+	kioskController := kioskController.NewKioskController(kioskUC, loggerInstance)
+	attestationController := attestationController.NewAttestationController(attestationUC, loggerInstance)
+	evvController := evvController.NewEVVController(evvUC, loggerInstance)
+	claimController := claimController.NewClaimController(claimUC, loggerInstance)
+	payRateController := payRateController.NewPayRateController(payRateUC, loggerInstance)
+	leaveController := leaveController.NewLeaveController(leaveUC, loggerInstance)
+	differentialController := differentialController.NewDifferentialController(differentialUC, loggerInstance)
+	statementController := statementController.NewStatementController(statementUC, loggerInstance)
+	supplyController := supplyController.NewSupplyController(supplyUC, loggerInstance)
+	equipmentController := equipmentController.NewEquipmentController(equipmentUC, loggerInstance)
+	screeningController := screeningController.NewScreeningController(screeningUC, loggerInstance)
+	searchController := searchController.NewSearchController(searchUC, loggerInstance)
+	trainingController := trainingController.NewTrainingController(trainingUC, loggerInstance)
+	referralController := referralController.NewReferralController(referralUC, loggerInstance)
+	prospectController := prospectController.NewProspectController(prospectUC, loggerInstance)
+	carePlanController := carePlanController.NewCarePlanController(carePlanUC, loggerInstance)
+	medicationController := medicationController.NewMedicationController(medicationUC, loggerInstance)
+	vitalsController := vitalsController.NewVitalsController(vitalsUC, loggerInstance)
+	consentController := consentController.NewConsentController(consentUC, loggerInstance)
+	deprecationController := deprecationController.NewDeprecationController(deprecationUC, loggerInstance)
+	reportController := reportController.NewReportController(reportUC, loggerInstance)
+	brandingController := brandingController.NewBrandingController(brandingUC, loggerInstance)
+	clientErrorController := clientErrorController.NewClientErrorController(clientErrorUC, loggerInstance)
+	trashController := trashController.NewTrashController(trashUC, loggerInstance)
+	clientMergeController := clientMergeController.NewClientMergeController(clientMergeUC, loggerInstance)
+	quotaController := quotaController.NewQuotaController(quotaUC, loggerInstance)
+	appVersionController := appVersionController.NewAppVersionController(appVersionUC, loggerInstance)
+	legalHoldController := legalHoldController.NewLegalHoldController(legalHoldUC, loggerInstance)
+	plannerController := plannerController.NewPlannerController(plannerUC, loggerInstance)
+	notificationController := notificationController.NewNotificationController(notificationUC, loggerInstance)
+	interruptionController := interruptionController.NewInterruptionController(interruptionUC, loggerInstance)
+	handoffController := handoffController.NewHandoffController(handoffUC, loggerInstance)
+	webhookController := webhookController.NewWebhookController(webhookUC, loggerInstance)
+	identityController := identityController.NewIdentityController(identityUC, loggerInstance)
+	payrollController := payrollController.NewPayrollController(payrollUC, loggerInstance)
+	localeController := localeController.NewLocaleController(localeUC, loggerInstance)
+	referenceController := referenceController.NewReferenceController(referenceUC, loggerInstance)
+	swapController := swapController.NewSwapController(swapUC, loggerInstance)
+	serviceNoteController := serviceNoteController.NewServiceNoteController(serviceNoteUC, loggerInstance)
+	timesheetController := timesheetController.NewTimesheetController(timesheetUC, loggerInstance)
+	announcementController := announcementController.NewAnnouncementController(announcementUC, loggerInstance)
+	invoiceController := invoiceController.NewInvoiceController(invoiceUC, loggerInstance)
+	retentionController := retentionController.NewRetentionController(retentionUC, loggerInstance)
+	availabilityController := availabilityController.NewAvailabilityController(availabilityUC, loggerInstance)
+	profileChangeController := profileChangeController.NewProfileChangeController(profileChangeUC, loggerInstance)
+	accessController := accessController.NewAccessController(accessUC, loggerInstance)
+	deactivationController := deactivationController.NewDeactivationController(deactivationUC, loggerInstance)
 
 	return &ApplicationContext{
-		DB:                 db,
-		Logger:             loggerInstance,
-		AuthController:     authController,
-		UserController:     userController,
-		ScheduleController: scheduleController,
-		JWTService:         jwtService,
-		UserRepository:     userRepo,
-		ScheduleRepository: scheduleRepo,
-		AuthUseCase:        authUC,
-		UserUseCase:        userUC,
-		ScheduleUseCase:    scheduleUC,
+		DB:                      db,
+		Logger:                  loggerInstance,
+		AuthController:          authController,
+		UserController:          userController,
+		ScheduleController:      scheduleController,
+		BudgetController:        budgetController,
+		AttachmentController:    attachmentController,
+		NFCTagController:        nfcTagController,
+		VoiceMemoController:     voiceMemoController,
+		ComplianceController:    complianceController,
+		ConfirmationController:  confirmationController,
+		WaitlistController:      waitlistController,
+		ServiceAreaController:   serviceAreaController,
+		ScheduleViewController:  scheduleViewController,
+		TeamController:          teamController,
+		AlertController:         alertController,
+		FormController:          formController,
+		SignatureController:     signatureController,
+		FatigueController:       fatigueController,
+		ReminderController:      reminderController,
+		KioskController:         kioskController,
+		AttestationController:   attestationController,
+		EVVController:           evvController,
+		ClaimController:         claimController,
+		PayRateController:       payRateController,
+		LeaveController:         leaveController,
+		DifferentialController:  differentialController,
+		StatementController:     statementController,
+		SupplyController:        supplyController,
+		EquipmentController:     equipmentController,
+		ScreeningController:     screeningController,
+		SearchController:        searchController,
+		TrainingController:      trainingController,
+		ReferralController:      referralController,
+		ProspectController:      prospectController,
+		CarePlanController:      carePlanController,
+		MedicationController:    medicationController,
+		VitalsController:        vitalsController,
+		ConsentController:       consentController,
+		DeprecationController:   deprecationController,
+		ReportController:        reportController,
+		BrandingController:      brandingController,
+		ClientErrorController:   clientErrorController,
+		TrashController:         trashController,
+		ClientMergeController:   clientMergeController,
+		QuotaController:         quotaController,
+		AppVersionController:    appVersionController,
+		LegalHoldController:     legalHoldController,
+		PlannerController:       plannerController,
+		NotificationController:  notificationController,
+		InterruptionController:  interruptionController,
+		HandoffController:       handoffController,
+		WebhookController:       webhookController,
+		IdentityController:      identityController,
+		PayrollController:       payrollController,
+		LocaleController:        localeController,
+		ReferenceController:     referenceController,
+		SwapController:          swapController,
+		ServiceNoteController:   serviceNoteController,
+		TimesheetController:     timesheetController,
+		AnnouncementController:  announcementController,
+		InvoiceController:       invoiceController,
+		RetentionController:     retentionController,
+		AvailabilityController:  availabilityController,
+		ProfileChangeController: profileChangeController,
+		AccessController:        accessController,
+		DeactivationController:  deactivationController,
+		JWTService:              jwtService,
+		UserRepository:          userRepo,
+		ScheduleRepository:      scheduleRepo,
+		BudgetRepository:        budgetRepo,
+		AttachmentRepository:    attachmentRepo,
+		NFCTagRepository:        nfcTagRepo,
+		VoiceMemoRepository:     voiceMemoRepo,
+		ComplianceRepository:    complianceRepo,
+		ConfirmationRepository:  confirmationRepo,
+		WaitlistRepository:      waitlistRepo,
+		ServiceAreaRepository:   serviceAreaRepo,
+		ScheduleViewRepository:  scheduleViewRepo,
+		TeamRepository:          teamRepo,
+		AlertRepository:         alertRepo,
+		FormRepository:          formRepo,
+		SignatureRepository:     signatureRepo,
+		FatigueRepository:       fatigueRepo,
+		ReminderRepository:      reminderRepo,
+		KioskRepository:         kioskRepo,
+		AttestationRepository:   attestationRepo,
+		EVVRepository:           evvRepo,
+		ClaimRepository:         claimRepo,
+		PayRateRepository:       payRateRepo,
+		LeaveRepository:         leaveRepo,
+		DifferentialRepository:  differentialRepo,
+		StatementRepository:     statementRepo,
+		SupplyRepository:        supplyRepo,
+		EquipmentRepository:     equipmentRepo,
+		ScreeningRepository:     screeningRepo,
+		TrainingRepository:      trainingRepo,
+		ReferralRepository:      referralRepo,
+		ProspectRepository:      prospectRepo,
+		CarePlanRepository:      carePlanRepo,
+		MedicationRepository:    medicationRepo,
+		VitalsRepository:        vitalsRepo,
+		ConsentRepository:       consentRepo,
+		DeprecationRepository:   deprecationRepo,
+		ReportRepository:        reportRepo,
+		BrandingRepository:      brandingRepo,
+		ClientErrorRepository:   clientErrorRepo,
+		TrashRepository:         trashRepo,
+		ClientMergeRepository:   clientMergeRepo,
+		QuotaRepository:         quotaRepo,
+		AppVersionRepository:    appVersionRepo,
+		LegalHoldRepository:     legalHoldRepo,
+		PlannerRepository:       plannerRepo,
+		NotificationRepository:  notificationRepo,
+		InterruptionRepository:  interruptionRepo,
+		HandoffRepository:       handoffRepo,
+		WebhookRepository:       webhookRepo,
+		IdentityRepository:      identityRepo,
+		PayrollRepository:       payrollRepo,
+		LocaleRepository:        localeRepo,
+		ReferenceRepository:     referenceRepo,
+		SwapRepository:          swapRepo,
+		ServiceNoteRepository:   serviceNoteRepo,
+		DeactivationRepository:  deactivationRepo,
+		ProfileChangeRepository: profileChangeRepo,
+		AnnouncementRepository:  announcementRepo,
+		InvoiceRepository:       invoiceRepo,
+		AuthUseCase:             authUC,
+		UserUseCase:             userUC,
+		ScheduleUseCase:         scheduleUC,
+		BudgetUseCase:           budgetUC,
+		AttachmentUseCase:       attachmentUC,
+		NFCTagUseCase:           nfcTagUC,
+		VoiceMemoUseCase:        voiceMemoUC,
+		SuggestionService:       suggestionSvc,
+		ComplianceUseCase:       complianceUC,
+		ConfirmationUseCase:     confirmationUC,
+		WaitlistUseCase:         waitlistUC,
+		ServiceAreaUseCase:      serviceAreaUC,
+		ScheduleViewUseCase:     scheduleViewUC,
+		TeamUseCase:             teamUC,
+		AlertUseCase:            alertUC,
+		FormUseCase:             formUC,
+		SignatureUseCase:        signatureUC,
+		FatigueUseCase:          fatigueUC,
+		ReminderUseCase:         reminderUC,
+		KioskUseCase:            kioskUC,
+		AttestationUseCase:      attestationUC,
+		EVVUseCase:              evvUC,
+		ClaimUseCase:            claimUC,
+		PayRateUseCase:          payRateUC,
+		LeaveUseCase:            leaveUC,
+		DifferentialUseCase:     differentialUC,
+		StatementUseCase:        statementUC,
+		SupplyUseCase:           supplyUC,
+		EquipmentUseCase:        equipmentUC,
+		ScreeningUseCase:        screeningUC,
+		SearchUseCase:           searchUC,
+		TrainingUseCase:         trainingUC,
+		ReferralUseCase:         referralUC,
+		ProspectUseCase:         prospectUC,
+		CarePlanUseCase:         carePlanUC,
+		MedicationUseCase:       medicationUC,
+		VitalsUseCase:           vitalsUC,
+		ConsentUseCase:          consentUC,
+		DeprecationUseCase:      deprecationUC,
+		ReportUseCase:           reportUC,
+		BrandingUseCase:         brandingUC,
+		ClientErrorUseCase:      clientErrorUC,
+		TrashUseCase:            trashUC,
+		ClientMergeUseCase:      clientMergeUC,
+		QuotaUseCase:            quotaUC,
+		AppVersionUseCase:       appVersionUC,
+		LegalHoldUseCase:        legalHoldUC,
+		PlannerUseCase:          plannerUC,
+		NotificationUseCase:     notificationUC,
+		NotificationQueue:       notificationQueue,
+		InterruptionUseCase:     interruptionUC,
+		HandoffUseCase:          handoffUC,
+		WebhookUseCase:          webhookUC,
+		IdentityUseCase:         identityUC,
+		PayrollUseCase:          payrollUC,
+		LocaleUseCase:           localeUC,
+		ReferenceUseCase:        referenceUC,
+		SwapUseCase:             swapUC,
+		ServiceNoteUseCase:      serviceNoteUC,
+		TimesheetUseCase:        timesheetUC,
+		AnnouncementUseCase:     announcementUC,
+		InvoiceUseCase:          invoiceUC,
+		RetentionUseCase:        retentionUC,
+		AvailabilityUseCase:     availabilityUC,
+		ProfileChangeUseCase:    profileChangeUC,
+		AccessUseCase:           accessUC,
+		DeactivationUseCase:     deactivationUC,
 	}, nil
 }
 
@@ -86,7 +966,7 @@ func NewTestApplicationContext(
 	mockJWTService security.IJWTService,
 	loggerInstance *logger.Logger,
 ) *ApplicationContext {
-	authUC := authUseCase.NewAuthUseCase(mockUserRepo, mockJWTService, loggerInstance)
+	authUC := authUseCase.NewAuthUseCase(mockUserRepo, nil, mockJWTService, security.NewPasswordService(), loggerInstance)
 	userUC := userUseCase.NewUserUseCase(mockUserRepo, loggerInstance)
 	scheduleUC := scheduleUseCase.NewScheduleUseCase(mockScheduleRepo, mockUserRepo, loggerInstance)
 
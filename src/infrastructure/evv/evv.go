@@ -0,0 +1,63 @@
+package evv
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	domainEVV "caregiver/src/domain/evv"
+)
+
+// Result is an aggregator's answer to a submitted visit.
+type Result struct {
+	Accepted   bool
+	ExternalID string
+	Code       string
+	Message    string
+}
+
+// IAdapter delivers visit records to one kind of aggregator API. Submit
+// returns an error only for failures worth retrying (network problems,
+// server errors); a refusal of the record itself is a Result that is not
+// accepted.
+type IAdapter interface {
+	Submit(ctx context.Context, aggregator *domainEVV.Aggregator, record *domainEVV.Record) (*Result, error)
+}
+
+// Registry maps the adapter names aggregators are configured with to their
+// implementations. State-specific adapters are added here.
+type Registry map[string]IAdapter
+
+// NewRegistry returns the built-in adapters: "rest" for JSON APIs and "soap"
+// for SOAP 1.1 services.
+func NewRegistry() Registry {
+	client := &http.Client{Timeout: 30 * time.Second}
+	return Registry{
+		"rest": &RESTAdapter{Client: client},
+		"soap": &SOAPAdapter{Client: client},
+	}
+}
+
+// apiKey reads the credential for the aggregator's state. The variable name
+// is derived from the state rather than taken from the stored record, so an
+// aggregator can never be made to send another secret.
+func apiKey(aggregator *domainEVV.Aggregator) string {
+	if aggregator.State == "" {
+		return ""
+	}
+	return os.Getenv(domainEVV.KeyEnvForState(aggregator.State))
+}
+
+// retryable reports whether an HTTP status is a temporary condition rather
+// than a verdict on the record.
+func retryable(status int) bool {
+	return status >= 500 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
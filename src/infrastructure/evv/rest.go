@@ -0,0 +1,113 @@
+package evv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	domainEVV "caregiver/src/domain/evv"
+)
+
+// RESTAdapter posts the visit as JSON to the aggregator endpoint. A 2xx reply
+// may carry {"id", "status", "code", "message"}; "status": "rejected" is a
+// refusal, anything else an acceptance. Other 4xx replies are refusals.
+type RESTAdapter struct {
+	Client *http.Client
+}
+
+type restVisit struct {
+	VisitID            string   `json:"visitId"`
+	ServiceName        string   `json:"serviceName"`
+	ClientID           string   `json:"clientId"`
+	ClientName         string   `json:"clientName"`
+	CaregiverID        string   `json:"caregiverId"`
+	CaregiverName      string   `json:"caregiverName"`
+	ServiceDate        string   `json:"serviceDate"`
+	StartTime          string   `json:"startTime"`
+	EndTime            string   `json:"endTime"`
+	StartLatitude      *float64 `json:"startLatitude,omitempty"`
+	StartLongitude     *float64 `json:"startLongitude,omitempty"`
+	EndLatitude        *float64 `json:"endLatitude,omitempty"`
+	EndLongitude       *float64 `json:"endLongitude,omitempty"`
+	VerificationMethod string   `json:"verificationMethod"`
+	ClientAttestation  string   `json:"clientAttestation,omitempty"`
+	Payer              string   `json:"payer,omitempty"`
+}
+
+type restReply struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (a *RESTAdapter) Submit(ctx context.Context, aggregator *domainEVV.Aggregator, record *domainEVV.Record) (*Result, error) {
+	body, err := json.Marshal(restVisit{
+		VisitID:            record.ScheduleID.String(),
+		ServiceName:        record.ServiceName,
+		ClientID:           record.ClientUserID.String(),
+		ClientName:         record.ClientName,
+		CaregiverID:        record.CaregiverUserID.String(),
+		CaregiverName:      record.CaregiverName,
+		ServiceDate:        record.ServiceDate,
+		StartTime:          record.CheckinTime.Format(time.RFC3339),
+		EndTime:            record.CheckoutTime.Format(time.RFC3339),
+		StartLatitude:      record.CheckinLat,
+		StartLongitude:     record.CheckinLong,
+		EndLatitude:        record.CheckoutLat,
+		EndLongitude:       record.CheckoutLong,
+		VerificationMethod: record.VerificationMethod,
+		ClientAttestation:  record.AttestationStatus,
+		Payer:              aggregator.Payer,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, aggregator.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if key := apiKey(aggregator); key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if retryable(resp.StatusCode) {
+		return nil, fmt.Errorf("aggregator returned status %d", resp.StatusCode)
+	}
+
+	var reply restReply
+	_ = json.Unmarshal(raw, &reply)
+	if resp.StatusCode >= 300 {
+		code := reply.Code
+		if code == "" {
+			code = fmt.Sprintf("HTTP_%d", resp.StatusCode)
+		}
+		message := reply.Message
+		if message == "" {
+			message = truncate(string(raw), 500)
+		}
+		return &Result{Code: code, Message: message}, nil
+	}
+	return &Result{
+		Accepted:   reply.Status != "rejected",
+		ExternalID: reply.ID,
+		Code:       reply.Code,
+		Message:    reply.Message,
+	}, nil
+}
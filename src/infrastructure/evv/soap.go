@@ -0,0 +1,146 @@
+package evv
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	domainEVV "caregiver/src/domain/evv"
+)
+
+// SOAPAdapter sends the visit as a SOAP 1.1 SubmitVisit call. The reply's
+// SubmitVisitResponse carries Accepted, VisitID, ErrorCode and ErrorMessage.
+// A Client fault is a refusal; a Server fault is retried.
+type SOAPAdapter struct {
+	Client *http.Client
+}
+
+type soapEnvelope struct {
+	XMLName xml.Name `xml:"soap:Envelope"`
+	Soap    string   `xml:"xmlns:soap,attr"`
+	Body    soapBody `xml:"soap:Body"`
+}
+
+type soapBody struct {
+	SubmitVisit soapSubmitVisit `xml:"SubmitVisit"`
+}
+
+type soapSubmitVisit struct {
+	APIKey string    `xml:"ApiKey,omitempty"`
+	Payer  string    `xml:"Payer,omitempty"`
+	Visit  soapVisit `xml:"Visit"`
+}
+
+type soapVisit struct {
+	VisitID            string `xml:"VisitID"`
+	ServiceName        string `xml:"ServiceName"`
+	ClientID           string `xml:"ClientID"`
+	ClientName         string `xml:"ClientName"`
+	CaregiverID        string `xml:"CaregiverID"`
+	CaregiverName      string `xml:"CaregiverName"`
+	ServiceDate        string `xml:"ServiceDate"`
+	StartTime          string `xml:"StartTime"`
+	EndTime            string `xml:"EndTime"`
+	StartLatitude      string `xml:"StartLatitude,omitempty"`
+	StartLongitude     string `xml:"StartLongitude,omitempty"`
+	EndLatitude        string `xml:"EndLatitude,omitempty"`
+	EndLongitude       string `xml:"EndLongitude,omitempty"`
+	VerificationMethod string `xml:"VerificationMethod"`
+	ClientAttestation  string `xml:"ClientAttestation,omitempty"`
+}
+
+type soapReply struct {
+	Body struct {
+		Response *struct {
+			Accepted     bool   `xml:"Accepted"`
+			VisitID      string `xml:"VisitID"`
+			ErrorCode    string `xml:"ErrorCode"`
+			ErrorMessage string `xml:"ErrorMessage"`
+		} `xml:"SubmitVisitResponse"`
+		Fault *struct {
+			Code   string `xml:"faultcode"`
+			String string `xml:"faultstring"`
+		} `xml:"Fault"`
+	} `xml:"Body"`
+}
+
+func (a *SOAPAdapter) Submit(ctx context.Context, aggregator *domainEVV.Aggregator, record *domainEVV.Record) (*Result, error) {
+	envelope := soapEnvelope{
+		Soap: "http://schemas.xmlsoap.org/soap/envelope/",
+		Body: soapBody{SubmitVisit: soapSubmitVisit{
+			APIKey: apiKey(aggregator),
+			Payer:  aggregator.Payer,
+			Visit: soapVisit{
+				VisitID:            record.ScheduleID.String(),
+				ServiceName:        record.ServiceName,
+				ClientID:           record.ClientUserID.String(),
+				ClientName:         record.ClientName,
+				CaregiverID:        record.CaregiverUserID.String(),
+				CaregiverName:      record.CaregiverName,
+				ServiceDate:        record.ServiceDate,
+				StartTime:          record.CheckinTime.Format(time.RFC3339),
+				EndTime:            record.CheckoutTime.Format(time.RFC3339),
+				StartLatitude:      formatCoordinate(record.CheckinLat),
+				StartLongitude:     formatCoordinate(record.CheckinLong),
+				EndLatitude:        formatCoordinate(record.CheckoutLat),
+				EndLongitude:       formatCoordinate(record.CheckoutLong),
+				VerificationMethod: record.VerificationMethod,
+				ClientAttestation:  record.AttestationStatus,
+			},
+		}},
+	}
+	body, err := xml.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, aggregator.Endpoint, bytes.NewReader(append([]byte(xml.Header), body...)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", "SubmitVisit")
+
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	// SOAP faults arrive with status 500, so the body decides.
+	var reply soapReply
+	if err := xml.Unmarshal(raw, &reply); err != nil {
+		if retryable(resp.StatusCode) {
+			return nil, fmt.Errorf("aggregator returned status %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("unreadable aggregator reply: %w", err)
+	}
+	if fault := reply.Body.Fault; fault != nil {
+		if strings.HasSuffix(fault.Code, "Client") {
+			return &Result{Code: fault.Code, Message: fault.String}, nil
+		}
+		return nil, fmt.Errorf("aggregator fault %s: %s", fault.Code, fault.String)
+	}
+	if reply.Body.Response == nil {
+		return nil, fmt.Errorf("aggregator returned status %d without a SubmitVisitResponse", resp.StatusCode)
+	}
+	r := reply.Body.Response
+	return &Result{Accepted: r.Accepted, ExternalID: r.VisitID, Code: r.ErrorCode, Message: r.ErrorMessage}, nil
+}
+
+func formatCoordinate(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', 6, 64)
+}
@@ -0,0 +1,181 @@
+package holiday
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	domainLocale "caregiver/src/domain/locale"
+)
+
+// observance moves a holiday falling on a weekend to a weekday off.
+type observance int
+
+const (
+	// observeNone keeps the holiday on its date.
+	observeNone observance = iota
+	// observeNearest moves Saturday to Friday and Sunday to Monday.
+	observeNearest
+	// observeMonday moves a weekend holiday to the next free weekday.
+	observeMonday
+)
+
+// rule computes the date of one holiday in a year.
+type rule struct {
+	name    string
+	date    func(year int) time.Time
+	observe bool
+}
+
+type calendar struct {
+	observance observance
+	rules      []rule
+}
+
+// builtinCalendars are the nationwide public holidays of the countries the
+// built-in source knows. Subdivision holidays need the API provider.
+var builtinCalendars = map[string]calendar{
+	"US": {observeNearest, []rule{
+		{"New Year's Day", fixed(time.January, 1), true},
+		{"Martin Luther King, Jr. Day", nth(time.January, time.Monday, 3), false},
+		{"Washington's Birthday", nth(time.February, time.Monday, 3), false},
+		{"Memorial Day", nth(time.May, time.Monday, -1), false},
+		{"Juneteenth National Independence Day", fixed(time.June, 19), true},
+		{"Independence Day", fixed(time.July, 4), true},
+		{"Labor Day", nth(time.September, time.Monday, 1), false},
+		{"Columbus Day", nth(time.October, time.Monday, 2), false},
+		{"Veterans Day", fixed(time.November, 11), true},
+		{"Thanksgiving Day", nth(time.November, time.Thursday, 4), false},
+		{"Christmas Day", fixed(time.December, 25), true},
+	}},
+	"CA": {observeMonday, []rule{
+		{"New Year's Day", fixed(time.January, 1), true},
+		{"Good Friday", easter(-2), false},
+		{"Victoria Day", mondayBefore(time.May, 25), false},
+		{"Canada Day", fixed(time.July, 1), true},
+		{"Labour Day", nth(time.September, time.Monday, 1), false},
+		{"National Day for Truth and Reconciliation", fixed(time.September, 30), true},
+		{"Thanksgiving", nth(time.October, time.Monday, 2), false},
+		{"Remembrance Day", fixed(time.November, 11), true},
+		{"Christmas Day", fixed(time.December, 25), true},
+		{"Boxing Day", fixed(time.December, 26), true},
+	}},
+	"GB": {observeMonday, []rule{
+		{"New Year's Day", fixed(time.January, 1), true},
+		{"Good Friday", easter(-2), false},
+		{"Easter Monday", easter(1), false},
+		{"Early May Bank Holiday", nth(time.May, time.Monday, 1), false},
+		{"Spring Bank Holiday", nth(time.May, time.Monday, -1), false},
+		{"Summer Bank Holiday", nth(time.August, time.Monday, -1), false},
+		{"Christmas Day", fixed(time.December, 25), true},
+		{"Boxing Day", fixed(time.December, 26), true},
+	}},
+	"MX": {observeNone, []rule{
+		{"Año Nuevo", fixed(time.January, 1), false},
+		{"Día de la Constitución", nth(time.February, time.Monday, 1), false},
+		{"Natalicio de Benito Juárez", nth(time.March, time.Monday, 3), false},
+		{"Día del Trabajo", fixed(time.May, 1), false},
+		{"Día de la Independencia", fixed(time.September, 16), false},
+		{"Día de la Revolución", nth(time.November, time.Monday, 3), false},
+		{"Navidad", fixed(time.December, 25), false},
+	}},
+}
+
+// Builtin computes the public holidays of a few countries from their rules,
+// without any network access.
+type Builtin struct{}
+
+func (Builtin) Holidays(region string, year int) ([]domainLocale.Holiday, error) {
+	country, _ := splitRegion(region)
+	cal, ok := builtinCalendars[country]
+	if !ok {
+		return nil, fmt.Errorf("no built-in holidays for region %q", country)
+	}
+	taken := make(map[string]bool, len(cal.rules))
+	dates := make([]time.Time, len(cal.rules))
+	for i, r := range cal.rules {
+		dates[i] = r.date(year)
+		taken[dates[i].Format(domainLocale.DateLayout)] = true
+	}
+	holidays := make([]domainLocale.Holiday, 0, len(cal.rules))
+	for i, r := range cal.rules {
+		date := dates[i]
+		if r.observe {
+			date = observe(date, cal.observance, taken)
+		}
+		holidays = append(holidays, domainLocale.Holiday{Date: date.Format(domainLocale.DateLayout), Name: r.name, Region: region})
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date < holidays[j].Date })
+	return holidays, nil
+}
+
+// observe returns the day off for a holiday on date. A day already taken by
+// another holiday pushes a substitute further along, so Christmas and Boxing
+// Day on a weekend give Monday and Tuesday.
+func observe(date time.Time, o observance, taken map[string]bool) time.Time {
+	switch o {
+	case observeNearest:
+		switch date.Weekday() {
+		case time.Saturday:
+			return date.AddDate(0, 0, -1)
+		case time.Sunday:
+			return date.AddDate(0, 0, 1)
+		}
+	case observeMonday:
+		if date.Weekday() != time.Saturday && date.Weekday() != time.Sunday {
+			return date
+		}
+		day := date
+		for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday || taken[day.Format(domainLocale.DateLayout)] {
+			day = day.AddDate(0, 0, 1)
+		}
+		taken[day.Format(domainLocale.DateLayout)] = true
+		return day
+	}
+	return date
+}
+
+func fixed(month time.Month, day int) func(int) time.Time {
+	return func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// nth is the n-th weekday of the month, or the last one for n = -1.
+func nth(month time.Month, weekday time.Weekday, n int) func(int) time.Time {
+	return func(year int) time.Time {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+			return last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7))
+		}
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		return first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+7*(n-1))
+	}
+}
+
+// mondayBefore is the last Monday before the given day.
+func mondayBefore(month time.Month, day int) func(int) time.Time {
+	return func(year int) time.Time {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		return date.AddDate(0, 0, -((int(date.Weekday()) - int(time.Monday) + 7) % 7))
+	}
+}
+
+// easter is offset days from Western Easter Sunday, by the anonymous
+// Gregorian algorithm.
+func easter(offset int) func(int) time.Time {
+	return func(year int) time.Time {
+		a := year % 19
+		b, c := year/100, year%100
+		d, e := b/4, b%4
+		f := (b + 8) / 25
+		g := (b - f + 1) / 3
+		h := (19*a + b - d - g + 15) % 30
+		i, k := c/4, c%4
+		l := (32 + 2*e + 2*i - h - k) % 7
+		m := (a + 11*h + 22*l) / 451
+		month := (h + l - 7*m + 114) / 31
+		day := (h+l-7*m+114)%31 + 1
+		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, offset)
+	}
+}
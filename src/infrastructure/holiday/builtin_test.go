@@ -0,0 +1,60 @@
+package holiday
+
+import (
+	"testing"
+)
+
+func TestBuiltinHolidays(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		year   int
+		want   map[string]string
+	}{
+		{"US observes weekend holidays on the nearest weekday", "US", 2026, map[string]string{
+			"2026-01-19": "Martin Luther King, Jr. Day",
+			"2026-05-25": "Memorial Day",
+			"2026-07-03": "Independence Day",
+			"2026-11-26": "Thanksgiving Day",
+		}},
+		{"Subdivisions fall back to the country", "US-CA", 2028, map[string]string{
+			"2027-12-31": "New Year's Day",
+			"2028-12-25": "Christmas Day",
+		}},
+		{"GB substitutes Christmas and Boxing Day in turn", "GB", 2027, map[string]string{
+			"2027-03-26": "Good Friday",
+			"2027-03-29": "Easter Monday",
+			"2027-12-27": "Christmas Day",
+			"2027-12-28": "Boxing Day",
+		}},
+		{"CA Victoria Day is the Monday before May 25", "CA", 2026, map[string]string{
+			"2026-04-03": "Good Friday",
+			"2026-05-18": "Victoria Day",
+		}},
+		{"MX keeps holidays on their date", "MX", 2026, map[string]string{
+			"2026-02-02": "Día de la Constitución",
+			"2026-11-16": "Día de la Revolución",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holidays, err := Builtin{}.Holidays(tt.region, tt.year)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make(map[string]string, len(holidays))
+			for _, h := range holidays {
+				got[h.Date] = h.Name
+			}
+			for date, name := range tt.want {
+				if got[date] != name {
+					t.Errorf("expected %s on %s, got %q", name, date, got[date])
+				}
+			}
+		})
+	}
+
+	if _, err := (Builtin{}).Holidays("ZZ", 2026); err == nil {
+		t.Error("expected an unknown region to be refused")
+	}
+}
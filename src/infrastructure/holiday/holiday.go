@@ -0,0 +1,75 @@
+package holiday
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	domainLocale "caregiver/src/domain/locale"
+)
+
+// defaultAPIURL is the public Nager.Date API.
+const defaultAPIURL = "https://date.nager.at/api/v3"
+
+// ISource lists the public holidays of a region in a year. Region is an ISO
+// 3166 country code, optionally followed by a subdivision ("CA-ON").
+type ISource interface {
+	Holidays(region string, year int) ([]domainLocale.Holiday, error)
+}
+
+// NewSourceFromEnv returns the Nager.Date client when HOLIDAY_PROVIDER is
+// "nager" (HOLIDAY_API_URL overrides its address), otherwise the built-in
+// rules. Either way yearly lists are cached.
+func NewSourceFromEnv() ISource {
+	var source ISource = Builtin{}
+	if strings.EqualFold(os.Getenv("HOLIDAY_PROVIDER"), "nager") {
+		apiURL := os.Getenv("HOLIDAY_API_URL")
+		if apiURL == "" {
+			apiURL = defaultAPIURL
+		}
+		source = &Nager{URL: apiURL, Client: &http.Client{Timeout: 10 * time.Second}}
+	}
+	return NewCache(source)
+}
+
+// Cache keeps the holidays of each region and year once fetched. Holiday
+// lists do not change within a year often enough to refetch them.
+type Cache struct {
+	source ISource
+	mu     sync.Mutex
+	years  map[string][]domainLocale.Holiday
+}
+
+func NewCache(source ISource) *Cache {
+	return &Cache{source: source, years: make(map[string][]domainLocale.Holiday)}
+}
+
+func (c *Cache) Holidays(region string, year int) ([]domainLocale.Holiday, error) {
+	key := fmt.Sprintf("%s/%d", strings.ToUpper(region), year)
+	c.mu.Lock()
+	holidays, ok := c.years[key]
+	c.mu.Unlock()
+	if ok {
+		return holidays, nil
+	}
+	holidays, err := c.source.Holidays(region, year)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.years[key] = holidays
+	c.mu.Unlock()
+	return holidays, nil
+}
+
+// splitRegion separates "CA-ON" into its country and full subdivision code.
+func splitRegion(region string) (country string, subdivision string) {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if i := strings.Index(region, "-"); i > 0 {
+		return region[:i], region
+	}
+	return region, ""
+}
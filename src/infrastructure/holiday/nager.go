@@ -0,0 +1,77 @@
+package holiday
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	domainLocale "caregiver/src/domain/locale"
+)
+
+// Nager reads public holidays from a Nager.Date compatible API.
+type Nager struct {
+	URL    string
+	Client *http.Client
+}
+
+type nagerHoliday struct {
+	Date      string   `json:"date"`
+	LocalName string   `json:"localName"`
+	Name      string   `json:"name"`
+	Global    bool     `json:"global"`
+	Counties  []string `json:"counties"`
+	Types     []string `json:"types"`
+}
+
+func (n *Nager) Holidays(region string, year int) ([]domainLocale.Holiday, error) {
+	country, subdivision := splitRegion(region)
+	resp, err := n.Client.Get(fmt.Sprintf("%s/PublicHolidays/%d/%s", strings.TrimRight(n.URL, "/"), year, country))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusNoContent {
+		return nil, fmt.Errorf("holiday provider does not know region %q", country)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("holiday provider returned status %d", resp.StatusCode)
+	}
+	var reply []nagerHoliday
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return nil, err
+	}
+	var holidays []domainLocale.Holiday
+	for _, h := range reply {
+		if !appliesTo(h, subdivision) {
+			continue
+		}
+		holidays = append(holidays, domainLocale.Holiday{Date: h.Date, Name: h.Name, Region: region})
+	}
+	return holidays, nil
+}
+
+// appliesTo keeps nationwide public holidays and those of the subdivision.
+// Bank and school holidays are not days off for care work.
+func appliesTo(h nagerHoliday, subdivision string) bool {
+	if len(h.Types) > 0 {
+		public := false
+		for _, t := range h.Types {
+			if t == "Public" {
+				public = true
+			}
+		}
+		if !public {
+			return false
+		}
+	}
+	if h.Global || len(h.Counties) == 0 {
+		return true
+	}
+	for _, county := range h.Counties {
+		if strings.EqualFold(county, subdivision) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+)
+
+// ErrNotConfigured is returned for a platform without a verifier.
+var ErrNotConfigured = errors.New("integrity verification not configured")
+
+// ErrInvalidToken is returned when the provider rejects the token itself:
+// forged, expired, or issued for another app or request.
+var ErrInvalidToken = errors.New("integrity token is invalid")
+
+// Verdict is what the platform concluded about the app and its device.
+type Verdict struct {
+	// Intact is set for a genuine app on a device passing the platform's
+	// integrity checks.
+	Intact bool
+	// Rooted is set when the device failed even basic integrity: rooted,
+	// jailbroken or otherwise tampered with.
+	Rooted   bool
+	Emulator bool
+	// Detail lists the provider's verdict labels.
+	Detail string
+}
+
+// IVerifier checks an attestation token from one platform. The nonce is
+// what the app was told to bind the token to.
+type IVerifier interface {
+	Verify(ctx context.Context, token string, nonce string) (*Verdict, error)
+}
+
+// Registry maps platforms to their verifiers.
+type Registry map[string]IVerifier
+
+// Verify checks token with the platform's verifier.
+func (r Registry) Verify(ctx context.Context, platform, token, nonce string) (*Verdict, error) {
+	verifier, ok := r[platform]
+	if !ok {
+		return nil, fmt.Errorf("%w for %q", ErrNotConfigured, platform)
+	}
+	return verifier.Verify(ctx, token, nonce)
+}
+
+// NewRegistryFromEnv returns the built-in verifiers that are configured:
+// Play Integrity for "android" when PLAY_INTEGRITY_PACKAGE and
+// PLAY_INTEGRITY_CREDENTIALS (a service account key file) are set. Other
+// platforms, such as App Attest, are added by plugins.
+func NewRegistryFromEnv() (Registry, error) {
+	registry := Registry{}
+	packageName := os.Getenv("PLAY_INTEGRITY_PACKAGE")
+	credentials := os.Getenv("PLAY_INTEGRITY_CREDENTIALS")
+	if packageName == "" || credentials == "" {
+		return registry, nil
+	}
+	key, err := os.ReadFile(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("reading PLAY_INTEGRITY_CREDENTIALS: %w", err)
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	tokens, err := newServiceAccountTokens(key, playIntegrityScope, client)
+	if err != nil {
+		return nil, fmt.Errorf("PLAY_INTEGRITY_CREDENTIALS: %w", err)
+	}
+	registry[domainSchedule.PlatformAndroid] = NewPlayIntegrityVerifier(packageName, tokens, client)
+	return registry, nil
+}
@@ -0,0 +1,141 @@
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPlayIntegrityURL = "https://playintegrity.googleapis.com/v1"
+	playIntegrityScope      = "https://www.googleapis.com/auth/playintegrity"
+	// playIntegrityMaxAge is how old a token may be when it is checked.
+	playIntegrityMaxAge = 10 * time.Minute
+)
+
+// PlayIntegrityVerifier decodes Play Integrity tokens with Google's
+// decodeIntegrityToken API. The app binds the token to the nonce as the
+// request hash of a standard request, or as the nonce of a classic one.
+type PlayIntegrityVerifier struct {
+	URL         string
+	PackageName string
+	Tokens      accessTokenSource
+	Client      *http.Client
+	now         func() time.Time
+}
+
+// accessTokenSource gives OAuth access tokens for Google APIs.
+type accessTokenSource interface {
+	AccessToken(ctx context.Context) (string, error)
+}
+
+func NewPlayIntegrityVerifier(packageName string, tokens accessTokenSource, client *http.Client) *PlayIntegrityVerifier {
+	return &PlayIntegrityVerifier{
+		URL:         defaultPlayIntegrityURL,
+		PackageName: packageName,
+		Tokens:      tokens,
+		Client:      client,
+		now:         time.Now,
+	}
+}
+
+type playIntegrityReply struct {
+	TokenPayloadExternal struct {
+		RequestDetails struct {
+			RequestPackageName string `json:"requestPackageName"`
+			Nonce              string `json:"nonce"`
+			RequestHash        string `json:"requestHash"`
+			TimestampMillis    string `json:"timestampMillis"`
+		} `json:"requestDetails"`
+		AppIntegrity struct {
+			AppRecognitionVerdict string `json:"appRecognitionVerdict"`
+		} `json:"appIntegrity"`
+		DeviceIntegrity struct {
+			DeviceRecognitionVerdict []string `json:"deviceRecognitionVerdict"`
+		} `json:"deviceIntegrity"`
+	} `json:"tokenPayloadExternal"`
+}
+
+func (v *PlayIntegrityVerifier) Verify(ctx context.Context, token string, nonce string) (*Verdict, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, ErrInvalidToken
+	}
+	accessToken, err := v.Tokens.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, _ := json.Marshal(map[string]string{"integrity_token": token})
+	endpoint := fmt.Sprintf("%s/%s:decodeIntegrityToken", v.URL, url.PathEscape(v.PackageName))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusBadRequest {
+		return nil, ErrInvalidToken
+	}
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("play integrity returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	var reply playIntegrityReply
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return nil, err
+	}
+
+	payload := reply.TokenPayloadExternal
+	details := payload.RequestDetails
+	if details.RequestPackageName != v.PackageName {
+		return nil, fmt.Errorf("%w: issued for %q", ErrInvalidToken, details.RequestPackageName)
+	}
+	if details.RequestHash != nonce && decodeNonce(details.Nonce) != nonce {
+		return nil, fmt.Errorf("%w: issued for another request", ErrInvalidToken)
+	}
+	issued, err := strconv.ParseInt(details.TimestampMillis, 10, 64)
+	if err != nil || v.now().Sub(time.UnixMilli(issued)) > playIntegrityMaxAge {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+
+	labels := map[string]bool{}
+	for _, label := range payload.DeviceIntegrity.DeviceRecognitionVerdict {
+		labels[label] = true
+	}
+	app := payload.AppIntegrity.AppRecognitionVerdict
+	device := strings.Join(payload.DeviceIntegrity.DeviceRecognitionVerdict, ", ")
+	if device == "" {
+		device = "no device verdict"
+	}
+	// Only the strongest label is listed unless the app opted in to all.
+	meetsDevice := labels["MEETS_DEVICE_INTEGRITY"] || labels["MEETS_STRONG_INTEGRITY"]
+	meetsBasic := meetsDevice || labels["MEETS_BASIC_INTEGRITY"]
+	return &Verdict{
+		Intact:   app == "PLAY_RECOGNIZED" && meetsDevice,
+		Rooted:   !meetsBasic && !labels["MEETS_VIRTUAL_INTEGRITY"],
+		Emulator: labels["MEETS_VIRTUAL_INTEGRITY"],
+		Detail:   fmt.Sprintf("app %s; device %s", app, device),
+	}, nil
+}
+
+// decodeNonce reads a classic request's nonce, which the app base64-encodes
+// web-safe, with or without padding.
+func decodeNonce(value string) string {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return ""
+	}
+	return string(decoded)
+}
@@ -0,0 +1,82 @@
+package integrity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type staticTokens string
+
+func (s staticTokens) AccessToken(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+func TestPlayIntegrityVerify(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	verdicts := map[string][]string{
+		"intact":   {"MEETS_DEVICE_INTEGRITY"},
+		"rooted":   {},
+		"emulator": {"MEETS_VIRTUAL_INTEGRITY"},
+		"stale":    {"MEETS_DEVICE_INTEGRITY"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/com.example.care:decodeIntegrityToken" || r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		labels, ok := verdicts[body["integrity_token"]]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		issued := now.Add(-time.Minute)
+		if body["integrity_token"] == "stale" {
+			issued = now.Add(-time.Hour)
+		}
+		reply := map[string]interface{}{"tokenPayloadExternal": map[string]interface{}{
+			"requestDetails": map[string]string{
+				"requestPackageName": "com.example.care",
+				"requestHash":        "visit-1",
+				"timestampMillis":    strconv.FormatInt(issued.UnixMilli(), 10),
+			},
+			"appIntegrity":    map[string]string{"appRecognitionVerdict": "PLAY_RECOGNIZED"},
+			"deviceIntegrity": map[string][]string{"deviceRecognitionVerdict": labels},
+		}}
+		_ = json.NewEncoder(w).Encode(reply)
+	}))
+	defer server.Close()
+
+	v := NewPlayIntegrityVerifier("com.example.care", staticTokens("access"), server.Client())
+	v.URL = server.URL
+	v.now = func() time.Time { return now }
+
+	verdict, err := v.Verify(context.Background(), "intact", "visit-1")
+	if err != nil || !verdict.Intact || verdict.Rooted {
+		t.Errorf("expected an intact device, got %+v, %v", verdict, err)
+	}
+	if verdict, err := v.Verify(context.Background(), "rooted", "visit-1"); err != nil || verdict.Intact || !verdict.Rooted {
+		t.Errorf("expected a rooted device, got %+v, %v", verdict, err)
+	}
+	if verdict, err := v.Verify(context.Background(), "emulator", "visit-1"); err != nil || verdict.Intact || !verdict.Emulator || verdict.Rooted {
+		t.Errorf("expected an emulator, got %+v, %v", verdict, err)
+	}
+	for _, tt := range []struct{ token, nonce string }{{"intact", "visit-2"}, {"stale", "visit-1"}, {"forged", "visit-1"}} {
+		if _, err := v.Verify(context.Background(), tt.token, tt.nonce); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s for %s: expected ErrInvalidToken, got %v", tt.token, tt.nonce, err)
+		}
+	}
+}
+
+func TestRegistryWithoutVerifier(t *testing.T) {
+	if _, err := (Registry{}).Verify(context.Background(), "ios", "token", "nonce"); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("expected ErrNotConfigured, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package integrity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const defaultTokenURL = "https://oauth2.googleapis.com/token"
+
+// serviceAccountTokens exchanges a signed JWT for Google API access tokens,
+// caching each until shortly before it expires.
+type serviceAccountTokens struct {
+	email    string
+	key      interface{}
+	tokenURL string
+	scope    string
+	client   *http.Client
+	now      func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+func newServiceAccountTokens(keyFile []byte, scope string, client *http.Client) (*serviceAccountTokens, error) {
+	var account serviceAccountKey
+	if err := json.Unmarshal(keyFile, &account); err != nil {
+		return nil, err
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, errors.New("service account key needs client_email and private_key")
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	tokenURL := account.TokenURI
+	if tokenURL == "" {
+		tokenURL = defaultTokenURL
+	}
+	return &serviceAccountTokens{email: account.ClientEmail, key: key, tokenURL: tokenURL, scope: scope, client: client, now: time.Now}, nil
+}
+
+func (s *serviceAccountTokens) AccessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if s.token != "" && now.Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   s.email,
+		"scope": s.scope,
+		"aud":   s.tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(s.key)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"}, "assertion": {assertion}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token exchange returned %s", resp.Status)
+	}
+	var reply struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return "", err
+	}
+	s.token = reply.AccessToken
+	// Renew a minute early so a token never expires mid-request.
+	s.expiresAt = now.Add(time.Duration(reply.ExpiresIn)*time.Second - time.Minute)
+	return s.token, nil
+}
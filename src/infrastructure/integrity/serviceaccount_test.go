@@ -0,0 +1,67 @@
+package integrity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestServiceAccountTokens(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchanges := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		claims := jwt.MapClaims{}
+		_, err := jwt.NewParser(jwt.WithoutClaimsValidation()).ParseWithClaims(r.PostForm.Get("assertion"), claims, func(token *jwt.Token) (interface{}, error) {
+			return &key.PublicKey, nil
+		})
+		if err != nil || claims["iss"] != "verifier@example.iam.gserviceaccount.com" || claims["scope"] != playIntegrityScope {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		exchanges++
+		_, _ = w.Write([]byte(`{"access_token":"access","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	keyFile, _ := json.Marshal(serviceAccountKey{
+		ClientEmail: "verifier@example.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		TokenURI:    server.URL,
+	})
+	tokens, err := newServiceAccountTokens(keyFile, playIntegrityScope, server.Client())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	tokens.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if token, err := tokens.AccessToken(context.Background()); err != nil || token != "access" {
+			t.Fatalf("expected the exchanged token, got %q, %v", token, err)
+		}
+	}
+	if exchanges != 1 {
+		t.Errorf("expected the token cached, got %d exchanges", exchanges)
+	}
+	now = now.Add(time.Hour)
+	if _, err := tokens.AccessToken(context.Background()); err != nil || exchanges != 2 {
+		t.Errorf("expected a new token once expired, got %d exchanges, %v", exchanges, err)
+	}
+
+	if _, err := newServiceAccountTokens([]byte(`{"client_email":"x"}`), playIntegrityScope, server.Client()); err == nil {
+		t.Error("expected an error for a key file without a private key")
+	}
+}
@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"os"
+	"time"
+
+	logger "caregiver/src/infrastructure/logger"
+
+	"go.uber.org/zap"
+)
+
+// Job is work the server repeats in the background on a fixed interval.
+type Job struct {
+	Name string
+	// IntervalEnv names the environment variable holding the interval as a Go
+	// duration; "0" disables the job.
+	IntervalEnv     string
+	DefaultInterval time.Duration
+	// Run is passed the tick time in UTC.
+	Run func(now time.Time) error
+}
+
+// Interval is how often the job runs, false when it is disabled or the
+// configured interval is not a valid duration.
+func (j Job) Interval() (time.Duration, bool) {
+	value := os.Getenv(j.IntervalEnv)
+	if value == "" {
+		return j.DefaultInterval, j.DefaultInterval > 0
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return 0, false
+	}
+	return interval, true
+}
+
+// Start runs every enabled job on its own ticker. A failed run is logged and
+// the job runs again on the next tick.
+func Start(jobs []Job, loggerInstance *logger.Logger) {
+	for _, job := range jobs {
+		interval, ok := job.Interval()
+		if !ok {
+			loggerInstance.Info("Background job disabled", zap.String("job", job.Name))
+			continue
+		}
+		go run(job, interval, loggerInstance)
+	}
+}
+
+func run(job Job, interval time.Duration, loggerInstance *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if err := job.Run(now.UTC()); err != nil {
+			loggerInstance.Error("Background job failed", zap.String("job", job.Name), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJobInterval(t *testing.T) {
+	job := Job{Name: "missed visits", IntervalEnv: "TEST_JOB_INTERVAL", DefaultInterval: time.Minute}
+	tests := []struct {
+		value    string
+		interval time.Duration
+		enabled  bool
+	}{
+		{"", time.Minute, true},
+		{"30s", 30 * time.Second, true},
+		{"0", 0, false},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Setenv(job.IntervalEnv, tt.value)
+		interval, enabled := job.Interval()
+		if interval != tt.interval || enabled != tt.enabled {
+			t.Errorf("%q: expected %v, %v, got %v, %v", tt.value, tt.interval, tt.enabled, interval, enabled)
+		}
+	}
+}
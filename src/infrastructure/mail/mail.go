@@ -0,0 +1,175 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrNotConfigured is returned when no mail server is set up.
+var ErrNotConfigured = errors.New("mail server not configured")
+
+// Attachment is a file sent along with a message.
+type Attachment struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// Message is a plain text email. FromName is shown as the sender beside the
+// configured From address, and replies go to ReplyTo when set.
+type Message struct {
+	To          []string
+	FromName    string
+	ReplyTo     string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+}
+
+// IMailer sends email.
+type IMailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// NewMailerFromEnv returns an SMTP mailer when SMTP_HOST is set, otherwise a
+// mailer that always reports ErrNotConfigured.
+func NewMailerFromEnv() IMailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return disabledMailer{}
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	return &SMTPMailer{
+		Addr:     net.JoinHostPort(host, port),
+		Host:     host,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+}
+
+type disabledMailer struct{}
+
+func (disabledMailer) Send(ctx context.Context, message Message) error {
+	return ErrNotConfigured
+}
+
+// SMTPMailer sends mail through an SMTP relay, authenticating with PLAIN auth
+// when a username is set. net/smtp upgrades to TLS when the server offers
+// STARTTLS.
+type SMTPMailer struct {
+	Addr     string
+	Host     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message Message) error {
+	if len(message.To) == 0 {
+		return errors.New("mail has no recipients")
+	}
+	body, err := m.compose(message)
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(m.Addr, auth, m.From, message.To, body)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// compose builds a multipart/mixed message with the body as the first part
+// and each attachment base64 encoded after it.
+func (m *SMTPMailer) compose(message Message) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	from := (&netmail.Address{Name: message.FromName, Address: m.From}).String()
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(message.To, ", "))
+	if replyTo, err := netmail.ParseAddress(message.ReplyTo); err == nil {
+		fmt.Fprintf(&buf, "Reply-To: %s\r\n", replyTo.String())
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", writer.Boundary())
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", "text/plain; charset=utf-8")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeQuotedPrintable(part, message.Body); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range message.Attachments {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Type", attachment.ContentType)
+		header.Set("Content-Transfer-Encoding", "base64")
+		header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(part, attachment.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, text string) error {
+	encoder := quotedprintable.NewWriter(w)
+	if _, err := encoder.Write([]byte(text)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// writeBase64 writes data base64 encoded in 76 character lines.
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(w, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := io.WriteString(w, encoded+"\r\n")
+	return err
+}
@@ -0,0 +1,143 @@
+package medication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Interaction is a known interaction between two drugs.
+type Interaction struct {
+	DrugA       string `json:"drugA"`
+	DrugB       string `json:"drugB"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// IInteractionChecker looks up interactions between a drug about to be given
+// and the other drugs a client takes.
+type IInteractionChecker interface {
+	Name() string
+	Check(ctx context.Context, drug string, others []string) ([]Interaction, error)
+}
+
+// NewInteractionCheckerFromEnv returns an HTTP checker when
+// MEDICATION_INTERACTIONS_URL is set, a table loaded from the JSON file at
+// MEDICATION_INTERACTIONS_FILE when that is set, and otherwise an empty table
+// that knows no interactions.
+func NewInteractionCheckerFromEnv() (IInteractionChecker, error) {
+	if endpoint := os.Getenv("MEDICATION_INTERACTIONS_URL"); endpoint != "" {
+		return &HTTPChecker{
+			Endpoint: strings.TrimRight(endpoint, "/"),
+			APIKey:   os.Getenv("MEDICATION_INTERACTIONS_API_KEY"),
+			Client:   &http.Client{Timeout: 10 * time.Second},
+		}, nil
+	}
+	if path := os.Getenv("MEDICATION_INTERACTIONS_FILE"); path != "" {
+		return LoadTable(path)
+	}
+	return NewTableChecker(nil), nil
+}
+
+// TableChecker answers from a fixed list of interactions. Drug names match
+// case-insensitively when one contains the other, so "Warfarin 5mg" matches
+// a "warfarin" entry.
+type TableChecker struct {
+	interactions []Interaction
+}
+
+func NewTableChecker(interactions []Interaction) *TableChecker {
+	return &TableChecker{interactions: interactions}
+}
+
+// LoadTable reads a JSON array of interactions.
+func LoadTable(path string) (*TableChecker, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading medication interactions: %w", err)
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(raw, &interactions); err != nil {
+		return nil, fmt.Errorf("parsing medication interactions: %w", err)
+	}
+	return NewTableChecker(interactions), nil
+}
+
+func (t *TableChecker) Name() string { return "table" }
+
+func (t *TableChecker) Check(ctx context.Context, drug string, others []string) ([]Interaction, error) {
+	var res []Interaction
+	for _, other := range others {
+		for _, interaction := range t.interactions {
+			if (Matches(drug, interaction.DrugA) && Matches(other, interaction.DrugB)) ||
+				(Matches(drug, interaction.DrugB) && Matches(other, interaction.DrugA)) {
+				res = append(res, Interaction{DrugA: drug, DrugB: other, Severity: interaction.Severity, Description: interaction.Description})
+			}
+		}
+	}
+	return res, nil
+}
+
+// Matches reports whether two drug or substance names refer to the same
+// thing: equal ignoring case, or one containing the other.
+func Matches(a, b string) bool {
+	a, b = strings.ToLower(strings.TrimSpace(a)), strings.ToLower(strings.TrimSpace(b))
+	if a == "" || b == "" {
+		return false
+	}
+	return strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+// HTTPChecker asks a JSON interaction service:
+//
+//	POST {Endpoint}/interactions/check  {"drug", "others"}
+//
+// which replies {"interactions": [{"drugA", "drugB", "severity", "description"}]}.
+type HTTPChecker struct {
+	Endpoint string
+	APIKey   string
+	Client   *http.Client
+}
+
+func (c *HTTPChecker) Name() string { return "http" }
+
+func (c *HTTPChecker) Check(ctx context.Context, drug string, others []string) ([]Interaction, error) {
+	if len(others) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(map[string]interface{}{"drug": drug, "others": others})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint+"/interactions/check", bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("interaction service returned status %d", resp.StatusCode)
+	}
+	var reply struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&reply); err != nil {
+		return nil, err
+	}
+	return reply.Interactions, nil
+}
@@ -0,0 +1,225 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	domainNotification "caregiver/src/domain/notification"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/mail"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// sendTimeout bounds one delivery attempt on one channel.
+const sendTimeout = 15 * time.Second
+
+// ErrNoAddress is returned by a channel that cannot reach the recipient, for
+// example SMS to a user without a phone number. It is not a failure.
+var ErrNoAddress = errors.New("recipient has no address for this channel")
+
+// Recipient is a user and the addresses they can be reached at. Channels,
+// when set, are the only channels the user wants to be notified on.
+type Recipient struct {
+	UserID       uuid.UUID
+	Name         string
+	Email        string
+	Phone        string
+	DeviceTokens []string
+	Channels     []string
+}
+
+// Accepts reports whether the recipient wants messages on the channel.
+func (r Recipient) Accepts(channel string) bool {
+	if len(r.Channels) == 0 {
+		return true
+	}
+	for _, c := range r.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// IDirectory resolves who a message is addressed to.
+type IDirectory interface {
+	Recipients(message Message) ([]Recipient, error)
+}
+
+// IChannel delivers messages one way: email, SMS or push.
+type IChannel interface {
+	Name() string
+	Send(ctx context.Context, recipient Recipient, message Message) error
+}
+
+// ChannelNotifier queues every message for its recipients and, when Deliver
+// runs, sends it on every channel that can reach each recipient and that
+// they have not opted out of. A failing channel does not stop the others;
+// their errors are returned together.
+type ChannelNotifier struct {
+	directory IDirectory
+	channels  []IChannel
+	mu        sync.Mutex
+	queued    []queuedMessage
+	Logger    *logger.Logger
+}
+
+// queuedMessage is a message waiting to be sent to one recipient.
+type queuedMessage struct {
+	recipient Recipient
+	message   Message
+}
+
+func NewChannelNotifier(directory IDirectory, loggerInstance *logger.Logger, channels ...IChannel) *ChannelNotifier {
+	return &ChannelNotifier{directory: directory, channels: channels, Logger: loggerInstance}
+}
+
+// NewNotifierFromEnv delivers by email when SMTP_HOST is set, by SMS when
+// TWILIO_ACCOUNT_SID is set and by push when FCM_SERVER_KEY is set. Without
+// any of them notifications are only logged. The queue sends what the
+// notifier queued and is meant to run from a background job.
+func NewNotifierFromEnv(directory IDirectory, mailer mail.IMailer, loggerInstance *logger.Logger) (INotifier, IQueue) {
+	var channels []IChannel
+	if email := NewEmailChannelFromEnv(mailer); email != nil {
+		channels = append(channels, email)
+	}
+	if sms := NewTwilioChannelFromEnv(); sms != nil {
+		channels = append(channels, sms)
+	}
+	if push := NewFCMChannelFromEnv(); push != nil {
+		channels = append(channels, push)
+	}
+	if len(channels) == 0 {
+		notifier := &LogNotifier{Logger: loggerInstance}
+		return notifier, notifier
+	}
+	notifier := NewChannelNotifier(directory, loggerInstance, channels...)
+	return notifier, notifier
+}
+
+// Notify queues the message for each of its recipients; nothing is sent
+// until Deliver runs.
+func (n *ChannelNotifier) Notify(message Message) error {
+	recipients, err := n.directory.Recipients(message)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, recipient := range recipients {
+		n.queued = append(n.queued, queuedMessage{recipient: recipient, message: message})
+	}
+	return nil
+}
+
+// Deliver sends the queued messages and returns how many deliveries went
+// out. A recipient who opted out of every channel is reported once, with no
+// channel and ErrNoAddress.
+func (n *ChannelNotifier) Deliver() (int, error) {
+	n.mu.Lock()
+	queued := n.queued
+	n.queued = nil
+	n.mu.Unlock()
+
+	delivered := 0
+	var errs []error
+	for _, q := range queued {
+		recipient, message := q.recipient, q.message
+		accepted := false
+		for _, channel := range n.channels {
+			if !recipient.Accepts(channel.Name()) {
+				continue
+			}
+			accepted = true
+			ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+			err := channel.Send(ctx, recipient, message)
+			cancel()
+			if message.Report != nil {
+				message.Report(recipient.UserID, channel.Name(), err)
+			}
+			switch {
+			case errors.Is(err, ErrNoAddress):
+			case err != nil:
+				n.Logger.Error("Notification delivery failed", zap.Error(err), zap.String("channel", channel.Name()),
+					zap.String("userID", recipient.UserID.String()), zap.String("subject", message.Subject))
+				errs = append(errs, fmt.Errorf("%s to %s: %w", channel.Name(), recipient.UserID, err))
+			default:
+				delivered++
+				n.Logger.Info("Notification delivered", zap.String("channel", channel.Name()),
+					zap.String("userID", recipient.UserID.String()), zap.String("subject", message.Subject))
+			}
+		}
+		if !accepted && message.Report != nil {
+			message.Report(recipient.UserID, "", ErrNoAddress)
+		}
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// UserDirectory finds recipients among the users, with the push tokens
+// their devices registered and the channels they prefer. Role messages reach
+// every active user holding the role.
+type UserDirectory struct {
+	userRepository       domainUser.IUserRepository
+	deviceRepository     domainNotification.IDeviceRepository
+	preferenceRepository domainNotification.IPreferenceRepository
+}
+
+func NewUserDirectory(userRepository domainUser.IUserRepository, deviceRepository domainNotification.IDeviceRepository, preferenceRepository domainNotification.IPreferenceRepository) IDirectory {
+	return &UserDirectory{userRepository: userRepository, deviceRepository: deviceRepository, preferenceRepository: preferenceRepository}
+}
+
+func (d *UserDirectory) Recipients(message Message) ([]Recipient, error) {
+	var users []domainUser.User
+	if message.UserID != nil {
+		user, err := d.userRepository.GetByID(*message.UserID)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	} else if message.Role != "" {
+		all, err := d.userRepository.GetAll()
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range *all {
+			if user.Role == message.Role && user.Status {
+				users = append(users, user)
+			}
+		}
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	tokens, err := d.deviceRepository.GetTokens(ids)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := d.preferenceRepository.GetChannels(ids)
+	if err != nil {
+		return nil, err
+	}
+	recipients := make([]Recipient, len(users))
+	for i, user := range users {
+		recipients[i] = Recipient{
+			UserID:       user.ID,
+			Name:         strings.TrimSpace(user.FirstName + " " + user.LastName),
+			Email:        user.Email,
+			Phone:        user.Phone,
+			DeviceTokens: tokens[user.ID],
+			Channels:     channels[user.ID],
+		}
+	}
+	return recipients, nil
+}
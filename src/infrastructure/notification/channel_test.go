@@ -0,0 +1,135 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+type fixedDirectory []Recipient
+
+func (d fixedDirectory) Recipients(message Message) ([]Recipient, error) {
+	return d, nil
+}
+
+func TestChannelNotifierDeliversOnReachableChannels(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	var texts []string
+	twilio := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "AC1" || pass != "secret" {
+			t.Errorf("expected basic auth with the account SID, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/2010-04-01/Accounts/AC1/Messages.json" {
+			t.Errorf("unexpected Twilio path %s", r.URL.Path)
+		}
+		_ = r.ParseForm()
+		texts = append(texts, r.PostForm.Get("To")+" "+r.PostForm.Get("From")+" "+r.PostForm.Get("Body"))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer twilio.Close()
+
+	var pushes []fcmRequest
+	fcm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "key=server-key" {
+			t.Errorf("unexpected FCM authorization %q", r.Header.Get("Authorization"))
+		}
+		var request fcmRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		pushes = append(pushes, request)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer fcm.Close()
+
+	withPhone := Recipient{UserID: uuid.New(), Phone: "+15550100"}
+	withDevice := Recipient{UserID: uuid.New(), DeviceTokens: []string{"token-1", "token-2"}}
+	notifier := NewChannelNotifier(fixedDirectory{withPhone, withDevice}, loggerInstance,
+		&TwilioChannel{URL: twilio.URL, AccountSID: "AC1", AuthToken: "secret", From: "+15550199", Client: twilio.Client()},
+		&FCMChannel{URL: fcm.URL, ServerKey: "server-key", Client: fcm.Client()},
+	)
+
+	if err := notifier.Notify(Message{Role: "client", Subject: "Visit started", Body: "Ana has checked in.", Data: map[string]interface{}{"attempt": 2}}); err != nil {
+		t.Fatalf("unexpected error queueing: %v", err)
+	}
+	if len(texts) != 0 || len(pushes) != 0 {
+		t.Fatalf("expected nothing sent before delivery, got %v and %+v", texts, pushes)
+	}
+	delivered, err := notifier.Deliver()
+	if delivered != 1 {
+		t.Errorf("expected the text counted as delivered, got %d", delivered)
+	}
+	if len(texts) != 1 || texts[0] != "+15550100 +15550199 Visit started: Ana has checked in." {
+		t.Errorf("expected one text to the recipient with a phone, got %v", texts)
+	}
+	if len(pushes) != 1 || len(pushes[0].RegistrationIDs) != 2 || pushes[0].Notification.Title != "Visit started" || pushes[0].Data["attempt"] != "2" {
+		t.Errorf("expected one push to both devices, got %+v", pushes)
+	}
+	if err == nil {
+		t.Error("expected the failed push to be reported")
+	}
+}
+
+type stubChannel struct {
+	name string
+	err  error
+	sent []uuid.UUID
+}
+
+func (c *stubChannel) Name() string { return c.name }
+
+func (c *stubChannel) Send(ctx context.Context, recipient Recipient, message Message) error {
+	if c.err == nil {
+		c.sent = append(c.sent, recipient.UserID)
+	}
+	return c.err
+}
+
+func TestChannelNotifierHonoursPreferencesAndReports(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	pushOnly := Recipient{UserID: uuid.New(), Channels: []string{"push"}}
+	anyChannel := Recipient{UserID: uuid.New()}
+	emailOnly := Recipient{UserID: uuid.New(), Channels: []string{"email"}}
+	sms := &stubChannel{name: "sms"}
+	push := &stubChannel{name: "push", err: ErrNoAddress}
+	notifier := NewChannelNotifier(fixedDirectory{pushOnly, anyChannel, emailOnly}, loggerInstance, sms, push)
+
+	reports := map[uuid.UUID][]string{}
+	err = notifier.Notify(Message{Subject: "Office closed", Body: "The office is closed on Friday.", Report: func(userID uuid.UUID, channel string, err error) {
+		outcome := channel + " sent"
+		if errors.Is(err, ErrNoAddress) {
+			outcome = channel + " no address"
+		}
+		reports[userID] = append(reports[userID], outcome)
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error queueing: %v", err)
+	}
+	if _, err := notifier.Deliver(); err != nil {
+		t.Fatalf("expected a missing address not to fail the message, got %v", err)
+	}
+	if len(sms.sent) != 1 || sms.sent[0] != anyChannel.UserID {
+		t.Errorf("expected the text sent only to the recipient without preferences, got %v", sms.sent)
+	}
+	if got := reports[pushOnly.UserID]; len(got) != 1 || got[0] != "push no address" {
+		t.Errorf("expected only the push attempt reported for the push-only recipient, got %v", got)
+	}
+	if got := reports[anyChannel.UserID]; len(got) != 2 || got[0] != "sms sent" || got[1] != "push no address" {
+		t.Errorf("expected both attempts reported, got %v", got)
+	}
+	if got := reports[emailOnly.UserID]; len(got) != 1 || got[0] != " no address" {
+		t.Errorf("expected a recipient without an accepted channel reported once, got %v", got)
+	}
+}
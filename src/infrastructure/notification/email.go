@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"context"
+	"os"
+
+	"caregiver/src/infrastructure/mail"
+)
+
+// EmailChannel sends notifications as plain text email.
+type EmailChannel struct {
+	Mailer mail.IMailer
+}
+
+// NewEmailChannelFromEnv returns an email channel over mailer when SMTP_HOST
+// is set, otherwise nil.
+func NewEmailChannelFromEnv(mailer mail.IMailer) IChannel {
+	if os.Getenv("SMTP_HOST") == "" {
+		return nil
+	}
+	return &EmailChannel{Mailer: mailer}
+}
+
+func (c *EmailChannel) Name() string {
+	return "email"
+}
+
+func (c *EmailChannel) Send(ctx context.Context, recipient Recipient, message Message) error {
+	if recipient.Email == "" {
+		return ErrNoAddress
+	}
+	return c.Mailer.Send(ctx, mail.Message{
+		To:      []string{recipient.Email},
+		Subject: message.Subject,
+		Body:    message.Body,
+	})
+}
@@ -0,0 +1,84 @@
+package notification
+
+import (
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Message is addressed either to every user holding Role or to one UserID.
+// Report, when set, is told how each delivery attempt went: a nil error when
+// the message was sent, ErrNoAddress when the channel cannot reach the user.
+// Notifiers that queue messages call it later, from the goroutine sending
+// them.
+type Message struct {
+	Role    string
+	UserID  *uuid.UUID
+	Subject string
+	Body    string
+	Data    map[string]interface{}
+	Report  func(userID uuid.UUID, channel string, err error)
+}
+
+type INotifier interface {
+	Notify(message Message) error
+}
+
+// IQueue sends the messages a notifier queued. Deliver returns how many
+// deliveries went out.
+type IQueue interface {
+	Deliver() (int, error)
+}
+
+// LogNotifier records notifications in the application log. It is the
+// default until a delivery channel is configured.
+type LogNotifier struct {
+	Logger *logger.Logger
+}
+
+func NewLogNotifier(loggerInstance *logger.Logger) INotifier {
+	return &LogNotifier{Logger: loggerInstance}
+}
+
+func (n *LogNotifier) Notify(message Message) error {
+	fields := []zap.Field{zap.String("role", message.Role), zap.String("subject", message.Subject), zap.String("body", message.Body), zap.Any("data", message.Data)}
+	if message.UserID != nil {
+		fields = append(fields, zap.String("userID", message.UserID.String()))
+	}
+	n.Logger.Info("Notification", fields...)
+	return nil
+}
+
+// Deliver has nothing to send: messages are logged as they are notified.
+func (n *LogNotifier) Deliver() (int, error) {
+	return 0, nil
+}
+
+// Transform rewrites a message before it is delivered, e.g. to add the
+// fields a regional system expects. Returning false drops the message.
+type Transform func(message Message) (Message, bool)
+
+type transformingNotifier struct {
+	next       INotifier
+	transforms []Transform
+}
+
+// WithTransforms applies transforms, in order, to every message before next
+// delivers it. Without transforms next is returned unchanged.
+func WithTransforms(next INotifier, transforms ...Transform) INotifier {
+	if len(transforms) == 0 {
+		return next
+	}
+	return &transformingNotifier{next: next, transforms: transforms}
+}
+
+func (n *transformingNotifier) Notify(message Message) error {
+	for _, transform := range n.transforms {
+		var keep bool
+		if message, keep = transform(message); !keep {
+			return nil
+		}
+	}
+	return n.next.Notify(message)
+}
@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+const defaultFCMURL = "https://fcm.googleapis.com/fcm/send"
+
+// FCMChannel sends push notifications to the recipient's registered devices
+// through Firebase Cloud Messaging's HTTP API.
+type FCMChannel struct {
+	URL       string
+	ServerKey string
+	Client    *http.Client
+}
+
+// NewFCMChannelFromEnv returns an FCM channel when FCM_SERVER_KEY is set,
+// otherwise nil. FCM_URL overrides the send endpoint.
+func NewFCMChannelFromEnv() IChannel {
+	key := os.Getenv("FCM_SERVER_KEY")
+	if key == "" {
+		return nil
+	}
+	sendURL := os.Getenv("FCM_URL")
+	if sendURL == "" {
+		sendURL = defaultFCMURL
+	}
+	return &FCMChannel{URL: sendURL, ServerKey: key, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type fcmRequest struct {
+	RegistrationIDs []string          `json:"registration_ids"`
+	Notification    fcmNotification   `json:"notification"`
+	Data            map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func (c *FCMChannel) Name() string {
+	return "push"
+}
+
+func (c *FCMChannel) Send(ctx context.Context, recipient Recipient, message Message) error {
+	if len(recipient.DeviceTokens) == 0 {
+		return ErrNoAddress
+	}
+	// FCM data payloads only carry strings.
+	data := make(map[string]string, len(message.Data))
+	for key, value := range message.Data {
+		data[key] = fmt.Sprint(value)
+	}
+	payload, err := json.Marshal(fcmRequest{
+		RegistrationIDs: recipient.DeviceTokens,
+		Notification:    fcmNotification{Title: message.Subject, Body: message.Body},
+		Data:            data,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "key="+c.ServerKey)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fcm returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultTwilioURL = "https://api.twilio.com"
+
+// TwilioChannel sends notifications as text messages through Twilio's
+// Messages API, from the From number or messaging service SID.
+type TwilioChannel struct {
+	URL        string
+	AccountSID string
+	AuthToken  string
+	From       string
+	Client     *http.Client
+}
+
+// NewTwilioChannelFromEnv returns a Twilio channel when TWILIO_ACCOUNT_SID
+// is set, otherwise nil. TWILIO_URL overrides the API host.
+func NewTwilioChannelFromEnv() IChannel {
+	sid := os.Getenv("TWILIO_ACCOUNT_SID")
+	if sid == "" {
+		return nil
+	}
+	apiURL := os.Getenv("TWILIO_URL")
+	if apiURL == "" {
+		apiURL = defaultTwilioURL
+	}
+	return &TwilioChannel{
+		URL:        apiURL,
+		AccountSID: sid,
+		AuthToken:  os.Getenv("TWILIO_AUTH_TOKEN"),
+		From:       os.Getenv("TWILIO_FROM"),
+		Client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *TwilioChannel) Name() string {
+	return "sms"
+}
+
+func (c *TwilioChannel) Send(ctx context.Context, recipient Recipient, message Message) error {
+	if recipient.Phone == "" {
+		return ErrNoAddress
+	}
+	form := url.Values{"To": {recipient.Phone}, "Body": {smsBody(message)}}
+	if strings.HasPrefix(c.From, "MG") {
+		form.Set("MessagingServiceSid", c.From)
+	} else {
+		form.Set("From", c.From)
+	}
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", strings.TrimRight(c.URL, "/"), url.PathEscape(c.AccountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.AccountSID, c.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("twilio returned %s", resp.Status)
+	}
+	return nil
+}
+
+// smsBody puts the subject in front of the body, as a text has no subject.
+func smsBody(message Message) string {
+	if message.Subject == "" {
+		return message.Body
+	}
+	return message.Subject + ": " + message.Body
+}
@@ -0,0 +1,242 @@
+// Package pdf writes simple PDF documents, such as client statements meant
+// for printing and mailing. Text is set in the standard Courier fonts so
+// that callers can line up columns with plain padding; a PNG or JPEG logo
+// may head the first page.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+)
+
+const (
+	pageWidth  = 612 // US Letter, in points
+	pageHeight = 792
+	margin     = 54
+
+	bodySize    = 10
+	headingSize = 14
+	leading     = 1.4
+
+	// charWidth is the advance of every Courier glyph per point of size.
+	charWidth = 0.6
+)
+
+// Columns is how many body characters fit across the printable width.
+const Columns = int((pageWidth - 2*margin) / (bodySize * charWidth))
+
+type textLine struct {
+	y     float64
+	size  float64
+	bold  bool
+	color string
+	text  string
+	// image is one more than the index of the image drawn instead of text.
+	image  int
+	width  float64
+	height float64
+}
+
+// picture is an image decoded to 8-bit RGB and deflated.
+type picture struct {
+	width  int
+	height int
+	data   []byte
+}
+
+// Document lays out lines of text top to bottom, starting a new page when
+// the current one is full.
+type Document struct {
+	pages        [][]textLine
+	images       []picture
+	headingColor string
+	y            float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.NewPage()
+	return d
+}
+
+// NewPage starts a fresh page.
+func (d *Document) NewPage() {
+	d.pages = append(d.pages, nil)
+	d.y = pageHeight - margin
+}
+
+// HeadingColor sets the color of headings written after it.
+func (d *Document) HeadingColor(r, g, b uint8) {
+	d.headingColor = fmt.Sprintf("%.3f %.3f %.3f rg ", float64(r)/255, float64(g)/255, float64(b)/255)
+}
+
+// Heading writes a line of large bold text.
+func (d *Document) Heading(text string) {
+	d.add(headingSize, true, text)
+	page := d.pages[len(d.pages)-1]
+	page[len(page)-1].color = d.headingColor
+}
+
+// Image draws a PNG or JPEG at the left margin, scaled to the given height
+// in points, or narrower when it would not fit across the page.
+func (d *Document) Image(data []byte, height float64) error {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return fmt.Errorf("image is empty")
+	}
+	width := height * float64(bounds.Dx()) / float64(bounds.Dy())
+	if available := float64(pageWidth - 2*margin); width > available {
+		height, width = height*available/width, available
+	}
+
+	// Transparent pixels are laid over white, as PDF images without a soft
+	// mask have no alpha.
+	raw := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			white := 0xffff - a
+			raw = append(raw, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+	}
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	if _, err := writer.Write(raw); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	d.images = append(d.images, picture{width: bounds.Dx(), height: bounds.Dy(), data: compressed.Bytes()})
+
+	if d.y-height < margin {
+		d.NewPage()
+	}
+	d.y -= height
+	page := len(d.pages) - 1
+	d.pages[page] = append(d.pages[page], textLine{y: d.y, image: len(d.images), width: width, height: height})
+	return nil
+}
+
+// Line writes a line of body text.
+func (d *Document) Line(text string) {
+	d.add(bodySize, false, text)
+}
+
+// Bold writes a line of bold body text.
+func (d *Document) Bold(text string) {
+	d.add(bodySize, true, text)
+}
+
+// Blank leaves an empty line.
+func (d *Document) Blank() {
+	d.add(bodySize, false, "")
+}
+
+// PageCount is the number of pages written so far.
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+func (d *Document) add(size float64, bold bool, text string) {
+	if d.y-size*leading < margin {
+		d.NewPage()
+	}
+	d.y -= size * leading
+	page := len(d.pages) - 1
+	d.pages[page] = append(d.pages[page], textLine{y: d.y, size: size, bold: bold, text: text})
+}
+
+// Bytes renders the document as a PDF file.
+func (d *Document) Bytes() []byte {
+	var objects []string
+	// Objects 1-4 are the catalog, page tree and the two fonts; each page
+	// then takes a page object followed by its content stream, and images
+	// come last.
+	pageRefs := make([]string, len(d.pages))
+	for i := range d.pages {
+		pageRefs[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(pageRefs, " "), len(d.pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	xObjects := ""
+	if len(d.images) > 0 {
+		refs := make([]string, len(d.images))
+		for i := range d.images {
+			refs[i] = fmt.Sprintf("/Im%d %d 0 R", i+1, 5+2*len(d.pages)+i)
+		}
+		xObjects = fmt.Sprintf(" /XObject << %s >>", strings.Join(refs, " "))
+	}
+	for i, lines := range d.pages {
+		var content strings.Builder
+		for _, line := range lines {
+			if line.image > 0 {
+				fmt.Fprintf(&content, "q %.2f 0 0 %.2f %d %.2f cm /Im%d Do Q\n", line.width, line.height, margin, line.y, line.image)
+				continue
+			}
+			if line.text == "" {
+				continue
+			}
+			font := "F1"
+			if line.bold {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "BT %s/%s %g Tf %d %.2f Td (%s) Tj ET\n", line.color, font, line.size, margin, line.y, escape(line.text))
+		}
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >>%s >> /Contents %d 0 R >>", pageWidth, pageHeight, xObjects, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+	for _, img := range d.images {
+		objects = append(objects, fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+			img.width, img.height, len(img.data), img.data))
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// escape makes text safe inside a PDF string literal. Characters outside
+// printable ASCII are replaced, as the standard fonts cannot be relied on to
+// render them.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > '~':
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
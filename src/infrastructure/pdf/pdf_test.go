@@ -0,0 +1,88 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestDocumentBytes(t *testing.T) {
+	doc := New()
+	doc.Heading("Statement")
+	doc.Line("Balance (due) \\ now: café")
+	for i := 0; i < 80; i++ {
+		doc.Line("row")
+	}
+	if doc.PageCount() != 2 {
+		t.Fatalf("expected the rows to spill onto a second page, got %d pages", doc.PageCount())
+	}
+	out := doc.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("expected a PDF header and trailer, got %q", out)
+	}
+	if !bytes.Contains(out, []byte(`(Balance \(due\) \\ now: caf?) Tj`)) {
+		t.Errorf("expected text to be escaped")
+	}
+	if !bytes.Contains(out, []byte("/Count 2")) {
+		t.Errorf("expected the page tree to hold both pages")
+	}
+
+	// Every xref entry must point at the object it names.
+	match := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	if match == nil {
+		t.Fatal("expected a startxref offset")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point at the xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	if len(entries) != 8 {
+		t.Fatalf("expected 8 objects, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		want := strconv.Itoa(i+1) + " 0 obj"
+		if !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("xref entry %d does not point at %q", i+1, want)
+		}
+	}
+}
+
+func TestDocumentImage(t *testing.T) {
+	logo := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	logo.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, logo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	doc := New()
+	if err := doc.Image([]byte("not an image"), 40); err == nil {
+		t.Errorf("expected an error for data that is not an image")
+	}
+	if err := doc.Image(encoded.Bytes(), 40); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	doc.HeadingColor(0, 51, 102)
+	doc.Heading("Agency")
+	out := doc.Bytes()
+
+	if !bytes.Contains(out, []byte("/XObject << /Im1 7 0 R >>")) {
+		t.Errorf("expected the page to reference the image")
+	}
+	if !bytes.Contains(out, []byte("/Subtype /Image /Width 4 /Height 2")) {
+		t.Errorf("expected the image object at its pixel size")
+	}
+	if !bytes.Contains(out, []byte("q 80.00 0 0 40.00 54")) {
+		t.Errorf("expected the image scaled to 40 points high")
+	}
+	if !bytes.Contains(out, []byte("BT 0.000 0.200 0.400 rg /F2 14 Tf")) {
+		t.Errorf("expected the heading in the heading color")
+	}
+}
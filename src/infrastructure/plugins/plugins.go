@@ -0,0 +1,177 @@
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	"caregiver/src/infrastructure/evv"
+	"caregiver/src/infrastructure/integrity"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/notification"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Plugin is an optional module adding region-specific behavior to the core
+// use cases. Every field is optional.
+type Plugin struct {
+	Name string
+	// Validators and CheckinGuards run alongside the built-in ones on every
+	// schedule write and check-in.
+	Validators    []scheduleUseCase.ScheduleValidator
+	Observers     []scheduleUseCase.ScheduleObserver
+	CheckinGuards []scheduleUseCase.CheckinGuard
+	// EVVAdapters are added to the adapters aggregators can be configured
+	// with, e.g. a state aggregator's own format.
+	EVVAdapters evv.Registry
+	// IntegrityVerifiers check device attestations from platforms without a
+	// built-in verifier, e.g. App Attest on iOS.
+	IntegrityVerifiers integrity.Registry
+	// NotificationTransforms rewrite outgoing notifications, in order.
+	NotificationTransforms []notification.Transform
+}
+
+// Deps is what a plugin may build itself from.
+type Deps struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+// Factory builds a plugin when it is enabled.
+type Factory func(deps Deps) (*Plugin, error)
+
+var (
+	mu        sync.Mutex
+	factories = map[string]Factory{}
+)
+
+// Register makes a plugin available under name. Plugin packages call it from
+// init and are linked in with a blank import in the di package; they only
+// take effect once named in PLUGINS. Registering a name twice panics.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if factory == nil {
+		panic("plugins: Register factory is nil for " + name)
+	}
+	if _, dup := factories[name]; dup {
+		panic("plugins: Register called twice for " + name)
+	}
+	factories[name] = factory
+}
+
+// Names returns the registered plugins, sorted.
+func Names() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Set is the plugins enabled in a deployment, in the order they were named.
+type Set []*Plugin
+
+// LoadFromEnv builds the plugins named in PLUGINS, a comma-separated list.
+func LoadFromEnv(deps Deps) (Set, error) {
+	return Load(strings.Split(os.Getenv("PLUGINS"), ","), deps)
+}
+
+// Load builds the named plugins. An unknown name is an error, so a typo in
+// the configuration stops startup instead of silently dropping behavior.
+func Load(names []string, deps Deps) (Set, error) {
+	var set Set
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		mu.Lock()
+		factory, ok := factories[name]
+		mu.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("plugin %q is not registered; available: %s", name, strings.Join(Names(), ", "))
+		}
+		plugin, err := factory(deps)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %q: %w", name, err)
+		}
+		plugin.Name = name
+		set = append(set, plugin)
+		if deps.Logger != nil {
+			deps.Logger.Info("Plugin enabled", zap.String("plugin", name))
+		}
+	}
+	return set, nil
+}
+
+func (s Set) Validators() []scheduleUseCase.ScheduleValidator {
+	var res []scheduleUseCase.ScheduleValidator
+	for _, plugin := range s {
+		res = append(res, plugin.Validators...)
+	}
+	return res
+}
+
+func (s Set) Observers() []scheduleUseCase.ScheduleObserver {
+	var res []scheduleUseCase.ScheduleObserver
+	for _, plugin := range s {
+		res = append(res, plugin.Observers...)
+	}
+	return res
+}
+
+func (s Set) CheckinGuards() []scheduleUseCase.CheckinGuard {
+	var res []scheduleUseCase.CheckinGuard
+	for _, plugin := range s {
+		res = append(res, plugin.CheckinGuards...)
+	}
+	return res
+}
+
+// ExtendEVV adds the plugins' EVV adapters to registry. An adapter may not
+// replace a built-in one or another plugin's.
+func (s Set) ExtendEVV(registry evv.Registry) error {
+	for _, plugin := range s {
+		for name, adapter := range plugin.EVVAdapters {
+			if _, taken := registry[name]; taken {
+				return fmt.Errorf("plugin %q: EVV adapter %q is already registered", plugin.Name, name)
+			}
+			registry[name] = adapter
+		}
+	}
+	return nil
+}
+
+// ExtendIntegrity adds the plugins' attestation verifiers to registry. A
+// verifier may not replace a built-in one or another plugin's.
+func (s Set) ExtendIntegrity(registry integrity.Registry) error {
+	for _, plugin := range s {
+		for platform, verifier := range plugin.IntegrityVerifiers {
+			if _, taken := registry[platform]; taken {
+				return fmt.Errorf("plugin %q: integrity verifier for %q is already registered", plugin.Name, platform)
+			}
+			registry[platform] = verifier
+		}
+	}
+	return nil
+}
+
+// Notifier wraps next so the plugins' transforms apply to every notification.
+func (s Set) Notifier(next notification.INotifier) notification.INotifier {
+	var transforms []notification.Transform
+	for _, plugin := range s {
+		transforms = append(transforms, plugin.NotificationTransforms...)
+	}
+	return notification.WithTransforms(next, transforms...)
+}
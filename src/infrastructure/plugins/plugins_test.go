@@ -0,0 +1,123 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	domainEVV "caregiver/src/domain/evv"
+	domainSchedule "caregiver/src/domain/schedule"
+	"caregiver/src/infrastructure/evv"
+	"caregiver/src/infrastructure/integrity"
+	"caregiver/src/infrastructure/notification"
+)
+
+type regionValidator struct{}
+
+func (regionValidator) ValidateSchedule(schedule *domainSchedule.Schedule) ([]string, error) {
+	return []string{"regional rule"}, nil
+}
+
+type regionAdapter struct{}
+
+func (regionAdapter) Submit(ctx context.Context, aggregator *domainEVV.Aggregator, record *domainEVV.Record) (*evv.Result, error) {
+	return &evv.Result{Accepted: true}, nil
+}
+
+type attestVerifier struct{}
+
+func (attestVerifier) Verify(ctx context.Context, token string, nonce string) (*integrity.Verdict, error) {
+	return &integrity.Verdict{Intact: true}, nil
+}
+
+type recordingNotifier struct {
+	messages []notification.Message
+}
+
+func (n *recordingNotifier) Notify(message notification.Message) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func init() {
+	Register("test-region", func(deps Deps) (*Plugin, error) {
+		return &Plugin{
+			Validators:         []scheduleUseCase.ScheduleValidator{regionValidator{}},
+			EVVAdapters:        evv.Registry{"test-state": regionAdapter{}},
+			IntegrityVerifiers: integrity.Registry{domainSchedule.PlatformIOS: attestVerifier{}},
+			NotificationTransforms: []notification.Transform{
+				func(message notification.Message) (notification.Message, bool) {
+					message.Subject = "[Region] " + message.Subject
+					return message, message.Role != "muted"
+				},
+			},
+		}, nil
+	})
+	Register("test-broken", func(deps Deps) (*Plugin, error) {
+		return nil, errors.New("missing credentials")
+	})
+}
+
+func TestLoad(t *testing.T) {
+	set, err := Load([]string{" test-region", "", "test-region"}, Deps{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(set) != 1 || set[0].Name != "test-region" {
+		t.Fatalf("expected test-region once, got %+v", set)
+	}
+	if len(set.Validators()) != 1 || len(set.Observers()) != 0 {
+		t.Errorf("expected one validator and no observers")
+	}
+
+	if _, err := Load([]string{"test-unknown"}, Deps{}); err == nil {
+		t.Error("expected an error for an unregistered plugin")
+	}
+	if _, err := Load([]string{"test-broken"}, Deps{}); err == nil {
+		t.Error("expected the factory's error")
+	}
+}
+
+func TestExtendEVV(t *testing.T) {
+	set, _ := Load([]string{"test-region"}, Deps{})
+	registry := evv.Registry{"rest": regionAdapter{}}
+	if err := set.ExtendEVV(registry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := registry["test-state"]; !ok {
+		t.Error("expected the plugin's adapter to be added")
+	}
+	if err := set.ExtendEVV(registry); err == nil {
+		t.Error("expected an error when an adapter name is taken")
+	}
+}
+
+func TestExtendIntegrity(t *testing.T) {
+	set, _ := Load([]string{"test-region"}, Deps{})
+	registry := integrity.Registry{}
+	if err := set.ExtendIntegrity(registry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verdict, err := registry.Verify(context.Background(), domainSchedule.PlatformIOS, "token", "nonce"); err != nil || !verdict.Intact {
+		t.Errorf("expected the plugin's verifier to be added, got %v, %v", verdict, err)
+	}
+	if err := set.ExtendIntegrity(registry); err == nil {
+		t.Error("expected an error when a platform is taken")
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	set, _ := Load([]string{"test-region"}, Deps{})
+	next := &recordingNotifier{}
+	notifier := set.Notifier(next)
+	_ = notifier.Notify(notification.Message{Role: "admin", Subject: "Visit missed"})
+	_ = notifier.Notify(notification.Message{Role: "muted", Subject: "Dropped"})
+	if len(next.messages) != 1 || next.messages[0].Subject != "[Region] Visit missed" {
+		t.Errorf("expected one transformed message, got %+v", next.messages)
+	}
+
+	if Set(nil).Notifier(next) != notification.INotifier(next) {
+		t.Error("expected the notifier unchanged without transforms")
+	}
+}
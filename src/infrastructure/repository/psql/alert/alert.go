@@ -0,0 +1,290 @@
+package alert
+
+import (
+	"time"
+
+	domainAlert "caregiver/src/domain/alert"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Policy struct {
+	ID        uuid.UUID          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name      string             `gorm:"column:name"`
+	AlertType string             `gorm:"column:alert_type;index"`
+	Steps     []domainAlert.Step `gorm:"column:steps;serializer:json"`
+	Active    bool               `gorm:"column:active"`
+	CreatedAt time.Time          `gorm:"autoCreateTime:milli"`
+	UpdatedAt time.Time          `gorm:"autoUpdateTime:milli"`
+}
+
+type Alert struct {
+	ID               uuid.UUID                  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Type             string                     `gorm:"column:type"`
+	PolicyID         *uuid.UUID                 `gorm:"column:policy_id;type:uuid"`
+	ScheduleID       *uuid.UUID                 `gorm:"column:schedule_id;type:uuid;index"`
+	CaregiverUserID  *uuid.UUID                 `gorm:"column:caregiver_user_id;type:uuid"`
+	ClientUserID     *uuid.UUID                 `gorm:"column:client_user_id;type:uuid"`
+	Message          string                     `gorm:"column:message"`
+	Emergency        bool                       `gorm:"column:emergency"`
+	Location         domainSchedule.Location    `gorm:"embedded;embeddedPrefix:location_"`
+	Status           string                     `gorm:"column:status;index"`
+	NextStep         int                        `gorm:"column:next_step"`
+	NextEscalationAt *time.Time                 `gorm:"column:next_escalation_at;index"`
+	Notifications    []domainAlert.Notification `gorm:"column:notifications;serializer:json"`
+	AcknowledgedBy   *uuid.UUID                 `gorm:"column:acknowledged_by;type:uuid"`
+	AcknowledgedAt   *time.Time                 `gorm:"column:acknowledged_at"`
+	CreatedAt        time.Time                  `gorm:"autoCreateTime:milli"`
+	UpdatedAt        time.Time                  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Policy) TableName() string {
+	return "escalation_policies"
+}
+
+func (Alert) TableName() string {
+	return "alerts"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewAlertRepository(db *gorm.DB, loggerInstance *logger.Logger) domainAlert.IAlertRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreatePolicy(policy *domainAlert.Policy) (*domainAlert.Policy, error) {
+	model := policyFromDomain(policy)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating escalation policy", zap.Error(err), zap.String("name", policy.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Escalation policy created successfully", zap.String("policyID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetPolicyByID(id uuid.UUID) (*domainAlert.Policy, error) {
+	var model Policy
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Escalation policy not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting escalation policy by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetPolicies() (*[]domainAlert.Policy, error) {
+	var models []Policy
+	if err := r.DB.Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting escalation policies", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	policies := make([]domainAlert.Policy, len(models))
+	for i := range models {
+		policies[i] = *models[i].toDomainMapper()
+	}
+	return &policies, nil
+}
+
+func (r *Repository) GetActivePolicy(alertType string) (*domainAlert.Policy, error) {
+	var model Policy
+	err := r.DB.Where("alert_type = ? AND active = ?", alertType, true).Order("created_at ASC").First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting active escalation policy", zap.Error(err), zap.String("alertType", alertType))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) UpdatePolicy(policy *domainAlert.Policy) (*domainAlert.Policy, error) {
+	model := policyFromDomain(policy)
+	if err := r.DB.Omit("created_at").Save(model).Error; err != nil {
+		r.Logger.Error("Error updating escalation policy", zap.Error(err), zap.String("id", policy.ID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetPolicyByID(policy.ID)
+}
+
+func (r *Repository) DeletePolicy(id uuid.UUID) error {
+	tx := r.DB.Delete(&Policy{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting escalation policy", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Escalation policy not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) CreateAlert(alert *domainAlert.Alert) (*domainAlert.Alert, error) {
+	model := alertFromDomain(alert)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating alert", zap.Error(err), zap.String("type", alert.Type))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Alert created successfully", zap.String("alertID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAlertByID(id uuid.UUID) (*domainAlert.Alert, error) {
+	var model Alert
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Alert not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting alert by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAlerts(status string) (*[]domainAlert.Alert, error) {
+	var models []Alert
+	query := r.DB.Order("created_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting alerts", zap.Error(err), zap.String("status", status))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return alertsToDomain(models), nil
+}
+
+func (r *Repository) GetDueAlerts(now time.Time) (*[]domainAlert.Alert, error) {
+	var models []Alert
+	err := r.DB.Where("status = ? AND next_escalation_at IS NOT NULL AND next_escalation_at <= ?", domainAlert.StatusOpen, now).
+		Order("next_escalation_at ASC").
+		Find(&models).Error
+	if err != nil {
+		r.Logger.Error("Error getting due alerts", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return alertsToDomain(models), nil
+}
+
+func (r *Repository) GetAlertBySchedule(scheduleID uuid.UUID, alertType string) (*domainAlert.Alert, error) {
+	var model Alert
+	err := r.DB.Where("schedule_id = ? AND type = ?", scheduleID, alertType).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting alert for schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAlertByClientSince(clientUserID uuid.UUID, alertType string, since time.Time) (*domainAlert.Alert, error) {
+	var model Alert
+	err := r.DB.Where("client_user_id = ? AND type = ? AND created_at >= ?", clientUserID, alertType, since).
+		Order("created_at DESC").First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting alert for client", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) SaveAlert(alert *domainAlert.Alert) (*domainAlert.Alert, error) {
+	model := alertFromDomain(alert)
+	if err := r.DB.Omit("created_at").Save(model).Error; err != nil {
+		r.Logger.Error("Error saving alert", zap.Error(err), zap.String("id", alert.ID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetAlertByID(alert.ID)
+}
+
+func (p *Policy) toDomainMapper() *domainAlert.Policy {
+	return &domainAlert.Policy{
+		ID:        p.ID,
+		Name:      p.Name,
+		AlertType: p.AlertType,
+		Steps:     p.Steps,
+		Active:    p.Active,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func policyFromDomain(p *domainAlert.Policy) *Policy {
+	return &Policy{
+		ID:        p.ID,
+		Name:      p.Name,
+		AlertType: p.AlertType,
+		Steps:     p.Steps,
+		Active:    p.Active,
+	}
+}
+
+func (a *Alert) toDomainMapper() *domainAlert.Alert {
+	return &domainAlert.Alert{
+		ID:               a.ID,
+		Type:             a.Type,
+		PolicyID:         a.PolicyID,
+		ScheduleID:       a.ScheduleID,
+		CaregiverUserID:  a.CaregiverUserID,
+		ClientUserID:     a.ClientUserID,
+		Message:          a.Message,
+		Emergency:        a.Emergency,
+		Location:         a.Location,
+		Status:           a.Status,
+		NextStep:         a.NextStep,
+		NextEscalationAt: a.NextEscalationAt,
+		Notifications:    a.Notifications,
+		AcknowledgedBy:   a.AcknowledgedBy,
+		AcknowledgedAt:   a.AcknowledgedAt,
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        a.UpdatedAt,
+	}
+}
+
+func alertFromDomain(a *domainAlert.Alert) *Alert {
+	return &Alert{
+		ID:               a.ID,
+		Type:             a.Type,
+		PolicyID:         a.PolicyID,
+		ScheduleID:       a.ScheduleID,
+		CaregiverUserID:  a.CaregiverUserID,
+		ClientUserID:     a.ClientUserID,
+		Message:          a.Message,
+		Emergency:        a.Emergency,
+		Location:         a.Location,
+		Status:           a.Status,
+		NextStep:         a.NextStep,
+		NextEscalationAt: a.NextEscalationAt,
+		Notifications:    a.Notifications,
+		AcknowledgedBy:   a.AcknowledgedBy,
+		AcknowledgedAt:   a.AcknowledgedAt,
+	}
+}
+
+func alertsToDomain(models []Alert) *[]domainAlert.Alert {
+	alerts := make([]domainAlert.Alert, len(models))
+	for i := range models {
+		alerts[i] = *models[i].toDomainMapper()
+	}
+	return &alerts
+}
@@ -0,0 +1,195 @@
+package announcement
+
+import (
+	"errors"
+	"time"
+
+	domainAnnouncement "caregiver/src/domain/announcement"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Announcement struct {
+	ID           uuid.UUID                   `gorm:"primaryKey;type:uuid"`
+	Subject      string                      `gorm:"column:subject"`
+	Body         string                      `gorm:"column:body;type:text"`
+	Audience     domainAnnouncement.Audience `gorm:"column:audience;serializer:json"`
+	SentByUserID *uuid.UUID                  `gorm:"column:sent_by_user_id;type:uuid"`
+	SentAt       time.Time                   `gorm:"column:sent_at;index"`
+	Recipients   int                         `gorm:"column:recipients"`
+	Reached      int                         `gorm:"column:reached"`
+	CreatedAt    time.Time                   `gorm:"autoCreateTime:milli"`
+}
+
+type Delivery struct {
+	ID             uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AnnouncementID uuid.UUID `gorm:"column:announcement_id;type:uuid;index"`
+	UserID         uuid.UUID `gorm:"column:user_id;type:uuid;index"`
+	Channel        string    `gorm:"column:channel"`
+	Status         string    `gorm:"column:status"`
+	Error          string    `gorm:"column:error"`
+	CreatedAt      time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Announcement) TableName() string {
+	return "announcements"
+}
+
+func (Delivery) TableName() string {
+	return "announcement_deliveries"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewAnnouncementRepository(db *gorm.DB, loggerInstance *logger.Logger) domainAnnouncement.IAnnouncementRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(announcement *domainAnnouncement.Announcement, deliveries []domainAnnouncement.Delivery) (*domainAnnouncement.Announcement, error) {
+	model := &Announcement{
+		ID:           announcement.ID,
+		Subject:      announcement.Subject,
+		Body:         announcement.Body,
+		Audience:     announcement.Audience,
+		SentByUserID: announcement.SentByUserID,
+		SentAt:       announcement.SentAt,
+		Recipients:   announcement.Recipients,
+		Reached:      announcement.Reached,
+	}
+	deliveryModels := make([]Delivery, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryModels[i] = Delivery{
+			AnnouncementID: announcement.ID,
+			UserID:         delivery.UserID,
+			Channel:        delivery.Channel,
+			Status:         delivery.Status,
+			Error:          delivery.Error,
+		}
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		if len(deliveryModels) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(deliveryModels, 500).Error
+	})
+	if err != nil {
+		r.Logger.Error("Error saving announcement", zap.Error(err), zap.String("id", announcement.ID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainAnnouncement.Announcement, error) {
+	var model Announcement
+	if err := r.DB.Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting announcement", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll() (*[]domainAnnouncement.Announcement, error) {
+	var models []Announcement
+	if err := r.DB.Order("sent_at DESC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting announcements", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainAnnouncement.Announcement, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetDeliveries(announcementID uuid.UUID) (*[]domainAnnouncement.Delivery, error) {
+	var models []Delivery
+	if err := r.DB.Where("announcement_id = ?", announcementID).Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting announcement deliveries", zap.Error(err), zap.String("announcementID", announcementID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainAnnouncement.Delivery, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) RecordDelivery(delivery domainAnnouncement.Delivery) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if delivery.Status == domainAnnouncement.DeliveryUnreachable {
+			return tx.Model(&Delivery{}).
+				Where("announcement_id = ? AND user_id = ? AND status = ?", delivery.AnnouncementID, delivery.UserID, domainAnnouncement.DeliveryQueued).
+				Update("status", domainAnnouncement.DeliveryUnreachable).Error
+		}
+		if err := tx.Where("announcement_id = ? AND user_id = ? AND status IN ?", delivery.AnnouncementID, delivery.UserID,
+			[]string{domainAnnouncement.DeliveryQueued, domainAnnouncement.DeliveryUnreachable}).Delete(&Delivery{}).Error; err != nil {
+			return err
+		}
+		if delivery.Status == domainAnnouncement.DeliverySent {
+			var sent int64
+			if err := tx.Model(&Delivery{}).
+				Where("announcement_id = ? AND user_id = ? AND status = ?", delivery.AnnouncementID, delivery.UserID, domainAnnouncement.DeliverySent).
+				Count(&sent).Error; err != nil {
+				return err
+			}
+			if sent == 0 {
+				if err := tx.Model(&Announcement{}).Where("id = ?", delivery.AnnouncementID).
+					Update("reached", gorm.Expr("reached + 1")).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Create(&Delivery{
+			AnnouncementID: delivery.AnnouncementID,
+			UserID:         delivery.UserID,
+			Channel:        delivery.Channel,
+			Status:         delivery.Status,
+			Error:          delivery.Error,
+		}).Error
+	})
+	if err != nil {
+		r.Logger.Error("Error recording announcement delivery", zap.Error(err), zap.String("announcementID", delivery.AnnouncementID.String()),
+			zap.String("userID", delivery.UserID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (m *Announcement) toDomainMapper() *domainAnnouncement.Announcement {
+	return &domainAnnouncement.Announcement{
+		ID:           m.ID,
+		Subject:      m.Subject,
+		Body:         m.Body,
+		Audience:     m.Audience,
+		SentByUserID: m.SentByUserID,
+		SentAt:       m.SentAt,
+		Recipients:   m.Recipients,
+		Reached:      m.Reached,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func (m *Delivery) toDomainMapper() *domainAnnouncement.Delivery {
+	return &domainAnnouncement.Delivery{
+		ID:             m.ID,
+		AnnouncementID: m.AnnouncementID,
+		UserID:         m.UserID,
+		Channel:        m.Channel,
+		Status:         m.Status,
+		Error:          m.Error,
+		CreatedAt:      m.CreatedAt,
+	}
+}
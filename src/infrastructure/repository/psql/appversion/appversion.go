@@ -0,0 +1,95 @@
+package appversion
+
+import (
+	"time"
+
+	domainAppVersion "caregiver/src/domain/appversion"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Requirement struct {
+	ID             uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Platform       string    `gorm:"column:platform;uniqueIndex"`
+	MinimumVersion string    `gorm:"column:minimum_version"`
+	StoreURL       string    `gorm:"column:store_url"`
+	Message        string    `gorm:"column:message"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Requirement) TableName() string {
+	return "app_version_requirements"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewAppVersionRepository(db *gorm.DB, loggerInstance *logger.Logger) domainAppVersion.IAppVersionRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) GetRequirements() (*[]domainAppVersion.Requirement, error) {
+	var requirements []Requirement
+	if err := r.DB.Order("platform ASC").Find(&requirements).Error; err != nil {
+		r.Logger.Error("Error getting app version requirements", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainAppVersion.Requirement, len(requirements))
+	for i := range requirements {
+		res[i] = *requirements[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) SaveRequirement(requirement *domainAppVersion.Requirement) (*domainAppVersion.Requirement, error) {
+	model := &Requirement{
+		Platform:       requirement.Platform,
+		MinimumVersion: requirement.MinimumVersion,
+		StoreURL:       requirement.StoreURL,
+		Message:        requirement.Message,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "platform"}},
+		DoUpdates: clause.AssignmentColumns([]string{"minimum_version", "store_url", "message", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		r.Logger.Error("Error saving app version requirement", zap.Error(err), zap.String("platform", requirement.Platform))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	var saved Requirement
+	if err := r.DB.Where("platform = ?", requirement.Platform).First(&saved).Error; err != nil {
+		r.Logger.Error("Error getting saved app version requirement", zap.Error(err), zap.String("platform", requirement.Platform))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return saved.toDomainMapper(), nil
+}
+
+func (r *Repository) DeleteRequirement(platform string) error {
+	result := r.DB.Where("platform = ?", platform).Delete(&Requirement{})
+	if result.Error != nil {
+		r.Logger.Error("Error deleting app version requirement", zap.Error(result.Error), zap.String("platform", platform))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (m *Requirement) toDomainMapper() *domainAppVersion.Requirement {
+	return &domainAppVersion.Requirement{
+		ID:             m.ID,
+		Platform:       m.Platform,
+		MinimumVersion: m.MinimumVersion,
+		StoreURL:       m.StoreURL,
+		Message:        m.Message,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
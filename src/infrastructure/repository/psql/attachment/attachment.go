@@ -0,0 +1,186 @@
+package attachment
+
+import (
+	"time"
+
+	domainAttachment "caregiver/src/domain/attachment"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Attachment struct {
+	ID                    uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID            uuid.UUID  `gorm:"column:schedule_id;type:uuid;index"`
+	TaskID                *uuid.UUID `gorm:"column:task_id;type:uuid;index"`
+	FileName              string     `gorm:"column:file_name"`
+	ContentType           string     `gorm:"column:content_type"`
+	Size                  int64      `gorm:"column:size"`
+	StorageKey            string     `gorm:"column:storage_key"`
+	RequiredBeforeCheckin bool       `gorm:"column:required_before_checkin"`
+	UploadedByUserID      uuid.UUID  `gorm:"column:uploaded_by_user_id;type:uuid"`
+	CreatedAt             time.Time  `gorm:"autoCreateTime:milli"`
+}
+
+type View struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AttachmentID uuid.UUID `gorm:"column:attachment_id;type:uuid;uniqueIndex:idx_attachment_view_user"`
+	ScheduleID   uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	UserID       uuid.UUID `gorm:"column:user_id;type:uuid;uniqueIndex:idx_attachment_view_user"`
+	ViewedAt     time.Time `gorm:"column:viewed_at"`
+}
+
+func (Attachment) TableName() string {
+	return "schedule_attachments"
+}
+
+func (View) TableName() string {
+	return "schedule_attachment_views"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewAttachmentRepository(db *gorm.DB, loggerInstance *logger.Logger) domainAttachment.IAttachmentRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newAttachment *domainAttachment.Attachment) (*domainAttachment.Attachment, error) {
+	model := fromDomainMapper(newAttachment)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating attachment", zap.Error(err), zap.String("scheduleID", newAttachment.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Attachment created successfully", zap.String("attachmentID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainAttachment.Attachment, error) {
+	var model Attachment
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Attachment not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting attachment by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetBySchedule(scheduleID uuid.UUID) (*[]domainAttachment.Attachment, error) {
+	var models []Attachment
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting attachments for schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	attachments := make([]domainAttachment.Attachment, len(models))
+	for i := range models {
+		attachments[i] = *models[i].toDomainMapper()
+	}
+	return &attachments, nil
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	tx := r.DB.Delete(&Attachment{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting attachment", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Attachment not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	if err := r.DB.Where("attachment_id = ?", id).Delete(&View{}).Error; err != nil {
+		r.Logger.Error("Error deleting attachment views", zap.Error(err), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+// RecordView stores the first time a user opened an attachment; later opens
+// leave the original timestamp untouched.
+func (r *Repository) RecordView(view *domainAttachment.View) (*domainAttachment.View, error) {
+	model := View{
+		ID:           uuid.New(),
+		AttachmentID: view.AttachmentID,
+		ScheduleID:   view.ScheduleID,
+		UserID:       view.UserID,
+		ViewedAt:     view.ViewedAt,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "attachment_id"}, {Name: "user_id"}},
+		DoNothing: true,
+	}).Create(&model).Error
+	if err != nil {
+		r.Logger.Error("Error recording attachment view", zap.Error(err), zap.String("attachmentID", view.AttachmentID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	var stored View
+	if err := r.DB.Where("attachment_id = ? AND user_id = ?", view.AttachmentID, view.UserID).First(&stored).Error; err != nil {
+		r.Logger.Error("Error reading attachment view", zap.Error(err), zap.String("attachmentID", view.AttachmentID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return stored.toDomainMapper(), nil
+}
+
+func (r *Repository) GetViewsBySchedule(scheduleID uuid.UUID) (*[]domainAttachment.View, error) {
+	var models []View
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting attachment views for schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	views := make([]domainAttachment.View, len(models))
+	for i := range models {
+		views[i] = *models[i].toDomainMapper()
+	}
+	return &views, nil
+}
+
+func (a *Attachment) toDomainMapper() *domainAttachment.Attachment {
+	return &domainAttachment.Attachment{
+		ID:                    a.ID,
+		ScheduleID:            a.ScheduleID,
+		TaskID:                a.TaskID,
+		FileName:              a.FileName,
+		ContentType:           a.ContentType,
+		Size:                  a.Size,
+		StorageKey:            a.StorageKey,
+		RequiredBeforeCheckin: a.RequiredBeforeCheckin,
+		UploadedByUserID:      a.UploadedByUserID,
+		CreatedAt:             a.CreatedAt,
+	}
+}
+
+func fromDomainMapper(a *domainAttachment.Attachment) *Attachment {
+	return &Attachment{
+		ID:                    a.ID,
+		ScheduleID:            a.ScheduleID,
+		TaskID:                a.TaskID,
+		FileName:              a.FileName,
+		ContentType:           a.ContentType,
+		Size:                  a.Size,
+		StorageKey:            a.StorageKey,
+		RequiredBeforeCheckin: a.RequiredBeforeCheckin,
+		UploadedByUserID:      a.UploadedByUserID,
+		CreatedAt:             a.CreatedAt,
+	}
+}
+
+func (v *View) toDomainMapper() *domainAttachment.View {
+	return &domainAttachment.View{
+		ID:           v.ID,
+		AttachmentID: v.AttachmentID,
+		ScheduleID:   v.ScheduleID,
+		UserID:       v.UserID,
+		ViewedAt:     v.ViewedAt,
+	}
+}
@@ -0,0 +1,66 @@
+package attestation
+
+import (
+	"time"
+
+	domainAttestation "caregiver/src/domain/attestation"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Settings struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Required      bool      `gorm:"column:required"`
+	WindowMinutes int       `gorm:"column:window_minutes"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Settings) TableName() string {
+	return "attestation_settings"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewAttestationRepository(db *gorm.DB, loggerInstance *logger.Logger) domainAttestation.IAttestationRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+// GetSettings returns the organization's attestation settings, of which there
+// is at most one row.
+func (r *Repository) GetSettings() (*domainAttestation.Settings, error) {
+	var model Settings
+	err := r.DB.Order("updated_at ASC").First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting attestation settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) SaveSettings(settings *domainAttestation.Settings) (*domainAttestation.Settings, error) {
+	model := Settings{ID: settings.ID, Required: settings.Required, WindowMinutes: settings.WindowMinutes}
+	if err := r.DB.Save(&model).Error; err != nil {
+		r.Logger.Error("Error saving attestation settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetSettings()
+}
+
+func (s *Settings) toDomainMapper() *domainAttestation.Settings {
+	return &domainAttestation.Settings{
+		ID:            s.ID,
+		Required:      s.Required,
+		WindowMinutes: s.WindowMinutes,
+		UpdatedAt:     s.UpdatedAt,
+	}
+}
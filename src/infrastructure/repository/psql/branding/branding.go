@@ -0,0 +1,87 @@
+package branding
+
+import (
+	"time"
+
+	domainBranding "caregiver/src/domain/branding"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Branding struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	DisplayName     string    `gorm:"column:display_name"`
+	LogoURL         string    `gorm:"column:logo_url"`
+	LogoKey         string    `gorm:"column:logo_key"`
+	LogoContentType string    `gorm:"column:logo_content_type"`
+	PrimaryColor    string    `gorm:"column:primary_color"`
+	AccentColor     string    `gorm:"column:accent_color"`
+	SenderName      string    `gorm:"column:sender_name"`
+	ReplyTo         string    `gorm:"column:reply_to"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Branding) TableName() string {
+	return "branding_settings"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewBrandingRepository(db *gorm.DB, loggerInstance *logger.Logger) domainBranding.IBrandingRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+// Get returns the agency branding, of which there is at most one row.
+func (r *Repository) Get() (*domainBranding.Branding, error) {
+	var model Branding
+	err := r.DB.Order("updated_at ASC").First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting branding settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) Save(branding *domainBranding.Branding) (*domainBranding.Branding, error) {
+	model := Branding{
+		ID:              branding.ID,
+		DisplayName:     branding.DisplayName,
+		LogoURL:         branding.LogoURL,
+		LogoKey:         branding.LogoKey,
+		LogoContentType: branding.LogoContentType,
+		PrimaryColor:    branding.PrimaryColor,
+		AccentColor:     branding.AccentColor,
+		SenderName:      branding.SenderName,
+		ReplyTo:         branding.ReplyTo,
+	}
+	if err := r.DB.Save(&model).Error; err != nil {
+		r.Logger.Error("Error saving branding settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.Get()
+}
+
+func (b *Branding) toDomainMapper() *domainBranding.Branding {
+	return &domainBranding.Branding{
+		ID:              b.ID,
+		DisplayName:     b.DisplayName,
+		LogoURL:         b.LogoURL,
+		LogoKey:         b.LogoKey,
+		LogoContentType: b.LogoContentType,
+		PrimaryColor:    b.PrimaryColor,
+		AccentColor:     b.AccentColor,
+		SenderName:      b.SenderName,
+		ReplyTo:         b.ReplyTo,
+		UpdatedAt:       b.UpdatedAt,
+	}
+}
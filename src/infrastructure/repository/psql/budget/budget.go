@@ -0,0 +1,206 @@
+package budget
+
+import (
+	"time"
+
+	domainBudget "caregiver/src/domain/budget"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Budget struct {
+	ID               uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID     uuid.UUID `gorm:"column:client_user_id;type:uuid;index"`
+	Period           string    `gorm:"column:period"`
+	Unit             string    `gorm:"column:unit"`
+	Limit            float64   `gorm:"column:limit_amount"`
+	HourlyRate       *float64  `gorm:"column:hourly_rate"`
+	WarningThreshold float64   `gorm:"column:warning_threshold"`
+	HardCap          bool      `gorm:"column:hard_cap"`
+	Active           bool      `gorm:"column:active"`
+	CreatedAt        time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+type Entry struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	BudgetID    uuid.UUID `gorm:"column:budget_id;type:uuid;index"`
+	ScheduleID  uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	PeriodStart time.Time `gorm:"column:period_start"`
+	Amount      float64   `gorm:"column:amount"`
+	CreatedAt   time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Budget) TableName() string {
+	return "client_budgets"
+}
+
+func (Entry) TableName() string {
+	return "client_budget_entries"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewBudgetRepository(db *gorm.DB, loggerInstance *logger.Logger) domainBudget.IBudgetRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newBudget *domainBudget.Budget) (*domainBudget.Budget, error) {
+	budgetModel := fromDomainMapper(newBudget)
+	if err := r.DB.Create(budgetModel).Error; err != nil {
+		r.Logger.Error("Error creating budget", zap.Error(err), zap.String("clientUserID", newBudget.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Budget created successfully", zap.String("budgetID", budgetModel.ID.String()))
+	return budgetModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainBudget.Budget, error) {
+	var budgetModel Budget
+	err := r.DB.Where("id = ?", id).First(&budgetModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Budget not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting budget by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return budgetModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll() (*[]domainBudget.Budget, error) {
+	var budgets []Budget
+	if err := r.DB.Order("created_at ASC").Find(&budgets).Error; err != nil {
+		r.Logger.Error("Error getting all budgets", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&budgets), nil
+}
+
+func (r *Repository) GetActiveByClientUserID(clientUserID uuid.UUID) (*[]domainBudget.Budget, error) {
+	var budgets []Budget
+	if err := r.DB.Where("client_user_id = ? AND active = ?", clientUserID, true).Find(&budgets).Error; err != nil {
+		r.Logger.Error("Error getting budgets for client", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&budgets), nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainBudget.Budget, error) {
+	var budgetModel Budget
+	budgetModel.ID = id
+	if err := r.DB.Model(&budgetModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating budget", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	tx := r.DB.Delete(&Budget{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting budget", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Budget not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	if err := r.DB.Where("budget_id = ?", id).Delete(&Entry{}).Error; err != nil {
+		r.Logger.Error("Error deleting budget entries", zap.Error(err), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) SumEntries(budgetID uuid.UUID, periodStart time.Time, excludeScheduleID uuid.UUID) (float64, error) {
+	var total float64
+	err := r.DB.Model(&Entry{}).
+		Where("budget_id = ? AND period_start = ? AND schedule_id <> ?", budgetID, periodStart, excludeScheduleID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	if err != nil {
+		r.Logger.Error("Error summing budget entries", zap.Error(err), zap.String("budgetID", budgetID.String()))
+		return 0, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return total, nil
+}
+
+func (r *Repository) ReplaceEntries(scheduleID uuid.UUID, entries []domainBudget.Entry) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("schedule_id = ?", scheduleID).Delete(&Entry{}).Error; err != nil {
+			r.Logger.Error("Error clearing budget entries", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		for _, entry := range entries {
+			model := Entry{
+				ID:          uuid.New(),
+				BudgetID:    entry.BudgetID,
+				ScheduleID:  scheduleID,
+				PeriodStart: entry.PeriodStart,
+				Amount:      entry.Amount,
+			}
+			if err := tx.Create(&model).Error; err != nil {
+				r.Logger.Error("Error recording budget entry", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+				return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) DeleteEntriesBySchedule(scheduleID uuid.UUID) error {
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Delete(&Entry{}).Error; err != nil {
+		r.Logger.Error("Error releasing budget entries", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (b *Budget) toDomainMapper() *domainBudget.Budget {
+	return &domainBudget.Budget{
+		ID:               b.ID,
+		ClientUserID:     b.ClientUserID,
+		Period:           b.Period,
+		Unit:             b.Unit,
+		Limit:            b.Limit,
+		HourlyRate:       b.HourlyRate,
+		WarningThreshold: b.WarningThreshold,
+		HardCap:          b.HardCap,
+		Active:           b.Active,
+		CreatedAt:        b.CreatedAt,
+		UpdatedAt:        b.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(b *domainBudget.Budget) *Budget {
+	return &Budget{
+		ID:               b.ID,
+		ClientUserID:     b.ClientUserID,
+		Period:           b.Period,
+		Unit:             b.Unit,
+		Limit:            b.Limit,
+		HourlyRate:       b.HourlyRate,
+		WarningThreshold: b.WarningThreshold,
+		HardCap:          b.HardCap,
+		Active:           b.Active,
+		CreatedAt:        b.CreatedAt,
+		UpdatedAt:        b.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(budgets *[]Budget) *[]domainBudget.Budget {
+	budgetsDomain := make([]domainBudget.Budget, len(*budgets))
+	for i, b := range *budgets {
+		budgetsDomain[i] = *b.toDomainMapper()
+	}
+	return &budgetsDomain
+}
@@ -0,0 +1,363 @@
+package careplan
+
+import (
+	"time"
+
+	domainCarePlan "caregiver/src/domain/careplan"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Plan struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID  uuid.UUID  `gorm:"column:client_user_id;type:uuid;index"`
+	Title         string     `gorm:"column:title"`
+	Notes         string     `gorm:"column:notes"`
+	StartDate     time.Time  `gorm:"column:start_date"`
+	ReviewDate    *time.Time `gorm:"column:review_date"`
+	VisitsPerWeek int        `gorm:"column:visits_per_week;default:0"`
+	Active        bool       `gorm:"column:active"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Plan) TableName() string {
+	return "care_plans"
+}
+
+type Goal struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	PlanID      uuid.UUID  `gorm:"column:plan_id;type:uuid;index"`
+	Description string     `gorm:"column:description"`
+	Metric      string     `gorm:"column:metric"`
+	Unit        string     `gorm:"column:unit"`
+	Baseline    float64    `gorm:"column:baseline"`
+	Target      float64    `gorm:"column:target"`
+	Cadence     string     `gorm:"column:cadence"`
+	TargetDate  *time.Time `gorm:"column:target_date"`
+	Status      string     `gorm:"column:status"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Goal) TableName() string {
+	return "care_plan_goals"
+}
+
+type Entry struct {
+	ID               uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	GoalID           uuid.UUID `gorm:"column:goal_id;type:uuid;index"`
+	ScheduleID       uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	RecordedByUserID uuid.UUID `gorm:"column:recorded_by_user_id;type:uuid"`
+	Value            float64   `gorm:"column:value"`
+	Note             string    `gorm:"column:note"`
+	RecordedAt       time.Time `gorm:"column:recorded_at;index"`
+	CreatedAt        time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Entry) TableName() string {
+	return "care_plan_goal_entries"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewCarePlanRepository(db *gorm.DB, loggerInstance *logger.Logger) domainCarePlan.ICarePlanRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreatePlan(newPlan *domainCarePlan.Plan) (*domainCarePlan.Plan, error) {
+	planModel := &Plan{
+		ID:            newPlan.ID,
+		ClientUserID:  newPlan.ClientUserID,
+		Title:         newPlan.Title,
+		Notes:         newPlan.Notes,
+		StartDate:     newPlan.StartDate,
+		ReviewDate:    newPlan.ReviewDate,
+		VisitsPerWeek: newPlan.VisitsPerWeek,
+		Active:        newPlan.Active,
+	}
+	if err := r.DB.Create(planModel).Error; err != nil {
+		r.Logger.Error("Error creating care plan", zap.Error(err), zap.String("clientUserID", newPlan.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Care plan created successfully", zap.String("planID", planModel.ID.String()))
+	return planModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetPlanByID(id uuid.UUID) (*domainCarePlan.Plan, error) {
+	var planModel Plan
+	err := r.DB.Where("id = ?", id).First(&planModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Care plan not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting care plan by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return planModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetPlans(clientUserID *uuid.UUID) (*[]domainCarePlan.Plan, error) {
+	query := r.DB.Model(&Plan{})
+	if clientUserID != nil {
+		query = query.Where("client_user_id = ?", *clientUserID)
+	}
+	var plans []Plan
+	if err := query.Order("start_date DESC").Find(&plans).Error; err != nil {
+		r.Logger.Error("Error getting care plans", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainCarePlan.Plan, len(plans))
+	for i := range plans {
+		res[i] = *plans[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetFrequencyPlans() (*[]domainCarePlan.Plan, error) {
+	var plans []Plan
+	if err := r.DB.Where("active = ? AND visits_per_week > 0", true).Order("client_user_id ASC").Find(&plans).Error; err != nil {
+		r.Logger.Error("Error getting care plans with a visit frequency", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainCarePlan.Plan, len(plans))
+	for i := range plans {
+		res[i] = *plans[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdatePlan(id uuid.UUID, updates map[string]interface{}) (*domainCarePlan.Plan, error) {
+	planModel := Plan{ID: id}
+	if err := r.DB.Model(&planModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating care plan", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetPlanByID(id)
+}
+
+func (r *Repository) DeletePlan(id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		goalIDs := tx.Model(&Goal{}).Select("id").Where("plan_id = ?", id)
+		if err := tx.Where("goal_id IN (?)", goalIDs).Delete(&Entry{}).Error; err != nil {
+			r.Logger.Error("Error deleting care plan goal entries", zap.Error(err), zap.String("planID", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		if err := tx.Where("plan_id = ?", id).Delete(&Goal{}).Error; err != nil {
+			r.Logger.Error("Error deleting care plan goals", zap.Error(err), zap.String("planID", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		result := tx.Delete(&Plan{}, "id = ?", id)
+		if result.Error != nil {
+			r.Logger.Error("Error deleting care plan", zap.Error(result.Error), zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		if result.RowsAffected == 0 {
+			r.Logger.Warn("Care plan not found for deletion", zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) CreateGoal(newGoal *domainCarePlan.Goal) (*domainCarePlan.Goal, error) {
+	goalModel := &Goal{
+		ID:          newGoal.ID,
+		PlanID:      newGoal.PlanID,
+		Description: newGoal.Description,
+		Metric:      newGoal.Metric,
+		Unit:        newGoal.Unit,
+		Baseline:    newGoal.Baseline,
+		Target:      newGoal.Target,
+		Cadence:     newGoal.Cadence,
+		TargetDate:  newGoal.TargetDate,
+		Status:      newGoal.Status,
+	}
+	if err := r.DB.Create(goalModel).Error; err != nil {
+		r.Logger.Error("Error creating care plan goal", zap.Error(err), zap.String("planID", newGoal.PlanID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Care plan goal created successfully", zap.String("goalID", goalModel.ID.String()))
+	return goalModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetGoalByID(id uuid.UUID) (*domainCarePlan.Goal, error) {
+	var goalModel Goal
+	err := r.DB.Where("id = ?", id).First(&goalModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Care plan goal not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting care plan goal by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return goalModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetGoals(planID uuid.UUID) (*[]domainCarePlan.Goal, error) {
+	var goals []Goal
+	if err := r.DB.Where("plan_id = ?", planID).Order("created_at ASC").Find(&goals).Error; err != nil {
+		r.Logger.Error("Error getting care plan goals", zap.Error(err), zap.String("planID", planID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainCarePlan.Goal, len(goals))
+	for i := range goals {
+		res[i] = *goals[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateGoal(id uuid.UUID, updates map[string]interface{}) (*domainCarePlan.Goal, error) {
+	goalModel := Goal{ID: id}
+	if err := r.DB.Model(&goalModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating care plan goal", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetGoalByID(id)
+}
+
+func (r *Repository) DeleteGoal(id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("goal_id = ?", id).Delete(&Entry{}).Error; err != nil {
+			r.Logger.Error("Error deleting care plan goal entries", zap.Error(err), zap.String("goalID", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		result := tx.Delete(&Goal{}, "id = ?", id)
+		if result.Error != nil {
+			r.Logger.Error("Error deleting care plan goal", zap.Error(result.Error), zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		if result.RowsAffected == 0 {
+			r.Logger.Warn("Care plan goal not found for deletion", zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) CreateEntry(newEntry *domainCarePlan.Entry) (*domainCarePlan.Entry, error) {
+	entryModel := &Entry{
+		ID:               newEntry.ID,
+		GoalID:           newEntry.GoalID,
+		ScheduleID:       newEntry.ScheduleID,
+		RecordedByUserID: newEntry.RecordedByUserID,
+		Value:            newEntry.Value,
+		Note:             newEntry.Note,
+		RecordedAt:       newEntry.RecordedAt,
+	}
+	if err := r.DB.Create(entryModel).Error; err != nil {
+		r.Logger.Error("Error creating care plan goal entry", zap.Error(err), zap.String("goalID", newEntry.GoalID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Care plan goal entry created successfully", zap.String("entryID", entryModel.ID.String()))
+	return entryModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetEntryByID(id uuid.UUID) (*domainCarePlan.Entry, error) {
+	var entryModel Entry
+	err := r.DB.Where("id = ?", id).First(&entryModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Care plan goal entry not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting care plan goal entry by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return entryModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetEntries(goalID uuid.UUID, from, to time.Time) (*[]domainCarePlan.Entry, error) {
+	var entries []Entry
+	err := r.DB.Where("goal_id = ? AND recorded_at >= ? AND recorded_at < ?", goalID, from, to).
+		Order("recorded_at ASC").Find(&entries).Error
+	if err != nil {
+		r.Logger.Error("Error getting care plan goal entries", zap.Error(err), zap.String("goalID", goalID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return entriesToDomainMapper(entries), nil
+}
+
+func (r *Repository) GetEntriesBySchedule(scheduleID uuid.UUID) (*[]domainCarePlan.Entry, error) {
+	var entries []Entry
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Order("recorded_at ASC").Find(&entries).Error; err != nil {
+		r.Logger.Error("Error getting care plan goal entries for visit", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return entriesToDomainMapper(entries), nil
+}
+
+func (r *Repository) DeleteEntry(id uuid.UUID) error {
+	tx := r.DB.Delete(&Entry{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting care plan goal entry", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Care plan goal entry not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (p *Plan) toDomainMapper() *domainCarePlan.Plan {
+	return &domainCarePlan.Plan{
+		ID:            p.ID,
+		ClientUserID:  p.ClientUserID,
+		Title:         p.Title,
+		Notes:         p.Notes,
+		StartDate:     p.StartDate,
+		ReviewDate:    p.ReviewDate,
+		VisitsPerWeek: p.VisitsPerWeek,
+		Active:        p.Active,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+}
+
+func (g *Goal) toDomainMapper() *domainCarePlan.Goal {
+	return &domainCarePlan.Goal{
+		ID:          g.ID,
+		PlanID:      g.PlanID,
+		Description: g.Description,
+		Metric:      g.Metric,
+		Unit:        g.Unit,
+		Baseline:    g.Baseline,
+		Target:      g.Target,
+		Cadence:     g.Cadence,
+		TargetDate:  g.TargetDate,
+		Status:      g.Status,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
+
+func (e *Entry) toDomainMapper() *domainCarePlan.Entry {
+	return &domainCarePlan.Entry{
+		ID:               e.ID,
+		GoalID:           e.GoalID,
+		ScheduleID:       e.ScheduleID,
+		RecordedByUserID: e.RecordedByUserID,
+		Value:            e.Value,
+		Note:             e.Note,
+		RecordedAt:       e.RecordedAt,
+		CreatedAt:        e.CreatedAt,
+	}
+}
+
+func entriesToDomainMapper(entries []Entry) *[]domainCarePlan.Entry {
+	res := make([]domainCarePlan.Entry, len(entries))
+	for i := range entries {
+		res[i] = *entries[i].toDomainMapper()
+	}
+	return &res
+}
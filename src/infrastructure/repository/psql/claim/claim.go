@@ -0,0 +1,886 @@
+package claim
+
+import (
+	"encoding/json"
+	"time"
+
+	domainClaim "caregiver/src/domain/claim"
+	domainDifferential "caregiver/src/domain/differential"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Payer struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name            string    `gorm:"column:name"`
+	PayerCode       string    `gorm:"column:payer_code;uniqueIndex"`
+	FilingIndicator string    `gorm:"column:filing_indicator"`
+	Active          bool      `gorm:"column:active"`
+	CreatedAt       time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Payer) TableName() string {
+	return "payers"
+}
+
+type Coverage struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID  uuid.UUID `gorm:"column:client_user_id;type:uuid;index"`
+	PayerID       uuid.UUID `gorm:"column:payer_id;type:uuid;index"`
+	MemberID      string    `gorm:"column:member_id"`
+	BirthDate     time.Time `gorm:"column:birth_date;type:date"`
+	Gender        string    `gorm:"column:gender"`
+	DiagnosisCode string    `gorm:"column:diagnosis_code"`
+	Active        bool      `gorm:"column:active"`
+	CreatedAt     time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Coverage) TableName() string {
+	return "client_coverages"
+}
+
+type Rate struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	PayerID       uuid.UUID  `gorm:"column:payer_id;type:uuid;index"`
+	ServiceName   string     `gorm:"column:service_name"`
+	ProcedureCode string     `gorm:"column:procedure_code"`
+	Modifier      string     `gorm:"column:modifier"`
+	UnitMinutes   int        `gorm:"column:unit_minutes"`
+	UnitRate      float64    `gorm:"column:unit_rate"`
+	EffectiveFrom time.Time  `gorm:"column:effective_from;type:date"`
+	EffectiveTo   *time.Time `gorm:"column:effective_to;type:date"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Rate) TableName() string {
+	return "payer_rates"
+}
+
+type ProviderSettings struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name         string    `gorm:"column:name"`
+	NPI          string    `gorm:"column:npi"`
+	TaxID        string    `gorm:"column:tax_id"`
+	Street       string    `gorm:"column:street"`
+	City         string    `gorm:"column:city"`
+	State        string    `gorm:"column:state"`
+	Zip          string    `gorm:"column:zip"`
+	SubmitterID  string    `gorm:"column:submitter_id"`
+	ContactName  string    `gorm:"column:contact_name"`
+	ContactPhone string    `gorm:"column:contact_phone"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (ProviderSettings) TableName() string {
+	return "claim_provider_settings"
+}
+
+type Batch struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	PayerID       uuid.UUID `gorm:"column:payer_id;type:uuid;index"`
+	ControlNumber int       `gorm:"column:control_number;uniqueIndex"`
+	From          time.Time `gorm:"column:from"`
+	To            time.Time `gorm:"column:to"`
+	ClaimCount    int       `gorm:"column:claim_count"`
+	TotalCharge   float64   `gorm:"column:total_charge"`
+	Content       string    `gorm:"column:content;type:text"`
+	CreatedAt     time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Batch) TableName() string {
+	return "claim_batches"
+}
+
+type Claim struct {
+	ID              uuid.UUID                 `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	BatchID         uuid.UUID                 `gorm:"column:batch_id;type:uuid;index"`
+	PayerID         uuid.UUID                 `gorm:"column:payer_id;type:uuid;index"`
+	ClientUserID    uuid.UUID                 `gorm:"column:client_user_id;type:uuid;index"`
+	ScheduleID      uuid.UUID                 `gorm:"column:schedule_id;type:uuid;index"`
+	ControlNumber   string                    `gorm:"column:control_number;uniqueIndex"`
+	ServiceDate     time.Time                 `gorm:"column:service_date;type:date"`
+	ProcedureCode   string                    `gorm:"column:procedure_code"`
+	Modifier        string                    `gorm:"column:modifier"`
+	Units           int                       `gorm:"column:units"`
+	Charge          float64                   `gorm:"column:charge"`
+	ChargeBreakdown []domainDifferential.Line `gorm:"column:charge_breakdown;serializer:json"`
+	PaidAmount      float64                   `gorm:"column:paid_amount"`
+	Status          string                    `gorm:"column:status;index"`
+	StatusReason    string                    `gorm:"column:status_reason"`
+	RemittanceID    *uuid.UUID                `gorm:"column:remittance_id;type:uuid"`
+	AdjudicatedAt   *time.Time                `gorm:"column:adjudicated_at"`
+	OriginalClaimID *uuid.UUID                `gorm:"column:original_claim_id;type:uuid"`
+	CreatedAt       time.Time                 `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time                 `gorm:"autoUpdateTime:milli"`
+}
+
+func (Claim) TableName() string {
+	return "claims"
+}
+
+type Remittance struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	PayerID     *uuid.UUID `gorm:"column:payer_id;type:uuid"`
+	PayerName   string     `gorm:"column:payer_name"`
+	TraceNumber string     `gorm:"column:trace_number;index"`
+	PaymentDate *time.Time `gorm:"column:payment_date;type:date"`
+	TotalPaid   float64    `gorm:"column:total_paid"`
+	Matched     int        `gorm:"column:matched"`
+	Unmatched   int        `gorm:"column:unmatched"`
+	Content     string     `gorm:"column:content;type:text"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime:milli"`
+}
+
+func (Remittance) TableName() string {
+	return "remittances"
+}
+
+type RemittanceLine struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	RemittanceID  uuid.UUID  `gorm:"column:remittance_id;type:uuid;index"`
+	ClaimID       *uuid.UUID `gorm:"column:claim_id;type:uuid;index"`
+	ControlNumber string     `gorm:"column:control_number"`
+	StatusCode    string     `gorm:"column:status_code"`
+	Charge        float64    `gorm:"column:charge"`
+	Paid          float64    `gorm:"column:paid"`
+	Adjustments   string     `gorm:"column:adjustments"`
+}
+
+func (RemittanceLine) TableName() string {
+	return "remittance_lines"
+}
+
+type Denial struct {
+	ID             uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClaimID        uuid.UUID  `gorm:"column:claim_id;type:uuid;index"`
+	ScheduleID     uuid.UUID  `gorm:"column:schedule_id;type:uuid;index"`
+	ClientUserID   uuid.UUID  `gorm:"column:client_user_id;type:uuid"`
+	PayerID        uuid.UUID  `gorm:"column:payer_id;type:uuid;index"`
+	Amount         float64    `gorm:"column:amount"`
+	ReasonCodes    []string   `gorm:"column:reason_codes;serializer:json"`
+	Note           string     `gorm:"column:note"`
+	AssignedUserID *uuid.UUID `gorm:"column:assigned_user_id;type:uuid;index"`
+	Status         string     `gorm:"column:status;index"`
+	RebillClaimID  *uuid.UUID `gorm:"column:rebill_claim_id;type:uuid"`
+	DeniedAt       time.Time  `gorm:"column:denied_at"`
+	ResolvedAt     *time.Time `gorm:"column:resolved_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Denial) TableName() string {
+	return "claim_denials"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewClaimRepository(db *gorm.DB, loggerInstance *logger.Logger) domainClaim.IClaimRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreatePayer(newPayer *domainClaim.Payer) (*domainClaim.Payer, error) {
+	payerModel := &Payer{
+		ID:              newPayer.ID,
+		Name:            newPayer.Name,
+		PayerCode:       newPayer.PayerCode,
+		FilingIndicator: newPayer.FilingIndicator,
+		Active:          newPayer.Active,
+	}
+	if err := r.DB.Create(payerModel).Error; err != nil {
+		r.Logger.Error("Error creating payer", zap.Error(err), zap.String("payerCode", newPayer.PayerCode))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Payer created successfully", zap.String("payerID", payerModel.ID.String()))
+	return payerModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetPayerByID(id uuid.UUID) (*domainClaim.Payer, error) {
+	var payerModel Payer
+	if err := r.first(&payerModel, "payer", id); err != nil {
+		return nil, err
+	}
+	return payerModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetPayers() (*[]domainClaim.Payer, error) {
+	var payers []Payer
+	if err := r.DB.Order("name ASC").Find(&payers).Error; err != nil {
+		r.Logger.Error("Error getting payers", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClaim.Payer, len(payers))
+	for i := range payers {
+		res[i] = *payers[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdatePayer(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Payer, error) {
+	if err := r.update(&Payer{ID: id}, "payer", id, updates); err != nil {
+		return nil, err
+	}
+	return r.GetPayerByID(id)
+}
+
+func (r *Repository) DeletePayer(id uuid.UUID) error {
+	return r.delete(&Payer{}, "payer", id)
+}
+
+func (r *Repository) CreateCoverage(newCoverage *domainClaim.Coverage) (*domainClaim.Coverage, error) {
+	coverageModel := &Coverage{
+		ID:            newCoverage.ID,
+		ClientUserID:  newCoverage.ClientUserID,
+		PayerID:       newCoverage.PayerID,
+		MemberID:      newCoverage.MemberID,
+		BirthDate:     newCoverage.BirthDate,
+		Gender:        newCoverage.Gender,
+		DiagnosisCode: newCoverage.DiagnosisCode,
+		Active:        newCoverage.Active,
+	}
+	if err := r.DB.Create(coverageModel).Error; err != nil {
+		r.Logger.Error("Error creating coverage", zap.Error(err), zap.String("clientUserID", newCoverage.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return coverageModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetCoverageByID(id uuid.UUID) (*domainClaim.Coverage, error) {
+	var coverageModel Coverage
+	if err := r.first(&coverageModel, "coverage", id); err != nil {
+		return nil, err
+	}
+	return coverageModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetCoverages(filter domainClaim.CoverageFilter) (*[]domainClaim.Coverage, error) {
+	query := r.DB.Model(&Coverage{})
+	if filter.ClientUserID != nil {
+		query = query.Where("client_user_id = ?", *filter.ClientUserID)
+	}
+	if filter.PayerID != nil {
+		query = query.Where("payer_id = ?", *filter.PayerID)
+	}
+	var coverages []Coverage
+	if err := query.Order("created_at ASC").Find(&coverages).Error; err != nil {
+		r.Logger.Error("Error getting coverages", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClaim.Coverage, len(coverages))
+	for i := range coverages {
+		res[i] = *coverages[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateCoverage(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Coverage, error) {
+	if err := r.update(&Coverage{ID: id}, "coverage", id, updates); err != nil {
+		return nil, err
+	}
+	return r.GetCoverageByID(id)
+}
+
+func (r *Repository) DeleteCoverage(id uuid.UUID) error {
+	return r.delete(&Coverage{}, "coverage", id)
+}
+
+func (r *Repository) CreateRate(newRate *domainClaim.Rate) (*domainClaim.Rate, error) {
+	rateModel := &Rate{
+		ID:            newRate.ID,
+		PayerID:       newRate.PayerID,
+		ServiceName:   newRate.ServiceName,
+		ProcedureCode: newRate.ProcedureCode,
+		Modifier:      newRate.Modifier,
+		UnitMinutes:   newRate.UnitMinutes,
+		UnitRate:      newRate.UnitRate,
+		EffectiveFrom: newRate.EffectiveFrom,
+		EffectiveTo:   newRate.EffectiveTo,
+	}
+	if err := r.DB.Create(rateModel).Error; err != nil {
+		r.Logger.Error("Error creating payer rate", zap.Error(err), zap.String("payerID", newRate.PayerID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return rateModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRateByID(id uuid.UUID) (*domainClaim.Rate, error) {
+	var rateModel Rate
+	if err := r.first(&rateModel, "payer rate", id); err != nil {
+		return nil, err
+	}
+	return rateModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRates(payerID uuid.UUID) (*[]domainClaim.Rate, error) {
+	var rates []Rate
+	if err := r.DB.Where("payer_id = ?", payerID).Order("service_name ASC, effective_from ASC").Find(&rates).Error; err != nil {
+		r.Logger.Error("Error getting payer rates", zap.Error(err), zap.String("payerID", payerID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClaim.Rate, len(rates))
+	for i := range rates {
+		res[i] = *rates[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateRate(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Rate, error) {
+	if err := r.update(&Rate{ID: id}, "payer rate", id, updates); err != nil {
+		return nil, err
+	}
+	return r.GetRateByID(id)
+}
+
+func (r *Repository) DeleteRate(id uuid.UUID) error {
+	return r.delete(&Rate{}, "payer rate", id)
+}
+
+// GetProviderSettings returns the organization's billing provider details,
+// of which there is at most one row.
+func (r *Repository) GetProviderSettings() (*domainClaim.ProviderSettings, error) {
+	var model ProviderSettings
+	err := r.DB.Order("updated_at ASC").First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting claim provider settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) SaveProviderSettings(settings *domainClaim.ProviderSettings) (*domainClaim.ProviderSettings, error) {
+	model := ProviderSettings{
+		ID:           settings.ID,
+		Name:         settings.Name,
+		NPI:          settings.NPI,
+		TaxID:        settings.TaxID,
+		Street:       settings.Street,
+		City:         settings.City,
+		State:        settings.State,
+		Zip:          settings.Zip,
+		SubmitterID:  settings.SubmitterID,
+		ContactName:  settings.ContactName,
+		ContactPhone: settings.ContactPhone,
+	}
+	if err := r.DB.Save(&model).Error; err != nil {
+		r.Logger.Error("Error saving claim provider settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetProviderSettings()
+}
+
+func (r *Repository) CreateBatch(newBatch *domainClaim.Batch, claims []domainClaim.Claim) (*domainClaim.Batch, error) {
+	batchModel := &Batch{
+		ID:            newBatch.ID,
+		PayerID:       newBatch.PayerID,
+		ControlNumber: newBatch.ControlNumber,
+		From:          newBatch.From,
+		To:            newBatch.To,
+		ClaimCount:    newBatch.ClaimCount,
+		TotalCharge:   newBatch.TotalCharge,
+		Content:       newBatch.Content,
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(batchModel).Error; err != nil {
+			return err
+		}
+		if len(claims) == 0 {
+			return nil
+		}
+		claimModels := make([]Claim, len(claims))
+		for i := range claims {
+			claims[i].BatchID = batchModel.ID
+			claimModels[i] = *claimFromDomainMapper(&claims[i])
+		}
+		return tx.Create(&claimModels).Error
+	})
+	if err != nil {
+		r.Logger.Error("Error creating claim batch", zap.Error(err), zap.String("payerID", newBatch.PayerID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Claim batch created successfully", zap.String("batchID", batchModel.ID.String()), zap.Int("claims", len(claims)))
+	return batchModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetBatchByID(id uuid.UUID) (*domainClaim.Batch, error) {
+	var batchModel Batch
+	if err := r.first(&batchModel, "claim batch", id); err != nil {
+		return nil, err
+	}
+	return batchModel.toDomainMapper(), nil
+}
+
+// GetBatches lists batches without their file content.
+func (r *Repository) GetBatches(payerID *uuid.UUID) (*[]domainClaim.Batch, error) {
+	query := r.DB.Omit("content")
+	if payerID != nil {
+		query = query.Where("payer_id = ?", *payerID)
+	}
+	var batches []Batch
+	if err := query.Order("created_at DESC").Find(&batches).Error; err != nil {
+		r.Logger.Error("Error getting claim batches", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClaim.Batch, len(batches))
+	for i := range batches {
+		res[i] = *batches[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetLastBatchControlNumber() (int, error) {
+	var last int
+	if err := r.DB.Model(&Batch{}).Select("COALESCE(MAX(control_number), 0)").Scan(&last).Error; err != nil {
+		r.Logger.Error("Error getting last claim batch control number", zap.Error(err))
+		return 0, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return last, nil
+}
+
+func (r *Repository) GetClaimByID(id uuid.UUID) (*domainClaim.Claim, error) {
+	var claimModel Claim
+	if err := r.first(&claimModel, "claim", id); err != nil {
+		return nil, err
+	}
+	return claimModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetClaimByControlNumber(controlNumber string) (*domainClaim.Claim, error) {
+	var claimModel Claim
+	err := r.DB.Where("control_number = ?", controlNumber).First(&claimModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting claim by control number", zap.Error(err), zap.String("controlNumber", controlNumber))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return claimModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetClaims(filter domainClaim.ClaimFilter) (*[]domainClaim.Claim, error) {
+	query := r.DB.Model(&Claim{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.PayerID != nil {
+		query = query.Where("payer_id = ?", *filter.PayerID)
+	}
+	if filter.ClientUserID != nil {
+		query = query.Where("client_user_id = ?", *filter.ClientUserID)
+	}
+	if filter.BatchID != nil {
+		query = query.Where("batch_id = ?", *filter.BatchID)
+	}
+	var claims []Claim
+	if err := query.Order("service_date DESC, created_at DESC").Find(&claims).Error; err != nil {
+		r.Logger.Error("Error getting claims", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClaim.Claim, len(claims))
+	for i := range claims {
+		res[i] = *claims[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetBilledScheduleIDs(scheduleIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	billed := make(map[uuid.UUID]bool)
+	if len(scheduleIDs) == 0 {
+		return billed, nil
+	}
+	var ids []uuid.UUID
+	if err := r.DB.Model(&Claim{}).
+		Where("schedule_id IN ?", scheduleIDs).
+		Pluck("schedule_id", &ids).Error; err != nil {
+		r.Logger.Error("Error getting billed schedules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	for _, id := range ids {
+		billed[id] = true
+	}
+	return billed, nil
+}
+
+func (r *Repository) UpdateClaim(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Claim, error) {
+	if err := r.update(&Claim{ID: id}, "claim", id, updates); err != nil {
+		return nil, err
+	}
+	return r.GetClaimByID(id)
+}
+
+func (r *Repository) CreateRemittance(newRemittance *domainClaim.Remittance, lines []domainClaim.RemittanceLine) (*domainClaim.Remittance, error) {
+	remittanceModel := &Remittance{
+		ID:          newRemittance.ID,
+		PayerID:     newRemittance.PayerID,
+		PayerName:   newRemittance.PayerName,
+		TraceNumber: newRemittance.TraceNumber,
+		PaymentDate: newRemittance.PaymentDate,
+		TotalPaid:   newRemittance.TotalPaid,
+		Matched:     newRemittance.Matched,
+		Unmatched:   newRemittance.Unmatched,
+		Content:     newRemittance.Content,
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(remittanceModel).Error; err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return nil
+		}
+		lineModels := make([]RemittanceLine, len(lines))
+		for i, line := range lines {
+			lineModels[i] = RemittanceLine{
+				ID:            line.ID,
+				RemittanceID:  remittanceModel.ID,
+				ClaimID:       line.ClaimID,
+				ControlNumber: line.ControlNumber,
+				StatusCode:    line.StatusCode,
+				Charge:        line.Charge,
+				Paid:          line.Paid,
+				Adjustments:   line.Adjustments,
+			}
+		}
+		return tx.Create(&lineModels).Error
+	})
+	if err != nil {
+		r.Logger.Error("Error creating remittance", zap.Error(err), zap.String("traceNumber", newRemittance.TraceNumber))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return remittanceModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRemittanceByID(id uuid.UUID) (*domainClaim.Remittance, error) {
+	var remittanceModel Remittance
+	if err := r.first(&remittanceModel, "remittance", id); err != nil {
+		return nil, err
+	}
+	return remittanceModel.toDomainMapper(), nil
+}
+
+// GetRemittances lists remittances without their file content.
+func (r *Repository) GetRemittances() (*[]domainClaim.Remittance, error) {
+	var remittances []Remittance
+	if err := r.DB.Omit("content").Order("created_at DESC").Find(&remittances).Error; err != nil {
+		r.Logger.Error("Error getting remittances", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClaim.Remittance, len(remittances))
+	for i := range remittances {
+		res[i] = *remittances[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetRemittanceLines(remittanceID uuid.UUID) (*[]domainClaim.RemittanceLine, error) {
+	var lines []RemittanceLine
+	if err := r.DB.Where("remittance_id = ?", remittanceID).Order("control_number ASC").Find(&lines).Error; err != nil {
+		r.Logger.Error("Error getting remittance lines", zap.Error(err), zap.String("remittanceID", remittanceID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClaim.RemittanceLine, len(lines))
+	for i, line := range lines {
+		res[i] = domainClaim.RemittanceLine{
+			ID:            line.ID,
+			RemittanceID:  line.RemittanceID,
+			ClaimID:       line.ClaimID,
+			ControlNumber: line.ControlNumber,
+			StatusCode:    line.StatusCode,
+			Charge:        line.Charge,
+			Paid:          line.Paid,
+			Adjustments:   line.Adjustments,
+		}
+	}
+	return &res, nil
+}
+
+func (r *Repository) CreateDenial(newDenial *domainClaim.Denial) (*domainClaim.Denial, error) {
+	denialModel := &Denial{
+		ID:             newDenial.ID,
+		ClaimID:        newDenial.ClaimID,
+		ScheduleID:     newDenial.ScheduleID,
+		ClientUserID:   newDenial.ClientUserID,
+		PayerID:        newDenial.PayerID,
+		Amount:         newDenial.Amount,
+		ReasonCodes:    newDenial.ReasonCodes,
+		Note:           newDenial.Note,
+		AssignedUserID: newDenial.AssignedUserID,
+		Status:         newDenial.Status,
+		DeniedAt:       newDenial.DeniedAt,
+	}
+	if err := r.DB.Create(denialModel).Error; err != nil {
+		r.Logger.Error("Error creating claim denial", zap.Error(err), zap.String("claimID", newDenial.ClaimID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Claim denial created successfully", zap.String("denialID", denialModel.ID.String()))
+	return denialModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetDenialByID(id uuid.UUID) (*domainClaim.Denial, error) {
+	var denialModel Denial
+	if err := r.first(&denialModel, "claim denial", id); err != nil {
+		return nil, err
+	}
+	return denialModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetOpenDenialByClaim(claimID uuid.UUID) (*domainClaim.Denial, error) {
+	var denialModel Denial
+	err := r.DB.Where("claim_id = ? AND status = ?", claimID, domainClaim.DenialOpen).First(&denialModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting open denial for claim", zap.Error(err), zap.String("claimID", claimID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return denialModel.toDomainMapper(), nil
+}
+
+// GetDenials lists denials oldest first. ReasonCode matches the adjustment
+// reason number whatever its group ("16" matches "CO-16").
+func (r *Repository) GetDenials(filter domainClaim.DenialFilter) (*[]domainClaim.Denial, error) {
+	query := r.DB.Model(&Denial{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.PayerID != nil {
+		query = query.Where("payer_id = ?", *filter.PayerID)
+	}
+	if filter.AssignedUserID != nil {
+		query = query.Where("assigned_user_id = ?", *filter.AssignedUserID)
+	}
+	if filter.ReasonCode != "" {
+		query = query.Where("reason_codes LIKE ? OR reason_codes LIKE ?", `%"`+filter.ReasonCode+`"%`, `%-`+filter.ReasonCode+`"%`)
+	}
+	var denials []Denial
+	if err := query.Order("denied_at ASC").Find(&denials).Error; err != nil {
+		r.Logger.Error("Error getting claim denials", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClaim.Denial, len(denials))
+	for i := range denials {
+		res[i] = *denials[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateDenial(id uuid.UUID, updates map[string]interface{}) (*domainClaim.Denial, error) {
+	// Map updates bypass the field serializer, so encode reason codes here.
+	if codes, ok := updates["reason_codes"]; ok {
+		encoded, err := json.Marshal(codes)
+		if err != nil {
+			r.Logger.Error("Error encoding denial reason codes", zap.Error(err), zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		updates["reason_codes"] = string(encoded)
+	}
+	if err := r.update(&Denial{ID: id}, "claim denial", id, updates); err != nil {
+		return nil, err
+	}
+	return r.GetDenialByID(id)
+}
+
+func (r *Repository) first(model interface{}, name string, id uuid.UUID) error {
+	err := r.DB.Where("id = ?", id).First(model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn(name+" not found", zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting "+name+" by ID", zap.Error(err), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) update(model interface{}, name string, id uuid.UUID, updates map[string]interface{}) error {
+	if err := r.DB.Model(model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating "+name, zap.Error(err), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) delete(model interface{}, name string, id uuid.UUID) error {
+	tx := r.DB.Delete(model, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting "+name, zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn(name+" not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (p *Payer) toDomainMapper() *domainClaim.Payer {
+	return &domainClaim.Payer{
+		ID:              p.ID,
+		Name:            p.Name,
+		PayerCode:       p.PayerCode,
+		FilingIndicator: p.FilingIndicator,
+		Active:          p.Active,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+func (c *Coverage) toDomainMapper() *domainClaim.Coverage {
+	return &domainClaim.Coverage{
+		ID:            c.ID,
+		ClientUserID:  c.ClientUserID,
+		PayerID:       c.PayerID,
+		MemberID:      c.MemberID,
+		BirthDate:     c.BirthDate,
+		Gender:        c.Gender,
+		DiagnosisCode: c.DiagnosisCode,
+		Active:        c.Active,
+		CreatedAt:     c.CreatedAt,
+		UpdatedAt:     c.UpdatedAt,
+	}
+}
+
+func (rt *Rate) toDomainMapper() *domainClaim.Rate {
+	return &domainClaim.Rate{
+		ID:            rt.ID,
+		PayerID:       rt.PayerID,
+		ServiceName:   rt.ServiceName,
+		ProcedureCode: rt.ProcedureCode,
+		Modifier:      rt.Modifier,
+		UnitMinutes:   rt.UnitMinutes,
+		UnitRate:      rt.UnitRate,
+		EffectiveFrom: rt.EffectiveFrom,
+		EffectiveTo:   rt.EffectiveTo,
+		CreatedAt:     rt.CreatedAt,
+		UpdatedAt:     rt.UpdatedAt,
+	}
+}
+
+func (s *ProviderSettings) toDomainMapper() *domainClaim.ProviderSettings {
+	return &domainClaim.ProviderSettings{
+		ID:           s.ID,
+		Name:         s.Name,
+		NPI:          s.NPI,
+		TaxID:        s.TaxID,
+		Street:       s.Street,
+		City:         s.City,
+		State:        s.State,
+		Zip:          s.Zip,
+		SubmitterID:  s.SubmitterID,
+		ContactName:  s.ContactName,
+		ContactPhone: s.ContactPhone,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+func (b *Batch) toDomainMapper() *domainClaim.Batch {
+	return &domainClaim.Batch{
+		ID:            b.ID,
+		PayerID:       b.PayerID,
+		ControlNumber: b.ControlNumber,
+		From:          b.From,
+		To:            b.To,
+		ClaimCount:    b.ClaimCount,
+		TotalCharge:   b.TotalCharge,
+		Content:       b.Content,
+		CreatedAt:     b.CreatedAt,
+	}
+}
+
+func (c *Claim) toDomainMapper() *domainClaim.Claim {
+	return &domainClaim.Claim{
+		ID:              c.ID,
+		BatchID:         c.BatchID,
+		PayerID:         c.PayerID,
+		ClientUserID:    c.ClientUserID,
+		ScheduleID:      c.ScheduleID,
+		ControlNumber:   c.ControlNumber,
+		ServiceDate:     c.ServiceDate,
+		ProcedureCode:   c.ProcedureCode,
+		Modifier:        c.Modifier,
+		Units:           c.Units,
+		Charge:          c.Charge,
+		ChargeBreakdown: c.ChargeBreakdown,
+		PaidAmount:      c.PaidAmount,
+		Status:          c.Status,
+		StatusReason:    c.StatusReason,
+		RemittanceID:    c.RemittanceID,
+		AdjudicatedAt:   c.AdjudicatedAt,
+		OriginalClaimID: c.OriginalClaimID,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func claimFromDomainMapper(c *domainClaim.Claim) *Claim {
+	return &Claim{
+		ID:              c.ID,
+		BatchID:         c.BatchID,
+		PayerID:         c.PayerID,
+		ClientUserID:    c.ClientUserID,
+		ScheduleID:      c.ScheduleID,
+		ControlNumber:   c.ControlNumber,
+		ServiceDate:     c.ServiceDate,
+		ProcedureCode:   c.ProcedureCode,
+		Modifier:        c.Modifier,
+		Units:           c.Units,
+		Charge:          c.Charge,
+		ChargeBreakdown: c.ChargeBreakdown,
+		PaidAmount:      c.PaidAmount,
+		Status:          c.Status,
+		StatusReason:    c.StatusReason,
+		RemittanceID:    c.RemittanceID,
+		AdjudicatedAt:   c.AdjudicatedAt,
+		OriginalClaimID: c.OriginalClaimID,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func (rm *Remittance) toDomainMapper() *domainClaim.Remittance {
+	return &domainClaim.Remittance{
+		ID:          rm.ID,
+		PayerID:     rm.PayerID,
+		PayerName:   rm.PayerName,
+		TraceNumber: rm.TraceNumber,
+		PaymentDate: rm.PaymentDate,
+		TotalPaid:   rm.TotalPaid,
+		Matched:     rm.Matched,
+		Unmatched:   rm.Unmatched,
+		Content:     rm.Content,
+		CreatedAt:   rm.CreatedAt,
+	}
+}
+
+func (d *Denial) toDomainMapper() *domainClaim.Denial {
+	return &domainClaim.Denial{
+		ID:             d.ID,
+		ClaimID:        d.ClaimID,
+		ScheduleID:     d.ScheduleID,
+		ClientUserID:   d.ClientUserID,
+		PayerID:        d.PayerID,
+		Amount:         d.Amount,
+		ReasonCodes:    d.ReasonCodes,
+		Note:           d.Note,
+		AssignedUserID: d.AssignedUserID,
+		Status:         d.Status,
+		RebillClaimID:  d.RebillClaimID,
+		DeniedAt:       d.DeniedAt,
+		ResolvedAt:     d.ResolvedAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
@@ -0,0 +1,98 @@
+package clienterror
+
+import (
+	"time"
+
+	domainClientError "caregiver/src/domain/clienterror"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Failure struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Method      string    `gorm:"column:method;uniqueIndex:idx_client_error_cause"`
+	Route       string    `gorm:"column:route;uniqueIndex:idx_client_error_cause"`
+	Status      int       `gorm:"column:status;uniqueIndex:idx_client_error_cause"`
+	Field       string    `gorm:"column:field;uniqueIndex:idx_client_error_cause"`
+	Rule        string    `gorm:"column:rule;uniqueIndex:idx_client_error_cause"`
+	Count       int64     `gorm:"column:count"`
+	FirstSeenAt time.Time `gorm:"column:first_seen_at"`
+	LastSeenAt  time.Time `gorm:"column:last_seen_at;index"`
+}
+
+func (Failure) TableName() string {
+	return "client_error_failures"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewClientErrorRepository(db *gorm.DB, loggerInstance *logger.Logger) domainClientError.IClientErrorRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) RecordFailure(failure *domainClientError.Failure) error {
+	failureModel := &Failure{
+		Method:      failure.Method,
+		Route:       failure.Route,
+		Status:      failure.Status,
+		Field:       failure.Field,
+		Rule:        failure.Rule,
+		Count:       failure.Count,
+		FirstSeenAt: failure.FirstSeenAt,
+		LastSeenAt:  failure.LastSeenAt,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "method"}, {Name: "route"}, {Name: "status"}, {Name: "field"}, {Name: "rule"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"count":        gorm.Expr("client_error_failures.count + ?", failure.Count),
+			"last_seen_at": failure.LastSeenAt,
+		}),
+	}).Create(failureModel).Error
+	if err != nil {
+		r.Logger.Error("Error recording client error", zap.Error(err), zap.String("route", failure.Route))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) GetFailures(filter domainClientError.FailureFilter) (*[]domainClientError.Failure, error) {
+	query := r.DB.Model(&Failure{})
+	if filter.Route != "" {
+		query = query.Where("route = ?", filter.Route)
+	}
+	if filter.Since != nil {
+		query = query.Where("last_seen_at >= ?", *filter.Since)
+	}
+	var failures []Failure
+	if err := query.Order("last_seen_at DESC").Find(&failures).Error; err != nil {
+		r.Logger.Error("Error getting client errors", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClientError.Failure, len(failures))
+	for i := range failures {
+		res[i] = *failures[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (f *Failure) toDomainMapper() *domainClientError.Failure {
+	return &domainClientError.Failure{
+		ID:          f.ID,
+		Method:      f.Method,
+		Route:       f.Route,
+		Status:      f.Status,
+		Field:       f.Field,
+		Rule:        f.Rule,
+		Count:       f.Count,
+		FirstSeenAt: f.FirstSeenAt,
+		LastSeenAt:  f.LastSeenAt,
+	}
+}
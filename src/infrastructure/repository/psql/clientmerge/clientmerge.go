@@ -0,0 +1,200 @@
+package clientmerge
+
+import (
+	"encoding/json"
+	"time"
+
+	domainClientMerge "caregiver/src/domain/clientmerge"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// reference is a table holding a client_user_id. Tables with a unique key
+// over the client list the rest of it in uniqueWith; rows clashing with one
+// the survivor already has stay with the duplicate.
+type reference struct {
+	table      string
+	uniqueWith string
+}
+
+// references are every table that points at a client. Visit notes,
+// attachments and voice memos hang off schedules and follow them.
+var references = []reference{
+	{table: "schedules"},
+	{table: "care_plans"},
+	{table: "client_allergies"},
+	{table: "client_medications"},
+	{table: "medication_administrations"},
+	{table: "vital_ranges", uniqueWith: "measure"},
+	{table: "vital_readings"},
+	{table: "client_nfc_tags", uniqueWith: "tag_value"},
+	{table: "form_submissions"},
+	{table: "client_consents"},
+	{table: "client_budgets"},
+	{table: "client_coverages"},
+	{table: "claims"},
+	{table: "claim_denials"},
+	{table: "client_payments"},
+	{table: "client_account_adjustments"},
+	{table: "visit_confirmations"},
+	{table: "visit_change_requests"},
+	{table: "waitlist_entries"},
+	{table: "equipment_checkouts"},
+	{table: "supply_usages"},
+	{table: "compliance_exceptions"},
+	{table: "alerts"},
+	{table: "prospects"},
+}
+
+type Merge struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	SurvivorUserID  uuid.UUID  `gorm:"column:survivor_user_id;type:uuid;index"`
+	DuplicateUserID uuid.UUID  `gorm:"column:duplicate_user_id;type:uuid;index"`
+	MergedByUserID  *uuid.UUID `gorm:"column:merged_by_user_id;type:uuid"`
+	Note            string     `gorm:"column:note"`
+	Moved           string     `gorm:"column:moved;type:jsonb"`
+	Kept            string     `gorm:"column:kept;type:jsonb"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli"`
+}
+
+func (Merge) TableName() string {
+	return "client_merges"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewClientMergeRepository(db *gorm.DB, loggerInstance *logger.Logger) domainClientMerge.IClientMergeRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Count(clientUserID uuid.UUID) (map[string]int64, error) {
+	counts := make(map[string]int64, len(references))
+	for _, ref := range references {
+		var count int64
+		if err := r.DB.Table(ref.table).Where("client_user_id = ?", clientUserID).Count(&count).Error; err != nil {
+			r.Logger.Error("Error counting client rows", zap.Error(err), zap.String("table", ref.table), zap.String("clientUserID", clientUserID.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		counts[ref.table] = count
+	}
+	return counts, nil
+}
+
+func (r *Repository) Merge(merge *domainClientMerge.Merge) (*domainClientMerge.Merge, error) {
+	merge.Moved = make(map[string]int64, len(references))
+	merge.Kept = make(map[string]int64)
+	var model *Merge
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, ref := range references {
+			query := tx.Table(ref.table).Where("client_user_id = ?", merge.DuplicateUserID)
+			if ref.uniqueWith != "" {
+				query = query.Where("NOT EXISTS (SELECT 1 FROM "+ref.table+" AS kept WHERE kept.client_user_id = ? AND kept."+ref.uniqueWith+" = "+ref.table+"."+ref.uniqueWith+")", merge.SurvivorUserID)
+			}
+			moved := query.Update("client_user_id", merge.SurvivorUserID)
+			if moved.Error != nil {
+				return moved.Error
+			}
+			merge.Moved[ref.table] = moved.RowsAffected
+			if ref.uniqueWith != "" {
+				var kept int64
+				if err := tx.Table(ref.table).Where("client_user_id = ?", merge.DuplicateUserID).Count(&kept).Error; err != nil {
+					return err
+				}
+				if kept > 0 {
+					merge.Kept[ref.table] = kept
+				}
+			}
+		}
+
+		tombstone := tx.Table("users").
+			Where("id = ? AND deleted_at IS NULL", merge.DuplicateUserID).
+			Updates(map[string]interface{}{"status": false, "deleted_at": time.Now(), "deleted_by": merge.MergedByUserID})
+		if tombstone.Error != nil {
+			return tombstone.Error
+		}
+		if tombstone.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var err error
+		if model, err = fromDomainMapper(merge); err != nil {
+			return err
+		}
+		return tx.Create(model).Error
+	})
+	if err == gorm.ErrRecordNotFound {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	if err != nil {
+		r.Logger.Error("Error merging clients", zap.Error(err), zap.String("survivorUserID", merge.SurvivorUserID.String()), zap.String("duplicateUserID", merge.DuplicateUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll() (*[]domainClientMerge.Merge, error) {
+	var models []Merge
+	if err := r.DB.Order("created_at DESC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting client merges", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainClientMerge.Merge, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainClientMerge.Merge, error) {
+	var model Merge
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting client merge", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func fromDomainMapper(m *domainClientMerge.Merge) (*Merge, error) {
+	moved, err := json.Marshal(m.Moved)
+	if err != nil {
+		return nil, err
+	}
+	kept, err := json.Marshal(m.Kept)
+	if err != nil {
+		return nil, err
+	}
+	return &Merge{
+		ID:              m.ID,
+		SurvivorUserID:  m.SurvivorUserID,
+		DuplicateUserID: m.DuplicateUserID,
+		MergedByUserID:  m.MergedByUserID,
+		Note:            m.Note,
+		Moved:           string(moved),
+		Kept:            string(kept),
+	}, nil
+}
+
+func (m *Merge) toDomainMapper() *domainClientMerge.Merge {
+	merge := &domainClientMerge.Merge{
+		ID:              m.ID,
+		SurvivorUserID:  m.SurvivorUserID,
+		DuplicateUserID: m.DuplicateUserID,
+		MergedByUserID:  m.MergedByUserID,
+		Note:            m.Note,
+		CreatedAt:       m.CreatedAt,
+	}
+	_ = json.Unmarshal([]byte(m.Moved), &merge.Moved)
+	_ = json.Unmarshal([]byte(m.Kept), &merge.Kept)
+	return merge
+}
@@ -0,0 +1,228 @@
+package compliance
+
+import (
+	"time"
+
+	domainCompliance "caregiver/src/domain/compliance"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Rule struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name        string    `gorm:"column:name"`
+	Type        string    `gorm:"column:type"`
+	ServiceName string    `gorm:"column:service_name"`
+	Threshold   int       `gorm:"column:threshold"`
+	Active      bool      `gorm:"column:active"`
+	CreatedAt   time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+type Exception struct {
+	ID                     uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	RuleID                 uuid.UUID `gorm:"column:rule_id;type:uuid;index"`
+	RuleName               string    `gorm:"column:rule_name"`
+	ScheduleID             uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	ClientUserID           uuid.UUID `gorm:"column:client_user_id;type:uuid"`
+	AssignedUserID         uuid.UUID `gorm:"column:assigned_user_id;type:uuid"`
+	Stage                  string    `gorm:"column:stage"`
+	Message                string    `gorm:"column:message"`
+	VerificationConfidence *int      `gorm:"column:verification_confidence"`
+	DetectedAt             time.Time `gorm:"column:detected_at;index"`
+}
+
+func (Rule) TableName() string {
+	return "compliance_rules"
+}
+
+func (Exception) TableName() string {
+	return "compliance_exceptions"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewComplianceRepository(db *gorm.DB, loggerInstance *logger.Logger) domainCompliance.IComplianceRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateRule(newRule *domainCompliance.Rule) (*domainCompliance.Rule, error) {
+	ruleModel := fromDomainMapper(newRule)
+	if err := r.DB.Create(ruleModel).Error; err != nil {
+		r.Logger.Error("Error creating compliance rule", zap.Error(err), zap.String("name", newRule.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Compliance rule created successfully", zap.String("ruleID", ruleModel.ID.String()))
+	return ruleModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRuleByID(id uuid.UUID) (*domainCompliance.Rule, error) {
+	var ruleModel Rule
+	err := r.DB.Where("id = ?", id).First(&ruleModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Compliance rule not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting compliance rule by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return ruleModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRules() (*[]domainCompliance.Rule, error) {
+	var rules []Rule
+	if err := r.DB.Order("created_at ASC").Find(&rules).Error; err != nil {
+		r.Logger.Error("Error getting compliance rules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&rules), nil
+}
+
+func (r *Repository) GetActiveRules() (*[]domainCompliance.Rule, error) {
+	var rules []Rule
+	if err := r.DB.Where("active = ?", true).Order("created_at ASC").Find(&rules).Error; err != nil {
+		r.Logger.Error("Error getting active compliance rules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&rules), nil
+}
+
+func (r *Repository) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainCompliance.Rule, error) {
+	var ruleModel Rule
+	ruleModel.ID = id
+	if err := r.DB.Model(&ruleModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating compliance rule", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetRuleByID(id)
+}
+
+func (r *Repository) DeleteRule(id uuid.UUID) error {
+	tx := r.DB.Delete(&Rule{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting compliance rule", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Compliance rule not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+// ReplaceExceptions swaps the exceptions recorded for a schedule at one stage,
+// so re-evaluating a visit never leaves stale entries behind.
+func (r *Repository) ReplaceExceptions(scheduleID uuid.UUID, stage string, exceptions []domainCompliance.Exception) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("schedule_id = ? AND stage = ?", scheduleID, stage).Delete(&Exception{}).Error; err != nil {
+			r.Logger.Error("Error clearing compliance exceptions", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		for _, exception := range exceptions {
+			model := exceptionFromDomainMapper(&exception)
+			model.ID = uuid.New()
+			if err := tx.Create(model).Error; err != nil {
+				r.Logger.Error("Error recording compliance exception", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+				return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) DeleteExceptionsBySchedule(scheduleID uuid.UUID) error {
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Delete(&Exception{}).Error; err != nil {
+		r.Logger.Error("Error deleting compliance exceptions", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) GetExceptions(from, to time.Time, ruleID *uuid.UUID) (*[]domainCompliance.Exception, error) {
+	var exceptions []Exception
+	query := r.DB.Where("detected_at >= ? AND detected_at < ?", from, to)
+	if ruleID != nil {
+		query = query.Where("rule_id = ?", *ruleID)
+	}
+	if err := query.Order("detected_at ASC").Find(&exceptions).Error; err != nil {
+		r.Logger.Error("Error getting compliance exceptions", zap.Error(err), zap.Time("from", from), zap.Time("to", to))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainCompliance.Exception, len(exceptions))
+	for i, e := range exceptions {
+		res[i] = *e.toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Rule) toDomainMapper() *domainCompliance.Rule {
+	return &domainCompliance.Rule{
+		ID:          r.ID,
+		Name:        r.Name,
+		Type:        r.Type,
+		ServiceName: r.ServiceName,
+		Threshold:   r.Threshold,
+		Active:      r.Active,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(r *domainCompliance.Rule) *Rule {
+	return &Rule{
+		ID:          r.ID,
+		Name:        r.Name,
+		Type:        r.Type,
+		ServiceName: r.ServiceName,
+		Threshold:   r.Threshold,
+		Active:      r.Active,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(rules *[]Rule) *[]domainCompliance.Rule {
+	rulesDomain := make([]domainCompliance.Rule, len(*rules))
+	for i, r := range *rules {
+		rulesDomain[i] = *r.toDomainMapper()
+	}
+	return &rulesDomain
+}
+
+func (e *Exception) toDomainMapper() *domainCompliance.Exception {
+	return &domainCompliance.Exception{
+		ID:                     e.ID,
+		RuleID:                 e.RuleID,
+		RuleName:               e.RuleName,
+		ScheduleID:             e.ScheduleID,
+		ClientUserID:           e.ClientUserID,
+		AssignedUserID:         e.AssignedUserID,
+		Stage:                  e.Stage,
+		Message:                e.Message,
+		VerificationConfidence: e.VerificationConfidence,
+		DetectedAt:             e.DetectedAt,
+	}
+}
+
+func exceptionFromDomainMapper(e *domainCompliance.Exception) *Exception {
+	return &Exception{
+		ID:                     e.ID,
+		RuleID:                 e.RuleID,
+		RuleName:               e.RuleName,
+		ScheduleID:             e.ScheduleID,
+		ClientUserID:           e.ClientUserID,
+		AssignedUserID:         e.AssignedUserID,
+		Stage:                  e.Stage,
+		Message:                e.Message,
+		VerificationConfidence: e.VerificationConfidence,
+		DetectedAt:             e.DetectedAt,
+	}
+}
@@ -0,0 +1,223 @@
+package confirmation
+
+import (
+	"time"
+
+	domainConfirmation "caregiver/src/domain/confirmation"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Confirmation struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID   uuid.UUID  `gorm:"column:schedule_id;type:uuid;index"`
+	ClientUserID uuid.UUID  `gorm:"column:client_user_id;type:uuid"`
+	ConsentID    *uuid.UUID `gorm:"column:consent_id;type:uuid"`
+	TokenHash    string     `gorm:"column:token_hash;uniqueIndex"`
+	Status       string     `gorm:"column:status"`
+	RespondedBy  string     `gorm:"column:responded_by"`
+	RespondedAt  *time.Time `gorm:"column:responded_at"`
+	ExpiresAt    time.Time  `gorm:"column:expires_at"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+type ChangeRequest struct {
+	ID               uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID       uuid.UUID  `gorm:"column:schedule_id;type:uuid;index"`
+	ClientUserID     uuid.UUID  `gorm:"column:client_user_id;type:uuid"`
+	ConfirmationID   uuid.UUID  `gorm:"column:confirmation_id;type:uuid"`
+	RequestedBy      string     `gorm:"column:requested_by"`
+	Reason           string     `gorm:"column:reason"`
+	PreferredFrom    *time.Time `gorm:"column:preferred_from"`
+	PreferredTo      *time.Time `gorm:"column:preferred_to"`
+	SlotReleased     bool       `gorm:"column:slot_released"`
+	Status           string     `gorm:"column:status;index"`
+	Resolution       string     `gorm:"column:resolution"`
+	ResolvedByUserID *uuid.UUID `gorm:"column:resolved_by_user_id;type:uuid"`
+	ResolvedAt       *time.Time `gorm:"column:resolved_at"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Confirmation) TableName() string {
+	return "visit_confirmations"
+}
+
+func (ChangeRequest) TableName() string {
+	return "visit_change_requests"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewConfirmationRepository(db *gorm.DB, loggerInstance *logger.Logger) domainConfirmation.IConfirmationRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newConfirmation *domainConfirmation.Confirmation) (*domainConfirmation.Confirmation, error) {
+	model := fromDomainMapper(newConfirmation)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating visit confirmation", zap.Error(err), zap.String("scheduleID", newConfirmation.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Visit confirmation created successfully", zap.String("confirmationID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByTokenHash(tokenHash string) (*domainConfirmation.Confirmation, error) {
+	var model Confirmation
+	err := r.DB.Where("token_hash = ?", tokenHash).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Visit confirmation not found for token")
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting visit confirmation by token", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainConfirmation.Confirmation, error) {
+	var model Confirmation
+	model.ID = id
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating visit confirmation", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if err := r.DB.Where("id = ?", id).First(&model).Error; err != nil {
+		r.Logger.Error("Error reloading visit confirmation", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) CreateChangeRequest(newRequest *domainConfirmation.ChangeRequest) (*domainConfirmation.ChangeRequest, error) {
+	model := changeRequestFromDomainMapper(newRequest)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating change request", zap.Error(err), zap.String("scheduleID", newRequest.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Change request created successfully", zap.String("changeRequestID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetChangeRequestByID(id uuid.UUID) (*domainConfirmation.ChangeRequest, error) {
+	var model ChangeRequest
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Change request not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting change request by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetChangeRequests(status string) (*[]domainConfirmation.ChangeRequest, error) {
+	var models []ChangeRequest
+	query := r.DB.Order("created_at ASC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting change requests", zap.Error(err), zap.String("status", status))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainConfirmation.ChangeRequest, len(models))
+	for i, m := range models {
+		res[i] = *m.toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateChangeRequest(id uuid.UUID, updates map[string]interface{}) (*domainConfirmation.ChangeRequest, error) {
+	var model ChangeRequest
+	model.ID = id
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating change request", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetChangeRequestByID(id)
+}
+
+func (c *Confirmation) toDomainMapper() *domainConfirmation.Confirmation {
+	return &domainConfirmation.Confirmation{
+		ID:           c.ID,
+		ScheduleID:   c.ScheduleID,
+		ClientUserID: c.ClientUserID,
+		ConsentID:    c.ConsentID,
+		TokenHash:    c.TokenHash,
+		Status:       c.Status,
+		RespondedBy:  c.RespondedBy,
+		RespondedAt:  c.RespondedAt,
+		ExpiresAt:    c.ExpiresAt,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(c *domainConfirmation.Confirmation) *Confirmation {
+	return &Confirmation{
+		ID:           c.ID,
+		ScheduleID:   c.ScheduleID,
+		ClientUserID: c.ClientUserID,
+		ConsentID:    c.ConsentID,
+		TokenHash:    c.TokenHash,
+		Status:       c.Status,
+		RespondedBy:  c.RespondedBy,
+		RespondedAt:  c.RespondedAt,
+		ExpiresAt:    c.ExpiresAt,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}
+}
+
+func (c *ChangeRequest) toDomainMapper() *domainConfirmation.ChangeRequest {
+	return &domainConfirmation.ChangeRequest{
+		ID:               c.ID,
+		ScheduleID:       c.ScheduleID,
+		ClientUserID:     c.ClientUserID,
+		ConfirmationID:   c.ConfirmationID,
+		RequestedBy:      c.RequestedBy,
+		Reason:           c.Reason,
+		PreferredFrom:    c.PreferredFrom,
+		PreferredTo:      c.PreferredTo,
+		SlotReleased:     c.SlotReleased,
+		Status:           c.Status,
+		Resolution:       c.Resolution,
+		ResolvedByUserID: c.ResolvedByUserID,
+		ResolvedAt:       c.ResolvedAt,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
+
+func changeRequestFromDomainMapper(c *domainConfirmation.ChangeRequest) *ChangeRequest {
+	return &ChangeRequest{
+		ID:               c.ID,
+		ScheduleID:       c.ScheduleID,
+		ClientUserID:     c.ClientUserID,
+		ConfirmationID:   c.ConfirmationID,
+		RequestedBy:      c.RequestedBy,
+		Reason:           c.Reason,
+		PreferredFrom:    c.PreferredFrom,
+		PreferredTo:      c.PreferredTo,
+		SlotReleased:     c.SlotReleased,
+		Status:           c.Status,
+		Resolution:       c.Resolution,
+		ResolvedByUserID: c.ResolvedByUserID,
+		ResolvedAt:       c.ResolvedAt,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
@@ -0,0 +1,171 @@
+package consent
+
+import (
+	"time"
+
+	domainConsent "caregiver/src/domain/consent"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Consent struct {
+	ID               uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	SeriesID         uuid.UUID  `gorm:"column:series_id;type:uuid;index"`
+	Version          int        `gorm:"column:version"`
+	ClientUserID     uuid.UUID  `gorm:"column:client_user_id;type:uuid;index"`
+	RecipientType    string     `gorm:"column:recipient_type"`
+	RecipientName    string     `gorm:"column:recipient_name"`
+	RecipientContact string     `gorm:"column:recipient_contact"`
+	PayerID          *uuid.UUID `gorm:"column:payer_id;type:uuid;index"`
+	Scopes           []string   `gorm:"column:scopes;serializer:json"`
+	Status           string     `gorm:"column:status;index"`
+	GrantedBy        string     `gorm:"column:granted_by"`
+	GrantedAt        time.Time  `gorm:"column:granted_at"`
+	ExpiresAt        *time.Time `gorm:"column:expires_at"`
+	WithdrawnAt      *time.Time `gorm:"column:withdrawn_at"`
+	WithdrawnReason  string     `gorm:"column:withdrawn_reason"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Consent) TableName() string {
+	return "client_consents"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewConsentRepository(db *gorm.DB, loggerInstance *logger.Logger) domainConsent.IConsentRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newConsent *domainConsent.Consent) (*domainConsent.Consent, error) {
+	consentModel := fromDomainMapper(newConsent)
+	if err := r.DB.Create(consentModel).Error; err != nil {
+		r.Logger.Error("Error creating consent", zap.Error(err), zap.String("clientUserID", newConsent.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Consent created successfully", zap.String("consentID", consentModel.ID.String()))
+	return consentModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainConsent.Consent, error) {
+	var consentModel Consent
+	err := r.DB.Where("id = ?", id).First(&consentModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Consent not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting consent by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return consentModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll(filter domainConsent.ConsentFilter) (*[]domainConsent.Consent, error) {
+	query := r.DB.Model(&Consent{})
+	if filter.ClientUserID != nil {
+		query = query.Where("client_user_id = ?", *filter.ClientUserID)
+	}
+	if filter.PayerID != nil {
+		query = query.Where("payer_id = ?", *filter.PayerID)
+	}
+	if filter.SeriesID != nil {
+		query = query.Where("series_id = ?", *filter.SeriesID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	var consents []Consent
+	if err := query.Order("version DESC").Order("created_at DESC").Find(&consents).Error; err != nil {
+		r.Logger.Error("Error getting consents", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainConsent.Consent, len(consents))
+	for i := range consents {
+		res[i] = *consents[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainConsent.Consent, error) {
+	consentModel := Consent{ID: id}
+	if err := r.DB.Model(&consentModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating consent", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (r *Repository) Supersede(previousID uuid.UUID, next *domainConsent.Consent) (*domainConsent.Consent, error) {
+	consentModel := fromDomainMapper(next)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Consent{}).Where("id = ? AND status = ?", previousID, domainConsent.StatusActive).Update("status", domainConsent.StatusSuperseded)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(consentModel).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Active consent not found to supersede", zap.String("id", previousID.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error superseding consent", zap.Error(err), zap.String("id", previousID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Consent superseded successfully", zap.String("previousID", previousID.String()), zap.String("consentID", consentModel.ID.String()))
+	return consentModel.toDomainMapper(), nil
+}
+
+func fromDomainMapper(c *domainConsent.Consent) *Consent {
+	return &Consent{
+		ID:               c.ID,
+		SeriesID:         c.SeriesID,
+		Version:          c.Version,
+		ClientUserID:     c.ClientUserID,
+		RecipientType:    c.RecipientType,
+		RecipientName:    c.RecipientName,
+		RecipientContact: c.RecipientContact,
+		PayerID:          c.PayerID,
+		Scopes:           c.Scopes,
+		Status:           c.Status,
+		GrantedBy:        c.GrantedBy,
+		GrantedAt:        c.GrantedAt,
+		ExpiresAt:        c.ExpiresAt,
+		WithdrawnAt:      c.WithdrawnAt,
+		WithdrawnReason:  c.WithdrawnReason,
+	}
+}
+
+func (c *Consent) toDomainMapper() *domainConsent.Consent {
+	return &domainConsent.Consent{
+		ID:               c.ID,
+		SeriesID:         c.SeriesID,
+		Version:          c.Version,
+		ClientUserID:     c.ClientUserID,
+		RecipientType:    c.RecipientType,
+		RecipientName:    c.RecipientName,
+		RecipientContact: c.RecipientContact,
+		PayerID:          c.PayerID,
+		Scopes:           c.Scopes,
+		Status:           c.Status,
+		GrantedBy:        c.GrantedBy,
+		GrantedAt:        c.GrantedAt,
+		ExpiresAt:        c.ExpiresAt,
+		WithdrawnAt:      c.WithdrawnAt,
+		WithdrawnReason:  c.WithdrawnReason,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
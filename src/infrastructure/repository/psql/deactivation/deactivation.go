@@ -0,0 +1,77 @@
+package deactivation
+
+import (
+	"time"
+
+	domainDeactivation "caregiver/src/domain/deactivation"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Change struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid;index"`
+	Role      string    `gorm:"column:role;index"`
+	Action    string    `gorm:"column:action"`
+	Reason    string    `gorm:"column:reason"`
+	Note      string    `gorm:"column:note"`
+	ChangedAt time.Time `gorm:"column:changed_at;index"`
+}
+
+func (Change) TableName() string {
+	return "user_status_changes"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewDeactivationRepository(db *gorm.DB, loggerInstance *logger.Logger) domainDeactivation.IDeactivationRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) RecordChange(change *domainDeactivation.Change) (*domainDeactivation.Change, error) {
+	model := &Change{
+		UserID:    change.UserID,
+		Role:      change.Role,
+		Action:    change.Action,
+		Reason:    change.Reason,
+		Note:      change.Note,
+		ChangedAt: change.ChangedAt,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error recording user status change", zap.Error(err), zap.String("userID", change.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetChanges(role string, to time.Time) (*[]domainDeactivation.Change, error) {
+	var models []Change
+	if err := r.DB.Where("role = ? AND changed_at < ?", role, to).Order("changed_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting user status changes", zap.Error(err), zap.String("role", role))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainDeactivation.Change, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (m *Change) toDomainMapper() *domainDeactivation.Change {
+	return &domainDeactivation.Change{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Role:      m.Role,
+		Action:    m.Action,
+		Reason:    m.Reason,
+		Note:      m.Note,
+		ChangedAt: m.ChangedAt,
+	}
+}
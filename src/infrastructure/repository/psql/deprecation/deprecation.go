@@ -0,0 +1,100 @@
+package deprecation
+
+import (
+	"time"
+
+	domainDeprecation "caregiver/src/domain/deprecation"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Usage struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Feature     string    `gorm:"column:feature;uniqueIndex:idx_deprecation_usage_client"`
+	Method      string    `gorm:"column:method"`
+	Route       string    `gorm:"column:route"`
+	Client      string    `gorm:"column:client;uniqueIndex:idx_deprecation_usage_client"`
+	UserAgent   string    `gorm:"column:user_agent;uniqueIndex:idx_deprecation_usage_client"`
+	Calls       int64     `gorm:"column:calls"`
+	FirstSeenAt time.Time `gorm:"column:first_seen_at"`
+	LastSeenAt  time.Time `gorm:"column:last_seen_at;index"`
+}
+
+func (Usage) TableName() string {
+	return "deprecation_usage"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewDeprecationRepository(db *gorm.DB, loggerInstance *logger.Logger) domainDeprecation.IDeprecationRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) RecordUsage(usage *domainDeprecation.Usage) error {
+	usageModel := &Usage{
+		Feature:     usage.Feature,
+		Method:      usage.Method,
+		Route:       usage.Route,
+		Client:      usage.Client,
+		UserAgent:   usage.UserAgent,
+		Calls:       usage.Calls,
+		FirstSeenAt: usage.FirstSeenAt,
+		LastSeenAt:  usage.LastSeenAt,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "feature"}, {Name: "client"}, {Name: "user_agent"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"calls":        gorm.Expr("deprecation_usage.calls + ?", usage.Calls),
+			"method":       usage.Method,
+			"route":        usage.Route,
+			"last_seen_at": usage.LastSeenAt,
+		}),
+	}).Create(usageModel).Error
+	if err != nil {
+		r.Logger.Error("Error recording deprecated feature usage", zap.Error(err), zap.String("feature", usage.Feature))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) GetUsage(filter domainDeprecation.UsageFilter) (*[]domainDeprecation.Usage, error) {
+	query := r.DB.Model(&Usage{})
+	if filter.Feature != "" {
+		query = query.Where("feature = ?", filter.Feature)
+	}
+	if filter.Since != nil {
+		query = query.Where("last_seen_at >= ?", *filter.Since)
+	}
+	var usage []Usage
+	if err := query.Order("last_seen_at DESC").Find(&usage).Error; err != nil {
+		r.Logger.Error("Error getting deprecated feature usage", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainDeprecation.Usage, len(usage))
+	for i := range usage {
+		res[i] = *usage[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (u *Usage) toDomainMapper() *domainDeprecation.Usage {
+	return &domainDeprecation.Usage{
+		ID:          u.ID,
+		Feature:     u.Feature,
+		Method:      u.Method,
+		Route:       u.Route,
+		Client:      u.Client,
+		UserAgent:   u.UserAgent,
+		Calls:       u.Calls,
+		FirstSeenAt: u.FirstSeenAt,
+		LastSeenAt:  u.LastSeenAt,
+	}
+}
@@ -0,0 +1,156 @@
+package differential
+
+import (
+	"encoding/json"
+	"time"
+
+	domainDifferential "caregiver/src/domain/differential"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Rule struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name        string    `gorm:"column:name"`
+	AppliesTo   string    `gorm:"column:applies_to"`
+	Weekdays    []int     `gorm:"column:weekdays;serializer:json"`
+	StartMinute int       `gorm:"column:start_minute"`
+	EndMinute   int       `gorm:"column:end_minute"`
+	Timezone    string    `gorm:"column:timezone"`
+	Multiplier  float64   `gorm:"column:multiplier"`
+	Active      bool      `gorm:"column:active"`
+	CreatedAt   time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Rule) TableName() string {
+	return "shift_differential_rules"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewDifferentialRepository(db *gorm.DB, loggerInstance *logger.Logger) domainDifferential.IDifferentialRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateRule(newRule *domainDifferential.Rule) (*domainDifferential.Rule, error) {
+	ruleModel := fromDomainMapper(newRule)
+	if err := r.DB.Create(ruleModel).Error; err != nil {
+		r.Logger.Error("Error creating shift differential rule", zap.Error(err), zap.String("name", newRule.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Shift differential rule created successfully", zap.String("ruleID", ruleModel.ID.String()))
+	return ruleModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRuleByID(id uuid.UUID) (*domainDifferential.Rule, error) {
+	var ruleModel Rule
+	err := r.DB.Where("id = ?", id).First(&ruleModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Shift differential rule not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting shift differential rule by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return ruleModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRules() (*[]domainDifferential.Rule, error) {
+	var rules []Rule
+	if err := r.DB.Order("created_at ASC").Find(&rules).Error; err != nil {
+		r.Logger.Error("Error getting shift differential rules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&rules), nil
+}
+
+func (r *Repository) GetActiveRules(appliesTo string) (*[]domainDifferential.Rule, error) {
+	var rules []Rule
+	err := r.DB.Where("active = ? AND applies_to IN ?", true, []string{appliesTo, domainDifferential.AppliesToBoth}).
+		Order("created_at ASC").Find(&rules).Error
+	if err != nil {
+		r.Logger.Error("Error getting active shift differential rules", zap.Error(err), zap.String("appliesTo", appliesTo))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&rules), nil
+}
+
+func (r *Repository) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainDifferential.Rule, error) {
+	// Map updates bypass the field serializer, so encode weekdays here.
+	if weekdays, ok := updates["weekdays"]; ok {
+		encoded, err := json.Marshal(weekdays)
+		if err != nil {
+			r.Logger.Error("Error encoding shift differential weekdays", zap.Error(err), zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		updates["weekdays"] = string(encoded)
+	}
+	ruleModel := Rule{ID: id}
+	if err := r.DB.Model(&ruleModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating shift differential rule", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetRuleByID(id)
+}
+
+func (r *Repository) DeleteRule(id uuid.UUID) error {
+	tx := r.DB.Delete(&Rule{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting shift differential rule", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Shift differential rule not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Rule) toDomainMapper() *domainDifferential.Rule {
+	return &domainDifferential.Rule{
+		ID:          r.ID,
+		Name:        r.Name,
+		AppliesTo:   r.AppliesTo,
+		Weekdays:    r.Weekdays,
+		StartMinute: r.StartMinute,
+		EndMinute:   r.EndMinute,
+		Timezone:    r.Timezone,
+		Multiplier:  r.Multiplier,
+		Active:      r.Active,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(r *domainDifferential.Rule) *Rule {
+	return &Rule{
+		ID:          r.ID,
+		Name:        r.Name,
+		AppliesTo:   r.AppliesTo,
+		Weekdays:    r.Weekdays,
+		StartMinute: r.StartMinute,
+		EndMinute:   r.EndMinute,
+		Timezone:    r.Timezone,
+		Multiplier:  r.Multiplier,
+		Active:      r.Active,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(rules *[]Rule) *[]domainDifferential.Rule {
+	rulesDomain := make([]domainDifferential.Rule, len(*rules))
+	for i, r := range *rules {
+		rulesDomain[i] = *r.toDomainMapper()
+	}
+	return &rulesDomain
+}
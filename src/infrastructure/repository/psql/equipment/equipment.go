@@ -0,0 +1,320 @@
+package equipment
+
+import (
+	"time"
+
+	domainEquipment "caregiver/src/domain/equipment"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Equipment struct {
+	ID                      uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name                    string     `gorm:"column:name"`
+	Type                    string     `gorm:"column:type"`
+	Identifier              string     `gorm:"column:identifier;index"`
+	Status                  string     `gorm:"column:status;index"`
+	MaintenanceIntervalDays int        `gorm:"column:maintenance_interval_days"`
+	LastMaintainedAt        *time.Time `gorm:"column:last_maintained_at"`
+	NextMaintenanceDue      *time.Time `gorm:"column:next_maintenance_due;index"`
+	ReminderSentAt          *time.Time `gorm:"column:reminder_sent_at"`
+	CreatedAt               time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt               time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Equipment) TableName() string {
+	return "equipment"
+}
+
+type Checkout struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	EquipmentID     uuid.UUID  `gorm:"column:equipment_id;type:uuid;index"`
+	CaregiverUserID *uuid.UUID `gorm:"column:caregiver_user_id;type:uuid;index"`
+	ClientUserID    *uuid.UUID `gorm:"column:client_user_id;type:uuid;index"`
+	ScheduleID      *uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	CheckedOutAt    time.Time  `gorm:"column:checked_out_at"`
+	DueBackAt       *time.Time `gorm:"column:due_back_at"`
+	ReturnedAt      *time.Time `gorm:"column:returned_at"`
+	ConditionOut    string     `gorm:"column:condition_out"`
+	ConditionIn     string     `gorm:"column:condition_in"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Checkout) TableName() string {
+	return "equipment_checkouts"
+}
+
+type MaintenanceRecord struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	EquipmentID uuid.UUID `gorm:"column:equipment_id;type:uuid;index"`
+	PerformedAt time.Time `gorm:"column:performed_at"`
+	Description string    `gorm:"column:description"`
+	PerformedBy string    `gorm:"column:performed_by"`
+	Cost        float64   `gorm:"column:cost"`
+	CreatedAt   time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (MaintenanceRecord) TableName() string {
+	return "equipment_maintenance_records"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewEquipmentRepository(db *gorm.DB, loggerInstance *logger.Logger) domainEquipment.IEquipmentRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateEquipment(newEquipment *domainEquipment.Equipment) (*domainEquipment.Equipment, error) {
+	equipmentModel := equipmentFromDomainMapper(newEquipment)
+	if err := r.DB.Create(equipmentModel).Error; err != nil {
+		r.Logger.Error("Error creating equipment", zap.Error(err), zap.String("name", newEquipment.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Equipment created successfully", zap.String("equipmentID", equipmentModel.ID.String()))
+	return equipmentModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetEquipmentByID(id uuid.UUID) (*domainEquipment.Equipment, error) {
+	var equipmentModel Equipment
+	err := r.DB.Where("id = ?", id).First(&equipmentModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Equipment not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting equipment by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return equipmentModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetEquipment(status string) (*[]domainEquipment.Equipment, error) {
+	query := r.DB.Model(&Equipment{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var equipment []Equipment
+	if err := query.Order("name ASC").Find(&equipment).Error; err != nil {
+		r.Logger.Error("Error getting equipment", zap.Error(err), zap.String("status", status))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&equipment), nil
+}
+
+func (r *Repository) UpdateEquipment(id uuid.UUID, updates map[string]interface{}) (*domainEquipment.Equipment, error) {
+	equipmentModel := Equipment{ID: id}
+	if err := r.DB.Model(&equipmentModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating equipment", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetEquipmentByID(id)
+}
+
+func (r *Repository) DeleteEquipment(id uuid.UUID) error {
+	tx := r.DB.Delete(&Equipment{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting equipment", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Equipment not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) GetMaintenanceDue(before time.Time) (*[]domainEquipment.Equipment, error) {
+	var equipment []Equipment
+	err := r.DB.Where("status <> ? AND next_maintenance_due <= ? AND reminder_sent_at IS NULL", domainEquipment.StatusRetired, before).
+		Order("next_maintenance_due ASC").Find(&equipment).Error
+	if err != nil {
+		r.Logger.Error("Error getting equipment due for maintenance", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&equipment), nil
+}
+
+func (r *Repository) CreateCheckout(newCheckout *domainEquipment.Checkout) (*domainEquipment.Checkout, error) {
+	checkoutModel := checkoutFromDomainMapper(newCheckout)
+	if err := r.DB.Create(checkoutModel).Error; err != nil {
+		r.Logger.Error("Error creating equipment checkout", zap.Error(err), zap.String("equipmentID", newCheckout.EquipmentID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Equipment checkout created successfully", zap.String("checkoutID", checkoutModel.ID.String()))
+	return checkoutModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetCheckoutByID(id uuid.UUID) (*domainEquipment.Checkout, error) {
+	var checkoutModel Checkout
+	err := r.DB.Where("id = ?", id).First(&checkoutModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Equipment checkout not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting equipment checkout by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return checkoutModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetCheckouts(filter domainEquipment.CheckoutFilter) (*[]domainEquipment.Checkout, error) {
+	query := r.DB.Model(&Checkout{})
+	if filter.EquipmentID != nil {
+		query = query.Where("equipment_id = ?", *filter.EquipmentID)
+	}
+	if filter.CaregiverUserID != nil {
+		query = query.Where("caregiver_user_id = ?", *filter.CaregiverUserID)
+	}
+	if filter.ClientUserID != nil {
+		query = query.Where("client_user_id = ?", *filter.ClientUserID)
+	}
+	if filter.ScheduleID != nil {
+		query = query.Where("schedule_id = ?", *filter.ScheduleID)
+	}
+	if filter.OpenOnly {
+		query = query.Where("returned_at IS NULL")
+	}
+	var checkouts []Checkout
+	if err := query.Order("checked_out_at DESC").Find(&checkouts).Error; err != nil {
+		r.Logger.Error("Error getting equipment checkouts", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainEquipment.Checkout, len(checkouts))
+	for i := range checkouts {
+		res[i] = *checkouts[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateCheckout(id uuid.UUID, updates map[string]interface{}) (*domainEquipment.Checkout, error) {
+	checkoutModel := Checkout{ID: id}
+	if err := r.DB.Model(&checkoutModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating equipment checkout", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetCheckoutByID(id)
+}
+
+func (r *Repository) CreateMaintenanceRecord(record *domainEquipment.MaintenanceRecord) (*domainEquipment.MaintenanceRecord, error) {
+	recordModel := &MaintenanceRecord{
+		ID:          record.ID,
+		EquipmentID: record.EquipmentID,
+		PerformedAt: record.PerformedAt,
+		Description: record.Description,
+		PerformedBy: record.PerformedBy,
+		Cost:        record.Cost,
+	}
+	if err := r.DB.Create(recordModel).Error; err != nil {
+		r.Logger.Error("Error creating equipment maintenance record", zap.Error(err), zap.String("equipmentID", record.EquipmentID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return recordModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetMaintenanceRecords(equipmentID uuid.UUID) (*[]domainEquipment.MaintenanceRecord, error) {
+	var records []MaintenanceRecord
+	if err := r.DB.Where("equipment_id = ?", equipmentID).Order("performed_at DESC").Find(&records).Error; err != nil {
+		r.Logger.Error("Error getting equipment maintenance records", zap.Error(err), zap.String("equipmentID", equipmentID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainEquipment.MaintenanceRecord, len(records))
+	for i := range records {
+		res[i] = *records[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (e *Equipment) toDomainMapper() *domainEquipment.Equipment {
+	return &domainEquipment.Equipment{
+		ID:                      e.ID,
+		Name:                    e.Name,
+		Type:                    e.Type,
+		Identifier:              e.Identifier,
+		Status:                  e.Status,
+		MaintenanceIntervalDays: e.MaintenanceIntervalDays,
+		LastMaintainedAt:        e.LastMaintainedAt,
+		NextMaintenanceDue:      e.NextMaintenanceDue,
+		ReminderSentAt:          e.ReminderSentAt,
+		CreatedAt:               e.CreatedAt,
+		UpdatedAt:               e.UpdatedAt,
+	}
+}
+
+func equipmentFromDomainMapper(e *domainEquipment.Equipment) *Equipment {
+	return &Equipment{
+		ID:                      e.ID,
+		Name:                    e.Name,
+		Type:                    e.Type,
+		Identifier:              e.Identifier,
+		Status:                  e.Status,
+		MaintenanceIntervalDays: e.MaintenanceIntervalDays,
+		LastMaintainedAt:        e.LastMaintainedAt,
+		NextMaintenanceDue:      e.NextMaintenanceDue,
+		ReminderSentAt:          e.ReminderSentAt,
+		CreatedAt:               e.CreatedAt,
+		UpdatedAt:               e.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(equipment *[]Equipment) *[]domainEquipment.Equipment {
+	equipmentDomain := make([]domainEquipment.Equipment, len(*equipment))
+	for i, e := range *equipment {
+		equipmentDomain[i] = *e.toDomainMapper()
+	}
+	return &equipmentDomain
+}
+
+func (c *Checkout) toDomainMapper() *domainEquipment.Checkout {
+	return &domainEquipment.Checkout{
+		ID:              c.ID,
+		EquipmentID:     c.EquipmentID,
+		CaregiverUserID: c.CaregiverUserID,
+		ClientUserID:    c.ClientUserID,
+		ScheduleID:      c.ScheduleID,
+		CheckedOutAt:    c.CheckedOutAt,
+		DueBackAt:       c.DueBackAt,
+		ReturnedAt:      c.ReturnedAt,
+		ConditionOut:    c.ConditionOut,
+		ConditionIn:     c.ConditionIn,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func checkoutFromDomainMapper(c *domainEquipment.Checkout) *Checkout {
+	return &Checkout{
+		ID:              c.ID,
+		EquipmentID:     c.EquipmentID,
+		CaregiverUserID: c.CaregiverUserID,
+		ClientUserID:    c.ClientUserID,
+		ScheduleID:      c.ScheduleID,
+		CheckedOutAt:    c.CheckedOutAt,
+		DueBackAt:       c.DueBackAt,
+		ReturnedAt:      c.ReturnedAt,
+		ConditionOut:    c.ConditionOut,
+		ConditionIn:     c.ConditionIn,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func (m *MaintenanceRecord) toDomainMapper() *domainEquipment.MaintenanceRecord {
+	return &domainEquipment.MaintenanceRecord{
+		ID:          m.ID,
+		EquipmentID: m.EquipmentID,
+		PerformedAt: m.PerformedAt,
+		Description: m.Description,
+		PerformedBy: m.PerformedBy,
+		Cost:        m.Cost,
+		CreatedAt:   m.CreatedAt,
+	}
+}
@@ -0,0 +1,330 @@
+package evv
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainEVV "caregiver/src/domain/evv"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Aggregator struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name      string    `gorm:"column:name"`
+	State     string    `gorm:"column:state;index"`
+	Payer     string    `gorm:"column:payer"`
+	Adapter   string    `gorm:"column:adapter"`
+	Endpoint  string    `gorm:"column:endpoint"`
+	APIKeyEnv string    `gorm:"column:api_key_env"`
+	Active    bool      `gorm:"column:active"`
+	CreatedAt time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Aggregator) TableName() string {
+	return "evv_aggregators"
+}
+
+type Submission struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID      uuid.UUID  `gorm:"column:schedule_id;type:uuid;uniqueIndex"`
+	AggregatorID    *uuid.UUID `gorm:"column:aggregator_id;type:uuid"`
+	Status          string     `gorm:"column:status;index"`
+	Attempts        int        `gorm:"column:attempts"`
+	ExternalID      string     `gorm:"column:external_id"`
+	RejectionCode   string     `gorm:"column:rejection_code"`
+	RejectionReason string     `gorm:"column:rejection_reason"`
+	NextAttemptAt   *time.Time `gorm:"column:next_attempt_at;index"`
+	LastAttemptAt   *time.Time `gorm:"column:last_attempt_at"`
+	AcceptedAt      *time.Time `gorm:"column:accepted_at"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Submission) TableName() string {
+	return "evv_submissions"
+}
+
+type Attempt struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	SubmissionID uuid.UUID  `gorm:"column:submission_id;type:uuid;index"`
+	AggregatorID *uuid.UUID `gorm:"column:aggregator_id;type:uuid"`
+	AttemptedAt  time.Time  `gorm:"column:attempted_at"`
+	Outcome      string     `gorm:"column:outcome"`
+	Code         string     `gorm:"column:code"`
+	Message      string     `gorm:"column:message"`
+}
+
+func (Attempt) TableName() string {
+	return "evv_submission_attempts"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewEVVRepository(db *gorm.DB, loggerInstance *logger.Logger) domainEVV.IEVVRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateAggregator(newAggregator *domainEVV.Aggregator) (*domainEVV.Aggregator, error) {
+	aggregatorModel := aggregatorFromDomainMapper(newAggregator)
+	if err := r.DB.Create(aggregatorModel).Error; err != nil {
+		r.Logger.Error("Error creating EVV aggregator", zap.Error(err), zap.String("name", newAggregator.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("EVV aggregator created successfully", zap.String("aggregatorID", aggregatorModel.ID.String()))
+	return aggregatorModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAggregatorByID(id uuid.UUID) (*domainEVV.Aggregator, error) {
+	var aggregatorModel Aggregator
+	err := r.DB.Where("id = ?", id).First(&aggregatorModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("EVV aggregator not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting EVV aggregator by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return aggregatorModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAggregators() (*[]domainEVV.Aggregator, error) {
+	var aggregators []Aggregator
+	if err := r.DB.Order("state ASC, name ASC").Find(&aggregators).Error; err != nil {
+		r.Logger.Error("Error getting EVV aggregators", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainEVV.Aggregator, len(aggregators))
+	for i := range aggregators {
+		res[i] = *aggregators[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetActiveAggregatorByState(state string) (*domainEVV.Aggregator, error) {
+	var aggregatorModel Aggregator
+	err := r.DB.Where("UPPER(state) = UPPER(?) AND active = ?", state, true).
+		Order("created_at ASC").First(&aggregatorModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting EVV aggregator by state", zap.Error(err), zap.String("state", state))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return aggregatorModel.toDomainMapper(), nil
+}
+
+func (r *Repository) UpdateAggregator(id uuid.UUID, updates map[string]interface{}) (*domainEVV.Aggregator, error) {
+	var aggregatorModel Aggregator
+	aggregatorModel.ID = id
+	if err := r.DB.Model(&aggregatorModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating EVV aggregator", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetAggregatorByID(id)
+}
+
+func (r *Repository) DeleteAggregator(id uuid.UUID) error {
+	tx := r.DB.Delete(&Aggregator{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting EVV aggregator", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("EVV aggregator not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) CreateSubmission(newSubmission *domainEVV.Submission) (*domainEVV.Submission, error) {
+	submissionModel := submissionFromDomainMapper(newSubmission)
+	if err := r.DB.Create(submissionModel).Error; err != nil {
+		r.Logger.Error("Error creating EVV submission", zap.Error(err), zap.String("scheduleID", newSubmission.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return submissionModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetSubmissionByID(id uuid.UUID) (*domainEVV.Submission, error) {
+	return r.getSubmission("id = ?", id)
+}
+
+func (r *Repository) GetSubmissionBySchedule(scheduleID uuid.UUID) (*domainEVV.Submission, error) {
+	return r.getSubmission("schedule_id = ?", scheduleID)
+}
+
+func (r *Repository) getSubmission(query string, id uuid.UUID) (*domainEVV.Submission, error) {
+	var submissionModel Submission
+	err := r.DB.Where(query, id).First(&submissionModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting EVV submission", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return submissionModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetSubmissions(filter domainEVV.SubmissionFilter) (*[]domainEVV.Submission, error) {
+	query := r.DB.Model(&Submission{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ScheduleID != nil {
+		query = query.Where("schedule_id = ?", *filter.ScheduleID)
+	}
+	var submissions []Submission
+	if err := query.Order("updated_at DESC").Find(&submissions).Error; err != nil {
+		r.Logger.Error("Error getting EVV submissions", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return submissionsToDomain(submissions), nil
+}
+
+func (r *Repository) GetDueSubmissions(now time.Time, limit int) (*[]domainEVV.Submission, error) {
+	var submissions []Submission
+	err := r.DB.Where("status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", domainEVV.StatusQueued, now).
+		Order("created_at ASC").Limit(limit).Find(&submissions).Error
+	if err != nil {
+		r.Logger.Error("Error getting due EVV submissions", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return submissionsToDomain(submissions), nil
+}
+
+func (r *Repository) UpdateSubmission(id uuid.UUID, updates map[string]interface{}) (*domainEVV.Submission, error) {
+	var submissionModel Submission
+	submissionModel.ID = id
+	if err := r.DB.Model(&submissionModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating EVV submission", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetSubmissionByID(id)
+}
+
+func (r *Repository) CreateAttempt(attempt *domainEVV.Attempt) (*domainEVV.Attempt, error) {
+	attemptModel := &Attempt{
+		ID:           attempt.ID,
+		SubmissionID: attempt.SubmissionID,
+		AggregatorID: attempt.AggregatorID,
+		AttemptedAt:  attempt.AttemptedAt,
+		Outcome:      attempt.Outcome,
+		Code:         attempt.Code,
+		Message:      attempt.Message,
+	}
+	if err := r.DB.Create(attemptModel).Error; err != nil {
+		r.Logger.Error("Error creating EVV submission attempt", zap.Error(err), zap.String("submissionID", attempt.SubmissionID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return attemptModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAttempts(submissionID uuid.UUID) (*[]domainEVV.Attempt, error) {
+	var attempts []Attempt
+	if err := r.DB.Where("submission_id = ?", submissionID).Order("attempted_at ASC").Find(&attempts).Error; err != nil {
+		r.Logger.Error("Error getting EVV submission attempts", zap.Error(err), zap.String("submissionID", submissionID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainEVV.Attempt, len(attempts))
+	for i := range attempts {
+		res[i] = *attempts[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func submissionsToDomain(submissions []Submission) *[]domainEVV.Submission {
+	res := make([]domainEVV.Submission, len(submissions))
+	for i := range submissions {
+		res[i] = *submissions[i].toDomainMapper()
+	}
+	return &res
+}
+
+func (a *Aggregator) toDomainMapper() *domainEVV.Aggregator {
+	return &domainEVV.Aggregator{
+		ID:        a.ID,
+		Name:      a.Name,
+		State:     a.State,
+		Payer:     a.Payer,
+		Adapter:   a.Adapter,
+		Endpoint:  a.Endpoint,
+		APIKeyEnv: a.APIKeyEnv,
+		Active:    a.Active,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+func aggregatorFromDomainMapper(a *domainEVV.Aggregator) *Aggregator {
+	return &Aggregator{
+		ID:        a.ID,
+		Name:      a.Name,
+		State:     a.State,
+		Payer:     a.Payer,
+		Adapter:   a.Adapter,
+		Endpoint:  a.Endpoint,
+		APIKeyEnv: a.APIKeyEnv,
+		Active:    a.Active,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+func (s *Submission) toDomainMapper() *domainEVV.Submission {
+	return &domainEVV.Submission{
+		ID:              s.ID,
+		ScheduleID:      s.ScheduleID,
+		AggregatorID:    s.AggregatorID,
+		Status:          s.Status,
+		Attempts:        s.Attempts,
+		ExternalID:      s.ExternalID,
+		RejectionCode:   s.RejectionCode,
+		RejectionReason: s.RejectionReason,
+		NextAttemptAt:   s.NextAttemptAt,
+		LastAttemptAt:   s.LastAttemptAt,
+		AcceptedAt:      s.AcceptedAt,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+func submissionFromDomainMapper(s *domainEVV.Submission) *Submission {
+	return &Submission{
+		ID:              s.ID,
+		ScheduleID:      s.ScheduleID,
+		AggregatorID:    s.AggregatorID,
+		Status:          s.Status,
+		Attempts:        s.Attempts,
+		ExternalID:      s.ExternalID,
+		RejectionCode:   s.RejectionCode,
+		RejectionReason: s.RejectionReason,
+		NextAttemptAt:   s.NextAttemptAt,
+		LastAttemptAt:   s.LastAttemptAt,
+		AcceptedAt:      s.AcceptedAt,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+func (a *Attempt) toDomainMapper() *domainEVV.Attempt {
+	return &domainEVV.Attempt{
+		ID:           a.ID,
+		SubmissionID: a.SubmissionID,
+		AggregatorID: a.AggregatorID,
+		AttemptedAt:  a.AttemptedAt,
+		Outcome:      a.Outcome,
+		Code:         a.Code,
+		Message:      a.Message,
+	}
+}
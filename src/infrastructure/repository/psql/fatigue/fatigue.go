@@ -0,0 +1,136 @@
+package fatigue
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainFatigue "caregiver/src/domain/fatigue"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Rule struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name      string    `gorm:"column:name"`
+	Type      string    `gorm:"column:type"`
+	Threshold float64   `gorm:"column:threshold"`
+	Mode      string    `gorm:"column:mode"`
+	Active    bool      `gorm:"column:active"`
+	CreatedAt time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Rule) TableName() string {
+	return "fatigue_rules"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewFatigueRepository(db *gorm.DB, loggerInstance *logger.Logger) domainFatigue.IFatigueRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateRule(newRule *domainFatigue.Rule) (*domainFatigue.Rule, error) {
+	ruleModel := fromDomainMapper(newRule)
+	if err := r.DB.Create(ruleModel).Error; err != nil {
+		r.Logger.Error("Error creating fatigue rule", zap.Error(err), zap.String("name", newRule.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Fatigue rule created successfully", zap.String("ruleID", ruleModel.ID.String()))
+	return ruleModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRuleByID(id uuid.UUID) (*domainFatigue.Rule, error) {
+	var ruleModel Rule
+	err := r.DB.Where("id = ?", id).First(&ruleModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Fatigue rule not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting fatigue rule by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return ruleModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRules() (*[]domainFatigue.Rule, error) {
+	var rules []Rule
+	if err := r.DB.Order("created_at ASC").Find(&rules).Error; err != nil {
+		r.Logger.Error("Error getting fatigue rules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&rules), nil
+}
+
+func (r *Repository) GetActiveRules() (*[]domainFatigue.Rule, error) {
+	var rules []Rule
+	if err := r.DB.Where("active = ?", true).Order("created_at ASC").Find(&rules).Error; err != nil {
+		r.Logger.Error("Error getting active fatigue rules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&rules), nil
+}
+
+func (r *Repository) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainFatigue.Rule, error) {
+	var ruleModel Rule
+	ruleModel.ID = id
+	if err := r.DB.Model(&ruleModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating fatigue rule", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetRuleByID(id)
+}
+
+func (r *Repository) DeleteRule(id uuid.UUID) error {
+	tx := r.DB.Delete(&Rule{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting fatigue rule", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Fatigue rule not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Rule) toDomainMapper() *domainFatigue.Rule {
+	return &domainFatigue.Rule{
+		ID:        r.ID,
+		Name:      r.Name,
+		Type:      r.Type,
+		Threshold: r.Threshold,
+		Mode:      r.Mode,
+		Active:    r.Active,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(r *domainFatigue.Rule) *Rule {
+	return &Rule{
+		ID:        r.ID,
+		Name:      r.Name,
+		Type:      r.Type,
+		Threshold: r.Threshold,
+		Mode:      r.Mode,
+		Active:    r.Active,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(rules *[]Rule) *[]domainFatigue.Rule {
+	rulesDomain := make([]domainFatigue.Rule, len(*rules))
+	for i, r := range *rules {
+		rulesDomain[i] = *r.toDomainMapper()
+	}
+	return &rulesDomain
+}
@@ -0,0 +1,236 @@
+package form
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainForm "caregiver/src/domain/form"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Definition struct {
+	ID                       uuid.UUID          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Key                      string             `gorm:"column:form_key;uniqueIndex:idx_form_key_version"`
+	Version                  int                `gorm:"column:version;uniqueIndex:idx_form_key_version"`
+	Name                     string             `gorm:"column:name"`
+	Purpose                  string             `gorm:"column:purpose"`
+	ReassessmentIntervalDays int                `gorm:"column:reassessment_interval_days"`
+	Fields                   []domainForm.Field `gorm:"column:fields;serializer:json"`
+	Active                   bool               `gorm:"column:active"`
+	CreatedByUserID          uuid.UUID          `gorm:"column:created_by_user_id;type:uuid"`
+	CreatedAt                time.Time          `gorm:"autoCreateTime:milli"`
+}
+
+type Submission struct {
+	ID                uuid.UUID              `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	DefinitionID      uuid.UUID              `gorm:"column:definition_id;type:uuid"`
+	FormKey           string                 `gorm:"column:form_key;index:idx_form_submissions_key_client"`
+	FormVersion       int                    `gorm:"column:form_version"`
+	ClientUserID      uuid.UUID              `gorm:"column:client_user_id;type:uuid;index:idx_form_submissions_key_client"`
+	SubmittedByUserID uuid.UUID              `gorm:"column:submitted_by_user_id;type:uuid"`
+	Answers           map[string]interface{} `gorm:"column:answers;serializer:json"`
+	FieldScores       map[string]float64     `gorm:"column:field_scores;serializer:json"`
+	Score             float64                `gorm:"column:score"`
+	SubmittedAt       time.Time              `gorm:"column:submitted_at"`
+}
+
+func (Definition) TableName() string {
+	return "form_definitions"
+}
+
+func (Submission) TableName() string {
+	return "form_submissions"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewFormRepository(db *gorm.DB, loggerInstance *logger.Logger) domainForm.IFormRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateDefinition(definition *domainForm.Definition) (*domainForm.Definition, error) {
+	model := definitionFromDomain(definition)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating form definition", zap.Error(err), zap.String("key", definition.Key), zap.Int("version", definition.Version))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Form definition created successfully", zap.String("key", model.Key), zap.Int("version", model.Version))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetLatest(key string) (*domainForm.Definition, error) {
+	var model Definition
+	err := r.DB.Where("form_key = ?", key).Order("version DESC").First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Form not found", zap.String("key", key))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting latest form definition", zap.Error(err), zap.String("key", key))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetVersion(key string, version int) (*domainForm.Definition, error) {
+	var model Definition
+	err := r.DB.Where("form_key = ? AND version = ?", key, version).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Form version not found", zap.String("key", key), zap.Int("version", version))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting form definition version", zap.Error(err), zap.String("key", key), zap.Int("version", version))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetVersions(key string) (*[]domainForm.Definition, error) {
+	var models []Definition
+	if err := r.DB.Where("form_key = ?", key).Order("version ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting form definition versions", zap.Error(err), zap.String("key", key))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return definitionsToDomain(models), nil
+}
+
+func (r *Repository) GetAllLatest() (*[]domainForm.Definition, error) {
+	var models []Definition
+	err := r.DB.
+		Where("version = (SELECT MAX(d.version) FROM form_definitions d WHERE d.form_key = form_definitions.form_key)").
+		Order("name ASC").
+		Find(&models).Error
+	if err != nil {
+		r.Logger.Error("Error getting forms", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return definitionsToDomain(models), nil
+}
+
+func (r *Repository) SetActive(key string, active bool) error {
+	tx := r.DB.Model(&Definition{}).Where("form_key = ?", key).Update("active", active)
+	if tx.Error != nil {
+		r.Logger.Error("Error updating form status", zap.Error(tx.Error), zap.String("key", key))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) CreateSubmission(submission *domainForm.Submission) (*domainForm.Submission, error) {
+	model := submissionFromDomain(submission)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating form submission", zap.Error(err), zap.String("key", submission.FormKey))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Form submission created successfully", zap.String("submissionID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetSubmissionByID(id uuid.UUID) (*domainForm.Submission, error) {
+	var model Submission
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Form submission not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting form submission", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetSubmissions(key string, clientUserID *uuid.UUID) (*[]domainForm.Submission, error) {
+	var models []Submission
+	query := r.DB.Where("form_key = ?", key)
+	if clientUserID != nil {
+		query = query.Where("client_user_id = ?", *clientUserID)
+	}
+	if err := query.Order("submitted_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting form submissions", zap.Error(err), zap.String("key", key))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	submissions := make([]domainForm.Submission, len(models))
+	for i := range models {
+		submissions[i] = *models[i].toDomainMapper()
+	}
+	return &submissions, nil
+}
+
+func (d *Definition) toDomainMapper() *domainForm.Definition {
+	return &domainForm.Definition{
+		ID:                       d.ID,
+		Key:                      d.Key,
+		Version:                  d.Version,
+		Name:                     d.Name,
+		Purpose:                  d.Purpose,
+		ReassessmentIntervalDays: d.ReassessmentIntervalDays,
+		Fields:                   d.Fields,
+		Active:                   d.Active,
+		CreatedByUserID:          d.CreatedByUserID,
+		CreatedAt:                d.CreatedAt,
+	}
+}
+
+func definitionFromDomain(d *domainForm.Definition) *Definition {
+	return &Definition{
+		ID:                       d.ID,
+		Key:                      d.Key,
+		Version:                  d.Version,
+		Name:                     d.Name,
+		Purpose:                  d.Purpose,
+		ReassessmentIntervalDays: d.ReassessmentIntervalDays,
+		Fields:                   d.Fields,
+		Active:                   d.Active,
+		CreatedByUserID:          d.CreatedByUserID,
+	}
+}
+
+func definitionsToDomain(models []Definition) *[]domainForm.Definition {
+	definitions := make([]domainForm.Definition, len(models))
+	for i := range models {
+		definitions[i] = *models[i].toDomainMapper()
+	}
+	return &definitions
+}
+
+func (s *Submission) toDomainMapper() *domainForm.Submission {
+	return &domainForm.Submission{
+		ID:                s.ID,
+		DefinitionID:      s.DefinitionID,
+		FormKey:           s.FormKey,
+		FormVersion:       s.FormVersion,
+		ClientUserID:      s.ClientUserID,
+		SubmittedByUserID: s.SubmittedByUserID,
+		Answers:           s.Answers,
+		FieldScores:       s.FieldScores,
+		Score:             s.Score,
+		SubmittedAt:       s.SubmittedAt,
+	}
+}
+
+func submissionFromDomain(s *domainForm.Submission) *Submission {
+	return &Submission{
+		ID:                s.ID,
+		DefinitionID:      s.DefinitionID,
+		FormKey:           s.FormKey,
+		FormVersion:       s.FormVersion,
+		ClientUserID:      s.ClientUserID,
+		SubmittedByUserID: s.SubmittedByUserID,
+		Answers:           s.Answers,
+		FieldScores:       s.FieldScores,
+		Score:             s.Score,
+		SubmittedAt:       s.SubmittedAt,
+	}
+}
@@ -0,0 +1,132 @@
+package handoff
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainHandoff "caregiver/src/domain/handoff"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Handoff struct {
+	ID                  uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	FromScheduleID      uuid.UUID  `gorm:"column:from_schedule_id;type:uuid;uniqueIndex"`
+	ToScheduleID        uuid.UUID  `gorm:"column:to_schedule_id;type:uuid;index"`
+	ClientUserID        uuid.UUID  `gorm:"column:client_user_id;type:uuid;index"`
+	FromCaregiverUserID uuid.UUID  `gorm:"column:from_caregiver_user_id;type:uuid"`
+	ToCaregiverUserID   uuid.UUID  `gorm:"column:to_caregiver_user_id;type:uuid"`
+	Summary             string     `gorm:"column:summary"`
+	ClientMood          string     `gorm:"column:client_mood"`
+	Concerns            string     `gorm:"column:concerns"`
+	PendingTasks        []string   `gorm:"column:pending_tasks;serializer:json"`
+	Medications         string     `gorm:"column:medications"`
+	AcknowledgedAt      *time.Time `gorm:"column:acknowledged_at"`
+	AcknowledgedBy      *uuid.UUID `gorm:"column:acknowledged_by;type:uuid"`
+	CreatedAt           time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt           time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Handoff) TableName() string {
+	return "shift_handoffs"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewHandoffRepository(db *gorm.DB, loggerInstance *logger.Logger) domainHandoff.IHandoffRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) GetFrom(fromScheduleID uuid.UUID) (*domainHandoff.Handoff, error) {
+	var model Handoff
+	if err := r.DB.Where("from_schedule_id = ?", fromScheduleID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting handoff", zap.Error(err), zap.String("fromScheduleID", fromScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetTo(toScheduleID uuid.UUID) (*[]domainHandoff.Handoff, error) {
+	var models []Handoff
+	if err := r.DB.Where("to_schedule_id = ?", toScheduleID).Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting handoffs", zap.Error(err), zap.String("toScheduleID", toScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return toDomainList(models), nil
+}
+
+func (r *Repository) Save(handoff *domainHandoff.Handoff) (*domainHandoff.Handoff, error) {
+	model := &Handoff{
+		FromScheduleID:      handoff.FromScheduleID,
+		ToScheduleID:        handoff.ToScheduleID,
+		ClientUserID:        handoff.ClientUserID,
+		FromCaregiverUserID: handoff.FromCaregiverUserID,
+		ToCaregiverUserID:   handoff.ToCaregiverUserID,
+		Summary:             handoff.Notes.Summary,
+		ClientMood:          handoff.Notes.ClientMood,
+		Concerns:            handoff.Notes.Concerns,
+		PendingTasks:        handoff.Notes.PendingTasks,
+		Medications:         handoff.Notes.Medications,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "from_schedule_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"to_schedule_id", "to_caregiver_user_id", "summary", "client_mood",
+			"concerns", "pending_tasks", "medications", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		r.Logger.Error("Error saving handoff", zap.Error(err), zap.String("fromScheduleID", handoff.FromScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetFrom(handoff.FromScheduleID)
+}
+
+func (r *Repository) Acknowledge(toScheduleID uuid.UUID, userID uuid.UUID, at time.Time) (*[]domainHandoff.Handoff, error) {
+	err := r.DB.Model(&Handoff{}).Where("to_schedule_id = ? AND acknowledged_at IS NULL", toScheduleID).
+		Updates(map[string]interface{}{"acknowledged_at": at, "acknowledged_by": userID}).Error
+	if err != nil {
+		r.Logger.Error("Error acknowledging handoffs", zap.Error(err), zap.String("toScheduleID", toScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetTo(toScheduleID)
+}
+
+func (m *Handoff) toDomainMapper() *domainHandoff.Handoff {
+	return &domainHandoff.Handoff{
+		ID:                  m.ID,
+		FromScheduleID:      m.FromScheduleID,
+		ToScheduleID:        m.ToScheduleID,
+		ClientUserID:        m.ClientUserID,
+		FromCaregiverUserID: m.FromCaregiverUserID,
+		ToCaregiverUserID:   m.ToCaregiverUserID,
+		Notes: domainHandoff.Notes{
+			Summary:      m.Summary,
+			ClientMood:   m.ClientMood,
+			Concerns:     m.Concerns,
+			PendingTasks: m.PendingTasks,
+			Medications:  m.Medications,
+		},
+		AcknowledgedAt: m.AcknowledgedAt,
+		AcknowledgedBy: m.AcknowledgedBy,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func toDomainList(models []Handoff) *[]domainHandoff.Handoff {
+	res := make([]domainHandoff.Handoff, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res
+}
@@ -0,0 +1,178 @@
+package identity
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainIdentity "caregiver/src/domain/identity"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Settings struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Required  bool      `gorm:"column:required"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Settings) TableName() string {
+	return "identity_settings"
+}
+
+type Challenge struct {
+	ID               uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID     uuid.UUID  `gorm:"column:client_user_id;type:uuid;uniqueIndex"`
+	Question         string     `gorm:"column:question"`
+	AnswerHash       string     `gorm:"column:answer_hash"`
+	PhotoKey         string     `gorm:"column:photo_key"`
+	PhotoContentType string     `gorm:"column:photo_content_type"`
+	UpdatedBy        *uuid.UUID `gorm:"column:updated_by;type:uuid"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Challenge) TableName() string {
+	return "identity_challenges"
+}
+
+type Check struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID      uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	ClientUserID    uuid.UUID `gorm:"column:client_user_id;type:uuid;index"`
+	CaregiverUserID uuid.UUID `gorm:"column:caregiver_user_id;type:uuid"`
+	Method          string    `gorm:"column:method"`
+	Passed          bool      `gorm:"column:passed"`
+	Note            string    `gorm:"column:note"`
+	CheckedAt       time.Time `gorm:"column:checked_at"`
+}
+
+func (Check) TableName() string {
+	return "identity_checks"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewIdentityRepository(db *gorm.DB, loggerInstance *logger.Logger) domainIdentity.IIdentityRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+// GetSettings returns the organization's identity check settings, of which
+// there is at most one row.
+func (r *Repository) GetSettings() (*domainIdentity.Settings, error) {
+	var model Settings
+	if err := r.DB.Order("updated_at ASC").First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting identity settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return &domainIdentity.Settings{ID: model.ID, Required: model.Required, UpdatedAt: model.UpdatedAt}, nil
+}
+
+func (r *Repository) SaveSettings(settings *domainIdentity.Settings) (*domainIdentity.Settings, error) {
+	model := Settings{ID: settings.ID, Required: settings.Required}
+	if err := r.DB.Save(&model).Error; err != nil {
+		r.Logger.Error("Error saving identity settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetSettings()
+}
+
+func (r *Repository) GetChallenge(clientUserID uuid.UUID) (*domainIdentity.Challenge, error) {
+	var model Challenge
+	if err := r.DB.Where("client_user_id = ?", clientUserID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting identity challenge", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) SaveChallenge(challenge *domainIdentity.Challenge) (*domainIdentity.Challenge, error) {
+	model := &Challenge{
+		ClientUserID:     challenge.ClientUserID,
+		Question:         challenge.Question,
+		AnswerHash:       challenge.AnswerHash,
+		PhotoKey:         challenge.PhotoKey,
+		PhotoContentType: challenge.PhotoContentType,
+		UpdatedBy:        challenge.UpdatedBy,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "client_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"question", "answer_hash", "photo_key", "photo_content_type",
+			"updated_by", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		r.Logger.Error("Error saving identity challenge", zap.Error(err), zap.String("clientUserID", challenge.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetChallenge(challenge.ClientUserID)
+}
+
+func (r *Repository) CreateCheck(check *domainIdentity.Check) (*domainIdentity.Check, error) {
+	model := &Check{
+		ScheduleID:      check.ScheduleID,
+		ClientUserID:    check.ClientUserID,
+		CaregiverUserID: check.CaregiverUserID,
+		Method:          check.Method,
+		Passed:          check.Passed,
+		Note:            check.Note,
+		CheckedAt:       check.CheckedAt,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error recording identity check", zap.Error(err), zap.String("scheduleID", check.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetChecks(scheduleID uuid.UUID) (*[]domainIdentity.Check, error) {
+	var models []Check
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Order("checked_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting identity checks", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainIdentity.Check, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (m *Challenge) toDomainMapper() *domainIdentity.Challenge {
+	return &domainIdentity.Challenge{
+		ID:               m.ID,
+		ClientUserID:     m.ClientUserID,
+		Question:         m.Question,
+		AnswerHash:       m.AnswerHash,
+		PhotoKey:         m.PhotoKey,
+		PhotoContentType: m.PhotoContentType,
+		UpdatedBy:        m.UpdatedBy,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+func (m *Check) toDomainMapper() *domainIdentity.Check {
+	return &domainIdentity.Check{
+		ID:              m.ID,
+		ScheduleID:      m.ScheduleID,
+		ClientUserID:    m.ClientUserID,
+		CaregiverUserID: m.CaregiverUserID,
+		Method:          m.Method,
+		Passed:          m.Passed,
+		Note:            m.Note,
+		CheckedAt:       m.CheckedAt,
+	}
+}
@@ -0,0 +1,106 @@
+package interruption
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainInterruption "caregiver/src/domain/interruption"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/repository/psql/schedule"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Repository writes the schedule_interruptions rows that the schedule
+// repository preloads.
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewInterruptionRepository(db *gorm.DB, loggerInstance *logger.Logger) domainInterruption.IInterruptionRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(interruption *domainSchedule.Interruption) (*domainSchedule.Interruption, error) {
+	model := &schedule.Interruption{
+		ScheduleID:      interruption.ScheduleID,
+		ClientUserID:    interruption.ClientUserID,
+		CaregiverUserID: interruption.CaregiverUserID,
+		ReasonCode:      interruption.ReasonCode,
+		Note:            interruption.Note,
+		StartedAt:       interruption.StartedAt,
+		EndedAt:         interruption.EndedAt,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating interruption", zap.Error(err), zap.String("scheduleID", interruption.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.ToDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainSchedule.Interruption, error) {
+	var model schedule.Interruption
+	if err := r.DB.Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting interruption", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.ToDomainMapper(), nil
+}
+
+func (r *Repository) GetBySchedule(scheduleID uuid.UUID) (*[]domainSchedule.Interruption, error) {
+	var models []schedule.Interruption
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Order("started_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting interruptions", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return toDomainList(models), nil
+}
+
+func (r *Repository) GetOpen(scheduleID uuid.UUID) (*domainSchedule.Interruption, error) {
+	var model schedule.Interruption
+	if err := r.DB.Where("schedule_id = ? AND ended_at IS NULL", scheduleID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting open interruption", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.ToDomainMapper(), nil
+}
+
+func (r *Repository) GetBetween(from time.Time, to time.Time) (*[]domainSchedule.Interruption, error) {
+	var models []schedule.Interruption
+	if err := r.DB.Where("started_at >= ? AND started_at < ?", from, to).Order("started_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting interruptions between", zap.Error(err), zap.Time("from", from), zap.Time("to", to))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return toDomainList(models), nil
+}
+
+func (r *Repository) End(id uuid.UUID, endedAt time.Time) (*domainSchedule.Interruption, error) {
+	tx := r.DB.Model(&schedule.Interruption{}).Where("id = ? AND ended_at IS NULL", id).Update("ended_at", endedAt)
+	if tx.Error != nil {
+		r.Logger.Error("Error ending interruption", zap.Error(tx.Error), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return r.GetByID(id)
+}
+
+func toDomainList(models []schedule.Interruption) *[]domainSchedule.Interruption {
+	res := make([]domainSchedule.Interruption, len(models))
+	for i := range models {
+		res[i] = *models[i].ToDomainMapper()
+	}
+	return &res
+}
@@ -0,0 +1,282 @@
+package invoice
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainInvoice "caregiver/src/domain/invoice"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type RateCard struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ServiceName   string     `gorm:"column:service_name"`
+	UnitMinutes   int        `gorm:"column:unit_minutes"`
+	UnitRate      float64    `gorm:"column:unit_rate"`
+	EffectiveFrom time.Time  `gorm:"column:effective_from;type:date"`
+	EffectiveTo   *time.Time `gorm:"column:effective_to;type:date"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+type Invoice struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	Number       string     `gorm:"column:number;uniqueIndex"`
+	ClientUserID uuid.UUID  `gorm:"column:client_user_id;type:uuid;index"`
+	ClientName   string     `gorm:"column:client_name"`
+	PeriodStart  time.Time  `gorm:"column:period_start;type:date"`
+	PeriodEnd    time.Time  `gorm:"column:period_end;type:date"`
+	Total        float64    `gorm:"column:total"`
+	IssuedAt     time.Time  `gorm:"column:issued_at"`
+	Lines        []LineItem `gorm:"foreignKey:InvoiceID"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime:milli"`
+}
+
+// LineItem's unique schedule index keeps a visit on a single invoice.
+type LineItem struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	InvoiceID     uuid.UUID `gorm:"column:invoice_id;type:uuid;index"`
+	ScheduleID    uuid.UUID `gorm:"column:schedule_id;type:uuid;uniqueIndex"`
+	ServiceDate   time.Time `gorm:"column:service_date;type:date"`
+	ServiceName   string    `gorm:"column:service_name"`
+	WorkedMinutes int       `gorm:"column:worked_minutes"`
+	Units         int       `gorm:"column:units"`
+	UnitRate      float64   `gorm:"column:unit_rate"`
+	Amount        float64   `gorm:"column:amount"`
+}
+
+func (RateCard) TableName() string {
+	return "rate_cards"
+}
+
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+func (LineItem) TableName() string {
+	return "invoice_line_items"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewInvoiceRepository(db *gorm.DB, loggerInstance *logger.Logger) domainInvoice.IInvoiceRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateRateCard(newRateCard *domainInvoice.RateCard) (*domainInvoice.RateCard, error) {
+	model := &RateCard{
+		ID:            newRateCard.ID,
+		ServiceName:   newRateCard.ServiceName,
+		UnitMinutes:   newRateCard.UnitMinutes,
+		UnitRate:      newRateCard.UnitRate,
+		EffectiveFrom: newRateCard.EffectiveFrom,
+		EffectiveTo:   newRateCard.EffectiveTo,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating rate card", zap.Error(err), zap.String("serviceName", newRateCard.ServiceName))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRateCardByID(id uuid.UUID) (*domainInvoice.RateCard, error) {
+	var model RateCard
+	if err := r.first(&model, "rate card", id); err != nil {
+		return nil, err
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRateCards() (*[]domainInvoice.RateCard, error) {
+	var models []RateCard
+	if err := r.DB.Order("service_name ASC, effective_from ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting rate cards", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainInvoice.RateCard, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateRateCard(id uuid.UUID, updates map[string]interface{}) (*domainInvoice.RateCard, error) {
+	if err := r.DB.Model(&RateCard{ID: id}).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating rate card", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetRateCardByID(id)
+}
+
+func (r *Repository) DeleteRateCard(id uuid.UUID) error {
+	tx := r.DB.Delete(&RateCard{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting rate card", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("rate card not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) CreateInvoices(invoices []domainInvoice.Invoice) error {
+	models := make([]Invoice, len(invoices))
+	for i, invoice := range invoices {
+		models[i] = Invoice{
+			ID:           invoice.ID,
+			Number:       invoice.Number,
+			ClientUserID: invoice.ClientUserID,
+			ClientName:   invoice.ClientName,
+			PeriodStart:  invoice.PeriodStart,
+			PeriodEnd:    invoice.PeriodEnd,
+			Total:        invoice.Total,
+			IssuedAt:     invoice.IssuedAt,
+		}
+		for _, line := range invoice.Lines {
+			models[i].Lines = append(models[i].Lines, LineItem{
+				ID:            line.ID,
+				InvoiceID:     invoice.ID,
+				ScheduleID:    line.ScheduleID,
+				ServiceDate:   line.ServiceDate,
+				ServiceName:   line.ServiceName,
+				WorkedMinutes: line.WorkedMinutes,
+				Units:         line.Units,
+				UnitRate:      line.UnitRate,
+				Amount:        line.Amount,
+			})
+		}
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range models {
+			if err := tx.Create(&models[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.Logger.Error("Error creating invoices", zap.Error(err), zap.Int("invoices", len(models)))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) GetInvoices(filter domainInvoice.Filter) (*[]domainInvoice.Invoice, error) {
+	query := r.DB.Model(&Invoice{})
+	if filter.ClientUserID != nil {
+		query = query.Where("client_user_id = ?", *filter.ClientUserID)
+	}
+	if filter.From != nil {
+		query = query.Where("period_end >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("period_start <= ?", *filter.To)
+	}
+	var models []Invoice
+	if err := query.Order("period_end DESC, number ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting invoices", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainInvoice.Invoice, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetInvoiceByID(id uuid.UUID) (*domainInvoice.Invoice, error) {
+	var model Invoice
+	err := r.DB.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("service_date ASC")
+	}).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting invoice by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetInvoicedScheduleIDs(scheduleIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	invoiced := make(map[uuid.UUID]bool)
+	if len(scheduleIDs) == 0 {
+		return invoiced, nil
+	}
+	var ids []uuid.UUID
+	if err := r.DB.Model(&LineItem{}).
+		Where("schedule_id IN ?", scheduleIDs).
+		Pluck("schedule_id", &ids).Error; err != nil {
+		r.Logger.Error("Error getting invoiced schedules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	for _, id := range ids {
+		invoiced[id] = true
+	}
+	return invoiced, nil
+}
+
+func (r *Repository) first(model interface{}, name string, id uuid.UUID) error {
+	err := r.DB.Where("id = ?", id).First(model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.Logger.Warn(name+" not found", zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting "+name+" by ID", zap.Error(err), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (m *RateCard) toDomainMapper() *domainInvoice.RateCard {
+	return &domainInvoice.RateCard{
+		ID:            m.ID,
+		ServiceName:   m.ServiceName,
+		UnitMinutes:   m.UnitMinutes,
+		UnitRate:      m.UnitRate,
+		EffectiveFrom: m.EffectiveFrom,
+		EffectiveTo:   m.EffectiveTo,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func (m *Invoice) toDomainMapper() *domainInvoice.Invoice {
+	invoice := &domainInvoice.Invoice{
+		ID:           m.ID,
+		Number:       m.Number,
+		ClientUserID: m.ClientUserID,
+		ClientName:   m.ClientName,
+		PeriodStart:  m.PeriodStart,
+		PeriodEnd:    m.PeriodEnd,
+		Total:        m.Total,
+		IssuedAt:     m.IssuedAt,
+		CreatedAt:    m.CreatedAt,
+	}
+	for _, line := range m.Lines {
+		invoice.Lines = append(invoice.Lines, domainInvoice.LineItem{
+			ID:            line.ID,
+			InvoiceID:     line.InvoiceID,
+			ScheduleID:    line.ScheduleID,
+			ServiceDate:   line.ServiceDate,
+			ServiceName:   line.ServiceName,
+			WorkedMinutes: line.WorkedMinutes,
+			Units:         line.Units,
+			UnitRate:      line.UnitRate,
+			Amount:        line.Amount,
+		})
+	}
+	return invoice
+}
@@ -0,0 +1,255 @@
+package kiosk
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainKiosk "caregiver/src/domain/kiosk"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Kiosk struct {
+	ID                 uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name               string     `gorm:"column:name"`
+	AddressHouseNumber string     `gorm:"column:address_house_number"`
+	AddressStreet      string     `gorm:"column:address_street"`
+	AddressCity        string     `gorm:"column:address_city"`
+	AddressState       string     `gorm:"column:address_state"`
+	AddressPincode     string     `gorm:"column:address_pincode"`
+	TokenHash          string     `gorm:"column:token_hash;uniqueIndex"`
+	Active             bool       `gorm:"column:active"`
+	LastUsedAt         *time.Time `gorm:"column:last_used_at"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt          time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Kiosk) TableName() string {
+	return "kiosks"
+}
+
+type PIN struct {
+	UserID    uuid.UUID `gorm:"primaryKey;type:uuid"`
+	PINHash   string    `gorm:"column:pin_hash"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (PIN) TableName() string {
+	return "kiosk_pins"
+}
+
+type AuditEntry struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	KioskID         uuid.UUID  `gorm:"column:kiosk_id;type:uuid;index"`
+	ScheduleID      *uuid.UUID `gorm:"column:schedule_id;type:uuid"`
+	CaregiverUserID *uuid.UUID `gorm:"column:caregiver_user_id;type:uuid;index"`
+	Action          string     `gorm:"column:action"`
+	Detail          string     `gorm:"column:detail"`
+	IPAddress       string     `gorm:"column:ip_address"`
+	OccurredAt      time.Time  `gorm:"column:occurred_at;index"`
+}
+
+func (AuditEntry) TableName() string {
+	return "kiosk_audit_entries"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewKioskRepository(db *gorm.DB, loggerInstance *logger.Logger) domainKiosk.IKioskRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newKiosk *domainKiosk.Kiosk) (*domainKiosk.Kiosk, error) {
+	kioskModel := fromDomainMapper(newKiosk)
+	if err := r.DB.Create(kioskModel).Error; err != nil {
+		r.Logger.Error("Error creating kiosk", zap.Error(err), zap.String("name", newKiosk.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Kiosk created successfully", zap.String("kioskID", kioskModel.ID.String()))
+	return kioskModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainKiosk.Kiosk, error) {
+	var kioskModel Kiosk
+	err := r.DB.Where("id = ?", id).First(&kioskModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Kiosk not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting kiosk by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return kioskModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll() (*[]domainKiosk.Kiosk, error) {
+	var kiosks []Kiosk
+	if err := r.DB.Order("name ASC").Find(&kiosks).Error; err != nil {
+		r.Logger.Error("Error getting kiosks", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainKiosk.Kiosk, len(kiosks))
+	for i := range kiosks {
+		res[i] = *kiosks[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetByTokenHash(tokenHash string) (*domainKiosk.Kiosk, error) {
+	var kioskModel Kiosk
+	err := r.DB.Where("token_hash = ?", tokenHash).First(&kioskModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting kiosk by token", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return kioskModel.toDomainMapper(), nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainKiosk.Kiosk, error) {
+	var kioskModel Kiosk
+	kioskModel.ID = id
+	if err := r.DB.Model(&kioskModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating kiosk", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	tx := r.DB.Delete(&Kiosk{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting kiosk", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Kiosk not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) GetPIN(userID uuid.UUID) (*domainKiosk.PIN, error) {
+	var pinModel PIN
+	err := r.DB.Where("user_id = ?", userID).First(&pinModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting kiosk PIN", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return &domainKiosk.PIN{UserID: pinModel.UserID, PINHash: pinModel.PINHash, UpdatedAt: pinModel.UpdatedAt}, nil
+}
+
+func (r *Repository) SavePIN(pin *domainKiosk.PIN) (*domainKiosk.PIN, error) {
+	pinModel := &PIN{UserID: pin.UserID, PINHash: pin.PINHash}
+	if err := r.DB.Save(pinModel).Error; err != nil {
+		r.Logger.Error("Error saving kiosk PIN", zap.Error(err), zap.String("userID", pin.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return &domainKiosk.PIN{UserID: pinModel.UserID, PINHash: pinModel.PINHash, UpdatedAt: pinModel.UpdatedAt}, nil
+}
+
+func (r *Repository) CreateAuditEntry(entry *domainKiosk.AuditEntry) (*domainKiosk.AuditEntry, error) {
+	entryModel := &AuditEntry{
+		ID:              entry.ID,
+		KioskID:         entry.KioskID,
+		ScheduleID:      entry.ScheduleID,
+		CaregiverUserID: entry.CaregiverUserID,
+		Action:          entry.Action,
+		Detail:          entry.Detail,
+		IPAddress:       entry.IPAddress,
+		OccurredAt:      entry.OccurredAt,
+	}
+	if err := r.DB.Create(entryModel).Error; err != nil {
+		r.Logger.Error("Error creating kiosk audit entry", zap.Error(err), zap.String("kioskID", entry.KioskID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return entryModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAuditEntries(kioskID uuid.UUID, from, to time.Time) (*[]domainKiosk.AuditEntry, error) {
+	var entries []AuditEntry
+	err := r.DB.Where("kiosk_id = ? AND occurred_at >= ? AND occurred_at < ?", kioskID, from, to).
+		Order("occurred_at DESC").Find(&entries).Error
+	if err != nil {
+		r.Logger.Error("Error getting kiosk audit entries", zap.Error(err), zap.String("kioskID", kioskID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainKiosk.AuditEntry, len(entries))
+	for i := range entries {
+		res[i] = *entries[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) CountPINFailuresSince(caregiverUserID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.DB.Model(&AuditEntry{}).
+		Where("caregiver_user_id = ? AND action = ? AND occurred_at >= ?", caregiverUserID, domainKiosk.ActionPINRejected, since).
+		Count(&count).Error
+	if err != nil {
+		r.Logger.Error("Error counting failed kiosk PIN attempts", zap.Error(err), zap.String("caregiverUserID", caregiverUserID.String()))
+		return 0, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return count, nil
+}
+
+func (k *Kiosk) toDomainMapper() *domainKiosk.Kiosk {
+	return &domainKiosk.Kiosk{
+		ID:   k.ID,
+		Name: k.Name,
+		Address: domainKiosk.Address{
+			HouseNumber: k.AddressHouseNumber,
+			Street:      k.AddressStreet,
+			City:        k.AddressCity,
+			State:       k.AddressState,
+			Pincode:     k.AddressPincode,
+		},
+		TokenHash:  k.TokenHash,
+		Active:     k.Active,
+		LastUsedAt: k.LastUsedAt,
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(k *domainKiosk.Kiosk) *Kiosk {
+	return &Kiosk{
+		ID:                 k.ID,
+		Name:               k.Name,
+		AddressHouseNumber: k.Address.HouseNumber,
+		AddressStreet:      k.Address.Street,
+		AddressCity:        k.Address.City,
+		AddressState:       k.Address.State,
+		AddressPincode:     k.Address.Pincode,
+		TokenHash:          k.TokenHash,
+		Active:             k.Active,
+		LastUsedAt:         k.LastUsedAt,
+		CreatedAt:          k.CreatedAt,
+		UpdatedAt:          k.UpdatedAt,
+	}
+}
+
+func (e *AuditEntry) toDomainMapper() *domainKiosk.AuditEntry {
+	return &domainKiosk.AuditEntry{
+		ID:              e.ID,
+		KioskID:         e.KioskID,
+		ScheduleID:      e.ScheduleID,
+		CaregiverUserID: e.CaregiverUserID,
+		Action:          e.Action,
+		Detail:          e.Detail,
+		IPAddress:       e.IPAddress,
+		OccurredAt:      e.OccurredAt,
+	}
+}
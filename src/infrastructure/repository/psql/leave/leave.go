@@ -0,0 +1,317 @@
+package leave
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLeave "caregiver/src/domain/leave"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AccrualRule struct {
+	ID                 uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CaregiverUserID    uuid.UUID `gorm:"column:caregiver_user_id;type:uuid;uniqueIndex"`
+	HoursPerHourWorked float64   `gorm:"column:hours_per_hour_worked"`
+	MaxBalance         float64   `gorm:"column:max_balance"`
+	Active             bool      `gorm:"column:active"`
+	CreatedAt          time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (AccrualRule) TableName() string {
+	return "leave_accrual_rules"
+}
+
+type Request struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CaregiverUserID uuid.UUID  `gorm:"column:caregiver_user_id;type:uuid;index"`
+	Type            string     `gorm:"column:type"`
+	StartsAt        time.Time  `gorm:"column:starts_at;index"`
+	EndsAt          time.Time  `gorm:"column:ends_at"`
+	Hours           float64    `gorm:"column:hours"`
+	Reason          string     `gorm:"column:reason"`
+	Status          string     `gorm:"column:status;index"`
+	DecidedByUserID *uuid.UUID `gorm:"column:decided_by_user_id;type:uuid"`
+	DecisionNote    string     `gorm:"column:decision_note"`
+	DecidedAt       *time.Time `gorm:"column:decided_at"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Request) TableName() string {
+	return "leave_requests"
+}
+
+type Entry struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CaregiverUserID uuid.UUID  `gorm:"column:caregiver_user_id;type:uuid;index"`
+	Type            string     `gorm:"column:type"`
+	Hours           float64    `gorm:"column:hours"`
+	ScheduleID      *uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	RequestID       *uuid.UUID `gorm:"column:request_id;type:uuid"`
+	Note            string     `gorm:"column:note"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli"`
+}
+
+func (Entry) TableName() string {
+	return "leave_ledger_entries"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewLeaveRepository(db *gorm.DB, loggerInstance *logger.Logger) domainLeave.ILeaveRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateRule(newRule *domainLeave.AccrualRule) (*domainLeave.AccrualRule, error) {
+	ruleModel := ruleFromDomainMapper(newRule)
+	if err := r.DB.Create(ruleModel).Error; err != nil {
+		r.Logger.Error("Error creating leave accrual rule", zap.Error(err), zap.String("caregiverUserID", newRule.CaregiverUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Leave accrual rule created successfully", zap.String("ruleID", ruleModel.ID.String()))
+	return ruleModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRuleByID(id uuid.UUID) (*domainLeave.AccrualRule, error) {
+	return r.getRule("id = ?", id)
+}
+
+func (r *Repository) GetRuleByCaregiver(caregiverUserID uuid.UUID) (*domainLeave.AccrualRule, error) {
+	return r.getRule("caregiver_user_id = ?", caregiverUserID)
+}
+
+func (r *Repository) getRule(condition string, id uuid.UUID) (*domainLeave.AccrualRule, error) {
+	var ruleModel AccrualRule
+	err := r.DB.Where(condition, id).First(&ruleModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Leave accrual rule not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting leave accrual rule", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return ruleModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRules() (*[]domainLeave.AccrualRule, error) {
+	var rules []AccrualRule
+	if err := r.DB.Order("created_at ASC").Find(&rules).Error; err != nil {
+		r.Logger.Error("Error getting leave accrual rules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainLeave.AccrualRule, len(rules))
+	for i := range rules {
+		res[i] = *rules[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domainLeave.AccrualRule, error) {
+	ruleModel := AccrualRule{ID: id}
+	if err := r.DB.Model(&ruleModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating leave accrual rule", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetRuleByID(id)
+}
+
+func (r *Repository) DeleteRule(id uuid.UUID) error {
+	tx := r.DB.Delete(&AccrualRule{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting leave accrual rule", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Leave accrual rule not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) CreateRequest(newRequest *domainLeave.Request) (*domainLeave.Request, error) {
+	requestModel := requestFromDomainMapper(newRequest)
+	if err := r.DB.Create(requestModel).Error; err != nil {
+		r.Logger.Error("Error creating leave request", zap.Error(err), zap.String("caregiverUserID", newRequest.CaregiverUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Leave request created successfully", zap.String("requestID", requestModel.ID.String()))
+	return requestModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRequestByID(id uuid.UUID) (*domainLeave.Request, error) {
+	var requestModel Request
+	err := r.DB.Where("id = ?", id).First(&requestModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Leave request not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting leave request by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return requestModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRequests(filter domainLeave.RequestFilter) (*[]domainLeave.Request, error) {
+	query := r.DB.Model(&Request{})
+	if filter.CaregiverUserID != nil {
+		query = query.Where("caregiver_user_id = ?", *filter.CaregiverUserID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.To != nil {
+		query = query.Where("starts_at < ?", *filter.To)
+	}
+	if filter.From != nil {
+		query = query.Where("ends_at > ?", *filter.From)
+	}
+	var requests []Request
+	if err := query.Order("starts_at ASC").Find(&requests).Error; err != nil {
+		r.Logger.Error("Error getting leave requests", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainLeave.Request, len(requests))
+	for i := range requests {
+		res[i] = *requests[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateRequest(id uuid.UUID, updates map[string]interface{}) (*domainLeave.Request, error) {
+	requestModel := Request{ID: id}
+	if err := r.DB.Model(&requestModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating leave request", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetRequestByID(id)
+}
+
+func (r *Repository) CreateEntry(entry *domainLeave.Entry) (*domainLeave.Entry, error) {
+	entryModel := entryFromDomainMapper(entry)
+	if err := r.DB.Create(entryModel).Error; err != nil {
+		r.Logger.Error("Error creating leave ledger entry", zap.Error(err), zap.String("caregiverUserID", entry.CaregiverUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return entryModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetEntries(caregiverUserID uuid.UUID) (*[]domainLeave.Entry, error) {
+	var entries []Entry
+	if err := r.DB.Where("caregiver_user_id = ?", caregiverUserID).Order("created_at ASC").Find(&entries).Error; err != nil {
+		r.Logger.Error("Error getting leave ledger entries", zap.Error(err), zap.String("caregiverUserID", caregiverUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainLeave.Entry, len(entries))
+	for i := range entries {
+		res[i] = *entries[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) HasAccrualForSchedule(scheduleID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.Model(&Entry{}).
+		Where("schedule_id = ? AND type = ?", scheduleID, domainLeave.EntryAccrual).
+		Count(&count).Error
+	if err != nil {
+		r.Logger.Error("Error checking leave accrual for schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return false, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return count > 0, nil
+}
+
+func (a *AccrualRule) toDomainMapper() *domainLeave.AccrualRule {
+	return &domainLeave.AccrualRule{
+		ID:                 a.ID,
+		CaregiverUserID:    a.CaregiverUserID,
+		HoursPerHourWorked: a.HoursPerHourWorked,
+		MaxBalance:         a.MaxBalance,
+		Active:             a.Active,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
+	}
+}
+
+func ruleFromDomainMapper(a *domainLeave.AccrualRule) *AccrualRule {
+	return &AccrualRule{
+		ID:                 a.ID,
+		CaregiverUserID:    a.CaregiverUserID,
+		HoursPerHourWorked: a.HoursPerHourWorked,
+		MaxBalance:         a.MaxBalance,
+		Active:             a.Active,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
+	}
+}
+
+func (q *Request) toDomainMapper() *domainLeave.Request {
+	return &domainLeave.Request{
+		ID:              q.ID,
+		CaregiverUserID: q.CaregiverUserID,
+		Type:            q.Type,
+		From:            q.StartsAt,
+		To:              q.EndsAt,
+		Hours:           q.Hours,
+		Reason:          q.Reason,
+		Status:          q.Status,
+		DecidedByUserID: q.DecidedByUserID,
+		DecisionNote:    q.DecisionNote,
+		DecidedAt:       q.DecidedAt,
+		CreatedAt:       q.CreatedAt,
+		UpdatedAt:       q.UpdatedAt,
+	}
+}
+
+func requestFromDomainMapper(q *domainLeave.Request) *Request {
+	return &Request{
+		ID:              q.ID,
+		CaregiverUserID: q.CaregiverUserID,
+		Type:            q.Type,
+		StartsAt:        q.From,
+		EndsAt:          q.To,
+		Hours:           q.Hours,
+		Reason:          q.Reason,
+		Status:          q.Status,
+		DecidedByUserID: q.DecidedByUserID,
+		DecisionNote:    q.DecisionNote,
+		DecidedAt:       q.DecidedAt,
+		CreatedAt:       q.CreatedAt,
+		UpdatedAt:       q.UpdatedAt,
+	}
+}
+
+func (e *Entry) toDomainMapper() *domainLeave.Entry {
+	return &domainLeave.Entry{
+		ID:              e.ID,
+		CaregiverUserID: e.CaregiverUserID,
+		Type:            e.Type,
+		Hours:           e.Hours,
+		ScheduleID:      e.ScheduleID,
+		RequestID:       e.RequestID,
+		Note:            e.Note,
+		CreatedAt:       e.CreatedAt,
+	}
+}
+
+func entryFromDomainMapper(e *domainLeave.Entry) *Entry {
+	return &Entry{
+		ID:              e.ID,
+		CaregiverUserID: e.CaregiverUserID,
+		Type:            e.Type,
+		Hours:           e.Hours,
+		ScheduleID:      e.ScheduleID,
+		RequestID:       e.RequestID,
+		Note:            e.Note,
+		CreatedAt:       e.CreatedAt,
+	}
+}
@@ -0,0 +1,232 @@
+package legalhold
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLegalHold "caregiver/src/domain/legalhold"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Hold struct {
+	ID               uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID     uuid.UUID  `gorm:"column:client_user_id;type:uuid;index"`
+	Reason           string     `gorm:"column:reason"`
+	PlacedByUserID   *uuid.UUID `gorm:"column:placed_by_user_id;type:uuid"`
+	PlacedAt         time.Time  `gorm:"column:placed_at"`
+	ReleasedByUserID *uuid.UUID `gorm:"column:released_by_user_id;type:uuid"`
+	ReleasedAt       *time.Time `gorm:"column:released_at;index"`
+}
+
+func (Hold) TableName() string {
+	return "client_legal_holds"
+}
+
+// source is a table holding client data. where selects the client's rows
+// with the @client named argument; at is the column ordering them in the
+// timeline.
+type source struct {
+	table string
+	where string
+	at    string
+}
+
+const (
+	byClient    = "client_user_id = @client"
+	bySchedules = "schedule_id IN (SELECT id FROM schedules WHERE client_user_id = @client)"
+)
+
+// sources are every table with rows about a client: the ones carrying a
+// client_user_id, those hanging off the client's visits and care plans, and
+// the audit trails of the client's profile.
+var sources = []source{
+	{table: "users", where: "id = @client", at: "created_at"},
+	{table: "user_addresses", where: "user_id = @client", at: "created_at"},
+	{table: "user_versions", where: "user_id = @client", at: "created_at"},
+	{table: "profile_changes", where: "user_id = @client", at: "created_at"},
+	{table: "client_merges", where: "survivor_user_id = @client OR duplicate_user_id = @client", at: "created_at"},
+	{table: "client_legal_holds", where: byClient, at: "placed_at"},
+	{table: "schedules", where: byClient, at: "created_at"},
+	{table: "tasks", where: bySchedules, at: "created_at"},
+	{table: "schedule_segments", where: bySchedules, at: "created_at"},
+	{table: "schedule_attachments", where: bySchedules, at: "created_at"},
+	{table: "schedule_attachment_views", where: bySchedules, at: "viewed_at"},
+	{table: "document_signatures", where: bySchedules, at: "signed_at"},
+	{table: "visit_voice_memos", where: bySchedules, at: "created_at"},
+	{table: "kiosk_audit_entries", where: bySchedules, at: "occurred_at"},
+	{table: "reminder_deliveries", where: bySchedules, at: "sent_at"},
+	{table: "evv_submissions", where: bySchedules, at: "created_at"},
+	{table: "evv_submission_attempts", where: "submission_id IN (SELECT id FROM evv_submissions WHERE " + bySchedules + ")", at: "attempted_at"},
+	{table: "client_budget_entries", where: bySchedules, at: "created_at"},
+	{table: "care_plans", where: byClient, at: "created_at"},
+	{table: "care_plan_goals", where: "plan_id IN (SELECT id FROM care_plans WHERE client_user_id = @client)", at: "created_at"},
+	{table: "care_plan_goal_entries", where: bySchedules, at: "recorded_at"},
+	{table: "client_allergies", where: byClient, at: "created_at"},
+	{table: "client_medications", where: byClient, at: "created_at"},
+	{table: "medication_administrations", where: byClient, at: "created_at"},
+	{table: "vital_ranges", where: byClient, at: "created_at"},
+	{table: "vital_readings", where: byClient, at: "created_at"},
+	{table: "client_nfc_tags", where: byClient, at: "created_at"},
+	{table: "form_submissions", where: byClient, at: "submitted_at"},
+	{table: "client_consents", where: byClient, at: "created_at"},
+	{table: "client_budgets", where: byClient, at: "created_at"},
+	{table: "client_coverages", where: byClient, at: "created_at"},
+	{table: "claims", where: byClient, at: "created_at"},
+	{table: "claim_denials", where: byClient, at: "created_at"},
+	{table: "client_payments", where: byClient, at: "created_at"},
+	{table: "client_account_adjustments", where: byClient, at: "created_at"},
+	{table: "visit_confirmations", where: byClient, at: "created_at"},
+	{table: "visit_change_requests", where: byClient, at: "created_at"},
+	{table: "waitlist_entries", where: byClient, at: "created_at"},
+	{table: "equipment_checkouts", where: byClient, at: "created_at"},
+	{table: "supply_usages", where: byClient, at: "created_at"},
+	{table: "compliance_exceptions", where: byClient, at: "detected_at"},
+	{table: "alerts", where: byClient, at: "created_at"},
+	{table: "prospects", where: byClient, at: "created_at"},
+}
+
+// secretColumns are never exported.
+var secretColumns = map[string]map[string]bool{
+	"users": {"hash_password": true},
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewLegalHoldRepository(db *gorm.DB, loggerInstance *logger.Logger) domainLegalHold.ILegalHoldRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) GetActive(clientUserID uuid.UUID) (*domainLegalHold.Hold, error) {
+	var hold Hold
+	err := r.DB.Where("client_user_id = ? AND released_at IS NULL", clientUserID).Order("placed_at DESC").First(&hold).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting active legal hold", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return hold.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAllActive() (*[]domainLegalHold.Hold, error) {
+	var holds []Hold
+	if err := r.DB.Where("released_at IS NULL").Order("placed_at DESC").Find(&holds).Error; err != nil {
+		r.Logger.Error("Error getting active legal holds", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(holds), nil
+}
+
+func (r *Repository) GetByClient(clientUserID uuid.UUID) (*[]domainLegalHold.Hold, error) {
+	var holds []Hold
+	if err := r.DB.Where("client_user_id = ?", clientUserID).Order("placed_at DESC").Find(&holds).Error; err != nil {
+		r.Logger.Error("Error getting client legal holds", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(holds), nil
+}
+
+func (r *Repository) Create(hold *domainLegalHold.Hold) (*domainLegalHold.Hold, error) {
+	model := &Hold{
+		ClientUserID:   hold.ClientUserID,
+		Reason:         hold.Reason,
+		PlacedByUserID: hold.PlacedByUserID,
+		PlacedAt:       hold.PlacedAt,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating legal hold", zap.Error(err), zap.String("clientUserID", hold.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) Release(id uuid.UUID, releasedBy *uuid.UUID, at time.Time) (*domainLegalHold.Hold, error) {
+	result := r.DB.Model(&Hold{}).Where("id = ? AND released_at IS NULL", id).
+		Updates(map[string]interface{}{"released_at": at, "released_by_user_id": releasedBy})
+	if result.Error != nil {
+		r.Logger.Error("Error releasing legal hold", zap.Error(result.Error), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	var hold Hold
+	if err := r.DB.Where("id = ?", id).First(&hold).Error; err != nil {
+		r.Logger.Error("Error getting released legal hold", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return hold.toDomainMapper(), nil
+}
+
+// GetRecords reads the sources in one repeatable-read transaction, so the
+// export is a consistent snapshot even while the client's data changes.
+func (r *Repository) GetRecords(clientUserID uuid.UUID) (*[]domainLegalHold.Record, error) {
+	records := []domainLegalHold.Record{}
+	client := sql.Named("client", clientUserID)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, src := range sources {
+			var rows []map[string]interface{}
+			if err := tx.Table(src.table).Where(src.where, client).Order(src.at + " ASC").Find(&rows).Error; err != nil {
+				return fmt.Errorf("%s: %w", src.table, err)
+			}
+			for _, row := range rows {
+				records = append(records, toRecord(src, row))
+			}
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		r.Logger.Error("Error getting client records", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return &records, nil
+}
+
+func toRecord(src source, row map[string]interface{}) domainLegalHold.Record {
+	record := domainLegalHold.Record{Table: src.table, Data: make(map[string]interface{}, len(row))}
+	for column, value := range row {
+		if secretColumns[src.table][column] {
+			continue
+		}
+		// jsonb and bytea columns scan as bytes; keep them readable.
+		if raw, ok := value.([]byte); ok {
+			value = string(raw)
+		}
+		record.Data[column] = value
+	}
+	record.ID = fmt.Sprint(record.Data["id"])
+	if at, ok := row[src.at].(time.Time); ok {
+		record.At = at
+	}
+	return record
+}
+
+func arrayToDomainMapper(holds []Hold) *[]domainLegalHold.Hold {
+	res := make([]domainLegalHold.Hold, len(holds))
+	for i := range holds {
+		res[i] = *holds[i].toDomainMapper()
+	}
+	return &res
+}
+
+func (m *Hold) toDomainMapper() *domainLegalHold.Hold {
+	return &domainLegalHold.Hold{
+		ID:               m.ID,
+		ClientUserID:     m.ClientUserID,
+		Reason:           m.Reason,
+		PlacedByUserID:   m.PlacedByUserID,
+		PlacedAt:         m.PlacedAt,
+		ReleasedByUserID: m.ReleasedByUserID,
+		ReleasedAt:       m.ReleasedAt,
+	}
+}
@@ -0,0 +1,115 @@
+package locale
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Settings struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Timezone     string    `gorm:"column:timezone"`
+	Region       string    `gorm:"column:region"`
+	SkipHolidays bool      `gorm:"column:skip_holidays"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Settings) TableName() string {
+	return "locale_settings"
+}
+
+// Holiday is a custom holiday. Date is kept as YYYY-MM-DD so it names the
+// same day whatever the database time zone.
+type Holiday struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Date      string    `gorm:"column:holiday_date;size:10;index"`
+	Name      string    `gorm:"column:name"`
+	CreatedAt time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Holiday) TableName() string {
+	return "locale_holidays"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewLocaleRepository(db *gorm.DB, loggerInstance *logger.Logger) domainLocale.ILocaleRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+// GetSettings returns the organization's locale, of which there is at most
+// one row.
+func (r *Repository) GetSettings() (*domainLocale.Settings, error) {
+	var model Settings
+	if err := r.DB.Order("updated_at ASC").First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting locale settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return &domainLocale.Settings{
+		ID:           model.ID,
+		Timezone:     model.Timezone,
+		Region:       model.Region,
+		SkipHolidays: model.SkipHolidays,
+		UpdatedAt:    model.UpdatedAt,
+	}, nil
+}
+
+func (r *Repository) SaveSettings(settings *domainLocale.Settings) (*domainLocale.Settings, error) {
+	model := Settings{ID: settings.ID, Timezone: settings.Timezone, Region: settings.Region, SkipHolidays: settings.SkipHolidays}
+	if err := r.DB.Save(&model).Error; err != nil {
+		r.Logger.Error("Error saving locale settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetSettings()
+}
+
+func (r *Repository) GetHolidays(from, to string) (*[]domainLocale.Holiday, error) {
+	var models []Holiday
+	if err := r.DB.Where("holiday_date >= ? AND holiday_date <= ?", from, to).Order("holiday_date ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting custom holidays", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainLocale.Holiday, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) CreateHoliday(holiday *domainLocale.Holiday) (*domainLocale.Holiday, error) {
+	model := &Holiday{Date: holiday.Date, Name: holiday.Name}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating custom holiday", zap.Error(err), zap.String("date", holiday.Date))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) DeleteHoliday(id uuid.UUID) error {
+	result := r.DB.Where("id = ?", id).Delete(&Holiday{})
+	if result.Error != nil {
+		r.Logger.Error("Error deleting custom holiday", zap.Error(result.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (m *Holiday) toDomainMapper() *domainLocale.Holiday {
+	return &domainLocale.Holiday{ID: m.ID, Date: m.Date, Name: m.Name, Custom: true, CreatedAt: m.CreatedAt}
+}
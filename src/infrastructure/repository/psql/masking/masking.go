@@ -0,0 +1,249 @@
+package masking
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Run records that a database was masked, so a restart does not scramble
+// it again. A freshly imported snapshot has no runs and is masked on start.
+type Run struct {
+	ID       uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Rows     int64     `gorm:"column:rows"`
+	MaskedAt time.Time `gorm:"column:masked_at"`
+}
+
+func (Run) TableName() string {
+	return "data_masking_runs"
+}
+
+// Enabled reports whether personal data should be masked on start: MASK_DATA
+// must be "true" and GO_ENV "staging", so production data is never touched.
+func Enabled() bool {
+	return strings.EqualFold(os.Getenv("MASK_DATA"), "true") && os.Getenv("GO_ENV") == "staging"
+}
+
+type kind int
+
+const (
+	firstName kind = iota
+	lastName
+	fullName
+	userName
+	email
+	phone
+	houseNumber
+	street
+	latitude
+	longitude
+	emailList
+)
+
+// Masked coordinates are placed at random within about 25 km of a fixed
+// point rather than near the real ones, so distances between people stay
+// usable for testing without locating anyone's home.
+const (
+	maskedLatitude  = 39.8283
+	maskedLongitude = -98.5795
+	maskedSpread    = 0.5
+)
+
+type column struct {
+	Name string
+	Kind kind
+}
+
+// table lists the personal columns of one table. Key identifies whose data a
+// row holds: rows with the same key get the same masked values, so a person
+// keeps one name and one home across tables. From joins the table the key is
+// on when it is not on the row itself.
+type table struct {
+	Name    string
+	Key     string
+	From    string
+	Where   string
+	Columns []column
+}
+
+var personName = []column{{"first_name", firstName}, {"last_name", lastName}}
+
+func homeAddress(prefix string) []column {
+	return []column{
+		{prefix + "house_number", houseNumber},
+		{prefix + "street", street},
+		{prefix + "lat", latitude},
+		{prefix + "long", longitude},
+	}
+}
+
+var visitLocation = []column{
+	{"checkin_location_lat", latitude},
+	{"checkin_location_long", longitude},
+	{"checkout_location_lat", latitude},
+	{"checkout_location_long", longitude},
+}
+
+// tables is every table holding names, contact details or coordinates of
+// people. Check-in coordinates are keyed by the client, so they land on the
+// client's masked home and still fall inside its geofence, as do those of
+// alerts raised during a visit.
+var tables = []table{
+	{Name: "users", Key: "users.id", Columns: append(append([]column{
+		{"user_name", userName}, {"email", email}, {"phone", phone},
+	}, personName...), homeAddress("location_")...)},
+	{Name: "user_versions", Key: "user_versions.user_id", Columns: append(append([]column{}, personName...), homeAddress("location_")...)},
+	{Name: "user_addresses", Key: "user_addresses.user_id", Columns: homeAddress("")},
+	{Name: "profile_changes", Key: "profile_changes.user_id", Columns: homeAddress("location_")},
+	{Name: "prospects", Key: "prospects.id", Columns: append(append([]column{
+		{"email", email}, {"phone", phone},
+	}, personName...), homeAddress("location_")...)},
+	{Name: "referral_sources", Key: "referral_sources.id", Columns: []column{
+		{"contact_name", fullName}, {"phone", phone}, {"email", email},
+	}},
+	{Name: "claim_provider_settings", Key: "claim_provider_settings.id", Columns: []column{
+		{"contact_name", fullName}, {"contact_phone", phone},
+	}},
+	{Name: "document_signatures", Key: "document_signatures.signer_user_id", Columns: []column{
+		{"signer_name", fullName},
+	}},
+	{Name: "client_consents", Key: "client_consents.id", Columns: []column{
+		{"recipient_name", fullName}, {"recipient_contact", email},
+	}},
+	{Name: "schedules", Key: "schedules.client_user_id", Columns: visitLocation},
+	{
+		Name: "schedule_segments", Key: "s.client_user_id",
+		From: "schedules AS s", Where: "s.id = schedule_segments.schedule_id",
+		Columns: visitLocation,
+	},
+	{Name: "alerts", Key: "COALESCE(alerts.client_user_id, alerts.caregiver_user_id, alerts.id)", Columns: []column{
+		{"location_lat", latitude}, {"location_long", longitude},
+	}},
+	{Name: "caregiver_service_areas", Key: "caregiver_service_areas.user_id", Columns: []column{
+		{"center_lat", latitude}, {"center_long", longitude},
+	}},
+	{Name: "kiosks", Key: "kiosks.id", Columns: []column{
+		{"address_house_number", houseNumber}, {"address_street", street},
+	}},
+	{Name: "invoices", Key: "invoices.client_user_id", Columns: []column{
+		{"client_name", fullName},
+	}},
+	{Name: "saved_reports", Key: "saved_reports.id", Columns: []column{
+		{"recipients", emailList},
+	}},
+	{Name: "saved_report_runs", Key: "saved_report_runs.report_id", Columns: []column{
+		{"recipients", emailList},
+	}},
+}
+
+// expression returns the SQL computing the masked value of a column from
+// digest, a hex string derived from the row's key and the run's salt. Empty
+// values stay empty.
+func expression(tableName string, c column, digest string) string {
+	ref := tableName + "." + c.Name
+	// hexInt reads n hex digits of the digest starting at from as a number.
+	hexInt := func(from, n int) string {
+		return fmt.Sprintf("('x' || substr(%s, %d, %d))::bit(%d)::bigint", digest, from, n, n*4)
+	}
+	// coordinate is a point within maskedSpread degrees around center.
+	coordinate := func(center float64, from int) string {
+		return fmt.Sprintf("%g + (%s / 65535.0 - 0.5) * %g", center, hexInt(from, 4), maskedSpread)
+	}
+	var masked string
+	switch c.Kind {
+	case firstName:
+		masked = fmt.Sprintf("'First-' || substr(%s, 1, 6)", digest)
+	case lastName:
+		masked = fmt.Sprintf("'Last-' || substr(%s, 7, 6)", digest)
+	case fullName:
+		masked = fmt.Sprintf("'First-' || substr(%s, 1, 6) || ' Last-' || substr(%s, 7, 6)", digest, digest)
+	case userName:
+		masked = fmt.Sprintf("'user-' || substr(%s, 1, 12)", digest)
+	case email:
+		masked = fmt.Sprintf("'user-' || substr(%s, 1, 12) || '@example.invalid'", digest)
+	case phone:
+		masked = fmt.Sprintf("'555' || lpad((%s %% 10000000)::text, 7, '0')", hexInt(13, 8))
+	case houseNumber:
+		masked = fmt.Sprintf("(%s %% 9999 + 1)::text", hexInt(21, 4))
+	case street:
+		masked = fmt.Sprintf("'Street ' || substr(%s, 25, 4)", digest)
+	case latitude:
+		return fmt.Sprintf("CASE WHEN %s IS NULL OR %s = 0 THEN %s ELSE %s END", ref, ref, ref, coordinate(maskedLatitude, 29))
+	case longitude:
+		return fmt.Sprintf("CASE WHEN %s IS NULL OR %s = 0 THEN %s ELSE %s END", ref, ref, ref, coordinate(maskedLongitude, 1))
+	case emailList:
+		// Each address of the JSON array is replaced in place, so a list
+		// keeps its length and order.
+		masked = fmt.Sprintf("(SELECT COALESCE(json_agg('user-' || substr(md5(r.value || %s), 1, 12) || '@example.invalid' ORDER BY r.n), '[]')::text "+
+			"FROM json_array_elements_text(%s::json) WITH ORDINALITY AS r(value, n))", digest, ref)
+		return fmt.Sprintf("CASE WHEN %s IS NULL OR %s = '' OR %s = 'null' THEN %s ELSE %s END", ref, ref, ref, ref, masked)
+	}
+	return fmt.Sprintf("CASE WHEN %s IS NULL OR %s = '' THEN %s ELSE %s END", ref, ref, ref, masked)
+}
+
+// statement builds the UPDATE masking every personal column of t.
+func statement(t table) string {
+	digest := fmt.Sprintf("md5(%s::text || @salt)", t.Key)
+	sets := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		sets[i] = c.Name + " = " + expression(t.Name, c, digest)
+	}
+	query := "UPDATE " + t.Name + " SET " + strings.Join(sets, ", ")
+	if t.From != "" {
+		query += " FROM " + t.From
+	}
+	if t.Where != "" {
+		query += " WHERE " + t.Where
+	}
+	return query
+}
+
+// Mask scrambles the names, contact details, street addresses and
+// coordinates of everyone in the database, unless it has been masked before.
+// Masked values are derived from a random salt that is never stored, so the
+// originals cannot be recovered. It returns how many rows were changed.
+func Mask(db *gorm.DB, loggerInstance *logger.Logger) (int64, error) {
+	var runs int64
+	if err := db.Model(&Run{}).Count(&runs).Error; err != nil {
+		return 0, err
+	}
+	if runs > 0 {
+		loggerInstance.Info("Data already masked, skipping")
+		return 0, nil
+	}
+	saltBytes := make([]byte, 32)
+	if _, err := rand.Read(saltBytes); err != nil {
+		return 0, err
+	}
+	salt := sql.Named("salt", hex.EncodeToString(saltBytes))
+
+	var total int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, t := range tables {
+			if !tx.Migrator().HasTable(t.Name) {
+				continue
+			}
+			result := tx.Exec(statement(t), salt)
+			if result.Error != nil {
+				return fmt.Errorf("masking %s: %w", t.Name, result.Error)
+			}
+			loggerInstance.Info("Masked table", zap.String("table", t.Name), zap.Int64("rows", result.RowsAffected))
+			total += result.RowsAffected
+		}
+		return tx.Create(&Run{Rows: total, MaskedAt: time.Now().UTC()}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,75 @@
+package masking
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStatement(t *testing.T) {
+	query := statement(table{
+		Name: "schedule_segments", Key: "s.client_user_id",
+		From: "schedules AS s", Where: "s.id = schedule_segments.schedule_id",
+		Columns: []column{{"checkin_location_lat", latitude}},
+	})
+	for _, want := range []string{
+		"UPDATE schedule_segments SET checkin_location_lat = CASE WHEN schedule_segments.checkin_location_lat IS NULL",
+		"md5(s.client_user_id::text || @salt)",
+		" FROM schedules AS s WHERE s.id = schedule_segments.schedule_id",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("expected %q in %s", want, query)
+		}
+	}
+}
+
+func TestCoordinatesAreReplaced(t *testing.T) {
+	masked := expression("users", column{"location_lat", latitude}, "d")
+	elseBranch := masked[strings.Index(masked, " ELSE "):]
+	if strings.Contains(elseBranch, "users.location_lat") {
+		t.Errorf("masked latitude should not be derived from the real one: %s", masked)
+	}
+}
+
+func TestPersonalTablesCovered(t *testing.T) {
+	covered := map[string]bool{}
+	for _, tbl := range tables {
+		for _, c := range tbl.Columns {
+			covered[tbl.Name+"."+c.Name] = true
+		}
+	}
+	for _, want := range []string{
+		"profile_changes.location_street",
+		"profile_changes.location_lat",
+		"saved_reports.recipients",
+		"saved_report_runs.recipients",
+	} {
+		if !covered[want] {
+			t.Errorf("expected %s to be masked", want)
+		}
+	}
+}
+
+func TestEmailListStatement(t *testing.T) {
+	query := statement(table{Name: "saved_reports", Key: "saved_reports.id", Columns: []column{{"recipients", emailList}}})
+	for _, want := range []string{
+		"json_array_elements_text(saved_reports.recipients::json) WITH ORDINALITY",
+		"ORDER BY r.n",
+		"'@example.invalid'",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("expected %q in %s", want, query)
+		}
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("MASK_DATA", "true")
+	t.Setenv("GO_ENV", "production")
+	if Enabled() {
+		t.Error("expected masking never to run in production")
+	}
+	t.Setenv("GO_ENV", "staging")
+	if !Enabled() {
+		t.Error("expected masking to run in staging")
+	}
+}
@@ -0,0 +1,266 @@
+package medication
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainMedication "caregiver/src/domain/medication"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Allergy struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID uuid.UUID `gorm:"column:client_user_id;type:uuid;index"`
+	Substance    string    `gorm:"column:substance"`
+	Reaction     string    `gorm:"column:reaction"`
+	Severity     string    `gorm:"column:severity"`
+	CreatedAt    time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Allergy) TableName() string {
+	return "client_allergies"
+}
+
+type Medication struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID uuid.UUID `gorm:"column:client_user_id;type:uuid;index"`
+	Name         string    `gorm:"column:name"`
+	Dose         string    `gorm:"column:dose"`
+	Route        string    `gorm:"column:route"`
+	Frequency    string    `gorm:"column:frequency"`
+	Active       bool      `gorm:"column:active"`
+	CreatedAt    time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Medication) TableName() string {
+	return "client_medications"
+}
+
+type Administration struct {
+	ID              uuid.UUID                  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID      uuid.UUID                  `gorm:"column:schedule_id;type:uuid;index"`
+	ClientUserID    uuid.UUID                  `gorm:"column:client_user_id;type:uuid;index"`
+	CaregiverUserID uuid.UUID                  `gorm:"column:caregiver_user_id;type:uuid"`
+	MedicationID    *uuid.UUID                 `gorm:"column:medication_id;type:uuid"`
+	DrugName        string                     `gorm:"column:drug_name"`
+	Dose            string                     `gorm:"column:dose"`
+	Route           string                     `gorm:"column:route"`
+	AdministeredAt  time.Time                  `gorm:"column:administered_at"`
+	Note            string                     `gorm:"column:note"`
+	Warnings        []domainMedication.Warning `gorm:"column:warnings;serializer:json"`
+	AcknowledgedAt  *time.Time                 `gorm:"column:acknowledged_at"`
+	CreatedAt       time.Time                  `gorm:"autoCreateTime:milli"`
+}
+
+func (Administration) TableName() string {
+	return "medication_administrations"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewMedicationRepository(db *gorm.DB, loggerInstance *logger.Logger) domainMedication.IMedicationRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateAllergy(newAllergy *domainMedication.Allergy) (*domainMedication.Allergy, error) {
+	allergyModel := &Allergy{
+		ID:           newAllergy.ID,
+		ClientUserID: newAllergy.ClientUserID,
+		Substance:    newAllergy.Substance,
+		Reaction:     newAllergy.Reaction,
+		Severity:     newAllergy.Severity,
+	}
+	if err := r.DB.Create(allergyModel).Error; err != nil {
+		r.Logger.Error("Error creating client allergy", zap.Error(err), zap.String("clientUserID", newAllergy.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Client allergy created successfully", zap.String("allergyID", allergyModel.ID.String()))
+	return allergyModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAllergies(clientUserID uuid.UUID) (*[]domainMedication.Allergy, error) {
+	var allergies []Allergy
+	if err := r.DB.Where("client_user_id = ?", clientUserID).Order("substance ASC").Find(&allergies).Error; err != nil {
+		r.Logger.Error("Error getting client allergies", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainMedication.Allergy, len(allergies))
+	for i := range allergies {
+		res[i] = *allergies[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) DeleteAllergy(id uuid.UUID) error {
+	tx := r.DB.Delete(&Allergy{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting client allergy", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Client allergy not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) CreateMedication(newMedication *domainMedication.Medication) (*domainMedication.Medication, error) {
+	medicationModel := &Medication{
+		ID:           newMedication.ID,
+		ClientUserID: newMedication.ClientUserID,
+		Name:         newMedication.Name,
+		Dose:         newMedication.Dose,
+		Route:        newMedication.Route,
+		Frequency:    newMedication.Frequency,
+		Active:       newMedication.Active,
+	}
+	if err := r.DB.Create(medicationModel).Error; err != nil {
+		r.Logger.Error("Error creating client medication", zap.Error(err), zap.String("clientUserID", newMedication.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Client medication created successfully", zap.String("medicationID", medicationModel.ID.String()))
+	return medicationModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetMedicationByID(id uuid.UUID) (*domainMedication.Medication, error) {
+	var medicationModel Medication
+	err := r.DB.Where("id = ?", id).First(&medicationModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Client medication not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting client medication by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return medicationModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetMedications(clientUserID uuid.UUID) (*[]domainMedication.Medication, error) {
+	var medications []Medication
+	if err := r.DB.Where("client_user_id = ?", clientUserID).Order("name ASC").Find(&medications).Error; err != nil {
+		r.Logger.Error("Error getting client medications", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainMedication.Medication, len(medications))
+	for i := range medications {
+		res[i] = *medications[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateMedication(id uuid.UUID, updates map[string]interface{}) (*domainMedication.Medication, error) {
+	medicationModel := Medication{ID: id}
+	if err := r.DB.Model(&medicationModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating client medication", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetMedicationByID(id)
+}
+
+func (r *Repository) DeleteMedication(id uuid.UUID) error {
+	tx := r.DB.Delete(&Medication{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting client medication", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Client medication not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) CreateAdministration(newAdministration *domainMedication.Administration) (*domainMedication.Administration, error) {
+	administrationModel := &Administration{
+		ID:              newAdministration.ID,
+		ScheduleID:      newAdministration.ScheduleID,
+		ClientUserID:    newAdministration.ClientUserID,
+		CaregiverUserID: newAdministration.CaregiverUserID,
+		MedicationID:    newAdministration.MedicationID,
+		DrugName:        newAdministration.DrugName,
+		Dose:            newAdministration.Dose,
+		Route:           newAdministration.Route,
+		AdministeredAt:  newAdministration.AdministeredAt,
+		Note:            newAdministration.Note,
+		Warnings:        newAdministration.Warnings,
+		AcknowledgedAt:  newAdministration.AcknowledgedAt,
+	}
+	if err := r.DB.Create(administrationModel).Error; err != nil {
+		r.Logger.Error("Error creating medication administration", zap.Error(err), zap.String("scheduleID", newAdministration.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Medication administration created successfully", zap.String("administrationID", administrationModel.ID.String()))
+	return administrationModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAdministrations(filter domainMedication.AdministrationFilter) (*[]domainMedication.Administration, error) {
+	query := r.DB.Model(&Administration{})
+	if filter.ScheduleID != nil {
+		query = query.Where("schedule_id = ?", *filter.ScheduleID)
+	}
+	if filter.ClientUserID != nil {
+		query = query.Where("client_user_id = ?", *filter.ClientUserID)
+	}
+	var administrations []Administration
+	if err := query.Order("administered_at DESC").Find(&administrations).Error; err != nil {
+		r.Logger.Error("Error getting medication administrations", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainMedication.Administration, len(administrations))
+	for i := range administrations {
+		res[i] = *administrations[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (a *Allergy) toDomainMapper() *domainMedication.Allergy {
+	return &domainMedication.Allergy{
+		ID:           a.ID,
+		ClientUserID: a.ClientUserID,
+		Substance:    a.Substance,
+		Reaction:     a.Reaction,
+		Severity:     a.Severity,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+func (m *Medication) toDomainMapper() *domainMedication.Medication {
+	return &domainMedication.Medication{
+		ID:           m.ID,
+		ClientUserID: m.ClientUserID,
+		Name:         m.Name,
+		Dose:         m.Dose,
+		Route:        m.Route,
+		Frequency:    m.Frequency,
+		Active:       m.Active,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func (a *Administration) toDomainMapper() *domainMedication.Administration {
+	return &domainMedication.Administration{
+		ID:              a.ID,
+		ScheduleID:      a.ScheduleID,
+		ClientUserID:    a.ClientUserID,
+		CaregiverUserID: a.CaregiverUserID,
+		MedicationID:    a.MedicationID,
+		DrugName:        a.DrugName,
+		Dose:            a.Dose,
+		Route:           a.Route,
+		AdministeredAt:  a.AdministeredAt,
+		Note:            a.Note,
+		Warnings:        a.Warnings,
+		AcknowledgedAt:  a.AcknowledgedAt,
+		CreatedAt:       a.CreatedAt,
+	}
+}
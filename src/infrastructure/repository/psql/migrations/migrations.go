@@ -0,0 +1,154 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	logger "caregiver/src/infrastructure/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// lockKey is the advisory lock every instance takes before touching the
+// schema, so replicas starting together migrate one at a time.
+const lockKey int64 = 0x63617265676976 // "caregiv"
+
+// Migration is one versioned change to the database, applied once. Pre runs
+// before SQL and Post after it, in the same transaction, e.g. to backfill a
+// column SQL has just added; either may be nil.
+type Migration struct {
+	// Version orders migrations; a timestamp prefix such as "20261016_01"
+	// keeps them sorted by when they were written.
+	Version     string
+	Description string
+	SQL         string
+	Pre         func(tx *gorm.DB) error
+	Post        func(tx *gorm.DB) error
+}
+
+// Checksum fingerprints the migration's SQL. A migration whose SQL changed
+// after it was applied stops startup rather than silently diverging.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.SQL))
+	return hex.EncodeToString(sum[:])
+}
+
+// Applied records a migration that has run.
+type Applied struct {
+	Version     string    `gorm:"primaryKey;column:version"`
+	Description string    `gorm:"column:description"`
+	Checksum    string    `gorm:"column:checksum"`
+	AppliedAt   time.Time `gorm:"column:applied_at"`
+	DurationMs  int64     `gorm:"column:duration_ms"`
+}
+
+func (Applied) TableName() string {
+	return "schema_migrations"
+}
+
+// WithLock runs fn holding the migration lock on a single connection, which
+// fn must use for all its work. Other instances wait until fn returns.
+func WithLock(db *gorm.DB, loggerInstance *logger.Logger, fn func(conn *gorm.DB) error) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		var acquired bool
+		if err := conn.Raw("SELECT pg_try_advisory_lock(?)", lockKey).Scan(&acquired).Error; err != nil {
+			return fmt.Errorf("taking the migration lock: %w", err)
+		}
+		if !acquired {
+			loggerInstance.Info("Another instance is migrating the database, waiting")
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+				return fmt.Errorf("taking the migration lock: %w", err)
+			}
+		}
+		defer func() {
+			if err := conn.Exec("SELECT pg_advisory_unlock(?)", lockKey).Error; err != nil {
+				loggerInstance.Error("Error releasing the migration lock", zap.Error(err))
+			}
+		}()
+		return fn(conn)
+	})
+}
+
+// Apply runs, in version order, every migration not yet recorded as applied,
+// each in its own transaction. Applied versions this build does not know,
+// written by a newer release during a blue/green rollout, are left alone.
+func Apply(conn *gorm.DB, loggerInstance *logger.Logger, migrations []Migration) error {
+	if err := conn.AutoMigrate(&Applied{}); err != nil {
+		return err
+	}
+	var applied []Applied
+	if err := conn.Find(&applied).Error; err != nil {
+		return err
+	}
+	todo, err := pending(applied, migrations)
+	if err != nil {
+		return err
+	}
+	for _, m := range todo {
+		start := time.Now()
+		err := conn.Transaction(func(tx *gorm.DB) error {
+			if m.Pre != nil {
+				if err := m.Pre(tx); err != nil {
+					return fmt.Errorf("pre hook: %w", err)
+				}
+			}
+			if m.SQL != "" {
+				if err := tx.Exec(m.SQL).Error; err != nil {
+					return err
+				}
+			}
+			if m.Post != nil {
+				if err := m.Post(tx); err != nil {
+					return fmt.Errorf("post hook: %w", err)
+				}
+			}
+			return tx.Create(&Applied{
+				Version:     m.Version,
+				Description: m.Description,
+				Checksum:    m.Checksum(),
+				AppliedAt:   time.Now().UTC(),
+				DurationMs:  time.Since(start).Milliseconds(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", m.Version, err)
+		}
+		loggerInstance.Info("Migration applied", zap.String("version", m.Version), zap.String("description", m.Description), zap.Duration("duration", time.Since(start)))
+	}
+	return nil
+}
+
+// pending returns the migrations still to apply, in version order. It fails
+// on duplicate versions and on applied migrations whose SQL has changed.
+func pending(applied []Applied, migrations []Migration) ([]Migration, error) {
+	checksums := make(map[string]string, len(applied))
+	for _, a := range applied {
+		checksums[a.Version] = a.Checksum
+	}
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	var todo []Migration
+	for i, m := range sorted {
+		if m.Version == "" {
+			return nil, fmt.Errorf("migration %q has no version", m.Description)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("migration version %s is used twice", m.Version)
+		}
+		checksum, done := checksums[m.Version]
+		if !done {
+			todo = append(todo, m)
+			continue
+		}
+		if checksum != m.Checksum() {
+			return nil, fmt.Errorf("migration %s was changed after it was applied; add a new migration instead", m.Version)
+		}
+	}
+	return todo, nil
+}
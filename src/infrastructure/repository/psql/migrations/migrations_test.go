@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"testing"
+)
+
+func TestPending(t *testing.T) {
+	first := Migration{Version: "20260101_01", SQL: "CREATE TABLE a (id int)"}
+	second := Migration{Version: "20260102_01", SQL: "CREATE TABLE b (id int)"}
+	third := Migration{Version: "20260103_01", SQL: "CREATE TABLE c (id int)"}
+	applied := []Applied{
+		{Version: first.Version, Checksum: first.Checksum()},
+		// Written by a newer release still rolling out.
+		{Version: "20270101_01", Checksum: "unknown"},
+	}
+
+	todo, err := pending(applied, []Migration{third, first, second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(todo) != 2 || todo[0].Version != second.Version || todo[1].Version != third.Version {
+		t.Errorf("expected the second and third migrations in order, got %+v", todo)
+	}
+
+	edited := first
+	edited.SQL = "CREATE TABLE a (id bigint)"
+	if _, err := pending(applied, []Migration{edited}); err == nil {
+		t.Error("expected an error for a migration changed after it was applied")
+	}
+	if _, err := pending(nil, []Migration{second, second}); err == nil {
+		t.Error("expected an error for a duplicate version")
+	}
+}
+
+func TestAllIsValid(t *testing.T) {
+	if _, err := pending(nil, All); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
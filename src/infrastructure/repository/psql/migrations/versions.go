@@ -0,0 +1,11 @@
+package migrations
+
+// All is every versioned migration, applied after the models are
+// auto-migrated. Add new ones at the end; never edit one that has shipped.
+var All = []Migration{
+	{
+		Version:     "20261016_01",
+		Description: "index visits by status and slot end for the missed and stuck visit sweeps",
+		SQL:         "CREATE INDEX IF NOT EXISTS idx_schedules_status_slot_to ON schedules (visit_status, scheduled_slot_to)",
+	},
+}
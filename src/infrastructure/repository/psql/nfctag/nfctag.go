@@ -0,0 +1,141 @@
+package nfctag
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainNFCTag "caregiver/src/domain/nfctag"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Tag struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID uuid.UUID `gorm:"column:client_user_id;type:uuid;uniqueIndex:idx_nfc_tag_client_value"`
+	TagValue     string    `gorm:"column:tag_value;uniqueIndex:idx_nfc_tag_client_value"`
+	Label        string    `gorm:"column:label"`
+	Active       bool      `gorm:"column:active"`
+	CreatedAt    time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Tag) TableName() string {
+	return "client_nfc_tags"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewNFCTagRepository(db *gorm.DB, loggerInstance *logger.Logger) domainNFCTag.INFCTagRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newTag *domainNFCTag.Tag) (*domainNFCTag.Tag, error) {
+	model := fromDomainMapper(newTag)
+	tx := r.DB.Where("client_user_id = ? AND tag_value = ?", newTag.ClientUserID, newTag.TagValue).Limit(1).Find(&Tag{})
+	if tx.Error != nil {
+		r.Logger.Error("Error checking NFC tag uniqueness", zap.Error(tx.Error), zap.String("clientUserID", newTag.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected > 0 {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.ResourceAlreadyExists)
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating NFC tag", zap.Error(err), zap.String("clientUserID", newTag.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("NFC tag registered successfully", zap.String("tagID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainNFCTag.Tag, error) {
+	var model Tag
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("NFC tag not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting NFC tag by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByClientUserID(clientUserID uuid.UUID) (*[]domainNFCTag.Tag, error) {
+	var models []Tag
+	if err := r.DB.Where("client_user_id = ?", clientUserID).Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting NFC tags for client", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	tags := make([]domainNFCTag.Tag, len(models))
+	for i := range models {
+		tags[i] = *models[i].toDomainMapper()
+	}
+	return &tags, nil
+}
+
+func (r *Repository) GetActiveByValue(clientUserID uuid.UUID, tagValue string) (*domainNFCTag.Tag, error) {
+	var model Tag
+	err := r.DB.Where("client_user_id = ? AND tag_value = ? AND active = ?", clientUserID, tagValue, true).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error looking up NFC tag", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainNFCTag.Tag, error) {
+	var model Tag
+	model.ID = id
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating NFC tag", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	tx := r.DB.Delete(&Tag{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting NFC tag", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("NFC tag not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (t *Tag) toDomainMapper() *domainNFCTag.Tag {
+	return &domainNFCTag.Tag{
+		ID:           t.ID,
+		ClientUserID: t.ClientUserID,
+		TagValue:     t.TagValue,
+		Label:        t.Label,
+		Active:       t.Active,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(t *domainNFCTag.Tag) *Tag {
+	return &Tag{
+		ID:           t.ID,
+		ClientUserID: t.ClientUserID,
+		TagValue:     t.TagValue,
+		Label:        t.Label,
+		Active:       t.Active,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
@@ -0,0 +1,176 @@
+package notification
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainNotification "caregiver/src/domain/notification"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Device struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID     uuid.UUID `gorm:"column:user_id;type:uuid;index"`
+	Platform   string    `gorm:"column:platform"`
+	Token      string    `gorm:"column:token;uniqueIndex"`
+	CreatedAt  time.Time `gorm:"autoCreateTime:milli"`
+	LastSeenAt time.Time `gorm:"column:last_seen_at"`
+}
+
+func (Device) TableName() string {
+	return "push_devices"
+}
+
+// Preference is a user's preferred notification channels.
+type Preference struct {
+	UserID    uuid.UUID `gorm:"primaryKey;column:user_id;type:uuid"`
+	Channels  []string  `gorm:"column:channels;serializer:json"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Preference) TableName() string {
+	return "notification_preferences"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewDeviceRepository(db *gorm.DB, loggerInstance *logger.Logger) domainNotification.IDeviceRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func NewPreferenceRepository(db *gorm.DB, loggerInstance *logger.Logger) domainNotification.IPreferenceRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) GetByUser(userID uuid.UUID) (*[]domainNotification.Device, error) {
+	var models []Device
+	if err := r.DB.Where("user_id = ?", userID).Order("last_seen_at DESC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting push devices", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainNotification.Device, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetTokens(userIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	tokens := make(map[uuid.UUID][]string)
+	if len(userIDs) == 0 {
+		return tokens, nil
+	}
+	var models []Device
+	if err := r.DB.Select("user_id", "token").Where("user_id IN ?", userIDs).Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting push tokens", zap.Error(err), zap.Int("users", len(userIDs)))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	for _, model := range models {
+		tokens[model.UserID] = append(tokens[model.UserID], model.Token)
+	}
+	return tokens, nil
+}
+
+func (r *Repository) Register(device *domainNotification.Device) (*domainNotification.Device, error) {
+	model := &Device{
+		UserID:     device.UserID,
+		Platform:   device.Platform,
+		Token:      device.Token,
+		LastSeenAt: device.LastSeenAt,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "last_seen_at"}),
+	}).Create(model).Error
+	if err != nil {
+		r.Logger.Error("Error registering push device", zap.Error(err), zap.String("userID", device.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	var saved Device
+	if err := r.DB.Where("token = ?", device.Token).First(&saved).Error; err != nil {
+		r.Logger.Error("Error getting registered push device", zap.Error(err), zap.String("userID", device.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return saved.toDomainMapper(), nil
+}
+
+func (r *Repository) Delete(userID uuid.UUID, id uuid.UUID) error {
+	tx := r.DB.Where("id = ? AND user_id = ?", id, userID).Delete(&Device{})
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting push device", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) GetPreference(userID uuid.UUID) (*domainNotification.ChannelPreference, error) {
+	var model Preference
+	if err := r.DB.Where("user_id = ?", userID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting notification preference", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) SavePreference(preference *domainNotification.ChannelPreference) (*domainNotification.ChannelPreference, error) {
+	model := &Preference{UserID: preference.UserID, Channels: preference.Channels}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"channels", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		r.Logger.Error("Error saving notification preference", zap.Error(err), zap.String("userID", preference.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetPreference(preference.UserID)
+}
+
+func (r *Repository) GetChannels(userIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	channels := make(map[uuid.UUID][]string)
+	if len(userIDs) == 0 {
+		return channels, nil
+	}
+	var models []Preference
+	if err := r.DB.Where("user_id IN ?", userIDs).Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting notification preferences", zap.Error(err), zap.Int("users", len(userIDs)))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	for _, model := range models {
+		channels[model.UserID] = model.Channels
+	}
+	return channels, nil
+}
+
+func (m *Preference) toDomainMapper() *domainNotification.ChannelPreference {
+	return &domainNotification.ChannelPreference{
+		UserID:    m.UserID,
+		Channels:  m.Channels,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func (m *Device) toDomainMapper() *domainNotification.Device {
+	return &domainNotification.Device{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Platform:   m.Platform,
+		Token:      m.Token,
+		CreatedAt:  m.CreatedAt,
+		LastSeenAt: m.LastSeenAt,
+	}
+}
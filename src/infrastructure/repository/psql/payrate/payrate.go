@@ -0,0 +1,126 @@
+package payrate
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainPayRate "caregiver/src/domain/payrate"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PayRate struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CaregiverUserID uuid.UUID  `gorm:"column:caregiver_user_id;type:uuid;index"`
+	ServiceName     string     `gorm:"column:service_name"`
+	HourlyRate      float64    `gorm:"column:hourly_rate"`
+	EffectiveFrom   time.Time  `gorm:"column:effective_from;type:date"`
+	EffectiveTo     *time.Time `gorm:"column:effective_to;type:date"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (PayRate) TableName() string {
+	return "caregiver_pay_rates"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewPayRateRepository(db *gorm.DB, loggerInstance *logger.Logger) domainPayRate.IPayRateRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newRate *domainPayRate.PayRate) (*domainPayRate.PayRate, error) {
+	rateModel := fromDomainMapper(newRate)
+	if err := r.DB.Create(rateModel).Error; err != nil {
+		r.Logger.Error("Error creating pay rate", zap.Error(err), zap.String("caregiverUserID", newRate.CaregiverUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Pay rate created successfully", zap.String("payRateID", rateModel.ID.String()))
+	return rateModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainPayRate.PayRate, error) {
+	var rateModel PayRate
+	err := r.DB.Where("id = ?", id).First(&rateModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Pay rate not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting pay rate by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return rateModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll(caregiverUserID *uuid.UUID) (*[]domainPayRate.PayRate, error) {
+	query := r.DB.Model(&PayRate{})
+	if caregiverUserID != nil {
+		query = query.Where("caregiver_user_id = ?", *caregiverUserID)
+	}
+	var rates []PayRate
+	if err := query.Order("caregiver_user_id ASC, service_name ASC, effective_from ASC").Find(&rates).Error; err != nil {
+		r.Logger.Error("Error getting pay rates", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainPayRate.PayRate, len(rates))
+	for i := range rates {
+		res[i] = *rates[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainPayRate.PayRate, error) {
+	rateModel := PayRate{ID: id}
+	if err := r.DB.Model(&rateModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating pay rate", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	tx := r.DB.Delete(&PayRate{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting pay rate", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Pay rate not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (p *PayRate) toDomainMapper() *domainPayRate.PayRate {
+	return &domainPayRate.PayRate{
+		ID:              p.ID,
+		CaregiverUserID: p.CaregiverUserID,
+		ServiceName:     p.ServiceName,
+		HourlyRate:      p.HourlyRate,
+		EffectiveFrom:   p.EffectiveFrom,
+		EffectiveTo:     p.EffectiveTo,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(p *domainPayRate.PayRate) *PayRate {
+	return &PayRate{
+		ID:              p.ID,
+		CaregiverUserID: p.CaregiverUserID,
+		ServiceName:     p.ServiceName,
+		HourlyRate:      p.HourlyRate,
+		EffectiveFrom:   p.EffectiveFrom,
+		EffectiveTo:     p.EffectiveTo,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
@@ -0,0 +1,196 @@
+package payroll
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainPayroll "caregiver/src/domain/payroll"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Period struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	From         time.Time  `gorm:"column:period_from;index"`
+	To           time.Time  `gorm:"column:period_to;index"`
+	Status       string     `gorm:"column:status;index"`
+	ClosedBy     *uuid.UUID `gorm:"column:closed_by;type:uuid"`
+	ClosedAt     time.Time  `gorm:"column:closed_at"`
+	ReopenedBy   *uuid.UUID `gorm:"column:reopened_by;type:uuid"`
+	ReopenedAt   *time.Time `gorm:"column:reopened_at"`
+	ReopenReason string     `gorm:"column:reopen_reason"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Period) TableName() string {
+	return "payroll_periods"
+}
+
+type Unlock struct {
+	ID         uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID uuid.UUID  `gorm:"column:schedule_id;type:uuid;index"`
+	PeriodID   uuid.UUID  `gorm:"column:period_id;type:uuid;index"`
+	Reason     string     `gorm:"column:reason"`
+	UnlockedBy *uuid.UUID `gorm:"column:unlocked_by;type:uuid"`
+	UnlockedAt time.Time  `gorm:"column:unlocked_at"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at"`
+}
+
+func (Unlock) TableName() string {
+	return "payroll_unlocks"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewPayrollRepository(db *gorm.DB, loggerInstance *logger.Logger) domainPayroll.IPayrollRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreatePeriod(period *domainPayroll.Period) (*domainPayroll.Period, error) {
+	model := &Period{
+		From:     period.From,
+		To:       period.To,
+		Status:   period.Status,
+		ClosedBy: period.ClosedBy,
+		ClosedAt: period.ClosedAt,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating payroll period", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetPeriods() (*[]domainPayroll.Period, error) {
+	var models []Period
+	if err := r.DB.Order("period_from DESC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting payroll periods", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return periodsToDomain(models), nil
+}
+
+func (r *Repository) GetPeriodByID(id uuid.UUID) (*domainPayroll.Period, error) {
+	var model Period
+	if err := r.DB.Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting payroll period", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetClosedPeriods(from, to time.Time) (*[]domainPayroll.Period, error) {
+	var models []Period
+	err := r.DB.Where("status = ? AND period_from < ? AND period_to > ?", domainPayroll.StatusClosed, to, from).
+		Order("period_from ASC").Find(&models).Error
+	if err != nil {
+		r.Logger.Error("Error getting closed payroll periods", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return periodsToDomain(models), nil
+}
+
+func (r *Repository) UpdatePeriod(id uuid.UUID, updates map[string]interface{}) (*domainPayroll.Period, error) {
+	model := Period{ID: id}
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating payroll period", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetPeriodByID(id)
+}
+
+func (r *Repository) CreateUnlock(unlock *domainPayroll.Unlock) (*domainPayroll.Unlock, error) {
+	model := &Unlock{
+		ScheduleID: unlock.ScheduleID,
+		PeriodID:   unlock.PeriodID,
+		Reason:     unlock.Reason,
+		UnlockedBy: unlock.UnlockedBy,
+		UnlockedAt: unlock.UnlockedAt,
+		ExpiresAt:  unlock.ExpiresAt,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating payroll unlock", zap.Error(err), zap.String("scheduleID", unlock.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetUnlocks(scheduleID uuid.UUID) (*[]domainPayroll.Unlock, error) {
+	var models []Unlock
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Order("unlocked_at DESC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting payroll unlocks", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainPayroll.Unlock, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+// GetTaskSchedule reads the tables directly, so soft-deleted rows are found
+// too and a locked task cannot be restored.
+func (r *Repository) GetTaskSchedule(taskID uuid.UUID) (uuid.UUID, time.Time, error) {
+	var row struct {
+		ScheduleID        uuid.UUID
+		ScheduledSlotFrom time.Time
+	}
+	err := r.DB.Table("tasks").Select("tasks.schedule_id, schedules.scheduled_slot_from").
+		Joins("JOIN schedules ON schedules.id = tasks.schedule_id").
+		Where("tasks.id = ?", taskID).Limit(1).Scan(&row).Error
+	if err != nil {
+		r.Logger.Error("Error getting task schedule", zap.Error(err), zap.String("taskID", taskID.String()))
+		return uuid.Nil, time.Time{}, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if row.ScheduleID == uuid.Nil {
+		return uuid.Nil, time.Time{}, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return row.ScheduleID, row.ScheduledSlotFrom, nil
+}
+
+func (m *Period) toDomainMapper() *domainPayroll.Period {
+	return &domainPayroll.Period{
+		ID:           m.ID,
+		From:         m.From,
+		To:           m.To,
+		Status:       m.Status,
+		ClosedBy:     m.ClosedBy,
+		ClosedAt:     m.ClosedAt,
+		ReopenedBy:   m.ReopenedBy,
+		ReopenedAt:   m.ReopenedAt,
+		ReopenReason: m.ReopenReason,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func (m *Unlock) toDomainMapper() *domainPayroll.Unlock {
+	return &domainPayroll.Unlock{
+		ID:         m.ID,
+		ScheduleID: m.ScheduleID,
+		PeriodID:   m.PeriodID,
+		Reason:     m.Reason,
+		UnlockedBy: m.UnlockedBy,
+		UnlockedAt: m.UnlockedAt,
+		ExpiresAt:  m.ExpiresAt,
+	}
+}
+
+func periodsToDomain(models []Period) *[]domainPayroll.Period {
+	res := make([]domainPayroll.Period, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res
+}
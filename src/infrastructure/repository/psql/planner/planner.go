@@ -0,0 +1,344 @@
+package planner
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainPlanner "caregiver/src/domain/planner"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Template struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID    uuid.UUID `gorm:"column:client_user_id;type:uuid;uniqueIndex"`
+	ServiceName     string    `gorm:"column:service_name"`
+	DurationMinutes int       `gorm:"column:duration_minutes"`
+	StartMinute     int       `gorm:"column:start_minute"`
+	Weekdays        []int     `gorm:"column:weekdays;serializer:json"`
+	Timezone        string    `gorm:"column:timezone"`
+	CreatedAt       time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Template) TableName() string {
+	return "demand_templates"
+}
+
+type Availability struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CaregiverUserID uuid.UUID `gorm:"column:caregiver_user_id;type:uuid;index"`
+	Weekday         int       `gorm:"column:weekday"`
+	StartMinute     int       `gorm:"column:start_minute"`
+	EndMinute       int       `gorm:"column:end_minute"`
+	Timezone        string    `gorm:"column:timezone"`
+}
+
+func (Availability) TableName() string {
+	return "caregiver_availability"
+}
+
+type Proposal struct {
+	ID                uuid.UUID                `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	WeekStart         time.Time                `gorm:"column:week_start;index"`
+	WeekEnd           time.Time                `gorm:"column:week_end"`
+	Status            string                   `gorm:"column:status"`
+	Solver            string                   `gorm:"column:solver"`
+	Unplaced          []domainPlanner.Unplaced `gorm:"column:unplaced;serializer:json"`
+	Assignments       []Assignment             `gorm:"foreignKey:ProposalID"`
+	CreatedByUserID   *uuid.UUID               `gorm:"column:created_by_user_id;type:uuid"`
+	CreatedAt         time.Time                `gorm:"autoCreateTime:milli"`
+	CommittedByUserID *uuid.UUID               `gorm:"column:committed_by_user_id;type:uuid"`
+	CommittedAt       *time.Time               `gorm:"column:committed_at"`
+}
+
+func (Proposal) TableName() string {
+	return "schedule_proposals"
+}
+
+type Assignment struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ProposalID      uuid.UUID  `gorm:"column:proposal_id;type:uuid;index"`
+	ClientUserID    uuid.UUID  `gorm:"column:client_user_id;type:uuid"`
+	CaregiverUserID uuid.UUID  `gorm:"column:caregiver_user_id;type:uuid"`
+	ServiceName     string     `gorm:"column:service_name"`
+	From            time.Time  `gorm:"column:slot_from"`
+	To              time.Time  `gorm:"column:slot_to"`
+	DistanceKm      *float64   `gorm:"column:distance_km"`
+	Warnings        []string   `gorm:"column:warnings;serializer:json"`
+	Accepted        bool       `gorm:"column:accepted"`
+	ScheduleID      *uuid.UUID `gorm:"column:schedule_id;type:uuid"`
+	Error           string     `gorm:"column:error"`
+}
+
+func (Assignment) TableName() string {
+	return "schedule_proposal_assignments"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewPlannerRepository(db *gorm.DB, loggerInstance *logger.Logger) domainPlanner.IPlannerRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) GetTemplates() (*[]domainPlanner.Template, error) {
+	var models []Template
+	if err := r.DB.Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting demand templates", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainPlanner.Template, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetTemplate(clientUserID uuid.UUID) (*domainPlanner.Template, error) {
+	var model Template
+	if err := r.DB.Where("client_user_id = ?", clientUserID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting demand template", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) SaveTemplate(template *domainPlanner.Template) (*domainPlanner.Template, error) {
+	model := &Template{
+		ClientUserID:    template.ClientUserID,
+		ServiceName:     template.ServiceName,
+		DurationMinutes: template.DurationMinutes,
+		StartMinute:     template.StartMinute,
+		Weekdays:        template.Weekdays,
+		Timezone:        template.Timezone,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "client_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"service_name", "duration_minutes", "start_minute", "weekdays", "timezone", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		r.Logger.Error("Error saving demand template", zap.Error(err), zap.String("clientUserID", template.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetTemplate(template.ClientUserID)
+}
+
+func (r *Repository) DeleteTemplate(clientUserID uuid.UUID) error {
+	tx := r.DB.Where("client_user_id = ?", clientUserID).Delete(&Template{})
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting demand template", zap.Error(tx.Error), zap.String("clientUserID", clientUserID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) GetAvailability(caregiverUserIDs []uuid.UUID) (*[]domainPlanner.Availability, error) {
+	var models []Availability
+	query := r.DB.Order("caregiver_user_id ASC, weekday ASC, start_minute ASC")
+	if len(caregiverUserIDs) > 0 {
+		query = query.Where("caregiver_user_id IN ?", caregiverUserIDs)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting caregiver availability", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return availabilityToDomainMapper(models), nil
+}
+
+func (r *Repository) ReplaceAvailability(caregiverUserID uuid.UUID, windows []domainPlanner.Availability) (*[]domainPlanner.Availability, error) {
+	models := make([]Availability, len(windows))
+	for i, window := range windows {
+		models[i] = Availability{
+			CaregiverUserID: caregiverUserID,
+			Weekday:         window.Weekday,
+			StartMinute:     window.StartMinute,
+			EndMinute:       window.EndMinute,
+			Timezone:        window.Timezone,
+		}
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("caregiver_user_id = ?", caregiverUserID).Delete(&Availability{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		return tx.Create(&models).Error
+	})
+	if err != nil {
+		r.Logger.Error("Error replacing caregiver availability", zap.Error(err), zap.String("caregiverUserID", caregiverUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return availabilityToDomainMapper(models), nil
+}
+
+func (r *Repository) CreateProposal(proposal *domainPlanner.Proposal) (*domainPlanner.Proposal, error) {
+	model := &Proposal{
+		WeekStart:       proposal.WeekStart,
+		WeekEnd:         proposal.WeekEnd,
+		Status:          proposal.Status,
+		Solver:          proposal.Solver,
+		Unplaced:        proposal.Unplaced,
+		CreatedByUserID: proposal.CreatedByUserID,
+		Assignments:     make([]Assignment, len(proposal.Assignments)),
+	}
+	for i := range proposal.Assignments {
+		model.Assignments[i] = *assignmentFromDomainMapper(&proposal.Assignments[i])
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating schedule proposal", zap.Error(err), zap.Time("weekStart", proposal.WeekStart))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetProposal(id uuid.UUID) (*domainPlanner.Proposal, error) {
+	var model Proposal
+	err := r.DB.Preload("Assignments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("slot_from ASC")
+	}).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting schedule proposal", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+// GetProposals lists proposals newest first, without their assignments.
+func (r *Repository) GetProposals() (*[]domainPlanner.Proposal, error) {
+	var models []Proposal
+	if err := r.DB.Order("created_at DESC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting schedule proposals", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainPlanner.Proposal, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateProposal(proposal *domainPlanner.Proposal) (*domainPlanner.Proposal, error) {
+	err := r.DB.Model(&Proposal{ID: proposal.ID}).Updates(map[string]interface{}{
+		"status":               proposal.Status,
+		"committed_by_user_id": proposal.CommittedByUserID,
+		"committed_at":         proposal.CommittedAt,
+	}).Error
+	if err != nil {
+		r.Logger.Error("Error updating schedule proposal", zap.Error(err), zap.String("id", proposal.ID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetProposal(proposal.ID)
+}
+
+func (r *Repository) UpdateAssignment(assignment *domainPlanner.Assignment) (*domainPlanner.Assignment, error) {
+	model := assignmentFromDomainMapper(assignment)
+	// A struct update keeps the warnings serializer, unlike a map.
+	err := r.DB.Model(&Assignment{ID: assignment.ID}).
+		Select("caregiver_user_id", "distance_km", "warnings", "accepted", "schedule_id", "error").
+		Updates(model).Error
+	if err != nil {
+		r.Logger.Error("Error updating proposal assignment", zap.Error(err), zap.String("id", assignment.ID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func availabilityToDomainMapper(models []Availability) *[]domainPlanner.Availability {
+	res := make([]domainPlanner.Availability, len(models))
+	for i, m := range models {
+		res[i] = domainPlanner.Availability{
+			ID:              m.ID,
+			CaregiverUserID: m.CaregiverUserID,
+			Weekday:         m.Weekday,
+			StartMinute:     m.StartMinute,
+			EndMinute:       m.EndMinute,
+			Timezone:        m.Timezone,
+		}
+	}
+	return &res
+}
+
+func assignmentFromDomainMapper(a *domainPlanner.Assignment) *Assignment {
+	return &Assignment{
+		ID:              a.ID,
+		ProposalID:      a.ProposalID,
+		ClientUserID:    a.ClientUserID,
+		CaregiverUserID: a.CaregiverUserID,
+		ServiceName:     a.ServiceName,
+		From:            a.From,
+		To:              a.To,
+		DistanceKm:      a.DistanceKm,
+		Warnings:        a.Warnings,
+		Accepted:        a.Accepted,
+		ScheduleID:      a.ScheduleID,
+		Error:           a.Error,
+	}
+}
+
+func (m *Template) toDomainMapper() *domainPlanner.Template {
+	return &domainPlanner.Template{
+		ID:              m.ID,
+		ClientUserID:    m.ClientUserID,
+		ServiceName:     m.ServiceName,
+		DurationMinutes: m.DurationMinutes,
+		StartMinute:     m.StartMinute,
+		Weekdays:        m.Weekdays,
+		Timezone:        m.Timezone,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+func (m *Proposal) toDomainMapper() *domainPlanner.Proposal {
+	proposal := &domainPlanner.Proposal{
+		ID:                m.ID,
+		WeekStart:         m.WeekStart,
+		WeekEnd:           m.WeekEnd,
+		Status:            m.Status,
+		Solver:            m.Solver,
+		Unplaced:          m.Unplaced,
+		Assignments:       make([]domainPlanner.Assignment, len(m.Assignments)),
+		CreatedByUserID:   m.CreatedByUserID,
+		CreatedAt:         m.CreatedAt,
+		CommittedByUserID: m.CommittedByUserID,
+		CommittedAt:       m.CommittedAt,
+	}
+	for i := range m.Assignments {
+		proposal.Assignments[i] = *m.Assignments[i].toDomainMapper()
+	}
+	return proposal
+}
+
+func (m *Assignment) toDomainMapper() *domainPlanner.Assignment {
+	return &domainPlanner.Assignment{
+		ID:              m.ID,
+		ProposalID:      m.ProposalID,
+		ClientUserID:    m.ClientUserID,
+		CaregiverUserID: m.CaregiverUserID,
+		ServiceName:     m.ServiceName,
+		From:            m.From,
+		To:              m.To,
+		DistanceKm:      m.DistanceKm,
+		Warnings:        m.Warnings,
+		Accepted:        m.Accepted,
+		ScheduleID:      m.ScheduleID,
+		Error:           m.Error,
+	}
+}
@@ -0,0 +1,116 @@
+package profilechange
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainProfileChange "caregiver/src/domain/profilechange"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Change struct {
+	ID              uuid.UUID           `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID          uuid.UUID           `gorm:"column:user_id;type:uuid;index"`
+	Location        domainUser.Location `gorm:"embedded;embeddedPrefix:location_"`
+	Status          string              `gorm:"column:status;index"`
+	DecidedByUserID *uuid.UUID          `gorm:"column:decided_by_user_id;type:uuid"`
+	DecisionNote    string              `gorm:"column:decision_note"`
+	DecidedAt       *time.Time          `gorm:"column:decided_at"`
+	CreatedAt       time.Time           `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time           `gorm:"autoUpdateTime:milli"`
+}
+
+func (Change) TableName() string {
+	return "profile_changes"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewProfileChangeRepository(db *gorm.DB, loggerInstance *logger.Logger) domainProfileChange.IProfileChangeRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(change *domainProfileChange.Change) (*domainProfileChange.Change, error) {
+	model := fromDomainMapper(change)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating profile change", zap.Error(err), zap.String("userID", change.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainProfileChange.Change, error) {
+	var model Change
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting profile change", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll(filter domainProfileChange.Filter) (*[]domainProfileChange.Change, error) {
+	query := r.DB.Model(&Change{})
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	var models []Change
+	if err := query.Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting profile changes", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainProfileChange.Change, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainProfileChange.Change, error) {
+	model := Change{ID: id}
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating profile change", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func fromDomainMapper(c *domainProfileChange.Change) *Change {
+	return &Change{
+		ID:              c.ID,
+		UserID:          c.UserID,
+		Location:        c.Location,
+		Status:          c.Status,
+		DecidedByUserID: c.DecidedByUserID,
+		DecisionNote:    c.DecisionNote,
+		DecidedAt:       c.DecidedAt,
+	}
+}
+
+func (c *Change) toDomainMapper() *domainProfileChange.Change {
+	return &domainProfileChange.Change{
+		ID:              c.ID,
+		UserID:          c.UserID,
+		Location:        c.Location,
+		Status:          c.Status,
+		DecidedByUserID: c.DecidedByUserID,
+		DecisionNote:    c.DecisionNote,
+		DecidedAt:       c.DecidedAt,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
@@ -0,0 +1,179 @@
+package prospect
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainProspect "caregiver/src/domain/prospect"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Prospect struct {
+	ID                 uuid.UUID                      `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	FirstName          string                         `gorm:"column:first_name"`
+	LastName           string                         `gorm:"column:last_name"`
+	Email              string                         `gorm:"column:email"`
+	Phone              string                         `gorm:"column:phone"`
+	Location           domainUser.Location            `gorm:"embedded;embeddedPrefix:location_"`
+	ReferralSourceID   *uuid.UUID                     `gorm:"column:referral_source_id;type:uuid"`
+	CareNeeds          string                         `gorm:"column:care_needs"`
+	ServiceName        string                         `gorm:"column:service_name"`
+	PreferredTimes     []domainSchedule.ScheduledSlot `gorm:"column:preferred_times;serializer:json"`
+	Channel            string                         `gorm:"column:channel;default:staff;index"`
+	Notes              string                         `gorm:"column:notes"`
+	Status             string                         `gorm:"column:status;index"`
+	AssessmentAt       *time.Time                     `gorm:"column:assessment_at"`
+	AssessorUserID     *uuid.UUID                     `gorm:"column:assessor_user_id;type:uuid"`
+	AssessmentNotes    string                         `gorm:"column:assessment_notes"`
+	QuotedHourlyRate   *float64                       `gorm:"column:quoted_hourly_rate"`
+	QuotedHoursPerWeek *float64                       `gorm:"column:quoted_hours_per_week"`
+	QuoteNotes         string                         `gorm:"column:quote_notes"`
+	QuotedAt           *time.Time                     `gorm:"column:quoted_at"`
+	ClientUserID       *uuid.UUID                     `gorm:"column:client_user_id;type:uuid"`
+	ConvertedAt        *time.Time                     `gorm:"column:converted_at"`
+	DeclineReason      string                         `gorm:"column:decline_reason"`
+	CreatedAt          time.Time                      `gorm:"autoCreateTime:milli"`
+	UpdatedAt          time.Time                      `gorm:"autoUpdateTime:milli"`
+}
+
+func (Prospect) TableName() string {
+	return "prospects"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewProspectRepository(db *gorm.DB, loggerInstance *logger.Logger) domainProspect.IProspectRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newProspect *domainProspect.Prospect) (*domainProspect.Prospect, error) {
+	prospectModel := fromDomainMapper(newProspect)
+	if err := r.DB.Create(prospectModel).Error; err != nil {
+		r.Logger.Error("Error creating prospect", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Prospect created successfully", zap.String("prospectID", prospectModel.ID.String()))
+	return prospectModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainProspect.Prospect, error) {
+	var prospectModel Prospect
+	err := r.DB.Where("id = ?", id).First(&prospectModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Prospect not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting prospect by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return prospectModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll(status string) (*[]domainProspect.Prospect, error) {
+	query := r.DB.Model(&Prospect{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var prospects []Prospect
+	if err := query.Order("created_at DESC").Find(&prospects).Error; err != nil {
+		r.Logger.Error("Error getting prospects", zap.Error(err), zap.String("status", status))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainProspect.Prospect, len(prospects))
+	for i := range prospects {
+		res[i] = *prospects[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainProspect.Prospect, error) {
+	prospectModel := Prospect{ID: id}
+	if err := r.DB.Model(&prospectModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating prospect", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	tx := r.DB.Delete(&Prospect{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting prospect", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Prospect not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (p *Prospect) toDomainMapper() *domainProspect.Prospect {
+	return &domainProspect.Prospect{
+		ID:                 p.ID,
+		FirstName:          p.FirstName,
+		LastName:           p.LastName,
+		Email:              p.Email,
+		Phone:              p.Phone,
+		Location:           p.Location,
+		ReferralSourceID:   p.ReferralSourceID,
+		CareNeeds:          p.CareNeeds,
+		ServiceName:        p.ServiceName,
+		PreferredTimes:     p.PreferredTimes,
+		Channel:            p.Channel,
+		Notes:              p.Notes,
+		Status:             p.Status,
+		AssessmentAt:       p.AssessmentAt,
+		AssessorUserID:     p.AssessorUserID,
+		AssessmentNotes:    p.AssessmentNotes,
+		QuotedHourlyRate:   p.QuotedHourlyRate,
+		QuotedHoursPerWeek: p.QuotedHoursPerWeek,
+		QuoteNotes:         p.QuoteNotes,
+		QuotedAt:           p.QuotedAt,
+		ClientUserID:       p.ClientUserID,
+		ConvertedAt:        p.ConvertedAt,
+		DeclineReason:      p.DeclineReason,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(p *domainProspect.Prospect) *Prospect {
+	return &Prospect{
+		ID:                 p.ID,
+		FirstName:          p.FirstName,
+		LastName:           p.LastName,
+		Email:              p.Email,
+		Phone:              p.Phone,
+		Location:           p.Location,
+		ReferralSourceID:   p.ReferralSourceID,
+		CareNeeds:          p.CareNeeds,
+		ServiceName:        p.ServiceName,
+		PreferredTimes:     p.PreferredTimes,
+		Channel:            p.Channel,
+		Notes:              p.Notes,
+		Status:             p.Status,
+		AssessmentAt:       p.AssessmentAt,
+		AssessorUserID:     p.AssessorUserID,
+		AssessmentNotes:    p.AssessmentNotes,
+		QuotedHourlyRate:   p.QuotedHourlyRate,
+		QuotedHoursPerWeek: p.QuotedHoursPerWeek,
+		QuoteNotes:         p.QuoteNotes,
+		QuotedAt:           p.QuotedAt,
+		ClientUserID:       p.ClientUserID,
+		ConvertedAt:        p.ConvertedAt,
+		DeclineReason:      p.DeclineReason,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
@@ -7,8 +7,65 @@ import (
 
 	domainUser "caregiver/src/domain/user" // Added
 	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/repository/psql/alert"
+	"caregiver/src/infrastructure/repository/psql/announcement"
+	"caregiver/src/infrastructure/repository/psql/appversion"
+	"caregiver/src/infrastructure/repository/psql/attachment"
+	"caregiver/src/infrastructure/repository/psql/attestation"
+	"caregiver/src/infrastructure/repository/psql/branding"
+	"caregiver/src/infrastructure/repository/psql/budget"
+	"caregiver/src/infrastructure/repository/psql/careplan"
+	"caregiver/src/infrastructure/repository/psql/claim"
+	"caregiver/src/infrastructure/repository/psql/clienterror"
+	"caregiver/src/infrastructure/repository/psql/clientmerge"
+	"caregiver/src/infrastructure/repository/psql/compliance"
+	"caregiver/src/infrastructure/repository/psql/confirmation"
+	"caregiver/src/infrastructure/repository/psql/consent"
+	"caregiver/src/infrastructure/repository/psql/deactivation"
+	"caregiver/src/infrastructure/repository/psql/deprecation"
+	"caregiver/src/infrastructure/repository/psql/differential"
+	"caregiver/src/infrastructure/repository/psql/equipment"
+	"caregiver/src/infrastructure/repository/psql/evv"
+	"caregiver/src/infrastructure/repository/psql/fatigue"
+	"caregiver/src/infrastructure/repository/psql/form"
+	"caregiver/src/infrastructure/repository/psql/handoff"
+	"caregiver/src/infrastructure/repository/psql/identity"
+	"caregiver/src/infrastructure/repository/psql/invoice"
+	"caregiver/src/infrastructure/repository/psql/kiosk"
+	"caregiver/src/infrastructure/repository/psql/leave"
+	"caregiver/src/infrastructure/repository/psql/legalhold"
+	"caregiver/src/infrastructure/repository/psql/locale"
+	"caregiver/src/infrastructure/repository/psql/masking"
+	"caregiver/src/infrastructure/repository/psql/medication"
+	"caregiver/src/infrastructure/repository/psql/migrations"
+	"caregiver/src/infrastructure/repository/psql/nfctag"
+	"caregiver/src/infrastructure/repository/psql/notification"
+	"caregiver/src/infrastructure/repository/psql/payrate"
+	"caregiver/src/infrastructure/repository/psql/payroll"
+	"caregiver/src/infrastructure/repository/psql/planner"
+	"caregiver/src/infrastructure/repository/psql/profilechange"
+	"caregiver/src/infrastructure/repository/psql/prospect"
+	"caregiver/src/infrastructure/repository/psql/quota"
+	"caregiver/src/infrastructure/repository/psql/reference"
+	"caregiver/src/infrastructure/repository/psql/referral"
+	"caregiver/src/infrastructure/repository/psql/reminder"
+	"caregiver/src/infrastructure/repository/psql/report"
 	"caregiver/src/infrastructure/repository/psql/schedule"
+	"caregiver/src/infrastructure/repository/psql/scheduleview"
+	"caregiver/src/infrastructure/repository/psql/screening"
+	"caregiver/src/infrastructure/repository/psql/servicearea"
+	"caregiver/src/infrastructure/repository/psql/servicenote"
+	"caregiver/src/infrastructure/repository/psql/signature"
+	"caregiver/src/infrastructure/repository/psql/statement"
+	"caregiver/src/infrastructure/repository/psql/supply"
+	"caregiver/src/infrastructure/repository/psql/swap"
+	"caregiver/src/infrastructure/repository/psql/team"
+	"caregiver/src/infrastructure/repository/psql/training"
 	"caregiver/src/infrastructure/repository/psql/user"
+	"caregiver/src/infrastructure/repository/psql/vitals"
+	"caregiver/src/infrastructure/repository/psql/voicememo"
+	"caregiver/src/infrastructure/repository/psql/waitlist"
+	"caregiver/src/infrastructure/repository/psql/webhook"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -126,9 +183,28 @@ func (r *PSQLRepository) InitDatabase() error {
 		return err
 	}
 
-	err = r.MigrateEntitiesGORM()
+	// Replicas starting together take turns, so schema changes never race.
+	err = migrations.WithLock(r.DB, r.Logger, func(conn *gorm.DB) error {
+		locked := &PSQLRepository{DB: conn, Logger: r.Logger}
+		if err := locked.MigrateEntitiesGORM(); err != nil {
+			r.Logger.Error("Error migrating the database", zap.Error(err))
+			return err
+		}
+		if err := migrations.Apply(conn, r.Logger, migrations.All); err != nil {
+			r.Logger.Error("Error applying versioned migrations", zap.Error(err))
+			return err
+		}
+		if masking.Enabled() {
+			rows, err := masking.Mask(conn, r.Logger)
+			if err != nil {
+				r.Logger.Error("Error masking personal data", zap.Error(err))
+				return err
+			}
+			r.Logger.Info("Personal data masked", zap.Int64("rows", rows))
+		}
+		return nil
+	})
 	if err != nil {
-		r.Logger.Error("Error migrating the database", zap.Error(err))
 		return err
 	}
 
@@ -139,7 +215,70 @@ func (r *PSQLRepository) InitDatabase() error {
 func (r *PSQLRepository) MigrateEntitiesGORM() error {
 	var err error
 
-	err = r.DB.AutoMigrate(&user.User{}, &schedule.Schedule{}, &schedule.Task{})
+	err = r.DB.AutoMigrate(
+		&user.User{}, &user.UserVersion{}, &user.UserAddress{}, &user.PasswordToken{},
+		&schedule.Schedule{}, &schedule.Task{}, &schedule.Segment{}, &schedule.Interruption{},
+		&budget.Budget{}, &budget.Entry{},
+		&attachment.Attachment{}, &attachment.View{},
+		&nfctag.Tag{},
+		&voicememo.Memo{},
+		&compliance.Rule{}, &compliance.Exception{},
+		&confirmation.Confirmation{}, &confirmation.ChangeRequest{},
+		&waitlist.Entry{},
+		&servicearea.ServiceArea{},
+		&scheduleview.View{},
+		&team.Team{}, &team.Member{},
+		&alert.Policy{}, &alert.Alert{},
+		&form.Definition{}, &form.Submission{},
+		&signature.Signature{},
+		&fatigue.Rule{},
+		&reminder.Settings{}, &reminder.Override{}, &reminder.Preference{}, &reminder.Delivery{},
+		&kiosk.Kiosk{}, &kiosk.PIN{}, &kiosk.AuditEntry{},
+		&attestation.Settings{},
+		&evv.Aggregator{}, &evv.Submission{}, &evv.Attempt{},
+		&claim.Payer{}, &claim.Coverage{}, &claim.Rate{}, &claim.ProviderSettings{},
+		&claim.Batch{}, &claim.Claim{}, &claim.Remittance{}, &claim.RemittanceLine{}, &claim.Denial{},
+		&payrate.PayRate{},
+		&leave.AccrualRule{}, &leave.Request{}, &leave.Entry{},
+		&differential.Rule{},
+		&statement.Payment{}, &statement.Adjustment{},
+		&supply.Item{}, &supply.Usage{},
+		&equipment.Equipment{}, &equipment.Checkout{}, &equipment.MaintenanceRecord{},
+		&screening.Check{},
+		&training.Course{}, &training.Completion{},
+		&referral.Source{},
+		&prospect.Prospect{},
+		&careplan.Plan{}, &careplan.Goal{}, &careplan.Entry{},
+		&medication.Allergy{}, &medication.Medication{}, &medication.Administration{},
+		&vitals.Range{}, &vitals.Reading{},
+		&consent.Consent{},
+		&deprecation.Usage{},
+		&report.SavedReport{}, &report.Run{},
+		&branding.Branding{},
+		&clienterror.Failure{},
+		&profilechange.Change{},
+		&clientmerge.Merge{},
+		&masking.Run{},
+		&quota.Quota{}, &quota.Usage{},
+		&appversion.Requirement{},
+		&legalhold.Hold{},
+		&planner.Template{},
+		&planner.Availability{},
+		&planner.Proposal{},
+		&planner.Assignment{},
+		&notification.Device{}, &notification.Preference{},
+		&handoff.Handoff{},
+		&webhook.Subscription{}, &webhook.Delivery{},
+		&identity.Settings{}, &identity.Challenge{}, &identity.Check{},
+		&payroll.Period{}, &payroll.Unlock{},
+		&locale.Settings{}, &locale.Holiday{},
+		&reference.Service{}, &reference.TaskTemplate{},
+		&swap.Request{},
+		&deactivation.Change{},
+		&servicenote.Revision{},
+		&announcement.Announcement{}, &announcement.Delivery{},
+		&invoice.RateCard{}, &invoice.Invoice{}, &invoice.LineItem{},
+	)
 	if err != nil {
 		r.Logger.Error("Error migrating database entities", zap.Error(err))
 		return err
@@ -0,0 +1,167 @@
+package quota
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainQuota "caregiver/src/domain/quota"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Quota struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Client    string    `gorm:"column:client;uniqueIndex"`
+	Name      string    `gorm:"column:name"`
+	SoftLimit int64     `gorm:"column:soft_limit"`
+	HardLimit int64     `gorm:"column:hard_limit"`
+	CreatedAt time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Quota) TableName() string {
+	return "api_quotas"
+}
+
+type Usage struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Client     string    `gorm:"column:client;uniqueIndex:idx_api_usage_client_month"`
+	Month      string    `gorm:"column:month;uniqueIndex:idx_api_usage_client_month;index"`
+	Calls      int64     `gorm:"column:calls"`
+	LastCallAt time.Time `gorm:"column:last_call_at"`
+}
+
+func (Usage) TableName() string {
+	return "api_usage"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewQuotaRepository(db *gorm.DB, loggerInstance *logger.Logger) domainQuota.IQuotaRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CountCall(client, month string, at time.Time) (int64, error) {
+	usage := &Usage{Client: client, Month: month, Calls: 1, LastCallAt: at}
+	err := r.DB.Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "client"}, {Name: "month"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"calls":        gorm.Expr("api_usage.calls + 1"),
+				"last_call_at": at,
+			}),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "calls"}}},
+	).Create(usage).Error
+	if err != nil {
+		r.Logger.Error("Error counting API call", zap.Error(err), zap.String("client", client))
+		return 0, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return usage.Calls, nil
+}
+
+func (r *Repository) GetUsage(client string) (*[]domainQuota.Usage, error) {
+	var usage []Usage
+	if err := r.DB.Where("client = ?", client).Order("month DESC").Find(&usage).Error; err != nil {
+		r.Logger.Error("Error getting API usage", zap.Error(err), zap.String("client", client))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return usageToDomainMapper(usage), nil
+}
+
+func (r *Repository) GetMonthUsage(month string) (*[]domainQuota.Usage, error) {
+	var usage []Usage
+	if err := r.DB.Where("month = ?", month).Order("calls DESC").Find(&usage).Error; err != nil {
+		r.Logger.Error("Error getting monthly API usage", zap.Error(err), zap.String("month", month))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return usageToDomainMapper(usage), nil
+}
+
+func (r *Repository) GetQuotas() (*[]domainQuota.Quota, error) {
+	var quotas []Quota
+	if err := r.DB.Order("name ASC").Find(&quotas).Error; err != nil {
+		r.Logger.Error("Error getting API quotas", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainQuota.Quota, len(quotas))
+	for i := range quotas {
+		res[i] = *quotas[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetQuotaByClient(client string) (*domainQuota.Quota, error) {
+	var quota Quota
+	if err := r.DB.Where("client = ?", client).First(&quota).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting API quota", zap.Error(err), zap.String("client", client))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return quota.toDomainMapper(), nil
+}
+
+func (r *Repository) SaveQuota(quota *domainQuota.Quota) (*domainQuota.Quota, error) {
+	quotaModel := &Quota{
+		Client:    quota.Client,
+		Name:      quota.Name,
+		SoftLimit: quota.SoftLimit,
+		HardLimit: quota.HardLimit,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "client"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "soft_limit", "hard_limit", "updated_at"}),
+	}).Create(quotaModel).Error
+	if err != nil {
+		r.Logger.Error("Error saving API quota", zap.Error(err), zap.String("client", quota.Client))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetQuotaByClient(quota.Client)
+}
+
+func (r *Repository) DeleteQuota(id uuid.UUID) error {
+	result := r.DB.Delete(&Quota{}, "id = ?", id)
+	if result.Error != nil {
+		r.Logger.Error("Error deleting API quota", zap.Error(result.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (q *Quota) toDomainMapper() *domainQuota.Quota {
+	return &domainQuota.Quota{
+		ID:        q.ID,
+		Client:    q.Client,
+		Name:      q.Name,
+		SoftLimit: q.SoftLimit,
+		HardLimit: q.HardLimit,
+		CreatedAt: q.CreatedAt,
+		UpdatedAt: q.UpdatedAt,
+	}
+}
+
+func usageToDomainMapper(usage []Usage) *[]domainQuota.Usage {
+	res := make([]domainQuota.Usage, len(usage))
+	for i, u := range usage {
+		res[i] = domainQuota.Usage{
+			Client:     u.Client,
+			Month:      u.Month,
+			Calls:      u.Calls,
+			LastCallAt: u.LastCallAt,
+		}
+	}
+	return &res
+}
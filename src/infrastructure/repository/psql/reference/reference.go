@@ -0,0 +1,190 @@
+package reference
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReference "caregiver/src/domain/reference"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Service struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name            string    `gorm:"column:name;uniqueIndex"`
+	Description     string    `gorm:"column:description"`
+	DurationMinutes int       `gorm:"column:duration_minutes"`
+	Active          bool      `gorm:"column:active;index"`
+	CreatedAt       time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Service) TableName() string {
+	return "reference_services"
+}
+
+type TaskTemplate struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ServiceID   uuid.UUID `gorm:"column:service_id;type:uuid;index"`
+	Title       string    `gorm:"column:title"`
+	Description string    `gorm:"column:description"`
+	Position    int       `gorm:"column:position"`
+	CreatedAt   time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (TaskTemplate) TableName() string {
+	return "reference_task_templates"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewReferenceRepository(db *gorm.DB, loggerInstance *logger.Logger) domainReference.IReferenceRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) GetServices() (*[]domainReference.Service, error) {
+	var models []Service
+	if err := r.DB.Order("name ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting services", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainReference.Service, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) GetServiceByID(id uuid.UUID) (*domainReference.Service, error) {
+	var model Service
+	if err := r.DB.Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting service", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) CreateService(service *domainReference.Service) (*domainReference.Service, error) {
+	model := &Service{
+		Name:            service.Name,
+		Description:     service.Description,
+		DurationMinutes: service.DurationMinutes,
+		Active:          service.Active,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating service", zap.Error(err), zap.String("name", service.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) UpdateService(id uuid.UUID, updates map[string]interface{}) (*domainReference.Service, error) {
+	model := Service{ID: id}
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating service", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetServiceByID(id)
+}
+
+func (r *Repository) DeleteService(id uuid.UUID) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("service_id = ?", id).Delete(&TaskTemplate{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&Service{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error deleting service", zap.Error(err), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) GetTaskTemplates(serviceIDs []uuid.UUID) (*[]domainReference.TaskTemplate, error) {
+	var models []TaskTemplate
+	query := r.DB.Order("service_id ASC, position ASC, title ASC")
+	if len(serviceIDs) > 0 {
+		query = query.Where("service_id IN ?", serviceIDs)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting task templates", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainReference.TaskTemplate, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) CreateTaskTemplate(template *domainReference.TaskTemplate) (*domainReference.TaskTemplate, error) {
+	model := &TaskTemplate{
+		ServiceID:   template.ServiceID,
+		Title:       template.Title,
+		Description: template.Description,
+		Position:    template.Position,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating task template", zap.Error(err), zap.String("serviceID", template.ServiceID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) DeleteTaskTemplate(id uuid.UUID) error {
+	result := r.DB.Delete(&TaskTemplate{}, "id = ?", id)
+	if result.Error != nil {
+		r.Logger.Error("Error deleting task template", zap.Error(result.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (s *Service) toDomainMapper() *domainReference.Service {
+	return &domainReference.Service{
+		ID:              s.ID,
+		Name:            s.Name,
+		Description:     s.Description,
+		DurationMinutes: s.DurationMinutes,
+		Active:          s.Active,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+func (t *TaskTemplate) toDomainMapper() *domainReference.TaskTemplate {
+	return &domainReference.TaskTemplate{
+		ID:          t.ID,
+		ServiceID:   t.ServiceID,
+		Title:       t.Title,
+		Description: t.Description,
+		Position:    t.Position,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
@@ -0,0 +1,125 @@
+package referral
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReferral "caregiver/src/domain/referral"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Source struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name        string    `gorm:"column:name"`
+	Type        string    `gorm:"column:type;index"`
+	ContactName string    `gorm:"column:contact_name"`
+	Phone       string    `gorm:"column:phone"`
+	Email       string    `gorm:"column:email"`
+	Active      bool      `gorm:"column:active"`
+	CreatedAt   time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Source) TableName() string {
+	return "referral_sources"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewReferralRepository(db *gorm.DB, loggerInstance *logger.Logger) domainReferral.IReferralRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateSource(newSource *domainReferral.Source) (*domainReferral.Source, error) {
+	sourceModel := fromDomainMapper(newSource)
+	if err := r.DB.Create(sourceModel).Error; err != nil {
+		r.Logger.Error("Error creating referral source", zap.Error(err), zap.String("name", newSource.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Referral source created successfully", zap.String("sourceID", sourceModel.ID.String()))
+	return sourceModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetSourceByID(id uuid.UUID) (*domainReferral.Source, error) {
+	var sourceModel Source
+	err := r.DB.Where("id = ?", id).First(&sourceModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Referral source not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting referral source by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return sourceModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetSources() (*[]domainReferral.Source, error) {
+	var sources []Source
+	if err := r.DB.Order("name ASC").Find(&sources).Error; err != nil {
+		r.Logger.Error("Error getting referral sources", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainReferral.Source, len(sources))
+	for i := range sources {
+		res[i] = *sources[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateSource(id uuid.UUID, updates map[string]interface{}) (*domainReferral.Source, error) {
+	sourceModel := Source{ID: id}
+	if err := r.DB.Model(&sourceModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating referral source", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetSourceByID(id)
+}
+
+func (r *Repository) DeleteSource(id uuid.UUID) error {
+	tx := r.DB.Delete(&Source{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting referral source", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Referral source not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (s *Source) toDomainMapper() *domainReferral.Source {
+	return &domainReferral.Source{
+		ID:          s.ID,
+		Name:        s.Name,
+		Type:        s.Type,
+		ContactName: s.ContactName,
+		Phone:       s.Phone,
+		Email:       s.Email,
+		Active:      s.Active,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(s *domainReferral.Source) *Source {
+	return &Source{
+		ID:          s.ID,
+		Name:        s.Name,
+		Type:        s.Type,
+		ContactName: s.ContactName,
+		Phone:       s.Phone,
+		Email:       s.Email,
+		Active:      s.Active,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
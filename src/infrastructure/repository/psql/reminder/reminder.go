@@ -0,0 +1,237 @@
+package reminder
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReminder "caregiver/src/domain/reminder"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Settings struct {
+	ID             uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	OffsetsMinutes []int     `gorm:"column:offsets_minutes;serializer:json"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+type Override struct {
+	ScheduleID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	OffsetsMinutes []int     `gorm:"column:offsets_minutes;serializer:json"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+type Preference struct {
+	UserID    uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Enabled   bool      `gorm:"column:enabled"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+type Delivery struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID      uuid.UUID `gorm:"column:schedule_id;type:uuid;uniqueIndex:idx_reminder_delivery"`
+	OffsetMinutes   int       `gorm:"column:offset_minutes;uniqueIndex:idx_reminder_delivery"`
+	RecipientUserID uuid.UUID `gorm:"column:recipient_user_id;type:uuid;uniqueIndex:idx_reminder_delivery"`
+	Skipped         bool      `gorm:"column:skipped"`
+	SentAt          time.Time `gorm:"column:sent_at"`
+}
+
+func (Settings) TableName() string {
+	return "reminder_settings"
+}
+
+func (Override) TableName() string {
+	return "reminder_overrides"
+}
+
+func (Preference) TableName() string {
+	return "reminder_preferences"
+}
+
+func (Delivery) TableName() string {
+	return "reminder_deliveries"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewReminderRepository(db *gorm.DB, loggerInstance *logger.Logger) domainReminder.IReminderRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+// GetSettings returns the organization's reminder settings, of which there is
+// at most one row.
+func (r *Repository) GetSettings() (*domainReminder.Settings, error) {
+	var model Settings
+	err := r.DB.Order("updated_at ASC").First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting reminder settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return &domainReminder.Settings{ID: model.ID, OffsetsMinutes: model.OffsetsMinutes, UpdatedAt: model.UpdatedAt}, nil
+}
+
+func (r *Repository) SaveSettings(settings *domainReminder.Settings) (*domainReminder.Settings, error) {
+	model := Settings{ID: settings.ID, OffsetsMinutes: settings.OffsetsMinutes}
+	if err := r.DB.Save(&model).Error; err != nil {
+		r.Logger.Error("Error saving reminder settings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetSettings()
+}
+
+func (r *Repository) GetOverride(scheduleID uuid.UUID) (*domainReminder.Override, error) {
+	var model Override
+	err := r.DB.Where("schedule_id = ?", scheduleID).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting reminder override", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetOverrides(scheduleIDs []uuid.UUID) (*[]domainReminder.Override, error) {
+	overrides := []domainReminder.Override{}
+	if len(scheduleIDs) == 0 {
+		return &overrides, nil
+	}
+	var models []Override
+	if err := r.DB.Where("schedule_id IN ?", scheduleIDs).Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting reminder overrides", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	for i := range models {
+		overrides = append(overrides, *models[i].toDomainMapper())
+	}
+	return &overrides, nil
+}
+
+func (r *Repository) SaveOverride(override *domainReminder.Override) (*domainReminder.Override, error) {
+	model := Override{ScheduleID: override.ScheduleID, OffsetsMinutes: override.OffsetsMinutes}
+	if err := r.DB.Save(&model).Error; err != nil {
+		r.Logger.Error("Error saving reminder override", zap.Error(err), zap.String("scheduleID", override.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetOverride(override.ScheduleID)
+}
+
+func (r *Repository) DeleteOverride(scheduleID uuid.UUID) error {
+	tx := r.DB.Delete(&Override{}, "schedule_id = ?", scheduleID)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting reminder override", zap.Error(tx.Error), zap.String("scheduleID", scheduleID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) GetPreference(userID uuid.UUID) (*domainReminder.Preference, error) {
+	var model Preference
+	err := r.DB.Where("user_id = ?", userID).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting reminder preference", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return &domainReminder.Preference{UserID: model.UserID, Enabled: model.Enabled, UpdatedAt: model.UpdatedAt}, nil
+}
+
+func (r *Repository) GetPreferences(userIDs []uuid.UUID) (*[]domainReminder.Preference, error) {
+	preferences := []domainReminder.Preference{}
+	if len(userIDs) == 0 {
+		return &preferences, nil
+	}
+	var models []Preference
+	if err := r.DB.Where("user_id IN ?", userIDs).Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting reminder preferences", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	for _, m := range models {
+		preferences = append(preferences, domainReminder.Preference{UserID: m.UserID, Enabled: m.Enabled, UpdatedAt: m.UpdatedAt})
+	}
+	return &preferences, nil
+}
+
+func (r *Repository) SavePreference(preference *domainReminder.Preference) (*domainReminder.Preference, error) {
+	model := Preference{UserID: preference.UserID, Enabled: preference.Enabled}
+	if err := r.DB.Save(&model).Error; err != nil {
+		r.Logger.Error("Error saving reminder preference", zap.Error(err), zap.String("userID", preference.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetPreference(preference.UserID)
+}
+
+// CreateDeliveries records handled reminders. A reminder recorded by an
+// overlapping sweep is left as it is.
+func (r *Repository) CreateDeliveries(deliveries []domainReminder.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	models := make([]Delivery, len(deliveries))
+	for i, d := range deliveries {
+		models[i] = Delivery{
+			ID:              d.ID,
+			ScheduleID:      d.ScheduleID,
+			OffsetMinutes:   d.OffsetMinutes,
+			RecipientUserID: d.RecipientUserID,
+			Skipped:         d.Skipped,
+			SentAt:          d.SentAt,
+		}
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "schedule_id"}, {Name: "offset_minutes"}, {Name: "recipient_user_id"}},
+		DoNothing: true,
+	}).Create(&models).Error
+	if err != nil {
+		r.Logger.Error("Error recording reminder deliveries", zap.Error(err))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) GetDeliveries(scheduleIDs []uuid.UUID) (*[]domainReminder.Delivery, error) {
+	deliveries := []domainReminder.Delivery{}
+	if len(scheduleIDs) == 0 {
+		return &deliveries, nil
+	}
+	var models []Delivery
+	if err := r.DB.Where("schedule_id IN ?", scheduleIDs).Order("sent_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting reminder deliveries", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	for _, m := range models {
+		deliveries = append(deliveries, domainReminder.Delivery{
+			ID:              m.ID,
+			ScheduleID:      m.ScheduleID,
+			OffsetMinutes:   m.OffsetMinutes,
+			RecipientUserID: m.RecipientUserID,
+			Skipped:         m.Skipped,
+			SentAt:          m.SentAt,
+		})
+	}
+	return &deliveries, nil
+}
+
+func (o *Override) toDomainMapper() *domainReminder.Override {
+	offsets := o.OffsetsMinutes
+	if offsets == nil {
+		offsets = []int{}
+	}
+	return &domainReminder.Override{ScheduleID: o.ScheduleID, OffsetsMinutes: offsets, UpdatedAt: o.UpdatedAt}
+}
@@ -0,0 +1,324 @@
+package report
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReport "caregiver/src/domain/report"
+	logger "caregiver/src/infrastructure/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// queryField maps a report builder field to the SQL expression it reads.
+type queryField struct {
+	domainReport.Field
+	expr string
+}
+
+// queryMeasure maps a measure to its aggregate. Decimal measures are rounded
+// to two places.
+type queryMeasure struct {
+	name    string
+	expr    string
+	decimal bool
+}
+
+type queryEntity struct {
+	name     string
+	table    string
+	fields   []queryField
+	measures []queryMeasure
+	// softDelete leaves out rows with deleted_at set
+	softDelete bool
+}
+
+// queryEntities is the whitelist of everything the report builder may read.
+// Only these expressions ever reach the SQL; filter values are always bound
+// as parameters.
+var queryEntities = []queryEntity{
+	{
+		name:       "visits",
+		table:      "schedules",
+		softDelete: true,
+		fields: []queryField{
+			{domainReport.Field{Name: "service_name", Type: domainReport.FieldString}, "service_name"},
+			{domainReport.Field{Name: "visit_status", Type: domainReport.FieldString}, "visit_status"},
+			{domainReport.Field{Name: "client_user_id", Type: domainReport.FieldID}, "client_user_id"},
+			{domainReport.Field{Name: "caregiver_user_id", Type: domainReport.FieldID}, "assigned_user_id"},
+			{domainReport.Field{Name: "verification_method", Type: domainReport.FieldString}, "checkin_verification_method"},
+			{domainReport.Field{Name: "visit_date", Type: domainReport.FieldDate}, "DATE(scheduled_slot_from)"},
+			{domainReport.Field{Name: "visit_week", Type: domainReport.FieldDate}, "DATE(DATE_TRUNC('week', scheduled_slot_from))"},
+			{domainReport.Field{Name: "visit_month", Type: domainReport.FieldDate}, "DATE(DATE_TRUNC('month', scheduled_slot_from))"},
+		},
+		measures: []queryMeasure{
+			{name: "visits", expr: "COUNT(*)"},
+			{name: "completed_visits", expr: "COUNT(*) FILTER (WHERE visit_status = 'completed')"},
+			{name: "scheduled_hours", expr: "COALESCE(SUM(EXTRACT(EPOCH FROM scheduled_slot_to - scheduled_slot_from)), 0) / 3600", decimal: true},
+			{name: "worked_hours", expr: "COALESCE(SUM(EXTRACT(EPOCH FROM checkout_time - checkin_time)), 0) / 3600", decimal: true},
+		},
+	},
+	{
+		name:       "users",
+		table:      "users",
+		softDelete: true,
+		fields: []queryField{
+			{domainReport.Field{Name: "role", Type: domainReport.FieldString}, "role"},
+			{domainReport.Field{Name: "status", Type: domainReport.FieldString}, "CASE WHEN status THEN 'active' ELSE 'inactive' END"},
+			{domainReport.Field{Name: "city", Type: domainReport.FieldString}, "location_city"},
+			{domainReport.Field{Name: "state", Type: domainReport.FieldString}, "location_state"},
+			{domainReport.Field{Name: "referral_source_id", Type: domainReport.FieldID}, "referral_source_id"},
+			{domainReport.Field{Name: "joined_date", Type: domainReport.FieldDate}, "DATE(created_at)"},
+			{domainReport.Field{Name: "joined_month", Type: domainReport.FieldDate}, "DATE(DATE_TRUNC('month', created_at))"},
+		},
+		measures: []queryMeasure{
+			{name: "users", expr: "COUNT(*)"},
+			{name: "average_hourly_rate", expr: "COALESCE(AVG(hourly_rate), 0)", decimal: true},
+		},
+	},
+	{
+		name:  "claims",
+		table: "claims",
+		fields: []queryField{
+			{domainReport.Field{Name: "payer_id", Type: domainReport.FieldID}, "payer_id"},
+			{domainReport.Field{Name: "client_user_id", Type: domainReport.FieldID}, "client_user_id"},
+			{domainReport.Field{Name: "status", Type: domainReport.FieldString}, "status"},
+			{domainReport.Field{Name: "procedure_code", Type: domainReport.FieldString}, "procedure_code"},
+			{domainReport.Field{Name: "service_date", Type: domainReport.FieldDate}, "service_date"},
+			{domainReport.Field{Name: "service_month", Type: domainReport.FieldDate}, "DATE(DATE_TRUNC('month', service_date))"},
+			{domainReport.Field{Name: "charge", Type: domainReport.FieldNumber}, "charge"},
+		},
+		measures: []queryMeasure{
+			{name: "claims", expr: "COUNT(*)"},
+			{name: "units", expr: "COALESCE(SUM(units), 0)"},
+			{name: "charged", expr: "COALESCE(SUM(charge), 0)", decimal: true},
+			{name: "paid", expr: "COALESCE(SUM(paid_amount), 0)", decimal: true},
+		},
+	},
+	{
+		name:  "supply_usages",
+		table: "supply_usages",
+		fields: []queryField{
+			{domainReport.Field{Name: "item_id", Type: domainReport.FieldID}, "item_id"},
+			{domainReport.Field{Name: "client_user_id", Type: domainReport.FieldID}, "client_user_id"},
+			{domainReport.Field{Name: "caregiver_user_id", Type: domainReport.FieldID}, "caregiver_user_id"},
+			{domainReport.Field{Name: "used_date", Type: domainReport.FieldDate}, "DATE(used_at)"},
+			{domainReport.Field{Name: "used_month", Type: domainReport.FieldDate}, "DATE(DATE_TRUNC('month', used_at))"},
+		},
+		measures: []queryMeasure{
+			{name: "usages", expr: "COUNT(*)"},
+			{name: "quantity", expr: "COALESCE(SUM(quantity), 0)"},
+			{name: "cost", expr: "COALESCE(SUM(quantity * unit_cost), 0)", decimal: true},
+		},
+	},
+}
+
+var queryOperators = map[string]string{
+	domainReport.OperatorEq:  "=",
+	domainReport.OperatorNe:  "<>",
+	domainReport.OperatorGt:  ">",
+	domainReport.OperatorGte: ">=",
+	domainReport.OperatorLt:  "<",
+	domainReport.OperatorLte: "<=",
+}
+
+type QueryRepository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewQueryRepository(db *gorm.DB, loggerInstance *logger.Logger) domainReport.IQueryRepository {
+	return &QueryRepository{DB: db, Logger: loggerInstance}
+}
+
+func (r *QueryRepository) Entities() []domainReport.Entity {
+	entities := make([]domainReport.Entity, len(queryEntities))
+	for i, entity := range queryEntities {
+		entities[i].Name = entity.name
+		for _, field := range entity.fields {
+			entities[i].Fields = append(entities[i].Fields, field.Field)
+		}
+		for _, measure := range entity.measures {
+			entities[i].Measures = append(entities[i].Measures, measure.name)
+		}
+	}
+	return entities
+}
+
+func (r *QueryRepository) RunQuery(query domainReport.Query) (*domainReport.Table, error) {
+	statement, args, err := buildQuery(query)
+	if err != nil {
+		return nil, domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
+	rows, err := r.DB.Raw(statement, args...).Rows()
+	if err != nil {
+		r.Logger.Error("Error running report query", zap.Error(err), zap.String("entity", query.Entity))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	defer rows.Close()
+
+	table := &domainReport.Table{Columns: append(append([]string{}, query.Dimensions...), query.Measures...), Rows: [][]string{}}
+	values := make([]sql.NullString, len(table.Columns))
+	targets := make([]interface{}, len(values))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(targets...); err != nil {
+			r.Logger.Error("Error reading report query row", zap.Error(err), zap.String("entity", query.Entity))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		row := make([]string, len(values))
+		for i, value := range values {
+			row[i] = value.String
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("Error reading report query rows", zap.Error(err), zap.String("entity", query.Entity))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return table, nil
+}
+
+// buildQuery turns a query into SQL using only the whitelisted expressions,
+// with every filter value and the limit bound as parameters. Values are
+// selected as text so every row scans the same way; grouping and ordering
+// use the underlying expressions so that numbers and dates sort naturally.
+func buildQuery(query domainReport.Query) (string, []interface{}, error) {
+	entity, ok := findQueryEntity(query.Entity)
+	if !ok {
+		return "", nil, fmt.Errorf("unknown entity '%s'", query.Entity)
+	}
+	if len(query.Measures) == 0 {
+		return "", nil, fmt.Errorf("at least one measure is required")
+	}
+
+	var selects, groups []string
+	ordering := make(map[string]string)
+	for _, name := range query.Dimensions {
+		field, ok := entity.field(name)
+		if !ok {
+			return "", nil, fmt.Errorf("unknown dimension '%s' for %s", name, entity.name)
+		}
+		selects = append(selects, fmt.Sprintf("(%s)::text", field.expr))
+		groups = append(groups, field.expr)
+		ordering[name] = field.expr
+	}
+	for _, name := range query.Measures {
+		measure, ok := entity.measure(name)
+		if !ok {
+			return "", nil, fmt.Errorf("unknown measure '%s' for %s", name, entity.name)
+		}
+		if measure.decimal {
+			selects = append(selects, fmt.Sprintf("ROUND((%s)::numeric, 2)::text", measure.expr))
+		} else {
+			selects = append(selects, fmt.Sprintf("(%s)::text", measure.expr))
+		}
+		ordering[name] = measure.expr
+	}
+
+	var conditions []string
+	var args []interface{}
+	if entity.softDelete {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	for _, condition := range query.Filters {
+		field, ok := entity.field(condition.Field)
+		if !ok {
+			return "", nil, fmt.Errorf("unknown filter field '%s' for %s", condition.Field, entity.name)
+		}
+		values := make([]interface{}, len(condition.Values))
+		for i, raw := range condition.Values {
+			value, err := field.Parse(raw)
+			if err != nil {
+				return "", nil, err
+			}
+			values[i] = value
+		}
+		if condition.Operator == domainReport.OperatorIn {
+			if len(values) == 0 {
+				return "", nil, fmt.Errorf("filter on %s needs at least one value", field.Name)
+			}
+			conditions = append(conditions, fmt.Sprintf("(%s) IN ?", field.expr))
+			args = append(args, values)
+			continue
+		}
+		operator, ok := queryOperators[condition.Operator]
+		if !ok || !contains(field.Operators(), condition.Operator) {
+			return "", nil, fmt.Errorf("operator '%s' cannot be used on %s", condition.Operator, field.Name)
+		}
+		if len(values) != 1 {
+			return "", nil, fmt.Errorf("filter on %s needs exactly one value", field.Name)
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s) %s ?", field.expr, operator))
+		args = append(args, values[0])
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT " + strings.Join(selects, ", ") + " FROM " + entity.table)
+	if len(conditions) > 0 {
+		b.WriteString(" WHERE " + strings.Join(conditions, " AND "))
+	}
+	if len(groups) > 0 {
+		b.WriteString(" GROUP BY " + strings.Join(groups, ", "))
+	}
+	switch {
+	case query.OrderBy != "":
+		expr, ok := ordering[query.OrderBy]
+		if !ok {
+			return "", nil, fmt.Errorf("can only order by a chosen dimension or measure")
+		}
+		direction := "ASC"
+		if query.Descending {
+			direction = "DESC"
+		}
+		b.WriteString(" ORDER BY " + expr + " " + direction)
+	case len(groups) > 0:
+		b.WriteString(" ORDER BY " + strings.Join(groups, ", "))
+	}
+	if query.Limit > 0 {
+		b.WriteString(" LIMIT ?")
+		args = append(args, query.Limit)
+	}
+	return b.String(), args, nil
+}
+
+func findQueryEntity(name string) (*queryEntity, bool) {
+	for i := range queryEntities {
+		if queryEntities[i].name == name {
+			return &queryEntities[i], true
+		}
+	}
+	return nil, false
+}
+
+func (e *queryEntity) field(name string) (queryField, bool) {
+	for _, field := range e.fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return queryField{}, false
+}
+
+func (e *queryEntity) measure(name string) (queryMeasure, bool) {
+	for _, measure := range e.measures {
+		if measure.name == name {
+			return measure, true
+		}
+	}
+	return queryMeasure{}, false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	domainReport "caregiver/src/domain/report"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+	cleanup := func() { db.Close() }
+	return gormDB, mock, cleanup
+}
+
+func setupLogger(t *testing.T) *logger.Logger {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	return loggerInstance
+}
+
+func TestRunQuery(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+	repo := NewQueryRepository(db, setupLogger(t))
+
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT (service_name)::text, (COUNT(*))::text, ROUND((COALESCE(SUM(EXTRACT(EPOCH FROM checkout_time - checkin_time)), 0) / 3600)::numeric, 2)::text "+
+			"FROM schedules WHERE deleted_at IS NULL AND (DATE(scheduled_slot_from)) >= $1 AND (service_name) IN ($2,$3) "+
+			"GROUP BY service_name ORDER BY COALESCE(SUM(EXTRACT(EPOCH FROM checkout_time - checkin_time)), 0) / 3600 DESC LIMIT $4")).
+		WithArgs(from, "Personal Care", "Companionship'; DROP TABLE users; --", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"service_name", "visits", "worked_hours"}).
+			AddRow("Personal Care", "12", "30.50").
+			AddRow(nil, "1", "0.00"))
+
+	table, err := repo.RunQuery(domainReport.Query{
+		Entity:     "visits",
+		Dimensions: []string{"service_name"},
+		Measures:   []string{"visits", "worked_hours"},
+		Filters: []domainReport.Condition{
+			{Field: "visit_date", Operator: domainReport.OperatorGte, Values: []string{"2025-06-01"}},
+			{Field: "service_name", Operator: domainReport.OperatorIn, Values: []string{"Personal Care", "Companionship'; DROP TABLE users; --"}},
+		},
+		OrderBy:    "worked_hours",
+		Descending: true,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service_name", "visits", "worked_hours"}, table.Columns)
+	assert.Equal(t, [][]string{{"Personal Care", "12", "30.50"}, {"", "1", "0.00"}}, table.Rows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBuildQueryRejectsUnlistedNames(t *testing.T) {
+	queries := []domainReport.Query{
+		{Entity: "users; DELETE FROM users", Measures: []string{"users"}},
+		{Entity: "users", Measures: []string{"users"}, Dimensions: []string{"hash_password"}},
+		{Entity: "users", Measures: []string{"SUM(hourly_rate)"}},
+		{Entity: "users", Measures: []string{"users"}, Filters: []domainReport.Condition{{Field: "email", Operator: domainReport.OperatorEq, Values: []string{"a@example.com"}}}},
+		{Entity: "users", Measures: []string{"users"}, Filters: []domainReport.Condition{{Field: "role", Operator: "LIKE", Values: []string{"%"}}}},
+		{Entity: "users", Measures: []string{"users"}, OrderBy: "email"},
+	}
+	for _, query := range queries {
+		_, _, err := buildQuery(query)
+		assert.Error(t, err, "%+v", query)
+	}
+}
+
+func TestEntities(t *testing.T) {
+	db, _, cleanup := setupMockDB(t)
+	defer cleanup()
+	repo := NewQueryRepository(db, setupLogger(t))
+
+	entities := repo.Entities()
+	require.Len(t, entities, len(queryEntities))
+	for _, entity := range entities {
+		for _, field := range entity.Fields {
+			assert.NotEqual(t, "hash_password", field.Name)
+			assert.NotEqual(t, "email", field.Name)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package report
+
+import (
+	"encoding/json"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainReport "caregiver/src/domain/report"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type SavedReport struct {
+	ID          uuid.UUID         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name        string            `gorm:"column:name"`
+	Kind        string            `gorm:"column:kind"`
+	Filters     map[string]string `gorm:"column:filters;serializer:json"`
+	Columns     []string          `gorm:"column:columns;serializer:json"`
+	Format      string            `gorm:"column:format"`
+	Frequency   string            `gorm:"column:frequency"`
+	Recipients  []string          `gorm:"column:recipients;serializer:json"`
+	OwnerUserID uuid.UUID         `gorm:"column:owner_user_id;type:uuid;index"`
+	Active      bool              `gorm:"column:active"`
+	NextRunAt   *time.Time        `gorm:"column:next_run_at;index"`
+	LastRunAt   *time.Time        `gorm:"column:last_run_at"`
+	CreatedAt   time.Time         `gorm:"autoCreateTime:milli"`
+	UpdatedAt   time.Time         `gorm:"autoUpdateTime:milli"`
+}
+
+func (SavedReport) TableName() string {
+	return "saved_reports"
+}
+
+type Run struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ReportID   uuid.UUID `gorm:"column:report_id;type:uuid;index"`
+	PeriodFrom time.Time `gorm:"column:period_from"`
+	PeriodTo   time.Time `gorm:"column:period_to"`
+	Rows       int       `gorm:"column:rows"`
+	Recipients []string  `gorm:"column:recipients;serializer:json"`
+	Status     string    `gorm:"column:status"`
+	Error      string    `gorm:"column:error"`
+	RanAt      time.Time `gorm:"column:ran_at"`
+	CreatedAt  time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Run) TableName() string {
+	return "saved_report_runs"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewReportRepository(db *gorm.DB, loggerInstance *logger.Logger) domainReport.IReportRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newReport *domainReport.SavedReport) (*domainReport.SavedReport, error) {
+	reportModel := fromDomainMapper(newReport)
+	if err := r.DB.Create(reportModel).Error; err != nil {
+		r.Logger.Error("Error creating saved report", zap.Error(err), zap.String("name", newReport.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Saved report created successfully", zap.String("reportID", reportModel.ID.String()))
+	return reportModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainReport.SavedReport, error) {
+	var reportModel SavedReport
+	err := r.DB.Where("id = ?", id).First(&reportModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Saved report not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting saved report by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return reportModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll() (*[]domainReport.SavedReport, error) {
+	var reports []SavedReport
+	if err := r.DB.Order("name ASC").Find(&reports).Error; err != nil {
+		r.Logger.Error("Error getting saved reports", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&reports), nil
+}
+
+func (r *Repository) GetDue(at time.Time) (*[]domainReport.SavedReport, error) {
+	var reports []SavedReport
+	err := r.DB.Where("active = ? AND next_run_at IS NOT NULL AND next_run_at <= ?", true, at).
+		Order("next_run_at ASC").Find(&reports).Error
+	if err != nil {
+		r.Logger.Error("Error getting due saved reports", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&reports), nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainReport.SavedReport, error) {
+	// Map updates bypass the field serializer, so encode JSON columns here.
+	for _, column := range []string{"filters", "columns", "recipients"} {
+		value, ok := updates[column]
+		if !ok {
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			r.Logger.Error("Error encoding saved report field", zap.Error(err), zap.String("id", id.String()), zap.String("column", column))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		updates[column] = string(encoded)
+	}
+	reportModel := SavedReport{ID: id}
+	if err := r.DB.Model(&reportModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating saved report", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("report_id = ?", id).Delete(&Run{}).Error; err != nil {
+			r.Logger.Error("Error deleting saved report runs", zap.Error(err), zap.String("reportID", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		result := tx.Delete(&SavedReport{}, "id = ?", id)
+		if result.Error != nil {
+			r.Logger.Error("Error deleting saved report", zap.Error(result.Error), zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		if result.RowsAffected == 0 {
+			r.Logger.Warn("Saved report not found for deletion", zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) CreateRun(newRun *domainReport.Run) (*domainReport.Run, error) {
+	runModel := &Run{
+		ID:         newRun.ID,
+		ReportID:   newRun.ReportID,
+		PeriodFrom: newRun.PeriodFrom,
+		PeriodTo:   newRun.PeriodTo,
+		Rows:       newRun.Rows,
+		Recipients: newRun.Recipients,
+		Status:     newRun.Status,
+		Error:      newRun.Error,
+		RanAt:      newRun.RanAt,
+	}
+	if err := r.DB.Create(runModel).Error; err != nil {
+		r.Logger.Error("Error creating saved report run", zap.Error(err), zap.String("reportID", newRun.ReportID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return runModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRuns(reportID uuid.UUID) (*[]domainReport.Run, error) {
+	var runs []Run
+	if err := r.DB.Where("report_id = ?", reportID).Order("ran_at DESC").Find(&runs).Error; err != nil {
+		r.Logger.Error("Error getting saved report runs", zap.Error(err), zap.String("reportID", reportID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainReport.Run, len(runs))
+	for i := range runs {
+		res[i] = *runs[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (s *SavedReport) toDomainMapper() *domainReport.SavedReport {
+	return &domainReport.SavedReport{
+		ID:          s.ID,
+		Name:        s.Name,
+		Kind:        s.Kind,
+		Filters:     s.Filters,
+		Columns:     s.Columns,
+		Format:      s.Format,
+		Frequency:   s.Frequency,
+		Recipients:  s.Recipients,
+		OwnerUserID: s.OwnerUserID,
+		Active:      s.Active,
+		NextRunAt:   s.NextRunAt,
+		LastRunAt:   s.LastRunAt,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(s *domainReport.SavedReport) *SavedReport {
+	return &SavedReport{
+		ID:          s.ID,
+		Name:        s.Name,
+		Kind:        s.Kind,
+		Filters:     s.Filters,
+		Columns:     s.Columns,
+		Format:      s.Format,
+		Frequency:   s.Frequency,
+		Recipients:  s.Recipients,
+		OwnerUserID: s.OwnerUserID,
+		Active:      s.Active,
+		NextRunAt:   s.NextRunAt,
+		LastRunAt:   s.LastRunAt,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(reports *[]SavedReport) *[]domainReport.SavedReport {
+	reportsDomain := make([]domainReport.SavedReport, len(*reports))
+	for i, report := range *reports {
+		reportsDomain[i] = *report.toDomainMapper()
+	}
+	return &reportsDomain
+}
+
+func (r *Run) toDomainMapper() *domainReport.Run {
+	return &domainReport.Run{
+		ID:         r.ID,
+		ReportID:   r.ReportID,
+		PeriodFrom: r.PeriodFrom,
+		PeriodTo:   r.PeriodTo,
+		Rows:       r.Rows,
+		Recipients: r.Recipients,
+		Status:     r.Status,
+		Error:      r.Error,
+		RanAt:      r.RanAt,
+		CreatedAt:  r.CreatedAt,
+	}
+}
@@ -5,45 +5,151 @@ import (
 	"time"
 
 	"caregiver/src/domain"
+	domainAttachment "caregiver/src/domain/attachment"
 	domainErrors "caregiver/src/domain/errors"
 	domainSchedule "caregiver/src/domain/schedule"
+	domainServiceNote "caregiver/src/domain/servicenote"
 	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/repository/psql/softdelete"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Schedule struct {
-	ID                   uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	ClientUserID         uuid.UUID  `gorm:"column:client_user_id;type:uuid"`
-	AssignedUserID       uuid.UUID  `gorm:"column:assigned_user_id;type:uuid"`
-	ServiceName          string     `gorm:"column:service_name"`
-	ScheduledSlotFrom    time.Time  `gorm:"column:scheduled_slot_from"`
-	ScheduledSlotTo      time.Time  `gorm:"column:scheduled_slot_to"`
-	VisitStatus          string     `gorm:"column:visit_status"`
-	CheckinTime          *time.Time `gorm:"column:checkin_time"`
-	CheckoutTime         *time.Time `gorm:"column:checkout_time"`
-	CheckinLocationLat   *float64   `gorm:"column:checkin_location_lat"`
-	CheckinLocationLong  *float64   `gorm:"column:checkin_location_long"`
-	CheckoutLocationLat  *float64   `gorm:"column:checkout_location_lat"`
-	CheckoutLocationLong *float64   `gorm:"column:checkout_location_long"`
-	Tasks                []Task     `gorm:"foreignKey:ScheduleID"`
-	ServiceNote          *string    `gorm:"column:service_note"`
-	CreatedAt            time.Time  `gorm:"autoCreateTime:milli"`
-	UpdatedAt            time.Time  `gorm:"autoUpdateTime:milli"`
+	ID                        uuid.UUID      `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID              uuid.UUID      `gorm:"column:client_user_id;type:uuid"`
+	AssignedUserID            uuid.UUID      `gorm:"column:assigned_user_id;type:uuid"`
+	ServiceName               string         `gorm:"column:service_name"`
+	ScheduledSlotFrom         time.Time      `gorm:"column:scheduled_slot_from"`
+	ScheduledSlotTo           time.Time      `gorm:"column:scheduled_slot_to"`
+	VisitStatus               string         `gorm:"column:visit_status"`
+	CheckinTime               *time.Time     `gorm:"column:checkin_time"`
+	CheckoutTime              *time.Time     `gorm:"column:checkout_time"`
+	CheckinLocationLat        *float64       `gorm:"column:checkin_location_lat"`
+	CheckinLocationLong       *float64       `gorm:"column:checkin_location_long"`
+	CheckoutLocationLat       *float64       `gorm:"column:checkout_location_lat"`
+	CheckoutLocationLong      *float64       `gorm:"column:checkout_location_long"`
+	CheckinVerificationMethod string         `gorm:"column:checkin_verification_method"`
+	CheckinNFCTagID           *uuid.UUID     `gorm:"column:checkin_nfc_tag_id;type:uuid"`
+	CheckinKioskID            *uuid.UUID     `gorm:"column:checkin_kiosk_id;type:uuid"`
+	AttestationStatus         string         `gorm:"column:attestation_status"`
+	AttestationDueAt          *time.Time     `gorm:"column:attestation_due_at"`
+	AttestationRespondedAt    *time.Time     `gorm:"column:attestation_responded_at"`
+	AttestationDeviceID       string         `gorm:"column:attestation_device_id"`
+	Tasks                     []Task         `gorm:"foreignKey:ScheduleID"`
+	Segments                  []Segment      `gorm:"foreignKey:ScheduleID"`
+	Interruptions             []Interruption `gorm:"foreignKey:ScheduleID"`
+	Attachments               []Attachment   `gorm:"foreignKey:ScheduleID"`
+	ServiceNote               *string        `gorm:"column:service_note"`
+	NoteRevisions             []NoteRevision `gorm:"foreignKey:ScheduleID"`
+	ColorTag                  string         `gorm:"column:color_tag"`
+	SeriesID                  *uuid.UUID     `gorm:"column:series_id;type:uuid;index"`
+	RecurrenceFrequency       string         `gorm:"column:recurrence_frequency"`
+	RecurrenceInterval        int            `gorm:"column:recurrence_interval"`
+	RecurrenceUntil           *time.Time     `gorm:"column:recurrence_until"`
+	CreatedAt                 time.Time      `gorm:"autoCreateTime:milli"`
+	UpdatedAt                 time.Time      `gorm:"autoUpdateTime:milli"`
+	DeletedAt                 gorm.DeletedAt `gorm:"index"`
+	DeletedBy                 *uuid.UUID     `gorm:"column:deleted_by;type:uuid"`
+	// Device hints from check-in and the confidence scored at checkout.
+	CheckinDeviceMockLocation *bool                             `gorm:"column:checkin_device_mock_location"`
+	CheckinDeviceRooted       *bool                             `gorm:"column:checkin_device_rooted"`
+	CheckinDeviceEmulator     *bool                             `gorm:"column:checkin_device_emulator"`
+	CheckinClockSkewSeconds   *int                              `gorm:"column:checkin_clock_skew_seconds"`
+	CheckinIntegrityStatus    string                            `gorm:"column:checkin_integrity_status;index"`
+	CheckinIntegrityPlatform  string                            `gorm:"column:checkin_integrity_platform"`
+	CheckinIntegrityDetail    string                            `gorm:"column:checkin_integrity_detail"`
+	VerificationConfidence    *int                              `gorm:"column:verification_confidence"`
+	VerificationSignals       []domainSchedule.ConfidenceSignal `gorm:"column:verification_signals;serializer:json"`
 }
 
 type Task struct {
-	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	ScheduleID  uuid.UUID `gorm:"column:schedule_id;type:uuid"`
-	Title       string    `gorm:"column:title"`
-	Description string    `gorm:"column:description"`
-	Status      string    `gorm:"column:status"`
-	Done        *bool     `gorm:"column:done"`
-	Feedback    *string   `gorm:"column:feedback"`
-	CreatedAt   time.Time `gorm:"autoCreateTime:milli"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime:milli"`
+	ID                     uuid.UUID      `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID             uuid.UUID      `gorm:"column:schedule_id;type:uuid"`
+	Title                  string         `gorm:"column:title"`
+	Description            string         `gorm:"column:description"`
+	Kind                   string         `gorm:"column:kind;default:generic"`
+	MedicationDrugName     string         `gorm:"column:medication_drug_name"`
+	MedicationDose         string         `gorm:"column:medication_dose"`
+	MedicationRoute        string         `gorm:"column:medication_route"`
+	MedicationScheduledAt  *time.Time     `gorm:"column:medication_scheduled_at"`
+	VitalsSystolicBP       *int           `gorm:"column:vitals_systolic_bp"`
+	VitalsDiastolicBP      *int           `gorm:"column:vitals_diastolic_bp"`
+	VitalsHeartRate        *int           `gorm:"column:vitals_heart_rate"`
+	VitalsRespiratoryRate  *int           `gorm:"column:vitals_respiratory_rate"`
+	VitalsTemperature      *float64       `gorm:"column:vitals_temperature"`
+	VitalsOxygenSaturation *int           `gorm:"column:vitals_oxygen_saturation"`
+	VitalsBloodGlucose     *float64       `gorm:"column:vitals_blood_glucose"`
+	Status                 string         `gorm:"column:status"`
+	Done                   *bool          `gorm:"column:done"`
+	Feedback               *string        `gorm:"column:feedback"`
+	CreatedAt              time.Time      `gorm:"autoCreateTime:milli"`
+	UpdatedAt              time.Time      `gorm:"autoUpdateTime:milli"`
+	DeletedAt              gorm.DeletedAt `gorm:"index"`
+}
+
+type Segment struct {
+	ID                        uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID                uuid.UUID  `gorm:"column:schedule_id;type:uuid;index"`
+	Sequence                  int        `gorm:"column:sequence"`
+	SlotFrom                  time.Time  `gorm:"column:slot_from"`
+	SlotTo                    time.Time  `gorm:"column:slot_to"`
+	VisitStatus               string     `gorm:"column:visit_status"`
+	CheckinTime               *time.Time `gorm:"column:checkin_time"`
+	CheckoutTime              *time.Time `gorm:"column:checkout_time"`
+	CheckinLocationLat        *float64   `gorm:"column:checkin_location_lat"`
+	CheckinLocationLong       *float64   `gorm:"column:checkin_location_long"`
+	CheckoutLocationLat       *float64   `gorm:"column:checkout_location_lat"`
+	CheckoutLocationLong      *float64   `gorm:"column:checkout_location_long"`
+	CheckinVerificationMethod string     `gorm:"column:checkin_verification_method"`
+	CheckinNFCTagID           *uuid.UUID `gorm:"column:checkin_nfc_tag_id;type:uuid"`
+	CheckinKioskID            *uuid.UUID `gorm:"column:checkin_kiosk_id;type:uuid"`
+	CreatedAt                 time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt                 time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+// Interruption rows are written by the interruption repository; schedules
+// only preload them to work out the time worked.
+type Interruption struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID      uuid.UUID  `gorm:"column:schedule_id;type:uuid;index"`
+	ClientUserID    uuid.UUID  `gorm:"column:client_user_id;type:uuid;index"`
+	CaregiverUserID uuid.UUID  `gorm:"column:caregiver_user_id;type:uuid;index"`
+	ReasonCode      string     `gorm:"column:reason_code"`
+	Note            string     `gorm:"column:note"`
+	StartedAt       time.Time  `gorm:"column:started_at;index"`
+	EndedAt         *time.Time `gorm:"column:ended_at"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+// Attachment rows are written by the attachment repository; schedules only
+// preload their metadata for the response.
+type Attachment struct {
+	ID                    uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID            uuid.UUID  `gorm:"column:schedule_id;type:uuid;index"`
+	TaskID                *uuid.UUID `gorm:"column:task_id;type:uuid;index"`
+	FileName              string     `gorm:"column:file_name"`
+	ContentType           string     `gorm:"column:content_type"`
+	Size                  int64      `gorm:"column:size"`
+	StorageKey            string     `gorm:"column:storage_key"`
+	RequiredBeforeCheckin bool       `gorm:"column:required_before_checkin"`
+	UploadedByUserID      uuid.UUID  `gorm:"column:uploaded_by_user_id;type:uuid"`
+	CreatedAt             time.Time  `gorm:"autoCreateTime:milli"`
+}
+
+// NoteRevision rows are written by the service note repository; a schedule
+// fetched by ID preloads them for the response.
+type NoteRevision struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID   uuid.UUID  `gorm:"column:schedule_id;type:uuid"`
+	Revision     int        `gorm:"column:revision"`
+	Body         string     `gorm:"column:body;type:text"`
+	AuthorUserID *uuid.UUID `gorm:"column:author_user_id;type:uuid"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime:milli"`
 }
 
 func (Schedule) TableName() string {
@@ -54,6 +160,60 @@ func (Task) TableName() string {
 	return "tasks"
 }
 
+func (Segment) TableName() string {
+	return "schedule_segments"
+}
+
+func (Interruption) TableName() string {
+	return "schedule_interruptions"
+}
+
+func (Attachment) TableName() string {
+	return "schedule_attachments"
+}
+
+func (NoteRevision) TableName() string {
+	return "service_note_revisions"
+}
+
+// withRelations preloads the child rows every schedule response needs.
+func withRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tasks").Preload("Segments", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("sequence ASC")
+	}).Preload("Interruptions", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("started_at ASC")
+	}).Preload("Attachments", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("created_at ASC")
+	})
+}
+
+// searchScope keeps the schedules the query matches. Soft-deleted ones are
+// left out unless q.Deleted asks for them.
+func searchScope(q domainSchedule.SearchQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = softdelete.Scope(q.Deleted)(db)
+		if len(q.Statuses) > 0 {
+			db = db.Where("visit_status IN ?", q.Statuses)
+		}
+		if len(q.AssignedUserIDs) > 0 {
+			db = db.Where("assigned_user_id IN ?", q.AssignedUserIDs)
+		}
+		if len(q.ClientUserIDs) > 0 {
+			db = db.Where("client_user_id IN ?", q.ClientUserIDs)
+		}
+		if len(q.ServiceNames) > 0 {
+			db = db.Where("service_name IN ?", q.ServiceNames)
+		}
+		if q.From != nil {
+			db = db.Where("scheduled_slot_to > ?", *q.From)
+		}
+		if q.To != nil {
+			db = db.Where("scheduled_slot_from < ?", *q.To)
+		}
+		return db
+	}
+}
+
 type Repository struct {
 	DB     *gorm.DB
 	Logger *logger.Logger
@@ -65,16 +225,99 @@ func NewScheduleRepository(db *gorm.DB, loggerInstance *logger.Logger) domainSch
 
 func (r *Repository) GetSchedules() (*[]domainSchedule.Schedule, error) {
 	var schedules []Schedule
-	if err := r.DB.Preload("Tasks").Find(&schedules).Error; err != nil {
+	if err := r.DB.Scopes(withRelations).Find(&schedules).Error; err != nil {
 		r.Logger.Error("Error getting all schedules", zap.Error(err))
 		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
 	}
 	return arrayToDomainMapper(&schedules), nil
 }
 
+func (r *Repository) GetFilteredSchedules(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	if err := r.DB.Scopes(searchScope(filter.Query()), withRelations).
+		Order("scheduled_slot_from ASC").Order("id ASC").
+		Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting filtered schedules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+// streamBatchSize is how many schedules StreamSchedules holds at once; their
+// tasks and segments are preloaded a batch at a time.
+const streamBatchSize = 200
+
+func (r *Repository) StreamSchedules(filter domainSchedule.ListFilter, fn func(*domainSchedule.Schedule) error) error {
+	var schedules []Schedule
+	var fnErr error
+	err := r.DB.Scopes(searchScope(filter.Query()), withRelations).FindInBatches(&schedules, streamBatchSize, func(tx *gorm.DB, batch int) error {
+		for i := range schedules {
+			if fnErr = fn(schedules[i].toDomainMapper()); fnErr != nil {
+				return fnErr
+			}
+		}
+		return nil
+	}).Error
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		r.Logger.Error("Error streaming schedules", zap.Error(err))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+// SearchPaginated returns one page of all schedules, ordered by scheduled
+// start unless another sortable column is requested.
+func (r *Repository) SearchPaginated(filters domain.DataFilters) (*domainSchedule.SearchResultSchedule, error) {
+	var total int64
+	if err := r.DB.Model(&Schedule{}).Scopes(softdelete.Scope(filters.Deleted)).Count(&total).Error; err != nil {
+		r.Logger.Error("Error counting schedules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	sortBy, direction := "scheduled_slot_from", domain.SortAsc
+	if len(filters.SortBy) > 0 {
+		for _, column := range domainSchedule.SortableColumns {
+			if filters.SortBy[0] == column {
+				sortBy = column
+			}
+		}
+	}
+	if filters.SortDirection.IsValid() {
+		direction = filters.SortDirection
+	}
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.PageSize < 1 {
+		filters.PageSize = 10
+	}
+
+	var schedules []Schedule
+	if err := r.DB.Scopes(softdelete.Scope(filters.Deleted), withRelations).
+		Order(sortBy + " " + string(direction)).Order("id ASC").
+		Offset((filters.Page - 1) * filters.PageSize).Limit(filters.PageSize).
+		Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting schedules page", zap.Error(err), zap.Int("page", filters.Page))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	return &domainSchedule.SearchResultSchedule{
+		Data:       arrayToDomainMapper(&schedules),
+		Total:      total,
+		Page:       filters.Page,
+		PageSize:   filters.PageSize,
+		TotalPages: int((total + int64(filters.PageSize) - 1) / int64(filters.PageSize)),
+	}, nil
+}
+
 func (r *Repository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
 	var schedule Schedule
-	err := r.DB.Preload("Tasks").Where("id = ?", id).First(&schedule).Error
+	err := r.DB.Scopes(withRelations).Preload("NoteRevisions", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("revision ASC")
+	}).Where("id = ?", id).First(&schedule).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			r.Logger.Warn("Schedule not found", zap.String("id", id.String()))
@@ -90,10 +333,10 @@ func (r *Repository) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, er
 
 func (r *Repository) GetTodaySchedules(userID uuid.UUID) (*[]domainSchedule.Schedule, error) {
 	var schedules []Schedule
-	today := time.Now().Truncate(24 * time.Hour)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
 	tomorrow := today.Add(24 * time.Hour)
 
-	if err := r.DB.Preload("Tasks").
+	if err := r.DB.Scopes(withRelations).
 		Where("client_user_id = ?", userID).
 		Where("scheduled_slot_from >= ? AND scheduled_slot_from < ?", today, tomorrow).
 		Find(&schedules).Error; err != nil {
@@ -105,7 +348,7 @@ func (r *Repository) GetTodaySchedules(userID uuid.UUID) (*[]domainSchedule.Sche
 
 func (r *Repository) UpdateSchedule(id uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
 	var scheduleObj Schedule
-	if err := r.DB.Preload("Tasks").Where("id = ?", id).First(&scheduleObj).Error; err != nil {
+	if err := r.DB.Scopes(withRelations).Where("id = ?", id).First(&scheduleObj).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			r.Logger.Warn("Schedule not found for update", zap.String("id", id.String()))
 			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
@@ -114,6 +357,16 @@ func (r *Repository) UpdateSchedule(id uuid.UUID, updates map[string]interface{}
 		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
 	}
 
+	// Map updates bypass the field serializer, so encode the signals here.
+	if signals, ok := updates["verification_signals"]; ok {
+		encoded, err := json.Marshal(signals)
+		if err != nil {
+			r.Logger.Error("Error encoding verification signals", zap.Error(err), zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		updates["verification_signals"] = string(encoded)
+	}
+
 	err := r.DB.Model(&scheduleObj).Updates(updates).Error
 	if err != nil {
 		r.Logger.Error("Error updating schedule", zap.Error(err), zap.String("id", id.String()))
@@ -131,7 +384,7 @@ func (r *Repository) UpdateSchedule(id uuid.UUID, updates map[string]interface{}
 		}
 	}
 
-	if err := r.DB.Preload("Tasks").Where("id = ?", id).First(&scheduleObj).Error; err != nil {
+	if err := r.DB.Scopes(withRelations).Where("id = ?", id).First(&scheduleObj).Error; err != nil {
 		r.Logger.Error("Error retrieving updated schedule", zap.Error(err), zap.String("id", id.String()))
 		return nil, err
 	}
@@ -139,6 +392,19 @@ func (r *Repository) UpdateSchedule(id uuid.UUID, updates map[string]interface{}
 	return scheduleObj.toDomainMapper(), nil
 }
 
+func (r *Repository) GetTaskByID(taskID uuid.UUID) (*domainSchedule.Task, error) {
+	var taskObj Task
+	if err := r.DB.Where("id = ?", taskID).First(&taskObj).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Task not found", zap.String("taskID", taskID.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting task by ID", zap.Error(err), zap.String("taskID", taskID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return taskObj.toDomainMapper(), nil
+}
+
 func (r *Repository) UpdateTask(taskID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Task, error) {
 	var taskObj Task
 	taskObj.ID = taskID
@@ -150,6 +416,10 @@ func (r *Repository) UpdateTask(taskID uuid.UUID, updates map[string]interface{}
 	}
 
 	if err := r.DB.Where("id = ?", taskID).First(&taskObj).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Task not found for update", zap.String("taskID", taskID.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
 		r.Logger.Error("Error retrieving updated task", zap.Error(err), zap.String("taskID", taskID.String()))
 		return nil, err
 	}
@@ -157,12 +427,110 @@ func (r *Repository) UpdateTask(taskID uuid.UUID, updates map[string]interface{}
 	return taskObj.toDomainMapper(), nil
 }
 
+func (r *Repository) Delete(id uuid.UUID, deletedBy *uuid.UUID) error {
+	deleted, err := softdelete.Delete(r.DB, &Schedule{}, id, deletedBy)
+	if err != nil {
+		r.Logger.Error("Error deleting schedule", zap.Error(err), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if !deleted {
+		r.Logger.Warn("Schedule not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) Restore(id uuid.UUID) (*domainSchedule.Schedule, error) {
+	restored, err := softdelete.Restore(r.DB, &Schedule{}, id)
+	if err != nil {
+		r.Logger.Error("Error restoring schedule", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if !restored {
+		r.Logger.Warn("Deleted schedule not found for restore", zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return r.GetScheduleByID(id)
+}
+
+func (r *Repository) DeleteTask(taskID uuid.UUID) error {
+	tx := r.DB.Delete(&Task{}, "id = ?", taskID)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting task", zap.Error(tx.Error), zap.String("taskID", taskID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Task not found for deletion", zap.String("taskID", taskID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) RestoreTask(taskID uuid.UUID) (*domainSchedule.Task, error) {
+	restored, err := softdelete.Restore(r.DB, &Task{}, taskID)
+	if err != nil {
+		r.Logger.Error("Error restoring task", zap.Error(err), zap.String("taskID", taskID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if !restored {
+		r.Logger.Warn("Deleted task not found for restore", zap.String("taskID", taskID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	var taskObj Task
+	if err := r.DB.Where("id = ?", taskID).First(&taskObj).Error; err != nil {
+		r.Logger.Error("Error retrieving restored task", zap.Error(err), zap.String("taskID", taskID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return taskObj.toDomainMapper(), nil
+}
+
+func deletedAtToDomain(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return &deletedAt.Time
+}
+
 func (s *Schedule) toDomainMapper() *domainSchedule.Schedule {
 	tasksDomain := make([]domainSchedule.Task, len(s.Tasks))
 	for i, task := range s.Tasks {
 		tasksDomain[i] = *task.toDomainMapper()
 	}
 
+	segmentsDomain := make([]domainSchedule.Segment, len(s.Segments))
+	for i, segment := range s.Segments {
+		segmentsDomain[i] = *segment.toDomainMapper()
+	}
+
+	interruptionsDomain := make([]domainSchedule.Interruption, len(s.Interruptions))
+	for i, interruption := range s.Interruptions {
+		interruptionsDomain[i] = *interruption.ToDomainMapper()
+	}
+
+	attachmentsDomain := make([]domainAttachment.Attachment, len(s.Attachments))
+	for i, attachment := range s.Attachments {
+		attachmentsDomain[i] = *attachment.toDomainMapper()
+	}
+
+	var noteRevisionsDomain []domainServiceNote.Revision
+	for _, revision := range s.NoteRevisions {
+		noteRevisionsDomain = append(noteRevisionsDomain, *revision.toDomainMapper())
+	}
+
+	var recurrence *domainSchedule.Recurrence
+	if s.RecurrenceFrequency != "" && s.RecurrenceUntil != nil {
+		recurrence = &domainSchedule.Recurrence{
+			Frequency: s.RecurrenceFrequency,
+			Interval:  s.RecurrenceInterval,
+			Until:     *s.RecurrenceUntil,
+		}
+	}
+
+	var confidence *domainSchedule.Confidence
+	if s.VerificationConfidence != nil {
+		confidence = &domainSchedule.Confidence{Score: *s.VerificationConfidence, Signals: s.VerificationSignals}
+	}
+
 	return &domainSchedule.Schedule{
 		ID:             s.ID,
 		ClientUserID:   s.ClientUserID,
@@ -183,25 +551,177 @@ func (s *Schedule) toDomainMapper() *domainSchedule.Schedule {
 			Lat:  s.CheckoutLocationLat,
 			Long: s.CheckoutLocationLong,
 		},
-		Tasks:       tasksDomain,
-		ServiceNote: s.ServiceNote,
-		CreatedAt:   s.CreatedAt,
-		UpdatedAt:   s.UpdatedAt,
+		CheckinVerification: domainSchedule.Verification{
+			Method:   s.CheckinVerificationMethod,
+			NFCTagID: s.CheckinNFCTagID,
+			KioskID:  s.CheckinKioskID,
+			Device: domainSchedule.DeviceIntegrity{
+				MockLocation: s.CheckinDeviceMockLocation,
+				Rooted:       s.CheckinDeviceRooted,
+				Emulator:     s.CheckinDeviceEmulator,
+			},
+			ClockSkewSeconds: s.CheckinClockSkewSeconds,
+			Integrity: domainSchedule.IntegrityCheck{
+				Status:   s.CheckinIntegrityStatus,
+				Platform: s.CheckinIntegrityPlatform,
+				Detail:   s.CheckinIntegrityDetail,
+			},
+		},
+		Confidence: confidence,
+		Attestation: domainSchedule.Attestation{
+			Status:      s.AttestationStatus,
+			DueAt:       s.AttestationDueAt,
+			RespondedAt: s.AttestationRespondedAt,
+			DeviceID:    s.AttestationDeviceID,
+		},
+		Tasks:         tasksDomain,
+		Segments:      segmentsDomain,
+		Interruptions: interruptionsDomain,
+		Attachments:   attachmentsDomain,
+		ServiceNote:   s.ServiceNote,
+		NoteRevisions: noteRevisionsDomain,
+		ColorTag:      s.ColorTag,
+		SeriesID:      s.SeriesID,
+		Recurrence:    recurrence,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+		DeletedAt:     deletedAtToDomain(s.DeletedAt),
+	}
+}
+
+func (i *Interruption) ToDomainMapper() *domainSchedule.Interruption {
+	return &domainSchedule.Interruption{
+		ID:              i.ID,
+		ScheduleID:      i.ScheduleID,
+		ClientUserID:    i.ClientUserID,
+		CaregiverUserID: i.CaregiverUserID,
+		ReasonCode:      i.ReasonCode,
+		Note:            i.Note,
+		StartedAt:       i.StartedAt,
+		EndedAt:         i.EndedAt,
+		CreatedAt:       i.CreatedAt,
+		UpdatedAt:       i.UpdatedAt,
+	}
+}
+
+func (a *Attachment) toDomainMapper() *domainAttachment.Attachment {
+	return &domainAttachment.Attachment{
+		ID:                    a.ID,
+		ScheduleID:            a.ScheduleID,
+		TaskID:                a.TaskID,
+		FileName:              a.FileName,
+		ContentType:           a.ContentType,
+		Size:                  a.Size,
+		StorageKey:            a.StorageKey,
+		RequiredBeforeCheckin: a.RequiredBeforeCheckin,
+		UploadedByUserID:      a.UploadedByUserID,
+		CreatedAt:             a.CreatedAt,
+	}
+}
+
+func (n *NoteRevision) toDomainMapper() *domainServiceNote.Revision {
+	return &domainServiceNote.Revision{
+		ID:           n.ID,
+		ScheduleID:   n.ScheduleID,
+		Revision:     n.Revision,
+		Body:         n.Body,
+		AuthorUserID: n.AuthorUserID,
+		CreatedAt:    n.CreatedAt,
+	}
+}
+
+func (sg *Segment) toDomainMapper() *domainSchedule.Segment {
+	return &domainSchedule.Segment{
+		ID:           sg.ID,
+		ScheduleID:   sg.ScheduleID,
+		Sequence:     sg.Sequence,
+		From:         sg.SlotFrom,
+		To:           sg.SlotTo,
+		VisitStatus:  sg.VisitStatus,
+		CheckinTime:  sg.CheckinTime,
+		CheckoutTime: sg.CheckoutTime,
+		CheckinLocation: domainSchedule.Location{
+			Lat:  sg.CheckinLocationLat,
+			Long: sg.CheckinLocationLong,
+		},
+		CheckoutLocation: domainSchedule.Location{
+			Lat:  sg.CheckoutLocationLat,
+			Long: sg.CheckoutLocationLong,
+		},
+		CheckinVerification: domainSchedule.Verification{
+			Method:   sg.CheckinVerificationMethod,
+			NFCTagID: sg.CheckinNFCTagID,
+			KioskID:  sg.CheckinKioskID,
+		},
+		CreatedAt: sg.CreatedAt,
+		UpdatedAt: sg.UpdatedAt,
 	}
 }
 
 func (t *Task) toDomainMapper() *domainSchedule.Task {
-	return &domainSchedule.Task{
+	task := &domainSchedule.Task{
 		ID:          t.ID,
 		ScheduleID:  t.ScheduleID,
 		Title:       t.Title,
 		Description: t.Description,
+		Kind:        t.Kind,
 		Status:      t.Status,
 		Done:        t.Done,
 		Feedback:    t.Feedback,
 		CreatedAt:   t.CreatedAt,
 		UpdatedAt:   t.UpdatedAt,
+		DeletedAt:   deletedAtToDomain(t.DeletedAt),
+	}
+	if task.Kind == "" {
+		task.Kind = domainSchedule.TaskKindGeneric
+	}
+	if t.MedicationScheduledAt != nil {
+		task.Medication = &domainSchedule.TaskMedication{
+			DrugName:    t.MedicationDrugName,
+			Dose:        t.MedicationDose,
+			Route:       t.MedicationRoute,
+			ScheduledAt: *t.MedicationScheduledAt,
+		}
+	}
+	vitals := domainSchedule.Vitals{
+		SystolicBP:       t.VitalsSystolicBP,
+		DiastolicBP:      t.VitalsDiastolicBP,
+		HeartRate:        t.VitalsHeartRate,
+		RespiratoryRate:  t.VitalsRespiratoryRate,
+		Temperature:      t.VitalsTemperature,
+		OxygenSaturation: t.VitalsOxygenSaturation,
+		BloodGlucose:     t.VitalsBloodGlucose,
+	}
+	if !vitals.Empty() {
+		task.Vitals = &vitals
 	}
+	return task
+}
+
+func taskFromDomainMapper(task *domainSchedule.Task) Task {
+	model := Task{
+		ID:          task.ID,
+		ScheduleID:  task.ScheduleID,
+		Title:       task.Title,
+		Description: task.Description,
+		Kind:        task.Kind,
+		Status:      task.Status,
+		Done:        task.Done,
+		Feedback:    task.Feedback,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+	}
+	if m := task.Medication; m != nil {
+		scheduledAt := m.ScheduledAt
+		model.MedicationDrugName, model.MedicationDose, model.MedicationRoute = m.DrugName, m.Dose, m.Route
+		model.MedicationScheduledAt = &scheduledAt
+	}
+	if v := task.Vitals; v != nil {
+		model.VitalsSystolicBP, model.VitalsDiastolicBP = v.SystolicBP, v.DiastolicBP
+		model.VitalsHeartRate, model.VitalsRespiratoryRate = v.HeartRate, v.RespiratoryRate
+		model.VitalsTemperature, model.VitalsOxygenSaturation, model.VitalsBloodGlucose = v.Temperature, v.OxygenSaturation, v.BloodGlucose
+	}
+	return model
 }
 
 func arrayToDomainMapper(schedules *[]Schedule) *[]domainSchedule.Schedule {
@@ -240,44 +760,85 @@ func (r *Repository) Create(newSchedule *domainSchedule.Schedule) (*domainSchedu
 
 func fromDomainMapper(s *domainSchedule.Schedule) *Schedule {
 	tasksModel := make([]Task, len(s.Tasks))
-	for i, task := range s.Tasks {
-		tasksModel[i] = Task{
-			ID:          task.ID,
-			ScheduleID:  task.ScheduleID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      task.Status,
-			Done:        task.Done,
-			Feedback:    task.Feedback,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   task.UpdatedAt,
+	for i := range s.Tasks {
+		tasksModel[i] = taskFromDomainMapper(&s.Tasks[i])
+	}
+
+	segmentsModel := make([]Segment, len(s.Segments))
+	for i, segment := range s.Segments {
+		segmentsModel[i] = Segment{
+			ID:                        segment.ID,
+			ScheduleID:                segment.ScheduleID,
+			Sequence:                  segment.Sequence,
+			SlotFrom:                  segment.From,
+			SlotTo:                    segment.To,
+			VisitStatus:               segment.VisitStatus,
+			CheckinTime:               segment.CheckinTime,
+			CheckoutTime:              segment.CheckoutTime,
+			CheckinLocationLat:        segment.CheckinLocation.Lat,
+			CheckinLocationLong:       segment.CheckinLocation.Long,
+			CheckoutLocationLat:       segment.CheckoutLocation.Lat,
+			CheckoutLocationLong:      segment.CheckoutLocation.Long,
+			CheckinVerificationMethod: segment.CheckinVerification.Method,
+			CheckinNFCTagID:           segment.CheckinVerification.NFCTagID,
+			CheckinKioskID:            segment.CheckinVerification.KioskID,
+			CreatedAt:                 segment.CreatedAt,
+			UpdatedAt:                 segment.UpdatedAt,
 		}
 	}
 
-	return &Schedule{
-		ID:                   s.ID,
-		ClientUserID:         s.ClientUserID,
-		AssignedUserID:       s.AssignedUserID,
-		ServiceName:          s.ServiceName,
-		ScheduledSlotFrom:    s.ScheduledSlot.From,
-		ScheduledSlotTo:      s.ScheduledSlot.To,
-		VisitStatus:          s.VisitStatus,
-		CheckinTime:          s.CheckinTime,
-		CheckoutTime:         s.CheckoutTime,
-		CheckinLocationLat:   s.CheckinLocation.Lat,
-		CheckinLocationLong:  s.CheckinLocation.Long,
-		CheckoutLocationLat:  s.CheckoutLocation.Lat,
-		CheckoutLocationLong: s.CheckoutLocation.Long,
-		Tasks:                tasksModel,
-		ServiceNote:          s.ServiceNote,
-		CreatedAt:            s.CreatedAt,
-		UpdatedAt:            s.UpdatedAt,
+	model := &Schedule{
+		ID:                        s.ID,
+		ClientUserID:              s.ClientUserID,
+		AssignedUserID:            s.AssignedUserID,
+		ServiceName:               s.ServiceName,
+		ScheduledSlotFrom:         s.ScheduledSlot.From,
+		ScheduledSlotTo:           s.ScheduledSlot.To,
+		VisitStatus:               s.VisitStatus,
+		CheckinTime:               s.CheckinTime,
+		CheckoutTime:              s.CheckoutTime,
+		CheckinLocationLat:        s.CheckinLocation.Lat,
+		CheckinLocationLong:       s.CheckinLocation.Long,
+		CheckoutLocationLat:       s.CheckoutLocation.Lat,
+		CheckoutLocationLong:      s.CheckoutLocation.Long,
+		CheckinVerificationMethod: s.CheckinVerification.Method,
+		CheckinNFCTagID:           s.CheckinVerification.NFCTagID,
+		CheckinKioskID:            s.CheckinVerification.KioskID,
+		CheckinDeviceMockLocation: s.CheckinVerification.Device.MockLocation,
+		CheckinDeviceRooted:       s.CheckinVerification.Device.Rooted,
+		CheckinDeviceEmulator:     s.CheckinVerification.Device.Emulator,
+		CheckinClockSkewSeconds:   s.CheckinVerification.ClockSkewSeconds,
+		CheckinIntegrityStatus:    s.CheckinVerification.Integrity.Status,
+		CheckinIntegrityPlatform:  s.CheckinVerification.Integrity.Platform,
+		CheckinIntegrityDetail:    s.CheckinVerification.Integrity.Detail,
+		AttestationStatus:         s.Attestation.Status,
+		AttestationDueAt:          s.Attestation.DueAt,
+		AttestationRespondedAt:    s.Attestation.RespondedAt,
+		AttestationDeviceID:       s.Attestation.DeviceID,
+		Tasks:                     tasksModel,
+		Segments:                  segmentsModel,
+		ServiceNote:               s.ServiceNote,
+		ColorTag:                  s.ColorTag,
+		SeriesID:                  s.SeriesID,
+		CreatedAt:                 s.CreatedAt,
+		UpdatedAt:                 s.UpdatedAt,
+	}
+	if s.Confidence != nil {
+		model.VerificationConfidence = &s.Confidence.Score
+		model.VerificationSignals = s.Confidence.Signals
 	}
+	if s.Recurrence != nil {
+		until := s.Recurrence.Until
+		model.RecurrenceFrequency = s.Recurrence.Frequency
+		model.RecurrenceInterval = s.Recurrence.Interval
+		model.RecurrenceUntil = &until
+	}
+	return model
 }
 
 func (r *Repository) GetSchedulesByAssignedUserIDPaginated(assignedUserID uuid.UUID, filters domain.DataFilters) (*domainSchedule.SearchResultSchedule, error) {
 
-	query := r.DB.Session(&gorm.Session{PrepareStmt: false}).Model(&Schedule{}).Preload("Tasks").Where("assigned_user_id = ?", assignedUserID)
+	query := r.DB.Session(&gorm.Session{PrepareStmt: false}).Model(&Schedule{}).Scopes(withRelations).Where("assigned_user_id = ?", assignedUserID)
 
 	for _, dateFilter := range filters.DateRangeFilters {
 		if dateFilter.Field == "scheduled_slot_from" { // Assuming filtering on scheduled_slot_from
@@ -333,9 +894,54 @@ func (r *Repository) GetSchedulesByAssignedUserIDPaginated(assignedUserID uuid.U
 	return result, nil
 }
 
+// Search returns one page of schedules matching the query, ordered by
+// scheduled start unless another sortable column is requested.
+func (r *Repository) Search(q domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, error) {
+	filter := searchScope(q)
+
+	var total int64
+	if err := r.DB.Model(&Schedule{}).Scopes(filter).Count(&total).Error; err != nil {
+		r.Logger.Error("Error counting schedules for search", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	sortBy, direction := "scheduled_slot_from", domain.SortAsc
+	for _, column := range domainSchedule.SortableColumns {
+		if q.SortBy == column {
+			sortBy = column
+		}
+	}
+	if q.SortDirection.IsValid() {
+		direction = q.SortDirection
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize < 1 {
+		q.PageSize = 10
+	}
+
+	var schedules []Schedule
+	if err := r.DB.Scopes(filter, withRelations).
+		Order(sortBy + " " + string(direction)).Order("id ASC").
+		Offset((q.Page - 1) * q.PageSize).Limit(q.PageSize).
+		Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error searching schedules", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	return &domainSchedule.SearchResultSchedule{
+		Data:       arrayToDomainMapper(&schedules),
+		Total:      total,
+		Page:       q.Page,
+		PageSize:   q.PageSize,
+		TotalPages: int((total + int64(q.PageSize) - 1) / int64(q.PageSize)),
+	}, nil
+}
+
 func (r *Repository) GetSchedulesInProgressByAssignedUserID(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error) {
 	var schedules []Schedule
-	if err := r.DB.Preload("Tasks").
+	if err := r.DB.Scopes(withRelations).
 		Where("assigned_user_id = ? AND visit_status = ?", assignedUserID, "in_progress").
 		Find(&schedules).Error; err != nil {
 		r.Logger.Error("Error getting schedules in progress by assigned user ID", zap.Error(err), zap.String("assignedUserID", assignedUserID.String()))
@@ -343,3 +949,234 @@ func (r *Repository) GetSchedulesInProgressByAssignedUserID(assignedUserID uuid.
 	}
 	return arrayToDomainMapper(&schedules), nil
 }
+
+// GetActiveSchedulesBetween returns schedules that still occupy a caregiver and
+// overlap [from, to). An empty assignedUserIDs matches every caregiver.
+func (r *Repository) GetActiveSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	query := r.DB.
+		Where("visit_status IN ?", domainSchedule.ActiveStatuses).
+		Where("scheduled_slot_from < ? AND scheduled_slot_to > ?", to, from)
+	if len(assignedUserIDs) > 0 {
+		query = query.Where("assigned_user_id IN ?", assignedUserIDs)
+	}
+	if err := query.Order("scheduled_slot_from ASC").Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting active schedules in range", zap.Error(err), zap.Time("from", from), zap.Time("to", to))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+func (r *Repository) GetUnstartedSchedulesBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	if err := r.DB.
+		Where("visit_status = ? AND checkin_time IS NULL AND scheduled_slot_from < ?", "upcoming", before).
+		Order("scheduled_slot_from ASC").
+		Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting unstarted schedules", zap.Error(err), zap.Time("before", before))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+func (r *Repository) GetStuckSchedulesBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	if err := withRelations(r.DB).
+		Where("visit_status = ? AND scheduled_slot_to < ?", "in_progress", before).
+		Order("scheduled_slot_to ASC").
+		Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting stuck schedules", zap.Error(err), zap.Time("before", before))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+// MarkMissedBefore moves upcoming visits whose slot ended before the given
+// time without a check-in, and their segments, to "missed". The rows are
+// locked while they change so a late check-in cannot interleave.
+func (r *Repository) MarkMissedBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Model(&Schedule{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("visit_status = ? AND checkin_time IS NULL AND scheduled_slot_to < ?", "upcoming", before).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Model(&Schedule{}).Where("id IN ?", ids).Update("visit_status", "missed").Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&Segment{}).Where("schedule_id IN ? AND visit_status = ?", ids, "upcoming").
+			Update("visit_status", "missed").Error; err != nil {
+			return err
+		}
+		return tx.Scopes(withRelations).Where("id IN ?", ids).Order("scheduled_slot_to ASC").Find(&schedules).Error
+	})
+	if err != nil {
+		r.Logger.Error("Error marking missed schedules", zap.Error(err), zap.Time("before", before))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+func (r *Repository) GetWorkedSchedulesBetween(assignedUserID uuid.UUID, from, to time.Time) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	if err := r.DB.Scopes(withRelations).
+		Where("assigned_user_id = ? AND visit_status IN ?", assignedUserID, domainSchedule.WorkedStatuses).
+		Where("scheduled_slot_from < ? AND scheduled_slot_to > ?", to, from).
+		Order("scheduled_slot_from ASC").
+		Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting worked schedules in range", zap.Error(err), zap.String("assignedUserID", assignedUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+func (r *Repository) GetCheckedInSchedulesBetween(from, to time.Time, assignedUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	query := r.DB.Scopes(withRelations).
+		Where("visit_status IN ?", domainSchedule.WorkedStatuses).
+		Where("checkin_time >= ? AND checkin_time < ?", from, to)
+	if len(assignedUserIDs) > 0 {
+		query = query.Where("assigned_user_id IN ?", assignedUserIDs)
+	}
+	if err := query.Order("checkin_time ASC").Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting checked-in schedules in range", zap.Error(err), zap.Time("from", from), zap.Time("to", to))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+func (r *Repository) GetPendingAttestationsDueBefore(before time.Time) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	if err := r.DB.
+		Where("attestation_status = ? AND attestation_due_at < ?", domainSchedule.AttestationPending, before).
+		Order("attestation_due_at ASC").
+		Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting pending attestations", zap.Error(err), zap.Time("before", before))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+func (r *Repository) GetCompletedSchedulesBetween(from, to time.Time, clientUserIDs []uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	if len(clientUserIDs) == 0 {
+		return arrayToDomainMapper(&schedules), nil
+	}
+	if err := r.DB.Scopes(withRelations).
+		Where("visit_status = ? AND client_user_id IN ?", "completed", clientUserIDs).
+		Where("checkout_time >= ? AND checkout_time < ?", from, to).
+		Order("checkout_time ASC").
+		Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting completed schedules in range", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+// GetUpcomingSeriesSchedules returns the visits of a recurring series that
+// start at or after from and have not been started, earliest first.
+func (r *Repository) GetUpcomingSeriesSchedules(seriesID uuid.UUID, from time.Time) (*[]domainSchedule.Schedule, error) {
+	var schedules []Schedule
+	if err := r.DB.Scopes(withRelations).
+		Where("series_id = ? AND visit_status IN ? AND scheduled_slot_from >= ?", seriesID, []string{"upcoming", domainSchedule.StatusDraft}, from).
+		Order("scheduled_slot_from ASC").
+		Find(&schedules).Error; err != nil {
+		r.Logger.Error("Error getting upcoming series schedules", zap.Error(err), zap.String("seriesID", seriesID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&schedules), nil
+}
+
+func (r *Repository) ClaimOpenSchedule(id uuid.UUID, assignedUserID uuid.UUID) (*domainSchedule.Schedule, error) {
+	result := r.DB.Model(&Schedule{}).
+		Where("id = ? AND assigned_user_id = ? AND visit_status = ?", id, uuid.Nil, "upcoming").
+		Update("assigned_user_id", assignedUserID)
+	if result.Error != nil {
+		r.Logger.Error("Error claiming open schedule", zap.Error(result.Error), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetScheduleByID(id); err != nil {
+			return nil, err
+		}
+		r.Logger.Warn("Open schedule already claimed", zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.Conflict)
+	}
+	return r.GetScheduleByID(id)
+}
+
+// GetTaskOutcomes groups the client's delivered tasks the same way as
+// domainSchedule.TaskCategory. Visit starts are truncated as local times in
+// timezone and the period start converted back to an instant, so evening
+// visits count towards the organization's day rather than UTC's.
+func (r *Repository) GetTaskOutcomes(clientUserID uuid.UUID, from, to time.Time, period string, timezone string) ([]domainSchedule.TaskOutcomes, error) {
+	var rows []struct {
+		Category     string
+		PeriodStart  time.Time
+		Total        int
+		Completed    int
+		NotCompleted int
+	}
+	err := r.DB.Table("tasks AS t").
+		Select("CASE WHEN t.kind IN ? THEN t.kind ELSE lower(trim(t.title)) END AS category, "+
+			"date_trunc(?, s.scheduled_slot_from AT TIME ZONE ?) AT TIME ZONE ? AS period_start, COUNT(*) AS total, "+
+			"COUNT(*) FILTER (WHERE t.done = true) AS completed, COUNT(*) FILTER (WHERE t.done = false) AS not_completed",
+			[]string{domainSchedule.TaskKindMedication, domainSchedule.TaskKindVitals}, period, timezone, timezone).
+		Joins("JOIN schedules AS s ON s.id = t.schedule_id").
+		Where("s.client_user_id = ? AND s.visit_status IN ?", clientUserID, domainSchedule.DeliveredStatuses).
+		Where("s.scheduled_slot_from >= ? AND s.scheduled_slot_from < ?", from, to).
+		Where("t.deleted_at IS NULL AND s.deleted_at IS NULL").
+		Group("1, 2").Order("1, 2").
+		Scan(&rows).Error
+	if err != nil {
+		r.Logger.Error("Error getting task outcomes", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	outcomes := make([]domainSchedule.TaskOutcomes, len(rows))
+	for i, row := range rows {
+		outcomes[i] = domainSchedule.TaskOutcomes{
+			Category:     row.Category,
+			PeriodStart:  row.PeriodStart,
+			Total:        row.Total,
+			Completed:    row.Completed,
+			NotCompleted: row.NotCompleted,
+			Unrecorded:   row.Total - row.Completed - row.NotCompleted,
+		}
+	}
+	return outcomes, nil
+}
+
+func (r *Repository) UpdateSegment(segmentID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Segment, error) {
+	var segmentObj Segment
+	segmentObj.ID = segmentID
+
+	err := r.DB.Model(&segmentObj).Updates(updates).Error
+	if err != nil {
+		r.Logger.Error("Error updating schedule segment", zap.Error(err), zap.String("segmentID", segmentID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+
+	if err := r.DB.Where("id = ?", segmentID).First(&segmentObj).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Schedule segment not found", zap.String("segmentID", segmentID.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error retrieving updated schedule segment", zap.Error(err), zap.String("segmentID", segmentID.String()))
+		return nil, err
+	}
+
+	return segmentObj.toDomainMapper(), nil
+}
+
+// Transaction runs fn against a copy of the repository bound to a single
+// database transaction, committing when fn returns nil.
+func (r *Repository) Transaction(fn func(repo domainSchedule.IScheduleRepository) error) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&Repository{DB: tx, Logger: r.Logger})
+	})
+}
@@ -0,0 +1,61 @@
+package schedule
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+	cleanup := func() { db.Close() }
+	return gormDB, mock, cleanup
+}
+
+func setupLogger(t *testing.T) *logger.Logger {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	return loggerInstance
+}
+
+func TestGetTaskOutcomesTruncatesInTimeZone(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+	repo := NewScheduleRepository(db, setupLogger(t))
+
+	clientID := uuid.New()
+	from := time.Date(2025, 3, 1, 6, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 5, 0, 0, 0, time.UTC)
+	weekStart := time.Date(2025, 3, 3, 6, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("date_trunc($3, s.scheduled_slot_from AT TIME ZONE $4) AT TIME ZONE $5 AS period_start")).
+		WithArgs(domainSchedule.TaskKindMedication, domainSchedule.TaskKindVitals, domainSchedule.HistoryPeriodWeek,
+			"America/Chicago", "America/Chicago", clientID, "completed", "partially_completed", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"category", "period_start", "total", "completed", "not_completed"}).
+			AddRow("bathing", weekStart, 3, 2, 1))
+
+	outcomes, err := repo.GetTaskOutcomes(clientID, from, to, domainSchedule.HistoryPeriodWeek, "America/Chicago")
+	require.NoError(t, err)
+	require.Len(t, outcomes, 1)
+	assert.Equal(t, "bathing", outcomes[0].Category)
+	assert.True(t, outcomes[0].PeriodStart.Equal(weekStart))
+	assert.Equal(t, 0, outcomes[0].Unrecorded)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,127 @@
+package scheduleview
+
+import (
+	"time"
+
+	"caregiver/src/domain"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainScheduleView "caregiver/src/domain/scheduleview"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type View struct {
+	ID            uuid.UUID                  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	OwnerUserID   uuid.UUID                  `gorm:"column:owner_user_id;type:uuid;uniqueIndex:idx_schedule_views_owner_name"`
+	Name          string                     `gorm:"column:name;uniqueIndex:idx_schedule_views_owner_name"`
+	Filters       domainScheduleView.Filters `gorm:"column:filters;serializer:json"`
+	SortBy        string                     `gorm:"column:sort_by"`
+	SortDirection string                     `gorm:"column:sort_direction"`
+	ColorRules    []domainSchedule.ColorRule `gorm:"column:color_rules;serializer:json"`
+	CreatedAt     time.Time                  `gorm:"autoCreateTime:milli"`
+	UpdatedAt     time.Time                  `gorm:"autoUpdateTime:milli"`
+}
+
+func (View) TableName() string {
+	return "schedule_views"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewScheduleViewRepository(db *gorm.DB, loggerInstance *logger.Logger) domainScheduleView.IScheduleViewRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(view *domainScheduleView.View) (*domainScheduleView.View, error) {
+	model := fromDomainMapper(view)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating schedule view", zap.Error(err), zap.String("ownerUserID", view.OwnerUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Schedule view created successfully", zap.String("viewID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainScheduleView.View, error) {
+	var model View
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Schedule view not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting schedule view by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByOwner(ownerUserID uuid.UUID) (*[]domainScheduleView.View, error) {
+	var models []View
+	if err := r.DB.Where("owner_user_id = ?", ownerUserID).Order("name ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting schedule views for owner", zap.Error(err), zap.String("ownerUserID", ownerUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	views := make([]domainScheduleView.View, len(models))
+	for i := range models {
+		views[i] = *models[i].toDomainMapper()
+	}
+	return &views, nil
+}
+
+// Update replaces the saved view. Save is used rather than a column map so
+// the JSON columns go through their serializer.
+func (r *Repository) Update(view *domainScheduleView.View) (*domainScheduleView.View, error) {
+	model := fromDomainMapper(view)
+	if err := r.DB.Omit("created_at").Save(model).Error; err != nil {
+		r.Logger.Error("Error updating schedule view", zap.Error(err), zap.String("id", view.ID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(view.ID)
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	tx := r.DB.Delete(&View{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting schedule view", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Schedule view not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (v *View) toDomainMapper() *domainScheduleView.View {
+	return &domainScheduleView.View{
+		ID:            v.ID,
+		OwnerUserID:   v.OwnerUserID,
+		Name:          v.Name,
+		Filters:       v.Filters,
+		SortBy:        v.SortBy,
+		SortDirection: domain.SortDirection(v.SortDirection),
+		ColorRules:    v.ColorRules,
+		CreatedAt:     v.CreatedAt,
+		UpdatedAt:     v.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(v *domainScheduleView.View) *View {
+	return &View{
+		ID:            v.ID,
+		OwnerUserID:   v.OwnerUserID,
+		Name:          v.Name,
+		Filters:       v.Filters,
+		SortBy:        v.SortBy,
+		SortDirection: string(v.SortDirection),
+		ColorRules:    v.ColorRules,
+	}
+}
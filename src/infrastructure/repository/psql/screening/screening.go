@@ -0,0 +1,153 @@
+package screening
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainScreening "caregiver/src/domain/screening"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Check struct {
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CaregiverUserID uuid.UUID  `gorm:"column:caregiver_user_id;type:uuid;index"`
+	Kind            string     `gorm:"column:kind;index"`
+	Provider        string     `gorm:"column:provider"`
+	ExternalID      string     `gorm:"column:external_id;index"`
+	LicenseNumber   string     `gorm:"column:license_number"`
+	LicenseState    string     `gorm:"column:license_state"`
+	LicenseType     string     `gorm:"column:license_type"`
+	Status          string     `gorm:"column:status;index"`
+	Detail          string     `gorm:"column:detail"`
+	OrderedAt       time.Time  `gorm:"column:ordered_at"`
+	CompletedAt     *time.Time `gorm:"column:completed_at"`
+	ExpiresAt       *time.Time `gorm:"column:expires_at"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Check) TableName() string {
+	return "screening_checks"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewScreeningRepository(db *gorm.DB, loggerInstance *logger.Logger) domainScreening.IScreeningRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateCheck(newCheck *domainScreening.Check) (*domainScreening.Check, error) {
+	checkModel := fromDomainMapper(newCheck)
+	if err := r.DB.Create(checkModel).Error; err != nil {
+		r.Logger.Error("Error creating screening check", zap.Error(err), zap.String("caregiverUserID", newCheck.CaregiverUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Screening check created successfully", zap.String("checkID", checkModel.ID.String()))
+	return checkModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetCheckByID(id uuid.UUID) (*domainScreening.Check, error) {
+	var checkModel Check
+	err := r.DB.Where("id = ?", id).First(&checkModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Screening check not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting screening check by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return checkModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetChecks(filter domainScreening.CheckFilter) (*[]domainScreening.Check, error) {
+	query := r.DB.Model(&Check{})
+	if filter.CaregiverUserID != nil {
+		query = query.Where("caregiver_user_id = ?", *filter.CaregiverUserID)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	var checks []Check
+	if err := query.Order("ordered_at DESC").Find(&checks).Error; err != nil {
+		r.Logger.Error("Error getting screening checks", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainScreening.Check, len(checks))
+	for i := range checks {
+		res[i] = *checks[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) UpdateCheck(id uuid.UUID, updates map[string]interface{}) (*domainScreening.Check, error) {
+	checkModel := Check{ID: id}
+	if err := r.DB.Model(&checkModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating screening check", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetCheckByID(id)
+}
+
+func (r *Repository) DeleteCheck(id uuid.UUID) error {
+	tx := r.DB.Delete(&Check{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting screening check", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Screening check not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (c *Check) toDomainMapper() *domainScreening.Check {
+	return &domainScreening.Check{
+		ID:              c.ID,
+		CaregiverUserID: c.CaregiverUserID,
+		Kind:            c.Kind,
+		Provider:        c.Provider,
+		ExternalID:      c.ExternalID,
+		LicenseNumber:   c.LicenseNumber,
+		LicenseState:    c.LicenseState,
+		LicenseType:     c.LicenseType,
+		Status:          c.Status,
+		Detail:          c.Detail,
+		OrderedAt:       c.OrderedAt,
+		CompletedAt:     c.CompletedAt,
+		ExpiresAt:       c.ExpiresAt,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(c *domainScreening.Check) *Check {
+	return &Check{
+		ID:              c.ID,
+		CaregiverUserID: c.CaregiverUserID,
+		Kind:            c.Kind,
+		Provider:        c.Provider,
+		ExternalID:      c.ExternalID,
+		LicenseNumber:   c.LicenseNumber,
+		LicenseState:    c.LicenseState,
+		LicenseType:     c.LicenseType,
+		Status:          c.Status,
+		Detail:          c.Detail,
+		OrderedAt:       c.OrderedAt,
+		CompletedAt:     c.CompletedAt,
+		ExpiresAt:       c.ExpiresAt,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
@@ -0,0 +1,136 @@
+package servicearea
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainServiceArea "caregiver/src/domain/servicearea"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ServiceArea struct {
+	ID         uuid.UUID                 `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID     uuid.UUID                 `gorm:"column:user_id;type:uuid;uniqueIndex"`
+	Type       string                    `gorm:"column:type"`
+	CenterLat  *float64                  `gorm:"column:center_lat"`
+	CenterLong *float64                  `gorm:"column:center_long"`
+	RadiusKm   float64                   `gorm:"column:radius_km"`
+	Polygon    []domainServiceArea.Point `gorm:"column:polygon;serializer:json"`
+	Mode       string                    `gorm:"column:mode"`
+	CreatedAt  time.Time                 `gorm:"autoCreateTime:milli"`
+	UpdatedAt  time.Time                 `gorm:"autoUpdateTime:milli"`
+}
+
+func (ServiceArea) TableName() string {
+	return "caregiver_service_areas"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewServiceAreaRepository(db *gorm.DB, loggerInstance *logger.Logger) domainServiceArea.IServiceAreaRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Save(area *domainServiceArea.Area) (*domainServiceArea.Area, error) {
+	model := fromDomainMapper(area)
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"type", "center_lat", "center_long", "radius_km", "polygon", "mode", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		r.Logger.Error("Error saving service area", zap.Error(err), zap.String("userID", area.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Service area saved", zap.String("userID", area.UserID.String()))
+	return r.GetByUserID(area.UserID)
+}
+
+func (r *Repository) GetByUserID(userID uuid.UUID) (*domainServiceArea.Area, error) {
+	var model ServiceArea
+	err := r.DB.Where("user_id = ?", userID).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting service area", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByUserIDs(userIDs []uuid.UUID) (*[]domainServiceArea.Area, error) {
+	var models []ServiceArea
+	if len(userIDs) > 0 {
+		if err := r.DB.Where("user_id IN ?", userIDs).Find(&models).Error; err != nil {
+			r.Logger.Error("Error getting service areas", zap.Error(err), zap.Int("users", len(userIDs)))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+	}
+	return arrayToDomainMapper(models), nil
+}
+
+func (r *Repository) GetAll() (*[]domainServiceArea.Area, error) {
+	var models []ServiceArea
+	if err := r.DB.Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting all service areas", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(models), nil
+}
+
+func (r *Repository) DeleteByUserID(userID uuid.UUID) error {
+	tx := r.DB.Where("user_id = ?", userID).Delete(&ServiceArea{})
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting service area", zap.Error(tx.Error), zap.String("userID", userID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Service area not found for deletion", zap.String("userID", userID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (a *ServiceArea) toDomainMapper() *domainServiceArea.Area {
+	return &domainServiceArea.Area{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		Type:       a.Type,
+		CenterLat:  a.CenterLat,
+		CenterLong: a.CenterLong,
+		RadiusKm:   a.RadiusKm,
+		Polygon:    a.Polygon,
+		Mode:       a.Mode,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(a *domainServiceArea.Area) *ServiceArea {
+	return &ServiceArea{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		Type:       a.Type,
+		CenterLat:  a.CenterLat,
+		CenterLong: a.CenterLong,
+		RadiusKm:   a.RadiusKm,
+		Polygon:    a.Polygon,
+		Mode:       a.Mode,
+	}
+}
+
+func arrayToDomainMapper(models []ServiceArea) *[]domainServiceArea.Area {
+	areas := make([]domainServiceArea.Area, len(models))
+	for i := range models {
+		areas[i] = *models[i].toDomainMapper()
+	}
+	return &areas
+}
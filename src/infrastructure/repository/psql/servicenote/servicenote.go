@@ -0,0 +1,104 @@
+package servicenote
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainServiceNote "caregiver/src/domain/servicenote"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Revision struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID   uuid.UUID  `gorm:"column:schedule_id;type:uuid;uniqueIndex:idx_service_note_revisions_schedule_revision"`
+	Revision     int        `gorm:"column:revision;uniqueIndex:idx_service_note_revisions_schedule_revision"`
+	Body         string     `gorm:"column:body;type:text"`
+	AuthorUserID *uuid.UUID `gorm:"column:author_user_id;type:uuid"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime:milli"`
+}
+
+func (Revision) TableName() string {
+	return "service_note_revisions"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewServiceNoteRepository(db *gorm.DB, loggerInstance *logger.Logger) domainServiceNote.IServiceNoteRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Append(revision *domainServiceNote.Revision) (*domainServiceNote.Revision, error) {
+	model := fromDomainMapper(revision)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the visit's row so concurrent saves number revisions in order.
+		var schedule struct{ ID uuid.UUID }
+		if err := tx.Table("schedules").Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("id = ? AND deleted_at IS NULL", revision.ScheduleID).Take(&schedule).Error; err != nil {
+			return err
+		}
+		var latest int
+		if err := tx.Model(&Revision{}).Where("schedule_id = ?", revision.ScheduleID).Select("COALESCE(MAX(revision), 0)").Scan(&latest).Error; err != nil {
+			return err
+		}
+		model.ID = uuid.New()
+		model.Revision = latest + 1
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		return tx.Table("schedules").Where("id = ?", revision.ScheduleID).
+			Updates(map[string]interface{}{"service_note": model.Body, "updated_at": model.CreatedAt}).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Schedule not found for service note", zap.String("scheduleID", revision.ScheduleID.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error appending service note revision", zap.Error(err), zap.String("scheduleID", revision.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Service note revision recorded", zap.String("scheduleID", revision.ScheduleID.String()), zap.Int("revision", model.Revision))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetBySchedule(scheduleID uuid.UUID) (*[]domainServiceNote.Revision, error) {
+	var models []Revision
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Order("revision ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting service note revisions", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	revisions := make([]domainServiceNote.Revision, len(models))
+	for i, m := range models {
+		revisions[i] = *m.toDomainMapper()
+	}
+	return &revisions, nil
+}
+
+func fromDomainMapper(revision *domainServiceNote.Revision) *Revision {
+	return &Revision{
+		ID:           revision.ID,
+		ScheduleID:   revision.ScheduleID,
+		Revision:     revision.Revision,
+		Body:         revision.Body,
+		AuthorUserID: revision.AuthorUserID,
+		CreatedAt:    revision.CreatedAt,
+	}
+}
+
+func (r *Revision) toDomainMapper() *domainServiceNote.Revision {
+	return &domainServiceNote.Revision{
+		ID:           r.ID,
+		ScheduleID:   r.ScheduleID,
+		Revision:     r.Revision,
+		Body:         r.Body,
+		AuthorUserID: r.AuthorUserID,
+		CreatedAt:    r.CreatedAt,
+	}
+}
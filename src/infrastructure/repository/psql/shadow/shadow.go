@@ -0,0 +1,99 @@
+package shadow
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	logger "caregiver/src/infrastructure/logger"
+
+	"go.uber.org/zap"
+)
+
+// ErrMissing is returned by a comparison when a record has no shadow copy
+// yet, such as records written before shadow writes were turned on.
+var ErrMissing = errors.New("shadow copy missing")
+
+// Enabled reports whether the named shadow write is turned on, by listing it
+// in the comma separated SHADOW_WRITES environment variable.
+func Enabled(name string) bool {
+	for _, enabled := range strings.Split(os.Getenv("SHADOW_WRITES"), ",") {
+		if strings.TrimSpace(enabled) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Backfiller copies records written before shadow writes were turned on,
+// returning how many it copied.
+type Backfiller interface {
+	Backfill() (int, error)
+}
+
+// Stats counts shadow writes and how their comparisons went.
+type Stats struct {
+	Written    int64
+	Failed     int64
+	Mismatched int64
+}
+
+// Writer dual-writes an entity into the table shape a schema refactor moves it
+// to, ahead of cutover. Each shadow write is read back and compared with the
+// primary record; failures and differences are logged and counted but never
+// fail the primary write.
+type Writer struct {
+	name       string
+	written    atomic.Int64
+	failed     atomic.Int64
+	mismatched atomic.Int64
+	Logger     *logger.Logger
+}
+
+func NewWriter(name string, loggerInstance *logger.Logger) *Writer {
+	return &Writer{name: name, Logger: loggerInstance}
+}
+
+// Mirror runs write once the primary record with the given id was saved,
+// then diff, which names the fields whose shadow copy differs. It reports
+// whether the shadow copy matches.
+func (w *Writer) Mirror(id string, write func() error, diff func() ([]string, error)) bool {
+	w.written.Add(1)
+	if err := write(); err != nil {
+		w.failed.Add(1)
+		w.Logger.Error("Shadow write failed", zap.String("shadow", w.name), zap.String("id", id), zap.Error(err))
+		return false
+	}
+	return w.Compare(id, diff)
+}
+
+// Compare checks the shadow copy of the record with the given id without
+// writing it, logging any difference.
+func (w *Writer) Compare(id string, diff func() ([]string, error)) bool {
+	fields, err := diff()
+	if err != nil && !errors.Is(err, ErrMissing) {
+		w.failed.Add(1)
+		w.Logger.Error("Shadow comparison failed", zap.String("shadow", w.name), zap.String("id", id), zap.Error(err))
+		return false
+	}
+	if errors.Is(err, ErrMissing) || len(fields) > 0 {
+		w.mismatched.Add(1)
+		w.Logger.Warn("Shadow copy differs from primary record", zap.String("shadow", w.name), zap.String("id", id), zap.Strings("fields", fields), zap.Bool("missing", errors.Is(err, ErrMissing)))
+		return false
+	}
+	return true
+}
+
+// Remove runs remove once the primary record with the given id was deleted.
+func (w *Writer) Remove(id string, remove func() error) {
+	if err := remove(); err != nil {
+		w.failed.Add(1)
+		w.Logger.Error("Shadow delete failed", zap.String("shadow", w.name), zap.String("id", id), zap.Error(err))
+	}
+}
+
+// Stats returns the counts since the writer was created.
+func (w *Writer) Stats() Stats {
+	return Stats{Written: w.written.Load(), Failed: w.failed.Load(), Mismatched: w.mismatched.Load()}
+}
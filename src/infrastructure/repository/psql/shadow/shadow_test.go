@@ -0,0 +1,46 @@
+package shadow
+
+import (
+	"errors"
+	"testing"
+
+	logger "caregiver/src/infrastructure/logger"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("SHADOW_WRITES", "user_addresses, schedule_slots")
+	if !Enabled("user_addresses") || !Enabled("schedule_slots") {
+		t.Error("expected listed shadow writes to be enabled")
+	}
+	if Enabled("visit_notes") || Enabled("") {
+		t.Error("expected unlisted shadow writes to be disabled")
+	}
+}
+
+func TestMirror(t *testing.T) {
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	writer := NewWriter("user_addresses", loggerInstance)
+	noDiff := func() ([]string, error) { return nil, nil }
+
+	if !writer.Mirror("1", func() error { return nil }, noDiff) {
+		t.Error("expected a matching copy")
+	}
+	if writer.Mirror("2", func() error { return nil }, func() ([]string, error) { return []string{"City"}, nil }) {
+		t.Error("expected a differing copy to be reported")
+	}
+	if writer.Mirror("3", func() error { return nil }, func() ([]string, error) { return nil, ErrMissing }) {
+		t.Error("expected a missing copy to be reported")
+	}
+	diffed := false
+	if writer.Mirror("4", func() error { return errors.New("relation does not exist") }, func() ([]string, error) { diffed = true; return nil, nil }) || diffed {
+		t.Error("expected a failed write not to be compared")
+	}
+	writer.Remove("5", func() error { return errors.New("timeout") })
+
+	if stats := writer.Stats(); stats != (Stats{Written: 4, Failed: 2, Mismatched: 2}) {
+		t.Errorf("expected counts of every outcome, got %+v", stats)
+	}
+}
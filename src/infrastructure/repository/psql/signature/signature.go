@@ -0,0 +1,115 @@
+package signature
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSignature "caregiver/src/domain/signature"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Signature struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AttachmentID  uuid.UUID `gorm:"column:attachment_id;type:uuid;uniqueIndex:idx_signature_attachment_signer"`
+	ScheduleID    uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	DocumentType  string    `gorm:"column:document_type"`
+	SignerUserID  uuid.UUID `gorm:"column:signer_user_id;type:uuid;uniqueIndex:idx_signature_attachment_signer"`
+	SignerName    string    `gorm:"column:signer_name"`
+	Method        string    `gorm:"column:method"`
+	SignatureData string    `gorm:"column:signature_data;type:text"`
+	DocumentHash  string    `gorm:"column:document_hash"`
+	IPAddress     string    `gorm:"column:ip_address"`
+	UserAgent     string    `gorm:"column:user_agent"`
+	SignedAt      time.Time `gorm:"column:signed_at"`
+	RecordHash    string    `gorm:"column:record_hash"`
+}
+
+func (Signature) TableName() string {
+	return "document_signatures"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewSignatureRepository(db *gorm.DB, loggerInstance *logger.Logger) domainSignature.ISignatureRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newSignature *domainSignature.Signature) (*domainSignature.Signature, error) {
+	model := fromDomainMapper(newSignature)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating signature", zap.Error(err), zap.String("attachmentID", newSignature.AttachmentID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Signature created successfully", zap.String("signatureID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainSignature.Signature, error) {
+	var model Signature
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Signature not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting signature by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByAttachment(attachmentID uuid.UUID) (*[]domainSignature.Signature, error) {
+	var models []Signature
+	if err := r.DB.Where("attachment_id = ?", attachmentID).Order("signed_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting signatures for attachment", zap.Error(err), zap.String("attachmentID", attachmentID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	signatures := make([]domainSignature.Signature, len(models))
+	for i := range models {
+		signatures[i] = *models[i].toDomainMapper()
+	}
+	return &signatures, nil
+}
+
+func (s *Signature) toDomainMapper() *domainSignature.Signature {
+	return &domainSignature.Signature{
+		ID:            s.ID,
+		AttachmentID:  s.AttachmentID,
+		ScheduleID:    s.ScheduleID,
+		DocumentType:  s.DocumentType,
+		SignerUserID:  s.SignerUserID,
+		SignerName:    s.SignerName,
+		Method:        s.Method,
+		SignatureData: s.SignatureData,
+		DocumentHash:  s.DocumentHash,
+		IPAddress:     s.IPAddress,
+		UserAgent:     s.UserAgent,
+		SignedAt:      s.SignedAt,
+		RecordHash:    s.RecordHash,
+	}
+}
+
+func fromDomainMapper(s *domainSignature.Signature) *Signature {
+	return &Signature{
+		ID:            s.ID,
+		AttachmentID:  s.AttachmentID,
+		ScheduleID:    s.ScheduleID,
+		DocumentType:  s.DocumentType,
+		SignerUserID:  s.SignerUserID,
+		SignerName:    s.SignerName,
+		Method:        s.Method,
+		SignatureData: s.SignatureData,
+		DocumentHash:  s.DocumentHash,
+		IPAddress:     s.IPAddress,
+		UserAgent:     s.UserAgent,
+		SignedAt:      s.SignedAt,
+		RecordHash:    s.RecordHash,
+	}
+}
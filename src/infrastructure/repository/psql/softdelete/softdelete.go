@@ -0,0 +1,48 @@
+package softdelete
+
+import (
+	"time"
+
+	"caregiver/src/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Soft-deleted models embed a gorm.DeletedAt column named deleted_at. GORM
+// then turns Delete into setting it and leaves those rows out of queries;
+// Scope and Restore reach them again. Models shown in the admin trash also
+// keep a deleted_by column, set through Delete.
+
+// Scope returns a GORM scope applying the DeletedScope to a query on a
+// soft-deleted model.
+func Scope(scope domain.DeletedScope) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch scope {
+		case domain.WithDeleted:
+			return db.Unscoped()
+		case domain.OnlyDeleted:
+			return db.Unscoped().Where("deleted_at IS NOT NULL")
+		default:
+			return db
+		}
+	}
+}
+
+// Delete soft-deletes the model's row with the given id, recording who
+// deleted it, if known, in deleted_by. It reports whether a row was deleted.
+func Delete(db *gorm.DB, model interface{}, id uuid.UUID, deletedBy *uuid.UUID) (bool, error) {
+	tx := db.Model(model).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"deleted_at": time.Now(), "deleted_by": deletedBy})
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// Restore clears deleted_at on the model's row with the given id, reporting
+// whether a deleted row was found.
+func Restore(db *gorm.DB, model interface{}, id uuid.UUID) (bool, error) {
+	tx := db.Unscoped().Model(model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	return tx.RowsAffected > 0, tx.Error
+}
@@ -0,0 +1,212 @@
+package statement
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainStatement "caregiver/src/domain/statement"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Payment struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID uuid.UUID `gorm:"column:client_user_id;type:uuid;index"`
+	Amount       float64   `gorm:"column:amount"`
+	Method       string    `gorm:"column:method"`
+	Reference    string    `gorm:"column:reference"`
+	Note         string    `gorm:"column:note"`
+	ReceivedAt   time.Time `gorm:"column:received_at;index"`
+	CreatedAt    time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Payment) TableName() string {
+	return "client_payments"
+}
+
+type Adjustment struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID uuid.UUID `gorm:"column:client_user_id;type:uuid;index"`
+	Amount       float64   `gorm:"column:amount"`
+	Reason       string    `gorm:"column:reason"`
+	EffectiveAt  time.Time `gorm:"column:effective_at;index"`
+	CreatedAt    time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Adjustment) TableName() string {
+	return "client_account_adjustments"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewStatementRepository(db *gorm.DB, loggerInstance *logger.Logger) domainStatement.IStatementRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreatePayment(newPayment *domainStatement.Payment) (*domainStatement.Payment, error) {
+	paymentModel := paymentFromDomainMapper(newPayment)
+	if err := r.DB.Create(paymentModel).Error; err != nil {
+		r.Logger.Error("Error creating client payment", zap.Error(err), zap.String("clientUserID", newPayment.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Client payment created successfully", zap.String("paymentID", paymentModel.ID.String()))
+	return paymentModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetPaymentByID(id uuid.UUID) (*domainStatement.Payment, error) {
+	var paymentModel Payment
+	err := r.DB.Where("id = ?", id).First(&paymentModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Client payment not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting client payment by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return paymentModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetPayments(filter domainStatement.Filter) (*[]domainStatement.Payment, error) {
+	query := r.DB.Where("client_user_id = ?", filter.ClientUserID)
+	if filter.From != nil {
+		query = query.Where("received_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("received_at < ?", *filter.To)
+	}
+	var payments []Payment
+	if err := query.Order("received_at ASC").Find(&payments).Error; err != nil {
+		r.Logger.Error("Error getting client payments", zap.Error(err), zap.String("clientUserID", filter.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainStatement.Payment, len(payments))
+	for i := range payments {
+		res[i] = *payments[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) DeletePayment(id uuid.UUID) error {
+	tx := r.DB.Delete(&Payment{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting client payment", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Client payment not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) CreateAdjustment(newAdjustment *domainStatement.Adjustment) (*domainStatement.Adjustment, error) {
+	adjustmentModel := adjustmentFromDomainMapper(newAdjustment)
+	if err := r.DB.Create(adjustmentModel).Error; err != nil {
+		r.Logger.Error("Error creating client account adjustment", zap.Error(err), zap.String("clientUserID", newAdjustment.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Client account adjustment created successfully", zap.String("adjustmentID", adjustmentModel.ID.String()))
+	return adjustmentModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAdjustmentByID(id uuid.UUID) (*domainStatement.Adjustment, error) {
+	var adjustmentModel Adjustment
+	err := r.DB.Where("id = ?", id).First(&adjustmentModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Client account adjustment not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting client account adjustment by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return adjustmentModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAdjustments(filter domainStatement.Filter) (*[]domainStatement.Adjustment, error) {
+	query := r.DB.Where("client_user_id = ?", filter.ClientUserID)
+	if filter.From != nil {
+		query = query.Where("effective_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("effective_at < ?", *filter.To)
+	}
+	var adjustments []Adjustment
+	if err := query.Order("effective_at ASC").Find(&adjustments).Error; err != nil {
+		r.Logger.Error("Error getting client account adjustments", zap.Error(err), zap.String("clientUserID", filter.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainStatement.Adjustment, len(adjustments))
+	for i := range adjustments {
+		res[i] = *adjustments[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) DeleteAdjustment(id uuid.UUID) error {
+	tx := r.DB.Delete(&Adjustment{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting client account adjustment", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Client account adjustment not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (p *Payment) toDomainMapper() *domainStatement.Payment {
+	return &domainStatement.Payment{
+		ID:           p.ID,
+		ClientUserID: p.ClientUserID,
+		Amount:       p.Amount,
+		Method:       p.Method,
+		Reference:    p.Reference,
+		Note:         p.Note,
+		ReceivedAt:   p.ReceivedAt,
+		CreatedAt:    p.CreatedAt,
+	}
+}
+
+func paymentFromDomainMapper(p *domainStatement.Payment) *Payment {
+	return &Payment{
+		ID:           p.ID,
+		ClientUserID: p.ClientUserID,
+		Amount:       p.Amount,
+		Method:       p.Method,
+		Reference:    p.Reference,
+		Note:         p.Note,
+		ReceivedAt:   p.ReceivedAt,
+		CreatedAt:    p.CreatedAt,
+	}
+}
+
+func (a *Adjustment) toDomainMapper() *domainStatement.Adjustment {
+	return &domainStatement.Adjustment{
+		ID:           a.ID,
+		ClientUserID: a.ClientUserID,
+		Amount:       a.Amount,
+		Reason:       a.Reason,
+		EffectiveAt:  a.EffectiveAt,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+func adjustmentFromDomainMapper(a *domainStatement.Adjustment) *Adjustment {
+	return &Adjustment{
+		ID:           a.ID,
+		ClientUserID: a.ClientUserID,
+		Amount:       a.Amount,
+		Reason:       a.Reason,
+		EffectiveAt:  a.EffectiveAt,
+		CreatedAt:    a.CreatedAt,
+	}
+}
@@ -0,0 +1,264 @@
+package supply
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSupply "caregiver/src/domain/supply"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Item struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name         string    `gorm:"column:name"`
+	SKU          string    `gorm:"column:sku;index"`
+	Unit         string    `gorm:"column:unit"`
+	UnitCost     float64   `gorm:"column:unit_cost"`
+	OnHand       int       `gorm:"column:on_hand"`
+	ReorderLevel int       `gorm:"column:reorder_level"`
+	Active       bool      `gorm:"column:active"`
+	CreatedAt    time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Item) TableName() string {
+	return "supply_items"
+}
+
+type Usage struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ItemID          uuid.UUID `gorm:"column:item_id;type:uuid;index"`
+	ScheduleID      uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	ClientUserID    uuid.UUID `gorm:"column:client_user_id;type:uuid;index"`
+	CaregiverUserID uuid.UUID `gorm:"column:caregiver_user_id;type:uuid"`
+	Quantity        int       `gorm:"column:quantity"`
+	UnitCost        float64   `gorm:"column:unit_cost"`
+	Note            string    `gorm:"column:note"`
+	UsedAt          time.Time `gorm:"column:used_at;index"`
+	CreatedAt       time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Usage) TableName() string {
+	return "supply_usages"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewSupplyRepository(db *gorm.DB, loggerInstance *logger.Logger) domainSupply.ISupplyRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateItem(newItem *domainSupply.Item) (*domainSupply.Item, error) {
+	itemModel := itemFromDomainMapper(newItem)
+	if err := r.DB.Create(itemModel).Error; err != nil {
+		r.Logger.Error("Error creating supply item", zap.Error(err), zap.String("name", newItem.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Supply item created successfully", zap.String("itemID", itemModel.ID.String()))
+	return itemModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetItemByID(id uuid.UUID) (*domainSupply.Item, error) {
+	var itemModel Item
+	err := r.DB.Where("id = ?", id).First(&itemModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Supply item not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting supply item by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return itemModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetItems() (*[]domainSupply.Item, error) {
+	var items []Item
+	if err := r.DB.Order("name ASC").Find(&items).Error; err != nil {
+		r.Logger.Error("Error getting supply items", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&items), nil
+}
+
+func (r *Repository) GetLowStockItems() (*[]domainSupply.Item, error) {
+	var items []Item
+	if err := r.DB.Where("active = ? AND on_hand <= reorder_level", true).Order("name ASC").Find(&items).Error; err != nil {
+		r.Logger.Error("Error getting low stock supply items", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&items), nil
+}
+
+func (r *Repository) UpdateItem(id uuid.UUID, updates map[string]interface{}) (*domainSupply.Item, error) {
+	itemModel := Item{ID: id}
+	if err := r.DB.Model(&itemModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating supply item", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetItemByID(id)
+}
+
+func (r *Repository) DeleteItem(id uuid.UUID) error {
+	tx := r.DB.Delete(&Item{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting supply item", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Supply item not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) AdjustStock(id uuid.UUID, delta int) (*domainSupply.Item, error) {
+	tx := r.DB.Model(&Item{}).Where("id = ?", id).Update("on_hand", gorm.Expr("on_hand + ?", delta))
+	if tx.Error != nil {
+		r.Logger.Error("Error adjusting supply stock", zap.Error(tx.Error), zap.String("id", id.String()), zap.Int("delta", delta))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Supply item not found for stock adjustment", zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return r.GetItemByID(id)
+}
+
+func (r *Repository) CreateUsage(newUsage *domainSupply.Usage) (*domainSupply.Usage, error) {
+	usageModel := usageFromDomainMapper(newUsage)
+	if err := r.DB.Create(usageModel).Error; err != nil {
+		r.Logger.Error("Error creating supply usage", zap.Error(err), zap.String("scheduleID", newUsage.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Supply usage created successfully", zap.String("usageID", usageModel.ID.String()))
+	return usageModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetUsageByID(id uuid.UUID) (*domainSupply.Usage, error) {
+	var usageModel Usage
+	err := r.DB.Where("id = ?", id).First(&usageModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Supply usage not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting supply usage by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return usageModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetUsages(filter domainSupply.UsageFilter) (*[]domainSupply.Usage, error) {
+	query := r.DB.Model(&Usage{})
+	if filter.ScheduleID != nil {
+		query = query.Where("schedule_id = ?", *filter.ScheduleID)
+	}
+	if filter.ClientUserID != nil {
+		query = query.Where("client_user_id = ?", *filter.ClientUserID)
+	}
+	if filter.From != nil {
+		query = query.Where("used_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("used_at < ?", *filter.To)
+	}
+	var usages []Usage
+	if err := query.Order("used_at ASC").Find(&usages).Error; err != nil {
+		r.Logger.Error("Error getting supply usages", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainSupply.Usage, len(usages))
+	for i := range usages {
+		res[i] = *usages[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) DeleteUsage(id uuid.UUID) error {
+	tx := r.DB.Delete(&Usage{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting supply usage", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Supply usage not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (i *Item) toDomainMapper() *domainSupply.Item {
+	return &domainSupply.Item{
+		ID:           i.ID,
+		Name:         i.Name,
+		SKU:          i.SKU,
+		Unit:         i.Unit,
+		UnitCost:     i.UnitCost,
+		OnHand:       i.OnHand,
+		ReorderLevel: i.ReorderLevel,
+		Active:       i.Active,
+		CreatedAt:    i.CreatedAt,
+		UpdatedAt:    i.UpdatedAt,
+	}
+}
+
+func itemFromDomainMapper(i *domainSupply.Item) *Item {
+	return &Item{
+		ID:           i.ID,
+		Name:         i.Name,
+		SKU:          i.SKU,
+		Unit:         i.Unit,
+		UnitCost:     i.UnitCost,
+		OnHand:       i.OnHand,
+		ReorderLevel: i.ReorderLevel,
+		Active:       i.Active,
+		CreatedAt:    i.CreatedAt,
+		UpdatedAt:    i.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(items *[]Item) *[]domainSupply.Item {
+	itemsDomain := make([]domainSupply.Item, len(*items))
+	for i, item := range *items {
+		itemsDomain[i] = *item.toDomainMapper()
+	}
+	return &itemsDomain
+}
+
+func (u *Usage) toDomainMapper() *domainSupply.Usage {
+	return &domainSupply.Usage{
+		ID:              u.ID,
+		ItemID:          u.ItemID,
+		ScheduleID:      u.ScheduleID,
+		ClientUserID:    u.ClientUserID,
+		CaregiverUserID: u.CaregiverUserID,
+		Quantity:        u.Quantity,
+		UnitCost:        u.UnitCost,
+		Note:            u.Note,
+		UsedAt:          u.UsedAt,
+		CreatedAt:       u.CreatedAt,
+	}
+}
+
+func usageFromDomainMapper(u *domainSupply.Usage) *Usage {
+	return &Usage{
+		ID:              u.ID,
+		ItemID:          u.ItemID,
+		ScheduleID:      u.ScheduleID,
+		ClientUserID:    u.ClientUserID,
+		CaregiverUserID: u.CaregiverUserID,
+		Quantity:        u.Quantity,
+		UnitCost:        u.UnitCost,
+		Note:            u.Note,
+		UsedAt:          u.UsedAt,
+		CreatedAt:       u.CreatedAt,
+	}
+}
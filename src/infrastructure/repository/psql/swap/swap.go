@@ -0,0 +1,126 @@
+package swap
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSwap "caregiver/src/domain/swap"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Request struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID   uuid.UUID  `gorm:"column:schedule_id;type:uuid;index"`
+	FromUserID   uuid.UUID  `gorm:"column:from_user_id;type:uuid;index"`
+	ToUserID     *uuid.UUID `gorm:"column:to_user_id;type:uuid;index"`
+	Reason       string     `gorm:"column:reason"`
+	Status       string     `gorm:"column:status;index"`
+	AcceptedAt   *time.Time `gorm:"column:accepted_at"`
+	DecidedBy    *uuid.UUID `gorm:"column:decided_by;type:uuid"`
+	DecidedAt    *time.Time `gorm:"column:decided_at"`
+	DecisionNote string     `gorm:"column:decision_note"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Request) TableName() string {
+	return "swap_requests"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewSwapRepository(db *gorm.DB, loggerInstance *logger.Logger) domainSwap.ISwapRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(request *domainSwap.Request) (*domainSwap.Request, error) {
+	model := &Request{
+		ScheduleID: request.ScheduleID,
+		FromUserID: request.FromUserID,
+		Reason:     request.Reason,
+		Status:     request.Status,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating swap request", zap.Error(err), zap.String("scheduleID", request.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainSwap.Request, error) {
+	var model Request
+	if err := r.DB.Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting swap request", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetBySchedule(scheduleID uuid.UUID) (*[]domainSwap.Request, error) {
+	var models []Request
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Order("created_at DESC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting swap history", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return requestsToDomain(models), nil
+}
+
+func (r *Repository) GetByStatus(statuses ...string) (*[]domainSwap.Request, error) {
+	var models []Request
+	if err := r.DB.Where("status IN ?", statuses).Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting swap requests", zap.Error(err), zap.Strings("statuses", statuses))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return requestsToDomain(models), nil
+}
+
+func (r *Repository) Transition(id uuid.UUID, from []string, updates map[string]interface{}) (*domainSwap.Request, error) {
+	result := r.DB.Model(&Request{}).Where("id = ? AND status IN ?", id, from).Updates(updates)
+	if result.Error != nil {
+		r.Logger.Error("Error updating swap request", zap.Error(result.Error), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetByID(id); err != nil {
+			return nil, err
+		}
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.Conflict)
+	}
+	return r.GetByID(id)
+}
+
+func requestsToDomain(models []Request) *[]domainSwap.Request {
+	res := make([]domainSwap.Request, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res
+}
+
+func (m *Request) toDomainMapper() *domainSwap.Request {
+	return &domainSwap.Request{
+		ID:           m.ID,
+		ScheduleID:   m.ScheduleID,
+		FromUserID:   m.FromUserID,
+		ToUserID:     m.ToUserID,
+		Reason:       m.Reason,
+		Status:       m.Status,
+		AcceptedAt:   m.AcceptedAt,
+		DecidedBy:    m.DecidedBy,
+		DecidedAt:    m.DecidedAt,
+		DecisionNote: m.DecisionNote,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
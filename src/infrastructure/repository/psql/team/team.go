@@ -0,0 +1,203 @@
+package team
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainTeam "caregiver/src/domain/team"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Team struct {
+	ID               uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name             string    `gorm:"column:name;uniqueIndex"`
+	Region           string    `gorm:"column:region"`
+	SupervisorUserID uuid.UUID `gorm:"column:supervisor_user_id;type:uuid;index"`
+	Members          []Member  `gorm:"foreignKey:TeamID"`
+	CreatedAt        time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+type Member struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	TeamID    uuid.UUID `gorm:"column:team_id;type:uuid;index"`
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid;uniqueIndex"`
+	CreatedAt time.Time `gorm:"autoCreateTime:milli"`
+}
+
+func (Team) TableName() string {
+	return "teams"
+}
+
+func (Member) TableName() string {
+	return "team_members"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewTeamRepository(db *gorm.DB, loggerInstance *logger.Logger) domainTeam.ITeamRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) withMembers() *gorm.DB {
+	return r.DB.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	})
+}
+
+func (r *Repository) Create(newTeam *domainTeam.Team) (*domainTeam.Team, error) {
+	teamModel := fromDomainMapper(newTeam)
+	if err := r.DB.Create(teamModel).Error; err != nil {
+		r.Logger.Error("Error creating team", zap.Error(err), zap.String("name", newTeam.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Team created successfully", zap.String("teamID", teamModel.ID.String()))
+	return teamModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainTeam.Team, error) {
+	var teamModel Team
+	err := r.withMembers().Where("id = ?", id).First(&teamModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Team not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting team by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return teamModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll() (*[]domainTeam.Team, error) {
+	var teams []Team
+	if err := r.withMembers().Order("name ASC").Find(&teams).Error; err != nil {
+		r.Logger.Error("Error getting all teams", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(teams), nil
+}
+
+func (r *Repository) GetBySupervisor(supervisorUserID uuid.UUID) (*[]domainTeam.Team, error) {
+	var teams []Team
+	if err := r.withMembers().Where("supervisor_user_id = ?", supervisorUserID).Order("name ASC").Find(&teams).Error; err != nil {
+		r.Logger.Error("Error getting teams for supervisor", zap.Error(err), zap.String("supervisorUserID", supervisorUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(teams), nil
+}
+
+func (r *Repository) GetByMember(userID uuid.UUID) (*domainTeam.Team, error) {
+	var member Member
+	err := r.DB.Where("user_id = ?", userID).First(&member).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting team membership", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(member.TeamID)
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainTeam.Team, error) {
+	var teamModel Team
+	teamModel.ID = id
+	if err := r.DB.Model(&teamModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating team", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&Team{}, "id = ?", id)
+		if result.Error != nil {
+			r.Logger.Error("Error deleting team", zap.Error(result.Error), zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		if result.RowsAffected == 0 {
+			r.Logger.Warn("Team not found for deletion", zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		if err := tx.Where("team_id = ?", id).Delete(&Member{}).Error; err != nil {
+			r.Logger.Error("Error deleting team members", zap.Error(err), zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) AddMember(teamID, userID uuid.UUID) error {
+	member := Member{ID: uuid.New(), TeamID: teamID, UserID: userID}
+	if err := r.DB.Create(&member).Error; err != nil {
+		r.Logger.Error("Error adding team member", zap.Error(err), zap.String("teamID", teamID.String()), zap.String("userID", userID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) RemoveMember(teamID, userID uuid.UUID) error {
+	tx := r.DB.Where("team_id = ? AND user_id = ?", teamID, userID).Delete(&Member{})
+	if tx.Error != nil {
+		r.Logger.Error("Error removing team member", zap.Error(tx.Error), zap.String("teamID", teamID.String()), zap.String("userID", userID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) GetMemberIDs(teamIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	if len(teamIDs) == 0 {
+		return userIDs, nil
+	}
+	if err := r.DB.Model(&Member{}).Where("team_id IN ?", teamIDs).Pluck("user_id", &userIDs).Error; err != nil {
+		r.Logger.Error("Error getting team member IDs", zap.Error(err), zap.Int("teams", len(teamIDs)))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return userIDs, nil
+}
+
+func (t *Team) toDomainMapper() *domainTeam.Team {
+	memberIDs := make([]uuid.UUID, len(t.Members))
+	for i, member := range t.Members {
+		memberIDs[i] = member.UserID
+	}
+	return &domainTeam.Team{
+		ID:               t.ID,
+		Name:             t.Name,
+		Region:           t.Region,
+		SupervisorUserID: t.SupervisorUserID,
+		MemberUserIDs:    memberIDs,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(t *domainTeam.Team) *Team {
+	return &Team{
+		ID:               t.ID,
+		Name:             t.Name,
+		Region:           t.Region,
+		SupervisorUserID: t.SupervisorUserID,
+	}
+}
+
+func arrayToDomainMapper(teams []Team) *[]domainTeam.Team {
+	result := make([]domainTeam.Team, len(teams))
+	for i := range teams {
+		result[i] = *teams[i].toDomainMapper()
+	}
+	return &result
+}
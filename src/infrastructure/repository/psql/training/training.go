@@ -0,0 +1,250 @@
+package training
+
+import (
+	"encoding/json"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainTraining "caregiver/src/domain/training"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Course struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name            string    `gorm:"column:name"`
+	Description     string    `gorm:"column:description"`
+	RequiredForRole string    `gorm:"column:required_for_role;index"`
+	ServiceNames    []string  `gorm:"column:service_names;serializer:json"`
+	DueWithinDays   int       `gorm:"column:due_within_days"`
+	ValidityDays    int       `gorm:"column:validity_days"`
+	Active          bool      `gorm:"column:active"`
+	CreatedAt       time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Course) TableName() string {
+	return "training_courses"
+}
+
+type Completion struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CourseID    uuid.UUID  `gorm:"column:course_id;type:uuid;index"`
+	UserID      uuid.UUID  `gorm:"column:user_id;type:uuid;index"`
+	CompletedAt time.Time  `gorm:"column:completed_at"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+	Certificate string     `gorm:"column:certificate"`
+	Note        string     `gorm:"column:note"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime:milli"`
+}
+
+func (Completion) TableName() string {
+	return "training_completions"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewTrainingRepository(db *gorm.DB, loggerInstance *logger.Logger) domainTraining.ITrainingRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateCourse(newCourse *domainTraining.Course) (*domainTraining.Course, error) {
+	courseModel := courseFromDomainMapper(newCourse)
+	if err := r.DB.Create(courseModel).Error; err != nil {
+		r.Logger.Error("Error creating training course", zap.Error(err), zap.String("name", newCourse.Name))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Training course created successfully", zap.String("courseID", courseModel.ID.String()))
+	return courseModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetCourseByID(id uuid.UUID) (*domainTraining.Course, error) {
+	var courseModel Course
+	err := r.DB.Where("id = ?", id).First(&courseModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Training course not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting training course by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return courseModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetCourses() (*[]domainTraining.Course, error) {
+	var courses []Course
+	if err := r.DB.Order("name ASC").Find(&courses).Error; err != nil {
+		r.Logger.Error("Error getting training courses", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&courses), nil
+}
+
+func (r *Repository) GetActiveCourses() (*[]domainTraining.Course, error) {
+	var courses []Course
+	if err := r.DB.Where("active = ?", true).Order("name ASC").Find(&courses).Error; err != nil {
+		r.Logger.Error("Error getting active training courses", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&courses), nil
+}
+
+func (r *Repository) UpdateCourse(id uuid.UUID, updates map[string]interface{}) (*domainTraining.Course, error) {
+	// Map updates bypass the field serializer, so encode service names here.
+	if serviceNames, ok := updates["service_names"]; ok {
+		encoded, err := json.Marshal(serviceNames)
+		if err != nil {
+			r.Logger.Error("Error encoding training course services", zap.Error(err), zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		updates["service_names"] = string(encoded)
+	}
+	courseModel := Course{ID: id}
+	if err := r.DB.Model(&courseModel).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating training course", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetCourseByID(id)
+}
+
+func (r *Repository) DeleteCourse(id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("course_id = ?", id).Delete(&Completion{}).Error; err != nil {
+			r.Logger.Error("Error deleting training completions", zap.Error(err), zap.String("courseID", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		result := tx.Delete(&Course{}, "id = ?", id)
+		if result.Error != nil {
+			r.Logger.Error("Error deleting training course", zap.Error(result.Error), zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		if result.RowsAffected == 0 {
+			r.Logger.Warn("Training course not found for deletion", zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) CreateCompletion(newCompletion *domainTraining.Completion) (*domainTraining.Completion, error) {
+	completionModel := &Completion{
+		ID:          newCompletion.ID,
+		CourseID:    newCompletion.CourseID,
+		UserID:      newCompletion.UserID,
+		CompletedAt: newCompletion.CompletedAt,
+		ExpiresAt:   newCompletion.ExpiresAt,
+		Certificate: newCompletion.Certificate,
+		Note:        newCompletion.Note,
+	}
+	if err := r.DB.Create(completionModel).Error; err != nil {
+		r.Logger.Error("Error creating training completion", zap.Error(err), zap.String("userID", newCompletion.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Training completion created successfully", zap.String("completionID", completionModel.ID.String()))
+	return completionModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetCompletionByID(id uuid.UUID) (*domainTraining.Completion, error) {
+	var completionModel Completion
+	err := r.DB.Where("id = ?", id).First(&completionModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Training completion not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting training completion by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return completionModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetCompletions(filter domainTraining.CompletionFilter) (*[]domainTraining.Completion, error) {
+	query := r.DB.Model(&Completion{})
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.CourseID != nil {
+		query = query.Where("course_id = ?", *filter.CourseID)
+	}
+	var completions []Completion
+	if err := query.Order("completed_at DESC").Find(&completions).Error; err != nil {
+		r.Logger.Error("Error getting training completions", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainTraining.Completion, len(completions))
+	for i := range completions {
+		res[i] = *completions[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) DeleteCompletion(id uuid.UUID) error {
+	tx := r.DB.Delete(&Completion{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting training completion", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Training completion not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (c *Course) toDomainMapper() *domainTraining.Course {
+	return &domainTraining.Course{
+		ID:              c.ID,
+		Name:            c.Name,
+		Description:     c.Description,
+		RequiredForRole: c.RequiredForRole,
+		ServiceNames:    c.ServiceNames,
+		DueWithinDays:   c.DueWithinDays,
+		ValidityDays:    c.ValidityDays,
+		Active:          c.Active,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func courseFromDomainMapper(c *domainTraining.Course) *Course {
+	return &Course{
+		ID:              c.ID,
+		Name:            c.Name,
+		Description:     c.Description,
+		RequiredForRole: c.RequiredForRole,
+		ServiceNames:    c.ServiceNames,
+		DueWithinDays:   c.DueWithinDays,
+		ValidityDays:    c.ValidityDays,
+		Active:          c.Active,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(courses *[]Course) *[]domainTraining.Course {
+	coursesDomain := make([]domainTraining.Course, len(*courses))
+	for i, course := range *courses {
+		coursesDomain[i] = *course.toDomainMapper()
+	}
+	return &coursesDomain
+}
+
+func (c *Completion) toDomainMapper() *domainTraining.Completion {
+	return &domainTraining.Completion{
+		ID:          c.ID,
+		CourseID:    c.CourseID,
+		UserID:      c.UserID,
+		CompletedAt: c.CompletedAt,
+		ExpiresAt:   c.ExpiresAt,
+		Certificate: c.Certificate,
+		Note:        c.Note,
+		CreatedAt:   c.CreatedAt,
+	}
+}
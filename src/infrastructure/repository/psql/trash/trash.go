@@ -0,0 +1,100 @@
+package trash
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainTrash "caregiver/src/domain/trash"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// row is what each trash query selects; Name and Detail make up the label.
+type row struct {
+	ID        uuid.UUID
+	Name      string
+	Detail    *time.Time
+	DeletedAt time.Time
+	DeletedBy *uuid.UUID
+}
+
+// sources are the soft-deleted tables read by the trash, keyed by kind.
+var sources = map[domainTrash.Kind]struct {
+	table  string
+	name   string
+	detail string
+}{
+	domainTrash.KindUser:     {table: "users", name: "TRIM(first_name || ' ' || last_name)", detail: "NULL"},
+	domainTrash.KindSchedule: {table: "schedules", name: "service_name", detail: "scheduled_slot_from"},
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewTrashRepository(db *gorm.DB, loggerInstance *logger.Logger) domainTrash.ITrashRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) List(kind domainTrash.Kind, since time.Time) (*[]domainTrash.Item, error) {
+	kinds := domainTrash.Kinds
+	if kind != "" {
+		kinds = []domainTrash.Kind{kind}
+	}
+	items := []domainTrash.Item{}
+	for _, k := range kinds {
+		var rows []row
+		if err := r.query(k).Where("deleted_at >= ?", since).Scan(&rows).Error; err != nil {
+			r.Logger.Error("Error listing deleted records", zap.Error(err), zap.String("kind", string(k)))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		for i := range rows {
+			items = append(items, rows[i].toDomainMapper(k))
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return &items, nil
+}
+
+func (r *Repository) Get(kind domainTrash.Kind, id uuid.UUID) (*domainTrash.Item, error) {
+	var rows []row
+	if err := r.query(kind).Where("id = ?", id).Limit(1).Scan(&rows).Error; err != nil {
+		r.Logger.Error("Error getting deleted record", zap.Error(err), zap.String("kind", string(kind)), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if len(rows) == 0 {
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	item := rows[0].toDomainMapper(kind)
+	return &item, nil
+}
+
+func (r *Repository) query(kind domainTrash.Kind) *gorm.DB {
+	source := sources[kind]
+	return r.DB.Table(source.table).
+		Select(fmt.Sprintf("id, %s AS name, %s AS detail, deleted_at, deleted_by", source.name, source.detail)).
+		Where("deleted_at IS NOT NULL")
+}
+
+func (r *row) toDomainMapper(kind domainTrash.Kind) domainTrash.Item {
+	label := strings.TrimSpace(r.Name)
+	if r.Detail != nil {
+		label = fmt.Sprintf("%s, %s", label, r.Detail.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+	return domainTrash.Item{
+		Kind:            kind,
+		ID:              r.ID,
+		Label:           label,
+		DeletedAt:       r.DeletedAt,
+		DeletedByUserID: r.DeletedBy,
+	}
+}
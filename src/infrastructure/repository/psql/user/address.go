@@ -0,0 +1,195 @@
+package user
+
+import (
+	"time"
+
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/repository/psql/shadow"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AddressShadow names the shadow write of user locations into
+// user_addresses, the table the move to several addresses per user reads from.
+const AddressShadow = "user_addresses"
+
+// homeAddress is the kind the single location on users becomes.
+const homeAddress = "home"
+
+type UserAddress struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID      uuid.UUID `gorm:"column:user_id;type:uuid;uniqueIndex:idx_user_addresses_user_kind"`
+	Kind        string    `gorm:"column:kind;uniqueIndex:idx_user_addresses_user_kind"`
+	HouseNumber string    `gorm:"column:house_number"`
+	Street      string    `gorm:"column:street"`
+	City        string    `gorm:"column:city"`
+	State       string    `gorm:"column:state"`
+	Pincode     string    `gorm:"column:pincode"`
+	Lat         float64   `gorm:"column:lat"`
+	Long        float64   `gorm:"column:long"`
+	CreatedAt   time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (UserAddress) TableName() string {
+	return "user_addresses"
+}
+
+// AddressShadowRepository writes through to the user repository and mirrors
+// each user's location into user_addresses as their home address.
+type AddressShadowRepository struct {
+	UserRepositoryInterface
+	DB     *gorm.DB
+	writer *shadow.Writer
+	Logger *logger.Logger
+}
+
+// WithShadowWrites wraps the user repository with the shadow writes listed in
+// SHADOW_WRITES, returning it unchanged when none apply.
+func WithShadowWrites(inner UserRepositoryInterface, db *gorm.DB, loggerInstance *logger.Logger) UserRepositoryInterface {
+	if !shadow.Enabled(AddressShadow) {
+		return inner
+	}
+	loggerInstance.Info("Shadow writing user addresses")
+	return &AddressShadowRepository{
+		UserRepositoryInterface: inner,
+		DB:                      db,
+		writer:                  shadow.NewWriter(AddressShadow, loggerInstance),
+		Logger:                  loggerInstance,
+	}
+}
+
+func (r *AddressShadowRepository) Create(userDomain *domainUser.User) (*domainUser.User, error) {
+	created, err := r.UserRepositoryInterface.Create(userDomain)
+	if err == nil {
+		r.mirror(created)
+	}
+	return created, err
+}
+
+func (r *AddressShadowRepository) Update(id uuid.UUID, userMap map[string]interface{}) (*domainUser.User, error) {
+	updated, err := r.UserRepositoryInterface.Update(id, userMap)
+	if err == nil {
+		r.mirror(updated)
+	}
+	return updated, err
+}
+
+func (r *AddressShadowRepository) Delete(id uuid.UUID) error {
+	return r.DeleteBy(id, nil)
+}
+
+func (r *AddressShadowRepository) DeleteBy(id uuid.UUID, deletedBy *uuid.UUID) error {
+	if err := r.UserRepositoryInterface.DeleteBy(id, deletedBy); err != nil {
+		return err
+	}
+	r.writer.Remove(id.String(), func() error {
+		return r.DB.Where("user_id = ?", id).Delete(&UserAddress{}).Error
+	})
+	return nil
+}
+
+func (r *AddressShadowRepository) Restore(id uuid.UUID) (*domainUser.User, error) {
+	restored, err := r.UserRepositoryInterface.Restore(id)
+	if err == nil {
+		r.mirror(restored)
+	}
+	return restored, err
+}
+
+// Backfill mirrors users whose home address is missing or stale, such as
+// those written before shadow writes were turned on.
+func (r *AddressShadowRepository) Backfill() (int, error) {
+	users, err := r.UserRepositoryInterface.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	copied := 0
+	for i := range *users {
+		user := &(*users)[i]
+		if fields, err := r.diff(user); err == nil && len(fields) == 0 {
+			continue
+		}
+		r.mirror(user)
+		copied++
+	}
+	stats := r.writer.Stats()
+	r.Logger.Info("Backfilled user addresses", zap.Int("copied", copied), zap.Int64("failed", stats.Failed), zap.Int64("mismatched", stats.Mismatched))
+	return copied, nil
+}
+
+func (r *AddressShadowRepository) mirror(user *domainUser.User) {
+	r.writer.Mirror(user.ID.String(), func() error {
+		address := addressFromLocation(user.ID, user.Location)
+		return r.DB.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "kind"}},
+			DoUpdates: clause.AssignmentColumns([]string{"house_number", "street", "city", "state", "pincode", "lat", "long", "updated_at"}),
+		}).Create(address).Error
+	}, func() ([]string, error) {
+		return r.diff(user)
+	})
+}
+
+// diff names the location fields whose home address copy differs.
+func (r *AddressShadowRepository) diff(user *domainUser.User) ([]string, error) {
+	var address UserAddress
+	err := r.DB.Where("user_id = ? AND kind = ?", user.ID, homeAddress).First(&address).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, shadow.ErrMissing
+	}
+	if err != nil {
+		return nil, err
+	}
+	return locationDiff(user.Location, address.toLocation()), nil
+}
+
+func addressFromLocation(userID uuid.UUID, l domainUser.Location) *UserAddress {
+	return &UserAddress{
+		UserID:      userID,
+		Kind:        homeAddress,
+		HouseNumber: l.HouseNumber,
+		Street:      l.Street,
+		City:        l.City,
+		State:       l.State,
+		Pincode:     l.Pincode,
+		Lat:         l.Lat,
+		Long:        l.Long,
+	}
+}
+
+func (a *UserAddress) toLocation() domainUser.Location {
+	return domainUser.Location{
+		HouseNumber: a.HouseNumber,
+		Street:      a.Street,
+		City:        a.City,
+		State:       a.State,
+		Pincode:     a.Pincode,
+		Lat:         a.Lat,
+		Long:        a.Long,
+	}
+}
+
+func locationDiff(primary, shadowed domainUser.Location) []string {
+	var fields []string
+	for _, field := range []struct {
+		name  string
+		equal bool
+	}{
+		{"HouseNumber", primary.HouseNumber == shadowed.HouseNumber},
+		{"Street", primary.Street == shadowed.Street},
+		{"City", primary.City == shadowed.City},
+		{"State", primary.State == shadowed.State},
+		{"Pincode", primary.Pincode == shadowed.Pincode},
+		{"Lat", primary.Lat == shadowed.Lat},
+		{"Long", primary.Long == shadowed.Long},
+	} {
+		if !field.equal {
+			fields = append(fields, field.name)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"testing"
+
+	domainUser "caregiver/src/domain/user"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithShadowWrites(t *testing.T) {
+	db, _, cleanup := setupMockDB(t)
+	defer cleanup()
+	logger := setupLogger(t)
+	inner := NewUserRepository(db, logger)
+
+	t.Setenv("SHADOW_WRITES", "")
+	assert.Same(t, inner, WithShadowWrites(inner, db, logger))
+
+	t.Setenv("SHADOW_WRITES", AddressShadow)
+	wrapped, ok := WithShadowWrites(inner, db, logger).(*AddressShadowRepository)
+	assert.True(t, ok)
+	assert.Same(t, inner, wrapped.UserRepositoryInterface)
+}
+
+func TestLocationDiff(t *testing.T) {
+	location := domainUser.Location{HouseNumber: "1", Street: "Main St", City: "Austin", Lat: 30.27}
+	address := addressFromLocation(uuid.New(), location)
+	assert.Equal(t, homeAddress, address.Kind)
+	assert.Empty(t, locationDiff(location, address.toLocation()))
+
+	address.City = "Dallas"
+	address.Lat = 32.78
+	assert.Equal(t, []string{"City", "Lat"}, locationDiff(location, address.toLocation()))
+}
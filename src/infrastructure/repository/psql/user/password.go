@@ -0,0 +1,109 @@
+package user
+
+import (
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PasswordToken struct {
+	ID        uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID    uuid.UUID  `gorm:"column:user_id;type:uuid;index"`
+	TokenHash string     `gorm:"column:token_hash;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime:milli"`
+}
+
+func (PasswordToken) TableName() string {
+	return "password_tokens"
+}
+
+// errTokenNotUsable rolls back SetPasswordWithToken when the token cannot be
+// used.
+var errTokenNotUsable = errors.New("password token not usable")
+
+type PasswordRepository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewPasswordRepository(db *gorm.DB, loggerInstance *logger.Logger) domainUser.IPasswordRepository {
+	return &PasswordRepository{DB: db, Logger: loggerInstance}
+}
+
+func (r *PasswordRepository) SetPassword(userID uuid.UUID, hash string) error {
+	result := r.DB.Model(&User{}).Where("id = ?", userID).Update("hash_password", hash)
+	if result.Error != nil {
+		r.Logger.Error("Error setting password", zap.Error(result.Error), zap.String("userID", userID.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *PasswordRepository) CreatePasswordToken(token *domainUser.PasswordToken) (*domainUser.PasswordToken, error) {
+	model := &PasswordToken{
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND used_at IS NULL", token.UserID).Delete(&PasswordToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		r.Logger.Error("Error creating password token", zap.Error(err), zap.String("userID", token.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *PasswordRepository) SetPasswordWithToken(tokenHash, passwordHash string, now time.Time) (uuid.UUID, error) {
+	var model PasswordToken
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errTokenNotUsable
+		}
+		if err := tx.Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+			return err
+		}
+		return tx.Model(&User{}).Where("id = ?", model.UserID).Update("hash_password", passwordHash).Error
+	})
+	if errors.Is(err, errTokenNotUsable) {
+		return uuid.Nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	if err != nil {
+		r.Logger.Error("Error setting password with token", zap.Error(err))
+		return uuid.Nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.UserID, nil
+}
+
+func (t *PasswordToken) toDomainMapper() *domainUser.PasswordToken {
+	return &domainUser.PasswordToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		TokenHash: t.TokenHash,
+		ExpiresAt: t.ExpiresAt,
+		UsedAt:    t.UsedAt,
+		CreatedAt: t.CreatedAt,
+	}
+}
@@ -2,12 +2,15 @@ package user
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"caregiver/src/domain"
 	domainErrors "caregiver/src/domain/errors"
 	domainUser "caregiver/src/domain/user"
 	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/repository/psql/softdelete"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -15,18 +18,23 @@ import (
 )
 
 type User struct {
-	ID             uuid.UUID           `gorm:"primaryKey"`
-	UserName       string              `gorm:"column:user_name;unique"`
-	Email          string              `gorm:"unique"`
-	FirstName      string              `gorm:"column:first_name"`
-	LastName       string              `gorm:"column:last_name"`
-	Status         bool                `gorm:"column:status"`
-	HashPassword   string              `gorm:"column:hash_password"`
-	Role           string              `gorm:"column:role"`
-	ProfilePicture string              `gorm:"column:profile_picture"`
-	Location       domainUser.Location `gorm:"embedded;embeddedPrefix:location_"`
-	CreatedAt      time.Time           `gorm:"autoCreateTime:mili"`
-	UpdatedAt      time.Time           `gorm:"autoUpdateTime:mili"`
+	ID               uuid.UUID           `gorm:"primaryKey"`
+	UserName         string              `gorm:"column:user_name;unique"`
+	Email            string              `gorm:"unique"`
+	Phone            string              `gorm:"column:phone"`
+	FirstName        string              `gorm:"column:first_name"`
+	LastName         string              `gorm:"column:last_name"`
+	Status           bool                `gorm:"column:status"`
+	HashPassword     string              `gorm:"column:hash_password"`
+	Role             string              `gorm:"column:role"`
+	ProfilePicture   string              `gorm:"column:profile_picture"`
+	HourlyRate       *float64            `gorm:"column:hourly_rate"`
+	ReferralSourceID *uuid.UUID          `gorm:"column:referral_source_id;type:uuid;index"`
+	Location         domainUser.Location `gorm:"embedded;embeddedPrefix:location_"`
+	CreatedAt        time.Time           `gorm:"autoCreateTime:mili"`
+	UpdatedAt        time.Time           `gorm:"autoUpdateTime:mili"`
+	DeletedAt        gorm.DeletedAt      `gorm:"index"`
+	DeletedBy        *uuid.UUID          `gorm:"column:deleted_by;type:uuid"`
 }
 
 func (User) TableName() string {
@@ -34,36 +42,45 @@ func (User) TableName() string {
 }
 
 var ColumnsUserMapping = map[string]string{
-	"ID":             "id",
-	"UserName":       "user_name",
-	"Email":          "email",
-	"FirstName":      "first_name",
-	"LastName":       "last_name",
-	"Status":         "status",
-	"HashPassword":   "hash_password",
-	"Role":           "role",
-	"ProfilePicture": "profile_picture",
-	"Location":       "location",
-	"HouseNumber":    "location_house_number",
-	"Street":         "location_street",
-	"City":           "location_city",
-	"State":          "location_state",
-	"Pincode":        "location_pincode",
-	"Lat":            "location_lat",
-	"Long":           "location_long",
-	"CreatedAt":      "created_at",
-	"UpdatedAt":      "updated_at",
+	"ID":               "id",
+	"UserName":         "user_name",
+	"Email":            "email",
+	"Phone":            "phone",
+	"FirstName":        "first_name",
+	"LastName":         "last_name",
+	"Status":           "status",
+	"HashPassword":     "hash_password",
+	"Role":             "role",
+	"ProfilePicture":   "profile_picture",
+	"HourlyRate":       "hourly_rate",
+	"ReferralSourceID": "referral_source_id",
+	"Location":         "location",
+	"HouseNumber":      "location_house_number",
+	"Street":           "location_street",
+	"City":             "location_city",
+	"State":            "location_state",
+	"Pincode":          "location_pincode",
+	"Lat":              "location_lat",
+	"Long":             "location_long",
+	"CreatedAt":        "created_at",
+	"UpdatedAt":        "updated_at",
+	"DeletedAt":        "deleted_at",
 }
 
 type UserRepositoryInterface interface {
 	GetAll() (*[]domainUser.User, error)
+	// Stream calls fn with every user as its row is read, and stops at the
+	// first error fn returns.
+	Stream(fn func(*domainUser.User) error) error
 	Create(userDomain *domainUser.User) (*domainUser.User, error)
 	GetByID(id uuid.UUID) (*domainUser.User, error)
 	GetByEmail(email string) (*domainUser.User, error)
 	Update(id uuid.UUID, userMap map[string]interface{}) (*domainUser.User, error)
 	Delete(id uuid.UUID) error
+	DeleteBy(id uuid.UUID, deletedBy *uuid.UUID) error
+	Restore(id uuid.UUID) (*domainUser.User, error)
 	SearchPaginated(filters domain.DataFilters) (*domainUser.SearchResultUser, error)
-	SearchByProperty(property string, searchText string) (*[]string, error)
+	SearchByProperty(search domainUser.PropertySearch) (*domainUser.SearchResultProperty, error)
 }
 
 type Repository struct {
@@ -85,6 +102,30 @@ func (r *Repository) GetAll() (*[]domainUser.User, error) {
 	return arrayToDomainMapper(&users), nil
 }
 
+func (r *Repository) Stream(fn func(*domainUser.User) error) error {
+	rows, err := r.DB.Model(&User{}).Rows()
+	if err != nil {
+		r.Logger.Error("Error streaming users", zap.Error(err))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var u User
+		if err := r.DB.ScanRows(rows, &u); err != nil {
+			r.Logger.Error("Error scanning streamed user", zap.Error(err))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		if err := fn(u.toDomainMapper()); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("Error streaming users", zap.Error(err))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
 func (r *Repository) Create(userDomain *domainUser.User) (*domainUser.User, error) {
 	r.Logger.Info("Creating new user", zap.String("email", userDomain.Email))
 	userRepository := fromDomainMapper(userDomain)
@@ -158,7 +199,7 @@ func (r *Repository) Update(id uuid.UUID, userMap map[string]interface{}) (*doma
 	}
 
 	err := r.DB.Model(&userObj).
-		Select("user_name", "email", "first_name", "last_name", "status", "role", "profile_picture",
+		Select("user_name", "email", "phone", "first_name", "last_name", "status", "role", "profile_picture", "hourly_rate", "referral_source_id",
 			"location_house_number", "location_street", "location_city",
 			"location_state", "location_pincode", "location_lat", "location_long").
 		Updates(updateData).Error
@@ -186,12 +227,17 @@ func (r *Repository) Update(id uuid.UUID, userMap map[string]interface{}) (*doma
 }
 
 func (r *Repository) Delete(id uuid.UUID) error {
-	tx := r.DB.Delete(&User{}, id)
-	if tx.Error != nil {
-		r.Logger.Error("Error deleting user", zap.Error(tx.Error), zap.String("id", id.String()))
+	return r.DeleteBy(id, nil)
+}
+
+// DeleteBy soft-deletes the user, recording who did it for the admin trash.
+func (r *Repository) DeleteBy(id uuid.UUID, deletedBy *uuid.UUID) error {
+	deleted, err := softdelete.Delete(r.DB, &User{}, id, deletedBy)
+	if err != nil {
+		r.Logger.Error("Error deleting user", zap.Error(err), zap.String("id", id.String()))
 		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
 	}
-	if tx.RowsAffected == 0 {
+	if !deleted {
 		r.Logger.Warn("User not found for deletion", zap.String("id", id.String()))
 		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
 	}
@@ -199,8 +245,25 @@ func (r *Repository) Delete(id uuid.UUID) error {
 	return nil
 }
 
+// Restore undoes a soft delete.
+func (r *Repository) Restore(id uuid.UUID) (*domainUser.User, error) {
+	restored, err := softdelete.Restore(r.DB, &User{}, id)
+	if err != nil {
+		r.Logger.Error("Error restoring user", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if !restored {
+		r.Logger.Warn("Deleted user not found for restore", zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	r.Logger.Info("Successfully restored user", zap.String("id", id.String()))
+	return r.GetByID(id)
+}
+
+// SearchPaginated leaves soft-deleted users out unless filters.Deleted asks
+// for them.
 func (r *Repository) SearchPaginated(filters domain.DataFilters) (*domainUser.SearchResultUser, error) {
-	query := r.DB.Model(&User{})
+	query := r.DB.Model(&User{}).Scopes(softdelete.Scope(filters.Deleted))
 
 	for field, values := range filters.LikeFilters {
 		if len(values) > 0 {
@@ -281,61 +344,124 @@ func (r *Repository) SearchPaginated(filters domain.DataFilters) (*domainUser.Se
 	return result, nil
 }
 
-func (r *Repository) SearchByProperty(property string, searchText string) (*[]string, error) {
-	column := ColumnsUserMapping[property]
-	if column == "" {
-		r.Logger.Warn("Invalid property for search", zap.String("property", property))
+// likeEscaper makes search text match literally inside an ILIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// SearchByProperty finds values of the given properties containing the
+// search text, one match per user and property, ranked exact matches first,
+// then prefixes, then the rest.
+func (r *Repository) SearchByProperty(search domainUser.PropertySearch) (*domainUser.SearchResultProperty, error) {
+	if search.Page < 1 {
+		search.Page = 1
+	}
+	if search.PageSize < 1 {
+		search.PageSize = 10
+	}
+	text := likeEscaper.Replace(search.Text)
+
+	var selects []string
+	var args []interface{}
+	for _, property := range search.Properties {
+		column := ColumnsUserMapping[property]
+		if column == "" {
+			r.Logger.Warn("Invalid property for search", zap.String("property", property))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.ValidationError)
+		}
+		value := "CAST(" + column + " AS text)"
+		selects = append(selects, fmt.Sprintf(
+			"SELECT id AS user_id, ? AS property, %[1]s AS value, "+
+				"CASE WHEN %[1]s ILIKE ? THEN %[2]d WHEN %[1]s ILIKE ? THEN %[3]d ELSE %[4]d END AS match_rank "+
+				"FROM users WHERE deleted_at IS NULL AND %[1]s ILIKE ?",
+			value, domainUser.MatchExact, domainUser.MatchPrefix, domainUser.MatchContains))
+		args = append(args, property, text, text+"%", "%"+text+"%")
+	}
+	if len(selects) == 0 {
 		return nil, domainErrors.NewAppErrorWithType(domainErrors.ValidationError)
 	}
+	matches := strings.Join(selects, " UNION ALL ")
+
+	var total int64
+	if err := r.DB.Raw("SELECT COUNT(*) FROM ("+matches+") AS matches", args...).Scan(&total).Error; err != nil {
+		r.Logger.Error("Error counting property matches", zap.Error(err), zap.Strings("properties", search.Properties))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
 
-	var coincidences []string
-	if err := r.DB.Model(&User{}).
-		Distinct(column).
-		Where(column+" ILIKE ?", "%"+searchText+"%").
-		Limit(20).
-		Pluck(column, &coincidences).Error; err != nil {
-		r.Logger.Error("Error searching by property", zap.Error(err), zap.String("property", property))
+	var rows []struct {
+		UserID    uuid.UUID
+		Property  string
+		Value     string
+		MatchRank int
+	}
+	offset := (search.Page - 1) * search.PageSize
+	err := r.DB.Raw(matches+" ORDER BY match_rank DESC, value, property, user_id LIMIT ? OFFSET ?", append(args, search.PageSize, offset)...).
+		Scan(&rows).Error
+	if err != nil {
+		r.Logger.Error("Error searching by property", zap.Error(err), zap.Strings("properties", search.Properties))
 		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
 	}
 
+	data := make([]domainUser.PropertyMatch, len(rows))
+	for i, row := range rows {
+		data[i] = domainUser.PropertyMatch{UserID: row.UserID, Property: row.Property, Value: row.Value, Rank: row.MatchRank}
+	}
 	r.Logger.Info("Successfully searched by property",
-		zap.String("property", property),
-		zap.Int("results", len(coincidences)))
+		zap.Strings("properties", search.Properties),
+		zap.Int64("total", total))
 
-	return &coincidences, nil
+	return &domainUser.SearchResultProperty{
+		Data:       &data,
+		Total:      total,
+		Page:       search.Page,
+		PageSize:   search.PageSize,
+		TotalPages: int((total + int64(search.PageSize) - 1) / int64(search.PageSize)),
+	}, nil
 }
 
 func (u *User) toDomainMapper() *domainUser.User {
 	return &domainUser.User{
-		ID:             u.ID,
-		UserName:       u.UserName,
-		Email:          u.Email,
-		FirstName:      u.FirstName,
-		LastName:       u.LastName,
-		Status:         u.Status,
-		HashPassword:   u.HashPassword,
-		Role:           u.Role,
-		ProfilePicture: u.ProfilePicture,
-		Location:       u.Location,
-		CreatedAt:      u.CreatedAt,
-		UpdatedAt:      u.UpdatedAt,
+		ID:               u.ID,
+		UserName:         u.UserName,
+		Email:            u.Email,
+		Phone:            u.Phone,
+		FirstName:        u.FirstName,
+		LastName:         u.LastName,
+		Status:           u.Status,
+		HashPassword:     u.HashPassword,
+		Role:             u.Role,
+		ProfilePicture:   u.ProfilePicture,
+		HourlyRate:       u.HourlyRate,
+		ReferralSourceID: u.ReferralSourceID,
+		Location:         u.Location,
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
+		DeletedAt:        deletedAtToDomain(u.DeletedAt),
+	}
+}
+
+func deletedAtToDomain(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
 	}
+	return &deletedAt.Time
 }
 
 func fromDomainMapper(u *domainUser.User) *User {
 	return &User{
-		ID:             u.ID,
-		UserName:       u.UserName,
-		Email:          u.Email,
-		FirstName:      u.FirstName,
-		LastName:       u.LastName,
-		Status:         u.Status,
-		HashPassword:   u.HashPassword,
-		Role:           u.Role,
-		ProfilePicture: u.ProfilePicture,
-		Location:       u.Location,
-		CreatedAt:      u.CreatedAt,
-		UpdatedAt:      u.UpdatedAt,
+		ID:               u.ID,
+		UserName:         u.UserName,
+		Email:            u.Email,
+		Phone:            u.Phone,
+		FirstName:        u.FirstName,
+		LastName:         u.LastName,
+		Status:           u.Status,
+		HashPassword:     u.HashPassword,
+		Role:             u.Role,
+		ProfilePicture:   u.ProfilePicture,
+		HourlyRate:       u.HourlyRate,
+		ReferralSourceID: u.ReferralSourceID,
+		Location:         u.Location,
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
 	}
 }
 
@@ -117,14 +117,14 @@ func TestRepository_GetByID(t *testing.T) {
 	id2 := uuid.New()
 	rows := sqlmock.NewRows([]string{"id", "user_name", "email", "first_name", "last_name", "status", "hash_password", "role", "location_house_number", "location_street", "location_city", "location_state", "location_pincode", "location_lat", "location_long"}).
 		AddRow(id1, "user1", "a@a.com", "A", "B", true, "hash1", "caregiver", "1", "Main St", "City", "State", "12345", 1.0, 2.0)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 ORDER BY "users"."id" LIMIT $2`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
 		WithArgs(id1, 1).WillReturnRows(rows)
 	user, err := repo.GetByID(id1)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 	assert.Equal(t, "user1", user.UserName)
 	// Not found
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 ORDER BY "users"."id" LIMIT $2`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
 		WithArgs(id2, 1).WillReturnRows(sqlmock.NewRows([]string{"id", "user_name", "email", "first_name", "last_name", "status", "hash_password", "role", "location_house_number", "location_street", "location_city", "location_state", "location_pincode", "location_lat", "location_long"}))
 	user, err = repo.GetByID(id2)
 	assert.Error(t, err)
@@ -148,8 +148,8 @@ func TestRepository_Create(t *testing.T) {
 		Location:     domainUser.Location{HouseNumber: "1", Street: "Main St"},
 	}
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "users" ("id","user_name","email","first_name","last_name","status","hash_password","role","profile_picture","location_house_number","location_street","location_city","location_state","location_pincode","location_lat","location_long","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18)`)).
-		WithArgs(sqlmock.AnyArg(), domainU.UserName, domainU.Email, domainU.FirstName, domainU.LastName, domainU.Status, domainU.HashPassword, domainU.Role, domainU.ProfilePicture, domainU.Location.HouseNumber, domainU.Location.Street, domainU.Location.City, domainU.Location.State, domainU.Location.Pincode, domainU.Location.Lat, domainU.Location.Long, sqlmock.AnyArg(), sqlmock.AnyArg()).
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "users" ("id","user_name","email","phone","first_name","last_name","status","hash_password","role","profile_picture","hourly_rate","referral_source_id","location_house_number","location_street","location_city","location_state","location_pincode","location_lat","location_long","created_at","updated_at","deleted_at","deleted_by") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23)`)).
+		WithArgs(sqlmock.AnyArg(), domainU.UserName, domainU.Email, domainU.Phone, domainU.FirstName, domainU.LastName, domainU.Status, domainU.HashPassword, domainU.Role, domainU.ProfilePicture, domainU.HourlyRate, domainU.ReferralSourceID, domainU.Location.HouseNumber, domainU.Location.Street, domainU.Location.City, domainU.Location.State, domainU.Location.Pincode, domainU.Location.Lat, domainU.Location.Long, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 	user, err := repo.Create(domainU)
@@ -166,15 +166,15 @@ func TestRepository_Delete(t *testing.T) {
 	id1 := uuid.New()
 	id2 := uuid.New()
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE "users"."id" = $1`)).
-		WithArgs(id1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "deleted_at"=$1,"deleted_by"=$2,"updated_at"=$3 WHERE id = $4 AND "users"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), nil, sqlmock.AnyArg(), id1).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 	err := repo.Delete(id1)
 	assert.NoError(t, err)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE "users"."id" = $1`)).
-		WithArgs(id2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "deleted_at"=$1,"deleted_by"=$2,"updated_at"=$3 WHERE id = $4 AND "users"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), nil, sqlmock.AnyArg(), id2).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectCommit()
 	err = repo.Delete(id2)
 	assert.Error(t, err)
@@ -189,7 +189,7 @@ func TestRepository_GetByEmail(t *testing.T) {
 	email := "test@example.com"
 	rows := sqlmock.NewRows([]string{"id", "user_name", "email", "first_name", "last_name", "status", "hash_password", "role", "location_house_number", "location_street", "location_city", "location_state", "location_pincode", "location_lat", "location_long"}).
 		AddRow(uuid.New(), "user1", email, "A", "B", true, "hash1", "caregiver", "1", "Main St", "City", "State", "12345", 1.0, 2.0)
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 ORDER BY "users"."id" LIMIT $2`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
 		WithArgs(email, 1).WillReturnRows(rows)
 	user, err := repo.GetByEmail(email)
 	assert.NoError(t, err)
@@ -198,7 +198,7 @@ func TestRepository_GetByEmail(t *testing.T) {
 
 	// Not found
 	emailNotFound := "notfound@example.com"
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 ORDER BY "users"."id" LIMIT $2`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
 		WithArgs(emailNotFound, 1).WillReturnRows(sqlmock.NewRows([]string{"id", "user_name", "email", "first_name", "last_name", "status", "hash_password", "role", "location_house_number", "location_street", "location_city", "location_state", "location_pincode", "location_lat", "location_long"}))
 	user, err = repo.GetByEmail(emailNotFound)
 	assert.Error(t, err)
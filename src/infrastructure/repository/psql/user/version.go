@@ -0,0 +1,138 @@
+package user
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserVersion struct {
+	ID            uuid.UUID           `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID        uuid.UUID           `gorm:"column:user_id;type:uuid;uniqueIndex:idx_user_versions_user_version"`
+	Version       int                 `gorm:"column:version;uniqueIndex:idx_user_versions_user_version"`
+	FirstName     string              `gorm:"column:first_name"`
+	LastName      string              `gorm:"column:last_name"`
+	Role          string              `gorm:"column:role"`
+	Status        bool                `gorm:"column:status"`
+	HourlyRate    *float64            `gorm:"column:hourly_rate"`
+	Location      domainUser.Location `gorm:"embedded;embeddedPrefix:location_"`
+	ChangedFields []string            `gorm:"column:changed_fields;serializer:json"`
+	EffectiveFrom time.Time           `gorm:"column:effective_from"`
+	EffectiveTo   *time.Time          `gorm:"column:effective_to"`
+	CreatedAt     time.Time           `gorm:"autoCreateTime:milli"`
+}
+
+func (UserVersion) TableName() string {
+	return "user_versions"
+}
+
+type VersionRepository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewUserVersionRepository(db *gorm.DB, loggerInstance *logger.Logger) domainUser.IUserVersionRepository {
+	return &VersionRepository{DB: db, Logger: loggerInstance}
+}
+
+func (r *VersionRepository) Append(version *domainUser.Version) (*domainUser.Version, error) {
+	model := versionFromDomainMapper(version)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the user's row so concurrent updates number versions in order.
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", version.UserID).First(&User{}).Error; err != nil {
+			return err
+		}
+		var latest int
+		if err := tx.Model(&UserVersion{}).Where("user_id = ?", version.UserID).Select("COALESCE(MAX(version), 0)").Scan(&latest).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&UserVersion{}).
+			Where("user_id = ? AND effective_to IS NULL", version.UserID).
+			Update("effective_to", version.EffectiveFrom).Error; err != nil {
+			return err
+		}
+		model.ID = uuid.New()
+		model.Version = latest + 1
+		model.EffectiveTo = nil
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		r.Logger.Error("Error appending user version", zap.Error(err), zap.String("userID", version.UserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("User version recorded", zap.String("userID", version.UserID.String()), zap.Int("version", model.Version))
+	return model.toDomainMapper(), nil
+}
+
+func (r *VersionRepository) GetByUserID(userID uuid.UUID) (*[]domainUser.Version, error) {
+	var models []UserVersion
+	if err := r.DB.Where("user_id = ?", userID).Order("version ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting user versions", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	versions := make([]domainUser.Version, len(models))
+	for i, m := range models {
+		versions[i] = *m.toDomainMapper()
+	}
+	return &versions, nil
+}
+
+func (r *VersionRepository) GetAt(userID uuid.UUID, at time.Time) (*domainUser.Version, error) {
+	var model UserVersion
+	err := r.DB.
+		Where("user_id = ? AND effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", userID, at, at).
+		Order("version DESC").
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("No user version in effect", zap.String("userID", userID.String()), zap.Time("at", at))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting user version in effect", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (v *UserVersion) toDomainMapper() *domainUser.Version {
+	return &domainUser.Version{
+		ID:            v.ID,
+		UserID:        v.UserID,
+		Version:       v.Version,
+		FirstName:     v.FirstName,
+		LastName:      v.LastName,
+		Role:          v.Role,
+		Status:        v.Status,
+		HourlyRate:    v.HourlyRate,
+		Location:      v.Location,
+		ChangedFields: v.ChangedFields,
+		EffectiveFrom: v.EffectiveFrom,
+		EffectiveTo:   v.EffectiveTo,
+		CreatedAt:     v.CreatedAt,
+	}
+}
+
+func versionFromDomainMapper(v *domainUser.Version) *UserVersion {
+	return &UserVersion{
+		ID:            v.ID,
+		UserID:        v.UserID,
+		Version:       v.Version,
+		FirstName:     v.FirstName,
+		LastName:      v.LastName,
+		Role:          v.Role,
+		Status:        v.Status,
+		HourlyRate:    v.HourlyRate,
+		Location:      v.Location,
+		ChangedFields: v.ChangedFields,
+		EffectiveFrom: v.EffectiveFrom,
+		EffectiveTo:   v.EffectiveTo,
+		CreatedAt:     v.CreatedAt,
+	}
+}
@@ -0,0 +1,200 @@
+package vitals
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainVitals "caregiver/src/domain/vitals"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Range struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID uuid.UUID `gorm:"column:client_user_id;type:uuid;uniqueIndex:idx_vital_ranges_client_measure"`
+	Measure      string    `gorm:"column:measure;uniqueIndex:idx_vital_ranges_client_measure"`
+	Min          *float64  `gorm:"column:min"`
+	Max          *float64  `gorm:"column:max"`
+	CreatedAt    time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Range) TableName() string {
+	return "vital_ranges"
+}
+
+type Reading struct {
+	ID               uuid.UUID             `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID       uuid.UUID             `gorm:"column:schedule_id;type:uuid;index"`
+	ClientUserID     uuid.UUID             `gorm:"column:client_user_id;type:uuid;index"`
+	RecordedByUserID uuid.UUID             `gorm:"column:recorded_by_user_id;type:uuid"`
+	Systolic         *float64              `gorm:"column:systolic"`
+	Diastolic        *float64              `gorm:"column:diastolic"`
+	Pulse            *float64              `gorm:"column:pulse"`
+	Glucose          *float64              `gorm:"column:glucose"`
+	Weight           *float64              `gorm:"column:weight"`
+	Note             string                `gorm:"column:note"`
+	Breaches         []domainVitals.Breach `gorm:"column:breaches;serializer:json"`
+	RecordedAt       time.Time             `gorm:"column:recorded_at;index"`
+	CreatedAt        time.Time             `gorm:"autoCreateTime:milli"`
+}
+
+func (Reading) TableName() string {
+	return "vital_readings"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewVitalsRepository(db *gorm.DB, loggerInstance *logger.Logger) domainVitals.IVitalsRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) SaveRange(newRange *domainVitals.Range) (*domainVitals.Range, error) {
+	rangeModel := &Range{
+		ID:           newRange.ID,
+		ClientUserID: newRange.ClientUserID,
+		Measure:      newRange.Measure,
+		Min:          newRange.Min,
+		Max:          newRange.Max,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "client_user_id"}, {Name: "measure"}},
+		DoUpdates: clause.AssignmentColumns([]string{"min", "max", "updated_at"}),
+	}).Create(rangeModel).Error
+	if err != nil {
+		r.Logger.Error("Error saving vital range", zap.Error(err), zap.String("clientUserID", newRange.ClientUserID.String()), zap.String("measure", newRange.Measure))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	var saved Range
+	if err := r.DB.Where("client_user_id = ? AND measure = ?", newRange.ClientUserID, newRange.Measure).First(&saved).Error; err != nil {
+		r.Logger.Error("Error getting saved vital range", zap.Error(err), zap.String("clientUserID", newRange.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Vital range saved successfully", zap.String("rangeID", saved.ID.String()))
+	return saved.toDomainMapper(), nil
+}
+
+func (r *Repository) GetRanges(clientUserID uuid.UUID) (*[]domainVitals.Range, error) {
+	var ranges []Range
+	if err := r.DB.Where("client_user_id = ?", clientUserID).Order("measure ASC").Find(&ranges).Error; err != nil {
+		r.Logger.Error("Error getting vital ranges", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainVitals.Range, len(ranges))
+	for i := range ranges {
+		res[i] = *ranges[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) DeleteRange(id uuid.UUID) error {
+	tx := r.DB.Delete(&Range{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting vital range", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Vital range not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (r *Repository) CreateReading(newReading *domainVitals.Reading) (*domainVitals.Reading, error) {
+	readingModel := &Reading{
+		ID:               newReading.ID,
+		ScheduleID:       newReading.ScheduleID,
+		ClientUserID:     newReading.ClientUserID,
+		RecordedByUserID: newReading.RecordedByUserID,
+		Systolic:         newReading.Systolic,
+		Diastolic:        newReading.Diastolic,
+		Pulse:            newReading.Pulse,
+		Glucose:          newReading.Glucose,
+		Weight:           newReading.Weight,
+		Note:             newReading.Note,
+		Breaches:         newReading.Breaches,
+		RecordedAt:       newReading.RecordedAt,
+	}
+	if err := r.DB.Create(readingModel).Error; err != nil {
+		r.Logger.Error("Error creating vitals reading", zap.Error(err), zap.String("scheduleID", newReading.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Vitals reading created successfully", zap.String("readingID", readingModel.ID.String()))
+	return readingModel.toDomainMapper(), nil
+}
+
+func (r *Repository) GetReadings(filter domainVitals.ReadingFilter) (*[]domainVitals.Reading, error) {
+	query := r.DB.Model(&Reading{})
+	if filter.ClientUserID != nil {
+		query = query.Where("client_user_id = ?", *filter.ClientUserID)
+	}
+	if filter.ScheduleID != nil {
+		query = query.Where("schedule_id = ?", *filter.ScheduleID)
+	}
+	if filter.From != nil {
+		query = query.Where("recorded_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("recorded_at < ?", *filter.To)
+	}
+	var readings []Reading
+	if err := query.Order("recorded_at ASC").Find(&readings).Error; err != nil {
+		r.Logger.Error("Error getting vitals readings", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make([]domainVitals.Reading, len(readings))
+	for i := range readings {
+		res[i] = *readings[i].toDomainMapper()
+	}
+	return &res, nil
+}
+
+func (r *Repository) DeleteReading(id uuid.UUID) error {
+	tx := r.DB.Delete(&Reading{}, "id = ?", id)
+	if tx.Error != nil {
+		r.Logger.Error("Error deleting vitals reading", zap.Error(tx.Error), zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	if tx.RowsAffected == 0 {
+		r.Logger.Warn("Vitals reading not found for deletion", zap.String("id", id.String()))
+		return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+	}
+	return nil
+}
+
+func (m *Range) toDomainMapper() *domainVitals.Range {
+	return &domainVitals.Range{
+		ID:           m.ID,
+		ClientUserID: m.ClientUserID,
+		Measure:      m.Measure,
+		Min:          m.Min,
+		Max:          m.Max,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func (m *Reading) toDomainMapper() *domainVitals.Reading {
+	return &domainVitals.Reading{
+		ID:               m.ID,
+		ScheduleID:       m.ScheduleID,
+		ClientUserID:     m.ClientUserID,
+		RecordedByUserID: m.RecordedByUserID,
+		Systolic:         m.Systolic,
+		Diastolic:        m.Diastolic,
+		Pulse:            m.Pulse,
+		Glucose:          m.Glucose,
+		Weight:           m.Weight,
+		Note:             m.Note,
+		Breaches:         m.Breaches,
+		RecordedAt:       m.RecordedAt,
+		CreatedAt:        m.CreatedAt,
+	}
+}
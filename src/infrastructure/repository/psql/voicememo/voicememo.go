@@ -0,0 +1,135 @@
+package voicememo
+
+import (
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainVoiceMemo "caregiver/src/domain/voicememo"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Memo struct {
+	ID               uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ScheduleID       uuid.UUID `gorm:"column:schedule_id;type:uuid;index"`
+	UploadedByUserID uuid.UUID `gorm:"column:uploaded_by_user_id;type:uuid"`
+	ContentType      string    `gorm:"column:content_type"`
+	Size             int64     `gorm:"column:size"`
+	StorageKey       string    `gorm:"column:storage_key"`
+	Status           string    `gorm:"column:status;index"`
+	Transcript       *string   `gorm:"column:transcript;type:text"`
+	FailureReason    *string   `gorm:"column:failure_reason"`
+	CreatedAt        time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+func (Memo) TableName() string {
+	return "visit_voice_memos"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewVoiceMemoRepository(db *gorm.DB, loggerInstance *logger.Logger) domainVoiceMemo.IVoiceMemoRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newMemo *domainVoiceMemo.Memo) (*domainVoiceMemo.Memo, error) {
+	model := fromDomainMapper(newMemo)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating voice memo", zap.Error(err), zap.String("scheduleID", newMemo.ScheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainVoiceMemo.Memo, error) {
+	var model Memo
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Voice memo not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting voice memo by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetBySchedule(scheduleID uuid.UUID) (*[]domainVoiceMemo.Memo, error) {
+	var models []Memo
+	if err := r.DB.Where("schedule_id = ?", scheduleID).Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting voice memos for schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&models), nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainVoiceMemo.Memo, error) {
+	var model Memo
+	model.ID = id
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating voice memo", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (r *Repository) SearchTranscripts(query string, limit int) (*[]domainVoiceMemo.Memo, error) {
+	var models []Memo
+	err := r.DB.Where("transcript ILIKE ?", "%"+query+"%").
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		r.Logger.Error("Error searching voice memo transcripts", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&models), nil
+}
+
+func (m *Memo) toDomainMapper() *domainVoiceMemo.Memo {
+	return &domainVoiceMemo.Memo{
+		ID:               m.ID,
+		ScheduleID:       m.ScheduleID,
+		UploadedByUserID: m.UploadedByUserID,
+		ContentType:      m.ContentType,
+		Size:             m.Size,
+		StorageKey:       m.StorageKey,
+		Status:           m.Status,
+		Transcript:       m.Transcript,
+		FailureReason:    m.FailureReason,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(m *domainVoiceMemo.Memo) *Memo {
+	return &Memo{
+		ID:               m.ID,
+		ScheduleID:       m.ScheduleID,
+		UploadedByUserID: m.UploadedByUserID,
+		ContentType:      m.ContentType,
+		Size:             m.Size,
+		StorageKey:       m.StorageKey,
+		Status:           m.Status,
+		Transcript:       m.Transcript,
+		FailureReason:    m.FailureReason,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(models *[]Memo) *[]domainVoiceMemo.Memo {
+	memos := make([]domainVoiceMemo.Memo, len(*models))
+	for i := range *models {
+		memos[i] = *(*models)[i].toDomainMapper()
+	}
+	return &memos
+}
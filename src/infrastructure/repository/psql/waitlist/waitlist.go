@@ -0,0 +1,153 @@
+package waitlist
+
+import (
+	"encoding/json"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainWaitlist "caregiver/src/domain/waitlist"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Entry struct {
+	ID            uuid.UUID                            `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ClientUserID  uuid.UUID                            `gorm:"column:client_user_id;type:uuid;index"`
+	ServiceName   string                               `gorm:"column:service_name"`
+	RequestedFrom time.Time                            `gorm:"column:requested_from;index"`
+	RequestedTo   time.Time                            `gorm:"column:requested_to"`
+	Notes         string                               `gorm:"column:notes"`
+	Status        string                               `gorm:"column:status;index"`
+	Candidates    []domainSchedule.CaregiverSuggestion `gorm:"column:candidates;serializer:json"`
+	MatchedAt     *time.Time                           `gorm:"column:matched_at"`
+	CreatedAt     time.Time                            `gorm:"autoCreateTime:milli"`
+	UpdatedAt     time.Time                            `gorm:"autoUpdateTime:milli"`
+}
+
+func (Entry) TableName() string {
+	return "waitlist_entries"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewWaitlistRepository(db *gorm.DB, loggerInstance *logger.Logger) domainWaitlist.IWaitlistRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) Create(newEntry *domainWaitlist.Entry) (*domainWaitlist.Entry, error) {
+	model := fromDomainMapper(newEntry)
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating waitlist entry", zap.Error(err), zap.String("clientUserID", newEntry.ClientUserID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	r.Logger.Info("Waitlist entry created successfully", zap.String("entryID", model.ID.String()))
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*domainWaitlist.Entry, error) {
+	var model Entry
+	err := r.DB.Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.Logger.Warn("Waitlist entry not found", zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting waitlist entry by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetAll(status string) (*[]domainWaitlist.Entry, error) {
+	var models []Entry
+	query := r.DB.Order("requested_from ASC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting waitlist entries", zap.Error(err), zap.String("status", status))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&models), nil
+}
+
+// GetWaitingBetween returns waiting entries whose requested slot overlaps
+// [from, to), oldest request first.
+func (r *Repository) GetWaitingBetween(from, to time.Time) (*[]domainWaitlist.Entry, error) {
+	var models []Entry
+	err := r.DB.
+		Where("status = ?", domainWaitlist.StatusWaiting).
+		Where("requested_from < ? AND requested_to > ?", to, from).
+		Order("created_at ASC").
+		Find(&models).Error
+	if err != nil {
+		r.Logger.Error("Error getting waiting entries in range", zap.Error(err), zap.Time("from", from), zap.Time("to", to))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return arrayToDomainMapper(&models), nil
+}
+
+func (r *Repository) Update(id uuid.UUID, updates map[string]interface{}) (*domainWaitlist.Entry, error) {
+	// Map updates bypass the field serializer, so encode candidates here.
+	if candidates, ok := updates["candidates"]; ok {
+		encoded, err := json.Marshal(candidates)
+		if err != nil {
+			r.Logger.Error("Error encoding waitlist candidates", zap.Error(err), zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		updates["candidates"] = string(encoded)
+	}
+	model := Entry{ID: id}
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating waitlist entry", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetByID(id)
+}
+
+func (e *Entry) toDomainMapper() *domainWaitlist.Entry {
+	return &domainWaitlist.Entry{
+		ID:            e.ID,
+		ClientUserID:  e.ClientUserID,
+		ServiceName:   e.ServiceName,
+		RequestedFrom: e.RequestedFrom,
+		RequestedTo:   e.RequestedTo,
+		Notes:         e.Notes,
+		Status:        e.Status,
+		Candidates:    e.Candidates,
+		MatchedAt:     e.MatchedAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+func fromDomainMapper(e *domainWaitlist.Entry) *Entry {
+	return &Entry{
+		ID:            e.ID,
+		ClientUserID:  e.ClientUserID,
+		ServiceName:   e.ServiceName,
+		RequestedFrom: e.RequestedFrom,
+		RequestedTo:   e.RequestedTo,
+		Notes:         e.Notes,
+		Status:        e.Status,
+		Candidates:    e.Candidates,
+		MatchedAt:     e.MatchedAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+func arrayToDomainMapper(models *[]Entry) *[]domainWaitlist.Entry {
+	entries := make([]domainWaitlist.Entry, len(*models))
+	for i, m := range *models {
+		entries[i] = *m.toDomainMapper()
+	}
+	return &entries
+}
@@ -0,0 +1,322 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainWebhook "caregiver/src/domain/webhook"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Subscription struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name          string    `gorm:"column:name"`
+	URL           string    `gorm:"column:url"`
+	Secret        string    `gorm:"column:secret"`
+	EventTypes    []string  `gorm:"column:event_types;serializer:json"`
+	Active        bool      `gorm:"column:active"`
+	DigestMinutes int       `gorm:"column:digest_minutes"`
+	CreatedAt     time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime:milli"`
+}
+
+type Delivery struct {
+	ID             uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	SubscriptionID uuid.UUID  `gorm:"column:subscription_id;type:uuid;index"`
+	EventID        uuid.UUID  `gorm:"column:event_id;type:uuid"`
+	EventType      string     `gorm:"column:event_type;index"`
+	Payload        string     `gorm:"column:payload;type:text"`
+	Status         string     `gorm:"column:status;index"`
+	Attempts       int        `gorm:"column:attempts"`
+	ResponseStatus int        `gorm:"column:response_status"`
+	LastError      string     `gorm:"column:last_error"`
+	NextAttemptAt  *time.Time `gorm:"column:next_attempt_at;index"`
+	LastAttemptAt  *time.Time `gorm:"column:last_attempt_at"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+	DigestID       *uuid.UUID `gorm:"column:digest_id;type:uuid;index"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime:milli"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime:milli"`
+}
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+type Repository struct {
+	DB     *gorm.DB
+	Logger *logger.Logger
+}
+
+func NewWebhookRepository(db *gorm.DB, loggerInstance *logger.Logger) domainWebhook.IWebhookRepository {
+	return &Repository{DB: db, Logger: loggerInstance}
+}
+
+func (r *Repository) CreateSubscription(subscription *domainWebhook.Subscription) (*domainWebhook.Subscription, error) {
+	model := &Subscription{
+		Name:          subscription.Name,
+		URL:           subscription.URL,
+		Secret:        subscription.Secret,
+		EventTypes:    subscription.EventTypes,
+		Active:        subscription.Active,
+		DigestMinutes: subscription.DigestMinutes,
+	}
+	if err := r.DB.Create(model).Error; err != nil {
+		r.Logger.Error("Error creating webhook subscription", zap.Error(err), zap.String("url", subscription.URL))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetSubscriptions() (*[]domainWebhook.Subscription, error) {
+	var models []Subscription
+	if err := r.DB.Order("created_at ASC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting webhook subscriptions", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return subscriptionsToDomain(models), nil
+}
+
+func (r *Repository) GetSubscriptionByID(id uuid.UUID) (*domainWebhook.Subscription, error) {
+	var model Subscription
+	if err := r.DB.Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting webhook subscription", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetActiveSubscriptions() (*[]domainWebhook.Subscription, error) {
+	var models []Subscription
+	if err := r.DB.Where("active = ?", true).Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting active webhook subscriptions", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return subscriptionsToDomain(models), nil
+}
+
+func (r *Repository) UpdateSubscription(id uuid.UUID, updates map[string]interface{}) (*domainWebhook.Subscription, error) {
+	// Map updates bypass the field serializer, so encode event types here.
+	if eventTypes, ok := updates["event_types"]; ok {
+		encoded, err := json.Marshal(eventTypes)
+		if err != nil {
+			r.Logger.Error("Error encoding webhook event types", zap.Error(err), zap.String("id", id.String()))
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		updates["event_types"] = string(encoded)
+	}
+	model := Subscription{ID: id}
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating webhook subscription", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetSubscriptionByID(id)
+}
+
+// DeleteSubscription removes the subscription and its delivery log.
+func (r *Repository) DeleteSubscription(id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&Delivery{}).Error; err != nil {
+			r.Logger.Error("Error deleting webhook deliveries", zap.Error(err), zap.String("subscriptionID", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		result := tx.Delete(&Subscription{}, "id = ?", id)
+		if result.Error != nil {
+			r.Logger.Error("Error deleting webhook subscription", zap.Error(result.Error), zap.String("id", id.String()))
+			return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+		}
+		if result.RowsAffected == 0 {
+			return domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) CreateDeliveries(deliveries []domainWebhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	models := make([]Delivery, len(deliveries))
+	for i, delivery := range deliveries {
+		models[i] = Delivery{
+			SubscriptionID: delivery.SubscriptionID,
+			EventID:        delivery.EventID,
+			EventType:      delivery.EventType,
+			Payload:        delivery.Payload,
+			Status:         delivery.Status,
+			NextAttemptAt:  delivery.NextAttemptAt,
+		}
+	}
+	if err := r.DB.Create(&models).Error; err != nil {
+		r.Logger.Error("Error creating webhook deliveries", zap.Error(err), zap.Int("count", len(models)))
+		return domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return nil
+}
+
+func (r *Repository) GetDeliveryByID(id uuid.UUID) (*domainWebhook.Delivery, error) {
+	var model Delivery
+	if err := r.DB.Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.NewAppErrorWithType(domainErrors.NotFound)
+		}
+		r.Logger.Error("Error getting webhook delivery", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (r *Repository) GetDeliveries(filter domainWebhook.DeliveryFilter) (*[]domainWebhook.Delivery, error) {
+	query := r.DB.Model(&Delivery{})
+	if filter.SubscriptionID != nil {
+		query = query.Where("subscription_id = ?", *filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	var models []Delivery
+	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+		r.Logger.Error("Error getting webhook deliveries", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return deliveriesToDomain(models), nil
+}
+
+func (r *Repository) GetDueDeliveries(now time.Time, limit int) (*[]domainWebhook.Delivery, error) {
+	var models []Delivery
+	err := r.DB.Where("status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", domainWebhook.StatusQueued, now).
+		Order("created_at ASC").Limit(limit).Find(&models).Error
+	if err != nil {
+		r.Logger.Error("Error getting due webhook deliveries", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return deliveriesToDomain(models), nil
+}
+
+func (r *Repository) UpdateDelivery(id uuid.UUID, updates map[string]interface{}) (*domainWebhook.Delivery, error) {
+	model := Delivery{ID: id}
+	if err := r.DB.Model(&model).Updates(updates).Error; err != nil {
+		r.Logger.Error("Error updating webhook delivery", zap.Error(err), zap.String("id", id.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return r.GetDeliveryByID(id)
+}
+
+func (r *Repository) GetHeldSince() (map[uuid.UUID]time.Time, error) {
+	var rows []struct {
+		SubscriptionID uuid.UUID
+		HeldSince      time.Time
+	}
+	err := r.DB.Model(&Delivery{}).Select("subscription_id, MIN(created_at) AS held_since").
+		Where("status = ?", domainWebhook.StatusHeld).Group("subscription_id").Scan(&rows).Error
+	if err != nil {
+		r.Logger.Error("Error getting held webhook events", zap.Error(err))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	res := make(map[uuid.UUID]time.Time, len(rows))
+	for _, row := range rows {
+		res[row.SubscriptionID] = row.HeldSince
+	}
+	return res, nil
+}
+
+func (r *Repository) GetHeldDeliveries(subscriptionID uuid.UUID, limit int) (*[]domainWebhook.Delivery, error) {
+	var models []Delivery
+	err := r.DB.Where("subscription_id = ? AND status = ?", subscriptionID, domainWebhook.StatusHeld).
+		Order("created_at ASC").Limit(limit).Find(&models).Error
+	if err != nil {
+		r.Logger.Error("Error getting held webhook events", zap.Error(err), zap.String("subscriptionID", subscriptionID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return deliveriesToDomain(models), nil
+}
+
+func (r *Repository) CreateDigest(digest *domainWebhook.Delivery, batchedIDs []uuid.UUID) (*domainWebhook.Delivery, error) {
+	model := &Delivery{
+		SubscriptionID: digest.SubscriptionID,
+		EventID:        digest.EventID,
+		EventType:      digest.EventType,
+		Payload:        digest.Payload,
+		Status:         digest.Status,
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Delivery{}).Where("id IN ? AND status = ?", batchedIDs, domainWebhook.StatusHeld).
+			Updates(map[string]interface{}{"status": domainWebhook.StatusBatched, "digest_id": model.ID}).Error
+	})
+	if err != nil {
+		r.Logger.Error("Error creating webhook digest", zap.Error(err), zap.String("subscriptionID", digest.SubscriptionID.String()))
+		return nil, domainErrors.NewAppErrorWithType(domainErrors.UnknownError)
+	}
+	return model.toDomainMapper(), nil
+}
+
+func (m *Subscription) toDomainMapper() *domainWebhook.Subscription {
+	return &domainWebhook.Subscription{
+		ID:            m.ID,
+		Name:          m.Name,
+		URL:           m.URL,
+		Secret:        m.Secret,
+		EventTypes:    m.EventTypes,
+		Active:        m.Active,
+		DigestMinutes: m.DigestMinutes,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func (m *Delivery) toDomainMapper() *domainWebhook.Delivery {
+	return &domainWebhook.Delivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventID:        m.EventID,
+		EventType:      m.EventType,
+		Payload:        m.Payload,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		ResponseStatus: m.ResponseStatus,
+		LastError:      m.LastError,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastAttemptAt:  m.LastAttemptAt,
+		DeliveredAt:    m.DeliveredAt,
+		DigestID:       m.DigestID,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func subscriptionsToDomain(models []Subscription) *[]domainWebhook.Subscription {
+	res := make([]domainWebhook.Subscription, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res
+}
+
+func deliveriesToDomain(models []Delivery) *[]domainWebhook.Delivery {
+	res := make([]domainWebhook.Delivery, len(models))
+	for i := range models {
+		res[i] = *models[i].toDomainMapper()
+	}
+	return &res
+}
@@ -12,6 +12,10 @@ func BindJSON(c *gin.Context, request any) error {
 	buf := make([]byte, 5120)
 	num, _ := c.Request.Body.Read(buf)
 	reqBody := string(buf[0:num])
+	if err := checkTimestamps([]byte(reqBody), request); err != nil {
+		c.Request.Body = io.NopCloser(bytes.NewBuffer([]byte(reqBody)))
+		return err
+	}
 	c.Request.Body = io.NopCloser(bytes.NewBuffer([]byte(reqBody)))
 	err := c.ShouldBindJSON(request)
 	c.Request.Body = io.NopCloser(bytes.NewBuffer([]byte(reqBody)))
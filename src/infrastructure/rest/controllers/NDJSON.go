@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NDJSONContentType is the media type of newline-delimited JSON, one value
+// per line.
+const NDJSONContentType = "application/x-ndjson"
+
+// ndjsonFlushEvery is how many lines are written between flushes, so clients
+// see rows as they arrive without a flush per row.
+const ndjsonFlushEvery = 100
+
+// WantsNDJSON reports whether the client asked for a list as NDJSON through
+// the Accept header.
+func WantsNDJSON(ctx *gin.Context) bool {
+	return strings.Contains(ctx.GetHeader("Accept"), NDJSONContentType)
+}
+
+// NDJSONWriter streams values to the response one line at a time. Nothing is
+// written until the first value, so an error before then can still be
+// answered with a normal error response.
+type NDJSONWriter struct {
+	ctx     *gin.Context
+	encoder *json.Encoder
+	lines   int
+}
+
+func NewNDJSONWriter(ctx *gin.Context) *NDJSONWriter {
+	return &NDJSONWriter{ctx: ctx}
+}
+
+// Write encodes value as the next line.
+func (w *NDJSONWriter) Write(value any) error {
+	if w.encoder == nil {
+		w.ctx.Header("Content-Type", NDJSONContentType)
+		w.ctx.Status(http.StatusOK)
+		w.encoder = json.NewEncoder(w.ctx.Writer)
+	}
+	if err := w.encoder.Encode(value); err != nil {
+		return err
+	}
+	w.lines++
+	if w.lines%ndjsonFlushEvery == 0 {
+		w.ctx.Writer.Flush()
+	}
+	return nil
+}
+
+// Close ends the stream. When err is set and lines have already gone out,
+// the status can no longer change, so a last {"Error": ...} line tells the
+// client the stream is incomplete; otherwise err is handed to the error
+// handler. A stream with no values is an empty 200 response.
+func (w *NDJSONWriter) Close(err error) {
+	switch {
+	case err != nil && w.encoder != nil:
+		_ = w.encoder.Encode(map[string]string{"Error": err.Error()})
+	case err != nil:
+		_ = w.ctx.Error(err)
+		return
+	case w.encoder == nil:
+		w.ctx.Header("Content-Type", NDJSONContentType)
+		w.ctx.Status(http.StatusOK)
+		w.ctx.Writer.WriteHeaderNow()
+		return
+	}
+	w.ctx.Writer.Flush()
+}
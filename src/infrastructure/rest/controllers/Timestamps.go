@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// checkTimestamps reports the first time-typed field of target whose value in
+// the JSON body is not an RFC3339 timestamp with an explicit offset. Naive
+// timestamps are ambiguous and were being interpreted differently across
+// handlers. A malformed body is left for binding to report.
+func checkTimestamps(body []byte, target any) error {
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+	return walkTimestamps("", reflect.TypeOf(target), payload)
+}
+
+func walkTimestamps(path string, t reflect.Type, value any) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		if s, ok := value.(string); ok {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return fmt.Errorf("field '%s' must be an RFC3339 timestamp with an explicit offset (e.g. 2025-07-16T09:00:00Z)", path)
+			}
+		}
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, embedded := jsonName(field)
+			switch {
+			case !field.IsExported() || name == "-":
+			case embedded:
+				if err := walkTimestamps(path, field.Type, object); err != nil {
+					return err
+				}
+			default:
+				if child, ok := lookupField(object, name); ok {
+					if err := walkTimestamps(joinPath(path, name), field.Type, child); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, _ := value.([]any)
+		for i, item := range items {
+			if err := walkTimestamps(fmt.Sprintf("%s[%d]", path, i), t.Elem(), item); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		object, _ := value.(map[string]any)
+		for key, child := range object {
+			if err := walkTimestamps(joinPath(path, key), t.Elem(), child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonName returns the key encoding/json reads the field from, and whether
+// the field is an untagged embedded struct whose fields are read inline.
+func jsonName(field reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		return field.Name, field.Anonymous && fieldType.Kind() == reflect.Struct
+	}
+	return name, false
+}
+
+// lookupField finds the key like encoding/json does: an exact match first,
+// then one differing only in case.
+func lookupField(object map[string]any, name string) (any, bool) {
+	if value, ok := object[name]; ok {
+		return value, true
+	}
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type timestampSlot struct {
+	From time.Time `json:"From"`
+	To   time.Time `json:"To"`
+}
+
+type timestampRequest struct {
+	ServiceName   string          `json:"ServiceName"`
+	ScheduledSlot timestampSlot   `json:"ScheduledSlot"`
+	Segments      []timestampSlot `json:"Segments"`
+	Timestamp     *time.Time      `json:"timestamp"`
+	Note          string          `json:"note"`
+}
+
+func TestBindJSONRequiresTimestampOffsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"UTC timestamp", `{"ScheduledSlot":{"From":"2025-07-16T09:00:00Z","To":"2025-07-16T10:00:00Z"}}`, ""},
+		{"Offset timestamp", `{"timestamp":"2025-07-16T09:00:00+05:30"}`, ""},
+		{"Naive timestamp", `{"ScheduledSlot":{"From":"2025-07-16T09:00:00"}}`, "field 'ScheduledSlot.From' must be an RFC3339 timestamp"},
+		{"Naive timestamp in array", `{"Segments":[{"From":"2025-07-16 09:00"}]}`, "field 'Segments[0].From' must be an RFC3339 timestamp"},
+		{"Timestamp-like text untouched", `{"ServiceName":"Morning visit","note":"2025-07-16 09:00 by phone"}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := setupGinContext()
+			c.Request = httptest.NewRequest("POST", "/test", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			var request timestampRequest
+			err := BindJSON(c, &request)
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package controllers
 import (
 	"strconv"
 
+	domainUser "caregiver/src/domain/user"
+
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 func PaginationValues(limit int64, page int64, total int64) (numPages int64, nextCursor int64, prevCursor int64) {
@@ -25,3 +28,45 @@ func GetQueryInt(ctx *gin.Context, key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// GetQueryUUID parses an optional UUID query parameter, returning nil when it
+// is absent.
+func GetQueryUUID(ctx *gin.Context, key string) (*uuid.UUID, error) {
+	strVal := ctx.Query(key)
+	if strVal == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(strVal)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// ViewerRole returns the role of whoever is asking, which response mappers
+// trim data for. Anonymous callers get family, the most restricted role.
+func ViewerRole(ctx *gin.Context) string {
+	if role := ctx.GetString(CallerRoleKey); role != "" {
+		return role
+	}
+	return domainUser.RoleFamily
+}
+
+// CallerIDKey is the context key holding the user ID of an authenticated
+// caller, set from the bearer token by the IdentifyCaller middleware.
+const CallerIDKey = "callerID"
+
+// CallerRoleKey is the context key holding the authenticated caller's role,
+// looked up by the IdentifyCaller middleware.
+const CallerRoleKey = "callerRole"
+
+// CallerID returns the authenticated caller's user ID, or nil when the
+// request carried no bearer token.
+func CallerID(ctx *gin.Context) *uuid.UUID {
+	if id, ok := ctx.Get(CallerIDKey); ok {
+		if callerID, ok := id.(uuid.UUID); ok {
+			return &callerID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package access
+
+import (
+	"errors"
+	"net/http"
+
+	accessUseCase "caregiver/src/application/usecases/access"
+	domainAccess "caregiver/src/domain/access"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IAccessController interface {
+	Explain(ctx *gin.Context)
+}
+
+type Controller struct {
+	accessUseCase accessUseCase.IAccessUseCase
+	Logger        *logger.Logger
+}
+
+func NewAccessController(accessUseCase accessUseCase.IAccessUseCase, loggerInstance *logger.Logger) IAccessController {
+	return &Controller{accessUseCase: accessUseCase, Logger: loggerInstance}
+}
+
+// Explain answers "who can see this?" for one user and one record, e.g.
+// GET /admin/access/schedules/:id?userID=...
+func (c *Controller) Explain(ctx *gin.Context) {
+	entityID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid entity ID for access explanation", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	userID, err := uuid.Parse(ctx.Query("userID"))
+	if err != nil {
+		c.Logger.Error("Invalid user ID for access explanation", zap.Error(err), zap.String("userID", ctx.Query("userID")))
+		appError := domainErrors.NewAppError(errors.New("userID query parameter is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	explanation, err := c.accessUseCase.Explain(domainAccess.Kind(ctx.Param("type")), entityID, userID)
+	if err != nil {
+		c.Logger.Error("Error explaining access", zap.Error(err), zap.String("id", entityID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, explanationToResponseMapper(explanation))
+}
+
+func explanationToResponseMapper(e *domainAccess.Explanation) *ExplanationResponse {
+	steps := make([]StepResponse, len(e.Steps))
+	for i, step := range e.Steps {
+		steps[i] = StepResponse{
+			Rule:      step.Rule,
+			Effect:    step.Effect,
+			Reason:    step.Reason,
+			Decisive:  step.Decisive,
+			Evaluated: step.Evaluated,
+		}
+	}
+	return &ExplanationResponse{
+		Type:     string(e.Kind),
+		EntityID: e.EntityID,
+		UserID:   e.UserID,
+		Role:     e.Role,
+		Allowed:  e.Allowed,
+		Level:    e.Level,
+		Steps:    steps,
+	}
+}
@@ -0,0 +1,21 @@
+package access
+
+import "github.com/google/uuid"
+
+type StepResponse struct {
+	Rule      string `json:"Rule"`
+	Effect    string `json:"Effect"`
+	Reason    string `json:"Reason"`
+	Decisive  bool   `json:"Decisive"`
+	Evaluated bool   `json:"Evaluated"`
+}
+
+type ExplanationResponse struct {
+	Type     string         `json:"Type"`
+	EntityID uuid.UUID      `json:"EntityID"`
+	UserID   uuid.UUID      `json:"UserID"`
+	Role     string         `json:"Role"`
+	Allowed  bool           `json:"Allowed"`
+	Level    string         `json:"Level"`
+	Steps    []StepResponse `json:"Steps"`
+}
@@ -0,0 +1,307 @@
+package alert
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	alertUseCase "caregiver/src/application/usecases/alert"
+	domainAlert "caregiver/src/domain/alert"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IAlertController interface {
+	GetPolicies(ctx *gin.Context)
+	CreatePolicy(ctx *gin.Context)
+	GetPolicyByID(ctx *gin.Context)
+	UpdatePolicy(ctx *gin.Context)
+	DeletePolicy(ctx *gin.Context)
+	GetAlerts(ctx *gin.Context)
+	GetAlertByID(ctx *gin.Context)
+	RaiseIncident(ctx *gin.Context)
+	RaiseEmergency(ctx *gin.Context)
+	Acknowledge(ctx *gin.Context)
+	Sweep(ctx *gin.Context)
+}
+
+type Controller struct {
+	alertUseCase alertUseCase.IAlertUseCase
+	Logger       *logger.Logger
+}
+
+func NewAlertController(alertUseCase alertUseCase.IAlertUseCase, loggerInstance *logger.Logger) IAlertController {
+	return &Controller{alertUseCase: alertUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetPolicies(ctx *gin.Context) {
+	policies, err := c.alertUseCase.GetPolicies()
+	if err != nil {
+		c.Logger.Error("Error getting escalation policies", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]PolicyResponse, len(*policies))
+	for i := range *policies {
+		res[i] = *policyToResponseMapper(&(*policies)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) CreatePolicy(ctx *gin.Context) {
+	var request PolicyRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new escalation policy", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	policy, err := c.alertUseCase.CreatePolicy(policyFromRequest(&request))
+	if err != nil {
+		c.Logger.Error("Error creating escalation policy", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Escalation policy created successfully", zap.String("policyID", policy.ID.String()))
+	ctx.JSON(http.StatusCreated, policyToResponseMapper(policy))
+}
+
+func (c *Controller) GetPolicyByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "policy")
+	if !ok {
+		return
+	}
+	policy, err := c.alertUseCase.GetPolicyByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting escalation policy", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, policyToResponseMapper(policy))
+}
+
+// UpdatePolicy replaces a policy's name, type and steps. Active is kept
+// unless given.
+func (c *Controller) UpdatePolicy(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "policy")
+	if !ok {
+		return
+	}
+	var request PolicyRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for escalation policy update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	existing, err := c.alertUseCase.GetPolicyByID(id)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	policy := policyFromRequest(&request)
+	policy.ID = id
+	policy.Active = existing.Active
+	if request.Active != nil {
+		policy.Active = *request.Active
+	}
+	updated, err := c.alertUseCase.UpdatePolicy(policy)
+	if err != nil {
+		c.Logger.Error("Error updating escalation policy", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, policyToResponseMapper(updated))
+}
+
+func (c *Controller) DeletePolicy(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "policy")
+	if !ok {
+		return
+	}
+	if err := c.alertUseCase.DeletePolicy(id); err != nil {
+		c.Logger.Error("Error deleting escalation policy", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetAlerts lists alerts, newest first, optionally filtered by ?status=.
+func (c *Controller) GetAlerts(ctx *gin.Context) {
+	alerts, err := c.alertUseCase.GetAlerts(ctx.Query("status"))
+	if err != nil {
+		c.Logger.Error("Error getting alerts", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]AlertResponse, len(*alerts))
+	for i := range *alerts {
+		res[i] = *alertToResponseMapper(&(*alerts)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetAlertByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "alert")
+	if !ok {
+		return
+	}
+	alert, err := c.alertUseCase.GetAlertByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting alert", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, alertToResponseMapper(alert))
+}
+
+func (c *Controller) RaiseIncident(ctx *gin.Context) {
+	var request IncidentRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for incident", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	alert, err := c.alertUseCase.RaiseIncident(request.ScheduleID, request.Message)
+	if err != nil {
+		c.Logger.Error("Error raising incident", zap.Error(err), zap.String("scheduleID", request.ScheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Incident raised", zap.String("alertID", alert.ID.String()))
+	ctx.JSON(http.StatusCreated, alertToResponseMapper(alert))
+}
+
+// RaiseEmergency is the caregiver app's one-tap SOS during a visit. The body
+// is optional so the app can send it before a location fix is available.
+func (c *Controller) RaiseEmergency(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "schedule")
+	if !ok {
+		return
+	}
+	var request EmergencyRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := controllers.BindJSON(ctx, &request); err != nil {
+			c.Logger.Error("Error binding JSON for emergency", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+			appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+	}
+	location := domainSchedule.Location{Lat: request.Latitude, Long: request.Longitude}
+	alert, err := c.alertUseCase.RaiseEmergency(scheduleID, location, request.Note, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error raising emergency", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Warn("Emergency raised", zap.String("alertID", alert.ID.String()), zap.String("scheduleID", scheduleID.String()))
+	ctx.JSON(http.StatusCreated, alertToResponseMapper(alert))
+}
+
+func (c *Controller) Acknowledge(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "alert")
+	if !ok {
+		return
+	}
+	var request AcknowledgeRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for alert acknowledgment", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	alert, err := c.alertUseCase.Acknowledge(id, request.UserID)
+	if err != nil {
+		c.Logger.Error("Error acknowledging alert", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Alert acknowledged", zap.String("alertID", id.String()), zap.String("userID", request.UserID.String()))
+	ctx.JSON(http.StatusOK, alertToResponseMapper(alert))
+}
+
+// Sweep raises missed-visit alerts and runs due escalations immediately. The
+// server also sweeps on its own interval; this is for external schedulers.
+func (c *Controller) Sweep(ctx *gin.Context) {
+	result, err := c.alertUseCase.Sweep(time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error sweeping alerts", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SweepResponse{Raised: result.Raised, Escalated: result.Escalated})
+}
+
+func (c *Controller) parseID(ctx *gin.Context, resource string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+resource+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(resource+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func policyFromRequest(request *PolicyRequest) *domainAlert.Policy {
+	steps := make([]domainAlert.Step, len(request.Steps))
+	for i, step := range request.Steps {
+		steps[i] = domainAlert.Step{Target: step.Target, DelayMinutes: step.DelayMinutes, UserID: step.UserID}
+	}
+	return &domainAlert.Policy{
+		Name:      request.Name,
+		AlertType: request.AlertType,
+		Steps:     steps,
+	}
+}
+
+func policyToResponseMapper(p *domainAlert.Policy) *PolicyResponse {
+	steps := make([]StepRequest, len(p.Steps))
+	for i, step := range p.Steps {
+		steps[i] = StepRequest{Target: step.Target, DelayMinutes: step.DelayMinutes, UserID: step.UserID}
+	}
+	return &PolicyResponse{
+		ID:        p.ID,
+		Name:      p.Name,
+		AlertType: p.AlertType,
+		Steps:     steps,
+		Active:    p.Active,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func alertToResponseMapper(a *domainAlert.Alert) *AlertResponse {
+	notifications := make([]NotificationResponse, len(a.Notifications))
+	for i, n := range a.Notifications {
+		notifications[i] = NotificationResponse{Step: n.Step, Target: n.Target, UserID: n.UserID, SentAt: n.SentAt}
+	}
+	return &AlertResponse{
+		ID:               a.ID,
+		Type:             a.Type,
+		PolicyID:         a.PolicyID,
+		ScheduleID:       a.ScheduleID,
+		CaregiverUserID:  a.CaregiverUserID,
+		ClientUserID:     a.ClientUserID,
+		Message:          a.Message,
+		Emergency:        a.Emergency,
+		Latitude:         a.Location.Lat,
+		Longitude:        a.Location.Long,
+		Status:           a.Status,
+		NextEscalationAt: a.NextEscalationAt,
+		Notifications:    notifications,
+		AcknowledgedBy:   a.AcknowledgedBy,
+		AcknowledgedAt:   a.AcknowledgedAt,
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        a.UpdatedAt,
+	}
+}
@@ -0,0 +1,79 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StepRequest struct {
+	Target       string     `json:"Target" binding:"required"`
+	DelayMinutes int        `json:"DelayMinutes"`
+	UserID       *uuid.UUID `json:"UserID"`
+}
+
+type PolicyRequest struct {
+	Name      string        `json:"Name" binding:"required"`
+	AlertType string        `json:"AlertType" binding:"required"`
+	Steps     []StepRequest `json:"Steps" binding:"required"`
+	Active    *bool         `json:"Active"`
+}
+
+type PolicyResponse struct {
+	ID        uuid.UUID     `json:"ID"`
+	Name      string        `json:"Name"`
+	AlertType string        `json:"AlertType"`
+	Steps     []StepRequest `json:"Steps"`
+	Active    bool          `json:"Active"`
+	CreatedAt time.Time     `json:"CreatedAt"`
+	UpdatedAt time.Time     `json:"UpdatedAt"`
+}
+
+type IncidentRequest struct {
+	ScheduleID uuid.UUID `json:"ScheduleID" binding:"required"`
+	Message    string    `json:"Message" binding:"required"`
+}
+
+// EmergencyRequest is the optional body of an SOS: where the caregiver is
+// and anything they had time to add.
+type EmergencyRequest struct {
+	Latitude  *float64 `json:"Latitude"`
+	Longitude *float64 `json:"Longitude"`
+	Note      string   `json:"Note"`
+}
+
+type AcknowledgeRequest struct {
+	UserID uuid.UUID `json:"UserID" binding:"required"`
+}
+
+type NotificationResponse struct {
+	Step   int       `json:"Step"`
+	Target string    `json:"Target"`
+	UserID uuid.UUID `json:"UserID"`
+	SentAt time.Time `json:"SentAt"`
+}
+
+type AlertResponse struct {
+	ID               uuid.UUID              `json:"ID"`
+	Type             string                 `json:"Type"`
+	PolicyID         *uuid.UUID             `json:"PolicyID"`
+	ScheduleID       *uuid.UUID             `json:"ScheduleID"`
+	CaregiverUserID  *uuid.UUID             `json:"CaregiverUserID"`
+	ClientUserID     *uuid.UUID             `json:"ClientUserID"`
+	Message          string                 `json:"Message"`
+	Emergency        bool                   `json:"Emergency"`
+	Latitude         *float64               `json:"Latitude"`
+	Longitude        *float64               `json:"Longitude"`
+	Status           string                 `json:"Status"`
+	NextEscalationAt *time.Time             `json:"NextEscalationAt"`
+	Notifications    []NotificationResponse `json:"Notifications"`
+	AcknowledgedBy   *uuid.UUID             `json:"AcknowledgedBy"`
+	AcknowledgedAt   *time.Time             `json:"AcknowledgedAt"`
+	CreatedAt        time.Time              `json:"CreatedAt"`
+	UpdatedAt        time.Time              `json:"UpdatedAt"`
+}
+
+type SweepResponse struct {
+	Raised    int `json:"Raised"`
+	Escalated int `json:"Escalated"`
+}
@@ -0,0 +1,139 @@
+package announcement
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	announcementUseCase "caregiver/src/application/usecases/announcement"
+	domainAnnouncement "caregiver/src/domain/announcement"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IAnnouncementController interface {
+	Send(ctx *gin.Context)
+	GetAll(ctx *gin.Context)
+	GetByID(ctx *gin.Context)
+	GetDeliveries(ctx *gin.Context)
+}
+
+type Controller struct {
+	announcementUseCase announcementUseCase.IAnnouncementUseCase
+	Logger              *logger.Logger
+}
+
+func NewAnnouncementController(announcementUseCase announcementUseCase.IAnnouncementUseCase, loggerInstance *logger.Logger) IAnnouncementController {
+	return &Controller{announcementUseCase: announcementUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) Send(ctx *gin.Context) {
+	var request SendRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for announcement", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	audience := domainAnnouncement.Audience{
+		TeamIDs:      request.TeamIDs,
+		Regions:      request.Regions,
+		UpcomingFrom: request.UpcomingFrom,
+		UpcomingTo:   request.UpcomingTo,
+	}
+	announcement, err := c.announcementUseCase.Send(request.Subject, request.Body, audience, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error sending announcement", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Announcement sent successfully", zap.String("id", announcement.ID.String()), zap.Int("reached", announcement.Reached))
+	ctx.JSON(http.StatusOK, announcementToResponseMapper(announcement))
+}
+
+func (c *Controller) GetAll(ctx *gin.Context) {
+	announcements, err := c.announcementUseCase.GetAll()
+	if err != nil {
+		c.Logger.Error("Error getting announcements", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]AnnouncementResponse, len(*announcements))
+	for i := range *announcements {
+		res[i] = *announcementToResponseMapper(&(*announcements)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	announcement, err := c.announcementUseCase.GetByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting announcement", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, announcementToResponseMapper(announcement))
+}
+
+func (c *Controller) GetDeliveries(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	deliveries, err := c.announcementUseCase.GetDeliveries(id)
+	if err != nil {
+		c.Logger.Error("Error getting announcement deliveries", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]DeliveryResponse, len(*deliveries))
+	for i, delivery := range *deliveries {
+		res[i] = DeliveryResponse{
+			ID:        delivery.ID,
+			UserID:    delivery.UserID,
+			Channel:   delivery.Channel,
+			Status:    delivery.Status,
+			Error:     delivery.Error,
+			CreatedAt: delivery.CreatedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid announcement ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("announcement id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func announcementToResponseMapper(announcement *domainAnnouncement.Announcement) *AnnouncementResponse {
+	return &AnnouncementResponse{
+		ID:      announcement.ID,
+		Subject: announcement.Subject,
+		Body:    announcement.Body,
+		Audience: AudienceResponse{
+			TeamIDs:      announcement.Audience.TeamIDs,
+			Regions:      announcement.Audience.Regions,
+			UpcomingFrom: announcement.Audience.UpcomingFrom,
+			UpcomingTo:   announcement.Audience.UpcomingTo,
+		},
+		SentByUserID: announcement.SentByUserID,
+		SentAt:       announcement.SentAt,
+		Recipients:   announcement.Recipients,
+		Reached:      announcement.Reached,
+	}
+}
@@ -0,0 +1,46 @@
+package announcement
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SendRequest picks the caregivers by team, by the city or state they live
+// in and by having a visit between UpcomingFrom and UpcomingTo. Filters
+// combine; leaving them all out sends to every active caregiver.
+type SendRequest struct {
+	Subject      string      `json:"Subject" binding:"required"`
+	Body         string      `json:"Body" binding:"required"`
+	TeamIDs      []uuid.UUID `json:"TeamIDs"`
+	Regions      []string    `json:"Regions"`
+	UpcomingFrom *time.Time  `json:"UpcomingFrom"`
+	UpcomingTo   *time.Time  `json:"UpcomingTo"`
+}
+
+type AudienceResponse struct {
+	TeamIDs      []uuid.UUID `json:"TeamIDs"`
+	Regions      []string    `json:"Regions"`
+	UpcomingFrom *time.Time  `json:"UpcomingFrom"`
+	UpcomingTo   *time.Time  `json:"UpcomingTo"`
+}
+
+type AnnouncementResponse struct {
+	ID           uuid.UUID        `json:"ID"`
+	Subject      string           `json:"Subject"`
+	Body         string           `json:"Body"`
+	Audience     AudienceResponse `json:"Audience"`
+	SentByUserID *uuid.UUID       `json:"SentByUserID"`
+	SentAt       time.Time        `json:"SentAt"`
+	Recipients   int              `json:"Recipients"`
+	Reached      int              `json:"Reached"`
+}
+
+type DeliveryResponse struct {
+	ID        uuid.UUID `json:"ID"`
+	UserID    uuid.UUID `json:"UserID"`
+	Channel   string    `json:"Channel"`
+	Status    string    `json:"Status"`
+	Error     string    `json:"Error,omitempty"`
+	CreatedAt time.Time `json:"CreatedAt"`
+}
@@ -0,0 +1,88 @@
+package appversion
+
+import (
+	"net/http"
+
+	appVersionUseCase "caregiver/src/application/usecases/appversion"
+	domainAppVersion "caregiver/src/domain/appversion"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type IAppVersionController interface {
+	GetRequirements(ctx *gin.Context)
+	SaveRequirement(ctx *gin.Context)
+	DeleteRequirement(ctx *gin.Context)
+}
+
+type Controller struct {
+	appVersionUseCase appVersionUseCase.IAppVersionUseCase
+	Logger            *logger.Logger
+}
+
+func NewAppVersionController(appVersionUseCase appVersionUseCase.IAppVersionUseCase, loggerInstance *logger.Logger) IAppVersionController {
+	return &Controller{appVersionUseCase: appVersionUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetRequirements(ctx *gin.Context) {
+	requirements, err := c.appVersionUseCase.GetRequirements()
+	if err != nil {
+		c.Logger.Error("Error getting app version requirements", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]RequirementResponse, len(*requirements))
+	for i := range *requirements {
+		res[i] = requirementToResponseMapper(&(*requirements)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// SaveRequirement sets the minimum app version of the ":platform" in the
+// path, "ios" or "android". Older apps are refused from the next request.
+func (c *Controller) SaveRequirement(ctx *gin.Context) {
+	var request SaveRequirementRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for app version requirement", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	requirement, err := c.appVersionUseCase.SaveRequirement(&domainAppVersion.Requirement{
+		Platform:       ctx.Param("platform"),
+		MinimumVersion: request.MinimumVersion,
+		StoreURL:       request.StoreURL,
+		Message:        request.Message,
+	})
+	if err != nil {
+		c.Logger.Error("Error saving app version requirement", zap.Error(err), zap.String("platform", ctx.Param("platform")))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, requirementToResponseMapper(requirement))
+}
+
+// DeleteRequirement stops gating the platform's app versions.
+func (c *Controller) DeleteRequirement(ctx *gin.Context) {
+	if err := c.appVersionUseCase.DeleteRequirement(ctx.Param("platform")); err != nil {
+		c.Logger.Error("Error deleting app version requirement", zap.Error(err), zap.String("platform", ctx.Param("platform")))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func requirementToResponseMapper(r *domainAppVersion.Requirement) RequirementResponse {
+	return RequirementResponse{
+		ID:             r.ID,
+		Platform:       r.Platform,
+		MinimumVersion: r.MinimumVersion,
+		StoreURL:       r.StoreURL,
+		Message:        r.Message,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
@@ -0,0 +1,24 @@
+package appversion
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SaveRequirementRequest sets the oldest app version allowed on a platform.
+// Message, if set, replaces the default upgrade prompt.
+type SaveRequirementRequest struct {
+	MinimumVersion string `json:"MinimumVersion" binding:"required"`
+	StoreURL       string `json:"StoreURL"`
+	Message        string `json:"Message"`
+}
+
+type RequirementResponse struct {
+	ID             uuid.UUID `json:"ID"`
+	Platform       string    `json:"Platform"`
+	MinimumVersion string    `json:"MinimumVersion"`
+	StoreURL       string    `json:"StoreURL"`
+	Message        string    `json:"Message"`
+	UpdatedAt      time.Time `json:"UpdatedAt"`
+}
@@ -0,0 +1,214 @@
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	attachmentUseCase "caregiver/src/application/usecases/attachment"
+	domainAttachment "caregiver/src/domain/attachment"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IAttachmentController interface {
+	UploadAttachment(ctx *gin.Context)
+	UploadTaskAttachment(ctx *gin.Context)
+	GetAttachments(ctx *gin.Context)
+	DownloadAttachment(ctx *gin.Context)
+	DeleteAttachment(ctx *gin.Context)
+}
+
+type Controller struct {
+	attachmentUseCase attachmentUseCase.IAttachmentUseCase
+	Logger            *logger.Logger
+}
+
+func NewAttachmentController(attachmentUseCase attachmentUseCase.IAttachmentUseCase, loggerInstance *logger.Logger) IAttachmentController {
+	return &Controller{attachmentUseCase: attachmentUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) UploadAttachment(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+	c.upload(ctx, scheduleID, nil)
+}
+
+// UploadTaskAttachment uploads a file for one of the visit's tasks, such as a
+// wound photo or a receipt.
+func (c *Controller) UploadTaskAttachment(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+	taskID, err := uuid.Parse(ctx.Param("taskId"))
+	if err != nil {
+		c.Logger.Error("Invalid task ID parameter", zap.Error(err), zap.String("taskId", ctx.Param("taskId")))
+		appError := domainErrors.NewAppError(errors.New("task id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	c.upload(ctx, scheduleID, &taskID)
+}
+
+func (c *Controller) upload(ctx *gin.Context, scheduleID uuid.UUID, taskID *uuid.UUID) {
+	var request UploadAttachmentRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.Logger.Error("Error binding form for attachment upload", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		c.Logger.Error("Attachment file is missing", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(errors.New("file is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Logger.Error("Error opening uploaded attachment", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	defer file.Close()
+
+	created, err := c.attachmentUseCase.Upload(&domainAttachment.Attachment{
+		ScheduleID:            scheduleID,
+		TaskID:                taskID,
+		FileName:              fileHeader.Filename,
+		ContentType:           fileHeader.Header.Get("Content-Type"),
+		Size:                  fileHeader.Size,
+		RequiredBeforeCheckin: request.RequiredBeforeCheckin,
+		UploadedByUserID:      request.UploadedByUserID,
+	}, file)
+	if err != nil {
+		c.Logger.Error("Error uploading attachment", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.Logger.Info("Attachment uploaded successfully", zap.String("attachmentID", created.ID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(created, nil))
+}
+
+func (c *Controller) GetAttachments(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+
+	attachments, views, err := c.attachmentUseCase.GetBySchedule(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting attachments", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res := make([]AttachmentResponse, len(*attachments))
+	for i := range *attachments {
+		res[i] = *domainToResponseMapper(&(*attachments)[i], views)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DownloadAttachment streams the file. Passing "viewerUserID" records the open
+// against the visit, which is what the check-in requirement looks for.
+func (c *Controller) DownloadAttachment(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+	attachmentID, err := uuid.Parse(ctx.Param("attachmentId"))
+	if err != nil {
+		c.Logger.Error("Invalid attachment ID parameter", zap.Error(err), zap.String("attachmentId", ctx.Param("attachmentId")))
+		appError := domainErrors.NewAppError(errors.New("attachment id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	viewerUserID := uuid.Nil
+	if viewer := ctx.Query("viewerUserID"); viewer != "" {
+		viewerUserID, err = uuid.Parse(viewer)
+		if err != nil {
+			appError := domainErrors.NewAppError(errors.New("viewerUserID is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+	}
+
+	attachment, content, err := c.attachmentUseCase.Open(scheduleID, attachmentID, viewerUserID)
+	if err != nil {
+		c.Logger.Error("Error opening attachment", zap.Error(err), zap.String("attachmentID", attachmentID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	defer content.Close()
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", attachment.FileName))
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, io.Reader(content), nil)
+}
+
+func (c *Controller) DeleteAttachment(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+	attachmentID, err := uuid.Parse(ctx.Param("attachmentId"))
+	if err != nil {
+		c.Logger.Error("Invalid attachment ID parameter for deletion", zap.Error(err), zap.String("attachmentId", ctx.Param("attachmentId")))
+		appError := domainErrors.NewAppError(errors.New("attachment id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if err := c.attachmentUseCase.Delete(scheduleID, attachmentID); err != nil {
+		c.Logger.Error("Error deleting attachment", zap.Error(err), zap.String("attachmentID", attachmentID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Attachment deleted successfully", zap.String("attachmentID", attachmentID.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) parseScheduleID(ctx *gin.Context) (uuid.UUID, bool) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return scheduleID, true
+}
+
+func domainToResponseMapper(a *domainAttachment.Attachment, views *[]domainAttachment.View) *AttachmentResponse {
+	res := &AttachmentResponse{
+		ID:                    a.ID,
+		ScheduleID:            a.ScheduleID,
+		TaskID:                a.TaskID,
+		FileName:              a.FileName,
+		ContentType:           a.ContentType,
+		Size:                  a.Size,
+		RequiredBeforeCheckin: a.RequiredBeforeCheckin,
+		UploadedByUserID:      a.UploadedByUserID,
+		CreatedAt:             a.CreatedAt,
+		Views:                 []AttachmentView{},
+	}
+	if views != nil {
+		for _, view := range *views {
+			if view.AttachmentID == a.ID {
+				res.Views = append(res.Views, AttachmentView{UserID: view.UserID, ViewedAt: view.ViewedAt})
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,30 @@
+package attachment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UploadAttachmentRequest struct {
+	UploadedByUserID      uuid.UUID `form:"UploadedByUserID" binding:"required"`
+	RequiredBeforeCheckin bool      `form:"RequiredBeforeCheckin"`
+}
+
+type AttachmentView struct {
+	UserID   uuid.UUID `json:"UserID"`
+	ViewedAt time.Time `json:"ViewedAt"`
+}
+
+type AttachmentResponse struct {
+	ID                    uuid.UUID        `json:"ID"`
+	ScheduleID            uuid.UUID        `json:"ScheduleID"`
+	TaskID                *uuid.UUID       `json:"TaskID,omitempty"`
+	FileName              string           `json:"FileName"`
+	ContentType           string           `json:"ContentType"`
+	Size                  int64            `json:"Size"`
+	RequiredBeforeCheckin bool             `json:"RequiredBeforeCheckin"`
+	UploadedByUserID      uuid.UUID        `json:"UploadedByUserID"`
+	CreatedAt             time.Time        `json:"CreatedAt"`
+	Views                 []AttachmentView `json:"Views"`
+}
@@ -0,0 +1,109 @@
+package attestation
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	attestationUseCase "caregiver/src/application/usecases/attestation"
+	domainAttestation "caregiver/src/domain/attestation"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IAttestationController interface {
+	GetSettings(ctx *gin.Context)
+	SetSettings(ctx *gin.Context)
+	Respond(ctx *gin.Context)
+	Sweep(ctx *gin.Context)
+}
+
+type Controller struct {
+	attestationUseCase attestationUseCase.IAttestationUseCase
+	Logger             *logger.Logger
+}
+
+func NewAttestationController(attestationUseCase attestationUseCase.IAttestationUseCase, loggerInstance *logger.Logger) IAttestationController {
+	return &Controller{attestationUseCase: attestationUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetSettings(ctx *gin.Context) {
+	settings, err := c.attestationUseCase.GetSettings()
+	if err != nil {
+		c.Logger.Error("Error getting attestation settings", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, settingsToResponseMapper(settings))
+}
+
+func (c *Controller) SetSettings(ctx *gin.Context) {
+	var request SettingsRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for attestation settings", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	settings, err := c.attestationUseCase.SetSettings(*request.Required, request.WindowMinutes)
+	if err != nil {
+		c.Logger.Error("Error setting attestation settings", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, settingsToResponseMapper(settings))
+}
+
+// Respond records the client's tap to confirm, or dispute, the caregiver's
+// arrival.
+func (c *Controller) Respond(ctx *gin.Context) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	var request RespondRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for attestation", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	schedule, err := c.attestationUseCase.Respond(scheduleID, request.ClientUserID, *request.Confirmed, request.DeviceID, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error recording attestation", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Attestation recorded successfully", zap.String("scheduleID", scheduleID.String()), zap.String("status", schedule.Attestation.Status))
+	ctx.JSON(http.StatusOK, AttestationResponse{
+		ScheduleID:  schedule.ID,
+		Status:      schedule.Attestation.Status,
+		DueAt:       schedule.Attestation.DueAt,
+		RespondedAt: schedule.Attestation.RespondedAt,
+		DeviceID:    schedule.Attestation.DeviceID,
+	})
+}
+
+// Sweep expires unanswered requests immediately. The server also sweeps on
+// its own interval; this is for external schedulers.
+func (c *Controller) Sweep(ctx *gin.Context) {
+	expired, err := c.attestationUseCase.Sweep(time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error sweeping attestations", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SweepResponse{Expired: expired})
+}
+
+func settingsToResponseMapper(s *domainAttestation.Settings) *SettingsResponse {
+	return &SettingsResponse{Required: s.Required, WindowMinutes: s.WindowMinutes, UpdatedAt: s.UpdatedAt}
+}
@@ -0,0 +1,36 @@
+package attestation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SettingsRequest struct {
+	Required      *bool `json:"Required" binding:"required"`
+	WindowMinutes int   `json:"WindowMinutes" binding:"required"`
+}
+
+type RespondRequest struct {
+	ClientUserID uuid.UUID `json:"ClientUserID" binding:"required"`
+	Confirmed    *bool     `json:"Confirmed" binding:"required"`
+	DeviceID     string    `json:"DeviceID"`
+}
+
+type SettingsResponse struct {
+	Required      bool      `json:"Required"`
+	WindowMinutes int       `json:"WindowMinutes"`
+	UpdatedAt     time.Time `json:"UpdatedAt"`
+}
+
+type AttestationResponse struct {
+	ScheduleID  uuid.UUID  `json:"ScheduleID"`
+	Status      string     `json:"Status"`
+	DueAt       *time.Time `json:"DueAt"`
+	RespondedAt *time.Time `json:"RespondedAt"`
+	DeviceID    string     `json:"DeviceID"`
+}
+
+type SweepResponse struct {
+	Expired int `json:"Expired"`
+}
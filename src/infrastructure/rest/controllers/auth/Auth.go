@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 
 	useCaseAuth "caregiver/src/application/usecases/auth"
@@ -9,12 +10,16 @@ import (
 	"caregiver/src/infrastructure/rest/controllers"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 type IAuthController interface {
 	Login(ctx *gin.Context)
 	GetAccessTokenByRefreshToken(ctx *gin.Context)
+	SetPassword(ctx *gin.Context)
+	ChangePassword(ctx *gin.Context)
+	ResetPassword(ctx *gin.Context)
 }
 
 type AuthController struct {
@@ -104,3 +109,66 @@ func (c *AuthController) GetAccessTokenByRefreshToken(ctx *gin.Context) {
 	c.Logger.Info("Token refresh successful", zap.String("userID", domainUser.ID.String()))
 	ctx.JSON(http.StatusOK, response)
 }
+
+// SetPassword sets a password with the token from a password reset, for a
+// new user's first password or after an admin reset.
+func (c *AuthController) SetPassword(ctx *gin.Context) {
+	var request SetPasswordRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for set password", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if err := c.authUseCase.SetPassword(request.Token, request.Password); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "password set successfully"})
+}
+
+// ChangePassword changes the authenticated caller's own password.
+func (c *AuthController) ChangePassword(ctx *gin.Context) {
+	callerID := controllers.CallerID(ctx)
+	if callerID == nil {
+		appError := domainErrors.NewAppError(errors.New("log in to change your password"), domainErrors.NotAuthenticated)
+		_ = ctx.Error(appError)
+		return
+	}
+	var request ChangePasswordRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for change password", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if err := c.authUseCase.ChangePassword(*callerID, request.CurrentPassword, request.NewPassword); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "password changed successfully"})
+}
+
+// ResetPassword clears a user's password and returns a token they set a new
+// one with. The caller must be an authenticated admin.
+func (c *AuthController) ResetPassword(ctx *gin.Context) {
+	callerID := controllers.CallerID(ctx)
+	if callerID == nil {
+		appError := domainErrors.NewAppError(errors.New("log in as an admin to reset passwords"), domainErrors.NotAuthenticated)
+		_ = ctx.Error(appError)
+		return
+	}
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("user id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	reset, err := c.authUseCase.ResetPassword(*callerID, userID)
+	if err != nil {
+		c.Logger.Error("Error resetting password", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, PasswordResetResponse{UserID: reset.UserID, Token: reset.Token, ExpiresAt: reset.ExpiresAt})
+}
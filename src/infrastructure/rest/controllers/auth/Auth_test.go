@@ -36,6 +36,22 @@ func (m *MockAuthUseCase) AccessTokenByRefreshToken(refreshToken string) (*userD
 	return nil, nil, nil
 }
 
+func (m *MockAuthUseCase) IssueTokens(userID uuid.UUID) (*userDomain.User, *useCaseAuth.AuthTokens, error) {
+	return nil, nil, nil
+}
+
+func (m *MockAuthUseCase) SetPassword(token, password string) error {
+	return nil
+}
+
+func (m *MockAuthUseCase) ChangePassword(userID uuid.UUID, currentPassword, newPassword string) error {
+	return nil
+}
+
+func (m *MockAuthUseCase) ResetPassword(adminID, userID uuid.UUID) (*useCaseAuth.PasswordReset, error) {
+	return nil, nil
+}
+
 func setupLogger(t *testing.T) *logger.Logger {
 	loggerInstance, err := logger.NewLogger()
 	if err != nil {
@@ -195,6 +211,30 @@ func TestAuthController_GetAccessTokenByRefreshToken_InvalidRequest(t *testing.T
 	}
 }
 
+func TestAuthController_ChangePassword_RequiresCaller(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := &MockAuthUseCase{}
+
+	logger := setupLogger(t)
+	controller := NewAuthController(mockUseCase, logger)
+
+	requestBody := []byte(`{"CurrentPassword": "oldPassword", "NewPassword": "newPassword"}`)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/auth/password", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	controller.ChangePassword(c)
+
+	if len(c.Errors) == 0 {
+		t.Error("Expected error to be added to context")
+	}
+}
+
 func TestLoginRequest_Validation(t *testing.T) {
 	validRequest := LoginRequest{
 		Email:    "test@example.com",
@@ -35,3 +35,21 @@ type LoginResponse struct {
 	Data     UserData     `json:"Data"`
 	Security SecurityData `json:"Security"`
 }
+
+type SetPasswordRequest struct {
+	Token    string `json:"Token" binding:"required"`
+	Password string `json:"Password" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"CurrentPassword" binding:"required"`
+	NewPassword     string `json:"NewPassword" binding:"required"`
+}
+
+// PasswordResetResponse carries the token the user sets their password with.
+// It is shown once; only its hash is kept.
+type PasswordResetResponse struct {
+	UserID    uuid.UUID `json:"UserID"`
+	Token     string    `json:"Token"`
+	ExpiresAt time.Time `json:"ExpiresAt"`
+}
@@ -0,0 +1,98 @@
+package availability
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	availabilityUseCase "caregiver/src/application/usecases/availability"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type IAvailabilityController interface {
+	GetCalendar(ctx *gin.Context)
+}
+
+type Controller struct {
+	availabilityUseCase availabilityUseCase.IAvailabilityUseCase
+	Logger              *logger.Logger
+}
+
+func NewAvailabilityController(availabilityUseCase availabilityUseCase.IAvailabilityUseCase, loggerInstance *logger.Logger) IAvailabilityController {
+	return &Controller{availabilityUseCase: availabilityUseCase, Logger: loggerInstance}
+}
+
+// GetCalendar shows a caregiver's weekly windows and time off between the
+// "from" and "to" days (YYYY-MM-DD, inclusive). It defaults to the next two
+// weeks.
+func (c *Controller) GetCalendar(ctx *gin.Context) {
+	caregiverID, err := uuid.Parse(ctx.Param("userID"))
+	if err != nil {
+		c.Logger.Error("Invalid caregiver ID parameter", zap.Error(err), zap.String("userID", ctx.Param("userID")))
+		appError := domainErrors.NewAppError(errors.New("caregiver id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var ok bool
+	if value := ctx.Query("from"); value != "" {
+		if from, ok = c.parseDate(ctx, "from", value); !ok {
+			return
+		}
+	}
+	to := from.AddDate(0, 0, 13)
+	if value := ctx.Query("to"); value != "" {
+		if to, ok = c.parseDate(ctx, "to", value); !ok {
+			return
+		}
+	}
+	calendar, err := c.availabilityUseCase.GetCalendar(caregiverID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		c.Logger.Error("Error getting caregiver availability", zap.Error(err), zap.String("caregiverID", caregiverID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := CalendarResponse{
+		CaregiverUserID: calendar.CaregiverUserID,
+		From:            calendar.From,
+		To:              calendar.To,
+		Windows:         make([]WindowResponse, len(calendar.Windows)),
+		TimeOff:         make([]TimeOffResponse, len(calendar.TimeOff)),
+	}
+	for i, w := range calendar.Windows {
+		res.Windows[i] = WindowResponse{
+			Weekday:   w.Weekday,
+			StartTime: formatClock(w.StartMinute),
+			EndTime:   formatClock(w.EndMinute),
+			Timezone:  w.Timezone,
+		}
+	}
+	for i, r := range calendar.TimeOff {
+		res.TimeOff[i] = TimeOffResponse{ID: r.ID, Type: r.Type, Status: r.Status, From: r.From, To: r.To}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
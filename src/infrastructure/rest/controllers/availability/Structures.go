@@ -0,0 +1,31 @@
+package availability
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WindowResponse is a weekly window as local "HH:MM" times.
+type WindowResponse struct {
+	Weekday   int    `json:"Weekday"`
+	StartTime string `json:"StartTime"`
+	EndTime   string `json:"EndTime"`
+	Timezone  string `json:"Timezone"`
+}
+
+type TimeOffResponse struct {
+	ID     uuid.UUID `json:"ID"`
+	Type   string    `json:"Type"`
+	Status string    `json:"Status"`
+	From   time.Time `json:"From"`
+	To     time.Time `json:"To"`
+}
+
+type CalendarResponse struct {
+	CaregiverUserID uuid.UUID         `json:"CaregiverUserID"`
+	From            time.Time         `json:"From"`
+	To              time.Time         `json:"To"`
+	Windows         []WindowResponse  `json:"Windows"`
+	TimeOff         []TimeOffResponse `json:"TimeOff"`
+}
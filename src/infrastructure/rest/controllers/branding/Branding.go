@@ -0,0 +1,135 @@
+package branding
+
+import (
+	"errors"
+	"net/http"
+
+	brandingUseCase "caregiver/src/application/usecases/branding"
+	domainBranding "caregiver/src/domain/branding"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type IBrandingController interface {
+	GetBranding(ctx *gin.Context)
+	SetBranding(ctx *gin.Context)
+	UploadLogo(ctx *gin.Context)
+	GetLogo(ctx *gin.Context)
+	DeleteLogo(ctx *gin.Context)
+}
+
+type Controller struct {
+	brandingUseCase brandingUseCase.IBrandingUseCase
+	Logger          *logger.Logger
+}
+
+func NewBrandingController(brandingUseCase brandingUseCase.IBrandingUseCase, loggerInstance *logger.Logger) IBrandingController {
+	return &Controller{brandingUseCase: brandingUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetBranding(ctx *gin.Context) {
+	branding, err := c.brandingUseCase.Get()
+	if err != nil {
+		c.Logger.Error("Error getting agency branding", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toResponseMapper(branding))
+}
+
+func (c *Controller) SetBranding(ctx *gin.Context) {
+	var request BrandingRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for agency branding", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	branding, err := c.brandingUseCase.Set(&domainBranding.Branding{
+		DisplayName:  request.DisplayName,
+		LogoURL:      request.LogoURL,
+		PrimaryColor: request.PrimaryColor,
+		AccentColor:  request.AccentColor,
+		SenderName:   request.SenderName,
+		ReplyTo:      request.ReplyTo,
+	})
+	if err != nil {
+		c.Logger.Error("Error setting agency branding", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Agency branding set successfully")
+	ctx.JSON(http.StatusOK, toResponseMapper(branding))
+}
+
+// UploadLogo takes a PNG or JPEG logo as the multipart "file" field.
+func (c *Controller) UploadLogo(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		c.Logger.Error("Logo file is missing", zap.Error(err))
+		appError := domainErrors.NewAppError(errors.New("file is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Logger.Error("Error opening uploaded logo", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	defer file.Close()
+
+	branding, err := c.brandingUseCase.UploadLogo(fileHeader.Header.Get("Content-Type"), fileHeader.Size, file)
+	if err != nil {
+		c.Logger.Error("Error uploading agency logo", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Agency logo uploaded successfully")
+	ctx.JSON(http.StatusOK, toResponseMapper(branding))
+}
+
+// GetLogo serves the uploaded logo. It needs no account so portal pages and
+// emails can show it.
+func (c *Controller) GetLogo(ctx *gin.Context) {
+	branding, content, err := c.brandingUseCase.OpenLogo()
+	if err != nil {
+		c.Logger.Error("Error opening agency logo", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	defer content.Close()
+	ctx.DataFromReader(http.StatusOK, -1, branding.LogoContentType, content, nil)
+}
+
+func (c *Controller) DeleteLogo(ctx *gin.Context) {
+	branding, err := c.brandingUseCase.DeleteLogo()
+	if err != nil {
+		c.Logger.Error("Error deleting agency logo", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Agency logo deleted successfully")
+	ctx.JSON(http.StatusOK, toResponseMapper(branding))
+}
+
+func toResponseMapper(b *domainBranding.Branding) *BrandingResponse {
+	res := &BrandingResponse{
+		DisplayName:  b.DisplayName,
+		LogoURL:      b.LogoURL,
+		HasLogo:      b.HasLogo(),
+		PrimaryColor: b.PrimaryColor,
+		AccentColor:  b.AccentColor,
+		SenderName:   b.SenderName,
+		ReplyTo:      b.ReplyTo,
+	}
+	if !b.UpdatedAt.IsZero() {
+		res.UpdatedAt = &b.UpdatedAt
+	}
+	return res
+}
@@ -0,0 +1,28 @@
+package branding
+
+import (
+	"time"
+)
+
+// BrandingRequest replaces the agency branding. Colors are written as
+// "#RRGGBB". LogoURL links a logo hosted elsewhere; keep it as returned to
+// hold on to an uploaded logo.
+type BrandingRequest struct {
+	DisplayName  string `json:"DisplayName"`
+	LogoURL      string `json:"LogoURL"`
+	PrimaryColor string `json:"PrimaryColor"`
+	AccentColor  string `json:"AccentColor"`
+	SenderName   string `json:"SenderName"`
+	ReplyTo      string `json:"ReplyTo"`
+}
+
+type BrandingResponse struct {
+	DisplayName  string     `json:"DisplayName"`
+	LogoURL      string     `json:"LogoURL"`
+	HasLogo      bool       `json:"HasLogo"`
+	PrimaryColor string     `json:"PrimaryColor"`
+	AccentColor  string     `json:"AccentColor"`
+	SenderName   string     `json:"SenderName"`
+	ReplyTo      string     `json:"ReplyTo"`
+	UpdatedAt    *time.Time `json:"UpdatedAt"`
+}
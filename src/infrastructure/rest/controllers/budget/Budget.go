@@ -0,0 +1,232 @@
+package budget
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	budgetUseCase "caregiver/src/application/usecases/budget"
+	domainBudget "caregiver/src/domain/budget"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IBudgetController interface {
+	CreateBudget(ctx *gin.Context)
+	GetBudgets(ctx *gin.Context)
+	GetBudgetByID(ctx *gin.Context)
+	UpdateBudget(ctx *gin.Context)
+	DeleteBudget(ctx *gin.Context)
+	GetBudgetReport(ctx *gin.Context)
+}
+
+type Controller struct {
+	budgetUseCase budgetUseCase.IBudgetUseCase
+	Logger        *logger.Logger
+}
+
+func NewBudgetController(budgetUseCase budgetUseCase.IBudgetUseCase, loggerInstance *logger.Logger) IBudgetController {
+	return &Controller{budgetUseCase: budgetUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateBudget(ctx *gin.Context) {
+	c.Logger.Info("Creating new budget")
+	var request CreateBudgetRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new budget", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	created, err := c.budgetUseCase.Create(&domainBudget.Budget{
+		ClientUserID:     request.ClientUserID,
+		Period:           request.Period,
+		Unit:             request.Unit,
+		Limit:            request.Limit,
+		HourlyRate:       request.HourlyRate,
+		WarningThreshold: request.WarningThreshold,
+		HardCap:          request.HardCap,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating budget", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.Logger.Info("Budget created successfully", zap.String("budgetID", created.ID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(created))
+}
+
+func (c *Controller) GetBudgets(ctx *gin.Context) {
+	c.Logger.Info("Getting all budgets")
+	budgets, err := c.budgetUseCase.GetAll()
+	if err != nil {
+		c.Logger.Error("Error getting budgets", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	clientFilter := ctx.Query("clientUserID")
+	res := make([]BudgetResponse, 0, len(*budgets))
+	for i := range *budgets {
+		b := &(*budgets)[i]
+		if clientFilter != "" && b.ClientUserID.String() != clientFilter {
+			continue
+		}
+		res = append(res, *domainToResponseMapper(b))
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetBudgetByID(ctx *gin.Context) {
+	budgetID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid budget ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("budget id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	b, err := c.budgetUseCase.GetByID(budgetID)
+	if err != nil {
+		c.Logger.Error("Error getting budget by ID", zap.Error(err), zap.String("id", budgetID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(b))
+}
+
+func (c *Controller) UpdateBudget(ctx *gin.Context) {
+	budgetID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid budget ID parameter for update", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("budget id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	var request UpdateBudgetRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for budget update", zap.Error(err), zap.String("id", budgetID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Period != nil {
+		updates["period"] = *request.Period
+	}
+	if request.Unit != nil {
+		updates["unit"] = *request.Unit
+	}
+	if request.Limit != nil {
+		updates["limit_amount"] = *request.Limit
+	}
+	if request.HourlyRate != nil {
+		updates["hourly_rate"] = *request.HourlyRate
+	}
+	if request.WarningThreshold != nil {
+		updates["warning_threshold"] = *request.WarningThreshold
+	}
+	if request.HardCap != nil {
+		updates["hard_cap"] = *request.HardCap
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", budgetID.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updated, err := c.budgetUseCase.Update(budgetID, updates)
+	if err != nil {
+		c.Logger.Error("Error updating budget", zap.Error(err), zap.String("id", budgetID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Budget updated successfully", zap.String("id", budgetID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteBudget(ctx *gin.Context) {
+	budgetID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid budget ID parameter for deletion", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("budget id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if err := c.budgetUseCase.Delete(budgetID); err != nil {
+		c.Logger.Error("Error deleting budget", zap.Error(err), zap.String("id", budgetID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Budget deleted successfully", zap.String("id", budgetID.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetBudgetReport returns spend versus budget per client for the period that
+// contains the optional "date" query parameter (RFC3339, defaults to now).
+func (c *Controller) GetBudgetReport(ctx *gin.Context) {
+	at := time.Now().UTC()
+	if dateStr := ctx.Query("date"); dateStr != "" {
+		parsed, err := time.Parse(time.RFC3339, dateStr)
+		if err != nil {
+			c.Logger.Error("Invalid date for budget report", zap.Error(err), zap.String("date", dateStr))
+			appError := domainErrors.NewAppError(errors.New("date must be RFC3339"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		at = parsed.UTC()
+	}
+
+	report, err := c.budgetUseCase.Report(at)
+	if err != nil {
+		c.Logger.Error("Error building budget report", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res := make([]BudgetUsageResponse, len(*report))
+	for i, usage := range *report {
+		res[i] = BudgetUsageResponse{
+			BudgetID:     usage.Budget.ID,
+			ClientUserID: usage.Budget.ClientUserID,
+			Period:       usage.Budget.Period,
+			Unit:         usage.Budget.Unit,
+			PeriodStart:  usage.PeriodStart,
+			PeriodEnd:    usage.PeriodEnd,
+			Limit:        usage.Budget.Limit,
+			Spent:        usage.Spent,
+			Remaining:    usage.Remaining,
+			PercentUsed:  usage.PercentUsed,
+		}
+	}
+	c.Logger.Info("Successfully built budget report", zap.Int("count", len(res)))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func domainToResponseMapper(b *domainBudget.Budget) *BudgetResponse {
+	return &BudgetResponse{
+		ID:               b.ID,
+		ClientUserID:     b.ClientUserID,
+		Period:           b.Period,
+		Unit:             b.Unit,
+		Limit:            b.Limit,
+		HourlyRate:       b.HourlyRate,
+		WarningThreshold: b.WarningThreshold,
+		HardCap:          b.HardCap,
+		Active:           b.Active,
+		CreatedAt:        b.CreatedAt,
+		UpdatedAt:        b.UpdatedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateBudgetRequest struct {
+	ClientUserID     uuid.UUID `json:"ClientUserID" binding:"required"`
+	Period           string    `json:"Period" binding:"required"`
+	Unit             string    `json:"Unit" binding:"required"`
+	Limit            float64   `json:"Limit" binding:"required"`
+	HourlyRate       *float64  `json:"HourlyRate"`
+	WarningThreshold float64   `json:"WarningThreshold"`
+	HardCap          bool      `json:"HardCap"`
+}
+
+type UpdateBudgetRequest struct {
+	Period           *string  `json:"Period"`
+	Unit             *string  `json:"Unit"`
+	Limit            *float64 `json:"Limit"`
+	HourlyRate       *float64 `json:"HourlyRate"`
+	WarningThreshold *float64 `json:"WarningThreshold"`
+	HardCap          *bool    `json:"HardCap"`
+	Active           *bool    `json:"Active"`
+}
+
+type BudgetResponse struct {
+	ID               uuid.UUID `json:"ID"`
+	ClientUserID     uuid.UUID `json:"ClientUserID"`
+	Period           string    `json:"Period"`
+	Unit             string    `json:"Unit"`
+	Limit            float64   `json:"Limit"`
+	HourlyRate       *float64  `json:"HourlyRate"`
+	WarningThreshold float64   `json:"WarningThreshold"`
+	HardCap          bool      `json:"HardCap"`
+	Active           bool      `json:"Active"`
+	CreatedAt        time.Time `json:"CreatedAt"`
+	UpdatedAt        time.Time `json:"UpdatedAt"`
+}
+
+type BudgetUsageResponse struct {
+	BudgetID     uuid.UUID `json:"BudgetID"`
+	ClientUserID uuid.UUID `json:"ClientUserID"`
+	Period       string    `json:"Period"`
+	Unit         string    `json:"Unit"`
+	PeriodStart  time.Time `json:"PeriodStart"`
+	PeriodEnd    time.Time `json:"PeriodEnd"`
+	Limit        float64   `json:"Limit"`
+	Spent        float64   `json:"Spent"`
+	Remaining    float64   `json:"Remaining"`
+	PercentUsed  float64   `json:"PercentUsed"`
+}
@@ -0,0 +1,543 @@
+package careplan
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	carePlanUseCase "caregiver/src/application/usecases/careplan"
+	domainCarePlan "caregiver/src/domain/careplan"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type ICarePlanController interface {
+	CreatePlan(ctx *gin.Context)
+	GetPlans(ctx *gin.Context)
+	GetPlanByID(ctx *gin.Context)
+	UpdatePlan(ctx *gin.Context)
+	DeletePlan(ctx *gin.Context)
+	CreateGoal(ctx *gin.Context)
+	GetGoals(ctx *gin.Context)
+	GetGoalByID(ctx *gin.Context)
+	UpdateGoal(ctx *gin.Context)
+	DeleteGoal(ctx *gin.Context)
+	GetGoalChart(ctx *gin.Context)
+	GetVisitGoals(ctx *gin.Context)
+	LogProgress(ctx *gin.Context)
+	GetVisitEntries(ctx *gin.Context)
+	DeleteEntry(ctx *gin.Context)
+	GetFrequencyReport(ctx *gin.Context)
+}
+
+type Controller struct {
+	carePlanUseCase carePlanUseCase.ICarePlanUseCase
+	Logger          *logger.Logger
+}
+
+func NewCarePlanController(carePlanUseCase carePlanUseCase.ICarePlanUseCase, loggerInstance *logger.Logger) ICarePlanController {
+	return &Controller{carePlanUseCase: carePlanUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreatePlan(ctx *gin.Context) {
+	var request CreatePlanRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new care plan", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	plan := &domainCarePlan.Plan{
+		ClientUserID:  request.ClientUserID,
+		Title:         request.Title,
+		Notes:         request.Notes,
+		ReviewDate:    request.ReviewDate,
+		VisitsPerWeek: request.VisitsPerWeek,
+	}
+	if request.StartDate != nil {
+		plan.StartDate = *request.StartDate
+	} else {
+		now := time.Now().UTC()
+		plan.StartDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	created, err := c.carePlanUseCase.CreatePlan(plan)
+	if err != nil {
+		c.Logger.Error("Error creating care plan", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Care plan created successfully", zap.String("planID", created.ID.String()))
+	ctx.JSON(http.StatusOK, planToResponseMapper(created))
+}
+
+// GetPlans lists care plans, optionally for a single "clientID".
+func (c *Controller) GetPlans(ctx *gin.Context) {
+	var clientUserID *uuid.UUID
+	if value := ctx.Query("clientID"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.Logger.Error("Invalid clientID query parameter", zap.Error(err), zap.String("clientID", value))
+			appError := domainErrors.NewAppError(errors.New("client id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		clientUserID = &id
+	}
+	plans, err := c.carePlanUseCase.GetPlans(clientUserID)
+	if err != nil {
+		c.Logger.Error("Error getting care plans", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]PlanResponse, len(*plans))
+	for i := range *plans {
+		res[i] = *planToResponseMapper(&(*plans)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetPlanByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "care plan")
+	if !ok {
+		return
+	}
+	plan, err := c.carePlanUseCase.GetPlanByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting care plan by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, planToResponseMapper(plan))
+}
+
+func (c *Controller) UpdatePlan(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "care plan")
+	if !ok {
+		return
+	}
+	var request UpdatePlanRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for care plan update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Title != nil {
+		updates["title"] = *request.Title
+	}
+	if request.Notes != nil {
+		updates["notes"] = *request.Notes
+	}
+	if request.StartDate != nil {
+		updates["start_date"] = *request.StartDate
+	}
+	if request.ReviewDate != nil {
+		updates["review_date"] = *request.ReviewDate
+	}
+	if request.VisitsPerWeek != nil {
+		updates["visits_per_week"] = *request.VisitsPerWeek
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	plan, err := c.carePlanUseCase.UpdatePlan(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating care plan", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Care plan updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, planToResponseMapper(plan))
+}
+
+func (c *Controller) DeletePlan(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "care plan")
+	if !ok {
+		return
+	}
+	if err := c.carePlanUseCase.DeletePlan(id); err != nil {
+		c.Logger.Error("Error deleting care plan", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Care plan deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) CreateGoal(ctx *gin.Context) {
+	planID, ok := c.parseID(ctx, "care plan")
+	if !ok {
+		return
+	}
+	var request CreateGoalRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new care plan goal", zap.Error(err), zap.String("planID", planID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	goal, err := c.carePlanUseCase.CreateGoal(&domainCarePlan.Goal{
+		PlanID:      planID,
+		Description: request.Description,
+		Metric:      request.Metric,
+		Unit:        request.Unit,
+		Baseline:    request.Baseline,
+		Target:      request.Target,
+		Cadence:     request.Cadence,
+		TargetDate:  request.TargetDate,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating care plan goal", zap.Error(err), zap.String("planID", planID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Care plan goal created successfully", zap.String("goalID", goal.ID.String()))
+	ctx.JSON(http.StatusOK, goalToResponseMapper(goal))
+}
+
+func (c *Controller) GetGoals(ctx *gin.Context) {
+	planID, ok := c.parseID(ctx, "care plan")
+	if !ok {
+		return
+	}
+	goals, err := c.carePlanUseCase.GetGoals(planID)
+	if err != nil {
+		c.Logger.Error("Error getting care plan goals", zap.Error(err), zap.String("planID", planID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, goalsToResponseMapper(goals))
+}
+
+func (c *Controller) GetGoalByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "goal")
+	if !ok {
+		return
+	}
+	goal, err := c.carePlanUseCase.GetGoalByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting care plan goal by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, goalToResponseMapper(goal))
+}
+
+func (c *Controller) UpdateGoal(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "goal")
+	if !ok {
+		return
+	}
+	var request UpdateGoalRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for care plan goal update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Description != nil {
+		updates["description"] = *request.Description
+	}
+	if request.Metric != nil {
+		updates["metric"] = *request.Metric
+	}
+	if request.Unit != nil {
+		updates["unit"] = *request.Unit
+	}
+	if request.Baseline != nil {
+		updates["baseline"] = *request.Baseline
+	}
+	if request.Target != nil {
+		updates["target"] = *request.Target
+	}
+	if request.Cadence != nil {
+		updates["cadence"] = *request.Cadence
+	}
+	if request.TargetDate != nil {
+		updates["target_date"] = *request.TargetDate
+	}
+	if request.Status != nil {
+		updates["status"] = *request.Status
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	goal, err := c.carePlanUseCase.UpdateGoal(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating care plan goal", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Care plan goal updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, goalToResponseMapper(goal))
+}
+
+func (c *Controller) DeleteGoal(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "goal")
+	if !ok {
+		return
+	}
+	if err := c.carePlanUseCase.DeleteGoal(id); err != nil {
+		c.Logger.Error("Error deleting care plan goal", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Care plan goal deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetGoalChart returns a goal's progress between the "from" and "to" days
+// (YYYY-MM-DD, inclusive). It defaults to the last 90 days.
+func (c *Controller) GetGoalChart(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "goal")
+	if !ok {
+		return
+	}
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if value := ctx.Query("to"); value != "" {
+		if to, ok = c.parseDate(ctx, "to", value); !ok {
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -90)
+	if value := ctx.Query("from"); value != "" {
+		if from, ok = c.parseDate(ctx, "from", value); !ok {
+			return
+		}
+	}
+	chart, err := c.carePlanUseCase.Chart(id, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		c.Logger.Error("Error building care plan goal chart", zap.Error(err), zap.String("goalID", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := ChartResponse{
+		Goal:          *goalToResponseMapper(&chart.Goal),
+		From:          chart.From,
+		To:            chart.To,
+		Points:        make([]ChartPointResponse, len(chart.Points)),
+		Latest:        chart.Latest,
+		Progress:      chart.Progress,
+		Achieved:      chart.Achieved,
+		MissedPeriods: chart.MissedPeriods,
+	}
+	for i, point := range chart.Points {
+		res.Points[i] = ChartPointResponse{At: point.At, Value: point.Value, Count: point.Count, Progress: point.Progress}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetVisitGoals lists the goals a caregiver can measure during a visit.
+func (c *Controller) GetVisitGoals(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "schedule")
+	if !ok {
+		return
+	}
+	goals, err := c.carePlanUseCase.GetVisitGoals(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting visit goals", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, goalsToResponseMapper(goals))
+}
+
+func (c *Controller) LogProgress(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "schedule")
+	if !ok {
+		return
+	}
+	var request LogProgressRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for goal progress", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	entry := &domainCarePlan.Entry{
+		GoalID:     request.GoalID,
+		ScheduleID: scheduleID,
+		Value:      *request.Value,
+		Note:       request.Note,
+	}
+	if request.RecordedAt != nil {
+		entry.RecordedAt = *request.RecordedAt
+	}
+	created, err := c.carePlanUseCase.LogProgress(entry)
+	if err != nil {
+		c.Logger.Error("Error logging goal progress", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Goal progress logged successfully", zap.String("entryID", created.ID.String()))
+	ctx.JSON(http.StatusOK, entryToResponseMapper(created))
+}
+
+func (c *Controller) GetVisitEntries(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "schedule")
+	if !ok {
+		return
+	}
+	entries, err := c.carePlanUseCase.GetVisitEntries(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting visit goal progress", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]EntryResponse, len(*entries))
+	for i := range *entries {
+		res[i] = *entryToResponseMapper(&(*entries)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) DeleteEntry(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "progress entry")
+	if !ok {
+		return
+	}
+	if err := c.carePlanUseCase.DeleteEntry(id); err != nil {
+		c.Logger.Error("Error deleting goal progress entry", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Goal progress entry deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetFrequencyReport compares each client's visits with their plan's
+// required frequency for the week containing "week" (YYYY-MM-DD), by default
+// the current one.
+func (c *Controller) GetFrequencyReport(ctx *gin.Context) {
+	weekOf := time.Now().UTC()
+	if value := ctx.Query("week"); value != "" {
+		var ok bool
+		if weekOf, ok = c.parseDate(ctx, "week", value); !ok {
+			return
+		}
+	}
+	report, err := c.carePlanUseCase.FrequencyReport(weekOf)
+	if err != nil {
+		c.Logger.Error("Error building visit frequency report", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := FrequencyReportResponse{
+		WeekStart:   report.WeekStart,
+		WeekEnd:     report.WeekEnd,
+		Clients:     make([]VisitFrequencyResponse, len(report.Clients)),
+		UnderServed: report.UnderServed,
+	}
+	for i, entry := range report.Clients {
+		res.Clients[i] = VisitFrequencyResponse{
+			PlanID:       entry.PlanID,
+			ClientUserID: entry.ClientUserID,
+			Required:     entry.Required,
+			Scheduled:    entry.Scheduled,
+			Delivered:    entry.Delivered,
+			Upcoming:     entry.Upcoming,
+			Missed:       entry.Missed,
+			Shortfall:    entry.Shortfall,
+			UnderServed:  entry.UnderServed,
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func planToResponseMapper(p *domainCarePlan.Plan) *PlanResponse {
+	return &PlanResponse{
+		ID:            p.ID,
+		ClientUserID:  p.ClientUserID,
+		Title:         p.Title,
+		Notes:         p.Notes,
+		StartDate:     p.StartDate,
+		ReviewDate:    p.ReviewDate,
+		VisitsPerWeek: p.VisitsPerWeek,
+		Active:        p.Active,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+}
+
+func goalToResponseMapper(g *domainCarePlan.Goal) *GoalResponse {
+	return &GoalResponse{
+		ID:          g.ID,
+		PlanID:      g.PlanID,
+		Description: g.Description,
+		Metric:      g.Metric,
+		Unit:        g.Unit,
+		Baseline:    g.Baseline,
+		Target:      g.Target,
+		Cadence:     g.Cadence,
+		TargetDate:  g.TargetDate,
+		Status:      g.Status,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
+
+func goalsToResponseMapper(goals *[]domainCarePlan.Goal) []GoalResponse {
+	res := make([]GoalResponse, len(*goals))
+	for i := range *goals {
+		res[i] = *goalToResponseMapper(&(*goals)[i])
+	}
+	return res
+}
+
+func entryToResponseMapper(e *domainCarePlan.Entry) *EntryResponse {
+	return &EntryResponse{
+		ID:               e.ID,
+		GoalID:           e.GoalID,
+		ScheduleID:       e.ScheduleID,
+		RecordedByUserID: e.RecordedByUserID,
+		Value:            e.Value,
+		Note:             e.Note,
+		RecordedAt:       e.RecordedAt,
+		CreatedAt:        e.CreatedAt,
+	}
+}
@@ -0,0 +1,136 @@
+package careplan
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreatePlanRequest adds a care plan for a client. StartDate defaults to
+// today. VisitsPerWeek is the required visit frequency, if any.
+type CreatePlanRequest struct {
+	ClientUserID  uuid.UUID  `json:"ClientUserID" binding:"required"`
+	Title         string     `json:"Title" binding:"required"`
+	Notes         string     `json:"Notes"`
+	StartDate     *time.Time `json:"StartDate"`
+	ReviewDate    *time.Time `json:"ReviewDate"`
+	VisitsPerWeek int        `json:"VisitsPerWeek"`
+}
+
+type UpdatePlanRequest struct {
+	Title         *string    `json:"Title"`
+	Notes         *string    `json:"Notes"`
+	StartDate     *time.Time `json:"StartDate"`
+	ReviewDate    *time.Time `json:"ReviewDate"`
+	VisitsPerWeek *int       `json:"VisitsPerWeek"`
+	Active        *bool      `json:"Active"`
+}
+
+type PlanResponse struct {
+	ID            uuid.UUID  `json:"ID"`
+	ClientUserID  uuid.UUID  `json:"ClientUserID"`
+	Title         string     `json:"Title"`
+	Notes         string     `json:"Notes"`
+	StartDate     time.Time  `json:"StartDate"`
+	ReviewDate    *time.Time `json:"ReviewDate"`
+	VisitsPerWeek int        `json:"VisitsPerWeek"`
+	Active        bool       `json:"Active"`
+	CreatedAt     time.Time  `json:"CreatedAt"`
+	UpdatedAt     time.Time  `json:"UpdatedAt"`
+}
+
+// CreateGoalRequest adds a measurable goal. Cadence is "per_visit",
+// "daily", "weekly" or "monthly".
+type CreateGoalRequest struct {
+	Description string     `json:"Description" binding:"required"`
+	Metric      string     `json:"Metric" binding:"required"`
+	Unit        string     `json:"Unit"`
+	Baseline    float64    `json:"Baseline"`
+	Target      float64    `json:"Target"`
+	Cadence     string     `json:"Cadence" binding:"required"`
+	TargetDate  *time.Time `json:"TargetDate"`
+}
+
+type UpdateGoalRequest struct {
+	Description *string    `json:"Description"`
+	Metric      *string    `json:"Metric"`
+	Unit        *string    `json:"Unit"`
+	Baseline    *float64   `json:"Baseline"`
+	Target      *float64   `json:"Target"`
+	Cadence     *string    `json:"Cadence"`
+	TargetDate  *time.Time `json:"TargetDate"`
+	Status      *string    `json:"Status"`
+}
+
+type GoalResponse struct {
+	ID          uuid.UUID  `json:"ID"`
+	PlanID      uuid.UUID  `json:"PlanID"`
+	Description string     `json:"Description"`
+	Metric      string     `json:"Metric"`
+	Unit        string     `json:"Unit"`
+	Baseline    float64    `json:"Baseline"`
+	Target      float64    `json:"Target"`
+	Cadence     string     `json:"Cadence"`
+	TargetDate  *time.Time `json:"TargetDate"`
+	Status      string     `json:"Status"`
+	CreatedAt   time.Time  `json:"CreatedAt"`
+	UpdatedAt   time.Time  `json:"UpdatedAt"`
+}
+
+// LogProgressRequest records a goal measurement during a visit. RecordedAt
+// defaults to the check-in time.
+type LogProgressRequest struct {
+	GoalID     uuid.UUID  `json:"GoalID" binding:"required"`
+	Value      *float64   `json:"Value" binding:"required"`
+	Note       string     `json:"Note"`
+	RecordedAt *time.Time `json:"RecordedAt"`
+}
+
+type EntryResponse struct {
+	ID               uuid.UUID `json:"ID"`
+	GoalID           uuid.UUID `json:"GoalID"`
+	ScheduleID       uuid.UUID `json:"ScheduleID"`
+	RecordedByUserID uuid.UUID `json:"RecordedByUserID"`
+	Value            float64   `json:"Value"`
+	Note             string    `json:"Note"`
+	RecordedAt       time.Time `json:"RecordedAt"`
+	CreatedAt        time.Time `json:"CreatedAt"`
+}
+
+type ChartPointResponse struct {
+	At       time.Time `json:"At"`
+	Value    float64   `json:"Value"`
+	Count    int       `json:"Count"`
+	Progress float64   `json:"Progress"`
+}
+
+type ChartResponse struct {
+	Goal          GoalResponse         `json:"Goal"`
+	From          time.Time            `json:"From"`
+	To            time.Time            `json:"To"`
+	Points        []ChartPointResponse `json:"Points"`
+	Latest        *float64             `json:"Latest"`
+	Progress      float64              `json:"Progress"`
+	Achieved      bool                 `json:"Achieved"`
+	MissedPeriods int                  `json:"MissedPeriods"`
+}
+
+type VisitFrequencyResponse struct {
+	PlanID       uuid.UUID `json:"PlanID"`
+	ClientUserID uuid.UUID `json:"ClientUserID"`
+	Required     int       `json:"Required"`
+	Scheduled    int       `json:"Scheduled"`
+	Delivered    int       `json:"Delivered"`
+	Upcoming     int       `json:"Upcoming"`
+	Missed       int       `json:"Missed"`
+	Shortfall    int       `json:"Shortfall"`
+	UnderServed  bool      `json:"UnderServed"`
+}
+
+// FrequencyReportResponse is the weekly visit frequency compliance report.
+type FrequencyReportResponse struct {
+	WeekStart   time.Time                `json:"WeekStart"`
+	WeekEnd     time.Time                `json:"WeekEnd"`
+	Clients     []VisitFrequencyResponse `json:"Clients"`
+	UnderServed int                      `json:"UnderServed"`
+}
@@ -0,0 +1,984 @@
+package claim
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	claimUseCase "caregiver/src/application/usecases/claim"
+	domainClaim "caregiver/src/domain/claim"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+// MaxRemittanceBytes bounds an uploaded 835 file. A remittance covering a
+// few thousand claims stays well under it.
+const MaxRemittanceBytes = 10 << 20
+
+type IClaimController interface {
+	CreatePayer(ctx *gin.Context)
+	GetPayers(ctx *gin.Context)
+	GetPayerByID(ctx *gin.Context)
+	UpdatePayer(ctx *gin.Context)
+	DeletePayer(ctx *gin.Context)
+	CreateRate(ctx *gin.Context)
+	GetRates(ctx *gin.Context)
+	UpdateRate(ctx *gin.Context)
+	DeleteRate(ctx *gin.Context)
+	CreateCoverage(ctx *gin.Context)
+	GetCoverages(ctx *gin.Context)
+	UpdateCoverage(ctx *gin.Context)
+	DeleteCoverage(ctx *gin.Context)
+	GetProviderSettings(ctx *gin.Context)
+	SetProviderSettings(ctx *gin.Context)
+	GenerateBatch(ctx *gin.Context)
+	GetBatches(ctx *gin.Context)
+	GetBatchByID(ctx *gin.Context)
+	DownloadBatch(ctx *gin.Context)
+	GetClaims(ctx *gin.Context)
+	GetClaimByID(ctx *gin.Context)
+	UpdateClaimStatus(ctx *gin.Context)
+	IngestRemittance(ctx *gin.Context)
+	GetRemittances(ctx *gin.Context)
+	GetRemittanceByID(ctx *gin.Context)
+	GetDenialReasons(ctx *gin.Context)
+	GetDenials(ctx *gin.Context)
+	GetDenialByID(ctx *gin.Context)
+	UpdateDenial(ctx *gin.Context)
+	RebillDenial(ctx *gin.Context)
+	WriteOffDenial(ctx *gin.Context)
+	GetDenialAging(ctx *gin.Context)
+}
+
+type Controller struct {
+	claimUseCase claimUseCase.IClaimUseCase
+	Logger       *logger.Logger
+}
+
+func NewClaimController(claimUseCase claimUseCase.IClaimUseCase, loggerInstance *logger.Logger) IClaimController {
+	return &Controller{claimUseCase: claimUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreatePayer(ctx *gin.Context) {
+	var request CreatePayerRequest
+	if !c.bind(ctx, &request, "new payer") {
+		return
+	}
+	created, err := c.claimUseCase.CreatePayer(&domainClaim.Payer{
+		Name:            request.Name,
+		PayerCode:       request.PayerCode,
+		FilingIndicator: request.FilingIndicator,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating payer", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Payer created successfully", zap.String("payerID", created.ID.String()))
+	ctx.JSON(http.StatusOK, payerToResponseMapper(created))
+}
+
+func (c *Controller) GetPayers(ctx *gin.Context) {
+	payers, err := c.claimUseCase.GetPayers()
+	if err != nil {
+		c.Logger.Error("Error getting payers", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]PayerResponse, len(*payers))
+	for i := range *payers {
+		res[i] = *payerToResponseMapper(&(*payers)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetPayerByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "payer")
+	if !ok {
+		return
+	}
+	payer, err := c.claimUseCase.GetPayerByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting payer by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, payerToResponseMapper(payer))
+}
+
+func (c *Controller) UpdatePayer(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "payer")
+	if !ok {
+		return
+	}
+	var request UpdatePayerRequest
+	if !c.bind(ctx, &request, "payer update") {
+		return
+	}
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.PayerCode != nil {
+		updates["payer_code"] = *request.PayerCode
+	}
+	if request.FilingIndicator != nil {
+		updates["filing_indicator"] = *request.FilingIndicator
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if !c.hasUpdates(ctx, updates, id) {
+		return
+	}
+	updated, err := c.claimUseCase.UpdatePayer(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating payer", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, payerToResponseMapper(updated))
+}
+
+func (c *Controller) DeletePayer(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "payer")
+	if !ok {
+		return
+	}
+	if err := c.claimUseCase.DeletePayer(id); err != nil {
+		c.Logger.Error("Error deleting payer", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) CreateRate(ctx *gin.Context) {
+	payerID, ok := c.parseID(ctx, "payer")
+	if !ok {
+		return
+	}
+	var request CreateRateRequest
+	if !c.bind(ctx, &request, "new payer rate") {
+		return
+	}
+	rate := &domainClaim.Rate{
+		PayerID:       payerID,
+		ServiceName:   request.ServiceName,
+		ProcedureCode: request.ProcedureCode,
+		Modifier:      request.Modifier,
+		UnitMinutes:   request.UnitMinutes,
+		UnitRate:      request.UnitRate,
+	}
+	if request.EffectiveFrom != "" {
+		if rate.EffectiveFrom, ok = c.parseDate(ctx, "effective from", request.EffectiveFrom); !ok {
+			return
+		}
+	}
+	if request.EffectiveTo != "" {
+		effectiveTo, ok := c.parseDate(ctx, "effective to", request.EffectiveTo)
+		if !ok {
+			return
+		}
+		rate.EffectiveTo = &effectiveTo
+	}
+	created, err := c.claimUseCase.CreateRate(rate)
+	if err != nil {
+		c.Logger.Error("Error creating payer rate", zap.Error(err), zap.String("payerID", payerID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rateToResponseMapper(created))
+}
+
+// GetRates lists a payer's rates, or with "date" (YYYY-MM-DD) only those in
+// effect that day.
+func (c *Controller) GetRates(ctx *gin.Context) {
+	payerID, ok := c.parseID(ctx, "payer")
+	if !ok {
+		return
+	}
+	var on *time.Time
+	if value := ctx.Query("date"); value != "" {
+		day, ok := c.parseDate(ctx, "date", value)
+		if !ok {
+			return
+		}
+		on = &day
+	}
+	rates, err := c.claimUseCase.GetRates(payerID)
+	if err != nil {
+		c.Logger.Error("Error getting payer rates", zap.Error(err), zap.String("payerID", payerID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := []RateResponse{}
+	for i := range *rates {
+		if on == nil || (*rates)[i].EffectiveOn(*on) {
+			res = append(res, *rateToResponseMapper(&(*rates)[i]))
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) UpdateRate(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "rate")
+	if !ok {
+		return
+	}
+	var request UpdateRateRequest
+	if !c.bind(ctx, &request, "payer rate update") {
+		return
+	}
+	updates := make(map[string]interface{})
+	if request.ServiceName != nil {
+		updates["service_name"] = *request.ServiceName
+	}
+	if request.ProcedureCode != nil {
+		updates["procedure_code"] = *request.ProcedureCode
+	}
+	if request.Modifier != nil {
+		updates["modifier"] = *request.Modifier
+	}
+	if request.UnitMinutes != nil {
+		updates["unit_minutes"] = *request.UnitMinutes
+	}
+	if request.UnitRate != nil {
+		updates["unit_rate"] = *request.UnitRate
+	}
+	if request.EffectiveFrom != nil {
+		effectiveFrom, ok := c.parseDate(ctx, "effective from", *request.EffectiveFrom)
+		if !ok {
+			return
+		}
+		updates["effective_from"] = effectiveFrom
+	}
+	if request.EffectiveTo != nil {
+		updates["effective_to"] = nil
+		if *request.EffectiveTo != "" {
+			effectiveTo, ok := c.parseDate(ctx, "effective to", *request.EffectiveTo)
+			if !ok {
+				return
+			}
+			updates["effective_to"] = effectiveTo
+		}
+	}
+	if !c.hasUpdates(ctx, updates, id) {
+		return
+	}
+	updated, err := c.claimUseCase.UpdateRate(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating payer rate", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rateToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteRate(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "rate")
+	if !ok {
+		return
+	}
+	if err := c.claimUseCase.DeleteRate(id); err != nil {
+		c.Logger.Error("Error deleting payer rate", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) CreateCoverage(ctx *gin.Context) {
+	var request CreateCoverageRequest
+	if !c.bind(ctx, &request, "new coverage") {
+		return
+	}
+	birthDate, ok := c.parseDate(ctx, "birth date", request.BirthDate)
+	if !ok {
+		return
+	}
+	created, err := c.claimUseCase.CreateCoverage(&domainClaim.Coverage{
+		ClientUserID:  request.ClientUserID,
+		PayerID:       request.PayerID,
+		MemberID:      request.MemberID,
+		BirthDate:     birthDate,
+		Gender:        request.Gender,
+		DiagnosisCode: request.DiagnosisCode,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating coverage", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, coverageToResponseMapper(created))
+}
+
+// GetCoverages lists coverages, optionally narrowed by "clientID" or
+// "payerID".
+func (c *Controller) GetCoverages(ctx *gin.Context) {
+	var filter domainClaim.CoverageFilter
+	var ok bool
+	if filter.ClientUserID, ok = c.queryID(ctx, "clientID"); !ok {
+		return
+	}
+	if filter.PayerID, ok = c.queryID(ctx, "payerID"); !ok {
+		return
+	}
+	coverages, err := c.claimUseCase.GetCoverages(filter)
+	if err != nil {
+		c.Logger.Error("Error getting coverages", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]CoverageResponse, len(*coverages))
+	for i := range *coverages {
+		res[i] = *coverageToResponseMapper(&(*coverages)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) UpdateCoverage(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "coverage")
+	if !ok {
+		return
+	}
+	var request UpdateCoverageRequest
+	if !c.bind(ctx, &request, "coverage update") {
+		return
+	}
+	updates := make(map[string]interface{})
+	if request.MemberID != nil {
+		updates["member_id"] = *request.MemberID
+	}
+	if request.BirthDate != nil {
+		birthDate, ok := c.parseDate(ctx, "birth date", *request.BirthDate)
+		if !ok {
+			return
+		}
+		updates["birth_date"] = birthDate
+	}
+	if request.Gender != nil {
+		updates["gender"] = *request.Gender
+	}
+	if request.DiagnosisCode != nil {
+		updates["diagnosis_code"] = *request.DiagnosisCode
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if !c.hasUpdates(ctx, updates, id) {
+		return
+	}
+	updated, err := c.claimUseCase.UpdateCoverage(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating coverage", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, coverageToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteCoverage(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "coverage")
+	if !ok {
+		return
+	}
+	if err := c.claimUseCase.DeleteCoverage(id); err != nil {
+		c.Logger.Error("Error deleting coverage", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) GetProviderSettings(ctx *gin.Context) {
+	settings, err := c.claimUseCase.GetProviderSettings()
+	if err != nil {
+		c.Logger.Error("Error getting claim provider settings", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, providerSettingsToResponseMapper(settings))
+}
+
+func (c *Controller) SetProviderSettings(ctx *gin.Context) {
+	var request ProviderSettingsRequest
+	if !c.bind(ctx, &request, "claim provider settings") {
+		return
+	}
+	settings, err := c.claimUseCase.SetProviderSettings(&domainClaim.ProviderSettings{
+		Name:         request.Name,
+		NPI:          request.NPI,
+		TaxID:        request.TaxID,
+		Street:       request.Street,
+		City:         request.City,
+		State:        request.State,
+		Zip:          request.Zip,
+		SubmitterID:  request.SubmitterID,
+		ContactName:  request.ContactName,
+		ContactPhone: request.ContactPhone,
+	})
+	if err != nil {
+		c.Logger.Error("Error setting claim provider settings", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, providerSettingsToResponseMapper(settings))
+}
+
+// GenerateBatch bills a payer for the visits completed in a period and
+// returns the batch with its claims. The 837P file is downloaded separately.
+func (c *Controller) GenerateBatch(ctx *gin.Context) {
+	var request GenerateBatchRequest
+	if !c.bind(ctx, &request, "claim batch") {
+		return
+	}
+	batch, err := c.claimUseCase.GenerateBatch(request.PayerID, request.From.UTC(), request.To.UTC())
+	if err != nil {
+		c.Logger.Error("Error generating claim batch", zap.Error(err), zap.String("payerID", request.PayerID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	claims, err := c.claimUseCase.GetClaims(domainClaim.ClaimFilter{BatchID: &batch.ID})
+	if err != nil {
+		c.Logger.Error("Error getting claims for batch", zap.Error(err), zap.String("batchID", batch.ID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Claim batch generated successfully", zap.String("batchID", batch.ID.String()), zap.Int("claims", batch.ClaimCount))
+	res := batchToResponseMapper(batch)
+	res.Claims = claimsToResponse(claims)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetBatches lists batches, optionally for one "payerID".
+func (c *Controller) GetBatches(ctx *gin.Context) {
+	payerID, ok := c.queryID(ctx, "payerID")
+	if !ok {
+		return
+	}
+	batches, err := c.claimUseCase.GetBatches(payerID)
+	if err != nil {
+		c.Logger.Error("Error getting claim batches", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]BatchResponse, len(*batches))
+	for i := range *batches {
+		res[i] = *batchToResponseMapper(&(*batches)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetBatchByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "batch")
+	if !ok {
+		return
+	}
+	batch, err := c.claimUseCase.GetBatchByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting claim batch", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	claims, err := c.claimUseCase.GetClaims(domainClaim.ClaimFilter{BatchID: &id})
+	if err != nil {
+		c.Logger.Error("Error getting claims for batch", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := batchToResponseMapper(batch)
+	res.Claims = claimsToResponse(claims)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DownloadBatch returns the batch's 837P file for upload to the payer or
+// clearinghouse.
+func (c *Controller) DownloadBatch(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "batch")
+	if !ok {
+		return
+	}
+	batch, err := c.claimUseCase.GetBatchByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting claim batch file", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("claims-%09d.837", batch.ControlNumber)))
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(batch.Content))
+}
+
+// GetClaims lists claims, optionally narrowed by "status", "payerID",
+// "clientID" or "batchID".
+func (c *Controller) GetClaims(ctx *gin.Context) {
+	filter := domainClaim.ClaimFilter{Status: ctx.Query("status")}
+	var ok bool
+	if filter.PayerID, ok = c.queryID(ctx, "payerID"); !ok {
+		return
+	}
+	if filter.ClientUserID, ok = c.queryID(ctx, "clientID"); !ok {
+		return
+	}
+	if filter.BatchID, ok = c.queryID(ctx, "batchID"); !ok {
+		return
+	}
+	claims, err := c.claimUseCase.GetClaims(filter)
+	if err != nil {
+		c.Logger.Error("Error getting claims", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, claimsToResponse(claims))
+}
+
+func (c *Controller) GetClaimByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "claim")
+	if !ok {
+		return
+	}
+	claim, err := c.claimUseCase.GetClaimByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting claim", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, claimToResponseMapper(claim))
+}
+
+func (c *Controller) UpdateClaimStatus(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "claim")
+	if !ok {
+		return
+	}
+	var request UpdateClaimStatusRequest
+	if !c.bind(ctx, &request, "claim status") {
+		return
+	}
+	claim, err := c.claimUseCase.UpdateClaimStatus(id, request.Status, request.Reason, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error updating claim status", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Claim status updated successfully", zap.String("id", id.String()), zap.String("status", claim.Status))
+	ctx.JSON(http.StatusOK, claimToResponseMapper(claim))
+}
+
+// IngestRemittance reads the whole 835 file from the body, up to
+// MaxRemittanceBytes; BindJSON's fixed buffer would cut real files short.
+func (c *Controller) IngestRemittance(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, MaxRemittanceBytes)
+	var request IngestRemittanceRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.Logger.Error("Error binding JSON for remittance", zap.Error(err))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			err = fmt.Errorf("remittance must be at most %d bytes", MaxRemittanceBytes)
+		}
+		_ = ctx.Error(domainErrors.NewAppError(err, domainErrors.ValidationError))
+		return
+	}
+	remittance, lines, err := c.claimUseCase.IngestRemittance(request.Content, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error ingesting remittance", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, remittanceToResponseMapper(remittance, lines))
+}
+
+func (c *Controller) GetRemittances(ctx *gin.Context) {
+	remittances, err := c.claimUseCase.GetRemittances()
+	if err != nil {
+		c.Logger.Error("Error getting remittances", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]RemittanceResponse, len(*remittances))
+	for i := range *remittances {
+		res[i] = *remittanceToResponseMapper(&(*remittances)[i], nil)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetRemittanceByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "remittance")
+	if !ok {
+		return
+	}
+	remittance, lines, err := c.claimUseCase.GetRemittance(id)
+	if err != nil {
+		c.Logger.Error("Error getting remittance", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, remittanceToResponseMapper(remittance, lines))
+}
+
+func (c *Controller) GetDenialReasons(ctx *gin.Context) {
+	reasons := c.claimUseCase.GetDenialReasons()
+	res := make([]DenialReasonResponse, len(reasons))
+	for i, reason := range reasons {
+		res[i] = DenialReasonResponse{Code: reason.Code, Description: reason.Description, Correctable: reason.Correctable}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetDenials lists denials, optionally narrowed by "status", "payerID",
+// "assignedUserID" or "reasonCode".
+func (c *Controller) GetDenials(ctx *gin.Context) {
+	filter := domainClaim.DenialFilter{Status: ctx.Query("status"), ReasonCode: ctx.Query("reasonCode")}
+	var ok bool
+	if filter.PayerID, ok = c.queryID(ctx, "payerID"); !ok {
+		return
+	}
+	if filter.AssignedUserID, ok = c.queryID(ctx, "assignedUserID"); !ok {
+		return
+	}
+	denials, err := c.claimUseCase.GetDenials(filter)
+	if err != nil {
+		c.Logger.Error("Error getting claim denials", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]DenialResponse, len(*denials))
+	for i := range *denials {
+		res[i] = *denialToResponseMapper(&(*denials)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetDenialByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "denial")
+	if !ok {
+		return
+	}
+	denial, err := c.claimUseCase.GetDenialByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting claim denial", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, denialToResponseMapper(denial))
+}
+
+func (c *Controller) UpdateDenial(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "denial")
+	if !ok {
+		return
+	}
+	var request UpdateDenialRequest
+	if !c.bind(ctx, &request, "claim denial update") {
+		return
+	}
+	updates := make(map[string]interface{})
+	if request.Note != nil {
+		updates["note"] = *request.Note
+	}
+	if request.AssignedUserID != nil {
+		updates["assigned_user_id"] = *request.AssignedUserID
+	}
+	if !c.hasUpdates(ctx, updates, id) {
+		return
+	}
+	updated, err := c.claimUseCase.UpdateDenial(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating claim denial", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, denialToResponseMapper(updated))
+}
+
+func (c *Controller) RebillDenial(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "denial")
+	if !ok {
+		return
+	}
+	denial, batch, err := c.claimUseCase.RebillDenial(id, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error rebilling claim denial", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Claim denial rebilled successfully", zap.String("id", id.String()), zap.String("batchID", batch.ID.String()))
+	ctx.JSON(http.StatusOK, RebillResponse{Denial: *denialToResponseMapper(denial), Batch: *batchToResponseMapper(batch)})
+}
+
+func (c *Controller) WriteOffDenial(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "denial")
+	if !ok {
+		return
+	}
+	var request WriteOffDenialRequest
+	if !c.bind(ctx, &request, "claim denial write-off") {
+		return
+	}
+	denial, err := c.claimUseCase.WriteOffDenial(id, request.Note, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error writing off claim denial", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Claim denial written off", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, denialToResponseMapper(denial))
+}
+
+// GetDenialAging reports open denials by age, optionally for one "payerID".
+func (c *Controller) GetDenialAging(ctx *gin.Context) {
+	payerID, ok := c.queryID(ctx, "payerID")
+	if !ok {
+		return
+	}
+	report, err := c.claimUseCase.DenialAging(time.Now().UTC(), payerID)
+	if err != nil {
+		c.Logger.Error("Error building denial aging report", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := DenialAgingResponse{
+		AsOf:        report.AsOf,
+		Total:       report.Total,
+		TotalAmount: report.TotalAmount,
+		Buckets:     make([]DenialAgingBucketResponse, len(report.Buckets)),
+		ByReason:    make([]DenialReasonSummaryResponse, len(report.ByReason)),
+	}
+	for i, b := range report.Buckets {
+		res.Buckets[i] = DenialAgingBucketResponse{Label: b.Label, MinDays: b.MinDays, MaxDays: b.MaxDays, Count: b.Count, Amount: b.Amount}
+	}
+	for i, r := range report.ByReason {
+		res.ByReason[i] = DenialReasonSummaryResponse{Code: r.Code, Description: r.Description, Count: r.Count, Amount: r.Amount}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) bind(ctx *gin.Context, request interface{}, name string) bool {
+	if err := controllers.BindJSON(ctx, request); err != nil {
+		c.Logger.Error("Error binding JSON for "+name, zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return false
+	}
+	return true
+}
+
+func (c *Controller) hasUpdates(ctx *gin.Context, updates map[string]interface{}, id uuid.UUID) bool {
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return false
+	}
+	return true
+}
+
+func (c *Controller) parseID(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func (c *Controller) queryID(ctx *gin.Context, param string) (*uuid.UUID, bool) {
+	value := ctx.Query(param)
+	if value == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		c.Logger.Error("Invalid "+param+" query parameter", zap.Error(err), zap.String(param, value))
+		appError := domainErrors.NewAppError(errors.New(param+" is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return nil, false
+	}
+	return &id, true
+}
+
+func payerToResponseMapper(p *domainClaim.Payer) *PayerResponse {
+	return &PayerResponse{
+		ID:              p.ID,
+		Name:            p.Name,
+		PayerCode:       p.PayerCode,
+		FilingIndicator: p.FilingIndicator,
+		Active:          p.Active,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+func coverageToResponseMapper(cv *domainClaim.Coverage) *CoverageResponse {
+	return &CoverageResponse{
+		ID:            cv.ID,
+		ClientUserID:  cv.ClientUserID,
+		PayerID:       cv.PayerID,
+		MemberID:      cv.MemberID,
+		BirthDate:     cv.BirthDate.Format(dateLayout),
+		Gender:        cv.Gender,
+		DiagnosisCode: cv.DiagnosisCode,
+		Active:        cv.Active,
+		CreatedAt:     cv.CreatedAt,
+		UpdatedAt:     cv.UpdatedAt,
+	}
+}
+
+func rateToResponseMapper(r *domainClaim.Rate) *RateResponse {
+	return &RateResponse{
+		ID:            r.ID,
+		PayerID:       r.PayerID,
+		ServiceName:   r.ServiceName,
+		ProcedureCode: r.ProcedureCode,
+		Modifier:      r.Modifier,
+		UnitMinutes:   r.UnitMinutes,
+		UnitRate:      r.UnitRate,
+		EffectiveFrom: r.EffectiveFrom.Format(dateLayout),
+		EffectiveTo:   formatDate(r.EffectiveTo),
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+func providerSettingsToResponseMapper(s *domainClaim.ProviderSettings) *ProviderSettingsResponse {
+	return &ProviderSettingsResponse{
+		Name:         s.Name,
+		NPI:          s.NPI,
+		TaxID:        s.TaxID,
+		Street:       s.Street,
+		City:         s.City,
+		State:        s.State,
+		Zip:          s.Zip,
+		SubmitterID:  s.SubmitterID,
+		ContactName:  s.ContactName,
+		ContactPhone: s.ContactPhone,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+func batchToResponseMapper(b *domainClaim.Batch) *BatchResponse {
+	return &BatchResponse{
+		ID:            b.ID,
+		PayerID:       b.PayerID,
+		ControlNumber: b.ControlNumber,
+		From:          b.From,
+		To:            b.To,
+		ClaimCount:    b.ClaimCount,
+		TotalCharge:   b.TotalCharge,
+		CreatedAt:     b.CreatedAt,
+	}
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(dateLayout)
+	return &formatted
+}
+
+func claimToResponseMapper(cl *domainClaim.Claim) *ClaimResponse {
+	breakdown := make([]ChargeLineResponse, len(cl.ChargeBreakdown))
+	for i, line := range cl.ChargeBreakdown {
+		breakdown[i] = ChargeLineResponse{RuleID: line.RuleID, Name: line.Name, Multiplier: line.Multiplier, Minutes: line.Minutes, Amount: line.Amount}
+	}
+	return &ClaimResponse{
+		ID:              cl.ID,
+		BatchID:         cl.BatchID,
+		PayerID:         cl.PayerID,
+		ClientUserID:    cl.ClientUserID,
+		ScheduleID:      cl.ScheduleID,
+		ControlNumber:   cl.ControlNumber,
+		ServiceDate:     cl.ServiceDate.Format(dateLayout),
+		ProcedureCode:   cl.ProcedureCode,
+		Modifier:        cl.Modifier,
+		Units:           cl.Units,
+		Charge:          cl.Charge,
+		ChargeBreakdown: breakdown,
+		PaidAmount:      cl.PaidAmount,
+		Status:          cl.Status,
+		StatusReason:    cl.StatusReason,
+		RemittanceID:    cl.RemittanceID,
+		AdjudicatedAt:   cl.AdjudicatedAt,
+		OriginalClaimID: cl.OriginalClaimID,
+		CreatedAt:       cl.CreatedAt,
+		UpdatedAt:       cl.UpdatedAt,
+	}
+}
+
+func claimsToResponse(claims *[]domainClaim.Claim) []ClaimResponse {
+	res := make([]ClaimResponse, len(*claims))
+	for i := range *claims {
+		res[i] = *claimToResponseMapper(&(*claims)[i])
+	}
+	return res
+}
+
+func remittanceToResponseMapper(r *domainClaim.Remittance, lines *[]domainClaim.RemittanceLine) *RemittanceResponse {
+	res := &RemittanceResponse{
+		ID:          r.ID,
+		PayerID:     r.PayerID,
+		PayerName:   r.PayerName,
+		TraceNumber: r.TraceNumber,
+		PaymentDate: r.PaymentDate,
+		TotalPaid:   r.TotalPaid,
+		Matched:     r.Matched,
+		Unmatched:   r.Unmatched,
+		CreatedAt:   r.CreatedAt,
+	}
+	if lines != nil {
+		res.Lines = make([]RemittanceLineResponse, len(*lines))
+		for i, line := range *lines {
+			res.Lines[i] = RemittanceLineResponse{
+				ClaimID:       line.ClaimID,
+				ControlNumber: line.ControlNumber,
+				StatusCode:    line.StatusCode,
+				Charge:        line.Charge,
+				Paid:          line.Paid,
+				Adjustments:   line.Adjustments,
+			}
+		}
+	}
+	return res
+}
+
+func denialToResponseMapper(d *domainClaim.Denial) *DenialResponse {
+	return &DenialResponse{
+		ID:             d.ID,
+		ClaimID:        d.ClaimID,
+		ScheduleID:     d.ScheduleID,
+		ClientUserID:   d.ClientUserID,
+		PayerID:        d.PayerID,
+		Amount:         d.Amount,
+		ReasonCodes:    d.ReasonCodes,
+		Note:           d.Note,
+		AssignedUserID: d.AssignedUserID,
+		Status:         d.Status,
+		RebillClaimID:  d.RebillClaimID,
+		DeniedAt:       d.DeniedAt,
+		ResolvedAt:     d.ResolvedAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
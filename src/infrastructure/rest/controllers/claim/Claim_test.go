@@ -0,0 +1,112 @@
+package claim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	claimUseCase "caregiver/src/application/usecases/claim"
+	domainClaim "caregiver/src/domain/claim"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MockClaimUseCase struct {
+	claimUseCase.IClaimUseCase
+	ingestRemittanceFunc func(string, time.Time) (*domainClaim.Remittance, *[]domainClaim.RemittanceLine, error)
+}
+
+func (m *MockClaimUseCase) IngestRemittance(content string, now time.Time) (*domainClaim.Remittance, *[]domainClaim.RemittanceLine, error) {
+	if m.ingestRemittanceFunc != nil {
+		return m.ingestRemittanceFunc(content, now)
+	}
+	return nil, nil, nil
+}
+
+func setupTestRouter(t *testing.T, useCase claimUseCase.IClaimUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	loggerInstance, err := logger.NewLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	controller := NewClaimController(useCase, loggerInstance)
+	router := gin.New()
+	router.POST("/remittances", controller.IngestRemittance)
+	return router
+}
+
+// remittanceFile builds an 835 with one claim payment per visit.
+func remittanceFile(visits int) string {
+	var b strings.Builder
+	b.WriteString("ISA*00*          *00*          *ZZ*PAYER          *ZZ*AGENCY         *250716*0900*^*00501*000000001*0*P*:~")
+	b.WriteString("GS*HP*PAYER*AGENCY*20250716*0900*1*X*005010X221A1~ST*835*0001~BPR*I*1500*C*ACH~TRN*1*TRACE123*1234567890~")
+	for i := 0; i < visits; i++ {
+		fmt.Fprintf(&b, "CLP*%s*1*150*150**MC*PAYERCLAIM%04d~SVC*HC:T1019*150*150**8~DTM*472*20250716~", uuid.New().String(), i)
+	}
+	b.WriteString("SE*4*0001~GE*1*1~IEA*1*000000001~")
+	return b.String()
+}
+
+func TestClaimController_IngestRemittance(t *testing.T) {
+	t.Run("Files larger than 5 KB are read in full", func(t *testing.T) {
+		content := remittanceFile(100)
+		if len(content) <= 5120 {
+			t.Fatalf("fixture too small: %d bytes", len(content))
+		}
+		var received string
+		router := setupTestRouter(t, &MockClaimUseCase{
+			ingestRemittanceFunc: func(c string, now time.Time) (*domainClaim.Remittance, *[]domainClaim.RemittanceLine, error) {
+				received = c
+				return &domainClaim.Remittance{ID: uuid.New(), TraceNumber: "TRACE123"}, &[]domainClaim.RemittanceLine{}, nil
+			},
+		})
+		body, _ := json.Marshal(IngestRemittanceRequest{Content: content})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/remittances", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if received != content {
+			t.Errorf("use case got %d of %d bytes", len(received), len(content))
+		}
+	})
+
+	t.Run("Oversized files are refused", func(t *testing.T) {
+		called := false
+		router := setupTestRouter(t, &MockClaimUseCase{
+			ingestRemittanceFunc: func(c string, now time.Time) (*domainClaim.Remittance, *[]domainClaim.RemittanceLine, error) {
+				called = true
+				return nil, nil, nil
+			},
+		})
+		body, _ := json.Marshal(IngestRemittanceRequest{Content: strings.Repeat("A", MaxRemittanceBytes)})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/remittances", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = req
+		router.HandleContext(ctx)
+
+		if called {
+			t.Error("use case should not be called")
+		}
+		if len(ctx.Errors) == 0 {
+			t.Fatal("expected an error")
+		}
+		appErr, ok := ctx.Errors.Last().Err.(*domainErrors.AppError)
+		if !ok || appErr.Type != domainErrors.ValidationError {
+			t.Errorf("expected validation error, got %v", ctx.Errors.Last().Err)
+		}
+	})
+}
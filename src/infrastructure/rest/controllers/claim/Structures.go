@@ -0,0 +1,270 @@
+package claim
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreatePayerRequest struct {
+	Name            string `json:"Name" binding:"required"`
+	PayerCode       string `json:"PayerCode" binding:"required"`
+	FilingIndicator string `json:"FilingIndicator"`
+}
+
+type UpdatePayerRequest struct {
+	Name            *string `json:"Name"`
+	PayerCode       *string `json:"PayerCode"`
+	FilingIndicator *string `json:"FilingIndicator"`
+	Active          *bool   `json:"Active"`
+}
+
+type PayerResponse struct {
+	ID              uuid.UUID `json:"ID"`
+	Name            string    `json:"Name"`
+	PayerCode       string    `json:"PayerCode"`
+	FilingIndicator string    `json:"FilingIndicator"`
+	Active          bool      `json:"Active"`
+	CreatedAt       time.Time `json:"CreatedAt"`
+	UpdatedAt       time.Time `json:"UpdatedAt"`
+}
+
+// CreateCoverageRequest takes BirthDate as YYYY-MM-DD.
+type CreateCoverageRequest struct {
+	ClientUserID  uuid.UUID `json:"ClientUserID" binding:"required"`
+	PayerID       uuid.UUID `json:"PayerID" binding:"required"`
+	MemberID      string    `json:"MemberID" binding:"required"`
+	BirthDate     string    `json:"BirthDate" binding:"required"`
+	Gender        string    `json:"Gender"`
+	DiagnosisCode string    `json:"DiagnosisCode" binding:"required"`
+}
+
+type UpdateCoverageRequest struct {
+	MemberID      *string `json:"MemberID"`
+	BirthDate     *string `json:"BirthDate"`
+	Gender        *string `json:"Gender"`
+	DiagnosisCode *string `json:"DiagnosisCode"`
+	Active        *bool   `json:"Active"`
+}
+
+type CoverageResponse struct {
+	ID            uuid.UUID `json:"ID"`
+	ClientUserID  uuid.UUID `json:"ClientUserID"`
+	PayerID       uuid.UUID `json:"PayerID"`
+	MemberID      string    `json:"MemberID"`
+	BirthDate     string    `json:"BirthDate"`
+	Gender        string    `json:"Gender"`
+	DiagnosisCode string    `json:"DiagnosisCode"`
+	Active        bool      `json:"Active"`
+	CreatedAt     time.Time `json:"CreatedAt"`
+	UpdatedAt     time.Time `json:"UpdatedAt"`
+}
+
+// CreateRateRequest takes effective dates as YYYY-MM-DD. Without
+// EffectiveFrom the rate applies to every past visit; without EffectiveTo it
+// stays in effect.
+type CreateRateRequest struct {
+	ServiceName   string  `json:"ServiceName" binding:"required"`
+	ProcedureCode string  `json:"ProcedureCode" binding:"required"`
+	Modifier      string  `json:"Modifier"`
+	UnitMinutes   int     `json:"UnitMinutes" binding:"required"`
+	UnitRate      float64 `json:"UnitRate" binding:"required"`
+	EffectiveFrom string  `json:"EffectiveFrom"`
+	EffectiveTo   string  `json:"EffectiveTo"`
+}
+
+// UpdateRateRequest clears the end date when EffectiveTo is an empty string.
+type UpdateRateRequest struct {
+	ServiceName   *string  `json:"ServiceName"`
+	ProcedureCode *string  `json:"ProcedureCode"`
+	Modifier      *string  `json:"Modifier"`
+	UnitMinutes   *int     `json:"UnitMinutes"`
+	UnitRate      *float64 `json:"UnitRate"`
+	EffectiveFrom *string  `json:"EffectiveFrom"`
+	EffectiveTo   *string  `json:"EffectiveTo"`
+}
+
+type RateResponse struct {
+	ID            uuid.UUID `json:"ID"`
+	PayerID       uuid.UUID `json:"PayerID"`
+	ServiceName   string    `json:"ServiceName"`
+	ProcedureCode string    `json:"ProcedureCode"`
+	Modifier      string    `json:"Modifier"`
+	UnitMinutes   int       `json:"UnitMinutes"`
+	UnitRate      float64   `json:"UnitRate"`
+	EffectiveFrom string    `json:"EffectiveFrom"`
+	EffectiveTo   *string   `json:"EffectiveTo"`
+	CreatedAt     time.Time `json:"CreatedAt"`
+	UpdatedAt     time.Time `json:"UpdatedAt"`
+}
+
+type ProviderSettingsRequest struct {
+	Name         string `json:"Name" binding:"required"`
+	NPI          string `json:"NPI" binding:"required"`
+	TaxID        string `json:"TaxID" binding:"required"`
+	Street       string `json:"Street"`
+	City         string `json:"City"`
+	State        string `json:"State"`
+	Zip          string `json:"Zip"`
+	SubmitterID  string `json:"SubmitterID" binding:"required"`
+	ContactName  string `json:"ContactName"`
+	ContactPhone string `json:"ContactPhone"`
+}
+
+type ProviderSettingsResponse struct {
+	Name         string    `json:"Name"`
+	NPI          string    `json:"NPI"`
+	TaxID        string    `json:"TaxID"`
+	Street       string    `json:"Street"`
+	City         string    `json:"City"`
+	State        string    `json:"State"`
+	Zip          string    `json:"Zip"`
+	SubmitterID  string    `json:"SubmitterID"`
+	ContactName  string    `json:"ContactName"`
+	ContactPhone string    `json:"ContactPhone"`
+	UpdatedAt    time.Time `json:"UpdatedAt"`
+}
+
+type GenerateBatchRequest struct {
+	PayerID uuid.UUID `json:"PayerID" binding:"required"`
+	From    time.Time `json:"From" binding:"required"`
+	To      time.Time `json:"To" binding:"required"`
+}
+
+type BatchResponse struct {
+	ID            uuid.UUID       `json:"ID"`
+	PayerID       uuid.UUID       `json:"PayerID"`
+	ControlNumber int             `json:"ControlNumber"`
+	From          time.Time       `json:"From"`
+	To            time.Time       `json:"To"`
+	ClaimCount    int             `json:"ClaimCount"`
+	TotalCharge   float64         `json:"TotalCharge"`
+	CreatedAt     time.Time       `json:"CreatedAt"`
+	Claims        []ClaimResponse `json:"Claims,omitempty"`
+}
+
+type ClaimResponse struct {
+	ID              uuid.UUID            `json:"ID"`
+	BatchID         uuid.UUID            `json:"BatchID"`
+	PayerID         uuid.UUID            `json:"PayerID"`
+	ClientUserID    uuid.UUID            `json:"ClientUserID"`
+	ScheduleID      uuid.UUID            `json:"ScheduleID"`
+	ControlNumber   string               `json:"ControlNumber"`
+	ServiceDate     string               `json:"ServiceDate"`
+	ProcedureCode   string               `json:"ProcedureCode"`
+	Modifier        string               `json:"Modifier"`
+	Units           int                  `json:"Units"`
+	Charge          float64              `json:"Charge"`
+	ChargeBreakdown []ChargeLineResponse `json:"ChargeBreakdown"`
+	PaidAmount      float64              `json:"PaidAmount"`
+	Status          string               `json:"Status"`
+	StatusReason    string               `json:"StatusReason"`
+	RemittanceID    *uuid.UUID           `json:"RemittanceID"`
+	AdjudicatedAt   *time.Time           `json:"AdjudicatedAt"`
+	OriginalClaimID *uuid.UUID           `json:"OriginalClaimID"`
+	CreatedAt       time.Time            `json:"CreatedAt"`
+	UpdatedAt       time.Time            `json:"UpdatedAt"`
+}
+
+// ChargeLineResponse is the part of a claim's charge for time worked at one
+// shift differential; RuleID is null for standard time.
+type ChargeLineResponse struct {
+	RuleID     *uuid.UUID `json:"RuleID"`
+	Name       string     `json:"Name"`
+	Multiplier float64    `json:"Multiplier"`
+	Minutes    float64    `json:"Minutes"`
+	Amount     float64    `json:"Amount"`
+}
+
+type UpdateClaimStatusRequest struct {
+	Status string `json:"Status" binding:"required"`
+	Reason string `json:"Reason"`
+}
+
+// IngestRemittanceRequest carries the raw 835 file.
+type IngestRemittanceRequest struct {
+	Content string `json:"Content" binding:"required"`
+}
+
+type RemittanceResponse struct {
+	ID          uuid.UUID                `json:"ID"`
+	PayerID     *uuid.UUID               `json:"PayerID"`
+	PayerName   string                   `json:"PayerName"`
+	TraceNumber string                   `json:"TraceNumber"`
+	PaymentDate *time.Time               `json:"PaymentDate"`
+	TotalPaid   float64                  `json:"TotalPaid"`
+	Matched     int                      `json:"Matched"`
+	Unmatched   int                      `json:"Unmatched"`
+	CreatedAt   time.Time                `json:"CreatedAt"`
+	Lines       []RemittanceLineResponse `json:"Lines,omitempty"`
+}
+
+type RemittanceLineResponse struct {
+	ClaimID       *uuid.UUID `json:"ClaimID"`
+	ControlNumber string     `json:"ControlNumber"`
+	StatusCode    string     `json:"StatusCode"`
+	Charge        float64    `json:"Charge"`
+	Paid          float64    `json:"Paid"`
+	Adjustments   string     `json:"Adjustments"`
+}
+
+type DenialReasonResponse struct {
+	Code        string `json:"Code"`
+	Description string `json:"Description"`
+	Correctable bool   `json:"Correctable"`
+}
+
+type DenialResponse struct {
+	ID             uuid.UUID  `json:"ID"`
+	ClaimID        uuid.UUID  `json:"ClaimID"`
+	ScheduleID     uuid.UUID  `json:"ScheduleID"`
+	ClientUserID   uuid.UUID  `json:"ClientUserID"`
+	PayerID        uuid.UUID  `json:"PayerID"`
+	Amount         float64    `json:"Amount"`
+	ReasonCodes    []string   `json:"ReasonCodes"`
+	Note           string     `json:"Note"`
+	AssignedUserID *uuid.UUID `json:"AssignedUserID"`
+	Status         string     `json:"Status"`
+	RebillClaimID  *uuid.UUID `json:"RebillClaimID"`
+	DeniedAt       time.Time  `json:"DeniedAt"`
+	ResolvedAt     *time.Time `json:"ResolvedAt"`
+	CreatedAt      time.Time  `json:"CreatedAt"`
+	UpdatedAt      time.Time  `json:"UpdatedAt"`
+}
+
+type UpdateDenialRequest struct {
+	Note           *string    `json:"Note"`
+	AssignedUserID *uuid.UUID `json:"AssignedUserID"`
+}
+
+type WriteOffDenialRequest struct {
+	Note string `json:"Note" binding:"required"`
+}
+
+type RebillResponse struct {
+	Denial DenialResponse `json:"Denial"`
+	Batch  BatchResponse  `json:"Batch"`
+}
+
+type DenialAgingBucketResponse struct {
+	Label   string  `json:"Label"`
+	MinDays int     `json:"MinDays"`
+	MaxDays int     `json:"MaxDays"`
+	Count   int     `json:"Count"`
+	Amount  float64 `json:"Amount"`
+}
+
+type DenialReasonSummaryResponse struct {
+	Code        string  `json:"Code"`
+	Description string  `json:"Description"`
+	Count       int     `json:"Count"`
+	Amount      float64 `json:"Amount"`
+}
+
+type DenialAgingResponse struct {
+	AsOf        time.Time                     `json:"AsOf"`
+	Total       int                           `json:"Total"`
+	TotalAmount float64                       `json:"TotalAmount"`
+	Buckets     []DenialAgingBucketResponse   `json:"Buckets"`
+	ByReason    []DenialReasonSummaryResponse `json:"ByReason"`
+}
@@ -0,0 +1,79 @@
+package clienterror
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	clientErrorUseCase "caregiver/src/application/usecases/clienterror"
+	domainClientError "caregiver/src/domain/clienterror"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type IClientErrorController interface {
+	GetReport(ctx *gin.Context)
+}
+
+type Controller struct {
+	clientErrorUseCase clientErrorUseCase.IClientErrorUseCase
+	Logger             *logger.Logger
+}
+
+func NewClientErrorController(clientErrorUseCase clientErrorUseCase.IClientErrorUseCase, loggerInstance *logger.Logger) IClientErrorController {
+	return &Controller{clientErrorUseCase: clientErrorUseCase, Logger: loggerInstance}
+}
+
+// GetReport lists the endpoints clients most often send bad requests to and
+// why, optionally for one "route" (as registered, e.g. /v1/prospects/:id) and
+// only failures seen "since" a date (YYYY-MM-DD).
+func (c *Controller) GetReport(ctx *gin.Context) {
+	var since *time.Time
+	if value := ctx.Query("since"); value != "" {
+		day, err := time.Parse(dateLayout, value)
+		if err != nil {
+			c.Logger.Error("Invalid since query parameter", zap.Error(err), zap.String("since", value))
+			appError := domainErrors.NewAppError(errors.New("since must be YYYY-MM-DD"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		since = &day
+	}
+	reports, err := c.clientErrorUseCase.Report(ctx.Query("route"), since)
+	if err != nil {
+		c.Logger.Error("Error getting client error report", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]EndpointReportResponse, len(reports))
+	for i := range reports {
+		res[i] = reportToResponseMapper(&reports[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func reportToResponseMapper(r *domainClientError.EndpointReport) EndpointReportResponse {
+	failures := make([]FailureResponse, len(r.Failures))
+	for i, f := range r.Failures {
+		failures[i] = FailureResponse{
+			Status:      f.Status,
+			Field:       f.Field,
+			Rule:        f.Rule,
+			Count:       f.Count,
+			FirstSeenAt: f.FirstSeenAt,
+			LastSeenAt:  f.LastSeenAt,
+		}
+	}
+	return EndpointReportResponse{
+		Method:     r.Method,
+		Route:      r.Route,
+		Count:      r.Count,
+		LastSeenAt: r.LastSeenAt,
+		Failures:   failures,
+	}
+}
@@ -0,0 +1,20 @@
+package clienterror
+
+import "time"
+
+type FailureResponse struct {
+	Status      int       `json:"Status"`
+	Field       string    `json:"Field"`
+	Rule        string    `json:"Rule"`
+	Count       int64     `json:"Count"`
+	FirstSeenAt time.Time `json:"FirstSeenAt"`
+	LastSeenAt  time.Time `json:"LastSeenAt"`
+}
+
+type EndpointReportResponse struct {
+	Method     string            `json:"Method"`
+	Route      string            `json:"Route"`
+	Count      int64             `json:"Count"`
+	LastSeenAt time.Time         `json:"LastSeenAt"`
+	Failures   []FailureResponse `json:"Failures"`
+}
@@ -0,0 +1,128 @@
+package clientmerge
+
+import (
+	"errors"
+	"net/http"
+
+	clientMergeUseCase "caregiver/src/application/usecases/clientmerge"
+	domainClientMerge "caregiver/src/domain/clientmerge"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IClientMergeController interface {
+	PreviewMerge(ctx *gin.Context)
+	MergeClients(ctx *gin.Context)
+	GetMerges(ctx *gin.Context)
+	GetMergeByID(ctx *gin.Context)
+}
+
+type Controller struct {
+	clientMergeUseCase clientMergeUseCase.IClientMergeUseCase
+	Logger             *logger.Logger
+}
+
+func NewClientMergeController(clientMergeUseCase clientMergeUseCase.IClientMergeUseCase, loggerInstance *logger.Logger) IClientMergeController {
+	return &Controller{clientMergeUseCase: clientMergeUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) PreviewMerge(ctx *gin.Context) {
+	survivorID, ok := c.parseQueryID(ctx, "survivorID", "survivor")
+	if !ok {
+		return
+	}
+	duplicateID, ok := c.parseQueryID(ctx, "duplicateID", "duplicate")
+	if !ok {
+		return
+	}
+	preview, err := c.clientMergeUseCase.Preview(survivorID, duplicateID)
+	if err != nil {
+		c.Logger.Error("Error previewing client merge", zap.Error(err), zap.String("survivorID", survivorID.String()), zap.String("duplicateID", duplicateID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, PreviewResponse{SurvivorUserID: preview.SurvivorUserID, DuplicateUserID: preview.DuplicateUserID, Rows: preview.Rows})
+}
+
+func (c *Controller) MergeClients(ctx *gin.Context) {
+	var request MergeRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for client merge", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	merged, err := c.clientMergeUseCase.Merge(&domainClientMerge.Merge{
+		SurvivorUserID:  request.SurvivorUserID,
+		DuplicateUserID: request.DuplicateUserID,
+		MergedByUserID:  request.MergedByUserID,
+		Note:            request.Note,
+	})
+	if err != nil {
+		c.Logger.Error("Error merging clients", zap.Error(err), zap.String("survivorUserID", request.SurvivorUserID.String()), zap.String("duplicateUserID", request.DuplicateUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Clients merged", zap.String("id", merged.ID.String()))
+	ctx.JSON(http.StatusCreated, mergeToResponseMapper(merged))
+}
+
+func (c *Controller) GetMerges(ctx *gin.Context) {
+	merges, err := c.clientMergeUseCase.GetAll()
+	if err != nil {
+		c.Logger.Error("Error getting client merges", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]MergeResponse, len(*merges))
+	for i := range *merges {
+		res[i] = *mergeToResponseMapper(&(*merges)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetMergeByID(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid client merge ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("client merge id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	merge, err := c.clientMergeUseCase.GetByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting client merge", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, mergeToResponseMapper(merge))
+}
+
+func (c *Controller) parseQueryID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Query(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID query", zap.Error(err), zap.String(param, ctx.Query(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func mergeToResponseMapper(merge *domainClientMerge.Merge) *MergeResponse {
+	return &MergeResponse{
+		ID:              merge.ID,
+		SurvivorUserID:  merge.SurvivorUserID,
+		DuplicateUserID: merge.DuplicateUserID,
+		MergedByUserID:  merge.MergedByUserID,
+		Note:            merge.Note,
+		Moved:           merge.Moved,
+		Kept:            merge.Kept,
+		CreatedAt:       merge.CreatedAt,
+	}
+}
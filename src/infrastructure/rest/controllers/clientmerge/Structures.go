@@ -0,0 +1,32 @@
+package clientmerge
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MergeRequest folds DuplicateUserID into SurvivorUserID.
+type MergeRequest struct {
+	SurvivorUserID  uuid.UUID  `json:"SurvivorUserID" binding:"required"`
+	DuplicateUserID uuid.UUID  `json:"DuplicateUserID" binding:"required"`
+	MergedByUserID  *uuid.UUID `json:"MergedByUserID"`
+	Note            string     `json:"Note"`
+}
+
+type MergeResponse struct {
+	ID              uuid.UUID        `json:"ID"`
+	SurvivorUserID  uuid.UUID        `json:"SurvivorUserID"`
+	DuplicateUserID uuid.UUID        `json:"DuplicateUserID"`
+	MergedByUserID  *uuid.UUID       `json:"MergedByUserID,omitempty"`
+	Note            string           `json:"Note"`
+	Moved           map[string]int64 `json:"Moved"`
+	Kept            map[string]int64 `json:"Kept,omitempty"`
+	CreatedAt       time.Time        `json:"CreatedAt"`
+}
+
+type PreviewResponse struct {
+	SurvivorUserID  uuid.UUID        `json:"SurvivorUserID"`
+	DuplicateUserID uuid.UUID        `json:"DuplicateUserID"`
+	Rows            map[string]int64 `json:"Rows"`
+}
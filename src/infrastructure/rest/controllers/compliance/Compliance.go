@@ -0,0 +1,256 @@
+package compliance
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	complianceUseCase "caregiver/src/application/usecases/compliance"
+	domainCompliance "caregiver/src/domain/compliance"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultReportDays = 30
+
+type IComplianceController interface {
+	CreateRule(ctx *gin.Context)
+	GetRules(ctx *gin.Context)
+	GetRuleByID(ctx *gin.Context)
+	UpdateRule(ctx *gin.Context)
+	DeleteRule(ctx *gin.Context)
+	GetExceptionsReport(ctx *gin.Context)
+}
+
+type Controller struct {
+	complianceUseCase complianceUseCase.IComplianceUseCase
+	Logger            *logger.Logger
+}
+
+func NewComplianceController(complianceUseCase complianceUseCase.IComplianceUseCase, loggerInstance *logger.Logger) IComplianceController {
+	return &Controller{complianceUseCase: complianceUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateRule(ctx *gin.Context) {
+	c.Logger.Info("Creating new compliance rule")
+	var request CreateRuleRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new compliance rule", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	created, err := c.complianceUseCase.CreateRule(&domainCompliance.Rule{
+		Name:        request.Name,
+		Type:        request.Type,
+		ServiceName: request.ServiceName,
+		Threshold:   request.Threshold,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating compliance rule", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.Logger.Info("Compliance rule created successfully", zap.String("ruleID", created.ID.String()))
+	ctx.JSON(http.StatusOK, ruleToResponseMapper(created))
+}
+
+func (c *Controller) GetRules(ctx *gin.Context) {
+	c.Logger.Info("Getting all compliance rules")
+	rules, err := c.complianceUseCase.GetRules()
+	if err != nil {
+		c.Logger.Error("Error getting compliance rules", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]RuleResponse, len(*rules))
+	for i := range *rules {
+		res[i] = *ruleToResponseMapper(&(*rules)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetRuleByID(ctx *gin.Context) {
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid compliance rule ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("rule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	rule, err := c.complianceUseCase.GetRuleByID(ruleID)
+	if err != nil {
+		c.Logger.Error("Error getting compliance rule by ID", zap.Error(err), zap.String("id", ruleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, ruleToResponseMapper(rule))
+}
+
+func (c *Controller) UpdateRule(ctx *gin.Context) {
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid compliance rule ID parameter for update", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("rule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	var request UpdateRuleRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for compliance rule update", zap.Error(err), zap.String("id", ruleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Type != nil {
+		updates["type"] = *request.Type
+	}
+	if request.ServiceName != nil {
+		updates["service_name"] = *request.ServiceName
+	}
+	if request.Threshold != nil {
+		updates["threshold"] = *request.Threshold
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", ruleID.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updated, err := c.complianceUseCase.UpdateRule(ruleID, updates)
+	if err != nil {
+		c.Logger.Error("Error updating compliance rule", zap.Error(err), zap.String("id", ruleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Compliance rule updated successfully", zap.String("id", ruleID.String()))
+	ctx.JSON(http.StatusOK, ruleToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteRule(ctx *gin.Context) {
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid compliance rule ID parameter for deletion", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("rule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if err := c.complianceUseCase.DeleteRule(ruleID); err != nil {
+		c.Logger.Error("Error deleting compliance rule", zap.Error(err), zap.String("id", ruleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Compliance rule deleted successfully", zap.String("id", ruleID.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetExceptionsReport returns the compliance exceptions detected between the
+// optional "from" and "to" query parameters (RFC3339, defaulting to the last
+// 30 days), optionally narrowed to one "ruleID" or one "teamID".
+func (c *Controller) GetExceptionsReport(ctx *gin.Context) {
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, -defaultReportDays)
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.Logger.Error("Invalid date for compliance report", zap.Error(err), zap.String(param.name, value))
+			appError := domainErrors.NewAppError(errors.New(param.name+" must be RFC3339"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		*param.target = parsed.UTC()
+	}
+
+	var filter domainCompliance.ReportFilter
+	if ruleStr := ctx.Query("ruleID"); ruleStr != "" {
+		parsed, err := uuid.Parse(ruleStr)
+		if err != nil {
+			c.Logger.Error("Invalid rule ID for compliance report", zap.Error(err), zap.String("ruleID", ruleStr))
+			appError := domainErrors.NewAppError(errors.New("rule id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		filter.RuleID = &parsed
+	}
+	if teamStr := ctx.Query("teamID"); teamStr != "" {
+		parsed, err := uuid.Parse(teamStr)
+		if err != nil {
+			c.Logger.Error("Invalid team ID for compliance report", zap.Error(err), zap.String("teamID", teamStr))
+			appError := domainErrors.NewAppError(errors.New("team id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		filter.TeamIDs = []uuid.UUID{parsed}
+	}
+
+	report, err := c.complianceUseCase.Report(from, to, filter)
+	if err != nil {
+		c.Logger.Error("Error building compliance report", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res := ReportResponse{
+		From:       report.From,
+		To:         report.To,
+		Total:      report.Total,
+		ByRule:     make([]RuleSummaryResponse, len(report.ByRule)),
+		Exceptions: make([]ExceptionResponse, len(report.Exceptions)),
+	}
+	for i, summary := range report.ByRule {
+		res.ByRule[i] = RuleSummaryResponse{RuleID: summary.RuleID, RuleName: summary.RuleName, Count: summary.Count}
+	}
+	for i, e := range report.Exceptions {
+		res.Exceptions[i] = ExceptionResponse{
+			ID:                     e.ID,
+			RuleID:                 e.RuleID,
+			RuleName:               e.RuleName,
+			ScheduleID:             e.ScheduleID,
+			ClientUserID:           e.ClientUserID,
+			AssignedUserID:         e.AssignedUserID,
+			Stage:                  e.Stage,
+			Message:                e.Message,
+			VerificationConfidence: e.VerificationConfidence,
+			DetectedAt:             e.DetectedAt,
+		}
+	}
+	c.Logger.Info("Successfully built compliance report", zap.Int("count", res.Total))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func ruleToResponseMapper(r *domainCompliance.Rule) *RuleResponse {
+	return &RuleResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		Type:        r.Type,
+		ServiceName: r.ServiceName,
+		Threshold:   r.Threshold,
+		Active:      r.Active,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
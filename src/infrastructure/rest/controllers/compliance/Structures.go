@@ -0,0 +1,60 @@
+package compliance
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateRuleRequest struct {
+	Name        string `json:"Name" binding:"required"`
+	Type        string `json:"Type" binding:"required"`
+	ServiceName string `json:"ServiceName"`
+	Threshold   int    `json:"Threshold"`
+}
+
+type UpdateRuleRequest struct {
+	Name        *string `json:"Name"`
+	Type        *string `json:"Type"`
+	ServiceName *string `json:"ServiceName"`
+	Threshold   *int    `json:"Threshold"`
+	Active      *bool   `json:"Active"`
+}
+
+type RuleResponse struct {
+	ID          uuid.UUID `json:"ID"`
+	Name        string    `json:"Name"`
+	Type        string    `json:"Type"`
+	ServiceName string    `json:"ServiceName"`
+	Threshold   int       `json:"Threshold"`
+	Active      bool      `json:"Active"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
+}
+
+type ExceptionResponse struct {
+	ID                     uuid.UUID `json:"ID"`
+	RuleID                 uuid.UUID `json:"RuleID"`
+	RuleName               string    `json:"RuleName"`
+	ScheduleID             uuid.UUID `json:"ScheduleID"`
+	ClientUserID           uuid.UUID `json:"ClientUserID"`
+	AssignedUserID         uuid.UUID `json:"AssignedUserID"`
+	Stage                  string    `json:"Stage"`
+	Message                string    `json:"Message"`
+	VerificationConfidence *int      `json:"VerificationConfidence"`
+	DetectedAt             time.Time `json:"DetectedAt"`
+}
+
+type RuleSummaryResponse struct {
+	RuleID   uuid.UUID `json:"RuleID"`
+	RuleName string    `json:"RuleName"`
+	Count    int       `json:"Count"`
+}
+
+type ReportResponse struct {
+	From       time.Time             `json:"From"`
+	To         time.Time             `json:"To"`
+	Total      int                   `json:"Total"`
+	ByRule     []RuleSummaryResponse `json:"ByRule"`
+	Exceptions []ExceptionResponse   `json:"Exceptions"`
+}
@@ -0,0 +1,223 @@
+package confirmation
+
+import (
+	"errors"
+	"net/http"
+
+	confirmationUseCase "caregiver/src/application/usecases/confirmation"
+	domainConfirmation "caregiver/src/domain/confirmation"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IConfirmationController interface {
+	IssueConfirmationLink(ctx *gin.Context)
+	GetPortalVisit(ctx *gin.Context)
+	ConfirmVisit(ctx *gin.Context)
+	DeclineVisit(ctx *gin.Context)
+	GetChangeRequests(ctx *gin.Context)
+	ResolveChangeRequest(ctx *gin.Context)
+}
+
+type Controller struct {
+	confirmationUseCase confirmationUseCase.IConfirmationUseCase
+	Logger              *logger.Logger
+}
+
+func NewConfirmationController(confirmationUseCase confirmationUseCase.IConfirmationUseCase, loggerInstance *logger.Logger) IConfirmationController {
+	return &Controller{confirmationUseCase: confirmationUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) IssueConfirmationLink(ctx *gin.Context) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter for confirmation link", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	// A link for a family member carries the client's consent to share
+	// their visits with them.
+	var request IssueConfirmationLinkRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := controllers.BindJSON(ctx, &request); err != nil {
+			c.Logger.Error("Error binding JSON for confirmation link", zap.Error(err))
+			appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+	}
+
+	var confirmation *domainConfirmation.Confirmation
+	var token string
+	if request.ConsentID != nil {
+		confirmation, token, err = c.confirmationUseCase.IssueFamilyLink(scheduleID, *request.ConsentID)
+	} else {
+		confirmation, token, err = c.confirmationUseCase.IssueLink(scheduleID)
+	}
+	if err != nil {
+		c.Logger.Error("Error issuing confirmation link", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Confirmation link issued", zap.String("scheduleID", scheduleID.String()))
+	ctx.JSON(http.StatusOK, ConfirmationLinkResponse{
+		ConfirmationID: confirmation.ID,
+		ScheduleID:     confirmation.ScheduleID,
+		ConsentID:      confirmation.ConsentID,
+		Token:          token,
+		ExpiresAt:      confirmation.ExpiresAt,
+	})
+}
+
+func (c *Controller) GetPortalVisit(ctx *gin.Context) {
+	confirmation, schedule, err := c.confirmationUseCase.GetByToken(ctx.Param("token"))
+	if err != nil {
+		c.Logger.Error("Error resolving confirmation token", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	branding, err := c.confirmationUseCase.PortalBranding()
+	if err != nil {
+		c.Logger.Error("Error getting portal branding", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, PortalVisitResponse{
+		ScheduleID:  schedule.ID,
+		ServiceName: schedule.ServiceName,
+		From:        schedule.ScheduledSlot.From,
+		To:          schedule.ScheduledSlot.To,
+		VisitStatus: schedule.VisitStatus,
+		Status:      confirmation.Status,
+		RespondedBy: confirmation.RespondedBy,
+		RespondedAt: confirmation.RespondedAt,
+		ExpiresAt:   confirmation.ExpiresAt,
+		Branding: PortalBrandingResponse{
+			DisplayName:  branding.DisplayName,
+			LogoURL:      branding.LogoURL,
+			PrimaryColor: branding.PrimaryColor,
+			AccentColor:  branding.AccentColor,
+		},
+	})
+}
+
+func (c *Controller) ConfirmVisit(ctx *gin.Context) {
+	var request ConfirmVisitRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := controllers.BindJSON(ctx, &request); err != nil {
+			c.Logger.Error("Error binding JSON for visit confirmation", zap.Error(err))
+			appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+	}
+
+	confirmation, err := c.confirmationUseCase.Confirm(ctx.Param("token"), request.RespondedBy)
+	if err != nil {
+		c.Logger.Error("Error confirming visit", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"Status": confirmation.Status, "RespondedAt": confirmation.RespondedAt})
+}
+
+func (c *Controller) DeclineVisit(ctx *gin.Context) {
+	var request DeclineVisitRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for visit decline", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	decline := domainConfirmation.Decline{
+		RespondedBy:   request.RespondedBy,
+		Reason:        request.Reason,
+		PreferredFrom: request.PreferredFrom,
+		PreferredTo:   request.PreferredTo,
+		ReleaseSlot:   request.ReleaseSlot,
+	}
+	if decline.PreferredFrom != nil {
+		utc := decline.PreferredFrom.UTC()
+		decline.PreferredFrom = &utc
+	}
+	if decline.PreferredTo != nil {
+		utc := decline.PreferredTo.UTC()
+		decline.PreferredTo = &utc
+	}
+
+	changeRequest, err := c.confirmationUseCase.Decline(ctx.Param("token"), decline)
+	if err != nil {
+		c.Logger.Error("Error declining visit", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Visit declined by client", zap.String("changeRequestID", changeRequest.ID.String()))
+	ctx.JSON(http.StatusOK, changeRequestToResponseMapper(changeRequest))
+}
+
+func (c *Controller) GetChangeRequests(ctx *gin.Context) {
+	status := ctx.Query("status")
+	requests, err := c.confirmationUseCase.GetChangeRequests(status)
+	if err != nil {
+		c.Logger.Error("Error getting change requests", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ChangeRequestResponse, len(*requests))
+	for i := range *requests {
+		res[i] = *changeRequestToResponseMapper(&(*requests)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) ResolveChangeRequest(ctx *gin.Context) {
+	requestID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid change request ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("change request id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	var request ResolveChangeRequestRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for change request resolution", zap.Error(err), zap.String("id", requestID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	resolved, err := c.confirmationUseCase.ResolveChangeRequest(requestID, request.Status, request.Resolution, request.ResolvedByUserID)
+	if err != nil {
+		c.Logger.Error("Error resolving change request", zap.Error(err), zap.String("id", requestID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Change request resolved", zap.String("id", requestID.String()), zap.String("status", resolved.Status))
+	ctx.JSON(http.StatusOK, changeRequestToResponseMapper(resolved))
+}
+
+func changeRequestToResponseMapper(r *domainConfirmation.ChangeRequest) *ChangeRequestResponse {
+	return &ChangeRequestResponse{
+		ID:               r.ID,
+		ScheduleID:       r.ScheduleID,
+		ClientUserID:     r.ClientUserID,
+		RequestedBy:      r.RequestedBy,
+		Reason:           r.Reason,
+		PreferredFrom:    r.PreferredFrom,
+		PreferredTo:      r.PreferredTo,
+		SlotReleased:     r.SlotReleased,
+		Status:           r.Status,
+		Resolution:       r.Resolution,
+		ResolvedByUserID: r.ResolvedByUserID,
+		ResolvedAt:       r.ResolvedAt,
+		CreatedAt:        r.CreatedAt,
+	}
+}
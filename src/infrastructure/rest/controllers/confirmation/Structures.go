@@ -0,0 +1,75 @@
+package confirmation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IssueConfirmationLinkRequest struct {
+	ConsentID *uuid.UUID `json:"ConsentID"`
+}
+
+type ConfirmationLinkResponse struct {
+	ConfirmationID uuid.UUID  `json:"ConfirmationID"`
+	ScheduleID     uuid.UUID  `json:"ScheduleID"`
+	ConsentID      *uuid.UUID `json:"ConsentID,omitempty"`
+	Token          string     `json:"Token"`
+	ExpiresAt      time.Time  `json:"ExpiresAt"`
+}
+
+type PortalVisitResponse struct {
+	ScheduleID  uuid.UUID              `json:"ScheduleID"`
+	ServiceName string                 `json:"ServiceName"`
+	From        time.Time              `json:"From"`
+	To          time.Time              `json:"To"`
+	VisitStatus string                 `json:"VisitStatus"`
+	Status      string                 `json:"Status"`
+	RespondedBy string                 `json:"RespondedBy"`
+	RespondedAt *time.Time             `json:"RespondedAt"`
+	ExpiresAt   time.Time              `json:"ExpiresAt"`
+	Branding    PortalBrandingResponse `json:"Branding"`
+}
+
+// PortalBrandingResponse is the agency look portal pages are shown in;
+// fields are empty when the agency has not set them.
+type PortalBrandingResponse struct {
+	DisplayName  string `json:"DisplayName"`
+	LogoURL      string `json:"LogoURL"`
+	PrimaryColor string `json:"PrimaryColor"`
+	AccentColor  string `json:"AccentColor"`
+}
+
+type ConfirmVisitRequest struct {
+	RespondedBy string `json:"RespondedBy"`
+}
+
+type DeclineVisitRequest struct {
+	RespondedBy   string     `json:"RespondedBy"`
+	Reason        string     `json:"Reason" binding:"required"`
+	PreferredFrom *time.Time `json:"PreferredFrom"`
+	PreferredTo   *time.Time `json:"PreferredTo"`
+	ReleaseSlot   bool       `json:"ReleaseSlot"`
+}
+
+type ResolveChangeRequestRequest struct {
+	Status           string    `json:"Status" binding:"required"`
+	Resolution       string    `json:"Resolution"`
+	ResolvedByUserID uuid.UUID `json:"ResolvedByUserID" binding:"required"`
+}
+
+type ChangeRequestResponse struct {
+	ID               uuid.UUID  `json:"ID"`
+	ScheduleID       uuid.UUID  `json:"ScheduleID"`
+	ClientUserID     uuid.UUID  `json:"ClientUserID"`
+	RequestedBy      string     `json:"RequestedBy"`
+	Reason           string     `json:"Reason"`
+	PreferredFrom    *time.Time `json:"PreferredFrom"`
+	PreferredTo      *time.Time `json:"PreferredTo"`
+	SlotReleased     bool       `json:"SlotReleased"`
+	Status           string     `json:"Status"`
+	Resolution       string     `json:"Resolution"`
+	ResolvedByUserID *uuid.UUID `json:"ResolvedByUserID"`
+	ResolvedAt       *time.Time `json:"ResolvedAt"`
+	CreatedAt        time.Time  `json:"CreatedAt"`
+}
@@ -0,0 +1,218 @@
+package consent
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	consentUseCase "caregiver/src/application/usecases/consent"
+	domainConsent "caregiver/src/domain/consent"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IConsentController interface {
+	GrantConsent(ctx *gin.Context)
+	GetConsents(ctx *gin.Context)
+	GetConsentByID(ctx *gin.Context)
+	ReviseConsent(ctx *gin.Context)
+	WithdrawConsent(ctx *gin.Context)
+	GetConsentHistory(ctx *gin.Context)
+}
+
+type Controller struct {
+	consentUseCase consentUseCase.IConsentUseCase
+	Logger         *logger.Logger
+}
+
+func NewConsentController(consentUseCase consentUseCase.IConsentUseCase, loggerInstance *logger.Logger) IConsentController {
+	return &Controller{consentUseCase: consentUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GrantConsent(ctx *gin.Context) {
+	var request GrantConsentRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new consent", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	consent, err := c.consentUseCase.Grant(&domainConsent.Consent{
+		ClientUserID:     request.ClientUserID,
+		RecipientType:    request.RecipientType,
+		RecipientName:    request.RecipientName,
+		RecipientContact: request.RecipientContact,
+		PayerID:          request.PayerID,
+		Scopes:           request.Scopes,
+		GrantedBy:        request.GrantedBy,
+		ExpiresAt:        request.ExpiresAt,
+	}, time.Now())
+	if err != nil {
+		c.Logger.Error("Error granting consent", zap.Error(err), zap.String("clientUserID", request.ClientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Consent granted successfully", zap.String("consentID", consent.ID.String()))
+	ctx.JSON(http.StatusCreated, consentToResponseMapper(consent))
+}
+
+// GetConsents lists consents, optionally filtered by "clientID", "payerID"
+// and "status".
+func (c *Controller) GetConsents(ctx *gin.Context) {
+	filter := domainConsent.ConsentFilter{Status: ctx.Query("status")}
+	if value := ctx.Query("clientID"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.Logger.Error("Invalid clientID query parameter", zap.Error(err), zap.String("clientID", value))
+			appError := domainErrors.NewAppError(errors.New("client id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		filter.ClientUserID = &id
+	}
+	if value := ctx.Query("payerID"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.Logger.Error("Invalid payerID query parameter", zap.Error(err), zap.String("payerID", value))
+			appError := domainErrors.NewAppError(errors.New("payer id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		filter.PayerID = &id
+	}
+	consents, err := c.consentUseCase.GetConsents(filter)
+	if err != nil {
+		c.Logger.Error("Error getting consents", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, arrayToResponseMapper(consents))
+}
+
+func (c *Controller) GetConsentByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	consent, err := c.consentUseCase.GetByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting consent", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, consentToResponseMapper(consent))
+}
+
+// ReviseConsent replaces an active consent with its next version.
+func (c *Controller) ReviseConsent(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request ReviseConsentRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for consent revision", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	consent, err := c.consentUseCase.Revise(id, &domainConsent.Consent{
+		RecipientName:    request.RecipientName,
+		RecipientContact: request.RecipientContact,
+		Scopes:           request.Scopes,
+		GrantedBy:        request.GrantedBy,
+		ExpiresAt:        request.ExpiresAt,
+	}, time.Now())
+	if err != nil {
+		c.Logger.Error("Error revising consent", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Consent revised successfully", zap.String("id", id.String()), zap.String("versionID", consent.ID.String()))
+	ctx.JSON(http.StatusOK, consentToResponseMapper(consent))
+}
+
+func (c *Controller) WithdrawConsent(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request WithdrawConsentRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := controllers.BindJSON(ctx, &request); err != nil {
+			c.Logger.Error("Error binding JSON for consent withdrawal", zap.Error(err), zap.String("id", id.String()))
+			appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+	}
+	consent, err := c.consentUseCase.Withdraw(id, request.Reason, time.Now())
+	if err != nil {
+		c.Logger.Error("Error withdrawing consent", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Consent withdrawn successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, consentToResponseMapper(consent))
+}
+
+// GetConsentHistory lists every version of the consent, newest first.
+func (c *Controller) GetConsentHistory(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	consents, err := c.consentUseCase.History(id)
+	if err != nil {
+		c.Logger.Error("Error getting consent history", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, arrayToResponseMapper(consents))
+}
+
+func (c *Controller) parseID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid consent ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("consent id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func consentToResponseMapper(c *domainConsent.Consent) *ConsentResponse {
+	return &ConsentResponse{
+		ID:               c.ID,
+		SeriesID:         c.SeriesID,
+		Version:          c.Version,
+		ClientUserID:     c.ClientUserID,
+		RecipientType:    c.RecipientType,
+		RecipientName:    c.RecipientName,
+		RecipientContact: c.RecipientContact,
+		PayerID:          c.PayerID,
+		Scopes:           c.Scopes,
+		Status:           c.Status,
+		GrantedBy:        c.GrantedBy,
+		GrantedAt:        c.GrantedAt,
+		ExpiresAt:        c.ExpiresAt,
+		WithdrawnAt:      c.WithdrawnAt,
+		WithdrawnReason:  c.WithdrawnReason,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
+
+func arrayToResponseMapper(consents *[]domainConsent.Consent) []ConsentResponse {
+	res := make([]ConsentResponse, len(*consents))
+	for i := range *consents {
+		res[i] = *consentToResponseMapper(&(*consents)[i])
+	}
+	return res
+}
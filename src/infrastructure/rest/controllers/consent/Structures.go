@@ -0,0 +1,54 @@
+package consent
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GrantConsentRequest records a client's consent to share data with a family
+// member or a payer. Scopes are any of "visits", "billing" and "health".
+type GrantConsentRequest struct {
+	ClientUserID     uuid.UUID  `json:"ClientUserID" binding:"required"`
+	RecipientType    string     `json:"RecipientType" binding:"required"`
+	RecipientName    string     `json:"RecipientName"`
+	RecipientContact string     `json:"RecipientContact"`
+	PayerID          *uuid.UUID `json:"PayerID"`
+	Scopes           []string   `json:"Scopes" binding:"required"`
+	GrantedBy        string     `json:"GrantedBy"`
+	ExpiresAt        *time.Time `json:"ExpiresAt"`
+}
+
+// ReviseConsentRequest changes the terms of an active consent, creating its
+// next version. Fields left out keep their current value.
+type ReviseConsentRequest struct {
+	RecipientName    string     `json:"RecipientName"`
+	RecipientContact string     `json:"RecipientContact"`
+	Scopes           []string   `json:"Scopes"`
+	GrantedBy        string     `json:"GrantedBy"`
+	ExpiresAt        *time.Time `json:"ExpiresAt"`
+}
+
+type WithdrawConsentRequest struct {
+	Reason string `json:"Reason"`
+}
+
+type ConsentResponse struct {
+	ID               uuid.UUID  `json:"ID"`
+	SeriesID         uuid.UUID  `json:"SeriesID"`
+	Version          int        `json:"Version"`
+	ClientUserID     uuid.UUID  `json:"ClientUserID"`
+	RecipientType    string     `json:"RecipientType"`
+	RecipientName    string     `json:"RecipientName"`
+	RecipientContact string     `json:"RecipientContact"`
+	PayerID          *uuid.UUID `json:"PayerID"`
+	Scopes           []string   `json:"Scopes"`
+	Status           string     `json:"Status"`
+	GrantedBy        string     `json:"GrantedBy"`
+	GrantedAt        time.Time  `json:"GrantedAt"`
+	ExpiresAt        *time.Time `json:"ExpiresAt"`
+	WithdrawnAt      *time.Time `json:"WithdrawnAt"`
+	WithdrawnReason  string     `json:"WithdrawnReason"`
+	CreatedAt        time.Time  `json:"CreatedAt"`
+	UpdatedAt        time.Time  `json:"UpdatedAt"`
+}
@@ -0,0 +1,105 @@
+package deactivation
+
+import (
+	"net/http"
+	"time"
+
+	deactivationUseCase "caregiver/src/application/usecases/deactivation"
+	domainDeactivation "caregiver/src/domain/deactivation"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IDeactivationController interface {
+	DeactivateUsers(ctx *gin.Context)
+	ReactivateUsers(ctx *gin.Context)
+}
+
+type Controller struct {
+	deactivationUseCase deactivationUseCase.IDeactivationUseCase
+	Logger              *logger.Logger
+}
+
+func NewDeactivationController(deactivationUseCase deactivationUseCase.IDeactivationUseCase, loggerInstance *logger.Logger) IDeactivationController {
+	return &Controller{deactivationUseCase: deactivationUseCase, Logger: loggerInstance}
+}
+
+// DeactivateUsers switches off a set of users; with ?preview=true it only
+// lists the visits that would be affected.
+func (c *Controller) DeactivateUsers(ctx *gin.Context) {
+	c.apply(ctx, domainDeactivation.ActionDeactivate)
+}
+
+// ReactivateUsers switches a set of users back on; ?preview=true works as
+// for DeactivateUsers.
+func (c *Controller) ReactivateUsers(ctx *gin.Context) {
+	c.apply(ctx, domainDeactivation.ActionReactivate)
+}
+
+func (c *Controller) apply(ctx *gin.Context, action string) {
+	var request BulkStatusRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for bulk user status change", zap.Error(err), zap.String("action", action))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	result, err := c.deactivationUseCase.Apply(domainDeactivation.Request{
+		Action:  action,
+		UserIDs: request.UserIDs,
+		Preview: ctx.Query("preview") == "true",
+		Reason:  request.Reason,
+		Note:    request.Note,
+	}, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error applying bulk user status change", zap.Error(err), zap.String("action", action))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Bulk user status change done", zap.String("action", action), zap.Bool("preview", result.Preview), zap.Int("changed", result.Changed))
+	ctx.JSON(http.StatusOK, resultToResponseMapper(result))
+}
+
+func resultToResponseMapper(result *domainDeactivation.Result) *BulkStatusResponse {
+	outcomes := make([]OutcomeResponse, len(result.Outcomes))
+	for i, outcome := range result.Outcomes {
+		schedules := make([]AffectedScheduleResponse, len(outcome.Schedules))
+		for j, schedule := range outcome.Schedules {
+			schedules[j] = AffectedScheduleResponse{
+				ID:             schedule.ID,
+				ClientUserID:   schedule.ClientUserID,
+				AssignedUserID: schedule.AssignedUserID,
+				ServiceName:    schedule.ServiceName,
+				From:           schedule.ScheduledSlot.From,
+				To:             schedule.ScheduledSlot.To,
+				VisitStatus:    schedule.VisitStatus,
+				SeriesID:       schedule.SeriesID,
+			}
+		}
+		seriesIDs := outcome.SeriesIDs
+		if seriesIDs == nil {
+			seriesIDs = []uuid.UUID{}
+		}
+		outcomes[i] = OutcomeResponse{
+			UserID:        outcome.UserID,
+			FirstName:     outcome.FirstName,
+			LastName:      outcome.LastName,
+			Role:          outcome.Role,
+			Skipped:       outcome.Skipped,
+			Schedules:     schedules,
+			ScheduleTotal: outcome.ScheduleTotal,
+			SeriesIDs:     seriesIDs,
+		}
+	}
+	return &BulkStatusResponse{
+		Action:   result.Action,
+		Preview:  result.Preview,
+		Changed:  result.Changed,
+		Outcomes: outcomes,
+	}
+}
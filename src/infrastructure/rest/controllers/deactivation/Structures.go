@@ -0,0 +1,44 @@
+package deactivation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BulkStatusRequest lists the users to change. Reason, one of the
+// termination reasons, and Note explain why clients are deactivated.
+type BulkStatusRequest struct {
+	UserIDs []uuid.UUID `json:"UserIDs" binding:"required,min=1"`
+	Reason  string      `json:"Reason"`
+	Note    string      `json:"Note"`
+}
+
+type AffectedScheduleResponse struct {
+	ID             uuid.UUID  `json:"ID"`
+	ClientUserID   uuid.UUID  `json:"ClientUserID"`
+	AssignedUserID uuid.UUID  `json:"AssignedUserID"`
+	ServiceName    string     `json:"ServiceName"`
+	From           time.Time  `json:"From"`
+	To             time.Time  `json:"To"`
+	VisitStatus    string     `json:"VisitStatus"`
+	SeriesID       *uuid.UUID `json:"SeriesID,omitempty"`
+}
+
+type OutcomeResponse struct {
+	UserID        uuid.UUID                  `json:"UserID"`
+	FirstName     string                     `json:"FirstName"`
+	LastName      string                     `json:"LastName"`
+	Role          string                     `json:"Role"`
+	Skipped       string                     `json:"Skipped,omitempty"`
+	Schedules     []AffectedScheduleResponse `json:"Schedules"`
+	ScheduleTotal int64                      `json:"ScheduleTotal"`
+	SeriesIDs     []uuid.UUID                `json:"SeriesIDs"`
+}
+
+type BulkStatusResponse struct {
+	Action   string            `json:"Action"`
+	Preview  bool              `json:"Preview"`
+	Changed  int               `json:"Changed"`
+	Outcomes []OutcomeResponse `json:"Outcomes"`
+}
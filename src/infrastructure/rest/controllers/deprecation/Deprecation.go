@@ -0,0 +1,80 @@
+package deprecation
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	deprecationUseCase "caregiver/src/application/usecases/deprecation"
+	domainDeprecation "caregiver/src/domain/deprecation"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type IDeprecationController interface {
+	GetUsageReport(ctx *gin.Context)
+}
+
+type Controller struct {
+	deprecationUseCase deprecationUseCase.IDeprecationUseCase
+	Logger             *logger.Logger
+}
+
+func NewDeprecationController(deprecationUseCase deprecationUseCase.IDeprecationUseCase, loggerInstance *logger.Logger) IDeprecationController {
+	return &Controller{deprecationUseCase: deprecationUseCase, Logger: loggerInstance}
+}
+
+// GetUsageReport lists which clients still call deprecated features,
+// optionally for one "feature" and only usage seen "since" a date
+// (YYYY-MM-DD).
+func (c *Controller) GetUsageReport(ctx *gin.Context) {
+	var since *time.Time
+	if value := ctx.Query("since"); value != "" {
+		day, err := time.Parse(dateLayout, value)
+		if err != nil {
+			c.Logger.Error("Invalid since query parameter", zap.Error(err), zap.String("since", value))
+			appError := domainErrors.NewAppError(errors.New("since must be YYYY-MM-DD"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		since = &day
+	}
+	reports, err := c.deprecationUseCase.Report(ctx.Query("feature"), since)
+	if err != nil {
+		c.Logger.Error("Error getting deprecation usage report", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]FeatureReportResponse, len(reports))
+	for i := range reports {
+		res[i] = reportToResponseMapper(&reports[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func reportToResponseMapper(r *domainDeprecation.FeatureReport) FeatureReportResponse {
+	usage := make([]UsageResponse, len(r.Usage))
+	for i, u := range r.Usage {
+		usage[i] = UsageResponse{
+			Method:      u.Method,
+			Route:       u.Route,
+			Client:      u.Client,
+			UserAgent:   u.UserAgent,
+			Calls:       u.Calls,
+			FirstSeenAt: u.FirstSeenAt,
+			LastSeenAt:  u.LastSeenAt,
+		}
+	}
+	return FeatureReportResponse{
+		Feature:    r.Feature,
+		Calls:      r.Calls,
+		Clients:    r.Clients,
+		LastSeenAt: r.LastSeenAt,
+		Usage:      usage,
+	}
+}
@@ -0,0 +1,21 @@
+package deprecation
+
+import "time"
+
+type UsageResponse struct {
+	Method      string    `json:"Method"`
+	Route       string    `json:"Route"`
+	Client      string    `json:"Client"`
+	UserAgent   string    `json:"UserAgent"`
+	Calls       int64     `json:"Calls"`
+	FirstSeenAt time.Time `json:"FirstSeenAt"`
+	LastSeenAt  time.Time `json:"LastSeenAt"`
+}
+
+type FeatureReportResponse struct {
+	Feature    string          `json:"Feature"`
+	Calls      int64           `json:"Calls"`
+	Clients    int             `json:"Clients"`
+	LastSeenAt time.Time       `json:"LastSeenAt"`
+	Usage      []UsageResponse `json:"Usage"`
+}
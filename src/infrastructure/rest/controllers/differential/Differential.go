@@ -0,0 +1,338 @@
+package differential
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	differentialUseCase "caregiver/src/application/usecases/differential"
+	domainDifferential "caregiver/src/domain/differential"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const clockLayout = "15:04"
+
+type IDifferentialController interface {
+	CreateRule(ctx *gin.Context)
+	GetRules(ctx *gin.Context)
+	GetRuleByID(ctx *gin.Context)
+	UpdateRule(ctx *gin.Context)
+	DeleteRule(ctx *gin.Context)
+	GetVisitPay(ctx *gin.Context)
+	EstimateVisit(ctx *gin.Context)
+}
+
+type Controller struct {
+	differentialUseCase differentialUseCase.IDifferentialUseCase
+	Logger              *logger.Logger
+}
+
+func NewDifferentialController(differentialUseCase differentialUseCase.IDifferentialUseCase, loggerInstance *logger.Logger) IDifferentialController {
+	return &Controller{differentialUseCase: differentialUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateRule(ctx *gin.Context) {
+	var request CreateRuleRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new shift differential rule", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	rule := &domainDifferential.Rule{
+		Name:       request.Name,
+		AppliesTo:  request.AppliesTo,
+		Weekdays:   request.Weekdays,
+		Timezone:   request.Timezone,
+		Multiplier: request.Multiplier,
+	}
+	var ok bool
+	if rule.StartMinute, ok = c.parseClock(ctx, "start time", request.StartTime); !ok {
+		return
+	}
+	if rule.EndMinute, ok = c.parseClock(ctx, "end time", request.EndTime); !ok {
+		return
+	}
+
+	created, err := c.differentialUseCase.CreateRule(rule)
+	if err != nil {
+		c.Logger.Error("Error creating shift differential rule", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Shift differential rule created successfully", zap.String("ruleID", created.ID.String()))
+	ctx.JSON(http.StatusOK, toResponseMapper(created))
+}
+
+func (c *Controller) GetRules(ctx *gin.Context) {
+	rules, err := c.differentialUseCase.GetRules()
+	if err != nil {
+		c.Logger.Error("Error getting shift differential rules", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]RuleResponse, len(*rules))
+	for i := range *rules {
+		res[i] = *toResponseMapper(&(*rules)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetRuleByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "rule")
+	if !ok {
+		return
+	}
+	rule, err := c.differentialUseCase.GetRuleByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting shift differential rule by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toResponseMapper(rule))
+}
+
+func (c *Controller) UpdateRule(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "rule")
+	if !ok {
+		return
+	}
+	var request UpdateRuleRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for shift differential rule update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.AppliesTo != nil {
+		updates["applies_to"] = *request.AppliesTo
+	}
+	if request.Weekdays != nil {
+		updates["weekdays"] = *request.Weekdays
+	}
+	if request.StartTime != nil {
+		minute, ok := c.parseClock(ctx, "start time", *request.StartTime)
+		if !ok {
+			return
+		}
+		updates["start_minute"] = minute
+	}
+	if request.EndTime != nil {
+		minute, ok := c.parseClock(ctx, "end time", *request.EndTime)
+		if !ok {
+			return
+		}
+		updates["end_minute"] = minute
+	}
+	if request.Timezone != nil {
+		updates["timezone"] = *request.Timezone
+	}
+	if request.Multiplier != nil {
+		updates["multiplier"] = *request.Multiplier
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updated, err := c.differentialUseCase.UpdateRule(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating shift differential rule", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Shift differential rule updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, toResponseMapper(updated))
+}
+
+func (c *Controller) DeleteRule(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "rule")
+	if !ok {
+		return
+	}
+	if err := c.differentialUseCase.DeleteRule(id); err != nil {
+		c.Logger.Error("Error deleting shift differential rule", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Shift differential rule deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetVisitPay returns a completed visit's pay itemised by differential.
+func (c *Controller) GetVisitPay(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "scheduleID", "schedule")
+	if !ok {
+		return
+	}
+	pay, err := c.differentialUseCase.VisitPay(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error computing visit pay", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := VisitPayResponse{
+		ScheduleID:      pay.ScheduleID,
+		CaregiverUserID: pay.CaregiverUserID,
+		ServiceName:     pay.ServiceName,
+		ServiceDate:     pay.ServiceDate.Format("2006-01-02"),
+		HourlyRate:      pay.HourlyRate,
+		RateSource:      pay.RateSource,
+		Hours:           pay.Hours,
+		BaseAmount:      pay.BaseAmount,
+		Amount:          pay.Amount,
+		Lines:           linesToResponse(pay.Lines),
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// EstimateVisit quotes the bill and pay for a visit before it is booked.
+// It takes clientUserID, caregiverUserID, serviceName, and the slot as
+// RFC3339 from and to; payerID picks the coverage to bill when the client
+// has several.
+func (c *Controller) EstimateVisit(ctx *gin.Context) {
+	request := domainDifferential.EstimateRequest{ServiceName: ctx.Query("serviceName")}
+	for _, param := range []struct {
+		name   string
+		target *uuid.UUID
+	}{{"clientUserID", &request.ClientUserID}, {"caregiverUserID", &request.CaregiverUserID}} {
+		id, err := uuid.Parse(ctx.Query(param.name))
+		if err != nil {
+			appError := domainErrors.NewAppError(errors.New(param.name+" is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		*param.target = id
+	}
+	if value := ctx.Query("payerID"); value != "" {
+		payerID, err := uuid.Parse(value)
+		if err != nil {
+			appError := domainErrors.NewAppError(errors.New("payerID is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		request.PayerID = &payerID
+	}
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &request.Slot.From}, {"to", &request.Slot.To}} {
+		parsed, err := time.Parse(time.RFC3339, ctx.Query(param.name))
+		if err != nil {
+			appError := domainErrors.NewAppError(errors.New(param.name+" must be RFC3339"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		*param.target = parsed.UTC()
+	}
+
+	estimate, err := c.differentialUseCase.Estimate(request)
+	if err != nil {
+		c.Logger.Error("Error estimating visit cost", zap.Error(err), zap.String("clientUserID", request.ClientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	pay := estimate.Pay
+	res := EstimateResponse{
+		ClientUserID:    estimate.ClientUserID,
+		CaregiverUserID: estimate.CaregiverUserID,
+		ServiceName:     estimate.ServiceName,
+		ServiceDate:     estimate.ServiceDate.Format("2006-01-02"),
+		Hours:           estimate.Hours,
+		Pay: PayEstimateResponse{
+			HourlyRate: pay.HourlyRate,
+			RateSource: pay.RateSource,
+			BaseAmount: pay.BaseAmount,
+			Amount:     pay.Amount,
+			Lines:      linesToResponse(pay.Lines),
+		},
+		Warnings: estimate.Warnings,
+	}
+	if bill := estimate.Bill; bill != nil {
+		res.Bill = &BillEstimateResponse{
+			PayerID:       bill.PayerID,
+			ProcedureCode: bill.ProcedureCode,
+			Units:         bill.Units,
+			UnitRate:      bill.UnitRate,
+			BaseAmount:    bill.BaseAmount,
+			Amount:        bill.Amount,
+			Lines:         linesToResponse(bill.Lines),
+		}
+	}
+	if res.Warnings == nil {
+		res.Warnings = []string{}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseClock turns an "HH:MM" time into minutes after midnight.
+func (c *Controller) parseClock(ctx *gin.Context, name string, value string) (int, bool) {
+	t, err := time.Parse(clockLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be HH:MM"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func linesToResponse(lines []domainDifferential.Line) []LineResponse {
+	res := make([]LineResponse, len(lines))
+	for i, line := range lines {
+		res[i] = LineResponse{RuleID: line.RuleID, Name: line.Name, Multiplier: line.Multiplier, Minutes: line.Minutes, Amount: line.Amount}
+	}
+	return res
+}
+
+func toResponseMapper(r *domainDifferential.Rule) *RuleResponse {
+	weekdays := r.Weekdays
+	if weekdays == nil {
+		weekdays = []int{}
+	}
+	return &RuleResponse{
+		ID:         r.ID,
+		Name:       r.Name,
+		AppliesTo:  r.AppliesTo,
+		Weekdays:   weekdays,
+		StartTime:  formatClock(r.StartMinute),
+		EndTime:    formatClock(r.EndMinute),
+		Timezone:   r.Timezone,
+		Multiplier: r.Multiplier,
+		Active:     r.Active,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
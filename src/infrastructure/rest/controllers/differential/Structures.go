@@ -0,0 +1,99 @@
+package differential
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateRuleRequest takes the window as local "HH:MM" times; equal times
+// cover the whole day. Weekdays run from 0 (Sunday) to 6 (Saturday) and
+// default to every day. AppliesTo defaults to "both" and Timezone to UTC.
+type CreateRuleRequest struct {
+	Name       string  `json:"Name" binding:"required"`
+	AppliesTo  string  `json:"AppliesTo"`
+	Weekdays   []int   `json:"Weekdays"`
+	StartTime  string  `json:"StartTime" binding:"required"`
+	EndTime    string  `json:"EndTime" binding:"required"`
+	Timezone   string  `json:"Timezone"`
+	Multiplier float64 `json:"Multiplier" binding:"required"`
+}
+
+type UpdateRuleRequest struct {
+	Name       *string  `json:"Name"`
+	AppliesTo  *string  `json:"AppliesTo"`
+	Weekdays   *[]int   `json:"Weekdays"`
+	StartTime  *string  `json:"StartTime"`
+	EndTime    *string  `json:"EndTime"`
+	Timezone   *string  `json:"Timezone"`
+	Multiplier *float64 `json:"Multiplier"`
+	Active     *bool    `json:"Active"`
+}
+
+type RuleResponse struct {
+	ID         uuid.UUID `json:"ID"`
+	Name       string    `json:"Name"`
+	AppliesTo  string    `json:"AppliesTo"`
+	Weekdays   []int     `json:"Weekdays"`
+	StartTime  string    `json:"StartTime"`
+	EndTime    string    `json:"EndTime"`
+	Timezone   string    `json:"Timezone"`
+	Multiplier float64   `json:"Multiplier"`
+	Active     bool      `json:"Active"`
+	CreatedAt  time.Time `json:"CreatedAt"`
+	UpdatedAt  time.Time `json:"UpdatedAt"`
+}
+
+// LineResponse is the pay for time worked at one multiplier; RuleID is null
+// for standard time.
+type LineResponse struct {
+	RuleID     *uuid.UUID `json:"RuleID"`
+	Name       string     `json:"Name"`
+	Multiplier float64    `json:"Multiplier"`
+	Minutes    float64    `json:"Minutes"`
+	Amount     float64    `json:"Amount"`
+}
+
+type VisitPayResponse struct {
+	ScheduleID      uuid.UUID      `json:"ScheduleID"`
+	CaregiverUserID uuid.UUID      `json:"CaregiverUserID"`
+	ServiceName     string         `json:"ServiceName"`
+	ServiceDate     string         `json:"ServiceDate"`
+	HourlyRate      float64        `json:"HourlyRate"`
+	RateSource      string         `json:"RateSource"`
+	Hours           float64        `json:"Hours"`
+	BaseAmount      float64        `json:"BaseAmount"`
+	Amount          float64        `json:"Amount"`
+	Lines           []LineResponse `json:"Lines"`
+}
+
+type PayEstimateResponse struct {
+	HourlyRate float64        `json:"HourlyRate"`
+	RateSource string         `json:"RateSource"`
+	BaseAmount float64        `json:"BaseAmount"`
+	Amount     float64        `json:"Amount"`
+	Lines      []LineResponse `json:"Lines"`
+}
+
+type BillEstimateResponse struct {
+	PayerID       uuid.UUID      `json:"PayerID"`
+	ProcedureCode string         `json:"ProcedureCode"`
+	Units         int            `json:"Units"`
+	UnitRate      float64        `json:"UnitRate"`
+	BaseAmount    float64        `json:"BaseAmount"`
+	Amount        float64        `json:"Amount"`
+	Lines         []LineResponse `json:"Lines"`
+}
+
+// EstimateResponse quotes a visit before booking. Bill is null when the
+// client has nothing billable for the service; Warnings say why.
+type EstimateResponse struct {
+	ClientUserID    uuid.UUID             `json:"ClientUserID"`
+	CaregiverUserID uuid.UUID             `json:"CaregiverUserID"`
+	ServiceName     string                `json:"ServiceName"`
+	ServiceDate     string                `json:"ServiceDate"`
+	Hours           float64               `json:"Hours"`
+	Bill            *BillEstimateResponse `json:"Bill"`
+	Pay             PayEstimateResponse   `json:"Pay"`
+	Warnings        []string              `json:"Warnings"`
+}
@@ -0,0 +1,363 @@
+package equipment
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	equipmentUseCase "caregiver/src/application/usecases/equipment"
+	domainEquipment "caregiver/src/domain/equipment"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IEquipmentController interface {
+	CreateEquipment(ctx *gin.Context)
+	GetEquipment(ctx *gin.Context)
+	GetEquipmentByID(ctx *gin.Context)
+	UpdateEquipment(ctx *gin.Context)
+	DeleteEquipment(ctx *gin.Context)
+	CheckoutEquipment(ctx *gin.Context)
+	ReturnEquipment(ctx *gin.Context)
+	GetCheckouts(ctx *gin.Context)
+	GetVisitCheckouts(ctx *gin.Context)
+	RecordMaintenance(ctx *gin.Context)
+	GetMaintenanceRecords(ctx *gin.Context)
+}
+
+type Controller struct {
+	equipmentUseCase equipmentUseCase.IEquipmentUseCase
+	Logger           *logger.Logger
+}
+
+func NewEquipmentController(equipmentUseCase equipmentUseCase.IEquipmentUseCase, loggerInstance *logger.Logger) IEquipmentController {
+	return &Controller{equipmentUseCase: equipmentUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateEquipment(ctx *gin.Context) {
+	var request CreateEquipmentRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new equipment", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	equipment, err := c.equipmentUseCase.CreateEquipment(&domainEquipment.Equipment{
+		Name:                    request.Name,
+		Type:                    request.Type,
+		Identifier:              request.Identifier,
+		MaintenanceIntervalDays: request.MaintenanceIntervalDays,
+		LastMaintainedAt:        request.LastMaintainedAt,
+		NextMaintenanceDue:      request.NextMaintenanceDue,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating equipment", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Equipment created successfully", zap.String("equipmentID", equipment.ID.String()))
+	ctx.JSON(http.StatusOK, equipmentToResponseMapper(equipment))
+}
+
+// GetEquipment lists the agency's equipment, optionally narrowed by "status".
+func (c *Controller) GetEquipment(ctx *gin.Context) {
+	equipment, err := c.equipmentUseCase.GetEquipment(ctx.Query("status"))
+	if err != nil {
+		c.Logger.Error("Error getting equipment", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]EquipmentResponse, len(*equipment))
+	for i := range *equipment {
+		res[i] = *equipmentToResponseMapper(&(*equipment)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetEquipmentByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "equipment")
+	if !ok {
+		return
+	}
+	equipment, err := c.equipmentUseCase.GetEquipmentByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting equipment by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, equipmentToResponseMapper(equipment))
+}
+
+func (c *Controller) UpdateEquipment(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "equipment")
+	if !ok {
+		return
+	}
+	var request UpdateEquipmentRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for equipment update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Type != nil {
+		updates["type"] = *request.Type
+	}
+	if request.Identifier != nil {
+		updates["identifier"] = *request.Identifier
+	}
+	if request.Status != nil {
+		updates["status"] = *request.Status
+	}
+	if request.MaintenanceIntervalDays != nil {
+		updates["maintenance_interval_days"] = *request.MaintenanceIntervalDays
+	}
+	if request.NextMaintenanceDue != nil {
+		updates["next_maintenance_due"] = *request.NextMaintenanceDue
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	equipment, err := c.equipmentUseCase.UpdateEquipment(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating equipment", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Equipment updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, equipmentToResponseMapper(equipment))
+}
+
+func (c *Controller) DeleteEquipment(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "equipment")
+	if !ok {
+		return
+	}
+	if err := c.equipmentUseCase.DeleteEquipment(id); err != nil {
+		c.Logger.Error("Error deleting equipment", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Equipment deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) CheckoutEquipment(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "equipment")
+	if !ok {
+		return
+	}
+	var request CheckoutRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for equipment checkout", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	checkout, err := c.equipmentUseCase.Checkout(&domainEquipment.Checkout{
+		EquipmentID:     id,
+		CaregiverUserID: request.CaregiverUserID,
+		ClientUserID:    request.ClientUserID,
+		ScheduleID:      request.ScheduleID,
+		DueBackAt:       request.DueBackAt,
+		ConditionOut:    request.Condition,
+	}, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error checking out equipment", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Equipment checked out successfully", zap.String("checkoutID", checkout.ID.String()))
+	ctx.JSON(http.StatusOK, checkoutToResponseMapper(checkout))
+}
+
+func (c *Controller) ReturnEquipment(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "checkout")
+	if !ok {
+		return
+	}
+	var request ReturnRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for equipment return", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	checkout, err := c.equipmentUseCase.Return(id, request.Condition, request.NeedsMaintenance, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error returning equipment", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Equipment returned successfully", zap.String("checkoutID", id.String()))
+	ctx.JSON(http.StatusOK, checkoutToResponseMapper(checkout))
+}
+
+// GetCheckouts lists checkouts, optionally narrowed by "equipmentID",
+// "caregiverID" or "clientID"; "open=true" keeps those not yet returned.
+func (c *Controller) GetCheckouts(ctx *gin.Context) {
+	filter := domainEquipment.CheckoutFilter{OpenOnly: ctx.Query("open") == "true"}
+	var ok bool
+	if filter.EquipmentID, ok = c.queryID(ctx, "equipmentID"); !ok {
+		return
+	}
+	if filter.CaregiverUserID, ok = c.queryID(ctx, "caregiverID"); !ok {
+		return
+	}
+	if filter.ClientUserID, ok = c.queryID(ctx, "clientID"); !ok {
+		return
+	}
+	c.respondCheckouts(ctx, filter)
+}
+
+// GetVisitCheckouts lists the equipment checked out for a visit.
+func (c *Controller) GetVisitCheckouts(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	c.respondCheckouts(ctx, domainEquipment.CheckoutFilter{ScheduleID: &scheduleID})
+}
+
+func (c *Controller) respondCheckouts(ctx *gin.Context, filter domainEquipment.CheckoutFilter) {
+	checkouts, err := c.equipmentUseCase.GetCheckouts(filter)
+	if err != nil {
+		c.Logger.Error("Error getting equipment checkouts", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]CheckoutResponse, len(*checkouts))
+	for i := range *checkouts {
+		res[i] = *checkoutToResponseMapper(&(*checkouts)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) RecordMaintenance(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "equipment")
+	if !ok {
+		return
+	}
+	var request MaintenanceRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for equipment maintenance", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	record, err := c.equipmentUseCase.RecordMaintenance(&domainEquipment.MaintenanceRecord{
+		EquipmentID: id,
+		PerformedAt: request.PerformedAt,
+		Description: request.Description,
+		PerformedBy: request.PerformedBy,
+		Cost:        request.Cost,
+	})
+	if err != nil {
+		c.Logger.Error("Error recording equipment maintenance", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Equipment maintenance recorded successfully", zap.String("recordID", record.ID.String()))
+	ctx.JSON(http.StatusOK, maintenanceToResponseMapper(record))
+}
+
+func (c *Controller) GetMaintenanceRecords(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "equipment")
+	if !ok {
+		return
+	}
+	records, err := c.equipmentUseCase.GetMaintenanceRecords(id)
+	if err != nil {
+		c.Logger.Error("Error getting equipment maintenance records", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]MaintenanceResponse, len(*records))
+	for i := range *records {
+		res[i] = *maintenanceToResponseMapper(&(*records)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) queryID(ctx *gin.Context, param string) (*uuid.UUID, bool) {
+	value := ctx.Query(param)
+	if value == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		c.Logger.Error("Invalid "+param+" query parameter", zap.Error(err), zap.String(param, value))
+		appError := domainErrors.NewAppError(errors.New(param+" is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return nil, false
+	}
+	return &id, true
+}
+
+func equipmentToResponseMapper(e *domainEquipment.Equipment) *EquipmentResponse {
+	return &EquipmentResponse{
+		ID:                      e.ID,
+		Name:                    e.Name,
+		Type:                    e.Type,
+		Identifier:              e.Identifier,
+		Status:                  e.Status,
+		MaintenanceIntervalDays: e.MaintenanceIntervalDays,
+		LastMaintainedAt:        e.LastMaintainedAt,
+		NextMaintenanceDue:      e.NextMaintenanceDue,
+		MaintenanceOverdue:      e.MaintenanceOverdue(time.Now()),
+		CreatedAt:               e.CreatedAt,
+		UpdatedAt:               e.UpdatedAt,
+	}
+}
+
+func checkoutToResponseMapper(c *domainEquipment.Checkout) *CheckoutResponse {
+	return &CheckoutResponse{
+		ID:              c.ID,
+		EquipmentID:     c.EquipmentID,
+		CaregiverUserID: c.CaregiverUserID,
+		ClientUserID:    c.ClientUserID,
+		ScheduleID:      c.ScheduleID,
+		CheckedOutAt:    c.CheckedOutAt,
+		DueBackAt:       c.DueBackAt,
+		ReturnedAt:      c.ReturnedAt,
+		ConditionOut:    c.ConditionOut,
+		ConditionIn:     c.ConditionIn,
+	}
+}
+
+func maintenanceToResponseMapper(m *domainEquipment.MaintenanceRecord) *MaintenanceResponse {
+	return &MaintenanceResponse{
+		ID:          m.ID,
+		EquipmentID: m.EquipmentID,
+		PerformedAt: m.PerformedAt,
+		Description: m.Description,
+		PerformedBy: m.PerformedBy,
+		Cost:        m.Cost,
+		CreatedAt:   m.CreatedAt,
+	}
+}
@@ -0,0 +1,92 @@
+package equipment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateEquipmentRequest adds an asset. Type is "vehicle", "hoist",
+// "mobility_aid" or "other"; Identifier is the serial number or plate.
+// NextMaintenanceDue defaults to LastMaintainedAt plus the interval.
+type CreateEquipmentRequest struct {
+	Name                    string     `json:"Name" binding:"required"`
+	Type                    string     `json:"Type" binding:"required"`
+	Identifier              string     `json:"Identifier"`
+	MaintenanceIntervalDays int        `json:"MaintenanceIntervalDays"`
+	LastMaintainedAt        *time.Time `json:"LastMaintainedAt"`
+	NextMaintenanceDue      *time.Time `json:"NextMaintenanceDue"`
+}
+
+// UpdateEquipmentRequest edits an asset. Status may be "available",
+// "maintenance" or "retired".
+type UpdateEquipmentRequest struct {
+	Name                    *string    `json:"Name"`
+	Type                    *string    `json:"Type"`
+	Identifier              *string    `json:"Identifier"`
+	Status                  *string    `json:"Status"`
+	MaintenanceIntervalDays *int       `json:"MaintenanceIntervalDays"`
+	NextMaintenanceDue      *time.Time `json:"NextMaintenanceDue"`
+}
+
+type EquipmentResponse struct {
+	ID                      uuid.UUID  `json:"ID"`
+	Name                    string     `json:"Name"`
+	Type                    string     `json:"Type"`
+	Identifier              string     `json:"Identifier"`
+	Status                  string     `json:"Status"`
+	MaintenanceIntervalDays int        `json:"MaintenanceIntervalDays"`
+	LastMaintainedAt        *time.Time `json:"LastMaintainedAt"`
+	NextMaintenanceDue      *time.Time `json:"NextMaintenanceDue"`
+	MaintenanceOverdue      bool       `json:"MaintenanceOverdue"`
+	CreatedAt               time.Time  `json:"CreatedAt"`
+	UpdatedAt               time.Time  `json:"UpdatedAt"`
+}
+
+// CheckoutRequest lends the equipment to a caregiver or a client. With a
+// ScheduleID and no holder it goes to the visit's caregiver, due back at the
+// end of the visit.
+type CheckoutRequest struct {
+	CaregiverUserID *uuid.UUID `json:"CaregiverUserID"`
+	ClientUserID    *uuid.UUID `json:"ClientUserID"`
+	ScheduleID      *uuid.UUID `json:"ScheduleID"`
+	DueBackAt       *time.Time `json:"DueBackAt"`
+	Condition       string     `json:"Condition"`
+}
+
+// ReturnRequest closes a checkout. NeedsMaintenance sends the equipment to
+// maintenance instead of back into service.
+type ReturnRequest struct {
+	Condition        string `json:"Condition"`
+	NeedsMaintenance bool   `json:"NeedsMaintenance"`
+}
+
+type CheckoutResponse struct {
+	ID              uuid.UUID  `json:"ID"`
+	EquipmentID     uuid.UUID  `json:"EquipmentID"`
+	CaregiverUserID *uuid.UUID `json:"CaregiverUserID"`
+	ClientUserID    *uuid.UUID `json:"ClientUserID"`
+	ScheduleID      *uuid.UUID `json:"ScheduleID"`
+	CheckedOutAt    time.Time  `json:"CheckedOutAt"`
+	DueBackAt       *time.Time `json:"DueBackAt"`
+	ReturnedAt      *time.Time `json:"ReturnedAt"`
+	ConditionOut    string     `json:"ConditionOut"`
+	ConditionIn     string     `json:"ConditionIn"`
+}
+
+type MaintenanceRequest struct {
+	PerformedAt time.Time `json:"PerformedAt" binding:"required"`
+	Description string    `json:"Description" binding:"required"`
+	PerformedBy string    `json:"PerformedBy"`
+	Cost        float64   `json:"Cost"`
+}
+
+type MaintenanceResponse struct {
+	ID          uuid.UUID `json:"ID"`
+	EquipmentID uuid.UUID `json:"EquipmentID"`
+	PerformedAt time.Time `json:"PerformedAt"`
+	Description string    `json:"Description"`
+	PerformedBy string    `json:"PerformedBy"`
+	Cost        float64   `json:"Cost"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+}
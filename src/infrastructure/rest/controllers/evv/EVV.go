@@ -0,0 +1,308 @@
+package evv
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	evvUseCase "caregiver/src/application/usecases/evv"
+	domainErrors "caregiver/src/domain/errors"
+	domainEVV "caregiver/src/domain/evv"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IEVVController interface {
+	CreateAggregator(ctx *gin.Context)
+	GetAggregators(ctx *gin.Context)
+	GetAggregatorByID(ctx *gin.Context)
+	UpdateAggregator(ctx *gin.Context)
+	DeleteAggregator(ctx *gin.Context)
+	GetSubmissions(ctx *gin.Context)
+	GetSubmission(ctx *gin.Context)
+	Enqueue(ctx *gin.Context)
+	Resubmit(ctx *gin.Context)
+	Sweep(ctx *gin.Context)
+}
+
+type Controller struct {
+	evvUseCase evvUseCase.IEVVUseCase
+	Logger     *logger.Logger
+}
+
+func NewEVVController(evvUseCase evvUseCase.IEVVUseCase, loggerInstance *logger.Logger) IEVVController {
+	return &Controller{evvUseCase: evvUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateAggregator(ctx *gin.Context) {
+	c.Logger.Info("Creating new EVV aggregator")
+	var request CreateAggregatorRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new EVV aggregator", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	active := true
+	if request.Active != nil {
+		active = *request.Active
+	}
+	created, err := c.evvUseCase.CreateAggregator(&domainEVV.Aggregator{
+		Name:      request.Name,
+		State:     request.State,
+		Payer:     request.Payer,
+		Adapter:   request.Adapter,
+		Endpoint:  request.Endpoint,
+		APIKeyEnv: request.APIKeyEnv,
+		Active:    active,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating EVV aggregator", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("EVV aggregator created successfully", zap.String("aggregatorID", created.ID.String()))
+	ctx.JSON(http.StatusOK, aggregatorToResponseMapper(created))
+}
+
+func (c *Controller) GetAggregators(ctx *gin.Context) {
+	aggregators, err := c.evvUseCase.GetAggregators()
+	if err != nil {
+		c.Logger.Error("Error getting EVV aggregators", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]AggregatorResponse, len(*aggregators))
+	for i := range *aggregators {
+		res[i] = *aggregatorToResponseMapper(&(*aggregators)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetAggregatorByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "aggregator")
+	if !ok {
+		return
+	}
+	aggregator, err := c.evvUseCase.GetAggregatorByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting EVV aggregator by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, aggregatorToResponseMapper(aggregator))
+}
+
+func (c *Controller) UpdateAggregator(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "aggregator")
+	if !ok {
+		return
+	}
+	var request UpdateAggregatorRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for EVV aggregator update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.State != nil {
+		updates["state"] = *request.State
+	}
+	if request.Payer != nil {
+		updates["payer"] = *request.Payer
+	}
+	if request.Adapter != nil {
+		updates["adapter"] = *request.Adapter
+	}
+	if request.Endpoint != nil {
+		updates["endpoint"] = *request.Endpoint
+	}
+	if request.APIKeyEnv != nil {
+		updates["api_key_env"] = *request.APIKeyEnv
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updated, err := c.evvUseCase.UpdateAggregator(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating EVV aggregator", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("EVV aggregator updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, aggregatorToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteAggregator(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "aggregator")
+	if !ok {
+		return
+	}
+	if err := c.evvUseCase.DeleteAggregator(id); err != nil {
+		c.Logger.Error("Error deleting EVV aggregator", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("EVV aggregator deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetSubmissions lists submissions, optionally narrowed by "status" (for
+// example "rejected" for the resubmission queue) or "scheduleID".
+func (c *Controller) GetSubmissions(ctx *gin.Context) {
+	filter := domainEVV.SubmissionFilter{Status: ctx.Query("status")}
+	if scheduleStr := ctx.Query("scheduleID"); scheduleStr != "" {
+		parsed, err := uuid.Parse(scheduleStr)
+		if err != nil {
+			c.Logger.Error("Invalid schedule ID for EVV submissions", zap.Error(err), zap.String("scheduleID", scheduleStr))
+			appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		filter.ScheduleID = &parsed
+	}
+	submissions, err := c.evvUseCase.GetSubmissions(filter)
+	if err != nil {
+		c.Logger.Error("Error getting EVV submissions", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]SubmissionResponse, len(*submissions))
+	for i := range *submissions {
+		res[i] = *submissionToResponseMapper(&(*submissions)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetSubmission returns one submission with every delivery attempt.
+func (c *Controller) GetSubmission(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "submission")
+	if !ok {
+		return
+	}
+	submission, attempts, err := c.evvUseCase.GetSubmission(id)
+	if err != nil {
+		c.Logger.Error("Error getting EVV submission", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := submissionToResponseMapper(submission)
+	res.History = make([]AttemptResponse, len(*attempts))
+	for i, a := range *attempts {
+		res.History[i] = AttemptResponse{
+			ID:           a.ID,
+			AggregatorID: a.AggregatorID,
+			AttemptedAt:  a.AttemptedAt,
+			Outcome:      a.Outcome,
+			Code:         a.Code,
+			Message:      a.Message,
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Enqueue queues a completed visit, or a corrected one that was rejected.
+func (c *Controller) Enqueue(ctx *gin.Context) {
+	var request EnqueueRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for EVV submission", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	submission, err := c.evvUseCase.Enqueue(request.ScheduleID)
+	if err != nil {
+		c.Logger.Error("Error queueing EVV submission", zap.Error(err), zap.String("scheduleID", request.ScheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, submissionToResponseMapper(submission))
+}
+
+func (c *Controller) Resubmit(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "submission")
+	if !ok {
+		return
+	}
+	submission, err := c.evvUseCase.Resubmit(id)
+	if err != nil {
+		c.Logger.Error("Error resubmitting EVV submission", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("EVV submission queued for resubmission", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, submissionToResponseMapper(submission))
+}
+
+// Sweep sends due submissions immediately. The server also runs the worker on
+// its own interval; this is for external schedulers.
+func (c *Controller) Sweep(ctx *gin.Context) {
+	attempted, err := c.evvUseCase.Sweep(time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error sweeping EVV submissions", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SweepResponse{Attempted: attempted})
+}
+
+func (c *Controller) parseID(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func aggregatorToResponseMapper(a *domainEVV.Aggregator) *AggregatorResponse {
+	return &AggregatorResponse{
+		ID:        a.ID,
+		Name:      a.Name,
+		State:     a.State,
+		Payer:     a.Payer,
+		Adapter:   a.Adapter,
+		Endpoint:  a.Endpoint,
+		APIKeyEnv: a.APIKeyEnv,
+		Active:    a.Active,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+func submissionToResponseMapper(s *domainEVV.Submission) *SubmissionResponse {
+	return &SubmissionResponse{
+		ID:              s.ID,
+		ScheduleID:      s.ScheduleID,
+		AggregatorID:    s.AggregatorID,
+		Status:          s.Status,
+		Attempts:        s.Attempts,
+		ExternalID:      s.ExternalID,
+		RejectionCode:   s.RejectionCode,
+		RejectionReason: s.RejectionReason,
+		NextAttemptAt:   s.NextAttemptAt,
+		LastAttemptAt:   s.LastAttemptAt,
+		AcceptedAt:      s.AcceptedAt,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
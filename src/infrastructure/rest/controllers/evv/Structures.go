@@ -0,0 +1,74 @@
+package evv
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateAggregatorRequest struct {
+	Name      string `json:"Name" binding:"required"`
+	State     string `json:"State" binding:"required"`
+	Payer     string `json:"Payer"`
+	Adapter   string `json:"Adapter" binding:"required"`
+	Endpoint  string `json:"Endpoint" binding:"required"`
+	APIKeyEnv string `json:"APIKeyEnv"`
+	Active    *bool  `json:"Active"`
+}
+
+type UpdateAggregatorRequest struct {
+	Name      *string `json:"Name"`
+	State     *string `json:"State"`
+	Payer     *string `json:"Payer"`
+	Adapter   *string `json:"Adapter"`
+	Endpoint  *string `json:"Endpoint"`
+	APIKeyEnv *string `json:"APIKeyEnv"`
+	Active    *bool   `json:"Active"`
+}
+
+type AggregatorResponse struct {
+	ID        uuid.UUID `json:"ID"`
+	Name      string    `json:"Name"`
+	State     string    `json:"State"`
+	Payer     string    `json:"Payer"`
+	Adapter   string    `json:"Adapter"`
+	Endpoint  string    `json:"Endpoint"`
+	APIKeyEnv string    `json:"APIKeyEnv"`
+	Active    bool      `json:"Active"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+type EnqueueRequest struct {
+	ScheduleID uuid.UUID `json:"ScheduleID" binding:"required"`
+}
+
+type SubmissionResponse struct {
+	ID              uuid.UUID         `json:"ID"`
+	ScheduleID      uuid.UUID         `json:"ScheduleID"`
+	AggregatorID    *uuid.UUID        `json:"AggregatorID"`
+	Status          string            `json:"Status"`
+	Attempts        int               `json:"Attempts"`
+	ExternalID      string            `json:"ExternalID"`
+	RejectionCode   string            `json:"RejectionCode"`
+	RejectionReason string            `json:"RejectionReason"`
+	NextAttemptAt   *time.Time        `json:"NextAttemptAt"`
+	LastAttemptAt   *time.Time        `json:"LastAttemptAt"`
+	AcceptedAt      *time.Time        `json:"AcceptedAt"`
+	CreatedAt       time.Time         `json:"CreatedAt"`
+	UpdatedAt       time.Time         `json:"UpdatedAt"`
+	History         []AttemptResponse `json:"History,omitempty"`
+}
+
+type AttemptResponse struct {
+	ID           uuid.UUID  `json:"ID"`
+	AggregatorID *uuid.UUID `json:"AggregatorID"`
+	AttemptedAt  time.Time  `json:"AttemptedAt"`
+	Outcome      string     `json:"Outcome"`
+	Code         string     `json:"Code"`
+	Message      string     `json:"Message"`
+}
+
+type SweepResponse struct {
+	Attempted int `json:"Attempted"`
+}
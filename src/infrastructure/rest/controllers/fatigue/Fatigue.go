@@ -0,0 +1,243 @@
+package fatigue
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	fatigueUseCase "caregiver/src/application/usecases/fatigue"
+	domainErrors "caregiver/src/domain/errors"
+	domainFatigue "caregiver/src/domain/fatigue"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultReportDays = 14
+
+type IFatigueController interface {
+	CreateRule(ctx *gin.Context)
+	GetRules(ctx *gin.Context)
+	GetRuleByID(ctx *gin.Context)
+	UpdateRule(ctx *gin.Context)
+	DeleteRule(ctx *gin.Context)
+	GetCaregiverReport(ctx *gin.Context)
+}
+
+type Controller struct {
+	fatigueUseCase fatigueUseCase.IFatigueUseCase
+	Logger         *logger.Logger
+}
+
+func NewFatigueController(fatigueUseCase fatigueUseCase.IFatigueUseCase, loggerInstance *logger.Logger) IFatigueController {
+	return &Controller{fatigueUseCase: fatigueUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateRule(ctx *gin.Context) {
+	c.Logger.Info("Creating new fatigue rule")
+	var request CreateRuleRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new fatigue rule", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	created, err := c.fatigueUseCase.CreateRule(&domainFatigue.Rule{
+		Name:      request.Name,
+		Type:      request.Type,
+		Threshold: request.Threshold,
+		Mode:      request.Mode,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating fatigue rule", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.Logger.Info("Fatigue rule created successfully", zap.String("ruleID", created.ID.String()))
+	ctx.JSON(http.StatusOK, ruleToResponseMapper(created))
+}
+
+func (c *Controller) GetRules(ctx *gin.Context) {
+	c.Logger.Info("Getting all fatigue rules")
+	rules, err := c.fatigueUseCase.GetRules()
+	if err != nil {
+		c.Logger.Error("Error getting fatigue rules", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]RuleResponse, len(*rules))
+	for i := range *rules {
+		res[i] = *ruleToResponseMapper(&(*rules)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetRuleByID(ctx *gin.Context) {
+	ruleID, ok := c.parseRuleID(ctx)
+	if !ok {
+		return
+	}
+	rule, err := c.fatigueUseCase.GetRuleByID(ruleID)
+	if err != nil {
+		c.Logger.Error("Error getting fatigue rule by ID", zap.Error(err), zap.String("id", ruleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, ruleToResponseMapper(rule))
+}
+
+func (c *Controller) UpdateRule(ctx *gin.Context) {
+	ruleID, ok := c.parseRuleID(ctx)
+	if !ok {
+		return
+	}
+
+	var request UpdateRuleRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for fatigue rule update", zap.Error(err), zap.String("id", ruleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Type != nil {
+		updates["type"] = *request.Type
+	}
+	if request.Threshold != nil {
+		updates["threshold"] = *request.Threshold
+	}
+	if request.Mode != nil {
+		updates["mode"] = *request.Mode
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", ruleID.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updated, err := c.fatigueUseCase.UpdateRule(ruleID, updates)
+	if err != nil {
+		c.Logger.Error("Error updating fatigue rule", zap.Error(err), zap.String("id", ruleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Fatigue rule updated successfully", zap.String("id", ruleID.String()))
+	ctx.JSON(http.StatusOK, ruleToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteRule(ctx *gin.Context) {
+	ruleID, ok := c.parseRuleID(ctx)
+	if !ok {
+		return
+	}
+	if err := c.fatigueUseCase.DeleteRule(ruleID); err != nil {
+		c.Logger.Error("Error deleting fatigue rule", zap.Error(err), zap.String("id", ruleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Fatigue rule deleted successfully", zap.String("id", ruleID.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetCaregiverReport returns the caregiver's daily hours, streaks and rule
+// violations between the optional "from" and "to" query parameters (RFC3339,
+// defaulting to two weeks either side of today).
+func (c *Controller) GetCaregiverReport(ctx *gin.Context) {
+	caregiverUserID, err := uuid.Parse(ctx.Param("userID"))
+	if err != nil {
+		c.Logger.Error("Invalid caregiver ID parameter", zap.Error(err), zap.String("userID", ctx.Param("userID")))
+		appError := domainErrors.NewAppError(errors.New("caregiver id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	today := domainFatigue.Day(time.Now())
+	from, to := today.AddDate(0, 0, -defaultReportDays), today.AddDate(0, 0, defaultReportDays)
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.Logger.Error("Invalid date for fatigue report", zap.Error(err), zap.String(param.name, value))
+			appError := domainErrors.NewAppError(errors.New(param.name+" must be RFC3339"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		*param.target = parsed.UTC()
+	}
+
+	report, err := c.fatigueUseCase.Report(caregiverUserID, from, to)
+	if err != nil {
+		c.Logger.Error("Error building fatigue report", zap.Error(err), zap.String("userID", caregiverUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res := ReportResponse{
+		CaregiverUserID: report.CaregiverUserID,
+		From:            report.From,
+		To:              report.To,
+		TotalHours:      report.TotalHours,
+		LongestStreak:   report.LongestStreak,
+		Days:            make([]DayLoadResponse, len(report.Days)),
+		Violations:      make([]ViolationResponse, len(report.Violations)),
+	}
+	for i, day := range report.Days {
+		res.Days[i] = DayLoadResponse{Date: day.Date, Hours: day.Hours, ScheduleIDs: day.ScheduleIDs}
+	}
+	for i, v := range report.Violations {
+		res.Violations[i] = ViolationResponse{
+			RuleID:    v.RuleID,
+			RuleName:  v.RuleName,
+			Type:      v.Type,
+			Mode:      v.Mode,
+			Date:      v.Date,
+			Value:     v.Value,
+			Threshold: v.Threshold,
+			Message:   v.Message,
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseRuleID(ctx *gin.Context) (uuid.UUID, bool) {
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid fatigue rule ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("rule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return ruleID, true
+}
+
+func ruleToResponseMapper(r *domainFatigue.Rule) *RuleResponse {
+	return &RuleResponse{
+		ID:        r.ID,
+		Name:      r.Name,
+		Type:      r.Type,
+		Threshold: r.Threshold,
+		Mode:      r.Mode,
+		Active:    r.Active,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package fatigue
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateRuleRequest struct {
+	Name      string  `json:"Name" binding:"required"`
+	Type      string  `json:"Type" binding:"required"`
+	Threshold float64 `json:"Threshold" binding:"required"`
+	Mode      string  `json:"Mode"`
+}
+
+type UpdateRuleRequest struct {
+	Name      *string  `json:"Name"`
+	Type      *string  `json:"Type"`
+	Threshold *float64 `json:"Threshold"`
+	Mode      *string  `json:"Mode"`
+	Active    *bool    `json:"Active"`
+}
+
+type RuleResponse struct {
+	ID        uuid.UUID `json:"ID"`
+	Name      string    `json:"Name"`
+	Type      string    `json:"Type"`
+	Threshold float64   `json:"Threshold"`
+	Mode      string    `json:"Mode"`
+	Active    bool      `json:"Active"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+type DayLoadResponse struct {
+	Date        time.Time   `json:"Date"`
+	Hours       float64     `json:"Hours"`
+	ScheduleIDs []uuid.UUID `json:"ScheduleIDs"`
+}
+
+type ViolationResponse struct {
+	RuleID    uuid.UUID `json:"RuleID"`
+	RuleName  string    `json:"RuleName"`
+	Type      string    `json:"Type"`
+	Mode      string    `json:"Mode"`
+	Date      time.Time `json:"Date"`
+	Value     float64   `json:"Value"`
+	Threshold float64   `json:"Threshold"`
+	Message   string    `json:"Message"`
+}
+
+type ReportResponse struct {
+	CaregiverUserID uuid.UUID           `json:"CaregiverUserID"`
+	From            time.Time           `json:"From"`
+	To              time.Time           `json:"To"`
+	TotalHours      float64             `json:"TotalHours"`
+	LongestStreak   int                 `json:"LongestStreak"`
+	Days            []DayLoadResponse   `json:"Days"`
+	Violations      []ViolationResponse `json:"Violations"`
+}
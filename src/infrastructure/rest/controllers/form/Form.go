@@ -0,0 +1,312 @@
+package form
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	formUseCase "caregiver/src/application/usecases/form"
+	domainErrors "caregiver/src/domain/errors"
+	domainForm "caregiver/src/domain/form"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IFormController interface {
+	GetForms(ctx *gin.Context)
+	CreateForm(ctx *gin.Context)
+	GetForm(ctx *gin.Context)
+	PublishVersion(ctx *gin.Context)
+	RetireForm(ctx *gin.Context)
+	GetVersions(ctx *gin.Context)
+	Submit(ctx *gin.Context)
+	GetSubmissions(ctx *gin.Context)
+	GetSubmission(ctx *gin.Context)
+	GetScoreHistory(ctx *gin.Context)
+}
+
+type Controller struct {
+	formUseCase formUseCase.IFormUseCase
+	Logger      *logger.Logger
+}
+
+func NewFormController(formUseCase formUseCase.IFormUseCase, loggerInstance *logger.Logger) IFormController {
+	return &Controller{formUseCase: formUseCase, Logger: loggerInstance}
+}
+
+// GetForms lists the latest version of every form.
+func (c *Controller) GetForms(ctx *gin.Context) {
+	forms, err := c.formUseCase.GetAll()
+	if err != nil {
+		c.Logger.Error("Error getting forms", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, definitionsToResponse(forms))
+}
+
+func (c *Controller) CreateForm(ctx *gin.Context) {
+	var request FormRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new form", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	definition, err := c.formUseCase.Create(definitionFromRequest(&request))
+	if err != nil {
+		c.Logger.Error("Error creating form", zap.Error(err), zap.String("key", request.Key))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Form created successfully", zap.String("key", definition.Key))
+	ctx.JSON(http.StatusCreated, definitionToResponseMapper(definition))
+}
+
+// GetForm returns the latest version of a form, or ?version=N.
+func (c *Controller) GetForm(ctx *gin.Context) {
+	key := ctx.Param("key")
+	var definition *domainForm.Definition
+	var err error
+	if versionStr := ctx.Query("version"); versionStr != "" {
+		version, convErr := strconv.Atoi(versionStr)
+		if convErr != nil || version < 1 {
+			appError := domainErrors.NewAppError(errors.New("version must be a positive integer"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		definition, err = c.formUseCase.GetVersion(key, version)
+	} else {
+		definition, err = c.formUseCase.GetLatest(key)
+	}
+	if err != nil {
+		c.Logger.Error("Error getting form", zap.Error(err), zap.String("key", key))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, definitionToResponseMapper(definition))
+}
+
+// PublishVersion stores the request as the form's next version.
+func (c *Controller) PublishVersion(ctx *gin.Context) {
+	key := ctx.Param("key")
+	var request FormRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for form version", zap.Error(err), zap.String("key", key))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	definition, err := c.formUseCase.Publish(key, definitionFromRequest(&request))
+	if err != nil {
+		c.Logger.Error("Error publishing form version", zap.Error(err), zap.String("key", key))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Form version published", zap.String("key", key), zap.Int("version", definition.Version))
+	ctx.JSON(http.StatusOK, definitionToResponseMapper(definition))
+}
+
+func (c *Controller) RetireForm(ctx *gin.Context) {
+	key := ctx.Param("key")
+	if err := c.formUseCase.Retire(key); err != nil {
+		c.Logger.Error("Error retiring form", zap.Error(err), zap.String("key", key))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "form retired successfully"})
+}
+
+func (c *Controller) GetVersions(ctx *gin.Context) {
+	key := ctx.Param("key")
+	versions, err := c.formUseCase.GetVersions(key)
+	if err != nil {
+		c.Logger.Error("Error getting form versions", zap.Error(err), zap.String("key", key))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, definitionsToResponse(versions))
+}
+
+func (c *Controller) Submit(ctx *gin.Context) {
+	key := ctx.Param("key")
+	var request SubmissionRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for form submission", zap.Error(err), zap.String("key", key))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	submission, err := c.formUseCase.Submit(key, &domainForm.Submission{
+		ClientUserID:      request.ClientUserID,
+		SubmittedByUserID: request.SubmittedByUserID,
+		Answers:           request.Answers,
+	})
+	if err != nil {
+		c.Logger.Error("Error submitting form", zap.Error(err), zap.String("key", key))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Form submitted", zap.String("key", key), zap.String("submissionID", submission.ID.String()))
+	ctx.JSON(http.StatusCreated, submissionToResponseMapper(submission))
+}
+
+// GetSubmissions lists a form's submissions, optionally for ?clientUserID=.
+func (c *Controller) GetSubmissions(ctx *gin.Context) {
+	key := ctx.Param("key")
+	var clientUserID *uuid.UUID
+	if clientStr := ctx.Query("clientUserID"); clientStr != "" {
+		parsed, err := uuid.Parse(clientStr)
+		if err != nil {
+			appError := domainErrors.NewAppError(errors.New("clientUserID is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		clientUserID = &parsed
+	}
+	submissions, err := c.formUseCase.GetSubmissions(key, clientUserID)
+	if err != nil {
+		c.Logger.Error("Error getting form submissions", zap.Error(err), zap.String("key", key))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]SubmissionResponse, len(*submissions))
+	for i := range *submissions {
+		res[i] = *submissionToResponseMapper(&(*submissions)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetSubmission(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("submission id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	submission, err := c.formUseCase.GetSubmission(id)
+	if err != nil {
+		c.Logger.Error("Error getting form submission", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, submissionToResponseMapper(submission))
+}
+
+// GetScoreHistory returns ?clientUserID='s scores for the form over time.
+func (c *Controller) GetScoreHistory(ctx *gin.Context) {
+	key := ctx.Param("key")
+	clientUserID, err := uuid.Parse(ctx.Query("clientUserID"))
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("clientUserID query parameter is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	history, err := c.formUseCase.ScoreHistory(key, clientUserID)
+	if err != nil {
+		c.Logger.Error("Error getting score history", zap.Error(err), zap.String("key", key))
+		_ = ctx.Error(err)
+		return
+	}
+	res := ScoreHistoryResponse{
+		FormKey:      history.FormKey,
+		ClientUserID: history.ClientUserID,
+		Points:       make([]ScorePointResponse, len(history.Points)),
+		NextDueAt:    history.NextDueAt,
+	}
+	for i, point := range history.Points {
+		res.Points[i] = ScorePointResponse{
+			SubmissionID: point.SubmissionID,
+			FormVersion:  point.FormVersion,
+			Score:        point.Score,
+			SubmittedAt:  point.SubmittedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func definitionFromRequest(request *FormRequest) *domainForm.Definition {
+	fields := make([]domainForm.Field, len(request.Fields))
+	for i, f := range request.Fields {
+		options := make([]domainForm.Option, len(f.Options))
+		for j, o := range f.Options {
+			options[j] = domainForm.Option{Value: o.Value, Label: o.Label, Score: o.Score}
+		}
+		fields[i] = domainForm.Field{
+			Key:      f.Key,
+			Label:    f.Label,
+			Type:     f.Type,
+			Required: f.Required,
+			Options:  options,
+			Min:      f.Min,
+			Max:      f.Max,
+			Weight:   f.Weight,
+		}
+	}
+	return &domainForm.Definition{
+		Key:                      request.Key,
+		Name:                     request.Name,
+		Purpose:                  request.Purpose,
+		ReassessmentIntervalDays: request.ReassessmentIntervalDays,
+		Fields:                   fields,
+		CreatedByUserID:          request.CreatedByUserID,
+	}
+}
+
+func definitionToResponseMapper(d *domainForm.Definition) *FormResponse {
+	fields := make([]FieldRequest, len(d.Fields))
+	for i, f := range d.Fields {
+		options := make([]OptionRequest, len(f.Options))
+		for j, o := range f.Options {
+			options[j] = OptionRequest{Value: o.Value, Label: o.Label, Score: o.Score}
+		}
+		fields[i] = FieldRequest{
+			Key:      f.Key,
+			Label:    f.Label,
+			Type:     f.Type,
+			Required: f.Required,
+			Options:  options,
+			Min:      f.Min,
+			Max:      f.Max,
+			Weight:   f.Weight,
+		}
+	}
+	return &FormResponse{
+		ID:                       d.ID,
+		Key:                      d.Key,
+		Version:                  d.Version,
+		Name:                     d.Name,
+		Purpose:                  d.Purpose,
+		ReassessmentIntervalDays: d.ReassessmentIntervalDays,
+		Fields:                   fields,
+		Active:                   d.Active,
+		CreatedByUserID:          d.CreatedByUserID,
+		CreatedAt:                d.CreatedAt,
+	}
+}
+
+func definitionsToResponse(definitions *[]domainForm.Definition) []FormResponse {
+	res := make([]FormResponse, len(*definitions))
+	for i := range *definitions {
+		res[i] = *definitionToResponseMapper(&(*definitions)[i])
+	}
+	return res
+}
+
+func submissionToResponseMapper(s *domainForm.Submission) *SubmissionResponse {
+	return &SubmissionResponse{
+		ID:                s.ID,
+		FormKey:           s.FormKey,
+		FormVersion:       s.FormVersion,
+		ClientUserID:      s.ClientUserID,
+		SubmittedByUserID: s.SubmittedByUserID,
+		Answers:           s.Answers,
+		FieldScores:       s.FieldScores,
+		Score:             s.Score,
+		SubmittedAt:       s.SubmittedAt,
+	}
+}
@@ -0,0 +1,80 @@
+package form
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OptionRequest struct {
+	Value string  `json:"Value" binding:"required"`
+	Label string  `json:"Label"`
+	Score float64 `json:"Score"`
+}
+
+type FieldRequest struct {
+	Key      string          `json:"Key" binding:"required"`
+	Label    string          `json:"Label" binding:"required"`
+	Type     string          `json:"Type" binding:"required"`
+	Required bool            `json:"Required"`
+	Options  []OptionRequest `json:"Options"`
+	Min      *float64        `json:"Min"`
+	Max      *float64        `json:"Max"`
+	Weight   float64         `json:"Weight"`
+}
+
+// FormRequest defines a form or, on PUT, its next version. Key is taken from
+// the path when publishing.
+type FormRequest struct {
+	Key                      string         `json:"Key"`
+	Name                     string         `json:"Name" binding:"required"`
+	Purpose                  string         `json:"Purpose" binding:"required"`
+	ReassessmentIntervalDays int            `json:"ReassessmentIntervalDays"`
+	Fields                   []FieldRequest `json:"Fields" binding:"required"`
+	CreatedByUserID          uuid.UUID      `json:"CreatedByUserID" binding:"required"`
+}
+
+type FormResponse struct {
+	ID                       uuid.UUID      `json:"ID"`
+	Key                      string         `json:"Key"`
+	Version                  int            `json:"Version"`
+	Name                     string         `json:"Name"`
+	Purpose                  string         `json:"Purpose"`
+	ReassessmentIntervalDays int            `json:"ReassessmentIntervalDays"`
+	Fields                   []FieldRequest `json:"Fields"`
+	Active                   bool           `json:"Active"`
+	CreatedByUserID          uuid.UUID      `json:"CreatedByUserID"`
+	CreatedAt                time.Time      `json:"CreatedAt"`
+}
+
+type SubmissionRequest struct {
+	ClientUserID      uuid.UUID              `json:"ClientUserID" binding:"required"`
+	SubmittedByUserID uuid.UUID              `json:"SubmittedByUserID" binding:"required"`
+	Answers           map[string]interface{} `json:"Answers" binding:"required"`
+}
+
+type SubmissionResponse struct {
+	ID                uuid.UUID              `json:"ID"`
+	FormKey           string                 `json:"FormKey"`
+	FormVersion       int                    `json:"FormVersion"`
+	ClientUserID      uuid.UUID              `json:"ClientUserID"`
+	SubmittedByUserID uuid.UUID              `json:"SubmittedByUserID"`
+	Answers           map[string]interface{} `json:"Answers"`
+	FieldScores       map[string]float64     `json:"FieldScores"`
+	Score             float64                `json:"Score"`
+	SubmittedAt       time.Time              `json:"SubmittedAt"`
+}
+
+type ScorePointResponse struct {
+	SubmissionID uuid.UUID `json:"SubmissionID"`
+	FormVersion  int       `json:"FormVersion"`
+	Score        float64   `json:"Score"`
+	SubmittedAt  time.Time `json:"SubmittedAt"`
+}
+
+type ScoreHistoryResponse struct {
+	FormKey      string               `json:"FormKey"`
+	ClientUserID uuid.UUID            `json:"ClientUserID"`
+	Points       []ScorePointResponse `json:"Points"`
+	NextDueAt    *time.Time           `json:"NextDueAt"`
+}
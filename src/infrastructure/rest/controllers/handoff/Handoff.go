@@ -0,0 +1,168 @@
+package handoff
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	handoffUseCase "caregiver/src/application/usecases/handoff"
+	domainErrors "caregiver/src/domain/errors"
+	domainHandoff "caregiver/src/domain/handoff"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IHandoffController interface {
+	GetNextVisit(ctx *gin.Context)
+	GetOutgoing(ctx *gin.Context)
+	LeaveHandoff(ctx *gin.Context)
+	GetIncoming(ctx *gin.Context)
+	Acknowledge(ctx *gin.Context)
+}
+
+type Controller struct {
+	handoffUseCase handoffUseCase.IHandoffUseCase
+	Logger         *logger.Logger
+}
+
+func NewHandoffController(handoffUseCase handoffUseCase.IHandoffUseCase, loggerInstance *logger.Logger) IHandoffController {
+	return &Controller{handoffUseCase: handoffUseCase, Logger: loggerInstance}
+}
+
+// GetNextVisit tells the outgoing caregiver whether the client's next visit
+// is by someone else and so needs handoff notes.
+func (c *Controller) GetNextVisit(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	next, err := c.handoffUseCase.GetNextVisit(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting next visit for handoff", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, NextVisitResponse{
+		ScheduleID:     next.ID,
+		AssignedUserID: next.AssignedUserID,
+		ServiceName:    next.ServiceName,
+		From:           next.ScheduledSlot.From,
+		To:             next.ScheduledSlot.To,
+	})
+}
+
+func (c *Controller) GetOutgoing(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	handoff, err := c.handoffUseCase.GetOutgoing(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting handoff", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toResponseMapper(handoff))
+}
+
+// LeaveHandoff saves the outgoing caregiver's notes; leaving them again
+// replaces them until the next caregiver has acknowledged them.
+func (c *Controller) LeaveHandoff(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request LeaveHandoffRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for handoff", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	handoff, err := c.handoffUseCase.Leave(scheduleID, domainHandoff.Notes{
+		Summary:      request.Summary,
+		ClientMood:   request.ClientMood,
+		Concerns:     request.Concerns,
+		PendingTasks: request.PendingTasks,
+		Medications:  request.Medications,
+	}, controllers.CallerID(ctx))
+	if err != nil {
+		c.Logger.Error("Error leaving handoff", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Handoff left successfully", zap.String("id", handoff.ID.String()))
+	ctx.JSON(http.StatusOK, toResponseMapper(handoff))
+}
+
+func (c *Controller) GetIncoming(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	handoffs, err := c.handoffUseCase.GetIncoming(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting incoming handoffs", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toResponseList(handoffs))
+}
+
+func (c *Controller) Acknowledge(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	handoffs, err := c.handoffUseCase.Acknowledge(scheduleID, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error acknowledging handoffs", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Handoffs acknowledged", zap.String("scheduleID", scheduleID.String()))
+	ctx.JSON(http.StatusOK, toResponseList(handoffs))
+}
+
+func (c *Controller) parseID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func toResponseMapper(handoff *domainHandoff.Handoff) *HandoffResponse {
+	return &HandoffResponse{
+		ID:                  handoff.ID,
+		FromScheduleID:      handoff.FromScheduleID,
+		ToScheduleID:        handoff.ToScheduleID,
+		ClientUserID:        handoff.ClientUserID,
+		FromCaregiverUserID: handoff.FromCaregiverUserID,
+		ToCaregiverUserID:   handoff.ToCaregiverUserID,
+		Summary:             handoff.Notes.Summary,
+		ClientMood:          handoff.Notes.ClientMood,
+		Concerns:            handoff.Notes.Concerns,
+		PendingTasks:        handoff.Notes.PendingTasks,
+		Medications:         handoff.Notes.Medications,
+		AcknowledgedAt:      handoff.AcknowledgedAt,
+		AcknowledgedBy:      handoff.AcknowledgedBy,
+		CreatedAt:           handoff.CreatedAt,
+		UpdatedAt:           handoff.UpdatedAt,
+	}
+}
+
+func toResponseList(handoffs *[]domainHandoff.Handoff) []HandoffResponse {
+	res := make([]HandoffResponse, len(*handoffs))
+	for i := range *handoffs {
+		res[i] = *toResponseMapper(&(*handoffs)[i])
+	}
+	return res
+}
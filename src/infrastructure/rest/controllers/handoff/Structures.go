@@ -0,0 +1,42 @@
+package handoff
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LeaveHandoffRequest struct {
+	Summary      string   `json:"Summary" binding:"required"`
+	ClientMood   string   `json:"ClientMood"`
+	Concerns     string   `json:"Concerns"`
+	PendingTasks []string `json:"PendingTasks"`
+	Medications  string   `json:"Medications"`
+}
+
+type HandoffResponse struct {
+	ID                  uuid.UUID  `json:"ID"`
+	FromScheduleID      uuid.UUID  `json:"FromScheduleID"`
+	ToScheduleID        uuid.UUID  `json:"ToScheduleID"`
+	ClientUserID        uuid.UUID  `json:"ClientUserID"`
+	FromCaregiverUserID uuid.UUID  `json:"FromCaregiverUserID"`
+	ToCaregiverUserID   uuid.UUID  `json:"ToCaregiverUserID"`
+	Summary             string     `json:"Summary"`
+	ClientMood          string     `json:"ClientMood"`
+	Concerns            string     `json:"Concerns"`
+	PendingTasks        []string   `json:"PendingTasks"`
+	Medications         string     `json:"Medications"`
+	AcknowledgedAt      *time.Time `json:"AcknowledgedAt"`
+	AcknowledgedBy      *uuid.UUID `json:"AcknowledgedBy"`
+	CreatedAt           time.Time  `json:"CreatedAt"`
+	UpdatedAt           time.Time  `json:"UpdatedAt"`
+}
+
+// NextVisitResponse is the visit a handoff left now would be addressed to.
+type NextVisitResponse struct {
+	ScheduleID     uuid.UUID `json:"ScheduleID"`
+	AssignedUserID uuid.UUID `json:"AssignedUserID"`
+	ServiceName    string    `json:"ServiceName"`
+	From           time.Time `json:"From"`
+	To             time.Time `json:"To"`
+}
@@ -0,0 +1,250 @@
+package identity
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	identityUseCase "caregiver/src/application/usecases/identity"
+	domainErrors "caregiver/src/domain/errors"
+	domainIdentity "caregiver/src/domain/identity"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IIdentityController interface {
+	GetSettings(ctx *gin.Context)
+	SetSettings(ctx *gin.Context)
+	GetChallenge(ctx *gin.Context)
+	SetQuestion(ctx *gin.Context)
+	UploadPhoto(ctx *gin.Context)
+	GetPhoto(ctx *gin.Context)
+	GetStatus(ctx *gin.Context)
+	Verify(ctx *gin.Context)
+	GetVisitPhoto(ctx *gin.Context)
+}
+
+type Controller struct {
+	identityUseCase identityUseCase.IIdentityUseCase
+	Logger          *logger.Logger
+}
+
+func NewIdentityController(identityUseCase identityUseCase.IIdentityUseCase, loggerInstance *logger.Logger) IIdentityController {
+	return &Controller{identityUseCase: identityUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetSettings(ctx *gin.Context) {
+	settings, err := c.identityUseCase.GetSettings()
+	if err != nil {
+		c.Logger.Error("Error getting identity settings", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SettingsResponse{Required: settings.Required, UpdatedAt: settings.UpdatedAt})
+}
+
+func (c *Controller) SetSettings(ctx *gin.Context) {
+	var request SettingsRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for identity settings", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	settings, err := c.identityUseCase.SetSettings(*request.Required)
+	if err != nil {
+		c.Logger.Error("Error setting identity settings", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SettingsResponse{Required: settings.Required, UpdatedAt: settings.UpdatedAt})
+}
+
+func (c *Controller) GetChallenge(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "clientID", "client")
+	if !ok {
+		return
+	}
+	challenge, err := c.identityUseCase.GetChallenge(clientID)
+	if err != nil {
+		c.Logger.Error("Error getting identity challenge", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, challengeToResponseMapper(challenge))
+}
+
+func (c *Controller) SetQuestion(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "clientID", "client")
+	if !ok {
+		return
+	}
+	var request QuestionRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for security question", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	challenge, err := c.identityUseCase.SetQuestion(clientID, request.Question, request.Answer, controllers.CallerID(ctx))
+	if err != nil {
+		c.Logger.Error("Error setting security question", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Security question set", zap.String("clientID", clientID.String()))
+	ctx.JSON(http.StatusOK, challengeToResponseMapper(challenge))
+}
+
+// UploadPhoto takes a PNG or JPEG photo of the client as the multipart
+// "file" field.
+func (c *Controller) UploadPhoto(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "clientID", "client")
+	if !ok {
+		return
+	}
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		c.Logger.Error("Identity photo file is missing", zap.Error(err))
+		appError := domainErrors.NewAppError(errors.New("file is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Logger.Error("Error opening uploaded identity photo", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	defer file.Close()
+
+	challenge, err := c.identityUseCase.UploadPhoto(clientID, fileHeader.Header.Get("Content-Type"), fileHeader.Size, file, controllers.CallerID(ctx))
+	if err != nil {
+		c.Logger.Error("Error uploading identity photo", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Identity photo uploaded", zap.String("clientID", clientID.String()))
+	ctx.JSON(http.StatusOK, challengeToResponseMapper(challenge))
+}
+
+func (c *Controller) GetPhoto(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "clientID", "client")
+	if !ok {
+		return
+	}
+	challenge, content, err := c.identityUseCase.OpenPhoto(clientID)
+	if err != nil {
+		c.Logger.Error("Error opening identity photo", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	defer content.Close()
+	ctx.DataFromReader(http.StatusOK, -1, challenge.PhotoContentType, content, nil)
+}
+
+func (c *Controller) GetStatus(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	status, err := c.identityUseCase.GetStatus(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting identity status", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := StatusResponse{
+		Required:    status.Required,
+		FirstVisit:  status.FirstVisit,
+		Verified:    status.Verified,
+		Question:    status.Question,
+		HasPhoto:    status.HasPhoto,
+		FailedCount: status.FailedCount,
+		Checks:      make([]CheckResponse, len(status.Checks)),
+	}
+	for i := range status.Checks {
+		res.Checks[i] = *checkToResponseMapper(&status.Checks[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Verify records the caregiver's identity confirmation. A failed check is
+// still recorded and returned, with Passed false.
+func (c *Controller) Verify(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	var request VerifyRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for identity check", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	check, err := c.identityUseCase.Verify(scheduleID, request.Method, request.Answer, request.Matched, request.Note, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error recording identity check", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Identity check recorded", zap.String("scheduleID", scheduleID.String()), zap.Bool("passed", check.Passed))
+	ctx.JSON(http.StatusCreated, checkToResponseMapper(check))
+}
+
+func (c *Controller) GetVisitPhoto(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	challenge, content, err := c.identityUseCase.OpenVisitPhoto(scheduleID, controllers.CallerID(ctx))
+	if err != nil {
+		c.Logger.Error("Error opening identity photo", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	defer content.Close()
+	ctx.DataFromReader(http.StatusOK, -1, challenge.PhotoContentType, content, nil)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// challengeToResponseMapper leaves out the answer hash and the photo key.
+func challengeToResponseMapper(challenge *domainIdentity.Challenge) *ChallengeResponse {
+	return &ChallengeResponse{
+		ClientUserID: challenge.ClientUserID,
+		Question:     challenge.Question,
+		HasAnswer:    challenge.HasQuestion(),
+		HasPhoto:     challenge.HasPhoto(),
+		UpdatedBy:    challenge.UpdatedBy,
+		UpdatedAt:    challenge.UpdatedAt,
+	}
+}
+
+func checkToResponseMapper(check *domainIdentity.Check) *CheckResponse {
+	return &CheckResponse{
+		ID:              check.ID,
+		ScheduleID:      check.ScheduleID,
+		ClientUserID:    check.ClientUserID,
+		CaregiverUserID: check.CaregiverUserID,
+		Method:          check.Method,
+		Passed:          check.Passed,
+		Note:            check.Note,
+		CheckedAt:       check.CheckedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package identity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SettingsRequest struct {
+	Required *bool `json:"Required" binding:"required"`
+}
+
+type QuestionRequest struct {
+	Question string `json:"Question" binding:"required"`
+	Answer   string `json:"Answer" binding:"required"`
+}
+
+// VerifyRequest carries the client's Answer for a security question, or the
+// caregiver's Matched verdict for a photo.
+type VerifyRequest struct {
+	Method  string `json:"Method" binding:"required"`
+	Answer  string `json:"Answer"`
+	Matched bool   `json:"Matched"`
+	Note    string `json:"Note"`
+}
+
+type SettingsResponse struct {
+	Required  bool      `json:"Required"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+type ChallengeResponse struct {
+	ClientUserID uuid.UUID  `json:"ClientUserID"`
+	Question     string     `json:"Question"`
+	HasAnswer    bool       `json:"HasAnswer"`
+	HasPhoto     bool       `json:"HasPhoto"`
+	UpdatedBy    *uuid.UUID `json:"UpdatedBy"`
+	UpdatedAt    time.Time  `json:"UpdatedAt"`
+}
+
+type CheckResponse struct {
+	ID              uuid.UUID `json:"ID"`
+	ScheduleID      uuid.UUID `json:"ScheduleID"`
+	ClientUserID    uuid.UUID `json:"ClientUserID"`
+	CaregiverUserID uuid.UUID `json:"CaregiverUserID"`
+	Method          string    `json:"Method"`
+	Passed          bool      `json:"Passed"`
+	Note            string    `json:"Note"`
+	CheckedAt       time.Time `json:"CheckedAt"`
+}
+
+type StatusResponse struct {
+	Required    bool            `json:"Required"`
+	FirstVisit  bool            `json:"FirstVisit"`
+	Verified    bool            `json:"Verified"`
+	Question    string          `json:"Question"`
+	HasPhoto    bool            `json:"HasPhoto"`
+	FailedCount int             `json:"FailedCount"`
+	Checks      []CheckResponse `json:"Checks"`
+}
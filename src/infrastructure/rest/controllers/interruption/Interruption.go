@@ -0,0 +1,192 @@
+package interruption
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	interruptionUseCase "caregiver/src/application/usecases/interruption"
+	domainErrors "caregiver/src/domain/errors"
+	domainInterruption "caregiver/src/domain/interruption"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type IInterruptionController interface {
+	GetInterruptions(ctx *gin.Context)
+	StartInterruption(ctx *gin.Context)
+	ResumeVisit(ctx *gin.Context)
+	GetReport(ctx *gin.Context)
+}
+
+type Controller struct {
+	interruptionUseCase interruptionUseCase.IInterruptionUseCase
+	Logger              *logger.Logger
+}
+
+func NewInterruptionController(interruptionUseCase interruptionUseCase.IInterruptionUseCase, loggerInstance *logger.Logger) IInterruptionController {
+	return &Controller{interruptionUseCase: interruptionUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetInterruptions(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	interruptions, err := c.interruptionUseCase.GetBySchedule(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting interruptions", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]InterruptionResponse, len(*interruptions))
+	for i := range *interruptions {
+		res[i] = *toResponseMapper(&(*interruptions)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// StartInterruption pauses the visit clock, from StartedAt when the
+// caregiver logs it after the fact.
+func (c *Controller) StartInterruption(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	var request StartInterruptionRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for interruption", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	interruption := &domainSchedule.Interruption{
+		ScheduleID: scheduleID,
+		ReasonCode: request.ReasonCode,
+		Note:       request.Note,
+	}
+	if request.StartedAt != nil {
+		interruption.StartedAt = request.StartedAt.UTC()
+	}
+	created, err := c.interruptionUseCase.Start(interruption, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error starting interruption", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Visit interrupted", zap.String("scheduleID", scheduleID.String()), zap.String("id", created.ID.String()))
+	ctx.JSON(http.StatusCreated, toResponseMapper(created))
+}
+
+func (c *Controller) ResumeVisit(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	var request ResumeVisitRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := controllers.BindJSON(ctx, &request); err != nil {
+			c.Logger.Error("Error binding JSON for resuming visit", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+			appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+	}
+	var endedAt time.Time
+	if request.EndedAt != nil {
+		endedAt = request.EndedAt.UTC()
+	}
+	ended, err := c.interruptionUseCase.Resume(scheduleID, endedAt, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error resuming visit", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Visit resumed", zap.String("scheduleID", scheduleID.String()), zap.String("id", ended.ID.String()))
+	ctx.JSON(http.StatusOK, toResponseMapper(ended))
+}
+
+// GetReport counts the interruptions started between the "from" and "to"
+// days (YYYY-MM-DD, inclusive). It defaults to the last 30 days.
+func (c *Controller) GetReport(ctx *gin.Context) {
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var ok bool
+	if value := ctx.Query("to"); value != "" {
+		if to, ok = c.parseDate(ctx, "to", value); !ok {
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -30)
+	if value := ctx.Query("from"); value != "" {
+		if from, ok = c.parseDate(ctx, "from", value); !ok {
+			return
+		}
+	}
+	report, err := c.interruptionUseCase.Report(from, to.AddDate(0, 0, 1), now)
+	if err != nil {
+		c.Logger.Error("Error building interruption report", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, ReportResponse{
+		From:        report.From,
+		To:          report.To,
+		Total:       report.Total,
+		Minutes:     report.Minutes,
+		ByReason:    toCountResponses(report.ByReason),
+		ByClient:    toCountResponses(report.ByClient),
+		ByCaregiver: toCountResponses(report.ByCaregiver),
+	})
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func toResponseMapper(interruption *domainSchedule.Interruption) *InterruptionResponse {
+	return &InterruptionResponse{
+		ID:              interruption.ID,
+		ScheduleID:      interruption.ScheduleID,
+		ClientUserID:    interruption.ClientUserID,
+		CaregiverUserID: interruption.CaregiverUserID,
+		ReasonCode:      interruption.ReasonCode,
+		Note:            interruption.Note,
+		StartedAt:       interruption.StartedAt,
+		EndedAt:         interruption.EndedAt,
+		CreatedAt:       interruption.CreatedAt,
+	}
+}
+
+func toCountResponses(counts []domainInterruption.Count) []CountResponse {
+	res := make([]CountResponse, len(counts))
+	for i, count := range counts {
+		res[i] = CountResponse{Key: count.Key, Count: count.Count, Minutes: count.Minutes}
+	}
+	return res
+}
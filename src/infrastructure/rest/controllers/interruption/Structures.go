@@ -0,0 +1,45 @@
+package interruption
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StartInterruptionRequest struct {
+	ReasonCode string     `json:"ReasonCode" binding:"required"`
+	Note       string     `json:"Note"`
+	StartedAt  *time.Time `json:"StartedAt"`
+}
+
+type ResumeVisitRequest struct {
+	EndedAt *time.Time `json:"EndedAt"`
+}
+
+type InterruptionResponse struct {
+	ID              uuid.UUID  `json:"ID"`
+	ScheduleID      uuid.UUID  `json:"ScheduleID"`
+	ClientUserID    uuid.UUID  `json:"ClientUserID"`
+	CaregiverUserID uuid.UUID  `json:"CaregiverUserID"`
+	ReasonCode      string     `json:"ReasonCode"`
+	Note            string     `json:"Note"`
+	StartedAt       time.Time  `json:"StartedAt"`
+	EndedAt         *time.Time `json:"EndedAt"`
+	CreatedAt       time.Time  `json:"CreatedAt"`
+}
+
+type CountResponse struct {
+	Key     string `json:"Key"`
+	Count   int    `json:"Count"`
+	Minutes int    `json:"Minutes"`
+}
+
+type ReportResponse struct {
+	From        time.Time       `json:"From"`
+	To          time.Time       `json:"To"`
+	Total       int             `json:"Total"`
+	Minutes     int             `json:"Minutes"`
+	ByReason    []CountResponse `json:"ByReason"`
+	ByClient    []CountResponse `json:"ByClient"`
+	ByCaregiver []CountResponse `json:"ByCaregiver"`
+}
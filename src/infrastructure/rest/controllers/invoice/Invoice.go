@@ -0,0 +1,320 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	invoiceUseCase "caregiver/src/application/usecases/invoice"
+	domainErrors "caregiver/src/domain/errors"
+	domainInvoice "caregiver/src/domain/invoice"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type IInvoiceController interface {
+	CreateRateCard(ctx *gin.Context)
+	GetRateCards(ctx *gin.Context)
+	UpdateRateCard(ctx *gin.Context)
+	DeleteRateCard(ctx *gin.Context)
+	Generate(ctx *gin.Context)
+	GetInvoices(ctx *gin.Context)
+	GetInvoiceByID(ctx *gin.Context)
+	Download(ctx *gin.Context)
+}
+
+type Controller struct {
+	invoiceUseCase invoiceUseCase.IInvoiceUseCase
+	Logger         *logger.Logger
+}
+
+func NewInvoiceController(invoiceUseCase invoiceUseCase.IInvoiceUseCase, loggerInstance *logger.Logger) IInvoiceController {
+	return &Controller{invoiceUseCase: invoiceUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateRateCard(ctx *gin.Context) {
+	var request CreateRateCardRequest
+	if !c.bind(ctx, &request, "new rate card") {
+		return
+	}
+	card := &domainInvoice.RateCard{
+		ServiceName: request.ServiceName,
+		UnitMinutes: request.UnitMinutes,
+		UnitRate:    request.UnitRate,
+	}
+	var ok bool
+	if request.EffectiveFrom != "" {
+		if card.EffectiveFrom, ok = c.parseDate(ctx, "effective from", request.EffectiveFrom); !ok {
+			return
+		}
+	}
+	if request.EffectiveTo != "" {
+		effectiveTo, ok := c.parseDate(ctx, "effective to", request.EffectiveTo)
+		if !ok {
+			return
+		}
+		card.EffectiveTo = &effectiveTo
+	}
+	created, err := c.invoiceUseCase.CreateRateCard(card)
+	if err != nil {
+		c.Logger.Error("Error creating rate card", zap.Error(err), zap.String("serviceName", request.ServiceName))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rateCardToResponseMapper(created))
+}
+
+func (c *Controller) GetRateCards(ctx *gin.Context) {
+	cards, err := c.invoiceUseCase.GetRateCards()
+	if err != nil {
+		c.Logger.Error("Error getting rate cards", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]RateCardResponse, len(*cards))
+	for i := range *cards {
+		res[i] = *rateCardToResponseMapper(&(*cards)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) UpdateRateCard(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "rate card")
+	if !ok {
+		return
+	}
+	var request UpdateRateCardRequest
+	if !c.bind(ctx, &request, "rate card update") {
+		return
+	}
+	updates := make(map[string]interface{})
+	if request.ServiceName != nil {
+		updates["service_name"] = *request.ServiceName
+	}
+	if request.UnitMinutes != nil {
+		updates["unit_minutes"] = *request.UnitMinutes
+	}
+	if request.UnitRate != nil {
+		updates["unit_rate"] = *request.UnitRate
+	}
+	if request.EffectiveFrom != nil {
+		effectiveFrom, ok := c.parseDate(ctx, "effective from", *request.EffectiveFrom)
+		if !ok {
+			return
+		}
+		updates["effective_from"] = effectiveFrom
+	}
+	if request.EffectiveTo != nil {
+		updates["effective_to"] = nil
+		if *request.EffectiveTo != "" {
+			effectiveTo, ok := c.parseDate(ctx, "effective to", *request.EffectiveTo)
+			if !ok {
+				return
+			}
+			updates["effective_to"] = effectiveTo
+		}
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	updated, err := c.invoiceUseCase.UpdateRateCard(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating rate card", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rateCardToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteRateCard(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "rate card")
+	if !ok {
+		return
+	}
+	if err := c.invoiceUseCase.DeleteRateCard(id); err != nil {
+		c.Logger.Error("Error deleting rate card", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) Generate(ctx *gin.Context) {
+	var request GenerateRequest
+	if !c.bind(ctx, &request, "invoice generation") {
+		return
+	}
+	from, ok := c.parseDate(ctx, "from", request.From)
+	if !ok {
+		return
+	}
+	to, ok := c.parseDate(ctx, "to", request.To)
+	if !ok {
+		return
+	}
+	invoices, err := c.invoiceUseCase.Generate(from, to, request.ClientUserID, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error generating invoices", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Invoices generated successfully", zap.Int("invoices", len(*invoices)))
+	res := make([]InvoiceResponse, len(*invoices))
+	for i := range *invoices {
+		res[i] = *invoiceToResponseMapper(&(*invoices)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetInvoices lists invoices, optionally for one "clientID" and for periods
+// overlapping the "from" through "to" days (YYYY-MM-DD).
+func (c *Controller) GetInvoices(ctx *gin.Context) {
+	var filter domainInvoice.Filter
+	clientID, err := controllers.GetQueryUUID(ctx, "clientID")
+	if err != nil {
+		c.Logger.Error("Invalid clientID query parameter", zap.Error(err))
+		appError := domainErrors.NewAppError(errors.New("clientID is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	filter.ClientUserID = clientID
+	if value := ctx.Query("from"); value != "" {
+		from, ok := c.parseDate(ctx, "from", value)
+		if !ok {
+			return
+		}
+		filter.From = &from
+	}
+	if value := ctx.Query("to"); value != "" {
+		to, ok := c.parseDate(ctx, "to", value)
+		if !ok {
+			return
+		}
+		filter.To = &to
+	}
+	invoices, err := c.invoiceUseCase.GetInvoices(filter)
+	if err != nil {
+		c.Logger.Error("Error getting invoices", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]InvoiceResponse, len(*invoices))
+	for i := range *invoices {
+		res[i] = *invoiceToResponseMapper(&(*invoices)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetInvoiceByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "invoice")
+	if !ok {
+		return
+	}
+	invoice, err := c.invoiceUseCase.GetInvoiceByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting invoice", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, invoiceToResponseMapper(invoice))
+}
+
+// Download sends the invoice as a PDF, or as CSV with "format=csv".
+func (c *Controller) Download(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "invoice")
+	if !ok {
+		return
+	}
+	export, err := c.invoiceUseCase.Download(id, ctx.DefaultQuery("format", invoiceUseCase.FormatPDF))
+	if err != nil {
+		c.Logger.Error("Error downloading invoice", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName))
+	ctx.Data(http.StatusOK, export.ContentType, export.Data)
+}
+
+func (c *Controller) bind(ctx *gin.Context, request interface{}, name string) bool {
+	if err := controllers.BindJSON(ctx, request); err != nil {
+		c.Logger.Error("Error binding JSON for "+name, zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return false
+	}
+	return true
+}
+
+func (c *Controller) parseID(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func rateCardToResponseMapper(card *domainInvoice.RateCard) *RateCardResponse {
+	res := &RateCardResponse{
+		ID:            card.ID,
+		ServiceName:   card.ServiceName,
+		UnitMinutes:   card.UnitMinutes,
+		UnitRate:      card.UnitRate,
+		EffectiveFrom: card.EffectiveFrom.Format(dateLayout),
+		CreatedAt:     card.CreatedAt,
+		UpdatedAt:     card.UpdatedAt,
+	}
+	if card.EffectiveTo != nil {
+		effectiveTo := card.EffectiveTo.Format(dateLayout)
+		res.EffectiveTo = &effectiveTo
+	}
+	return res
+}
+
+func invoiceToResponseMapper(invoice *domainInvoice.Invoice) *InvoiceResponse {
+	res := &InvoiceResponse{
+		ID:           invoice.ID,
+		Number:       invoice.Number,
+		ClientUserID: invoice.ClientUserID,
+		ClientName:   invoice.ClientName,
+		PeriodStart:  invoice.PeriodStart.Format(dateLayout),
+		PeriodEnd:    invoice.PeriodEnd.Format(dateLayout),
+		Total:        invoice.Total,
+		IssuedAt:     invoice.IssuedAt,
+	}
+	for _, line := range invoice.Lines {
+		res.Lines = append(res.Lines, LineItemResponse{
+			ScheduleID:    line.ScheduleID,
+			ServiceDate:   line.ServiceDate.Format(dateLayout),
+			ServiceName:   line.ServiceName,
+			WorkedMinutes: line.WorkedMinutes,
+			Units:         line.Units,
+			UnitRate:      line.UnitRate,
+			Amount:        line.Amount,
+		})
+	}
+	return res
+}
@@ -0,0 +1,69 @@
+package invoice
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateRateCardRequest takes effective dates as YYYY-MM-DD. Without
+// EffectiveFrom the rate card applies to every past visit; without
+// EffectiveTo it stays in effect.
+type CreateRateCardRequest struct {
+	ServiceName   string  `json:"ServiceName" binding:"required"`
+	UnitMinutes   int     `json:"UnitMinutes" binding:"required"`
+	UnitRate      float64 `json:"UnitRate" binding:"required"`
+	EffectiveFrom string  `json:"EffectiveFrom"`
+	EffectiveTo   string  `json:"EffectiveTo"`
+}
+
+// UpdateRateCardRequest clears the end date when EffectiveTo is an empty
+// string.
+type UpdateRateCardRequest struct {
+	ServiceName   *string  `json:"ServiceName"`
+	UnitMinutes   *int     `json:"UnitMinutes"`
+	UnitRate      *float64 `json:"UnitRate"`
+	EffectiveFrom *string  `json:"EffectiveFrom"`
+	EffectiveTo   *string  `json:"EffectiveTo"`
+}
+
+type RateCardResponse struct {
+	ID            uuid.UUID `json:"ID"`
+	ServiceName   string    `json:"ServiceName"`
+	UnitMinutes   int       `json:"UnitMinutes"`
+	UnitRate      float64   `json:"UnitRate"`
+	EffectiveFrom string    `json:"EffectiveFrom"`
+	EffectiveTo   *string   `json:"EffectiveTo"`
+	CreatedAt     time.Time `json:"CreatedAt"`
+	UpdatedAt     time.Time `json:"UpdatedAt"`
+}
+
+// GenerateRequest takes the billing period as YYYY-MM-DD days, both
+// inclusive. Without ClientUserID every client is invoiced.
+type GenerateRequest struct {
+	From         string     `json:"From" binding:"required"`
+	To           string     `json:"To" binding:"required"`
+	ClientUserID *uuid.UUID `json:"ClientUserID"`
+}
+
+type LineItemResponse struct {
+	ScheduleID    uuid.UUID `json:"ScheduleID"`
+	ServiceDate   string    `json:"ServiceDate"`
+	ServiceName   string    `json:"ServiceName"`
+	WorkedMinutes int       `json:"WorkedMinutes"`
+	Units         int       `json:"Units"`
+	UnitRate      float64   `json:"UnitRate"`
+	Amount        float64   `json:"Amount"`
+}
+
+type InvoiceResponse struct {
+	ID           uuid.UUID          `json:"ID"`
+	Number       string             `json:"Number"`
+	ClientUserID uuid.UUID          `json:"ClientUserID"`
+	ClientName   string             `json:"ClientName"`
+	PeriodStart  string             `json:"PeriodStart"`
+	PeriodEnd    string             `json:"PeriodEnd"`
+	Total        float64            `json:"Total"`
+	IssuedAt     time.Time          `json:"IssuedAt"`
+	Lines        []LineItemResponse `json:"Lines,omitempty"`
+}
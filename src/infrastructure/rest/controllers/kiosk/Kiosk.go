@@ -0,0 +1,338 @@
+package kiosk
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	kioskUseCase "caregiver/src/application/usecases/kiosk"
+	domainErrors "caregiver/src/domain/errors"
+	domainKiosk "caregiver/src/domain/kiosk"
+	domainSchedule "caregiver/src/domain/schedule"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TokenHeader carries the kiosk token on requests from a kiosk tablet.
+const TokenHeader = "X-Kiosk-Token"
+
+const defaultAuditDays = 7
+
+type IKioskController interface {
+	CreateKiosk(ctx *gin.Context)
+	GetKiosks(ctx *gin.Context)
+	GetKioskByID(ctx *gin.Context)
+	UpdateKiosk(ctx *gin.Context)
+	DeleteKiosk(ctx *gin.Context)
+	RotateToken(ctx *gin.Context)
+	GetAudit(ctx *gin.Context)
+	SetPIN(ctx *gin.Context)
+	GetKioskSchedules(ctx *gin.Context)
+	CheckIn(ctx *gin.Context)
+	CheckOut(ctx *gin.Context)
+}
+
+type Controller struct {
+	kioskUseCase kioskUseCase.IKioskUseCase
+	Logger       *logger.Logger
+}
+
+func NewKioskController(kioskUseCase kioskUseCase.IKioskUseCase, loggerInstance *logger.Logger) IKioskController {
+	return &Controller{kioskUseCase: kioskUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateKiosk(ctx *gin.Context) {
+	c.Logger.Info("Creating new kiosk")
+	var request CreateKioskRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new kiosk", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	created, token, err := c.kioskUseCase.Register(&domainKiosk.Kiosk{
+		Name:    request.Name,
+		Address: domainKiosk.Address(request.Address),
+	})
+	if err != nil {
+		c.Logger.Error("Error creating kiosk", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Kiosk created successfully", zap.String("kioskID", created.ID.String()))
+	ctx.JSON(http.StatusOK, KioskTokenResponse{KioskResponse: *kioskToResponseMapper(created), Token: token})
+}
+
+func (c *Controller) GetKiosks(ctx *gin.Context) {
+	kiosks, err := c.kioskUseCase.GetKiosks()
+	if err != nil {
+		c.Logger.Error("Error getting kiosks", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]KioskResponse, len(*kiosks))
+	for i := range *kiosks {
+		res[i] = *kioskToResponseMapper(&(*kiosks)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetKioskByID(ctx *gin.Context) {
+	kioskID, ok := c.parseID(ctx, "kiosk id is invalid")
+	if !ok {
+		return
+	}
+	kiosk, err := c.kioskUseCase.GetByID(kioskID)
+	if err != nil {
+		c.Logger.Error("Error getting kiosk by ID", zap.Error(err), zap.String("id", kioskID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, kioskToResponseMapper(kiosk))
+}
+
+func (c *Controller) UpdateKiosk(ctx *gin.Context) {
+	kioskID, ok := c.parseID(ctx, "kiosk id is invalid")
+	if !ok {
+		return
+	}
+	var request UpdateKioskRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for kiosk update", zap.Error(err), zap.String("id", kioskID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Address != nil {
+		updates["address_house_number"] = request.Address.HouseNumber
+		updates["address_street"] = request.Address.Street
+		updates["address_city"] = request.Address.City
+		updates["address_state"] = request.Address.State
+		updates["address_pincode"] = request.Address.Pincode
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", kioskID.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updated, err := c.kioskUseCase.Update(kioskID, updates)
+	if err != nil {
+		c.Logger.Error("Error updating kiosk", zap.Error(err), zap.String("id", kioskID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Kiosk updated successfully", zap.String("id", kioskID.String()))
+	ctx.JSON(http.StatusOK, kioskToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteKiosk(ctx *gin.Context) {
+	kioskID, ok := c.parseID(ctx, "kiosk id is invalid")
+	if !ok {
+		return
+	}
+	if err := c.kioskUseCase.Delete(kioskID); err != nil {
+		c.Logger.Error("Error deleting kiosk", zap.Error(err), zap.String("id", kioskID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Kiosk deleted successfully", zap.String("id", kioskID.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) RotateToken(ctx *gin.Context) {
+	kioskID, ok := c.parseID(ctx, "kiosk id is invalid")
+	if !ok {
+		return
+	}
+	updated, token, err := c.kioskUseCase.RotateToken(kioskID)
+	if err != nil {
+		c.Logger.Error("Error rotating kiosk token", zap.Error(err), zap.String("id", kioskID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Kiosk token rotated successfully", zap.String("id", kioskID.String()))
+	ctx.JSON(http.StatusOK, KioskTokenResponse{KioskResponse: *kioskToResponseMapper(updated), Token: token})
+}
+
+// GetAudit returns the kiosk's attempts between the optional "from" and "to"
+// query parameters (RFC3339, defaulting to the last 7 days).
+func (c *Controller) GetAudit(ctx *gin.Context) {
+	kioskID, ok := c.parseID(ctx, "kiosk id is invalid")
+	if !ok {
+		return
+	}
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, -defaultAuditDays)
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.Logger.Error("Invalid date for kiosk audit", zap.Error(err), zap.String(param.name, value))
+			appError := domainErrors.NewAppError(errors.New(param.name+" must be RFC3339"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		*param.target = parsed.UTC()
+	}
+
+	entries, err := c.kioskUseCase.GetAudit(kioskID, from, to)
+	if err != nil {
+		c.Logger.Error("Error getting kiosk audit", zap.Error(err), zap.String("id", kioskID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]AuditEntryResponse, len(*entries))
+	for i, e := range *entries {
+		res[i] = AuditEntryResponse{
+			ID:              e.ID,
+			ScheduleID:      e.ScheduleID,
+			CaregiverUserID: e.CaregiverUserID,
+			Action:          e.Action,
+			Detail:          e.Detail,
+			IPAddress:       e.IPAddress,
+			OccurredAt:      e.OccurredAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) SetPIN(ctx *gin.Context) {
+	userID, ok := c.parseID(ctx, "user id is invalid")
+	if !ok {
+		return
+	}
+	var request SetPINRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for kiosk PIN", zap.Error(err), zap.String("userID", userID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if err := c.kioskUseCase.SetPIN(userID, request.PIN); err != nil {
+		c.Logger.Error("Error setting kiosk PIN", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "kiosk PIN updated successfully"})
+}
+
+// GetKioskSchedules lists today's visits at the calling kiosk's facility.
+func (c *Controller) GetKioskSchedules(ctx *gin.Context) {
+	kiosk, ok := c.authenticate(ctx)
+	if !ok {
+		return
+	}
+	schedules, err := c.kioskUseCase.GetSchedules(kiosk, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error getting kiosk schedules", zap.Error(err), zap.String("kioskID", kiosk.ID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]KioskScheduleResponse, len(*schedules))
+	for i := range *schedules {
+		res[i] = *scheduleToResponseMapper(&(*schedules)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CheckIn and CheckOut use the server clock rather than one supplied by the
+// tablet.
+func (c *Controller) CheckIn(ctx *gin.Context) {
+	c.punch(ctx, c.kioskUseCase.CheckIn)
+}
+
+func (c *Controller) CheckOut(ctx *gin.Context) {
+	c.punch(ctx, c.kioskUseCase.CheckOut)
+}
+
+func (c *Controller) punch(ctx *gin.Context, action func(*domainKiosk.Kiosk, uuid.UUID, string, string, time.Time) (*domainSchedule.Schedule, error)) {
+	kiosk, ok := c.authenticate(ctx)
+	if !ok {
+		return
+	}
+	scheduleID, ok := c.parseID(ctx, "schedule id is invalid")
+	if !ok {
+		return
+	}
+	var request KioskPunchRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for kiosk check-in", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	schedule, err := action(kiosk, scheduleID, request.PIN, ctx.ClientIP(), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Kiosk check-in rejected", zap.Error(err), zap.String("kioskID", kiosk.ID.String()), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, scheduleToResponseMapper(schedule))
+}
+
+func (c *Controller) authenticate(ctx *gin.Context) (*domainKiosk.Kiosk, bool) {
+	kiosk, err := c.kioskUseCase.Authenticate(ctx.GetHeader(TokenHeader))
+	if err != nil {
+		c.Logger.Warn("Kiosk authentication failed", zap.Error(err), zap.String("ip", ctx.ClientIP()))
+		_ = ctx.Error(err)
+		return nil, false
+	}
+	return kiosk, true
+}
+
+func (c *Controller) parseID(ctx *gin.Context, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(message), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func kioskToResponseMapper(k *domainKiosk.Kiosk) *KioskResponse {
+	return &KioskResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Address:    Address(k.Address),
+		Active:     k.Active,
+		LastUsedAt: k.LastUsedAt,
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}
+}
+
+func scheduleToResponseMapper(s *domainSchedule.Schedule) *KioskScheduleResponse {
+	return &KioskScheduleResponse{
+		ID:                s.ID,
+		ServiceName:       s.ServiceName,
+		AssignedUserID:    s.AssignedUserID,
+		ScheduledSlotFrom: s.ScheduledSlot.From,
+		ScheduledSlotTo:   s.ScheduledSlot.To,
+		VisitStatus:       s.VisitStatus,
+		CheckinTime:       s.CheckinTime,
+		CheckoutTime:      s.CheckoutTime,
+	}
+}
@@ -0,0 +1,74 @@
+package kiosk
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Address struct {
+	HouseNumber string `json:"HouseNumber"`
+	Street      string `json:"Street" binding:"required"`
+	City        string `json:"City"`
+	State       string `json:"State"`
+	Pincode     string `json:"Pincode"`
+}
+
+type CreateKioskRequest struct {
+	Name    string  `json:"Name" binding:"required"`
+	Address Address `json:"Address" binding:"required"`
+}
+
+type UpdateKioskRequest struct {
+	Name    *string  `json:"Name"`
+	Address *Address `json:"Address"`
+	Active  *bool    `json:"Active"`
+}
+
+type SetPINRequest struct {
+	PIN string `json:"PIN" binding:"required"`
+}
+
+type KioskPunchRequest struct {
+	PIN string `json:"PIN" binding:"required"`
+}
+
+type KioskResponse struct {
+	ID         uuid.UUID  `json:"ID"`
+	Name       string     `json:"Name"`
+	Address    Address    `json:"Address"`
+	Active     bool       `json:"Active"`
+	LastUsedAt *time.Time `json:"LastUsedAt"`
+	CreatedAt  time.Time  `json:"CreatedAt"`
+	UpdatedAt  time.Time  `json:"UpdatedAt"`
+}
+
+// KioskTokenResponse is returned when a token is issued. The token cannot be
+// retrieved again.
+type KioskTokenResponse struct {
+	KioskResponse
+	Token string `json:"Token"`
+}
+
+type AuditEntryResponse struct {
+	ID              uuid.UUID  `json:"ID"`
+	ScheduleID      *uuid.UUID `json:"ScheduleID"`
+	CaregiverUserID *uuid.UUID `json:"CaregiverUserID"`
+	Action          string     `json:"Action"`
+	Detail          string     `json:"Detail"`
+	IPAddress       string     `json:"IPAddress"`
+	OccurredAt      time.Time  `json:"OccurredAt"`
+}
+
+// KioskScheduleResponse is what a kiosk shows for a visit: enough to pick it
+// from a list, without the client's care details.
+type KioskScheduleResponse struct {
+	ID                uuid.UUID  `json:"ID"`
+	ServiceName       string     `json:"ServiceName"`
+	AssignedUserID    uuid.UUID  `json:"AssignedUserID"`
+	ScheduledSlotFrom time.Time  `json:"ScheduledSlotFrom"`
+	ScheduledSlotTo   time.Time  `json:"ScheduledSlotTo"`
+	VisitStatus       string     `json:"VisitStatus"`
+	CheckinTime       *time.Time `json:"CheckinTime"`
+	CheckoutTime      *time.Time `json:"CheckoutTime"`
+}
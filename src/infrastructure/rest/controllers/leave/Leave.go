@@ -0,0 +1,411 @@
+package leave
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	leaveUseCase "caregiver/src/application/usecases/leave"
+	domainErrors "caregiver/src/domain/errors"
+	domainLeave "caregiver/src/domain/leave"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ILeaveController interface {
+	CreateAccrualRule(ctx *gin.Context)
+	GetAccrualRules(ctx *gin.Context)
+	GetAccrualRuleByID(ctx *gin.Context)
+	UpdateAccrualRule(ctx *gin.Context)
+	DeleteAccrualRule(ctx *gin.Context)
+	CreateRequest(ctx *gin.Context)
+	GetRequests(ctx *gin.Context)
+	GetRequestByID(ctx *gin.Context)
+	ApproveRequest(ctx *gin.Context)
+	DenyRequest(ctx *gin.Context)
+	CancelRequest(ctx *gin.Context)
+	GetBalance(ctx *gin.Context)
+	GetLedger(ctx *gin.Context)
+	AdjustBalance(ctx *gin.Context)
+}
+
+type Controller struct {
+	leaveUseCase leaveUseCase.ILeaveUseCase
+	Logger       *logger.Logger
+}
+
+func NewLeaveController(leaveUseCase leaveUseCase.ILeaveUseCase, loggerInstance *logger.Logger) ILeaveController {
+	return &Controller{leaveUseCase: leaveUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateAccrualRule(ctx *gin.Context) {
+	var request CreateAccrualRuleRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new accrual rule", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	created, err := c.leaveUseCase.CreateRule(&domainLeave.AccrualRule{
+		CaregiverUserID:    request.CaregiverUserID,
+		HoursPerHourWorked: request.HoursPerHourWorked,
+		MaxBalance:         request.MaxBalance,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating accrual rule", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Accrual rule created successfully", zap.String("ruleID", created.ID.String()))
+	ctx.JSON(http.StatusOK, ruleToResponseMapper(created))
+}
+
+func (c *Controller) GetAccrualRules(ctx *gin.Context) {
+	rules, err := c.leaveUseCase.GetRules()
+	if err != nil {
+		c.Logger.Error("Error getting accrual rules", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]AccrualRuleResponse, len(*rules))
+	for i := range *rules {
+		res[i] = *ruleToResponseMapper(&(*rules)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetAccrualRuleByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "rule")
+	if !ok {
+		return
+	}
+	rule, err := c.leaveUseCase.GetRuleByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting accrual rule by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, ruleToResponseMapper(rule))
+}
+
+func (c *Controller) UpdateAccrualRule(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "rule")
+	if !ok {
+		return
+	}
+	var request UpdateAccrualRuleRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for accrual rule update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.HoursPerHourWorked != nil {
+		updates["hours_per_hour_worked"] = *request.HoursPerHourWorked
+	}
+	if request.MaxBalance != nil {
+		updates["max_balance"] = *request.MaxBalance
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updated, err := c.leaveUseCase.UpdateRule(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating accrual rule", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Accrual rule updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, ruleToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteAccrualRule(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "rule")
+	if !ok {
+		return
+	}
+	if err := c.leaveUseCase.DeleteRule(id); err != nil {
+		c.Logger.Error("Error deleting accrual rule", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Accrual rule deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) CreateRequest(ctx *gin.Context) {
+	var request CreateLeaveRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new leave request", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	created, err := c.leaveUseCase.CreateRequest(&domainLeave.Request{
+		CaregiverUserID: request.CaregiverUserID,
+		Type:            request.Type,
+		From:            request.From,
+		To:              request.To,
+		Hours:           request.Hours,
+		Reason:          request.Reason,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating leave request", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Leave request created successfully", zap.String("requestID", created.ID.String()))
+	ctx.JSON(http.StatusOK, requestToResponseMapper(created))
+}
+
+// GetRequests lists leave requests, optionally for one "caregiverID", in one
+// "status", or overlapping the RFC3339 "from" and "to" bounds.
+func (c *Controller) GetRequests(ctx *gin.Context) {
+	var filter domainLeave.RequestFilter
+	if value := ctx.Query("caregiverID"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			c.Logger.Error("Invalid caregiver ID for leave requests", zap.Error(err), zap.String("caregiverID", value))
+			appError := domainErrors.NewAppError(errors.New("caregiver id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		filter.CaregiverUserID = &parsed
+	}
+	if status := ctx.Query("status"); status != "" {
+		filter.Statuses = []string{status}
+	}
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.Logger.Error("Invalid date for leave requests", zap.Error(err), zap.String(param.name, value))
+			appError := domainErrors.NewAppError(errors.New(param.name+" must be RFC3339"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		parsed = parsed.UTC()
+		*param.target = &parsed
+	}
+
+	requests, err := c.leaveUseCase.GetRequests(filter)
+	if err != nil {
+		c.Logger.Error("Error getting leave requests", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]LeaveRequestResponse, len(*requests))
+	for i := range *requests {
+		res[i] = *requestToResponseMapper(&(*requests)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetRequestByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "leave request")
+	if !ok {
+		return
+	}
+	request, err := c.leaveUseCase.GetRequestByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting leave request by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, requestToResponseMapper(request))
+}
+
+func (c *Controller) ApproveRequest(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "leave request")
+	if !ok {
+		return
+	}
+	var request DecideLeaveRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for leave approval", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	decision, err := c.leaveUseCase.ApproveRequest(id, request.DecidedByUserID, request.Note, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error approving leave request", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Leave request approved", zap.String("id", id.String()), zap.Int("conflicts", len(decision.ConflictingScheduleIDs)))
+	ctx.JSON(http.StatusOK, ApproveLeaveResponse{
+		Request:                *requestToResponseMapper(decision.Request),
+		ConflictingScheduleIDs: decision.ConflictingScheduleIDs,
+	})
+}
+
+func (c *Controller) DenyRequest(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "leave request")
+	if !ok {
+		return
+	}
+	var request DecideLeaveRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for leave denial", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	denied, err := c.leaveUseCase.DenyRequest(id, request.DecidedByUserID, request.Note, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error denying leave request", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Leave request denied", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, requestToResponseMapper(denied))
+}
+
+func (c *Controller) CancelRequest(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "leave request")
+	if !ok {
+		return
+	}
+	cancelled, err := c.leaveUseCase.CancelRequest(id, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error cancelling leave request", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Leave request cancelled", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, requestToResponseMapper(cancelled))
+}
+
+func (c *Controller) GetBalance(ctx *gin.Context) {
+	caregiverID, ok := c.parseID(ctx, "caregiverID", "caregiver")
+	if !ok {
+		return
+	}
+	balance, err := c.leaveUseCase.GetBalance(caregiverID)
+	if err != nil {
+		c.Logger.Error("Error getting PTO balance", zap.Error(err), zap.String("caregiverID", caregiverID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, BalanceResponse{
+		CaregiverUserID: balance.CaregiverUserID,
+		Accrued:         balance.Accrued,
+		Used:            balance.Used,
+		Adjusted:        balance.Adjusted,
+		Available:       balance.Available,
+		Pending:         balance.Pending,
+	})
+}
+
+func (c *Controller) GetLedger(ctx *gin.Context) {
+	caregiverID, ok := c.parseID(ctx, "caregiverID", "caregiver")
+	if !ok {
+		return
+	}
+	entries, err := c.leaveUseCase.GetLedger(caregiverID)
+	if err != nil {
+		c.Logger.Error("Error getting PTO ledger", zap.Error(err), zap.String("caregiverID", caregiverID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]LedgerEntryResponse, len(*entries))
+	for i := range *entries {
+		res[i] = *entryToResponseMapper(&(*entries)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) AdjustBalance(ctx *gin.Context) {
+	caregiverID, ok := c.parseID(ctx, "caregiverID", "caregiver")
+	if !ok {
+		return
+	}
+	var request AdjustBalanceRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for PTO adjustment", zap.Error(err), zap.String("caregiverID", caregiverID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	entry, err := c.leaveUseCase.AdjustBalance(caregiverID, request.Hours, request.Note)
+	if err != nil {
+		c.Logger.Error("Error adjusting PTO balance", zap.Error(err), zap.String("caregiverID", caregiverID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("PTO balance adjusted", zap.String("caregiverID", caregiverID.String()))
+	ctx.JSON(http.StatusOK, entryToResponseMapper(entry))
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func ruleToResponseMapper(r *domainLeave.AccrualRule) *AccrualRuleResponse {
+	return &AccrualRuleResponse{
+		ID:                 r.ID,
+		CaregiverUserID:    r.CaregiverUserID,
+		HoursPerHourWorked: r.HoursPerHourWorked,
+		MaxBalance:         r.MaxBalance,
+		Active:             r.Active,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+	}
+}
+
+func requestToResponseMapper(r *domainLeave.Request) *LeaveRequestResponse {
+	return &LeaveRequestResponse{
+		ID:              r.ID,
+		CaregiverUserID: r.CaregiverUserID,
+		Type:            r.Type,
+		From:            r.From,
+		To:              r.To,
+		Hours:           r.Hours,
+		Reason:          r.Reason,
+		Status:          r.Status,
+		DecidedByUserID: r.DecidedByUserID,
+		DecisionNote:    r.DecisionNote,
+		DecidedAt:       r.DecidedAt,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+}
+
+func entryToResponseMapper(e *domainLeave.Entry) *LedgerEntryResponse {
+	return &LedgerEntryResponse{
+		ID:         e.ID,
+		Type:       e.Type,
+		Hours:      e.Hours,
+		ScheduleID: e.ScheduleID,
+		RequestID:  e.RequestID,
+		Note:       e.Note,
+		CreatedAt:  e.CreatedAt,
+	}
+}
@@ -0,0 +1,93 @@
+package leave
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAccrualRuleRequest leaves the balance uncapped when MaxBalance is 0.
+type CreateAccrualRuleRequest struct {
+	CaregiverUserID    uuid.UUID `json:"CaregiverUserID" binding:"required"`
+	HoursPerHourWorked float64   `json:"HoursPerHourWorked" binding:"required"`
+	MaxBalance         float64   `json:"MaxBalance"`
+}
+
+type UpdateAccrualRuleRequest struct {
+	HoursPerHourWorked *float64 `json:"HoursPerHourWorked"`
+	MaxBalance         *float64 `json:"MaxBalance"`
+	Active             *bool    `json:"Active"`
+}
+
+type AccrualRuleResponse struct {
+	ID                 uuid.UUID `json:"ID"`
+	CaregiverUserID    uuid.UUID `json:"CaregiverUserID"`
+	HoursPerHourWorked float64   `json:"HoursPerHourWorked"`
+	MaxBalance         float64   `json:"MaxBalance"`
+	Active             bool      `json:"Active"`
+	CreatedAt          time.Time `json:"CreatedAt"`
+	UpdatedAt          time.Time `json:"UpdatedAt"`
+}
+
+type CreateLeaveRequest struct {
+	CaregiverUserID uuid.UUID `json:"CaregiverUserID" binding:"required"`
+	Type            string    `json:"Type" binding:"required"`
+	From            time.Time `json:"From" binding:"required"`
+	To              time.Time `json:"To" binding:"required"`
+	Hours           float64   `json:"Hours"`
+	Reason          string    `json:"Reason"`
+}
+
+// DecideLeaveRequest is sent by the coordinator approving or denying a
+// request. A note is required to deny.
+type DecideLeaveRequest struct {
+	DecidedByUserID uuid.UUID `json:"DecidedByUserID" binding:"required"`
+	Note            string    `json:"Note"`
+}
+
+type LeaveRequestResponse struct {
+	ID              uuid.UUID  `json:"ID"`
+	CaregiverUserID uuid.UUID  `json:"CaregiverUserID"`
+	Type            string     `json:"Type"`
+	From            time.Time  `json:"From"`
+	To              time.Time  `json:"To"`
+	Hours           float64    `json:"Hours"`
+	Reason          string     `json:"Reason"`
+	Status          string     `json:"Status"`
+	DecidedByUserID *uuid.UUID `json:"DecidedByUserID"`
+	DecisionNote    string     `json:"DecisionNote"`
+	DecidedAt       *time.Time `json:"DecidedAt"`
+	CreatedAt       time.Time  `json:"CreatedAt"`
+	UpdatedAt       time.Time  `json:"UpdatedAt"`
+}
+
+// ApproveLeaveResponse lists the visits still assigned to the caregiver
+// during the approved leave.
+type ApproveLeaveResponse struct {
+	Request                LeaveRequestResponse `json:"Request"`
+	ConflictingScheduleIDs []uuid.UUID          `json:"ConflictingScheduleIDs"`
+}
+
+type AdjustBalanceRequest struct {
+	Hours float64 `json:"Hours" binding:"required"`
+	Note  string  `json:"Note" binding:"required"`
+}
+
+type BalanceResponse struct {
+	CaregiverUserID uuid.UUID `json:"CaregiverUserID"`
+	Accrued         float64   `json:"Accrued"`
+	Used            float64   `json:"Used"`
+	Adjusted        float64   `json:"Adjusted"`
+	Available       float64   `json:"Available"`
+	Pending         float64   `json:"Pending"`
+}
+
+type LedgerEntryResponse struct {
+	ID         uuid.UUID  `json:"ID"`
+	Type       string     `json:"Type"`
+	Hours      float64    `json:"Hours"`
+	ScheduleID *uuid.UUID `json:"ScheduleID"`
+	RequestID  *uuid.UUID `json:"RequestID"`
+	Note       string     `json:"Note"`
+	CreatedAt  time.Time  `json:"CreatedAt"`
+}
@@ -0,0 +1,148 @@
+package legalhold
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	legalHoldUseCase "caregiver/src/application/usecases/legalhold"
+	domainErrors "caregiver/src/domain/errors"
+	domainLegalHold "caregiver/src/domain/legalhold"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ILegalHoldController interface {
+	GetActiveHolds(ctx *gin.Context)
+	GetClientHolds(ctx *gin.Context)
+	PlaceHold(ctx *gin.Context)
+	ReleaseHold(ctx *gin.Context)
+	ExportClient(ctx *gin.Context)
+}
+
+type Controller struct {
+	legalHoldUseCase legalHoldUseCase.ILegalHoldUseCase
+	Logger           *logger.Logger
+}
+
+func NewLegalHoldController(legalHoldUseCase legalHoldUseCase.ILegalHoldUseCase, loggerInstance *logger.Logger) ILegalHoldController {
+	return &Controller{legalHoldUseCase: legalHoldUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetActiveHolds(ctx *gin.Context) {
+	holds, err := c.legalHoldUseCase.GetActive()
+	if err != nil {
+		c.Logger.Error("Error getting legal holds", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, arrayToResponseMapper(holds))
+}
+
+// GetClientHolds lists a client's holds, released ones included.
+func (c *Controller) GetClientHolds(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "clientID", "client")
+	if !ok {
+		return
+	}
+	holds, err := c.legalHoldUseCase.GetByClient(clientID)
+	if err != nil {
+		c.Logger.Error("Error getting client legal holds", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, arrayToResponseMapper(holds))
+}
+
+// PlaceHold stops the client from being deleted or merged until released.
+// The authenticated caller is recorded as placing it.
+func (c *Controller) PlaceHold(ctx *gin.Context) {
+	var request PlaceHoldRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for legal hold", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	hold, err := c.legalHoldUseCase.Place(&domainLegalHold.Hold{
+		ClientUserID:   request.ClientUserID,
+		Reason:         request.Reason,
+		PlacedByUserID: controllers.CallerID(ctx),
+	}, time.Now())
+	if err != nil {
+		c.Logger.Error("Error placing legal hold", zap.Error(err), zap.String("clientUserID", request.ClientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Legal hold placed", zap.String("id", hold.ID.String()))
+	ctx.JSON(http.StatusCreated, toResponseMapper(hold))
+}
+
+func (c *Controller) ReleaseHold(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "legal hold")
+	if !ok {
+		return
+	}
+	hold, err := c.legalHoldUseCase.Release(id, controllers.CallerID(ctx), time.Now())
+	if err != nil {
+		c.Logger.Error("Error releasing legal hold", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Legal hold released", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, toResponseMapper(hold))
+}
+
+// ExportClient downloads the client's signed timeline archive.
+func (c *Controller) ExportClient(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "clientID", "client")
+	if !ok {
+		return
+	}
+	archive, err := c.legalHoldUseCase.Export(clientID, controllers.CallerID(ctx), time.Now())
+	if err != nil {
+		c.Logger.Error("Error exporting client timeline", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Client timeline exported", zap.String("clientID", clientID.String()), zap.Int("records", archive.Records))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archive.FileName))
+	ctx.Data(http.StatusOK, archive.ContentType, archive.Data)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func arrayToResponseMapper(holds *[]domainLegalHold.Hold) []HoldResponse {
+	res := make([]HoldResponse, len(*holds))
+	for i := range *holds {
+		res[i] = *toResponseMapper(&(*holds)[i])
+	}
+	return res
+}
+
+func toResponseMapper(hold *domainLegalHold.Hold) *HoldResponse {
+	return &HoldResponse{
+		ID:               hold.ID,
+		ClientUserID:     hold.ClientUserID,
+		Reason:           hold.Reason,
+		PlacedByUserID:   hold.PlacedByUserID,
+		PlacedAt:         hold.PlacedAt,
+		ReleasedByUserID: hold.ReleasedByUserID,
+		ReleasedAt:       hold.ReleasedAt,
+		Active:           hold.Active(),
+	}
+}
@@ -0,0 +1,23 @@
+package legalhold
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PlaceHoldRequest struct {
+	ClientUserID uuid.UUID `json:"ClientUserID" binding:"required"`
+	Reason       string    `json:"Reason" binding:"required"`
+}
+
+type HoldResponse struct {
+	ID               uuid.UUID  `json:"ID"`
+	ClientUserID     uuid.UUID  `json:"ClientUserID"`
+	Reason           string     `json:"Reason"`
+	PlacedByUserID   *uuid.UUID `json:"PlacedByUserID,omitempty"`
+	PlacedAt         time.Time  `json:"PlacedAt"`
+	ReleasedByUserID *uuid.UUID `json:"ReleasedByUserID,omitempty"`
+	ReleasedAt       *time.Time `json:"ReleasedAt,omitempty"`
+	Active           bool       `json:"Active"`
+}
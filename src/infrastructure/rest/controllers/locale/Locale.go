@@ -0,0 +1,138 @@
+package locale
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	localeUseCase "caregiver/src/application/usecases/locale"
+	domainErrors "caregiver/src/domain/errors"
+	domainLocale "caregiver/src/domain/locale"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ILocaleController interface {
+	GetSettings(ctx *gin.Context)
+	SetSettings(ctx *gin.Context)
+	GetHolidays(ctx *gin.Context)
+	CreateHoliday(ctx *gin.Context)
+	DeleteHoliday(ctx *gin.Context)
+}
+
+type Controller struct {
+	localeUseCase localeUseCase.ILocaleUseCase
+	Logger        *logger.Logger
+}
+
+func NewLocaleController(localeUseCase localeUseCase.ILocaleUseCase, loggerInstance *logger.Logger) ILocaleController {
+	return &Controller{localeUseCase: localeUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetSettings(ctx *gin.Context) {
+	settings, err := c.localeUseCase.GetSettings()
+	if err != nil {
+		c.Logger.Error("Error getting locale settings", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, settingsToResponseMapper(settings))
+}
+
+func (c *Controller) SetSettings(ctx *gin.Context) {
+	var request SettingsRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for locale settings", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	settings, err := c.localeUseCase.SetSettings(request.Timezone, request.Region, request.SkipHolidays)
+	if err != nil {
+		c.Logger.Error("Error setting locale settings", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Locale settings updated", zap.String("timezone", settings.Timezone), zap.String("region", settings.Region))
+	ctx.JSON(http.StatusOK, settingsToResponseMapper(settings))
+}
+
+// GetHolidays lists the public and custom holidays between the from and to
+// query dates, by default the coming year.
+func (c *Controller) GetHolidays(ctx *gin.Context) {
+	today := time.Now().UTC()
+	from := ctx.DefaultQuery("from", today.Format(domainLocale.DateLayout))
+	to := ctx.DefaultQuery("to", today.AddDate(1, 0, 0).Format(domainLocale.DateLayout))
+	holidays, err := c.localeUseCase.GetHolidays(from, to)
+	if err != nil {
+		c.Logger.Error("Error getting holidays", zap.Error(err), zap.String("from", from), zap.String("to", to))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]HolidayResponse, len(*holidays))
+	for i := range *holidays {
+		res[i] = *holidayToResponseMapper(&(*holidays)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) CreateHoliday(ctx *gin.Context) {
+	var request HolidayRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for holiday", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	holiday, err := c.localeUseCase.CreateHoliday(request.Date, request.Name)
+	if err != nil {
+		c.Logger.Error("Error creating holiday", zap.Error(err), zap.String("date", request.Date))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Holiday created", zap.String("id", holiday.ID.String()), zap.String("date", holiday.Date))
+	ctx.JSON(http.StatusCreated, holidayToResponseMapper(holiday))
+}
+
+func (c *Controller) DeleteHoliday(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid holiday ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("holiday id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if err := c.localeUseCase.DeleteHoliday(id); err != nil {
+		c.Logger.Error("Error deleting holiday", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Holiday deleted", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func settingsToResponseMapper(settings *domainLocale.Settings) *SettingsResponse {
+	return &SettingsResponse{
+		Timezone:     settings.Timezone,
+		Region:       settings.Region,
+		SkipHolidays: settings.SkipHolidays,
+		UpdatedAt:    settings.UpdatedAt,
+	}
+}
+
+func holidayToResponseMapper(holiday *domainLocale.Holiday) *HolidayResponse {
+	response := &HolidayResponse{
+		Date:   holiday.Date,
+		Name:   holiday.Name,
+		Region: holiday.Region,
+		Custom: holiday.Custom,
+	}
+	if holiday.Custom {
+		response.ID = &holiday.ID
+	}
+	return response
+}
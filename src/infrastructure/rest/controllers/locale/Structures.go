@@ -0,0 +1,36 @@
+package locale
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SettingsRequest sets the organization's IANA time zone and the region
+// ("US", "CA-ON") whose public holidays apply.
+type SettingsRequest struct {
+	Timezone     string `json:"Timezone" binding:"required"`
+	Region       string `json:"Region"`
+	SkipHolidays bool   `json:"SkipHolidays"`
+}
+
+type SettingsResponse struct {
+	Timezone     string    `json:"Timezone"`
+	Region       string    `json:"Region"`
+	SkipHolidays bool      `json:"SkipHolidays"`
+	UpdatedAt    time.Time `json:"UpdatedAt"`
+}
+
+// HolidayRequest adds a custom holiday on Date, written as YYYY-MM-DD.
+type HolidayRequest struct {
+	Date string `json:"Date" binding:"required"`
+	Name string `json:"Name" binding:"required"`
+}
+
+type HolidayResponse struct {
+	ID     *uuid.UUID `json:"ID,omitempty"`
+	Date   string     `json:"Date"`
+	Name   string     `json:"Name"`
+	Region string     `json:"Region,omitempty"`
+	Custom bool       `json:"Custom"`
+}
@@ -0,0 +1,333 @@
+package medication
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	medicationUseCase "caregiver/src/application/usecases/medication"
+	domainErrors "caregiver/src/domain/errors"
+	domainMedication "caregiver/src/domain/medication"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IMedicationController interface {
+	AddAllergy(ctx *gin.Context)
+	GetAllergies(ctx *gin.Context)
+	DeleteAllergy(ctx *gin.Context)
+	CreateMedication(ctx *gin.Context)
+	GetMedications(ctx *gin.Context)
+	UpdateMedication(ctx *gin.Context)
+	DeleteMedication(ctx *gin.Context)
+	GetClientAdministrations(ctx *gin.Context)
+	LogAdministration(ctx *gin.Context)
+	GetVisitAdministrations(ctx *gin.Context)
+}
+
+type Controller struct {
+	medicationUseCase medicationUseCase.IMedicationUseCase
+	Logger            *logger.Logger
+}
+
+func NewMedicationController(medicationUseCase medicationUseCase.IMedicationUseCase, loggerInstance *logger.Logger) IMedicationController {
+	return &Controller{medicationUseCase: medicationUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) AddAllergy(ctx *gin.Context) {
+	clientUserID, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	var request AddAllergyRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new allergy", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	allergy, err := c.medicationUseCase.AddAllergy(&domainMedication.Allergy{
+		ClientUserID: clientUserID,
+		Substance:    request.Substance,
+		Reaction:     request.Reaction,
+		Severity:     request.Severity,
+	})
+	if err != nil {
+		c.Logger.Error("Error adding allergy", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Allergy added successfully", zap.String("allergyID", allergy.ID.String()))
+	ctx.JSON(http.StatusOK, allergyToResponseMapper(allergy))
+}
+
+func (c *Controller) GetAllergies(ctx *gin.Context) {
+	clientUserID, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	allergies, err := c.medicationUseCase.GetAllergies(clientUserID)
+	if err != nil {
+		c.Logger.Error("Error getting allergies", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]AllergyResponse, len(*allergies))
+	for i := range *allergies {
+		res[i] = *allergyToResponseMapper(&(*allergies)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) DeleteAllergy(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "allergy")
+	if !ok {
+		return
+	}
+	if err := c.medicationUseCase.DeleteAllergy(id); err != nil {
+		c.Logger.Error("Error deleting allergy", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Allergy deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) CreateMedication(ctx *gin.Context) {
+	clientUserID, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	var request CreateMedicationRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new medication", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	medication, err := c.medicationUseCase.CreateMedication(&domainMedication.Medication{
+		ClientUserID: clientUserID,
+		Name:         request.Name,
+		Dose:         request.Dose,
+		Route:        request.Route,
+		Frequency:    request.Frequency,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating medication", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Medication created successfully", zap.String("medicationID", medication.ID.String()))
+	ctx.JSON(http.StatusOK, medicationToResponseMapper(medication))
+}
+
+func (c *Controller) GetMedications(ctx *gin.Context) {
+	clientUserID, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	medications, err := c.medicationUseCase.GetMedications(clientUserID)
+	if err != nil {
+		c.Logger.Error("Error getting medications", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]MedicationResponse, len(*medications))
+	for i := range *medications {
+		res[i] = *medicationToResponseMapper(&(*medications)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) UpdateMedication(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "medication")
+	if !ok {
+		return
+	}
+	var request UpdateMedicationRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for medication update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Dose != nil {
+		updates["dose"] = *request.Dose
+	}
+	if request.Route != nil {
+		updates["route"] = *request.Route
+	}
+	if request.Frequency != nil {
+		updates["frequency"] = *request.Frequency
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	medication, err := c.medicationUseCase.UpdateMedication(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating medication", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Medication updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, medicationToResponseMapper(medication))
+}
+
+func (c *Controller) DeleteMedication(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "medication")
+	if !ok {
+		return
+	}
+	if err := c.medicationUseCase.DeleteMedication(id); err != nil {
+		c.Logger.Error("Error deleting medication", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Medication deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) GetClientAdministrations(ctx *gin.Context) {
+	clientUserID, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	c.getAdministrations(ctx, domainMedication.AdministrationFilter{ClientUserID: &clientUserID})
+}
+
+// LogAdministration records a drug given during a visit. Unacknowledged
+// allergy or interaction warnings are returned as a conflict with the
+// warnings in its details.
+func (c *Controller) LogAdministration(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "schedule")
+	if !ok {
+		return
+	}
+	var request LogAdministrationRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for medication administration", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	administration := &domainMedication.Administration{
+		ScheduleID:   scheduleID,
+		MedicationID: request.MedicationID,
+		DrugName:     request.DrugName,
+		Dose:         request.Dose,
+		Route:        request.Route,
+		Note:         request.Note,
+	}
+	if request.AdministeredAt != nil {
+		administration.AdministeredAt = *request.AdministeredAt
+	}
+	created, err := c.medicationUseCase.LogAdministration(administration, request.Acknowledged, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error logging medication administration", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Medication administration logged successfully", zap.String("administrationID", created.ID.String()))
+	ctx.JSON(http.StatusOK, administrationToResponseMapper(created))
+}
+
+func (c *Controller) GetVisitAdministrations(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "schedule")
+	if !ok {
+		return
+	}
+	c.getAdministrations(ctx, domainMedication.AdministrationFilter{ScheduleID: &scheduleID})
+}
+
+func (c *Controller) getAdministrations(ctx *gin.Context, filter domainMedication.AdministrationFilter) {
+	administrations, err := c.medicationUseCase.GetAdministrations(filter)
+	if err != nil {
+		c.Logger.Error("Error getting medication administrations", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]AdministrationResponse, len(*administrations))
+	for i := range *administrations {
+		res[i] = *administrationToResponseMapper(&(*administrations)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func allergyToResponseMapper(a *domainMedication.Allergy) *AllergyResponse {
+	return &AllergyResponse{
+		ID:           a.ID,
+		ClientUserID: a.ClientUserID,
+		Substance:    a.Substance,
+		Reaction:     a.Reaction,
+		Severity:     a.Severity,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+func medicationToResponseMapper(m *domainMedication.Medication) *MedicationResponse {
+	return &MedicationResponse{
+		ID:           m.ID,
+		ClientUserID: m.ClientUserID,
+		Name:         m.Name,
+		Dose:         m.Dose,
+		Route:        m.Route,
+		Frequency:    m.Frequency,
+		Active:       m.Active,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func administrationToResponseMapper(a *domainMedication.Administration) *AdministrationResponse {
+	warnings := make([]WarningResponse, len(a.Warnings))
+	for i, warning := range a.Warnings {
+		warnings[i] = WarningResponse{
+			Kind:     warning.Kind,
+			Severity: warning.Severity,
+			Subject:  warning.Subject,
+			Message:  warning.Message,
+		}
+	}
+	return &AdministrationResponse{
+		ID:              a.ID,
+		ScheduleID:      a.ScheduleID,
+		ClientUserID:    a.ClientUserID,
+		CaregiverUserID: a.CaregiverUserID,
+		MedicationID:    a.MedicationID,
+		DrugName:        a.DrugName,
+		Dose:            a.Dose,
+		Route:           a.Route,
+		AdministeredAt:  a.AdministeredAt,
+		Note:            a.Note,
+		Warnings:        warnings,
+		AcknowledgedAt:  a.AcknowledgedAt,
+		CreatedAt:       a.CreatedAt,
+	}
+}
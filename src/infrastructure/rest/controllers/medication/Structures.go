@@ -0,0 +1,87 @@
+package medication
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AddAllergyRequest records a client allergy. Severity is "mild",
+// "moderate" or "severe".
+type AddAllergyRequest struct {
+	Substance string `json:"Substance" binding:"required"`
+	Reaction  string `json:"Reaction"`
+	Severity  string `json:"Severity"`
+}
+
+type AllergyResponse struct {
+	ID           uuid.UUID `json:"ID"`
+	ClientUserID uuid.UUID `json:"ClientUserID"`
+	Substance    string    `json:"Substance"`
+	Reaction     string    `json:"Reaction"`
+	Severity     string    `json:"Severity"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+}
+
+type CreateMedicationRequest struct {
+	Name      string `json:"Name" binding:"required"`
+	Dose      string `json:"Dose"`
+	Route     string `json:"Route"`
+	Frequency string `json:"Frequency"`
+}
+
+type UpdateMedicationRequest struct {
+	Name      *string `json:"Name"`
+	Dose      *string `json:"Dose"`
+	Route     *string `json:"Route"`
+	Frequency *string `json:"Frequency"`
+	Active    *bool   `json:"Active"`
+}
+
+type MedicationResponse struct {
+	ID           uuid.UUID `json:"ID"`
+	ClientUserID uuid.UUID `json:"ClientUserID"`
+	Name         string    `json:"Name"`
+	Dose         string    `json:"Dose"`
+	Route        string    `json:"Route"`
+	Frequency    string    `json:"Frequency"`
+	Active       bool      `json:"Active"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+	UpdatedAt    time.Time `json:"UpdatedAt"`
+}
+
+// LogAdministrationRequest records a drug given during a visit, either from
+// the client's medication list or by name. If the check raises warnings the
+// request is rejected with them until it is resent with Acknowledged set.
+type LogAdministrationRequest struct {
+	MedicationID   *uuid.UUID `json:"MedicationID"`
+	DrugName       string     `json:"DrugName"`
+	Dose           string     `json:"Dose"`
+	Route          string     `json:"Route"`
+	AdministeredAt *time.Time `json:"AdministeredAt"`
+	Note           string     `json:"Note"`
+	Acknowledged   bool       `json:"Acknowledged"`
+}
+
+type WarningResponse struct {
+	Kind     string `json:"Kind"`
+	Severity string `json:"Severity"`
+	Subject  string `json:"Subject"`
+	Message  string `json:"Message"`
+}
+
+type AdministrationResponse struct {
+	ID              uuid.UUID         `json:"ID"`
+	ScheduleID      uuid.UUID         `json:"ScheduleID"`
+	ClientUserID    uuid.UUID         `json:"ClientUserID"`
+	CaregiverUserID uuid.UUID         `json:"CaregiverUserID"`
+	MedicationID    *uuid.UUID        `json:"MedicationID"`
+	DrugName        string            `json:"DrugName"`
+	Dose            string            `json:"Dose"`
+	Route           string            `json:"Route"`
+	AdministeredAt  time.Time         `json:"AdministeredAt"`
+	Note            string            `json:"Note"`
+	Warnings        []WarningResponse `json:"Warnings"`
+	AcknowledgedAt  *time.Time        `json:"AcknowledgedAt"`
+	CreatedAt       time.Time         `json:"CreatedAt"`
+}
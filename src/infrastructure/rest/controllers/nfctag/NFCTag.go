@@ -0,0 +1,133 @@
+package nfctag
+
+import (
+	"errors"
+	"net/http"
+
+	nfcTagUseCase "caregiver/src/application/usecases/nfctag"
+	domainErrors "caregiver/src/domain/errors"
+	domainNFCTag "caregiver/src/domain/nfctag"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type INFCTagController interface {
+	RegisterTag(ctx *gin.Context)
+	GetTags(ctx *gin.Context)
+	UpdateTag(ctx *gin.Context)
+	DeleteTag(ctx *gin.Context)
+}
+
+type Controller struct {
+	nfcTagUseCase nfcTagUseCase.INFCTagUseCase
+	Logger        *logger.Logger
+}
+
+func NewNFCTagController(nfcTagUseCase nfcTagUseCase.INFCTagUseCase, loggerInstance *logger.Logger) INFCTagController {
+	return &Controller{nfcTagUseCase: nfcTagUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) RegisterTag(ctx *gin.Context) {
+	c.Logger.Info("Registering NFC tag")
+	var request RegisterTagRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for NFC tag", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	tag, err := c.nfcTagUseCase.Register(&domainNFCTag.Tag{
+		ClientUserID: request.ClientUserID,
+		TagValue:     request.TagValue,
+		Label:        request.Label,
+	})
+	if err != nil {
+		c.Logger.Error("Error registering NFC tag", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(tag))
+}
+
+// GetTags lists the tags registered for the client in the "clientUserID"
+// query parameter.
+func (c *Controller) GetTags(ctx *gin.Context) {
+	clientUserID, err := uuid.Parse(ctx.Query("clientUserID"))
+	if err != nil {
+		c.Logger.Error("Invalid clientUserID for NFC tags", zap.Error(err), zap.String("clientUserID", ctx.Query("clientUserID")))
+		appError := domainErrors.NewAppError(errors.New("clientUserID is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	tags, err := c.nfcTagUseCase.GetByClientUserID(clientUserID)
+	if err != nil {
+		c.Logger.Error("Error getting NFC tags", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]TagResponse, len(*tags))
+	for i := range *tags {
+		res[i] = *domainToResponseMapper(&(*tags)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) UpdateTag(ctx *gin.Context) {
+	tagID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid NFC tag ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("tag id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	var request UpdateTagRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for NFC tag update", zap.Error(err), zap.String("id", tagID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	tag, err := c.nfcTagUseCase.SetActive(tagID, *request.Active)
+	if err != nil {
+		c.Logger.Error("Error updating NFC tag", zap.Error(err), zap.String("id", tagID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(tag))
+}
+
+func (c *Controller) DeleteTag(ctx *gin.Context) {
+	tagID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid NFC tag ID parameter for deletion", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("tag id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if err := c.nfcTagUseCase.Delete(tagID); err != nil {
+		c.Logger.Error("Error deleting NFC tag", zap.Error(err), zap.String("id", tagID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("NFC tag deleted successfully", zap.String("id", tagID.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func domainToResponseMapper(t *domainNFCTag.Tag) *TagResponse {
+	return &TagResponse{
+		ID:           t.ID,
+		ClientUserID: t.ClientUserID,
+		TagValue:     t.TagValue,
+		Label:        t.Label,
+		Active:       t.Active,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
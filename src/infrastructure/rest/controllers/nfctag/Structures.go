@@ -0,0 +1,27 @@
+package nfctag
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RegisterTagRequest struct {
+	ClientUserID uuid.UUID `json:"ClientUserID" binding:"required"`
+	TagValue     string    `json:"TagValue" binding:"required"`
+	Label        string    `json:"Label"`
+}
+
+type UpdateTagRequest struct {
+	Active *bool `json:"Active" binding:"required"`
+}
+
+type TagResponse struct {
+	ID           uuid.UUID `json:"ID"`
+	ClientUserID uuid.UUID `json:"ClientUserID"`
+	TagValue     string    `json:"TagValue"`
+	Label        string    `json:"Label"`
+	Active       bool      `json:"Active"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+	UpdatedAt    time.Time `json:"UpdatedAt"`
+}
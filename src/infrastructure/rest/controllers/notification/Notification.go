@@ -0,0 +1,159 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	notificationUseCase "caregiver/src/application/usecases/notification"
+	domainErrors "caregiver/src/domain/errors"
+	domainNotification "caregiver/src/domain/notification"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type INotificationController interface {
+	GetDevices(ctx *gin.Context)
+	RegisterDevice(ctx *gin.Context)
+	RemoveDevice(ctx *gin.Context)
+	GetPreference(ctx *gin.Context)
+	SetPreference(ctx *gin.Context)
+}
+
+type Controller struct {
+	notificationUseCase notificationUseCase.INotificationUseCase
+	Logger              *logger.Logger
+}
+
+func NewNotificationController(notificationUseCase notificationUseCase.INotificationUseCase, loggerInstance *logger.Logger) INotificationController {
+	return &Controller{notificationUseCase: notificationUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetDevices(ctx *gin.Context) {
+	userID, ok := c.parseID(ctx, "userID", "user")
+	if !ok {
+		return
+	}
+	devices, err := c.notificationUseCase.GetDevices(userID)
+	if err != nil {
+		c.Logger.Error("Error getting push devices", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]DeviceResponse, len(*devices))
+	for i := range *devices {
+		res[i] = *toResponseMapper(&(*devices)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RegisterDevice is called by the apps on sign-in and whenever FCM hands
+// them a new push token.
+func (c *Controller) RegisterDevice(ctx *gin.Context) {
+	userID, ok := c.parseID(ctx, "userID", "user")
+	if !ok {
+		return
+	}
+	var request RegisterDeviceRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for push device", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	device, err := c.notificationUseCase.RegisterDevice(&domainNotification.Device{
+		UserID:   userID,
+		Platform: request.Platform,
+		Token:    request.Token,
+	}, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error registering push device", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Push device registered", zap.String("userID", userID.String()), zap.String("id", device.ID.String()))
+	ctx.JSON(http.StatusCreated, toResponseMapper(device))
+}
+
+func (c *Controller) RemoveDevice(ctx *gin.Context) {
+	userID, ok := c.parseID(ctx, "userID", "user")
+	if !ok {
+		return
+	}
+	id, ok := c.parseID(ctx, "id", "device")
+	if !ok {
+		return
+	}
+	if err := c.notificationUseCase.RemoveDevice(userID, id); err != nil {
+		c.Logger.Error("Error removing push device", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Push device removed", zap.String("userID", userID.String()), zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetPreference returns the channels the user is notified on.
+func (c *Controller) GetPreference(ctx *gin.Context) {
+	userID, ok := c.parseID(ctx, "userID", "user")
+	if !ok {
+		return
+	}
+	preference, err := c.notificationUseCase.GetPreference(userID)
+	if err != nil {
+		c.Logger.Error("Error getting notification channels", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, PreferenceResponse{UserID: preference.UserID, Channels: preference.Channels})
+}
+
+// SetPreference chooses the channels, "email", "sms" or "push", the user is
+// notified on, including announcements.
+func (c *Controller) SetPreference(ctx *gin.Context) {
+	userID, ok := c.parseID(ctx, "userID", "user")
+	if !ok {
+		return
+	}
+	var request PreferenceRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for notification channels", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	preference, err := c.notificationUseCase.SetPreference(userID, request.Channels)
+	if err != nil {
+		c.Logger.Error("Error setting notification channels", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Notification channels set", zap.String("userID", userID.String()), zap.Strings("channels", preference.Channels))
+	ctx.JSON(http.StatusOK, PreferenceResponse{UserID: preference.UserID, Channels: preference.Channels})
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// toResponseMapper leaves out the push token, which only the app needs.
+func toResponseMapper(device *domainNotification.Device) *DeviceResponse {
+	return &DeviceResponse{
+		ID:         device.ID,
+		UserID:     device.UserID,
+		Platform:   device.Platform,
+		CreatedAt:  device.CreatedAt,
+		LastSeenAt: device.LastSeenAt,
+	}
+}
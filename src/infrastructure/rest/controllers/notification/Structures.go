@@ -0,0 +1,29 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RegisterDeviceRequest struct {
+	Platform string `json:"Platform" binding:"required"`
+	Token    string `json:"Token" binding:"required"`
+}
+
+type DeviceResponse struct {
+	ID         uuid.UUID `json:"ID"`
+	UserID     uuid.UUID `json:"UserID"`
+	Platform   string    `json:"Platform"`
+	CreatedAt  time.Time `json:"CreatedAt"`
+	LastSeenAt time.Time `json:"LastSeenAt"`
+}
+
+type PreferenceRequest struct {
+	Channels []string `json:"Channels" binding:"required"`
+}
+
+type PreferenceResponse struct {
+	UserID   uuid.UUID `json:"UserID"`
+	Channels []string  `json:"Channels"`
+}
@@ -0,0 +1,252 @@
+package payrate
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	payRateUseCase "caregiver/src/application/usecases/payrate"
+	domainErrors "caregiver/src/domain/errors"
+	domainPayRate "caregiver/src/domain/payrate"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type IPayRateController interface {
+	CreatePayRate(ctx *gin.Context)
+	GetPayRates(ctx *gin.Context)
+	GetPayRateByID(ctx *gin.Context)
+	UpdatePayRate(ctx *gin.Context)
+	DeletePayRate(ctx *gin.Context)
+	GetEffectiveRate(ctx *gin.Context)
+}
+
+type Controller struct {
+	payRateUseCase payRateUseCase.IPayRateUseCase
+	Logger         *logger.Logger
+}
+
+func NewPayRateController(payRateUseCase payRateUseCase.IPayRateUseCase, loggerInstance *logger.Logger) IPayRateController {
+	return &Controller{payRateUseCase: payRateUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreatePayRate(ctx *gin.Context) {
+	var request CreatePayRateRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new pay rate", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	rate := &domainPayRate.PayRate{
+		CaregiverUserID: request.CaregiverUserID,
+		ServiceName:     request.ServiceName,
+		HourlyRate:      request.HourlyRate,
+	}
+	var ok bool
+	if rate.EffectiveFrom, ok = c.parseDate(ctx, "effective from", request.EffectiveFrom); !ok {
+		return
+	}
+	if request.EffectiveTo != "" {
+		effectiveTo, ok := c.parseDate(ctx, "effective to", request.EffectiveTo)
+		if !ok {
+			return
+		}
+		rate.EffectiveTo = &effectiveTo
+	}
+
+	created, err := c.payRateUseCase.Create(rate)
+	if err != nil {
+		c.Logger.Error("Error creating pay rate", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Pay rate created successfully", zap.String("payRateID", created.ID.String()))
+	ctx.JSON(http.StatusOK, toResponseMapper(created))
+}
+
+// GetPayRates lists pay rates, optionally for one "caregiverID".
+func (c *Controller) GetPayRates(ctx *gin.Context) {
+	var caregiverID *uuid.UUID
+	if value := ctx.Query("caregiverID"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			c.Logger.Error("Invalid caregiver ID for pay rates", zap.Error(err), zap.String("caregiverID", value))
+			appError := domainErrors.NewAppError(errors.New("caregiver id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		caregiverID = &parsed
+	}
+	rates, err := c.payRateUseCase.GetAll(caregiverID)
+	if err != nil {
+		c.Logger.Error("Error getting pay rates", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]PayRateResponse, len(*rates))
+	for i := range *rates {
+		res[i] = *toResponseMapper(&(*rates)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetPayRateByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	rate, err := c.payRateUseCase.GetByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting pay rate by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toResponseMapper(rate))
+}
+
+func (c *Controller) UpdatePayRate(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request UpdatePayRateRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for pay rate update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.ServiceName != nil {
+		updates["service_name"] = *request.ServiceName
+	}
+	if request.HourlyRate != nil {
+		updates["hourly_rate"] = *request.HourlyRate
+	}
+	if request.EffectiveFrom != nil {
+		effectiveFrom, ok := c.parseDate(ctx, "effective from", *request.EffectiveFrom)
+		if !ok {
+			return
+		}
+		updates["effective_from"] = effectiveFrom
+	}
+	if request.EffectiveTo != nil {
+		updates["effective_to"] = nil
+		if *request.EffectiveTo != "" {
+			effectiveTo, ok := c.parseDate(ctx, "effective to", *request.EffectiveTo)
+			if !ok {
+				return
+			}
+			updates["effective_to"] = effectiveTo
+		}
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updated, err := c.payRateUseCase.Update(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating pay rate", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Pay rate updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, toResponseMapper(updated))
+}
+
+func (c *Controller) DeletePayRate(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	if err := c.payRateUseCase.Delete(id); err != nil {
+		c.Logger.Error("Error deleting pay rate", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Pay rate deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetEffectiveRate resolves the hourly pay of "caregiverID" for
+// "serviceName" on "date" (YYYY-MM-DD, defaulting to today).
+func (c *Controller) GetEffectiveRate(ctx *gin.Context) {
+	caregiverID, err := uuid.Parse(ctx.Query("caregiverID"))
+	if err != nil {
+		c.Logger.Error("Invalid caregiver ID for effective pay rate", zap.Error(err), zap.String("caregiverID", ctx.Query("caregiverID")))
+		appError := domainErrors.NewAppError(errors.New("caregiver id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	day := time.Now().UTC()
+	if value := ctx.Query("date"); value != "" {
+		var ok bool
+		if day, ok = c.parseDate(ctx, "date", value); !ok {
+			return
+		}
+	}
+	rate, err := c.payRateUseCase.RateFor(caregiverID, ctx.Query("serviceName"), day)
+	if err != nil {
+		c.Logger.Error("Error resolving effective pay rate", zap.Error(err), zap.String("caregiverID", caregiverID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, EffectiveRateResponse{
+		CaregiverUserID: rate.CaregiverUserID,
+		ServiceName:     rate.ServiceName,
+		Date:            rate.Date.Format(dateLayout),
+		HourlyRate:      rate.HourlyRate,
+		Source:          rate.Source,
+		PayRateID:       rate.PayRateID,
+	})
+}
+
+func (c *Controller) parseID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid pay rate ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("pay rate id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func toResponseMapper(r *domainPayRate.PayRate) *PayRateResponse {
+	res := &PayRateResponse{
+		ID:              r.ID,
+		CaregiverUserID: r.CaregiverUserID,
+		ServiceName:     r.ServiceName,
+		HourlyRate:      r.HourlyRate,
+		EffectiveFrom:   r.EffectiveFrom.Format(dateLayout),
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+	if r.EffectiveTo != nil {
+		effectiveTo := r.EffectiveTo.Format(dateLayout)
+		res.EffectiveTo = &effectiveTo
+	}
+	return res
+}
@@ -0,0 +1,46 @@
+package payrate
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreatePayRateRequest takes effective dates as YYYY-MM-DD. An empty
+// ServiceName makes it the caregiver's general rate.
+type CreatePayRateRequest struct {
+	CaregiverUserID uuid.UUID `json:"CaregiverUserID" binding:"required"`
+	ServiceName     string    `json:"ServiceName"`
+	HourlyRate      float64   `json:"HourlyRate" binding:"required"`
+	EffectiveFrom   string    `json:"EffectiveFrom" binding:"required"`
+	EffectiveTo     string    `json:"EffectiveTo"`
+}
+
+// UpdatePayRateRequest clears the end date when EffectiveTo is an empty
+// string.
+type UpdatePayRateRequest struct {
+	ServiceName   *string  `json:"ServiceName"`
+	HourlyRate    *float64 `json:"HourlyRate"`
+	EffectiveFrom *string  `json:"EffectiveFrom"`
+	EffectiveTo   *string  `json:"EffectiveTo"`
+}
+
+type PayRateResponse struct {
+	ID              uuid.UUID `json:"ID"`
+	CaregiverUserID uuid.UUID `json:"CaregiverUserID"`
+	ServiceName     string    `json:"ServiceName"`
+	HourlyRate      float64   `json:"HourlyRate"`
+	EffectiveFrom   string    `json:"EffectiveFrom"`
+	EffectiveTo     *string   `json:"EffectiveTo"`
+	CreatedAt       time.Time `json:"CreatedAt"`
+	UpdatedAt       time.Time `json:"UpdatedAt"`
+}
+
+type EffectiveRateResponse struct {
+	CaregiverUserID uuid.UUID  `json:"CaregiverUserID"`
+	ServiceName     string     `json:"ServiceName"`
+	Date            string     `json:"Date"`
+	HourlyRate      float64    `json:"HourlyRate"`
+	Source          string     `json:"Source"`
+	PayRateID       *uuid.UUID `json:"PayRateID"`
+}
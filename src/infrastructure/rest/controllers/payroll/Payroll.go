@@ -0,0 +1,181 @@
+package payroll
+
+import (
+	"errors"
+	"net/http"
+
+	payrollUseCase "caregiver/src/application/usecases/payroll"
+	domainErrors "caregiver/src/domain/errors"
+	domainPayroll "caregiver/src/domain/payroll"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IPayrollController interface {
+	ClosePeriod(ctx *gin.Context)
+	GetPeriods(ctx *gin.Context)
+	GetPeriod(ctx *gin.Context)
+	ReopenPeriod(ctx *gin.Context)
+	UnlockVisit(ctx *gin.Context)
+	GetUnlocks(ctx *gin.Context)
+}
+
+type Controller struct {
+	payrollUseCase payrollUseCase.IPayrollUseCase
+	Logger         *logger.Logger
+}
+
+func NewPayrollController(payrollUseCase payrollUseCase.IPayrollUseCase, loggerInstance *logger.Logger) IPayrollController {
+	return &Controller{payrollUseCase: payrollUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) ClosePeriod(ctx *gin.Context) {
+	var request ClosePeriodRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for payroll period", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	period, err := c.payrollUseCase.ClosePeriod(request.From.UTC(), request.To.UTC(), controllers.CallerID(ctx))
+	if err != nil {
+		c.Logger.Error("Error closing payroll period", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Payroll period closed", zap.String("id", period.ID.String()))
+	ctx.JSON(http.StatusCreated, periodToResponseMapper(period))
+}
+
+func (c *Controller) GetPeriods(ctx *gin.Context) {
+	periods, err := c.payrollUseCase.GetPeriods()
+	if err != nil {
+		c.Logger.Error("Error getting payroll periods", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]PeriodResponse, len(*periods))
+	for i := range *periods {
+		res[i] = *periodToResponseMapper(&(*periods)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetPeriod(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "period")
+	if !ok {
+		return
+	}
+	period, err := c.payrollUseCase.GetPeriod(id)
+	if err != nil {
+		c.Logger.Error("Error getting payroll period", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, periodToResponseMapper(period))
+}
+
+func (c *Controller) ReopenPeriod(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "period")
+	if !ok {
+		return
+	}
+	var request ReasonRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for payroll reopen", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	period, err := c.payrollUseCase.ReopenPeriod(id, request.Reason, controllers.CallerID(ctx))
+	if err != nil {
+		c.Logger.Error("Error reopening payroll period", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Payroll period reopened", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, periodToResponseMapper(period))
+}
+
+// UnlockVisit lets an admin edit one visit of a closed payroll period for the
+// next 24 hours.
+func (c *Controller) UnlockVisit(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	var request ReasonRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for payroll unlock", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	unlock, err := c.payrollUseCase.UnlockVisit(scheduleID, request.Reason, controllers.CallerID(ctx))
+	if err != nil {
+		c.Logger.Error("Error unlocking visit", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Visit unlocked for payroll correction", zap.String("scheduleID", scheduleID.String()))
+	ctx.JSON(http.StatusCreated, unlockToResponseMapper(unlock))
+}
+
+func (c *Controller) GetUnlocks(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	unlocks, err := c.payrollUseCase.GetUnlocks(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting payroll unlocks", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]UnlockResponse, len(*unlocks))
+	for i := range *unlocks {
+		res[i] = *unlockToResponseMapper(&(*unlocks)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func periodToResponseMapper(period *domainPayroll.Period) *PeriodResponse {
+	return &PeriodResponse{
+		ID:           period.ID,
+		From:         period.From,
+		To:           period.To,
+		Status:       period.Status,
+		ClosedBy:     period.ClosedBy,
+		ClosedAt:     period.ClosedAt,
+		ReopenedBy:   period.ReopenedBy,
+		ReopenedAt:   period.ReopenedAt,
+		ReopenReason: period.ReopenReason,
+	}
+}
+
+func unlockToResponseMapper(unlock *domainPayroll.Unlock) *UnlockResponse {
+	return &UnlockResponse{
+		ID:         unlock.ID,
+		ScheduleID: unlock.ScheduleID,
+		PeriodID:   unlock.PeriodID,
+		Reason:     unlock.Reason,
+		UnlockedBy: unlock.UnlockedBy,
+		UnlockedAt: unlock.UnlockedAt,
+		ExpiresAt:  unlock.ExpiresAt,
+	}
+}
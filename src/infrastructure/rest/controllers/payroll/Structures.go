@@ -0,0 +1,39 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClosePeriodRequest closes payroll over [From, To).
+type ClosePeriodRequest struct {
+	From time.Time `json:"From" binding:"required"`
+	To   time.Time `json:"To" binding:"required"`
+}
+
+type ReasonRequest struct {
+	Reason string `json:"Reason" binding:"required"`
+}
+
+type PeriodResponse struct {
+	ID           uuid.UUID  `json:"ID"`
+	From         time.Time  `json:"From"`
+	To           time.Time  `json:"To"`
+	Status       string     `json:"Status"`
+	ClosedBy     *uuid.UUID `json:"ClosedBy"`
+	ClosedAt     time.Time  `json:"ClosedAt"`
+	ReopenedBy   *uuid.UUID `json:"ReopenedBy,omitempty"`
+	ReopenedAt   *time.Time `json:"ReopenedAt,omitempty"`
+	ReopenReason string     `json:"ReopenReason,omitempty"`
+}
+
+type UnlockResponse struct {
+	ID         uuid.UUID  `json:"ID"`
+	ScheduleID uuid.UUID  `json:"ScheduleID"`
+	PeriodID   uuid.UUID  `json:"PeriodID"`
+	Reason     string     `json:"Reason"`
+	UnlockedBy *uuid.UUID `json:"UnlockedBy"`
+	UnlockedAt time.Time  `json:"UnlockedAt"`
+	ExpiresAt  time.Time  `json:"ExpiresAt"`
+}
@@ -0,0 +1,377 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	plannerUseCase "caregiver/src/application/usecases/planner"
+	domainErrors "caregiver/src/domain/errors"
+	domainPlanner "caregiver/src/domain/planner"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+)
+
+type IPlannerController interface {
+	GetTemplates(ctx *gin.Context)
+	SaveTemplate(ctx *gin.Context)
+	DeleteTemplate(ctx *gin.Context)
+	GetAvailability(ctx *gin.Context)
+	SetAvailability(ctx *gin.Context)
+	Propose(ctx *gin.Context)
+	GetProposals(ctx *gin.Context)
+	GetProposal(ctx *gin.Context)
+	UpdateAssignment(ctx *gin.Context)
+	Commit(ctx *gin.Context)
+	Discard(ctx *gin.Context)
+}
+
+type Controller struct {
+	plannerUseCase plannerUseCase.IPlannerUseCase
+	Logger         *logger.Logger
+}
+
+func NewPlannerController(plannerUseCase plannerUseCase.IPlannerUseCase, loggerInstance *logger.Logger) IPlannerController {
+	return &Controller{plannerUseCase: plannerUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetTemplates(ctx *gin.Context) {
+	templates, err := c.plannerUseCase.GetTemplates()
+	if err != nil {
+		c.Logger.Error("Error getting demand templates", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]TemplateResponse, len(*templates))
+	for i := range *templates {
+		res[i] = *templateToResponseMapper(&(*templates)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// SaveTemplate sets how the client's care plan visits are booked by the
+// planner, replacing any earlier template.
+func (c *Controller) SaveTemplate(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "clientID", "client")
+	if !ok {
+		return
+	}
+	var request SaveTemplateRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for demand template", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	startMinute, ok := c.parseClock(ctx, "start time", request.StartTime)
+	if !ok {
+		return
+	}
+	template, err := c.plannerUseCase.SaveTemplate(&domainPlanner.Template{
+		ClientUserID:    clientID,
+		ServiceName:     request.ServiceName,
+		DurationMinutes: request.DurationMinutes,
+		StartMinute:     startMinute,
+		Weekdays:        request.Weekdays,
+		Timezone:        request.Timezone,
+	})
+	if err != nil {
+		c.Logger.Error("Error saving demand template", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Demand template saved", zap.String("clientID", clientID.String()))
+	ctx.JSON(http.StatusOK, templateToResponseMapper(template))
+}
+
+func (c *Controller) DeleteTemplate(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "clientID", "client")
+	if !ok {
+		return
+	}
+	if err := c.plannerUseCase.DeleteTemplate(clientID); err != nil {
+		c.Logger.Error("Error deleting demand template", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Demand template deleted", zap.String("clientID", clientID.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) GetAvailability(ctx *gin.Context) {
+	caregiverID, ok := c.parseID(ctx, "caregiverID", "caregiver")
+	if !ok {
+		return
+	}
+	windows, err := c.plannerUseCase.GetAvailability(caregiverID)
+	if err != nil {
+		c.Logger.Error("Error getting caregiver availability", zap.Error(err), zap.String("caregiverID", caregiverID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, availabilityToResponseMapper(windows))
+}
+
+// SetAvailability replaces all of the caregiver's weekly windows. An empty
+// list takes the caregiver out of planning.
+func (c *Controller) SetAvailability(ctx *gin.Context) {
+	caregiverID, ok := c.parseID(ctx, "caregiverID", "caregiver")
+	if !ok {
+		return
+	}
+	var request SetAvailabilityRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for caregiver availability", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	windows := make([]domainPlanner.Availability, len(request.Windows))
+	for i, window := range request.Windows {
+		windows[i] = domainPlanner.Availability{Weekday: window.Weekday, Timezone: window.Timezone}
+		if windows[i].StartMinute, ok = c.parseClock(ctx, "start time", window.StartTime); !ok {
+			return
+		}
+		if windows[i].EndMinute, ok = c.parseClock(ctx, "end time", window.EndTime); !ok {
+			return
+		}
+	}
+	saved, err := c.plannerUseCase.SetAvailability(caregiverID, windows)
+	if err != nil {
+		c.Logger.Error("Error setting caregiver availability", zap.Error(err), zap.String("caregiverID", caregiverID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Caregiver availability set", zap.String("caregiverID", caregiverID.String()), zap.Int("windows", len(*saved)))
+	ctx.JSON(http.StatusOK, availabilityToResponseMapper(saved))
+}
+
+// Propose plans the visits care plans still need in a week and returns the
+// draft for review. Nothing is booked until it is committed.
+func (c *Controller) Propose(ctx *gin.Context) {
+	var request ProposeRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := controllers.BindJSON(ctx, &request); err != nil {
+			c.Logger.Error("Error binding JSON for schedule proposal", zap.Error(err))
+			appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+	}
+	now := time.Now().UTC()
+	weekOf := now.AddDate(0, 0, 7)
+	if request.Week != "" {
+		day, err := time.Parse(dateLayout, request.Week)
+		if err != nil {
+			c.Logger.Error("Invalid week", zap.Error(err), zap.String("value", request.Week))
+			appError := domainErrors.NewAppError(errors.New("week must be YYYY-MM-DD"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		weekOf = day
+	}
+	proposal, err := c.plannerUseCase.Propose(weekOf, controllers.CallerID(ctx), now)
+	if err != nil {
+		c.Logger.Error("Error proposing schedule plan", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Schedule plan proposed", zap.String("id", proposal.ID.String()))
+	ctx.JSON(http.StatusCreated, proposalToResponseMapper(proposal))
+}
+
+func (c *Controller) GetProposals(ctx *gin.Context) {
+	proposals, err := c.plannerUseCase.GetProposals()
+	if err != nil {
+		c.Logger.Error("Error getting schedule proposals", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ProposalResponse, len(*proposals))
+	for i := range *proposals {
+		res[i] = *proposalToResponseMapper(&(*proposals)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetProposal(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "proposal")
+	if !ok {
+		return
+	}
+	proposal, err := c.plannerUseCase.GetProposal(id)
+	if err != nil {
+		c.Logger.Error("Error getting schedule proposal", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, proposalToResponseMapper(proposal))
+}
+
+// UpdateAssignment rejects a proposed visit or gives it to another caregiver.
+func (c *Controller) UpdateAssignment(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "proposal")
+	if !ok {
+		return
+	}
+	assignmentID, ok := c.parseID(ctx, "assignmentID", "assignment")
+	if !ok {
+		return
+	}
+	var request UpdateAssignmentRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for assignment update", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	assignment, err := c.plannerUseCase.UpdateAssignment(id, assignmentID, plannerUseCase.AssignmentUpdate{
+		Accepted:        request.Accepted,
+		CaregiverUserID: request.CaregiverUserID,
+	})
+	if err != nil {
+		c.Logger.Error("Error updating proposal assignment", zap.Error(err), zap.String("assignmentID", assignmentID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, assignmentToResponseMapper(assignment))
+}
+
+// Commit books every accepted assignment. Visits the schedule validators
+// reject carry the reason in Error.
+func (c *Controller) Commit(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "proposal")
+	if !ok {
+		return
+	}
+	proposal, err := c.plannerUseCase.Commit(id, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error committing schedule proposal", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Schedule proposal committed", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, proposalToResponseMapper(proposal))
+}
+
+func (c *Controller) Discard(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "proposal")
+	if !ok {
+		return
+	}
+	proposal, err := c.plannerUseCase.Discard(id)
+	if err != nil {
+		c.Logger.Error("Error discarding schedule proposal", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Schedule proposal discarded", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, proposalToResponseMapper(proposal))
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseClock turns an "HH:MM" time into minutes after midnight; "24:00" is
+// the end of the day.
+func (c *Controller) parseClock(ctx *gin.Context, name string, value string) (int, bool) {
+	if value == "24:00" {
+		return 24 * 60, true
+	}
+	t, err := time.Parse(clockLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be HH:MM"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func templateToResponseMapper(t *domainPlanner.Template) *TemplateResponse {
+	return &TemplateResponse{
+		ID:              t.ID,
+		ClientUserID:    t.ClientUserID,
+		ServiceName:     t.ServiceName,
+		DurationMinutes: t.DurationMinutes,
+		StartTime:       formatClock(t.StartMinute),
+		Weekdays:        t.Weekdays,
+		Timezone:        t.Timezone,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+	}
+}
+
+func availabilityToResponseMapper(windows *[]domainPlanner.Availability) []AvailabilityWindow {
+	res := make([]AvailabilityWindow, len(*windows))
+	for i, w := range *windows {
+		res[i] = AvailabilityWindow{
+			Weekday:   w.Weekday,
+			StartTime: formatClock(w.StartMinute),
+			EndTime:   formatClock(w.EndMinute),
+			Timezone:  w.Timezone,
+		}
+	}
+	return res
+}
+
+func assignmentToResponseMapper(a *domainPlanner.Assignment) *AssignmentResponse {
+	return &AssignmentResponse{
+		ID:              a.ID,
+		ClientUserID:    a.ClientUserID,
+		CaregiverUserID: a.CaregiverUserID,
+		ServiceName:     a.ServiceName,
+		From:            a.From,
+		To:              a.To,
+		DistanceKm:      a.DistanceKm,
+		Warnings:        a.Warnings,
+		Accepted:        a.Accepted,
+		ScheduleID:      a.ScheduleID,
+		Error:           a.Error,
+	}
+}
+
+func proposalToResponseMapper(p *domainPlanner.Proposal) *ProposalResponse {
+	res := &ProposalResponse{
+		ID:                p.ID,
+		WeekStart:         p.WeekStart,
+		WeekEnd:           p.WeekEnd,
+		Status:            p.Status,
+		Solver:            p.Solver,
+		Assignments:       make([]AssignmentResponse, len(p.Assignments)),
+		Unplaced:          make([]UnplacedResponse, len(p.Unplaced)),
+		CreatedByUserID:   p.CreatedByUserID,
+		CreatedAt:         p.CreatedAt,
+		CommittedByUserID: p.CommittedByUserID,
+		CommittedAt:       p.CommittedAt,
+	}
+	for i := range p.Assignments {
+		res.Assignments[i] = *assignmentToResponseMapper(&p.Assignments[i])
+	}
+	for i, u := range p.Unplaced {
+		res.Unplaced[i] = UnplacedResponse{ClientUserID: u.ClientUserID, Visits: u.Visits, Reason: u.Reason}
+	}
+	return res
+}
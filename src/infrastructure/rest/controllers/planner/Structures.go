@@ -0,0 +1,88 @@
+package planner
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SaveTemplateRequest takes the visit start as a local "HH:MM" time in
+// Timezone (default UTC). Weekdays run from 0 (Sunday) to 6 (Saturday) and
+// default to every day.
+type SaveTemplateRequest struct {
+	ServiceName     string `json:"ServiceName" binding:"required"`
+	DurationMinutes int    `json:"DurationMinutes" binding:"required"`
+	StartTime       string `json:"StartTime" binding:"required"`
+	Weekdays        []int  `json:"Weekdays"`
+	Timezone        string `json:"Timezone"`
+}
+
+type TemplateResponse struct {
+	ID              uuid.UUID `json:"ID"`
+	ClientUserID    uuid.UUID `json:"ClientUserID"`
+	ServiceName     string    `json:"ServiceName"`
+	DurationMinutes int       `json:"DurationMinutes"`
+	StartTime       string    `json:"StartTime"`
+	Weekdays        []int     `json:"Weekdays"`
+	Timezone        string    `json:"Timezone"`
+	CreatedAt       time.Time `json:"CreatedAt"`
+	UpdatedAt       time.Time `json:"UpdatedAt"`
+}
+
+// AvailabilityWindow is a weekly window as local "HH:MM" times; an EndTime of
+// "24:00" runs to midnight.
+type AvailabilityWindow struct {
+	Weekday   int    `json:"Weekday"`
+	StartTime string `json:"StartTime" binding:"required"`
+	EndTime   string `json:"EndTime" binding:"required"`
+	Timezone  string `json:"Timezone"`
+}
+
+type SetAvailabilityRequest struct {
+	Windows []AvailabilityWindow `json:"Windows"`
+}
+
+// ProposeRequest picks the week to plan by any date in it (YYYY-MM-DD),
+// by default next week.
+type ProposeRequest struct {
+	Week string `json:"Week"`
+}
+
+type UpdateAssignmentRequest struct {
+	Accepted        *bool      `json:"Accepted"`
+	CaregiverUserID *uuid.UUID `json:"CaregiverUserID"`
+}
+
+type AssignmentResponse struct {
+	ID              uuid.UUID  `json:"ID"`
+	ClientUserID    uuid.UUID  `json:"ClientUserID"`
+	CaregiverUserID uuid.UUID  `json:"CaregiverUserID"`
+	ServiceName     string     `json:"ServiceName"`
+	From            time.Time  `json:"From"`
+	To              time.Time  `json:"To"`
+	DistanceKm      *float64   `json:"DistanceKm,omitempty"`
+	Warnings        []string   `json:"Warnings,omitempty"`
+	Accepted        bool       `json:"Accepted"`
+	ScheduleID      *uuid.UUID `json:"ScheduleID,omitempty"`
+	Error           string     `json:"Error,omitempty"`
+}
+
+type UnplacedResponse struct {
+	ClientUserID uuid.UUID `json:"ClientUserID"`
+	Visits       int       `json:"Visits"`
+	Reason       string    `json:"Reason"`
+}
+
+type ProposalResponse struct {
+	ID                uuid.UUID            `json:"ID"`
+	WeekStart         time.Time            `json:"WeekStart"`
+	WeekEnd           time.Time            `json:"WeekEnd"`
+	Status            string               `json:"Status"`
+	Solver            string               `json:"Solver"`
+	Assignments       []AssignmentResponse `json:"Assignments"`
+	Unplaced          []UnplacedResponse   `json:"Unplaced"`
+	CreatedByUserID   *uuid.UUID           `json:"CreatedByUserID,omitempty"`
+	CreatedAt         time.Time            `json:"CreatedAt"`
+	CommittedByUserID *uuid.UUID           `json:"CommittedByUserID,omitempty"`
+	CommittedAt       *time.Time           `json:"CommittedAt,omitempty"`
+}
@@ -0,0 +1,224 @@
+package profilechange
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	profileChangeUseCase "caregiver/src/application/usecases/profilechange"
+	domainErrors "caregiver/src/domain/errors"
+	domainProfileChange "caregiver/src/domain/profilechange"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IProfileChangeController interface {
+	UpdateOwnProfile(ctx *gin.Context)
+	GetChanges(ctx *gin.Context)
+	GetChangeByID(ctx *gin.Context)
+	ApproveChange(ctx *gin.Context)
+	RejectChange(ctx *gin.Context)
+}
+
+type Controller struct {
+	profileChangeUseCase profileChangeUseCase.IProfileChangeUseCase
+	Logger               *logger.Logger
+}
+
+func NewProfileChangeController(profileChangeUseCase profileChangeUseCase.IProfileChangeUseCase, loggerInstance *logger.Logger) IProfileChangeController {
+	return &Controller{profileChangeUseCase: profileChangeUseCase, Logger: loggerInstance}
+}
+
+// UpdateOwnProfile lets a logged-in caregiver change their own profile; the
+// caregiver in the path must be the caller.
+func (c *Controller) UpdateOwnProfile(ctx *gin.Context) {
+	callerID := controllers.CallerID(ctx)
+	if callerID == nil {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("log in to update your profile"), domainErrors.NotAuthenticated))
+		return
+	}
+	userID, ok := c.parseID(ctx, "userID", "caregiver")
+	if !ok {
+		return
+	}
+	if userID != *callerID {
+		c.Logger.Warn("Profile update for another caregiver refused", zap.String("userID", userID.String()), zap.String("callerID", callerID.String()))
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("caregivers can only update their own profile"), domainErrors.NotAuthorized))
+		return
+	}
+	var request UpdateProfileRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for profile update", zap.Error(err), zap.String("userID", userID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	update := domainProfileChange.Update{Phone: request.Phone, ProfilePicture: request.ProfilePicture}
+	if request.Location != nil {
+		location := locationToDomainMapper(*request.Location)
+		update.Location = &location
+	}
+	result, err := c.profileChangeUseCase.UpdateOwnProfile(userID, update)
+	if err != nil {
+		c.Logger.Error("Error updating own profile", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Profile updated", zap.String("userID", userID.String()), zap.Bool("pendingApproval", result.Pending != nil))
+	ctx.JSON(http.StatusOK, resultToResponseMapper(result))
+}
+
+func (c *Controller) GetChanges(ctx *gin.Context) {
+	var filter domainProfileChange.Filter
+	if value := ctx.Query("userID"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			c.Logger.Error("Invalid user ID for profile changes", zap.Error(err), zap.String("userID", value))
+			appError := domainErrors.NewAppError(errors.New("user id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		filter.UserID = &parsed
+	}
+	if status := ctx.Query("status"); status != "" {
+		filter.Statuses = []string{status}
+	}
+	changes, err := c.profileChangeUseCase.GetAll(filter)
+	if err != nil {
+		c.Logger.Error("Error getting profile changes", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ChangeResponse, len(*changes))
+	for i := range *changes {
+		res[i] = *changeToResponseMapper(&(*changes)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetChangeByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "profile change")
+	if !ok {
+		return
+	}
+	change, err := c.profileChangeUseCase.GetByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting profile change", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, changeToResponseMapper(change))
+}
+
+func (c *Controller) ApproveChange(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "profile change")
+	if !ok {
+		return
+	}
+	var request DecideChangeRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for profile change approval", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	approved, err := c.profileChangeUseCase.Approve(id, request.DecidedByUserID, request.Note, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error approving profile change", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Profile change approved", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, changeToResponseMapper(approved))
+}
+
+func (c *Controller) RejectChange(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "profile change")
+	if !ok {
+		return
+	}
+	var request DecideChangeRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for profile change rejection", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	rejected, err := c.profileChangeUseCase.Reject(id, request.DecidedByUserID, request.Note, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error rejecting profile change", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Profile change rejected", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, changeToResponseMapper(rejected))
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func resultToResponseMapper(result *domainProfileChange.Result) *ProfileResponse {
+	response := &ProfileResponse{
+		ID:             result.User.ID,
+		FirstName:      result.User.FirstName,
+		LastName:       result.User.LastName,
+		Phone:          result.User.Phone,
+		ProfilePicture: result.User.ProfilePicture,
+		Location:       locationToResponseMapper(result.User.Location),
+	}
+	if result.Pending != nil {
+		response.PendingChange = changeToResponseMapper(result.Pending)
+	}
+	return response
+}
+
+func changeToResponseMapper(change *domainProfileChange.Change) *ChangeResponse {
+	return &ChangeResponse{
+		ID:              change.ID,
+		UserID:          change.UserID,
+		Location:        locationToResponseMapper(change.Location),
+		Status:          change.Status,
+		DecidedByUserID: change.DecidedByUserID,
+		DecisionNote:    change.DecisionNote,
+		DecidedAt:       change.DecidedAt,
+		CreatedAt:       change.CreatedAt,
+		UpdatedAt:       change.UpdatedAt,
+	}
+}
+
+func locationToDomainMapper(location LocationRequest) domainUser.Location {
+	return domainUser.Location{
+		HouseNumber: location.HouseNumber,
+		Street:      location.Street,
+		City:        location.City,
+		State:       location.State,
+		Pincode:     location.Pincode,
+		Lat:         location.Lat,
+		Long:        location.Long,
+	}
+}
+
+func locationToResponseMapper(location domainUser.Location) LocationRequest {
+	return LocationRequest{
+		HouseNumber: location.HouseNumber,
+		Street:      location.Street,
+		City:        location.City,
+		State:       location.State,
+		Pincode:     location.Pincode,
+		Lat:         location.Lat,
+		Long:        location.Long,
+	}
+}
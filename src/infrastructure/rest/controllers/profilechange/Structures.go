@@ -0,0 +1,56 @@
+package profilechange
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LocationRequest struct {
+	HouseNumber string  `json:"HouseNumber"`
+	Street      string  `json:"Street"`
+	City        string  `json:"City"`
+	State       string  `json:"State"`
+	Pincode     string  `json:"Pincode"`
+	Lat         float64 `json:"Lat"`
+	Long        float64 `json:"Long"`
+}
+
+// UpdateProfileRequest carries the fields a caregiver may change on their
+// own profile; omitted fields are left as they are.
+type UpdateProfileRequest struct {
+	Phone          *string          `json:"Phone"`
+	ProfilePicture *string          `json:"ProfilePicture"`
+	Location       *LocationRequest `json:"Location"`
+}
+
+// DecideChangeRequest is sent by the coordinator approving or rejecting an
+// address change. A note is required to reject.
+type DecideChangeRequest struct {
+	DecidedByUserID uuid.UUID `json:"DecidedByUserID" binding:"required"`
+	Note            string    `json:"Note"`
+}
+
+type ChangeResponse struct {
+	ID              uuid.UUID       `json:"ID"`
+	UserID          uuid.UUID       `json:"UserID"`
+	Location        LocationRequest `json:"Location"`
+	Status          string          `json:"Status"`
+	DecidedByUserID *uuid.UUID      `json:"DecidedByUserID"`
+	DecisionNote    string          `json:"DecisionNote"`
+	DecidedAt       *time.Time      `json:"DecidedAt"`
+	CreatedAt       time.Time       `json:"CreatedAt"`
+	UpdatedAt       time.Time       `json:"UpdatedAt"`
+}
+
+// ProfileResponse is the caregiver's profile after a self-service update.
+// PendingChange is set when the new address awaits a coordinator.
+type ProfileResponse struct {
+	ID             uuid.UUID       `json:"ID"`
+	FirstName      string          `json:"FirstName"`
+	LastName       string          `json:"LastName"`
+	Phone          string          `json:"Phone"`
+	ProfilePicture string          `json:"ProfilePicture"`
+	Location       LocationRequest `json:"Location"`
+	PendingChange  *ChangeResponse `json:"PendingChange,omitempty"`
+}
@@ -0,0 +1,370 @@
+package prospect
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	prospectUseCase "caregiver/src/application/usecases/prospect"
+	domainErrors "caregiver/src/domain/errors"
+	domainProspect "caregiver/src/domain/prospect"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainUser "caregiver/src/domain/user"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IProspectController interface {
+	CreateProspect(ctx *gin.Context)
+	GetProspects(ctx *gin.Context)
+	GetProspectByID(ctx *gin.Context)
+	UpdateProspect(ctx *gin.Context)
+	DeleteProspect(ctx *gin.Context)
+	ScheduleAssessment(ctx *gin.Context)
+	Quote(ctx *gin.Context)
+	Accept(ctx *gin.Context)
+	Decline(ctx *gin.Context)
+	SubmitBookingRequest(ctx *gin.Context)
+}
+
+type Controller struct {
+	prospectUseCase prospectUseCase.IProspectUseCase
+	Logger          *logger.Logger
+}
+
+func NewProspectController(prospectUseCase prospectUseCase.IProspectUseCase, loggerInstance *logger.Logger) IProspectController {
+	return &Controller{prospectUseCase: prospectUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateProspect(ctx *gin.Context) {
+	var request CreateProspectRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new prospect", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	prospect, err := c.prospectUseCase.Create(&domainProspect.Prospect{
+		FirstName:        request.FirstName,
+		LastName:         request.LastName,
+		Email:            request.Email,
+		Phone:            request.Phone,
+		Location:         toDomainLocation(&request.Location),
+		ReferralSourceID: request.ReferralSourceID,
+		CareNeeds:        request.CareNeeds,
+		Notes:            request.Notes,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating prospect", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Prospect created successfully", zap.String("prospectID", prospect.ID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(prospect))
+}
+
+// GetProspects lists prospects, optionally only those with a "status".
+func (c *Controller) GetProspects(ctx *gin.Context) {
+	prospects, err := c.prospectUseCase.GetAll(ctx.Query("status"))
+	if err != nil {
+		c.Logger.Error("Error getting prospects", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ProspectResponse, len(*prospects))
+	for i := range *prospects {
+		res[i] = *domainToResponseMapper(&(*prospects)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetProspectByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	prospect, err := c.prospectUseCase.GetByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting prospect by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(prospect))
+}
+
+func (c *Controller) UpdateProspect(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request UpdateProspectRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for prospect update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.FirstName != nil {
+		updates["first_name"] = *request.FirstName
+	}
+	if request.LastName != nil {
+		updates["last_name"] = *request.LastName
+	}
+	if request.Email != nil {
+		updates["email"] = *request.Email
+	}
+	if request.Phone != nil {
+		updates["phone"] = *request.Phone
+	}
+	if request.Location != nil {
+		updates["location_house_number"] = request.Location.HouseNumber
+		updates["location_street"] = request.Location.Street
+		updates["location_city"] = request.Location.City
+		updates["location_state"] = request.Location.State
+		updates["location_pincode"] = request.Location.Pincode
+		updates["location_lat"] = request.Location.Lat
+		updates["location_long"] = request.Location.Long
+	}
+	if request.ReferralSourceID != nil {
+		updates["referral_source_id"] = *request.ReferralSourceID
+	}
+	if request.CareNeeds != nil {
+		updates["care_needs"] = *request.CareNeeds
+	}
+	if request.Notes != nil {
+		updates["notes"] = *request.Notes
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	prospect, err := c.prospectUseCase.Update(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating prospect", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Prospect updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(prospect))
+}
+
+func (c *Controller) DeleteProspect(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	if err := c.prospectUseCase.Delete(id); err != nil {
+		c.Logger.Error("Error deleting prospect", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Prospect deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) ScheduleAssessment(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request ScheduleAssessmentRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for prospect assessment", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	prospect, err := c.prospectUseCase.ScheduleAssessment(id, request.At, request.AssessorUserID, request.Notes, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error scheduling prospect assessment", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Prospect assessment scheduled successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(prospect))
+}
+
+func (c *Controller) Quote(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request QuoteRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for prospect quote", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	prospect, err := c.prospectUseCase.Quote(id, request.HourlyRate, request.HoursPerWeek, request.Notes, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error quoting prospect", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Prospect quoted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(prospect))
+}
+
+// Accept converts a quoted prospect into a client user.
+func (c *Controller) Accept(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request AcceptRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := controllers.BindJSON(ctx, &request); err != nil {
+			c.Logger.Error("Error binding JSON for prospect acceptance", zap.Error(err), zap.String("id", id.String()))
+			appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+	}
+	prospect, err := c.prospectUseCase.Accept(id, request.UserName, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error accepting prospect", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Prospect accepted successfully", zap.String("id", id.String()), zap.String("clientUserID", prospect.ClientUserID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(prospect))
+}
+
+func (c *Controller) Decline(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request DeclineRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := controllers.BindJSON(ctx, &request); err != nil {
+			c.Logger.Error("Error binding JSON for prospect decline", zap.Error(err), zap.String("id", id.String()))
+			appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+	}
+	prospect, err := c.prospectUseCase.Decline(id, request.Reason)
+	if err != nil {
+		c.Logger.Error("Error declining prospect", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Prospect declined successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(prospect))
+}
+
+// SubmitBookingRequest takes a service request from the public booking form.
+// It needs no login; the CAPTCHA and the route's rate limit keep it usable.
+func (c *Controller) SubmitBookingRequest(ctx *gin.Context) {
+	var request BookingRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for booking request", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	preferredTimes := make([]domainSchedule.ScheduledSlot, len(request.PreferredTimes))
+	for i, slot := range request.PreferredTimes {
+		preferredTimes[i] = domainSchedule.ScheduledSlot{From: slot.From, To: slot.To}
+	}
+	prospect, err := c.prospectUseCase.SubmitBookingRequest(&domainProspect.Prospect{
+		FirstName:      request.FirstName,
+		LastName:       request.LastName,
+		Email:          request.Email,
+		Phone:          request.Phone,
+		Location:       toDomainLocation(&request.Location),
+		ServiceName:    request.ServiceName,
+		PreferredTimes: preferredTimes,
+		CareNeeds:      request.CareNeeds,
+	}, request.CaptchaToken, ctx.ClientIP(), time.Now())
+	if err != nil {
+		c.Logger.Error("Error submitting booking request", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Booking request submitted successfully", zap.String("prospectID", prospect.ID.String()))
+	ctx.JSON(http.StatusCreated, BookingRequestResponse{
+		ID:      prospect.ID,
+		Message: "Thank you, our intake team will contact you shortly",
+	})
+}
+
+func (c *Controller) parseID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid prospect ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("prospect id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func toDomainLocation(l *LocationRequest) domainUser.Location {
+	return domainUser.Location{
+		HouseNumber: l.HouseNumber,
+		Street:      l.Street,
+		City:        l.City,
+		State:       l.State,
+		Pincode:     l.Pincode,
+		Lat:         l.Lat,
+		Long:        l.Long,
+	}
+}
+
+func domainToResponseMapper(p *domainProspect.Prospect) *ProspectResponse {
+	return &ProspectResponse{
+		ID:        p.ID,
+		FirstName: p.FirstName,
+		LastName:  p.LastName,
+		Email:     p.Email,
+		Phone:     p.Phone,
+		Location: LocationResponse{
+			HouseNumber: p.Location.HouseNumber,
+			Street:      p.Location.Street,
+			City:        p.Location.City,
+			State:       p.Location.State,
+			Pincode:     p.Location.Pincode,
+			Lat:         p.Location.Lat,
+			Long:        p.Location.Long,
+		},
+		ReferralSourceID:   p.ReferralSourceID,
+		CareNeeds:          p.CareNeeds,
+		ServiceName:        p.ServiceName,
+		PreferredTimes:     slotsToResponse(p.PreferredTimes),
+		Channel:            p.Channel,
+		Notes:              p.Notes,
+		Status:             p.Status,
+		AssessmentAt:       p.AssessmentAt,
+		AssessorUserID:     p.AssessorUserID,
+		AssessmentNotes:    p.AssessmentNotes,
+		QuotedHourlyRate:   p.QuotedHourlyRate,
+		QuotedHoursPerWeek: p.QuotedHoursPerWeek,
+		QuoteNotes:         p.QuoteNotes,
+		QuotedAt:           p.QuotedAt,
+		ClientUserID:       p.ClientUserID,
+		ConvertedAt:        p.ConvertedAt,
+		DeclineReason:      p.DeclineReason,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
+
+func slotsToResponse(slots []domainSchedule.ScheduledSlot) []SlotResponse {
+	response := make([]SlotResponse, len(slots))
+	for i, slot := range slots {
+		response[i] = SlotResponse{From: slot.From, To: slot.To}
+	}
+	return response
+}
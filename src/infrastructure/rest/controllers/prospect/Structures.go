@@ -0,0 +1,132 @@
+package prospect
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LocationRequest struct {
+	HouseNumber string  `json:"HouseNumber"`
+	Street      string  `json:"Street"`
+	City        string  `json:"City"`
+	State       string  `json:"State"`
+	Pincode     string  `json:"Pincode"`
+	Lat         float64 `json:"Lat"`
+	Long        float64 `json:"Long"`
+}
+
+// CreateProspectRequest records an inquiry. An email or phone number is
+// required; the email becomes the client's login on acceptance.
+type CreateProspectRequest struct {
+	FirstName        string          `json:"FirstName" binding:"required"`
+	LastName         string          `json:"LastName" binding:"required"`
+	Email            string          `json:"Email"`
+	Phone            string          `json:"Phone"`
+	Location         LocationRequest `json:"Location"`
+	ReferralSourceID *uuid.UUID      `json:"ReferralSourceID"`
+	CareNeeds        string          `json:"CareNeeds"`
+	Notes            string          `json:"Notes"`
+}
+
+type SlotRequest struct {
+	From time.Time `json:"From" binding:"required"`
+	To   time.Time `json:"To" binding:"required"`
+}
+
+// BookingRequest is what the public booking form submits. CaptchaToken is
+// the response token of the CAPTCHA widget on the form.
+type BookingRequest struct {
+	FirstName      string          `json:"FirstName" binding:"required"`
+	LastName       string          `json:"LastName" binding:"required"`
+	Email          string          `json:"Email"`
+	Phone          string          `json:"Phone"`
+	Location       LocationRequest `json:"Location"`
+	ServiceName    string          `json:"ServiceName" binding:"required"`
+	PreferredTimes []SlotRequest   `json:"PreferredTimes" binding:"required,dive"`
+	CareNeeds      string          `json:"CareNeeds"`
+	CaptchaToken   string          `json:"CaptchaToken" binding:"required"`
+}
+
+type UpdateProspectRequest struct {
+	FirstName        *string          `json:"FirstName"`
+	LastName         *string          `json:"LastName"`
+	Email            *string          `json:"Email"`
+	Phone            *string          `json:"Phone"`
+	Location         *LocationRequest `json:"Location"`
+	ReferralSourceID *uuid.UUID       `json:"ReferralSourceID"`
+	CareNeeds        *string          `json:"CareNeeds"`
+	Notes            *string          `json:"Notes"`
+}
+
+type ScheduleAssessmentRequest struct {
+	At             time.Time  `json:"At" binding:"required"`
+	AssessorUserID *uuid.UUID `json:"AssessorUserID"`
+	Notes          string     `json:"Notes"`
+}
+
+type QuoteRequest struct {
+	HourlyRate   float64  `json:"HourlyRate" binding:"required"`
+	HoursPerWeek *float64 `json:"HoursPerWeek"`
+	Notes        string   `json:"Notes"`
+}
+
+// AcceptRequest converts the prospect into a client. UserName defaults to the
+// prospect's email.
+type AcceptRequest struct {
+	UserName string `json:"UserName"`
+}
+
+type DeclineRequest struct {
+	Reason string `json:"Reason"`
+}
+
+type LocationResponse struct {
+	HouseNumber string  `json:"HouseNumber"`
+	Street      string  `json:"Street"`
+	City        string  `json:"City"`
+	State       string  `json:"State"`
+	Pincode     string  `json:"Pincode"`
+	Lat         float64 `json:"Lat"`
+	Long        float64 `json:"Long"`
+}
+
+type SlotResponse struct {
+	From time.Time `json:"From"`
+	To   time.Time `json:"To"`
+}
+
+// BookingRequestResponse acknowledges a booking request without echoing
+// anything back to the public caller.
+type BookingRequestResponse struct {
+	ID      uuid.UUID `json:"ID"`
+	Message string    `json:"Message"`
+}
+
+type ProspectResponse struct {
+	ID                 uuid.UUID        `json:"ID"`
+	FirstName          string           `json:"FirstName"`
+	LastName           string           `json:"LastName"`
+	Email              string           `json:"Email"`
+	Phone              string           `json:"Phone"`
+	Location           LocationResponse `json:"Location"`
+	ReferralSourceID   *uuid.UUID       `json:"ReferralSourceID"`
+	CareNeeds          string           `json:"CareNeeds"`
+	ServiceName        string           `json:"ServiceName"`
+	PreferredTimes     []SlotResponse   `json:"PreferredTimes"`
+	Channel            string           `json:"Channel"`
+	Notes              string           `json:"Notes"`
+	Status             string           `json:"Status"`
+	AssessmentAt       *time.Time       `json:"AssessmentAt"`
+	AssessorUserID     *uuid.UUID       `json:"AssessorUserID"`
+	AssessmentNotes    string           `json:"AssessmentNotes"`
+	QuotedHourlyRate   *float64         `json:"QuotedHourlyRate"`
+	QuotedHoursPerWeek *float64         `json:"QuotedHoursPerWeek"`
+	QuoteNotes         string           `json:"QuoteNotes"`
+	QuotedAt           *time.Time       `json:"QuotedAt"`
+	ClientUserID       *uuid.UUID       `json:"ClientUserID"`
+	ConvertedAt        *time.Time       `json:"ConvertedAt"`
+	DeclineReason      string           `json:"DeclineReason"`
+	CreatedAt          time.Time        `json:"CreatedAt"`
+	UpdatedAt          time.Time        `json:"UpdatedAt"`
+}
@@ -0,0 +1,165 @@
+package quota
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	quotaUseCase "caregiver/src/application/usecases/quota"
+	domainErrors "caregiver/src/domain/errors"
+	domainQuota "caregiver/src/domain/quota"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// apiKeyHeader identifies the agency integration asking for its usage.
+const apiKeyHeader = "X-API-Key"
+
+type IQuotaController interface {
+	GetMyUsage(ctx *gin.Context)
+	GetUsageReport(ctx *gin.Context)
+	GetQuotas(ctx *gin.Context)
+	SaveQuota(ctx *gin.Context)
+	DeleteQuota(ctx *gin.Context)
+}
+
+type Controller struct {
+	quotaUseCase quotaUseCase.IQuotaUseCase
+	Logger       *logger.Logger
+}
+
+func NewQuotaController(quotaUseCase quotaUseCase.IQuotaUseCase, loggerInstance *logger.Logger) IQuotaController {
+	return &Controller{quotaUseCase: quotaUseCase, Logger: loggerInstance}
+}
+
+// GetMyUsage shows the calling API key where it stands against its quota
+// this month and its usage in earlier months.
+func (c *Controller) GetMyUsage(ctx *gin.Context) {
+	client := domainQuota.ClientOf(ctx.GetHeader(apiKeyHeader))
+	if client == "" {
+		appError := domainErrors.NewAppError(errors.New("the X-API-Key header is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	status, err := c.quotaUseCase.Status(client, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error getting API usage status", zap.Error(err), zap.String("client", client))
+		_ = ctx.Error(err)
+		return
+	}
+	history, err := c.quotaUseCase.History(client)
+	if err != nil {
+		c.Logger.Error("Error getting API usage history", zap.Error(err), zap.String("client", client))
+		_ = ctx.Error(err)
+		return
+	}
+	res := MyUsageResponse{Current: statusToResponseMapper(status), History: make([]UsageResponse, len(*history))}
+	for i, usage := range *history {
+		res.History[i] = UsageResponse{Month: usage.Month, Calls: usage.Calls, LastCallAt: usage.LastCallAt}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetUsageReport lists every API client's calls in a "month" (YYYY-MM,
+// default this month) against its quota, busiest first.
+func (c *Controller) GetUsageReport(ctx *gin.Context) {
+	month := ctx.DefaultQuery("month", time.Now().UTC().Format(domainQuota.MonthLayout))
+	statuses, err := c.quotaUseCase.Report(month)
+	if err != nil {
+		c.Logger.Error("Error getting API usage report", zap.Error(err), zap.String("month", month))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]StatusResponse, len(statuses))
+	for i := range statuses {
+		res[i] = statusToResponseMapper(&statuses[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetQuotas(ctx *gin.Context) {
+	quotas, err := c.quotaUseCase.GetQuotas()
+	if err != nil {
+		c.Logger.Error("Error getting API quotas", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]QuotaResponse, len(*quotas))
+	for i := range *quotas {
+		res[i] = quotaToResponseMapper(&(*quotas)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// SaveQuota sets the monthly limits of one API client, replacing any it had.
+func (c *Controller) SaveQuota(ctx *gin.Context) {
+	var request SaveQuotaRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for API quota", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	client := request.Client
+	if request.APIKey != "" {
+		client = domainQuota.ClientOf(request.APIKey)
+	}
+	quota, err := c.quotaUseCase.SaveQuota(&domainQuota.Quota{
+		Client:    client,
+		Name:      request.Name,
+		SoftLimit: request.SoftLimit,
+		HardLimit: request.HardLimit,
+	})
+	if err != nil {
+		c.Logger.Error("Error saving API quota", zap.Error(err), zap.String("client", client))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, quotaToResponseMapper(quota))
+}
+
+func (c *Controller) DeleteQuota(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("invalid quota id"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if err := c.quotaUseCase.DeleteQuota(id); err != nil {
+		c.Logger.Error("Error deleting API quota", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func quotaToResponseMapper(q *domainQuota.Quota) QuotaResponse {
+	return QuotaResponse{
+		ID:        q.ID,
+		Client:    q.Client,
+		Name:      q.Name,
+		SoftLimit: q.SoftLimit,
+		HardLimit: q.HardLimit,
+		CreatedAt: q.CreatedAt,
+		UpdatedAt: q.UpdatedAt,
+	}
+}
+
+func statusToResponseMapper(s *domainQuota.Status) StatusResponse {
+	return StatusResponse{
+		Client:        s.Client,
+		Name:          s.Name,
+		Month:         s.Month,
+		Calls:         s.Calls,
+		SoftLimit:     s.SoftLimit,
+		HardLimit:     s.HardLimit,
+		Remaining:     s.Remaining,
+		OverSoftLimit: s.OverSoftLimit,
+		OverHardLimit: s.OverHardLimit,
+		ResetsAt:      s.ResetsAt,
+	}
+}
@@ -0,0 +1,51 @@
+package quota
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SaveQuotaRequest names the client by its API key, which is not stored, or
+// by the Client fingerprint shown in usage reports.
+type SaveQuotaRequest struct {
+	APIKey    string `json:"APIKey"`
+	Client    string `json:"Client"`
+	Name      string `json:"Name"`
+	SoftLimit int64  `json:"SoftLimit"`
+	HardLimit int64  `json:"HardLimit"`
+}
+
+type QuotaResponse struct {
+	ID        uuid.UUID `json:"ID"`
+	Client    string    `json:"Client"`
+	Name      string    `json:"Name"`
+	SoftLimit int64     `json:"SoftLimit"`
+	HardLimit int64     `json:"HardLimit"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+type StatusResponse struct {
+	Client        string    `json:"Client"`
+	Name          string    `json:"Name,omitempty"`
+	Month         string    `json:"Month"`
+	Calls         int64     `json:"Calls"`
+	SoftLimit     int64     `json:"SoftLimit"`
+	HardLimit     int64     `json:"HardLimit"`
+	Remaining     *int64    `json:"Remaining"`
+	OverSoftLimit bool      `json:"OverSoftLimit"`
+	OverHardLimit bool      `json:"OverHardLimit"`
+	ResetsAt      time.Time `json:"ResetsAt"`
+}
+
+type UsageResponse struct {
+	Month      string    `json:"Month"`
+	Calls      int64     `json:"Calls"`
+	LastCallAt time.Time `json:"LastCallAt"`
+}
+
+type MyUsageResponse struct {
+	Current StatusResponse  `json:"Current"`
+	History []UsageResponse `json:"History"`
+}
@@ -0,0 +1,267 @@
+package reference
+
+import (
+	"errors"
+	"net/http"
+
+	referenceUseCase "caregiver/src/application/usecases/reference"
+	domainErrors "caregiver/src/domain/errors"
+	domainReference "caregiver/src/domain/reference"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IReferenceController interface {
+	GetReference(ctx *gin.Context)
+	GetServices(ctx *gin.Context)
+	CreateService(ctx *gin.Context)
+	UpdateService(ctx *gin.Context)
+	DeleteService(ctx *gin.Context)
+	GetTaskTemplates(ctx *gin.Context)
+	CreateTaskTemplate(ctx *gin.Context)
+	DeleteTaskTemplate(ctx *gin.Context)
+}
+
+type Controller struct {
+	referenceUseCase referenceUseCase.IReferenceUseCase
+	Logger           *logger.Logger
+}
+
+func NewReferenceController(referenceUseCase referenceUseCase.IReferenceUseCase, loggerInstance *logger.Logger) IReferenceController {
+	return &Controller{referenceUseCase: referenceUseCase, Logger: loggerInstance}
+}
+
+// GetReference returns the mobile app's reference data. The app passes the
+// versions it holds as repeated known parameters: the overall version gets
+// 304 Not Modified when nothing changed, section versions leave those
+// sections out.
+func (c *Controller) GetReference(ctx *gin.Context) {
+	known := ctx.QueryArray("known")
+	snapshot, err := c.referenceUseCase.GetReference(known)
+	if err != nil {
+		c.Logger.Error("Error getting reference data", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	if snapshot.Current(known) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.JSON(http.StatusOK, snapshotToResponseMapper(snapshot))
+}
+
+func (c *Controller) GetServices(ctx *gin.Context) {
+	services, err := c.referenceUseCase.GetServices()
+	if err != nil {
+		c.Logger.Error("Error getting services", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ServiceResponse, len(*services))
+	for i := range *services {
+		res[i] = *serviceToResponseMapper(&(*services)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) CreateService(ctx *gin.Context) {
+	var request ServiceRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for service", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	service := &domainReference.Service{
+		Name:            request.Name,
+		Description:     request.Description,
+		DurationMinutes: request.DurationMinutes,
+		Active:          request.Active == nil || *request.Active,
+	}
+	created, err := c.referenceUseCase.CreateService(service)
+	if err != nil {
+		c.Logger.Error("Error creating service", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Service created", zap.String("id", created.ID.String()))
+	ctx.JSON(http.StatusCreated, serviceToResponseMapper(created))
+}
+
+func (c *Controller) UpdateService(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "service")
+	if !ok {
+		return
+	}
+	var request UpdateServiceRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for service update", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	updates := map[string]interface{}{}
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Description != nil {
+		updates["description"] = *request.Description
+	}
+	if request.DurationMinutes != nil {
+		updates["duration_minutes"] = *request.DurationMinutes
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		appError := domainErrors.NewAppError(errors.New("no fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	service, err := c.referenceUseCase.UpdateService(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating service", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Service updated", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, serviceToResponseMapper(service))
+}
+
+func (c *Controller) DeleteService(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "service")
+	if !ok {
+		return
+	}
+	if err := c.referenceUseCase.DeleteService(id); err != nil {
+		c.Logger.Error("Error deleting service", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Service deleted", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) GetTaskTemplates(ctx *gin.Context) {
+	serviceID, ok := c.parseID(ctx, "id", "service")
+	if !ok {
+		return
+	}
+	templates, err := c.referenceUseCase.GetTaskTemplates(serviceID)
+	if err != nil {
+		c.Logger.Error("Error getting task templates", zap.Error(err), zap.String("serviceID", serviceID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]TaskTemplateResponse, len(*templates))
+	for i := range *templates {
+		res[i] = *taskTemplateToResponseMapper(&(*templates)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) CreateTaskTemplate(ctx *gin.Context) {
+	serviceID, ok := c.parseID(ctx, "id", "service")
+	if !ok {
+		return
+	}
+	var request TaskTemplateRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for task template", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	template := &domainReference.TaskTemplate{
+		ServiceID:   serviceID,
+		Title:       request.Title,
+		Description: request.Description,
+		Position:    request.Position,
+	}
+	created, err := c.referenceUseCase.CreateTaskTemplate(template)
+	if err != nil {
+		c.Logger.Error("Error creating task template", zap.Error(err), zap.String("serviceID", serviceID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Task template created", zap.String("id", created.ID.String()))
+	ctx.JSON(http.StatusCreated, taskTemplateToResponseMapper(created))
+}
+
+func (c *Controller) DeleteTaskTemplate(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "task template")
+	if !ok {
+		return
+	}
+	if err := c.referenceUseCase.DeleteTaskTemplate(id); err != nil {
+		c.Logger.Error("Error deleting task template", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Task template deleted", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func snapshotToResponseMapper(snapshot *domainReference.Snapshot) *ReferenceResponse {
+	response := &ReferenceResponse{
+		Version:      snapshot.Version,
+		Versions:     snapshot.Versions,
+		Settings:     snapshot.Settings,
+		FeatureFlags: snapshot.FeatureFlags,
+		Unchanged:    snapshot.Unchanged,
+	}
+	if snapshot.Services != nil {
+		response.Services = make([]ServiceResponse, len(snapshot.Services))
+		for i := range snapshot.Services {
+			response.Services[i] = *serviceToResponseMapper(&snapshot.Services[i])
+		}
+	}
+	if snapshot.TaskTemplates != nil {
+		response.TaskTemplates = make([]TaskTemplateResponse, len(snapshot.TaskTemplates))
+		for i := range snapshot.TaskTemplates {
+			response.TaskTemplates[i] = *taskTemplateToResponseMapper(&snapshot.TaskTemplates[i])
+		}
+	}
+	if snapshot.Statuses != nil {
+		response.Statuses = make([]StatusResponse, len(snapshot.Statuses))
+		for i, status := range snapshot.Statuses {
+			response.Statuses[i] = StatusResponse{Name: status.Name, Color: status.Color}
+		}
+	}
+	return response
+}
+
+func serviceToResponseMapper(service *domainReference.Service) *ServiceResponse {
+	return &ServiceResponse{
+		ID:              service.ID,
+		Name:            service.Name,
+		Description:     service.Description,
+		DurationMinutes: service.DurationMinutes,
+		Active:          service.Active,
+	}
+}
+
+func taskTemplateToResponseMapper(template *domainReference.TaskTemplate) *TaskTemplateResponse {
+	return &TaskTemplateResponse{
+		ID:          template.ID,
+		ServiceID:   template.ServiceID,
+		Title:       template.Title,
+		Description: template.Description,
+		Position:    template.Position,
+	}
+}
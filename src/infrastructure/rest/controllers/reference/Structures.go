@@ -0,0 +1,60 @@
+package reference
+
+import (
+	"github.com/google/uuid"
+)
+
+type ServiceRequest struct {
+	Name            string `json:"Name" binding:"required"`
+	Description     string `json:"Description"`
+	DurationMinutes int    `json:"DurationMinutes"`
+	Active          *bool  `json:"Active"`
+}
+
+// UpdateServiceRequest changes the fields that are set.
+type UpdateServiceRequest struct {
+	Name            *string `json:"Name"`
+	Description     *string `json:"Description"`
+	DurationMinutes *int    `json:"DurationMinutes"`
+	Active          *bool   `json:"Active"`
+}
+
+type TaskTemplateRequest struct {
+	Title       string `json:"Title" binding:"required"`
+	Description string `json:"Description"`
+	Position    int    `json:"Position"`
+}
+
+type ServiceResponse struct {
+	ID              uuid.UUID `json:"ID"`
+	Name            string    `json:"Name"`
+	Description     string    `json:"Description,omitempty"`
+	DurationMinutes int       `json:"DurationMinutes,omitempty"`
+	Active          bool      `json:"Active"`
+}
+
+type TaskTemplateResponse struct {
+	ID          uuid.UUID `json:"ID"`
+	ServiceID   uuid.UUID `json:"ServiceID"`
+	Title       string    `json:"Title"`
+	Description string    `json:"Description,omitempty"`
+	Position    int       `json:"Position"`
+}
+
+type StatusResponse struct {
+	Name  string `json:"Name"`
+	Color string `json:"Color"`
+}
+
+// ReferenceResponse is the mobile app's reference data. Sections listed in
+// Unchanged are omitted; the app keeps its copy of them.
+type ReferenceResponse struct {
+	Version       string                 `json:"Version"`
+	Versions      map[string]string      `json:"Versions"`
+	Services      []ServiceResponse      `json:"Services"`
+	TaskTemplates []TaskTemplateResponse `json:"TaskTemplates"`
+	Statuses      []StatusResponse       `json:"Statuses"`
+	Settings      map[string]string      `json:"Settings"`
+	FeatureFlags  []string               `json:"FeatureFlags"`
+	Unchanged     []string               `json:"Unchanged,omitempty"`
+}
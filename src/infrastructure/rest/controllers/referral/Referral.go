@@ -0,0 +1,269 @@
+package referral
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	referralUseCase "caregiver/src/application/usecases/referral"
+	domainErrors "caregiver/src/domain/errors"
+	domainReferral "caregiver/src/domain/referral"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type IReferralController interface {
+	CreateSource(ctx *gin.Context)
+	GetSources(ctx *gin.Context)
+	GetSourceByID(ctx *gin.Context)
+	UpdateSource(ctx *gin.Context)
+	DeleteSource(ctx *gin.Context)
+	AttributeClient(ctx *gin.Context)
+	GetReport(ctx *gin.Context)
+}
+
+type Controller struct {
+	referralUseCase referralUseCase.IReferralUseCase
+	Logger          *logger.Logger
+}
+
+func NewReferralController(referralUseCase referralUseCase.IReferralUseCase, loggerInstance *logger.Logger) IReferralController {
+	return &Controller{referralUseCase: referralUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateSource(ctx *gin.Context) {
+	var request CreateSourceRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new referral source", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	source, err := c.referralUseCase.CreateSource(&domainReferral.Source{
+		Name:        request.Name,
+		Type:        request.Type,
+		ContactName: request.ContactName,
+		Phone:       request.Phone,
+		Email:       request.Email,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating referral source", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Referral source created successfully", zap.String("sourceID", source.ID.String()))
+	ctx.JSON(http.StatusOK, sourceToResponseMapper(source))
+}
+
+func (c *Controller) GetSources(ctx *gin.Context) {
+	sources, err := c.referralUseCase.GetSources()
+	if err != nil {
+		c.Logger.Error("Error getting referral sources", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]SourceResponse, len(*sources))
+	for i := range *sources {
+		res[i] = *sourceToResponseMapper(&(*sources)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetSourceByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "referral source")
+	if !ok {
+		return
+	}
+	source, err := c.referralUseCase.GetSourceByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting referral source by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, sourceToResponseMapper(source))
+}
+
+func (c *Controller) UpdateSource(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "referral source")
+	if !ok {
+		return
+	}
+	var request UpdateSourceRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for referral source update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Type != nil {
+		updates["type"] = *request.Type
+	}
+	if request.ContactName != nil {
+		updates["contact_name"] = *request.ContactName
+	}
+	if request.Phone != nil {
+		updates["phone"] = *request.Phone
+	}
+	if request.Email != nil {
+		updates["email"] = *request.Email
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	source, err := c.referralUseCase.UpdateSource(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating referral source", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Referral source updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, sourceToResponseMapper(source))
+}
+
+func (c *Controller) DeleteSource(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "referral source")
+	if !ok {
+		return
+	}
+	if err := c.referralUseCase.DeleteSource(id); err != nil {
+		c.Logger.Error("Error deleting referral source", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Referral source deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// AttributeClient sets or clears the referral source of an existing client.
+func (c *Controller) AttributeClient(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	var request AttributeClientRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for referral attribution", zap.Error(err), zap.String("clientUserID", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	client, err := c.referralUseCase.Attribute(id, request.SourceID)
+	if err != nil {
+		c.Logger.Error("Error attributing client to referral source", zap.Error(err), zap.String("clientUserID", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Client referral source updated successfully", zap.String("clientUserID", id.String()))
+	ctx.JSON(http.StatusOK, AttributionResponse{ClientUserID: client.ID, ReferralSourceID: client.ReferralSourceID})
+}
+
+// GetReport breaks down the clients referred between the "from" and "to"
+// days (YYYY-MM-DD, inclusive) by source and month. It defaults to the last
+// twelve months.
+func (c *Controller) GetReport(ctx *gin.Context) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	to := today
+	if value := ctx.Query("to"); value != "" {
+		parsed, ok := c.parseDate(ctx, "to", value)
+		if !ok {
+			return
+		}
+		to = parsed
+	}
+	from := time.Date(to.Year(), to.Month()-11, 1, 0, 0, 0, 0, time.UTC)
+	if value := ctx.Query("from"); value != "" {
+		parsed, ok := c.parseDate(ctx, "from", value)
+		if !ok {
+			return
+		}
+		from = parsed
+	}
+
+	report, err := c.referralUseCase.Report(from, to.AddDate(0, 0, 1), now)
+	if err != nil {
+		c.Logger.Error("Error building referral report", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := ReportResponse{From: report.From, To: report.To, AsOf: report.AsOf, Sources: make([]SourceStatsResponse, len(report.Sources))}
+	for i, source := range report.Sources {
+		periods := make([]PeriodStatsResponse, len(source.Periods))
+		for j := range source.Periods {
+			periods[j] = periodToResponseMapper(&source.Periods[j])
+		}
+		res.Sources[i] = SourceStatsResponse{
+			SourceID:   source.SourceID,
+			SourceName: source.SourceName,
+			SourceType: source.SourceType,
+			Totals:     periodToResponseMapper(&source.Totals),
+			Periods:    periods,
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func sourceToResponseMapper(source *domainReferral.Source) *SourceResponse {
+	return &SourceResponse{
+		ID:          source.ID,
+		Name:        source.Name,
+		Type:        source.Type,
+		ContactName: source.ContactName,
+		Phone:       source.Phone,
+		Email:       source.Email,
+		Active:      source.Active,
+		CreatedAt:   source.CreatedAt,
+		UpdatedAt:   source.UpdatedAt,
+	}
+}
+
+func periodToResponseMapper(stats *domainReferral.PeriodStats) PeriodStatsResponse {
+	return PeriodStatsResponse{
+		Month:          stats.Month,
+		Referred:       stats.Referred,
+		Converted:      stats.Converted,
+		Retained:       stats.Retained,
+		ConversionRate: stats.ConversionRate,
+		RetentionRate:  stats.RetentionRate,
+	}
+}
@@ -0,0 +1,73 @@
+package referral
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateSourceRequest adds a referral source. Type is one of "hospital",
+// "physician", "website", "word_of_mouth", "agency" or "other".
+type CreateSourceRequest struct {
+	Name        string `json:"Name" binding:"required"`
+	Type        string `json:"Type" binding:"required"`
+	ContactName string `json:"ContactName"`
+	Phone       string `json:"Phone"`
+	Email       string `json:"Email"`
+}
+
+type UpdateSourceRequest struct {
+	Name        *string `json:"Name"`
+	Type        *string `json:"Type"`
+	ContactName *string `json:"ContactName"`
+	Phone       *string `json:"Phone"`
+	Email       *string `json:"Email"`
+	Active      *bool   `json:"Active"`
+}
+
+type SourceResponse struct {
+	ID          uuid.UUID `json:"ID"`
+	Name        string    `json:"Name"`
+	Type        string    `json:"Type"`
+	ContactName string    `json:"ContactName"`
+	Phone       string    `json:"Phone"`
+	Email       string    `json:"Email"`
+	Active      bool      `json:"Active"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
+}
+
+// AttributeClientRequest sets a client's referral source; a null SourceID
+// clears it.
+type AttributeClientRequest struct {
+	SourceID *uuid.UUID `json:"SourceID"`
+}
+
+type AttributionResponse struct {
+	ClientUserID     uuid.UUID  `json:"ClientUserID"`
+	ReferralSourceID *uuid.UUID `json:"ReferralSourceID"`
+}
+
+type PeriodStatsResponse struct {
+	Month          string  `json:"Month,omitempty"`
+	Referred       int     `json:"Referred"`
+	Converted      int     `json:"Converted"`
+	Retained       int     `json:"Retained"`
+	ConversionRate float64 `json:"ConversionRate"`
+	RetentionRate  float64 `json:"RetentionRate"`
+}
+
+type SourceStatsResponse struct {
+	SourceID   *uuid.UUID            `json:"SourceID"`
+	SourceName string                `json:"SourceName"`
+	SourceType string                `json:"SourceType"`
+	Totals     PeriodStatsResponse   `json:"Totals"`
+	Periods    []PeriodStatsResponse `json:"Periods"`
+}
+
+type ReportResponse struct {
+	From    time.Time             `json:"From"`
+	To      time.Time             `json:"To"`
+	AsOf    time.Time             `json:"AsOf"`
+	Sources []SourceStatsResponse `json:"Sources"`
+}
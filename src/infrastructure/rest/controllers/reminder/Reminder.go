@@ -0,0 +1,192 @@
+package reminder
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	reminderUseCase "caregiver/src/application/usecases/reminder"
+	domainErrors "caregiver/src/domain/errors"
+	domainReminder "caregiver/src/domain/reminder"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IReminderController interface {
+	GetDefaults(ctx *gin.Context)
+	SetDefaults(ctx *gin.Context)
+	GetSchedulePlan(ctx *gin.Context)
+	SetScheduleOverride(ctx *gin.Context)
+	ClearScheduleOverride(ctx *gin.Context)
+	GetPreference(ctx *gin.Context)
+	SetPreference(ctx *gin.Context)
+	Sweep(ctx *gin.Context)
+}
+
+type Controller struct {
+	reminderUseCase reminderUseCase.IReminderUseCase
+	Logger          *logger.Logger
+}
+
+func NewReminderController(reminderUseCase reminderUseCase.IReminderUseCase, loggerInstance *logger.Logger) IReminderController {
+	return &Controller{reminderUseCase: reminderUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetDefaults(ctx *gin.Context) {
+	settings, err := c.reminderUseCase.GetDefaults()
+	if err != nil {
+		c.Logger.Error("Error getting reminder defaults", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, DefaultsResponse{OffsetsMinutes: settings.OffsetsMinutes, UpdatedAt: settings.UpdatedAt})
+}
+
+func (c *Controller) SetDefaults(ctx *gin.Context) {
+	var request OffsetsRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for reminder defaults", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	settings, err := c.reminderUseCase.SetDefaults(request.OffsetsMinutes)
+	if err != nil {
+		c.Logger.Error("Error setting reminder defaults", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, DefaultsResponse{OffsetsMinutes: settings.OffsetsMinutes, UpdatedAt: settings.UpdatedAt})
+}
+
+func (c *Controller) GetSchedulePlan(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule id is invalid")
+	if !ok {
+		return
+	}
+	plan, err := c.reminderUseCase.GetPlan(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting schedule reminders", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, planToResponseMapper(plan))
+}
+
+// SetScheduleOverride replaces the default offsets for the schedule. An empty
+// list turns its reminders off.
+func (c *Controller) SetScheduleOverride(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule id is invalid")
+	if !ok {
+		return
+	}
+	var request OffsetsRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for schedule reminders", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	plan, err := c.reminderUseCase.SetOverride(scheduleID, request.OffsetsMinutes)
+	if err != nil {
+		c.Logger.Error("Error setting schedule reminders", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, planToResponseMapper(plan))
+}
+
+// ClearScheduleOverride puts the schedule back on the default offsets.
+func (c *Controller) ClearScheduleOverride(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule id is invalid")
+	if !ok {
+		return
+	}
+	plan, err := c.reminderUseCase.ClearOverride(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error clearing schedule reminders", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, planToResponseMapper(plan))
+}
+
+func (c *Controller) GetPreference(ctx *gin.Context) {
+	userID, ok := c.parseID(ctx, "userID", "user id is invalid")
+	if !ok {
+		return
+	}
+	preference, err := c.reminderUseCase.GetPreference(userID)
+	if err != nil {
+		c.Logger.Error("Error getting reminder preference", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, PreferenceResponse{UserID: preference.UserID, Enabled: preference.Enabled})
+}
+
+func (c *Controller) SetPreference(ctx *gin.Context) {
+	userID, ok := c.parseID(ctx, "userID", "user id is invalid")
+	if !ok {
+		return
+	}
+	var request PreferenceRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for reminder preference", zap.Error(err), zap.String("userID", userID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	preference, err := c.reminderUseCase.SetPreference(userID, *request.Enabled)
+	if err != nil {
+		c.Logger.Error("Error setting reminder preference", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, PreferenceResponse{UserID: preference.UserID, Enabled: preference.Enabled})
+}
+
+// Sweep sends due reminders immediately. The server also sweeps on its own
+// interval; this is for external schedulers.
+func (c *Controller) Sweep(ctx *gin.Context) {
+	result, err := c.reminderUseCase.Sweep(time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error sweeping reminders", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SweepResponse{Sent: result.Sent, Skipped: result.Skipped})
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(message), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func planToResponseMapper(plan *domainReminder.Plan) *PlanResponse {
+	res := &PlanResponse{
+		ScheduleID:     plan.ScheduleID,
+		Source:         plan.Source,
+		OffsetsMinutes: plan.OffsetsMinutes,
+		Deliveries:     make([]DeliveryResponse, len(plan.Deliveries)),
+	}
+	for i, d := range plan.Deliveries {
+		res.Deliveries[i] = DeliveryResponse{
+			OffsetMinutes:   d.OffsetMinutes,
+			RecipientUserID: d.RecipientUserID,
+			Skipped:         d.Skipped,
+			SentAt:          d.SentAt,
+		}
+	}
+	return res
+}
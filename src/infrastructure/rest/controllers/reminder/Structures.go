@@ -0,0 +1,44 @@
+package reminder
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OffsetsRequest struct {
+	OffsetsMinutes []int `json:"OffsetsMinutes" binding:"required"`
+}
+
+type PreferenceRequest struct {
+	Enabled *bool `json:"Enabled" binding:"required"`
+}
+
+type DefaultsResponse struct {
+	OffsetsMinutes []int     `json:"OffsetsMinutes"`
+	UpdatedAt      time.Time `json:"UpdatedAt"`
+}
+
+type DeliveryResponse struct {
+	OffsetMinutes   int       `json:"OffsetMinutes"`
+	RecipientUserID uuid.UUID `json:"RecipientUserID"`
+	Skipped         bool      `json:"Skipped"`
+	SentAt          time.Time `json:"SentAt"`
+}
+
+type PlanResponse struct {
+	ScheduleID     uuid.UUID          `json:"ScheduleID"`
+	Source         string             `json:"Source"`
+	OffsetsMinutes []int              `json:"OffsetsMinutes"`
+	Deliveries     []DeliveryResponse `json:"Deliveries"`
+}
+
+type PreferenceResponse struct {
+	UserID  uuid.UUID `json:"UserID"`
+	Enabled bool      `json:"Enabled"`
+}
+
+type SweepResponse struct {
+	Sent    int `json:"Sent"`
+	Skipped int `json:"Skipped"`
+}
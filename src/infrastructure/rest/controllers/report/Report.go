@@ -0,0 +1,312 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	reportUseCase "caregiver/src/application/usecases/report"
+	domainErrors "caregiver/src/domain/errors"
+	domainReport "caregiver/src/domain/report"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IReportController interface {
+	GetDefinitions(ctx *gin.Context)
+	CreateReport(ctx *gin.Context)
+	GetReports(ctx *gin.Context)
+	GetReportByID(ctx *gin.Context)
+	UpdateReport(ctx *gin.Context)
+	DeleteReport(ctx *gin.Context)
+	ExportReport(ctx *gin.Context)
+	DeliverReport(ctx *gin.Context)
+	GetReportRuns(ctx *gin.Context)
+	GetEntities(ctx *gin.Context)
+	RunQuery(ctx *gin.Context)
+}
+
+type Controller struct {
+	reportUseCase reportUseCase.IReportUseCase
+	Logger        *logger.Logger
+}
+
+func NewReportController(reportUseCase reportUseCase.IReportUseCase, loggerInstance *logger.Logger) IReportController {
+	return &Controller{reportUseCase: reportUseCase, Logger: loggerInstance}
+}
+
+// GetDefinitions lists the kinds of report that can be saved, with their
+// columns and filters.
+func (c *Controller) GetDefinitions(ctx *gin.Context) {
+	definitions := c.reportUseCase.Definitions()
+	res := make([]DefinitionResponse, len(definitions))
+	for i, definition := range definitions {
+		res[i] = DefinitionResponse{Kind: definition.Kind, Columns: definition.Columns, Filters: definition.Filters}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) CreateReport(ctx *gin.Context) {
+	var request CreateReportRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new saved report", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	report, err := c.reportUseCase.Create(&domainReport.SavedReport{
+		Name:        request.Name,
+		Kind:        request.Kind,
+		Filters:     request.Filters,
+		Columns:     request.Columns,
+		Format:      request.Format,
+		Frequency:   request.Frequency,
+		Recipients:  request.Recipients,
+		OwnerUserID: request.OwnerUserID,
+	}, time.Now())
+	if err != nil {
+		c.Logger.Error("Error saving report", zap.Error(err), zap.String("name", request.Name))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Report saved successfully", zap.String("reportID", report.ID.String()))
+	ctx.JSON(http.StatusCreated, reportToResponseMapper(report))
+}
+
+func (c *Controller) GetReports(ctx *gin.Context) {
+	reports, err := c.reportUseCase.GetAll()
+	if err != nil {
+		c.Logger.Error("Error getting saved reports", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]*ReportResponse, len(*reports))
+	for i := range *reports {
+		res[i] = reportToResponseMapper(&(*reports)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetReportByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	report, err := c.reportUseCase.GetByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting saved report", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, reportToResponseMapper(report))
+}
+
+func (c *Controller) UpdateReport(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	var request UpdateReportRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for saved report update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Filters != nil {
+		updates["filters"] = *request.Filters
+	}
+	if request.Columns != nil {
+		updates["columns"] = *request.Columns
+	}
+	if request.Format != nil {
+		updates["format"] = *request.Format
+	}
+	if request.Frequency != nil {
+		updates["frequency"] = *request.Frequency
+	}
+	if request.Recipients != nil {
+		updates["recipients"] = *request.Recipients
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	report, err := c.reportUseCase.Update(id, updates, time.Now())
+	if err != nil {
+		c.Logger.Error("Error updating saved report", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Saved report updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, reportToResponseMapper(report))
+}
+
+func (c *Controller) DeleteReport(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	if err := c.reportUseCase.Delete(id); err != nil {
+		c.Logger.Error("Error deleting saved report", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Saved report deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// ExportReport runs the saved report now and downloads it in its format.
+func (c *Controller) ExportReport(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	export, err := c.reportUseCase.Export(id, time.Now())
+	if err != nil {
+		c.Logger.Error("Error exporting saved report", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName))
+	ctx.Data(http.StatusOK, export.ContentType, export.Data)
+}
+
+// DeliverReport emails the saved report to its recipients now, outside its
+// schedule.
+func (c *Controller) DeliverReport(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	run, err := c.reportUseCase.Deliver(id, time.Now())
+	if err != nil {
+		c.Logger.Error("Error delivering saved report", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Saved report delivered successfully", zap.String("id", id.String()), zap.Int("rows", run.Rows))
+	ctx.JSON(http.StatusOK, runToResponseMapper(run))
+}
+
+// GetReportRuns lists the deliveries of a saved report, newest first.
+func (c *Controller) GetReportRuns(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	runs, err := c.reportUseCase.GetRuns(id)
+	if err != nil {
+		c.Logger.Error("Error getting saved report runs", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]*RunResponse, len(*runs))
+	for i := range *runs {
+		res[i] = runToResponseMapper(&(*runs)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetEntities lists what the report builder can query.
+func (c *Controller) GetEntities(ctx *gin.Context) {
+	entities := c.reportUseCase.Entities()
+	res := make([]EntityResponse, len(entities))
+	for i, entity := range entities {
+		fields := make([]FieldResponse, len(entity.Fields))
+		for j, field := range entity.Fields {
+			fields[j] = FieldResponse{Name: field.Name, Type: field.Type, Operators: field.Operators()}
+		}
+		res[i] = EntityResponse{Name: entity.Name, Fields: fields, Measures: entity.Measures}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RunQuery runs an ad-hoc report builder query and returns its rows.
+func (c *Controller) RunQuery(ctx *gin.Context) {
+	var request QueryRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for report query", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	query := domainReport.Query{
+		Entity:     request.Entity,
+		Dimensions: request.Dimensions,
+		Measures:   request.Measures,
+		OrderBy:    request.OrderBy,
+		Descending: request.Descending,
+		Limit:      request.Limit,
+	}
+	for _, condition := range request.Filters {
+		query.Filters = append(query.Filters, domainReport.Condition{Field: condition.Field, Operator: condition.Operator, Values: condition.Values})
+	}
+	table, err := c.reportUseCase.Query(query)
+	if err != nil {
+		c.Logger.Error("Error running report query", zap.Error(err), zap.String("entity", request.Entity))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, TableResponse{Columns: table.Columns, Rows: table.Rows})
+}
+
+func (c *Controller) parseID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid saved report ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("report id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func reportToResponseMapper(r *domainReport.SavedReport) *ReportResponse {
+	return &ReportResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		Kind:        r.Kind,
+		Filters:     r.Filters,
+		Columns:     r.Columns,
+		Format:      r.Format,
+		Frequency:   r.Frequency,
+		Recipients:  r.Recipients,
+		OwnerUserID: r.OwnerUserID,
+		Active:      r.Active,
+		NextRunAt:   r.NextRunAt,
+		LastRunAt:   r.LastRunAt,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func runToResponseMapper(r *domainReport.Run) *RunResponse {
+	return &RunResponse{
+		ID:         r.ID,
+		ReportID:   r.ReportID,
+		PeriodFrom: r.PeriodFrom,
+		PeriodTo:   r.PeriodTo,
+		Rows:       r.Rows,
+		Recipients: r.Recipients,
+		Status:     r.Status,
+		Error:      r.Error,
+		RanAt:      r.RanAt,
+	}
+}
@@ -0,0 +1,105 @@
+package report
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateReportRequest saves a report configuration. Kind is one listed by
+// GET /reports/kinds; Filters map filter names to IDs and Columns picks and
+// orders the columns, all of them when empty. Format is "csv" (the default)
+// or "json". Setting Frequency to "daily", "weekly" or "monthly" emails the
+// report to Recipients on that schedule.
+type CreateReportRequest struct {
+	Name        string            `json:"Name" binding:"required"`
+	Kind        string            `json:"Kind" binding:"required"`
+	Filters     map[string]string `json:"Filters"`
+	Columns     []string          `json:"Columns"`
+	Format      string            `json:"Format"`
+	Frequency   string            `json:"Frequency"`
+	Recipients  []string          `json:"Recipients"`
+	OwnerUserID uuid.UUID         `json:"OwnerUserID"`
+}
+
+type UpdateReportRequest struct {
+	Name       *string            `json:"Name"`
+	Filters    *map[string]string `json:"Filters"`
+	Columns    *[]string          `json:"Columns"`
+	Format     *string            `json:"Format"`
+	Frequency  *string            `json:"Frequency"`
+	Recipients *[]string          `json:"Recipients"`
+	Active     *bool              `json:"Active"`
+}
+
+type DefinitionResponse struct {
+	Kind    string   `json:"Kind"`
+	Columns []string `json:"Columns"`
+	Filters []string `json:"Filters"`
+}
+
+type ReportResponse struct {
+	ID          uuid.UUID         `json:"ID"`
+	Name        string            `json:"Name"`
+	Kind        string            `json:"Kind"`
+	Filters     map[string]string `json:"Filters"`
+	Columns     []string          `json:"Columns"`
+	Format      string            `json:"Format"`
+	Frequency   string            `json:"Frequency"`
+	Recipients  []string          `json:"Recipients"`
+	OwnerUserID uuid.UUID         `json:"OwnerUserID"`
+	Active      bool              `json:"Active"`
+	NextRunAt   *time.Time        `json:"NextRunAt"`
+	LastRunAt   *time.Time        `json:"LastRunAt"`
+	CreatedAt   time.Time         `json:"CreatedAt"`
+	UpdatedAt   time.Time         `json:"UpdatedAt"`
+}
+
+type RunResponse struct {
+	ID         uuid.UUID `json:"ID"`
+	ReportID   uuid.UUID `json:"ReportID"`
+	PeriodFrom time.Time `json:"PeriodFrom"`
+	PeriodTo   time.Time `json:"PeriodTo"`
+	Rows       int       `json:"Rows"`
+	Recipients []string  `json:"Recipients"`
+	Status     string    `json:"Status"`
+	Error      string    `json:"Error,omitempty"`
+	RanAt      time.Time `json:"RanAt"`
+}
+
+// QueryRequest is a report builder query. Entity, Dimensions, Measures and
+// filter fields must be ones listed by GET /reports/builder/entities.
+// Operators are "eq", "ne", "in", "gt", "gte", "lt" and "lte"; dates are
+// written as YYYY-MM-DD.
+type QueryRequest struct {
+	Entity     string             `json:"Entity" binding:"required"`
+	Dimensions []string           `json:"Dimensions"`
+	Measures   []string           `json:"Measures" binding:"required"`
+	Filters    []ConditionRequest `json:"Filters"`
+	OrderBy    string             `json:"OrderBy"`
+	Descending bool               `json:"Descending"`
+	Limit      int                `json:"Limit"`
+}
+
+type ConditionRequest struct {
+	Field    string   `json:"Field" binding:"required"`
+	Operator string   `json:"Operator" binding:"required"`
+	Values   []string `json:"Values"`
+}
+
+type FieldResponse struct {
+	Name      string   `json:"Name"`
+	Type      string   `json:"Type"`
+	Operators []string `json:"Operators"`
+}
+
+type EntityResponse struct {
+	Name     string          `json:"Name"`
+	Fields   []FieldResponse `json:"Fields"`
+	Measures []string        `json:"Measures"`
+}
+
+type TableResponse struct {
+	Columns []string   `json:"Columns"`
+	Rows    [][]string `json:"Rows"`
+}
@@ -0,0 +1,115 @@
+package retention
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	retentionUseCase "caregiver/src/application/usecases/retention"
+	domainErrors "caregiver/src/domain/errors"
+	domainRetention "caregiver/src/domain/retention"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// defaultMonths is how many months a series covers when no range is given.
+const defaultMonths = 12
+
+type IRetentionController interface {
+	GetChurn(ctx *gin.Context)
+	GetTenure(ctx *gin.Context)
+	GetTerminationReasons(ctx *gin.Context)
+}
+
+type Controller struct {
+	retentionUseCase retentionUseCase.IRetentionUseCase
+	Logger           *logger.Logger
+}
+
+func NewRetentionController(retentionUseCase retentionUseCase.IRetentionUseCase, loggerInstance *logger.Logger) IRetentionController {
+	return &Controller{retentionUseCase: retentionUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetChurn(ctx *gin.Context) {
+	from, to, ok := c.parseRange(ctx)
+	if !ok {
+		return
+	}
+	points, err := c.retentionUseCase.Churn(from, to)
+	if err != nil {
+		c.Logger.Error("Error computing client churn", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ChurnPointResponse, len(points))
+	for i, p := range points {
+		res[i] = ChurnPointResponse(p)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetTenure(ctx *gin.Context) {
+	from, to, ok := c.parseRange(ctx)
+	if !ok {
+		return
+	}
+	points, err := c.retentionUseCase.Tenure(from, to)
+	if err != nil {
+		c.Logger.Error("Error computing client tenure", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]TenurePointResponse, len(points))
+	for i, p := range points {
+		res[i] = TenurePointResponse(p)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetTerminationReasons(ctx *gin.Context) {
+	from, to, ok := c.parseRange(ctx)
+	if !ok {
+		return
+	}
+	points, err := c.retentionUseCase.TerminationReasons(from, to)
+	if err != nil {
+		c.Logger.Error("Error computing termination reasons", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ReasonPointResponse, len(points))
+	for i, p := range points {
+		res[i] = ReasonPointResponse(p)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// parseRange reads the from and to months, written as YYYY-MM, both
+// included. Without them the series covers the last 12 months up to the
+// current one.
+func (c *Controller) parseRange(ctx *gin.Context) (time.Time, time.Time, bool) {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	from := thisMonth.AddDate(0, 1-defaultMonths, 0)
+	to := thisMonth
+	for _, param := range []struct {
+		name  string
+		value *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		raw := ctx.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(domainRetention.MonthLayout, raw)
+		if err != nil {
+			c.Logger.Error("Invalid month parameter", zap.Error(err), zap.String(param.name, raw))
+			appError := domainErrors.NewAppError(errors.New(param.name+" must be a month written as YYYY-MM"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return time.Time{}, time.Time{}, false
+		}
+		*param.value = parsed
+	}
+	return from, to.AddDate(0, 1, 0), true
+}
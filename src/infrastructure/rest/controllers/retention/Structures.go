@@ -0,0 +1,25 @@
+package retention
+
+type ChurnPointResponse struct {
+	Month         string  `json:"Month"`
+	ActiveAtStart int     `json:"ActiveAtStart"`
+	New           int     `json:"New"`
+	Churned       int     `json:"Churned"`
+	ActiveAtEnd   int     `json:"ActiveAtEnd"`
+	ChurnRate     float64 `json:"ChurnRate"`
+}
+
+type TenurePointResponse struct {
+	Month                    string  `json:"Month"`
+	ActiveClients            int     `json:"ActiveClients"`
+	AverageTenureDays        float64 `json:"AverageTenureDays"`
+	MedianTenureDays         float64 `json:"MedianTenureDays"`
+	ChurnedClients           int     `json:"ChurnedClients"`
+	ChurnedAverageTenureDays float64 `json:"ChurnedAverageTenureDays"`
+}
+
+type ReasonPointResponse struct {
+	Month   string         `json:"Month"`
+	Total   int            `json:"Total"`
+	Reasons map[string]int `json:"Reasons"`
+}
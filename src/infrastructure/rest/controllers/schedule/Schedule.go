@@ -2,9 +2,13 @@ package schedule
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	scheduleUseCase "caregiver/src/application/usecases/schedule"
+	"caregiver/src/domain"
 	domainErrors "caregiver/src/domain/errors"
 	domainSchedule "caregiver/src/domain/schedule"
 	domainUser "caregiver/src/domain/user"
@@ -16,6 +20,12 @@ import (
 	"go.uber.org/zap"
 )
 
+const dateLayout = "2006-01-02"
+
+// defaultHistoryDays is how far back task history looks when no "from" is
+// given.
+const defaultHistoryDays = 180
+
 type IScheduleController interface {
 	GetSchedules(ctx *gin.Context)
 	GetTodaySchedules(ctx *gin.Context)
@@ -25,7 +35,19 @@ type IScheduleController interface {
 	UpdateTask(ctx *gin.Context)
 	UpdateSchedule(ctx *gin.Context)
 	CreateSchedule(ctx *gin.Context)
+	CreateRecurringSchedule(ctx *gin.Context)
+	UpdateOccurrence(ctx *gin.Context)
+	UpdateFollowingOccurrences(ctx *gin.Context)
 	GetTodaySchedulesByAssignedUserID(ctx *gin.Context)
+	SearchSchedules(ctx *gin.Context)
+	DeleteSchedule(ctx *gin.Context)
+	RestoreSchedule(ctx *gin.Context)
+	DeleteTask(ctx *gin.Context)
+	RestoreTask(ctx *gin.Context)
+	GetOpenShifts(ctx *gin.Context)
+	ClaimOpenShift(ctx *gin.Context)
+	PublishSchedules(ctx *gin.Context)
+	GetClientTaskHistory(ctx *gin.Context)
 }
 
 type Controller struct {
@@ -37,16 +59,133 @@ func NewScheduleController(scheduleUseCase scheduleUseCase.IScheduleUseCase, log
 	return &Controller{scheduleUseCase: scheduleUseCase, Logger: loggerInstance}
 }
 
+// GetSchedules lists every schedule, or one page of them when any of page,
+// pageSize, sortBy or sortDirection is given. from, to, visitStatus,
+// clientUserID and assignedUserID narrow the list; visitStatus may be
+// repeated, e.g. ?from=2025-03-10&to=2025-03-16&visitStatus=cancelled.
 func (c *Controller) GetSchedules(ctx *gin.Context) {
+	filter, ok := c.parseListFilter(ctx)
+	if !ok {
+		return
+	}
+	if controllers.WantsNDJSON(ctx) {
+		c.streamSchedules(ctx, filter)
+		return
+	}
+	if isPaginated(ctx) {
+		c.getSchedulesPage(ctx, filter)
+		return
+	}
 	c.Logger.Info("Getting all schedules")
-	schedules, clients, err := c.scheduleUseCase.GetSchedulesWithClientInfo()
+	schedules, clients, err := c.scheduleUseCase.GetSchedulesWithClientInfo(filter)
 	if err != nil {
 		c.Logger.Error("Error getting all schedules", zap.Error(err))
 		_ = ctx.Error(err)
 		return
 	}
 	c.Logger.Info("Successfully retrieved all schedules", zap.Int("count", len(*schedules)))
-	ctx.JSON(http.StatusOK, arrayDomainToResponseMapperWithClients(*schedules, *clients))
+	ctx.JSON(http.StatusOK, arrayDomainToResponseMapperWithClients(*schedules, *clients, controllers.ViewerRole(ctx)))
+}
+
+// streamSchedules writes every schedule as a line of NDJSON as it is read, for
+// pulls too large to hold in memory.
+func (c *Controller) streamSchedules(ctx *gin.Context, filter domainSchedule.ListFilter) {
+	c.Logger.Info("Streaming all schedules")
+	writer := controllers.NewNDJSONWriter(ctx)
+	count := 0
+	err := c.scheduleUseCase.StreamSchedulesWithClientInfo(filter, func(schedule *domainSchedule.Schedule, client *domainUser.User) error {
+		response := domainToResponseMapper(schedule)
+		if client != nil {
+			response.ClientInfo = clientToResponseMapper(client, controllers.ViewerRole(ctx))
+		}
+		count++
+		return writer.Write(response)
+	})
+	if err != nil {
+		c.Logger.Error("Error streaming schedules", zap.Error(err), zap.Int("written", count))
+	} else {
+		c.Logger.Info("Successfully streamed all schedules", zap.Int("count", count))
+	}
+	writer.Close(err)
+}
+
+func (c *Controller) getSchedulesPage(ctx *gin.Context, filter domainSchedule.ListFilter) {
+	var filters domain.DataFilters
+	var err error
+	if filters.Page, err = strconv.Atoi(ctx.DefaultQuery("page", "1")); err != nil || filters.Page < 1 {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("page must be a positive number"), domainErrors.ValidationError))
+		return
+	}
+	if filters.PageSize, err = strconv.Atoi(ctx.DefaultQuery("pageSize", "10")); err != nil || filters.PageSize < 1 {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("pageSize must be a positive number"), domainErrors.ValidationError))
+		return
+	}
+	if sortBy := ctx.Query("sortBy"); sortBy != "" {
+		filters.SortBy = []string{sortBy}
+	}
+	filters.SortDirection = domain.SortDirection(ctx.Query("sortDirection"))
+	c.Logger.Info("Getting schedules page", zap.Int("page", filters.Page), zap.Int("pageSize", filters.PageSize))
+
+	result, clients, err := c.scheduleUseCase.GetSchedulesWithClientInfoPaginated(filters, filter)
+	if err != nil {
+		c.Logger.Error("Error getting schedules page", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SearchSchedulesResponse{
+		Data:       arrayDomainToResponseMapperWithClients(*result.Data, *clients, controllers.ViewerRole(ctx)),
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// parseListFilter reads the schedule list filters. from and to are
+// RFC3339 timestamps or YYYY-MM-DD dates in the organization's time zone; a
+// date given as to is included whole.
+func (c *Controller) parseListFilter(ctx *gin.Context) (domainSchedule.ListFilter, bool) {
+	filter := domainSchedule.ListFilter{VisitStatuses: ctx.QueryArray("visitStatus")}
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+		date   *string
+	}{{"from", &filter.From, &filter.FromDate}, {"to", &filter.To, &filter.ToDate}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			parsed = parsed.UTC()
+			*param.target = &parsed
+			continue
+		}
+		if _, err := time.Parse(dateLayout, value); err != nil {
+			c.Logger.Error("Invalid "+param.name, zap.Error(err), zap.String("value", value))
+			_ = ctx.Error(domainErrors.NewAppError(fmt.Errorf("%s must be YYYY-MM-DD or an RFC3339 timestamp", param.name), domainErrors.ValidationError))
+			return filter, false
+		}
+		*param.date = value
+	}
+	var err error
+	if filter.ClientUserID, err = controllers.GetQueryUUID(ctx, "clientUserID"); err != nil {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("clientUserID is invalid"), domainErrors.ValidationError))
+		return filter, false
+	}
+	if filter.AssignedUserID, err = controllers.GetQueryUUID(ctx, "assignedUserID"); err != nil {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("assignedUserID is invalid"), domainErrors.ValidationError))
+		return filter, false
+	}
+	return filter, true
+}
+
+func isPaginated(ctx *gin.Context) bool {
+	for _, param := range []string{"page", "pageSize", "sortBy", "sortDirection"} {
+		if _, ok := ctx.GetQuery(param); ok {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *Controller) CreateSchedule(ctx *gin.Context) {
@@ -58,30 +197,85 @@ func (c *Controller) CreateSchedule(ctx *gin.Context) {
 		_ = ctx.Error(appError)
 		return
 	}
+	newSchedule, ok := c.scheduleFromRequest(ctx, request)
+	if !ok {
+		return
+	}
 
+	createdSchedule, err := c.scheduleUseCase.CreateSchedule(newSchedule)
+	if err != nil {
+		c.Logger.Error("Error creating schedule", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.Logger.Info("Schedule created successfully", zap.String("scheduleID", createdSchedule.ID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(createdSchedule))
+}
+
+// CreateRecurringSchedule books a visit and its repeats, e.g. the same
+// caregiver for the same client every week until a given date.
+func (c *Controller) CreateRecurringSchedule(ctx *gin.Context) {
+	c.Logger.Info("Creating recurring schedule")
+	var request CreateRecurringScheduleRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for recurring schedule", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	first, ok := c.scheduleFromRequest(ctx, request.CreateScheduleRequest)
+	if !ok {
+		return
+	}
+	recurrence := domainSchedule.Recurrence{
+		Frequency: request.Recurrence.Frequency,
+		Interval:  request.Recurrence.Interval,
+		Until:     request.Recurrence.Until.UTC(),
+	}
+
+	schedules, err := c.scheduleUseCase.CreateRecurringSchedule(first, recurrence)
+	if err != nil {
+		c.Logger.Error("Error creating recurring schedule", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.Logger.Info("Recurring schedule created successfully", zap.Int("occurrences", len(*schedules)))
+	ctx.JSON(http.StatusOK, arrayDomainToResponseMapper(*schedules))
+}
+
+// scheduleFromRequest validates a new visit and maps it to the domain,
+// reporting any problem on ctx.
+func (c *Controller) scheduleFromRequest(ctx *gin.Context, request CreateScheduleRequest) (*domainSchedule.Schedule, bool) {
 	if request.ClientUserID == uuid.Nil {
 		c.Logger.Error("ClientUserID is required for new schedule")
 		appError := domainErrors.NewAppError(errors.New("ClientUserID is required"), domainErrors.ValidationError)
 		_ = ctx.Error(appError)
-		return
+		return nil, false
 	}
 	if request.ScheduledSlot.From.IsZero() || request.ScheduledSlot.To.IsZero() {
 		c.Logger.Error("ScheduledSlot (From, To) is required for new schedule")
 		appError := domainErrors.NewAppError(errors.New("ScheduledSlot (From, To) is required"), domainErrors.ValidationError)
 		_ = ctx.Error(appError)
-		return
+		return nil, false
 	}
 	if request.ScheduledSlot.From.After(request.ScheduledSlot.To) {
 		c.Logger.Error("ScheduledSlot 'From' cannot be after 'To'")
 		appError := domainErrors.NewAppError(errors.New("ScheduledSlot 'From' cannot be after 'To'"), domainErrors.ValidationError)
 		_ = ctx.Error(appError)
-		return
+		return nil, false
+	}
+	if request.ColorTag != "" && !domainSchedule.ValidColor(request.ColorTag) {
+		appError := domainErrors.NewAppError(errors.New("ColorTag must be a #RRGGBB hex value"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return nil, false
 	}
 	if len(request.Tasks) == 0 {
 		c.Logger.Error("At least one task is required for new schedule")
 		appError := domainErrors.NewAppError(errors.New("at least one task is required"), domainErrors.ValidationError)
 		_ = ctx.Error(appError)
-		return
+		return nil, false
 	}
 
 	domainTasks := make([]domainSchedule.Task, len(request.Tasks))
@@ -89,44 +283,65 @@ func (c *Controller) CreateSchedule(ctx *gin.Context) {
 		domainTasks[i] = domainSchedule.Task{
 			Title:       taskReq.Title,
 			Description: taskReq.Description,
+			Kind:        taskReq.Kind,
 			Status:      "pending",
 			Done:        nil,
 			Feedback:    nil,
 		}
+		if taskReq.Medication != nil {
+			medication := domainSchedule.TaskMedication(*taskReq.Medication)
+			medication.ScheduledAt = medication.ScheduledAt.UTC()
+			domainTasks[i].Medication = &medication
+		}
+	}
+
+	domainSegments := make([]domainSchedule.Segment, len(request.Segments))
+	for i, segmentReq := range request.Segments {
+		segmentReq = segmentReq.UTC()
+		domainSegments[i] = domainSchedule.Segment{
+			From: segmentReq.From,
+			To:   segmentReq.To,
+		}
 	}
 
+	slot := request.ScheduledSlot.UTC()
 	newSchedule := &domainSchedule.Schedule{
 		ClientUserID:   request.ClientUserID,
 		AssignedUserID: request.AssignedUserID,
 		ServiceName:    request.ServiceName,
-		ScheduledSlot:  domainSchedule.ScheduledSlot{From: request.ScheduledSlot.From, To: request.ScheduledSlot.To},
+		ScheduledSlot:  domainSchedule.ScheduledSlot{From: slot.From, To: slot.To},
 		Tasks:          domainTasks,
+		Segments:       domainSegments,
 		VisitStatus:    "upcoming",
+		ColorTag:       request.ColorTag,
 	}
-
-	createdSchedule, err := c.scheduleUseCase.CreateSchedule(newSchedule)
-	if err != nil {
-		c.Logger.Error("Error creating schedule", zap.Error(err))
-		_ = ctx.Error(err)
-		return
+	if request.Draft {
+		newSchedule.VisitStatus = domainSchedule.StatusDraft
 	}
 
-	c.Logger.Info("Schedule created successfully", zap.String("scheduleID", createdSchedule.ID.String()))
-	ctx.JSON(http.StatusOK, domainToResponseMapper(createdSchedule))
+	return newSchedule, true
 }
 
-func clientToResponseMapper(u *domainUser.User) *ClientInfo {
+// clientToResponseMapper keeps what the viewer's role may see of the
+// client, as the user responses do: admins see everything, caregivers the
+// address they visit, and other roles only who the client is.
+func clientToResponseMapper(u *domainUser.User, viewerRole string) *ClientInfo {
 	if u == nil {
 		return nil
 	}
-	return &ClientInfo{
-		ID:             u.ID,
-		UserName:       u.UserName,
-		Email:          u.Email,
-		FirstName:      u.FirstName,
-		LastName:       u.LastName,
-		ProfilePicture: u.ProfilePicture,
-		Location: ClientLocation{
+	info := &ClientInfo{
+		ID:        u.ID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+	}
+	switch viewerRole {
+	case domainUser.RoleAdmin:
+		info.UserName = u.UserName
+		info.Email = u.Email
+		fallthrough
+	case domainUser.RoleCaregiver:
+		info.ProfilePicture = u.ProfilePicture
+		info.Location = &ClientLocation{
 			HouseNumber: u.Location.HouseNumber,
 			Street:      u.Location.Street,
 			City:        u.Location.City,
@@ -134,23 +349,76 @@ func clientToResponseMapper(u *domainUser.User) *ClientInfo {
 			Pincode:     u.Location.Pincode,
 			Lat:         u.Location.Lat,
 			Long:        u.Location.Long,
-		},
+		}
 	}
+	return info
 }
 
 func domainToResponseMapper(s *domainSchedule.Schedule) *ScheduleResponse {
 	tasksResponse := make([]Task, len(s.Tasks))
-	for i, task := range s.Tasks {
-		tasksResponse[i] = Task{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      task.Status,
-			Done:        task.Done,
-			Feedback:    task.Feedback,
+	for i := range s.Tasks {
+		tasksResponse[i] = taskToResponseMapper(&s.Tasks[i])
+	}
+
+	segmentsResponse := make([]Segment, len(s.Segments))
+	for i, segment := range s.Segments {
+		segmentsResponse[i] = Segment{
+			ID:           segment.ID,
+			Sequence:     segment.Sequence,
+			From:         segment.From,
+			To:           segment.To,
+			VisitStatus:  segment.VisitStatus,
+			CheckinTime:  segment.CheckinTime,
+			CheckoutTime: segment.CheckoutTime,
+			CheckinLocation: Location{
+				Lat:  segment.CheckinLocation.Lat,
+				Long: segment.CheckinLocation.Long,
+			},
+			CheckoutLocation: Location{
+				Lat:  segment.CheckoutLocation.Lat,
+				Long: segment.CheckoutLocation.Long,
+			},
+			CheckinVerification: verificationToResponse(segment.CheckinVerification),
 		}
 	}
 
+	attachmentsResponse := make([]Attachment, len(s.Attachments))
+	for i, attachment := range s.Attachments {
+		attachmentsResponse[i] = Attachment{
+			ID:                    attachment.ID,
+			TaskID:                attachment.TaskID,
+			FileName:              attachment.FileName,
+			ContentType:           attachment.ContentType,
+			Size:                  attachment.Size,
+			RequiredBeforeCheckin: attachment.RequiredBeforeCheckin,
+			UploadedByUserID:      attachment.UploadedByUserID,
+			CreatedAt:             attachment.CreatedAt,
+		}
+	}
+
+	var noteRevisionsResponse []NoteRevision
+	for _, revision := range s.NoteRevisions {
+		noteRevisionsResponse = append(noteRevisionsResponse, NoteRevision{
+			Revision:     revision.Revision,
+			Body:         revision.Body,
+			AuthorUserID: revision.AuthorUserID,
+			CreatedAt:    revision.CreatedAt,
+		})
+	}
+
+	var confidence *Confidence
+	if s.Confidence != nil {
+		confidence = &Confidence{Score: s.Confidence.Score, Signals: make([]ConfidenceSignal, len(s.Confidence.Signals))}
+		for i, signal := range s.Confidence.Signals {
+			confidence.Signals[i] = ConfidenceSignal(signal)
+		}
+	}
+
+	var recurrence *Recurrence
+	if s.Recurrence != nil {
+		recurrence = &Recurrence{Frequency: s.Recurrence.Frequency, Interval: s.Recurrence.Interval, Until: s.Recurrence.Until}
+	}
+
 	return &ScheduleResponse{
 		ID:             s.ID,
 		ClientUserID:   s.ClientUserID,
@@ -172,11 +440,62 @@ func domainToResponseMapper(s *domainSchedule.Schedule) *ScheduleResponse {
 			Lat:  s.CheckoutLocation.Lat,
 			Long: s.CheckoutLocation.Long,
 		},
-		Tasks:       tasksResponse,
-		ServiceNote: s.ServiceNote,
+		CheckinVerification: verificationToResponse(s.CheckinVerification),
+		Attestation:         Attestation(s.Attestation),
+		Confidence:          confidence,
+		Tasks:               tasksResponse,
+		Segments:            segmentsResponse,
+		Attachments:         attachmentsResponse,
+		ServiceNote:         s.ServiceNote,
+		NoteRevisions:       noteRevisionsResponse,
+		ColorTag:            s.Color(),
+		SeriesID:            s.SeriesID,
+		Recurrence:          recurrence,
+		Warnings:            s.Warnings,
+		DeletedAt:           s.DeletedAt,
 	}
 }
 
+func verificationToResponse(v domainSchedule.Verification) Verification {
+	response := Verification{Method: v.Method, NFCTagID: v.NFCTagID, KioskID: v.KioskID}
+	if v.Integrity.Status != "" {
+		integrity := IntegrityCheck(v.Integrity)
+		response.Integrity = &integrity
+	}
+	return response
+}
+
+func taskToResponseMapper(task *domainSchedule.Task) Task {
+	response := Task{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Kind:        task.Kind,
+		Status:      task.Status,
+		Done:        task.Done,
+		Feedback:    task.Feedback,
+		DeletedAt:   task.DeletedAt,
+	}
+	if task.Medication != nil {
+		medication := TaskMedication(*task.Medication)
+		response.Medication = &medication
+	}
+	if task.Vitals != nil {
+		vitals := Vitals(*task.Vitals)
+		response.Vitals = &vitals
+	}
+	return response
+}
+
+// vitalsFromRequest maps the readings sent for a vitals task, if any.
+func vitalsFromRequest(vitals *Vitals) *domainSchedule.Vitals {
+	if vitals == nil {
+		return nil
+	}
+	readings := domainSchedule.Vitals(*vitals)
+	return &readings
+}
+
 func arrayDomainToResponseMapper(schedules []domainSchedule.Schedule) []ScheduleResponse {
 	res := make([]ScheduleResponse, len(schedules))
 	for i, s := range schedules {
@@ -185,7 +504,7 @@ func arrayDomainToResponseMapper(schedules []domainSchedule.Schedule) []Schedule
 	return res
 }
 
-func arrayDomainToResponseMapperWithClients(schedules []domainSchedule.Schedule, clients []domainUser.User) []ScheduleResponse {
+func arrayDomainToResponseMapperWithClients(schedules []domainSchedule.Schedule, clients []domainUser.User, viewerRole string) []ScheduleResponse {
 	res := make([]ScheduleResponse, len(schedules))
 
 	// Create a map for quick client lookup
@@ -197,7 +516,7 @@ func arrayDomainToResponseMapperWithClients(schedules []domainSchedule.Schedule,
 	for i, s := range schedules {
 		response := domainToResponseMapper(&s)
 		if client, exists := clientMap[s.ClientUserID]; exists {
-			response.ClientInfo = clientToResponseMapper(client)
+			response.ClientInfo = clientToResponseMapper(client, viewerRole)
 		}
 		res[i] = *response
 	}
@@ -229,7 +548,7 @@ func (c *Controller) GetTodaySchedules(ctx *gin.Context) {
 		return
 	}
 	c.Logger.Info("Successfully retrieved today's schedules", zap.Int("count", len(*schedules)), zap.String("userID", userID.String()))
-	ctx.JSON(http.StatusOK, arrayDomainToResponseMapperWithClients(*schedules, *clients))
+	ctx.JSON(http.StatusOK, arrayDomainToResponseMapperWithClients(*schedules, *clients, controllers.ViewerRole(ctx)))
 }
 
 func (c *Controller) GetScheduleByID(ctx *gin.Context) {
@@ -251,7 +570,7 @@ func (c *Controller) GetScheduleByID(ctx *gin.Context) {
 	c.Logger.Info("Successfully retrieved schedule by ID", zap.String("id", scheduleID.String()))
 
 	response := domainToResponseMapper(schedule)
-	response.ClientInfo = clientToResponseMapper(client)
+	response.ClientInfo = clientToResponseMapper(client, controllers.ViewerRole(ctx))
 	ctx.JSON(http.StatusOK, response)
 }
 
@@ -264,6 +583,9 @@ func (c *Controller) StartSchedule(ctx *gin.Context) {
 		_ = ctx.Error(appError)
 		return
 	}
+	if !c.authorizeOperator(ctx, scheduleID) {
+		return
+	}
 
 	var request StartScheduleRequest
 	if err := controllers.BindJSON(ctx, &request); err != nil {
@@ -279,14 +601,43 @@ func (c *Controller) StartSchedule(ctx *gin.Context) {
 		_ = ctx.Error(appError)
 		return
 	}
-	if request.Location.Lat == nil || request.Location.Long == nil {
-		c.Logger.Error("Location (Lat, Long) is required for start schedule", zap.String("ScheduleID", scheduleID.String()))
-		appError := domainErrors.NewAppError(errors.New("Location (Lat, Long) is required"), domainErrors.ValidationError)
+	if request.Location == nil && request.NFCTag == "" {
+		c.Logger.Error("Location or NFC tag is required for start schedule", zap.String("ScheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(errors.New("Location (Lat, Long) or nfc_tag is required"), domainErrors.ValidationError)
 		_ = ctx.Error(appError)
 		return
 	}
+	if request.IntegrityToken != "" && request.Platform == "" {
+		c.Logger.Error("Platform is required with an integrity token", zap.String("ScheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(errors.New("platform is required with integrity_token"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	var location domainSchedule.Location
+	if request.Location != nil {
+		if request.Location.Lat == nil || request.Location.Long == nil {
+			c.Logger.Error("Location (Lat, Long) is required for start schedule", zap.String("ScheduleID", scheduleID.String()))
+			appError := domainErrors.NewAppError(errors.New("Location (Lat, Long) is required"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		location = domainSchedule.Location{Lat: request.Location.Lat, Long: request.Location.Long}
+	}
+
+	// The timestamp is the device's clock; how far it is from ours counts
+	// towards the visit's verification confidence.
+	skew := int(request.Timestamp.Sub(time.Now()).Seconds())
+	verification := domainSchedule.Verification{
+		NFCTagValue:       request.NFCTag,
+		ClockSkewSeconds:  &skew,
+		IntegrityToken:    request.IntegrityToken,
+		IntegrityPlatform: request.Platform,
+	}
+	if request.Device != nil {
+		verification.Device = domainSchedule.DeviceIntegrity(*request.Device)
+	}
 
-	schedule, err := c.scheduleUseCase.StartSchedule(scheduleID, request.Timestamp, domainSchedule.Location{Lat: request.Location.Lat, Long: request.Location.Long})
+	schedule, err := c.scheduleUseCase.StartSchedule(scheduleID, request.Timestamp.UTC(), location, verification)
 	if err != nil {
 		c.Logger.Error("Error starting schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
 		_ = ctx.Error(err)
@@ -294,11 +645,18 @@ func (c *Controller) StartSchedule(ctx *gin.Context) {
 	}
 
 	c.Logger.Info("Schedule started successfully", zap.String("scheduleID", scheduleID.String()))
-	ctx.JSON(http.StatusOK, StartScheduleResponse{
-		Message:         "Check-in recorded successfully",
-		CheckinTime:     schedule.CheckinTime,
-		CheckinLocation: &Location{Lat: schedule.CheckinLocation.Lat, Long: schedule.CheckinLocation.Long},
-	})
+	checkinVerification := verificationToResponse(schedule.CheckinVerification)
+	res := StartScheduleResponse{
+		Message:             "Check-in recorded successfully",
+		CheckinTime:         schedule.CheckinTime,
+		CheckinLocation:     &Location{Lat: schedule.CheckinLocation.Lat, Long: schedule.CheckinLocation.Long},
+		CheckinVerification: &checkinVerification,
+	}
+	if schedule.Attestation.Status != "" {
+		attestation := Attestation(schedule.Attestation)
+		res.Attestation = &attestation
+	}
+	ctx.JSON(http.StatusOK, res)
 }
 
 func (c *Controller) EndSchedule(ctx *gin.Context) {
@@ -310,6 +668,9 @@ func (c *Controller) EndSchedule(ctx *gin.Context) {
 		_ = ctx.Error(appError)
 		return
 	}
+	if !c.authorizeOperator(ctx, scheduleID) {
+		return
+	}
 
 	var request EndScheduleRequest
 	if err := controllers.BindJSON(ctx, &request); err != nil {
@@ -341,10 +702,11 @@ func (c *Controller) EndSchedule(ctx *gin.Context) {
 			Status:      taskReq.Status,
 			Done:        taskReq.Done,
 			Feedback:    taskReq.Feedback,
+			Vitals:      vitalsFromRequest(taskReq.Vitals),
 		}
 	}
 
-	schedule, err := c.scheduleUseCase.EndSchedule(scheduleID, request.Timestamp, domainSchedule.Location{Lat: request.Location.Lat, Long: request.Location.Long}, domainTasks)
+	schedule, err := c.scheduleUseCase.EndSchedule(scheduleID, request.Timestamp.UTC(), domainSchedule.Location{Lat: request.Location.Lat, Long: request.Location.Long}, domainTasks)
 	if err != nil {
 		c.Logger.Error("Error ending schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
 		_ = ctx.Error(err)
@@ -396,7 +758,7 @@ func (c *Controller) UpdateTask(ctx *gin.Context) {
 		feedback = *request.Feedback
 	}
 
-	updatedTask, err := c.scheduleUseCase.UpdateTaskStatus(taskID, request.Status, *request.Done, feedback)
+	updatedTask, err := c.scheduleUseCase.UpdateTaskStatus(taskID, request.Status, *request.Done, feedback, vitalsFromRequest(request.Vitals))
 	if err != nil {
 		c.Logger.Error("Error updating task status", zap.Error(err), zap.String("taskID", taskID.String()))
 		_ = ctx.Error(err)
@@ -406,7 +768,7 @@ func (c *Controller) UpdateTask(ctx *gin.Context) {
 	c.Logger.Info("Task updated successfully", zap.String("taskID", taskID.String()))
 	ctx.JSON(http.StatusOK, UpdateTaskResponse{
 		Message: "Task updated successfully",
-		Task:    Task{ID: updatedTask.ID, Status: updatedTask.Status, Done: updatedTask.Done, Feedback: updatedTask.Feedback},
+		Task:    taskToResponseMapper(updatedTask),
 	})
 }
 
@@ -430,24 +792,111 @@ func (c *Controller) GetTodaySchedulesByAssignedUserID(ctx *gin.Context) {
 	}
 
 	c.Logger.Info("Successfully retrieved today's schedules by assigned user ID", zap.Int("count", len(*schedules)), zap.String("assignedUserID", assignedUserID.String()))
-	ctx.JSON(http.StatusOK, arrayDomainToResponseMapperWithClients(*schedules, *clients))
+	ctx.JSON(http.StatusOK, arrayDomainToResponseMapperWithClients(*schedules, *clients, controllers.ViewerRole(ctx)))
 }
 
 func (c *Controller) UpdateSchedule(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+
+	updates, ok := c.updatesFromRequest(ctx, scheduleID)
+	if !ok {
+		return
+	}
+
+	updatedSchedule, err := c.scheduleUseCase.UpdateSchedule(scheduleID, updates)
+	if err != nil {
+		c.Logger.Error("Error updating schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+
+	_, client, _ := c.scheduleUseCase.GetScheduleWithClientInfo(scheduleID)
+
+	response := domainToResponseMapper(updatedSchedule)
+	response.ClientInfo = clientToResponseMapper(client, controllers.ViewerRole(ctx))
+
+	c.Logger.Info("Schedule updated successfully", zap.String("scheduleID", scheduleID.String()))
+	ctx.JSON(http.StatusOK, UpdateScheduleResponse{
+		Message:  "Schedule updated successfully",
+		Schedule: response,
+	})
+}
+
+// UpdateOccurrence edits one visit of a recurring series, leaving the rest
+// of the series as it is.
+func (c *Controller) UpdateOccurrence(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+	updates, ok := c.updatesFromRequest(ctx, scheduleID)
+	if !ok {
+		return
+	}
+
+	updatedSchedule, err := c.scheduleUseCase.UpdateOccurrence(scheduleID, updates)
+	if err != nil {
+		c.Logger.Error("Error updating occurrence", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.Logger.Info("Occurrence updated successfully", zap.String("scheduleID", scheduleID.String()))
+	ctx.JSON(http.StatusOK, UpdateScheduleResponse{
+		Message:  "Occurrence updated successfully",
+		Schedule: domainToResponseMapper(updatedSchedule),
+	})
+}
+
+// UpdateFollowingOccurrences edits a visit of a recurring series and every
+// upcoming visit after it.
+func (c *Controller) UpdateFollowingOccurrences(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+	updates, ok := c.updatesFromRequest(ctx, scheduleID)
+	if !ok {
+		return
+	}
+
+	updatedSchedules, err := c.scheduleUseCase.UpdateFollowingOccurrences(scheduleID, updates)
+	if err != nil {
+		c.Logger.Error("Error updating following occurrences", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.Logger.Info("Following occurrences updated successfully", zap.String("scheduleID", scheduleID.String()), zap.Int("occurrences", len(*updatedSchedules)))
+	ctx.JSON(http.StatusOK, UpdateSeriesResponse{
+		Message:   "Following occurrences updated successfully",
+		Schedules: arrayDomainToResponseMapper(*updatedSchedules),
+	})
+}
+
+func (c *Controller) parseScheduleID(ctx *gin.Context) (uuid.UUID, bool) {
 	scheduleIDStr := ctx.Param("id")
 	scheduleID, err := uuid.Parse(scheduleIDStr)
 	if err != nil {
 		c.Logger.Error("Invalid schedule ID parameter for update", zap.Error(err), zap.String("id", scheduleIDStr))
 		appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
 		_ = ctx.Error(appError)
-		return
+		return uuid.Nil, false
 	}
+	return scheduleID, true
+}
 
-	_, _, err = c.scheduleUseCase.GetScheduleWithClientInfo(scheduleID)
+// updatesFromRequest binds and validates a schedule update, reporting any
+// problem on ctx.
+func (c *Controller) updatesFromRequest(ctx *gin.Context, scheduleID uuid.UUID) (map[string]interface{}, bool) {
+	_, _, err := c.scheduleUseCase.GetScheduleWithClientInfo(scheduleID)
 	if err != nil {
 		c.Logger.Error("Error getting schedule for update", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
 		_ = ctx.Error(err)
-		return
+		return nil, false
 	}
 
 	var request UpdateScheduleRequest
@@ -455,7 +904,7 @@ func (c *Controller) UpdateSchedule(ctx *gin.Context) {
 		c.Logger.Error("Error binding JSON for update schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
 		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
 		_ = ctx.Error(appError)
-		return
+		return nil, false
 	}
 
 	updates := make(map[string]interface{})
@@ -476,47 +925,351 @@ func (c *Controller) UpdateSchedule(ctx *gin.Context) {
 		updates["visit_status"] = request.VisitStatus
 	}
 
+	if request.ColorTag != nil {
+		if *request.ColorTag != "" && !domainSchedule.ValidColor(*request.ColorTag) {
+			appError := domainErrors.NewAppError(errors.New("ColorTag must be a #RRGGBB hex value"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return nil, false
+		}
+		updates["color_tag"] = *request.ColorTag
+	}
+
 	if request.ScheduledSlot != nil {
 		if request.ScheduledSlot.From.IsZero() || request.ScheduledSlot.To.IsZero() {
 			c.Logger.Error("Both From and To dates must be provided for ScheduledSlot", zap.String("scheduleID", scheduleID.String()))
 			appError := domainErrors.NewAppError(errors.New("Both From and To dates must be provided for ScheduledSlot"), domainErrors.ValidationError)
 			_ = ctx.Error(appError)
-			return
+			return nil, false
 		}
 
 		if request.ScheduledSlot.From.After(request.ScheduledSlot.To) {
 			c.Logger.Error("ScheduledSlot 'From' cannot be after 'To'", zap.String("scheduleID", scheduleID.String()))
 			appError := domainErrors.NewAppError(errors.New("ScheduledSlot 'From' cannot be after 'To'"), domainErrors.ValidationError)
 			_ = ctx.Error(appError)
-			return
+			return nil, false
 		}
 
-		updates["scheduled_slot_from"] = request.ScheduledSlot.From
-		updates["scheduled_slot_to"] = request.ScheduledSlot.To
+		slot := request.ScheduledSlot.UTC()
+		updates["scheduled_slot_from"] = slot.From
+		updates["scheduled_slot_to"] = slot.To
 	}
 
 	if len(updates) == 0 {
 		c.Logger.Warn("No valid fields to update", zap.String("scheduleID", scheduleID.String()))
 		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
 		_ = ctx.Error(appError)
+		return nil, false
+	}
+
+	return updates, true
+}
+
+// SearchSchedules filters and pages schedules. Filters may be repeated, e.g.
+// ?status=upcoming&status=in_progress; ?viewID= starts from a saved view
+// whose filters apply wherever the request gives none.
+func (c *Controller) SearchSchedules(ctx *gin.Context) {
+	c.Logger.Info("Searching schedules")
+
+	query := domainSchedule.SearchQuery{
+		Statuses:      ctx.QueryArray("status"),
+		ServiceNames:  ctx.QueryArray("serviceName"),
+		Regions:       ctx.QueryArray("region"),
+		SortBy:        ctx.Query("sortBy"),
+		SortDirection: domain.SortDirection(ctx.Query("sortDirection")),
+	}
+	var err error
+	if query.AssignedUserIDs, err = parseUUIDs(ctx.QueryArray("assignedUserID")); err != nil {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("assignedUserID is invalid"), domainErrors.ValidationError))
 		return
 	}
+	if query.ClientUserIDs, err = parseUUIDs(ctx.QueryArray("clientUserID")); err != nil {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("clientUserID is invalid"), domainErrors.ValidationError))
+		return
+	}
+	if query.TeamIDs, err = parseUUIDs(ctx.QueryArray("teamID")); err != nil {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("teamID is invalid"), domainErrors.ValidationError))
+		return
+	}
+	if viewID := ctx.Query("viewID"); viewID != "" {
+		parsed, err := uuid.Parse(viewID)
+		if err != nil {
+			_ = ctx.Error(domainErrors.NewAppError(errors.New("viewID is invalid"), domainErrors.ValidationError))
+			return
+		}
+		query.ViewID = &parsed
+	}
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &query.From}, {"to", &query.To}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			_ = ctx.Error(domainErrors.NewAppError(fmt.Errorf("%s must be an RFC3339 timestamp", param.name), domainErrors.ValidationError))
+			return
+		}
+		parsed = parsed.UTC()
+		*param.target = &parsed
+	}
+	query.Deleted = domain.DeletedScope(ctx.Query("deleted"))
+	if !query.Deleted.IsValid() {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("deleted must be 'with' or 'only'"), domainErrors.ValidationError))
+		return
+	}
+	query.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	query.PageSize, _ = strconv.Atoi(ctx.DefaultQuery("pageSize", "10"))
 
-	updatedSchedule, err := c.scheduleUseCase.UpdateSchedule(scheduleID, updates)
+	result, clients, err := c.scheduleUseCase.SearchSchedules(query)
 	if err != nil {
-		c.Logger.Error("Error updating schedule", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		c.Logger.Error("Error searching schedules", zap.Error(err))
 		_ = ctx.Error(err)
 		return
 	}
 
-	_, client, _ := c.scheduleUseCase.GetScheduleWithClientInfo(scheduleID)
+	c.Logger.Info("Successfully searched schedules", zap.Int64("total", result.Total), zap.Int("page", result.Page))
+	ctx.JSON(http.StatusOK, SearchSchedulesResponse{
+		Data:       arrayDomainToResponseMapperWithClients(*result.Data, *clients, controllers.ViewerRole(ctx)),
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	})
+}
 
-	response := domainToResponseMapper(updatedSchedule)
-	response.ClientInfo = clientToResponseMapper(client)
+// DeleteSchedule soft-deletes a visit; RestoreSchedule brings it back.
+// ?deletedBy= names who deleted it for the admin trash.
+func (c *Controller) DeleteSchedule(ctx *gin.Context) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter for deletion", zap.Error(err), zap.String("id", ctx.Param("id")))
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError))
+		return
+	}
+	deletedBy, err := controllers.GetQueryUUID(ctx, "deletedBy")
+	if err != nil {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("deletedBy is invalid"), domainErrors.ValidationError))
+		return
+	}
+	if err := c.scheduleUseCase.DeleteSchedule(scheduleID, deletedBy); err != nil {
+		c.Logger.Error("Error deleting schedule", zap.Error(err), zap.String("id", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Schedule deleted successfully", zap.String("id", scheduleID.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
 
-	c.Logger.Info("Schedule updated successfully", zap.String("scheduleID", scheduleID.String()))
-	ctx.JSON(http.StatusOK, UpdateScheduleResponse{
-		Message:  "Schedule updated successfully",
-		Schedule: response,
+func (c *Controller) RestoreSchedule(ctx *gin.Context) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter for restore", zap.Error(err), zap.String("id", ctx.Param("id")))
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError))
+		return
+	}
+	schedule, err := c.scheduleUseCase.RestoreSchedule(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error restoring schedule", zap.Error(err), zap.String("id", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Schedule restored successfully", zap.String("id", scheduleID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(schedule))
+}
+
+func (c *Controller) DeleteTask(ctx *gin.Context) {
+	taskID, err := uuid.Parse(ctx.Param("taskId"))
+	if err != nil {
+		c.Logger.Error("Invalid task ID parameter for deletion", zap.Error(err), zap.String("taskID", ctx.Param("taskId")))
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("task id is invalid"), domainErrors.ValidationError))
+		return
+	}
+	if err := c.scheduleUseCase.DeleteTask(taskID); err != nil {
+		c.Logger.Error("Error deleting task", zap.Error(err), zap.String("taskID", taskID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Task deleted successfully", zap.String("taskID", taskID.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) RestoreTask(ctx *gin.Context) {
+	taskID, err := uuid.Parse(ctx.Param("taskId"))
+	if err != nil {
+		c.Logger.Error("Invalid task ID parameter for restore", zap.Error(err), zap.String("taskID", ctx.Param("taskId")))
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("task id is invalid"), domainErrors.ValidationError))
+		return
+	}
+	task, err := c.scheduleUseCase.RestoreTask(taskID)
+	if err != nil {
+		c.Logger.Error("Error restoring task", zap.Error(err), zap.String("taskID", taskID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Task restored successfully", zap.String("taskID", taskID.String()))
+	ctx.JSON(http.StatusOK, UpdateTaskResponse{
+		Message: "Task restored successfully",
+		Task:    taskToResponseMapper(task),
 	})
 }
+
+// GetOpenShifts lists the upcoming visits nobody is assigned to yet. A
+// signed-in caregiver only sees the ones they are eligible for.
+func (c *Controller) GetOpenShifts(ctx *gin.Context) {
+	shifts, clients, err := c.scheduleUseCase.GetOpenShifts(controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error getting open shifts", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, arrayDomainToResponseMapperWithClients(*shifts, *clients, controllers.ViewerRole(ctx)))
+}
+
+// ClaimOpenShift assigns an open shift to the signed-in caregiver.
+func (c *Controller) ClaimOpenShift(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+	callerID := controllers.CallerID(ctx)
+	if callerID == nil {
+		appError := domainErrors.NewAppError(errors.New("log in to claim a shift"), domainErrors.NotAuthenticated)
+		_ = ctx.Error(appError)
+		return
+	}
+	schedule, err := c.scheduleUseCase.ClaimOpenShift(scheduleID, *callerID, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error claiming open shift", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Open shift claimed", zap.String("scheduleID", scheduleID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(schedule))
+}
+
+// PublishSchedules publishes drafts in bulk. Drafts that fail the final
+// checks stay drafts and are listed with the reason.
+func (c *Controller) PublishSchedules(ctx *gin.Context) {
+	var request PublishSchedulesRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for publishing schedules", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	result, err := c.scheduleUseCase.PublishDrafts(request.ScheduleIDs, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error publishing schedules", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := PublishSchedulesResponse{
+		Published: make([]ScheduleResponse, len(result.Published)),
+		Rejected:  make([]PublishRejection, len(result.Rejected)),
+	}
+	for i := range result.Published {
+		res.Published[i] = *domainToResponseMapper(&result.Published[i])
+	}
+	for i, rejection := range result.Rejected {
+		res.Rejected[i] = PublishRejection(rejection)
+	}
+	c.Logger.Info("Schedules published", zap.Int("published", len(res.Published)), zap.Int("rejected", len(res.Rejected)))
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetClientTaskHistory returns how often each category of a client's tasks
+// was completed between "from" and "to" (YYYY-MM-DD, inclusive; the last
+// 180 days by default), per "period" (week or month, the default).
+func (c *Controller) GetClientTaskHistory(ctx *gin.Context) {
+	clientUserID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid client ID parameter for task history", zap.Error(err), zap.String("id", ctx.Param("id")))
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("client id is invalid"), domainErrors.ValidationError))
+		return
+	}
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	if value := ctx.Query("to"); value != "" {
+		day, ok := c.parseDate(ctx, "to", value)
+		if !ok {
+			return
+		}
+		to = day.AddDate(0, 0, 1)
+	}
+	from := to.AddDate(0, 0, -defaultHistoryDays)
+	if value := ctx.Query("from"); value != "" {
+		day, ok := c.parseDate(ctx, "from", value)
+		if !ok {
+			return
+		}
+		from = day
+	}
+	period := ctx.DefaultQuery("period", domainSchedule.HistoryPeriodMonth)
+
+	histories, err := c.scheduleUseCase.GetClientTaskHistory(clientUserID, from, to, period)
+	if err != nil {
+		c.Logger.Error("Error getting client task history", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := TaskHistoryResponse{ClientUserID: clientUserID, From: from, To: to, Period: period, Categories: make([]TaskCategoryHistory, len(histories))}
+	for i, history := range histories {
+		category := TaskCategoryHistory{Category: history.Category, TaskOutcomes: taskOutcomesToResponse(history.TaskOutcomes), Periods: make([]TaskPeriodOutcomes, len(history.Periods))}
+		for j, outcomes := range history.Periods {
+			category.Periods[j] = TaskPeriodOutcomes{Start: outcomes.PeriodStart, TaskOutcomes: taskOutcomesToResponse(outcomes)}
+		}
+		res.Categories[i] = category
+	}
+	c.Logger.Info("Client task history retrieved", zap.String("clientUserID", clientUserID.String()), zap.Int("categories", len(histories)))
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		_ = ctx.Error(domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError))
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func taskOutcomesToResponse(outcomes domainSchedule.TaskOutcomes) TaskOutcomes {
+	return TaskOutcomes{
+		Total:            outcomes.Total,
+		Completed:        outcomes.Completed,
+		NotCompleted:     outcomes.NotCompleted,
+		Unrecorded:       outcomes.Unrecorded,
+		CompletionRate:   outcomes.CompletionRate(),
+		NotCompletedRate: outcomes.NotCompletedRate(),
+	}
+}
+
+func parseUUIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// authorizeOperator refuses a check-in or check-out by an anonymous caller
+// or one who is neither the visit's caregiver nor an admin.
+func (c *Controller) authorizeOperator(ctx *gin.Context, scheduleID uuid.UUID) bool {
+	callerID := controllers.CallerID(ctx)
+	if callerID == nil {
+		_ = ctx.Error(domainErrors.NewAppError(errors.New("log in to check in or out of a visit"), domainErrors.NotAuthenticated))
+		return false
+	}
+	if err := c.scheduleUseCase.AuthorizeOperator(scheduleID, *callerID); err != nil {
+		_ = ctx.Error(err)
+		return false
+	}
+	return true
+}
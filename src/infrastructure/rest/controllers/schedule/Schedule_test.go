@@ -9,9 +9,12 @@ import (
 	"testing"
 	"time"
 
+	"caregiver/src/domain"
+	domainErrors "caregiver/src/domain/errors"
 	domainSchedule "caregiver/src/domain/schedule"
 	domainUser "caregiver/src/domain/user"
 	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -21,18 +24,26 @@ import (
 // mockScheduleUseCase is a mock implementation of the IScheduleUseCase interface
 type mockScheduleUseCase struct {
 	getSchedulesFn                                    func() (*[]domainSchedule.Schedule, error)
-	getSchedulesWithClientInfoFn                      func() (*[]domainSchedule.Schedule, *[]domainUser.User, error)
+	getSchedulesWithClientInfoFn                      func(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, *[]domainUser.User, error)
+	streamSchedulesWithClientInfoFn                   func(filter domainSchedule.ListFilter, fn func(*domainSchedule.Schedule, *domainUser.User) error) error
+	getSchedulesWithClientInfoPaginatedFn             func(filters domain.DataFilters, filter domainSchedule.ListFilter) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error)
 	getScheduleByIDFn                                 func(id uuid.UUID) (*domainSchedule.Schedule, error)
+	authorizeOperatorFn                               func(scheduleID, callerID uuid.UUID) error
 	getScheduleWithClientInfoFn                       func(id uuid.UUID) (*domainSchedule.Schedule, *domainUser.User, error)
 	getTodaySchedulesFn                               func(userID uuid.UUID) (*[]domainSchedule.Schedule, error)
 	getTodaySchedulesWithClientInfoFn                 func(userID uuid.UUID) (*[]domainSchedule.Schedule, *[]domainUser.User, error)
-	startScheduleFn                                   func(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location) (*domainSchedule.Schedule, error)
+	startScheduleFn                                   func(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, verification domainSchedule.Verification) (*domainSchedule.Schedule, error)
 	endScheduleFn                                     func(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, tasks []domainSchedule.Task) (*domainSchedule.Schedule, error)
-	updateTaskStatusFn                                func(taskID uuid.UUID, status string, done bool, feedback string) (*domainSchedule.Task, error)
+	updateTaskStatusFn                                func(taskID uuid.UUID, status string, done bool, feedback string, vitals *domainSchedule.Vitals) (*domainSchedule.Task, error)
 	updateScheduleFn                                  func(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error)
 	createScheduleFn                                  func(newSchedule *domainSchedule.Schedule) (*domainSchedule.Schedule, error)
+	createRecurringScheduleFn                         func(first *domainSchedule.Schedule, recurrence domainSchedule.Recurrence) (*[]domainSchedule.Schedule, error)
+	updateOccurrenceFn                                func(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error)
+	updateFollowingOccurrencesFn                      func(scheduleID uuid.UUID, updates map[string]interface{}) (*[]domainSchedule.Schedule, error)
 	getTodaySchedulesByAssignedUserIDFn               func(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error)
 	getTodaySchedulesByAssignedUserIDWithClientInfoFn func(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, *[]domainUser.User, error)
+	getSchedulesInProgressByAssignedUserIDFn          func(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error)
+	searchSchedulesFn                                 func(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error)
 }
 
 // Implement all methods of the IScheduleUseCase interface
@@ -40,8 +51,16 @@ func (m *mockScheduleUseCase) GetSchedules() (*[]domainSchedule.Schedule, error)
 	return m.getSchedulesFn()
 }
 
-func (m *mockScheduleUseCase) GetSchedulesWithClientInfo() (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
-	return m.getSchedulesWithClientInfoFn()
+func (m *mockScheduleUseCase) GetSchedulesWithClientInfo(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
+	return m.getSchedulesWithClientInfoFn(filter)
+}
+
+func (m *mockScheduleUseCase) StreamSchedulesWithClientInfo(filter domainSchedule.ListFilter, fn func(*domainSchedule.Schedule, *domainUser.User) error) error {
+	return m.streamSchedulesWithClientInfoFn(filter, fn)
+}
+
+func (m *mockScheduleUseCase) GetSchedulesWithClientInfoPaginated(filters domain.DataFilters, filter domainSchedule.ListFilter) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error) {
+	return m.getSchedulesWithClientInfoPaginatedFn(filters, filter)
 }
 
 func (m *mockScheduleUseCase) GetScheduleByID(id uuid.UUID) (*domainSchedule.Schedule, error) {
@@ -60,16 +79,75 @@ func (m *mockScheduleUseCase) GetTodaySchedulesWithClientInfo(userID uuid.UUID)
 	return m.getTodaySchedulesWithClientInfoFn(userID)
 }
 
-func (m *mockScheduleUseCase) StartSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location) (*domainSchedule.Schedule, error) {
-	return m.startScheduleFn(scheduleID, timestamp, location)
+func (m *mockScheduleUseCase) StartSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, verification domainSchedule.Verification) (*domainSchedule.Schedule, error) {
+	return m.startScheduleFn(scheduleID, timestamp, location, verification)
 }
 
 func (m *mockScheduleUseCase) EndSchedule(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, tasks []domainSchedule.Task) (*domainSchedule.Schedule, error) {
 	return m.endScheduleFn(scheduleID, timestamp, location, tasks)
 }
 
-func (m *mockScheduleUseCase) UpdateTaskStatus(taskID uuid.UUID, status string, done bool, feedback string) (*domainSchedule.Task, error) {
-	return m.updateTaskStatusFn(taskID, status, done, feedback)
+func (m *mockScheduleUseCase) UpdateTaskStatus(taskID uuid.UUID, status string, done bool, feedback string, vitals *domainSchedule.Vitals) (*domainSchedule.Task, error) {
+	return m.updateTaskStatusFn(taskID, status, done, feedback, vitals)
+}
+
+func (m *mockScheduleUseCase) DeleteSchedule(scheduleID uuid.UUID, deletedBy *uuid.UUID) error {
+	return nil
+}
+
+func (m *mockScheduleUseCase) RestoreSchedule(scheduleID uuid.UUID) (*domainSchedule.Schedule, error) {
+	return nil, nil
+}
+
+func (m *mockScheduleUseCase) DeleteTask(taskID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockScheduleUseCase) RestoreTask(taskID uuid.UUID) (*domainSchedule.Task, error) {
+	return nil, nil
+}
+
+func (m *mockScheduleUseCase) MarkMissedVisits(now time.Time) (*[]domainSchedule.Schedule, error) {
+	return nil, nil
+}
+
+func (m *mockScheduleUseCase) AuthorizeOperator(scheduleID, callerID uuid.UUID) error {
+	if m.authorizeOperatorFn == nil {
+		return nil
+	}
+	return m.authorizeOperatorFn(scheduleID, callerID)
+}
+
+func (m *mockScheduleUseCase) CloseStuckVisits(before time.Time) (*[]domainSchedule.Schedule, error) {
+	return nil, nil
+}
+
+func (m *mockScheduleUseCase) GetOpenShifts(callerID *uuid.UUID, now time.Time) (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
+	return nil, nil, nil
+}
+
+func (m *mockScheduleUseCase) ClaimOpenShift(scheduleID, caregiverID uuid.UUID, now time.Time) (*domainSchedule.Schedule, error) {
+	return nil, nil
+}
+
+func (m *mockScheduleUseCase) PublishDrafts(scheduleIDs []uuid.UUID, now time.Time) (*domainSchedule.PublishResult, error) {
+	return nil, nil
+}
+
+func (m *mockScheduleUseCase) GetClientTaskHistory(clientUserID uuid.UUID, from, to time.Time, period string) ([]domainSchedule.TaskHistory, error) {
+	return nil, nil
+}
+
+func (m *mockScheduleUseCase) CreateRecurringSchedule(first *domainSchedule.Schedule, recurrence domainSchedule.Recurrence) (*[]domainSchedule.Schedule, error) {
+	return m.createRecurringScheduleFn(first, recurrence)
+}
+
+func (m *mockScheduleUseCase) UpdateOccurrence(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
+	return m.updateOccurrenceFn(scheduleID, updates)
+}
+
+func (m *mockScheduleUseCase) UpdateFollowingOccurrences(scheduleID uuid.UUID, updates map[string]interface{}) (*[]domainSchedule.Schedule, error) {
+	return m.updateFollowingOccurrencesFn(scheduleID, updates)
 }
 
 func (m *mockScheduleUseCase) UpdateSchedule(scheduleID uuid.UUID, updates map[string]interface{}) (*domainSchedule.Schedule, error) {
@@ -88,6 +166,14 @@ func (m *mockScheduleUseCase) GetTodaySchedulesByAssignedUserIDWithClientInfo(as
 	return m.getTodaySchedulesByAssignedUserIDWithClientInfoFn(assignedUserID)
 }
 
+func (m *mockScheduleUseCase) GetSchedulesInProgressByAssignedUserID(assignedUserID uuid.UUID) (*[]domainSchedule.Schedule, error) {
+	return m.getSchedulesInProgressByAssignedUserIDFn(assignedUserID)
+}
+
+func (m *mockScheduleUseCase) SearchSchedules(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error) {
+	return m.searchSchedulesFn(query)
+}
+
 // setupLogger creates a logger instance for testing
 func setupLogger(t *testing.T) *logger.Logger {
 	loggerInstance, err := logger.NewLogger()
@@ -208,7 +294,7 @@ func TestGetSchedules(t *testing.T) {
 		schedules := []domainSchedule.Schedule{*schedule1, *schedule2}
 		clients := []domainUser.User{*createTestUser(schedule1.ClientUserID), *createTestUser(schedule2.ClientUserID)}
 
-		mockUseCase.getSchedulesWithClientInfoFn = func() (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
+		mockUseCase.getSchedulesWithClientInfoFn = func(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
 			return &schedules, &clients, nil
 		}
 
@@ -230,7 +316,7 @@ func TestGetSchedules(t *testing.T) {
 
 	t.Run("Error", func(t *testing.T) {
 		// Setup mock behavior
-		mockUseCase.getSchedulesWithClientInfoFn = func() (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
+		mockUseCase.getSchedulesWithClientInfoFn = func(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
 			return nil, nil, errors.New("database error")
 		}
 
@@ -242,6 +328,92 @@ func TestGetSchedules(t *testing.T) {
 		// Verify
 		assert.NotEqual(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("Paginated", func(t *testing.T) {
+		schedule := createTestSchedule(uuid.New())
+		clients := []domainUser.User{*createTestUser(schedule.ClientUserID)}
+		var received domain.DataFilters
+		mockUseCase.getSchedulesWithClientInfoPaginatedFn = func(filters domain.DataFilters, filter domainSchedule.ListFilter) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error) {
+			received = filters
+			return &domainSchedule.SearchResultSchedule{Data: &[]domainSchedule.Schedule{*schedule}, Total: 21, Page: 3, PageSize: 10, TotalPages: 3}, &clients, nil
+		}
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/schedules?page=3&sortBy=created_at&sortDirection=desc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, domain.DataFilters{Page: 3, PageSize: 10, SortBy: []string{"created_at"}, SortDirection: domain.SortDesc}, received)
+		var response SearchSchedulesResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, int64(21), response.Total)
+		assert.Equal(t, 3, response.TotalPages)
+	})
+
+	t.Run("Invalid page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/schedules?page=zero", nil)
+		router.ServeHTTP(w, req)
+
+		assert.NotEqual(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		var received domainSchedule.ListFilter
+		mockUseCase.getSchedulesWithClientInfoFn = func(filter domainSchedule.ListFilter) (*[]domainSchedule.Schedule, *[]domainUser.User, error) {
+			received = filter
+			return &[]domainSchedule.Schedule{}, &[]domainUser.User{}, nil
+		}
+		clientID := uuid.New()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/schedules?from=2025-03-10&to=2025-03-16T12:00:00-04:00&visitStatus=cancelled&visitStatus=missed&clientUserID="+clientID.String(), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2025-03-10", received.FromDate)
+		assert.Nil(t, received.From)
+		assert.Equal(t, time.Date(2025, 3, 16, 16, 0, 0, 0, time.UTC), *received.To)
+		assert.Empty(t, received.ToDate)
+		assert.Equal(t, []string{"cancelled", "missed"}, received.VisitStatuses)
+		assert.Equal(t, clientID, *received.ClientUserID)
+		assert.Nil(t, received.AssignedUserID)
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		for _, query := range []string{"from=last-week", "assignedUserID=nobody"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/schedules?"+query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.NotEqual(t, http.StatusOK, w.Code, query)
+		}
+	})
+}
+
+// TestClientToResponseMapperByViewerRole tests that client details are
+// trimmed like user responses
+func TestClientToResponseMapperByViewerRole(t *testing.T) {
+	client := createTestUser(uuid.New())
+	client.Email = "client@example.com"
+	client.Location = domainUser.Location{Street: "Main St", Lat: 52.5, Long: 13.4}
+
+	asAdmin := clientToResponseMapper(client, domainUser.RoleAdmin)
+	assert.Equal(t, client.Email, asAdmin.Email)
+	assert.Equal(t, "Main St", asAdmin.Location.Street)
+
+	asCaregiver := clientToResponseMapper(client, domainUser.RoleCaregiver)
+	assert.Empty(t, asCaregiver.Email)
+	assert.Empty(t, asCaregiver.UserName)
+	assert.Equal(t, 52.5, asCaregiver.Location.Lat)
+
+	for _, role := range []string{domainUser.RoleFamily, domainUser.RoleClient, "unknown"} {
+		response := clientToResponseMapper(client, role)
+		assert.Equal(t, client.FirstName, response.FirstName)
+		assert.Empty(t, response.Email, role)
+		assert.Nil(t, response.Location, role)
+	}
 }
 
 // TestCreateSchedule tests the CreateSchedule controller method
@@ -466,3 +638,162 @@ func TestCreateSchedule(t *testing.T) {
 		assert.NotEqual(t, http.StatusOK, w.Code)
 	})
 }
+
+// TestCreateRecurringSchedule tests that the rule reaches the use case and
+// every occurrence comes back
+func TestCreateRecurringSchedule(t *testing.T) {
+	controller, mockUseCase, router := setupTestController(t)
+	router.POST("/schedules/recurring", controller.CreateRecurringSchedule)
+
+	seriesID := uuid.New()
+	from := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	requestBody := CreateRecurringScheduleRequest{
+		CreateScheduleRequest: CreateScheduleRequest{
+			ClientUserID:   uuid.New(),
+			AssignedUserID: uuid.New(),
+			ServiceName:    "Companionship",
+			ScheduledSlot:  ScheduledSlot{From: from, To: from.Add(2 * time.Hour)},
+			Tasks:          []TaskRequest{{Title: "Lunch"}},
+		},
+		Recurrence: Recurrence{Frequency: domainSchedule.RecurrenceWeekly, Interval: 1, Until: until},
+	}
+	mockUseCase.createRecurringScheduleFn = func(first *domainSchedule.Schedule, recurrence domainSchedule.Recurrence) (*[]domainSchedule.Schedule, error) {
+		assert.Equal(t, "Companionship", first.ServiceName)
+		assert.Equal(t, domainSchedule.RecurrenceWeekly, recurrence.Frequency)
+		assert.True(t, until.Equal(recurrence.Until))
+		occurrences := []domainSchedule.Schedule{*createTestSchedule(uuid.New()), *createTestSchedule(uuid.New())}
+		for i := range occurrences {
+			occurrences[i].SeriesID = &seriesID
+			occurrences[i].Recurrence = &recurrence
+		}
+		return &occurrences, nil
+	}
+
+	w := httptest.NewRecorder()
+	jsonBody, _ := json.Marshal(requestBody)
+	req, _ := http.NewRequest("POST", "/schedules/recurring", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []ScheduleResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+	assert.Equal(t, &seriesID, response[1].SeriesID)
+	assert.Equal(t, domainSchedule.RecurrenceWeekly, response[1].Recurrence.Frequency)
+}
+
+// TestStartScheduleRequiresAssignedCaregiver tests that only a logged-in
+// caller the use case authorizes may check in
+func TestStartScheduleRequiresAssignedCaregiver(t *testing.T) {
+	controller, mockUseCase, router := setupTestController(t)
+	var callerID *uuid.UUID
+	router.Use(func(c *gin.Context) {
+		if callerID != nil {
+			c.Set(controllers.CallerIDKey, *callerID)
+		}
+	})
+	router.POST("/schedules/:id/start", controller.StartSchedule)
+
+	scheduleID := uuid.New()
+	authorized := 0
+	mockUseCase.authorizeOperatorFn = func(id, caller uuid.UUID) error {
+		authorized++
+		assert.Equal(t, scheduleID, id)
+		assert.Equal(t, *callerID, caller)
+		return errors.New("not assigned")
+	}
+	started := false
+	mockUseCase.startScheduleFn = func(scheduleID uuid.UUID, timestamp time.Time, location domainSchedule.Location, verification domainSchedule.Verification) (*domainSchedule.Schedule, error) {
+		started = true
+		return createTestSchedule(scheduleID), nil
+	}
+	start := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/schedules/"+scheduleID.String()+"/start", bytes.NewBufferString(`{"timestamp":"2026-03-02T09:00:00Z","nfc_tag":"tag"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := start()
+	assert.NotEqual(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "log in")
+	assert.Equal(t, 0, authorized)
+
+	caller := uuid.New()
+	callerID = &caller
+	w = start()
+	assert.NotEqual(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, authorized)
+	assert.False(t, started)
+}
+
+// TestClaimOpenShiftRequiresLogin tests that an anonymous claim is refused
+// as unauthenticated
+func TestClaimOpenShiftRequiresLogin(t *testing.T) {
+	controller, _, router := setupTestController(t)
+	var errType domainErrors.ErrorType
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		var appErr *domainErrors.AppError
+		if errors.As(c.Errors.Last(), &appErr) {
+			errType = appErr.Type
+		}
+	})
+	router.POST("/schedules/:id/claim", controller.ClaimOpenShift)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/schedules/"+uuid.New().String()+"/claim", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, domainErrors.NotAuthenticated, errType)
+	assert.Contains(t, w.Body.String(), "log in")
+}
+
+// TestSearchSchedules tests query parsing and color tags in search results
+func TestSearchSchedules(t *testing.T) {
+	controller, mockUseCase, router := setupTestController(t)
+	router.GET("/schedules/search", controller.SearchSchedules)
+
+	t.Run("Success", func(t *testing.T) {
+		assignedUserID := uuid.New()
+		viewID := uuid.New()
+		schedule := createTestSchedule(uuid.New())
+		tagged := createTestSchedule(uuid.New())
+		tagged.ColorTag = "#123456"
+
+		var received domainSchedule.SearchQuery
+		mockUseCase.searchSchedulesFn = func(query domainSchedule.SearchQuery) (*domainSchedule.SearchResultSchedule, *[]domainUser.User, error) {
+			received = query
+			return &domainSchedule.SearchResultSchedule{Data: &[]domainSchedule.Schedule{*schedule, *tagged}, Total: 2, Page: 1, PageSize: 10, TotalPages: 1}, &[]domainUser.User{}, nil
+		}
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/schedules/search?status=upcoming&status=in_progress&assignedUserID="+assignedUserID.String()+"&region=Berlin&from=2025-07-01T00:00:00%2B02:00&viewID="+viewID.String()+"&page=2", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"upcoming", "in_progress"}, received.Statuses)
+		assert.Equal(t, []uuid.UUID{assignedUserID}, received.AssignedUserIDs)
+		assert.Equal(t, []string{"Berlin"}, received.Regions)
+		assert.Equal(t, viewID, *received.ViewID)
+		assert.Equal(t, time.Date(2025, 6, 30, 22, 0, 0, 0, time.UTC), *received.From)
+		assert.Equal(t, 2, received.Page)
+
+		var response SearchSchedulesResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, domainSchedule.StatusColors["upcoming"], response.Data[0].ColorTag)
+		assert.Equal(t, "#123456", response.Data[1].ColorTag)
+	})
+
+	t.Run("Invalid From", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/schedules/search?from=yesterday", nil)
+		router.ServeHTTP(w, req)
+
+		assert.NotEqual(t, http.StatusOK, w.Code)
+	})
+}
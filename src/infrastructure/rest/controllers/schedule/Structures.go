@@ -6,18 +6,61 @@ import (
 	"github.com/google/uuid"
 )
 
-
+// CreateScheduleRequest is a new visit. Leaving out AssignedUserID posts it
+// as an open shift for caregivers to claim.
 type CreateScheduleRequest struct {
-	ClientUserID  uuid.UUID     `json:"ClientUserID" binding:"required"`
-	AssignedUserID uuid.UUID    `json:"AssignedUserID" binding:"required"`
-	ServiceName   string        `json:"ServiceName" binding:"required"`   
-	ScheduledSlot ScheduledSlot `json:"ScheduledSlot" binding:"required"`
-	Tasks         []TaskRequest `json:"Tasks" binding:"required,min=1,dive"`
+	ClientUserID   uuid.UUID       `json:"ClientUserID" binding:"required"`
+	AssignedUserID uuid.UUID       `json:"AssignedUserID"`
+	ServiceName    string          `json:"ServiceName" binding:"required"`
+	ScheduledSlot  ScheduledSlot   `json:"ScheduledSlot" binding:"required"`
+	Tasks          []TaskRequest   `json:"Tasks" binding:"required,min=1,dive"`
+	Segments       []ScheduledSlot `json:"Segments" binding:"omitempty,dive"`
+	ColorTag       string          `json:"ColorTag"`
+	// Draft saves the visit without announcing it until it is published.
+	Draft bool `json:"Draft"`
+}
+
+// CreateRecurringScheduleRequest is a new visit plus the rule it repeats by.
+type CreateRecurringScheduleRequest struct {
+	CreateScheduleRequest
+	Recurrence Recurrence `json:"Recurrence" binding:"required"`
 }
 
+// Recurrence repeats a visit every Interval days, weeks or months through
+// the Until date. Frequency is "daily", "weekly" or "monthly".
+type Recurrence struct {
+	Frequency string    `json:"Frequency" binding:"required"`
+	Interval  int       `json:"Interval" binding:"required"`
+	Until     time.Time `json:"Until" binding:"required"`
+}
+
+// TaskRequest is a task of a new visit. Kind defaults to generic; medication
+// tasks need Medication.
 type TaskRequest struct {
-	Title       string `json:"Title" binding:"required"`
-	Description string `json:"Description"`
+	Title       string          `json:"Title" binding:"required"`
+	Description string          `json:"Description"`
+	Kind        string          `json:"Kind"`
+	Medication  *TaskMedication `json:"Medication"`
+}
+
+// TaskMedication is the dose a medication task gives, and when.
+type TaskMedication struct {
+	DrugName    string    `json:"DrugName"`
+	Dose        string    `json:"Dose"`
+	Route       string    `json:"Route"`
+	ScheduledAt time.Time `json:"ScheduledAt"`
+}
+
+// Vitals are the readings of a vitals task. Temperature is in degrees
+// Celsius and blood glucose in mg/dL.
+type Vitals struct {
+	SystolicBP       *int     `json:"SystolicBP"`
+	DiastolicBP      *int     `json:"DiastolicBP"`
+	HeartRate        *int     `json:"HeartRate"`
+	RespiratoryRate  *int     `json:"RespiratoryRate"`
+	Temperature      *float64 `json:"Temperature"`
+	OxygenSaturation *int     `json:"OxygenSaturation"`
+	BloodGlucose     *float64 `json:"BloodGlucose"`
 }
 
 type ScheduledSlot struct {
@@ -25,28 +68,96 @@ type ScheduledSlot struct {
 	To   time.Time `json:"To" binding:"required"`
 }
 
+// UTC returns the slot normalized to UTC so stored boundaries never depend on
+// the offset the client happened to send.
+func (s ScheduledSlot) UTC() ScheduledSlot {
+	return ScheduledSlot{From: s.From.UTC(), To: s.To.UTC()}
+}
+
+type Segment struct {
+	ID                  uuid.UUID    `json:"ID"`
+	Sequence            int          `json:"Sequence"`
+	From                time.Time    `json:"From"`
+	To                  time.Time    `json:"To"`
+	VisitStatus         string       `json:"VisitStatus"`
+	CheckinTime         *time.Time   `json:"CheckinTime"`
+	CheckoutTime        *time.Time   `json:"CheckoutTime"`
+	CheckinLocation     Location     `json:"CheckinLocation"`
+	CheckoutLocation    Location     `json:"CheckoutLocation"`
+	CheckinVerification Verification `json:"CheckinVerification"`
+}
+
 type Location struct {
 	Lat  *float64 `json:"lat" binding:"required"`
 	Long *float64 `json:"long" binding:"required"`
 }
 
+type Verification struct {
+	Method   string     `json:"Method"`
+	NFCTagID *uuid.UUID `json:"NFCTagID"`
+	KioskID  *uuid.UUID `json:"KioskID"`
+	// Integrity is set when the app sent a device attestation.
+	Integrity *IntegrityCheck `json:"Integrity,omitempty"`
+}
+
+// IntegrityCheck is the outcome of checking a device attestation: verified,
+// failed, invalid or unverified.
+type IntegrityCheck struct {
+	Status   string `json:"Status"`
+	Platform string `json:"Platform"`
+	Detail   string `json:"Detail"`
+}
+
+// Attestation is the client's confirmation of the caregiver's arrival.
+type Attestation struct {
+	Status      string     `json:"Status"`
+	DueAt       *time.Time `json:"DueAt"`
+	RespondedAt *time.Time `json:"RespondedAt"`
+	DeviceID    string     `json:"DeviceID"`
+}
+
 type Task struct {
-	ID          uuid.UUID `json:"ID"`
-	Title       string    `json:"Title"`
-	Description string    `json:"Description"`
-	Status      string    `json:"Status"`
-	Done        *bool     `json:"Done"`
-	Feedback    *string   `json:"Feedback"`
+	ID          uuid.UUID       `json:"ID"`
+	Title       string          `json:"Title"`
+	Description string          `json:"Description"`
+	Kind        string          `json:"Kind"`
+	Medication  *TaskMedication `json:"Medication,omitempty"`
+	Vitals      *Vitals         `json:"Vitals,omitempty"`
+	Status      string          `json:"Status"`
+	Done        *bool           `json:"Done"`
+	Feedback    *string         `json:"Feedback"`
+	DeletedAt   *time.Time      `json:"DeletedAt,omitempty"`
+}
+
+// Attachment is the metadata of a file uploaded to the visit or one of its
+// tasks. The content is downloaded from the attachment endpoints.
+type Attachment struct {
+	ID                    uuid.UUID  `json:"ID"`
+	TaskID                *uuid.UUID `json:"TaskID,omitempty"`
+	FileName              string     `json:"FileName"`
+	ContentType           string     `json:"ContentType"`
+	Size                  int64      `json:"Size"`
+	RequiredBeforeCheckin bool       `json:"RequiredBeforeCheckin"`
+	UploadedByUserID      uuid.UUID  `json:"UploadedByUserID"`
+	CreatedAt             time.Time  `json:"CreatedAt"`
+}
+
+// NoteRevision is one version of the visit's service note.
+type NoteRevision struct {
+	Revision     int        `json:"Revision"`
+	Body         string     `json:"Body"`
+	AuthorUserID *uuid.UUID `json:"AuthorUserID"`
+	CreatedAt    time.Time  `json:"CreatedAt"`
 }
 
 type ClientInfo struct {
-	ID            uuid.UUID `json:"ID"`
-	UserName      string    `json:"UserName"`
-	Email         string    `json:"Email"`
-	FirstName     string    `json:"FirstName"`
-	LastName      string    `json:"LastName"`
-	ProfilePicture string    `json:"ProfilePicture"`
-	Location      ClientLocation `json:"Location"`
+	ID             uuid.UUID       `json:"ID"`
+	UserName       string          `json:"UserName,omitempty"`
+	Email          string          `json:"Email,omitempty"`
+	FirstName      string          `json:"FirstName"`
+	LastName       string          `json:"LastName"`
+	ProfilePicture string          `json:"ProfilePicture,omitempty"`
+	Location       *ClientLocation `json:"Location,omitempty"`
 }
 
 type ClientLocation struct {
@@ -60,30 +171,132 @@ type ClientLocation struct {
 }
 
 type ScheduleResponse struct {
-	ID               uuid.UUID      `json:"ID"`
-	ClientUserID     uuid.UUID      `json:"ClientUserID"`
-	ClientInfo       *ClientInfo    `json:"ClientInfo"`       
-	AssignedUserID   uuid.UUID      `json:"AssignedUserID"`  
-	ServiceName      string         `json:"ServiceName"`    
-	ScheduledSlot    ScheduledSlot  `json:"ScheduledSlot"`
-	VisitStatus      string         `json:"VisitStatus"`
-	CheckinTime      *time.Time     `json:"CheckinTime"`
-	CheckoutTime     *time.Time     `json:"CheckoutTime"`
-	CheckinLocation  Location       `json:"CheckinLocation"`
-	CheckoutLocation Location       `json:"CheckoutLocation"`
-	Tasks            []Task         `json:"Tasks"`
-	ServiceNote      *string        `json:"ServiceNote"`
+	ID                  uuid.UUID     `json:"ID"`
+	ClientUserID        uuid.UUID     `json:"ClientUserID"`
+	ClientInfo          *ClientInfo   `json:"ClientInfo"`
+	AssignedUserID      uuid.UUID     `json:"AssignedUserID"`
+	ServiceName         string        `json:"ServiceName"`
+	ScheduledSlot       ScheduledSlot `json:"ScheduledSlot"`
+	VisitStatus         string        `json:"VisitStatus"`
+	CheckinTime         *time.Time    `json:"CheckinTime"`
+	CheckoutTime        *time.Time    `json:"CheckoutTime"`
+	CheckinLocation     Location      `json:"CheckinLocation"`
+	CheckoutLocation    Location      `json:"CheckoutLocation"`
+	CheckinVerification Verification  `json:"CheckinVerification"`
+	Attestation         Attestation   `json:"Attestation"`
+	Tasks               []Task        `json:"Tasks"`
+	Segments            []Segment     `json:"Segments"`
+	Attachments         []Attachment  `json:"Attachments"`
+	ServiceNote         *string       `json:"ServiceNote"`
+	// Confidence is set once the visit is completed.
+	Confidence *Confidence `json:"Confidence,omitempty"`
+	// NoteRevisions are returned when a single schedule is fetched by ID.
+	NoteRevisions []NoteRevision `json:"NoteRevisions,omitempty"`
+	ColorTag      string         `json:"ColorTag"`
+	SeriesID      *uuid.UUID     `json:"SeriesID,omitempty"`
+	Recurrence    *Recurrence    `json:"Recurrence,omitempty"`
+	Warnings      []string       `json:"Warnings,omitempty"`
+	DeletedAt     *time.Time     `json:"DeletedAt,omitempty"`
+}
+
+type PublishSchedulesRequest struct {
+	ScheduleIDs []uuid.UUID `json:"ScheduleIDs" binding:"required,min=1"`
 }
 
+type PublishRejection struct {
+	ScheduleID uuid.UUID `json:"ScheduleID"`
+	Reason     string    `json:"Reason"`
+}
+
+type PublishSchedulesResponse struct {
+	Published []ScheduleResponse `json:"Published"`
+	Rejected  []PublishRejection `json:"Rejected"`
+}
+
+// TaskOutcomes counts a category's tasks. Unrecorded tasks were left
+// pending at checkout.
+type TaskOutcomes struct {
+	Total            int     `json:"Total"`
+	Completed        int     `json:"Completed"`
+	NotCompleted     int     `json:"NotCompleted"`
+	Unrecorded       int     `json:"Unrecorded"`
+	CompletionRate   float64 `json:"CompletionRate"`
+	NotCompletedRate float64 `json:"NotCompletedRate"`
+}
+
+type TaskPeriodOutcomes struct {
+	Start time.Time `json:"Start"`
+	TaskOutcomes
+}
+
+type TaskCategoryHistory struct {
+	Category string `json:"Category"`
+	TaskOutcomes
+	Periods []TaskPeriodOutcomes `json:"Periods"`
+}
+
+type TaskHistoryResponse struct {
+	ClientUserID uuid.UUID             `json:"ClientUserID"`
+	From         time.Time             `json:"From"`
+	To           time.Time             `json:"To"`
+	Period       string                `json:"Period"`
+	Categories   []TaskCategoryHistory `json:"Categories"`
+}
+
+type SearchSchedulesResponse struct {
+	Data       []ScheduleResponse `json:"Data"`
+	Total      int64              `json:"Total"`
+	Page       int                `json:"Page"`
+	PageSize   int                `json:"PageSize"`
+	TotalPages int                `json:"TotalPages"`
+}
+
+// StartScheduleRequest needs either a GPS location or the value of the NFC
+// tag scanned at the client's home.
 type StartScheduleRequest struct {
 	Timestamp time.Time `json:"timestamp" binding:"required"`
-	Location  Location  `json:"location" binding:"required"`
+	Location  *Location `json:"location"`
+	NFCTag    string    `json:"nfc_tag"`
+	// Device is the app's integrity report, sent when the platform offers
+	// one.
+	Device *DeviceIntegrity `json:"device"`
+	// IntegrityToken is a Play Integrity or App Attest token from platform,
+	// bound to the schedule's ID.
+	IntegrityToken string `json:"integrity_token"`
+	Platform       string `json:"platform" binding:"omitempty,oneof=android ios"`
+}
+
+// DeviceIntegrity hints reported by the caregiver's app. Fields left out
+// were not checked.
+type DeviceIntegrity struct {
+	MockLocation *bool `json:"mock_location"`
+	Rooted       *bool `json:"rooted"`
+	Emulator     *bool `json:"emulator"`
+}
+
+// ConfidenceSignal is one signal's part in a visit's verification
+// confidence, scored from 0 to 1.
+type ConfidenceSignal struct {
+	Name   string  `json:"Name"`
+	Score  float64 `json:"Score"`
+	Weight float64 `json:"Weight"`
+	Detail string  `json:"Detail"`
+}
+
+// Confidence is how well the visit's check-in and check-out evidence holds
+// up, from 0 to 100.
+type Confidence struct {
+	Score   int                `json:"Score"`
+	Signals []ConfidenceSignal `json:"Signals"`
 }
 
 type StartScheduleResponse struct {
-	Message         string     `json:"Message"`
-	CheckinTime     *time.Time `json:"checkin_time"`
-	CheckinLocation *Location  `json:"checkin_location"`
+	Message             string        `json:"Message"`
+	CheckinTime         *time.Time    `json:"checkin_time"`
+	CheckinLocation     *Location     `json:"checkin_location"`
+	CheckinVerification *Verification `json:"checkin_verification"`
+	// Attestation is set when the client has been asked to confirm arrival.
+	Attestation *Attestation `json:"attestation,omitempty"`
 }
 
 type EndScheduleTaskRequest struct {
@@ -93,12 +306,14 @@ type EndScheduleTaskRequest struct {
 	Status      string    `json:"Status" binding:"required"`
 	Done        *bool     `json:"Done" binding:"required"`
 	Feedback    *string   `json:"Feedback"`
+	// Vitals are the readings of a vitals task.
+	Vitals *Vitals `json:"Vitals"`
 }
 
 type EndScheduleRequest struct {
-	Timestamp    time.Time `json:"timestamp" binding:"required"`
-	Location     Location  `json:"location" binding:"required"`
-	Tasks        []EndScheduleTaskRequest `json:"tasks"` 
+	Timestamp time.Time                `json:"timestamp" binding:"required"`
+	Location  Location                 `json:"location" binding:"required"`
+	Tasks     []EndScheduleTaskRequest `json:"tasks"`
 }
 
 type EndScheduleResponse struct {
@@ -109,27 +324,35 @@ type EndScheduleResponse struct {
 }
 
 type UpdateTaskRequest struct {
-	Title       string    `json:"Title"`
-	Description string    `json:"Description"`
-	Status      string    `json:"Status" binding:"required"`
-	Done        *bool     `json:"Done" binding:"required"`
-	Feedback    *string   `json:"Feedback"`
+	Title       string  `json:"Title"`
+	Description string  `json:"Description"`
+	Status      string  `json:"Status" binding:"required"`
+	Done        *bool   `json:"Done" binding:"required"`
+	Feedback    *string `json:"Feedback"`
+	// Vitals are the readings of a vitals task.
+	Vitals *Vitals `json:"Vitals"`
 }
 
 type UpdateTaskResponse struct {
-	Message string     `json:"Message"`
-	Task    Task       `json:"Task"`
+	Message string `json:"Message"`
+	Task    Task   `json:"Task"`
 }
 
 type UpdateScheduleRequest struct {
-	ClientUserID     uuid.UUID     `json:"ClientUserID"`
-	AssignedUserID   uuid.UUID     `json:"AssignedUserID"`
-	ServiceName      string        `json:"ServiceName"`
-	ScheduledSlot    *ScheduledSlot `json:"ScheduledSlot"`
-	VisitStatus      string        `json:"VisitStatus"`
+	ClientUserID   uuid.UUID      `json:"ClientUserID"`
+	AssignedUserID uuid.UUID      `json:"AssignedUserID"`
+	ServiceName    string         `json:"ServiceName"`
+	ScheduledSlot  *ScheduledSlot `json:"ScheduledSlot"`
+	VisitStatus    string         `json:"VisitStatus"`
+	ColorTag       *string        `json:"ColorTag"`
 }
 
 type UpdateScheduleResponse struct {
-	Message  string           `json:"Message"`
+	Message  string            `json:"Message"`
 	Schedule *ScheduleResponse `json:"Schedule"`
-}
\ No newline at end of file
+}
+
+type UpdateSeriesResponse struct {
+	Message   string             `json:"Message"`
+	Schedules []ScheduleResponse `json:"Schedules"`
+}
@@ -0,0 +1,185 @@
+package scheduleview
+
+import (
+	"errors"
+	"net/http"
+
+	scheduleViewUseCase "caregiver/src/application/usecases/scheduleview"
+	"caregiver/src/domain"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainScheduleView "caregiver/src/domain/scheduleview"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IScheduleViewController interface {
+	CreateView(ctx *gin.Context)
+	GetViews(ctx *gin.Context)
+	GetViewByID(ctx *gin.Context)
+	UpdateView(ctx *gin.Context)
+	DeleteView(ctx *gin.Context)
+}
+
+type Controller struct {
+	scheduleViewUseCase scheduleViewUseCase.IScheduleViewUseCase
+	Logger              *logger.Logger
+}
+
+func NewScheduleViewController(scheduleViewUseCase scheduleViewUseCase.IScheduleViewUseCase, loggerInstance *logger.Logger) IScheduleViewController {
+	return &Controller{scheduleViewUseCase: scheduleViewUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateView(ctx *gin.Context) {
+	c.Logger.Info("Creating schedule view")
+	var request CreateViewRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new schedule view", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	view := toDomainMapper(request.Name, request.Filters, request.SortBy, request.SortDirection, request.ColorRules)
+	view.OwnerUserID = request.OwnerUserID
+	created, err := c.scheduleViewUseCase.Create(view)
+	if err != nil {
+		c.Logger.Error("Error creating schedule view", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Schedule view created successfully", zap.String("viewID", created.ID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(created))
+}
+
+// GetViews lists the saved views of the coordinator given by ?ownerUserID=.
+func (c *Controller) GetViews(ctx *gin.Context) {
+	ownerUserID, err := uuid.Parse(ctx.Query("ownerUserID"))
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("ownerUserID query parameter is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	views, err := c.scheduleViewUseCase.GetByOwner(ownerUserID)
+	if err != nil {
+		c.Logger.Error("Error getting schedule views", zap.Error(err), zap.String("ownerUserID", ownerUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ViewResponse, len(*views))
+	for i := range *views {
+		res[i] = *domainToResponseMapper(&(*views)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetViewByID(ctx *gin.Context) {
+	viewID, ok := c.parseViewID(ctx)
+	if !ok {
+		return
+	}
+	view, err := c.scheduleViewUseCase.GetByID(viewID)
+	if err != nil {
+		c.Logger.Error("Error getting schedule view", zap.Error(err), zap.String("id", viewID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(view))
+}
+
+func (c *Controller) UpdateView(ctx *gin.Context) {
+	viewID, ok := c.parseViewID(ctx)
+	if !ok {
+		return
+	}
+	var request UpdateViewRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for schedule view update", zap.Error(err), zap.String("id", viewID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	view := toDomainMapper(request.Name, request.Filters, request.SortBy, request.SortDirection, request.ColorRules)
+	updated, err := c.scheduleViewUseCase.Update(viewID, view)
+	if err != nil {
+		c.Logger.Error("Error updating schedule view", zap.Error(err), zap.String("id", viewID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(updated))
+}
+
+func (c *Controller) DeleteView(ctx *gin.Context) {
+	viewID, ok := c.parseViewID(ctx)
+	if !ok {
+		return
+	}
+	if err := c.scheduleViewUseCase.Delete(viewID); err != nil {
+		c.Logger.Error("Error deleting schedule view", zap.Error(err), zap.String("id", viewID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) parseViewID(ctx *gin.Context) (uuid.UUID, bool) {
+	viewID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule view ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("view id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return viewID, true
+}
+
+func toDomainMapper(name string, filters FiltersRequest, sortBy, sortDirection string, colorRules []ColorRuleRequest) *domainScheduleView.View {
+	rules := make([]domainSchedule.ColorRule, len(colorRules))
+	for i, rule := range colorRules {
+		rules[i] = domainSchedule.ColorRule{Field: rule.Field, Value: rule.Value, Color: rule.Color}
+	}
+	return &domainScheduleView.View{
+		Name: name,
+		Filters: domainScheduleView.Filters{
+			Statuses:        filters.Statuses,
+			AssignedUserIDs: filters.AssignedUserIDs,
+			ClientUserIDs:   filters.ClientUserIDs,
+			ServiceNames:    filters.ServiceNames,
+			Regions:         filters.Regions,
+			TeamIDs:         filters.TeamIDs,
+		},
+		SortBy:        sortBy,
+		SortDirection: domain.SortDirection(sortDirection),
+		ColorRules:    rules,
+	}
+}
+
+func domainToResponseMapper(v *domainScheduleView.View) *ViewResponse {
+	rules := make([]ColorRuleRequest, len(v.ColorRules))
+	for i, rule := range v.ColorRules {
+		rules[i] = ColorRuleRequest{Field: rule.Field, Value: rule.Value, Color: rule.Color}
+	}
+	return &ViewResponse{
+		ID:          v.ID,
+		OwnerUserID: v.OwnerUserID,
+		Name:        v.Name,
+		Filters: FiltersRequest{
+			Statuses:        v.Filters.Statuses,
+			AssignedUserIDs: v.Filters.AssignedUserIDs,
+			ClientUserIDs:   v.Filters.ClientUserIDs,
+			ServiceNames:    v.Filters.ServiceNames,
+			Regions:         v.Filters.Regions,
+			TeamIDs:         v.Filters.TeamIDs,
+		},
+		SortBy:        v.SortBy,
+		SortDirection: string(v.SortDirection),
+		ColorRules:    rules,
+		CreatedAt:     v.CreatedAt,
+		UpdatedAt:     v.UpdatedAt,
+	}
+}
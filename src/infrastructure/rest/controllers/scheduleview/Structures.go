@@ -0,0 +1,51 @@
+package scheduleview
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FiltersRequest struct {
+	Statuses        []string    `json:"Statuses"`
+	AssignedUserIDs []uuid.UUID `json:"AssignedUserIDs"`
+	ClientUserIDs   []uuid.UUID `json:"ClientUserIDs"`
+	ServiceNames    []string    `json:"ServiceNames"`
+	Regions         []string    `json:"Regions"`
+	TeamIDs         []uuid.UUID `json:"TeamIDs"`
+}
+
+type ColorRuleRequest struct {
+	Field string `json:"Field" binding:"required"`
+	Value string `json:"Value" binding:"required"`
+	Color string `json:"Color" binding:"required"`
+}
+
+type CreateViewRequest struct {
+	OwnerUserID   uuid.UUID          `json:"OwnerUserID" binding:"required"`
+	Name          string             `json:"Name" binding:"required"`
+	Filters       FiltersRequest     `json:"Filters"`
+	SortBy        string             `json:"SortBy"`
+	SortDirection string             `json:"SortDirection"`
+	ColorRules    []ColorRuleRequest `json:"ColorRules" binding:"omitempty,dive"`
+}
+
+type UpdateViewRequest struct {
+	Name          string             `json:"Name" binding:"required"`
+	Filters       FiltersRequest     `json:"Filters"`
+	SortBy        string             `json:"SortBy"`
+	SortDirection string             `json:"SortDirection"`
+	ColorRules    []ColorRuleRequest `json:"ColorRules" binding:"omitempty,dive"`
+}
+
+type ViewResponse struct {
+	ID            uuid.UUID          `json:"ID"`
+	OwnerUserID   uuid.UUID          `json:"OwnerUserID"`
+	Name          string             `json:"Name"`
+	Filters       FiltersRequest     `json:"Filters"`
+	SortBy        string             `json:"SortBy"`
+	SortDirection string             `json:"SortDirection"`
+	ColorRules    []ColorRuleRequest `json:"ColorRules"`
+	CreatedAt     time.Time          `json:"CreatedAt"`
+	UpdatedAt     time.Time          `json:"UpdatedAt"`
+}
@@ -0,0 +1,205 @@
+package screening
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	screeningUseCase "caregiver/src/application/usecases/screening"
+	domainErrors "caregiver/src/domain/errors"
+	domainScreening "caregiver/src/domain/screening"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IScreeningController interface {
+	OrderBackgroundCheck(ctx *gin.Context)
+	VerifyLicense(ctx *gin.Context)
+	RecordCheck(ctx *gin.Context)
+	GetChecks(ctx *gin.Context)
+	GetCheckByID(ctx *gin.Context)
+	RefreshCheck(ctx *gin.Context)
+	DeleteCheck(ctx *gin.Context)
+}
+
+type Controller struct {
+	screeningUseCase screeningUseCase.IScreeningUseCase
+	Logger           *logger.Logger
+}
+
+func NewScreeningController(screeningUseCase screeningUseCase.IScreeningUseCase, loggerInstance *logger.Logger) IScreeningController {
+	return &Controller{screeningUseCase: screeningUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) OrderBackgroundCheck(ctx *gin.Context) {
+	var request OrderBackgroundCheckRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for background check", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	check, err := c.screeningUseCase.OrderBackgroundCheck(request.CaregiverUserID, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error ordering background check", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Background check ordered successfully", zap.String("checkID", check.ID.String()))
+	ctx.JSON(http.StatusOK, toResponseMapper(check))
+}
+
+func (c *Controller) VerifyLicense(ctx *gin.Context) {
+	var request VerifyLicenseRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for license verification", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	check, err := c.screeningUseCase.VerifyLicense(request.CaregiverUserID, request.Number, request.State, request.Type, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error verifying license", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("License verified", zap.String("checkID", check.ID.String()), zap.String("status", check.Status))
+	ctx.JSON(http.StatusOK, toResponseMapper(check))
+}
+
+func (c *Controller) RecordCheck(ctx *gin.Context) {
+	var request RecordCheckRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for screening check", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	check := &domainScreening.Check{
+		CaregiverUserID: request.CaregiverUserID,
+		Kind:            request.Kind,
+		Status:          request.Status,
+		LicenseNumber:   request.LicenseNumber,
+		LicenseState:    request.LicenseState,
+		LicenseType:     request.LicenseType,
+		Detail:          request.Detail,
+		ExpiresAt:       request.ExpiresAt,
+	}
+	if request.OrderedAt != nil {
+		check.OrderedAt = *request.OrderedAt
+	}
+	created, err := c.screeningUseCase.RecordCheck(check, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error recording screening check", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Screening check recorded successfully", zap.String("checkID", created.ID.String()))
+	ctx.JSON(http.StatusOK, toResponseMapper(created))
+}
+
+// GetChecks lists checks, optionally narrowed by "caregiverID", "kind" and
+// "status".
+func (c *Controller) GetChecks(ctx *gin.Context) {
+	filter := domainScreening.CheckFilter{Kind: ctx.Query("kind"), Status: ctx.Query("status")}
+	if value := ctx.Query("caregiverID"); value != "" {
+		caregiverID, err := uuid.Parse(value)
+		if err != nil {
+			c.Logger.Error("Invalid caregiverID query parameter", zap.Error(err), zap.String("caregiverID", value))
+			appError := domainErrors.NewAppError(errors.New("caregiverID is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		filter.CaregiverUserID = &caregiverID
+	}
+	checks, err := c.screeningUseCase.GetChecks(filter)
+	if err != nil {
+		c.Logger.Error("Error getting screening checks", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]CheckResponse, len(*checks))
+	for i := range *checks {
+		res[i] = *toResponseMapper(&(*checks)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetCheckByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	check, err := c.screeningUseCase.GetCheckByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting screening check by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toResponseMapper(check))
+}
+
+// RefreshCheck asks the provider for the progress of a pending check.
+func (c *Controller) RefreshCheck(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	check, err := c.screeningUseCase.Refresh(id, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error refreshing screening check", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toResponseMapper(check))
+}
+
+func (c *Controller) DeleteCheck(ctx *gin.Context) {
+	id, ok := c.parseID(ctx)
+	if !ok {
+		return
+	}
+	if err := c.screeningUseCase.DeleteCheck(id); err != nil {
+		c.Logger.Error("Error deleting screening check", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Screening check deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) parseID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid screening check ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("screening check id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func toResponseMapper(c *domainScreening.Check) *CheckResponse {
+	return &CheckResponse{
+		ID:              c.ID,
+		CaregiverUserID: c.CaregiverUserID,
+		Kind:            c.Kind,
+		Provider:        c.Provider,
+		ExternalID:      c.ExternalID,
+		LicenseNumber:   c.LicenseNumber,
+		LicenseState:    c.LicenseState,
+		LicenseType:     c.LicenseType,
+		Status:          c.Status,
+		Detail:          c.Detail,
+		OrderedAt:       c.OrderedAt,
+		CompletedAt:     c.CompletedAt,
+		ExpiresAt:       c.ExpiresAt,
+		Expired:         c.Status == domainScreening.StatusPassed && c.Expired(time.Now()),
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
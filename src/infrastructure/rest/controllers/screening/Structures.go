@@ -0,0 +1,51 @@
+package screening
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderBackgroundCheckRequest struct {
+	CaregiverUserID uuid.UUID `json:"CaregiverUserID" binding:"required"`
+}
+
+type VerifyLicenseRequest struct {
+	CaregiverUserID uuid.UUID `json:"CaregiverUserID" binding:"required"`
+	Number          string    `json:"Number" binding:"required"`
+	State           string    `json:"State"`
+	Type            string    `json:"Type"`
+}
+
+// RecordCheckRequest enters a result obtained outside the screening provider.
+// Kind is "background_check" or "license"; Status is "passed" or "failed".
+type RecordCheckRequest struct {
+	CaregiverUserID uuid.UUID  `json:"CaregiverUserID" binding:"required"`
+	Kind            string     `json:"Kind" binding:"required"`
+	Status          string     `json:"Status" binding:"required"`
+	LicenseNumber   string     `json:"LicenseNumber"`
+	LicenseState    string     `json:"LicenseState"`
+	LicenseType     string     `json:"LicenseType"`
+	Detail          string     `json:"Detail"`
+	OrderedAt       *time.Time `json:"OrderedAt"`
+	ExpiresAt       *time.Time `json:"ExpiresAt"`
+}
+
+type CheckResponse struct {
+	ID              uuid.UUID  `json:"ID"`
+	CaregiverUserID uuid.UUID  `json:"CaregiverUserID"`
+	Kind            string     `json:"Kind"`
+	Provider        string     `json:"Provider"`
+	ExternalID      string     `json:"ExternalID"`
+	LicenseNumber   string     `json:"LicenseNumber"`
+	LicenseState    string     `json:"LicenseState"`
+	LicenseType     string     `json:"LicenseType"`
+	Status          string     `json:"Status"`
+	Detail          string     `json:"Detail"`
+	OrderedAt       time.Time  `json:"OrderedAt"`
+	CompletedAt     *time.Time `json:"CompletedAt"`
+	ExpiresAt       *time.Time `json:"ExpiresAt"`
+	Expired         bool       `json:"Expired"`
+	CreatedAt       time.Time  `json:"CreatedAt"`
+	UpdatedAt       time.Time  `json:"UpdatedAt"`
+}
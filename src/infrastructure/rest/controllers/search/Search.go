@@ -0,0 +1,75 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	searchUseCase "caregiver/src/application/usecases/search"
+	domainErrors "caregiver/src/domain/errors"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ISearchController interface {
+	Search(ctx *gin.Context)
+	Reindex(ctx *gin.Context)
+}
+
+type Controller struct {
+	searchUseCase searchUseCase.ISearchUseCase
+	Logger        *logger.Logger
+}
+
+func NewSearchController(searchUseCase searchUseCase.ISearchUseCase, loggerInstance *logger.Logger) ISearchController {
+	return &Controller{searchUseCase: searchUseCase, Logger: loggerInstance}
+}
+
+// Search ranks clients, caregivers, schedules and visit notes against the
+// "q" parameter. Repeat "type" to search only some of them; "limit" caps the
+// hits returned (20 by default, at most 100).
+func (c *Controller) Search(ctx *gin.Context) {
+	limit := 0
+	if value := ctx.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.Logger.Error("Invalid limit query parameter", zap.Error(err), zap.String("limit", value))
+			appError := domainErrors.NewAppError(errors.New("limit must be a whole number"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		limit = parsed
+	}
+	result, err := c.searchUseCase.Search(ctx.Query("q"), ctx.QueryArray("type"), limit)
+	if err != nil {
+		c.Logger.Error("Error searching", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	hits := make([]HitResponse, len(result.Hits))
+	for i, hit := range result.Hits {
+		hits[i] = HitResponse{
+			Type:       hit.Type,
+			ID:         hit.ID,
+			Title:      hit.Title,
+			Date:       hit.Date,
+			Score:      hit.Score,
+			Highlights: hit.Highlights,
+		}
+	}
+	ctx.JSON(http.StatusOK, SearchResponse{Total: result.Total, Hits: hits})
+}
+
+// Reindex rebuilds the search index from the database.
+func (c *Controller) Reindex(ctx *gin.Context) {
+	indexed, err := c.searchUseCase.Reindex()
+	if err != nil {
+		c.Logger.Error("Error reindexing search", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Search reindexed", zap.Int("indexed", indexed))
+	ctx.JSON(http.StatusOK, ReindexResponse{Indexed: indexed})
+}
@@ -0,0 +1,21 @@
+package search
+
+import "time"
+
+type HitResponse struct {
+	Type       string     `json:"Type"`
+	ID         string     `json:"ID"`
+	Title      string     `json:"Title"`
+	Date       *time.Time `json:"Date"`
+	Score      float64    `json:"Score"`
+	Highlights []string   `json:"Highlights"`
+}
+
+type SearchResponse struct {
+	Total int64         `json:"Total"`
+	Hits  []HitResponse `json:"Hits"`
+}
+
+type ReindexResponse struct {
+	Indexed int `json:"Indexed"`
+}
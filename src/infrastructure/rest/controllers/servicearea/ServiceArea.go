@@ -0,0 +1,139 @@
+package servicearea
+
+import (
+	"errors"
+	"net/http"
+
+	serviceAreaUseCase "caregiver/src/application/usecases/servicearea"
+	domainErrors "caregiver/src/domain/errors"
+	domainServiceArea "caregiver/src/domain/servicearea"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IServiceAreaController interface {
+	GetServiceAreas(ctx *gin.Context)
+	GetServiceArea(ctx *gin.Context)
+	SetServiceArea(ctx *gin.Context)
+	DeleteServiceArea(ctx *gin.Context)
+}
+
+type Controller struct {
+	serviceAreaUseCase serviceAreaUseCase.IServiceAreaUseCase
+	Logger             *logger.Logger
+}
+
+func NewServiceAreaController(serviceAreaUseCase serviceAreaUseCase.IServiceAreaUseCase, loggerInstance *logger.Logger) IServiceAreaController {
+	return &Controller{serviceAreaUseCase: serviceAreaUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) GetServiceAreas(ctx *gin.Context) {
+	c.Logger.Info("Getting all service areas")
+	areas, err := c.serviceAreaUseCase.GetAll()
+	if err != nil {
+		c.Logger.Error("Error getting service areas", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ServiceAreaResponse, len(*areas))
+	for i := range *areas {
+		res[i] = *domainToResponseMapper(&(*areas)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetServiceArea(ctx *gin.Context) {
+	userID, ok := c.parseUserID(ctx)
+	if !ok {
+		return
+	}
+	area, err := c.serviceAreaUseCase.GetByUserID(userID)
+	if err != nil {
+		c.Logger.Error("Error getting service area", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(area))
+}
+
+func (c *Controller) SetServiceArea(ctx *gin.Context) {
+	userID, ok := c.parseUserID(ctx)
+	if !ok {
+		return
+	}
+	var request SetServiceAreaRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for service area", zap.Error(err), zap.String("userID", userID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	polygon := make([]domainServiceArea.Point, len(request.Polygon))
+	for i, point := range request.Polygon {
+		polygon[i] = domainServiceArea.Point{Lat: point.Lat, Long: point.Long}
+	}
+	area, err := c.serviceAreaUseCase.Set(&domainServiceArea.Area{
+		UserID:     userID,
+		Type:       request.Type,
+		CenterLat:  request.CenterLat,
+		CenterLong: request.CenterLong,
+		RadiusKm:   request.RadiusKm,
+		Polygon:    polygon,
+		Mode:       request.Mode,
+	})
+	if err != nil {
+		c.Logger.Error("Error setting service area", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Service area set successfully", zap.String("userID", userID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(area))
+}
+
+func (c *Controller) DeleteServiceArea(ctx *gin.Context) {
+	userID, ok := c.parseUserID(ctx)
+	if !ok {
+		return
+	}
+	if err := c.serviceAreaUseCase.Delete(userID); err != nil {
+		c.Logger.Error("Error deleting service area", zap.Error(err), zap.String("userID", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) parseUserID(ctx *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(ctx.Param("userID"))
+	if err != nil {
+		c.Logger.Error("Invalid caregiver ID parameter", zap.Error(err), zap.String("userID", ctx.Param("userID")))
+		appError := domainErrors.NewAppError(errors.New("caregiver id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func domainToResponseMapper(a *domainServiceArea.Area) *ServiceAreaResponse {
+	polygon := make([]PointRequest, len(a.Polygon))
+	for i, point := range a.Polygon {
+		polygon[i] = PointRequest{Lat: point.Lat, Long: point.Long}
+	}
+	return &ServiceAreaResponse{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		Type:       a.Type,
+		CenterLat:  a.CenterLat,
+		CenterLong: a.CenterLong,
+		RadiusKm:   a.RadiusKm,
+		Polygon:    polygon,
+		Mode:       a.Mode,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package servicearea
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PointRequest struct {
+	Lat  float64 `json:"Lat"`
+	Long float64 `json:"Long"`
+}
+
+type SetServiceAreaRequest struct {
+	Type       string         `json:"Type" binding:"required"`
+	CenterLat  *float64       `json:"CenterLat"`
+	CenterLong *float64       `json:"CenterLong"`
+	RadiusKm   float64        `json:"RadiusKm"`
+	Polygon    []PointRequest `json:"Polygon"`
+	Mode       string         `json:"Mode"`
+}
+
+type ServiceAreaResponse struct {
+	ID         uuid.UUID      `json:"ID"`
+	UserID     uuid.UUID      `json:"UserID"`
+	Type       string         `json:"Type"`
+	CenterLat  *float64       `json:"CenterLat"`
+	CenterLong *float64       `json:"CenterLong"`
+	RadiusKm   float64        `json:"RadiusKm"`
+	Polygon    []PointRequest `json:"Polygon"`
+	Mode       string         `json:"Mode"`
+	CreatedAt  time.Time      `json:"CreatedAt"`
+	UpdatedAt  time.Time      `json:"UpdatedAt"`
+}
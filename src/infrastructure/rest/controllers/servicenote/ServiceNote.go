@@ -0,0 +1,112 @@
+package servicenote
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	serviceNoteUseCase "caregiver/src/application/usecases/servicenote"
+	domainErrors "caregiver/src/domain/errors"
+	domainServiceNote "caregiver/src/domain/servicenote"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IServiceNoteController interface {
+	CreateServiceNote(ctx *gin.Context)
+	UpdateServiceNote(ctx *gin.Context)
+	GetServiceNote(ctx *gin.Context)
+}
+
+type Controller struct {
+	serviceNoteUseCase serviceNoteUseCase.IServiceNoteUseCase
+	Logger             *logger.Logger
+}
+
+func NewServiceNoteController(serviceNoteUseCase serviceNoteUseCase.IServiceNoteUseCase, loggerInstance *logger.Logger) IServiceNoteController {
+	return &Controller{serviceNoteUseCase: serviceNoteUseCase, Logger: loggerInstance}
+}
+
+// CreateServiceNote writes a visit's first service note; UpdateServiceNote
+// saves a new revision of it. The caller is recorded as the author.
+func (c *Controller) CreateServiceNote(ctx *gin.Context) {
+	c.save(ctx, http.StatusCreated, c.serviceNoteUseCase.Create)
+}
+
+func (c *Controller) UpdateServiceNote(ctx *gin.Context) {
+	c.save(ctx, http.StatusOK, c.serviceNoteUseCase.Update)
+}
+
+// GetServiceNote returns the visit's current note and its revisions.
+func (c *Controller) GetServiceNote(ctx *gin.Context) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+	revisions, err := c.serviceNoteUseCase.GetHistory(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting service note", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, historyToResponseMapper(scheduleID, *revisions))
+}
+
+func (c *Controller) save(ctx *gin.Context, status int, save func(uuid.UUID, string, *uuid.UUID, time.Time) (*domainServiceNote.Revision, error)) {
+	scheduleID, ok := c.parseScheduleID(ctx)
+	if !ok {
+		return
+	}
+	var request SaveServiceNoteRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for service note", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	revision, err := save(scheduleID, request.Body, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error saving service note", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Service note saved successfully", zap.String("scheduleID", scheduleID.String()), zap.Int("revision", revision.Revision))
+	ctx.JSON(status, domainToResponseMapper(revision))
+}
+
+func (c *Controller) parseScheduleID(ctx *gin.Context) (uuid.UUID, bool) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter for service note", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return scheduleID, true
+}
+
+func domainToResponseMapper(revision *domainServiceNote.Revision) *RevisionResponse {
+	return &RevisionResponse{
+		ID:           revision.ID,
+		ScheduleID:   revision.ScheduleID,
+		Revision:     revision.Revision,
+		Body:         revision.Body,
+		AuthorUserID: revision.AuthorUserID,
+		CreatedAt:    revision.CreatedAt,
+	}
+}
+
+func historyToResponseMapper(scheduleID uuid.UUID, revisions []domainServiceNote.Revision) ServiceNoteResponse {
+	res := ServiceNoteResponse{ScheduleID: scheduleID, Revisions: make([]RevisionResponse, len(revisions))}
+	for i := range revisions {
+		res.Revisions[i] = *domainToResponseMapper(&revisions[i])
+	}
+	if n := len(res.Revisions); n > 0 {
+		res.Latest = &res.Revisions[n-1]
+	}
+	return res
+}
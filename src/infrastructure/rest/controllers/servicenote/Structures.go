@@ -0,0 +1,28 @@
+package servicenote
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SaveServiceNoteRequest struct {
+	Body string `json:"Body" binding:"required"`
+}
+
+type RevisionResponse struct {
+	ID           uuid.UUID  `json:"ID"`
+	ScheduleID   uuid.UUID  `json:"ScheduleID"`
+	Revision     int        `json:"Revision"`
+	Body         string     `json:"Body"`
+	AuthorUserID *uuid.UUID `json:"AuthorUserID"`
+	CreatedAt    time.Time  `json:"CreatedAt"`
+}
+
+// ServiceNoteResponse is the visit's current note, nil when it has none, and
+// every revision of it, oldest first.
+type ServiceNoteResponse struct {
+	ScheduleID uuid.UUID          `json:"ScheduleID"`
+	Latest     *RevisionResponse  `json:"Latest"`
+	Revisions  []RevisionResponse `json:"Revisions"`
+}
@@ -0,0 +1,168 @@
+package signature
+
+import (
+	"errors"
+	"net/http"
+
+	signatureUseCase "caregiver/src/application/usecases/signature"
+	domainErrors "caregiver/src/domain/errors"
+	domainSignature "caregiver/src/domain/signature"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ISignatureController interface {
+	SignAttachment(ctx *gin.Context)
+	GetAttachmentSignatures(ctx *gin.Context)
+	GetSignature(ctx *gin.Context)
+	VerifySignature(ctx *gin.Context)
+}
+
+type Controller struct {
+	signatureUseCase signatureUseCase.ISignatureUseCase
+	Logger           *logger.Logger
+}
+
+func NewSignatureController(signatureUseCase signatureUseCase.ISignatureUseCase, loggerInstance *logger.Logger) ISignatureController {
+	return &Controller{signatureUseCase: signatureUseCase, Logger: loggerInstance}
+}
+
+// SignAttachment captures a signature on a visit attachment. The caller's IP
+// and user agent are recorded alongside it.
+func (c *Controller) SignAttachment(ctx *gin.Context) {
+	scheduleID, attachmentID, ok := c.parseAttachmentPath(ctx)
+	if !ok {
+		return
+	}
+	var request SignRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for signature", zap.Error(err), zap.String("attachmentID", attachmentID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	signature, err := c.signatureUseCase.Sign(&domainSignature.Signature{
+		AttachmentID:  attachmentID,
+		ScheduleID:    scheduleID,
+		DocumentType:  request.DocumentType,
+		SignerUserID:  request.SignerUserID,
+		Method:        request.Method,
+		SignatureData: request.SignatureData,
+		IPAddress:     ctx.ClientIP(),
+		UserAgent:     ctx.Request.UserAgent(),
+	})
+	if err != nil {
+		c.Logger.Error("Error signing attachment", zap.Error(err), zap.String("attachmentID", attachmentID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Attachment signed successfully", zap.String("signatureID", signature.ID.String()))
+	ctx.JSON(http.StatusCreated, domainToResponseMapper(signature))
+}
+
+func (c *Controller) GetAttachmentSignatures(ctx *gin.Context) {
+	scheduleID, attachmentID, ok := c.parseAttachmentPath(ctx)
+	if !ok {
+		return
+	}
+	signatures, err := c.signatureUseCase.GetByAttachment(scheduleID, attachmentID)
+	if err != nil {
+		c.Logger.Error("Error getting signatures", zap.Error(err), zap.String("attachmentID", attachmentID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]SignatureResponse, len(*signatures))
+	for i := range *signatures {
+		res[i] = *domainToResponseMapper(&(*signatures)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetSignature(ctx *gin.Context) {
+	signatureID, ok := c.parseSignatureID(ctx)
+	if !ok {
+		return
+	}
+	signature, err := c.signatureUseCase.GetByID(signatureID)
+	if err != nil {
+		c.Logger.Error("Error getting signature", zap.Error(err), zap.String("id", signatureID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(signature))
+}
+
+// VerifySignature reports whether the signed document and the signature
+// record are unchanged since signing.
+func (c *Controller) VerifySignature(ctx *gin.Context) {
+	signatureID, ok := c.parseSignatureID(ctx)
+	if !ok {
+		return
+	}
+	verification, err := c.signatureUseCase.Verify(signatureID)
+	if err != nil {
+		c.Logger.Error("Error verifying signature", zap.Error(err), zap.String("id", signatureID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, VerificationResponse{
+		Signature:           *domainToResponseMapper(verification.Signature),
+		CurrentDocumentHash: verification.CurrentDocumentHash,
+		DocumentFound:       verification.DocumentFound,
+		DocumentIntact:      verification.DocumentIntact,
+		RecordIntact:        verification.RecordIntact,
+		Valid:               verification.Valid,
+	})
+}
+
+func (c *Controller) parseAttachmentPath(ctx *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, uuid.Nil, false
+	}
+	attachmentID, err := uuid.Parse(ctx.Param("attachmentId"))
+	if err != nil {
+		c.Logger.Error("Invalid attachment ID parameter", zap.Error(err), zap.String("attachmentId", ctx.Param("attachmentId")))
+		appError := domainErrors.NewAppError(errors.New("attachment id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return scheduleID, attachmentID, true
+}
+
+func (c *Controller) parseSignatureID(ctx *gin.Context) (uuid.UUID, bool) {
+	signatureID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid signature ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("signature id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return signatureID, true
+}
+
+func domainToResponseMapper(s *domainSignature.Signature) *SignatureResponse {
+	return &SignatureResponse{
+		ID:            s.ID,
+		AttachmentID:  s.AttachmentID,
+		ScheduleID:    s.ScheduleID,
+		DocumentType:  s.DocumentType,
+		SignerUserID:  s.SignerUserID,
+		SignerName:    s.SignerName,
+		Method:        s.Method,
+		SignatureData: s.SignatureData,
+		DocumentHash:  s.DocumentHash,
+		IPAddress:     s.IPAddress,
+		UserAgent:     s.UserAgent,
+		SignedAt:      s.SignedAt,
+		RecordHash:    s.RecordHash,
+	}
+}
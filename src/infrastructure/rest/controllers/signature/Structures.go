@@ -0,0 +1,39 @@
+package signature
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SignRequest struct {
+	SignerUserID  uuid.UUID `json:"SignerUserID" binding:"required"`
+	DocumentType  string    `json:"DocumentType" binding:"required"`
+	Method        string    `json:"Method" binding:"required"`
+	SignatureData string    `json:"SignatureData" binding:"required"`
+}
+
+type SignatureResponse struct {
+	ID            uuid.UUID `json:"ID"`
+	AttachmentID  uuid.UUID `json:"AttachmentID"`
+	ScheduleID    uuid.UUID `json:"ScheduleID"`
+	DocumentType  string    `json:"DocumentType"`
+	SignerUserID  uuid.UUID `json:"SignerUserID"`
+	SignerName    string    `json:"SignerName"`
+	Method        string    `json:"Method"`
+	SignatureData string    `json:"SignatureData"`
+	DocumentHash  string    `json:"DocumentHash"`
+	IPAddress     string    `json:"IPAddress"`
+	UserAgent     string    `json:"UserAgent"`
+	SignedAt      time.Time `json:"SignedAt"`
+	RecordHash    string    `json:"RecordHash"`
+}
+
+type VerificationResponse struct {
+	Signature           SignatureResponse `json:"Signature"`
+	CurrentDocumentHash string            `json:"CurrentDocumentHash"`
+	DocumentFound       bool              `json:"DocumentFound"`
+	DocumentIntact      bool              `json:"DocumentIntact"`
+	RecordIntact        bool              `json:"RecordIntact"`
+	Valid               bool              `json:"Valid"`
+}
@@ -0,0 +1,300 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	statementUseCase "caregiver/src/application/usecases/statement"
+	domainErrors "caregiver/src/domain/errors"
+	domainStatement "caregiver/src/domain/statement"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type IStatementController interface {
+	GetStatement(ctx *gin.Context)
+	RecordPayment(ctx *gin.Context)
+	GetPayments(ctx *gin.Context)
+	DeletePayment(ctx *gin.Context)
+	RecordAdjustment(ctx *gin.Context)
+	GetAdjustments(ctx *gin.Context)
+	DeleteAdjustment(ctx *gin.Context)
+}
+
+type Controller struct {
+	statementUseCase statementUseCase.IStatementUseCase
+	Logger           *logger.Logger
+}
+
+func NewStatementController(statementUseCase statementUseCase.IStatementUseCase, loggerInstance *logger.Logger) IStatementController {
+	return &Controller{statementUseCase: statementUseCase, Logger: loggerInstance}
+}
+
+// GetStatement returns the client's statement for the "from" through "to"
+// days (YYYY-MM-DD). With "format=pdf" it is sent as a printable PDF for
+// mailing instead.
+func (c *Controller) GetStatement(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "id", "client")
+	if !ok {
+		return
+	}
+	from, ok := c.parseDate(ctx, "from", ctx.Query("from"))
+	if !ok {
+		return
+	}
+	to, ok := c.parseDate(ctx, "to", ctx.Query("to"))
+	if !ok {
+		return
+	}
+	format := ctx.DefaultQuery("format", "json")
+	if format != "json" && format != "pdf" {
+		appError := domainErrors.NewAppError(errors.New("format must be 'json' or 'pdf'"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	statement, err := c.statementUseCase.GetStatement(clientID, from, to, time.Now())
+	if err != nil {
+		c.Logger.Error("Error building client statement", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	if format == "pdf" {
+		content, err := c.statementUseCase.RenderStatement(statement)
+		if err != nil {
+			c.Logger.Error("Error rendering client statement", zap.Error(err), zap.String("clientID", clientID.String()))
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("statement-%s.pdf", to.Format(dateLayout))))
+		ctx.Data(http.StatusOK, "application/pdf", content)
+		return
+	}
+
+	res := StatementResponse{
+		ClientUserID:   statement.ClientUserID,
+		ClientName:     statement.ClientName,
+		From:           statement.From.Format(dateLayout),
+		To:             statement.To.Format(dateLayout),
+		OpeningBalance: statement.OpeningBalance,
+		Invoiced:       statement.Invoiced,
+		Paid:           statement.Paid,
+		Adjusted:       statement.Adjusted,
+		ClosingBalance: statement.ClosingBalance,
+		Lines:          make([]StatementLineResponse, len(statement.Lines)),
+		GeneratedAt:    statement.GeneratedAt,
+	}
+	for i, line := range statement.Lines {
+		res.Lines[i] = StatementLineResponse{
+			Date:        line.Date,
+			Type:        line.Type,
+			Reference:   line.Reference,
+			Description: line.Description,
+			Amount:      line.Amount,
+			Balance:     line.Balance,
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) RecordPayment(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "id", "client")
+	if !ok {
+		return
+	}
+	var request RecordPaymentRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new client payment", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	payment, err := c.statementUseCase.RecordPayment(&domainStatement.Payment{
+		ClientUserID: clientID,
+		Amount:       request.Amount,
+		Method:       request.Method,
+		Reference:    request.Reference,
+		Note:         request.Note,
+		ReceivedAt:   request.ReceivedAt,
+	})
+	if err != nil {
+		c.Logger.Error("Error recording client payment", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Client payment recorded successfully", zap.String("paymentID", payment.ID.String()))
+	ctx.JSON(http.StatusOK, paymentToResponseMapper(payment))
+}
+
+// GetPayments lists the payments received from the client, optionally
+// narrowed to the "from" through "to" days (YYYY-MM-DD).
+func (c *Controller) GetPayments(ctx *gin.Context) {
+	filter, ok := c.parseFilter(ctx)
+	if !ok {
+		return
+	}
+	payments, err := c.statementUseCase.GetPayments(filter)
+	if err != nil {
+		c.Logger.Error("Error getting client payments", zap.Error(err), zap.String("clientID", filter.ClientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]PaymentResponse, len(*payments))
+	for i := range *payments {
+		res[i] = *paymentToResponseMapper(&(*payments)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) DeletePayment(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "payment")
+	if !ok {
+		return
+	}
+	if err := c.statementUseCase.DeletePayment(id); err != nil {
+		c.Logger.Error("Error deleting client payment", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Client payment deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) RecordAdjustment(ctx *gin.Context) {
+	clientID, ok := c.parseID(ctx, "id", "client")
+	if !ok {
+		return
+	}
+	var request RecordAdjustmentRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new client account adjustment", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	adjustment, err := c.statementUseCase.RecordAdjustment(&domainStatement.Adjustment{
+		ClientUserID: clientID,
+		Amount:       request.Amount,
+		Reason:       request.Reason,
+		EffectiveAt:  request.EffectiveAt,
+	})
+	if err != nil {
+		c.Logger.Error("Error recording client account adjustment", zap.Error(err), zap.String("clientID", clientID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Client account adjustment recorded successfully", zap.String("adjustmentID", adjustment.ID.String()))
+	ctx.JSON(http.StatusOK, adjustmentToResponseMapper(adjustment))
+}
+
+// GetAdjustments lists the manual adjustments to the client's account,
+// optionally narrowed to the "from" through "to" days (YYYY-MM-DD).
+func (c *Controller) GetAdjustments(ctx *gin.Context) {
+	filter, ok := c.parseFilter(ctx)
+	if !ok {
+		return
+	}
+	adjustments, err := c.statementUseCase.GetAdjustments(filter)
+	if err != nil {
+		c.Logger.Error("Error getting client account adjustments", zap.Error(err), zap.String("clientID", filter.ClientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]AdjustmentResponse, len(*adjustments))
+	for i := range *adjustments {
+		res[i] = *adjustmentToResponseMapper(&(*adjustments)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) DeleteAdjustment(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "adjustment")
+	if !ok {
+		return
+	}
+	if err := c.statementUseCase.DeleteAdjustment(id); err != nil {
+		c.Logger.Error("Error deleting client account adjustment", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Client account adjustment deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) parseFilter(ctx *gin.Context) (domainStatement.Filter, bool) {
+	clientID, ok := c.parseID(ctx, "id", "client")
+	if !ok {
+		return domainStatement.Filter{}, false
+	}
+	filter := domainStatement.Filter{ClientUserID: clientID}
+	if value := ctx.Query("from"); value != "" {
+		from, ok := c.parseDate(ctx, "from", value)
+		if !ok {
+			return filter, false
+		}
+		filter.From = &from
+	}
+	if value := ctx.Query("to"); value != "" {
+		to, ok := c.parseDate(ctx, "to", value)
+		if !ok {
+			return filter, false
+		}
+		end := to.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+	return filter, true
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func paymentToResponseMapper(p *domainStatement.Payment) *PaymentResponse {
+	return &PaymentResponse{
+		ID:           p.ID,
+		ClientUserID: p.ClientUserID,
+		Amount:       p.Amount,
+		Method:       p.Method,
+		Reference:    p.Reference,
+		Note:         p.Note,
+		ReceivedAt:   p.ReceivedAt,
+		CreatedAt:    p.CreatedAt,
+	}
+}
+
+func adjustmentToResponseMapper(a *domainStatement.Adjustment) *AdjustmentResponse {
+	return &AdjustmentResponse{
+		ID:           a.ID,
+		ClientUserID: a.ClientUserID,
+		Amount:       a.Amount,
+		Reason:       a.Reason,
+		EffectiveAt:  a.EffectiveAt,
+		CreatedAt:    a.CreatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package statement
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecordPaymentRequest records money received from the client. Method is
+// "check", "card", "ach" or "cash"; Reference is e.g. the check number.
+type RecordPaymentRequest struct {
+	Amount     float64   `json:"Amount" binding:"required"`
+	Method     string    `json:"Method" binding:"required"`
+	Reference  string    `json:"Reference"`
+	Note       string    `json:"Note"`
+	ReceivedAt time.Time `json:"ReceivedAt" binding:"required"`
+}
+
+type PaymentResponse struct {
+	ID           uuid.UUID `json:"ID"`
+	ClientUserID uuid.UUID `json:"ClientUserID"`
+	Amount       float64   `json:"Amount"`
+	Method       string    `json:"Method"`
+	Reference    string    `json:"Reference"`
+	Note         string    `json:"Note"`
+	ReceivedAt   time.Time `json:"ReceivedAt"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+}
+
+// RecordAdjustmentRequest charges the client a positive Amount or credits
+// them a negative one.
+type RecordAdjustmentRequest struct {
+	Amount      float64   `json:"Amount" binding:"required"`
+	Reason      string    `json:"Reason" binding:"required"`
+	EffectiveAt time.Time `json:"EffectiveAt" binding:"required"`
+}
+
+type AdjustmentResponse struct {
+	ID           uuid.UUID `json:"ID"`
+	ClientUserID uuid.UUID `json:"ClientUserID"`
+	Amount       float64   `json:"Amount"`
+	Reason       string    `json:"Reason"`
+	EffectiveAt  time.Time `json:"EffectiveAt"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+}
+
+// StatementLineResponse is positive for charges and negative for credits.
+type StatementLineResponse struct {
+	Date        time.Time `json:"Date"`
+	Type        string    `json:"Type"`
+	Reference   string    `json:"Reference"`
+	Description string    `json:"Description"`
+	Amount      float64   `json:"Amount"`
+	Balance     float64   `json:"Balance"`
+}
+
+type StatementResponse struct {
+	ClientUserID   uuid.UUID               `json:"ClientUserID"`
+	ClientName     string                  `json:"ClientName"`
+	From           string                  `json:"From"`
+	To             string                  `json:"To"`
+	OpeningBalance float64                 `json:"OpeningBalance"`
+	Invoiced       float64                 `json:"Invoiced"`
+	Paid           float64                 `json:"Paid"`
+	Adjusted       float64                 `json:"Adjusted"`
+	ClosingBalance float64                 `json:"ClosingBalance"`
+	Lines          []StatementLineResponse `json:"Lines"`
+	GeneratedAt    time.Time               `json:"GeneratedAt"`
+}
@@ -0,0 +1,90 @@
+package supply
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateItemRequest adds a catalog entry. OnHand is the opening stock count.
+type CreateItemRequest struct {
+	Name         string  `json:"Name" binding:"required"`
+	SKU          string  `json:"SKU"`
+	Unit         string  `json:"Unit" binding:"required"`
+	UnitCost     float64 `json:"UnitCost"`
+	OnHand       int     `json:"OnHand"`
+	ReorderLevel int     `json:"ReorderLevel"`
+}
+
+// UpdateItemRequest edits a catalog entry. Setting OnHand records a stock
+// count.
+type UpdateItemRequest struct {
+	Name         *string  `json:"Name"`
+	SKU          *string  `json:"SKU"`
+	Unit         *string  `json:"Unit"`
+	UnitCost     *float64 `json:"UnitCost"`
+	OnHand       *int     `json:"OnHand"`
+	ReorderLevel *int     `json:"ReorderLevel"`
+	Active       *bool    `json:"Active"`
+}
+
+type RestockRequest struct {
+	Quantity int `json:"Quantity" binding:"required"`
+}
+
+type ItemResponse struct {
+	ID           uuid.UUID `json:"ID"`
+	Name         string    `json:"Name"`
+	SKU          string    `json:"SKU"`
+	Unit         string    `json:"Unit"`
+	UnitCost     float64   `json:"UnitCost"`
+	OnHand       int       `json:"OnHand"`
+	ReorderLevel int       `json:"ReorderLevel"`
+	LowStock     bool      `json:"LowStock"`
+	Active       bool      `json:"Active"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+	UpdatedAt    time.Time `json:"UpdatedAt"`
+}
+
+// LogUsageRequest records supplies used on the visit. UsedAt defaults to the
+// visit's check-in time.
+type LogUsageRequest struct {
+	ItemID   uuid.UUID  `json:"ItemID" binding:"required"`
+	Quantity int        `json:"Quantity" binding:"required"`
+	Note     string     `json:"Note"`
+	UsedAt   *time.Time `json:"UsedAt"`
+}
+
+type UsageResponse struct {
+	ID              uuid.UUID `json:"ID"`
+	ItemID          uuid.UUID `json:"ItemID"`
+	ScheduleID      uuid.UUID `json:"ScheduleID"`
+	ClientUserID    uuid.UUID `json:"ClientUserID"`
+	CaregiverUserID uuid.UUID `json:"CaregiverUserID"`
+	Quantity        int       `json:"Quantity"`
+	UnitCost        float64   `json:"UnitCost"`
+	Note            string    `json:"Note"`
+	UsedAt          time.Time `json:"UsedAt"`
+	CreatedAt       time.Time `json:"CreatedAt"`
+}
+
+type ConsumptionLineResponse struct {
+	ItemID   uuid.UUID `json:"ItemID"`
+	Name     string    `json:"Name"`
+	Unit     string    `json:"Unit"`
+	Quantity int       `json:"Quantity"`
+	Cost     float64   `json:"Cost"`
+}
+
+type ClientConsumptionResponse struct {
+	ClientUserID uuid.UUID                 `json:"ClientUserID"`
+	Lines        []ConsumptionLineResponse `json:"Lines"`
+	TotalCost    float64                   `json:"TotalCost"`
+}
+
+type ConsumptionReportResponse struct {
+	From      string                      `json:"From"`
+	To        string                      `json:"To"`
+	Clients   []ClientConsumptionResponse `json:"Clients"`
+	TotalCost float64                     `json:"TotalCost"`
+}
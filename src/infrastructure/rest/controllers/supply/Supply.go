@@ -0,0 +1,359 @@
+package supply
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	supplyUseCase "caregiver/src/application/usecases/supply"
+	domainErrors "caregiver/src/domain/errors"
+	domainSupply "caregiver/src/domain/supply"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type ISupplyController interface {
+	CreateItem(ctx *gin.Context)
+	GetItems(ctx *gin.Context)
+	GetItemByID(ctx *gin.Context)
+	UpdateItem(ctx *gin.Context)
+	DeleteItem(ctx *gin.Context)
+	RestockItem(ctx *gin.Context)
+	GetLowStockItems(ctx *gin.Context)
+	LogUsage(ctx *gin.Context)
+	GetVisitUsages(ctx *gin.Context)
+	DeleteUsage(ctx *gin.Context)
+	GetConsumptionReport(ctx *gin.Context)
+}
+
+type Controller struct {
+	supplyUseCase supplyUseCase.ISupplyUseCase
+	Logger        *logger.Logger
+}
+
+func NewSupplyController(supplyUseCase supplyUseCase.ISupplyUseCase, loggerInstance *logger.Logger) ISupplyController {
+	return &Controller{supplyUseCase: supplyUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateItem(ctx *gin.Context) {
+	var request CreateItemRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new supply item", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	item, err := c.supplyUseCase.CreateItem(&domainSupply.Item{
+		Name:         request.Name,
+		SKU:          request.SKU,
+		Unit:         request.Unit,
+		UnitCost:     request.UnitCost,
+		OnHand:       request.OnHand,
+		ReorderLevel: request.ReorderLevel,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating supply item", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Supply item created successfully", zap.String("itemID", item.ID.String()))
+	ctx.JSON(http.StatusOK, itemToResponseMapper(item))
+}
+
+func (c *Controller) GetItems(ctx *gin.Context) {
+	items, err := c.supplyUseCase.GetItems()
+	if err != nil {
+		c.Logger.Error("Error getting supply items", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, itemsToResponseMapper(items))
+}
+
+func (c *Controller) GetItemByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "item")
+	if !ok {
+		return
+	}
+	item, err := c.supplyUseCase.GetItemByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting supply item by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, itemToResponseMapper(item))
+}
+
+func (c *Controller) UpdateItem(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "item")
+	if !ok {
+		return
+	}
+	var request UpdateItemRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for supply item update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.SKU != nil {
+		updates["sku"] = *request.SKU
+	}
+	if request.Unit != nil {
+		updates["unit"] = *request.Unit
+	}
+	if request.UnitCost != nil {
+		updates["unit_cost"] = *request.UnitCost
+	}
+	if request.OnHand != nil {
+		updates["on_hand"] = *request.OnHand
+	}
+	if request.ReorderLevel != nil {
+		updates["reorder_level"] = *request.ReorderLevel
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	item, err := c.supplyUseCase.UpdateItem(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating supply item", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Supply item updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, itemToResponseMapper(item))
+}
+
+func (c *Controller) DeleteItem(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "item")
+	if !ok {
+		return
+	}
+	if err := c.supplyUseCase.DeleteItem(id); err != nil {
+		c.Logger.Error("Error deleting supply item", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Supply item deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) RestockItem(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "item")
+	if !ok {
+		return
+	}
+	var request RestockRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for supply restock", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	item, err := c.supplyUseCase.Restock(id, request.Quantity)
+	if err != nil {
+		c.Logger.Error("Error restocking supply item", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Supply item restocked successfully", zap.String("id", id.String()), zap.Int("onHand", item.OnHand))
+	ctx.JSON(http.StatusOK, itemToResponseMapper(item))
+}
+
+// GetLowStockItems lists the active items at or below their reorder level.
+func (c *Controller) GetLowStockItems(ctx *gin.Context) {
+	items, err := c.supplyUseCase.GetLowStockItems()
+	if err != nil {
+		c.Logger.Error("Error getting low stock supply items", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, itemsToResponseMapper(items))
+}
+
+func (c *Controller) LogUsage(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	var request LogUsageRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for supply usage", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	usage := &domainSupply.Usage{
+		ItemID:     request.ItemID,
+		ScheduleID: scheduleID,
+		Quantity:   request.Quantity,
+		Note:       request.Note,
+	}
+	if request.UsedAt != nil {
+		usage.UsedAt = *request.UsedAt
+	}
+	created, err := c.supplyUseCase.LogUsage(usage)
+	if err != nil {
+		c.Logger.Error("Error logging supply usage", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Supply usage logged successfully", zap.String("usageID", created.ID.String()))
+	ctx.JSON(http.StatusOK, usageToResponseMapper(created))
+}
+
+func (c *Controller) GetVisitUsages(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	usages, err := c.supplyUseCase.GetUsages(domainSupply.UsageFilter{ScheduleID: &scheduleID})
+	if err != nil {
+		c.Logger.Error("Error getting supply usages", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]UsageResponse, len(*usages))
+	for i := range *usages {
+		res[i] = *usageToResponseMapper(&(*usages)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) DeleteUsage(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "usage")
+	if !ok {
+		return
+	}
+	if err := c.supplyUseCase.DeleteUsage(id); err != nil {
+		c.Logger.Error("Error deleting supply usage", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Supply usage deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetConsumptionReport totals supply usage per client for the "from" through
+// "to" days (YYYY-MM-DD), optionally for a single "clientID".
+func (c *Controller) GetConsumptionReport(ctx *gin.Context) {
+	from, ok := c.parseDate(ctx, "from", ctx.Query("from"))
+	if !ok {
+		return
+	}
+	to, ok := c.parseDate(ctx, "to", ctx.Query("to"))
+	if !ok {
+		return
+	}
+	var clientID *uuid.UUID
+	if value := ctx.Query("clientID"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.Logger.Error("Invalid clientID query parameter", zap.Error(err), zap.String("clientID", value))
+			appError := domainErrors.NewAppError(errors.New("client id is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		clientID = &id
+	}
+
+	report, err := c.supplyUseCase.ConsumptionReport(clientID, from, to)
+	if err != nil {
+		c.Logger.Error("Error building supply consumption report", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := ConsumptionReportResponse{
+		From:      report.From.Format(dateLayout),
+		To:        report.To.Format(dateLayout),
+		Clients:   make([]ClientConsumptionResponse, len(report.Clients)),
+		TotalCost: report.TotalCost,
+	}
+	for i, client := range report.Clients {
+		lines := make([]ConsumptionLineResponse, len(client.Lines))
+		for j, line := range client.Lines {
+			lines[j] = ConsumptionLineResponse{ItemID: line.ItemID, Name: line.Name, Unit: line.Unit, Quantity: line.Quantity, Cost: line.Cost}
+		}
+		res.Clients[i] = ClientConsumptionResponse{ClientUserID: client.ClientUserID, Lines: lines, TotalCost: client.TotalCost}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func itemToResponseMapper(i *domainSupply.Item) *ItemResponse {
+	return &ItemResponse{
+		ID:           i.ID,
+		Name:         i.Name,
+		SKU:          i.SKU,
+		Unit:         i.Unit,
+		UnitCost:     i.UnitCost,
+		OnHand:       i.OnHand,
+		ReorderLevel: i.ReorderLevel,
+		LowStock:     i.IsLow(),
+		Active:       i.Active,
+		CreatedAt:    i.CreatedAt,
+		UpdatedAt:    i.UpdatedAt,
+	}
+}
+
+func itemsToResponseMapper(items *[]domainSupply.Item) []ItemResponse {
+	res := make([]ItemResponse, len(*items))
+	for i := range *items {
+		res[i] = *itemToResponseMapper(&(*items)[i])
+	}
+	return res
+}
+
+func usageToResponseMapper(u *domainSupply.Usage) *UsageResponse {
+	return &UsageResponse{
+		ID:              u.ID,
+		ItemID:          u.ItemID,
+		ScheduleID:      u.ScheduleID,
+		ClientUserID:    u.ClientUserID,
+		CaregiverUserID: u.CaregiverUserID,
+		Quantity:        u.Quantity,
+		UnitCost:        u.UnitCost,
+		Note:            u.Note,
+		UsedAt:          u.UsedAt,
+		CreatedAt:       u.CreatedAt,
+	}
+}
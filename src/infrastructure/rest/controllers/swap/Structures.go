@@ -0,0 +1,29 @@
+package swap
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SwapRequest struct {
+	Reason string `json:"Reason"`
+}
+
+type DecisionRequest struct {
+	Note string `json:"Note"`
+}
+
+type SwapResponse struct {
+	ID           uuid.UUID  `json:"ID"`
+	ScheduleID   uuid.UUID  `json:"ScheduleID"`
+	FromUserID   uuid.UUID  `json:"FromUserID"`
+	ToUserID     *uuid.UUID `json:"ToUserID,omitempty"`
+	Reason       string     `json:"Reason,omitempty"`
+	Status       string     `json:"Status"`
+	AcceptedAt   *time.Time `json:"AcceptedAt,omitempty"`
+	DecidedBy    *uuid.UUID `json:"DecidedBy,omitempty"`
+	DecidedAt    *time.Time `json:"DecidedAt,omitempty"`
+	DecisionNote string     `json:"DecisionNote,omitempty"`
+	CreatedAt    time.Time  `json:"CreatedAt"`
+}
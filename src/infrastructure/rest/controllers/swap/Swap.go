@@ -0,0 +1,207 @@
+package swap
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	swapUseCase "caregiver/src/application/usecases/swap"
+	domainErrors "caregiver/src/domain/errors"
+	domainSwap "caregiver/src/domain/swap"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ISwapController interface {
+	RequestSwap(ctx *gin.Context)
+	GetHistory(ctx *gin.Context)
+	GetOpenSwaps(ctx *gin.Context)
+	GetSwap(ctx *gin.Context)
+	AcceptSwap(ctx *gin.Context)
+	CancelSwap(ctx *gin.Context)
+	GetSwaps(ctx *gin.Context)
+	ApproveSwap(ctx *gin.Context)
+	RejectSwap(ctx *gin.Context)
+}
+
+type Controller struct {
+	swapUseCase swapUseCase.ISwapUseCase
+	Logger      *logger.Logger
+}
+
+func NewSwapController(swapUseCase swapUseCase.ISwapUseCase, loggerInstance *logger.Logger) ISwapController {
+	return &Controller{swapUseCase: swapUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) RequestSwap(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	var request SwapRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for swap request", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	swap, err := c.swapUseCase.RequestSwap(scheduleID, request.Reason, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error requesting swap", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Swap requested", zap.String("id", swap.ID.String()), zap.String("scheduleID", scheduleID.String()))
+	ctx.JSON(http.StatusCreated, swapToResponseMapper(swap))
+}
+
+func (c *Controller) GetHistory(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "id", "schedule")
+	if !ok {
+		return
+	}
+	swaps, err := c.swapUseCase.GetHistory(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting swap history", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, swapsToResponseMapper(swaps))
+}
+
+// GetOpenSwaps lists the visits offered for swapping that the caller could
+// take over.
+func (c *Controller) GetOpenSwaps(ctx *gin.Context) {
+	swaps, err := c.swapUseCase.GetOpenSwaps(controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error getting open swaps", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, swapsToResponseMapper(swaps))
+}
+
+func (c *Controller) GetSwap(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "swap")
+	if !ok {
+		return
+	}
+	swap, err := c.swapUseCase.GetSwap(id)
+	if err != nil {
+		c.Logger.Error("Error getting swap", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, swapToResponseMapper(swap))
+}
+
+func (c *Controller) AcceptSwap(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "swap")
+	if !ok {
+		return
+	}
+	swap, err := c.swapUseCase.AcceptSwap(id, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error accepting swap", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Swap accepted", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, swapToResponseMapper(swap))
+}
+
+func (c *Controller) CancelSwap(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "id", "swap")
+	if !ok {
+		return
+	}
+	swap, err := c.swapUseCase.CancelSwap(id, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error cancelling swap", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Swap cancelled", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, swapToResponseMapper(swap))
+}
+
+// GetSwaps lists swaps in the status query parameter, by default those
+// waiting for approval.
+func (c *Controller) GetSwaps(ctx *gin.Context) {
+	swaps, err := c.swapUseCase.GetSwaps(ctx.Query("status"), controllers.CallerID(ctx))
+	if err != nil {
+		c.Logger.Error("Error getting swaps", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, swapsToResponseMapper(swaps))
+}
+
+func (c *Controller) ApproveSwap(ctx *gin.Context) {
+	c.decide(ctx, "approve", c.swapUseCase.ApproveSwap)
+}
+
+func (c *Controller) RejectSwap(ctx *gin.Context) {
+	c.decide(ctx, "reject", c.swapUseCase.RejectSwap)
+}
+
+func (c *Controller) decide(ctx *gin.Context, action string, decide func(uuid.UUID, string, *uuid.UUID, time.Time) (*domainSwap.Request, error)) {
+	id, ok := c.parseID(ctx, "id", "swap")
+	if !ok {
+		return
+	}
+	var request DecisionRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for swap decision", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	swap, err := decide(id, request.Note, controllers.CallerID(ctx), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error deciding swap", zap.Error(err), zap.String("id", id.String()), zap.String("action", action))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Swap decided", zap.String("id", id.String()), zap.String("status", swap.Status))
+	ctx.JSON(http.StatusOK, swapToResponseMapper(swap))
+}
+
+func (c *Controller) parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String(param, ctx.Param(param)))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func swapsToResponseMapper(swaps *[]domainSwap.Request) []SwapResponse {
+	res := make([]SwapResponse, len(*swaps))
+	for i := range *swaps {
+		res[i] = *swapToResponseMapper(&(*swaps)[i])
+	}
+	return res
+}
+
+func swapToResponseMapper(swap *domainSwap.Request) *SwapResponse {
+	return &SwapResponse{
+		ID:           swap.ID,
+		ScheduleID:   swap.ScheduleID,
+		FromUserID:   swap.FromUserID,
+		ToUserID:     swap.ToUserID,
+		Reason:       swap.Reason,
+		Status:       swap.Status,
+		AcceptedAt:   swap.AcceptedAt,
+		DecidedBy:    swap.DecidedBy,
+		DecidedAt:    swap.DecidedAt,
+		DecisionNote: swap.DecisionNote,
+		CreatedAt:    swap.CreatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package team
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateTeamRequest struct {
+	Name             string    `json:"Name" binding:"required"`
+	Region           string    `json:"Region"`
+	SupervisorUserID uuid.UUID `json:"SupervisorUserID" binding:"required"`
+}
+
+type UpdateTeamRequest struct {
+	Name             *string    `json:"Name"`
+	Region           *string    `json:"Region"`
+	SupervisorUserID *uuid.UUID `json:"SupervisorUserID"`
+}
+
+type AddMemberRequest struct {
+	UserID uuid.UUID `json:"UserID" binding:"required"`
+}
+
+type TeamResponse struct {
+	ID               uuid.UUID   `json:"ID"`
+	Name             string      `json:"Name"`
+	Region           string      `json:"Region"`
+	SupervisorUserID uuid.UUID   `json:"SupervisorUserID"`
+	MemberUserIDs    []uuid.UUID `json:"MemberUserIDs"`
+	CreatedAt        time.Time   `json:"CreatedAt"`
+	UpdatedAt        time.Time   `json:"UpdatedAt"`
+}
+
+type VisitResponse struct {
+	ID                uuid.UUID  `json:"ID"`
+	ClientUserID      uuid.UUID  `json:"ClientUserID"`
+	AssignedUserID    uuid.UUID  `json:"AssignedUserID"`
+	ServiceName       string     `json:"ServiceName"`
+	ScheduledSlotFrom time.Time  `json:"ScheduledSlotFrom"`
+	ScheduledSlotTo   time.Time  `json:"ScheduledSlotTo"`
+	VisitStatus       string     `json:"VisitStatus"`
+	CheckinTime       *time.Time `json:"CheckinTime"`
+	CheckoutTime      *time.Time `json:"CheckoutTime"`
+	ColorTag          string     `json:"ColorTag"`
+}
+
+type ExceptionResponse struct {
+	ID             uuid.UUID `json:"ID"`
+	RuleID         uuid.UUID `json:"RuleID"`
+	RuleName       string    `json:"RuleName"`
+	ScheduleID     uuid.UUID `json:"ScheduleID"`
+	ClientUserID   uuid.UUID `json:"ClientUserID"`
+	AssignedUserID uuid.UUID `json:"AssignedUserID"`
+	Message        string    `json:"Message"`
+	DetectedAt     time.Time `json:"DetectedAt"`
+}
+
+type OverviewResponse struct {
+	Team       TeamResponse        `json:"Team"`
+	From       time.Time           `json:"From"`
+	To         time.Time           `json:"To"`
+	Visits     []VisitResponse     `json:"Visits"`
+	Total      int64               `json:"Total"`
+	Page       int                 `json:"Page"`
+	PageSize   int                 `json:"PageSize"`
+	TotalPages int                 `json:"TotalPages"`
+	Exceptions []ExceptionResponse `json:"Exceptions"`
+}
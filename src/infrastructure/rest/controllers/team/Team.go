@@ -0,0 +1,307 @@
+package team
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	teamUseCase "caregiver/src/application/usecases/team"
+	domainErrors "caregiver/src/domain/errors"
+	domainTeam "caregiver/src/domain/team"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultOverviewDays is the look-back and look-ahead of a team overview
+// when no range is given.
+const defaultOverviewDays = 7
+
+type ITeamController interface {
+	GetTeams(ctx *gin.Context)
+	CreateTeam(ctx *gin.Context)
+	GetTeamByID(ctx *gin.Context)
+	UpdateTeam(ctx *gin.Context)
+	DeleteTeam(ctx *gin.Context)
+	AddMember(ctx *gin.Context)
+	RemoveMember(ctx *gin.Context)
+	GetOverview(ctx *gin.Context)
+}
+
+type Controller struct {
+	teamUseCase teamUseCase.ITeamUseCase
+	Logger      *logger.Logger
+}
+
+func NewTeamController(teamUseCase teamUseCase.ITeamUseCase, loggerInstance *logger.Logger) ITeamController {
+	return &Controller{teamUseCase: teamUseCase, Logger: loggerInstance}
+}
+
+// GetTeams lists all teams, or only those led by ?supervisorUserID=.
+func (c *Controller) GetTeams(ctx *gin.Context) {
+	var teams *[]domainTeam.Team
+	var err error
+	if supervisor := ctx.Query("supervisorUserID"); supervisor != "" {
+		supervisorUserID, parseErr := uuid.Parse(supervisor)
+		if parseErr != nil {
+			appError := domainErrors.NewAppError(errors.New("supervisorUserID is invalid"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		teams, err = c.teamUseCase.GetBySupervisor(supervisorUserID)
+	} else {
+		teams, err = c.teamUseCase.GetAll()
+	}
+	if err != nil {
+		c.Logger.Error("Error getting teams", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]TeamResponse, len(*teams))
+	for i := range *teams {
+		res[i] = *domainToResponseMapper(&(*teams)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) CreateTeam(ctx *gin.Context) {
+	var request CreateTeamRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new team", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	team, err := c.teamUseCase.Create(&domainTeam.Team{
+		Name:             request.Name,
+		Region:           request.Region,
+		SupervisorUserID: request.SupervisorUserID,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating team", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Team created successfully", zap.String("teamID", team.ID.String()))
+	ctx.JSON(http.StatusCreated, domainToResponseMapper(team))
+}
+
+func (c *Controller) GetTeamByID(ctx *gin.Context) {
+	id, ok := c.parseTeamID(ctx)
+	if !ok {
+		return
+	}
+	team, err := c.teamUseCase.GetByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting team", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(team))
+}
+
+func (c *Controller) UpdateTeam(ctx *gin.Context) {
+	id, ok := c.parseTeamID(ctx)
+	if !ok {
+		return
+	}
+	var request UpdateTeamRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for team update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Region != nil {
+		updates["region"] = *request.Region
+	}
+	if request.SupervisorUserID != nil {
+		updates["supervisor_user_id"] = *request.SupervisorUserID
+	}
+	if len(updates) == 0 {
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	team, err := c.teamUseCase.Update(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating team", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(team))
+}
+
+func (c *Controller) DeleteTeam(ctx *gin.Context) {
+	id, ok := c.parseTeamID(ctx)
+	if !ok {
+		return
+	}
+	if err := c.teamUseCase.Delete(id); err != nil {
+		c.Logger.Error("Error deleting team", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) AddMember(ctx *gin.Context) {
+	id, ok := c.parseTeamID(ctx)
+	if !ok {
+		return
+	}
+	var request AddMemberRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for team member", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	team, err := c.teamUseCase.AddMember(id, request.UserID)
+	if err != nil {
+		c.Logger.Error("Error adding team member", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(team))
+}
+
+func (c *Controller) RemoveMember(ctx *gin.Context) {
+	id, ok := c.parseTeamID(ctx)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(ctx.Param("userID"))
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("user id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	team, err := c.teamUseCase.RemoveMember(id, userID)
+	if err != nil {
+		c.Logger.Error("Error removing team member", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(team))
+}
+
+// GetOverview returns the team's visits and compliance exceptions for its
+// supervisor, given as ?supervisorUserID=. The optional "from" and "to"
+// parameters (RFC3339) default to a week either side of now.
+func (c *Controller) GetOverview(ctx *gin.Context) {
+	id, ok := c.parseTeamID(ctx)
+	if !ok {
+		return
+	}
+	supervisorUserID, err := uuid.Parse(ctx.Query("supervisorUserID"))
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("supervisorUserID query parameter is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	now := time.Now().UTC()
+	from, to := now.AddDate(0, 0, -defaultOverviewDays), now.AddDate(0, 0, defaultOverviewDays)
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			appError := domainErrors.NewAppError(errors.New(param.name+" must be RFC3339"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		*param.target = parsed.UTC()
+	}
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("pageSize", "10"))
+
+	overview, err := c.teamUseCase.Overview(id, supervisorUserID, from, to, page, pageSize)
+	if err != nil {
+		c.Logger.Error("Error building team overview", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res := OverviewResponse{
+		Team:       *domainToResponseMapper(&overview.Team),
+		From:       overview.From,
+		To:         overview.To,
+		Visits:     make([]VisitResponse, len(*overview.Visits.Data)),
+		Total:      overview.Visits.Total,
+		Page:       overview.Visits.Page,
+		PageSize:   overview.Visits.PageSize,
+		TotalPages: overview.Visits.TotalPages,
+		Exceptions: make([]ExceptionResponse, len(overview.Exceptions)),
+	}
+	for i, s := range *overview.Visits.Data {
+		res.Visits[i] = VisitResponse{
+			ID:                s.ID,
+			ClientUserID:      s.ClientUserID,
+			AssignedUserID:    s.AssignedUserID,
+			ServiceName:       s.ServiceName,
+			ScheduledSlotFrom: s.ScheduledSlot.From,
+			ScheduledSlotTo:   s.ScheduledSlot.To,
+			VisitStatus:       s.VisitStatus,
+			CheckinTime:       s.CheckinTime,
+			CheckoutTime:      s.CheckoutTime,
+			ColorTag:          s.Color(),
+		}
+	}
+	for i, e := range overview.Exceptions {
+		res.Exceptions[i] = ExceptionResponse{
+			ID:             e.ID,
+			RuleID:         e.RuleID,
+			RuleName:       e.RuleName,
+			ScheduleID:     e.ScheduleID,
+			ClientUserID:   e.ClientUserID,
+			AssignedUserID: e.AssignedUserID,
+			Message:        e.Message,
+			DetectedAt:     e.DetectedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseTeamID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid team ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("team id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func domainToResponseMapper(t *domainTeam.Team) *TeamResponse {
+	memberIDs := t.MemberUserIDs
+	if memberIDs == nil {
+		memberIDs = []uuid.UUID{}
+	}
+	return &TeamResponse{
+		ID:               t.ID,
+		Name:             t.Name,
+		Region:           t.Region,
+		SupervisorUserID: t.SupervisorUserID,
+		MemberUserIDs:    memberIDs,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+}
@@ -0,0 +1,50 @@
+package timesheet
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PolicyResponse struct {
+	RoundingMinutes int     `json:"RoundingMinutes"`
+	Rounding        string  `json:"Rounding"`
+	DailyOvertime   float64 `json:"DailyOvertime"`
+	WeeklyOvertime  float64 `json:"WeeklyOvertime"`
+}
+
+type EntryResponse struct {
+	ScheduleID    uuid.UUID `json:"ScheduleID"`
+	ServiceName   string    `json:"ServiceName"`
+	CheckinTime   time.Time `json:"CheckinTime"`
+	CheckoutTime  time.Time `json:"CheckoutTime"`
+	WorkedMinutes float64   `json:"WorkedMinutes"`
+	PaidMinutes   int       `json:"PaidMinutes"`
+}
+
+type DayResponse struct {
+	Date          string          `json:"Date"`
+	RegularHours  float64         `json:"RegularHours"`
+	OvertimeHours float64         `json:"OvertimeHours"`
+	Entries       []EntryResponse `json:"Entries"`
+}
+
+type TimesheetResponse struct {
+	CaregiverUserID uuid.UUID     `json:"CaregiverUserID"`
+	FirstName       string        `json:"FirstName"`
+	LastName        string        `json:"LastName"`
+	Email           string        `json:"Email"`
+	RegularHours    float64       `json:"RegularHours"`
+	OvertimeHours   float64       `json:"OvertimeHours"`
+	TotalHours      float64       `json:"TotalHours"`
+	Visits          int           `json:"Visits"`
+	Days            []DayResponse `json:"Days"`
+}
+
+// ReportResponse covers the days From through To, both inclusive.
+type ReportResponse struct {
+	From       string              `json:"From"`
+	To         string              `json:"To"`
+	Policy     PolicyResponse      `json:"Policy"`
+	Timesheets []TimesheetResponse `json:"Timesheets"`
+}
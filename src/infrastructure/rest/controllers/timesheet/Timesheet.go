@@ -0,0 +1,132 @@
+package timesheet
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	timesheetUseCase "caregiver/src/application/usecases/timesheet"
+	domainErrors "caregiver/src/domain/errors"
+	domainTimesheet "caregiver/src/domain/timesheet"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type ITimesheetController interface {
+	GetTimesheets(ctx *gin.Context)
+}
+
+type Controller struct {
+	timesheetUseCase timesheetUseCase.ITimesheetUseCase
+	Logger           *logger.Logger
+}
+
+func NewTimesheetController(timesheetUseCase timesheetUseCase.ITimesheetUseCase, loggerInstance *logger.Logger) ITimesheetController {
+	return &Controller{timesheetUseCase: timesheetUseCase, Logger: loggerInstance}
+}
+
+// GetTimesheets returns the hours worked per caregiver from the "from" day
+// through the "to" day (YYYY-MM-DD, both required), optionally for one
+// "caregiver_id". With "format=json" it returns the timesheets with their
+// days and visits; otherwise it downloads the payroll CSV.
+func (c *Controller) GetTimesheets(ctx *gin.Context) {
+	var from, to time.Time
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := ctx.Query(param.name)
+		day, err := time.Parse(dateLayout, value)
+		if err != nil {
+			c.Logger.Error("Invalid date for timesheets", zap.Error(err), zap.String(param.name, value))
+			appError := domainErrors.NewAppError(errors.New(param.name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		*param.target = day
+	}
+	caregiverUserID, err := controllers.GetQueryUUID(ctx, "caregiver_id")
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("caregiver_id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	to = to.AddDate(0, 0, 1)
+
+	switch ctx.DefaultQuery("format", "csv") {
+	case "csv":
+		export, err := c.timesheetUseCase.Export(from, to, caregiverUserID)
+		if err != nil {
+			c.Logger.Error("Error exporting timesheets", zap.Error(err))
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName))
+		ctx.Data(http.StatusOK, export.ContentType, export.Data)
+	case "json":
+		report, err := c.timesheetUseCase.Report(from, to, caregiverUserID)
+		if err != nil {
+			c.Logger.Error("Error building timesheets", zap.Error(err))
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, reportToResponseMapper(report))
+	default:
+		appError := domainErrors.NewAppError(errors.New("format must be 'csv' or 'json'"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+	}
+}
+
+func reportToResponseMapper(r *domainTimesheet.Report) ReportResponse {
+	res := ReportResponse{
+		From: r.From.Format(dateLayout),
+		To:   r.To.AddDate(0, 0, -1).Format(dateLayout),
+		Policy: PolicyResponse{
+			RoundingMinutes: r.Policy.RoundingMinutes,
+			Rounding:        r.Policy.Rounding,
+			DailyOvertime:   r.Policy.DailyOvertime,
+			WeeklyOvertime:  r.Policy.WeeklyOvertime,
+		},
+		Timesheets: make([]TimesheetResponse, len(r.Timesheets)),
+	}
+	for i, t := range r.Timesheets {
+		days := make([]DayResponse, len(t.Days))
+		for j, day := range t.Days {
+			entries := make([]EntryResponse, len(day.Entries))
+			for k, e := range day.Entries {
+				entries[k] = EntryResponse{
+					ScheduleID:    e.ScheduleID,
+					ServiceName:   e.ServiceName,
+					CheckinTime:   e.CheckinTime,
+					CheckoutTime:  e.CheckoutTime,
+					WorkedMinutes: e.WorkedMinutes,
+					PaidMinutes:   e.Minutes,
+				}
+			}
+			days[j] = DayResponse{
+				Date:          day.Date.Format(dateLayout),
+				RegularHours:  domainTimesheet.Hours(day.RegularMinutes),
+				OvertimeHours: domainTimesheet.Hours(day.OvertimeMinutes),
+				Entries:       entries,
+			}
+		}
+		res.Timesheets[i] = TimesheetResponse{
+			CaregiverUserID: t.CaregiverUserID,
+			FirstName:       t.FirstName,
+			LastName:        t.LastName,
+			Email:           t.Email,
+			RegularHours:    domainTimesheet.Hours(t.RegularMinutes),
+			OvertimeHours:   domainTimesheet.Hours(t.OvertimeMinutes),
+			TotalHours:      domainTimesheet.Hours(t.RegularMinutes + t.OvertimeMinutes),
+			Visits:          t.Visits,
+			Days:            days,
+		}
+	}
+	return res
+}
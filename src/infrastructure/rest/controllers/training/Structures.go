@@ -0,0 +1,80 @@
+package training
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateCourseRequest adds a training course. RequiredForRole ("admin",
+// "caregiver" or "client") makes it mandatory for that role; ServiceNames
+// lists the services whose visits need it.
+type CreateCourseRequest struct {
+	Name            string   `json:"Name" binding:"required"`
+	Description     string   `json:"Description"`
+	RequiredForRole string   `json:"RequiredForRole"`
+	ServiceNames    []string `json:"ServiceNames"`
+	DueWithinDays   int      `json:"DueWithinDays"`
+	ValidityDays    int      `json:"ValidityDays"`
+}
+
+type UpdateCourseRequest struct {
+	Name            *string   `json:"Name"`
+	Description     *string   `json:"Description"`
+	RequiredForRole *string   `json:"RequiredForRole"`
+	ServiceNames    *[]string `json:"ServiceNames"`
+	DueWithinDays   *int      `json:"DueWithinDays"`
+	ValidityDays    *int      `json:"ValidityDays"`
+	Active          *bool     `json:"Active"`
+}
+
+type CourseResponse struct {
+	ID              uuid.UUID `json:"ID"`
+	Name            string    `json:"Name"`
+	Description     string    `json:"Description"`
+	RequiredForRole string    `json:"RequiredForRole"`
+	ServiceNames    []string  `json:"ServiceNames"`
+	DueWithinDays   int       `json:"DueWithinDays"`
+	ValidityDays    int       `json:"ValidityDays"`
+	Active          bool      `json:"Active"`
+	CreatedAt       time.Time `json:"CreatedAt"`
+	UpdatedAt       time.Time `json:"UpdatedAt"`
+}
+
+// RecordCompletionRequest logs a user finishing a course. CompletedAt
+// defaults to now and ExpiresAt to the course's validity.
+type RecordCompletionRequest struct {
+	CourseID    uuid.UUID  `json:"CourseID" binding:"required"`
+	UserID      uuid.UUID  `json:"UserID" binding:"required"`
+	CompletedAt *time.Time `json:"CompletedAt"`
+	ExpiresAt   *time.Time `json:"ExpiresAt"`
+	Certificate string     `json:"Certificate"`
+	Note        string     `json:"Note"`
+}
+
+type CompletionResponse struct {
+	ID          uuid.UUID  `json:"ID"`
+	CourseID    uuid.UUID  `json:"CourseID"`
+	UserID      uuid.UUID  `json:"UserID"`
+	CompletedAt time.Time  `json:"CompletedAt"`
+	ExpiresAt   *time.Time `json:"ExpiresAt"`
+	Certificate string     `json:"Certificate"`
+	Note        string     `json:"Note"`
+	CreatedAt   time.Time  `json:"CreatedAt"`
+}
+
+type RequirementResponse struct {
+	CourseID    uuid.UUID  `json:"CourseID"`
+	CourseName  string     `json:"CourseName"`
+	Status      string     `json:"Status"`
+	DueAt       *time.Time `json:"DueAt"`
+	CompletedAt *time.Time `json:"CompletedAt"`
+}
+
+type UserComplianceResponse struct {
+	UserID       uuid.UUID             `json:"UserID"`
+	FirstName    string                `json:"FirstName"`
+	LastName     string                `json:"LastName"`
+	Role         string                `json:"Role"`
+	Requirements []RequirementResponse `json:"Requirements"`
+}
@@ -0,0 +1,346 @@
+package training
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	trainingUseCase "caregiver/src/application/usecases/training"
+	domainErrors "caregiver/src/domain/errors"
+	domainTraining "caregiver/src/domain/training"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ITrainingController interface {
+	CreateCourse(ctx *gin.Context)
+	GetCourses(ctx *gin.Context)
+	GetCourseByID(ctx *gin.Context)
+	UpdateCourse(ctx *gin.Context)
+	DeleteCourse(ctx *gin.Context)
+	RecordCompletion(ctx *gin.Context)
+	GetCompletions(ctx *gin.Context)
+	DeleteCompletion(ctx *gin.Context)
+	GetUserRequirements(ctx *gin.Context)
+	GetOutOfCompliance(ctx *gin.Context)
+}
+
+type Controller struct {
+	trainingUseCase trainingUseCase.ITrainingUseCase
+	Logger          *logger.Logger
+}
+
+func NewTrainingController(trainingUseCase trainingUseCase.ITrainingUseCase, loggerInstance *logger.Logger) ITrainingController {
+	return &Controller{trainingUseCase: trainingUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateCourse(ctx *gin.Context) {
+	var request CreateCourseRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for new training course", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	course, err := c.trainingUseCase.CreateCourse(&domainTraining.Course{
+		Name:            request.Name,
+		Description:     request.Description,
+		RequiredForRole: request.RequiredForRole,
+		ServiceNames:    request.ServiceNames,
+		DueWithinDays:   request.DueWithinDays,
+		ValidityDays:    request.ValidityDays,
+	})
+	if err != nil {
+		c.Logger.Error("Error creating training course", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Training course created successfully", zap.String("courseID", course.ID.String()))
+	ctx.JSON(http.StatusOK, courseToResponseMapper(course))
+}
+
+func (c *Controller) GetCourses(ctx *gin.Context) {
+	courses, err := c.trainingUseCase.GetCourses()
+	if err != nil {
+		c.Logger.Error("Error getting training courses", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]CourseResponse, len(*courses))
+	for i := range *courses {
+		res[i] = *courseToResponseMapper(&(*courses)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetCourseByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "course")
+	if !ok {
+		return
+	}
+	course, err := c.trainingUseCase.GetCourseByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting training course by ID", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, courseToResponseMapper(course))
+}
+
+func (c *Controller) UpdateCourse(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "course")
+	if !ok {
+		return
+	}
+	var request UpdateCourseRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for training course update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Description != nil {
+		updates["description"] = *request.Description
+	}
+	if request.RequiredForRole != nil {
+		updates["required_for_role"] = *request.RequiredForRole
+	}
+	if request.ServiceNames != nil {
+		updates["service_names"] = *request.ServiceNames
+	}
+	if request.DueWithinDays != nil {
+		updates["due_within_days"] = *request.DueWithinDays
+	}
+	if request.ValidityDays != nil {
+		updates["validity_days"] = *request.ValidityDays
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	course, err := c.trainingUseCase.UpdateCourse(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating training course", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Training course updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, courseToResponseMapper(course))
+}
+
+func (c *Controller) DeleteCourse(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "course")
+	if !ok {
+		return
+	}
+	if err := c.trainingUseCase.DeleteCourse(id); err != nil {
+		c.Logger.Error("Error deleting training course", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Training course deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) RecordCompletion(ctx *gin.Context) {
+	var request RecordCompletionRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for training completion", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	completion := &domainTraining.Completion{
+		CourseID:    request.CourseID,
+		UserID:      request.UserID,
+		ExpiresAt:   request.ExpiresAt,
+		Certificate: request.Certificate,
+		Note:        request.Note,
+	}
+	if request.CompletedAt != nil {
+		completion.CompletedAt = *request.CompletedAt
+	}
+	created, err := c.trainingUseCase.RecordCompletion(completion, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error recording training completion", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Training completion recorded successfully", zap.String("completionID", created.ID.String()))
+	ctx.JSON(http.StatusOK, completionToResponseMapper(created))
+}
+
+// GetCompletions lists completions, optionally narrowed by "userID" and
+// "courseID".
+func (c *Controller) GetCompletions(ctx *gin.Context) {
+	var filter domainTraining.CompletionFilter
+	var ok bool
+	if filter.UserID, ok = c.queryID(ctx, "userID"); !ok {
+		return
+	}
+	if filter.CourseID, ok = c.queryID(ctx, "courseID"); !ok {
+		return
+	}
+	completions, err := c.trainingUseCase.GetCompletions(filter)
+	if err != nil {
+		c.Logger.Error("Error getting training completions", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]CompletionResponse, len(*completions))
+	for i := range *completions {
+		res[i] = *completionToResponseMapper(&(*completions)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) DeleteCompletion(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "completion")
+	if !ok {
+		return
+	}
+	if err := c.trainingUseCase.DeleteCompletion(id); err != nil {
+		c.Logger.Error("Error deleting training completion", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Training completion deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetUserRequirements shows where a user stands on the courses their role
+// requires.
+func (c *Controller) GetUserRequirements(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "user")
+	if !ok {
+		return
+	}
+	requirements, err := c.trainingUseCase.GetRequirements(id, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error getting training requirements", zap.Error(err), zap.String("userID", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, requirementsToResponseMapper(requirements))
+}
+
+// GetOutOfCompliance lists users of a "role" (caregivers by default) who are
+// overdue on required training, or due within "withinDays" (default 0).
+func (c *Controller) GetOutOfCompliance(ctx *gin.Context) {
+	withinDays := 0
+	if value := ctx.Query("withinDays"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.Logger.Error("Invalid withinDays query parameter", zap.Error(err), zap.String("withinDays", value))
+			appError := domainErrors.NewAppError(errors.New("withinDays must be a whole number of days"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		withinDays = parsed
+	}
+	users, err := c.trainingUseCase.OutOfCompliance(ctx.Query("role"), time.Now().UTC(), time.Duration(withinDays)*24*time.Hour)
+	if err != nil {
+		c.Logger.Error("Error getting training compliance", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]UserComplianceResponse, len(users))
+	for i, user := range users {
+		res[i] = UserComplianceResponse{
+			UserID:       user.UserID,
+			FirstName:    user.FirstName,
+			LastName:     user.LastName,
+			Role:         user.Role,
+			Requirements: requirementsToResponseMapper(user.Requirements),
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) queryID(ctx *gin.Context, param string) (*uuid.UUID, bool) {
+	value := ctx.Query(param)
+	if value == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		c.Logger.Error("Invalid "+param+" query parameter", zap.Error(err), zap.String(param, value))
+		appError := domainErrors.NewAppError(errors.New(param+" is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return nil, false
+	}
+	return &id, true
+}
+
+func courseToResponseMapper(course *domainTraining.Course) *CourseResponse {
+	serviceNames := course.ServiceNames
+	if serviceNames == nil {
+		serviceNames = []string{}
+	}
+	return &CourseResponse{
+		ID:              course.ID,
+		Name:            course.Name,
+		Description:     course.Description,
+		RequiredForRole: course.RequiredForRole,
+		ServiceNames:    serviceNames,
+		DueWithinDays:   course.DueWithinDays,
+		ValidityDays:    course.ValidityDays,
+		Active:          course.Active,
+		CreatedAt:       course.CreatedAt,
+		UpdatedAt:       course.UpdatedAt,
+	}
+}
+
+func completionToResponseMapper(completion *domainTraining.Completion) *CompletionResponse {
+	return &CompletionResponse{
+		ID:          completion.ID,
+		CourseID:    completion.CourseID,
+		UserID:      completion.UserID,
+		CompletedAt: completion.CompletedAt,
+		ExpiresAt:   completion.ExpiresAt,
+		Certificate: completion.Certificate,
+		Note:        completion.Note,
+		CreatedAt:   completion.CreatedAt,
+	}
+}
+
+func requirementsToResponseMapper(requirements []domainTraining.Requirement) []RequirementResponse {
+	res := make([]RequirementResponse, len(requirements))
+	for i, requirement := range requirements {
+		res[i] = RequirementResponse{
+			CourseID:    requirement.CourseID,
+			CourseName:  requirement.CourseName,
+			Status:      requirement.Status,
+			DueAt:       requirement.DueAt,
+			CompletedAt: requirement.CompletedAt,
+		}
+	}
+	return res
+}
@@ -0,0 +1,15 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ItemResponse struct {
+	Type            string     `json:"Type"`
+	ID              uuid.UUID  `json:"ID"`
+	Label           string     `json:"Label"`
+	DeletedAt       time.Time  `json:"DeletedAt"`
+	DeletedByUserID *uuid.UUID `json:"DeletedByUserID"`
+}
@@ -0,0 +1,73 @@
+package trash
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	trashUseCase "caregiver/src/application/usecases/trash"
+	domainErrors "caregiver/src/domain/errors"
+	domainTrash "caregiver/src/domain/trash"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ITrashController interface {
+	ListTrash(ctx *gin.Context)
+	RestoreItem(ctx *gin.Context)
+}
+
+type Controller struct {
+	trashUseCase trashUseCase.ITrashUseCase
+	Logger       *logger.Logger
+}
+
+func NewTrashController(trashUseCase trashUseCase.ITrashUseCase, loggerInstance *logger.Logger) ITrashController {
+	return &Controller{trashUseCase: trashUseCase, Logger: loggerInstance}
+}
+
+// ListTrash lists recently deleted users and schedules, optionally only one
+// ?type= of them.
+func (c *Controller) ListTrash(ctx *gin.Context) {
+	items, err := c.trashUseCase.List(domainTrash.Kind(ctx.Query("type")), time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error listing trash", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ItemResponse, len(*items))
+	for i := range *items {
+		res[i] = domainToResponseMapper(&(*items)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) RestoreItem(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid ID parameter for restore", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("param id is necessary"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	item, err := c.trashUseCase.Restore(domainTrash.Kind(ctx.Param("type")), id, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error restoring from trash", zap.Error(err), zap.String("type", ctx.Param("type")), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(item))
+}
+
+func domainToResponseMapper(item *domainTrash.Item) ItemResponse {
+	return ItemResponse{
+		Type:            string(item.Kind),
+		ID:              item.ID,
+		Label:           item.Label,
+		DeletedAt:       item.DeletedAt,
+		DeletedByUserID: item.DeletedByUserID,
+	}
+}
@@ -30,25 +30,65 @@ type LocationRequest struct {
 }
 
 type NewUserRequest struct {
-	UserName  string          `json:"UserName" binding:"required"`
-	Email     string          `json:"Email" binding:"required"`
-	FirstName string          `json:"FirstName" binding:"required"`
-	LastName  string          `json:"LastName" binding:"required"`
-	Role      string          `json:"Role" binding:"required"`
-	Location  LocationRequest `json:"Location"`
+	UserName   string          `json:"UserName" binding:"required"`
+	Email      string          `json:"Email" binding:"required"`
+	Phone      string          `json:"Phone"`
+	FirstName  string          `json:"FirstName" binding:"required"`
+	LastName   string          `json:"LastName" binding:"required"`
+	Role       string          `json:"Role" binding:"required"`
+	HourlyRate *float64        `json:"HourlyRate"`
+	Location   LocationRequest `json:"Location"`
+	// ReferralSourceID attributes a new client to a referral source.
+	ReferralSourceID *uuid.UUID `json:"ReferralSourceID"`
 }
 
+// ResponseUser is a user as the viewer's role may see it; see
+// domainToResponseMapper for what each role gets.
 type ResponseUser struct {
-	ID        uuid.UUID       `json:"ID"`
-	UserName  string          `json:"UserName"`
-	Email     string          `json:"Email"`
-	FirstName string          `json:"FirstName"`
-	LastName  string          `json:"LastName"`
-	Status    bool            `json:"Status"`
-	Role      string          `json:"Role"`
-	Location  LocationRequest `json:"Location"`
-	CreatedAt time.Time       `json:"CreatedAt,omitempty"`
-	UpdatedAt time.Time       `json:"UpdatedAt,omitempty"`
+	ID               uuid.UUID        `json:"ID"`
+	UserName         string           `json:"UserName,omitempty"`
+	Email            string           `json:"Email,omitempty"`
+	Phone            string           `json:"Phone,omitempty"`
+	FirstName        string           `json:"FirstName"`
+	LastName         string           `json:"LastName"`
+	Status           bool             `json:"Status"`
+	Role             string           `json:"Role"`
+	HourlyRate       *float64         `json:"HourlyRate,omitempty"`
+	ReferralSourceID *uuid.UUID       `json:"ReferralSourceID,omitempty"`
+	Location         *LocationRequest `json:"Location,omitempty"`
+	CreatedAt        *time.Time       `json:"CreatedAt,omitempty"`
+	UpdatedAt        *time.Time       `json:"UpdatedAt,omitempty"`
+	DeletedAt        *time.Time       `json:"DeletedAt,omitempty"`
+}
+
+type PropertyMatchResponse struct {
+	UserID   uuid.UUID `json:"UserID"`
+	Property string    `json:"Property"`
+	Value    string    `json:"Value"`
+	Rank     int       `json:"Rank"`
+}
+
+type SearchPropertyResponse struct {
+	Data       []PropertyMatchResponse `json:"Data"`
+	Total      int64                   `json:"Total"`
+	Page       int                     `json:"Page"`
+	PageSize   int                     `json:"PageSize"`
+	TotalPages int                     `json:"TotalPages"`
+}
+
+type VersionResponse struct {
+	ID            uuid.UUID       `json:"ID"`
+	UserID        uuid.UUID       `json:"UserID"`
+	Version       int             `json:"Version"`
+	FirstName     string          `json:"FirstName"`
+	LastName      string          `json:"LastName"`
+	Role          string          `json:"Role"`
+	Status        bool            `json:"Status"`
+	HourlyRate    *float64        `json:"HourlyRate,omitempty"`
+	Location      LocationRequest `json:"Location"`
+	ChangedFields []string        `json:"ChangedFields"`
+	EffectiveFrom time.Time       `json:"EffectiveFrom"`
+	EffectiveTo   *time.Time      `json:"EffectiveTo,omitempty"`
 }
 
 type IUserController interface {
@@ -57,8 +97,10 @@ type IUserController interface {
 	GetUsersByID(ctx *gin.Context)
 	UpdateUser(ctx *gin.Context)
 	DeleteUser(ctx *gin.Context)
+	RestoreUser(ctx *gin.Context)
 	SearchPaginated(ctx *gin.Context)
 	SearchByProperty(ctx *gin.Context)
+	GetUserVersions(ctx *gin.Context)
 }
 
 type UserController struct {
@@ -85,12 +127,16 @@ func (c *UserController) NewUser(ctx *gin.Context) {
 		_ = ctx.Error(err)
 		return
 	}
-	userResponse := domainToResponseMapper(userModel)
+	userResponse := domainToResponseMapper(userModel, controllers.ViewerRole(ctx))
 	c.Logger.Info("User created successfully", zap.String("email", request.Email), zap.String("id", userModel.ID.String()))
 	ctx.JSON(http.StatusOK, userResponse)
 }
 
 func (c *UserController) GetAllUsers(ctx *gin.Context) {
+	if controllers.WantsNDJSON(ctx) {
+		c.streamUsers(ctx)
+		return
+	}
 	c.Logger.Info("Getting all users")
 	users, err := c.userService.GetAll()
 	if err != nil {
@@ -100,7 +146,26 @@ func (c *UserController) GetAllUsers(ctx *gin.Context) {
 		return
 	}
 	c.Logger.Info("Successfully retrieved all users", zap.Int("count", len(*users)))
-	ctx.JSON(http.StatusOK, arrayDomainToResponseMapper(users))
+	ctx.JSON(http.StatusOK, arrayDomainToResponseMapper(users, controllers.ViewerRole(ctx)))
+}
+
+// streamUsers writes every user as a line of NDJSON as it is read, for pulls
+// too large to hold in memory.
+func (c *UserController) streamUsers(ctx *gin.Context) {
+	c.Logger.Info("Streaming all users")
+	viewerRole := controllers.ViewerRole(ctx)
+	writer := controllers.NewNDJSONWriter(ctx)
+	count := 0
+	err := c.userService.Stream(func(u *domainUser.User) error {
+		count++
+		return writer.Write(domainToResponseMapper(u, viewerRole))
+	})
+	if err != nil {
+		c.Logger.Error("Error streaming users", zap.Error(err), zap.Int("written", count))
+	} else {
+		c.Logger.Info("Successfully streamed all users", zap.Int("count", count))
+	}
+	writer.Close(err)
 }
 
 func (c *UserController) GetUsersByID(ctx *gin.Context) {
@@ -119,7 +184,7 @@ func (c *UserController) GetUsersByID(ctx *gin.Context) {
 		return
 	}
 	c.Logger.Info("Successfully retrieved user by ID", zap.String("id", userID.String()))
-	ctx.JSON(http.StatusOK, domainToResponseMapper(user))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(user, controllers.ViewerRole(ctx)))
 }
 
 func (c *UserController) UpdateUser(ctx *gin.Context) {
@@ -152,7 +217,7 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 		return
 	}
 	c.Logger.Info("User updated successfully", zap.String("id", userID.String()))
-	ctx.JSON(http.StatusOK, domainToResponseMapper(userUpdated))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(userUpdated, controllers.ViewerRole(ctx)))
 }
 
 func (c *UserController) DeleteUser(ctx *gin.Context) {
@@ -163,8 +228,14 @@ func (c *UserController) DeleteUser(ctx *gin.Context) {
 		_ = ctx.Error(appError)
 		return
 	}
+	deletedBy, err := controllers.GetQueryUUID(ctx, "deletedBy")
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("deletedBy is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
 	c.Logger.Info("Deleting user", zap.String("id", userID.String()))
-	err = c.userService.Delete(userID)
+	err = c.userService.DeleteBy(userID, deletedBy)
 	if err != nil {
 		c.Logger.Error("Error deleting user", zap.Error(err), zap.String("id", userID.String()))
 		_ = ctx.Error(err)
@@ -174,6 +245,65 @@ func (c *UserController) DeleteUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
 }
 
+// RestoreUser brings back a soft-deleted user.
+func (c *UserController) RestoreUser(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid user ID parameter for restore", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("param id is necessary"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	restored, err := c.userService.Restore(userID)
+	if err != nil {
+		c.Logger.Error("Error restoring user", zap.Error(err), zap.String("id", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("User restored successfully", zap.String("id", userID.String()))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(restored, controllers.ViewerRole(ctx)))
+}
+
+// GetUserVersions lists the profile history of a user. With an ?at= RFC3339
+// timestamp it returns only the version in effect at that time.
+func (c *UserController) GetUserVersions(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid user ID parameter for versions", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("user id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	if atParam := ctx.Query("at"); atParam != "" {
+		at, err := time.Parse(time.RFC3339, atParam)
+		if err != nil {
+			appError := domainErrors.NewAppError(errors.New("at must be an RFC3339 timestamp"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		version, err := c.userService.GetVersionAt(userID, at)
+		if err != nil {
+			c.Logger.Error("Error getting user version", zap.Error(err), zap.String("id", userID.String()))
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, versionToResponseMapper(version))
+		return
+	}
+	versions, err := c.userService.GetVersions(userID)
+	if err != nil {
+		c.Logger.Error("Error getting user versions", zap.Error(err), zap.String("id", userID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	response := make([]VersionResponse, len(*versions))
+	for i := range *versions {
+		response[i] = *versionToResponseMapper(&(*versions)[i])
+	}
+	c.Logger.Info("Successfully retrieved user versions", zap.String("id", userID.String()), zap.Int("count", len(response)))
+	ctx.JSON(http.StatusOK, response)
+}
+
 func (c *UserController) SearchPaginated(ctx *gin.Context) {
 	c.Logger.Info("Searching users with pagination")
 
@@ -222,12 +352,14 @@ func (c *UserController) SearchPaginated(ctx *gin.Context) {
 
 			if startStr != "" {
 				if startTime, err := time.Parse(time.RFC3339, startStr); err == nil {
+					startTime = startTime.UTC()
 					dateRange.Start = &startTime
 				}
 			}
 
 			if endStr != "" {
 				if endTime, err := time.Parse(time.RFC3339, endStr); err == nil {
+					endTime = endTime.UTC()
 					dateRange.End = &endTime
 				}
 			}
@@ -248,6 +380,14 @@ func (c *UserController) SearchPaginated(ctx *gin.Context) {
 		filters.SortDirection = sortDirection
 	}
 
+	// Soft-deleted users are left out unless ?deleted=with or only
+	filters.Deleted = domain.DeletedScope(ctx.Query("deleted"))
+	if !filters.Deleted.IsValid() {
+		appError := domainErrors.NewAppError(errors.New("deleted must be 'with' or 'only'"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
 	result, err := c.userService.SearchPaginated(filters)
 	if err != nil {
 		c.Logger.Error("Error searching users", zap.Error(err))
@@ -256,7 +396,7 @@ func (c *UserController) SearchPaginated(ctx *gin.Context) {
 	}
 
 	response := gin.H{
-		"Data":       arrayDomainToResponseMapper(result.Data),
+		"Data":       arrayDomainToResponseMapper(result.Data, controllers.ViewerRole(ctx)),
 		"Total":      result.Total,
 		"Page":       result.Page,
 		"PageSize":   result.PageSize,
@@ -270,11 +410,14 @@ func (c *UserController) SearchPaginated(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// SearchByProperty ranks users' property values containing searchText.
+// property may be repeated to search several at once, e.g.
+// ?property=FirstName&property=LastName; results come in pages.
 func (c *UserController) SearchByProperty(ctx *gin.Context) {
-	property := ctx.Query("property")
+	properties := ctx.QueryArray("property")
 	searchText := ctx.Query("searchText")
 
-	if property == "" || searchText == "" {
+	if len(properties) == 0 || searchText == "" {
 		c.Logger.Error("Missing property or searchText parameter")
 		appError := domainErrors.NewAppError(errors.New("missing property or searchText parameter"), domainErrors.ValidationError)
 		_ = ctx.Error(appError)
@@ -297,65 +440,131 @@ func (c *UserController) SearchByProperty(ctx *gin.Context) {
 		"Lat":         true,
 		"Long":        true,
 	}
-	if !allowed[property] {
-		c.Logger.Error("Invalid property for search", zap.String("property", property))
-		appError := domainErrors.NewAppError(errors.New("invalid property"), domainErrors.ValidationError)
-		_ = ctx.Error(appError)
-		return
+	for _, property := range properties {
+		if !allowed[property] {
+			c.Logger.Error("Invalid property for search", zap.String("property", property))
+			appError := domainErrors.NewAppError(errors.New("invalid property"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
 	}
 
-	coincidences, err := c.userService.SearchByProperty(property, searchText)
+	result, err := c.userService.SearchByProperty(domainUser.PropertySearch{
+		Properties: properties,
+		Text:       searchText,
+		Page:       controllers.GetQueryInt(ctx, "page", 1),
+		PageSize:   controllers.GetQueryInt(ctx, "pageSize", 10),
+		ViewerRole: controllers.ViewerRole(ctx),
+	})
 	if err != nil {
-		c.Logger.Error("Error searching by property", zap.Error(err), zap.String("property", property))
+		c.Logger.Error("Error searching by property", zap.Error(err), zap.Strings("properties", properties))
 		_ = ctx.Error(err)
 		return
 	}
 
 	c.Logger.Info("Successfully searched by property",
-		zap.String("property", property),
-		zap.Int("results", len(*coincidences)))
-	ctx.JSON(http.StatusOK, coincidences)
+		zap.Strings("properties", properties),
+		zap.Int64("total", result.Total))
+	matches := make([]PropertyMatchResponse, len(*result.Data))
+	for i, match := range *result.Data {
+		matches[i] = PropertyMatchResponse(match)
+	}
+	ctx.JSON(http.StatusOK, SearchPropertyResponse{
+		Data:       matches,
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	})
 }
 
 // Mappers
-func domainToResponseMapper(domainUser *domainUser.User) *ResponseUser {
-	return &ResponseUser{
-		ID:        domainUser.ID,
-		UserName:  domainUser.UserName,
-		Email:     domainUser.Email,
-		FirstName: domainUser.FirstName,
-		LastName:  domainUser.LastName,
-		Status:    domainUser.Status,
-		Role:      domainUser.Role,
-		Location: LocationRequest{
-			HouseNumber: domainUser.Location.HouseNumber,
-			Street:      domainUser.Location.Street,
-			City:        domainUser.Location.City,
-			State:       domainUser.Location.State,
-			Pincode:     domainUser.Location.Pincode,
-			Lat:         domainUser.Location.Lat,
-			Long:        domainUser.Location.Long,
-		},
-		CreatedAt: domainUser.CreatedAt,
-		UpdatedAt: domainUser.UpdatedAt,
+
+// domainToResponseMapper keeps what the viewer's role may see. Admins see
+// everything. Caregivers see who a user is and, for clients, the address
+// they visit, but no contact details, pay or bookkeeping. Family, clients
+// and unknown roles see only names, role and status.
+func domainToResponseMapper(u *domainUser.User, viewerRole string) *ResponseUser {
+	response := &ResponseUser{
+		ID:        u.ID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Status:    u.Status,
+		Role:      u.Role,
+	}
+	switch viewerRole {
+	case domainUser.RoleAdmin:
+		location := locationToResponseMapper(u.Location)
+		createdAt, updatedAt := u.CreatedAt, u.UpdatedAt
+		response.UserName = u.UserName
+		response.Email = u.Email
+		response.Phone = u.Phone
+		response.HourlyRate = u.HourlyRate
+		response.ReferralSourceID = u.ReferralSourceID
+		response.Location = &location
+		response.CreatedAt = &createdAt
+		response.UpdatedAt = &updatedAt
+		response.DeletedAt = u.DeletedAt
+	case domainUser.RoleCaregiver:
+		if u.Role == domainUser.RoleClient {
+			location := locationToResponseMapper(u.Location)
+			response.Location = &location
+		}
+	}
+	return response
+}
+
+func locationToResponseMapper(location domainUser.Location) LocationRequest {
+	return LocationRequest{
+		HouseNumber: location.HouseNumber,
+		Street:      location.Street,
+		City:        location.City,
+		State:       location.State,
+		Pincode:     location.Pincode,
+		Lat:         location.Lat,
+		Long:        location.Long,
+	}
+}
+
+func versionToResponseMapper(version *domainUser.Version) *VersionResponse {
+	changed := version.ChangedFields
+	if changed == nil {
+		changed = []string{}
+	}
+	return &VersionResponse{
+		ID:            version.ID,
+		UserID:        version.UserID,
+		Version:       version.Version,
+		FirstName:     version.FirstName,
+		LastName:      version.LastName,
+		Role:          version.Role,
+		Status:        version.Status,
+		HourlyRate:    version.HourlyRate,
+		Location:      locationToResponseMapper(version.Location),
+		ChangedFields: changed,
+		EffectiveFrom: version.EffectiveFrom,
+		EffectiveTo:   version.EffectiveTo,
 	}
 }
 
-func arrayDomainToResponseMapper(users *[]domainUser.User) *[]ResponseUser {
+func arrayDomainToResponseMapper(users *[]domainUser.User, viewerRole string) *[]ResponseUser {
 	res := make([]ResponseUser, len(*users))
 	for i, u := range *users {
-		res[i] = *domainToResponseMapper(&u)
+		res[i] = *domainToResponseMapper(&u, viewerRole)
 	}
 	return &res
 }
 
 func toUsecaseMapper(req *NewUserRequest) *domainUser.User {
 	return &domainUser.User{
-		UserName:  req.UserName,
-		Email:     req.Email,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Role:      req.Role,
+		UserName:         req.UserName,
+		Email:            req.Email,
+		Phone:            req.Phone,
+		FirstName:        req.FirstName,
+		LastName:         req.LastName,
+		Role:             req.Role,
+		HourlyRate:       req.HourlyRate,
+		ReferralSourceID: req.ReferralSourceID,
 		Location: domainUser.Location{
 			HouseNumber: req.Location.HouseNumber,
 			Street:      req.Location.Street,
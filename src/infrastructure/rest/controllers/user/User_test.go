@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +29,18 @@ func (m *MockUserService) GetAll() (*[]domainUser.User, error) {
 	return args.Get(0).(*[]domainUser.User), args.Error(1)
 }
 
+func (m *MockUserService) Stream(fn func(*domainUser.User) error) error {
+	args := m.Called(fn)
+	if users, ok := args.Get(0).([]domainUser.User); ok {
+		for i := range users {
+			if err := fn(&users[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *MockUserService) GetByID(id uuid.UUID) (*domainUser.User, error) {
 	args := m.Called(id)
 	return args.Get(0).(*domainUser.User), args.Error(1)
@@ -53,14 +66,37 @@ func (m *MockUserService) Delete(id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockUserService) DeleteBy(id uuid.UUID, deletedBy *uuid.UUID) error {
+	args := m.Called(id, deletedBy)
+	return args.Error(0)
+}
+
+func (m *MockUserService) Restore(id uuid.UUID) (*domainUser.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
 func (m *MockUserService) SearchPaginated(filters domain.DataFilters) (*domainUser.SearchResultUser, error) {
 	args := m.Called(filters)
 	return args.Get(0).(*domainUser.SearchResultUser), args.Error(1)
 }
 
-func (m *MockUserService) SearchByProperty(property string, searchText string) (*[]string, error) {
-	args := m.Called(property, searchText)
-	return args.Get(0).(*[]string), args.Error(1)
+func (m *MockUserService) SearchByProperty(search domainUser.PropertySearch) (*domainUser.SearchResultProperty, error) {
+	args := m.Called(search)
+	return args.Get(0).(*domainUser.SearchResultProperty), args.Error(1)
+}
+
+func (m *MockUserService) GetVersions(id uuid.UUID) (*[]domainUser.Version, error) {
+	args := m.Called(id)
+	return args.Get(0).(*[]domainUser.Version), args.Error(1)
+}
+
+func (m *MockUserService) GetVersionAt(id uuid.UUID, at time.Time) (*domainUser.Version, error) {
+	args := m.Called(id, at)
+	return args.Get(0).(*domainUser.Version), args.Error(1)
 }
 
 func setupLogger(t *testing.T) *logger.Logger {
@@ -97,7 +133,7 @@ func TestDomainToResponseMapper(t *testing.T) {
 		UpdatedAt:    now,
 	}
 
-	response := domainToResponseMapper(domainUser)
+	response := domainToResponseMapper(domainUser, "admin")
 
 	assert.Equal(t, domainUser.ID, response.ID)
 	assert.Equal(t, domainUser.UserName, response.UserName)
@@ -105,8 +141,40 @@ func TestDomainToResponseMapper(t *testing.T) {
 	assert.Equal(t, domainUser.FirstName, response.FirstName)
 	assert.Equal(t, domainUser.LastName, response.LastName)
 	assert.Equal(t, domainUser.Status, response.Status)
-	assert.Equal(t, domainUser.CreatedAt, response.CreatedAt)
-	assert.Equal(t, domainUser.UpdatedAt, response.UpdatedAt)
+	assert.Equal(t, domainUser.CreatedAt, *response.CreatedAt)
+	assert.Equal(t, domainUser.UpdatedAt, *response.UpdatedAt)
+}
+
+func TestDomainToResponseMapperByViewerRole(t *testing.T) {
+	rate := 30.0
+	client := &domainUser.User{
+		ID:         uuid.New(),
+		UserName:   "client",
+		Email:      "client@example.com",
+		FirstName:  "Test",
+		LastName:   "Client",
+		Role:       "client",
+		HourlyRate: &rate,
+		Location:   domainUser.Location{HouseNumber: "1", Street: "Main St"},
+		CreatedAt:  time.Now(),
+	}
+	caregiver := *client
+	caregiver.Role = "caregiver"
+
+	asCaregiver := domainToResponseMapper(client, "caregiver")
+	assert.Equal(t, "Main St", asCaregiver.Location.Street)
+	assert.Empty(t, asCaregiver.Email)
+	assert.Empty(t, asCaregiver.UserName)
+	assert.Nil(t, asCaregiver.HourlyRate)
+	assert.Nil(t, asCaregiver.CreatedAt)
+	assert.Nil(t, domainToResponseMapper(&caregiver, "caregiver").Location)
+
+	for _, role := range []string{"family", "client", "unknown"} {
+		response := domainToResponseMapper(client, role)
+		assert.Equal(t, client.FirstName, response.FirstName)
+		assert.Nil(t, response.Location, role)
+		assert.Empty(t, response.Email, role)
+	}
 }
 
 func TestArrayDomainToResponseMapper(t *testing.T) {
@@ -140,7 +208,7 @@ func TestArrayDomainToResponseMapper(t *testing.T) {
 		},
 	}
 
-	responses := arrayDomainToResponseMapper(&users)
+	responses := arrayDomainToResponseMapper(&users, "admin")
 
 	assert.Len(t, *responses, 2)
 	assert.Equal(t, users[0].ID, (*responses)[0].ID)
@@ -360,6 +428,34 @@ func TestUserController_GetAllUsers(t *testing.T) {
 	})
 }
 
+func TestUserController_GetAllUsersNDJSON(t *testing.T) {
+	mockService := &MockUserService{}
+	controller := NewUserController(mockService, setupLogger(t))
+
+	c, w := setupGinContext()
+	c.Request = httptest.NewRequest("GET", "/users", nil)
+	c.Request.Header.Set("Accept", "application/x-ndjson")
+
+	users := []domainUser.User{
+		{ID: uuid.New(), UserName: "user1", Email: "user1@example.com"},
+		{ID: uuid.New(), UserName: "user2", Email: "user2@example.com"},
+	}
+	mockService.On("Stream", mock.Anything).Return(users, errors.New("connection reset"))
+
+	controller.GetAllUsers(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if assert.Len(t, lines, 3) {
+		var first ResponseUser
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.Equal(t, users[0].ID, first.ID)
+		assert.Contains(t, lines[2], `"Error"`)
+	}
+	mockService.AssertNotCalled(t, "GetAll")
+}
+
 func TestUserController_GetUsersByID(t *testing.T) {
 	mockService := &MockUserService{}
 	loggerInstance := setupLogger(t)
@@ -492,7 +588,7 @@ func TestUserController_DeleteUser(t *testing.T) {
 		id := uuid.New()
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
-		mockService.On("Delete", id).Return(nil)
+		mockService.On("DeleteBy", id, (*uuid.UUID)(nil)).Return(nil)
 
 		controller.DeleteUser(c)
 
@@ -516,7 +612,7 @@ func TestUserController_DeleteUser(t *testing.T) {
 		id := uuid.New()
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
-		mockService.On("Delete", id).Return(errors.New("service error"))
+		mockService.On("DeleteBy", id, (*uuid.UUID)(nil)).Return(errors.New("service error"))
 
 		controller.DeleteUser(c)
 
@@ -524,3 +620,57 @@ func TestUserController_DeleteUser(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 }
+
+func TestUserController_GetUserVersions(t *testing.T) {
+	mockService := &MockUserService{}
+	loggerInstance := setupLogger(t)
+	controller := NewUserController(mockService, loggerInstance)
+
+	t.Run("List", func(t *testing.T) {
+		c, w := setupGinContext()
+		id := uuid.New()
+		c.Request = httptest.NewRequest("GET", "/user/"+id.String()+"/versions", nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+
+		versions := []domainUser.Version{
+			{UserID: id, Version: 1, Role: "caregiver"},
+			{UserID: id, Version: 2, Role: "admin", ChangedFields: []string{"Role"}},
+		}
+		mockService.On("GetVersions", id).Return(&versions, nil)
+
+		controller.GetUserVersions(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response []VersionResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 2)
+		assert.Equal(t, []string{}, response[0].ChangedFields)
+		assert.Equal(t, []string{"Role"}, response[1].ChangedFields)
+	})
+
+	t.Run("At", func(t *testing.T) {
+		c, w := setupGinContext()
+		id := uuid.New()
+		at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		c.Request = httptest.NewRequest("GET", "/user/"+id.String()+"/versions?at="+at.Format(time.RFC3339), nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+
+		mockService.On("GetVersionAt", id, at).Return(&domainUser.Version{UserID: id, Version: 1}, nil)
+
+		controller.GetUserVersions(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid At", func(t *testing.T) {
+		c, _ := setupGinContext()
+		id := uuid.New()
+		c.Request = httptest.NewRequest("GET", "/user/"+id.String()+"/versions?at=yesterday", nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+
+		controller.GetUserVersions(c)
+
+		assert.Len(t, c.Errors, 1)
+	})
+}
@@ -0,0 +1,84 @@
+package vitals
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SetRangeRequest sets a client's normal range for one measure: "systolic",
+// "diastolic", "pulse", "glucose" or "weight". Either bound may be left out.
+type SetRangeRequest struct {
+	Measure string   `json:"Measure" binding:"required"`
+	Min     *float64 `json:"Min"`
+	Max     *float64 `json:"Max"`
+}
+
+type RangeResponse struct {
+	ID           uuid.UUID `json:"ID"`
+	ClientUserID uuid.UUID `json:"ClientUserID"`
+	Measure      string    `json:"Measure"`
+	Min          *float64  `json:"Min"`
+	Max          *float64  `json:"Max"`
+	Default      bool      `json:"Default"`
+}
+
+// RecordVitalsRequest records the vitals taken during a visit. RecordedAt
+// defaults to the check-in time.
+type RecordVitalsRequest struct {
+	Systolic   *float64   `json:"Systolic"`
+	Diastolic  *float64   `json:"Diastolic"`
+	Pulse      *float64   `json:"Pulse"`
+	Glucose    *float64   `json:"Glucose"`
+	Weight     *float64   `json:"Weight"`
+	Note       string     `json:"Note"`
+	RecordedAt *time.Time `json:"RecordedAt"`
+}
+
+type BreachResponse struct {
+	Measure string   `json:"Measure"`
+	Value   float64  `json:"Value"`
+	Min     *float64 `json:"Min"`
+	Max     *float64 `json:"Max"`
+}
+
+type ReadingResponse struct {
+	ID               uuid.UUID        `json:"ID"`
+	ScheduleID       uuid.UUID        `json:"ScheduleID"`
+	ClientUserID     uuid.UUID        `json:"ClientUserID"`
+	RecordedByUserID uuid.UUID        `json:"RecordedByUserID"`
+	Systolic         *float64         `json:"Systolic"`
+	Diastolic        *float64         `json:"Diastolic"`
+	Pulse            *float64         `json:"Pulse"`
+	Glucose          *float64         `json:"Glucose"`
+	Weight           *float64         `json:"Weight"`
+	Note             string           `json:"Note"`
+	Breaches         []BreachResponse `json:"Breaches"`
+	RecordedAt       time.Time        `json:"RecordedAt"`
+	CreatedAt        time.Time        `json:"CreatedAt"`
+}
+
+type PointResponse struct {
+	At         time.Time `json:"At"`
+	Value      float64   `json:"Value"`
+	OutOfRange bool      `json:"OutOfRange"`
+}
+
+type SeriesResponse struct {
+	Measure    string          `json:"Measure"`
+	Unit       string          `json:"Unit"`
+	Range      *RangeResponse  `json:"Range"`
+	Points     []PointResponse `json:"Points"`
+	Latest     *float64        `json:"Latest"`
+	Min        *float64        `json:"Min"`
+	Max        *float64        `json:"Max"`
+	Average    *float64        `json:"Average"`
+	OutOfRange int             `json:"OutOfRange"`
+}
+
+type TrendsResponse struct {
+	ClientUserID uuid.UUID        `json:"ClientUserID"`
+	From         time.Time        `json:"From"`
+	To           time.Time        `json:"To"`
+	Series       []SeriesResponse `json:"Series"`
+}
@@ -0,0 +1,304 @@
+package vitals
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	vitalsUseCase "caregiver/src/application/usecases/vitals"
+	domainErrors "caregiver/src/domain/errors"
+	domainVitals "caregiver/src/domain/vitals"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+// defaultTrendDays is how far back trends look when no "from" is given.
+const defaultTrendDays = 30
+
+type IVitalsController interface {
+	SetRange(ctx *gin.Context)
+	GetRanges(ctx *gin.Context)
+	DeleteRange(ctx *gin.Context)
+	GetClientReadings(ctx *gin.Context)
+	GetTrends(ctx *gin.Context)
+	DeleteReading(ctx *gin.Context)
+	RecordVitals(ctx *gin.Context)
+	GetVisitReadings(ctx *gin.Context)
+}
+
+type Controller struct {
+	vitalsUseCase vitalsUseCase.IVitalsUseCase
+	Logger        *logger.Logger
+}
+
+func NewVitalsController(vitalsUseCase vitalsUseCase.IVitalsUseCase, loggerInstance *logger.Logger) IVitalsController {
+	return &Controller{vitalsUseCase: vitalsUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) SetRange(ctx *gin.Context) {
+	clientUserID, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	var request SetRangeRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for vital range", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	saved, err := c.vitalsUseCase.SetRange(&domainVitals.Range{
+		ClientUserID: clientUserID,
+		Measure:      request.Measure,
+		Min:          request.Min,
+		Max:          request.Max,
+	})
+	if err != nil {
+		c.Logger.Error("Error setting vital range", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Vital range set successfully", zap.String("rangeID", saved.ID.String()))
+	ctx.JSON(http.StatusOK, rangeToResponseMapper(saved))
+}
+
+// GetRanges lists the ranges in force for a client, marking the defaults.
+func (c *Controller) GetRanges(ctx *gin.Context) {
+	clientUserID, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	ranges, err := c.vitalsUseCase.GetRanges(clientUserID)
+	if err != nil {
+		c.Logger.Error("Error getting vital ranges", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]RangeResponse, len(ranges))
+	for i := range ranges {
+		res[i] = *rangeToResponseMapper(&ranges[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) DeleteRange(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "vital range")
+	if !ok {
+		return
+	}
+	if err := c.vitalsUseCase.DeleteRange(id); err != nil {
+		c.Logger.Error("Error deleting vital range", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Vital range deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// GetClientReadings lists a client's readings, optionally between "from"
+// and "to" (YYYY-MM-DD, inclusive).
+func (c *Controller) GetClientReadings(ctx *gin.Context) {
+	clientUserID, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	filter := domainVitals.ReadingFilter{ClientUserID: &clientUserID}
+	if value := ctx.Query("from"); value != "" {
+		from, ok := c.parseDate(ctx, "from", value)
+		if !ok {
+			return
+		}
+		filter.From = &from
+	}
+	if value := ctx.Query("to"); value != "" {
+		to, ok := c.parseDate(ctx, "to", value)
+		if !ok {
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	c.getReadings(ctx, filter)
+}
+
+// GetTrends returns a client's vitals as time series between "from" and
+// "to" (YYYY-MM-DD, inclusive; the last 30 days by default), optionally for
+// a single "measure".
+func (c *Controller) GetTrends(ctx *gin.Context) {
+	clientUserID, ok := c.parseID(ctx, "client")
+	if !ok {
+		return
+	}
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	if value := ctx.Query("to"); value != "" {
+		if to, ok = c.parseDate(ctx, "to", value); !ok {
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	from := to.AddDate(0, 0, -defaultTrendDays)
+	if value := ctx.Query("from"); value != "" {
+		if from, ok = c.parseDate(ctx, "from", value); !ok {
+			return
+		}
+	}
+	trends, err := c.vitalsUseCase.Trends(clientUserID, ctx.Query("measure"), from, to)
+	if err != nil {
+		c.Logger.Error("Error getting vitals trends", zap.Error(err), zap.String("clientUserID", clientUserID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	res := TrendsResponse{ClientUserID: clientUserID, From: from, To: to, Series: make([]SeriesResponse, len(trends))}
+	for i, series := range trends {
+		points := make([]PointResponse, len(series.Points))
+		for j, point := range series.Points {
+			points[j] = PointResponse{At: point.At, Value: point.Value, OutOfRange: point.OutOfRange}
+		}
+		res.Series[i] = SeriesResponse{
+			Measure:    series.Measure,
+			Unit:       series.Unit,
+			Points:     points,
+			Latest:     series.Latest,
+			Min:        series.Min,
+			Max:        series.Max,
+			Average:    series.Average,
+			OutOfRange: series.OutOfRange,
+		}
+		if series.Range != nil {
+			res.Series[i].Range = rangeToResponseMapper(series.Range)
+		}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) DeleteReading(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "vitals reading")
+	if !ok {
+		return
+	}
+	if err := c.vitalsUseCase.DeleteReading(id); err != nil {
+		c.Logger.Error("Error deleting vitals reading", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Vitals reading deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+func (c *Controller) RecordVitals(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "schedule")
+	if !ok {
+		return
+	}
+	var request RecordVitalsRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for vitals", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	reading := &domainVitals.Reading{
+		ScheduleID: scheduleID,
+		Systolic:   request.Systolic,
+		Diastolic:  request.Diastolic,
+		Pulse:      request.Pulse,
+		Glucose:    request.Glucose,
+		Weight:     request.Weight,
+		Note:       request.Note,
+	}
+	if request.RecordedAt != nil {
+		reading.RecordedAt = *request.RecordedAt
+	}
+	created, err := c.vitalsUseCase.RecordVitals(reading)
+	if err != nil {
+		c.Logger.Error("Error recording vitals", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Vitals recorded successfully", zap.String("readingID", created.ID.String()), zap.Int("breaches", len(created.Breaches)))
+	ctx.JSON(http.StatusOK, readingToResponseMapper(created))
+}
+
+func (c *Controller) GetVisitReadings(ctx *gin.Context) {
+	scheduleID, ok := c.parseID(ctx, "schedule")
+	if !ok {
+		return
+	}
+	c.getReadings(ctx, domainVitals.ReadingFilter{ScheduleID: &scheduleID})
+}
+
+func (c *Controller) getReadings(ctx *gin.Context, filter domainVitals.ReadingFilter) {
+	readings, err := c.vitalsUseCase.GetReadings(filter)
+	if err != nil {
+		c.Logger.Error("Error getting vitals readings", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]ReadingResponse, len(*readings))
+	for i := range *readings {
+		res[i] = *readingToResponseMapper(&(*readings)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) parseID(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *Controller) parseDate(ctx *gin.Context, name string, value string) (time.Time, bool) {
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		c.Logger.Error("Invalid "+name, zap.Error(err), zap.String("value", value))
+		appError := domainErrors.NewAppError(errors.New(name+" must be YYYY-MM-DD"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func rangeToResponseMapper(r *domainVitals.Range) *RangeResponse {
+	return &RangeResponse{
+		ID:           r.ID,
+		ClientUserID: r.ClientUserID,
+		Measure:      r.Measure,
+		Min:          r.Min,
+		Max:          r.Max,
+		Default:      r.Default,
+	}
+}
+
+func readingToResponseMapper(r *domainVitals.Reading) *ReadingResponse {
+	breaches := make([]BreachResponse, len(r.Breaches))
+	for i, breach := range r.Breaches {
+		breaches[i] = BreachResponse{Measure: breach.Measure, Value: breach.Value, Min: breach.Min, Max: breach.Max}
+	}
+	return &ReadingResponse{
+		ID:               r.ID,
+		ScheduleID:       r.ScheduleID,
+		ClientUserID:     r.ClientUserID,
+		RecordedByUserID: r.RecordedByUserID,
+		Systolic:         r.Systolic,
+		Diastolic:        r.Diastolic,
+		Pulse:            r.Pulse,
+		Glucose:          r.Glucose,
+		Weight:           r.Weight,
+		Note:             r.Note,
+		Breaches:         breaches,
+		RecordedAt:       r.RecordedAt,
+		CreatedAt:        r.CreatedAt,
+	}
+}
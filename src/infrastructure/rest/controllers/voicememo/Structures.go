@@ -0,0 +1,24 @@
+package voicememo
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UploadVoiceMemoRequest struct {
+	UploadedByUserID uuid.UUID `form:"UploadedByUserID" binding:"required"`
+}
+
+type VoiceMemoResponse struct {
+	ID               uuid.UUID `json:"ID"`
+	ScheduleID       uuid.UUID `json:"ScheduleID"`
+	UploadedByUserID uuid.UUID `json:"UploadedByUserID"`
+	ContentType      string    `json:"ContentType"`
+	Size             int64     `json:"Size"`
+	Status           string    `json:"Status"`
+	Transcript       *string   `json:"Transcript"`
+	FailureReason    *string   `json:"FailureReason"`
+	CreatedAt        time.Time `json:"CreatedAt"`
+	UpdatedAt        time.Time `json:"UpdatedAt"`
+}
@@ -0,0 +1,129 @@
+package voicememo
+
+import (
+	"errors"
+	"net/http"
+
+	voiceMemoUseCase "caregiver/src/application/usecases/voicememo"
+	domainErrors "caregiver/src/domain/errors"
+	domainVoiceMemo "caregiver/src/domain/voicememo"
+	logger "caregiver/src/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IVoiceMemoController interface {
+	UploadVoiceMemo(ctx *gin.Context)
+	GetVoiceMemos(ctx *gin.Context)
+	SearchVoiceMemos(ctx *gin.Context)
+}
+
+type Controller struct {
+	voiceMemoUseCase voiceMemoUseCase.IVoiceMemoUseCase
+	Logger           *logger.Logger
+}
+
+func NewVoiceMemoController(voiceMemoUseCase voiceMemoUseCase.IVoiceMemoUseCase, loggerInstance *logger.Logger) IVoiceMemoController {
+	return &Controller{voiceMemoUseCase: voiceMemoUseCase, Logger: loggerInstance}
+}
+
+// UploadVoiceMemo accepts a multipart "file" with the recorded audio. The
+// transcript is filled in asynchronously; poll GetVoiceMemos for its status.
+func (c *Controller) UploadVoiceMemo(ctx *gin.Context) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter for voice memo", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	var request UploadVoiceMemoRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		c.Logger.Error("Error binding form for voice memo", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		c.Logger.Error("Voice memo file is missing", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(errors.New("file is required"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Logger.Error("Error opening uploaded voice memo", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	defer file.Close()
+
+	memo, err := c.voiceMemoUseCase.Upload(&domainVoiceMemo.Memo{
+		ScheduleID:       scheduleID,
+		UploadedByUserID: request.UploadedByUserID,
+		ContentType:      fileHeader.Header.Get("Content-Type"),
+		Size:             fileHeader.Size,
+	}, file)
+	if err != nil {
+		c.Logger.Error("Error uploading voice memo", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusAccepted, domainToResponseMapper(memo))
+}
+
+func (c *Controller) GetVoiceMemos(ctx *gin.Context) {
+	scheduleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid schedule ID parameter for voice memos", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("schedule id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	memos, err := c.voiceMemoUseCase.GetBySchedule(scheduleID)
+	if err != nil {
+		c.Logger.Error("Error getting voice memos", zap.Error(err), zap.String("scheduleID", scheduleID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, arrayDomainToResponseMapper(memos))
+}
+
+// SearchVoiceMemos finds memos whose transcript contains the "q" parameter.
+func (c *Controller) SearchVoiceMemos(ctx *gin.Context) {
+	memos, err := c.voiceMemoUseCase.Search(ctx.Query("q"))
+	if err != nil {
+		c.Logger.Error("Error searching voice memos", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, arrayDomainToResponseMapper(memos))
+}
+
+func domainToResponseMapper(m *domainVoiceMemo.Memo) *VoiceMemoResponse {
+	return &VoiceMemoResponse{
+		ID:               m.ID,
+		ScheduleID:       m.ScheduleID,
+		UploadedByUserID: m.UploadedByUserID,
+		ContentType:      m.ContentType,
+		Size:             m.Size,
+		Status:           m.Status,
+		Transcript:       m.Transcript,
+		FailureReason:    m.FailureReason,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+func arrayDomainToResponseMapper(memos *[]domainVoiceMemo.Memo) []VoiceMemoResponse {
+	res := make([]VoiceMemoResponse, len(*memos))
+	for i := range *memos {
+		res[i] = *domainToResponseMapper(&(*memos)[i])
+	}
+	return res
+}
@@ -0,0 +1,49 @@
+package waitlist
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VisitRequest struct {
+	ClientUserID  uuid.UUID `json:"ClientUserID" binding:"required"`
+	ServiceName   string    `json:"ServiceName" binding:"required"`
+	RequestedFrom time.Time `json:"RequestedFrom" binding:"required"`
+	RequestedTo   time.Time `json:"RequestedTo" binding:"required"`
+	Notes         string    `json:"Notes"`
+}
+
+type UpdateStatusRequest struct {
+	Status string `json:"Status" binding:"required"`
+}
+
+type CandidateResponse struct {
+	UserID     uuid.UUID `json:"UserID"`
+	FirstName  string    `json:"FirstName"`
+	LastName   string    `json:"LastName"`
+	DistanceKm *float64  `json:"DistanceKm"`
+	Warnings   []string  `json:"Warnings,omitempty"`
+}
+
+type EntryResponse struct {
+	ID            uuid.UUID           `json:"ID"`
+	ClientUserID  uuid.UUID           `json:"ClientUserID"`
+	ServiceName   string              `json:"ServiceName"`
+	RequestedFrom time.Time           `json:"RequestedFrom"`
+	RequestedTo   time.Time           `json:"RequestedTo"`
+	Notes         string              `json:"Notes"`
+	Status        string              `json:"Status"`
+	Candidates    []CandidateResponse `json:"Candidates"`
+	MatchedAt     *time.Time          `json:"MatchedAt"`
+	CreatedAt     time.Time           `json:"CreatedAt"`
+	UpdatedAt     time.Time           `json:"UpdatedAt"`
+}
+
+// VisitRequestResponse either lists caregivers free for the slot right now or,
+// when Waitlisted is true, the entry that was queued instead.
+type VisitRequestResponse struct {
+	Waitlisted bool                `json:"Waitlisted"`
+	Entry      *EntryResponse      `json:"Entry"`
+	Candidates []CandidateResponse `json:"Candidates"`
+}
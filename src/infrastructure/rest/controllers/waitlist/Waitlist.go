@@ -0,0 +1,165 @@
+package waitlist
+
+import (
+	"errors"
+	"net/http"
+
+	waitlistUseCase "caregiver/src/application/usecases/waitlist"
+	domainErrors "caregiver/src/domain/errors"
+	domainSchedule "caregiver/src/domain/schedule"
+	domainWaitlist "caregiver/src/domain/waitlist"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IWaitlistController interface {
+	RequestVisit(ctx *gin.Context)
+	GetWaitlist(ctx *gin.Context)
+	GetWaitlistEntryByID(ctx *gin.Context)
+	UpdateWaitlistEntryStatus(ctx *gin.Context)
+	RematchWaitlist(ctx *gin.Context)
+}
+
+type Controller struct {
+	waitlistUseCase waitlistUseCase.IWaitlistUseCase
+	Logger          *logger.Logger
+}
+
+func NewWaitlistController(waitlistUseCase waitlistUseCase.IWaitlistUseCase, loggerInstance *logger.Logger) IWaitlistController {
+	return &Controller{waitlistUseCase: waitlistUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) RequestVisit(ctx *gin.Context) {
+	c.Logger.Info("Handling visit request")
+	var request VisitRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for visit request", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	entry, candidates, err := c.waitlistUseCase.Request(&domainWaitlist.Entry{
+		ClientUserID:  request.ClientUserID,
+		ServiceName:   request.ServiceName,
+		RequestedFrom: request.RequestedFrom.UTC(),
+		RequestedTo:   request.RequestedTo.UTC(),
+		Notes:         request.Notes,
+	})
+	if err != nil {
+		c.Logger.Error("Error handling visit request", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res := VisitRequestResponse{Waitlisted: entry != nil, Candidates: candidatesToResponse(candidates)}
+	if entry != nil {
+		c.Logger.Info("Visit request waitlisted", zap.String("entryID", entry.ID.String()))
+		res.Entry = domainToResponseMapper(entry)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetWaitlist(ctx *gin.Context) {
+	entries, err := c.waitlistUseCase.GetAll(ctx.Query("status"))
+	if err != nil {
+		c.Logger.Error("Error getting waitlist", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]EntryResponse, len(*entries))
+	for i := range *entries {
+		res[i] = *domainToResponseMapper(&(*entries)[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetWaitlistEntryByID(ctx *gin.Context) {
+	entryID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid waitlist entry ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("waitlist entry id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	entry, err := c.waitlistUseCase.GetByID(entryID)
+	if err != nil {
+		c.Logger.Error("Error getting waitlist entry", zap.Error(err), zap.String("id", entryID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, domainToResponseMapper(entry))
+}
+
+func (c *Controller) UpdateWaitlistEntryStatus(ctx *gin.Context) {
+	entryID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid waitlist entry ID parameter for update", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New("waitlist entry id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	var request UpdateStatusRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for waitlist status update", zap.Error(err), zap.String("id", entryID.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	entry, err := c.waitlistUseCase.UpdateStatus(entryID, request.Status)
+	if err != nil {
+		c.Logger.Error("Error updating waitlist entry status", zap.Error(err), zap.String("id", entryID.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Waitlist entry status updated", zap.String("id", entryID.String()), zap.String("status", entry.Status))
+	ctx.JSON(http.StatusOK, domainToResponseMapper(entry))
+}
+
+// RematchWaitlist re-checks every waiting entry against current caregiver
+// availability, notifying coordinators of new matches.
+func (c *Controller) RematchWaitlist(ctx *gin.Context) {
+	matched, err := c.waitlistUseCase.Rematch()
+	if err != nil {
+		c.Logger.Error("Error rematching waitlist", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Waitlist rematched", zap.Int("matched", matched))
+	ctx.JSON(http.StatusOK, gin.H{"Matched": matched})
+}
+
+func candidatesToResponse(candidates []domainSchedule.CaregiverSuggestion) []CandidateResponse {
+	res := make([]CandidateResponse, len(candidates))
+	for i, candidate := range candidates {
+		res[i] = CandidateResponse{
+			UserID:     candidate.UserID,
+			FirstName:  candidate.FirstName,
+			LastName:   candidate.LastName,
+			DistanceKm: candidate.DistanceKm,
+			Warnings:   candidate.Warnings,
+		}
+	}
+	return res
+}
+
+func domainToResponseMapper(e *domainWaitlist.Entry) *EntryResponse {
+	return &EntryResponse{
+		ID:            e.ID,
+		ClientUserID:  e.ClientUserID,
+		ServiceName:   e.ServiceName,
+		RequestedFrom: e.RequestedFrom,
+		RequestedTo:   e.RequestedTo,
+		Notes:         e.Notes,
+		Status:        e.Status,
+		Candidates:    candidatesToResponse(e.Candidates),
+		MatchedAt:     e.MatchedAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
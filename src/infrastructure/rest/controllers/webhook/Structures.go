@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateSubscriptionRequest struct {
+	Name          string   `json:"Name" binding:"required"`
+	URL           string   `json:"URL" binding:"required"`
+	Secret        string   `json:"Secret"`
+	EventTypes    []string `json:"EventTypes" binding:"required"`
+	Active        *bool    `json:"Active"`
+	DigestMinutes int      `json:"DigestMinutes"`
+}
+
+type UpdateSubscriptionRequest struct {
+	Name          *string   `json:"Name"`
+	URL           *string   `json:"URL"`
+	Secret        *string   `json:"Secret"`
+	EventTypes    *[]string `json:"EventTypes"`
+	Active        *bool     `json:"Active"`
+	DigestMinutes *int      `json:"DigestMinutes"`
+}
+
+// SubscriptionResponse shows the signing secret only when it was just set;
+// otherwise SecretHint gives its last characters.
+type SubscriptionResponse struct {
+	ID            uuid.UUID `json:"ID"`
+	Name          string    `json:"Name"`
+	URL           string    `json:"URL"`
+	Secret        string    `json:"Secret,omitempty"`
+	SecretHint    string    `json:"SecretHint"`
+	EventTypes    []string  `json:"EventTypes"`
+	Active        bool      `json:"Active"`
+	DigestMinutes int       `json:"DigestMinutes"`
+	CreatedAt     time.Time `json:"CreatedAt"`
+	UpdatedAt     time.Time `json:"UpdatedAt"`
+}
+
+type DeliveryResponse struct {
+	ID             uuid.UUID  `json:"ID"`
+	SubscriptionID uuid.UUID  `json:"SubscriptionID"`
+	EventID        uuid.UUID  `json:"EventID"`
+	EventType      string     `json:"EventType"`
+	Payload        string     `json:"Payload,omitempty"`
+	Status         string     `json:"Status"`
+	Attempts       int        `json:"Attempts"`
+	ResponseStatus int        `json:"ResponseStatus"`
+	LastError      string     `json:"LastError"`
+	NextAttemptAt  *time.Time `json:"NextAttemptAt"`
+	LastAttemptAt  *time.Time `json:"LastAttemptAt"`
+	DeliveredAt    *time.Time `json:"DeliveredAt"`
+	DigestID       *uuid.UUID `json:"DigestID"`
+	CreatedAt      time.Time  `json:"CreatedAt"`
+}
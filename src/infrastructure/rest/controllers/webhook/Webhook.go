@@ -0,0 +1,313 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	webhookUseCase "caregiver/src/application/usecases/webhook"
+	domainErrors "caregiver/src/domain/errors"
+	domainWebhook "caregiver/src/domain/webhook"
+	logger "caregiver/src/infrastructure/logger"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// deliveryLogLimit caps the deliveries listed when no limit is asked for.
+const deliveryLogLimit = 100
+
+type IWebhookController interface {
+	CreateSubscription(ctx *gin.Context)
+	GetSubscriptions(ctx *gin.Context)
+	GetSubscriptionByID(ctx *gin.Context)
+	UpdateSubscription(ctx *gin.Context)
+	DeleteSubscription(ctx *gin.Context)
+	RotateSecret(ctx *gin.Context)
+	Ping(ctx *gin.Context)
+	GetDeliveries(ctx *gin.Context)
+	GetDelivery(ctx *gin.Context)
+	Redeliver(ctx *gin.Context)
+}
+
+type Controller struct {
+	webhookUseCase webhookUseCase.IWebhookUseCase
+	Logger         *logger.Logger
+}
+
+func NewWebhookController(webhookUseCase webhookUseCase.IWebhookUseCase, loggerInstance *logger.Logger) IWebhookController {
+	return &Controller{webhookUseCase: webhookUseCase, Logger: loggerInstance}
+}
+
+func (c *Controller) CreateSubscription(ctx *gin.Context) {
+	var request CreateSubscriptionRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for webhook subscription", zap.Error(err))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	subscription := &domainWebhook.Subscription{
+		Name:          request.Name,
+		URL:           request.URL,
+		Secret:        request.Secret,
+		EventTypes:    request.EventTypes,
+		Active:        true,
+		DigestMinutes: request.DigestMinutes,
+	}
+	if request.Active != nil {
+		subscription.Active = *request.Active
+	}
+	created, err := c.webhookUseCase.CreateSubscription(subscription)
+	if err != nil {
+		c.Logger.Error("Error creating webhook subscription", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Webhook subscription created successfully", zap.String("id", created.ID.String()))
+	ctx.JSON(http.StatusOK, subscriptionToResponseMapper(created, true))
+}
+
+func (c *Controller) GetSubscriptions(ctx *gin.Context) {
+	subscriptions, err := c.webhookUseCase.GetSubscriptions()
+	if err != nil {
+		c.Logger.Error("Error getting webhook subscriptions", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]SubscriptionResponse, len(*subscriptions))
+	for i := range *subscriptions {
+		res[i] = *subscriptionToResponseMapper(&(*subscriptions)[i], false)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetSubscriptionByID(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "subscription")
+	if !ok {
+		return
+	}
+	subscription, err := c.webhookUseCase.GetSubscriptionByID(id)
+	if err != nil {
+		c.Logger.Error("Error getting webhook subscription", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, subscriptionToResponseMapper(subscription, false))
+}
+
+func (c *Controller) UpdateSubscription(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "subscription")
+	if !ok {
+		return
+	}
+	var request UpdateSubscriptionRequest
+	if err := controllers.BindJSON(ctx, &request); err != nil {
+		c.Logger.Error("Error binding JSON for webhook subscription update", zap.Error(err), zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(err, domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.URL != nil {
+		updates["url"] = *request.URL
+	}
+	if request.Secret != nil {
+		updates["secret"] = *request.Secret
+	}
+	if request.EventTypes != nil {
+		updates["event_types"] = *request.EventTypes
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+	if request.DigestMinutes != nil {
+		updates["digest_minutes"] = *request.DigestMinutes
+	}
+	if len(updates) == 0 {
+		c.Logger.Warn("No valid fields to update", zap.String("id", id.String()))
+		appError := domainErrors.NewAppError(errors.New("No valid fields to update"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+
+	subscription, err := c.webhookUseCase.UpdateSubscription(id, updates)
+	if err != nil {
+		c.Logger.Error("Error updating webhook subscription", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Webhook subscription updated successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, subscriptionToResponseMapper(subscription, request.Secret != nil))
+}
+
+func (c *Controller) DeleteSubscription(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "subscription")
+	if !ok {
+		return
+	}
+	if err := c.webhookUseCase.DeleteSubscription(id); err != nil {
+		c.Logger.Error("Error deleting webhook subscription", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Webhook subscription deleted successfully", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, gin.H{"message": "resource deleted successfully"})
+}
+
+// RotateSecret returns the new secret; it is not shown again.
+func (c *Controller) RotateSecret(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "subscription")
+	if !ok {
+		return
+	}
+	subscription, err := c.webhookUseCase.RotateSecret(id)
+	if err != nil {
+		c.Logger.Error("Error rotating webhook secret", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Webhook secret rotated", zap.String("id", id.String()))
+	ctx.JSON(http.StatusOK, subscriptionToResponseMapper(subscription, true))
+}
+
+func (c *Controller) Ping(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "subscription")
+	if !ok {
+		return
+	}
+	delivery, err := c.webhookUseCase.Ping(id, time.Now().UTC())
+	if err != nil {
+		c.Logger.Error("Error queueing webhook ping", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusAccepted, deliveryToResponseMapper(delivery, true))
+}
+
+// GetDeliveries lists the delivery log, newest first, optionally filtered by
+// the "subscriptionID", "status" and "eventType" query parameters.
+func (c *Controller) GetDeliveries(ctx *gin.Context) {
+	subscriptionID, err := controllers.GetQueryUUID(ctx, "subscriptionID")
+	if err != nil {
+		appError := domainErrors.NewAppError(errors.New("subscriptionID is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return
+	}
+	filter := domainWebhook.DeliveryFilter{
+		SubscriptionID: subscriptionID,
+		Status:         ctx.Query("status"),
+		EventType:      ctx.Query("eventType"),
+		Limit:          deliveryLogLimit,
+	}
+	if value := ctx.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			appError := domainErrors.NewAppError(errors.New("limit must be a positive number"), domainErrors.ValidationError)
+			_ = ctx.Error(appError)
+			return
+		}
+		filter.Limit = limit
+	}
+	deliveries, err := c.webhookUseCase.GetDeliveries(filter)
+	if err != nil {
+		c.Logger.Error("Error getting webhook deliveries", zap.Error(err))
+		_ = ctx.Error(err)
+		return
+	}
+	res := make([]DeliveryResponse, len(*deliveries))
+	for i := range *deliveries {
+		res[i] = *deliveryToResponseMapper(&(*deliveries)[i], false)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) GetDelivery(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "delivery")
+	if !ok {
+		return
+	}
+	delivery, err := c.webhookUseCase.GetDelivery(id)
+	if err != nil {
+		c.Logger.Error("Error getting webhook delivery", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, deliveryToResponseMapper(delivery, true))
+}
+
+func (c *Controller) Redeliver(ctx *gin.Context) {
+	id, ok := c.parseID(ctx, "delivery")
+	if !ok {
+		return
+	}
+	delivery, err := c.webhookUseCase.Redeliver(id)
+	if err != nil {
+		c.Logger.Error("Error redelivering webhook", zap.Error(err), zap.String("id", id.String()))
+		_ = ctx.Error(err)
+		return
+	}
+	c.Logger.Info("Webhook queued for redelivery", zap.String("id", id.String()))
+	ctx.JSON(http.StatusAccepted, deliveryToResponseMapper(delivery, false))
+}
+
+func (c *Controller) parseID(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		c.Logger.Error("Invalid "+name+" ID parameter", zap.Error(err), zap.String("id", ctx.Param("id")))
+		appError := domainErrors.NewAppError(errors.New(name+" id is invalid"), domainErrors.ValidationError)
+		_ = ctx.Error(appError)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func subscriptionToResponseMapper(subscription *domainWebhook.Subscription, withSecret bool) *SubscriptionResponse {
+	res := &SubscriptionResponse{
+		ID:            subscription.ID,
+		Name:          subscription.Name,
+		URL:           subscription.URL,
+		EventTypes:    subscription.EventTypes,
+		Active:        subscription.Active,
+		DigestMinutes: subscription.DigestMinutes,
+		CreatedAt:     subscription.CreatedAt,
+		UpdatedAt:     subscription.UpdatedAt,
+	}
+	if withSecret {
+		res.Secret = subscription.Secret
+	}
+	if n := len(subscription.Secret); n > 4 {
+		res.SecretHint = "…" + subscription.Secret[n-4:]
+	}
+	return res
+}
+
+// deliveryToResponseMapper leaves the payload out of delivery lists.
+func deliveryToResponseMapper(delivery *domainWebhook.Delivery, withPayload bool) *DeliveryResponse {
+	res := &DeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		DigestID:       delivery.DigestID,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if withPayload {
+		res.Payload = delivery.Payload
+	}
+	return res
+}
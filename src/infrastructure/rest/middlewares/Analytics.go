@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"caregiver/src/infrastructure/analytics"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestEvent is the analytics event sent for every handled request.
+const requestEvent = "API Request"
+
+// FeatureUsage sends an analytics event for each request to a known route
+// once it has been handled: the endpoint, the caller's role, the response
+// status and the feature flags switched on. Callers are identified by their
+// API key or IP address, hashed. Tracking failures never fail the request.
+func FeatureUsage(sink analytics.ISink, features []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			return
+		}
+		status, _ := clientErrorOf(c)
+		_ = sink.Track(c.Request.Context(), analytics.Event{
+			Name:       requestEvent,
+			DistinctID: callerID(c),
+			Properties: map[string]interface{}{
+				"endpoint":   c.Request.Method + " " + route,
+				"method":     c.Request.Method,
+				"route":      route,
+				"status":     status,
+				"role":       controllers.ViewerRole(c),
+				"features":   features,
+				"durationMs": time.Since(start).Milliseconds(),
+			},
+			Timestamp: start.UTC(),
+		})
+	}
+}
+
+// callerID identifies the caller by API key, else by client IP, without
+// sending either.
+func callerID(c *gin.Context) string {
+	if client := clientFingerprint(c.GetHeader(apiKeyHeader)); client != "" {
+		return client
+	}
+	sum := sha256.Sum256([]byte(c.ClientIP()))
+	return "ip:" + hex.EncodeToString(sum[:])[:12]
+}
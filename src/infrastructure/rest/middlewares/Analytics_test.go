@@ -0,0 +1,66 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainErrors "caregiver/src/domain/errors"
+	"caregiver/src/infrastructure/analytics"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mockSink keeps the events it is given
+type mockSink struct {
+	events []analytics.Event
+}
+
+func (m *mockSink) Track(ctx context.Context, event analytics.Event) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func TestFeatureUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sink := &mockSink{}
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.Use(FeatureUsage(sink, []string{"open_shifts"}))
+	router.Use(func(c *gin.Context) {
+		c.Set(controllers.CallerRoleKey, "caregiver")
+	})
+	router.GET("/schedules/:id", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			_ = c.Error(domainErrors.NewAppErrorWithType(domainErrors.NotFound))
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "test"})
+	})
+
+	for _, path := range []string{"/schedules/42", "/schedules/missing", "/unknown"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("X-API-Key", "secret-key")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if len(sink.events) != 2 {
+		t.Fatalf("expected an event per known route, got %+v", sink.events)
+	}
+	event := sink.events[0]
+	if event.Name != "API Request" || event.Properties["endpoint"] != "GET /schedules/:id" || event.Properties["role"] != "caregiver" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if features, _ := event.Properties["features"].([]string); len(features) != 1 || features[0] != "open_shifts" {
+		t.Errorf("expected the active feature flags, got %v", event.Properties["features"])
+	}
+	if event.DistinctID == "" || event.DistinctID == "secret-key" {
+		t.Errorf("expected a hashed caller id, got %q", event.DistinctID)
+	}
+	if status := sink.events[1].Properties["status"]; status != http.StatusNotFound {
+		t.Errorf("expected the error status, got %v", status)
+	}
+}
@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"errors"
+
+	domainErrors "caregiver/src/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	appVersionHeader  = "X-App-Version"
+	appPlatformHeader = "X-App-Platform"
+)
+
+// VersionChecker decides whether an app version may still call the API.
+type VersionChecker interface {
+	Check(platform, version string) error
+}
+
+// AppVersion refuses calls from caregiver app builds older than their
+// platform's minimum supported version with 426 Upgrade Required, whose
+// details tell the app to force an upgrade and where to get it. The app
+// sends X-App-Platform ("ios" or "android") and X-App-Version; callers
+// without them, such as the web app and integrations, are not gated, and
+// failing to read the minimums never fails the request.
+func AppVersion(checker VersionChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, platform := c.GetHeader(appVersionHeader), c.GetHeader(appPlatformHeader)
+		if version == "" || platform == "" {
+			c.Next()
+			return
+		}
+		err := checker.Check(platform, version)
+		var appErr *domainErrors.AppError
+		if err != nil && errors.As(err, &appErr) && (appErr.Type == domainErrors.UpgradeRequired || appErr.Type == domainErrors.ValidationError) {
+			_ = c.Error(appErr)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
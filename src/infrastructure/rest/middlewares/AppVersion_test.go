@@ -0,0 +1,84 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainAppVersion "caregiver/src/domain/appversion"
+	domainErrors "caregiver/src/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// minimumVersion requires iOS 2.4.0 and fails for Android.
+type minimumVersion struct{}
+
+func (minimumVersion) Check(platform, version string) error {
+	if platform == domainAppVersion.PlatformAndroid {
+		return errors.New("database unavailable")
+	}
+	current, err := domainAppVersion.ParseVersion(version)
+	if err != nil {
+		return domainErrors.NewAppError(err, domainErrors.ValidationError)
+	}
+	if current.Compare(domainAppVersion.Version{2, 4, 0}) < 0 {
+		return domainErrors.NewAppError(&domainAppVersion.UpgradeRequiredError{
+			Platform: platform, Version: version, MinimumVersion: "2.4.0",
+			StoreURL: "https://apps.apple.com/app/id1", Message: "Please update the app.", ForceUpgrade: true,
+		}, domainErrors.UpgradeRequired)
+	}
+	return nil
+}
+
+func TestAppVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler(), AppVersion(minimumVersion{}))
+	router.GET("/schedules", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "test"})
+	})
+
+	tests := []struct {
+		name     string
+		platform string
+		version  string
+		want     int
+	}{
+		{"no headers", "", "", http.StatusOK},
+		{"current app", "ios", "2.4.0", http.StatusOK},
+		{"newer app", "ios", "2.10.1-beta", http.StatusOK},
+		{"outdated app", "ios", "2.3.9", http.StatusUpgradeRequired},
+		{"malformed version", "ios", "latest", http.StatusBadRequest},
+		{"minimums unavailable", "android", "1.0.0", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/schedules", nil)
+			if tt.platform != "" {
+				req.Header.Set("X-App-Platform", tt.platform)
+				req.Header.Set("X-App-Version", tt.version)
+			}
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.want != http.StatusUpgradeRequired {
+				return
+			}
+			var body struct {
+				Error   string
+				Details domainAppVersion.UpgradeRequiredError
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("unexpected body: %v", err)
+			}
+			if !body.Details.ForceUpgrade || body.Details.MinimumVersion != "2.4.0" || body.Details.StoreURL == "" {
+				t.Errorf("expected forced upgrade details, got %+v", body.Details)
+			}
+		})
+	}
+}
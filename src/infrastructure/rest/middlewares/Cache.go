@@ -0,0 +1,161 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CachePolicy is how clients and proxies may cache a route's successful
+// responses. With ETag set the response is hashed so clients can revalidate
+// with If-None-Match and get 304 Not Modified.
+type CachePolicy struct {
+	CacheControl string
+	ETag         bool
+}
+
+// CacheRule applies a policy to a route as registered with gin, e.g.
+// "/v1/schedules/:id". A route ending in "*" covers every route under it and
+// an empty Method covers every method.
+type CacheRule struct {
+	Method string
+	Route  string
+	Policy CachePolicy
+}
+
+func (r CacheRule) matches(method, route string) bool {
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Route, "*"); ok {
+		return strings.HasPrefix(route, prefix)
+	}
+	return r.Route == route
+}
+
+// CacheControl applies the first matching rule to each request. Routes
+// without a rule keep the no-store headers set by CommonHeaders, as do
+// failed responses. GET and HEAD responses are held back until the handler
+// is done so the ETag can be taken over the whole body.
+func CacheControl(rules []CacheRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		var policy *CachePolicy
+		for i := range rules {
+			if rules[i].matches(c.Request.Method, route) {
+				policy = &rules[i].Policy
+				break
+			}
+		}
+		if policy == nil {
+			c.Next()
+			return
+		}
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			setCacheControl(c.Writer.Header(), policy.CacheControl)
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buffered := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = buffered
+		c.Next()
+		c.Writer = original
+
+		if len(c.Errors) > 0 || buffered.status != http.StatusOK {
+			buffered.flush()
+			return
+		}
+		body := buffered.body.Bytes()
+		setCacheControl(original.Header(), policy.CacheControl)
+		if policy.ETag {
+			sum := sha256.Sum256(body)
+			etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+			original.Header().Set("ETag", etag)
+			if etagMatches(c.GetHeader("If-None-Match"), etag) {
+				original.WriteHeader(http.StatusNotModified)
+				original.WriteHeaderNow()
+				return
+			}
+		}
+		buffered.flush()
+	}
+}
+
+// setCacheControl replaces the no-store defaults with the policy's header.
+func setCacheControl(header http.Header, cacheControl string) {
+	if cacheControl == "" {
+		return
+	}
+	header.Set("Cache-Control", cacheControl)
+	if !strings.Contains(cacheControl, "no-store") && !strings.Contains(cacheControl, "no-cache") {
+		header.Del("Pragma")
+		header.Del("Expires")
+	}
+}
+
+// etagMatches reports whether an If-None-Match header names the ETag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter holds a response back until the cache policy is decided.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body    bytes.Buffer
+	status  int
+	written bool
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+	w.written = true
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+// flush sends whatever the handler wrote. Nothing is sent when the handler
+// only reported an error, so the ErrorHandler can still respond.
+func (w *bufferedWriter) flush() {
+	if !w.written {
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
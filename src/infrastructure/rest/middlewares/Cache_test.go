@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainErrors "caregiver/src/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rules := []CacheRule{
+		{Route: "/auth/*", Policy: CachePolicy{CacheControl: "no-store"}},
+		{Method: http.MethodGet, Route: "/schedules/:id", Policy: CachePolicy{CacheControl: "private, no-cache", ETag: true}},
+	}
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.Use(CommonHeaders)
+	router.Use(CacheControl(rules))
+	router.POST("/auth/login", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"Token": "secret"})
+	})
+	router.GET("/schedules/:id", func(c *gin.Context) {
+		switch c.Param("id") {
+		case "missing":
+			_ = c.Error(domainErrors.NewAppErrorWithType(domainErrors.NotFound))
+		case "done":
+			c.JSON(http.StatusOK, gin.H{"VisitStatus": "completed"})
+		default:
+			c.JSON(http.StatusOK, gin.H{"VisitStatus": "upcoming"})
+		}
+	})
+	router.GET("/users", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+	serve := func(method, path, ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve("POST", "/auth/login", ""); w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("expected no-store for auth, got %q", w.Header().Get("Cache-Control"))
+	}
+	if w := serve("GET", "/users", ""); w.Header().Get("Cache-Control") != "no-cache, no-store" {
+		t.Errorf("expected routes without a rule to keep the default, got %q", w.Header().Get("Cache-Control"))
+	}
+
+	upcoming := serve("GET", "/schedules/42", "")
+	etag := upcoming.Header().Get("ETag")
+	if upcoming.Code != http.StatusOK || etag == "" || upcoming.Header().Get("Cache-Control") != "private, no-cache" || upcoming.Header().Get("Pragma") != "no-cache" {
+		t.Fatalf("expected a revalidated visit with an ETag, got %d %v", upcoming.Code, upcoming.Header())
+	}
+	if upcoming.Body.String() != `{"VisitStatus":"upcoming"}` {
+		t.Errorf("expected the body passed through, got %s", upcoming.Body.String())
+	}
+	if w := serve("GET", "/schedules/42", etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("expected 304 for a matching ETag, got %d %s", w.Code, w.Body.String())
+	}
+
+	done := serve("GET", "/schedules/done", "")
+	if done.Header().Get("Cache-Control") != "private, no-cache" || done.Header().Get("ETag") == "" {
+		t.Errorf("expected a completed visit still revalidated, got %v", done.Header())
+	}
+
+	missing := serve("GET", "/schedules/missing", "")
+	if missing.Code != http.StatusNotFound || missing.Header().Get("ETag") != "" || missing.Header().Get("Cache-Control") != "no-cache, no-store" {
+		t.Errorf("expected errors to be left uncached, got %d %v", missing.Code, missing.Header())
+	}
+}
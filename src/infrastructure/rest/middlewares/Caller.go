@@ -0,0 +1,72 @@
+package middlewares
+
+import (
+	"errors"
+	"strings"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainUser "caregiver/src/domain/user"
+	"caregiver/src/infrastructure/rest/controllers"
+	"caregiver/src/infrastructure/security"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// TokenVerifier checks a token's signature, type and expiry and returns its
+// claims.
+type TokenVerifier interface {
+	GetClaimsAndVerifyToken(tokenString string, tokenType string) (jwt.MapClaims, error)
+}
+
+// CallerLookup finds the user a token was issued to.
+type CallerLookup interface {
+	GetByID(id uuid.UUID) (*domainUser.User, error)
+}
+
+// IdentifyCaller records the user ID and role of a caller presenting a
+// bearer access token under controllers.CallerIDKey and
+// controllers.CallerRoleKey, so handlers can check what the caller may do
+// and see. A token that is malformed, expired, not an access token or
+// issued to a user who no longer exists is refused with 401. Requests
+// without a token pass through anonymously while authentication is
+// disabled.
+func IdentifyCaller(verifier TokenVerifier, users CallerLookup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			refuseCaller(c, errors.New("invalid token format"))
+			return
+		}
+		claims, err := verifier.GetClaimsAndVerifyToken(token, security.Access)
+		if err != nil {
+			refuseCaller(c, errors.New("invalid token"))
+			return
+		}
+		id, _ := claims["id"].(string)
+		callerID, err := uuid.Parse(id)
+		if err != nil {
+			refuseCaller(c, errors.New("invalid token claims"))
+			return
+		}
+		caller, err := users.GetByID(callerID)
+		if err != nil {
+			refuseCaller(c, errors.New("invalid token user"))
+			return
+		}
+		c.Set(controllers.CallerIDKey, callerID)
+		c.Set(controllers.CallerRoleKey, caller.Role)
+		c.Next()
+	}
+}
+
+func refuseCaller(c *gin.Context, err error) {
+	_ = c.Error(domainErrors.NewAppError(err, domainErrors.NotAuthenticated))
+	c.Abort()
+}
@@ -0,0 +1,79 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainUser "caregiver/src/domain/user"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// staticVerifier accepts only the "good" access token, issued to userID.
+type staticVerifier struct {
+	userID uuid.UUID
+}
+
+func (v staticVerifier) GetClaimsAndVerifyToken(tokenString string, tokenType string) (jwt.MapClaims, error) {
+	if tokenString != "good" || tokenType != "access" {
+		return nil, errors.New("invalid token")
+	}
+	return jwt.MapClaims{"id": v.userID.String(), "type": tokenType}, nil
+}
+
+// staticUsers knows only the users it holds.
+type staticUsers map[uuid.UUID]domainUser.User
+
+func (u staticUsers) GetByID(id uuid.UUID) (*domainUser.User, error) {
+	user, ok := u[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &user, nil
+}
+
+func TestIdentifyCaller(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	router := gin.New()
+	users := staticUsers{userID: {ID: userID, Role: domainUser.RoleCaregiver}}
+	router.Use(ErrorHandler(), IdentifyCaller(staticVerifier{userID: userID}, users))
+	router.GET("/schedules", func(c *gin.Context) {
+		caller := ""
+		if callerID := controllers.CallerID(c); callerID != nil {
+			caller = callerID.String() + " " + controllers.ViewerRole(c)
+		}
+		c.String(http.StatusOK, caller)
+	})
+
+	send := func(authorization string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/schedules", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send("Bearer good"); w.Code != http.StatusOK || w.Body.String() != userID.String()+" caregiver" {
+		t.Errorf("expected the caller identified, got %d %q", w.Code, w.Body.String())
+	}
+	if w := send(""); w.Code != http.StatusOK || w.Body.String() != "" {
+		t.Errorf("expected an anonymous caller, got %d %q", w.Code, w.Body.String())
+	}
+	for _, authorization := range []string{"Bearer bad", "good", "Bearer "} {
+		if w := send(authorization); w.Code != http.StatusUnauthorized {
+			t.Errorf("%q: expected 401, got %d", authorization, w.Code)
+		}
+	}
+	delete(users, userID)
+	if w := send("Bearer good"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a user who no longer exists, got %d", w.Code)
+	}
+}
@@ -0,0 +1,93 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	domainClientError "caregiver/src/domain/clienterror"
+	domainErrors "caregiver/src/domain/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// malformedJSONRule is the rule recorded for bodies that are not valid JSON.
+const malformedJSONRule = "malformed_json"
+
+// listIndex matches the index in field paths such as "PreferredTimes[2]".
+var listIndex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// ClientErrorRecorder counts requests rejected with a 4xx status.
+type ClientErrorRecorder interface {
+	RecordFailure(method, route string, status int, causes []domainClientError.Cause, at time.Time) error
+}
+
+// ClientErrorTelemetry records why requests to known routes were rejected
+// with a 4xx status: the field and rule for binding failures, the message for
+// other validation errors and the error type for the rest. Requests to
+// unknown paths are skipped, as are recording failures.
+func ClientErrorTelemetry(recorder ClientErrorRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			return
+		}
+		status, causes := clientErrorOf(c)
+		if status < http.StatusBadRequest || status >= http.StatusInternalServerError {
+			return
+		}
+		_ = recorder.RecordFailure(c.Request.Method, route, status, causes, time.Now().UTC())
+	}
+}
+
+// clientErrorOf works out the status the ErrorHandler responds with, which
+// may not be written yet, and the causes behind it.
+func clientErrorOf(c *gin.Context) (int, []domainClientError.Cause) {
+	if len(c.Errors) == 0 {
+		return c.Writer.Status(), nil
+	}
+	var appErr *domainErrors.AppError
+	if !errors.As(c.Errors.Last().Err, &appErr) {
+		return http.StatusInternalServerError, nil
+	}
+	status, _ := domainErrors.AppErrorToHTTP(appErr)
+	return status, errorCauses(appErr)
+}
+
+func errorCauses(appErr *domainErrors.AppError) []domainClientError.Cause {
+	var fieldErrors validator.ValidationErrors
+	if errors.As(appErr.Err, &fieldErrors) {
+		causes := make([]domainClientError.Cause, len(fieldErrors))
+		for i, fieldError := range fieldErrors {
+			// The namespace starts with the request struct's name.
+			_, field, _ := strings.Cut(fieldError.Namespace(), ".")
+			causes[i] = domainClientError.Cause{Field: fieldPath(field), Rule: fieldError.Tag()}
+		}
+		return causes
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(appErr.Err, &typeErr) {
+		return []domainClientError.Cause{{Field: fieldPath(typeErr.Field), Rule: "type"}}
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(appErr.Err, &syntaxErr) || errors.Is(appErr.Err, io.EOF) || errors.Is(appErr.Err, io.ErrUnexpectedEOF) {
+		return []domainClientError.Cause{{Rule: malformedJSONRule}}
+	}
+	if appErr.Type == domainErrors.ValidationError {
+		return []domainClientError.Cause{{Rule: appErr.Error()}}
+	}
+	return []domainClientError.Cause{{Rule: string(appErr.Type)}}
+}
+
+// fieldPath drops list indexes from a field path, so "PreferredTimes[0].From"
+// and "PreferredTimes[3].From" count together.
+func fieldPath(field string) string {
+	return listIndex.ReplaceAllString(field, "[]")
+}
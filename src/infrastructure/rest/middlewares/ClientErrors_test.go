@@ -0,0 +1,103 @@
+package middlewares
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domainClientError "caregiver/src/domain/clienterror"
+	domainErrors "caregiver/src/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type recordedFailure struct {
+	method, route string
+	status        int
+	causes        []domainClientError.Cause
+}
+
+// mockClientErrorRecorder keeps the failures it is given
+type mockClientErrorRecorder struct {
+	failures []recordedFailure
+}
+
+func (m *mockClientErrorRecorder) RecordFailure(method, route string, status int, causes []domainClientError.Cause, at time.Time) error {
+	m.failures = append(m.failures, recordedFailure{method, route, status, causes})
+	return nil
+}
+
+type bookingForm struct {
+	Name  string `json:"Name" binding:"required"`
+	Slots []struct {
+		From time.Time `json:"From" binding:"required"`
+	} `json:"Slots" binding:"dive"`
+	Count int `json:"Count"`
+}
+
+func TestClientErrorTelemetry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := &mockClientErrorRecorder{}
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.Use(ClientErrorTelemetry(recorder))
+	router.POST("/bookings", func(c *gin.Context) {
+		var form bookingForm
+		if err := c.ShouldBindJSON(&form); err != nil {
+			_ = c.Error(domainErrors.NewAppError(err, domainErrors.ValidationError))
+			return
+		}
+		if form.Count > 3 {
+			_ = c.Error(domainErrors.NewAppError(errors.New("count must be at most 3"), domainErrors.ValidationError))
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"message": "test"})
+	})
+	router.GET("/bookings/:id", func(c *gin.Context) {
+		_ = c.Error(domainErrors.NewAppErrorWithType(domainErrors.NotFound))
+	})
+	router.GET("/broken", func(c *gin.Context) {
+		_ = c.Error(domainErrors.NewAppErrorWithType(domainErrors.UnknownError))
+	})
+
+	send := func(method, path, body string) {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	send("POST", "/bookings", `{"Slots":[{"From":"2025-07-01T09:00:00Z"},{}]}`)
+	send("POST", "/bookings", `{"Name":"Rosa","Count":"two"}`)
+	send("POST", "/bookings", `{"Name":`)
+	send("POST", "/bookings", `{"Name":"Rosa","Count":5}`)
+	send("POST", "/bookings", `{"Name":"Rosa"}`)
+	send("GET", "/bookings/42", "")
+	send("GET", "/broken", "")
+	send("GET", "/unknown", "")
+
+	expected := []recordedFailure{
+		{"POST", "/bookings", 400, []domainClientError.Cause{{Field: "Name", Rule: "required"}, {Field: "Slots[].From", Rule: "required"}}},
+		{"POST", "/bookings", 400, []domainClientError.Cause{{Field: "Count", Rule: "type"}}},
+		{"POST", "/bookings", 400, []domainClientError.Cause{{Rule: "malformed_json"}}},
+		{"POST", "/bookings", 400, []domainClientError.Cause{{Rule: "count must be at most 3"}}},
+		{"GET", "/bookings/:id", 404, []domainClientError.Cause{{Rule: "NotFound"}}},
+	}
+	if len(recorder.failures) != len(expected) {
+		t.Fatalf("expected %d failures recorded, got %+v", len(expected), recorder.failures)
+	}
+	for i, want := range expected {
+		got := recorder.failures[i]
+		if got.method != want.method || got.route != want.route || got.status != want.status || len(got.causes) != len(want.causes) {
+			t.Errorf("failure %d: expected %+v, got %+v", i, want, got)
+			continue
+		}
+		for j := range want.causes {
+			if got.causes[j] != want.causes[j] {
+				t.Errorf("failure %d: expected cause %+v, got %+v", i, want.causes[j], got.causes[j])
+			}
+		}
+	}
+}
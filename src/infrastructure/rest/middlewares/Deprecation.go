@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+
+	domainQuota "caregiver/src/domain/quota"
+
+	"github.com/gin-gonic/gin"
+)
+
+// deprecatedFeatureKey holds the deprecated feature a request used.
+const deprecatedFeatureKey = "deprecatedFeature"
+
+// apiKeyHeader identifies integrations calling the API.
+const apiKeyHeader = "X-API-Key"
+
+// DeprecationRecorder stores who still calls deprecated features.
+type DeprecationRecorder interface {
+	RecordUsage(feature, method, route, client, userAgent string, at time.Time) error
+}
+
+// Deprecated marks a route as deprecated. Register it ahead of the route's
+// handler; callers are told through the Deprecation, Sunset and Link headers
+// and their use is recorded by DeprecationTelemetry. A zero sunset or empty
+// successor leaves that header out.
+func Deprecated(feature string, sunset time.Time, successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		MarkDeprecated(c, feature)
+		if !sunset.IsZero() {
+			c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+		if successor != "" {
+			c.Header("Link", "<"+successor+">; rel=\"successor-version\"")
+		}
+		c.Next()
+	}
+}
+
+// MarkDeprecated flags the request as using a deprecated feature, such as a
+// legacy response format chosen by a handler.
+func MarkDeprecated(c *gin.Context, feature string) {
+	c.Set(deprecatedFeatureKey, feature)
+	c.Header("Deprecation", "true")
+}
+
+// DeprecationTelemetry records requests flagged as using a deprecated feature
+// once they have been handled. Recording failures never fail the request.
+func DeprecationTelemetry(recorder DeprecationRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		feature := c.GetString(deprecatedFeatureKey)
+		if feature == "" {
+			return
+		}
+		_ = recorder.RecordUsage(feature, c.Request.Method, c.FullPath(), clientFingerprint(c.GetHeader(apiKeyHeader)), c.Request.UserAgent(), time.Now().UTC())
+	}
+}
+
+// clientFingerprint identifies an API key without storing it.
+func clientFingerprint(apiKey string) string {
+	return domainQuota.ClientOf(apiKey)
+}
@@ -0,0 +1,79 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type recordedUsage struct {
+	feature, method, route, client, userAgent string
+}
+
+// mockDeprecationRecorder keeps the usage it is given
+type mockDeprecationRecorder struct {
+	usage []recordedUsage
+}
+
+func (m *mockDeprecationRecorder) RecordUsage(feature, method, route, client, userAgent string, at time.Time) error {
+	m.usage = append(m.usage, recordedUsage{feature, method, route, client, userAgent})
+	return nil
+}
+
+func TestDeprecationTelemetry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := &mockDeprecationRecorder{}
+	sunset := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	router := gin.New()
+	router.Use(DeprecationTelemetry(recorder))
+	router.GET("/old/:id", Deprecated("old-lookup", sunset, "/v1/new/:id"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "test"})
+	})
+	router.GET("/report", func(c *gin.Context) {
+		if c.Query("format") == "legacy" {
+			MarkDeprecated(c, "legacy-report-format")
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "test"})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/old/42", nil)
+	req.Header.Set("X-API-Key", "secret-key")
+	req.Header.Set("User-Agent", "partner-sync/1.2")
+	router.ServeHTTP(w, req)
+
+	if w.Header().Get("Deprecation") != "true" || w.Header().Get("Sunset") != "Thu, 01 Jan 2026 00:00:00 GMT" {
+		t.Errorf("expected deprecation headers, got %v", w.Header())
+	}
+	if w.Header().Get("Link") != "</v1/new/:id>; rel=\"successor-version\"" {
+		t.Errorf("expected a successor link, got %q", w.Header().Get("Link"))
+	}
+	if len(recorder.usage) != 1 {
+		t.Fatalf("expected the call to be recorded, got %+v", recorder.usage)
+	}
+	usage := recorder.usage[0]
+	if usage.feature != "old-lookup" || usage.method != "GET" || usage.route != "/old/:id" || usage.userAgent != "partner-sync/1.2" {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+	if usage.client == "" || usage.client == "secret-key" || usage.client != clientFingerprint("secret-key") {
+		t.Errorf("expected a fingerprint of the API key, got %q", usage.client)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/report", nil)
+	router.ServeHTTP(w, req)
+	if w.Header().Get("Deprecation") != "" || len(recorder.usage) != 1 {
+		t.Errorf("expected current behaviour not to be recorded, got %+v", recorder.usage)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/report?format=legacy", nil)
+	router.ServeHTTP(w, req)
+	if w.Header().Get("Deprecation") != "true" || len(recorder.usage) != 2 || recorder.usage[1].feature != "legacy-report-format" || recorder.usage[1].client != "" {
+		t.Errorf("expected the legacy format to be recorded, got %+v", recorder.usage)
+	}
+}
@@ -43,7 +43,7 @@ func TestCommonHeaders(t *testing.T) {
 	}
 
 	allowHeaders := headers.Get("Access-Control-Allow-Headers")
-	expectedAllowHeaders := "Content-Type, Depth, User-Agent, X-File-Size, X-Requested-With, If-Modified-Since, X-File-CompanyName, Cache-Control"
+	expectedAllowHeaders := "Content-Type, Depth, User-Agent, X-File-Size, X-Requested-With, If-Modified-Since, X-File-CompanyName, Cache-Control, X-Kiosk-Token, X-API-Key, X-App-Version, X-App-Platform"
 	if allowHeaders != expectedAllowHeaders {
 		t.Errorf("Access-Control-Allow-Headers: expected %s, got %s", expectedAllowHeaders, allowHeaders)
 	}
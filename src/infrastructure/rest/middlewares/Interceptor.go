@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,7 +16,10 @@ type bodyLogWriter struct {
 }
 
 func (w bodyLogWriter) Write(b []byte) (int, error) {
-	w.body.Write(b)
+	// Streamed NDJSON is not kept, as holding it would defeat streaming.
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/x-ndjson") {
+		w.body.Write(b)
+	}
 	return w.ResponseWriter.Write(b)
 }
 
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+	domainQuota "caregiver/src/domain/quota"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaCounter counts calls against an API client's monthly quota.
+type QuotaCounter interface {
+	CountCall(client string, at time.Time) (*domainQuota.Status, error)
+}
+
+// APIQuota counts every call made with an API key against the key's monthly
+// quota and tells the caller where it stands through X-Quota-* headers.
+// Calls past the hard limit get 429 until the month ends. Calls without a
+// key are not metered, and counting failures never fail the request.
+func APIQuota(counter QuotaCounter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client := clientFingerprint(c.GetHeader(apiKeyHeader))
+		if client == "" {
+			c.Next()
+			return
+		}
+		now := time.Now().UTC()
+		status, err := counter.CountCall(client, now)
+		if err != nil {
+			c.Next()
+			return
+		}
+		c.Header("X-Quota-Used", strconv.FormatInt(status.Calls, 10))
+		c.Header("X-Quota-Reset", status.ResetsAt.Format(time.RFC3339))
+		if status.HardLimit > 0 {
+			c.Header("X-Quota-Limit", strconv.FormatInt(status.HardLimit, 10))
+			c.Header("X-Quota-Remaining", strconv.FormatInt(*status.Remaining, 10))
+		}
+		if status.OverSoftLimit {
+			c.Header("X-Quota-Warning", "monthly soft limit of "+strconv.FormatInt(status.SoftLimit, 10)+" calls exceeded")
+		}
+		if status.OverHardLimit {
+			c.Header("Retry-After", strconv.Itoa(int(status.ResetsAt.Sub(now).Round(time.Second).Seconds())))
+			_ = c.Error(domainErrors.NewAppError(errors.New("monthly API quota exceeded"), domainErrors.TooManyRequests))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domainQuota "caregiver/src/domain/quota"
+
+	"github.com/gin-gonic/gin"
+)
+
+// countingQuota allows each client hardLimit calls.
+type countingQuota struct {
+	hardLimit int64
+	calls     map[string]int64
+}
+
+func (q *countingQuota) CountCall(client string, at time.Time) (*domainQuota.Status, error) {
+	q.calls[client]++
+	remaining := q.hardLimit - q.calls[client]
+	return &domainQuota.Status{
+		Client:        client,
+		Calls:         q.calls[client],
+		HardLimit:     q.hardLimit,
+		Remaining:     &remaining,
+		OverHardLimit: q.calls[client] > q.hardLimit,
+		ResetsAt:      at.Add(time.Hour),
+	}, nil
+}
+
+func TestAPIQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	counter := &countingQuota{hardLimit: 1, calls: map[string]int64{}}
+	router := gin.New()
+	router.Use(ErrorHandler(), APIQuota(counter))
+	router.GET("/schedules", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "test"})
+	})
+
+	send := func(apiKey string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/schedules", nil)
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send("agency-key"); w.Code != http.StatusOK || w.Header().Get("X-Quota-Remaining") != "0" {
+		t.Fatalf("expected the first call allowed with no calls remaining, got %d %v", w.Code, w.Header())
+	}
+	if w := send("agency-key"); w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "3600" {
+		t.Errorf("expected the second call refused, got %d %v", w.Code, w.Header())
+	}
+	if w := send(""); w.Code != http.StatusOK || w.Header().Get("X-Quota-Used") != "" {
+		t.Errorf("expected calls without an API key not metered, got %d %v", w.Code, w.Header())
+	}
+	if len(counter.calls) != 1 {
+		t.Errorf("expected only the keyed client counted, got %v", counter.calls)
+	}
+}
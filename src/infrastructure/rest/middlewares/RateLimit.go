@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	domainErrors "caregiver/src/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateWindow counts one client's requests since the window opened.
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimit allows each client IP at most limit requests per window, counted
+// in fixed windows held in memory, so the limit applies per API instance.
+// Requests over the limit get 429 with a Retry-After header.
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	var mu sync.Mutex
+	windows := make(map[string]*rateWindow)
+	lastPrune := time.Now()
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		client := c.ClientIP()
+
+		mu.Lock()
+		if now.Sub(lastPrune) >= window {
+			for key, w := range windows {
+				if now.Sub(w.start) >= window {
+					delete(windows, key)
+				}
+			}
+			lastPrune = now
+		}
+		w, ok := windows[client]
+		if !ok || now.Sub(w.start) >= window {
+			w = &rateWindow{start: now}
+			windows[client] = w
+		}
+		w.count++
+		allowed := w.count <= limit
+		retryAfter := w.start.Add(window).Sub(now)
+		mu.Unlock()
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second).Seconds())))
+			_ = c.Error(domainErrors.NewAppError(errors.New("too many requests, try again later"), domainErrors.TooManyRequests))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.POST("/public", RateLimit(2, time.Hour), func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"message": "test"})
+	})
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/public", nil)
+		req.RemoteAddr = remoteAddr
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := send("203.0.113.7:1234"); w.Code != http.StatusCreated {
+			t.Fatalf("expected request %d allowed, got %d", i+1, w.Code)
+		}
+	}
+	w := send("203.0.113.7:1234")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "3600" {
+		t.Errorf("expected the third request limited, got %d %v", w.Code, w.Header())
+	}
+	if w := send("198.51.100.2:1234"); w.Code != http.StatusCreated {
+		t.Errorf("expected another client allowed, got %d", w.Code)
+	}
+}
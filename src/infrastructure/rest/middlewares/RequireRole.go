@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"errors"
+
+	domainErrors "caregiver/src/domain/errors"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole admits only callers identified by IdentifyCaller whose role is
+// one of roles. Anonymous callers are refused with 401 and callers in any
+// other role with 403.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if controllers.CallerID(c) == nil {
+			_ = c.Error(domainErrors.NewAppError(errors.New("log in to continue"), domainErrors.NotAuthenticated))
+			c.Abort()
+			return
+		}
+		role := c.GetString(controllers.CallerRoleKey)
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+		_ = c.Error(domainErrors.NewAppError(errors.New("your role may not do this"), domainErrors.NotAuthorized))
+		c.Abort()
+	}
+}
@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainUser "caregiver/src/domain/user"
+	"caregiver/src/infrastructure/rest/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	send := func(role string) int {
+		router := gin.New()
+		router.Use(ErrorHandler(), func(c *gin.Context) {
+			if role != "" {
+				c.Set(controllers.CallerIDKey, uuid.New())
+				c.Set(controllers.CallerRoleKey, role)
+			}
+		}, RequireRole(domainUser.RoleAdmin))
+		router.POST("/evv/aggregators", func(c *gin.Context) { c.Status(http.StatusOK) })
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/evv/aggregators", nil)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := send(""); code != http.StatusUnauthorized {
+		t.Errorf("anonymous caller: expected 401, got %d", code)
+	}
+	if code := send(domainUser.RoleCaregiver); code != http.StatusForbidden {
+		t.Errorf("caregiver: expected 403, got %d", code)
+	}
+	if code := send(domainUser.RoleAdmin); code != http.StatusOK {
+		t.Errorf("admin: expected 200, got %d", code)
+	}
+}
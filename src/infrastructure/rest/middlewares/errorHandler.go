@@ -18,6 +18,11 @@ func ErrorHandler() gin.HandlerFunc {
 			var appErr *domainErrors.AppError
 			if errors.As(err, &appErr) {
 				status, message := domainErrors.AppErrorToHTTP(appErr)
+				var detailed domainErrors.DetailedError
+				if errors.As(appErr.Err, &detailed) {
+					c.JSON(status, gin.H{"error": message, "details": detailed.ErrorDetails()})
+					return
+				}
 				c.JSON(status, gin.H{"error": message})
 			} else {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
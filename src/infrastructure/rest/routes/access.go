@@ -0,0 +1,16 @@
+package routes
+
+import (
+	accessController "caregiver/src/infrastructure/rest/controllers/access"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessRoutes registers the admin diagnostic explaining why a user can or
+// cannot see a record.
+func AccessRoutes(router *gin.RouterGroup, controller accessController.IAccessController) {
+	accessRouter := router.Group("/admin/access")
+	{
+		accessRouter.GET("/:type/:id", controller.Explain)
+	}
+}
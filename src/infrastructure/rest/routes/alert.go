@@ -0,0 +1,24 @@
+package routes
+
+import (
+	alertController "caregiver/src/infrastructure/rest/controllers/alert"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AlertRoutes(router *gin.RouterGroup, controller alertController.IAlertController) {
+	alertRouter := router.Group("/alerts")
+	{
+		alertRouter.GET("/", controller.GetAlerts)
+		alertRouter.POST("/incidents", controller.RaiseIncident)
+		alertRouter.POST("/sweep", controller.Sweep)
+		alertRouter.GET("/policies", controller.GetPolicies)
+		alertRouter.POST("/policies", controller.CreatePolicy)
+		alertRouter.GET("/policies/:id", controller.GetPolicyByID)
+		alertRouter.PUT("/policies/:id", controller.UpdatePolicy)
+		alertRouter.DELETE("/policies/:id", controller.DeletePolicy)
+		alertRouter.GET("/:id", controller.GetAlertByID)
+		alertRouter.POST("/:id/acknowledge", controller.Acknowledge)
+	}
+	router.POST("/schedules/:id/emergency", controller.RaiseEmergency)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	announcementController "caregiver/src/infrastructure/rest/controllers/announcement"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnnouncementRoutes registers the endpoints coordinators use to message
+// caregivers in bulk, and the archive of what was sent.
+func AnnouncementRoutes(router *gin.RouterGroup, controller announcementController.IAnnouncementController) {
+	announcementRouter := router.Group("/announcements")
+	{
+		announcementRouter.POST("", controller.Send)
+		announcementRouter.GET("", controller.GetAll)
+		announcementRouter.GET("/:id", controller.GetByID)
+		announcementRouter.GET("/:id/deliveries", controller.GetDeliveries)
+	}
+}
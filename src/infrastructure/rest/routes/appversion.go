@@ -0,0 +1,18 @@
+package routes
+
+import (
+	appVersionController "caregiver/src/infrastructure/rest/controllers/appversion"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AppVersionRoutes registers the admin endpoints setting the minimum
+// caregiver app version per platform.
+func AppVersionRoutes(router *gin.RouterGroup, controller appVersionController.IAppVersionController) {
+	appVersionRouter := router.Group("/admin/app-versions")
+	{
+		appVersionRouter.GET("", controller.GetRequirements)
+		appVersionRouter.PUT("/:platform", controller.SaveRequirement)
+		appVersionRouter.DELETE("/:platform", controller.DeleteRequirement)
+	}
+}
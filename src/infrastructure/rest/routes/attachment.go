@@ -0,0 +1,18 @@
+package routes
+
+import (
+	attachmentController "caregiver/src/infrastructure/rest/controllers/attachment"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AttachmentRoutes(router *gin.RouterGroup, controller attachmentController.IAttachmentController) {
+	attachmentRouter := router.Group("/schedules/:id/attachments")
+	{
+		attachmentRouter.GET("/", controller.GetAttachments)
+		attachmentRouter.POST("/", controller.UploadAttachment)
+		attachmentRouter.GET("/:attachmentId", controller.DownloadAttachment)
+		attachmentRouter.DELETE("/:attachmentId", controller.DeleteAttachment)
+	}
+	router.POST("/schedules/:id/tasks/:taskId/attachments", controller.UploadTaskAttachment)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	attestationController "caregiver/src/infrastructure/rest/controllers/attestation"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AttestationRoutes(router *gin.RouterGroup, controller attestationController.IAttestationController) {
+	attestationRouter := router.Group("/attestation")
+	{
+		attestationRouter.GET("/settings", controller.GetSettings)
+		attestationRouter.PUT("/settings", controller.SetSettings)
+		attestationRouter.POST("/sweep", controller.Sweep)
+	}
+	router.POST("/schedules/:id/attestation", controller.Respond)
+}
@@ -7,9 +7,12 @@ import (
 )
 
 func AuthRoutes(router *gin.RouterGroup, controller authController.IAuthController) {
-	// routerAuth := router.Group("/auth")
-	// {
-	// 	// routerAuth.POST("/login", controller.Login)
-	// 	// routerAuth.POST("/access-token", controller.GetAccessTokenByRefreshToken)
-	// }
+	routerAuth := router.Group("/auth")
+	{
+		routerAuth.POST("/login", controller.Login)
+		routerAuth.POST("/access-token", controller.GetAccessTokenByRefreshToken)
+		routerAuth.POST("/password", controller.SetPassword)
+		routerAuth.PUT("/password", controller.ChangePassword)
+	}
+	router.POST("/admin/users/:id/password-reset", controller.ResetPassword)
 }
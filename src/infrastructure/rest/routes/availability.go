@@ -0,0 +1,14 @@
+package routes
+
+import (
+	availabilityController "caregiver/src/infrastructure/rest/controllers/availability"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AvailabilityRoutes registers the combined view of a caregiver's weekly
+// availability and time off. Windows are set through the planner and time
+// off through leave requests.
+func AvailabilityRoutes(router *gin.RouterGroup, controller availabilityController.IAvailabilityController) {
+	router.GET("/caregivers/:userID/availability", controller.GetCalendar)
+}
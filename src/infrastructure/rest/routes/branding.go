@@ -0,0 +1,19 @@
+package routes
+
+import (
+	brandingController "caregiver/src/infrastructure/rest/controllers/branding"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BrandingRoutes registers the agency's white-label settings and its logo.
+func BrandingRoutes(router *gin.RouterGroup, controller brandingController.IBrandingController) {
+	brandingRouter := router.Group("/branding")
+	{
+		brandingRouter.GET("/", controller.GetBranding)
+		brandingRouter.PUT("/", controller.SetBranding)
+		brandingRouter.POST("/logo", controller.UploadLogo)
+		brandingRouter.GET("/logo", controller.GetLogo)
+		brandingRouter.DELETE("/logo", controller.DeleteLogo)
+	}
+}
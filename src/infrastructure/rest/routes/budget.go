@@ -0,0 +1,19 @@
+package routes
+
+import (
+	budgetController "caregiver/src/infrastructure/rest/controllers/budget"
+
+	"github.com/gin-gonic/gin"
+)
+
+func BudgetRoutes(router *gin.RouterGroup, controller budgetController.IBudgetController) {
+	budgetRouter := router.Group("/budgets")
+	{
+		budgetRouter.GET("/", controller.GetBudgets)
+		budgetRouter.POST("/", controller.CreateBudget)
+		budgetRouter.GET("/report", controller.GetBudgetReport)
+		budgetRouter.GET("/:id", controller.GetBudgetByID)
+		budgetRouter.PUT("/:id", controller.UpdateBudget)
+		budgetRouter.DELETE("/:id", controller.DeleteBudget)
+	}
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"net/http"
+
+	"caregiver/src/infrastructure/rest/middlewares"
+)
+
+var (
+	// noStore keeps credentials and tokens out of every cache.
+	noStore = middlewares.CachePolicy{CacheControl: "no-store"}
+	// referenceData changes rarely; clients may reuse it for five minutes and
+	// revalidate after.
+	referenceData = middlewares.CachePolicy{CacheControl: "private, max-age=300", ETag: true}
+	// visitRecord is revalidated on every use: even a completed visit gains
+	// note revisions, attachments, transcripts and signatures.
+	visitRecord = middlewares.CachePolicy{CacheControl: "private, no-cache", ETag: true}
+)
+
+// CacheRules assigns a cache policy to routes, first match wins. Routes not
+// listed are never cached.
+var CacheRules = []middlewares.CacheRule{
+	{Route: "/v1/auth/*", Policy: noStore},
+	{Route: "/v1/kiosk/*", Policy: noStore},
+	{Route: "/v1/portal/*", Policy: noStore},
+	{Method: http.MethodGet, Route: "/v1/schedules/:id", Policy: visitRecord},
+	{Method: http.MethodGet, Route: "/v1/branding/", Policy: referenceData},
+	{Method: http.MethodGet, Route: "/v1/branding/logo", Policy: middlewares.CachePolicy{CacheControl: "public, max-age=300", ETag: true}},
+	{Method: http.MethodGet, Route: "/v1/reports/kinds", Policy: referenceData},
+	{Method: http.MethodGet, Route: "/v1/reports/builder/entities", Policy: referenceData},
+	{Method: http.MethodGet, Route: "/v1/denials/reasons", Policy: referenceData},
+	{Method: http.MethodGet, Route: "/v1/evv/aggregators", Policy: referenceData},
+	{Method: http.MethodGet, Route: "/v1/reminders/defaults", Policy: referenceData},
+	{Method: http.MethodGet, Route: "/v1/payers/", Policy: referenceData},
+	{Method: http.MethodGet, Route: "/v1/invoices/rate-cards", Policy: referenceData},
+	{Method: http.MethodGet, Route: "/v1/trainings/courses", Policy: referenceData},
+	{Method: http.MethodGet, Route: "/v1/reference", Policy: middlewares.CachePolicy{CacheControl: "private, no-cache", ETag: true}},
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	carePlanController "caregiver/src/infrastructure/rest/controllers/careplan"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CarePlanRoutes registers care plans, their measurable goals, the goal
+// progress logged during visits and the weekly visit frequency report.
+func CarePlanRoutes(router *gin.RouterGroup, controller carePlanController.ICarePlanController) {
+	carePlanRouter := router.Group("/care-plans")
+	{
+		carePlanRouter.POST("/", controller.CreatePlan)
+		carePlanRouter.GET("/", controller.GetPlans)
+		carePlanRouter.GET("/visit-frequency", controller.GetFrequencyReport)
+		carePlanRouter.GET("/goals/:id", controller.GetGoalByID)
+		carePlanRouter.PUT("/goals/:id", controller.UpdateGoal)
+		carePlanRouter.DELETE("/goals/:id", controller.DeleteGoal)
+		carePlanRouter.GET("/goals/:id/chart", controller.GetGoalChart)
+		carePlanRouter.DELETE("/progress/:id", controller.DeleteEntry)
+		carePlanRouter.GET("/:id", controller.GetPlanByID)
+		carePlanRouter.PUT("/:id", controller.UpdatePlan)
+		carePlanRouter.DELETE("/:id", controller.DeletePlan)
+		carePlanRouter.POST("/:id/goals", controller.CreateGoal)
+		carePlanRouter.GET("/:id/goals", controller.GetGoals)
+	}
+	router.GET("/schedules/:id/goals", controller.GetVisitGoals)
+	router.POST("/schedules/:id/goal-progress", controller.LogProgress)
+	router.GET("/schedules/:id/goal-progress", controller.GetVisitEntries)
+}
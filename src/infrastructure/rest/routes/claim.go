@@ -0,0 +1,60 @@
+package routes
+
+import (
+	claimController "caregiver/src/infrastructure/rest/controllers/claim"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClaimRoutes registers payer setup (payers, their rates and client
+// coverage) and the claims lifecycle: 837P batches, status tracking, 835
+// remittances and the denial work queue.
+func ClaimRoutes(router *gin.RouterGroup, controller claimController.IClaimController) {
+	payerRouter := router.Group("/payers")
+	{
+		payerRouter.POST("/", controller.CreatePayer)
+		payerRouter.GET("/", controller.GetPayers)
+		payerRouter.PUT("/rates/:id", controller.UpdateRate)
+		payerRouter.DELETE("/rates/:id", controller.DeleteRate)
+		payerRouter.GET("/:id", controller.GetPayerByID)
+		payerRouter.PUT("/:id", controller.UpdatePayer)
+		payerRouter.DELETE("/:id", controller.DeletePayer)
+		payerRouter.GET("/:id/rates", controller.GetRates)
+		payerRouter.POST("/:id/rates", controller.CreateRate)
+	}
+
+	coverageRouter := router.Group("/coverages")
+	{
+		coverageRouter.POST("/", controller.CreateCoverage)
+		coverageRouter.GET("/", controller.GetCoverages)
+		coverageRouter.PUT("/:id", controller.UpdateCoverage)
+		coverageRouter.DELETE("/:id", controller.DeleteCoverage)
+	}
+
+	claimRouter := router.Group("/claims")
+	{
+		claimRouter.GET("/", controller.GetClaims)
+		claimRouter.GET("/provider", controller.GetProviderSettings)
+		claimRouter.PUT("/provider", controller.SetProviderSettings)
+		claimRouter.POST("/batches", controller.GenerateBatch)
+		claimRouter.GET("/batches", controller.GetBatches)
+		claimRouter.GET("/batches/:id", controller.GetBatchByID)
+		claimRouter.GET("/batches/:id/file", controller.DownloadBatch)
+		claimRouter.POST("/remittances", controller.IngestRemittance)
+		claimRouter.GET("/remittances", controller.GetRemittances)
+		claimRouter.GET("/remittances/:id", controller.GetRemittanceByID)
+		claimRouter.GET("/:id", controller.GetClaimByID)
+		claimRouter.PUT("/:id/status", controller.UpdateClaimStatus)
+	}
+
+	denialRouter := router.Group("/denials")
+	{
+		denialRouter.GET("/", controller.GetDenials)
+		denialRouter.GET("/reasons", controller.GetDenialReasons)
+		denialRouter.GET("/aging", controller.GetDenialAging)
+		denialRouter.GET("/:id", controller.GetDenialByID)
+		denialRouter.PUT("/:id", controller.UpdateDenial)
+		denialRouter.POST("/:id/rebill", controller.RebillDenial)
+		denialRouter.POST("/:id/write-off", controller.WriteOffDenial)
+	}
+}
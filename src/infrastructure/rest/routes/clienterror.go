@@ -0,0 +1,13 @@
+package routes
+
+import (
+	clientErrorController "caregiver/src/infrastructure/rest/controllers/clienterror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientErrorRoutes registers the report of requests clients get wrong, by
+// endpoint, field and validation rule.
+func ClientErrorRoutes(router *gin.RouterGroup, controller clientErrorController.IClientErrorController) {
+	router.GET("/client-errors", controller.GetReport)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	clientMergeController "caregiver/src/infrastructure/rest/controllers/clientmerge"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientMergeRoutes registers the admin tool folding duplicate client records
+// into one.
+func ClientMergeRoutes(router *gin.RouterGroup, controller clientMergeController.IClientMergeController) {
+	mergeRouter := router.Group("/admin/client-merges")
+	{
+		mergeRouter.GET("", controller.GetMerges)
+		mergeRouter.GET("/preview", controller.PreviewMerge)
+		mergeRouter.GET("/:id", controller.GetMergeByID)
+		mergeRouter.POST("", controller.MergeClients)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	complianceController "caregiver/src/infrastructure/rest/controllers/compliance"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ComplianceRoutes(router *gin.RouterGroup, controller complianceController.IComplianceController) {
+	complianceRouter := router.Group("/compliance")
+	{
+		complianceRouter.GET("/rules", controller.GetRules)
+		complianceRouter.POST("/rules", controller.CreateRule)
+		complianceRouter.GET("/rules/:id", controller.GetRuleByID)
+		complianceRouter.PUT("/rules/:id", controller.UpdateRule)
+		complianceRouter.DELETE("/rules/:id", controller.DeleteRule)
+		complianceRouter.GET("/exceptions", controller.GetExceptionsReport)
+	}
+}
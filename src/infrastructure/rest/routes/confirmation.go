@@ -0,0 +1,26 @@
+package routes
+
+import (
+	confirmationController "caregiver/src/infrastructure/rest/controllers/confirmation"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ConfirmationRoutes(router *gin.RouterGroup, controller confirmationController.IConfirmationController) {
+	router.POST("/schedules/:id/confirmation-links", controller.IssueConfirmationLink)
+
+	// Portal endpoints are authorized by the link token alone so clients and
+	// family members can answer without an account.
+	portalRouter := router.Group("/portal/visits/:token")
+	{
+		portalRouter.GET("", controller.GetPortalVisit)
+		portalRouter.POST("/confirm", controller.ConfirmVisit)
+		portalRouter.POST("/decline", controller.DeclineVisit)
+	}
+
+	changeRequestRouter := router.Group("/change-requests")
+	{
+		changeRequestRouter.GET("/", controller.GetChangeRequests)
+		changeRequestRouter.PUT("/:id", controller.ResolveChangeRequest)
+	}
+}
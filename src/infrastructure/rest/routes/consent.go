@@ -0,0 +1,21 @@
+package routes
+
+import (
+	consentController "caregiver/src/infrastructure/rest/controllers/consent"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConsentRoutes registers the consents clients give to share their data with
+// family members and payers.
+func ConsentRoutes(router *gin.RouterGroup, controller consentController.IConsentController) {
+	consentRouter := router.Group("/consents")
+	{
+		consentRouter.POST("/", controller.GrantConsent)
+		consentRouter.GET("/", controller.GetConsents)
+		consentRouter.GET("/:id", controller.GetConsentByID)
+		consentRouter.PUT("/:id", controller.ReviseConsent)
+		consentRouter.POST("/:id/withdraw", controller.WithdrawConsent)
+		consentRouter.GET("/:id/history", controller.GetConsentHistory)
+	}
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	deactivationController "caregiver/src/infrastructure/rest/controllers/deactivation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeactivationRoutes registers bulk deactivation and reactivation of users,
+// each with a ?preview=true mode.
+func DeactivationRoutes(router *gin.RouterGroup, controller deactivationController.IDeactivationController) {
+	userRouter := router.Group("/admin/users")
+	{
+		userRouter.POST("/deactivate", controller.DeactivateUsers)
+		userRouter.POST("/reactivate", controller.ReactivateUsers)
+	}
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	deprecationController "caregiver/src/infrastructure/rest/controllers/deprecation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeprecationRoutes registers the report of clients still calling deprecated
+// endpoints and legacy response formats.
+func DeprecationRoutes(router *gin.RouterGroup, controller deprecationController.IDeprecationController) {
+	router.GET("/deprecations/usage", controller.GetUsageReport)
+}
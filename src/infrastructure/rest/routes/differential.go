@@ -0,0 +1,20 @@
+package routes
+
+import (
+	differentialController "caregiver/src/infrastructure/rest/controllers/differential"
+
+	"github.com/gin-gonic/gin"
+)
+
+func DifferentialRoutes(router *gin.RouterGroup, controller differentialController.IDifferentialController) {
+	differentialRouter := router.Group("/shift-differentials")
+	{
+		differentialRouter.POST("/", controller.CreateRule)
+		differentialRouter.GET("/", controller.GetRules)
+		differentialRouter.GET("/visits/:scheduleID/pay", controller.GetVisitPay)
+		differentialRouter.GET("/:id", controller.GetRuleByID)
+		differentialRouter.PUT("/:id", controller.UpdateRule)
+		differentialRouter.DELETE("/:id", controller.DeleteRule)
+	}
+	router.GET("/schedules/estimate", controller.EstimateVisit)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	equipmentController "caregiver/src/infrastructure/rest/controllers/equipment"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EquipmentRoutes registers agency equipment, its checkouts to caregivers
+// and clients, and its maintenance log.
+func EquipmentRoutes(router *gin.RouterGroup, controller equipmentController.IEquipmentController) {
+	equipmentRouter := router.Group("/equipment")
+	{
+		equipmentRouter.POST("/", controller.CreateEquipment)
+		equipmentRouter.GET("/", controller.GetEquipment)
+		equipmentRouter.GET("/checkouts", controller.GetCheckouts)
+		equipmentRouter.POST("/checkouts/:id/return", controller.ReturnEquipment)
+		equipmentRouter.GET("/:id", controller.GetEquipmentByID)
+		equipmentRouter.PUT("/:id", controller.UpdateEquipment)
+		equipmentRouter.DELETE("/:id", controller.DeleteEquipment)
+		equipmentRouter.POST("/:id/checkout", controller.CheckoutEquipment)
+		equipmentRouter.POST("/:id/maintenance", controller.RecordMaintenance)
+		equipmentRouter.GET("/:id/maintenance", controller.GetMaintenanceRecords)
+	}
+	router.GET("/schedules/:id/equipment", controller.GetVisitCheckouts)
+}
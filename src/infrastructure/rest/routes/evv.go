@@ -0,0 +1,28 @@
+package routes
+
+import (
+	domainUser "caregiver/src/domain/user"
+	evvController "caregiver/src/infrastructure/rest/controllers/evv"
+	"caregiver/src/infrastructure/rest/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func EVVRoutes(router *gin.RouterGroup, controller evvController.IEVVController) {
+	evvRouter := router.Group("/evv")
+	{
+		// Aggregators decide where visit records and state credentials are
+		// sent, so only admins may see or change them.
+		aggregatorRouter := evvRouter.Group("/aggregators", middlewares.RequireRole(domainUser.RoleAdmin))
+		aggregatorRouter.POST("", controller.CreateAggregator)
+		aggregatorRouter.GET("", controller.GetAggregators)
+		aggregatorRouter.GET("/:id", controller.GetAggregatorByID)
+		aggregatorRouter.PUT("/:id", controller.UpdateAggregator)
+		aggregatorRouter.DELETE("/:id", controller.DeleteAggregator)
+		evvRouter.GET("/submissions", controller.GetSubmissions)
+		evvRouter.POST("/submissions", controller.Enqueue)
+		evvRouter.GET("/submissions/:id", controller.GetSubmission)
+		evvRouter.POST("/submissions/:id/resubmit", controller.Resubmit)
+		evvRouter.POST("/sweep", controller.Sweep)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	fatigueController "caregiver/src/infrastructure/rest/controllers/fatigue"
+
+	"github.com/gin-gonic/gin"
+)
+
+func FatigueRoutes(router *gin.RouterGroup, controller fatigueController.IFatigueController) {
+	fatigueRouter := router.Group("/fatigue")
+	{
+		fatigueRouter.GET("/rules", controller.GetRules)
+		fatigueRouter.POST("/rules", controller.CreateRule)
+		fatigueRouter.GET("/rules/:id", controller.GetRuleByID)
+		fatigueRouter.PUT("/rules/:id", controller.UpdateRule)
+		fatigueRouter.DELETE("/rules/:id", controller.DeleteRule)
+		fatigueRouter.GET("/caregivers/:userID/report", controller.GetCaregiverReport)
+	}
+}
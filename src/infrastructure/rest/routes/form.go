@@ -0,0 +1,23 @@
+package routes
+
+import (
+	formController "caregiver/src/infrastructure/rest/controllers/form"
+
+	"github.com/gin-gonic/gin"
+)
+
+func FormRoutes(router *gin.RouterGroup, controller formController.IFormController) {
+	formRouter := router.Group("/forms")
+	{
+		formRouter.GET("/", controller.GetForms)
+		formRouter.POST("/", controller.CreateForm)
+		formRouter.GET("/submissions/:id", controller.GetSubmission)
+		formRouter.GET("/:key", controller.GetForm)
+		formRouter.PUT("/:key", controller.PublishVersion)
+		formRouter.POST("/:key/retire", controller.RetireForm)
+		formRouter.GET("/:key/versions", controller.GetVersions)
+		formRouter.POST("/:key/submissions", controller.Submit)
+		formRouter.GET("/:key/submissions", controller.GetSubmissions)
+		formRouter.GET("/:key/score-history", controller.GetScoreHistory)
+	}
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	handoffController "caregiver/src/infrastructure/rest/controllers/handoff"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandoffRoutes registers the notes an outgoing caregiver leaves for the
+// client's next visit and their acknowledgement by the next caregiver.
+func HandoffRoutes(router *gin.RouterGroup, controller handoffController.IHandoffController) {
+	router.GET("/schedules/:id/handoff/next-visit", controller.GetNextVisit)
+	router.GET("/schedules/:id/handoff", controller.GetOutgoing)
+	router.PUT("/schedules/:id/handoff", controller.LeaveHandoff)
+	router.GET("/schedules/:id/handoff/incoming", controller.GetIncoming)
+	router.POST("/schedules/:id/handoff/acknowledge", controller.Acknowledge)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	identityController "caregiver/src/infrastructure/rest/controllers/identity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdentityRoutes registers the endpoints for confirming a client's identity
+// on a caregiver's first visit.
+func IdentityRoutes(router *gin.RouterGroup, controller identityController.IIdentityController) {
+	identityRouter := router.Group("/identity")
+	{
+		identityRouter.GET("/settings", controller.GetSettings)
+		identityRouter.PUT("/settings", controller.SetSettings)
+		identityRouter.GET("/clients/:clientID", controller.GetChallenge)
+		identityRouter.PUT("/clients/:clientID/question", controller.SetQuestion)
+		identityRouter.PUT("/clients/:clientID/photo", controller.UploadPhoto)
+		identityRouter.GET("/clients/:clientID/photo", controller.GetPhoto)
+	}
+	router.GET("/schedules/:id/identity", controller.GetStatus)
+	router.POST("/schedules/:id/identity", controller.Verify)
+	router.GET("/schedules/:id/identity/photo", controller.GetVisitPhoto)
+}
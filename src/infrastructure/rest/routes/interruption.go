@@ -0,0 +1,16 @@
+package routes
+
+import (
+	interruptionController "caregiver/src/infrastructure/rest/controllers/interruption"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InterruptionRoutes registers the pauses caregivers log during a visit and
+// the report of how often visits are interrupted.
+func InterruptionRoutes(router *gin.RouterGroup, controller interruptionController.IInterruptionController) {
+	router.GET("/schedules/:id/interruptions", controller.GetInterruptions)
+	router.POST("/schedules/:id/interruptions", controller.StartInterruption)
+	router.POST("/schedules/:id/interruptions/resume", controller.ResumeVisit)
+	router.GET("/interruptions/report", controller.GetReport)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	invoiceController "caregiver/src/infrastructure/rest/controllers/invoice"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvoiceRoutes registers the rate cards clients paying privately are billed
+// at and the invoices generated for them, with PDF and CSV downloads.
+func InvoiceRoutes(router *gin.RouterGroup, controller invoiceController.IInvoiceController) {
+	invoiceRouter := router.Group("/invoices")
+	{
+		invoiceRouter.POST("", controller.Generate)
+		invoiceRouter.GET("", controller.GetInvoices)
+		invoiceRouter.POST("/rate-cards", controller.CreateRateCard)
+		invoiceRouter.GET("/rate-cards", controller.GetRateCards)
+		invoiceRouter.PUT("/rate-cards/:id", controller.UpdateRateCard)
+		invoiceRouter.DELETE("/rate-cards/:id", controller.DeleteRateCard)
+		invoiceRouter.GET("/:id", controller.GetInvoiceByID)
+		invoiceRouter.GET("/:id/download", controller.Download)
+	}
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	kioskController "caregiver/src/infrastructure/rest/controllers/kiosk"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KioskRoutes registers the coordinator endpoints for managing kiosks and the
+// endpoints kiosk tablets call with their X-Kiosk-Token.
+func KioskRoutes(router *gin.RouterGroup, controller kioskController.IKioskController) {
+	kioskRouter := router.Group("/kiosks")
+	{
+		kioskRouter.POST("/", controller.CreateKiosk)
+		kioskRouter.GET("/", controller.GetKiosks)
+		kioskRouter.PUT("/pins/:id", controller.SetPIN)
+		kioskRouter.GET("/:id", controller.GetKioskByID)
+		kioskRouter.PUT("/:id", controller.UpdateKiosk)
+		kioskRouter.DELETE("/:id", controller.DeleteKiosk)
+		kioskRouter.POST("/:id/rotate-token", controller.RotateToken)
+		kioskRouter.GET("/:id/audit", controller.GetAudit)
+	}
+
+	deviceRouter := router.Group("/kiosk")
+	{
+		deviceRouter.GET("/schedules", controller.GetKioskSchedules)
+		deviceRouter.POST("/schedules/:id/check-in", controller.CheckIn)
+		deviceRouter.POST("/schedules/:id/check-out", controller.CheckOut)
+	}
+}
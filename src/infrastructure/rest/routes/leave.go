@@ -0,0 +1,29 @@
+package routes
+
+import (
+	leaveController "caregiver/src/infrastructure/rest/controllers/leave"
+
+	"github.com/gin-gonic/gin"
+)
+
+func LeaveRoutes(router *gin.RouterGroup, controller leaveController.ILeaveController) {
+	leaveRouter := router.Group("/leave")
+	{
+		leaveRouter.POST("/accrual-rules", controller.CreateAccrualRule)
+		leaveRouter.GET("/accrual-rules", controller.GetAccrualRules)
+		leaveRouter.GET("/accrual-rules/:id", controller.GetAccrualRuleByID)
+		leaveRouter.PUT("/accrual-rules/:id", controller.UpdateAccrualRule)
+		leaveRouter.DELETE("/accrual-rules/:id", controller.DeleteAccrualRule)
+
+		leaveRouter.POST("/requests", controller.CreateRequest)
+		leaveRouter.GET("/requests", controller.GetRequests)
+		leaveRouter.GET("/requests/:id", controller.GetRequestByID)
+		leaveRouter.POST("/requests/:id/approve", controller.ApproveRequest)
+		leaveRouter.POST("/requests/:id/deny", controller.DenyRequest)
+		leaveRouter.POST("/requests/:id/cancel", controller.CancelRequest)
+
+		leaveRouter.GET("/balances/:caregiverID", controller.GetBalance)
+		leaveRouter.GET("/balances/:caregiverID/ledger", controller.GetLedger)
+		leaveRouter.POST("/balances/:caregiverID/adjustments", controller.AdjustBalance)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	legalHoldController "caregiver/src/infrastructure/rest/controllers/legalhold"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LegalHoldRoutes registers the admin endpoints placing clients under
+// litigation or audit hold and exporting everything held about them.
+func LegalHoldRoutes(router *gin.RouterGroup, controller legalHoldController.ILegalHoldController) {
+	holdRouter := router.Group("/admin/legal-holds")
+	{
+		holdRouter.GET("", controller.GetActiveHolds)
+		holdRouter.POST("", controller.PlaceHold)
+		holdRouter.POST("/:id/release", controller.ReleaseHold)
+		holdRouter.GET("/clients/:clientID", controller.GetClientHolds)
+		holdRouter.GET("/clients/:clientID/export", controller.ExportClient)
+	}
+}
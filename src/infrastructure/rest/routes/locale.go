@@ -0,0 +1,20 @@
+package routes
+
+import (
+	localeController "caregiver/src/infrastructure/rest/controllers/locale"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleRoutes registers the admin endpoints for the organization's time zone
+// and holiday calendar.
+func LocaleRoutes(router *gin.RouterGroup, controller localeController.ILocaleController) {
+	localeRouter := router.Group("/admin/locale")
+	{
+		localeRouter.GET("", controller.GetSettings)
+		localeRouter.PUT("", controller.SetSettings)
+		localeRouter.GET("/holidays", controller.GetHolidays)
+		localeRouter.POST("/holidays", controller.CreateHoliday)
+		localeRouter.DELETE("/holidays/:id", controller.DeleteHoliday)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	medicationController "caregiver/src/infrastructure/rest/controllers/medication"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MedicationRoutes registers client allergies and medication lists and the
+// medication administrations logged during visits.
+func MedicationRoutes(router *gin.RouterGroup, controller medicationController.IMedicationController) {
+	clientRouter := router.Group("/clients")
+	{
+		clientRouter.DELETE("/allergies/:id", controller.DeleteAllergy)
+		clientRouter.PUT("/medications/:id", controller.UpdateMedication)
+		clientRouter.DELETE("/medications/:id", controller.DeleteMedication)
+		clientRouter.POST("/:id/allergies", controller.AddAllergy)
+		clientRouter.GET("/:id/allergies", controller.GetAllergies)
+		clientRouter.POST("/:id/medications", controller.CreateMedication)
+		clientRouter.GET("/:id/medications", controller.GetMedications)
+		clientRouter.GET("/:id/medication-administrations", controller.GetClientAdministrations)
+	}
+	router.POST("/schedules/:id/medications", controller.LogAdministration)
+	router.GET("/schedules/:id/medications", controller.GetVisitAdministrations)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	nfcTagController "caregiver/src/infrastructure/rest/controllers/nfctag"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NFCTagRoutes(router *gin.RouterGroup, controller nfcTagController.INFCTagController) {
+	tagRouter := router.Group("/nfc-tags")
+	{
+		tagRouter.GET("/", controller.GetTags)
+		tagRouter.POST("/", controller.RegisterTag)
+		tagRouter.PUT("/:id", controller.UpdateTag)
+		tagRouter.DELETE("/:id", controller.DeleteTag)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	notificationController "caregiver/src/infrastructure/rest/controllers/notification"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationRoutes registers the endpoints the apps use to receive push
+// notifications on a user's devices and to choose the channels a user is
+// notified on.
+func NotificationRoutes(router *gin.RouterGroup, controller notificationController.INotificationController) {
+	deviceRouter := router.Group("/notifications/users/:userID/devices")
+	{
+		deviceRouter.GET("", controller.GetDevices)
+		deviceRouter.POST("", controller.RegisterDevice)
+		deviceRouter.DELETE("/:id", controller.RemoveDevice)
+	}
+	router.GET("/notifications/users/:userID/channels", controller.GetPreference)
+	router.PUT("/notifications/users/:userID/channels", controller.SetPreference)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	payRateController "caregiver/src/infrastructure/rest/controllers/payrate"
+
+	"github.com/gin-gonic/gin"
+)
+
+func PayRateRoutes(router *gin.RouterGroup, controller payRateController.IPayRateController) {
+	payRateRouter := router.Group("/pay-rates")
+	{
+		payRateRouter.POST("/", controller.CreatePayRate)
+		payRateRouter.GET("/", controller.GetPayRates)
+		payRateRouter.GET("/effective", controller.GetEffectiveRate)
+		payRateRouter.GET("/:id", controller.GetPayRateByID)
+		payRateRouter.PUT("/:id", controller.UpdatePayRate)
+		payRateRouter.DELETE("/:id", controller.DeletePayRate)
+	}
+}
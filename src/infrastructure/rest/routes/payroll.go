@@ -0,0 +1,21 @@
+package routes
+
+import (
+	payrollController "caregiver/src/infrastructure/rest/controllers/payroll"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PayrollRoutes registers the admin endpoints closing payroll periods and
+// unlocking individual visits in them for corrections.
+func PayrollRoutes(router *gin.RouterGroup, controller payrollController.IPayrollController) {
+	payrollRouter := router.Group("/admin/payroll/periods")
+	{
+		payrollRouter.GET("", controller.GetPeriods)
+		payrollRouter.POST("", controller.ClosePeriod)
+		payrollRouter.GET("/:id", controller.GetPeriod)
+		payrollRouter.POST("/:id/reopen", controller.ReopenPeriod)
+	}
+	router.GET("/schedules/:id/payroll-unlocks", controller.GetUnlocks)
+	router.POST("/schedules/:id/payroll-unlocks", controller.UnlockVisit)
+}
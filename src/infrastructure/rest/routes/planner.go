@@ -0,0 +1,26 @@
+package routes
+
+import (
+	plannerController "caregiver/src/infrastructure/rest/controllers/planner"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlannerRoutes registers the admin endpoints for planning a week of visits
+// from client demand templates and caregiver availability.
+func PlannerRoutes(router *gin.RouterGroup, controller plannerController.IPlannerController) {
+	plannerRouter := router.Group("/admin/planner")
+	{
+		plannerRouter.GET("/templates", controller.GetTemplates)
+		plannerRouter.PUT("/templates/:clientID", controller.SaveTemplate)
+		plannerRouter.DELETE("/templates/:clientID", controller.DeleteTemplate)
+		plannerRouter.GET("/availability/:caregiverID", controller.GetAvailability)
+		plannerRouter.PUT("/availability/:caregiverID", controller.SetAvailability)
+		plannerRouter.GET("/proposals", controller.GetProposals)
+		plannerRouter.POST("/proposals", controller.Propose)
+		plannerRouter.GET("/proposals/:id", controller.GetProposal)
+		plannerRouter.PATCH("/proposals/:id/assignments/:assignmentID", controller.UpdateAssignment)
+		plannerRouter.POST("/proposals/:id/commit", controller.Commit)
+		plannerRouter.POST("/proposals/:id/discard", controller.Discard)
+	}
+}
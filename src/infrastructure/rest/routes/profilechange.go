@@ -0,0 +1,21 @@
+package routes
+
+import (
+	profileChangeController "caregiver/src/infrastructure/rest/controllers/profilechange"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProfileChangeRoutes registers the caregiver self-service profile endpoint
+// and the coordinator queue for address changes awaiting approval.
+func ProfileChangeRoutes(router *gin.RouterGroup, controller profileChangeController.IProfileChangeController) {
+	router.PATCH("/caregivers/:userID/profile", controller.UpdateOwnProfile)
+
+	changeRouter := router.Group("/profile-changes")
+	{
+		changeRouter.GET("", controller.GetChanges)
+		changeRouter.GET("/:id", controller.GetChangeByID)
+		changeRouter.POST("/:id/approve", controller.ApproveChange)
+		changeRouter.POST("/:id/reject", controller.RejectChange)
+	}
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"time"
+
+	prospectController "caregiver/src/infrastructure/rest/controllers/prospect"
+	"caregiver/src/infrastructure/rest/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProspectRoutes registers the sales pipeline from inquiry through
+// assessment and quote to conversion into a client.
+func ProspectRoutes(router *gin.RouterGroup, controller prospectController.IProspectController) {
+	prospectRouter := router.Group("/prospects")
+	{
+		prospectRouter.POST("/", controller.CreateProspect)
+		prospectRouter.GET("/", controller.GetProspects)
+		prospectRouter.GET("/:id", controller.GetProspectByID)
+		prospectRouter.PUT("/:id", controller.UpdateProspect)
+		prospectRouter.DELETE("/:id", controller.DeleteProspect)
+		prospectRouter.POST("/:id/assessment", controller.ScheduleAssessment)
+		prospectRouter.POST("/:id/quote", controller.Quote)
+		prospectRouter.POST("/:id/accept", controller.Accept)
+		prospectRouter.POST("/:id/decline", controller.Decline)
+	}
+
+	// The booking form is open to anyone, so each address may only submit a
+	// few requests an hour on top of the CAPTCHA.
+	router.POST("/booking-requests", middlewares.RateLimit(5, time.Hour), controller.SubmitBookingRequest)
+}
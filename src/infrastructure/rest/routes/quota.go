@@ -0,0 +1,21 @@
+package routes
+
+import (
+	quotaController "caregiver/src/infrastructure/rest/controllers/quota"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaRoutes registers the usage endpoint API clients check their monthly
+// quota with, and the admin endpoints setting quotas and reporting usage.
+func QuotaRoutes(router *gin.RouterGroup, controller quotaController.IQuotaController) {
+	router.GET("/usage", controller.GetMyUsage)
+
+	quotaRouter := router.Group("/admin/quotas")
+	{
+		quotaRouter.GET("", controller.GetQuotas)
+		quotaRouter.PUT("", controller.SaveQuota)
+		quotaRouter.GET("/usage", controller.GetUsageReport)
+		quotaRouter.DELETE("/:id", controller.DeleteQuota)
+	}
+}
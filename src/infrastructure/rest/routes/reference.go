@@ -0,0 +1,23 @@
+package routes
+
+import (
+	referenceController "caregiver/src/infrastructure/rest/controllers/reference"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReferenceRoutes registers the mobile app's versioned reference data and the
+// admin endpoints for the service catalog behind it.
+func ReferenceRoutes(router *gin.RouterGroup, controller referenceController.IReferenceController) {
+	router.GET("/reference", controller.GetReference)
+	servicesRouter := router.Group("/admin/reference/services")
+	{
+		servicesRouter.GET("", controller.GetServices)
+		servicesRouter.POST("", controller.CreateService)
+		servicesRouter.PUT("/:id", controller.UpdateService)
+		servicesRouter.DELETE("/:id", controller.DeleteService)
+		servicesRouter.GET("/:id/tasks", controller.GetTaskTemplates)
+		servicesRouter.POST("/:id/tasks", controller.CreateTaskTemplate)
+	}
+	router.DELETE("/admin/reference/tasks/:id", controller.DeleteTaskTemplate)
+}
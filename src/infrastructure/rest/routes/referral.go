@@ -0,0 +1,22 @@
+package routes
+
+import (
+	referralController "caregiver/src/infrastructure/rest/controllers/referral"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReferralRoutes registers referral sources, client attribution and the
+// conversion report.
+func ReferralRoutes(router *gin.RouterGroup, controller referralController.IReferralController) {
+	referralRouter := router.Group("/referral-sources")
+	{
+		referralRouter.POST("/", controller.CreateSource)
+		referralRouter.GET("/", controller.GetSources)
+		referralRouter.GET("/report", controller.GetReport)
+		referralRouter.PUT("/clients/:id", controller.AttributeClient)
+		referralRouter.GET("/:id", controller.GetSourceByID)
+		referralRouter.PUT("/:id", controller.UpdateSource)
+		referralRouter.DELETE("/:id", controller.DeleteSource)
+	}
+}
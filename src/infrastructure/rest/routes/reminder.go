@@ -0,0 +1,24 @@
+package routes
+
+import (
+	reminderController "caregiver/src/infrastructure/rest/controllers/reminder"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ReminderRoutes(router *gin.RouterGroup, controller reminderController.IReminderController) {
+	reminderRouter := router.Group("/reminders")
+	{
+		reminderRouter.GET("/defaults", controller.GetDefaults)
+		reminderRouter.PUT("/defaults", controller.SetDefaults)
+		reminderRouter.GET("/preferences/:userID", controller.GetPreference)
+		reminderRouter.PUT("/preferences/:userID", controller.SetPreference)
+		reminderRouter.POST("/sweep", controller.Sweep)
+	}
+	scheduleReminderRouter := router.Group("/schedules/:id/reminders")
+	{
+		scheduleReminderRouter.GET("/", controller.GetSchedulePlan)
+		scheduleReminderRouter.PUT("/", controller.SetScheduleOverride)
+		scheduleReminderRouter.DELETE("/", controller.ClearScheduleOverride)
+	}
+}
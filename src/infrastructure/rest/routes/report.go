@@ -0,0 +1,27 @@
+package routes
+
+import (
+	reportController "caregiver/src/infrastructure/rest/controllers/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportRoutes registers saved report configurations, their on-demand export
+// and email delivery, the history of scheduled deliveries, and the ad-hoc
+// report builder.
+func ReportRoutes(router *gin.RouterGroup, controller reportController.IReportController) {
+	reportRouter := router.Group("/reports")
+	{
+		reportRouter.GET("/kinds", controller.GetDefinitions)
+		reportRouter.GET("/builder/entities", controller.GetEntities)
+		reportRouter.POST("/builder/query", controller.RunQuery)
+		reportRouter.POST("/", controller.CreateReport)
+		reportRouter.GET("/", controller.GetReports)
+		reportRouter.GET("/:id", controller.GetReportByID)
+		reportRouter.PUT("/:id", controller.UpdateReport)
+		reportRouter.DELETE("/:id", controller.DeleteReport)
+		reportRouter.GET("/:id/export", controller.ExportReport)
+		reportRouter.POST("/:id/deliver", controller.DeliverReport)
+		reportRouter.GET("/:id/runs", controller.GetReportRuns)
+	}
+}
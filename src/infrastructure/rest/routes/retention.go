@@ -0,0 +1,18 @@
+package routes
+
+import (
+	retentionController "caregiver/src/infrastructure/rest/controllers/retention"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionRoutes registers the monthly client retention series for
+// management dashboards.
+func RetentionRoutes(router *gin.RouterGroup, controller retentionController.IRetentionController) {
+	retentionRouter := router.Group("/admin/analytics/clients")
+	{
+		retentionRouter.GET("/churn", controller.GetChurn)
+		retentionRouter.GET("/tenure", controller.GetTenure)
+		retentionRouter.GET("/termination-reasons", controller.GetTerminationReasons)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"net/http"
 
 	"caregiver/src/infrastructure/di"
+	"caregiver/src/infrastructure/rest/middlewares"
 
 	"github.com/gin-gonic/gin"
 )
 
 func ApplicationRouter(router *gin.Engine, appContext *di.ApplicationContext) {
 	v1 := router.Group("/v1")
+	v1.Use(middlewares.CacheControl(CacheRules))
 
 	v1.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -21,4 +23,66 @@ func ApplicationRouter(router *gin.Engine, appContext *di.ApplicationContext) {
 	AuthRoutes(v1, appContext.AuthController)
 	UserRoutes(v1, appContext.UserController)
 	ScheduleRoutes(v1, appContext.ScheduleController)
+	BudgetRoutes(v1, appContext.BudgetController)
+	AttachmentRoutes(v1, appContext.AttachmentController)
+	NFCTagRoutes(v1, appContext.NFCTagController)
+	VoiceMemoRoutes(v1, appContext.VoiceMemoController)
+	ComplianceRoutes(v1, appContext.ComplianceController)
+	ConfirmationRoutes(v1, appContext.ConfirmationController)
+	WaitlistRoutes(v1, appContext.WaitlistController)
+	ServiceAreaRoutes(v1, appContext.ServiceAreaController)
+	ScheduleViewRoutes(v1, appContext.ScheduleViewController)
+	TeamRoutes(v1, appContext.TeamController)
+	AlertRoutes(v1, appContext.AlertController)
+	FormRoutes(v1, appContext.FormController)
+	SignatureRoutes(v1, appContext.SignatureController)
+	FatigueRoutes(v1, appContext.FatigueController)
+	ReminderRoutes(v1, appContext.ReminderController)
+	KioskRoutes(v1, appContext.KioskController)
+	AttestationRoutes(v1, appContext.AttestationController)
+	EVVRoutes(v1, appContext.EVVController)
+	ClaimRoutes(v1, appContext.ClaimController)
+	PayRateRoutes(v1, appContext.PayRateController)
+	LeaveRoutes(v1, appContext.LeaveController)
+	DifferentialRoutes(v1, appContext.DifferentialController)
+	StatementRoutes(v1, appContext.StatementController)
+	SupplyRoutes(v1, appContext.SupplyController)
+	EquipmentRoutes(v1, appContext.EquipmentController)
+	ScreeningRoutes(v1, appContext.ScreeningController)
+	TrainingRoutes(v1, appContext.TrainingController)
+	ReferralRoutes(v1, appContext.ReferralController)
+	ProspectRoutes(v1, appContext.ProspectController)
+	CarePlanRoutes(v1, appContext.CarePlanController)
+	MedicationRoutes(v1, appContext.MedicationController)
+	VitalsRoutes(v1, appContext.VitalsController)
+	ConsentRoutes(v1, appContext.ConsentController)
+	DeprecationRoutes(v1, appContext.DeprecationController)
+	SearchRoutes(v1, appContext.SearchController)
+	ReportRoutes(v1, appContext.ReportController)
+	BrandingRoutes(v1, appContext.BrandingController)
+	ClientErrorRoutes(v1, appContext.ClientErrorController)
+	TrashRoutes(v1, appContext.TrashController)
+	ProfileChangeRoutes(v1, appContext.ProfileChangeController)
+	AccessRoutes(v1, appContext.AccessController)
+	ClientMergeRoutes(v1, appContext.ClientMergeController)
+	DeactivationRoutes(v1, appContext.DeactivationController)
+	QuotaRoutes(v1, appContext.QuotaController)
+	AppVersionRoutes(v1, appContext.AppVersionController)
+	LegalHoldRoutes(v1, appContext.LegalHoldController)
+	PlannerRoutes(v1, appContext.PlannerController)
+	NotificationRoutes(v1, appContext.NotificationController)
+	InterruptionRoutes(v1, appContext.InterruptionController)
+	HandoffRoutes(v1, appContext.HandoffController)
+	WebhookRoutes(v1, appContext.WebhookController)
+	IdentityRoutes(v1, appContext.IdentityController)
+	AvailabilityRoutes(v1, appContext.AvailabilityController)
+	PayrollRoutes(v1, appContext.PayrollController)
+	LocaleRoutes(v1, appContext.LocaleController)
+	ReferenceRoutes(v1, appContext.ReferenceController)
+	SwapRoutes(v1, appContext.SwapController)
+	RetentionRoutes(v1, appContext.RetentionController)
+	ServiceNoteRoutes(v1, appContext.ServiceNoteController)
+	TimesheetRoutes(v1, appContext.TimesheetController)
+	AnnouncementRoutes(v1, appContext.AnnouncementController)
+	InvoiceRoutes(v1, appContext.InvoiceController)
 }
@@ -11,16 +11,29 @@ func ScheduleRoutes(router *gin.RouterGroup, controller scheduleController.ISche
 	{
 		scheduleRouter.GET("/", controller.GetSchedules)
 		scheduleRouter.POST("/", controller.CreateSchedule)
+		scheduleRouter.POST("/recurring", controller.CreateRecurringSchedule)
+		scheduleRouter.POST("/publish", controller.PublishSchedules)
+		scheduleRouter.GET("/search", controller.SearchSchedules)
+		scheduleRouter.GET("/open", controller.GetOpenShifts)
 		scheduleRouter.GET("/today", controller.GetTodaySchedules)
 		scheduleRouter.GET("/today/:assignedUserID", controller.GetTodaySchedulesByAssignedUserID)
 		scheduleRouter.GET("/:id", controller.GetScheduleByID)
 		scheduleRouter.PUT("/:id", controller.UpdateSchedule)
+		scheduleRouter.PUT("/:id/occurrence", controller.UpdateOccurrence)
+		scheduleRouter.PUT("/:id/following", controller.UpdateFollowingOccurrences)
+		scheduleRouter.DELETE("/:id", controller.DeleteSchedule)
+		scheduleRouter.POST("/:id/restore", controller.RestoreSchedule)
 		scheduleRouter.POST("/:id/start", controller.StartSchedule)
 		scheduleRouter.POST("/:id/end", controller.EndSchedule)
+		scheduleRouter.POST("/:id/claim", controller.ClaimOpenShift)
 	}
 
 	taskRouter := router.Group("/tasks")
 	{
 		taskRouter.POST("/:taskId/update", controller.UpdateTask)
+		taskRouter.DELETE("/:taskId", controller.DeleteTask)
+		taskRouter.POST("/:taskId/restore", controller.RestoreTask)
 	}
+
+	router.GET("/clients/:id/tasks/history", controller.GetClientTaskHistory)
 }
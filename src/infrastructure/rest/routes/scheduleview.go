@@ -0,0 +1,18 @@
+package routes
+
+import (
+	scheduleViewController "caregiver/src/infrastructure/rest/controllers/scheduleview"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ScheduleViewRoutes(router *gin.RouterGroup, controller scheduleViewController.IScheduleViewController) {
+	viewRouter := router.Group("/schedule-views")
+	{
+		viewRouter.GET("/", controller.GetViews)
+		viewRouter.POST("/", controller.CreateView)
+		viewRouter.GET("/:id", controller.GetViewByID)
+		viewRouter.PUT("/:id", controller.UpdateView)
+		viewRouter.DELETE("/:id", controller.DeleteView)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	screeningController "caregiver/src/infrastructure/rest/controllers/screening"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScreeningRoutes registers caregiver background checks and license
+// verifications.
+func ScreeningRoutes(router *gin.RouterGroup, controller screeningController.IScreeningController) {
+	screeningRouter := router.Group("/screening")
+	{
+		screeningRouter.POST("/background-checks", controller.OrderBackgroundCheck)
+		screeningRouter.POST("/licenses", controller.VerifyLicense)
+		screeningRouter.POST("/checks", controller.RecordCheck)
+		screeningRouter.GET("/checks", controller.GetChecks)
+		screeningRouter.GET("/checks/:id", controller.GetCheckByID)
+		screeningRouter.POST("/checks/:id/refresh", controller.RefreshCheck)
+		screeningRouter.DELETE("/checks/:id", controller.DeleteCheck)
+	}
+}
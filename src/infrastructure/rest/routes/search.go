@@ -0,0 +1,14 @@
+package routes
+
+import (
+	searchController "caregiver/src/infrastructure/rest/controllers/search"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchRoutes registers the unified search across clients, caregivers,
+// schedules and visit notes.
+func SearchRoutes(router *gin.RouterGroup, controller searchController.ISearchController) {
+	router.GET("/search", controller.Search)
+	router.POST("/search/reindex", controller.Reindex)
+}
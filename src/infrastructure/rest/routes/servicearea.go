@@ -0,0 +1,17 @@
+package routes
+
+import (
+	serviceAreaController "caregiver/src/infrastructure/rest/controllers/servicearea"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ServiceAreaRoutes(router *gin.RouterGroup, controller serviceAreaController.IServiceAreaController) {
+	serviceAreaRouter := router.Group("/service-areas")
+	{
+		serviceAreaRouter.GET("/", controller.GetServiceAreas)
+		serviceAreaRouter.GET("/:userID", controller.GetServiceArea)
+		serviceAreaRouter.PUT("/:userID", controller.SetServiceArea)
+		serviceAreaRouter.DELETE("/:userID", controller.DeleteServiceArea)
+	}
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	serviceNoteController "caregiver/src/infrastructure/rest/controllers/servicenote"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServiceNoteRoutes registers a visit's service note and its revisions.
+func ServiceNoteRoutes(router *gin.RouterGroup, controller serviceNoteController.IServiceNoteController) {
+	router.GET("/schedules/:id/service-note", controller.GetServiceNote)
+	router.POST("/schedules/:id/service-note", controller.CreateServiceNote)
+	router.PUT("/schedules/:id/service-note", controller.UpdateServiceNote)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	signatureController "caregiver/src/infrastructure/rest/controllers/signature"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SignatureRoutes(router *gin.RouterGroup, controller signatureController.ISignatureController) {
+	attachmentSignatureRouter := router.Group("/schedules/:id/attachments/:attachmentId/signatures")
+	{
+		attachmentSignatureRouter.GET("/", controller.GetAttachmentSignatures)
+		attachmentSignatureRouter.POST("/", controller.SignAttachment)
+	}
+	signatureRouter := router.Group("/signatures")
+	{
+		signatureRouter.GET("/:id", controller.GetSignature)
+		signatureRouter.GET("/:id/verify", controller.VerifySignature)
+	}
+}
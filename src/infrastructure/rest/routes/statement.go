@@ -0,0 +1,22 @@
+package routes
+
+import (
+	statementController "caregiver/src/infrastructure/rest/controllers/statement"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatementRoutes registers client account statements and the payments and
+// adjustments recorded against them.
+func StatementRoutes(router *gin.RouterGroup, controller statementController.IStatementController) {
+	clientRouter := router.Group("/clients")
+	{
+		clientRouter.GET("/:id/statements", controller.GetStatement)
+		clientRouter.POST("/:id/payments", controller.RecordPayment)
+		clientRouter.GET("/:id/payments", controller.GetPayments)
+		clientRouter.POST("/:id/adjustments", controller.RecordAdjustment)
+		clientRouter.GET("/:id/adjustments", controller.GetAdjustments)
+		clientRouter.DELETE("/payments/:id", controller.DeletePayment)
+		clientRouter.DELETE("/adjustments/:id", controller.DeleteAdjustment)
+	}
+}
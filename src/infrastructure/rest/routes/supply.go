@@ -0,0 +1,26 @@
+package routes
+
+import (
+	supplyController "caregiver/src/infrastructure/rest/controllers/supply"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SupplyRoutes registers the supplies catalog, per-visit usage logging and
+// the consumption report.
+func SupplyRoutes(router *gin.RouterGroup, controller supplyController.ISupplyController) {
+	supplyRouter := router.Group("/supplies")
+	{
+		supplyRouter.POST("/items", controller.CreateItem)
+		supplyRouter.GET("/items", controller.GetItems)
+		supplyRouter.GET("/items/:id", controller.GetItemByID)
+		supplyRouter.PUT("/items/:id", controller.UpdateItem)
+		supplyRouter.DELETE("/items/:id", controller.DeleteItem)
+		supplyRouter.POST("/items/:id/restock", controller.RestockItem)
+		supplyRouter.GET("/low-stock", controller.GetLowStockItems)
+		supplyRouter.GET("/consumption", controller.GetConsumptionReport)
+		supplyRouter.DELETE("/usages/:id", controller.DeleteUsage)
+	}
+	router.POST("/schedules/:id/supplies", controller.LogUsage)
+	router.GET("/schedules/:id/supplies", controller.GetVisitUsages)
+}
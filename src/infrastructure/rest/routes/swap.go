@@ -0,0 +1,27 @@
+package routes
+
+import (
+	swapController "caregiver/src/infrastructure/rest/controllers/swap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SwapRoutes registers the visit swap workflow: caregivers offer and accept
+// visits, admins approve the reassignment.
+func SwapRoutes(router *gin.RouterGroup, controller swapController.ISwapController) {
+	router.GET("/schedules/:id/swaps", controller.GetHistory)
+	router.POST("/schedules/:id/swaps", controller.RequestSwap)
+	swapRouter := router.Group("/swaps")
+	{
+		swapRouter.GET("/open", controller.GetOpenSwaps)
+		swapRouter.GET("/:id", controller.GetSwap)
+		swapRouter.POST("/:id/accept", controller.AcceptSwap)
+		swapRouter.POST("/:id/cancel", controller.CancelSwap)
+	}
+	adminRouter := router.Group("/admin/swaps")
+	{
+		adminRouter.GET("", controller.GetSwaps)
+		adminRouter.POST("/:id/approve", controller.ApproveSwap)
+		adminRouter.POST("/:id/reject", controller.RejectSwap)
+	}
+}
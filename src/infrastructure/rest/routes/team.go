@@ -0,0 +1,21 @@
+package routes
+
+import (
+	teamController "caregiver/src/infrastructure/rest/controllers/team"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TeamRoutes(router *gin.RouterGroup, controller teamController.ITeamController) {
+	teamRouter := router.Group("/teams")
+	{
+		teamRouter.GET("/", controller.GetTeams)
+		teamRouter.POST("/", controller.CreateTeam)
+		teamRouter.GET("/:id", controller.GetTeamByID)
+		teamRouter.PUT("/:id", controller.UpdateTeam)
+		teamRouter.DELETE("/:id", controller.DeleteTeam)
+		teamRouter.POST("/:id/members", controller.AddMember)
+		teamRouter.DELETE("/:id/members/:userID", controller.RemoveMember)
+		teamRouter.GET("/:id/overview", controller.GetOverview)
+	}
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	timesheetController "caregiver/src/infrastructure/rest/controllers/timesheet"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimesheetRoutes registers the timesheet report and its payroll export.
+func TimesheetRoutes(router *gin.RouterGroup, controller timesheetController.ITimesheetController) {
+	router.GET("/reports/timesheets", controller.GetTimesheets)
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	trainingController "caregiver/src/infrastructure/rest/controllers/training"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrainingRoutes registers training courses, completions and the training
+// compliance listing.
+func TrainingRoutes(router *gin.RouterGroup, controller trainingController.ITrainingController) {
+	trainingRouter := router.Group("/trainings")
+	{
+		trainingRouter.POST("/courses", controller.CreateCourse)
+		trainingRouter.GET("/courses", controller.GetCourses)
+		trainingRouter.GET("/courses/:id", controller.GetCourseByID)
+		trainingRouter.PUT("/courses/:id", controller.UpdateCourse)
+		trainingRouter.DELETE("/courses/:id", controller.DeleteCourse)
+		trainingRouter.POST("/completions", controller.RecordCompletion)
+		trainingRouter.GET("/completions", controller.GetCompletions)
+		trainingRouter.DELETE("/completions/:id", controller.DeleteCompletion)
+		trainingRouter.GET("/users/:id/requirements", controller.GetUserRequirements)
+	}
+	router.GET("/compliance/trainings", controller.GetOutOfCompliance)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	trashController "caregiver/src/infrastructure/rest/controllers/trash"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrashRoutes registers the admin recycle bin of soft-deleted users and
+// schedules.
+func TrashRoutes(router *gin.RouterGroup, controller trashController.ITrashController) {
+	trashRouter := router.Group("/admin/trash")
+	{
+		trashRouter.GET("/", controller.ListTrash)
+		trashRouter.POST("/:type/:id/restore", controller.RestoreItem)
+	}
+}
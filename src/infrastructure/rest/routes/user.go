@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"time"
+
 	"caregiver/src/infrastructure/rest/controllers/user"
 	"caregiver/src/infrastructure/rest/middlewares"
 
@@ -16,7 +18,9 @@ func UserRoutes(router *gin.RouterGroup, controller user.IUserController) {
 		u.GET("/:id", controller.GetUsersByID)
 		u.PUT("/:id", controller.UpdateUser)
 		u.DELETE("/:id", controller.DeleteUser)
+		u.POST("/:id/restore", controller.RestoreUser)
 		u.GET("/search", controller.SearchPaginated)
-		u.GET("/search-property", controller.SearchByProperty)
+		u.GET("/search-property", middlewares.RateLimit(60, time.Minute), controller.SearchByProperty)
+		u.GET("/:id/versions", controller.GetUserVersions)
 	}
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	vitalsController "caregiver/src/infrastructure/rest/controllers/vitals"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VitalsRoutes registers client vital ranges, the vitals recorded during
+// visits and their trends.
+func VitalsRoutes(router *gin.RouterGroup, controller vitalsController.IVitalsController) {
+	clientRouter := router.Group("/clients")
+	{
+		clientRouter.DELETE("/vital-ranges/:id", controller.DeleteRange)
+		clientRouter.DELETE("/vitals/:id", controller.DeleteReading)
+		clientRouter.PUT("/:id/vital-ranges", controller.SetRange)
+		clientRouter.GET("/:id/vital-ranges", controller.GetRanges)
+		clientRouter.GET("/:id/vitals", controller.GetClientReadings)
+		clientRouter.GET("/:id/vitals/trends", controller.GetTrends)
+	}
+	router.POST("/schedules/:id/vitals", controller.RecordVitals)
+	router.GET("/schedules/:id/vitals", controller.GetVisitReadings)
+}
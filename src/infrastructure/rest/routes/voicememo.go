@@ -0,0 +1,13 @@
+package routes
+
+import (
+	voiceMemoController "caregiver/src/infrastructure/rest/controllers/voicememo"
+
+	"github.com/gin-gonic/gin"
+)
+
+func VoiceMemoRoutes(router *gin.RouterGroup, controller voiceMemoController.IVoiceMemoController) {
+	router.GET("/schedules/:id/voice-memos", controller.GetVoiceMemos)
+	router.POST("/schedules/:id/voice-memos", controller.UploadVoiceMemo)
+	router.GET("/voice-memos/search", controller.SearchVoiceMemos)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	waitlistController "caregiver/src/infrastructure/rest/controllers/waitlist"
+
+	"github.com/gin-gonic/gin"
+)
+
+func WaitlistRoutes(router *gin.RouterGroup, controller waitlistController.IWaitlistController) {
+	waitlistRouter := router.Group("/waitlist")
+	{
+		waitlistRouter.GET("/", controller.GetWaitlist)
+		waitlistRouter.POST("/", controller.RequestVisit)
+		waitlistRouter.POST("/rematch", controller.RematchWaitlist)
+		waitlistRouter.GET("/:id", controller.GetWaitlistEntryByID)
+		waitlistRouter.PUT("/:id/status", controller.UpdateWaitlistEntryStatus)
+	}
+}
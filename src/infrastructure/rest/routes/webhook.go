@@ -0,0 +1,25 @@
+package routes
+
+import (
+	webhookController "caregiver/src/infrastructure/rest/controllers/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookRoutes registers the admin endpoints managing third-party webhook
+// subscriptions and the log of their deliveries.
+func WebhookRoutes(router *gin.RouterGroup, controller webhookController.IWebhookController) {
+	webhookRouter := router.Group("/admin/webhooks")
+	{
+		webhookRouter.POST("", controller.CreateSubscription)
+		webhookRouter.GET("", controller.GetSubscriptions)
+		webhookRouter.GET("/deliveries", controller.GetDeliveries)
+		webhookRouter.GET("/deliveries/:id", controller.GetDelivery)
+		webhookRouter.POST("/deliveries/:id/redeliver", controller.Redeliver)
+		webhookRouter.GET("/:id", controller.GetSubscriptionByID)
+		webhookRouter.PUT("/:id", controller.UpdateSubscription)
+		webhookRouter.DELETE("/:id", controller.DeleteSubscription)
+		webhookRouter.POST("/:id/rotate-secret", controller.RotateSecret)
+		webhookRouter.POST("/:id/ping", controller.Ping)
+	}
+}
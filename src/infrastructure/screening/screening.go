@@ -0,0 +1,185 @@
+package screening
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	domainScreening "caregiver/src/domain/screening"
+)
+
+// ErrNotConfigured is returned when no screening provider is set up.
+var ErrNotConfigured = errors.New("screening provider not configured")
+
+// Candidate is the caregiver a background check is ordered for.
+type Candidate struct {
+	ID        string
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+// License identifies a professional license with its issuing state.
+type License struct {
+	Number    string
+	State     string
+	Type      string
+	FirstName string
+	LastName  string
+}
+
+// Result is the provider's view of a check. Status is one of the domain
+// statuses; ExpiresAt is when a passed result must be renewed.
+type Result struct {
+	ExternalID string
+	Status     string
+	Detail     string
+	ExpiresAt  *time.Time
+}
+
+// IProvider orders background checks and verifies licenses with a screening
+// vendor. Background checks usually complete later and are polled with
+// GetBackgroundCheck; license lookups answer straight away.
+type IProvider interface {
+	Name() string
+	OrderBackgroundCheck(ctx context.Context, candidate Candidate) (*Result, error)
+	GetBackgroundCheck(ctx context.Context, externalID string) (*Result, error)
+	VerifyLicense(ctx context.Context, license License) (*Result, error)
+}
+
+// NewProviderFromEnv returns an HTTP provider when SCREENING_URL is set,
+// otherwise a provider that always reports ErrNotConfigured.
+func NewProviderFromEnv() IProvider {
+	endpoint := os.Getenv("SCREENING_URL")
+	if endpoint == "" {
+		return disabledProvider{}
+	}
+	return &HTTPProvider{
+		Endpoint: strings.TrimRight(endpoint, "/"),
+		APIKey:   os.Getenv("SCREENING_API_KEY"),
+		Client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type disabledProvider struct{}
+
+func (disabledProvider) Name() string { return "" }
+
+func (disabledProvider) OrderBackgroundCheck(ctx context.Context, candidate Candidate) (*Result, error) {
+	return nil, ErrNotConfigured
+}
+
+func (disabledProvider) GetBackgroundCheck(ctx context.Context, externalID string) (*Result, error) {
+	return nil, ErrNotConfigured
+}
+
+func (disabledProvider) VerifyLicense(ctx context.Context, license License) (*Result, error) {
+	return nil, ErrNotConfigured
+}
+
+// HTTPProvider talks to a JSON screening API:
+//
+//	POST {Endpoint}/background-checks       orders a check
+//	GET  {Endpoint}/background-checks/{id}  reads its progress
+//	POST {Endpoint}/licenses/verify         looks up a license
+//
+// Replies carry {"id", "status", "detail", "expiresAt"}. "clear", "passed",
+// "verified" and "active" are passing statuses, "pending" and "in_progress"
+// are not yet final, and anything else is a failure.
+type HTTPProvider struct {
+	Endpoint string
+	APIKey   string
+	Client   *http.Client
+}
+
+type httpReply struct {
+	ID        string     `json:"id"`
+	Status    string     `json:"status"`
+	Detail    string     `json:"detail"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+func (p *HTTPProvider) Name() string { return "http" }
+
+func (p *HTTPProvider) OrderBackgroundCheck(ctx context.Context, candidate Candidate) (*Result, error) {
+	return p.do(ctx, http.MethodPost, "/background-checks", map[string]string{
+		"candidateId": candidate.ID,
+		"firstName":   candidate.FirstName,
+		"lastName":    candidate.LastName,
+		"email":       candidate.Email,
+	})
+}
+
+func (p *HTTPProvider) GetBackgroundCheck(ctx context.Context, externalID string) (*Result, error) {
+	return p.do(ctx, http.MethodGet, "/background-checks/"+url.PathEscape(externalID), nil)
+}
+
+func (p *HTTPProvider) VerifyLicense(ctx context.Context, license License) (*Result, error) {
+	return p.do(ctx, http.MethodPost, "/licenses/verify", map[string]string{
+		"number":    license.Number,
+		"state":     license.State,
+		"type":      license.Type,
+		"firstName": license.FirstName,
+		"lastName":  license.LastName,
+	})
+}
+
+func (p *HTTPProvider) do(ctx context.Context, method, path string, payload interface{}) (*Result, error) {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, p.Endpoint+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("screening provider returned status %d", resp.StatusCode)
+	}
+	var reply httpReply
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&reply); err != nil {
+		return nil, err
+	}
+	return &Result{
+		ExternalID: reply.ID,
+		Status:     normalizeStatus(reply.Status),
+		Detail:     reply.Detail,
+		ExpiresAt:  reply.ExpiresAt,
+	}, nil
+}
+
+func normalizeStatus(status string) string {
+	switch strings.ToLower(status) {
+	case "clear", "passed", "verified", "active":
+		return domainScreening.StatusPassed
+	case "pending", "in_progress":
+		return domainScreening.StatusPending
+	default:
+		return domainScreening.StatusFailed
+	}
+}
@@ -0,0 +1,277 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotConfigured is returned when no search cluster is set up.
+var ErrNotConfigured = errors.New("search is not configured")
+
+// Document types held in the index.
+const (
+	TypeClient    = "client"
+	TypeCaregiver = "caregiver"
+	TypeSchedule  = "schedule"
+	TypeVisitNote = "visit_note"
+)
+
+// Document is a searchable record. Title is weighted above Body when
+// ranking; Date is optional.
+type Document struct {
+	Type  string     `json:"type"`
+	ID    string     `json:"id"`
+	Title string     `json:"title"`
+	Body  string     `json:"body"`
+	Date  *time.Time `json:"date,omitempty"`
+}
+
+// Query searches Text across the given document types, or all of them.
+type Query struct {
+	Text  string
+	Types []string
+	Limit int
+}
+
+// Hit is a matching document with its relevance score and the matched
+// fragments, where matches are wrapped in <em> tags.
+type Hit struct {
+	Type       string
+	ID         string
+	Title      string
+	Date       *time.Time
+	Score      float64
+	Highlights []string
+}
+
+type Result struct {
+	Total int64
+	Hits  []Hit
+}
+
+// IIndex stores documents in a search engine and ranks them against a query.
+type IIndex interface {
+	Index(ctx context.Context, doc Document) error
+	Delete(ctx context.Context, docType, id string) error
+	Search(ctx context.Context, query Query) (*Result, error)
+}
+
+// NewIndexFromEnv returns an OpenSearch index when SEARCH_URL is set,
+// otherwise an index that always reports ErrNotConfigured. Elasticsearch
+// clusters are supported through the same API.
+func NewIndexFromEnv() IIndex {
+	endpoint := os.Getenv("SEARCH_URL")
+	if endpoint == "" {
+		return disabledIndex{}
+	}
+	name := os.Getenv("SEARCH_INDEX")
+	if name == "" {
+		name = "caregiver"
+	}
+	return &OpenSearchIndex{
+		Endpoint: strings.TrimRight(endpoint, "/"),
+		Name:     name,
+		Username: os.Getenv("SEARCH_USERNAME"),
+		Password: os.Getenv("SEARCH_PASSWORD"),
+		APIKey:   os.Getenv("SEARCH_API_KEY"),
+		Client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type disabledIndex struct{}
+
+func (disabledIndex) Index(ctx context.Context, doc Document) error {
+	return ErrNotConfigured
+}
+
+func (disabledIndex) Delete(ctx context.Context, docType, id string) error {
+	return ErrNotConfigured
+}
+
+func (disabledIndex) Search(ctx context.Context, query Query) (*Result, error) {
+	return nil, ErrNotConfigured
+}
+
+// OpenSearchIndex keeps every document type in one index, creating it with
+// its mapping on first write.
+type OpenSearchIndex struct {
+	Endpoint string
+	Name     string
+	Username string
+	Password string
+	APIKey   string
+	Client   *http.Client
+
+	mu    sync.Mutex
+	ready bool
+}
+
+// mapping makes type an exact-match keyword so searches can filter on it.
+var mapping = map[string]any{
+	"mappings": map[string]any{
+		"properties": map[string]any{
+			"type":  map[string]any{"type": "keyword"},
+			"id":    map[string]any{"type": "keyword"},
+			"title": map[string]any{"type": "text"},
+			"body":  map[string]any{"type": "text"},
+			"date":  map[string]any{"type": "date"},
+		},
+	},
+}
+
+func (o *OpenSearchIndex) Index(ctx context.Context, doc Document) error {
+	if err := o.ensureIndex(ctx); err != nil {
+		return err
+	}
+	_, err := o.do(ctx, http.MethodPut, "/_doc/"+documentID(doc.Type, doc.ID), doc)
+	return err
+}
+
+func (o *OpenSearchIndex) Delete(ctx context.Context, docType, id string) error {
+	_, err := o.do(ctx, http.MethodDelete, "/_doc/"+documentID(docType, id), nil)
+	var status *statusError
+	if errors.As(err, &status) && status.code == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func (o *OpenSearchIndex) Search(ctx context.Context, query Query) (*Result, error) {
+	boolQuery := map[string]any{
+		"must": map[string]any{
+			"multi_match": map[string]any{
+				"query":     query.Text,
+				"fields":    []string{"title^3", "body"},
+				"fuzziness": "AUTO",
+			},
+		},
+	}
+	if len(query.Types) > 0 {
+		boolQuery["filter"] = []any{map[string]any{"terms": map[string]any{"type": query.Types}}}
+	}
+	body := map[string]any{
+		"size":  query.Limit,
+		"query": map[string]any{"bool": boolQuery},
+		"highlight": map[string]any{
+			"fields": map[string]any{"title": map[string]any{}, "body": map[string]any{}},
+		},
+	}
+
+	response, err := o.do(ctx, http.MethodPost, "/_search", body)
+	var status *statusError
+	if errors.As(err, &status) && status.code == http.StatusNotFound {
+		// Nothing has been indexed yet.
+		return &Result{Hits: []Hit{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var decoded struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Score     float64             `json:"_score"`
+				Source    Document            `json:"_source"`
+				Highlight map[string][]string `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(response, &decoded); err != nil {
+		return nil, fmt.Errorf("decoding search response: %w", err)
+	}
+	result := &Result{Total: decoded.Hits.Total.Value, Hits: make([]Hit, len(decoded.Hits.Hits))}
+	for i, hit := range decoded.Hits.Hits {
+		highlights := []string{}
+		highlights = append(highlights, hit.Highlight["title"]...)
+		highlights = append(highlights, hit.Highlight["body"]...)
+		result.Hits[i] = Hit{
+			Type:       hit.Source.Type,
+			ID:         hit.Source.ID,
+			Title:      hit.Source.Title,
+			Date:       hit.Source.Date,
+			Score:      hit.Score,
+			Highlights: highlights,
+		}
+	}
+	return result, nil
+}
+
+func (o *OpenSearchIndex) ensureIndex(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.ready {
+		return nil
+	}
+	_, err := o.do(ctx, http.MethodHead, "", nil)
+	var status *statusError
+	if errors.As(err, &status) && status.code == http.StatusNotFound {
+		_, err = o.do(ctx, http.MethodPut, "", mapping)
+	}
+	if err != nil {
+		return err
+	}
+	o.ready = true
+	return nil
+}
+
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("search cluster returned %d: %s", e.code, e.body)
+}
+
+func (o *OpenSearchIndex) do(ctx context.Context, method, path string, payload any) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, o.Endpoint+"/"+url.PathEscape(o.Name)+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if o.APIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+o.APIKey)
+	} else if o.Username != "" {
+		req.SetBasicAuth(o.Username, o.Password)
+	}
+
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, &statusError{code: resp.StatusCode, body: string(content)}
+	}
+	return content, nil
+}
+
+func documentID(docType, id string) string {
+	return url.PathEscape(docType + ":" + id)
+}
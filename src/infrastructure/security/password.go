@@ -0,0 +1,58 @@
+package security
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinPasswordLength is the shortest password accepted.
+const MinPasswordLength = 8
+
+// ErrPasswordTooShort is returned for passwords under MinPasswordLength.
+var ErrPasswordTooShort = errors.New("password must be at least 8 characters")
+
+// ErrPasswordTooLong is returned for passwords bcrypt cannot hash in full.
+var ErrPasswordTooLong = errors.New("password must be at most 72 bytes")
+
+type IPasswordService interface {
+	HashPassword(password string) (string, error)
+	// CheckPassword reports whether password matches hash. An empty hash
+	// matches nothing.
+	CheckPassword(hash, password string) bool
+}
+
+// PasswordService hashes passwords with bcrypt.
+type PasswordService struct {
+	cost int
+}
+
+func NewPasswordService() IPasswordService {
+	return &PasswordService{cost: bcrypt.DefaultCost}
+}
+
+// NewPasswordServiceWithCost is for tests, where the default cost is slow.
+func NewPasswordServiceWithCost(cost int) IPasswordService {
+	return &PasswordService{cost: cost}
+}
+
+func (s *PasswordService) HashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+	if len(password) > 72 {
+		return "", ErrPasswordTooLong
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (s *PasswordService) CheckPassword(hash, password string) bool {
+	if hash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,58 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"os"
+)
+
+// SignatureAlgorithm names the signatures ISigner makes.
+const SignatureAlgorithm = "Ed25519"
+
+// ISigner signs exports so whoever receives one can check it came from us
+// and was not altered, using the public key alone.
+type ISigner interface {
+	Sign(data []byte) []byte
+	PublicKey() []byte
+}
+
+type Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewSigner signs with the Ed25519 key derived from a 32 byte seed.
+func NewSigner(seed []byte) (ISigner, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.New("signing key must be a 32 byte seed")
+	}
+	return &Signer{key: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// NewSignerFromEnv reads the base64 seed in EXPORT_SIGNING_KEY. Without one
+// it signs with a key made up on start, whose signatures can only be checked
+// against the public key shipped in the same export.
+func NewSignerFromEnv() (ISigner, error) {
+	encoded := os.Getenv("EXPORT_SIGNING_KEY")
+	if encoded == "" {
+		seed := make([]byte, ed25519.SeedSize)
+		if _, err := rand.Read(seed); err != nil {
+			return nil, err
+		}
+		return NewSigner(seed)
+	}
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("EXPORT_SIGNING_KEY must be base64")
+	}
+	return NewSigner(seed)
+}
+
+func (s *Signer) Sign(data []byte) []byte {
+	return ed25519.Sign(s.key, data)
+}
+
+func (s *Signer) PublicKey() []byte {
+	return s.key.Public().(ed25519.PublicKey)
+}
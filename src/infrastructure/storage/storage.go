@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultUploadDir is where local storage keeps files when UPLOAD_DIR is not
+// set.
+const defaultUploadDir = "uploads"
+
+// IFileStorage stores uploaded binary content under opaque keys.
+type IFileStorage interface {
+	Save(key string, content io.Reader) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// NewStorageFromEnv returns the S3 driver when STORAGE_DRIVER is "s3",
+// configured by S3_BUCKET, S3_REGION (default us-east-1), S3_ENDPOINT for
+// S3-compatible services, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY.
+// Otherwise files are kept on local disk under UPLOAD_DIR.
+func NewStorageFromEnv() (IFileStorage, error) {
+	if strings.EqualFold(os.Getenv("STORAGE_DRIVER"), "s3") {
+		bucket := os.Getenv("S3_BUCKET")
+		accessKeyID := os.Getenv("S3_ACCESS_KEY_ID")
+		secretAccessKey := os.Getenv("S3_SECRET_ACCESS_KEY")
+		if bucket == "" || accessKeyID == "" || secretAccessKey == "" {
+			return nil, errors.New("S3 storage needs S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY")
+		}
+		region := os.Getenv("S3_REGION")
+		if region == "" {
+			region = "us-east-1"
+		}
+		return NewS3Storage(os.Getenv("S3_ENDPOINT"), bucket, region, accessKeyID, secretAccessKey), nil
+	}
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = defaultUploadDir
+	}
+	return NewLocalStorage(uploadDir), nil
+}
+
+type LocalStorage struct {
+	BaseDir string
+}
+
+func NewLocalStorage(baseDir string) IFileStorage {
+	return &LocalStorage{BaseDir: baseDir}
+}
+
+func (s *LocalStorage) Save(key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(file, content)
+	return err
+}
+
+func (s *LocalStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (s *LocalStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path resolves a key inside BaseDir, refusing keys that would escape it.
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", errors.New("invalid storage key")
+	}
+	return filepath.Join(s.BaseDir, cleaned), nil
+}
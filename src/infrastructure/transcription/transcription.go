@@ -0,0 +1,103 @@
+package transcription
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"time"
+)
+
+// ErrNotConfigured is returned when no speech-to-text provider is set up.
+var ErrNotConfigured = errors.New("transcription provider not configured")
+
+// ITranscriber converts recorded speech into text.
+type ITranscriber interface {
+	Transcribe(ctx context.Context, audio io.Reader, contentType string) (string, error)
+}
+
+// NewTranscriberFromEnv returns an HTTP transcriber when TRANSCRIPTION_URL is
+// set, otherwise a transcriber that always reports ErrNotConfigured.
+func NewTranscriberFromEnv() ITranscriber {
+	endpoint := os.Getenv("TRANSCRIPTION_URL")
+	if endpoint == "" {
+		return disabledTranscriber{}
+	}
+	return &HTTPTranscriber{
+		Endpoint: endpoint,
+		APIKey:   os.Getenv("TRANSCRIPTION_API_KEY"),
+		Model:    os.Getenv("TRANSCRIPTION_MODEL"),
+		Client:   &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+type disabledTranscriber struct{}
+
+func (disabledTranscriber) Transcribe(ctx context.Context, audio io.Reader, contentType string) (string, error) {
+	return "", ErrNotConfigured
+}
+
+// HTTPTranscriber posts audio as multipart form data to an OpenAI-compatible
+// /audio/transcriptions endpoint and reads the "text" field of the reply.
+type HTTPTranscriber struct {
+	Endpoint string
+	APIKey   string
+	Model    string
+	Client   *http.Client
+}
+
+func (t *HTTPTranscriber) Transcribe(ctx context.Context, audio io.Reader, contentType string) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="memo"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, audio); err != nil {
+		return "", err
+	}
+	if t.Model != "" {
+		if err := writer.WriteField("model", t.Model); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.Endpoint, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if t.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.APIKey)
+	}
+
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("transcription provider returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.Text, nil
+}
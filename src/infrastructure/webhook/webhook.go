@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Request is one signed POST to a subscriber.
+type Request struct {
+	URL       string
+	Secret    string
+	ID        string
+	EventType string
+	Body      []byte
+}
+
+// ISender posts webhook payloads. It returns the response status, if one was
+// received, and an error unless the subscriber answered with a 2xx.
+type ISender interface {
+	Send(ctx context.Context, request Request, now time.Time) (int, error)
+}
+
+// HTTPSender signs each payload with HMAC-SHA256 over "<timestamp>.<body>"
+// and sends it as "sha256=<hex>" in X-Webhook-Signature, so receivers can
+// verify it and reject replays by the timestamp.
+type HTTPSender struct {
+	Client *http.Client
+}
+
+func NewHTTPSender() *HTTPSender {
+	return &HTTPSender{Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, request Request, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CareService-Webhooks/1.0")
+	req.Header.Set(HeaderID, request.ID)
+	req.Header.Set(HeaderEvent, request.EventType)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(request.Secret, timestamp, request.Body))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded %d: %s", resp.StatusCode, bytes.TrimSpace(excerpt))
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the X-Webhook-Signature value for a payload.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
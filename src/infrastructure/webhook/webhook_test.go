@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPSenderSignsPayload(t *testing.T) {
+	now := time.Unix(1767261600, 0)
+	body := []byte(`{"type":"visit.checked_in"}`)
+	var got *http.Request
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := &HTTPSender{Client: server.Client()}
+	status, err := sender.Send(context.Background(), Request{URL: server.URL, Secret: "whsec_test", ID: "d1", EventType: "visit.checked_in", Body: body}, now)
+	if err != nil || status != http.StatusNoContent {
+		t.Fatalf("expected a 204, got %d, %v", status, err)
+	}
+	if got.Header.Get(HeaderTimestamp) != "1767261600" || got.Header.Get(HeaderID) != "d1" || got.Header.Get(HeaderEvent) != "visit.checked_in" {
+		t.Errorf("unexpected headers %v", got.Header)
+	}
+	if signature := got.Header.Get(HeaderSignature); signature != Sign("whsec_test", "1767261600", gotBody) {
+		t.Errorf("signature %q does not match the body", signature)
+	}
+	if Sign("other", "1767261600", body) == Sign("whsec_test", "1767261600", body) {
+		t.Error("expected the signature to depend on the secret")
+	}
+}
+
+func TestHTTPSenderReportsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sender := &HTTPSender{Client: server.Client()}
+	status, err := sender.Send(context.Background(), Request{URL: server.URL, Secret: "s", Body: []byte(`{}`)}, time.Now())
+	if status != http.StatusServiceUnavailable || err == nil {
+		t.Errorf("expected a 503 error, got %d, %v", status, err)
+	}
+}
@@ -0,0 +1,115 @@
+package x12
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Claim status codes (CLP02) that need special handling; the others mean the
+// claim was processed.
+const (
+	ClaimStatusDenied   = "4"
+	ClaimStatusReversal = "22"
+)
+
+// Remittance is the content of an 835 payment advice.
+type Remittance struct {
+	PayerName   string
+	PayerID     string
+	TraceNumber string
+	PaymentDate *time.Time
+	TotalPaid   float64
+	Claims      []ClaimPayment
+}
+
+// ClaimPayment is the payer's decision on one claim.
+type ClaimPayment struct {
+	ControlNumber string
+	StatusCode    string
+	Charge        float64
+	Paid          float64
+	Adjustments   []Adjustment
+}
+
+// Adjustment explains why part of a charge was not paid, as a claim
+// adjustment group code (CO, PR, OA, ...) and reason code.
+type Adjustment struct {
+	Group  string
+	Reason string
+	Amount float64
+}
+
+func (a Adjustment) String() string {
+	return a.Group + "-" + a.Reason
+}
+
+// Parse835 reads an 835 interchange. Delimiters are taken from the ISA
+// header, so files from any payer can be read.
+func Parse835(content string) (*Remittance, error) {
+	content = strings.TrimLeft(content, " \t\r\n\ufeff")
+	if !strings.HasPrefix(content, "ISA") || len(content) < 106 {
+		return nil, errors.New("content is not an X12 interchange")
+	}
+	element := string(content[3])
+	terminator := string(content[105])
+
+	remittance := &Remittance{}
+	var claim *ClaimPayment
+	found := false
+	for _, raw := range strings.Split(content, terminator) {
+		segment := strings.TrimSpace(raw)
+		if segment == "" {
+			continue
+		}
+		e := strings.Split(segment, element)
+		get := func(i int) string {
+			if i < len(e) {
+				return strings.TrimSpace(e[i])
+			}
+			return ""
+		}
+		switch e[0] {
+		case "ST":
+			if get(1) != "835" {
+				return nil, fmt.Errorf("transaction set %s is not an 835", get(1))
+			}
+			found = true
+		case "BPR":
+			remittance.TotalPaid = parseAmount(get(2))
+			if d, err := time.Parse("20060102", get(16)); err == nil {
+				remittance.PaymentDate = &d
+			}
+		case "TRN":
+			remittance.TraceNumber = get(2)
+		case "N1":
+			if get(1) == "PR" {
+				remittance.PayerName = get(2)
+				remittance.PayerID = get(4)
+			}
+		case "CLP":
+			remittance.Claims = append(remittance.Claims, ClaimPayment{
+				ControlNumber: get(1),
+				StatusCode:    get(2),
+				Charge:        parseAmount(get(3)),
+				Paid:          parseAmount(get(4)),
+			})
+			claim = &remittance.Claims[len(remittance.Claims)-1]
+		case "CAS":
+			if claim == nil {
+				continue
+			}
+			for i := 2; i+1 < len(e); i += 3 {
+				if get(i) == "" {
+					continue
+				}
+				claim.Adjustments = append(claim.Adjustments, Adjustment{Group: get(1), Reason: get(i), Amount: parseAmount(get(i + 1))})
+			}
+		}
+	}
+	if !found {
+		return nil, errors.New("no 835 transaction found")
+	}
+	return remittance, nil
+}
@@ -0,0 +1,133 @@
+package x12
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PlaceOfServiceHome is the CMS place of service code for the patient's home.
+const PlaceOfServiceHome = "12"
+
+type Address struct {
+	Street string
+	City   string
+	State  string
+	Zip    string
+}
+
+// BillingProvider is the agency submitting and billing the claims.
+type BillingProvider struct {
+	Name         string
+	NPI          string
+	TaxID        string
+	Address      Address
+	SubmitterID  string
+	ContactName  string
+	ContactPhone string
+}
+
+// Subscriber is the client, who is both insured and patient.
+type Subscriber struct {
+	FirstName string
+	LastName  string
+	MemberID  string
+	BirthDate time.Time
+	Gender    string
+	Address   Address
+}
+
+// ProfessionalClaim is a single-line claim for one visit.
+type ProfessionalClaim struct {
+	ControlNumber string
+	Subscriber    Subscriber
+	DiagnosisCode string
+	ProcedureCode string
+	Modifier      string
+	Units         int
+	Charge        float64
+	ServiceDate   time.Time
+}
+
+// ClaimFile is one interchange of claims for a single payer.
+type ClaimFile struct {
+	ControlNumber   int
+	CreatedAt       time.Time
+	Provider        BillingProvider
+	PayerName       string
+	PayerCode       string
+	FilingIndicator string
+	Claims          []ProfessionalClaim
+}
+
+// Build837P renders the file as an 837P (005010X222A1) interchange with one
+// billing provider and a subscriber loop per claim.
+func Build837P(file ClaimFile) string {
+	created := file.CreatedAt.UTC()
+	control := fmt.Sprintf("%09d", file.ControlNumber)
+	sender := clean(file.Provider.SubmitterID)
+	receiver := clean(file.PayerCode)
+
+	envelope := &segmentWriter{}
+	envelope.write("ISA", "00", strings.Repeat(" ", 10), "00", strings.Repeat(" ", 10),
+		"ZZ", fmt.Sprintf("%-15.15s", sender), "ZZ", fmt.Sprintf("%-15.15s", receiver),
+		created.Format("060102"), created.Format("1504"), repetitionSeparator, "00501", control, "0", "P", componentSeparator)
+	envelope.write("GS", "HC", sender, receiver, created.Format("20060102"), created.Format("1504"),
+		strconv.Itoa(file.ControlNumber), "X", "005010X222A1")
+
+	tx := &segmentWriter{}
+	tx.write("ST", "837", "0001", "005010X222A1")
+	tx.write("BHT", "0019", "00", control, created.Format("20060102"), created.Format("1504"), "CH")
+	tx.write("NM1", "41", "2", clean(file.Provider.Name), "", "", "", "", "46", sender)
+	tx.write("PER", "IC", clean(file.Provider.ContactName), "TE", clean(file.Provider.ContactPhone))
+	tx.write("NM1", "40", "2", clean(file.PayerName), "", "", "", "", "46", receiver)
+	tx.write("HL", "1", "", "20", "1")
+	tx.write("NM1", "85", "2", clean(file.Provider.Name), "", "", "", "", "XX", clean(file.Provider.NPI))
+	writeAddress(tx, file.Provider.Address)
+	tx.write("REF", "EI", clean(file.Provider.TaxID))
+
+	filing := file.FilingIndicator
+	if filing == "" {
+		filing = "MC"
+	}
+	for i, claim := range file.Claims {
+		s := claim.Subscriber
+		tx.write("HL", strconv.Itoa(i+2), "1", "22", "0")
+		tx.write("SBR", "P", "18", "", "", "", "", "", "", clean(filing))
+		tx.write("NM1", "IL", "1", clean(s.LastName), clean(s.FirstName), "", "", "", "MI", clean(s.MemberID))
+		writeAddress(tx, s.Address)
+		tx.write("DMG", "D8", s.BirthDate.Format("20060102"), genderCode(s.Gender))
+		tx.write("NM1", "PR", "2", clean(file.PayerName), "", "", "", "", "PI", receiver)
+		tx.write("CLM", clean(claim.ControlNumber), amount(claim.Charge), "", "",
+			PlaceOfServiceHome+componentSeparator+"B"+componentSeparator+"1", "Y", "A", "Y", "Y")
+		tx.write("HI", "ABK"+componentSeparator+strings.ReplaceAll(clean(claim.DiagnosisCode), ".", ""))
+		tx.write("LX", "1")
+		procedure := "HC" + componentSeparator + clean(claim.ProcedureCode)
+		if claim.Modifier != "" {
+			procedure += componentSeparator + clean(claim.Modifier)
+		}
+		tx.write("SV1", procedure, amount(claim.Charge), "UN", strconv.Itoa(claim.Units), "", "", "1")
+		tx.write("DTP", "472", "D8", claim.ServiceDate.Format("20060102"))
+	}
+	tx.write("SE", strconv.Itoa(tx.count+1), "0001")
+
+	trailer := &segmentWriter{}
+	trailer.write("GE", "1", strconv.Itoa(file.ControlNumber))
+	trailer.write("IEA", "1", control)
+
+	return envelope.b.String() + tx.b.String() + trailer.b.String()
+}
+
+func writeAddress(w *segmentWriter, a Address) {
+	w.write("N3", clean(a.Street))
+	w.write("N4", clean(a.City), clean(a.State), clean(a.Zip))
+}
+
+func genderCode(gender string) string {
+	switch strings.ToUpper(gender) {
+	case "M", "F":
+		return strings.ToUpper(gender)
+	}
+	return "U"
+}
@@ -0,0 +1,55 @@
+// Package x12 writes and reads the ASC X12 5010 healthcare transactions used
+// for billing: 837P professional claims and 835 remittance advice.
+package x12
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	elementSeparator    = "*"
+	componentSeparator  = ":"
+	repetitionSeparator = "^"
+	segmentTerminator   = "~"
+)
+
+// clean strips the delimiter characters from a value so it cannot break the
+// segment structure.
+func clean(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '*', ':', '^', '~', '\n', '\r':
+			return ' '
+		}
+		return r
+	}, strings.TrimSpace(value))
+}
+
+// amount formats a monetary value without superfluous zeros, as X12 "R"
+// elements expect.
+func amount(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func parseAmount(s string) float64 {
+	v, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return v
+}
+
+type segmentWriter struct {
+	b     strings.Builder
+	count int
+}
+
+func (w *segmentWriter) write(id string, elements ...string) {
+	w.b.WriteString(id)
+	for _, e := range elements {
+		w.b.WriteString(elementSeparator)
+		w.b.WriteString(e)
+	}
+	w.b.WriteString(segmentTerminator)
+	w.count++
+}
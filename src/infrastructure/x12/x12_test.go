@@ -0,0 +1,88 @@
+package x12
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuild837P(t *testing.T) {
+	file := ClaimFile{
+		ControlNumber: 42,
+		CreatedAt:     time.Date(2025, 7, 20, 14, 5, 0, 0, time.UTC),
+		Provider: BillingProvider{
+			Name:        "Sunrise Home Care",
+			NPI:         "1234567893",
+			TaxID:       "123456789",
+			Address:     Address{Street: "1 Main St", City: "Austin", State: "TX", Zip: "78701"},
+			SubmitterID: "SUNRISE",
+		},
+		PayerName: "Texas Medicaid",
+		PayerCode: "TXMCD",
+		Claims: []ProfessionalClaim{{
+			ControlNumber: "ABC123",
+			Subscriber:    Subscriber{FirstName: "Ana", LastName: "Ruiz*Lopez", MemberID: "M1", BirthDate: time.Date(1940, 1, 2, 0, 0, 0, 0, time.UTC), Gender: "f"},
+			DiagnosisCode: "Z74.1",
+			ProcedureCode: "T1019",
+			Modifier:      "U1",
+			Units:         8,
+			Charge:        40.5,
+			ServiceDate:   time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+	out := Build837P(file)
+	segments := strings.Split(strings.TrimSuffix(out, segmentTerminator), segmentTerminator)
+
+	if len(segments[0]) != 105 {
+		t.Errorf("expected a fixed-width ISA segment, got %d characters", len(segments[0]))
+	}
+	for _, want := range []string{
+		"ST*837*0001*005010X222A1",
+		"NM1*IL*1*Ruiz Lopez*Ana****MI*M1",
+		"DMG*D8*19400102*F",
+		"CLM*ABC123*40.5***12:B:1*Y*A*Y*Y",
+		"HI*ABK:Z741",
+		"SV1*HC:T1019:U1*40.5*UN*8***1",
+		"SE*23*0001",
+		"IEA*1*000000042",
+	} {
+		if !strings.Contains(out, want+segmentTerminator) {
+			t.Errorf("expected segment %q in output", want)
+		}
+	}
+}
+
+func TestParse835(t *testing.T) {
+	content := "ISA*00*          *00*          *ZZ*TXMCD          *ZZ*SUNRISE        *250801*1200*^*00501*000000007*0*P*:~\n" +
+		"GS*HP*TXMCD*SUNRISE*20250801*1200*7*X*005010X221A1~\n" +
+		"ST*835*0001~\n" +
+		"BPR*I*40.5*C*ACH*CCP*01*999999999*DA*123456*1512345678**01*999999999*DA*654321*20250801~\n" +
+		"TRN*1*EFT0001*1512345678~\n" +
+		"N1*PR*TEXAS MEDICAID*XV*TXMCD~\n" +
+		"CLP*ABC123*1*40.5*40.5**MC*PAYER1~\n" +
+		"CLP*DEF456*4*20*0**MC*PAYER2~\n" +
+		"CAS*CO*16*15**197*5~\n" +
+		"SE*8*0001~\nGE*1*7~\nIEA*1*000000007~\n"
+
+	remittance, err := Parse835(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remittance.TotalPaid != 40.5 || remittance.TraceNumber != "EFT0001" || remittance.PayerID != "TXMCD" {
+		t.Errorf("unexpected header %+v", remittance)
+	}
+	if remittance.PaymentDate == nil || !remittance.PaymentDate.Equal(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected payment date %v", remittance.PaymentDate)
+	}
+	if len(remittance.Claims) != 2 {
+		t.Fatalf("expected two claims, got %d", len(remittance.Claims))
+	}
+	denied := remittance.Claims[1]
+	if denied.StatusCode != ClaimStatusDenied || len(denied.Adjustments) != 2 || denied.Adjustments[1].String() != "CO-197" {
+		t.Errorf("unexpected denied claim %+v", denied)
+	}
+
+	if _, err := Parse835("ISA" + strings.Repeat("*", 110)); err == nil {
+		t.Error("expected an error without an 835 transaction")
+	}
+}